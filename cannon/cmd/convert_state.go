@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/singlethreaded"
+	"github.com/ethereum-optimism/optimism/cannon/serialize"
+)
+
+var (
+	ConvertStateInputFlag = &cli.PathFlag{
+		Name:      "input",
+		Usage:     "path of input state. Either JSON or binary, optionally gzipped, or an incremental binary snapshot.",
+		TakesFile: true,
+		Required:  true,
+	}
+	ConvertStateOutputFlag = &cli.PathFlag{
+		Name:      "output",
+		Usage:     "path of output state. Written in binary format if the path ends with .bin or .bin.gz, as JSON otherwise.",
+		TakesFile: true,
+		Required:  true,
+	}
+)
+
+func ConvertState(ctx *cli.Context) error {
+	input := ctx.Path(ConvertStateInputFlag.Name)
+	output := ctx.Path(ConvertStateOutputFlag.Name)
+	if vmType, err := vmTypeFromString(ctx); err != nil {
+		return err
+	} else if vmType == cannonVMType {
+		return convertState[singlethreaded.State](input, output)
+	} else if vmType == mtVMType {
+		return convertState[multithreaded.State](input, output)
+	} else {
+		return fmt.Errorf("invalid VM type: %q", vmType)
+	}
+}
+
+func convertState[X any, T interface {
+	*X
+	serialize.Serializable
+}](input string, output string) error {
+	state, err := serialize.Load[X](input)
+	if err != nil {
+		return fmt.Errorf("invalid input state (%v): %w", input, err)
+	}
+	if err := serialize.Write[T](output, state, OutFilePerm); err != nil {
+		return fmt.Errorf("failed to write output state (%v): %w", output, err)
+	}
+	return nil
+}
+
+var ConvertStateCommand = &cli.Command{
+	Name:  "convert-state",
+	Usage: "Convert a Cannon state between the JSON and binary formats",
+	Description: "Convert a Cannon state between the JSON and binary formats, e.g. to migrate JSON snapshots to the smaller " +
+		"compressed binary format. Incremental snapshots are converted into full states.",
+	Action: ConvertState,
+	Flags: []cli.Flag{
+		VMTypeFlag,
+		ConvertStateInputFlag,
+		ConvertStateOutputFlag,
+	},
+}
@@ -0,0 +1,278 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+)
+
+var (
+	DiffTestStateFlag = &cli.PathFlag{
+		Name:      "state",
+		Usage:     "path of input JSON state to start the differential test from.",
+		TakesFile: true,
+		Required:  true,
+	}
+	DiffTestRPCFlag = &cli.StringFlag{
+		Name:     "rpc",
+		Usage:    "RPC endpoint of an L1 fork with the MIPS contract, to execute the on-chain steps against.",
+		Required: true,
+	}
+	DiffTestMIPSFlag = &cli.StringFlag{
+		Name:     "mips",
+		Usage:    "address of the MIPS contract to execute the on-chain steps on.",
+		Required: true,
+	}
+	DiffTestStepsFlag = &cli.Uint64Flag{
+		Name:  "steps",
+		Usage: "number of steps to compare, stopping early if the VM exits.",
+		Value: 1000,
+	}
+	DiffTestBatchSizeFlag = &cli.IntFlag{
+		Name:  "batch-size",
+		Usage: "number of on-chain steps to execute in a single batch of RPC calls.",
+		Value: 100,
+	}
+	DiffTestLocalContextFlag = &cli.StringFlag{
+		Name:  "local-context",
+		Usage: "local context of the dispute game the steps are played in, local pre-image keys are localized with it.",
+		Value: common.Hash{}.Hex(),
+	}
+	DiffTestSenderFlag = &cli.StringFlag{
+		Name:  "sender",
+		Usage: "address the step calls are made from, local pre-image keys are localized with it.",
+		Value: common.Address{}.Hex(),
+	}
+	DiffTestOutputFlag = &cli.PathFlag{
+		Name:      "output",
+		Usage:     "path to write the JSON report to. Stdout if left empty.",
+		TakesFile: true,
+		Value:     "-",
+	}
+)
+
+// DiffTestReport is the result of a differential test of the VM against the on-chain MIPS contract.
+type DiffTestReport struct {
+	// Start is the step of the input state.
+	Start uint64 `json:"start"`
+	// Steps is the number of steps that matched on-chain.
+	Steps uint64 `json:"steps"`
+	// Exited is true if the VM exited before all steps were compared.
+	Exited bool `json:"exited"`
+	// Divergence is the first step with a different post-state on-chain, nil if all steps matched.
+	Divergence *DiffTestDivergence `json:"divergence,omitempty"`
+}
+
+// DiffTestDivergence is the context of a step with a different post-state on-chain.
+type DiffTestDivergence struct {
+	Step uint64 `json:"step"`
+
+	Pre         common.Hash `json:"pre"`
+	Post        common.Hash `json:"post"`
+	OnchainPost common.Hash `json:"onchain-post,omitempty"`
+	// CallError is the error of the on-chain step call, if it reverted.
+	CallError string `json:"call-error,omitempty"`
+
+	// Insn is the instruction at the PC of the pre-state.
+	Insn uint32             `json:"insn"`
+	Heap uint32             `json:"heap"`
+	Cpu  mipsevm.CpuScalars `json:"cpu"`
+
+	Registers     [32]uint32         `json:"registers"`
+	PostCpu       mipsevm.CpuScalars `json:"post-cpu"`
+	PostRegisters [32]uint32         `json:"post-registers"`
+
+	// StateData and ProofData are the step witness, with the instruction and memory proofs of the step.
+	StateData    hexutil.Bytes `json:"state-data"`
+	ProofData    hexutil.Bytes `json:"proof-data"`
+	PostState    hexutil.Bytes `json:"post-state"`
+	OracleKey    hexutil.Bytes `json:"oracle-key,omitempty"`
+	OracleOffset uint32        `json:"oracle-offset,omitempty"`
+}
+
+// diffTestStep is a step executed by the local VM, to compare with the on-chain step.
+type diffTestStep struct {
+	witness    *mipsevm.StepWitness
+	divergence DiffTestDivergence
+}
+
+func DiffTest(ctx *cli.Context) error {
+	vmType, err := vmTypeFromString(ctx)
+	if err != nil {
+		return err
+	}
+	if !common.IsHexAddress(ctx.String(DiffTestMIPSFlag.Name)) {
+		return fmt.Errorf("invalid MIPS contract address: %q", ctx.String(DiffTestMIPSFlag.Name))
+	}
+	mips := common.HexToAddress(ctx.String(DiffTestMIPSFlag.Name))
+	if !common.IsHexAddress(ctx.String(DiffTestSenderFlag.Name)) {
+		return fmt.Errorf("invalid sender address: %q", ctx.String(DiffTestSenderFlag.Name))
+	}
+	sender := common.HexToAddress(ctx.String(DiffTestSenderFlag.Name))
+	localContextBytes, err := hexutil.Decode(ctx.String(DiffTestLocalContextFlag.Name))
+	if err != nil || len(localContextBytes) != common.HashLength {
+		return fmt.Errorf("invalid local context: %q", ctx.String(DiffTestLocalContextFlag.Name))
+	}
+	localContext := mipsevm.LocalContext(localContextBytes)
+	batchSize := max(ctx.Int(DiffTestBatchSizeFlag.Name), 1)
+
+	logger := Logger(os.Stderr, log.LevelInfo)
+	po, err := startStepPreimageServer(ctx, logger)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := po.Close(); err != nil {
+			logger.Error("failed to close pre-image server", "err", err)
+		}
+	}()
+
+	input := ctx.Path(DiffTestStateFlag.Name)
+	vm, err := loadStepVM(vmType, input, po, logger)
+	if err != nil {
+		return fmt.Errorf("invalid input state (%v): %w", input, err)
+	}
+	stepFn := vm.Step
+	if po.cmd != nil {
+		stepFn = Guard(po.cmd.ProcessState, stepFn)
+	}
+
+	rpcClient, err := rpc.DialContext(ctx.Context, ctx.String(DiffTestRPCFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to dial L1 RPC: %w", err)
+	}
+	defer rpcClient.Close()
+	oracle, err := mipsOracle(ctx.Context, rpcClient, mips)
+	if err != nil {
+		return err
+	}
+
+	state := vm.GetState()
+	report := &DiffTestReport{Start: state.GetStep()}
+	remaining := ctx.Uint64(DiffTestStepsFlag.Name)
+	for remaining > 0 && report.Divergence == nil {
+		if state.GetExited() {
+			report.Exited = true
+			break
+		}
+		batch, err := diffTestBatch(vm, stepFn, min(remaining, uint64(batchSize)))
+		if err != nil {
+			return err
+		}
+		matched, divergence, err := compareOnchainSteps(ctx.Context, rpcClient, mips, oracle, sender, localContext, batch)
+		if err != nil {
+			return err
+		}
+		report.Steps += matched
+		report.Divergence = divergence
+		remaining -= uint64(len(batch))
+		logger.Info("Compared on-chain steps", "step", state.GetStep(), "matched", report.Steps)
+	}
+	if err := jsonutil.WriteJSON(ctx.Path(DiffTestOutputFlag.Name), report, OutFilePerm); err != nil {
+		return fmt.Errorf("failed to write differential test report: %w", err)
+	}
+	if d := report.Divergence; d != nil {
+		return fmt.Errorf("step %d (PC: %08x, insn: %08x) diverged from the on-chain MIPS contract", d.Step, d.Cpu.PC, d.Insn)
+	}
+	return nil
+}
+
+// diffTestBatch executes up to n steps with the local VM, stopping early if the VM exits,
+// and captures the context of each step to report if it diverges on-chain.
+func diffTestBatch(vm mipsevm.FPVM, stepFn StepFn, n uint64) ([]diffTestStep, error) {
+	state := vm.GetState()
+	batch := make([]diffTestStep, 0, n)
+	for i := uint64(0); i < n && !state.GetExited(); i++ {
+		d := DiffTestDivergence{
+			Step:      state.GetStep(),
+			Insn:      state.GetMemory().GetMemory(state.GetPC()),
+			Heap:      state.GetHeap(),
+			Cpu:       state.GetCpu(),
+			Registers: *state.GetRegistersRef(),
+		}
+		witness, err := stepWithProof(stepFn)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate step witness of step %d (PC: %08x): %w", d.Step, d.Cpu.PC, err)
+		}
+		d.PostState, d.Post = state.EncodeWitness()
+		d.PostCpu = state.GetCpu()
+		d.PostRegisters = *state.GetRegistersRef()
+		d.Pre = witness.StateHash
+		d.StateData = witness.State
+		d.ProofData = witness.ProofData
+		if witness.HasPreimage() {
+			d.OracleKey = witness.PreimageKey[:]
+			d.OracleOffset = witness.PreimageOffset
+		}
+		batch = append(batch, diffTestStep{witness: witness, divergence: d})
+	}
+	return batch, nil
+}
+
+// compareOnchainSteps executes the steps on the MIPS contract in a single batch of calls, and returns the number
+// of steps that matched the local VM before the first divergence, if any.
+func compareOnchainSteps(ctx context.Context, client *rpc.Client, mips common.Address, oracle common.Address, sender common.Address, localContext mipsevm.LocalContext, batch []diffTestStep) (uint64, *DiffTestDivergence, error) {
+	elems := make([]rpc.BatchElem, len(batch))
+	results := make([]hexutil.Bytes, len(batch))
+	for i, step := range batch {
+		msg, overrides, err := stepCall(mips, oracle, sender, localContext, step.witness)
+		if err != nil {
+			return 0, nil, err
+		}
+		elems[i] = rpc.BatchElem{
+			Method: "eth_call",
+			Args:   []any{toCallArg(msg), "latest", overrides},
+			Result: &results[i],
+		}
+	}
+	if err := client.BatchCallContext(ctx, elems); err != nil {
+		return 0, nil, fmt.Errorf("failed to execute on-chain steps: %w", err)
+	}
+	for i, elem := range elems {
+		d := batch[i].divergence
+		if elem.Error != nil {
+			d.CallError = elem.Error.Error()
+			return uint64(i), &d, nil
+		}
+		post, err := stepPostState(results[i])
+		if err != nil {
+			d.CallError = err.Error()
+			return uint64(i), &d, nil
+		}
+		if post != d.Post {
+			d.OnchainPost = post
+			return uint64(i), &d, nil
+		}
+	}
+	return uint64(len(batch)), nil, nil
+}
+
+var DiffTestCommand = &cli.Command{
+	Name:  "diff-test",
+	Usage: "Compare the steps of the VM with the on-chain MIPS contract",
+	Description: "Execute steps from a Cannon JSON state with the VM, and execute each step on the MIPS contract of an L1 fork with eth_call, " +
+		"comparing the post-state of every step. The first step with a different post-state is reported with the registers " +
+		"and memory proofs of the step. Pre-images read by the steps are provided to the PreimageOracle with state overrides. " +
+		"A pre-image server, if required by the steps, can be passed after '--'",
+	Action: DiffTest,
+	Flags: []cli.Flag{
+		VMTypeFlag,
+		DiffTestStateFlag,
+		DiffTestRPCFlag,
+		DiffTestMIPSFlag,
+		DiffTestStepsFlag,
+		DiffTestBatchSizeFlag,
+		DiffTestLocalContextFlag,
+		DiffTestSenderFlag,
+		DiffTestOutputFlag,
+	},
+}
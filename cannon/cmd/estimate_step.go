@@ -0,0 +1,348 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/ethclient/gethclient"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/singlethreaded"
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+	"github.com/ethereum-optimism/optimism/packages/contracts-bedrock/snapshots"
+)
+
+var (
+	EstimateStepStateFlag = &cli.PathFlag{
+		Name:      "state",
+		Usage:     "path of input JSON state to estimate the next step of.",
+		TakesFile: true,
+		Required:  true,
+	}
+	EstimateStepRPCFlag = &cli.StringFlag{
+		Name:     "rpc",
+		Usage:    "L1 RPC endpoint to simulate the step call against.",
+		Required: true,
+	}
+	EstimateStepMIPSFlag = &cli.StringFlag{
+		Name:     "mips",
+		Usage:    "address of the MIPS contract to simulate the step call on.",
+		Required: true,
+	}
+	EstimateStepLocalContextFlag = &cli.StringFlag{
+		Name:  "local-context",
+		Usage: "local context of the dispute game the step is played in, local pre-image keys are localized with it.",
+		Value: common.Hash{}.Hex(),
+	}
+	EstimateStepSenderFlag = &cli.StringFlag{
+		Name:  "sender",
+		Usage: "address the step call is simulated from, local pre-image keys are localized with it.",
+		Value: common.Address{}.Hex(),
+	}
+	EstimateStepGasLimitFlag = &cli.Uint64Flag{
+		Name:  "gas-limit",
+		Usage: "gas limit to check the step against. Defaults to the gas limit of the latest L1 block.",
+	}
+	EstimateStepOutputFlag = &cli.PathFlag{
+		Name:      "output",
+		Usage:     "path to write the JSON gas estimate to. Stdout if left empty.",
+		TakesFile: true,
+		Value:     "-",
+	}
+)
+
+// PreimageOracle storage slots of the pre-image mappings, see the PreimageOracle storage layout snapshot.
+var (
+	preimageLengthsSlot = common.BigToHash(big.NewInt(0))
+	preimagePartsSlot   = common.BigToHash(big.NewInt(1))
+	preimagePartOkSlot  = common.BigToHash(big.NewInt(2))
+)
+
+// StepGasEstimate is the gas used by the on-chain step from a state, as simulated against an L1 RPC.
+type StepGasEstimate struct {
+	Step uint64 `json:"step"`
+
+	Pre  common.Hash `json:"pre"`
+	Post common.Hash `json:"post"`
+
+	OracleKey    hexutil.Bytes `json:"oracle-key,omitempty"`
+	OracleOffset uint32        `json:"oracle-offset,omitempty"`
+
+	Gas             uint64 `json:"gas"`
+	GasLimit        uint64 `json:"gas-limit"`
+	ExceedsGasLimit bool   `json:"exceeds-gas-limit"`
+}
+
+func EstimateStep(ctx *cli.Context) error {
+	vmType, err := vmTypeFromString(ctx)
+	if err != nil {
+		return err
+	}
+	if !common.IsHexAddress(ctx.String(EstimateStepMIPSFlag.Name)) {
+		return fmt.Errorf("invalid MIPS contract address: %q", ctx.String(EstimateStepMIPSFlag.Name))
+	}
+	mips := common.HexToAddress(ctx.String(EstimateStepMIPSFlag.Name))
+	if !common.IsHexAddress(ctx.String(EstimateStepSenderFlag.Name)) {
+		return fmt.Errorf("invalid sender address: %q", ctx.String(EstimateStepSenderFlag.Name))
+	}
+	sender := common.HexToAddress(ctx.String(EstimateStepSenderFlag.Name))
+	localContextBytes, err := hexutil.Decode(ctx.String(EstimateStepLocalContextFlag.Name))
+	if err != nil || len(localContextBytes) != common.HashLength {
+		return fmt.Errorf("invalid local context: %q", ctx.String(EstimateStepLocalContextFlag.Name))
+	}
+	localContext := mipsevm.LocalContext(localContextBytes)
+
+	logger := Logger(os.Stderr, log.LevelInfo)
+	po, err := startStepPreimageServer(ctx, logger)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := po.Close(); err != nil {
+			logger.Error("failed to close pre-image server", "err", err)
+		}
+	}()
+
+	input := ctx.Path(EstimateStepStateFlag.Name)
+	vm, err := loadStepVM(vmType, input, po, logger)
+	if err != nil {
+		return fmt.Errorf("invalid input state (%v): %w", input, err)
+	}
+	state := vm.GetState()
+	if state.GetExited() {
+		return fmt.Errorf("cannot estimate step %d, the VM has exited", state.GetStep())
+	}
+	step := state.GetStep()
+	stepFn := vm.Step
+	if po.cmd != nil {
+		stepFn = Guard(po.cmd.ProcessState, stepFn)
+	}
+	witness, err := stepWithProof(stepFn)
+	if err != nil {
+		return fmt.Errorf("failed to generate step witness of step %d (PC: %08x): %w", step, state.GetPC(), err)
+	}
+	_, postStateHash := state.EncodeWitness()
+
+	rpcClient, err := rpc.DialContext(ctx.Context, ctx.String(EstimateStepRPCFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to dial L1 RPC: %w", err)
+	}
+	defer rpcClient.Close()
+	estimate, err := estimateStepGas(ctx.Context, rpcClient, mips, sender, localContext, witness, postStateHash)
+	if err != nil {
+		return fmt.Errorf("failed to estimate gas of step %d: %w", step, err)
+	}
+	estimate.Step = step
+	estimate.GasLimit = ctx.Uint64(EstimateStepGasLimitFlag.Name)
+	if estimate.GasLimit == 0 {
+		header, err := ethclient.NewClient(rpcClient).HeaderByNumber(ctx.Context, nil)
+		if err != nil {
+			return fmt.Errorf("failed to fetch latest L1 block: %w", err)
+		}
+		estimate.GasLimit = header.GasLimit
+	}
+	estimate.ExceedsGasLimit = estimate.Gas > estimate.GasLimit
+	if err := jsonutil.WriteJSON(ctx.Path(EstimateStepOutputFlag.Name), estimate, OutFilePerm); err != nil {
+		return fmt.Errorf("failed to write gas estimate: %w", err)
+	}
+	if estimate.ExceedsGasLimit {
+		return fmt.Errorf("step %d uses %d gas, exceeding the gas limit of %d", step, estimate.Gas, estimate.GasLimit)
+	}
+	return nil
+}
+
+// startStepPreimageServer starts the pre-image server passed after '--', like with run. The pre-image server is
+// only required if a step reads a pre-image, so the returned oracle has no server process if none is passed.
+func startStepPreimageServer(ctx *cli.Context, logger log.Logger) (*ProcessPreimageOracle, error) {
+	args := ctx.Args().Slice()
+	for i, arg := range args {
+		if arg == "--" {
+			args = args[i+1:]
+			break
+		}
+	}
+	if len(args) == 0 {
+		args = []string{""}
+	}
+	po, err := NewProcessPreimageOracle(args[0], args[1:], logger.With("module", "host"), logger.With("module", "host"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pre-image oracle process: %w", err)
+	}
+	if err := po.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start pre-image oracle server: %w", err)
+	}
+	return po, nil
+}
+
+// loadStepVM loads the VM of the given type from a JSON state, to generate step witnesses from.
+func loadStepVM(vmType VMType, input string, po mipsevm.PreimageOracle, logger log.Logger) (mipsevm.FPVM, error) {
+	outLog := &mipsevm.LoggingWriter{Log: logger.With("module", "guest", "stream", "stdout")}
+	errLog := &mipsevm.LoggingWriter{Log: logger.With("module", "guest", "stream", "stderr")}
+	switch vmType {
+	case cannonVMType:
+		return singlethreaded.NewInstrumentedStateFromFile(input, po, outLog, errLog, &program.Metadata{})
+	case mtVMType:
+		return multithreaded.NewInstrumentedStateFromFile(input, po, outLog, errLog, logger.With("module", "vm"))
+	default:
+		return nil, fmt.Errorf("unknown VM type %q", vmType)
+	}
+}
+
+// stepWithProof steps the VM, turning the panic of a missing pre-image server into an error.
+func stepWithProof(stepFn StepFn) (witness *mipsevm.StepWitness, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("step panicked, a pre-image server may be required after '--': %v", r)
+		}
+	}()
+	return stepFn(true)
+}
+
+// estimateStepGas simulates the step call on the MIPS contract, and checks that it results in the expected post-state.
+// A pre-image read by the step is set in the storage of the PreimageOracle with a state override,
+// so the step can be estimated without loading the pre-image on-chain first.
+func estimateStepGas(ctx context.Context, client *rpc.Client, mips common.Address, sender common.Address, localContext mipsevm.LocalContext, witness *mipsevm.StepWitness, post common.Hash) (*StepGasEstimate, error) {
+	estimate := &StepGasEstimate{Pre: witness.StateHash, Post: post}
+	var oracle common.Address
+	if witness.HasPreimage() {
+		estimate.OracleKey = witness.PreimageKey[:]
+		estimate.OracleOffset = witness.PreimageOffset
+		var err error
+		oracle, err = mipsOracle(ctx, client, mips)
+		if err != nil {
+			return nil, err
+		}
+	}
+	msg, overrides, err := stepCall(mips, oracle, sender, localContext, witness)
+	if err != nil {
+		return nil, err
+	}
+
+	ret, err := gethclient.New(client).CallContract(ctx, msg, nil, &overrides)
+	if err != nil {
+		return nil, fmt.Errorf("step call failed: %w", err)
+	}
+	onchainPost, err := stepPostState(ret)
+	if err != nil {
+		return nil, err
+	}
+	if onchainPost != post {
+		return nil, fmt.Errorf("on-chain post-state %s does not match the post-state %s of the VM", onchainPost, post)
+	}
+
+	var gas hexutil.Uint64
+	if err := client.CallContext(ctx, &gas, "eth_estimateGas", toCallArg(msg), "latest", overrides); err != nil {
+		return nil, fmt.Errorf("failed to estimate step call: %w", err)
+	}
+	estimate.Gas = uint64(gas)
+	return estimate, nil
+}
+
+// stepCall returns the step call on the MIPS contract, with the state overrides of the PreimageOracle
+// that provide the pre-image read by the step, if any.
+func stepCall(mips common.Address, oracle common.Address, sender common.Address, localContext mipsevm.LocalContext, witness *mipsevm.StepWitness) (ethereum.CallMsg, map[common.Address]gethclient.OverrideAccount, error) {
+	input, err := snapshots.LoadMIPSABI().Pack("step", witness.State, witness.ProofData, localContext)
+	if err != nil {
+		return ethereum.CallMsg{}, nil, fmt.Errorf("failed to encode step call: %w", err)
+	}
+	msg := ethereum.CallMsg{From: sender, To: &mips, Data: input}
+	var overrides map[common.Address]gethclient.OverrideAccount
+	if witness.HasPreimage() {
+		overrides = map[common.Address]gethclient.OverrideAccount{
+			oracle: {StateDiff: preimageOracleStateDiff(witness, sender, localContext)},
+		}
+	}
+	return msg, overrides, nil
+}
+
+// stepPostState decodes the post-state hash returned by the step call.
+func stepPostState(ret []byte) (common.Hash, error) {
+	if len(ret) != 32 {
+		return common.Hash{}, fmt.Errorf("step call returned %d bytes, expected the 32 byte post-state hash", len(ret))
+	}
+	return common.BytesToHash(ret), nil
+}
+
+// mipsOracle returns the address of the PreimageOracle the MIPS contract reads pre-images from.
+func mipsOracle(ctx context.Context, client *rpc.Client, mips common.Address) (common.Address, error) {
+	mipsABI := snapshots.LoadMIPSABI()
+	input, err := mipsABI.Pack("oracle")
+	if err != nil {
+		return common.Address{}, err
+	}
+	ret, err := ethclient.NewClient(client).CallContract(ctx, ethereum.CallMsg{To: &mips, Data: input}, nil)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to fetch PreimageOracle of MIPS contract: %w", err)
+	}
+	if len(ret) != 32 {
+		return common.Address{}, errors.New("invalid PreimageOracle address returned by MIPS contract")
+	}
+	return common.BytesToAddress(ret), nil
+}
+
+// preimageOracleStateDiff returns the PreimageOracle storage that makes the pre-image part read by the step available.
+func preimageOracleStateDiff(witness *mipsevm.StepWitness, sender common.Address, localContext mipsevm.LocalContext) map[common.Hash]common.Hash {
+	key := common.Hash(witness.PreimageKey)
+	if preimage.KeyType(key[0]) == preimage.LocalKeyType {
+		// Local keys are localized by the MIPS contract, for the caller of the step and the local context.
+		key = crypto.Keccak256Hash(key[:], common.LeftPadBytes(sender[:], 32), localContext[:])
+		key[0] = byte(preimage.LocalKeyType)
+	}
+	// The pre-image value includes the 8 byte length prefix that the parts are read from.
+	var part common.Hash
+	if int(witness.PreimageOffset) < len(witness.PreimageValue) {
+		copy(part[:], witness.PreimageValue[witness.PreimageOffset:])
+	}
+	offset := common.BigToHash(new(big.Int).SetUint64(uint64(witness.PreimageOffset)))
+	return map[common.Hash]common.Hash{
+		mappingSlot(key, preimageLengthsSlot):                     common.BigToHash(big.NewInt(int64(len(witness.PreimageValue) - 8))),
+		mappingSlot(offset, mappingSlot(key, preimagePartsSlot)):  part,
+		mappingSlot(offset, mappingSlot(key, preimagePartOkSlot)): common.BigToHash(big.NewInt(1)),
+	}
+}
+
+// mappingSlot returns the storage slot of the value at key, in the solidity mapping at slot.
+func mappingSlot(key common.Hash, slot common.Hash) common.Hash {
+	return crypto.Keccak256Hash(key[:], slot[:])
+}
+
+func toCallArg(msg ethereum.CallMsg) any {
+	return map[string]any{
+		"from": msg.From,
+		"to":   msg.To,
+		"data": hexutil.Bytes(msg.Data),
+	}
+}
+
+var EstimateStepCommand = &cli.Command{
+	Name:  "estimate-step",
+	Usage: "Estimate the gas of the on-chain step from a Cannon JSON state",
+	Description: "Generate the witness of the next step from a Cannon JSON state, and simulate the step call on the MIPS contract against an L1 RPC, " +
+		"to report the gas it uses. Fails if the step exceeds the gas limit. A pre-image read by the step is provided to the PreimageOracle with a state override. " +
+		"A pre-image server, if required by the step, can be passed after '--'",
+	Action: EstimateStep,
+	Flags: []cli.Flag{
+		VMTypeFlag,
+		EstimateStepStateFlag,
+		EstimateStepRPCFlag,
+		EstimateStepMIPSFlag,
+		EstimateStepLocalContextFlag,
+		EstimateStepSenderFlag,
+		EstimateStepGasLimitFlag,
+		EstimateStepOutputFlag,
+	},
+}
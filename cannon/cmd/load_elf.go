@@ -2,7 +2,9 @@ package cmd
 
 import (
 	"debug/elf"
+	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
@@ -14,6 +16,9 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
 )
 
+// errRISCVNotSupported is returned for RISC-V programs. Cannon only implements the MIPS instruction set.
+var errRISCVNotSupported = errors.New("RISC-V ELF files are not supported by cannon, use the asterisc VM instead")
+
 var (
 	LoadELFPathFlag = &cli.PathFlag{
 		Name:      "path",
@@ -22,10 +27,18 @@ var (
 		Required:  true,
 	}
 	LoadELFPatchFlag = &cli.StringSliceFlag{
-		Name:     "patch",
-		Usage:    "Type of patching to do",
-		Value:    cli.NewStringSlice("go", "stack"),
-		Required: false,
+		Name: "patch",
+		Usage: "Type of patching to do. Defaults to go and stack for the single-threaded VM, and stack for the " +
+			"multi-threaded VM, which runs the standard Go runtime without patching out the GC and background threads.",
+		DefaultText: "go,stack for cannon, stack for cannon-mt",
+		Required:    false,
+	}
+	LoadELFManifestFlag = &cli.PathFlag{
+		Name: "manifest",
+		Usage: "Path to a JSON manifest with the program arguments, environment variables and auxiliary vector entries " +
+			"to set up the stack with, and additional symbols to patch out. Optional.",
+		TakesFile: true,
+		Required:  false,
 	}
 	LoadELFOutFlag = &cli.PathFlag{
 		Name:     "out",
@@ -41,11 +54,25 @@ var (
 	}
 )
 
+// loadELFPatches returns the patches to apply to the initial state: the patch flag if it is set,
+// or else the default of the VM type. The Go runtime can schedule its threads on the multi-threaded VM,
+// so its GC and background threads are only patched out for the single-threaded VM by default.
+func loadELFPatches(ctx *cli.Context, vmType VMType) []string {
+	if ctx.IsSet(LoadELFPatchFlag.Name) {
+		return ctx.StringSlice(LoadELFPatchFlag.Name)
+	}
+	if vmType == mtVMType {
+		return []string{"stack"}
+	}
+	return []string{"go", "stack"}
+}
+
 func LoadELF(ctx *cli.Context) error {
 	var createInitialState func(f *elf.File) (mipsevm.FPVMState, error)
 	var writeState func(path string, state mipsevm.FPVMState) error
 
-	if vmType, err := vmTypeFromString(ctx); err != nil {
+	vmType, err := vmTypeFromString(ctx)
+	if err != nil {
 		return err
 	} else if vmType == cannonVMType {
 		createInitialState = func(f *elf.File) (mipsevm.FPVMState, error) {
@@ -69,17 +96,32 @@ func LoadELF(ctx *cli.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to open ELF file %q: %w", elfPath, err)
 	}
-	if elfProgram.Machine != elf.EM_MIPS {
-		return fmt.Errorf("ELF is not big-endian MIPS R3000, but got %q", elfProgram.Machine.String())
+	if err := checkELFMachine(elfProgram); err != nil {
+		return err
 	}
 	state, err := createInitialState(elfProgram)
 	if err != nil {
 		return fmt.Errorf("failed to load ELF data into VM state: %w", err)
 	}
-	for _, typ := range ctx.StringSlice(LoadELFPatchFlag.Name) {
+	patches := loadELFPatches(ctx, vmType)
+	manifest := new(program.Manifest)
+	if manifestPath := ctx.Path(LoadELFManifestFlag.Name); manifestPath != "" {
+		manifest, err = jsonutil.LoadJSON[program.Manifest](manifestPath)
+		if err != nil {
+			return fmt.Errorf("failed to load manifest: %w", err)
+		}
+		if manifest.CustomStack() && !slices.Contains(patches, "stack") {
+			return fmt.Errorf("manifest sets up the stack, but the stack is not patched")
+		}
+	}
+	for _, typ := range patches {
 		switch typ {
 		case "stack":
-			err = program.PatchStack(state)
+			if manifest.CustomStack() {
+				err = program.PatchStackWithManifest(state, manifest)
+			} else {
+				err = program.PatchStack(state)
+			}
 		case "go":
 			err = program.PatchGo(elfProgram, state)
 		default:
@@ -89,6 +131,9 @@ func LoadELF(ctx *cli.Context) error {
 			return fmt.Errorf("failed to apply patch %s: %w", typ, err)
 		}
 	}
+	if err := program.PatchSymbols(elfProgram, state, manifest.PatchSymbols); err != nil {
+		return fmt.Errorf("failed to apply manifest patches: %w", err)
+	}
 	meta, err := program.MakeMetadata(elfProgram)
 	if err != nil {
 		return fmt.Errorf("failed to compute program metadata: %w", err)
@@ -102,13 +147,26 @@ func LoadELF(ctx *cli.Context) error {
 var LoadELFCommand = &cli.Command{
 	Name:        "load-elf",
 	Usage:       "Load ELF file into Cannon JSON state",
-	Description: "Load ELF file into Cannon JSON state, optionally patch out functions",
+	Description: "Load ELF file into Cannon JSON state, optionally patch out functions and customize the initial stack with a manifest",
 	Action:      LoadELF,
 	Flags: []cli.Flag{
 		VMTypeFlag,
 		LoadELFPathFlag,
 		LoadELFPatchFlag,
+		LoadELFManifestFlag,
 		LoadELFOutFlag,
 		LoadELFMetaFlag,
 	},
 }
+
+// checkELFMachine returns an error if the ELF file is not a MIPS program that cannon can execute.
+func checkELFMachine(f *elf.File) error {
+	if f.Machine == elf.EM_RISCV {
+		// RISC-V programs are executed by the separate asterisc VM, which shares the preimage oracle interface.
+		return errRISCVNotSupported
+	}
+	if f.Machine != elf.EM_MIPS {
+		return fmt.Errorf("ELF is not big-endian MIPS R3000, but got %q", f.Machine.String())
+	}
+	return nil
+}
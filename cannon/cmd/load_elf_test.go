@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"debug/elf"
+	"flag"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+func TestCheckELFMachine(t *testing.T) {
+	elfFile := func(machine elf.Machine) *elf.File {
+		return &elf.File{FileHeader: elf.FileHeader{Machine: machine}}
+	}
+	require.NoError(t, checkELFMachine(elfFile(elf.EM_MIPS)))
+	require.ErrorIs(t, checkELFMachine(elfFile(elf.EM_RISCV)), errRISCVNotSupported)
+	require.ErrorContains(t, checkELFMachine(elfFile(elf.EM_X86_64)), "ELF is not big-endian MIPS R3000")
+}
+
+func TestLoadELFPatches(t *testing.T) {
+	newCtx := func(args ...string) *cli.Context {
+		fs := flag.NewFlagSet("load-elf", flag.ContinueOnError)
+		require.NoError(t, LoadELFPatchFlag.Apply(fs))
+		require.NoError(t, VMTypeFlag.Apply(fs))
+		require.NoError(t, fs.Parse(args))
+		return cli.NewContext(nil, fs, nil)
+	}
+	patches := func(args ...string) []string {
+		ctx := newCtx(args...)
+		vmType, err := vmTypeFromString(ctx)
+		require.NoError(t, err)
+		return loadELFPatches(ctx, vmType)
+	}
+
+	require.Equal(t, []string{"go", "stack"}, patches())
+	require.Equal(t, []string{"go", "stack"}, patches("--type", "cannon"))
+	require.Equal(t, []string{"stack"}, patches("--type", "cannon-mt"), "the Go runtime must not be patched for multi-threaded state")
+	require.Equal(t, []string{"go", "stack"}, patches("--type", "cannon-mt", "--patch", "go", "--patch", "stack"), "the patch flag overrides the default")
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/singlethreaded"
+	"github.com/ethereum-optimism/optimism/cannon/serialize"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+)
+
+var (
+	MemoryProofStateFlag = &cli.PathFlag{
+		Name:      "state",
+		Usage:     "path of input JSON state.",
+		TakesFile: true,
+		Required:  true,
+	}
+	MemoryProofAddrFlag = &cli.StringFlag{
+		Name:     "addr",
+		Usage:    "hex memory address to prove, e.g. 0x7fff0000. Unaligned addresses prove the word containing them.",
+		Required: true,
+	}
+	MemoryProofOutputFlag = &cli.PathFlag{
+		Name:      "output",
+		Usage:     "path to write JSON memory proof to. Stdout if left empty.",
+		TakesFile: true,
+		Value:     "-",
+	}
+)
+
+// MemoryProof is the merkle proof of a memory word, in the encoding expected by the on-chain MIPS step function.
+type MemoryProof struct {
+	// Addr is the word-aligned address that is proven
+	Addr hexutil.Uint64 `json:"addr"`
+	// Value is the memory word at Addr
+	Value hexutil.Uint64 `json:"value"`
+	// MemRoot is the merkle root of the memory the proof is against
+	MemRoot common.Hash `json:"memRoot"`
+	// Proof is the 32 byte leaf containing Addr, followed by the sibling nodes up to MemRoot
+	Proof hexutil.Bytes `json:"proof"`
+}
+
+func parseMemoryAddr(s string) (uint32, error) {
+	addr, err := strconv.ParseUint(strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X"), 16, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid memory address %q: %w", s, err)
+	}
+	return uint32(addr), nil
+}
+
+func MemoryProofCmd(ctx *cli.Context) error {
+	input := ctx.Path(MemoryProofStateFlag.Name)
+	addr, err := parseMemoryAddr(ctx.String(MemoryProofAddrFlag.Name))
+	if err != nil {
+		return err
+	}
+	var state mipsevm.FPVMState
+	if vmType, err := vmTypeFromString(ctx); err != nil {
+		return err
+	} else if vmType == cannonVMType {
+		state, err = serialize.Load[singlethreaded.State](input)
+		if err != nil {
+			return fmt.Errorf("invalid input state (%v): %w", input, err)
+		}
+	} else if vmType == mtVMType {
+		state, err = serialize.Load[multithreaded.State](input)
+		if err != nil {
+			return fmt.Errorf("invalid input state (%v): %w", input, err)
+		}
+	} else {
+		return fmt.Errorf("invalid VM type: %q", vmType)
+	}
+
+	mem := state.GetMemory()
+	aligned := addr &^ 3
+	proof := mem.MerkleProof(aligned)
+	out := &MemoryProof{
+		Addr:    hexutil.Uint64(aligned),
+		Value:   hexutil.Uint64(mem.GetMemory(aligned)),
+		MemRoot: mem.MerkleRoot(),
+		Proof:   proof[:],
+	}
+	if err := jsonutil.WriteJSON(ctx.Path(MemoryProofOutputFlag.Name), out, OutFilePerm); err != nil {
+		return fmt.Errorf("failed to write memory proof: %w", err)
+	}
+	return nil
+}
+
+var MemoryProofCommand = &cli.Command{
+	Name:        "memory-proof",
+	Usage:       "Generate the merkle proof of a memory address in a Cannon JSON state",
+	Description: "Generate the merkle proof of the memory word at an address in a Cannon JSON state, as used by the on-chain step function to verify memory reads and writes",
+	Action:      MemoryProofCmd,
+	Flags: []cli.Flag{
+		VMTypeFlag,
+		MemoryProofStateFlag,
+		MemoryProofAddrFlag,
+		MemoryProofOutputFlag,
+	},
+}
@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/profiling"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/singlethreaded"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+)
+
+var (
+	ProfileOutputFlag = &cli.PathFlag{
+		Name:      "output",
+		Usage:     "path of the pprof profile to write. Not written if empty.",
+		TakesFile: true,
+		Value:     "cannon.pprof",
+		Required:  false,
+	}
+	ProfileMetaFlag = &cli.PathFlag{
+		Name:     "meta",
+		Usage:    "path to metadata file for symbol lookup.",
+		Value:    "meta.json",
+		Required: true,
+	}
+	ProfileTopFlag = &cli.IntFlag{
+		Name:  "top",
+		Usage: "number of symbols, PCs and syscalls to include in the hot-spot report printed to stdout.",
+		Value: 20,
+	}
+)
+
+func Profile(ctx *cli.Context) error {
+	vmType, err := vmTypeFromString(ctx)
+	if err != nil {
+		return err
+	}
+
+	guestLogger := Logger(os.Stderr, log.LevelInfo)
+	outLog := &mipsevm.LoggingWriter{Log: guestLogger.With("module", "guest", "stream", "stdout")}
+	errLog := &mipsevm.LoggingWriter{Log: guestLogger.With("module", "guest", "stream", "stderr")}
+
+	l := Logger(os.Stderr, log.LevelInfo).With("module", "vm")
+
+	// split CLI args after first '--'
+	args := ctx.Args().Slice()
+	for i, arg := range args {
+		if arg == "--" {
+			args = args[i+1:]
+			break
+		}
+	}
+	if len(args) == 0 {
+		args = []string{""}
+	}
+
+	poOut := Logger(os.Stdout, log.LevelInfo).With("module", "host")
+	poErr := Logger(os.Stderr, log.LevelInfo).With("module", "host")
+	po, err := NewProcessPreimageOracle(args[0], args[1:], poOut, poErr)
+	if err != nil {
+		return fmt.Errorf("failed to create pre-image oracle process: %w", err)
+	}
+	if err := po.Start(); err != nil {
+		return fmt.Errorf("failed to start pre-image oracle server: %w", err)
+	}
+	defer func() {
+		if err := po.Close(); err != nil {
+			l.Error("failed to close pre-image server", "err", err)
+		}
+	}()
+
+	meta, err := jsonutil.LoadJSON[program.Metadata](ctx.Path(ProfileMetaFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to load metadata: %w", err)
+	}
+
+	var vm mipsevm.FPVM
+	switch vmType {
+	case cannonVMType:
+		vm, err = singlethreaded.NewInstrumentedStateFromFile(ctx.Path(RunInputFlag.Name), po, outLog, errLog, meta)
+	case mtVMType:
+		vm, err = multithreaded.NewInstrumentedStateFromFile(ctx.Path(RunInputFlag.Name), po, outLog, errLog, l)
+	default:
+		return fmt.Errorf("unknown VM type %q", vmType)
+	}
+	if err != nil {
+		return err
+	}
+
+	stepFn := vm.Step
+	if po.cmd != nil {
+		stepFn = Guard(po.cmd.ProcessState, stepFn)
+	}
+	stopAt := ctx.Generic(RunStopAtFlag.Name).(*StepMatcherFlag).Matcher()
+	infoAt := ctx.Generic(RunInfoAtFlag.Name).(*StepMatcherFlag).Matcher()
+	profiler := profiling.NewProfiler(meta)
+
+	start := time.Now()
+	state := vm.GetState()
+	startStep := state.GetStep()
+	for !state.GetExited() {
+		step := state.GetStep()
+		if step%100 == 0 { // don't do the ctx err check (includes lock) too often
+			if err := ctx.Context.Err(); err != nil {
+				return err
+			}
+		}
+		if infoAt(state) {
+			delta := time.Since(start)
+			l.Info("profiling",
+				"step", step,
+				"pc", mipsevm.HexU32(state.GetPC()),
+				"ips", float64(step-startStep)/(float64(delta)/float64(time.Second)),
+				"name", meta.LookupSymbol(state.GetPC()),
+			)
+		}
+		if vm.CheckInfiniteLoop() {
+			return fmt.Errorf("detected an infinite loop at step %d", step)
+		}
+		if stopAt(state) {
+			l.Info("Reached stop at")
+			break
+		}
+
+		pc := state.GetPC()
+		profiler.RecordStep(pc, state.GetMemory().GetMemory(pc), state.GetRegistersRef()[2])
+		if _, err := stepFn(false); err != nil {
+			return fmt.Errorf("failed at step %d (PC: %08x): %w", step, pc, err)
+		}
+	}
+	l.Info("Execution stopped", "exited", state.GetExited(), "code", state.GetExitCode(), "instructions", profiler.Total())
+
+	if outPath := ctx.Path(ProfileOutputFlag.Name); outPath != "" {
+		f, err := os.OpenFile(outPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, OutFilePerm)
+		if err != nil {
+			return fmt.Errorf("failed to create profile output: %w", err)
+		}
+		defer f.Close()
+		if err := profiler.Profile().Write(f); err != nil {
+			return fmt.Errorf("failed to write profile: %w", err)
+		}
+	}
+	return profiler.WriteReport(os.Stdout, ctx.Int(ProfileTopFlag.Name))
+}
+
+var ProfileCommand = &cli.Command{
+	Name:  "profile",
+	Usage: "Run the VM and report the hottest code paths of the program.",
+	Description: "Run the VM and record instruction counts per PC and per symbol, and syscall frequencies. " +
+		"Writes a pprof-compatible profile, which can be inspected with 'go tool pprof', and prints a hot-spot report to stdout. " +
+		"Arguments after '--' start the pre-image server, like with the run command.",
+	Action: Profile,
+	Flags: []cli.Flag{
+		VMTypeFlag,
+		RunInputFlag,
+		ProfileOutputFlag,
+		ProfileMetaFlag,
+		ProfileTopFlag,
+		RunStopAtFlag,
+		RunInfoAtFlag,
+	},
+}
@@ -65,6 +65,17 @@ var (
 		Value:    "state-%d.json",
 		Required: false,
 	}
+	RunSnapshotIncrementalFlag = &cli.BoolFlag{
+		Name:     "snapshot-incremental",
+		Usage:    "write snapshots as a diff from the last full snapshot. Requires a binary snapshot-fmt (.bin or .bin.gz).",
+		Required: false,
+	}
+	RunSnapshotFullIntervalFlag = &cli.Uint64Flag{
+		Name:     "snapshot-full-interval",
+		Usage:    "with incremental snapshots, write a full snapshot after this many diffs, to bound the size of the diffs.",
+		Value:    16,
+		Required: false,
+	}
 	RunStopAtFlag = &cli.GenericFlag{
 		Name:     "stop-at",
 		Usage:    "step pattern to stop at: " + patternHelp,
@@ -412,6 +423,14 @@ func Run(ctx *cli.Context) error {
 
 	proofFmt := ctx.String(RunProofFmtFlag.Name)
 	snapshotFmt := ctx.String(RunSnapshotFmtFlag.Name)
+	snapshotIncremental := ctx.Bool(RunSnapshotIncrementalFlag.Name)
+	if snapshotIncremental && !serialize.IsBinaryFile(snapshotFmt) {
+		return fmt.Errorf("incremental snapshots require a binary snapshot format, got %q", snapshotFmt)
+	}
+	snapshots := &snapshotWriter{
+		incremental:  snapshotIncremental,
+		fullInterval: ctx.Uint64(RunSnapshotFullIntervalFlag.Name),
+	}
 
 	stepFn := vm.Step
 	if po.cmd != nil {
@@ -455,7 +474,8 @@ func Run(ctx *cli.Context) error {
 		}
 
 		if snapshotAt(state) {
-			if err := serialize.Write(fmt.Sprintf(snapshotFmt, step), state, OutFilePerm); err != nil {
+			snapshotPath := fmt.Sprintf(snapshotFmt, step)
+			if err := snapshots.write(snapshotPath, state); err != nil {
 				return fmt.Errorf("failed to write state snapshot: %w", err)
 			}
 		}
@@ -523,6 +543,38 @@ func Run(ctx *cli.Context) error {
 	return nil
 }
 
+// snapshotWriter writes the snapshots of a run. Incremental snapshots are written as a diff from the last full
+// snapshot, so loading a snapshot applies at most one diff. As a diff includes all the memory modified since the
+// full snapshot, a new full snapshot is written after fullInterval diffs.
+type snapshotWriter struct {
+	incremental  bool
+	fullInterval uint64
+
+	lastFull string
+	diffs    uint64
+}
+
+func (w *snapshotWriter) write(path string, state mipsevm.FPVMState) error {
+	if !w.incremental {
+		return serialize.Write(path, state, OutFilePerm)
+	}
+	if w.lastFull != "" && w.diffs < w.fullInterval {
+		if err := serialize.WriteSerializedBinaryDiff(path, w.lastFull, state, OutFilePerm); err != nil {
+			return err
+		}
+		w.diffs++
+		return nil
+	}
+	if err := serialize.WriteSerializedBinary(path, state, OutFilePerm); err != nil {
+		return err
+	}
+	// Only marked clean after a full snapshot, so the following diffs are against it.
+	state.MarkClean()
+	w.lastFull = path
+	w.diffs = 0
+	return nil
+}
+
 var RunCommand = &cli.Command{
 	Name:        "run",
 	Usage:       "Run VM step(s) and generate proof data to replicate onchain.",
@@ -536,6 +588,8 @@ var RunCommand = &cli.Command{
 		RunProofFmtFlag,
 		RunSnapshotAtFlag,
 		RunSnapshotFmtFlag,
+		RunSnapshotIncrementalFlag,
+		RunSnapshotFullIntervalFlag,
 		RunStopAtFlag,
 		RunStopAtPreimageFlag,
 		RunStopAtPreimageTypeFlag,
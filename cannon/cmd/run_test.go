@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/singlethreaded"
+	"github.com/ethereum-optimism/optimism/cannon/serialize"
+)
+
+func TestSnapshotWriterIncremental(t *testing.T) {
+	dir := t.TempDir()
+	state := singlethreaded.CreateEmptyState()
+	w := &snapshotWriter{incremental: true, fullInterval: 2}
+
+	var paths []string
+	for i := uint32(0); i < 6; i++ {
+		// Modify a different page for every snapshot, so every diff has to include all pages since the full snapshot.
+		state.Memory.SetMemory(i*4096, i+1)
+		path := filepath.Join(dir, fmt.Sprintf("state-%d.bin", i))
+		require.NoError(t, w.write(path, state))
+		paths = append(paths, path)
+	}
+
+	full := map[string]bool{paths[0]: true, paths[3]: true}
+	for i, path := range paths {
+		base, err := serialize.DiffBase(path)
+		require.NoError(t, err)
+		if full[path] {
+			require.Empty(t, base, "snapshot %d must be a full snapshot", i)
+			continue
+		}
+		require.True(t, full[base], "snapshot %d must be a diff against a full snapshot, got %q", i, base)
+		baseOfBase, err := serialize.DiffBase(base)
+		require.NoError(t, err)
+		require.Empty(t, baseOfBase, "diff chain of snapshot %d must have a depth of one", i)
+
+		loaded, err := serialize.LoadSerializedBinary[singlethreaded.State](path)
+		require.NoError(t, err)
+		for j := uint32(0); j <= uint32(i); j++ {
+			require.Equal(t, j+1, loaded.Memory.GetMemory(j*4096), "snapshot %d must include the write to page %d", i, j)
+		}
+	}
+}
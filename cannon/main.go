@@ -21,6 +21,11 @@ func main() {
 		cmd.LoadELFCommand,
 		cmd.WitnessCommand,
 		cmd.RunCommand,
+		cmd.ConvertStateCommand,
+		cmd.ProfileCommand,
+		cmd.MemoryProofCommand,
+		cmd.EstimateStepCommand,
+		cmd.DiffTestCommand,
 	}
 	ctx := ctxinterrupt.WithSignalWaiterMain(context.Background())
 	err := app.RunContext(ctx, os.Args)
@@ -9,7 +9,7 @@ import (
 )
 
 type FPVMState interface {
-	serialize.Serializable
+	serialize.Incremental
 
 	GetMemory() *memory.Memory
 
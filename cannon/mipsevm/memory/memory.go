@@ -201,6 +201,7 @@ func (m *Memory) SetMemory(addr uint32, v uint32) {
 	} else {
 		m.Invalidate(addr) // invalidate this branch of memory, now that the value changed
 	}
+	p.Dirty = true
 	binary.BigEndian.PutUint32(p.Data[pageAddr:pageAddr+4], v)
 }
 
@@ -218,7 +219,7 @@ func (m *Memory) GetMemory(addr uint32) uint32 {
 }
 
 func (m *Memory) AllocPage(pageIndex uint32) *CachedPage {
-	p := &CachedPage{Data: new(Page)}
+	p := &CachedPage{Data: new(Page), Dirty: true}
 	m.pages[pageIndex] = p
 	// make nodes to root
 	k := (1 << PageKeySize) | uint64(pageIndex)
@@ -275,6 +276,7 @@ func (m *Memory) SetMemoryRange(addr uint32, r io.Reader) error {
 			p = m.AllocPage(pageIndex)
 		}
 		p.InvalidateFull()
+		p.Dirty = true
 		n, err := r.Read(p.Data[pageAddr:])
 		if err != nil {
 			if err == io.EOF {
@@ -328,6 +330,36 @@ func (m *Memory) Deserialize(in io.Reader) error {
 	return nil
 }
 
+// MarkClean marks all pages as unmodified, to track the pages modified after this point.
+func (m *Memory) MarkClean() {
+	for _, p := range m.pages {
+		p.Dirty = false
+	}
+}
+
+// Diff returns a memory with only the pages modified since the memory was last marked clean.
+// The page data is shared with m, and must not be modified.
+func (m *Memory) Diff() *Memory {
+	diff := NewMemory()
+	for pageIndex, p := range m.pages {
+		if p.Dirty {
+			diff.AllocPage(pageIndex).Data = p.Data
+		}
+	}
+	return diff
+}
+
+// ApplyBase restores the memory from a diff against the base memory, by adding all pages of base that m does not have.
+// Pages are never de-allocated, so the pages missing from the diff are the unmodified pages of base.
+// The page data is taken over from base, which must not be used after.
+func (m *Memory) ApplyBase(base *Memory) {
+	for pageIndex, p := range base.pages {
+		if _, ok := m.pages[pageIndex]; !ok {
+			m.AllocPage(pageIndex).Data = p.Data
+		}
+	}
+}
+
 type memReader struct {
 	m     *Memory
 	addr  uint32
@@ -187,3 +187,26 @@ func TestMemoryJSON(t *testing.T) {
 	require.NoError(t, json.Unmarshal(dat, &res))
 	require.Equal(t, uint32(123), res.GetMemory(8))
 }
+
+func TestMemoryDiff(t *testing.T) {
+	m := NewMemory()
+	m.SetMemory(0x1000, 1)
+	m.SetMemory(0x2000, 2)
+	m.MarkClean()
+	require.Zero(t, m.Diff().PageCount(), "no pages modified after marking clean")
+
+	m.SetMemory(0x2000, 3)
+	m.SetMemory(0x3000, 4)
+	diff := m.Diff()
+	require.Equal(t, 2, diff.PageCount(), "modified and newly allocated pages are in the diff")
+	require.Equal(t, uint32(3), diff.GetMemory(0x2000))
+	require.Equal(t, uint32(4), diff.GetMemory(0x3000))
+
+	base := NewMemory()
+	base.SetMemory(0x1000, 1)
+	base.SetMemory(0x2000, 2)
+	diff.ApplyBase(base)
+	require.Equal(t, m.MerkleRoot(), diff.MerkleRoot())
+	require.Equal(t, uint32(1), diff.GetMemory(0x1000))
+	require.Equal(t, uint32(3), diff.GetMemory(0x2000))
+}
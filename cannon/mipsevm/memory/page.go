@@ -68,6 +68,8 @@ type CachedPage struct {
 	Cache [PageSize / 32][32]byte
 	// true if the intermediate node is valid
 	Ok [PageSize / 32]bool
+	// true if the page was modified since the memory was last marked clean
+	Dirty bool
 }
 
 func (p *CachedPage) Invalidate(pageAddr uint32) {
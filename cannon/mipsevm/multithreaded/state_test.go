@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"debug/elf"
 	"encoding/json"
+	"path/filepath"
 	"testing"
 
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
@@ -16,6 +17,7 @@ import (
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
+	"github.com/ethereum-optimism/optimism/cannon/serialize"
 )
 
 func setWitnessField(witness StateWitness, fieldOffset int, fieldData []byte) {
@@ -371,3 +373,34 @@ func TestState_EncodeThreadProof_EmptyThreadStackPanic(t *testing.T) {
 		})
 	}
 }
+
+func TestStateDiff(t *testing.T) {
+	state := CreateInitialState(0x1000, 0x4000_0000)
+	for addr := uint32(0); addr < 0x10_0000; addr += memory.PageSize {
+		state.Memory.SetMemory(addr, addr)
+	}
+
+	dir := t.TempDir()
+	basePath := filepath.Join(dir, "base.bin.gz")
+	require.NoError(t, serialize.Write(basePath, state, 0o644))
+	state.MarkClean()
+
+	state.Step = 1000
+	state.Heap = 0x7000_0000
+	state.Memory.SetMemory(0x7000_0000, 0xdeadbeef)
+	diffPath := filepath.Join(dir, "diff.bin.gz")
+	require.NoError(t, serialize.WriteSerializedBinaryDiff(diffPath, basePath, state, 0o644))
+
+	diff := new(bytes.Buffer)
+	require.NoError(t, state.SerializeDiff(diff))
+	full := new(bytes.Buffer)
+	require.NoError(t, state.Serialize(full))
+	require.Less(t, diff.Len(), full.Len(), "diff only includes the modified page")
+
+	loaded, err := serialize.Load[State](diffPath)
+	require.NoError(t, err)
+	_, expectedHash := state.EncodeWitness()
+	_, loadedHash := loaded.EncodeWitness()
+	require.Equal(t, expectedHash, loadedHash)
+	require.Equal(t, state.Memory.PageCount(), loaded.Memory.PageCount())
+}
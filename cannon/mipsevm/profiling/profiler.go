@@ -0,0 +1,250 @@
+// Package profiling records instruction-level execution profiles of programs running in the MIPS VM.
+package profiling
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/google/pprof/profile"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
+)
+
+var syscallNames = map[uint32]string{
+	exec.SysMmap:          "mmap",
+	exec.SysBrk:           "brk",
+	exec.SysClone:         "clone",
+	exec.SysExitGroup:     "exit_group",
+	exec.SysRead:          "read",
+	exec.SysWrite:         "write",
+	exec.SysFcntl:         "fcntl",
+	exec.SysExit:          "exit",
+	exec.SysSchedYield:    "sched_yield",
+	exec.SysGetTID:        "gettid",
+	exec.SysFutex:         "futex",
+	exec.SysOpen:          "open",
+	exec.SysNanosleep:     "nanosleep",
+	exec.SysMunmap:        "munmap",
+	exec.SysGetAffinity:   "sched_getaffinity",
+	exec.SysMadvise:       "madvise",
+	exec.SysRtSigprocmask: "rt_sigprocmask",
+	exec.SysSigaltstack:   "sigaltstack",
+	exec.SysRtSigaction:   "rt_sigaction",
+	exec.SysClose:         "close",
+	exec.SysOpenAt:        "openat",
+	exec.SysReadlink:      "readlink",
+	exec.SysReadlinkAt:    "readlinkat",
+	exec.SysIoctl:         "ioctl",
+	exec.SysEpollCtl:      "epoll_ctl",
+	exec.SysEpollPwait:    "epoll_pwait",
+	exec.SysGetRandom:     "getrandom",
+	exec.SysUname:         "uname",
+	exec.SysGetuid:        "getuid",
+	exec.SysGetgid:        "getgid",
+	exec.SysLlseek:        "_llseek",
+	exec.SysMinCore:       "mincore",
+	exec.SysTgkill:        "tgkill",
+	exec.SysSetITimer:     "setitimer",
+	exec.SysTimerCreate:   "timer_create",
+	exec.SysTimerSetTime:  "timer_settime",
+	exec.SysTimerDelete:   "timer_delete",
+	exec.SysClockGetTime:  "clock_gettime",
+}
+
+// SyscallName returns the name of the syscall with the given number, or the number if the syscall is unknown.
+func SyscallName(num uint32) string {
+	if name, ok := syscallNames[num]; ok {
+		return name
+	}
+	return fmt.Sprintf("%d", num)
+}
+
+type syscallSite struct {
+	pc  uint32
+	num uint32
+}
+
+// Profiler counts the instructions executed per PC, and the syscalls made per PC and syscall number.
+type Profiler struct {
+	meta *program.Metadata
+
+	total        uint64
+	instructions map[uint32]uint64
+	syscalls     map[syscallSite]uint64
+}
+
+// NewProfiler creates a profiler that attributes instructions to the symbols of the given metadata.
+func NewProfiler(meta *program.Metadata) *Profiler {
+	return &Profiler{
+		meta:         meta,
+		instructions: make(map[uint32]uint64),
+		syscalls:     make(map[syscallSite]uint64),
+	}
+}
+
+// RecordStep records the execution of the instruction insn at pc.
+// For syscall instructions, v0 is the value of the $v0 register, which holds the syscall number.
+func (p *Profiler) RecordStep(pc uint32, insn uint32, v0 uint32) {
+	p.total++
+	p.instructions[pc]++
+	if opcode, fun := insn>>26, insn&0x3F; opcode == 0 && fun == 0xC {
+		p.syscalls[syscallSite{pc: pc, num: v0}]++
+	}
+}
+
+// Total returns the total number of recorded instructions.
+func (p *Profiler) Total() uint64 {
+	return p.total
+}
+
+// Count is the number of times a PC, symbol or syscall was recorded.
+type Count struct {
+	Name  string
+	Count uint64
+}
+
+func sortCounts(counts map[string]uint64) []Count {
+	out := make([]Count, 0, len(counts))
+	for name, count := range counts {
+		out = append(out, Count{Name: name, Count: count})
+	}
+	slices.SortFunc(out, func(a, b Count) int {
+		if c := cmp.Compare(b.Count, a.Count); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
+	return out
+}
+
+// Symbols returns the instruction counts per symbol, from most to least executed.
+func (p *Profiler) Symbols() []Count {
+	counts := make(map[string]uint64)
+	for pc, count := range p.instructions {
+		counts[p.meta.LookupSymbol(pc)] += count
+	}
+	return sortCounts(counts)
+}
+
+// Syscalls returns the counts per syscall, from most to least frequent.
+func (p *Profiler) Syscalls() []Count {
+	counts := make(map[string]uint64)
+	for site, count := range p.syscalls {
+		counts[SyscallName(site.num)] += count
+	}
+	return sortCounts(counts)
+}
+
+// PCs returns the instruction counts per PC, from most to least executed.
+func (p *Profiler) PCs() []Count {
+	counts := make(map[string]uint64, len(p.instructions))
+	for pc, count := range p.instructions {
+		counts[fmt.Sprintf("0x%08x %s", pc, p.meta.LookupSymbol(pc))] = count
+	}
+	return sortCounts(counts)
+}
+
+// Profile returns a pprof profile with the instruction and syscall counts per PC.
+// Syscall samples are labelled with the name of the syscall.
+func (p *Profiler) Profile() *profile.Profile {
+	prof := &profile.Profile{
+		SampleType: []*profile.ValueType{
+			{Type: "instructions", Unit: "count"},
+			{Type: "syscalls", Unit: "count"},
+		},
+		DefaultSampleType: "instructions",
+		PeriodType:        &profile.ValueType{Type: "instructions", Unit: "count"},
+		Period:            1,
+	}
+	mapping := &profile.Mapping{ID: 1, Limit: 1 << 32, File: "program", HasFunctions: true}
+	prof.Mapping = []*profile.Mapping{mapping}
+
+	functions := make(map[string]*profile.Function)
+	locations := make(map[uint32]*profile.Location)
+	location := func(pc uint32) *profile.Location {
+		if loc, ok := locations[pc]; ok {
+			return loc
+		}
+		name := p.meta.LookupSymbol(pc)
+		fn, ok := functions[name]
+		if !ok {
+			fn = &profile.Function{ID: uint64(len(prof.Function) + 1), Name: name, SystemName: name}
+			functions[name] = fn
+			prof.Function = append(prof.Function, fn)
+		}
+		loc := &profile.Location{
+			ID:      uint64(len(prof.Location) + 1),
+			Mapping: mapping,
+			Address: uint64(pc),
+			Line:    []profile.Line{{Function: fn}},
+		}
+		locations[pc] = loc
+		prof.Location = append(prof.Location, loc)
+		return loc
+	}
+
+	pcs := make([]uint32, 0, len(p.instructions))
+	for pc := range p.instructions {
+		pcs = append(pcs, pc)
+	}
+	slices.Sort(pcs)
+	for _, pc := range pcs {
+		prof.Sample = append(prof.Sample, &profile.Sample{
+			Location: []*profile.Location{location(pc)},
+			Value:    []int64{int64(p.instructions[pc]), 0},
+		})
+	}
+	sites := make([]syscallSite, 0, len(p.syscalls))
+	for site := range p.syscalls {
+		sites = append(sites, site)
+	}
+	slices.SortFunc(sites, func(a, b syscallSite) int {
+		if c := cmp.Compare(a.pc, b.pc); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.num, b.num)
+	})
+	for _, site := range sites {
+		prof.Sample = append(prof.Sample, &profile.Sample{
+			Location: []*profile.Location{location(site.pc)},
+			Value:    []int64{0, int64(p.syscalls[site])},
+			Label:    map[string][]string{"syscall": {SyscallName(site.num)}},
+		})
+	}
+	return prof
+}
+
+// WriteReport writes a human-readable report of the top n symbols, PCs and syscalls.
+func (p *Profiler) WriteReport(w io.Writer, n int) error {
+	var syscallTotal uint64
+	for _, count := range p.syscalls {
+		syscallTotal += count
+	}
+	if _, err := fmt.Fprintf(w, "Total instructions: %d\n", p.total); err != nil {
+		return err
+	}
+	sections := []struct {
+		title  string
+		counts []Count
+		total  uint64
+	}{
+		{"Top symbols", p.Symbols(), p.total},
+		{"Top PCs", p.PCs(), p.total},
+		{fmt.Sprintf("Syscalls (%d total)", syscallTotal), p.Syscalls(), syscallTotal},
+	}
+	for _, section := range sections {
+		if _, err := fmt.Fprintf(w, "\n%s:\n", section.title); err != nil {
+			return err
+		}
+		for _, c := range section.counts[:min(n, len(section.counts))] {
+			pct := float64(c.Count) * 100 / float64(section.total)
+			if _, err := fmt.Fprintf(w, "%14d %6.2f%%  %s\n", c.Count, pct, c.Name); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
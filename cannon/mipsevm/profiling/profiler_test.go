@@ -0,0 +1,64 @@
+package profiling
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/pprof/profile"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
+)
+
+const (
+	syscallInsn = 0x0000000C
+	addInsn     = 0x00851020 // add $v0, $a0, $a1
+)
+
+func TestProfiler(t *testing.T) {
+	meta := &program.Metadata{Symbols: []program.Symbol{
+		{Name: "main.main", Start: 0x1000, Size: 0x100},
+		{Name: "runtime.write", Start: 0x2000, Size: 0x100},
+	}}
+	p := NewProfiler(meta)
+	for i := 0; i < 5; i++ {
+		p.RecordStep(0x1000, addInsn, exec.SysWrite)
+		p.RecordStep(0x1004, addInsn, 0)
+	}
+	p.RecordStep(0x2000, syscallInsn, exec.SysWrite)
+	p.RecordStep(0x2000, syscallInsn, exec.SysWrite)
+	p.RecordStep(0x2000, syscallInsn, 1234)
+
+	require.Equal(t, uint64(13), p.Total())
+	require.Equal(t, []Count{{Name: "main.main", Count: 10}, {Name: "runtime.write", Count: 3}}, p.Symbols())
+	require.Equal(t, []Count{{Name: "write", Count: 2}, {Name: "1234", Count: 1}}, p.Syscalls())
+	require.Equal(t, Count{Name: "0x00001000 main.main", Count: 5}, p.PCs()[0])
+
+	prof := p.Profile()
+	require.NoError(t, prof.CheckValid())
+	var buf bytes.Buffer
+	require.NoError(t, prof.Write(&buf))
+	parsed, err := profile.Parse(&buf)
+	require.NoError(t, err)
+	require.Len(t, parsed.Location, 3)
+	require.Len(t, parsed.Function, 2)
+	var instructions, syscalls int64
+	for _, s := range parsed.Sample {
+		instructions += s.Value[0]
+		syscalls += s.Value[1]
+		if s.Value[1] != 0 {
+			require.Equal(t, "runtime.write", s.Location[0].Line[0].Function.Name)
+			require.Len(t, s.Label["syscall"], 1)
+		}
+	}
+	require.Equal(t, int64(13), instructions)
+	require.Equal(t, int64(3), syscalls)
+
+	var report bytes.Buffer
+	require.NoError(t, p.WriteReport(&report, 1))
+	require.Contains(t, report.String(), "Total instructions: 13")
+	require.Contains(t, report.String(), "main.main")
+	require.NotContains(t, report.String(), "runtime.write\n", "only the top symbol is reported")
+	require.Contains(t, report.String(), "write")
+}
@@ -0,0 +1,143 @@
+package program
+
+import (
+	"bytes"
+	"debug/elf"
+	"encoding/binary"
+	"fmt"
+	"slices"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
+)
+
+const (
+	AT_NULL   = 0
+	AT_PAGESZ = 6
+	AT_RANDOM = 25
+)
+
+// Manifest customizes how a program is loaded, so programs other than op-program can run
+// without changes to cannon.
+type Manifest struct {
+	// Args are the program arguments, including the program name. Defaults to the op-program name.
+	Args []string `json:"args,omitempty"`
+	// Env are the environment variables, formatted as KEY=VALUE.
+	// Defaults to the op-program environment, which disables memory profiling.
+	Env []string `json:"env,omitempty"`
+	// Auxv are the auxiliary vector entries in addition to, or replacing, the default
+	// page size and random value entries.
+	Auxv []AuxvEntry `json:"auxv,omitempty"`
+	// PatchSymbols are the names of additional functions to patch out, by returning immediately.
+	PatchSymbols []string `json:"patchSymbols,omitempty"`
+}
+
+type AuxvEntry struct {
+	Type  uint32 `json:"type"`
+	Value uint32 `json:"value"`
+}
+
+// CustomStack returns whether the manifest changes the initial stack contents.
+func (m *Manifest) CustomStack() bool {
+	return len(m.Args) > 0 || len(m.Env) > 0 || len(m.Auxv) > 0
+}
+
+// PatchStackWithManifest sets up the stack like PatchStack, with the arguments, environment
+// and auxiliary vector of the manifest.
+func PatchStackWithManifest(st mipsevm.FPVMState, m *Manifest) error {
+	args := m.Args
+	if len(args) == 0 {
+		args = []string{"op-program"}
+	}
+	env := m.Env
+	if len(env) == 0 {
+		env = []string{"GODEBUG=memprofilerate=0"}
+	}
+	// The value of AT_RANDOM is replaced with the address of the random data below, unless overridden.
+	auxv := []AuxvEntry{{Type: AT_PAGESZ, Value: 4096}, {Type: AT_RANDOM}}
+	randomOverridden := false
+	for _, entry := range m.Auxv {
+		if entry.Type == AT_NULL {
+			return fmt.Errorf("auxv entries must not contain the terminating AT_NULL entry")
+		}
+		if entry.Type == AT_RANDOM {
+			randomOverridden = true
+		}
+		if i := slices.IndexFunc(auxv, func(e AuxvEntry) bool { return e.Type == entry.Type }); i >= 0 {
+			auxv[i] = entry
+		} else {
+			auxv = append(auxv, entry)
+		}
+	}
+
+	sp := uint32(0x7f_ff_d0_00)
+	// argc, argv and envp with terminators, auxv pairs with terminating pair
+	vectorsSize := 4 * (1 + len(args) + 1 + len(env) + 1 + 2*len(auxv) + 2)
+	random := []byte("4;byfairdiceroll") // 16 bytes of "randomness"
+	var data bytes.Buffer
+	data.Write(random)
+	strAddr := func(s string) uint32 {
+		addr := sp + uint32(vectorsSize+data.Len())
+		data.WriteString(s)
+		// null-terminate, and pad to 4-byte alignment
+		data.Write(make([]byte, 4-len(s)%4))
+		return addr
+	}
+	words := []uint32{uint32(len(args))}
+	for _, arg := range args {
+		words = append(words, strAddr(arg))
+	}
+	words = append(words, 0)
+	for _, v := range env {
+		words = append(words, strAddr(v))
+	}
+	words = append(words, 0)
+	for _, entry := range auxv {
+		if entry.Type == AT_RANDOM && !randomOverridden {
+			entry.Value = sp + uint32(vectorsSize)
+		}
+		words = append(words, entry.Type, entry.Value)
+	}
+	words = append(words, AT_NULL, 0)
+
+	if size := vectorsSize + data.Len(); size > memory.PageSize {
+		return fmt.Errorf("initial stack data of %d bytes does not fit in a page", size)
+	}
+	// allocate 1 page for the initial stack data, and 16KB = 4 pages for the stack to grow
+	if err := st.GetMemory().SetMemoryRange(sp-4*memory.PageSize, bytes.NewReader(make([]byte, 5*memory.PageSize))); err != nil {
+		return fmt.Errorf("failed to allocate page for stack content")
+	}
+	st.GetRegistersRef()[29] = sp
+
+	var stack bytes.Buffer
+	for _, w := range words {
+		_ = binary.Write(&stack, binary.BigEndian, w)
+	}
+	stack.Write(data.Bytes())
+	if err := st.GetMemory().SetMemoryRange(sp, &stack); err != nil {
+		return fmt.Errorf("failed to write initial stack data: %w", err)
+	}
+	return nil
+}
+
+// PatchSymbols patches out the functions with the given names, by returning immediately.
+// All symbols must be present in the program.
+func PatchSymbols(f *elf.File, st mipsevm.FPVMState, names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+	symbols, err := f.Symbols()
+	if err != nil {
+		return fmt.Errorf("failed to read symbols data, cannot patch program: %w", err)
+	}
+	for _, name := range names {
+		i := slices.IndexFunc(symbols, func(s elf.Symbol) bool { return s.Name == name })
+		if i < 0 {
+			return fmt.Errorf("symbol %q not found", name)
+		}
+		if err := patchReturn(st, uint32(symbols[i].Value)); err != nil {
+			return fmt.Errorf("failed to patch %s: %w", name, err)
+		}
+	}
+	return nil
+}
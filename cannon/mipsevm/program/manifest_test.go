@@ -0,0 +1,55 @@
+package program_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/singlethreaded"
+)
+
+func readString(mem *memory.Memory, addr uint32) string {
+	var out []byte
+	for {
+		word := mem.GetMemory(addr &^ 3)
+		b := byte(word >> (24 - 8*(addr&3)))
+		if b == 0 {
+			return string(out)
+		}
+		out = append(out, b)
+		addr++
+	}
+}
+
+func TestPatchStackWithManifest(t *testing.T) {
+	state := singlethreaded.CreateEmptyState()
+	manifest := &program.Manifest{
+		Args: []string{"guest", "--verbose"},
+		Env:  []string{"GOMAXPROCS=1"},
+		Auxv: []program.AuxvEntry{{Type: program.AT_PAGESZ, Value: 8192}, {Type: 16, Value: 0xabcd}},
+	}
+	require.NoError(t, program.PatchStackWithManifest(state, manifest))
+
+	mem := state.GetMemory()
+	sp := state.GetRegistersRef()[29]
+	require.Equal(t, uint32(0x7f_ff_d0_00), sp)
+	word := func(i uint32) uint32 { return mem.GetMemory(sp + 4*i) }
+	require.Equal(t, uint32(2), word(0), "argc")
+	require.Equal(t, "guest", readString(mem, word(1)))
+	require.Equal(t, "--verbose", readString(mem, word(2)))
+	require.Zero(t, word(3))
+	require.Equal(t, "GOMAXPROCS=1", readString(mem, word(4)))
+	require.Zero(t, word(5))
+	// auxv: overridden page size, default random value, and additional entry
+	require.Equal(t, []uint32{program.AT_PAGESZ, 8192, program.AT_RANDOM}, []uint32{word(6), word(7), word(8)})
+	require.Equal(t, "4;byfairdiceroll", readString(mem, word(9))[:16])
+	require.Equal(t, []uint32{16, 0xabcd, program.AT_NULL, 0}, []uint32{word(10), word(11), word(12), word(13)})
+}
+
+func TestPatchStackWithManifestTooLarge(t *testing.T) {
+	state := singlethreaded.CreateEmptyState()
+	manifest := &program.Manifest{Args: make([]string, memory.PageSize/4)}
+	require.ErrorContains(t, program.PatchStackWithManifest(state, manifest), "does not fit")
+}
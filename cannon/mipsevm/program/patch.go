@@ -38,13 +38,7 @@ func PatchGo(f *elf.File, st mipsevm.FPVMState) error {
 			"flag.init",
 			// We need to patch this out, we don't pass float64nan because we don't support floats
 			"runtime.check":
-			// MIPS32 patch: ret (pseudo instruction)
-			// 03e00008 = jr $ra = ret (pseudo instruction)
-			// 00000000 = nop (executes with delay-slot, but does nothing)
-			if err := st.GetMemory().SetMemoryRange(uint32(s.Value), bytes.NewReader([]byte{
-				0x03, 0xe0, 0x00, 0x08,
-				0, 0, 0, 0,
-			})); err != nil {
+			if err := patchReturn(st, uint32(s.Value)); err != nil {
 				return fmt.Errorf("failed to patch Go runtime.gcenable: %w", err)
 			}
 		}
@@ -52,6 +46,17 @@ func PatchGo(f *elf.File, st mipsevm.FPVMState) error {
 	return nil
 }
 
+// patchReturn patches the function at addr to return immediately.
+func patchReturn(st mipsevm.FPVMState, addr uint32) error {
+	// MIPS32 patch: ret (pseudo instruction)
+	// 03e00008 = jr $ra = ret (pseudo instruction)
+	// 00000000 = nop (executes with delay-slot, but does nothing)
+	return st.GetMemory().SetMemoryRange(addr, bytes.NewReader([]byte{
+		0x03, 0xe0, 0x00, 0x08,
+		0, 0, 0, 0,
+	}))
+}
+
 func PatchStack(st mipsevm.FPVMState) error {
 	// setup stack pointer
 	sp := uint32(0x7f_ff_d0_00)
@@ -303,6 +303,28 @@ func (s *State) Deserialize(in io.Reader) error {
 	return nil
 }
 
+// SerializeDiff writes the state like Serialize, but only includes the memory pages modified since the last MarkClean.
+func (s *State) SerializeDiff(out io.Writer) error {
+	diff := *s
+	diff.Memory = s.Memory.Diff()
+	return diff.Serialize(out)
+}
+
+// MarkClean marks the memory as unmodified, to track the pages to include in the next diff.
+func (s *State) MarkClean() {
+	s.Memory.MarkClean()
+}
+
+// ApplyBase completes a state decoded from a diff, with the unmodified memory pages of the base state.
+func (s *State) ApplyBase(base serialize.Incremental) error {
+	baseState, ok := base.(*State)
+	if !ok {
+		return fmt.Errorf("invalid base state type %T", base)
+	}
+	s.Memory.ApplyBase(baseState.Memory)
+	return nil
+}
+
 type StateWitness []byte
 
 func (sw StateWitness) StateHash() (common.Hash, error) {
@@ -3,6 +3,7 @@ package singlethreaded
 import (
 	"bytes"
 	"debug/elf"
+	"path/filepath"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -13,6 +14,7 @@ import (
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
+	"github.com/ethereum-optimism/optimism/cannon/serialize"
 )
 
 // Run through all permutations of `exited` / `exitCode` and ensure that the
@@ -148,3 +150,34 @@ func TestSerializeStateRoundTrip(t *testing.T) {
 	require.NoError(t, err, "must deserialize state")
 	require.Equal(t, state, state2, "must roundtrip state")
 }
+
+func TestStateDiff(t *testing.T) {
+	state := CreateInitialState(0x1000, 0x4000_0000)
+	for addr := uint32(0); addr < 0x10_0000; addr += memory.PageSize {
+		state.Memory.SetMemory(addr, addr)
+	}
+
+	dir := t.TempDir()
+	basePath := filepath.Join(dir, "base.bin.gz")
+	require.NoError(t, serialize.Write(basePath, state, 0o644))
+	state.MarkClean()
+
+	state.Step = 1000
+	state.Heap = 0x7000_0000
+	state.Memory.SetMemory(0x7000_0000, 0xdeadbeef)
+	diffPath := filepath.Join(dir, "diff.bin.gz")
+	require.NoError(t, serialize.WriteSerializedBinaryDiff(diffPath, basePath, state, 0o644))
+
+	diff := new(bytes.Buffer)
+	require.NoError(t, state.SerializeDiff(diff))
+	full := new(bytes.Buffer)
+	require.NoError(t, state.Serialize(full))
+	require.Less(t, diff.Len(), full.Len(), "diff only includes the modified page")
+
+	loaded, err := serialize.Load[State](diffPath)
+	require.NoError(t, err)
+	_, expectedHash := state.EncodeWitness()
+	_, loadedHash := loaded.EncodeWitness()
+	require.Equal(t, expectedHash, loadedHash)
+	require.Equal(t, state.Memory.PageCount(), loaded.Memory.PageCount())
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"reflect"
 
 	"github.com/ethereum-optimism/optimism/op-service/ioutil"
@@ -20,6 +21,10 @@ type Serializable interface {
 }
 
 func LoadSerializedBinary[X any](inputPath string) (*X, error) {
+	return loadSerializedBinary[X](inputPath, make(map[string]bool))
+}
+
+func loadSerializedBinary[X any](inputPath string, loaded map[string]bool) (*X, error) {
 	if inputPath == "" {
 		return nil, errors.New("no path specified")
 	}
@@ -35,14 +40,43 @@ func LoadSerializedBinary[X any](inputPath string) (*X, error) {
 	if !ok {
 		return nil, fmt.Errorf("%T is not a Serializable", x)
 	}
-	err = serializable.Deserialize(f)
+	in, basePath, err := readDiffHeader(f)
 	if err != nil {
+		return nil, fmt.Errorf("failed to read file %q: %w", inputPath, err)
+	}
+	if basePath == "" {
+		if err := serializable.Deserialize(in); err != nil {
+			return nil, err
+		}
+		return &x, nil
+	}
+	incremental, ok := serializable.(Incremental)
+	if !ok {
+		return nil, fmt.Errorf("%T is not Incremental, cannot load diff %q", x, inputPath)
+	}
+	if err := incremental.Deserialize(in); err != nil {
 		return nil, err
 	}
+	basePath = filepath.Join(filepath.Dir(inputPath), basePath)
+	if loaded[filepath.Clean(inputPath)] {
+		return nil, fmt.Errorf("diff %q depends on itself", inputPath)
+	}
+	loaded[filepath.Clean(inputPath)] = true
+	base, err := loadSerializedBinary[X](basePath, loaded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load base %q of diff %q: %w", basePath, inputPath, err)
+	}
+	if err := incremental.ApplyBase(any(base).(Incremental)); err != nil {
+		return nil, fmt.Errorf("failed to apply base %q to diff %q: %w", basePath, inputPath, err)
+	}
 	return &x, nil
 }
 
 func WriteSerializedBinary(outputPath string, value Serializable, perm os.FileMode) error {
+	return writeBinary(outputPath, perm, value.Serialize)
+}
+
+func writeBinary(outputPath string, perm os.FileMode, write func(out io.Writer) error) error {
 	if outputPath == "" {
 		return nil
 	}
@@ -64,7 +98,7 @@ func WriteSerializedBinary(outputPath string, value Serializable, perm os.FileMo
 		// so make sure we handle any errors it returns
 		finish = f.Close
 	}
-	err := value.Serialize(out)
+	err := write(out)
 	if err != nil {
 		return fmt.Errorf("failed to write binary: %w", err)
 	}
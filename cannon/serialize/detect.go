@@ -8,19 +8,20 @@ import (
 )
 
 func Load[X any](inputPath string) (*X, error) {
-	if isBinary(inputPath) {
+	if IsBinaryFile(inputPath) {
 		return LoadSerializedBinary[X](inputPath)
 	}
 	return jsonutil.LoadJSON[X](inputPath)
 }
 
 func Write[X Serializable](outputPath string, x X, perm os.FileMode) error {
-	if isBinary(outputPath) {
+	if IsBinaryFile(outputPath) {
 		return WriteSerializedBinary(outputPath, x, perm)
 	}
 	return jsonutil.WriteJSON[X](outputPath, x, perm)
 }
 
-func isBinary(path string) bool {
+// IsBinaryFile returns true if the path is for the binary format, rather than JSON.
+func IsBinaryFile(path string) bool {
 	return strings.HasSuffix(path, ".bin") || strings.HasSuffix(path, ".bin.gz")
 }
@@ -0,0 +1,100 @@
+package serialize
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum-optimism/optimism/op-service/ioutil"
+)
+
+// diffMagic prefixes binary diffs. It can not be confused with the start of a regular binary encoding,
+// which starts with a version byte.
+const diffMagic = "\xffdif"
+
+// Incremental defines functionality for a Serializable type that may also be serialized as a diff,
+// containing only the data modified since a previous serialization.
+type Incremental interface {
+	Serializable
+
+	// SerializeDiff encodes the type like Serialize, but only includes the data modified since the last MarkClean.
+	SerializeDiff(out io.Writer) error
+
+	// MarkClean marks all data as unmodified, to track the modifications for the next diff.
+	MarkClean()
+
+	// ApplyBase completes a type decoded from a diff, with the unmodified data of the base the diff was created against.
+	ApplyBase(base Incremental) error
+}
+
+// WriteSerializedBinaryDiff writes the value as a diff against the base file, which must be the last serialization
+// of the value before it was marked clean. The diff refers to the base by its path relative to the diff,
+// and is loaded, together with the base, by LoadSerializedBinary.
+// The format of a diff is:
+//
+//	magic            [4]byte
+//	len(base path)   uint32
+//	base path        []byte
+//	diff             as encoded by SerializeDiff
+func WriteSerializedBinaryDiff(outputPath string, basePath string, value Incremental, perm os.FileMode) error {
+	if outputPath == "-" {
+		return errors.New("cannot write diff to stdout, a diff must be stored next to its base")
+	}
+	relBase, err := filepath.Rel(filepath.Dir(outputPath), basePath)
+	if err != nil {
+		return fmt.Errorf("failed to determine path of base %q relative to diff %q: %w", basePath, outputPath, err)
+	}
+	return writeBinary(outputPath, perm, func(out io.Writer) error {
+		if _, err := io.WriteString(out, diffMagic); err != nil {
+			return err
+		}
+		if err := NewBinaryWriter(out).WriteBytes([]byte(relBase)); err != nil {
+			return err
+		}
+		return value.SerializeDiff(out)
+	})
+}
+
+// DiffBase returns the path of the base the diff at the given path was created against,
+// or an empty string if the file is not a diff.
+func DiffBase(inputPath string) (string, error) {
+	f, err := ioutil.OpenDecompressed(inputPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to open file %q: %w", inputPath, err)
+	}
+	defer f.Close()
+	_, basePath, err := readDiffHeader(f)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file %q: %w", inputPath, err)
+	}
+	if basePath == "" {
+		return "", nil
+	}
+	return filepath.Join(filepath.Dir(inputPath), basePath), nil
+}
+
+// readDiffHeader reads the header of a diff, if the input is a diff, and returns the path of the base and the
+// remaining input. If the input is not a diff, the returned base path is empty and the input is returned as is.
+func readDiffHeader(in io.Reader) (io.Reader, string, error) {
+	magic := make([]byte, len(diffMagic))
+	n, err := io.ReadFull(in, magic)
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return io.MultiReader(bytes.NewReader(magic[:n]), in), "", nil
+	} else if err != nil {
+		return nil, "", err
+	}
+	if string(magic) != diffMagic {
+		return io.MultiReader(bytes.NewReader(magic), in), "", nil
+	}
+	var basePath []byte
+	if err := NewBinaryReader(in).ReadBytes(&basePath); err != nil {
+		return nil, "", fmt.Errorf("failed to read base path of diff: %w", err)
+	}
+	if len(basePath) == 0 {
+		return nil, "", errors.New("diff has empty base path")
+	}
+	return in, string(basePath), nil
+}
@@ -0,0 +1,121 @@
+package serialize
+
+import (
+	"errors"
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoundTripBinaryDiff(t *testing.T) {
+	for _, ext := range []string{".bin", ".bin.gz"} {
+		ext := ext
+		t.Run(ext, func(t *testing.T) {
+			dir := t.TempDir()
+			data := &incrementalTestData{Values: [4]byte{1, 2, 3, 4}}
+			basePath := filepath.Join(dir, "base"+ext)
+			require.NoError(t, WriteSerializedBinary(basePath, data, 0644))
+			data.MarkClean()
+
+			data.Set(1, 5)
+			diffPath := filepath.Join(dir, "diff"+ext)
+			require.NoError(t, WriteSerializedBinaryDiff(diffPath, basePath, data, 0644))
+			data.MarkClean()
+
+			data.Set(3, 6)
+			nextDiffPath := filepath.Join(dir, "next"+ext)
+			require.NoError(t, WriteSerializedBinaryDiff(nextDiffPath, diffPath, data, 0644))
+
+			base, err := DiffBase(nextDiffPath)
+			require.NoError(t, err)
+			require.Equal(t, diffPath, base)
+			base, err = DiffBase(basePath)
+			require.NoError(t, err)
+			require.Empty(t, base, "full serialization has no base")
+
+			result, err := LoadSerializedBinary[incrementalTestData](diffPath)
+			require.NoError(t, err)
+			require.Equal(t, [4]byte{1, 5, 3, 4}, result.Values)
+
+			result, err = LoadSerializedBinary[incrementalTestData](nextDiffPath)
+			require.NoError(t, err)
+			require.Equal(t, [4]byte{1, 5, 3, 6}, result.Values)
+		})
+	}
+}
+
+func TestLoadBinaryDiffErrors(t *testing.T) {
+	dir := t.TempDir()
+	data := &incrementalTestData{Values: [4]byte{1, 2, 3, 4}}
+	diffPath := filepath.Join(dir, "diff.bin")
+
+	require.NoError(t, WriteSerializedBinaryDiff(diffPath, filepath.Join(dir, "missing.bin"), data, 0644))
+	_, err := LoadSerializedBinary[incrementalTestData](diffPath)
+	require.ErrorContains(t, err, "failed to load base")
+
+	require.NoError(t, WriteSerializedBinaryDiff(diffPath, diffPath, data, 0644))
+	_, err = LoadSerializedBinary[incrementalTestData](diffPath)
+	require.ErrorContains(t, err, "depends on itself")
+
+	_, err = LoadSerializedBinary[serializableTestData](diffPath)
+	require.ErrorContains(t, err, "is not Incremental")
+
+	require.ErrorContains(t, WriteSerializedBinaryDiff("-", diffPath, data, 0644), "cannot write diff to stdout")
+}
+
+// incrementalTestData has a fixed number of values, and diffs encode each modified value with its index.
+type incrementalTestData struct {
+	Values [4]byte
+	dirty  [4]bool
+}
+
+func (s *incrementalTestData) Set(i int, v byte) {
+	s.Values[i] = v
+	s.dirty[i] = true
+}
+
+func (s *incrementalTestData) Serialize(w io.Writer) error {
+	s.dirty = [4]bool{true, true, true, true}
+	return s.SerializeDiff(w)
+}
+
+func (s *incrementalTestData) SerializeDiff(w io.Writer) error {
+	for i, v := range s.Values {
+		if !s.dirty[i] {
+			continue
+		}
+		if _, err := w.Write([]byte{byte(i), v}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *incrementalTestData) Deserialize(in io.Reader) error {
+	var entry [2]byte
+	for {
+		if _, err := io.ReadFull(in, entry[:]); errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		s.Values[entry[0]] = entry[1]
+		s.dirty[entry[0]] = true
+	}
+}
+
+func (s *incrementalTestData) MarkClean() {
+	s.dirty = [4]bool{}
+}
+
+func (s *incrementalTestData) ApplyBase(base Incremental) error {
+	b := base.(*incrementalTestData)
+	for i := range s.Values {
+		if !s.dirty[i] {
+			s.Values[i] = b.Values[i]
+		}
+	}
+	return nil
+}
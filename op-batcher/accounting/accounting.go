@@ -0,0 +1,160 @@
+package accounting
+
+import (
+	"cmp"
+	"math/big"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	DACalldata = "calldata"
+	DABlob     = "blob"
+
+	// DefaultRetentionDays is the number of days of totals kept in memory by default.
+	DefaultRetentionDays = 30
+
+	dayFormat = "2006-01-02"
+)
+
+// TxCost is the data availability cost of a confirmed batch transaction.
+type TxCost struct {
+	TxHash common.Hash
+	// DA is the data availability type of the transaction, DACalldata or DABlob.
+	DA string
+	// Compression describes the compression settings of the channel the transaction data belongs to.
+	Compression   string
+	CalldataBytes uint64
+	BlobBytes     uint64
+	GasUsed       uint64
+	BlobGasUsed   uint64
+	// Cost is the total fee paid for the transaction in wei, including the blob fee.
+	Cost *big.Int
+}
+
+// NewTxCost computes the cost of a batch transaction from its receipt.
+// The data sizes are those of the transaction candidate, as they are not part of the receipt.
+func NewTxCost(receipt *types.Receipt, da string, compression string, calldataBytes, blobBytes uint64) TxCost {
+	cost := new(big.Int)
+	if receipt.EffectiveGasPrice != nil {
+		cost.Mul(receipt.EffectiveGasPrice, new(big.Int).SetUint64(receipt.GasUsed))
+	}
+	if receipt.BlobGasPrice != nil {
+		cost.Add(cost, new(big.Int).Mul(receipt.BlobGasPrice, new(big.Int).SetUint64(receipt.BlobGasUsed)))
+	}
+	return TxCost{
+		TxHash:        receipt.TxHash,
+		DA:            da,
+		Compression:   compression,
+		CalldataBytes: calldataBytes,
+		BlobBytes:     blobBytes,
+		GasUsed:       receipt.GasUsed,
+		BlobGasUsed:   receipt.BlobGasUsed,
+		Cost:          cost,
+	}
+}
+
+// DailyCost is the total cost of the batch transactions confirmed on a day (UTC),
+// for a data availability type and compression setting.
+type DailyCost struct {
+	Day           string       `json:"day"`
+	DA            string       `json:"da"`
+	Compression   string       `json:"compression"`
+	Txs           uint64       `json:"txs"`
+	CalldataBytes uint64       `json:"calldataBytes"`
+	BlobBytes     uint64       `json:"blobBytes"`
+	GasUsed       uint64       `json:"gasUsed"`
+	BlobGasUsed   uint64       `json:"blobGasUsed"`
+	Cost          *hexutil.Big `json:"cost"`
+}
+
+type Metricer interface {
+	RecordDACost(da string, compression string, calldataBytes, blobBytes, gasUsed, blobGasUsed uint64, cost *big.Int)
+}
+
+type dailyKey struct {
+	day         string
+	da          string
+	compression string
+}
+
+// Accountant records the cost of batch transactions, and aggregates it per day.
+// The totals of the last retentionDays days are kept in memory, so they are lost on restart.
+// Prometheus metrics are recorded for all transactions, for long-term accounting.
+type Accountant struct {
+	m             Metricer
+	retentionDays int
+	now           func() time.Time
+
+	mu     sync.Mutex
+	totals map[dailyKey]*DailyCost
+}
+
+func NewAccountant(m Metricer, retentionDays int) *Accountant {
+	return &Accountant{
+		m:             m,
+		retentionDays: retentionDays,
+		now:           time.Now,
+		totals:        make(map[dailyKey]*DailyCost),
+	}
+}
+
+// RecordTx adds the cost of a confirmed transaction to the totals of the current day.
+func (a *Accountant) RecordTx(tx TxCost) {
+	a.m.RecordDACost(tx.DA, tx.Compression, tx.CalldataBytes, tx.BlobBytes, tx.GasUsed, tx.BlobGasUsed, tx.Cost)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	now := a.now().UTC()
+	key := dailyKey{day: now.Format(dayFormat), da: tx.DA, compression: tx.Compression}
+	total, ok := a.totals[key]
+	if !ok {
+		total = &DailyCost{Day: key.day, DA: tx.DA, Compression: tx.Compression, Cost: (*hexutil.Big)(new(big.Int))}
+		a.totals[key] = total
+	}
+	total.Txs++
+	total.CalldataBytes += tx.CalldataBytes
+	total.BlobBytes += tx.BlobBytes
+	total.GasUsed += tx.GasUsed
+	total.BlobGasUsed += tx.BlobGasUsed
+	total.Cost.ToInt().Add(total.Cost.ToInt(), tx.Cost)
+	a.prune(now)
+}
+
+// prune drops the totals of days that are past the retention period.
+func (a *Accountant) prune(now time.Time) {
+	oldest := now.AddDate(0, 0, -a.retentionDays+1).Format(dayFormat)
+	for key := range a.totals {
+		// The day format sorts lexicographically.
+		if key.day < oldest {
+			delete(a.totals, key)
+		}
+	}
+}
+
+// Report returns the daily totals, ordered by day, data availability type and compression setting.
+func (a *Accountant) Report() []DailyCost {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	report := make([]DailyCost, 0, len(a.totals))
+	for _, total := range a.totals {
+		entry := *total
+		entry.Cost = (*hexutil.Big)(new(big.Int).Set(total.Cost.ToInt()))
+		report = append(report, entry)
+	}
+	slices.SortFunc(report, func(x, y DailyCost) int {
+		if c := cmp.Compare(x.Day, y.Day); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(x.DA, y.DA); c != 0 {
+			return c
+		}
+		return cmp.Compare(x.Compression, y.Compression)
+	})
+	return report
+}
@@ -0,0 +1,83 @@
+package accounting
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+type recordedCost struct {
+	da, compression                                string
+	calldataBytes, blobBytes, gasUsed, blobGasUsed uint64
+	cost                                           *big.Int
+}
+
+type testMetrics struct {
+	costs []recordedCost
+}
+
+func (m *testMetrics) RecordDACost(da string, compression string, calldataBytes, blobBytes, gasUsed, blobGasUsed uint64, cost *big.Int) {
+	m.costs = append(m.costs, recordedCost{da, compression, calldataBytes, blobBytes, gasUsed, blobGasUsed, cost})
+}
+
+func TestNewTxCost(t *testing.T) {
+	receipt := &types.Receipt{
+		TxHash:            common.Hash{0xaa},
+		GasUsed:           21_000,
+		EffectiveGasPrice: big.NewInt(10),
+		BlobGasUsed:       131_072,
+		BlobGasPrice:      big.NewInt(2),
+	}
+	cost := NewTxCost(receipt, DABlob, "shadow/brotli-10", 0, 1000)
+	require.Equal(t, TxCost{
+		TxHash:      common.Hash{0xaa},
+		DA:          DABlob,
+		Compression: "shadow/brotli-10",
+		BlobBytes:   1000,
+		GasUsed:     21_000,
+		BlobGasUsed: 131_072,
+		Cost:        big.NewInt(21_000*10 + 131_072*2),
+	}, cost)
+
+	receipt = &types.Receipt{GasUsed: 30_000, EffectiveGasPrice: big.NewInt(3)}
+	cost = NewTxCost(receipt, DACalldata, "ratio/zlib", 500, 0)
+	require.Equal(t, big.NewInt(90_000), cost.Cost, "calldata tx must not have a blob fee")
+}
+
+func TestAccountant(t *testing.T) {
+	m := new(testMetrics)
+	a := NewAccountant(m, 2)
+	now := time.Date(2024, 9, 1, 23, 0, 0, 0, time.UTC)
+	a.now = func() time.Time { return now }
+
+	blobTx := TxCost{DA: DABlob, Compression: "shadow/brotli-10", BlobBytes: 1000, GasUsed: 21_000, BlobGasUsed: 131_072, Cost: big.NewInt(100)}
+	calldataTx := TxCost{DA: DACalldata, Compression: "shadow/brotli-10", CalldataBytes: 500, GasUsed: 30_000, Cost: big.NewInt(50)}
+	a.RecordTx(blobTx)
+	a.RecordTx(blobTx)
+	a.RecordTx(calldataTx)
+	require.Len(t, m.costs, 3, "metrics must be recorded for each tx")
+
+	now = now.Add(2 * time.Hour)
+	a.RecordTx(blobTx)
+	require.Equal(t, []DailyCost{
+		{Day: "2024-09-01", DA: DABlob, Compression: "shadow/brotli-10", Txs: 2, BlobBytes: 2000, GasUsed: 42_000, BlobGasUsed: 262_144, Cost: (*hexutil.Big)(big.NewInt(200))},
+		{Day: "2024-09-01", DA: DACalldata, Compression: "shadow/brotli-10", Txs: 1, CalldataBytes: 500, GasUsed: 30_000, Cost: (*hexutil.Big)(big.NewInt(50))},
+		{Day: "2024-09-02", DA: DABlob, Compression: "shadow/brotli-10", Txs: 1, BlobBytes: 1000, GasUsed: 21_000, BlobGasUsed: 131_072, Cost: (*hexutil.Big)(big.NewInt(100))},
+	}, a.Report())
+
+	report := a.Report()
+	report[0].Cost.ToInt().SetUint64(0)
+	require.Equal(t, big.NewInt(200), a.Report()[0].Cost.ToInt(), "report must not alias the totals")
+
+	now = now.AddDate(0, 0, 1)
+	a.RecordTx(calldataTx)
+	report = a.Report()
+	require.Len(t, report, 2, "days past the retention period must be pruned")
+	require.Equal(t, "2024-09-02", report[0].Day)
+	require.Equal(t, "2024-09-03", report[1].Day)
+}
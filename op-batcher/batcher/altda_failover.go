@@ -0,0 +1,85 @@
+package batcher
+
+import (
+	"sync"
+	"time"
+)
+
+// altDAFailover tracks the availability of the alt-DA server. Once posting inputs to the server failed for
+// longer than the grace period, the batcher fails over to posting the batch data directly to L1.
+// While failed over, the server is tried again once per grace period, and commitments are posted again
+// as soon as it accepts an input.
+type altDAFailover struct {
+	// gracePeriod is the time the server may be unavailable before failing over. Failover is disabled if 0.
+	gracePeriod time.Duration
+	now         func() time.Time
+
+	mu sync.Mutex
+	// firstFailure is the time of the first of the consecutive failures to post an input. Zero if the last post succeeded.
+	firstFailure time.Time
+	active       bool
+	lastAttempt  time.Time
+}
+
+func newAltDAFailover(gracePeriod time.Duration) *altDAFailover {
+	return &altDAFailover{
+		gracePeriod: gracePeriod,
+		now:         time.Now,
+	}
+}
+
+// tryAltDA returns whether the next input should be posted to the alt-DA server.
+// It is false while failed over, except once per grace period to check whether the server recovered.
+func (f *altDAFailover) tryAltDA() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.active {
+		return true
+	}
+	now := f.now()
+	if now.Sub(f.lastAttempt) < f.gracePeriod {
+		return false
+	}
+	f.lastAttempt = now
+	return true
+}
+
+// recordFailure records a failure to post an input to the alt-DA server, and returns whether the
+// batcher is failed over, so the input should be posted to L1 instead of retrying it later.
+func (f *altDAFailover) recordFailure() (failedOver bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.gracePeriod == 0 {
+		return false
+	}
+	now := f.now()
+	if f.firstFailure.IsZero() {
+		f.firstFailure = now
+	}
+	if !f.active && now.Sub(f.firstFailure) >= f.gracePeriod {
+		f.active = true
+		f.lastAttempt = now
+	}
+	return f.active
+}
+
+// recordSuccess records that an input was posted to the alt-DA server,
+// and returns whether the batcher was failed over until now.
+func (f *altDAFailover) recordSuccess() (recovered bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	recovered = f.active
+	f.firstFailure = time.Time{}
+	f.active = false
+	return recovered
+}
+
+// outage returns the time since the first of the consecutive failures to post an input, or 0 if the last post succeeded.
+func (f *altDAFailover) outage() time.Duration {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.firstFailure.IsZero() {
+		return 0
+	}
+	return f.now().Sub(f.firstFailure)
+}
@@ -0,0 +1,51 @@
+package batcher
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAltDAFailover(t *testing.T) {
+	now := time.Unix(1000, 0)
+	f := newAltDAFailover(time.Minute)
+	f.now = func() time.Time { return now }
+
+	require.True(t, f.tryAltDA())
+	require.False(t, f.recordFailure(), "no failover within grace period")
+	now = now.Add(30 * time.Second)
+	require.True(t, f.tryAltDA())
+	require.False(t, f.recordFailure(), "no failover within grace period")
+	require.Equal(t, 30*time.Second, f.outage())
+
+	now = now.Add(30 * time.Second)
+	require.True(t, f.tryAltDA())
+	require.True(t, f.recordFailure(), "failover after grace period")
+	require.False(t, f.tryAltDA(), "server not retried while failed over")
+
+	now = now.Add(time.Minute)
+	require.True(t, f.tryAltDA(), "server retried once per grace period")
+	require.False(t, f.tryAltDA())
+	require.True(t, f.recordFailure(), "still failed over")
+
+	now = now.Add(time.Minute)
+	require.True(t, f.tryAltDA())
+	require.True(t, f.recordSuccess(), "recovered from failover")
+	require.True(t, f.tryAltDA())
+	require.Zero(t, f.outage())
+
+	require.False(t, f.recordFailure(), "grace period restarts after recovery")
+	require.False(t, f.recordSuccess())
+}
+
+func TestAltDAFailoverDisabled(t *testing.T) {
+	now := time.Unix(1000, 0)
+	f := newAltDAFailover(0)
+	f.now = func() time.Time { return now }
+	for i := 0; i < 3; i++ {
+		require.True(t, f.tryAltDA())
+		require.False(t, f.recordFailure())
+		now = now.Add(time.Hour)
+	}
+}
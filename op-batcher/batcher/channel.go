@@ -3,11 +3,13 @@ package batcher
 import (
 	"fmt"
 	"math"
+	"slices"
 
 	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 )
@@ -23,6 +25,8 @@ type channel struct {
 	channelBuilder *ChannelBuilder
 	// Set of unconfirmed txID -> tx data. For tx resubmission
 	pendingTransactions map[string]txData
+	// Hashes of all published versions of the pending transactions. For finding them on L1 after a restart
+	publishedTxs map[string][]common.Hash
 	// Set of confirmed txID -> inclusion block. For determining if the channel is timed out
 	confirmedTransactions map[string]eth.BlockID
 
@@ -46,6 +50,7 @@ func newChannel(log log.Logger, metr metrics.Metricer, cfg ChannelConfig, rollup
 		cfg:                   cfg,
 		channelBuilder:        cb,
 		pendingTransactions:   make(map[string]txData),
+		publishedTxs:          make(map[string][]common.Hash),
 		confirmedTransactions: make(map[string]eth.BlockID),
 	}, nil
 }
@@ -55,11 +60,13 @@ func newChannel(log log.Logger, metr metrics.Metricer, cfg ChannelConfig, rollup
 func (s *channel) TxFailed(id string) {
 	if data, ok := s.pendingTransactions[id]; ok {
 		s.log.Trace("marked transaction as failed", "id", id)
-		// Note: when the batcher is changed to send multiple frames per tx,
-		// this needs to be changed to iterate over all frames of the tx data
-		// and re-queue them.
-		s.channelBuilder.PushFrames(data.Frames()...)
 		delete(s.pendingTransactions, id)
+		delete(s.publishedTxs, id)
+		if s.cfg.StrictOrdering {
+			s.rewindFrames(data)
+		} else {
+			s.channelBuilder.PushFrames(data.Frames()...)
+		}
 	} else {
 		s.log.Warn("unknown transaction marked as failed", "id", id)
 	}
@@ -67,6 +74,24 @@ func (s *channel) TxFailed(id string) {
 	s.metr.RecordBatchTxFailed()
 }
 
+// rewindFrames queues the frames of a failed transaction for resubmission, together with the frames of all
+// transactions of later frames that are still pending. Holocene drops frames that are not consecutive,
+// so later frames that are included before the failed frames are resubmitted are dropped, and must be resubmitted too.
+// Pending transactions of rewound frames are no longer tracked, and are ignored if they get confirmed.
+func (s *channel) rewindFrames(failed txData) {
+	first := failed.Frames()[0].id.frameNumber
+	frames := failed.Frames()
+	for id, data := range s.pendingTransactions {
+		if data.Frames()[0].id.frameNumber > first {
+			frames = append(frames, data.Frames()...)
+			delete(s.pendingTransactions, id)
+			delete(s.publishedTxs, id)
+		}
+	}
+	s.log.Info("Rewinding frames for resubmission", "id", s.ID(), "from_frame", first, "num_frames", len(frames))
+	s.channelBuilder.RewindFrames(frames...)
+}
+
 // TxConfirmed marks a transaction as confirmed on L1. Unfortunately even if all frames in
 // a channel have been marked as confirmed on L1 the channel may be invalid & need to be
 // resubmitted.
@@ -81,6 +106,7 @@ func (s *channel) TxConfirmed(id string, inclusionBlock eth.BlockID) (bool, []*t
 		return false, nil
 	}
 	delete(s.pendingTransactions, id)
+	delete(s.publishedTxs, id)
 	s.confirmedTransactions[id] = inclusionBlock
 	s.confirmedTxUpdated = true
 	s.channelBuilder.FramePublished(inclusionBlock.Number)
@@ -102,6 +128,17 @@ func (s *channel) TxConfirmed(id string, inclusionBlock eth.BlockID) (bool, []*t
 	return false, nil
 }
 
+// TxPublished records the hash of a published version of a pending transaction.
+func (s *channel) TxPublished(id string, hash common.Hash) {
+	if _, ok := s.pendingTransactions[id]; !ok {
+		s.log.Warn("unknown transaction marked as published", "id", id, "tx", hash)
+		return
+	}
+	if !slices.Contains(s.publishedTxs[id], hash) {
+		s.publishedTxs[id] = append(s.publishedTxs[id], hash)
+	}
+}
+
 // Timeout returns the channel timeout L1 block number. If there is no timeout set, it returns 0.
 func (s *channel) Timeout() uint64 {
 	return s.channelBuilder.Timeout()
@@ -177,6 +214,10 @@ func (s *channel) NextTxData() txData {
 }
 
 func (s *channel) HasTxData() bool {
+	if s.cfg.StrictOrdering {
+		// Frames are only submitted once the channel is closed and passed the self-check.
+		return s.IsFull() && s.CheckErr() == nil && s.channelBuilder.HasFrame()
+	}
 	if s.IsFull() || !s.cfg.UseBlobs {
 		return s.channelBuilder.HasFrame()
 	}
@@ -192,6 +233,10 @@ func (s *channel) FullErr() error {
 	return s.channelBuilder.FullErr()
 }
 
+func (s *channel) CheckErr() error {
+	return s.channelBuilder.CheckErr()
+}
+
 func (s *channel) CheckTimeout(l1BlockNum uint64) {
 	s.channelBuilder.CheckTimeout(l1BlockNum)
 }
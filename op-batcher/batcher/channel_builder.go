@@ -2,10 +2,12 @@ package batcher
 
 import (
 	"bytes"
+	"cmp"
 	"errors"
 	"fmt"
 	"io"
 	"math"
+	"slices"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
@@ -20,6 +22,7 @@ var (
 	ErrChannelTimeoutClose   = errors.New("close to channel timeout")
 	ErrSeqWindowClose        = errors.New("close to sequencer window timeout")
 	ErrTerminated            = errors.New("channel terminated")
+	ErrChannelRestored       = errors.New("channel restored from state directory")
 )
 
 type ChannelFullError struct {
@@ -80,6 +83,8 @@ type ChannelBuilder struct {
 	numFrames int
 	// total amount of output data of all frames created yet
 	outputBytes int
+	// checkErr is set if the channel failed the self-check when it was closed, with strict ordering
+	checkErr error
 }
 
 // NewChannelBuilder creates a new channel builder or returns an error if the
@@ -94,10 +99,14 @@ func NewChannelBuilder(cfg ChannelConfig, rollupCfg rollup.Config, latestL1Origi
 	chainSpec := rollup.NewChainSpec(&rollupCfg)
 	var co derive.ChannelOut
 	if cfg.BatchType == derive.SpanBatchType {
+		opts := []derive.SpanChannelOutOption{derive.WithMaxBlocksPerSpanBatch(cfg.MaxBlocksPerSpanBatch)}
+		if cfg.SplitSpansAtEpochs {
+			opts = append(opts, derive.WithSpanBatchSplitAtEpochs())
+		}
 		co, err = derive.NewSpanChannelOut(
 			rollupCfg.Genesis.L2Time, rollupCfg.L2ChainID,
 			cfg.CompressorConfig.TargetOutputSize, cfg.CompressorConfig.CompressionAlgo,
-			chainSpec, derive.WithMaxBlocksPerSpanBatch(cfg.MaxBlocksPerSpanBatch))
+			chainSpec, opts...)
 	} else {
 		co, err = derive.NewSingularChannelOut(c, chainSpec)
 	}
@@ -301,7 +310,8 @@ func (c *ChannelBuilder) IsFull() bool {
 //   - ErrMaxDurationReached if the max channel duration got reached,
 //   - ErrChannelTimeoutClose if the consensus channel timeout got too close,
 //   - ErrSeqWindowClose if the end of the sequencer window got too close,
-//   - ErrTerminated if the channel was explicitly terminated.
+//   - ErrTerminated if the channel was explicitly terminated,
+//   - ErrChannelRestored if the closed channel was restored from the state directory.
 func (c *ChannelBuilder) FullErr() error {
 	return c.fullErr
 }
@@ -354,11 +364,25 @@ func (c *ChannelBuilder) closeAndOutputAllFrames() error {
 
 	for {
 		if err := c.outputFrame(); err == io.EOF {
-			return nil
+			break
 		} else if err != nil {
 			return err
 		}
 	}
+	if c.cfg.StrictOrdering {
+		if len(c.frames) != c.numFrames {
+			c.checkErr = fmt.Errorf("%w: %d frames were taken before the channel was closed", ErrChannelCheckFailed, c.numFrames-len(c.frames))
+		} else {
+			c.checkErr = checkChannel(&c.rollupCfg, c.frames, c.blocks)
+		}
+	}
+	return nil
+}
+
+// CheckErr returns the reason the channel failed the self-check, with strict ordering.
+// Its frames must not be submitted then.
+func (c *ChannelBuilder) CheckErr() error {
+	return c.checkErr
 }
 
 // outputFrame creates one new frame and adds it to the frames queue.
@@ -434,6 +458,19 @@ func (c *ChannelBuilder) NextFrame() frameData {
 	return f
 }
 
+// RewindFrames adds the frames back to the front of the internal frames queue, ordered by frame number,
+// so they are resubmitted before any frames that were not submitted yet. Panics if not of the same channel.
+func (c *ChannelBuilder) RewindFrames(frames ...frameData) {
+	for _, f := range frames {
+		if f.id.chID != c.ID() {
+			panic("wrong channel")
+		}
+	}
+	frames = slices.Clone(frames)
+	slices.SortFunc(frames, func(a, b frameData) int { return cmp.Compare(a.id.frameNumber, b.id.frameNumber) })
+	c.frames = append(frames, c.frames...)
+}
+
 // PushFrames adds the frames back to the internal frames queue. Panics if not of
 // the same channel.
 func (c *ChannelBuilder) PushFrames(frames ...frameData) {
@@ -442,7 +442,7 @@ func TestChannelBuilder_OutputFrames_SpanBatch(t *testing.T) {
 func ChannelBuilder_OutputFrames_SpanBatch(t *testing.T, algo derive.CompressionAlgo) {
 	channelConfig := defaultTestChannelConfig()
 	channelConfig.MaxFrameSize = 20 + derive.FrameV0OverHeadSize
-	if algo.IsBrotli() {
+	if algo.IsBrotli() || algo.IsZstd() {
 		channelConfig.TargetNumFrames = 3
 	} else {
 		channelConfig.TargetNumFrames = 5
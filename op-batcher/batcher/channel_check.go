@@ -0,0 +1,136 @@
+package batcher
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var ErrChannelCheckFailed = errors.New("channel failed self-check")
+
+// checkChannel re-derives the batches of a closed channel from its frames, like the derivation pipeline does,
+// and checks that they hold exactly the blocks of the channel, in order.
+// It also checks Holocene's frame rules: frames must be numbered consecutively from zero,
+// and only the last frame may close the channel.
+// Fork activations are checked at the time of the latest block, as the channel is included on L1 after it.
+func checkChannel(rollupCfg *rollup.Config, frames []frameData, blocks []*types.Block) error {
+	if len(blocks) == 0 {
+		return fmt.Errorf("%w: channel has no blocks", ErrChannelCheckFailed)
+	}
+	for i := 1; i < len(blocks); i++ {
+		if blocks[i].ParentHash() != blocks[i-1].Hash() {
+			return fmt.Errorf("%w: block %s does not extend block %s", ErrChannelCheckFailed, eth.ToBlockID(blocks[i]), eth.ToBlockID(blocks[i-1]))
+		}
+	}
+	if len(frames) == 0 {
+		return fmt.Errorf("%w: channel has no frames", ErrChannelCheckFailed)
+	}
+
+	var ch *derive.Channel
+	for i, fd := range frames {
+		var frame derive.Frame
+		r := bytes.NewReader(fd.data)
+		if err := frame.UnmarshalBinary(r); err != nil {
+			return fmt.Errorf("%w: failed to decode frame %d: %w", ErrChannelCheckFailed, i, err)
+		}
+		if r.Len() != 0 {
+			return fmt.Errorf("%w: frame %d has %d trailing bytes", ErrChannelCheckFailed, i, r.Len())
+		}
+		if frame.FrameNumber != uint16(i) {
+			return fmt.Errorf("%w: frame %d has number %d", ErrChannelCheckFailed, i, frame.FrameNumber)
+		}
+		if frame.IsLast != (i == len(frames)-1) {
+			return fmt.Errorf("%w: frame %d has is_last %v", ErrChannelCheckFailed, i, frame.IsLast)
+		}
+		if ch == nil {
+			ch = derive.NewChannel(frame.ID, eth.L1BlockRef{})
+		}
+		if err := ch.AddFrame(frame, eth.L1BlockRef{}); err != nil {
+			return fmt.Errorf("%w: failed to add frame %d: %w", ErrChannelCheckFailed, i, err)
+		}
+	}
+	if !ch.IsReady() {
+		return fmt.Errorf("%w: channel is not complete", ErrChannelCheckFailed)
+	}
+
+	t := blocks[len(blocks)-1].Time()
+	spec := rollup.NewChainSpec(rollupCfg)
+	nextBatch, err := derive.BatchReader(ch.Reader(), spec.MaxRLPBytesPerChannel(t), rollupCfg.IsFjord(t), rollupCfg.IsZstd(t))
+	if err != nil {
+		return fmt.Errorf("%w: failed to read channel: %w", ErrChannelCheckFailed, err)
+	}
+	// next is the index of the next block expected in the channel
+	next := 0
+	expectBlock := func(timestamp uint64, epochNum uint64, txs []hexutil.Bytes) (*derive.SingularBatch, error) {
+		if next >= len(blocks) {
+			return nil, fmt.Errorf("%w: channel holds more than the %d blocks added", ErrChannelCheckFailed, len(blocks))
+		}
+		block := blocks[next]
+		expected, _, err := derive.BlockToSingularBatch(rollupCfg, block)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert block %s to batch: %w", eth.ToBlockID(block), err)
+		}
+		if timestamp != expected.Timestamp || epochNum != uint64(expected.EpochNum) {
+			return nil, fmt.Errorf("%w: batch %d has timestamp %d and epoch %d, but block %s has %d and %d", ErrChannelCheckFailed,
+				next, timestamp, epochNum, eth.ToBlockID(block), expected.Timestamp, expected.EpochNum)
+		}
+		if !slices.EqualFunc(txs, expected.Transactions, func(a, b hexutil.Bytes) bool { return bytes.Equal(a, b) }) {
+			return nil, fmt.Errorf("%w: transactions of batch %d do not match block %s", ErrChannelCheckFailed, next, eth.ToBlockID(block))
+		}
+		next++
+		return expected, nil
+	}
+	for {
+		batchData, err := nextBatch()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return fmt.Errorf("%w: failed to decode batch: %w", ErrChannelCheckFailed, err)
+		}
+		switch batchData.GetBatchType() {
+		case derive.SingularBatchType:
+			batch, err := derive.GetSingularBatch(batchData)
+			if err != nil {
+				return fmt.Errorf("%w: failed to decode singular batch: %w", ErrChannelCheckFailed, err)
+			}
+			expected, err := expectBlock(batch.Timestamp, uint64(batch.EpochNum), batch.Transactions)
+			if err != nil {
+				return err
+			}
+			if batch.ParentHash != expected.ParentHash || batch.EpochHash != expected.EpochHash {
+				return fmt.Errorf("%w: parent or epoch hash of batch %d does not match", ErrChannelCheckFailed, next-1)
+			}
+		case derive.SpanBatchType:
+			if !rollupCfg.IsDelta(t) {
+				return fmt.Errorf("%w: span batch before Delta", ErrChannelCheckFailed)
+			}
+			batch, err := derive.DeriveSpanBatch(batchData, rollupCfg.BlockTime, rollupCfg.Genesis.L2Time, rollupCfg.L2ChainID)
+			if err != nil {
+				return fmt.Errorf("%w: failed to decode span batch: %w", ErrChannelCheckFailed, err)
+			}
+			for i := 0; i < batch.GetBlockCount(); i++ {
+				expected, err := expectBlock(batch.GetBlockTimestamp(i), batch.GetBlockEpochNum(i), batch.GetBlockTransactions(i))
+				if err != nil {
+					return err
+				}
+				if i == 0 && !batch.CheckParentHash(expected.ParentHash) {
+					return fmt.Errorf("%w: parent check of span batch does not match", ErrChannelCheckFailed)
+				}
+			}
+		default:
+			return fmt.Errorf("%w: unknown batch type %d", ErrChannelCheckFailed, batchData.GetBatchType())
+		}
+	}
+	if next != len(blocks) {
+		return fmt.Errorf("%w: channel holds %d of the %d blocks added", ErrChannelCheckFailed, next, len(blocks))
+	}
+	return nil
+}
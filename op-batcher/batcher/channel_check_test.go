@@ -0,0 +1,112 @@
+package batcher
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+// newMiniL2Chain returns n minimal L2 blocks, each extending the previous one.
+func newMiniL2Chain(n int) []*types.Block {
+	blocks := make([]*types.Block, 0, n)
+	parent := newMiniL2Block(0)
+	blocks = append(blocks, parent)
+	for i := 1; i < n; i++ {
+		parent = newMiniL2BlockWithNumberParent(1, big.NewInt(int64(i)), parent.Hash())
+		blocks = append(blocks, parent)
+	}
+	return blocks
+}
+
+func strictTestChannelConfig() ChannelConfig {
+	cfg := defaultTestChannelConfig()
+	cfg.MaxFrameSize = 60
+	cfg.TargetNumFrames = 1000
+	cfg.StrictOrdering = true
+	cfg.InitNoneCompressor()
+	return cfg
+}
+
+func TestCheckChannel(t *testing.T) {
+	blocks := newMiniL2Chain(3)
+	cb, err := NewChannelBuilder(strictTestChannelConfig(), defaultTestRollupConfig, latestL1BlockOrigin)
+	require.NoError(t, err)
+	for _, block := range blocks {
+		_, err := cb.AddBlock(block)
+		require.NoError(t, err)
+	}
+	cb.Close()
+	require.NoError(t, cb.OutputFrames())
+	require.NoError(t, cb.CheckErr())
+	require.Greater(t, len(cb.frames), 1)
+
+	t.Run("ReorderedFrames", func(t *testing.T) {
+		frames := append([]frameData{cb.frames[1], cb.frames[0]}, cb.frames[2:]...)
+		require.ErrorIs(t, checkChannel(&defaultTestRollupConfig, frames, blocks), ErrChannelCheckFailed)
+	})
+
+	t.Run("MissingFrame", func(t *testing.T) {
+		require.ErrorIs(t, checkChannel(&defaultTestRollupConfig, cb.frames[:len(cb.frames)-1], blocks), ErrChannelCheckFailed)
+	})
+
+	t.Run("MissingBlock", func(t *testing.T) {
+		require.ErrorIs(t, checkChannel(&defaultTestRollupConfig, cb.frames, blocks[:2]), ErrChannelCheckFailed)
+	})
+
+	t.Run("DifferentBlock", func(t *testing.T) {
+		other := newMiniL2BlockWithNumberParent(2, big.NewInt(2), blocks[1].Hash())
+		require.ErrorIs(t, checkChannel(&defaultTestRollupConfig, cb.frames, []*types.Block{blocks[0], blocks[1], other}), ErrChannelCheckFailed)
+	})
+}
+
+func TestChannelBuilder_RewindFrames(t *testing.T) {
+	cb, err := NewChannelBuilder(strictTestChannelConfig(), defaultTestRollupConfig, latestL1BlockOrigin)
+	require.NoError(t, err)
+	require.NoError(t, addMiniBlock(cb))
+	cb.Close()
+	require.NoError(t, cb.OutputFrames())
+	require.GreaterOrEqual(t, cb.PendingFrames(), 3)
+
+	f0, f1, f2 := cb.NextFrame(), cb.NextFrame(), cb.NextFrame()
+	cb.RewindFrames(f2, f1)
+	require.Equal(t, f1, cb.NextFrame())
+	require.Equal(t, f2, cb.NextFrame())
+
+	require.Panics(t, func() {
+		f0.id.chID = derive.ChannelID{0xff}
+		cb.RewindFrames(f0)
+	})
+}
+
+func TestChannel_StrictOrdering(t *testing.T) {
+	log := testlog.Logger(t, log.LevelCrit)
+	c, err := newChannel(log, metrics.NoopMetrics, strictTestChannelConfig(), &defaultTestRollupConfig, latestL1BlockOrigin)
+	require.NoError(t, err)
+	for _, block := range newMiniL2Chain(2) {
+		_, err := c.AddBlock(block)
+		require.NoError(t, err)
+	}
+	require.NoError(t, c.OutputFrames())
+	require.False(t, c.HasTxData(), "frames must not be submitted before the channel is closed")
+
+	c.Close()
+	require.NoError(t, c.OutputFrames())
+	require.NoError(t, c.CheckErr())
+	require.True(t, c.HasTxData())
+
+	tx0, tx1, tx2 := c.NextTxData(), c.NextTxData(), c.NextTxData()
+	c.TxConfirmed(tx0.ID().String(), eth.BlockID{Number: latestL1BlockOrigin + 1})
+
+	// A failed frame rewinds all later frames that are still pending.
+	c.TxFailed(tx1.ID().String())
+	require.Empty(t, c.pendingTransactions)
+	require.Equal(t, tx1, c.NextTxData())
+	require.Equal(t, tx2, c.NextTxData())
+}
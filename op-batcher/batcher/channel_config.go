@@ -32,6 +32,12 @@ type ChannelConfig struct {
 	// MaxBlocksPerSpanBatch is the maximum number of blocks to add to a span batch.
 	// A value of 0 disables a maximum.
 	MaxBlocksPerSpanBatch int
+	// SplitSpansAtEpochs starts a new span batch at an epoch boundary if that
+	// improves the compression of the channel.
+	SplitSpansAtEpochs bool
+	// SingularBatchFallback creates channels of singular batches instead of span
+	// batches while Delta isn't active yet, when span batches are invalid.
+	SingularBatchFallback bool
 
 	// Target number of frames to create per channel.
 	// For blob transactions, this controls the number of blobs to target adding
@@ -49,6 +55,11 @@ type ChannelConfig struct {
 	// UseBlobs indicates that this channel should be sent as a multi-blob
 	// transaction with one blob per frame.
 	UseBlobs bool
+
+	// StrictOrdering enforces Holocene's strict ordering rules, which are also valid before Holocene:
+	// frames are only submitted once the channel is closed and re-derived locally, frames are submitted in order,
+	// and the frames of a channel are only submitted once all frames of the previous channel are confirmed.
+	StrictOrdering bool
 }
 
 // ChannelConfig returns a copy of itself. This makes a ChannelConfig a static
@@ -92,6 +103,33 @@ func (cc *ChannelConfig) ReinitCompressorConfig() {
 	)
 }
 
+// compressionSettings describes the compressor configuration, to attribute DA costs to it.
+func (cc *ChannelConfig) compressionSettings() string {
+	kind := cc.CompressorConfig.Kind
+	switch kind {
+	case compressor.NoneKind:
+		return kind
+	case "":
+		kind = compressor.RatioKind
+	}
+	return fmt.Sprintf("%s/%s", kind, cc.CompressorConfig.CompressionAlgo)
+}
+
+// batchSettings describes the batch configuration, to compare the compression ratios achieved with it.
+func (cc *ChannelConfig) batchSettings() string {
+	if cc.BatchType != derive.SpanBatchType {
+		return "singular"
+	}
+	settings := "span"
+	if cc.MaxBlocksPerSpanBatch > 0 {
+		settings += fmt.Sprintf("/max-blocks-%d", cc.MaxBlocksPerSpanBatch)
+	}
+	if cc.SplitSpansAtEpochs {
+		settings += "/epoch-split"
+	}
+	return settings
+}
+
 func (cc *ChannelConfig) MaxFramesPerTx() int {
 	if !cc.UseBlobs {
 		return 1
@@ -10,7 +10,12 @@ import (
 	"github.com/ethereum/go-ethereum/params"
 )
 
-const randomByteCalldataGas = params.TxDataNonZeroGasEIP2028
+const (
+	randomByteCalldataGas = params.TxDataNonZeroGasEIP2028
+
+	// switchThresholdDenominator is the precision of the DA switch threshold, in basis points.
+	switchThresholdDenominator = 10_000
+)
 
 type (
 	ChannelConfigProvider interface {
@@ -21,6 +26,19 @@ type (
 		SuggestGasPriceCaps(ctx context.Context) (tipCap *big.Int, baseFee *big.Int, blobBaseFee *big.Int, err error)
 	}
 
+	// PendingDataChannelConfigProvider is a ChannelConfigProvider that also takes the size of the
+	// batch data pending submission into account when configuring a new channel.
+	PendingDataChannelConfigProvider interface {
+		ChannelConfigProvider
+		PendingDataChannelConfig(pendingBytes uint64) ChannelConfig
+	}
+
+	// blobBaseFeeSampler is implemented by providers that sample the blob base fee, so providers
+	// wrapping them can reuse the sample instead of querying the gas pricer again.
+	blobBaseFeeSampler interface {
+		LastBlobBaseFee() *big.Int
+	}
+
 	DynamicEthChannelConfig struct {
 		log       log.Logger
 		timeout   time.Duration // query timeout
@@ -29,19 +47,31 @@ type (
 		blobConfig     ChannelConfig
 		calldataConfig ChannelConfig
 		lastConfig     *ChannelConfig
+		// blob base fee sampled by the last gas price query, nil before the first one or if it failed
+		lastBlobBaseFee *big.Int
+
+		// switch threshold in basis points, see NewDynamicEthChannelConfig
+		switchThreshold int64
 	}
 )
 
+// NewDynamicEthChannelConfig creates a ChannelConfigProvider that picks the cheaper
+// of the blob and calldata config for every new channel.
+// The switchThreshold is the relative cost advantage, e.g. 0.1 for 10%, that the
+// other DA type needs to have over the last used one before switching to it.
+// This avoids flapping between DA types when their costs are close.
 func NewDynamicEthChannelConfig(lgr log.Logger,
 	reqTimeout time.Duration, gasPricer GasPricer,
 	blobConfig ChannelConfig, calldataConfig ChannelConfig,
+	switchThreshold float64,
 ) *DynamicEthChannelConfig {
 	dec := &DynamicEthChannelConfig{
-		log:            lgr,
-		timeout:        reqTimeout,
-		gasPricer:      gasPricer,
-		blobConfig:     blobConfig,
-		calldataConfig: calldataConfig,
+		log:             lgr,
+		timeout:         reqTimeout,
+		gasPricer:       gasPricer,
+		blobConfig:      blobConfig,
+		calldataConfig:  calldataConfig,
+		switchThreshold: int64(switchThreshold * switchThresholdDenominator),
 	}
 	// start with blob config
 	dec.lastConfig = &dec.blobConfig
@@ -54,8 +84,11 @@ func (dec *DynamicEthChannelConfig) ChannelConfig() ChannelConfig {
 	tipCap, baseFee, blobBaseFee, err := dec.gasPricer.SuggestGasPriceCaps(ctx)
 	if err != nil {
 		dec.log.Warn("Error querying gas prices, returning last config", "err", err)
+		// Don't let wrapping providers reuse a blob base fee that may be arbitrarily old by now.
+		dec.lastBlobBaseFee = nil
 		return *dec.lastConfig
 	}
+	dec.lastBlobBaseFee = blobBaseFee
 
 	// We estimate the gas costs of a calldata and blob tx under the assumption that we'd fill
 	// a frame fully and compressed random channel data has few zeros, so they can be
@@ -89,7 +122,18 @@ func (dec *DynamicEthChannelConfig) ChannelConfig() ChannelConfig {
 		"blob_data_bytes", blobDataBytes, "blob_cost", blobCost,
 		"cost_ratio", costRatio)
 
-	if ay.Cmp(bx) == 1 {
+	// Apply the switch threshold to the cost of the DA type that is not currently used.
+	denom := big.NewInt(switchThresholdDenominator)
+	withThreshold := big.NewInt(switchThresholdDenominator + dec.switchThreshold)
+	useCalldata := false
+	if dec.lastConfig.UseBlobs {
+		// switch to calldata if blobCost(ay) > calldataCost(bx) * (1 + threshold)
+		useCalldata = new(big.Int).Mul(ay, denom).Cmp(new(big.Int).Mul(bx, withThreshold)) == 1
+	} else {
+		// stay on calldata unless blobCost(ay) * (1 + threshold) <= calldataCost(bx)
+		useCalldata = new(big.Int).Mul(ay, withThreshold).Cmp(new(big.Int).Mul(bx, denom)) == 1
+	}
+	if useCalldata {
 		lgr.Info("Using calldata channel config")
 		dec.lastConfig = &dec.calldataConfig
 		return dec.calldataConfig
@@ -98,3 +142,113 @@ func (dec *DynamicEthChannelConfig) ChannelConfig() ChannelConfig {
 	dec.lastConfig = &dec.blobConfig
 	return dec.blobConfig
 }
+
+// LastBlobBaseFee returns the blob base fee sampled by the last call to ChannelConfig,
+// or nil if no gas prices could be queried by it.
+func (dec *DynamicEthChannelConfig) LastBlobBaseFee() *big.Int {
+	return dec.lastBlobBaseFee
+}
+
+// DynamicBlobCountChannelConfig wraps a ChannelConfigProvider and reduces the
+// number of blobs per blob tx when the blob base fee rises above a threshold,
+// or when there is less pending batch data than fits into the target number of blobs.
+// Transactions carrying fewer blobs compete for less blob space per L1 block,
+// which improves their inclusion chances while the blob market is congested.
+// The blob base fee sampled by the wrapped provider is reused, if it samples one.
+type DynamicBlobCountChannelConfig struct {
+	log       log.Logger
+	timeout   time.Duration // query timeout
+	gasPricer GasPricer
+	inner     ChannelConfigProvider
+
+	// blob base fee above which the blob count is reduced
+	feeThreshold *big.Int
+}
+
+func NewDynamicBlobCountChannelConfig(lgr log.Logger,
+	reqTimeout time.Duration, gasPricer GasPricer,
+	inner ChannelConfigProvider, feeThreshold *big.Int,
+) *DynamicBlobCountChannelConfig {
+	return &DynamicBlobCountChannelConfig{
+		log:          lgr,
+		timeout:      reqTimeout,
+		gasPricer:    gasPricer,
+		inner:        inner,
+		feeThreshold: feeThreshold,
+	}
+}
+
+func (dbc *DynamicBlobCountChannelConfig) ChannelConfig() ChannelConfig {
+	return dbc.channelConfig(false, 0)
+}
+
+// PendingDataChannelConfig returns the channel config for a new channel, given the estimated
+// size of the batch data of the blocks pending submission.
+func (dbc *DynamicBlobCountChannelConfig) PendingDataChannelConfig(pendingBytes uint64) ChannelConfig {
+	return dbc.channelConfig(true, pendingBytes)
+}
+
+func (dbc *DynamicBlobCountChannelConfig) channelConfig(limitByPending bool, pendingBytes uint64) ChannelConfig {
+	cc := dbc.inner.ChannelConfig()
+	if !cc.UseBlobs || cc.TargetNumFrames <= 1 {
+		return cc
+	}
+	numBlobs := cc.TargetNumFrames
+	blobBaseFee, err := dbc.blobBaseFee()
+	if err != nil {
+		dbc.log.Warn("Error querying gas prices, using target number of blobs", "err", err)
+	} else {
+		numBlobs = BlobCountForFee(numBlobs, blobBaseFee, dbc.feeThreshold)
+	}
+	if limitByPending {
+		numBlobs = min(numBlobs, BlobCountForData(pendingBytes, cc))
+	}
+	if numBlobs == cc.TargetNumFrames {
+		return cc
+	}
+	dbc.log.Info("Reducing number of blobs per tx",
+		"blob_base_fee", blobBaseFee, "fee_threshold", dbc.feeThreshold, "pending_bytes", pendingBytes,
+		"target_num_blobs", cc.TargetNumFrames, "num_blobs", numBlobs)
+	cc.TargetNumFrames = numBlobs
+	cc.ReinitCompressorConfig()
+	return cc
+}
+
+// blobBaseFee returns the blob base fee sampled by the inner provider, or queries it if there is no sample.
+func (dbc *DynamicBlobCountChannelConfig) blobBaseFee() (*big.Int, error) {
+	if sampler, ok := dbc.inner.(blobBaseFeeSampler); ok {
+		if fee := sampler.LastBlobBaseFee(); fee != nil {
+			return fee, nil
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), dbc.timeout)
+	defer cancel()
+	_, _, blobBaseFee, err := dbc.gasPricer.SuggestGasPriceCaps(ctx)
+	return blobBaseFee, err
+}
+
+// BlobCountForData returns the number of blobs needed for pendingBytes of uncompressed batch data,
+// using the approximate compression ratio of the config. It is at least one and at most the target
+// number of frames of the config.
+func BlobCountForData(pendingBytes uint64, cc ChannelConfig) int {
+	ratio := cc.CompressorConfig.ApproxComprRatio
+	if ratio <= 0 || ratio > 1 {
+		ratio = 1
+	}
+	compressed := uint64(float64(pendingBytes) * ratio)
+	numBlobs := (compressed + cc.MaxFrameSize - 1) / cc.MaxFrameSize
+	return int(min(max(numBlobs, 1), uint64(cc.TargetNumFrames)))
+}
+
+// BlobCountForFee returns the number of blobs to target per blob tx, given the
+// configured target and the current blob base fee. Up to the fee threshold, the
+// target is used. Above it, the blob count is scaled down inversely proportional
+// to the blob base fee, but never below a single blob.
+func BlobCountForFee(target int, blobBaseFee, feeThreshold *big.Int) int {
+	if blobBaseFee.Cmp(feeThreshold) <= 0 {
+		return target
+	}
+	n := new(big.Int).Mul(big.NewInt(int64(target)), feeThreshold)
+	n.Div(n, blobBaseFee)
+	return max(int(n.Int64()), 1)
+}
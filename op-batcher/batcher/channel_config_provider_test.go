@@ -80,7 +80,7 @@ func TestDynamicEthChannelConfig_ChannelConfig(t *testing.T) {
 				baseFee:     tt.baseFee,
 				blobBaseFee: tt.blobBaseFee,
 			}
-			dec := NewDynamicEthChannelConfig(lgr, 1*time.Second, gp, blobCfg, calldataCfg)
+			dec := NewDynamicEthChannelConfig(lgr, 1*time.Second, gp, blobCfg, calldataCfg, 0)
 			cc := dec.ChannelConfig()
 			if tt.wantCalldata {
 				require.Equal(t, cc, calldataCfg)
@@ -102,7 +102,7 @@ func TestDynamicEthChannelConfig_ChannelConfig(t *testing.T) {
 			blobBaseFee: 1e6, // should return calldata cfg without error
 			err:         errors.New("gp-error"),
 		}
-		dec := NewDynamicEthChannelConfig(lgr, 1*time.Second, gp, blobCfg, calldataCfg)
+		dec := NewDynamicEthChannelConfig(lgr, 1*time.Second, gp, blobCfg, calldataCfg, 0)
 		require.Equal(t, dec.ChannelConfig(), blobCfg)
 		require.NotNil(t, ch.FindLog(
 			testlog.NewLevelFilter(slog.LevelWarn),
@@ -124,3 +124,133 @@ func TestDynamicEthChannelConfig_ChannelConfig(t *testing.T) {
 		))
 	})
 }
+
+func TestDynamicEthChannelConfig_SwitchThreshold(t *testing.T) {
+	calldataCfg := ChannelConfig{
+		MaxFrameSize:    120_000 - 1,
+		TargetNumFrames: 1,
+	}
+	blobCfg := ChannelConfig{
+		MaxFrameSize:    eth.MaxBlobDataSize - 1,
+		TargetNumFrames: 3,
+		UseBlobs:        true,
+	}
+	lgr := testlog.Logger(t, slog.LevelInfo)
+	gp := &mockGasPricer{
+		tipCap:  1e3,
+		baseFee: 1e6,
+	}
+	dec := NewDynamicEthChannelConfig(lgr, 1*time.Second, gp, blobCfg, calldataCfg, 0.1)
+
+	// Blobs slightly more expensive, but within the threshold: stay on blobs
+	gp.blobBaseFee = 161e5
+	require.Equal(t, blobCfg, dec.ChannelConfig())
+
+	// Blobs more expensive by more than the threshold: switch to calldata
+	gp.blobBaseFee = 18e6
+	require.Equal(t, calldataCfg, dec.ChannelConfig())
+
+	// Blobs slightly cheaper, but within the threshold: stay on calldata
+	gp.blobBaseFee = 16e6
+	require.Equal(t, calldataCfg, dec.ChannelConfig())
+
+	// Blobs cheaper by more than the threshold: switch back to blobs
+	gp.blobBaseFee = 14e6
+	require.Equal(t, blobCfg, dec.ChannelConfig())
+}
+
+func TestDynamicBlobCountChannelConfig_ChannelConfig(t *testing.T) {
+	blobCfg := ChannelConfig{
+		MaxFrameSize:    eth.MaxBlobDataSize - 1,
+		TargetNumFrames: 6,
+		UseBlobs:        true,
+	}
+	blobCfg.InitNoneCompressor()
+
+	tests := []struct {
+		name        string
+		blobBaseFee int64
+		err         error
+		wantBlobs   int
+	}{
+		{name: "below-threshold", blobBaseFee: 1e9, wantBlobs: 6},
+		{name: "at-threshold", blobBaseFee: 2e9, wantBlobs: 6},
+		{name: "double-threshold", blobBaseFee: 4e9, wantBlobs: 3},
+		{name: "triple-threshold", blobBaseFee: 6e9, wantBlobs: 2},
+		{name: "far-above-threshold", blobBaseFee: 1e12, wantBlobs: 1},
+		{name: "gas-pricer-error", blobBaseFee: 1e12, err: errors.New("gp-error"), wantBlobs: 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gp := &mockGasPricer{blobBaseFee: tt.blobBaseFee, err: tt.err}
+			dbc := NewDynamicBlobCountChannelConfig(testlog.Logger(t, slog.LevelInfo), 1*time.Second, gp, blobCfg, big.NewInt(2e9))
+			cc := dbc.ChannelConfig()
+			require.Equal(t, tt.wantBlobs, cc.TargetNumFrames)
+			require.Equal(t, tt.wantBlobs, cc.MaxFramesPerTx())
+			require.Equal(t, MaxDataSize(tt.wantBlobs, blobCfg.MaxFrameSize), cc.CompressorConfig.TargetOutputSize)
+		})
+	}
+
+	t.Run("calldata-unchanged", func(t *testing.T) {
+		calldataCfg := ChannelConfig{
+			MaxFrameSize:    120_000 - 1,
+			TargetNumFrames: 1,
+		}
+		gp := &mockGasPricer{blobBaseFee: 1e12}
+		dbc := NewDynamicBlobCountChannelConfig(testlog.Logger(t, slog.LevelInfo), 1*time.Second, gp, calldataCfg, big.NewInt(2e9))
+		require.Equal(t, calldataCfg, dbc.ChannelConfig())
+	})
+
+	t.Run("pending-data", func(t *testing.T) {
+		gp := &mockGasPricer{blobBaseFee: 1e9}
+		dbc := NewDynamicBlobCountChannelConfig(testlog.Logger(t, slog.LevelInfo), 1*time.Second, gp, blobCfg, big.NewInt(2e9))
+		require.Equal(t, 1, dbc.PendingDataChannelConfig(0).TargetNumFrames)
+		require.Equal(t, 2, dbc.PendingDataChannelConfig(blobCfg.MaxFrameSize+1).TargetNumFrames)
+		require.Equal(t, 6, dbc.PendingDataChannelConfig(100*blobCfg.MaxFrameSize).TargetNumFrames)
+
+		gp.blobBaseFee = 6e9
+		require.Equal(t, 2, dbc.PendingDataChannelConfig(100*blobCfg.MaxFrameSize).TargetNumFrames, "fee limit applies")
+		require.Equal(t, 1, dbc.PendingDataChannelConfig(blobCfg.MaxFrameSize).TargetNumFrames, "data limit applies")
+	})
+
+	t.Run("reuses-inner-sample", func(t *testing.T) {
+		calldataCfg := ChannelConfig{
+			MaxFrameSize:    120_000 - 1,
+			TargetNumFrames: 1,
+		}
+		innerGp := &mockGasPricer{tipCap: 1e3, baseFee: 1e11, blobBaseFee: 4e9}
+		inner := NewDynamicEthChannelConfig(testlog.Logger(t, slog.LevelInfo), 1*time.Second, innerGp, blobCfg, calldataCfg, 0)
+		gp := &mockGasPricer{err: errors.New("must not be queried")}
+		dbc := NewDynamicBlobCountChannelConfig(testlog.Logger(t, slog.LevelInfo), 1*time.Second, gp, inner, big.NewInt(2e9))
+		cc := dbc.ChannelConfig()
+		require.True(t, cc.UseBlobs)
+		require.Equal(t, 3, cc.TargetNumFrames)
+		require.Equal(t, big.NewInt(4e9), inner.LastBlobBaseFee())
+	})
+
+	t.Run("queries-after-inner-error", func(t *testing.T) {
+		calldataCfg := ChannelConfig{
+			MaxFrameSize:    120_000 - 1,
+			TargetNumFrames: 1,
+		}
+		innerGp := &mockGasPricer{tipCap: 1e3, baseFee: 1e11, blobBaseFee: 4e9}
+		inner := NewDynamicEthChannelConfig(testlog.Logger(t, slog.LevelInfo), 1*time.Second, innerGp, blobCfg, calldataCfg, 0)
+		gp := &mockGasPricer{blobBaseFee: 1e9}
+		dbc := NewDynamicBlobCountChannelConfig(testlog.Logger(t, slog.LevelInfo), 1*time.Second, gp, inner, big.NewInt(2e9))
+		require.Equal(t, 3, dbc.ChannelConfig().TargetNumFrames)
+
+		// The stale sample of the inner provider must not be reused once its query fails.
+		innerGp.err = errors.New("inner-gp-error")
+		require.Equal(t, 6, dbc.ChannelConfig().TargetNumFrames)
+		require.Nil(t, inner.LastBlobBaseFee())
+	})
+}
+
+func TestBlobCountForData(t *testing.T) {
+	cfg := ChannelConfig{MaxFrameSize: 1000, TargetNumFrames: 6}
+	cfg.CompressorConfig.ApproxComprRatio = 0.5
+	require.Equal(t, 1, BlobCountForData(0, cfg))
+	require.Equal(t, 1, BlobCountForData(2000, cfg))
+	require.Equal(t, 2, BlobCountForData(2002, cfg))
+	require.Equal(t, 6, BlobCountForData(1_000_000, cfg))
+}
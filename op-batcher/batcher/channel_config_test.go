@@ -94,3 +94,28 @@ func FuzzChannelConfig_CheckTimeout(f *testing.F) {
 		require.ErrorIs(t, channelConfig.Check(), ErrInvalidChannelTimeout)
 	})
 }
+
+func TestChannelConfig_CompressionSettings(t *testing.T) {
+	cfg := defaultTestChannelConfig()
+	cfg.InitShadowCompressor(derive.Brotli10)
+	require.Equal(t, "shadow/brotli-10", cfg.compressionSettings())
+	cfg.InitRatioCompressor(0.4, derive.Zlib)
+	require.Equal(t, "ratio/zlib", cfg.compressionSettings())
+	cfg.InitNoneCompressor()
+	require.Equal(t, "none", cfg.compressionSettings())
+	cfg.CompressorConfig.Kind = ""
+	require.Equal(t, "ratio/zlib", cfg.compressionSettings(), "default compressor is the ratio compressor")
+}
+
+func TestChannelConfig_BatchSettings(t *testing.T) {
+	cfg := defaultTestChannelConfig()
+	cfg.BatchType = derive.SingularBatchType
+	cfg.MaxBlocksPerSpanBatch = 100
+	require.Equal(t, "singular", cfg.batchSettings())
+	cfg.BatchType = derive.SpanBatchType
+	require.Equal(t, "span/max-blocks-100", cfg.batchSettings())
+	cfg.SplitSpansAtEpochs = true
+	require.Equal(t, "span/max-blocks-100/epoch-split", cfg.batchSettings())
+	cfg.MaxBlocksPerSpanBatch = 0
+	require.Equal(t, "span/epoch-split", cfg.batchSettings())
+}
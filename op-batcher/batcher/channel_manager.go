@@ -92,6 +92,19 @@ func (s *channelManager) TxFailed(_id txID) {
 	}
 }
 
+// TxPublished records the hash of a published version of a transaction,
+// so the transaction can be found on L1 if the batcher restarts before it is confirmed.
+func (s *channelManager) TxPublished(_id txID, hash common.Hash) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := _id.String()
+	if channel, ok := s.txChannels[id]; ok {
+		channel.TxPublished(id, hash)
+	} else {
+		s.log.Warn("transaction from unknown channel marked as published", "id", id, "tx", hash)
+	}
+}
+
 // TxConfirmed marks a transaction as confirmed on L1. Unfortunately even if all frames in
 // a channel have been marked as confirmed on L1 the channel may be invalid & need to be
 // resubmitted.
@@ -144,6 +157,17 @@ func (s *channelManager) nextTxData(channel *channel) (txData, error) {
 	return tx, nil
 }
 
+// compressionSettings returns the compression settings of the channel of the given
+// pending tx, for cost accounting. It is empty if the tx is not pending.
+func (s *channelManager) compressionSettings(id txID) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if channel, ok := s.txChannels[id.String()]; ok {
+		return channel.cfg.compressionSettings()
+	}
+	return ""
+}
+
 // TxData returns the next tx data that should be submitted to L1.
 //
 // If the pending channel is
@@ -154,10 +178,20 @@ func (s *channelManager) TxData(l1Head eth.BlockID) (txData, error) {
 	defer s.mu.Unlock()
 	var firstWithTxData *channel
 	for _, ch := range s.channelQueue {
+		if err := ch.CheckErr(); err != nil {
+			// Later channels must not be submitted either, as their blocks build on the blocks of this channel.
+			return txData{}, fmt.Errorf("refusing to submit channel %v: %w", ch.ID(), err)
+		}
 		if ch.HasTxData() {
 			firstWithTxData = ch
 			break
 		}
+		if ch.cfg.StrictOrdering && ch.IsFull() {
+			// With Holocene, frames of a channel that are included while the previous channel is incomplete
+			// drop the previous channel. So the next channel is only submitted once this one is confirmed.
+			s.log.Debug("Waiting for closed channel to be confirmed", "id", ch.ID(), "pending_txs", len(ch.pendingTransactions))
+			return txData{}, io.EOF
+		}
 	}
 
 	dataPending := firstWithTxData != nil && firstWithTxData.HasTxData()
@@ -203,7 +237,13 @@ func (s *channelManager) ensureChannelWithSpace(l1Head eth.BlockID) error {
 		return nil
 	}
 
-	cfg := s.cfgProvider.ChannelConfig()
+	cfg := s.newChannelConfig()
+	if cfg.BatchType == derive.SpanBatchType && cfg.SingularBatchFallback &&
+		len(s.blocks) > 0 && !s.rollupCfg.IsDelta(s.blocks[0].Time()) {
+		s.log.Info("Falling back to singular batches before Delta", "block", eth.ToBlockID(s.blocks[0]))
+		cfg.BatchType = derive.SingularBatchType
+	}
+	cfg.StrictOrdering = s.strictOrdering(cfg, s.blocks)
 	pc, err := newChannel(s.log, s.metr, cfg, s.rollupCfg, s.l1OriginLastClosedChannel.Number)
 	if err != nil {
 		return fmt.Errorf("creating new channel: %w", err)
@@ -222,12 +262,36 @@ func (s *channelManager) ensureChannelWithSpace(l1Head eth.BlockID) error {
 		"target_num_frames", cfg.TargetNumFrames,
 		"max_frame_size", cfg.MaxFrameSize,
 		"use_blobs", cfg.UseBlobs,
+		"strict_ordering", cfg.StrictOrdering,
 	)
 	s.metr.RecordChannelOpened(pc.ID(), len(s.blocks))
 
 	return nil
 }
 
+// strictOrdering returns whether the channel of the given blocks is built and submitted with strict ordering.
+// Strict ordering is enabled for all channels by the configuration, and required for channels with Holocene blocks.
+func (s *channelManager) strictOrdering(cfg ChannelConfig, blocks []*types.Block) bool {
+	if cfg.StrictOrdering || len(blocks) == 0 {
+		return cfg.StrictOrdering
+	}
+	return s.rollupCfg.IsHolocene(blocks[len(blocks)-1].Time())
+}
+
+// newChannelConfig returns the config of a new channel. Providers that size channels by the pending
+// data are passed the estimated batch size of the queued blocks, which the new channel is filled with.
+func (s *channelManager) newChannelConfig() ChannelConfig {
+	provider, ok := s.cfgProvider.(PendingDataChannelConfigProvider)
+	if !ok {
+		return s.cfgProvider.ChannelConfig()
+	}
+	var size uint64
+	for _, block := range s.blocks {
+		size += metrics.EstimateBatchSize(block)
+	}
+	return provider.PendingDataChannelConfig(size)
+}
+
 // registerL1Block registers the given block at the pending channel.
 func (s *channelManager) registerL1Block(l1Head eth.BlockID) {
 	s.currentChannel.CheckTimeout(l1Head.Number)
@@ -310,6 +374,7 @@ func (s *channelManager) outputFrames() error {
 		outBytes,
 		s.currentChannel.FullErr(),
 	)
+	s.metr.RecordChannelComprRatio(s.currentChannel.cfg.batchSettings(), s.currentChannel.cfg.compressionSettings(), inBytes, outBytes)
 
 	var comprRatio float64
 	if inBytes > 0 {
@@ -350,6 +415,180 @@ func (s *channelManager) AddL2Block(block *types.Block) error {
 	return nil
 }
 
+// Drain closes the channel manager, like Close, but submits all loaded state instead of dropping it:
+// all queued blocks are added to channels, the current channel is force-closed,
+// and pending channels that have not been submitted yet are kept, so they are submitted as well.
+// Like after Close, the caller SHOULD drain pending channels by generating TxData repeatedly until there is none left.
+// A ErrPendingAfterClose error will be returned if there are any remaining pending channels to submit.
+func (s *channelManager) Drain(l1Head eth.BlockID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.log.Info("Channel manager is draining", "blocks_pending", len(s.blocks), "channels_pending", len(s.channelQueue))
+
+	for len(s.blocks) > 0 {
+		if err := s.ensureChannelWithSpace(l1Head); err != nil {
+			return err
+		}
+		if err := s.processBlocks(); err != nil {
+			return err
+		}
+		if err := s.outputFrames(); err != nil {
+			return err
+		}
+	}
+
+	if s.currentChannel != nil && !s.currentChannel.IsFull() {
+		s.currentChannel.Close()
+		if err := s.outputFrames(); err != nil {
+			return fmt.Errorf("outputting frames during drain: %w", err)
+		}
+	}
+	s.closed = true
+
+	for _, ch := range s.channelQueue {
+		if ch.HasTxData() {
+			return ErrPendingAfterClose
+		}
+	}
+	return nil
+}
+
+// pendingState summarizes the L2 blocks that are loaded into the channel manager, but not submitted to L1 yet.
+type pendingState struct {
+	// OldestL2 and LatestL2 are the range of L2 blocks that are not fully submitted yet.
+	OldestL2 eth.BlockID
+	LatestL2 eth.BlockID
+	// Channels is the number of channels that are not fully submitted yet.
+	Channels int
+	// InFlightTxs is the number of transactions that were sent, but not confirmed yet.
+	InFlightTxs int
+}
+
+// PendingState returns a summary of the state that is not submitted to L1 yet, and whether there is any.
+func (s *channelManager) PendingState() (pendingState, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var st pendingState
+	for _, ch := range s.channelQueue {
+		if len(ch.channelBuilder.Blocks()) == 0 {
+			continue
+		}
+		if st.Channels == 0 {
+			st.OldestL2 = ch.OldestL2()
+		}
+		st.LatestL2 = ch.LatestL2()
+		st.Channels++
+	}
+	if len(s.blocks) > 0 {
+		if st.Channels == 0 {
+			st.OldestL2 = eth.ToBlockID(s.blocks[0])
+		}
+		st.LatestL2 = eth.ToBlockID(s.blocks[len(s.blocks)-1])
+	}
+	st.InFlightTxs = len(s.txChannels)
+	return st, st.Channels > 0 || len(s.blocks) > 0
+}
+
+// PendingBytes returns the estimated batch size of all blocks that are not fully submitted to L1 yet,
+// including the blocks of channels that are still being submitted.
+func (s *channelManager) PendingBytes() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var size uint64
+	for _, ch := range s.channelQueue {
+		for _, block := range ch.channelBuilder.Blocks() {
+			size += metrics.EstimateBatchSize(block)
+		}
+	}
+	for _, block := range s.blocks {
+		size += metrics.EstimateBatchSize(block)
+	}
+	return size
+}
+
+// PersistedState returns the state to persist, so a restarted batcher can resume where it left off.
+// Only closed channels can be persisted, because the data of an open channel is still in its compressor.
+// The persisted channels are the longest run of closed channels at the front of the channel queue
+// that covers a contiguous range of L2 blocks, without any queued blocks before it.
+// All blocks after the sync point are reloaded after a restart.
+func (s *channelManager) PersistedState() persistedState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st := persistedState{L1OriginLastClosedChannel: s.l1OriginLastClosedChannel}
+	for _, ch := range s.channelQueue {
+		if len(ch.channelBuilder.Blocks()) == 0 {
+			continue
+		}
+		if !ch.IsFull() {
+			break
+		}
+		// The blocks of a timed out channel are requeued, and must be submitted before any later channel.
+		if len(s.blocks) > 0 && s.blocks[0].NumberU64() < ch.OldestL2().Number {
+			break
+		}
+		if len(st.Channels) > 0 && ch.OldestL2().Number != st.SyncPoint.Number+1 {
+			break
+		}
+		st.Channels = append(st.Channels, ch.persisted())
+		st.SyncPoint = ch.LatestL2()
+	}
+	return st
+}
+
+// Restore restores the persisted channels into the channel queue of a cleared channel manager.
+// The blocks must be all L2 blocks of the persisted channels, in order and up to the sync point.
+// It returns an ErrReorg if the blocks do not match the persisted channels, in which case the state is not modified.
+func (s *channelManager) Restore(st *persistedState, blocks []*types.Block) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(st.Channels) == 0 {
+		return nil
+	}
+	for i := 1; i < len(blocks); i++ {
+		if blocks[i].ParentHash() != blocks[i-1].Hash() {
+			return fmt.Errorf("block %d does not extend block %d: %w", blocks[i].NumberU64(), blocks[i-1].NumberU64(), ErrReorg)
+		}
+	}
+	if len(blocks) == 0 || eth.ToBlockID(blocks[len(blocks)-1]) != st.SyncPoint {
+		return fmt.Errorf("blocks do not end at sync point %s: %w", st.SyncPoint, ErrReorg)
+	}
+
+	cfg := s.cfgProvider.ChannelConfig()
+	channels := make([]*channel, 0, len(st.Channels))
+	for _, pc := range st.Channels {
+		if pc.LatestL2.Number < pc.OldestL2.Number {
+			return fmt.Errorf("invalid L2 block range of channel %s", pc.ID)
+		}
+		n := pc.LatestL2.Number - pc.OldestL2.Number + 1
+		if uint64(len(blocks)) < n {
+			return fmt.Errorf("missing blocks of channel %s", pc.ID)
+		}
+		chBlocks := blocks[:n:n]
+		blocks = blocks[n:]
+		if eth.ToBlockID(chBlocks[0]) != pc.OldestL2 || eth.ToBlockID(chBlocks[n-1]) != pc.LatestL2 {
+			return fmt.Errorf("blocks of channel %s do not match: %w", pc.ID, ErrReorg)
+		}
+		chCfg := cfg
+		chCfg.StrictOrdering = s.strictOrdering(cfg, chBlocks)
+		s.log.Debug("Restoring persisted channel", "id", pc.ID, "strict_ordering", chCfg.StrictOrdering)
+		channels = append(channels, restoreChannel(s.log, s.metr, chCfg, s.rollupCfg, pc, chBlocks))
+	}
+	if len(blocks) > 0 {
+		return fmt.Errorf("%d blocks not in any persisted channel", len(blocks))
+	}
+
+	s.channelQueue = append(s.channelQueue, channels...)
+	s.tip = st.SyncPoint.Hash
+	if st.L1OriginLastClosedChannel.Number > s.l1OriginLastClosedChannel.Number {
+		s.l1OriginLastClosedChannel = st.L1OriginLastClosedChannel
+	}
+	s.log.Info("Restored persisted channels", "channels", len(channels), "sync_point", st.SyncPoint)
+	return nil
+}
+
 func l2BlockRefFromBlockAndL1Info(block *types.Block, l1info *derive.L1BlockInfo) eth.L2BlockRef {
 	return eth.L2BlockRef{
 		Hash:           block.Hash(),
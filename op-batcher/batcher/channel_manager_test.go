@@ -4,6 +4,7 @@ import (
 	"io"
 	"math/big"
 	"math/rand"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	derivetest "github.com/ethereum-optimism/optimism/op-node/rollup/derive/test"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -440,6 +442,55 @@ func ChannelManagerCloseAllTxsFailed(t *testing.T, batchType uint) {
 	require.ErrorIs(err, io.EOF, "Expected closed channel manager to produce no more tx data")
 }
 
+// TestChannelManager_Drain ensures that draining the channel manager submits all loaded blocks,
+// unlike closing it, which drops any state that was not submitted yet.
+func TestChannelManager_Drain(t *testing.T) {
+	require := require.New(t)
+	rng := rand.New(rand.NewSource(123))
+	log := testlog.Logger(t, log.LevelError)
+	cfg := channelManagerTestConfig(100_000, derive.SingularBatchType)
+	cfg.InitNoneCompressor()
+	m := NewChannelManager(log, metrics.NoopMetrics, cfg, &defaultTestRollupConfig)
+	m.Clear(eth.BlockID{})
+
+	a := derivetest.RandomL2BlockWithChainId(rng, 3, defaultTestRollupConfig.L2ChainID)
+	b := derivetest.RandomL2BlockWithChainId(rng, 3, defaultTestRollupConfig.L2ChainID)
+	bHeader := b.Header()
+	bHeader.Number = new(big.Int).Add(a.Number(), big.NewInt(1))
+	bHeader.ParentHash = a.Hash()
+	b = b.WithSeal(bHeader)
+	require.NoError(m.AddL2Block(a))
+	require.NoError(m.AddL2Block(b))
+
+	st, pending := m.PendingState()
+	require.True(pending)
+	require.Equal(pendingState{OldestL2: eth.ToBlockID(a), LatestL2: eth.ToBlockID(b)}, st)
+
+	require.ErrorIs(m.Drain(eth.BlockID{}), ErrPendingAfterClose)
+	require.Empty(m.blocks, "all blocks must be added to channels")
+	require.ErrorIs(m.currentChannel.FullErr(), ErrTerminated, "Expected current channel to be terminated by Drain")
+
+	var txdatas []txData
+	for {
+		txdata, err := m.TxData(eth.BlockID{})
+		if err == io.EOF {
+			break
+		}
+		require.NoError(err)
+		txdatas = append(txdatas, txdata)
+	}
+	require.NotEmpty(txdatas)
+	st, _ = m.PendingState()
+	require.Equal(1, st.Channels)
+	require.Equal(len(txdatas), st.InFlightTxs)
+	require.Equal(eth.ToBlockID(a), st.OldestL2)
+
+	for _, txdata := range txdatas {
+		m.TxConfirmed(txdata.ID(), eth.BlockID{})
+	}
+	require.Empty(m.channelQueue, "all channels must be submitted")
+}
+
 func TestChannelManager_ChannelCreation(t *testing.T) {
 	l := testlog.Logger(t, log.LevelCrit)
 	const maxChannelDuration = 15
@@ -483,3 +534,139 @@ func TestChannelManager_ChannelCreation(t *testing.T) {
 		})
 	}
 }
+
+func TestChannelManager_SingularBatchFallback(t *testing.T) {
+	l := testlog.Logger(t, log.LevelCrit)
+	deltaTime := uint64(1000)
+	rollupCfg := defaultTestRollupConfig
+	rollupCfg.DeltaTime = &deltaTime
+	cfg := channelManagerTestConfig(1000, derive.SpanBatchType)
+	cfg.SingularBatchFallback = true
+
+	for _, tt := range []struct {
+		name      string
+		blockTime uint64
+		fallback  bool
+		expected  uint
+	}{
+		{name: "BeforeDelta", blockTime: deltaTime - 2, fallback: true, expected: derive.SingularBatchType},
+		{name: "AfterDelta", blockTime: deltaTime, fallback: true, expected: derive.SpanBatchType},
+		{name: "Disabled", blockTime: deltaTime - 2, fallback: false, expected: derive.SpanBatchType},
+	} {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			cfg := cfg
+			cfg.SingularBatchFallback = test.fallback
+			m := NewChannelManager(l, metrics.NoopMetrics, cfg, &rollupCfg)
+			m.blocks = []*types.Block{types.NewBlock(&types.Header{Number: big.NewInt(1), Time: test.blockTime}, nil, nil, nil)}
+
+			require.NoError(t, m.ensureChannelWithSpace(eth.BlockID{}))
+			require.Equal(t, test.expected, m.currentChannel.cfg.BatchType)
+		})
+	}
+}
+
+func TestChannelManager_StrictOrderingAtHolocene(t *testing.T) {
+	l := testlog.Logger(t, log.LevelCrit)
+	holoceneTime := uint64(1000)
+	rollupCfg := defaultTestRollupConfig
+	rollupCfg.HoloceneTime = &holoceneTime
+	cfg := channelManagerTestConfig(1000, derive.SpanBatchType)
+
+	for _, tt := range []struct {
+		name      string
+		blockTime uint64
+		strict    bool
+		expected  bool
+	}{
+		{name: "BeforeHolocene", blockTime: holoceneTime - 2, strict: false, expected: false},
+		{name: "AfterHolocene", blockTime: holoceneTime, strict: false, expected: true},
+		{name: "Enabled", blockTime: holoceneTime - 2, strict: true, expected: true},
+	} {
+		test := tt
+		t.Run(test.name, func(t *testing.T) {
+			cfg := cfg
+			cfg.StrictOrdering = test.strict
+			m := NewChannelManager(l, metrics.NoopMetrics, cfg, &rollupCfg)
+			m.blocks = []*types.Block{types.NewBlock(&types.Header{Number: big.NewInt(1), Time: test.blockTime}, nil, nil, nil)}
+
+			require.NoError(t, m.ensureChannelWithSpace(eth.BlockID{}))
+			require.Equal(t, test.expected, m.currentChannel.cfg.StrictOrdering)
+		})
+	}
+}
+
+// TestChannelManager_PersistRestore ensures that a restored channel manager resubmits
+// the unconfirmed frames of the persisted channels, and continues after the sync point.
+func TestChannelManager_PersistRestore(t *testing.T) {
+	require := require.New(t)
+	rng := rand.New(rand.NewSource(123))
+	log := testlog.Logger(t, log.LevelError)
+	cfg := channelManagerTestConfig(derive.FrameV0OverHeadSize+100, derive.SingularBatchType)
+	cfg.ChannelTimeout = 10
+	cfg.InitNoneCompressor()
+	cfg.CompressorConfig.TargetOutputSize = 1 // full on first block
+	m := NewChannelManager(log, metrics.NoopMetrics, cfg, &defaultTestRollupConfig)
+	m.Clear(eth.BlockID{})
+
+	a := derivetest.RandomL2BlockWithChainId(rng, 4, defaultTestRollupConfig.L2ChainID)
+	b := derivetest.RandomL2BlockWithChainId(rng, 4, defaultTestRollupConfig.L2ChainID)
+	bHeader := b.Header()
+	bHeader.Number = new(big.Int).Add(a.Number(), big.NewInt(1))
+	bHeader.ParentHash = a.Hash()
+	b = b.WithSeal(bHeader)
+	require.NoError(m.AddL2Block(a))
+	require.NoError(m.AddL2Block(b))
+
+	require.Empty(m.PersistedState().Channels, "open channels must not be persisted")
+
+	txdata0, err := m.TxData(eth.BlockID{})
+	require.NoError(err)
+	txdata1, err := m.TxData(eth.BlockID{})
+	require.NoError(err)
+	inclusionBlock := eth.BlockID{Hash: common.Hash{0x01}, Number: 1}
+	m.TxConfirmed(txdata0.ID(), inclusionBlock)
+	txHashes := []common.Hash{{0x02}, {0x03}} // fee bump
+	for _, h := range txHashes {
+		m.TxPublished(txdata1.ID(), h)
+	}
+
+	st := m.PersistedState()
+	require.Len(st.Channels, 1)
+	require.Equal(eth.ToBlockID(a), st.SyncPoint)
+	require.Equal(map[string]eth.BlockID{txdata0.ID().String(): inclusionBlock}, st.Channels[0].ConfirmedTxs)
+	require.Equal(m.currentChannel.TotalFrames()-1, len(st.Channels[0].Frames), "in-flight frames must be persisted")
+	require.Equal([]persistedTx{{
+		ID:     txdata1.ID().String(),
+		Hashes: txHashes,
+		Frames: []uint16{txdata1.Frames()[0].id.frameNumber},
+	}}, st.Channels[0].InFlightTxs, "in-flight txs must be persisted with all published hashes")
+
+	path := filepath.Join(t.TempDir(), stateFileName)
+	require.NoError(jsonutil.WriteJSON(path, st, 0o644))
+	loaded, err := jsonutil.LoadJSON[persistedState](path)
+	require.NoError(err)
+
+	restored := NewChannelManager(log, metrics.NoopMetrics, cfg, &defaultTestRollupConfig)
+	restored.Clear(eth.BlockID{})
+	require.ErrorIs(restored.Restore(loaded, []*types.Block{b}), ErrReorg)
+	require.Empty(restored.channelQueue, "state must not be modified by a failed restore")
+	require.NoError(restored.Restore(loaded, []*types.Block{a}))
+
+	txdata, err := restored.TxData(eth.BlockID{})
+	require.NoError(err)
+	require.Equal(txdata1.CallData(), txdata.CallData(), "unconfirmed frames must be resubmitted first")
+	restored.TxConfirmed(txdata.ID(), inclusionBlock)
+	require.NoError(restored.AddL2Block(b), "blocks must be loaded after the sync point")
+	require.ErrorIs(restored.AddL2Block(a), ErrReorg)
+
+	for {
+		txdata, err := restored.TxData(eth.BlockID{})
+		if err == io.EOF {
+			break
+		}
+		require.NoError(err)
+		restored.TxConfirmed(txdata.ID(), inclusionBlock)
+	}
+	require.Empty(restored.channelQueue, "restored channel must be submitted")
+}
@@ -0,0 +1,182 @@
+package batcher
+
+import (
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// stateFileName is the name of the file in the state directory that the channel manager state is persisted to.
+const stateFileName = "channels.json"
+
+// txmgrStateDir is the subdirectory of the state directory that the tx manager persists pending blob txs in.
+const txmgrStateDir = "txmgr"
+
+// persistStateInterval is the minimum time between two writes of the state file.
+const persistStateInterval = time.Second
+
+// persistedState is the channel manager state that is persisted to the state directory,
+// so a restarted batcher resumes submitting where it left off.
+type persistedState struct {
+	// SyncPoint is the latest L2 block of the persisted channels.
+	// After restoring the channels, blocks are loaded from the sync point instead of the L2 safe head.
+	SyncPoint eth.BlockID `json:"syncPoint"`
+	// L1OriginLastClosedChannel is the latest L1 origin of the L2 blocks in the most recently closed channel.
+	L1OriginLastClosedChannel eth.BlockID `json:"l1OriginLastClosedChannel"`
+	// Channels are the closed channels that are not fully submitted yet, in submission order.
+	Channels []persistedChannel `json:"channels"`
+}
+
+// persistedChannel is a closed channel, with all its frames that are not confirmed on L1 yet.
+type persistedChannel struct {
+	ID       derive.ChannelID `json:"id"`
+	UseBlobs bool             `json:"useBlobs"`
+
+	OldestL1Origin eth.BlockID `json:"oldestL1Origin"`
+	LatestL1Origin eth.BlockID `json:"latestL1Origin"`
+	OldestL2       eth.BlockID `json:"oldestL2"`
+	LatestL2       eth.BlockID `json:"latestL2"`
+
+	TotalFrames int `json:"totalFrames"`
+	OutputBytes int `json:"outputBytes"`
+
+	// Frames are the frames that are not confirmed yet, including the frames of in-flight transactions.
+	Frames []persistedFrame `json:"frames"`
+	// ConfirmedTxs maps the ID of every confirmed transaction to the L1 block it was included in.
+	ConfirmedTxs map[string]eth.BlockID `json:"confirmedTxs"`
+	// InFlightTxs are the published transactions that are not confirmed yet.
+	InFlightTxs []persistedTx `json:"inFlightTxs,omitempty"`
+}
+
+// persistedTx is an in-flight transaction, with the hashes of all versions of it that were published.
+type persistedTx struct {
+	ID     string        `json:"id"`
+	Hashes []common.Hash `json:"hashes"`
+	// Frames are the numbers of the frames of the transaction.
+	Frames []uint16 `json:"frames"`
+}
+
+type persistedFrame struct {
+	Number uint16        `json:"number"`
+	Data   hexutil.Bytes `json:"data"`
+}
+
+// persisted returns the persistable state of the channel. The channel must be closed.
+// The frames of in-flight transactions are persisted as unsubmitted, together with the hashes of the published
+// transactions, so they are only resubmitted after a restart if none of the transactions was included on L1.
+func (c *channel) persisted() persistedChannel {
+	frames := slices.Clone(c.channelBuilder.frames)
+	for _, data := range c.pendingTransactions {
+		frames = append(frames, data.Frames()...)
+	}
+	slices.SortFunc(frames, func(a, b frameData) int {
+		return int(a.id.frameNumber) - int(b.id.frameNumber)
+	})
+	pc := persistedChannel{
+		ID:             c.ID(),
+		UseBlobs:       c.cfg.UseBlobs,
+		OldestL1Origin: c.OldestL1Origin(),
+		LatestL1Origin: c.LatestL1Origin(),
+		OldestL2:       c.OldestL2(),
+		LatestL2:       c.LatestL2(),
+		TotalFrames:    c.TotalFrames(),
+		OutputBytes:    c.OutputBytes(),
+		Frames:         make([]persistedFrame, 0, len(frames)),
+		ConfirmedTxs:   maps.Clone(c.confirmedTransactions),
+	}
+	for _, f := range frames {
+		pc.Frames = append(pc.Frames, persistedFrame{Number: f.id.frameNumber, Data: f.data})
+	}
+	for id, data := range c.pendingTransactions {
+		hashes := c.publishedTxs[id]
+		if len(hashes) == 0 {
+			continue
+		}
+		tx := persistedTx{ID: id, Hashes: slices.Clone(hashes)}
+		for _, f := range data.Frames() {
+			tx.Frames = append(tx.Frames, f.id.frameNumber)
+		}
+		pc.InFlightTxs = append(pc.InFlightTxs, tx)
+	}
+	slices.SortFunc(pc.InFlightTxs, func(a, b persistedTx) int {
+		return int(a.Frames[0]) - int(b.Frames[0])
+	})
+	return pc
+}
+
+// txConfirmed marks an in-flight transaction that was found on L1 as confirmed, so its frames are not resubmitted.
+func (pc *persistedChannel) txConfirmed(tx persistedTx, inclusionBlock eth.BlockID) {
+	pc.Frames = slices.DeleteFunc(pc.Frames, func(f persistedFrame) bool {
+		return slices.Contains(tx.Frames, f.Number)
+	})
+	if pc.ConfirmedTxs == nil {
+		pc.ConfirmedTxs = make(map[string]eth.BlockID)
+	}
+	pc.ConfirmedTxs[tx.ID] = inclusionBlock
+}
+
+// restoreChannel recreates a closed channel from its persisted state.
+// The blocks must be the L2 blocks of the channel, so they can be requeued if the channel times out.
+func restoreChannel(log log.Logger, metr metrics.Metricer, cfg ChannelConfig, rollupCfg *rollup.Config, pc persistedChannel, blocks []*types.Block) *channel {
+	// The frames were sized for the DA type of the channel when it was persisted.
+	cfg.UseBlobs = pc.UseBlobs
+	cb := &ChannelBuilder{
+		cfg:            cfg,
+		rollupCfg:      *rollupCfg,
+		co:             restoredChannelOut{id: pc.ID},
+		blocks:         blocks,
+		latestL1Origin: pc.LatestL1Origin,
+		oldestL1Origin: pc.OldestL1Origin,
+		latestL2:       pc.LatestL2,
+		oldestL2:       pc.OldestL2,
+		frames:         make([]frameData, 0, len(pc.Frames)),
+		numFrames:      pc.TotalFrames,
+		outputBytes:    pc.OutputBytes,
+	}
+	cb.setFullErr(ErrChannelRestored)
+	for _, f := range pc.Frames {
+		cb.frames = append(cb.frames, frameData{data: f.Data, id: frameID{chID: pc.ID, frameNumber: f.Number}})
+	}
+
+	confirmed := make(map[string]eth.BlockID, len(pc.ConfirmedTxs))
+	maps.Copy(confirmed, pc.ConfirmedTxs)
+	return &channel{
+		log:                   log,
+		metr:                  metr,
+		cfg:                   cfg,
+		channelBuilder:        cb,
+		pendingTransactions:   make(map[string]txData),
+		publishedTxs:          make(map[string][]common.Hash),
+		confirmedTransactions: confirmed,
+		confirmedTxUpdated:    true,
+	}
+}
+
+// restoredChannelOut is the channel out of a restored channel.
+// A restored channel is closed and all of its frames were output before it was persisted,
+// so no data is ever added to or output from its channel out.
+type restoredChannelOut struct {
+	derive.ChannelOut
+	id derive.ChannelID
+}
+
+func (co restoredChannelOut) ID() derive.ChannelID {
+	return co.id
+}
+
+func (co restoredChannelOut) InputBytes() int {
+	return 0
+}
+
+func (co restoredChannelOut) ReadyBytes() int {
+	return 0
+}
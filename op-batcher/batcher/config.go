@@ -12,6 +12,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-batcher/compressor"
 	"github.com/ethereum-optimism/optimism/op-batcher/flags"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/client"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
@@ -59,10 +60,28 @@ type CLIConfig struct {
 	// Maximum number of blocks to add to a span batch. Default is 0 - no maximum.
 	MaxBlocksPerSpanBatch int
 
+	// SplitSpansAtEpochs starts a new span batch at an epoch boundary if that improves compression.
+	SplitSpansAtEpochs bool
+
+	// SingularBatchFallback submits singular batches instead of span batches while Delta isn't active yet.
+	SingularBatchFallback bool
+
+	// StrictOrdering enforces Holocene's strict ordering rules when building and submitting channels,
+	// also before Holocene is scheduled.
+	StrictOrdering bool
+
 	// The target number of frames to create per channel. Controls number of blobs
 	// per blob tx, if using Blob DA.
 	TargetNumFrames int
 
+	// DynamicBlobsFeeThreshold is the blob base fee in GWei above which the number
+	// of blobs per blob tx is reduced from TargetNumFrames (0 == disabled).
+	DynamicBlobsFeeThreshold float64
+
+	// DASwitchThreshold is the relative cost advantage, e.g. 0.1 for 10%, the other DA type
+	// needs to have before the batcher switches to it, when using the auto DA type.
+	DASwitchThreshold float64
+
 	// ApproxComprRatio to assume (only [compressor.RatioCompressor]).
 	// Should be slightly smaller than average from experiments to avoid the
 	// chances of creating a small additional leftover frame.
@@ -71,7 +90,7 @@ type CLIConfig struct {
 	// Type of compressor to use. Must be one of [compressor.KindKeys].
 	Compressor string
 
-	// Type of compression algorithm to use. Must be one of [zlib, brotli, brotli[9-11]]
+	// Type of compression algorithm to use. Must be one of [zlib, brotli, brotli[9-11], zstd]
 	CompressionAlgo derive.CompressionAlgo
 
 	// If Stopped is true, the batcher starts stopped and won't start batching right away.
@@ -85,6 +104,16 @@ type CLIConfig struct {
 	// If 0, the batcher will just use the current head.
 	CheckRecentTxsDepth int
 
+	// DrainOnStop makes the batcher submit all loaded blocks and open channels to L1 before stopping.
+	DrainOnStop bool
+
+	// DrainTimeout is the maximum time to spend draining. If 0, draining is not limited in time.
+	DrainTimeout time.Duration
+
+	// StateDir is the directory to persist pending channels to, so a restarted batcher resumes where it left off.
+	// Disabled if empty.
+	StateDir string
+
 	BatchType uint
 
 	// DataAvailabilityType is one of the values defined in op-batcher/flags/types.go and dictates
@@ -95,16 +124,40 @@ type CLIConfig struct {
 	// ActiveSequencerCheckDuration is the duration between checks to determine the active sequencer endpoint.
 	ActiveSequencerCheckDuration time.Duration
 
+	// ThrottleInterval is the interval between updates of the DA throttling limits of the sequencer's
+	// execution engine. Throttling is disabled if 0.
+	ThrottleInterval time.Duration
+
+	// ThrottleThreshold is the estimated size in bytes of the blocks that are not fully submitted to L1 yet,
+	// above which the DA usage of new L2 blocks is throttled.
+	ThrottleThreshold uint64
+
+	// ThrottleTxSize is the max DA size of a transaction while throttling (0 == no limit).
+	ThrottleTxSize uint64
+
+	// ThrottleBlockSize is the max DA size of a block while throttling (0 == no limit).
+	ThrottleBlockSize uint64
+
+	// ThrottleAlwaysBlockSize is the max DA size of a block that is always applied, also when not throttling (0 == no limit).
+	ThrottleAlwaysBlockSize uint64
+
 	// TestUseMaxTxSizeForBlobs allows to set the blob size with MaxL1TxSize.
 	// Should only be used for testing purposes.
 	TestUseMaxTxSizeForBlobs bool
 
+	// AltDAFailoverGracePeriod is the time the alt-DA server may be unavailable before the batch data is posted
+	// directly to L1 instead, until the server recovers. Failover is disabled if 0.
+	AltDAFailoverGracePeriod time.Duration
+
 	TxMgrConfig   txmgr.CLIConfig
 	LogConfig     oplog.CLIConfig
 	MetricsConfig opmetrics.CLIConfig
 	PprofConfig   oppprof.CLIConfig
 	RPC           oprpc.CLIConfig
 	AltDA         altda.CLIConfig
+
+	// L1CircuitBreaker configures the circuit breaker of the L1 RPC client.
+	L1CircuitBreaker client.CircuitBreakerConfig
 }
 
 func (c *CLIConfig) Check() error {
@@ -141,9 +194,27 @@ func (c *CLIConfig) Check() error {
 	if c.CheckRecentTxsDepth > 128 {
 		return fmt.Errorf("CheckRecentTxsDepth cannot be set higher than 128: %v", c.CheckRecentTxsDepth)
 	}
+	if c.DrainTimeout < 0 {
+		return errors.New("DrainTimeout must not be negative")
+	}
 	if c.DataAvailabilityType == flags.BlobsType && c.TargetNumFrames > 6 {
 		return errors.New("too many frames for blob transactions, max 6")
 	}
+	if c.DynamicBlobsFeeThreshold < 0 {
+		return errors.New("DynamicBlobsFeeThreshold must not be negative")
+	}
+	if c.DASwitchThreshold < 0 {
+		return errors.New("DASwitchThreshold must not be negative")
+	}
+	if c.ThrottleInterval < 0 {
+		return errors.New("ThrottleInterval must not be negative")
+	}
+	if c.ThrottleInterval > 0 && c.ThrottleThreshold == 0 {
+		return errors.New("ThrottleThreshold must be set when throttling is enabled")
+	}
+	if c.AltDAFailoverGracePeriod < 0 {
+		return errors.New("AltDAFailoverGracePeriod must not be negative")
+	}
 	if !flags.ValidDataAvailabilityType(c.DataAvailabilityType) {
 		return fmt.Errorf("unknown data availability type: %q", c.DataAvailabilityType)
 	}
@@ -159,6 +230,9 @@ func (c *CLIConfig) Check() error {
 	if err := c.RPC.Check(); err != nil {
 		return err
 	}
+	if err := c.L1CircuitBreaker.Check(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -177,21 +251,36 @@ func NewConfig(ctx *cli.Context) *CLIConfig {
 		MaxChannelDuration:           ctx.Uint64(flags.MaxChannelDurationFlag.Name),
 		MaxL1TxSize:                  ctx.Uint64(flags.MaxL1TxSizeBytesFlag.Name),
 		MaxBlocksPerSpanBatch:        ctx.Int(flags.MaxBlocksPerSpanBatch.Name),
+		SplitSpansAtEpochs:           ctx.Bool(flags.SplitSpansAtEpochsFlag.Name),
+		SingularBatchFallback:        ctx.Bool(flags.SingularBatchFallbackFlag.Name),
+		StrictOrdering:               ctx.Bool(flags.StrictOrderingFlag.Name),
 		TargetNumFrames:              ctx.Int(flags.TargetNumFramesFlag.Name),
+		DynamicBlobsFeeThreshold:     ctx.Float64(flags.DynamicBlobsFeeThresholdFlag.Name),
+		DASwitchThreshold:            ctx.Float64(flags.DASwitchThresholdFlag.Name),
 		ApproxComprRatio:             ctx.Float64(flags.ApproxComprRatioFlag.Name),
 		Compressor:                   ctx.String(flags.CompressorFlag.Name),
 		CompressionAlgo:              derive.CompressionAlgo(ctx.String(flags.CompressionAlgoFlag.Name)),
 		Stopped:                      ctx.Bool(flags.StoppedFlag.Name),
 		WaitNodeSync:                 ctx.Bool(flags.WaitNodeSyncFlag.Name),
 		CheckRecentTxsDepth:          ctx.Int(flags.CheckRecentTxsDepthFlag.Name),
+		DrainOnStop:                  ctx.Bool(flags.DrainOnStopFlag.Name),
+		DrainTimeout:                 ctx.Duration(flags.DrainTimeoutFlag.Name),
+		StateDir:                     ctx.String(flags.StateDirFlag.Name),
 		BatchType:                    ctx.Uint(flags.BatchTypeFlag.Name),
 		DataAvailabilityType:         flags.DataAvailabilityType(ctx.String(flags.DataAvailabilityTypeFlag.Name)),
 		ActiveSequencerCheckDuration: ctx.Duration(flags.ActiveSequencerCheckDurationFlag.Name),
+		ThrottleInterval:             ctx.Duration(flags.ThrottleIntervalFlag.Name),
+		ThrottleThreshold:            ctx.Uint64(flags.ThrottleThresholdFlag.Name),
+		ThrottleTxSize:               ctx.Uint64(flags.ThrottleTxSizeFlag.Name),
+		ThrottleBlockSize:            ctx.Uint64(flags.ThrottleBlockSizeFlag.Name),
+		ThrottleAlwaysBlockSize:      ctx.Uint64(flags.ThrottleAlwaysBlockSizeFlag.Name),
+		AltDAFailoverGracePeriod:     ctx.Duration(flags.AltDAFailoverGracePeriodFlag.Name),
 		TxMgrConfig:                  txmgr.ReadCLIConfig(ctx),
 		LogConfig:                    oplog.ReadCLIConfig(ctx),
 		MetricsConfig:                opmetrics.ReadCLIConfig(ctx),
 		PprofConfig:                  oppprof.ReadCLIConfig(ctx),
 		RPC:                          oprpc.ReadCLIConfig(ctx),
 		AltDA:                        altda.ReadCLIConfig(ctx),
+		L1CircuitBreaker:             client.ReadCircuitBreakerCLIConfig(ctx),
 	}
 }
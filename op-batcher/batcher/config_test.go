@@ -113,6 +113,19 @@ func TestBatcherConfig(t *testing.T) {
 			},
 			errString: "invalid ApproxComprRatio 4.2 for ratio compressor",
 		},
+		{
+			name: "throttling without threshold",
+			override: func(c *batcher.CLIConfig) {
+				c.ThrottleInterval = time.Second
+				c.ThrottleThreshold = 0
+			},
+			errString: "ThrottleThreshold must be set when throttling is enabled",
+		},
+		{
+			name:      "negative AltDAFailoverGracePeriod",
+			override:  func(c *batcher.CLIConfig) { c.AltDAFailoverGracePeriod = -time.Second },
+			errString: "AltDAFailoverGracePeriod must not be negative",
+		},
 	}
 
 	for _, test := range tests {
@@ -7,26 +7,42 @@ import (
 	"io"
 	"math/big"
 	_ "net/http/pprof"
+	"os"
+	"path/filepath"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	altda "github.com/ethereum-optimism/optimism/op-alt-da"
+	"github.com/ethereum-optimism/optimism/op-batcher/accounting"
 	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-service/dial"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
 )
 
+// SetMaxDASizeMethod is the execution engine RPC method that limits the DA size of the
+// transactions and blocks built by the sequencer.
+const SetMaxDASizeMethod = "miner_setMaxDASize"
+
+// methodNotFoundErrorCode is the JSON-RPC error code returned for unknown methods.
+const methodNotFoundErrorCode = -32601
+
 var (
-	ErrBatcherNotRunning = errors.New("batcher is not running")
-	emptyTxData          = txData{
+	ErrBatcherNotRunning     = errors.New("batcher is not running")
+	errThrottlingUnsupported = errors.New("execution engine does not support DA throttling")
+	emptyTxData              = txData{
 		frames: []frameData{
 			{
 				data: []byte{},
@@ -39,11 +55,17 @@ type txRef struct {
 	id       txID
 	isCancel bool
 	isBlob   bool
+
+	// compression and data sizes of the tx, for cost accounting
+	compression   string
+	calldataBytes uint64
+	blobBytes     uint64
 }
 
 type L1Client interface {
 	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
 	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
 }
 
 type L2Client interface {
@@ -82,6 +104,9 @@ type BatchSubmitter struct {
 	mutex   sync.Mutex
 	running bool
 
+	// draining is set while the batch submitter is stopped with DrainBatchSubmitting.
+	draining atomic.Bool
+
 	txpoolMutex       sync.Mutex // guards txpoolState and txpoolBlockedBlob
 	txpoolState       int
 	txpoolBlockedBlob bool
@@ -91,6 +116,14 @@ type BatchSubmitter struct {
 	lastL1Tip       eth.L1BlockRef
 
 	state *channelManager
+	// stateFileMutex orders the writes of the state to the state directory.
+	stateFileMutex sync.Mutex
+	// stateUpdated signals the state writer that the state changed and needs to be persisted.
+	stateUpdated chan struct{}
+
+	accountant *accounting.Accountant
+
+	altDAFailover *altDAFailover
 }
 
 // NewBatchSubmitter initializes the BatchSubmitter driver from a preconfigured DriverSetup
@@ -98,6 +131,11 @@ func NewBatchSubmitter(setup DriverSetup) *BatchSubmitter {
 	return &BatchSubmitter{
 		DriverSetup: setup,
 		state:       NewChannelManager(setup.Log, setup.Metr, setup.ChannelConfig, setup.RollupConfig),
+		accountant:  accounting.NewAccountant(setup.Metr, accounting.DefaultRetentionDays),
+
+		stateUpdated: make(chan struct{}, 1),
+
+		altDAFailover: newAltDAFailover(setup.Config.AltDAFailoverGracePeriod),
 	}
 }
 
@@ -124,9 +162,16 @@ func (l *BatchSubmitter) StartBatchSubmitting() error {
 		}
 	}
 
+	l.restoreState(l.shutdownCtx)
+
 	l.wg.Add(1)
 	go l.loop()
 
+	if l.Config.ThrottleInterval > 0 {
+		l.wg.Add(1)
+		go l.throttlingLoop()
+	}
+
 	l.Log.Info("Batch Submitter started")
 	return nil
 }
@@ -168,6 +213,210 @@ func (l *BatchSubmitter) StopBatchSubmitting(ctx context.Context) error {
 	return nil
 }
 
+// DrainBatchSubmitting stops the batch-submitter loop like StopBatchSubmitting, but submits all loaded state first:
+// no new blocks are loaded, all open channels are force-closed and submitted, and their confirmations are waited for.
+// Submission is force-killed when the DrainTimeout passes, or the provided ctx is done.
+// Channels that are not fully submitted by then are persisted to the StateDir like on every stop, if configured,
+// and resumed on the next start.
+func (l *BatchSubmitter) DrainBatchSubmitting(ctx context.Context) error {
+	l.Log.Info("Draining Batch Submitter", "timeout", l.Config.DrainTimeout)
+	if l.Config.DrainTimeout != 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, l.Config.DrainTimeout)
+		defer cancel()
+	}
+
+	l.draining.Store(true)
+	defer l.draining.Store(false)
+	if err := l.StopBatchSubmitting(ctx); err != nil {
+		return err
+	}
+	l.logPendingState()
+	return nil
+}
+
+func (l *BatchSubmitter) DrainBatchSubmittingIfRunning(ctx context.Context) error {
+	err := l.DrainBatchSubmitting(ctx)
+	if errors.Is(err, ErrBatcherNotRunning) {
+		return nil
+	}
+	return err
+}
+
+// logPendingState logs a summary of the state that was not submitted to L1 after draining, if any.
+func (l *BatchSubmitter) logPendingState() {
+	st, ok := l.state.PendingState()
+	if !ok {
+		l.Log.Info("Drained all state")
+		return
+	}
+	l.Log.Warn("State was not submitted before the drain deadline", "persisted", l.Config.StateDir != "",
+		"oldest_l2", st.OldestL2, "latest_l2", st.LatestL2, "channels", st.Channels, "in_flight_txs", st.InFlightTxs)
+}
+
+func (l *BatchSubmitter) stateFile() string {
+	return filepath.Join(l.Config.StateDir, stateFileName)
+}
+
+// persistState schedules the state to be written to the StateDir by the state writer, if configured.
+// It never blocks, so it can be called on every state change.
+func (l *BatchSubmitter) persistState() {
+	if l.Config.StateDir == "" {
+		return
+	}
+	select {
+	case l.stateUpdated <- struct{}{}:
+	default: // a write is already scheduled
+	}
+}
+
+// stateWriterLoop writes the state to the StateDir whenever it was updated, at most once per persistStateInterval,
+// so the driver loop never waits for the disk. The latest state is written once more when done is closed.
+func (l *BatchSubmitter) stateWriterLoop(done <-chan struct{}) {
+	for {
+		select {
+		case <-l.stateUpdated:
+			l.writeState()
+		case <-done:
+			if l.Config.StateDir != "" {
+				l.writeState()
+			}
+			return
+		}
+		select {
+		case <-time.After(persistStateInterval):
+		case <-done:
+			l.writeState()
+			return
+		}
+	}
+}
+
+// writeState writes the closed channels that are not fully submitted yet to the StateDir.
+// The state file is removed if there are no such channels.
+func (l *BatchSubmitter) writeState() {
+	l.stateFileMutex.Lock()
+	defer l.stateFileMutex.Unlock()
+	st := l.state.PersistedState()
+	if len(st.Channels) == 0 {
+		if err := os.Remove(l.stateFile()); err != nil && !errors.Is(err, os.ErrNotExist) {
+			l.Log.Error("Failed to remove persisted state", "err", err)
+		}
+		return
+	}
+	if err := os.MkdirAll(l.Config.StateDir, 0o755); err != nil {
+		l.Log.Error("Failed to create state directory", "dir", l.Config.StateDir, "err", err)
+		return
+	}
+	if err := jsonutil.WriteJSON(l.stateFile(), st, 0o644); err != nil {
+		l.Log.Error("Failed to persist state", "err", err)
+	}
+}
+
+// restoreState restores the channels persisted to the StateDir by a previous run, if any,
+// and continues loading blocks after their sync point.
+// Channels are not restored if their blocks were reorged out of the L2 chain, or their confirmed transactions out of the L1 chain.
+// Channels that only contain blocks up to the L2 safe head were already derived, and are not restored either.
+func (l *BatchSubmitter) restoreState(ctx context.Context) {
+	if l.Config.StateDir == "" {
+		return
+	}
+	if _, err := os.Stat(l.stateFile()); errors.Is(err, os.ErrNotExist) {
+		return
+	}
+	st, err := jsonutil.LoadJSON[persistedState](l.stateFile())
+	if err != nil {
+		l.Log.Warn("Failed to load persisted state, starting at the safe head", "err", err)
+		return
+	}
+	if err := l.restoreChannels(ctx, st); err != nil {
+		l.Log.Warn("Failed to restore persisted channels, starting at the safe head", "err", err)
+		return
+	}
+}
+
+func (l *BatchSubmitter) restoreChannels(ctx context.Context, st *persistedState) error {
+	rollupClient, err := l.EndpointProvider.RollupClient(ctx)
+	if err != nil {
+		return fmt.Errorf("getting rollup client: %w", err)
+	}
+	cCtx, cancel := context.WithTimeout(ctx, l.Config.NetworkTimeout)
+	defer cancel()
+	syncStatus, err := rollupClient.SyncStatus(cCtx)
+	if err != nil {
+		return fmt.Errorf("failed to get sync status: %w", err)
+	}
+	for len(st.Channels) > 0 && st.Channels[0].LatestL2.Number <= syncStatus.SafeL2.Number {
+		st.Channels = st.Channels[1:]
+	}
+	if len(st.Channels) == 0 {
+		l.Log.Info("All persisted channels were already derived", "sync_point", st.SyncPoint, "safe", syncStatus.SafeL2)
+		return nil
+	}
+
+	for i := range st.Channels {
+		if err := l.reconcileInFlightTxs(ctx, &st.Channels[i]); err != nil {
+			return err
+		}
+	}
+	for _, ch := range st.Channels {
+		for id, inclusionBlock := range ch.ConfirmedTxs {
+			cCtx, cancel := context.WithTimeout(ctx, l.Config.NetworkTimeout)
+			header, err := l.L1Client.HeaderByNumber(cCtx, new(big.Int).SetUint64(inclusionBlock.Number))
+			cancel()
+			if err != nil {
+				return fmt.Errorf("getting L1 inclusion block of tx %s: %w", id, err)
+			}
+			if header.Hash() != inclusionBlock.Hash {
+				return fmt.Errorf("L1 inclusion block %s of tx %s was reorged out", inclusionBlock, id)
+			}
+		}
+	}
+
+	l2Client, err := l.EndpointProvider.EthClient(ctx)
+	if err != nil {
+		return fmt.Errorf("getting L2 client: %w", err)
+	}
+	var blocks []*types.Block
+	for i := st.Channels[0].OldestL2.Number; i <= st.SyncPoint.Number; i++ {
+		cCtx, cancel := context.WithTimeout(ctx, l.Config.NetworkTimeout)
+		block, err := l2Client.BlockByNumber(cCtx, new(big.Int).SetUint64(i))
+		cancel()
+		if err != nil {
+			return fmt.Errorf("getting L2 block: %w", err)
+		}
+		blocks = append(blocks, block)
+	}
+	if err := l.state.Restore(st, blocks); err != nil {
+		return err
+	}
+	l.lastStoredBlock = st.SyncPoint
+	return nil
+}
+
+// reconcileInFlightTxs looks up the receipts of the transactions that were in-flight when the channel was persisted.
+// Transactions that were included on L1 are marked as confirmed, the frames of all others are resubmitted.
+func (l *BatchSubmitter) reconcileInFlightTxs(ctx context.Context, ch *persistedChannel) error {
+	for _, tx := range ch.InFlightTxs {
+		for _, hash := range tx.Hashes {
+			cCtx, cancel := context.WithTimeout(ctx, l.Config.NetworkTimeout)
+			receipt, err := l.L1Client.TransactionReceipt(cCtx, hash)
+			cancel()
+			if errors.Is(err, ethereum.NotFound) {
+				continue
+			} else if err != nil {
+				return fmt.Errorf("getting receipt of in-flight tx %s: %w", tx.ID, err)
+			}
+			inclusionBlock := eth.ReceiptBlockID(receipt)
+			l.Log.Info("In-flight transaction was included before the restart", "id", tx.ID, "tx", hash, "block", inclusionBlock)
+			ch.txConfirmed(tx, inclusionBlock)
+			break
+		}
+	}
+	ch.InFlightTxs = nil
+	return nil
+}
+
 // loadBlocksIntoState loads all blocks since the previous stored block
 // It does the following:
 // 1. Fetch the sync status of the sequencer
@@ -300,6 +549,18 @@ const (
 func (l *BatchSubmitter) loop() {
 	defer l.wg.Done()
 
+	// start the state writer, it writes the final state after the loop and receipt processing are done
+	stopStateWriter := make(chan struct{})
+	stateWriterDone := make(chan struct{})
+	go func() {
+		defer close(stateWriterDone)
+		l.stateWriterLoop(stopStateWriter)
+	}()
+	defer func() {
+		close(stopStateWriter)
+		<-stateWriterDone
+	}()
+
 	receiptsCh := make(chan txmgr.TxReceipt[txRef])
 	queue := txmgr.NewQueue[txRef](l.killCtx, l.Txmgr, l.Config.MaxPendingTransactions)
 
@@ -366,6 +627,7 @@ func (l *BatchSubmitter) loop() {
 				// the state.
 				publishAndWait()
 				l.clearState(l.shutdownCtx)
+				l.persistState()
 				continue
 			}
 			l.publishStateToL1(queue, receiptsCh)
@@ -376,7 +638,13 @@ func (l *BatchSubmitter) loop() {
 			}
 			// This removes any never-submitted pending channels, so these do not have to be drained with transactions.
 			// Any remaining unfinished channel is terminated, so its data gets submitted.
-			err := l.state.Close()
+			// When draining, never-submitted channels and any loaded blocks are submitted as well.
+			var err error
+			if l.draining.Load() {
+				err = l.state.Drain(l.lastL1Tip.ID())
+			} else {
+				err = l.state.Close()
+			}
 			if err != nil {
 				if errors.Is(err, ErrPendingAfterClose) {
 					l.Log.Warn("Closed channel manager on shutdown with pending channel(s) remaining - submitting")
@@ -385,12 +653,94 @@ func (l *BatchSubmitter) loop() {
 				}
 			}
 			publishAndWait()
+			l.persistState()
 			l.Log.Info("Finished publishing all remaining channel data")
 			return
 		}
 	}
 }
 
+// throttlingLoop limits the DA usage of new L2 blocks while the backlog of blocks that are not fully submitted
+// to L1 exceeds the throttle threshold, so the safe head cannot fall arbitrarily far behind during L1 congestion.
+// The limits are set on every tick, so a new active sequencer is throttled too after a failover.
+func (l *BatchSubmitter) throttlingLoop() {
+	defer l.wg.Done()
+	l.Log.Info("Starting DA throttling loop", "interval", l.Config.ThrottleInterval, "threshold", l.Config.ThrottleThreshold)
+	ticker := time.NewTicker(l.Config.ThrottleInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := l.updateThrottle(l.shutdownCtx); errors.Is(err, errThrottlingUnsupported) {
+			l.Log.Error("DA throttling disabled", "err", err)
+			return
+		} else if err != nil {
+			l.Log.Warn("Failed to update DA throttling", "err", err)
+		}
+		select {
+		case <-ticker.C:
+		case <-l.shutdownCtx.Done():
+			// Lift the throttle so the sequencer isn't left throttled while the batcher is stopped.
+			ctx, cancel := context.WithTimeout(context.Background(), l.Config.NetworkTimeout)
+			defer cancel()
+			if err := l.setMaxDASize(ctx, 0, l.Config.ThrottleAlwaysBlockSize); err != nil {
+				l.Log.Warn("Failed to reset DA throttling", "err", err)
+			}
+			l.Log.Info("DA throttling loop done")
+			return
+		}
+	}
+}
+
+// throttleLimits returns the max DA size of transactions and blocks to apply for the given backlog size.
+// A limit of 0 means no limit.
+func (l *BatchSubmitter) throttleLimits(pendingBytes uint64) (maxTxSize, maxBlockSize uint64) {
+	maxBlockSize = l.Config.ThrottleAlwaysBlockSize
+	if pendingBytes <= l.Config.ThrottleThreshold {
+		return 0, maxBlockSize
+	}
+	if maxBlockSize == 0 || (l.Config.ThrottleBlockSize != 0 && l.Config.ThrottleBlockSize < maxBlockSize) {
+		maxBlockSize = l.Config.ThrottleBlockSize
+	}
+	return l.Config.ThrottleTxSize, maxBlockSize
+}
+
+func (l *BatchSubmitter) updateThrottle(ctx context.Context) error {
+	pendingBytes := l.state.PendingBytes()
+	l.Metr.RecordBacklogBytes(pendingBytes)
+	maxTxSize, maxBlockSize := l.throttleLimits(pendingBytes)
+	if pendingBytes > l.Config.ThrottleThreshold {
+		l.Log.Warn("Backlog over throttle threshold, throttling DA", "bytes", pendingBytes, "threshold", l.Config.ThrottleThreshold,
+			"max_tx_size", maxTxSize, "max_block_size", maxBlockSize)
+	}
+	ctx, cancel := context.WithTimeout(ctx, l.Config.NetworkTimeout)
+	defer cancel()
+	if err := l.setMaxDASize(ctx, maxTxSize, maxBlockSize); err != nil {
+		return err
+	}
+	l.Metr.RecordThrottle(maxTxSize, maxBlockSize)
+	return nil
+}
+
+// setMaxDASize sets the DA limits of the active sequencer's execution engine.
+func (l *BatchSubmitter) setMaxDASize(ctx context.Context, maxTxSize, maxBlockSize uint64) error {
+	cl, err := l.EndpointProvider.EthClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get L2 execution client: %w", err)
+	}
+	var success bool
+	err = cl.Client().CallContext(ctx, &success, SetMaxDASizeMethod, hexutil.Uint64(maxTxSize), hexutil.Uint64(maxBlockSize))
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == methodNotFoundErrorCode {
+		return fmt.Errorf("%w: %w", errThrottlingUnsupported, err)
+	} else if err != nil {
+		return fmt.Errorf("failed to set max DA size: %w", err)
+	}
+	if !success {
+		return errors.New("execution engine did not apply max DA size")
+	}
+	return nil
+}
+
 // waitNodeSync Check to see if there was a batcher tx sent recently that
 // still needs more block confirmations before being considered finalized
 func (l *BatchSubmitter) waitNodeSync() error {
@@ -507,6 +857,9 @@ func (l *BatchSubmitter) publishTxToL1(ctx context.Context, queue *txmgr.Queue[t
 		return err
 	}
 
+	// The channel of the tx data may just have been closed.
+	l.persistState()
+
 	if err = l.sendTransaction(ctx, txdata, queue, receiptsCh); err != nil {
 		return fmt.Errorf("BatchSubmitter.sendTransaction failed: %w", err)
 	}
@@ -575,17 +928,28 @@ func (l *BatchSubmitter) sendTransaction(ctx context.Context, txdata txData, que
 		}
 		data := txdata.CallData()
 		// if AltDA is enabled we post the txdata to the DA Provider and replace it with the commitment.
-		if l.Config.UseAltDA {
+		// While failed over to L1, the txdata is posted as calldata instead.
+		if l.Config.UseAltDA && l.altDAFailover.tryAltDA() {
 			comm, err := l.AltDA.SetInput(ctx, data)
 			if err != nil {
-				l.Log.Error("Failed to post input to Alt DA", "error", err)
-				// requeue frame if we fail to post to the DA Provider so it can be retried
-				l.recordFailedTx(txdata.ID(), err)
-				return nil
+				l.Log.Error("Failed to post input to Alt DA", "error", err, "outage", l.altDAFailover.outage())
+				if !l.altDAFailover.recordFailure() {
+					// requeue frame if we fail to post to the DA Provider so it can be retried
+					l.recordFailedTx(txdata.ID(), err)
+					return nil
+				}
+				l.Log.Warn("Alt DA server unavailable, posting batch data to L1", "tx", txdata.ID(),
+					"grace_period", l.Config.AltDAFailoverGracePeriod)
+				l.Metr.RecordAltDAFailover(true)
+			} else {
+				if l.altDAFailover.recordSuccess() {
+					l.Log.Info("Alt DA server recovered, posting commitments again")
+					l.Metr.RecordAltDAFailover(false)
+				}
+				l.Log.Info("Set AltDA input", "commitment", comm, "tx", txdata.ID())
+				// signal AltDA commitment tx with TxDataVersion1
+				data = comm.TxData()
 			}
-			l.Log.Info("Set AltDA input", "commitment", comm, "tx", txdata.ID())
-			// signal AltDA commitment tx with TxDataVersion1
-			data = comm.TxData()
 		}
 		candidate = l.calldataTxCandidate(data)
 	}
@@ -605,7 +969,24 @@ func (l *BatchSubmitter) sendTx(txdata txData, isCancel bool, candidate *txmgr.T
 		candidate.GasLimit = intrinsicGas
 	}
 
-	queue.Send(txRef{id: txdata.ID(), isCancel: isCancel, isBlob: txdata.asBlob}, *candidate, receiptsCh)
+	ref := txRef{
+		id:            txdata.ID(),
+		isCancel:      isCancel,
+		isBlob:        txdata.asBlob,
+		compression:   l.state.compressionSettings(txdata.ID()),
+		calldataBytes: uint64(len(candidate.TxData)),
+	}
+	if len(candidate.Blobs) > 0 {
+		// Each blob holds a frame, prefixed by the derivation version byte.
+		ref.blobBytes = uint64(txdata.Len() + len(txdata.frames))
+	}
+	if !isCancel {
+		candidate.OnPublish = func(tx *types.Transaction) {
+			l.state.TxPublished(ref.id, tx.Hash())
+			l.persistState()
+		}
+	}
+	queue.Send(ref, *candidate, receiptsCh)
 }
 
 func (l *BatchSubmitter) blobTxCandidate(data txData) (*txmgr.TxCandidate, error) {
@@ -618,6 +999,7 @@ func (l *BatchSubmitter) blobTxCandidate(data txData) (*txmgr.TxCandidate, error
 	l.Log.Info("Building Blob transaction candidate",
 		"size", size, "last_size", lastSize, "num_blobs", len(blobs))
 	l.Metr.RecordBlobUsedBytes(lastSize)
+	l.Metr.RecordBlobsPerTx(len(blobs))
 	return &txmgr.TxCandidate{
 		To:    &l.RollupConfig.BatchInboxAddress,
 		Blobs: blobs,
@@ -638,7 +1020,27 @@ func (l *BatchSubmitter) handleReceipt(r txmgr.TxReceipt[txRef]) {
 		l.recordFailedTx(r.ID.id, r.Err)
 	} else {
 		l.recordConfirmedTx(r.ID.id, r.Receipt)
+		l.recordTxCost(r.ID, r.Receipt)
 	}
+	l.persistState()
+}
+
+func (l *BatchSubmitter) recordTxCost(ref txRef, receipt *types.Receipt) {
+	da := accounting.DACalldata
+	if ref.isBlob {
+		da = accounting.DABlob
+	}
+	compression := ref.compression
+	if ref.isCancel {
+		compression = "cancel"
+	}
+	l.accountant.RecordTx(accounting.NewTxCost(receipt, da, compression, ref.calldataBytes, ref.blobBytes))
+}
+
+// CostReport returns the daily DA costs of the batch transactions confirmed since the batcher started,
+// for the retained days.
+func (l *BatchSubmitter) CostReport() []accounting.DailyCost {
+	return l.accountant.Report()
 }
 
 func (l *BatchSubmitter) recordL1Tip(l1tip eth.L1BlockRef) {
@@ -3,14 +3,25 @@ package batcher
 import (
 	"context"
 	"errors"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-service/dial"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum-optimism/optimism/op-service/testutils"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/stretchr/testify/require"
 )
 
@@ -117,3 +128,193 @@ func TestBatchSubmitter_SafeL1Origin_FailsToResolveRollupClient(t *testing.T) {
 	_, err := bs.safeL1Origin(context.Background())
 	require.Error(t, err)
 }
+
+func TestBatchSubmitter_PersistState(t *testing.T) {
+	stateDir := t.TempDir()
+	cfg := channelManagerTestConfig(120_000, derive.SingularBatchType)
+	cfg.CompressorConfig.TargetOutputSize = 1 // full on first block
+	newBatchSubmitter := func() (*BatchSubmitter, *mockL2EndpointProvider, *testutils.MockClient) {
+		bs, ep := setup(t)
+		l1 := new(testutils.MockClient)
+		bs.L1Client = l1
+		bs.Config.StateDir = stateDir
+		bs.state = NewChannelManager(bs.Log, metrics.NoopMetrics, cfg, bs.RollupConfig)
+		bs.state.Clear(eth.BlockID{})
+		return bs, ep, l1
+	}
+
+	bs, _, _ := newBatchSubmitter()
+	block := newMiniL2BlockWithNumberParent(0, big.NewInt(1), common.Hash{})
+	require.NoError(t, bs.state.AddL2Block(block))
+	bs.writeState()
+	require.NoFileExists(t, filepath.Join(stateDir, stateFileName), "open channels must not be persisted")
+
+	txdata, err := bs.state.TxData(eth.BlockID{})
+	require.NoError(t, err)
+	txHash := common.Hash{0x01}
+	bs.state.TxPublished(txdata.ID(), txHash)
+	bs.writeState()
+	require.FileExists(t, filepath.Join(stateDir, stateFileName))
+
+	t.Run("Restore", func(t *testing.T) {
+		bs, ep, l1 := newBatchSubmitter()
+		l1.ExpectTransactionReceipt(txHash, (*types.Receipt)(nil), ethereum.NotFound)
+		ep.rollupClient.ExpectSyncStatus(&eth.SyncStatus{}, nil)
+		ep.ethClient.ExpectBlockByNumber(big.NewInt(1), block, nil)
+		bs.restoreState(context.Background())
+		require.Equal(t, eth.ToBlockID(block), bs.lastStoredBlock)
+		require.Len(t, bs.state.channelQueue, 1)
+
+		restored, err := bs.state.TxData(eth.BlockID{})
+		require.NoError(t, err)
+		require.Equal(t, txdata.CallData(), restored.CallData())
+		l1.AssertExpectations(t)
+	})
+
+	t.Run("InFlightTxIncluded", func(t *testing.T) {
+		bs, ep, l1 := newBatchSubmitter()
+		header := &types.Header{Number: big.NewInt(10)}
+		l1.ExpectTransactionReceipt(txHash, &types.Receipt{BlockHash: header.Hash(), BlockNumber: header.Number}, nil)
+		l1.ExpectHeaderByNumber(header.Number, header, nil)
+		ep.rollupClient.ExpectSyncStatus(&eth.SyncStatus{}, nil)
+		ep.ethClient.ExpectBlockByNumber(big.NewInt(1), block, nil)
+		bs.restoreState(context.Background())
+		require.Equal(t, eth.ToBlockID(block), bs.lastStoredBlock)
+		require.Len(t, bs.state.channelQueue, 1)
+		require.Equal(t, map[string]eth.BlockID{txdata.ID().String(): eth.HeaderBlockID(header)},
+			bs.state.channelQueue[0].confirmedTransactions)
+
+		_, err := bs.state.TxData(eth.BlockID{})
+		require.ErrorIs(t, err, io.EOF, "frames of included txs must not be resubmitted")
+		l1.AssertExpectations(t)
+	})
+
+	t.Run("AlreadyDerived", func(t *testing.T) {
+		bs, ep, _ := newBatchSubmitter()
+		ep.rollupClient.ExpectSyncStatus(&eth.SyncStatus{SafeL2: eth.L2BlockRef{Number: 1}}, nil)
+		bs.restoreState(context.Background())
+		require.Equal(t, eth.BlockID{}, bs.lastStoredBlock)
+		require.Empty(t, bs.state.channelQueue)
+	})
+
+	t.Run("Reorged", func(t *testing.T) {
+		bs, ep, l1 := newBatchSubmitter()
+		l1.ExpectTransactionReceipt(txHash, (*types.Receipt)(nil), ethereum.NotFound)
+		ep.rollupClient.ExpectSyncStatus(&eth.SyncStatus{}, nil)
+		ep.ethClient.ExpectBlockByNumber(big.NewInt(1), newMiniL2BlockWithNumberParent(1, big.NewInt(1), common.Hash{}), nil)
+		bs.restoreState(context.Background())
+		require.Equal(t, eth.BlockID{}, bs.lastStoredBlock)
+		require.Empty(t, bs.state.channelQueue)
+	})
+
+	// The state file is removed once all channels are submitted.
+	bs.state.TxConfirmed(txdata.ID(), eth.BlockID{})
+	bs.writeState()
+	require.NoFileExists(t, filepath.Join(stateDir, stateFileName))
+}
+
+func TestBatchSubmitter_StateWriter(t *testing.T) {
+	cfg := channelManagerTestConfig(120_000, derive.SingularBatchType)
+	cfg.CompressorConfig.TargetOutputSize = 1 // full on first block
+	bs, _ := setup(t)
+	bs.Config.StateDir = t.TempDir()
+	bs.state = NewChannelManager(bs.Log, metrics.NoopMetrics, cfg, bs.RollupConfig)
+	bs.state.Clear(eth.BlockID{})
+	stateFile := filepath.Join(bs.Config.StateDir, stateFileName)
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		bs.stateWriterLoop(done)
+	}()
+
+	require.NoError(t, bs.state.AddL2Block(newMiniL2BlockWithNumberParent(0, big.NewInt(1), common.Hash{})))
+	txdata, err := bs.state.TxData(eth.BlockID{})
+	require.NoError(t, err)
+	bs.persistState()
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(stateFile)
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Updates within the write interval are written when the writer is stopped.
+	bs.state.TxConfirmed(txdata.ID(), eth.BlockID{})
+	bs.persistState()
+	close(done)
+	<-stopped
+	require.NoFileExists(t, stateFile)
+}
+
+type stubMinerAPI struct {
+	maxTxSize    hexutil.Uint64
+	maxBlockSize hexutil.Uint64
+}
+
+func (a *stubMinerAPI) SetMaxDASize(maxTxSize, maxBlockSize hexutil.Uint64) bool {
+	a.maxTxSize = maxTxSize
+	a.maxBlockSize = maxBlockSize
+	return true
+}
+
+func TestBatchSubmitter_ThrottleLimits(t *testing.T) {
+	bs, _ := setup(t)
+	bs.Config.ThrottleThreshold = 1000
+	bs.Config.ThrottleTxSize = 300
+	bs.Config.ThrottleBlockSize = 21_000
+	bs.Config.ThrottleAlwaysBlockSize = 130_000
+
+	maxTxSize, maxBlockSize := bs.throttleLimits(1000)
+	require.Zero(t, maxTxSize)
+	require.Equal(t, uint64(130_000), maxBlockSize)
+
+	maxTxSize, maxBlockSize = bs.throttleLimits(1001)
+	require.Equal(t, uint64(300), maxTxSize)
+	require.Equal(t, uint64(21_000), maxBlockSize)
+
+	// The smaller block size limit applies while throttling
+	bs.Config.ThrottleAlwaysBlockSize = 10_000
+	_, maxBlockSize = bs.throttleLimits(1001)
+	require.Equal(t, uint64(10_000), maxBlockSize)
+
+	bs.Config.ThrottleAlwaysBlockSize = 0
+	_, maxBlockSize = bs.throttleLimits(1000)
+	require.Zero(t, maxBlockSize)
+	_, maxBlockSize = bs.throttleLimits(1001)
+	require.Equal(t, uint64(21_000), maxBlockSize)
+}
+
+func TestBatchSubmitter_UpdateThrottle(t *testing.T) {
+	bs, ep := setup(t)
+	bs.Config.NetworkTimeout = time.Second
+	bs.Config.ThrottleTxSize = 300
+	bs.Config.ThrottleBlockSize = 21_000
+
+	api := &stubMinerAPI{}
+	srv := rpc.NewServer()
+	require.NoError(t, srv.RegisterName("miner", api))
+	t.Cleanup(srv.Stop)
+	ep.ethClient.ExpectClient(rpc.DialInProc(srv))
+
+	block := newMiniL2Block(0)
+	require.NoError(t, bs.state.AddL2Block(block))
+	bs.Config.ThrottleThreshold = metrics.EstimateBatchSize(block)
+	require.NoError(t, bs.updateThrottle(context.Background()))
+	require.Zero(t, api.maxTxSize)
+	require.Zero(t, api.maxBlockSize)
+
+	bs.Config.ThrottleThreshold--
+	require.NoError(t, bs.updateThrottle(context.Background()))
+	require.Equal(t, hexutil.Uint64(300), api.maxTxSize)
+	require.Equal(t, hexutil.Uint64(21_000), api.maxBlockSize)
+}
+
+func TestBatchSubmitter_UpdateThrottleUnsupported(t *testing.T) {
+	bs, ep := setup(t)
+	bs.Config.NetworkTimeout = time.Second
+	srv := rpc.NewServer()
+	t.Cleanup(srv.Stop)
+	ep.ethClient.ExpectClient(rpc.DialInProc(srv))
+
+	require.ErrorIs(t, bs.updateThrottle(context.Background()), errThrottlingUnsupported)
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"path/filepath"
 	"strings"
 	"sync/atomic"
 	"time"
@@ -20,6 +21,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/params"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-service/cliapp"
+	"github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/dial"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/httputil"
@@ -39,9 +41,32 @@ type BatcherConfig struct {
 	// UseAltDA is true if the rollup config has a DA challenge address so the batcher
 	// will post inputs to the DA server and post commitments to blobs or calldata.
 	UseAltDA bool
+	// AltDAFailoverGracePeriod is the time the alt-DA server may be unavailable before batch data is posted
+	// directly to L1 instead. Failover is disabled if 0.
+	AltDAFailoverGracePeriod time.Duration
 
 	WaitNodeSync        bool
 	CheckRecentTxsDepth int
+
+	// DrainOnStop makes the batcher drain all loaded state to L1 when the service is stopped.
+	DrainOnStop bool
+	// DrainTimeout is the maximum time to spend draining. 0 disables the timeout.
+	DrainTimeout time.Duration
+
+	// StateDir is the directory to persist closed channels that are not fully submitted yet to,
+	// so they are resumed after a restart. Disabled if empty.
+	StateDir string
+
+	// ThrottleInterval is the interval between updates of the DA throttling limits. 0 disables throttling.
+	ThrottleInterval time.Duration
+	// ThrottleThreshold is the backlog size in bytes above which throttling is applied.
+	ThrottleThreshold uint64
+	// ThrottleTxSize is the max DA size of a transaction while throttling (0 == no limit).
+	ThrottleTxSize uint64
+	// ThrottleBlockSize is the max DA size of a block while throttling (0 == no limit).
+	ThrottleBlockSize uint64
+	// ThrottleAlwaysBlockSize is the max DA size of a block that is always applied (0 == no limit).
+	ThrottleAlwaysBlockSize uint64
 }
 
 // BatcherService represents a full batch-submitter instance and its resources,
@@ -96,6 +121,15 @@ func (bs *BatcherService) initFromCLIConfig(ctx context.Context, version string,
 	bs.NetworkTimeout = cfg.TxMgrConfig.NetworkTimeout
 	bs.CheckRecentTxsDepth = cfg.CheckRecentTxsDepth
 	bs.WaitNodeSync = cfg.WaitNodeSync
+	bs.DrainOnStop = cfg.DrainOnStop
+	bs.DrainTimeout = cfg.DrainTimeout
+	bs.StateDir = cfg.StateDir
+	bs.AltDAFailoverGracePeriod = cfg.AltDAFailoverGracePeriod
+	bs.ThrottleInterval = cfg.ThrottleInterval
+	bs.ThrottleThreshold = cfg.ThrottleThreshold
+	bs.ThrottleTxSize = cfg.ThrottleTxSize
+	bs.ThrottleBlockSize = cfg.ThrottleBlockSize
+	bs.ThrottleAlwaysBlockSize = cfg.ThrottleAlwaysBlockSize
 	if err := bs.initRPCClients(ctx, cfg); err != nil {
 		return err
 	}
@@ -130,7 +164,8 @@ func (bs *BatcherService) initFromCLIConfig(ctx context.Context, version string,
 }
 
 func (bs *BatcherService) initRPCClients(ctx context.Context, cfg *CLIConfig) error {
-	l1Client, err := dial.DialEthClientWithTimeout(ctx, dial.DefaultDialTimeout, bs.Log, cfg.L1EthRpc)
+	l1Client, err := dial.DialEthClientWithTimeout(ctx, dial.DefaultDialTimeout, bs.Log, cfg.L1EthRpc,
+		client.CircuitBreakerDialOptions(bs.Log, cfg.L1EthRpc, cfg.L1CircuitBreaker, bs.Metrics)...)
 	if err != nil {
 		return fmt.Errorf("failed to dial L1 RPC: %w", err)
 	}
@@ -198,9 +233,12 @@ func (bs *BatcherService) initChannelConfig(cfg *CLIConfig) error {
 		MaxChannelDuration:    cfg.MaxChannelDuration,
 		MaxFrameSize:          cfg.MaxL1TxSize - 1, // account for version byte prefix; reset for blobs
 		MaxBlocksPerSpanBatch: cfg.MaxBlocksPerSpanBatch,
+		SplitSpansAtEpochs:    cfg.SplitSpansAtEpochs,
+		SingularBatchFallback: cfg.SingularBatchFallback,
 		TargetNumFrames:       cfg.TargetNumFrames,
 		SubSafetyMargin:       cfg.SubSafetyMargin,
 		BatchType:             cfg.BatchType,
+		StrictOrdering:        cfg.StrictOrdering,
 	}
 
 	switch cfg.DataAvailabilityType {
@@ -232,6 +270,10 @@ func (bs *BatcherService) initChannelConfig(cfg *CLIConfig) error {
 	if cc.CompressorConfig.CompressionAlgo.IsBrotli() && !bs.RollupConfig.IsFjord(uint64(time.Now().Unix())) {
 		return errors.New("cannot use brotli compression before Fjord")
 	}
+	// Zstd is experimental, and must be activated in the rollup config
+	if cc.CompressorConfig.CompressionAlgo.IsZstd() && !bs.RollupConfig.IsZstd(uint64(time.Now().Unix())) {
+		return errors.New("cannot use zstd compression before zstd activation")
+	}
 
 	if err := cc.Check(); err != nil {
 		return fmt.Errorf("invalid channel configuration: %w", err)
@@ -245,8 +287,12 @@ func (bs *BatcherService) initChannelConfig(cfg *CLIConfig) error {
 		"compression_algo", cc.CompressorConfig.CompressionAlgo,
 		"batch_type", cc.BatchType,
 		"max_channel_duration", cc.MaxChannelDuration,
+		"max_blocks_per_span_batch", cc.MaxBlocksPerSpanBatch,
+		"split_spans_at_epochs", cc.SplitSpansAtEpochs,
+		"singular_batch_fallback", cc.SingularBatchFallback,
 		"channel_timeout", cc.ChannelTimeout,
-		"sub_safety_margin", cc.SubSafetyMargin)
+		"sub_safety_margin", cc.SubSafetyMargin,
+		"strict_ordering", cc.StrictOrdering)
 	if bs.UseAltDA {
 		bs.Log.Warn("Alt-DA Mode is a Beta feature of the MIT licensed OP Stack.  While it has received initial review from core contributors, it is still undergoing testing, and may have bugs or other issues.")
 	}
@@ -259,16 +305,30 @@ func (bs *BatcherService) initChannelConfig(cfg *CLIConfig) error {
 		calldataCC.UseBlobs = false
 		calldataCC.ReinitCompressorConfig()
 
-		bs.ChannelConfig = NewDynamicEthChannelConfig(bs.Log, 10*time.Second, bs.TxManager, cc, calldataCC)
+		bs.ChannelConfig = NewDynamicEthChannelConfig(bs.Log, 10*time.Second, bs.TxManager, cc, calldataCC, cfg.DASwitchThreshold)
 	} else {
 		bs.ChannelConfig = cc
 	}
 
+	if cc.UseBlobs && cfg.DynamicBlobsFeeThreshold > 0 {
+		feeThreshold, err := eth.GweiToWei(cfg.DynamicBlobsFeeThreshold)
+		if err != nil {
+			return fmt.Errorf("invalid dynamic blobs fee threshold: %w", err)
+		}
+		bs.Log.Info("Enabling dynamic blob count", "fee_threshold", feeThreshold, "target_num_frames", cc.TargetNumFrames)
+		bs.ChannelConfig = NewDynamicBlobCountChannelConfig(bs.Log, 10*time.Second, bs.TxManager, bs.ChannelConfig, feeThreshold)
+	}
+
 	return nil
 }
 
 func (bs *BatcherService) initTxManager(cfg *CLIConfig) error {
-	txManager, err := txmgr.NewSimpleTxManager("batcher", bs.Log, bs.Metrics, cfg.TxMgrConfig)
+	txCfg := cfg.TxMgrConfig
+	if cfg.StateDir != "" {
+		// The pending blob txs are persisted alongside the channels, so all state of the batcher is in one directory.
+		txCfg.StateDir = filepath.Join(cfg.StateDir, txmgrStateDir)
+	}
+	txManager, err := txmgr.NewSimpleTxManager("batcher", bs.Log, bs.Metrics, txCfg)
 	if err != nil {
 		return err
 	}
@@ -284,6 +344,7 @@ func (bs *BatcherService) initPProf(cfg *CLIConfig) error {
 		cfg.PprofConfig.ProfileType,
 		cfg.PprofConfig.ProfileDir,
 		cfg.PprofConfig.ProfileFilename,
+		cfg.PprofConfig.Upload,
 	)
 
 	if err := bs.pprofService.Start(); err != nil {
@@ -327,15 +388,21 @@ func (bs *BatcherService) initDriver() {
 }
 
 func (bs *BatcherService) initRPCServer(cfg *CLIConfig) error {
+	limits, err := cfg.RPC.Limits()
+	if err != nil {
+		return fmt.Errorf("invalid RPC limits: %w", err)
+	}
 	server := oprpc.NewServer(
 		cfg.RPC.ListenAddr,
 		cfg.RPC.ListenPort,
 		bs.Version,
 		oprpc.WithLogger(bs.Log),
+		oprpc.WithLimits(limits, bs.Metrics),
 	)
 	if cfg.RPC.EnableAdmin {
 		adminAPI := rpc.NewAdminAPI(bs.driver, bs.Metrics, bs.Log)
 		server.AddAPI(rpc.GetAdminAPI(adminAPI))
+		server.AddAPI(rpc.GetBatcherAPI(rpc.NewBatcherAPI(bs.driver)))
 		server.AddAPI(bs.TxManager.API())
 		bs.Log.Info("Admin RPC enabled")
 	}
@@ -389,13 +456,20 @@ func (bs *BatcherService) Stop(ctx context.Context) error {
 	}
 	bs.Log.Info("Stopping batcher")
 
+	var result error
+	// Draining needs the TxManager to submit the remaining state, so it has to happen before it is closed.
+	if bs.driver != nil && bs.DrainOnStop {
+		if err := bs.driver.DrainBatchSubmittingIfRunning(ctx); err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to drain batch submitting: %w", err))
+		}
+	}
+
 	// close the TxManager first, so that new work is denied, in-flight work is cancelled as early as possible
 	// (transactions which are expected to be confirmed are still waited for)
 	if bs.TxManager != nil {
 		bs.TxManager.Close()
 	}
 
-	var result error
 	if bs.driver != nil {
 		if err := bs.driver.StopBatchSubmittingIfRunning(ctx); err != nil {
 			result = errors.Join(result, fmt.Errorf("failed to stop batch submitting: %w", err))
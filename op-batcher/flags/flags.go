@@ -12,6 +12,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-batcher/compressor"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/client"
 	openum "github.com/ethereum-optimism/optimism/op-service/enum"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
@@ -81,12 +82,29 @@ var (
 		Usage:   "Maximum number of blocks to add to a span batch. Default is 0 - no maximum.",
 		EnvVars: prefixEnvVars("MAX_BLOCKS_PER_SPAN_BATCH"),
 	}
+	SplitSpansAtEpochsFlag = &cli.BoolFlag{
+		Name:    "split-spans-at-epochs",
+		Usage:   "Start a new span batch at an epoch boundary if that improves the compression of the channel. Costs an extra compression per epoch.",
+		EnvVars: prefixEnvVars("SPLIT_SPANS_AT_EPOCHS"),
+	}
+	SingularBatchFallbackFlag = &cli.BoolFlag{
+		Name:    "singular-batch-fallback",
+		Usage:   "Submit singular batches instead of span batches while Delta isn't active yet.",
+		EnvVars: prefixEnvVars("SINGULAR_BATCH_FALLBACK"),
+	}
 	TargetNumFramesFlag = &cli.IntFlag{
 		Name:    "target-num-frames",
 		Usage:   "The target number of frames to create per channel. Controls number of blobs per blob tx, if using Blob DA.",
 		Value:   1,
 		EnvVars: prefixEnvVars("TARGET_NUM_FRAMES"),
 	}
+	DynamicBlobsFeeThresholdFlag = &cli.Float64Flag{
+		Name: "dynamic-blobs-fee-threshold",
+		Usage: "Blob base fee in GWei above which the number of blobs per blob tx is reduced " +
+			"from target-num-frames, inversely proportional to the blob base fee, down to a single blob. 0 to disable.",
+		Value:   0,
+		EnvVars: prefixEnvVars("DYNAMIC_BLOBS_FEE_THRESHOLD"),
+	}
 	ApproxComprRatioFlag = &cli.Float64Flag{
 		Name:    "approx-compr-ratio",
 		Usage:   "The approximate compression ratio (<= 1.0). Only relevant for ratio compressor.",
@@ -106,14 +124,22 @@ var (
 		},
 	}
 	CompressionAlgoFlag = &cli.GenericFlag{
-		Name:    "compression-algo",
-		Usage:   "The compression algorithm to use. Valid options: " + openum.EnumString(derive.CompressionAlgos),
+		Name: "compression-algo",
+		Usage: "The compression algorithm to use. Valid options: " + openum.EnumString(derive.CompressionAlgos) + ". " +
+			"Brotli requires Fjord. Zstd is experimental, and requires zstd_time to be activated in the rollup config.",
 		EnvVars: prefixEnvVars("COMPRESSION_ALGO"),
 		Value: func() *derive.CompressionAlgo {
 			out := derive.Zlib
 			return &out
 		}(),
 	}
+	StrictOrderingFlag = &cli.BoolFlag{
+		Name: "strict-ordering",
+		Usage: "Enforce Holocene's strict ordering rules: channels are re-derived locally before they are submitted, " +
+			"frames are resubmitted in order after failures, and one channel is submitted at a time. " +
+			"Always enabled for channels with blocks after the Holocene activation.",
+		EnvVars: prefixEnvVars("STRICT_ORDERING"),
+	}
 	StoppedFlag = &cli.BoolFlag{
 		Name:    "stopped",
 		Usage:   "Initialize the batcher in a stopped state. The batcher can be started using the admin_startBatcher RPC",
@@ -136,6 +162,13 @@ var (
 		}(),
 		EnvVars: prefixEnvVars("DATA_AVAILABILITY_TYPE"),
 	}
+	DASwitchThresholdFlag = &cli.Float64Flag{
+		Name: "da-switch-threshold",
+		Usage: "Relative cost advantage, e.g. 0.1 for 10%, that the other DA type needs to have over the " +
+			"currently used one before switching to it. Only applies to the auto data-availability-type.",
+		Value:   0,
+		EnvVars: prefixEnvVars("DA_SWITCH_THRESHOLD"),
+	}
 	ActiveSequencerCheckDurationFlag = &cli.DurationFlag{
 		Name:    "active-sequencer-check-duration",
 		Usage:   "The duration between checks to determine the active sequencer endpoint. ",
@@ -156,6 +189,64 @@ var (
 		Value:   false,
 		EnvVars: prefixEnvVars("WAIT_NODE_SYNC"),
 	}
+	DrainOnStopFlag = &cli.BoolFlag{
+		Name: "drain-on-stop",
+		Usage: "Indicates if, when stopped, the batcher should stop loading new blocks, and submit all loaded blocks and open " +
+			"channels to L1 before exiting. Draining can also be triggered with the batcher_stop RPC.",
+		Value:   false,
+		EnvVars: prefixEnvVars("DRAIN_ON_STOP"),
+	}
+	DrainTimeoutFlag = &cli.DurationFlag{
+		Name: "drain-timeout",
+		Usage: "Maximum time to spend submitting and confirming the remaining state when draining. 0 disables the timeout. " +
+			"Channels that are not submitted by then are persisted to the state-dir, if set.",
+		Value:   10 * time.Minute,
+		EnvVars: prefixEnvVars("DRAIN_TIMEOUT"),
+	}
+	StateDirFlag = &cli.StringFlag{
+		Name: "state-dir",
+		Usage: "Directory to persist closed channels that are not fully submitted yet to, including their frames and " +
+			"confirmed transactions, and pending blob txs. After a restart, the batcher resumes submitting these channels. Disabled if empty.",
+		EnvVars: prefixEnvVars("STATE_DIR"),
+	}
+	ThrottleIntervalFlag = &cli.DurationFlag{
+		Name: "throttle-interval",
+		Usage: "Interval between updates of the DA throttling limits of the sequencer's execution engine, " +
+			"which must support the miner_setMaxDASize RPC. 0 disables throttling.",
+		Value:   0,
+		EnvVars: prefixEnvVars("THROTTLE_INTERVAL"),
+	}
+	ThrottleThresholdFlag = &cli.Uint64Flag{
+		Name:    "throttle-threshold",
+		Usage:   "Estimated size in bytes of the blocks not yet fully submitted to L1 above which DA usage of new L2 blocks is throttled",
+		Value:   1_000_000,
+		EnvVars: prefixEnvVars("THROTTLE_THRESHOLD"),
+	}
+	ThrottleTxSizeFlag = &cli.Uint64Flag{
+		Name:    "throttle-tx-size",
+		Usage:   "Max DA size of a transaction while throttling. 0 means no limit.",
+		Value:   300,
+		EnvVars: prefixEnvVars("THROTTLE_TX_SIZE"),
+	}
+	ThrottleBlockSizeFlag = &cli.Uint64Flag{
+		Name:    "throttle-block-size",
+		Usage:   "Max DA size of a block while throttling. 0 means no limit.",
+		Value:   21_000,
+		EnvVars: prefixEnvVars("THROTTLE_BLOCK_SIZE"),
+	}
+	ThrottleAlwaysBlockSizeFlag = &cli.Uint64Flag{
+		Name:    "throttle-always-block-size",
+		Usage:   "Max DA size of a block that is applied even when not throttling. 0 means no limit.",
+		Value:   130_000,
+		EnvVars: prefixEnvVars("THROTTLE_ALWAYS_BLOCK_SIZE"),
+	}
+	AltDAFailoverGracePeriodFlag = &cli.DurationFlag{
+		Name: "altda-failover-grace-period",
+		Usage: "Time the alt-DA server may be unavailable before the batch data is posted directly to L1 as calldata instead, " +
+			"until the server accepts inputs again. The frames must fit into a calldata transaction. 0 disables failover.",
+		Value:   0,
+		EnvVars: prefixEnvVars("ALTDA_FAILOVER_GRACE_PERIOD"),
+	}
 	// Legacy Flags
 	SequencerHDPathFlag = txmgr.SequencerHDPathFlag
 )
@@ -169,13 +260,21 @@ var requiredFlags = []cli.Flag{
 var optionalFlags = []cli.Flag{
 	WaitNodeSyncFlag,
 	CheckRecentTxsDepthFlag,
+	DrainOnStopFlag,
+	DrainTimeoutFlag,
+	StateDirFlag,
 	SubSafetyMarginFlag,
 	PollIntervalFlag,
 	MaxPendingTransactionsFlag,
 	MaxChannelDurationFlag,
 	MaxL1TxSizeBytesFlag,
 	MaxBlocksPerSpanBatch,
+	SplitSpansAtEpochsFlag,
+	SingularBatchFallbackFlag,
+	StrictOrderingFlag,
 	TargetNumFramesFlag,
+	DynamicBlobsFeeThresholdFlag,
+	DASwitchThresholdFlag,
 	ApproxComprRatioFlag,
 	CompressorFlag,
 	StoppedFlag,
@@ -184,6 +283,12 @@ var optionalFlags = []cli.Flag{
 	DataAvailabilityTypeFlag,
 	ActiveSequencerCheckDurationFlag,
 	CompressionAlgoFlag,
+	ThrottleIntervalFlag,
+	ThrottleThresholdFlag,
+	ThrottleTxSizeFlag,
+	ThrottleBlockSizeFlag,
+	ThrottleAlwaysBlockSizeFlag,
+	AltDAFailoverGracePeriodFlag,
 }
 
 func init() {
@@ -193,6 +298,7 @@ func init() {
 	optionalFlags = append(optionalFlags, oppprof.CLIFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, txmgr.CLIFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, altda.CLIFlags(EnvVarPrefix, "")...)
+	optionalFlags = append(optionalFlags, client.CircuitBreakerCLIFlags(EnvVarPrefix)...)
 
 	Flags = append(requiredFlags, optionalFlags...)
 }
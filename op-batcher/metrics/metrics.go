@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"io"
+	"math/big"
 
 	"github.com/prometheus/client_golang/prometheus"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
@@ -29,6 +31,7 @@ type Metricer interface {
 	txmetrics.TxMetricer
 
 	opmetrics.RPCMetricer
+	opmetrics.CircuitBreakerMetricer
 
 	StartBalanceMetrics(l log.Logger, client *ethclient.Client, account common.Address) io.Closer
 
@@ -39,6 +42,7 @@ type Metricer interface {
 	RecordL2BlockInPendingQueue(block *types.Block)
 	RecordL2BlockInChannel(block *types.Block)
 	RecordChannelClosed(id derive.ChannelID, numPendingBlocks int, numFrames int, inputBytes int, outputComprBytes int, reason error)
+	RecordChannelComprRatio(batching string, compression string, inputBytes int, outputComprBytes int)
 	RecordChannelFullySubmitted(id derive.ChannelID)
 	RecordChannelTimedOut(id derive.ChannelID)
 
@@ -47,6 +51,13 @@ type Metricer interface {
 	RecordBatchTxFailed()
 
 	RecordBlobUsedBytes(num int)
+	RecordBlobsPerTx(num int)
+
+	RecordBacklogBytes(bytes uint64)
+	RecordThrottle(maxTxSize, maxBlockSize uint64)
+	RecordAltDAFailover(active bool)
+
+	RecordDACost(da string, compression string, calldataBytes, blobBytes, gasUsed, blobGasUsed uint64, cost *big.Int)
 
 	Document() []opmetrics.DocumentedMetric
 }
@@ -77,12 +88,23 @@ type Metrics struct {
 	channelClosedReason     prometheus.Gauge
 	channelNumFrames        prometheus.Gauge
 	channelComprRatio       prometheus.Histogram
+	channelConfigComprRatio prometheus.HistogramVec
 	channelInputBytesTotal  prometheus.Counter
 	channelOutputBytesTotal prometheus.Counter
 
 	batcherTxEvs opmetrics.EventVec
 
 	blobUsedBytes prometheus.Histogram
+	blobsPerTx    prometheus.Histogram
+
+	backlogBytes      prometheus.Gauge
+	throttleMaxDASize prometheus.GaugeVec
+	altDAFailover     prometheus.Gauge
+
+	daBytes       prometheus.CounterVec
+	daGasUsed     prometheus.CounterVec
+	daBlobGasUsed prometheus.Counter
+	daCost        prometheus.CounterVec
 }
 
 var _ Metricer = (*Metrics)(nil)
@@ -175,6 +197,12 @@ func NewMetrics(procName string) *Metrics {
 			Help:      "Compression ratios of closed channel.",
 			Buckets:   append([]float64{0.1, 0.2}, prometheus.LinearBuckets(0.3, 0.05, 14)...),
 		}),
+		channelConfigComprRatio: *factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: ns,
+			Name:      "channel_config_compr_ratio",
+			Help:      "Compression ratios of closed channels, by batch and compression configuration.",
+			Buckets:   append([]float64{0.1, 0.2}, prometheus.LinearBuckets(0.3, 0.05, 14)...),
+		}, []string{"batching", "compression"}),
 		channelInputBytesTotal: factory.NewCounter(prometheus.CounterOpts{
 			Namespace: ns,
 			Name:      "input_bytes_total",
@@ -192,6 +220,50 @@ func NewMetrics(procName string) *Metrics {
 			Buckets:   prometheus.LinearBuckets(0.0, eth.MaxBlobDataSize/13, 14),
 		}),
 
+		blobsPerTx: factory.NewHistogram(prometheus.HistogramOpts{
+			Namespace: ns,
+			Name:      "blobs_per_tx",
+			Help:      "Number of blobs per blob tx.",
+			Buckets:   prometheus.LinearBuckets(1, 1, 6),
+		}),
+
+		backlogBytes: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "backlog_bytes",
+			Help:      "Estimated batch size of the blocks loaded from L2 that are not fully submitted to L1 yet.",
+		}),
+		throttleMaxDASize: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "throttle_max_da_size",
+			Help:      "Max DA size of transactions and blocks currently applied to the sequencer by DA throttling (0 == no limit).",
+		}, []string{"limit"}),
+		altDAFailover: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "altda_failover",
+			Help:      "1 if batch data is posted directly to L1 because the alt-DA server is unavailable, 0 otherwise.",
+		}),
+
+		daBytes: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "da_bytes_total",
+			Help:      "Total calldata and blob data bytes of confirmed batch transactions.",
+		}, []string{"da", "compression"}),
+		daGasUsed: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "da_gas_used_total",
+			Help:      "Total gas used by confirmed batch transactions.",
+		}, []string{"da"}),
+		daBlobGasUsed: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "da_blob_gas_used_total",
+			Help:      "Total blob gas used by confirmed batch transactions.",
+		}),
+		daCost: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "da_cost_gwei_total",
+			Help:      "Total fees (in gwei) paid for confirmed batch transactions, including blob fees.",
+		}, []string{"da", "compression"}),
+
 		batcherTxEvs: opmetrics.NewEventVec(factory, ns, "", "batcher_tx", "BatcherTx", []string{"stage"}),
 	}
 }
@@ -277,14 +349,21 @@ func (m *Metrics) RecordChannelClosed(id derive.ChannelID, numPendingBlocks int,
 	m.channelClosedReason.Set(float64(ClosedReasonToNum(reason)))
 }
 
+func (m *Metrics) RecordChannelComprRatio(batching string, compression string, inputBytes int, outputComprBytes int) {
+	if inputBytes == 0 {
+		return
+	}
+	m.channelConfigComprRatio.WithLabelValues(batching, compression).Observe(float64(outputComprBytes) / float64(inputBytes))
+}
+
 func (m *Metrics) RecordL2BlockInPendingQueue(block *types.Block) {
-	size := float64(estimateBatchSize(block))
+	size := float64(EstimateBatchSize(block))
 	m.pendingBlocksBytesTotal.Add(size)
 	m.pendingBlocksBytesCurrent.Add(size)
 }
 
 func (m *Metrics) RecordL2BlockInChannel(block *types.Block) {
-	size := float64(estimateBatchSize(block))
+	size := float64(EstimateBatchSize(block))
 	m.pendingBlocksBytesCurrent.Add(-1 * size)
 	// Refer to RecordL2BlocksAdded to see the current + count of bytes added to a channel
 }
@@ -318,8 +397,37 @@ func (m *Metrics) RecordBlobUsedBytes(num int) {
 	m.blobUsedBytes.Observe(float64(num))
 }
 
-// estimateBatchSize estimates the size of the batch
-func estimateBatchSize(block *types.Block) uint64 {
+func (m *Metrics) RecordBlobsPerTx(num int) {
+	m.blobsPerTx.Observe(float64(num))
+}
+
+func (m *Metrics) RecordBacklogBytes(bytes uint64) {
+	m.backlogBytes.Set(float64(bytes))
+}
+
+func (m *Metrics) RecordThrottle(maxTxSize, maxBlockSize uint64) {
+	m.throttleMaxDASize.WithLabelValues("tx").Set(float64(maxTxSize))
+	m.throttleMaxDASize.WithLabelValues("block").Set(float64(maxBlockSize))
+}
+
+func (m *Metrics) RecordAltDAFailover(active bool) {
+	if active {
+		m.altDAFailover.Set(1)
+	} else {
+		m.altDAFailover.Set(0)
+	}
+}
+
+func (m *Metrics) RecordDACost(da string, compression string, calldataBytes, blobBytes, gasUsed, blobGasUsed uint64, cost *big.Int) {
+	m.daBytes.WithLabelValues(da, compression).Add(float64(calldataBytes + blobBytes))
+	m.daGasUsed.WithLabelValues(da).Add(float64(gasUsed))
+	m.daBlobGasUsed.Add(float64(blobGasUsed))
+	gwei, _ := new(big.Float).Quo(new(big.Float).SetInt(cost), big.NewFloat(params.GWei)).Float64()
+	m.daCost.WithLabelValues(da, compression).Add(gwei)
+}
+
+// EstimateBatchSize estimates the size of the batch
+func EstimateBatchSize(block *types.Block) uint64 {
 	size := uint64(70) // estimated overhead of batch metadata
 	for _, tx := range block.Transactions() {
 		// Don't include deposit transactions in the batch.
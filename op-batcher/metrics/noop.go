@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"io"
+	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -35,6 +36,7 @@ func (*noopMetrics) RecordL2BlockInPendingQueue(*types.Block)               {}
 func (*noopMetrics) RecordL2BlockInChannel(*types.Block)                    {}
 
 func (*noopMetrics) RecordChannelClosed(derive.ChannelID, int, int, int, int, error) {}
+func (*noopMetrics) RecordChannelComprRatio(string, string, int, int)                {}
 
 func (*noopMetrics) RecordChannelFullySubmitted(derive.ChannelID) {}
 func (*noopMetrics) RecordChannelTimedOut(derive.ChannelID)       {}
@@ -43,6 +45,14 @@ func (*noopMetrics) RecordBatchTxSubmitted() {}
 func (*noopMetrics) RecordBatchTxSuccess()   {}
 func (*noopMetrics) RecordBatchTxFailed()    {}
 func (*noopMetrics) RecordBlobUsedBytes(int) {}
+func (*noopMetrics) RecordBlobsPerTx(int)    {}
+
+func (*noopMetrics) RecordBacklogBytes(uint64)     {}
+func (*noopMetrics) RecordThrottle(uint64, uint64) {}
+func (*noopMetrics) RecordAltDAFailover(bool)      {}
+
+func (*noopMetrics) RecordDACost(string, string, uint64, uint64, uint64, uint64, *big.Int) {}
+
 func (*noopMetrics) StartBalanceMetrics(log.Logger, *ethclient.Client, common.Address) io.Closer {
 	return nil
 }
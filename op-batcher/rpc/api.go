@@ -6,6 +6,7 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 	gethrpc "github.com/ethereum/go-ethereum/rpc"
 
+	"github.com/ethereum-optimism/optimism/op-batcher/accounting"
 	"github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/rpc"
 )
@@ -13,6 +14,8 @@ import (
 type BatcherDriver interface {
 	StartBatchSubmitting() error
 	StopBatchSubmitting(ctx context.Context) error
+	DrainBatchSubmitting(ctx context.Context) error
+	CostReport() []accounting.DailyCost
 }
 
 type adminAPI struct {
@@ -41,3 +44,30 @@ func (a *adminAPI) StartBatcher(_ context.Context) error {
 func (a *adminAPI) StopBatcher(ctx context.Context) error {
 	return a.b.StopBatchSubmitting(ctx)
 }
+
+type batcherAPI struct {
+	b BatcherDriver
+}
+
+func NewBatcherAPI(dr BatcherDriver) *batcherAPI {
+	return &batcherAPI{b: dr}
+}
+
+func GetBatcherAPI(api *batcherAPI) gethrpc.API {
+	return gethrpc.API{
+		Namespace: "batcher",
+		Service:   api,
+	}
+}
+
+// Stop drains the batcher: it stops loading new blocks, and submits all loaded blocks and open channels to L1
+// before stopping batch submission. Batch submission can be restarted with admin_startBatcher.
+func (a *batcherAPI) Stop(ctx context.Context) error {
+	return a.b.DrainBatchSubmitting(ctx)
+}
+
+// CostReport returns the daily data availability costs of the confirmed batch transactions,
+// per DA type and compression setting. Costs are only kept in memory, for a limited number of days.
+func (a *batcherAPI) CostReport(_ context.Context) ([]accounting.DailyCost, error) {
+	return a.b.CostReport(), nil
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
+	"net/http"
+	"strconv"
 
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/urfave/cli/v2"
@@ -12,13 +15,16 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rpc"
 
+	"github.com/ethereum-optimism/optimism/op-bootnode/flags"
 	opnode "github.com/ethereum-optimism/optimism/op-node"
 	"github.com/ethereum-optimism/optimism/op-node/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/p2p"
 	p2pcli "github.com/ethereum-optimism/optimism/op-node/p2p/cli"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum-optimism/optimism/op-service/ctxinterrupt"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/httputil"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
@@ -100,6 +106,27 @@ func Main(cliCtx *cli.Context) error {
 
 	go p2pNode.DiscoveryProcess(ctx, logger, config, p2pConfig.TargetPeers())
 
+	if cliCtx.Bool(flags.RegistryEnabledFlagName) {
+		crawlCtx, crawlCancel := context.WithCancel(ctx)
+		defer crawlCancel()
+		registry := p2p.NewENRRegistry(clock.SystemClock, cliCtx.Duration(flags.RegistryExpiryFlagName))
+		go p2p.Crawl(crawlCtx, logger, config, p2pNode.Dv5Udp(), registry, cliCtx.Duration(flags.CrawlIntervalFlagName))
+
+		mux := http.NewServeMux()
+		mux.Handle("/enrs", registry)
+		addr := net.JoinHostPort(cliCtx.String(flags.RegistryAddrFlagName), strconv.Itoa(cliCtx.Int(flags.RegistryPortFlagName)))
+		registrySrv, err := httputil.StartHTTPServer(addr, mux)
+		if err != nil {
+			return fmt.Errorf("failed to start ENR registry server: %w", err)
+		}
+		defer func() {
+			if err := registrySrv.Stop(context.Background()); err != nil {
+				log.Error("failed to stop ENR registry server", "err", err)
+			}
+		}()
+		log.Info("started ENR registry server", "addr", registrySrv.Addr())
+	}
+
 	metricsCfg := opmetrics.ReadCLIConfig(cliCtx)
 	if metricsCfg.Enabled {
 		log.Debug("starting metrics server", "addr", metricsCfg.ListenAddr, "port", metricsCfg.ListenPort)
@@ -1,9 +1,12 @@
 package flags
 
 import (
+	"time"
+
 	"github.com/urfave/cli/v2"
 
 	"github.com/ethereum-optimism/optimism/op-node/flags"
+	opservice "github.com/ethereum-optimism/optimism/op-service"
 	opflags "github.com/ethereum-optimism/optimism/op-service/flags"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
@@ -12,9 +15,54 @@ import (
 
 const envVarPrefix = "OP_BOOTNODE"
 
+const (
+	RegistryEnabledFlagName = "registry.enabled"
+	RegistryAddrFlagName    = "registry.addr"
+	RegistryPortFlagName    = "registry.port"
+	RegistryExpiryFlagName  = "registry.expiry"
+	CrawlIntervalFlagName   = "crawl.interval"
+)
+
+var (
+	RegistryEnabledFlag = &cli.BoolFlag{
+		Name:    RegistryEnabledFlagName,
+		Usage:   "Crawl the DHT for node records of the chain and serve them over HTTP",
+		EnvVars: opservice.PrefixEnvVar(envVarPrefix, "REGISTRY_ENABLED"),
+	}
+	RegistryAddrFlag = &cli.StringFlag{
+		Name:    RegistryAddrFlagName,
+		Usage:   "Address the ENR registry HTTP server listens on",
+		Value:   "0.0.0.0",
+		EnvVars: opservice.PrefixEnvVar(envVarPrefix, "REGISTRY_ADDR"),
+	}
+	RegistryPortFlag = &cli.IntFlag{
+		Name:    RegistryPortFlagName,
+		Usage:   "Port the ENR registry HTTP server listens on",
+		Value:   7310,
+		EnvVars: opservice.PrefixEnvVar(envVarPrefix, "REGISTRY_PORT"),
+	}
+	RegistryExpiryFlag = &cli.DurationFlag{
+		Name:    RegistryExpiryFlagName,
+		Usage:   "Duration after which a node record that has not been crawled again is dropped from the registry",
+		Value:   24 * time.Hour,
+		EnvVars: opservice.PrefixEnvVar(envVarPrefix, "REGISTRY_EXPIRY"),
+	}
+	CrawlIntervalFlag = &cli.DurationFlag{
+		Name:    CrawlIntervalFlagName,
+		Usage:   "Interval between pulling node records from the DHT when crawling",
+		Value:   time.Second,
+		EnvVars: opservice.PrefixEnvVar(envVarPrefix, "CRAWL_INTERVAL"),
+	}
+)
+
 var Flags = []cli.Flag{
 	opflags.CLINetworkFlag(envVarPrefix, ""),
 	opflags.CLIRollupConfigFlag(envVarPrefix, ""),
+	RegistryEnabledFlag,
+	RegistryAddrFlag,
+	RegistryPortFlag,
+	RegistryExpiryFlag,
+	CrawlIntervalFlag,
 }
 
 func init() {
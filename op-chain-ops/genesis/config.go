@@ -21,6 +21,7 @@ import (
 	opparams "github.com/ethereum-optimism/optimism/op-node/params"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/predeploys"
 )
 
 var (
@@ -469,6 +470,9 @@ type L2CoreDeployConfig struct {
 	// sequencing window that batches must be included, otherwise L2 blocks including
 	// deposits are force included.
 	MaxSequencerDrift uint64 `json:"maxSequencerDrift"`
+	// SequencerDriftPolicy defines the L2 blocks that are produced once the MaxSequencerDrift is exceeded.
+	// Deposit-only blocks are produced if empty.
+	SequencerDriftPolicy rollup.SequencerDriftPolicy `json:"sequencerDriftPolicy,omitempty"`
 	// SequencerWindowSize is the number of L1 blocks per sequencing window.
 	SequencerWindowSize uint64 `json:"sequencerWindowSize"`
 	// ChannelTimeoutBedrock is the number of L1 blocks that a frame stays valid when included in L1.
@@ -549,6 +553,65 @@ func (d *AltDADeployConfig) Check(log log.Logger) error {
 	return nil
 }
 
+// CustomPredeploy is an additional account in the L2 genesis state, or an override of an account in it.
+type CustomPredeploy struct {
+	// Address of the account.
+	Address common.Address `json:"address"`
+	// Artifact is the path to a forge artifact, of which the deployed bytecode is used as code of the account.
+	// The constructor is not run: any state it would initialize must be set in Storage instead.
+	Artifact string `json:"artifact,omitempty"`
+	// Code is the code of the account, as alternative to Artifact.
+	Code hexutil.Bytes `json:"code,omitempty"`
+	// Storage slots to set. Slots of an existing account that are not set here are preserved.
+	Storage map[common.Hash]common.Hash `json:"storage,omitempty"`
+	// Balance of the account, if set.
+	Balance *hexutil.Big `json:"balance,omitempty"`
+}
+
+// CustomPredeploysDeployConfig configures accounts to add to, or override in, the L2 genesis state.
+// Overriding the storage of the predeploy proxies changes the defaults that the predeploys are initialized with,
+// e.g. the GasPriceOracle constants.
+type CustomPredeploysDeployConfig struct {
+	// CustomPredeploys are applied in order, after the predeploys are set up.
+	CustomPredeploys []CustomPredeploy `json:"customPredeploys,omitempty"`
+}
+
+var _ ConfigChecker = (*CustomPredeploysDeployConfig)(nil)
+
+func (d *CustomPredeploysDeployConfig) Check(log log.Logger) error {
+	seen := make(map[common.Address]struct{})
+	for i, p := range d.CustomPredeploys {
+		if p.Address == (common.Address{}) {
+			return fmt.Errorf("%w: custom predeploy %d cannot be address(0)", ErrInvalidDeployConfig, i)
+		}
+		if _, ok := seen[p.Address]; ok {
+			return fmt.Errorf("%w: custom predeploy %s is configured more than once", ErrInvalidDeployConfig, p.Address)
+		}
+		seen[p.Address] = struct{}{}
+		if p.Artifact != "" && len(p.Code) != 0 {
+			return fmt.Errorf("%w: custom predeploy %s cannot have both an artifact and code", ErrInvalidDeployConfig, p.Address)
+		}
+		if p.Artifact == "" && len(p.Code) == 0 && len(p.Storage) == 0 && p.Balance == nil {
+			return fmt.Errorf("%w: custom predeploy %s does not configure anything", ErrInvalidDeployConfig, p.Address)
+		}
+		hasCode := p.Artifact != "" || len(p.Code) != 0
+		if p.Address.Big().Cmp(big.NewInt(0x100)) <= 0 {
+			log.Warn("Custom predeploy is in the range of precompile addresses, and may be shadowed by a precompile", "address", p.Address)
+		}
+		if predeploy, ok := predeploys.PredeploysByAddress[p.Address]; ok {
+			if hasCode && !predeploy.ProxyDisabled {
+				log.Warn("Custom predeploy replaces the proxy of a predeploy", "address", p.Address)
+			} else if hasCode {
+				log.Warn("Custom predeploy replaces the code of a predeploy", "address", p.Address)
+			}
+		} else if inPredeployNamespace(p.Address) {
+			// the predeploy namespace is reserved for future predeploys
+			log.Warn("Custom predeploy is in the predeploy namespace, but is not a known predeploy", "address", p.Address)
+		}
+	}
+	return nil
+}
+
 // L2InitializationConfig represents all L2 configuration
 // data that can be configured before the deployment of any L1 contracts.
 type L2InitializationConfig struct {
@@ -564,6 +627,7 @@ type L2InitializationConfig struct {
 	UpgradeScheduleDeployConfig
 	L2CoreDeployConfig
 	AltDADeployConfig
+	CustomPredeploysDeployConfig
 }
 
 func (d *L2InitializationConfig) Check(log log.Logger) error {
@@ -914,6 +978,7 @@ func (d *DeployConfig) RollupConfig(l1StartBlock *types.Block, l2GenesisBlockHas
 		},
 		BlockTime:              d.L2BlockTime,
 		MaxSequencerDrift:      d.MaxSequencerDrift,
+		SequencerDriftPolicy:   d.SequencerDriftPolicy,
 		SeqWindowSize:          d.SequencerWindowSize,
 		ChannelTimeoutBedrock:  d.ChannelTimeoutBedrock,
 		L1ChainID:              new(big.Int).SetUint64(d.L1ChainID),
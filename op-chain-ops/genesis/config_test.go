@@ -14,6 +14,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/ethereum-optimism/optimism/op-service/predeploys"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 )
 
@@ -126,3 +127,65 @@ func TestL1Deployments(t *testing.T) {
 	// One that doesn't exist returns empty string
 	require.Equal(t, "", deployments.GetName(common.Address{19: 0xff}))
 }
+
+func TestCustomPredeploysCheck(t *testing.T) {
+	code := hexutil.Bytes{0x60, 0x80}
+	storage := map[common.Hash]common.Hash{{}: {0x01}}
+	customAddr := common.HexToAddress("0x4200000000000000000000000000000000000f00")
+	tests := []struct {
+		name       string
+		predeploys []CustomPredeploy
+		err        string
+		warning    string
+	}{
+		{name: "Valid", predeploys: []CustomPredeploy{{Address: customAddr, Code: code, Storage: storage}}},
+		{name: "StorageOverride", predeploys: []CustomPredeploy{{Address: predeploys.GasPriceOracleAddr, Storage: storage}}},
+		{name: "ZeroAddress", predeploys: []CustomPredeploy{{Code: code}}, err: "address(0)"},
+		{
+			name:       "Duplicate",
+			predeploys: []CustomPredeploy{{Address: customAddr, Code: code}, {Address: customAddr, Storage: storage}},
+			err:        "more than once",
+		},
+		{name: "ArtifactAndCode", predeploys: []CustomPredeploy{{Address: customAddr, Artifact: "a.json", Code: code}}, err: "both"},
+		{name: "Empty", predeploys: []CustomPredeploy{{Address: customAddr}}, err: "does not configure anything"},
+		{
+			name:       "Precompile",
+			predeploys: []CustomPredeploy{{Address: common.HexToAddress("0x05"), Code: code}},
+			warning:    "range of precompile addresses",
+		},
+		{
+			name:       "ReplacesProxy",
+			predeploys: []CustomPredeploy{{Address: predeploys.GasPriceOracleAddr, Code: code}},
+			warning:    "replaces the proxy",
+		},
+		{
+			name:       "ReplacesCode",
+			predeploys: []CustomPredeploy{{Address: predeploys.WETHAddr, Code: code}},
+			warning:    "replaces the code",
+		},
+		{
+			name:       "PredeployNamespace",
+			predeploys: []CustomPredeploy{{Address: common.HexToAddress("0x42000000000000000000000000000000000007ff"), Code: code}},
+			warning:    "not a known predeploy",
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			lgr, logs := testlog.CaptureLogger(t, log.LevelWarn)
+			cfg := &CustomPredeploysDeployConfig{CustomPredeploys: test.predeploys}
+			err := cfg.Check(lgr)
+			if test.err != "" {
+				require.ErrorIs(t, err, ErrInvalidDeployConfig)
+				require.ErrorContains(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			if test.warning != "" {
+				require.NotNil(t, logs.FindLog(testlog.NewLevelFilter(log.LevelWarn), testlog.NewMessageContainsFilter(test.warning)))
+			} else {
+				require.Nil(t, logs.FindLog(testlog.NewLevelFilter(log.LevelWarn)))
+			}
+		})
+	}
+}
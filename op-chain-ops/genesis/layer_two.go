@@ -26,6 +26,9 @@ const (
 	L2AllocsGranite L2AllocsMode = "granite"
 )
 
+// predeployNamespaceSize is the number of addresses in the predeploy namespace
+const predeployNamespaceSize = 2048
+
 var (
 	// l2PredeployNamespace is the namespace for L2 predeploys
 	l2PredeployNamespace = common.HexToAddress("0x4200000000000000000000000000000000000000")
@@ -42,6 +45,9 @@ func BuildL2Genesis(config *DeployConfig, dump *foundry.ForgeAllocs, l1StartBloc
 		return nil, err
 	}
 	genspec.Alloc = dump.Copy().Accounts
+	if err := ApplyCustomPredeploys(genspec.Alloc, config.CustomPredeploys); err != nil {
+		return nil, err
+	}
 	// ensure the dev accounts are not funded unintentionally
 	if hasDevAccounts, err := HasAnyDevAccounts(genspec.Alloc); err != nil {
 		return nil, fmt.Errorf("failed to check dev accounts: %w", err)
@@ -60,7 +66,7 @@ func BuildL2Genesis(config *DeployConfig, dump *foundry.ForgeAllocs, l1StartBloc
 		}
 	}
 	// sanity check that all predeploys are present
-	for i := 0; i < 2048; i++ {
+	for i := 0; i < predeployNamespaceSize; i++ {
 		addr := common.BigToAddress(new(big.Int).Or(l2PredeployNamespace.Big(), big.NewInt(int64(i))))
 		if !config.GovernanceEnabled() && addr == predeploys.GovernanceTokenAddr {
 			continue
@@ -73,6 +79,51 @@ func BuildL2Genesis(config *DeployConfig, dump *foundry.ForgeAllocs, l1StartBloc
 	return genspec, nil
 }
 
+// inPredeployNamespace reports whether the address is in the namespace of the L2 predeploys.
+func inPredeployNamespace(addr common.Address) bool {
+	offset := new(big.Int).Sub(addr.Big(), l2PredeployNamespace.Big())
+	return offset.Sign() >= 0 && offset.Cmp(big.NewInt(predeployNamespaceSize)) < 0
+}
+
+// ApplyCustomPredeploys adds the custom predeploys to the allocs, or overrides the accounts that exist already.
+func ApplyCustomPredeploys(allocs types.GenesisAlloc, customPredeploys []CustomPredeploy) error {
+	for _, p := range customPredeploys {
+		account := allocs[p.Address]
+		code := []byte(p.Code)
+		if p.Artifact != "" {
+			artifact, err := foundry.ReadArtifact(p.Artifact)
+			if err != nil {
+				return fmt.Errorf("failed to read artifact of custom predeploy %s: %w", p.Address, err)
+			}
+			code = artifact.DeployedBytecode.Object
+			if len(code) == 0 {
+				return fmt.Errorf("artifact %s of custom predeploy %s has no deployed bytecode", p.Artifact, p.Address)
+			}
+		}
+		if len(code) != 0 {
+			account.Code = code
+		}
+		if len(p.Storage) != 0 {
+			storage := make(map[common.Hash]common.Hash, len(account.Storage)+len(p.Storage))
+			for k, v := range account.Storage {
+				storage[k] = v
+			}
+			for k, v := range p.Storage {
+				storage[k] = v
+			}
+			account.Storage = storage
+		}
+		if p.Balance != nil {
+			account.Balance = p.Balance.ToInt()
+		}
+		if account.Balance == nil {
+			account.Balance = new(big.Int)
+		}
+		allocs[p.Address] = account
+	}
+	return nil
+}
+
 func HasAnyDevAccounts(allocs types.GenesisAlloc) (bool, error) {
 	wallet, err := hdwallet.NewFromMnemonic(testMnemonic)
 	if err != nil {
@@ -0,0 +1,62 @@
+package genesis
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/ethereum-optimism/optimism/op-service/predeploys"
+)
+
+func TestApplyCustomPredeploys(t *testing.T) {
+	slot0, slot1 := common.Hash{}, common.Hash{0x01}
+	proxyCode := []byte{0x60, 0x01}
+	allocs := types.GenesisAlloc{
+		predeploys.GasPriceOracleAddr: {
+			Code:    proxyCode,
+			Storage: map[common.Hash]common.Hash{slot0: {0xaa}, slot1: {0xbb}},
+			Balance: big.NewInt(7),
+		},
+	}
+
+	artifactPath := filepath.Join(t.TempDir(), "Custom.json")
+	artifact := `{"abi":[],"bytecode":{"object":"0x6000"},"deployedBytecode":{"object":"0x6080604052"}}`
+	require.NoError(t, os.WriteFile(artifactPath, []byte(artifact), 0o644))
+
+	fromArtifact := common.HexToAddress("0x4200000000000000000000000000000000000f00")
+	withCode := common.HexToAddress("0x4200000000000000000000000000000000000f01")
+	err := ApplyCustomPredeploys(allocs, []CustomPredeploy{
+		{Address: predeploys.GasPriceOracleAddr, Storage: map[common.Hash]common.Hash{slot1: {0xcc}}},
+		{Address: fromArtifact, Artifact: artifactPath, Storage: map[common.Hash]common.Hash{slot0: {0x01}}},
+		{Address: withCode, Code: hexutil.Bytes{0x60, 0x02}, Balance: (*hexutil.Big)(big.NewInt(100))},
+	})
+	require.NoError(t, err)
+
+	// storage overrides preserve the code, balance and other storage of the account
+	gpo := allocs[predeploys.GasPriceOracleAddr]
+	require.Equal(t, proxyCode, gpo.Code)
+	require.Equal(t, big.NewInt(7), gpo.Balance)
+	require.Equal(t, map[common.Hash]common.Hash{slot0: {0xaa}, slot1: {0xcc}}, gpo.Storage)
+
+	custom := allocs[fromArtifact]
+	require.Equal(t, []byte{0x60, 0x80, 0x60, 0x40, 0x52}, custom.Code)
+	require.Equal(t, map[common.Hash]common.Hash{slot0: {0x01}}, custom.Storage)
+	require.Equal(t, new(big.Int), custom.Balance)
+
+	require.Equal(t, []byte{0x60, 0x02}, allocs[withCode].Code)
+	require.Equal(t, big.NewInt(100), allocs[withCode].Balance)
+
+	t.Run("MissingArtifact", func(t *testing.T) {
+		err := ApplyCustomPredeploys(types.GenesisAlloc{}, []CustomPredeploy{
+			{Address: fromArtifact, Artifact: filepath.Join(t.TempDir(), "Missing.json")},
+		})
+		require.ErrorContains(t, err, "failed to read artifact")
+	})
+}
@@ -0,0 +1,123 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"path"
+	"strconv"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/sender"
+	"github.com/ethereum-optimism/optimism/op-service/httputil"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+type PendingTxSource interface {
+	PendingTxs() []sender.PendingTx
+}
+
+type GameRegistrar interface {
+	RegisterGame(game common.Address) error
+}
+
+// Server serves a JSON view of the games tracked by the challenger and its pending transactions.
+// The only write operation is registering a game to play, by posting to the game's path.
+// It is only served if a GameRegistrar is configured, as the server is not authenticated.
+type Server struct {
+	log     log.Logger
+	addr    string
+	tracker *Tracker
+	txs     PendingTxSource
+	games   GameRegistrar
+
+	httpServer *httputil.HTTPServer
+}
+
+// NewServer creates an API server. Games can be registered with games, if not nil.
+func NewServer(logger log.Logger, host string, port int, tracker *Tracker, txs PendingTxSource, games GameRegistrar) *Server {
+	return &Server{
+		log:     logger,
+		addr:    net.JoinHostPort(host, strconv.Itoa(port)),
+		tracker: tracker,
+		txs:     txs,
+		games:   games,
+	}
+}
+
+func (s *Server) Start() error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/games", s.handleGames)
+	mux.HandleFunc("/games/", s.handleGame)
+	mux.HandleFunc("/transactions", s.handleTransactions)
+	httpServer, err := httputil.StartHTTPServer(s.addr, mux)
+	if err != nil {
+		return fmt.Errorf("failed to start API server: %w", err)
+	}
+	s.log.Info("Started API server", "addr", httpServer.Addr())
+	s.httpServer = httpServer
+	return nil
+}
+
+func (s *Server) Addr() net.Addr {
+	return s.httpServer.Addr()
+}
+
+func (s *Server) Stop(ctx context.Context) error {
+	if s.httpServer == nil {
+		return nil
+	}
+	return s.httpServer.Stop(ctx)
+}
+
+func (s *Server) handleGames(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	s.writeJSON(w, s.tracker.Games())
+}
+
+func (s *Server) handleGame(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && (r.Method != http.MethodPost || s.games == nil) {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	key := path.Base(r.URL.Path)
+	if !common.IsHexAddress(key) {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	addr := common.HexToAddress(key)
+	if r.Method == http.MethodPost {
+		if err := s.games.RegisterGame(addr); err != nil {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+	game, ok := s.tracker.Game(addr)
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	s.writeJSON(w, game)
+}
+
+func (s *Server) handleTransactions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	s.writeJSON(w, s.txs.PendingTxs())
+}
+
+func (s *Server) writeJSON(w http.ResponseWriter, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		s.log.Warn("Failed to write API response", "err", err)
+	}
+}
@@ -0,0 +1,129 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/sender"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+type stubGameRegistrar struct {
+	games []common.Address
+	err   error
+}
+
+func (s *stubGameRegistrar) RegisterGame(game common.Address) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.games = append(s.games, game)
+	return nil
+}
+
+type stubPendingTxs []sender.PendingTx
+
+func (s stubPendingTxs) PendingTxs() []sender.PendingTx {
+	return s
+}
+
+func TestServer(t *testing.T) {
+	tracker := NewTracker([]common.Address{ourAddr})
+	tracker.TrackGames([]types.GameMetadata{{Index: 3, GameType: 1, Timestamp: 500, Proxy: gameAddr1}})
+	to := common.Address{0xcc}
+	txs := stubPendingTxs{{Purpose: "respond", To: &to}}
+	registrar := new(stubGameRegistrar)
+	server := NewServer(testlog.Logger(t, log.LevelInfo), "127.0.0.1", 0, tracker, txs, registrar)
+	require.NoError(t, server.Start())
+	t.Cleanup(func() {
+		require.NoError(t, server.Stop(context.Background()))
+	})
+	baseURL := "http://" + server.Addr().String()
+
+	get := func(path string, expectedStatus int, result any) {
+		resp, err := http.Get(baseURL + path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, expectedStatus, resp.StatusCode)
+		if result != nil {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(result))
+		}
+	}
+
+	t.Run("Games", func(t *testing.T) {
+		var games []GameView
+		get("/games", http.StatusOK, &games)
+		require.Len(t, games, 1)
+		require.Equal(t, gameAddr1, games[0].Address)
+		require.Equal(t, uint64(3), games[0].Index)
+		require.Equal(t, uint32(1), games[0].GameType)
+		require.Equal(t, uint64(500), games[0].Timestamp)
+	})
+
+	t.Run("Game", func(t *testing.T) {
+		var game GameView
+		get("/games/"+gameAddr1.Hex(), http.StatusOK, &game)
+		require.Equal(t, gameAddr1, game.Address)
+		require.Equal(t, HonestStatusUnknown, game.HonestStatus)
+	})
+
+	t.Run("UnknownGame", func(t *testing.T) {
+		get("/games/"+gameAddr2.Hex(), http.StatusNotFound, nil)
+	})
+
+	t.Run("InvalidGameAddress", func(t *testing.T) {
+		get("/games/foo", http.StatusBadRequest, nil)
+	})
+
+	t.Run("Transactions", func(t *testing.T) {
+		var pending []sender.PendingTx
+		get("/transactions", http.StatusOK, &pending)
+		require.Len(t, pending, 1)
+		require.Equal(t, "respond", pending[0].Purpose)
+		require.Equal(t, to, *pending[0].To)
+	})
+
+	t.Run("RegisterGame", func(t *testing.T) {
+		resp, err := http.Post(baseURL+"/games/"+gameAddr2.Hex(), "application/json", nil)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusAccepted, resp.StatusCode)
+		require.Equal(t, []common.Address{gameAddr2}, registrar.games)
+	})
+
+	t.Run("RegisterGameFailed", func(t *testing.T) {
+		registrar.err = errors.New("no allowlist")
+		defer func() { registrar.err = nil }()
+		resp, err := http.Post(baseURL+"/games/"+gameAddr1.Hex(), "application/json", nil)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusConflict, resp.StatusCode)
+		require.Equal(t, []common.Address{gameAddr2}, registrar.games)
+	})
+
+	t.Run("MethodNotAllowed", func(t *testing.T) {
+		resp, err := http.Post(baseURL+"/games", "application/json", nil)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+	})
+}
+
+func TestServerGameRegistrationDisabled(t *testing.T) {
+	server := NewServer(testlog.Logger(t, log.LevelInfo), "127.0.0.1", 0, NewTracker(nil), stubPendingTxs{}, nil)
+	require.NoError(t, server.Start())
+	t.Cleanup(func() {
+		require.NoError(t, server.Stop(context.Background()))
+	})
+	resp, err := http.Post("http://"+server.Addr().String()+"/games/"+gameAddr1.Hex(), "application/json", nil)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+}
@@ -0,0 +1,223 @@
+package api
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// HonestStatus describes how the honest actor is faring in a game.
+type HonestStatus string
+
+const (
+	// HonestStatusUnknown is used until the claims of the game have been evaluated.
+	HonestStatusUnknown HonestStatus = "unknown"
+	// HonestStatusWinning means the game would resolve in favour of the honest actor if resolved now.
+	HonestStatusWinning HonestStatus = "winning"
+	// HonestStatusLosing means the game would resolve against the honest actor if resolved now.
+	HonestStatusLosing HonestStatus = "losing"
+	HonestStatusWon    HonestStatus = "won"
+	HonestStatusLost   HonestStatus = "lost"
+)
+
+// GameView is the challenger's view of a single game.
+type GameView struct {
+	Index              uint64         `json:"index"`
+	GameType           uint32         `json:"gameType"`
+	Timestamp          uint64         `json:"timestamp"`
+	Address            common.Address `json:"address"`
+	Status             string         `json:"status"`
+	AgreeWithRootClaim *bool          `json:"agreeWithRootClaim,omitempty"`
+	HonestStatus       HonestStatus   `json:"honestStatus"`
+	OurClaims          []ClaimView    `json:"ourClaims"`
+	OpponentClaims     []ClaimView    `json:"opponentClaims"`
+}
+
+// ClaimView is a claim in a game, identified by its index in the game contract.
+type ClaimView struct {
+	Index        int             `json:"index"`
+	ParentIndex  int             `json:"parentIndex"`
+	Value        common.Hash     `json:"value"`
+	Depth        uint64          `json:"depth"`
+	IndexAtDepth *hexutil.Big    `json:"indexAtDepth"`
+	Claimant     common.Address  `json:"claimant"`
+	CounteredBy  *common.Address `json:"counteredBy,omitempty"`
+	Bond         *hexutil.Big    `json:"bond"`
+}
+
+type trackedGame struct {
+	metadata types.GameMetadata
+	status   types.GameStatus
+	claims   []faultTypes.Claim
+	// agreeWithRootClaim is nil until the claims of the game have been evaluated.
+	agreeWithRootClaim *bool
+}
+
+// Tracker records the challenger's view of the games it is tracking so it can be served by the API.
+// It is safe for concurrent use.
+type Tracker struct {
+	claimants []common.Address
+
+	lock  sync.RWMutex
+	games map[common.Address]*trackedGame
+}
+
+func NewTracker(claimants []common.Address) *Tracker {
+	return &Tracker{
+		claimants: claimants,
+		games:     make(map[common.Address]*trackedGame),
+	}
+}
+
+// TrackGames sets the games currently being tracked. Games that are no longer included are dropped.
+func (t *Tracker) TrackGames(games []types.GameMetadata) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	tracked := make(map[common.Address]*trackedGame, len(games))
+	for _, game := range games {
+		existing, ok := t.games[game.Proxy]
+		if !ok {
+			existing = &trackedGame{status: types.GameStatusInProgress}
+		}
+		existing.metadata = game
+		tracked[game.Proxy] = existing
+	}
+	t.games = tracked
+}
+
+// RestoreGame tracks a game with the state recorded before a restart.
+// The game is dropped by the next call to TrackGames if it is no longer included.
+func (t *Tracker) RestoreGame(game types.GameMetadata, status types.GameStatus, claims []faultTypes.Claim, agreeWithRootClaim *bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.games[game.Proxy] = &trackedGame{
+		metadata:           game,
+		status:             status,
+		claims:             claims,
+		agreeWithRootClaim: agreeWithRootClaim,
+	}
+}
+
+// UpdateStatus records the latest status of a tracked game.
+func (t *Tracker) UpdateStatus(addr common.Address, status types.GameStatus) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if game, ok := t.games[addr]; ok {
+		game.status = status
+	}
+}
+
+// UpdateClaims records the latest claims of a tracked game and whether the honest actor agrees with its root claim.
+func (t *Tracker) UpdateClaims(addr common.Address, claims []faultTypes.Claim, agreeWithRootClaim bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if game, ok := t.games[addr]; ok {
+		game.claims = claims
+		game.agreeWithRootClaim = &agreeWithRootClaim
+	}
+}
+
+// Games returns the views of all tracked games, ordered by game index.
+func (t *Tracker) Games() []GameView {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	views := make([]GameView, 0, len(t.games))
+	for _, game := range t.games {
+		views = append(views, t.view(game))
+	}
+	slices.SortFunc(views, func(a, b GameView) int {
+		return cmp.Compare(a.Index, b.Index)
+	})
+	return views
+}
+
+// Game returns the view of a single tracked game. Returns false if the game is not tracked.
+func (t *Tracker) Game(addr common.Address) (GameView, bool) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	game, ok := t.games[addr]
+	if !ok {
+		return GameView{}, false
+	}
+	return t.view(game), true
+}
+
+func (t *Tracker) view(game *trackedGame) GameView {
+	view := GameView{
+		Index:              game.metadata.Index,
+		GameType:           game.metadata.GameType,
+		Timestamp:          game.metadata.Timestamp,
+		Address:            game.metadata.Proxy,
+		Status:             game.status.String(),
+		AgreeWithRootClaim: game.agreeWithRootClaim,
+		HonestStatus:       honestStatus(game),
+		OurClaims:          []ClaimView{},
+		OpponentClaims:     []ClaimView{},
+	}
+	for _, claim := range game.claims {
+		claimView := ClaimView{
+			Index:        claim.ContractIndex,
+			ParentIndex:  claim.ParentContractIndex,
+			Value:        claim.Value,
+			Depth:        uint64(claim.Depth()),
+			IndexAtDepth: (*hexutil.Big)(claim.IndexAtDepth()),
+			Claimant:     claim.Claimant,
+			Bond:         (*hexutil.Big)(claim.Bond),
+		}
+		if claim.CounteredBy != (common.Address{}) {
+			counteredBy := claim.CounteredBy
+			claimView.CounteredBy = &counteredBy
+		}
+		if slices.Contains(t.claimants, claim.Claimant) {
+			view.OurClaims = append(view.OurClaims, claimView)
+		} else {
+			view.OpponentClaims = append(view.OpponentClaims, claimView)
+		}
+	}
+	return view
+}
+
+func honestStatus(game *trackedGame) HonestStatus {
+	if game.agreeWithRootClaim == nil {
+		return HonestStatusUnknown
+	}
+	agree := *game.agreeWithRootClaim
+	switch game.status {
+	case types.GameStatusDefenderWon:
+		if agree {
+			return HonestStatusWon
+		}
+		return HonestStatusLost
+	case types.GameStatusChallengerWon:
+		if agree {
+			return HonestStatusLost
+		}
+		return HonestStatusWon
+	}
+	if len(game.claims) == 0 {
+		return HonestStatusUnknown
+	}
+	if rootCountered(game.claims) != agree {
+		return HonestStatusWinning
+	}
+	return HonestStatusLosing
+}
+
+// rootCountered reports whether the root claim would be countered if the game were resolved with the current claims.
+// A claim is countered if any of its children are uncountered.
+func rootCountered(claims []faultTypes.Claim) bool {
+	countered := make([]bool, len(claims))
+	// Children are always added after their parent so walking backwards visits every child before its parent.
+	for i := len(claims) - 1; i > 0; i-- {
+		parent := claims[i].ParentContractIndex
+		if !countered[i] && parent >= 0 && parent < i {
+			countered[parent] = true
+		}
+	}
+	return countered[0]
+}
@@ -0,0 +1,143 @@
+package api
+
+import (
+	"math/big"
+	"testing"
+
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	ourAddr      = common.Address{0xaa}
+	opponentAddr = common.Address{0xbb}
+	gameAddr1    = common.Address{0x01}
+	gameAddr2    = common.Address{0x02}
+)
+
+func claim(idx int, parentIdx int, claimant common.Address, counteredBy common.Address) faultTypes.Claim {
+	return faultTypes.Claim{
+		ClaimData: faultTypes.ClaimData{
+			Value:    common.Hash{byte(idx)},
+			Bond:     big.NewInt(1000),
+			Position: faultTypes.NewPositionFromGIndex(big.NewInt(int64(idx + 1))),
+		},
+		Claimant:            claimant,
+		CounteredBy:         counteredBy,
+		ContractIndex:       idx,
+		ParentContractIndex: parentIdx,
+	}
+}
+
+func TestTrackGames(t *testing.T) {
+	tracker := NewTracker([]common.Address{ourAddr})
+	tracker.TrackGames([]types.GameMetadata{{Index: 2, Proxy: gameAddr2}, {Index: 1, Proxy: gameAddr1}})
+	tracker.UpdateStatus(gameAddr1, types.GameStatusDefenderWon)
+
+	games := tracker.Games()
+	require.Len(t, games, 2)
+	require.Equal(t, gameAddr1, games[0].Address)
+	require.Equal(t, types.GameStatusDefenderWon.String(), games[0].Status)
+	require.Equal(t, gameAddr2, games[1].Address)
+	require.Equal(t, types.GameStatusInProgress.String(), games[1].Status)
+
+	// State is retained while the game is still tracked
+	tracker.TrackGames([]types.GameMetadata{{Index: 1, Proxy: gameAddr1}})
+	game, ok := tracker.Game(gameAddr1)
+	require.True(t, ok)
+	require.Equal(t, types.GameStatusDefenderWon.String(), game.Status)
+	_, ok = tracker.Game(gameAddr2)
+	require.False(t, ok)
+
+	// Updates for untracked games are ignored
+	tracker.UpdateStatus(gameAddr2, types.GameStatusChallengerWon)
+	tracker.UpdateClaims(gameAddr2, []faultTypes.Claim{claim(0, -1, opponentAddr, common.Address{})}, true)
+	require.Len(t, tracker.Games(), 1)
+}
+
+func TestRestoreGame(t *testing.T) {
+	tracker := NewTracker([]common.Address{ourAddr})
+	agree := true
+	claims := []faultTypes.Claim{claim(0, -1, opponentAddr, common.Address{}), claim(1, 0, ourAddr, common.Address{})}
+	tracker.RestoreGame(types.GameMetadata{Index: 1, Proxy: gameAddr1}, types.GameStatusInProgress, claims, &agree)
+	tracker.RestoreGame(types.GameMetadata{Index: 2, Proxy: gameAddr2}, types.GameStatusDefenderWon, nil, nil)
+
+	game, ok := tracker.Game(gameAddr1)
+	require.True(t, ok)
+	require.Equal(t, HonestStatusLosing, game.HonestStatus)
+	require.Len(t, game.OurClaims, 1)
+	require.Len(t, game.OpponentClaims, 1)
+
+	// Restored state is retained while the game is still tracked
+	tracker.TrackGames([]types.GameMetadata{{Index: 1, Proxy: gameAddr1}})
+	game, ok = tracker.Game(gameAddr1)
+	require.True(t, ok)
+	require.Equal(t, HonestStatusLosing, game.HonestStatus)
+	_, ok = tracker.Game(gameAddr2)
+	require.False(t, ok)
+}
+
+func TestUpdateClaims(t *testing.T) {
+	tracker := NewTracker([]common.Address{ourAddr})
+	tracker.TrackGames([]types.GameMetadata{{Index: 1, Proxy: gameAddr1}})
+	game, _ := tracker.Game(gameAddr1)
+	require.Equal(t, HonestStatusUnknown, game.HonestStatus)
+	require.Nil(t, game.AgreeWithRootClaim)
+
+	claims := []faultTypes.Claim{
+		claim(0, -1, opponentAddr, ourAddr),
+		claim(1, 0, ourAddr, common.Address{}),
+	}
+	tracker.UpdateClaims(gameAddr1, claims, false)
+	game, _ = tracker.Game(gameAddr1)
+	require.False(t, *game.AgreeWithRootClaim)
+	require.Len(t, game.OurClaims, 1)
+	require.Equal(t, 1, game.OurClaims[0].Index)
+	require.Nil(t, game.OurClaims[0].CounteredBy)
+	require.Len(t, game.OpponentClaims, 1)
+	require.Equal(t, 0, game.OpponentClaims[0].Index)
+	require.Equal(t, ourAddr, *game.OpponentClaims[0].CounteredBy)
+}
+
+func TestHonestStatus(t *testing.T) {
+	unchallenged := []faultTypes.Claim{claim(0, -1, opponentAddr, common.Address{})}
+	countered := []faultTypes.Claim{
+		claim(0, -1, opponentAddr, common.Address{}),
+		claim(1, 0, ourAddr, common.Address{}),
+	}
+	counteredCounter := []faultTypes.Claim{
+		claim(0, -1, opponentAddr, common.Address{}),
+		claim(1, 0, ourAddr, common.Address{}),
+		claim(2, 1, opponentAddr, common.Address{}),
+	}
+	tests := []struct {
+		name     string
+		status   types.GameStatus
+		claims   []faultTypes.Claim
+		agree    bool
+		expected HonestStatus
+	}{
+		{"AgreeUnchallenged", types.GameStatusInProgress, unchallenged, true, HonestStatusWinning},
+		{"DisagreeUnchallenged", types.GameStatusInProgress, unchallenged, false, HonestStatusLosing},
+		{"AgreeCountered", types.GameStatusInProgress, countered, true, HonestStatusLosing},
+		{"DisagreeCountered", types.GameStatusInProgress, countered, false, HonestStatusWinning},
+		{"DisagreeCounterCountered", types.GameStatusInProgress, counteredCounter, false, HonestStatusLosing},
+		{"AgreeDefenderWon", types.GameStatusDefenderWon, unchallenged, true, HonestStatusWon},
+		{"DisagreeDefenderWon", types.GameStatusDefenderWon, unchallenged, false, HonestStatusLost},
+		{"AgreeChallengerWon", types.GameStatusChallengerWon, countered, true, HonestStatusLost},
+		{"DisagreeChallengerWon", types.GameStatusChallengerWon, countered, false, HonestStatusWon},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			tracker := NewTracker([]common.Address{ourAddr})
+			tracker.TrackGames([]types.GameMetadata{{Proxy: gameAddr1}})
+			tracker.UpdateClaims(gameAddr1, test.claims, test.agree)
+			tracker.UpdateStatus(gameAddr1, test.status)
+			game, _ := tracker.Game(gameAddr1)
+			require.Equal(t, test.expected, game.HonestStatus)
+		})
+	}
+}
@@ -31,17 +31,30 @@ var (
 	}
 	L2BlockNumFlag = &cli.StringFlag{
 		Name:    "l2-block-num",
+		Aliases: []string{"l2-block"},
 		Usage:   "The l2 block number for the game.",
 		EnvVars: opservice.PrefixEnvVar(flags.EnvVarPrefix, "L2_BLOCK_NUM"),
 	}
+	ChallengerAPIFlag = &cli.StringFlag{
+		Name:    "challenger-api",
+		Usage:   "URL of the API of a running challenger to register the new game with, e.g. http://127.0.0.1:7310. The challenger must run with --api.game-registration. Optional.",
+		EnvVars: opservice.PrefixEnvVar(flags.EnvVarPrefix, "CHALLENGER_API"),
+	}
 )
 
 func CreateGame(ctx *cli.Context) error {
 	outputRoot := common.HexToHash(ctx.String(OutputRootFlag.Name))
-	traceType := ctx.Uint64(TraceTypeFlag.Name)
+	var traceType types.TraceType
+	if err := traceType.Set(ctx.String(TraceTypeFlag.Name)); err != nil {
+		return err
+	}
 	l2BlockNum := ctx.Uint64(L2BlockNumFlag.Name)
 
-	contract, txMgr, err := NewContractWithTxMgr[*contracts.DisputeGameFactoryContract](ctx, flags.FactoryAddress,
+	caller, txMgr, err := newClientsFromCLI(ctx)
+	if err != nil {
+		return err
+	}
+	contract, err := newContractFromCLI(ctx, flags.FactoryAddress, caller,
 		func(ctx context.Context, metricer contractMetrics.ContractMetricer, address common.Address, caller *batching.MultiCaller) (*contracts.DisputeGameFactoryContract, error) {
 			return contracts.NewDisputeGameFactoryContract(metricer, address, caller), nil
 		})
@@ -49,12 +62,19 @@ func CreateGame(ctx *cli.Context) error {
 		return fmt.Errorf("failed to create dispute game factory bindings: %w", err)
 	}
 
-	creator := tools.NewGameCreator(contract, txMgr)
-	gameAddr, err := creator.CreateGame(ctx.Context, outputRoot, traceType, l2BlockNum)
+	creator := tools.NewGameCreator(contract, caller, txMgr)
+	game, err := creator.CreateGame(ctx.Context, outputRoot, traceType.GameType(), l2BlockNum)
 	if err != nil {
 		return fmt.Errorf("failed to create game: %w", err)
 	}
-	fmt.Printf("Fetched Game Address: %s\n", gameAddr.String())
+	fmt.Printf("Fetched Game Address: %s\n", game.Address.String())
+	fmt.Printf("Required Absolute Prestate: %s\n", game.AbsolutePrestate.Hex())
+	if apiURL := ctx.String(ChallengerAPIFlag.Name); apiURL != "" {
+		if err := tools.RegisterGame(ctx.Context, apiURL, game.Address); err != nil {
+			return err
+		}
+		fmt.Printf("Registered game with challenger at %s\n", apiURL)
+	}
 	return nil
 }
 
@@ -66,6 +86,7 @@ func createGameFlags() []cli.Flag {
 		TraceTypeFlag,
 		OutputRootFlag,
 		L2BlockNumFlag,
+		ChallengerAPIFlag,
 	}
 	cliFlags = append(cliFlags, txmgr.CLIFlagsWithDefaults(flags.EnvVarPrefix, txmgr.DefaultChallengerFlagValues)...)
 	cliFlags = append(cliFlags, oplog.CLIFlags(flags.EnvVarPrefix)...)
@@ -75,7 +96,7 @@ func createGameFlags() []cli.Flag {
 var CreateGameCommand = &cli.Command{
 	Name:        "create-game",
 	Usage:       "Creates a dispute game via the factory",
-	Description: "Creates a dispute game via the factory",
+	Description: "Creates a dispute game via the factory, paying the required initial bond, and optionally registers it with a running challenger",
 	Action:      Interruptible(CreateGame),
 	Flags:       createGameFlags(),
 }
@@ -35,6 +35,8 @@ var (
 	asteriscBin             = "./bin/asterisc"
 	asteriscServer          = "./bin/op-program"
 	asteriscPreState        = "./pre.json"
+	externalBin             = "./bin/external-alphabet"
+	externalGameType        = "100"
 )
 
 func TestLogLevel(t *testing.T) {
@@ -202,6 +204,18 @@ func TestTxManagerFlagsSupported(t *testing.T) {
 	require.Equal(t, uint64(7), cfg.TxMgrConfig.NumConfirmations)
 }
 
+func TestUrgentClockThreshold(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet))
+		require.Zero(t, cfg.UrgentClockThreshold)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet, "--urgent-clock-threshold", "30m"))
+		require.Equal(t, 30*time.Minute, cfg.UrgentClockThreshold)
+	})
+}
+
 func TestMaxConcurrency(t *testing.T) {
 	t.Run("Valid", func(t *testing.T) {
 		expected := uint(345)
@@ -224,6 +238,45 @@ func TestMaxConcurrency(t *testing.T) {
 	})
 }
 
+func TestTraceTypeMaxConcurrency(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet))
+		require.Empty(t, cfg.TraceTypeMaxConcurrency)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet,
+			"--trace-type", types.TraceTypeFast.String(), "--trace-type-max-concurrency", "alphabet=2,fast=1"))
+		require.Equal(t, map[types.TraceType]uint{types.TraceTypeAlphabet: 2, types.TraceTypeFast: 1}, cfg.TraceTypeMaxConcurrency)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		verifyArgsInvalid(
+			t,
+			"expected <trace-type>=<limit>",
+			addRequiredArgs(types.TraceTypeAlphabet, "--trace-type-max-concurrency", "alphabet"))
+	})
+
+	t.Run("UnknownTraceType", func(t *testing.T) {
+		verifyArgsInvalid(
+			t,
+			"unknown trace type: \"foo\"",
+			addRequiredArgs(types.TraceTypeAlphabet, "--trace-type-max-concurrency", "foo=1"))
+	})
+
+	t.Run("Zero", func(t *testing.T) {
+		verifyArgsInvalid(
+			t,
+			"trace-type-max-concurrency for trace type alphabet must not be 0",
+			addRequiredArgs(types.TraceTypeAlphabet, "--trace-type-max-concurrency", "alphabet=0"))
+	})
+
+	t.Run("UnsupportedTraceType", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet, "--trace-type-max-concurrency", "fast=1"))
+		require.ErrorIs(t, cfg.Check(), config.ErrTraceTypeMaxConcurrencyDisabled)
+	})
+}
+
 func TestMaxPendingTx(t *testing.T) {
 	t.Run("Valid", func(t *testing.T) {
 		expected := uint64(345)
@@ -244,6 +297,66 @@ func TestMaxPendingTx(t *testing.T) {
 	})
 }
 
+func TestMulticall(t *testing.T) {
+	t.Run("DisabledByDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet))
+		require.Equal(t, common.Address{}, cfg.MulticallAddress)
+		require.EqualValues(t, config.DefaultMulticallMaxCalls, cfg.MulticallMaxCalls)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		addr := common.Address{0xca, 0x11}
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet, "--multicall-address", addr.Hex(), "--multicall-max-calls", "7"))
+		require.Equal(t, addr, cfg.MulticallAddress)
+		require.EqualValues(t, 7, cfg.MulticallMaxCalls)
+	})
+
+	t.Run("InvalidAddress", func(t *testing.T) {
+		verifyArgsInvalid(
+			t,
+			"invalid multicall address",
+			addRequiredArgs(types.TraceTypeAlphabet, "--multicall-address", "0xabc"))
+	})
+
+	t.Run("ZeroMaxCalls", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet, "--multicall-address", common.Address{0xca, 0x11}.Hex(), "--multicall-max-calls", "0"))
+		require.ErrorIs(t, cfg.Check(), config.ErrMulticallMaxCallsZero)
+	})
+}
+
+func TestAPI(t *testing.T) {
+	t.Run("DisabledByDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet))
+		require.False(t, cfg.APIEnabled)
+		require.Equal(t, config.DefaultAPIListenAddr, cfg.APIListenAddr)
+		require.Equal(t, config.DefaultAPIListenPort, cfg.APIListenPort)
+		require.False(t, cfg.APIGameRegistration)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet, "--api.enabled", "--api.addr", "0.0.0.0", "--api.port", "1234"))
+		require.True(t, cfg.APIEnabled)
+		require.Equal(t, "0.0.0.0", cfg.APIListenAddr)
+		require.Equal(t, 1234, cfg.APIListenPort)
+	})
+
+	t.Run("InvalidPort", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet, "--api.enabled", "--api.port", "70000"))
+		require.ErrorIs(t, cfg.Check(), config.ErrInvalidAPIPort)
+	})
+
+	t.Run("GameRegistration", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet, "--api.enabled", "--api.game-registration", "--game-allowlist", common.Address{0xaa}.Hex()))
+		require.True(t, cfg.APIGameRegistration)
+		require.NoError(t, cfg.Check())
+	})
+
+	t.Run("GameRegistrationWithoutAllowlist", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet, "--api.enabled", "--api.game-registration"))
+		require.ErrorIs(t, cfg.Check(), config.ErrGameRegistrationWithoutAllowlist)
+	})
+}
+
 func TestPollInterval(t *testing.T) {
 	t.Run("UsesDefault", func(t *testing.T) {
 		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeCannon))
@@ -602,6 +715,25 @@ func TestCannonRequiredArgs(t *testing.T) {
 			})
 		})
 
+		t.Run(fmt.Sprintf("TestCannonSnapshotCache-%v", traceType), func(t *testing.T) {
+			t.Run("DisabledByDefault", func(t *testing.T) {
+				cfg := configForArgs(t, addRequiredArgs(traceType))
+				require.Zero(t, cfg.CannonSnapshotCacheSize)
+				require.Zero(t, cfg.CannonMaxConcurrentVMs)
+			})
+
+			t.Run("Valid", func(t *testing.T) {
+				cfg := configForArgs(t, addRequiredArgs(traceType, "--cannon-snapshot-cache-size=2048", "--cannon-max-concurrent-vms=3"))
+				require.Equal(t, uint64(2048), cfg.CannonSnapshotCacheSize)
+				require.Equal(t, uint(3), cfg.CannonMaxConcurrentVMs)
+			})
+
+			t.Run("Invalid", func(t *testing.T) {
+				verifyArgsInvalid(t, "invalid value \"abc\" for flag -cannon-snapshot-cache-size",
+					addRequiredArgs(traceType, "--cannon-snapshot-cache-size=abc"))
+			})
+		})
+
 		t.Run(fmt.Sprintf("TestRequireEitherCannonNetworkOrRollupAndGenesis-%v", traceType), func(t *testing.T) {
 			verifyArgsInvalid(
 				t,
@@ -722,6 +854,37 @@ func TestRollupRpc(t *testing.T) {
 	})
 }
 
+func TestExternalArgs(t *testing.T) {
+	t.Run("NotRequiredForAlphabetTrace", func(t *testing.T) {
+		configForArgs(t, addRequiredArgsExcept(types.TraceTypeAlphabet, "--external-bin"))
+	})
+
+	t.Run("BinRequired", func(t *testing.T) {
+		verifyArgsInvalid(t, "flag external-bin is required", addRequiredArgsExcept(types.TraceTypeExternal, "--external-bin"))
+	})
+
+	t.Run("GameTypeRequired", func(t *testing.T) {
+		verifyArgsInvalid(t, "flag external-game-type is required", addRequiredArgsExcept(types.TraceTypeExternal, "--external-game-type"))
+	})
+
+	t.Run("InvalidGameType", func(t *testing.T) {
+		verifyArgsInvalid(t, "invalid external-game-type", addRequiredArgsExcept(types.TraceTypeExternal, "--external-game-type", "--external-game-type=4294967295"))
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeExternal, "--external-args=--foo", "--external-args=bar", "--external-timeout=1m"))
+		require.Equal(t, externalBin, cfg.External.Command)
+		require.Equal(t, types.GameType(100), cfg.External.GameType)
+		require.Equal(t, []string{"--foo", "bar"}, cfg.External.Args)
+		require.Equal(t, time.Minute, cfg.External.Timeout)
+	})
+
+	t.Run("DefaultTimeout", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeExternal))
+		require.Equal(t, config.DefaultExternalTimeout, cfg.External.Timeout)
+	})
+}
+
 func TestGameWindow(t *testing.T) {
 	t.Run("UsesDefault", func(t *testing.T) {
 		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet))
@@ -761,6 +924,23 @@ func TestUnsafeAllowInvalidPrestate(t *testing.T) {
 	})
 }
 
+func TestBondClaimant(t *testing.T) {
+	t.Run("DefaultsToSender", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet))
+		require.Equal(t, common.Address{}, cfg.BondClaimant)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		claimant := common.Address{0xaa}
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet, "--bond-claimant", claimant.Hex()))
+		require.Equal(t, claimant, cfg.BondClaimant)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		verifyArgsInvalid(t, "invalid bond claimant", addRequiredArgs(types.TraceTypeAlphabet, "--bond-claimant", "nope"))
+	})
+}
+
 func TestAdditionalBondClaimants(t *testing.T) {
 	t.Run("DefaultsToEmpty", func(t *testing.T) {
 		cfg := configForArgs(t, addRequiredArgsExcept(types.TraceTypeAlphabet, "--additional-bond-claimants"))
@@ -853,6 +1033,8 @@ func requiredArgs(traceType types.TraceType) map[string]string {
 		addRequiredCannonArgs(args)
 	case types.TraceTypeAsterisc:
 		addRequiredAsteriscArgs(args)
+	case types.TraceTypeExternal:
+		addRequiredExternalArgs(args)
 	}
 	return args
 }
@@ -873,6 +1055,11 @@ func addRequiredAsteriscArgs(args map[string]string) {
 	args["--l2-eth-rpc"] = l2EthRpc
 }
 
+func addRequiredExternalArgs(args map[string]string) {
+	args["--external-bin"] = externalBin
+	args["--external-game-type"] = externalGameType
+}
+
 func toArgList(req map[string]string) []string {
 	var combined []string
 	for name, value := range req {
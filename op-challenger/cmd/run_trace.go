@@ -2,13 +2,38 @@ package main
 
 import (
 	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/flags"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
 	"github.com/ethereum-optimism/optimism/op-challenger/runner"
+	opservice "github.com/ethereum-optimism/optimism/op-service"
 	"github.com/ethereum-optimism/optimism/op-service/cliapp"
+	"github.com/ethereum-optimism/optimism/op-service/dial"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/urfave/cli/v2"
 )
 
+var (
+	RunTraceGameFlag = &cli.StringFlag{
+		Name:    "game",
+		Usage:   "Address of a fault game contract. If set, re-executes the trace for a single claim of the game and exits.",
+		EnvVars: opservice.PrefixEnvVar(flags.EnvVarPrefix, "RUN_TRACE_GAME"),
+	}
+	RunTraceClaimIdxFlag = &cli.Uint64Flag{
+		Name:    "claim-index",
+		Usage:   "Index of the claim to re-execute the trace for. Requires --game.",
+		EnvVars: opservice.PrefixEnvVar(flags.EnvVarPrefix, "RUN_TRACE_CLAIM_INDEX"),
+	}
+)
+
 func RunTrace(ctx *cli.Context, _ context.CancelCauseFunc) (cliapp.Lifecycle, error) {
 
 	logger, err := setupLogging(ctx)
@@ -27,14 +52,121 @@ func RunTrace(ctx *cli.Context, _ context.CancelCauseFunc) (cliapp.Lifecycle, er
 	return runner.NewRunner(logger, cfg), nil
 }
 
+// RunTraceClaim re-executes the trace for a single claim of a game and prints whether the local trace agrees with it.
+func RunTraceClaim(ctx *cli.Context) error {
+	if !ctx.IsSet(RunTraceClaimIdxFlag.Name) {
+		return fmt.Errorf("must specify %v flag", RunTraceClaimIdxFlag.Name)
+	}
+	claimIdx := ctx.Uint64(RunTraceClaimIdxFlag.Name)
+	gameAddr, err := opservice.ParseAddress(ctx.String(RunTraceGameFlag.Name))
+	if err != nil {
+		return err
+	}
+	logger, err := setupLogging(ctx)
+	if err != nil {
+		return err
+	}
+	cfg, err := flags.NewConfigFromCLI(ctx, logger)
+	if err != nil {
+		return err
+	}
+	if err := cfg.Check(); err != nil {
+		return err
+	}
+	if len(cfg.TraceTypes) != 1 {
+		return fmt.Errorf("must specify the single trace type of game %v", gameAddr)
+	}
+	task := fault.NewRegisterTask(cfg.TraceTypes[0], cfg, metrics.NoopMetrics)
+	if task == nil {
+		return fmt.Errorf("unsupported trace type %v", cfg.TraceTypes[0])
+	}
+
+	l1Client, err := dial.DialEthClientWithTimeout(ctx.Context, dial.DefaultDialTimeout, logger, cfg.L1EthRpc)
+	if err != nil {
+		return fmt.Errorf("failed to dial L1: %w", err)
+	}
+	defer l1Client.Close()
+	rollupClient, err := dial.DialRollupClientWithTimeout(ctx.Context, dial.DefaultDialTimeout, logger, cfg.RollupRpc)
+	if err != nil {
+		return fmt.Errorf("failed to dial rollup client: %w", err)
+	}
+	defer rollupClient.Close()
+	l2Client, err := ethclient.DialContext(ctx.Context, cfg.L2Rpc)
+	if err != nil {
+		return fmt.Errorf("failed to dial L2: %w", err)
+	}
+	defer l2Client.Close()
+
+	caller := batching.NewMultiCaller(l1Client.Client(), batching.DefaultBatchSize)
+	contract, err := contracts.NewFaultDisputeGameContract(ctx.Context, metrics.NoopMetrics, gameAddr, caller)
+	if err != nil {
+		return fmt.Errorf("failed to create dispute game bindings: %w", err)
+	}
+	maxDepth, err := contract.GetMaxGameDepth(ctx.Context)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve max depth: %w", err)
+	}
+	splitDepth, err := contract.GetSplitDepth(ctx.Context)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve split depth: %w", err)
+	}
+	claims, err := contract.GetAllClaims(ctx.Context, rpcblock.Latest)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve claims: %w", err)
+	}
+	dir := filepath.Join(cfg.Datadir, "run-trace", gameAddr.Hex())
+	accessor, err := task.NewTraceAccessor(ctx.Context, logger, metrics.NoopMetrics, gameAddr, contract, rollupClient, l2Client, l1Client, dir)
+	if err != nil {
+		return fmt.Errorf("failed to create trace accessor: %w", err)
+	}
+
+	check, err := runner.CheckClaim(ctx.Context, types.NewGameState(claims, maxDepth), accessor, splitDepth, claimIdx)
+	if err != nil {
+		return err
+	}
+	printClaimCheck(check)
+	return nil
+}
+
+func printClaimCheck(check runner.ClaimCheck) {
+	lineFormat := "%3v %5v %-6v %14v %-66v %-66v %v\n"
+	info := fmt.Sprintf(lineFormat, "Idx", "Depth", "Game", "Trace", "Value", "Local Value", "Agree")
+	for _, claim := range check.Claims {
+		game := "Top"
+		if claim.Bottom {
+			game = "Bottom"
+		}
+		agree := "✅"
+		if !claim.Agree() {
+			agree = "❌"
+		}
+		info += fmt.Sprintf(lineFormat,
+			claim.ContractIndex, claim.Depth(), game, claim.TraceIndex, claim.Value.Hex(), claim.LocalValue.Hex(), agree)
+	}
+	claim := check.Claim()
+	divergence := "None"
+	if check.FirstDivergence != nil && check.FirstDivergence.Cmp(check.LastDivergence) == 0 {
+		divergence = check.FirstDivergence.String()
+	} else if check.FirstDivergence != nil {
+		divergence = fmt.Sprintf("between %v and %v", check.FirstDivergence, check.LastDivergence)
+	}
+	fmt.Printf("%v\nClaim: %v • Agree: %v • Local Value: %v • First Divergent Instruction: %v\n",
+		info, claim.ContractIndex, claim.Agree(), claim.LocalValue.Hex(), divergence)
+}
+
 func runTraceFlags() []cli.Flag {
-	return flags.Flags
+	return append(slices.Clone(flags.Flags), RunTraceGameFlag, RunTraceClaimIdxFlag)
 }
 
 var RunTraceCommand = &cli.Command{
 	Name:        "run-trace",
 	Usage:       "Continuously runs the specified trace providers in a regular loop",
-	Description: "Runs trace providers against real chain data to confirm compatibility",
-	Action:      cliapp.LifecycleCmd(RunTrace),
-	Flags:       runTraceFlags(),
+	Description: "Runs trace providers against real chain data to confirm compatibility. With --game and --claim-index, instead re-executes the trace for a single claim of the game and reports whether it agrees.",
+	Action: func(ctx *cli.Context) error {
+		if ctx.IsSet(RunTraceGameFlag.Name) {
+			return Interruptible(RunTraceClaim)(ctx)
+		}
+		return cliapp.LifecycleCmd(RunTrace)(ctx)
+	},
+	Flags: runTraceFlags(),
 }
@@ -82,7 +82,7 @@ func newClientsFromCLI(ctx *cli.Context) (*batching.MultiCaller, txmgr.TxManager
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to dial L1: %w", err)
 	}
-	defer l1Client.Close()
+	// The client is used by the returned caller, so is left open until the command exits.
 
 	caller := batching.NewMultiCaller(l1Client.Client(), batching.DefaultBatchSize)
 	txMgrConfig := txmgr.ReadCLIConfig(ctx)
@@ -3,14 +3,17 @@ package config
 import (
 	"errors"
 	"fmt"
+	"math"
 	"net/url"
 	"runtime"
 	"slices"
 	"time"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/external"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/vm"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
+	"github.com/ethereum-optimism/optimism/op-service/client"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
@@ -21,6 +24,10 @@ var (
 	ErrMissingTraceType                 = errors.New("no supported trace types specified")
 	ErrMissingDatadir                   = errors.New("missing datadir")
 	ErrMaxConcurrencyZero               = errors.New("max concurrency must not be 0")
+	ErrMulticallMaxCallsZero            = errors.New("multicall max calls must not be 0")
+	ErrTraceTypeMaxConcurrencyZero      = errors.New("trace type max concurrency must not be 0")
+	ErrTraceTypeMaxConcurrencyDisabled  = errors.New("trace type max concurrency set for unsupported trace type")
+	ErrNegativeUrgentClockThreshold     = errors.New("urgent clock threshold must not be negative")
 	ErrMissingL2Rpc                     = errors.New("missing L2 rpc url")
 	ErrMissingCannonBin                 = errors.New("missing cannon bin")
 	ErrMissingCannonServer              = errors.New("missing cannon server")
@@ -37,6 +44,8 @@ var (
 	ErrCannonNetworkAndL2Genesis        = errors.New("only specify one of network or l2 genesis path")
 	ErrCannonNetworkUnknown             = errors.New("unknown cannon network")
 	ErrMissingRollupRpc                 = errors.New("missing rollup rpc url")
+	ErrInvalidAPIPort                   = errors.New("invalid api port")
+	ErrGameRegistrationWithoutAllowlist = errors.New("api game registration requires a game allowlist")
 
 	ErrMissingAsteriscBin                 = errors.New("missing asterisc bin")
 	ErrMissingAsteriscServer              = errors.New("missing asterisc server")
@@ -49,6 +58,10 @@ var (
 	ErrAsteriscNetworkAndRollupConfig     = errors.New("only specify one of network or rollup config path")
 	ErrAsteriscNetworkAndL2Genesis        = errors.New("only specify one of network or l2 genesis path")
 	ErrAsteriscNetworkUnknown             = errors.New("unknown asterisc network")
+
+	ErrMissingExternalBin       = errors.New("missing external trace provider bin")
+	ErrMissingExternalGameType  = errors.New("missing external trace provider game type")
+	ErrExternalGameTypeConflict = errors.New("external trace provider game type is played by another trace type")
 )
 
 const (
@@ -57,6 +70,7 @@ const (
 	DefaultCannonInfoFreq       = uint(10_000_000)
 	DefaultAsteriscSnapshotFreq = uint(1_000_000_000)
 	DefaultAsteriscInfoFreq     = uint(10_000_000)
+	DefaultExternalTimeout      = 10 * time.Minute
 	// DefaultGameWindow is the default maximum time duration in the past
 	// that the challenger will look for games to progress.
 	// The default value is 28 days. The worst case duration for a game is 16 days
@@ -64,6 +78,11 @@ const (
 	// buffer to monitor games to ensure bonds are claimed.
 	DefaultGameWindow   = time.Duration(28 * 24 * time.Hour)
 	DefaultMaxPendingTx = 10
+	// DefaultMulticallMaxCalls is the default maximum number of calls aggregated into a single multicall transaction.
+	DefaultMulticallMaxCalls = 20
+
+	DefaultAPIListenAddr = "127.0.0.1"
+	DefaultAPIListenPort = 7310
 )
 
 // Config is a well typed config that is parsed from the CLI params.
@@ -81,11 +100,19 @@ type Config struct {
 	AllowInvalidPrestate bool             // Whether to allow responding to games where the prestate does not match
 
 	AdditionalBondClaimants []common.Address // List of addresses to claim bonds for in addition to the tx manager sender
+	BondClaimant            common.Address   // Address to claim bonds for instead of the tx manager sender. Zero to claim for the sender
 
 	SelectiveClaimResolution bool // Whether to only resolve claims for the claimants in AdditionalBondClaimants union [TxSender.From()]
 
 	TraceTypes []types.TraceType // Type of traces supported
 
+	// TraceTypeMaxConcurrency limits the number of games of each trace type that are progressed concurrently.
+	// Trace types without a limit are only limited by MaxConcurrency.
+	TraceTypeMaxConcurrency map[types.TraceType]uint
+
+	// UrgentClockThreshold prioritises games with a chess clock expiring within the threshold. 0 to disable.
+	UrgentClockThreshold time.Duration
+
 	RollupRpc string // L2 Rollup RPC Url
 
 	L2Rpc string // L2 RPC Url
@@ -94,6 +121,8 @@ type Config struct {
 	Cannon                        vm.Config
 	CannonAbsolutePreState        string   // File to load the absolute pre-state for Cannon traces from
 	CannonAbsolutePreStateBaseURL *url.URL // Base URL to retrieve absolute pre-states for Cannon traces from
+	CannonSnapshotCacheSize       uint64   // Maximum disk space in MiB of the cannon snapshots shared between games. 0 to store snapshots per game
+	CannonMaxConcurrentVMs        uint     // Maximum number of cannon executions to run concurrently across all games. 0 for no limit
 
 	// Specific to the asterisc trace provider
 	Asterisc                            vm.Config
@@ -103,8 +132,21 @@ type Config struct {
 	AsteriscKonaAbsolutePreState        string   // File to load the absolute pre-state for AsteriscKona traces from
 	AsteriscKonaAbsolutePreStateBaseURL *url.URL // Base URL to retrieve absolute pre-states for AsteriscKona traces from
 
+	// Specific to the external trace provider
+	External external.Config
+
 	MaxPendingTx uint64 // Maximum number of pending transactions (0 == no limit)
 
+	MulticallAddress  common.Address // Multicall3 compatible contract to aggregate independent transactions through. Zero to disable batching
+	MulticallMaxCalls uint           // Maximum number of calls to aggregate into a single multicall transaction
+
+	APIEnabled          bool   // Whether to serve the game monitoring API
+	APIListenAddr       string // Address the API server listens on
+	APIListenPort       int    // Port the API server listens on
+	APIGameRegistration bool   // Whether games can be added to the allowlist through the API
+
+	L1CircuitBreaker client.CircuitBreakerConfig // Circuit breaker of the L1 RPC client
+
 	TxMgrConfig   txmgr.CLIConfig
 	MetricsConfig opmetrics.CLIConfig
 	PprofConfig   oppprof.CLIConfig
@@ -130,7 +172,11 @@ func NewConfig(
 
 		TraceTypes: supportedTraceTypes,
 
-		MaxPendingTx: DefaultMaxPendingTx,
+		MaxPendingTx:      DefaultMaxPendingTx,
+		MulticallMaxCalls: DefaultMulticallMaxCalls,
+
+		APIListenAddr: DefaultAPIListenAddr,
+		APIListenPort: DefaultAPIListenPort,
 
 		TxMgrConfig:   txmgr.NewCLIConfig(l1EthRpc, txmgr.DefaultChallengerFlagValues),
 		MetricsConfig: opmetrics.DefaultCLIConfig(),
@@ -138,6 +184,8 @@ func NewConfig(
 
 		Datadir: datadir,
 
+		L1CircuitBreaker: client.CircuitBreakerConfig{OpenDuration: client.DefaultCircuitBreakerOpenDuration},
+
 		Cannon: vm.Config{
 			VmType:       types.TraceTypeCannon,
 			L1:           l1EthRpc,
@@ -163,6 +211,10 @@ func NewConfig(
 			SnapshotFreq: DefaultAsteriscSnapshotFreq,
 			InfoFreq:     DefaultAsteriscInfoFreq,
 		},
+		External: external.Config{
+			GameType: types.UnknownGameType,
+			Timeout:  DefaultExternalTimeout,
+		},
 		GameWindow: DefaultGameWindow,
 	}
 }
@@ -171,6 +223,15 @@ func (c Config) TraceTypeEnabled(t types.TraceType) bool {
 	return slices.Contains(c.TraceTypes, t)
 }
 
+// GameType returns the game type played with the trace type.
+// Unlike [types.TraceType.GameType], this includes the configured game type of the external trace type.
+func (c Config) GameType(t types.TraceType) types.GameType {
+	if t == types.TraceTypeExternal {
+		return c.External.GameType
+	}
+	return t.GameType()
+}
+
 func (c Config) Check() error {
 	if c.L1EthRpc == "" {
 		return ErrMissingL1EthRPC
@@ -196,6 +257,20 @@ func (c Config) Check() error {
 	if c.MaxConcurrency == 0 {
 		return ErrMaxConcurrencyZero
 	}
+	if c.MulticallAddress != (common.Address{}) && c.MulticallMaxCalls == 0 {
+		return ErrMulticallMaxCallsZero
+	}
+	for traceType, limit := range c.TraceTypeMaxConcurrency {
+		if limit == 0 {
+			return fmt.Errorf("%w: %v", ErrTraceTypeMaxConcurrencyZero, traceType)
+		}
+		if !c.TraceTypeEnabled(traceType) {
+			return fmt.Errorf("%w: %v", ErrTraceTypeMaxConcurrencyDisabled, traceType)
+		}
+	}
+	if c.UrgentClockThreshold < 0 {
+		return ErrNegativeUrgentClockThreshold
+	}
 	if c.TraceTypeEnabled(types.TraceTypeCannon) || c.TraceTypeEnabled(types.TraceTypePermissioned) {
 		if c.Cannon.VmBin == "" {
 			return ErrMissingCannonBin
@@ -272,6 +347,28 @@ func (c Config) Check() error {
 			return ErrMissingAsteriscInfoFreq
 		}
 	}
+	if c.TraceTypeEnabled(types.TraceTypeExternal) {
+		if c.External.Command == "" {
+			return ErrMissingExternalBin
+		}
+		if c.External.GameType == types.UnknownGameType {
+			return ErrMissingExternalGameType
+		}
+		for _, traceType := range c.TraceTypes {
+			if traceType != types.TraceTypeExternal && traceType.GameType() == c.External.GameType {
+				return fmt.Errorf("%w: %v", ErrExternalGameTypeConflict, traceType)
+			}
+		}
+	}
+	if c.APIEnabled && (c.APIListenPort < 0 || c.APIListenPort > math.MaxUint16) {
+		return ErrInvalidAPIPort
+	}
+	if c.APIEnabled && c.APIGameRegistration && len(c.GameAllowlist) == 0 {
+		return ErrGameRegistrationWithoutAllowlist
+	}
+	if err := c.L1CircuitBreaker.Check(); err != nil {
+		return err
+	}
 	if err := c.TxMgrConfig.Check(); err != nil {
 		return err
 	}
@@ -5,6 +5,7 @@ import (
 	"net/url"
 	"runtime"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
@@ -31,6 +32,9 @@ var (
 	validAsteriscNetwork                    = "mainnet"
 	validAsteriscAbsolutePreState           = "pre.json"
 	validAsteriscAbsolutePreStateBaseURL, _ = url.Parse("http://localhost/bar/")
+
+	validExternalBin      = "./bin/external-alphabet"
+	validExternalGameType = types.GameType(100)
 )
 
 var cannonTraceTypes = []types.TraceType{types.TraceTypeCannon, types.TraceTypePermissioned}
@@ -50,6 +54,11 @@ func applyValidConfigForAsterisc(cfg *Config) {
 	cfg.Asterisc.Network = validAsteriscNetwork
 }
 
+func applyValidConfigForExternal(cfg *Config) {
+	cfg.External.Command = validExternalBin
+	cfg.External.GameType = validExternalGameType
+}
+
 func validConfig(traceType types.TraceType) Config {
 	cfg := NewConfig(validGameFactoryAddress, validL1EthRpc, validL1BeaconUrl, validRollupRpc, validL2Rpc, validDatadir, traceType)
 	if traceType == types.TraceTypeCannon || traceType == types.TraceTypePermissioned {
@@ -58,6 +67,9 @@ func validConfig(traceType types.TraceType) Config {
 	if traceType == types.TraceTypeAsterisc {
 		applyValidConfigForAsterisc(&cfg)
 	}
+	if traceType == types.TraceTypeExternal {
+		applyValidConfigForExternal(&cfg)
+	}
 	return cfg
 }
 
@@ -104,6 +116,25 @@ func TestSelectiveClaimResolutionNotRequired(t *testing.T) {
 	require.NoError(t, config.Check())
 }
 
+func TestAPIPort(t *testing.T) {
+	config := validConfig(types.TraceTypeCannon)
+	config.APIEnabled = true
+	require.NoError(t, config.Check())
+	config.APIListenPort = 65536
+	require.ErrorIs(t, config.Check(), ErrInvalidAPIPort)
+	config.APIEnabled = false
+	require.NoError(t, config.Check())
+}
+
+func TestAPIGameRegistration(t *testing.T) {
+	config := validConfig(types.TraceTypeCannon)
+	config.APIEnabled = true
+	config.APIGameRegistration = true
+	require.ErrorIs(t, config.Check(), ErrGameRegistrationWithoutAllowlist)
+	config.GameAllowlist = []common.Address{{0xaa}}
+	require.NoError(t, config.Check())
+}
+
 func TestGameAllowlistNotRequired(t *testing.T) {
 	config := validConfig(types.TraceTypeCannon)
 	config.GameAllowlist = []common.Address{}
@@ -351,6 +382,39 @@ func TestMaxConcurrency(t *testing.T) {
 	})
 }
 
+func TestTraceTypeMaxConcurrency(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		config := validConfig(types.TraceTypeAlphabet)
+		config.TraceTypeMaxConcurrency = map[types.TraceType]uint{types.TraceTypeAlphabet: 1}
+		require.NoError(t, config.Check())
+	})
+
+	t.Run("Zero", func(t *testing.T) {
+		config := validConfig(types.TraceTypeAlphabet)
+		config.TraceTypeMaxConcurrency = map[types.TraceType]uint{types.TraceTypeAlphabet: 0}
+		require.ErrorIs(t, config.Check(), ErrTraceTypeMaxConcurrencyZero)
+	})
+
+	t.Run("TraceTypeNotEnabled", func(t *testing.T) {
+		config := validConfig(types.TraceTypeAlphabet)
+		config.TraceTypeMaxConcurrency = map[types.TraceType]uint{types.TraceTypeCannon: 1}
+		require.ErrorIs(t, config.Check(), ErrTraceTypeMaxConcurrencyDisabled)
+	})
+}
+
+func TestUrgentClockThreshold(t *testing.T) {
+	t.Run("DefaultDisabled", func(t *testing.T) {
+		config := validConfig(types.TraceTypeAlphabet)
+		require.Zero(t, config.UrgentClockThreshold)
+	})
+
+	t.Run("Negative", func(t *testing.T) {
+		config := validConfig(types.TraceTypeAlphabet)
+		config.UrgentClockThreshold = -time.Minute
+		require.ErrorIs(t, config.Check(), ErrNegativeUrgentClockThreshold)
+	})
+}
+
 func TestHttpPollInterval(t *testing.T) {
 	t.Run("Default", func(t *testing.T) {
 		config := validConfig(types.TraceTypeAlphabet)
@@ -369,6 +433,45 @@ func TestRollupRpcRequired(t *testing.T) {
 	}
 }
 
+func TestExternalRequiredArgs(t *testing.T) {
+	t.Run("BinRequired", func(t *testing.T) {
+		config := validConfig(types.TraceTypeExternal)
+		config.External.Command = ""
+		require.ErrorIs(t, config.Check(), ErrMissingExternalBin)
+	})
+
+	t.Run("GameTypeRequired", func(t *testing.T) {
+		config := validConfig(types.TraceTypeExternal)
+		config.External.GameType = types.UnknownGameType
+		require.ErrorIs(t, config.Check(), ErrMissingExternalGameType)
+	})
+
+	t.Run("GameTypeMustNotConflict", func(t *testing.T) {
+		config := validConfig(types.TraceTypeExternal)
+		config.TraceTypes = append(config.TraceTypes, types.TraceTypeAlphabet)
+		config.External.GameType = types.AlphabetGameType
+		require.ErrorIs(t, config.Check(), ErrExternalGameTypeConflict)
+	})
+
+	t.Run("TimeoutNotRequired", func(t *testing.T) {
+		config := validConfig(types.TraceTypeExternal)
+		config.External.Timeout = 0
+		require.NoError(t, config.Check())
+	})
+
+	t.Run("NotRequiredForOtherTraceTypes", func(t *testing.T) {
+		config := validConfig(types.TraceTypeAlphabet)
+		config.External.Command = ""
+		require.NoError(t, config.Check())
+	})
+}
+
+func TestGameType(t *testing.T) {
+	config := validConfig(types.TraceTypeExternal)
+	require.Equal(t, validExternalGameType, config.GameType(types.TraceTypeExternal))
+	require.Equal(t, types.CannonGameType, config.GameType(types.TraceTypeCannon))
+}
+
 func TestRequireConfigForMultipleTraceTypesForCannon(t *testing.T) {
 	cfg := validConfig(types.TraceTypeCannon)
 	cfg.TraceTypes = []types.TraceType{types.TraceTypeCannon, types.TraceTypeAlphabet}
@@ -5,8 +5,10 @@ import (
 	"net/url"
 	"runtime"
 	"slices"
+	"strconv"
 	"strings"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/external"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/vm"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	"github.com/ethereum-optimism/optimism/op-service/flags"
@@ -18,6 +20,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-challenger/config"
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/client"
 	openum "github.com/ethereum-optimism/optimism/op-service/enum"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
@@ -78,6 +81,18 @@ var (
 		EnvVars: prefixEnvVars("MAX_CONCURRENCY"),
 		Value:   uint(runtime.NumCPU()),
 	}
+	TraceTypeMaxConcurrencyFlag = &cli.StringSliceFlag{
+		Name: "trace-type-max-concurrency",
+		Usage: "Maximum number of games of a trace type to progress concurrently, specified as <trace-type>=<limit>. " +
+			"Trace types without a limit are only limited by max-concurrency.",
+		EnvVars: prefixEnvVars("TRACE_TYPE_MAX_CONCURRENCY"),
+	}
+	UrgentClockThresholdFlag = &cli.DurationFlag{
+		Name: "urgent-clock-threshold",
+		Usage: "Prioritise progressing games with a chess clock expiring within this duration, deferring other games " +
+			"while urgent games are waiting. Games are ordered by clock deadline then bond at risk. 0 to disable.",
+		EnvVars: prefixEnvVars("URGENT_CLOCK_THRESHOLD"),
+	}
 	L2EthRpcFlag = &cli.StringFlag{
 		Name:    "l2-eth-rpc",
 		Usage:   "L2 Address of L2 JSON-RPC endpoint to use (eth and debug namespace required)  (cannon/asterisc trace type only)",
@@ -89,6 +104,18 @@ var (
 		Value:   config.DefaultMaxPendingTx,
 		EnvVars: prefixEnvVars("MAX_PENDING_TX"),
 	}
+	MulticallAddressFlag = &cli.StringFlag{
+		Name: "multicall-address",
+		Usage: "Address of a Multicall3 compatible contract to aggregate independent transactions, such as claim " +
+			"resolutions and bond claims, into. Transactions are sent individually if not set.",
+		EnvVars: prefixEnvVars("MULTICALL_ADDRESS"),
+	}
+	MulticallMaxCallsFlag = &cli.UintFlag{
+		Name:    "multicall-max-calls",
+		Usage:   "Maximum number of calls to aggregate into a single multicall transaction.",
+		EnvVars: prefixEnvVars("MULTICALL_MAX_CALLS"),
+		Value:   config.DefaultMulticallMaxCalls,
+	}
 	HTTPPollInterval = &cli.DurationFlag{
 		Name:    "http-poll-interval",
 		Usage:   "Polling interval for latest-block subscription when using an HTTP RPC provider.",
@@ -100,6 +127,13 @@ var (
 		Usage:   "List of addresses to claim bonds for, in addition to the configured transaction sender",
 		EnvVars: prefixEnvVars("ADDITIONAL_BOND_CLAIMANTS"),
 	}
+	BondClaimantFlag = &cli.StringFlag{
+		Name: "bond-claimant",
+		Usage: "Address to claim bonds for instead of the configured transaction sender. Games pay bonds to the account " +
+			"that posted them, so this is only needed when bonds were posted by a different account, e.g. a previous key. " +
+			"Games are still played and resolved for the transaction sender.",
+		EnvVars: prefixEnvVars("BOND_CLAIMANT"),
+	}
 	CannonNetworkFlag = &cli.StringFlag{
 		Name:    "cannon-network",
 		Usage:   fmt.Sprintf("Deprecated: Use %v instead", flags.NetworkFlagName),
@@ -153,6 +187,17 @@ var (
 		EnvVars: prefixEnvVars("CANNON_INFO_FREQ"),
 		Value:   config.DefaultCannonInfoFreq,
 	}
+	CannonSnapshotCacheSizeFlag = &cli.Uint64Flag{
+		Name: "cannon-snapshot-cache-size",
+		Usage: "Maximum disk space in MiB used by cannon snapshots shared between games that execute the same trace. " +
+			"Snapshots are stored per game if 0 (cannon trace type only)",
+		EnvVars: prefixEnvVars("CANNON_SNAPSHOT_CACHE_SIZE"),
+	}
+	CannonMaxConcurrentVMsFlag = &cli.UintFlag{
+		Name:    "cannon-max-concurrent-vms",
+		Usage:   "Maximum number of cannon executions to run concurrently across all games. No limit if 0 (cannon trace type only)",
+		EnvVars: prefixEnvVars("CANNON_MAX_CONCURRENT_VMS"),
+	}
 	AsteriscNetworkFlag = &cli.StringFlag{
 		Name:    "asterisc-network",
 		Usage:   fmt.Sprintf("Deprecated: Use %v instead", flags.NetworkFlagName),
@@ -217,6 +262,27 @@ var (
 		EnvVars: prefixEnvVars("ASTERISC_INFO_FREQ"),
 		Value:   config.DefaultAsteriscInfoFreq,
 	}
+	ExternalGameTypeFlag = &cli.UintFlag{
+		Name:    "external-game-type",
+		Usage:   "Game type played with the external trace provider (external trace type only)",
+		EnvVars: prefixEnvVars("EXTERNAL_GAME_TYPE"),
+	}
+	ExternalBinFlag = &cli.StringFlag{
+		Name:    "external-bin",
+		Usage:   "Path to the external trace provider executable, which is run for each trace request (external trace type only)",
+		EnvVars: prefixEnvVars("EXTERNAL_BIN"),
+	}
+	ExternalArgsFlag = &cli.StringSliceFlag{
+		Name:    "external-args",
+		Usage:   "Arguments to pass to the external trace provider executable (external trace type only)",
+		EnvVars: prefixEnvVars("EXTERNAL_ARGS"),
+	}
+	ExternalTimeoutFlag = &cli.DurationFlag{
+		Name:    "external-timeout",
+		Usage:   "Maximum time a single request to the external trace provider may take. 0 for no limit (external trace type only)",
+		EnvVars: prefixEnvVars("EXTERNAL_TIMEOUT"),
+		Value:   config.DefaultExternalTimeout,
+	}
 	GameWindowFlag = &cli.DurationFlag{
 		Name: "game-window",
 		Usage: "The time window which the challenger will look for games to progress and claim bonds. " +
@@ -229,6 +295,29 @@ var (
 		Usage:   "Only resolve claims for the configured claimants",
 		EnvVars: prefixEnvVars("SELECTIVE_CLAIM_RESOLUTION"),
 	}
+	APIEnabledFlag = &cli.BoolFlag{
+		Name:    "api.enabled",
+		Usage:   "Enable the HTTP API exposing the games, claims and pending transactions tracked by the challenger. Read-only unless --api.game-registration is set.",
+		EnvVars: prefixEnvVars("API_ENABLED"),
+	}
+	APIGameRegistrationFlag = &cli.BoolFlag{
+		Name: "api.game-registration",
+		Usage: "Allow adding games to the --game-allowlist by posting to /games/<address> on the API. " +
+			"The API is not authenticated, so only enable this if the API is not publicly reachable. Requires a game allowlist.",
+		EnvVars: prefixEnvVars("API_GAME_REGISTRATION"),
+	}
+	APIListenAddrFlag = &cli.StringFlag{
+		Name:    "api.addr",
+		Usage:   "API listening address",
+		EnvVars: prefixEnvVars("API_ADDR"),
+		Value:   config.DefaultAPIListenAddr,
+	}
+	APIListenPortFlag = &cli.IntFlag{
+		Name:    "api.port",
+		Usage:   "API listening port",
+		EnvVars: prefixEnvVars("API_PORT"),
+		Value:   config.DefaultAPIListenPort,
+	}
 	UnsafeAllowInvalidPrestate = &cli.BoolFlag{
 		Name:    "unsafe-allow-invalid-prestate",
 		Usage:   "Allow responding to games where the absolute prestate is configured incorrectly. THIS IS UNSAFE!",
@@ -251,10 +340,15 @@ var optionalFlags = []cli.Flag{
 	FactoryAddressFlag,
 	TraceTypeFlag,
 	MaxConcurrencyFlag,
+	TraceTypeMaxConcurrencyFlag,
+	UrgentClockThresholdFlag,
 	L2EthRpcFlag,
 	MaxPendingTransactionsFlag,
+	MulticallAddressFlag,
+	MulticallMaxCallsFlag,
 	HTTPPollInterval,
 	AdditionalBondClaimants,
+	BondClaimantFlag,
 	GameAllowlistFlag,
 	CannonNetworkFlag,
 	CannonRollupConfigFlag,
@@ -266,6 +360,8 @@ var optionalFlags = []cli.Flag{
 	CannonL2Flag,
 	CannonSnapshotFreqFlag,
 	CannonInfoFreqFlag,
+	CannonSnapshotCacheSizeFlag,
+	CannonMaxConcurrentVMsFlag,
 	AsteriscNetworkFlag,
 	AsteriscRollupConfigFlag,
 	AsteriscL2GenesisFlag,
@@ -278,8 +374,16 @@ var optionalFlags = []cli.Flag{
 	AsteriscKonaPreStatesURLFlag,
 	AsteriscSnapshotFreqFlag,
 	AsteriscInfoFreqFlag,
+	ExternalGameTypeFlag,
+	ExternalBinFlag,
+	ExternalArgsFlag,
+	ExternalTimeoutFlag,
 	GameWindowFlag,
 	SelectiveClaimResolutionFlag,
+	APIEnabledFlag,
+	APIListenAddrFlag,
+	APIListenPortFlag,
+	APIGameRegistrationFlag,
 	UnsafeAllowInvalidPrestate,
 }
 
@@ -288,6 +392,7 @@ func init() {
 	optionalFlags = append(optionalFlags, txmgr.CLIFlagsWithDefaults(EnvVarPrefix, txmgr.DefaultChallengerFlagValues)...)
 	optionalFlags = append(optionalFlags, opmetrics.CLIFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, oppprof.CLIFlags(EnvVarPrefix)...)
+	optionalFlags = append(optionalFlags, client.CircuitBreakerCLIFlags(EnvVarPrefix)...)
 
 	Flags = append(requiredFlags, optionalFlags...)
 }
@@ -359,6 +464,16 @@ func CheckAsteriscFlags(ctx *cli.Context) error {
 	return nil
 }
 
+func CheckExternalFlags(ctx *cli.Context) error {
+	if !ctx.IsSet(ExternalGameTypeFlag.Name) {
+		return fmt.Errorf("flag %s is required", ExternalGameTypeFlag.Name)
+	}
+	if !ctx.IsSet(ExternalBinFlag.Name) {
+		return fmt.Errorf("flag %s is required", ExternalBinFlag.Name)
+	}
+	return nil
+}
+
 func CheckRequired(ctx *cli.Context, traceTypes []types.TraceType) error {
 	for _, f := range requiredFlags {
 		if !ctx.IsSet(f.Names()[0]) {
@@ -379,6 +494,10 @@ func CheckRequired(ctx *cli.Context, traceTypes []types.TraceType) error {
 			if err := CheckAsteriscFlags(ctx); err != nil {
 				return err
 			}
+		case types.TraceTypeExternal:
+			if err := CheckExternalFlags(ctx); err != nil {
+				return err
+			}
 		case types.TraceTypeAlphabet, types.TraceTypeFast:
 		default:
 			return fmt.Errorf("invalid trace type %v. must be one of %v", traceType, types.TraceTypes)
@@ -401,6 +520,32 @@ func parseTraceTypes(ctx *cli.Context) ([]types.TraceType, error) {
 	return traceTypes, nil
 }
 
+func parseTraceTypeMaxConcurrency(ctx *cli.Context) (map[types.TraceType]uint, error) {
+	var limits map[types.TraceType]uint
+	for _, spec := range ctx.StringSlice(TraceTypeMaxConcurrencyFlag.Name) {
+		typeName, limitStr, ok := strings.Cut(spec, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid %v %q, expected <trace-type>=<limit>", TraceTypeMaxConcurrencyFlag.Name, spec)
+		}
+		traceType := new(types.TraceType)
+		if err := traceType.Set(typeName); err != nil {
+			return nil, err
+		}
+		limit, err := strconv.ParseUint(limitStr, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %v limit for trace type %v: %w", TraceTypeMaxConcurrencyFlag.Name, typeName, err)
+		}
+		if limit == 0 {
+			return nil, fmt.Errorf("%v for trace type %v must not be 0", TraceTypeMaxConcurrencyFlag.Name, typeName)
+		}
+		if limits == nil {
+			limits = make(map[types.TraceType]uint)
+		}
+		limits[*traceType] = uint(limit)
+	}
+	return limits, nil
+}
+
 func getL2Rpc(ctx *cli.Context, logger log.Logger) (string, error) {
 	if ctx.IsSet(CannonL2Flag.Name) && ctx.IsSet(L2EthRpcFlag.Name) {
 		return "", fmt.Errorf("flag %v and %v must not be both set", CannonL2Flag.Name, L2EthRpcFlag.Name)
@@ -427,22 +572,14 @@ func FactoryAddress(ctx *cli.Context) (common.Address, error) {
 	}
 	if ctx.IsSet(flags.NetworkFlagName) {
 		chainName := ctx.String(flags.NetworkFlagName)
-		chainCfg := chaincfg.ChainByName(chainName)
-		if chainCfg == nil {
-			var opts []string
-			for _, cfg := range superchain.OPChains {
-				opts = append(opts, cfg.Chain+"-"+cfg.Superchain)
-			}
-			return common.Address{}, fmt.Errorf("unknown chain: %v (Valid options: %v)", chainName, strings.Join(opts, ", "))
-		}
-		addrs, ok := superchain.Addresses[chainCfg.ChainID]
-		if !ok {
-			return common.Address{}, fmt.Errorf("no addresses available for chain %v", chainName)
+		chain, err := chaincfg.LoadChain(chainName)
+		if err != nil {
+			return common.Address{}, err
 		}
-		if addrs.DisputeGameFactoryProxy == (superchain.Address{}) {
+		if chain.Addresses.DisputeGameFactoryProxy == (superchain.Address{}) {
 			return common.Address{}, fmt.Errorf("dispute factory proxy not available for chain %v", chainName)
 		}
-		return common.Address(addrs.DisputeGameFactoryProxy), nil
+		return common.Address(chain.Addresses.DisputeGameFactoryProxy), nil
 	}
 	return common.Address{}, fmt.Errorf("flag %v or %v is required", FactoryAddressFlag.Name, flags.NetworkFlagName)
 }
@@ -479,6 +616,17 @@ func NewConfigFromCLI(ctx *cli.Context, logger log.Logger) (*config.Config, erro
 	if maxConcurrency == 0 {
 		return nil, fmt.Errorf("%v must not be 0", MaxConcurrencyFlag.Name)
 	}
+	traceTypeMaxConcurrency, err := parseTraceTypeMaxConcurrency(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var multicallAddress common.Address
+	if ctx.IsSet(MulticallAddressFlag.Name) {
+		multicallAddress, err = opservice.ParseAddress(ctx.String(MulticallAddressFlag.Name))
+		if err != nil {
+			return nil, fmt.Errorf("invalid multicall address: %w", err)
+		}
+	}
 	var claimants []common.Address
 	if ctx.IsSet(AdditionalBondClaimants.Name) {
 		for _, addrStr := range ctx.StringSlice(AdditionalBondClaimants.Name) {
@@ -489,6 +637,13 @@ func NewConfigFromCLI(ctx *cli.Context, logger log.Logger) (*config.Config, erro
 			claimants = append(claimants, claimant)
 		}
 	}
+	var bondClaimant common.Address
+	if ctx.IsSet(BondClaimantFlag.Name) {
+		bondClaimant, err = opservice.ParseAddress(ctx.String(BondClaimantFlag.Name))
+		if err != nil {
+			return nil, fmt.Errorf("invalid bond claimant: %w", err)
+		}
+	}
 	var cannonPrestatesURL *url.URL
 	if ctx.IsSet(CannonPreStatesURLFlag.Name) {
 		parsed, err := url.Parse(ctx.String(CannonPreStatesURLFlag.Name))
@@ -513,6 +668,14 @@ func NewConfigFromCLI(ctx *cli.Context, logger log.Logger) (*config.Config, erro
 		}
 		asteriscKonaPreStatesURL = parsed
 	}
+	externalGameType := types.UnknownGameType
+	if ctx.IsSet(ExternalGameTypeFlag.Name) {
+		gameType := ctx.Uint(ExternalGameTypeFlag.Name)
+		if gameType >= uint(types.UnknownGameType) {
+			return nil, fmt.Errorf("invalid %v: %v", ExternalGameTypeFlag.Name, gameType)
+		}
+		externalGameType = types.GameType(gameType)
+	}
 	l2Rpc, err := getL2Rpc(ctx, logger)
 	if err != nil {
 		return nil, err
@@ -536,10 +699,15 @@ func NewConfigFromCLI(ctx *cli.Context, logger log.Logger) (*config.Config, erro
 		GameAllowlist:           allowedGames,
 		GameWindow:              ctx.Duration(GameWindowFlag.Name),
 		MaxConcurrency:          maxConcurrency,
+		TraceTypeMaxConcurrency: traceTypeMaxConcurrency,
+		UrgentClockThreshold:    ctx.Duration(UrgentClockThresholdFlag.Name),
 		L2Rpc:                   l2Rpc,
 		MaxPendingTx:            ctx.Uint64(MaxPendingTransactionsFlag.Name),
+		MulticallAddress:        multicallAddress,
+		MulticallMaxCalls:       ctx.Uint(MulticallMaxCallsFlag.Name),
 		PollInterval:            ctx.Duration(HTTPPollInterval.Name),
 		AdditionalBondClaimants: claimants,
+		BondClaimant:            bondClaimant,
 		RollupRpc:               ctx.String(RollupRpcFlag.Name),
 		Cannon: vm.Config{
 			VmType:           types.TraceTypeCannon,
@@ -557,6 +725,8 @@ func NewConfigFromCLI(ctx *cli.Context, logger log.Logger) (*config.Config, erro
 		},
 		CannonAbsolutePreState:        ctx.String(CannonPreStateFlag.Name),
 		CannonAbsolutePreStateBaseURL: cannonPrestatesURL,
+		CannonSnapshotCacheSize:       ctx.Uint64(CannonSnapshotCacheSizeFlag.Name),
+		CannonMaxConcurrentVMs:        ctx.Uint(CannonMaxConcurrentVMsFlag.Name),
 		Datadir:                       ctx.String(DatadirFlag.Name),
 		Asterisc: vm.Config{
 			VmType:           types.TraceTypeAsterisc,
@@ -588,10 +758,21 @@ func NewConfigFromCLI(ctx *cli.Context, logger log.Logger) (*config.Config, erro
 		},
 		AsteriscKonaAbsolutePreState:        ctx.String(AsteriscKonaPreStateFlag.Name),
 		AsteriscKonaAbsolutePreStateBaseURL: asteriscKonaPreStatesURL,
-		TxMgrConfig:                         txMgrConfig,
-		MetricsConfig:                       metricsConfig,
-		PprofConfig:                         pprofConfig,
-		SelectiveClaimResolution:            ctx.Bool(SelectiveClaimResolutionFlag.Name),
-		AllowInvalidPrestate:                ctx.Bool(UnsafeAllowInvalidPrestate.Name),
+		External: external.Config{
+			GameType: externalGameType,
+			Command:  ctx.String(ExternalBinFlag.Name),
+			Args:     ctx.StringSlice(ExternalArgsFlag.Name),
+			Timeout:  ctx.Duration(ExternalTimeoutFlag.Name),
+		},
+		TxMgrConfig:              txMgrConfig,
+		MetricsConfig:            metricsConfig,
+		PprofConfig:              pprofConfig,
+		SelectiveClaimResolution: ctx.Bool(SelectiveClaimResolutionFlag.Name),
+		APIEnabled:               ctx.Bool(APIEnabledFlag.Name),
+		APIListenAddr:            ctx.String(APIListenAddrFlag.Name),
+		APIListenPort:            ctx.Int(APIListenPortFlag.Name),
+		APIGameRegistration:      ctx.Bool(APIGameRegistrationFlag.Name),
+		AllowInvalidPrestate:     ctx.Bool(UnsafeAllowInvalidPrestate.Name),
+		L1CircuitBreaker:         client.ReadCircuitBreakerCLIConfig(ctx),
 	}, nil
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/big"
 	"slices"
 	"sync"
 	"time"
@@ -42,9 +43,15 @@ type Agent struct {
 	responder        Responder
 	selective        bool
 	claimants        []common.Address
+	addr             common.Address
+	tracker          GameTracker
 	maxDepth         types.Depth
 	maxClockDuration time.Duration
 	log              log.Logger
+
+	// urgency is the urgency of the game as of the last call to Act.
+	// Only accessed by the goroutine progressing the game.
+	urgency gameTypes.GameUrgency
 }
 
 func NewAgent(
@@ -59,6 +66,8 @@ func NewAgent(
 	log log.Logger,
 	selective bool,
 	claimants []common.Address,
+	addr common.Address,
+	tracker GameTracker,
 ) *Agent {
 	return &Agent{
 		metrics:          m,
@@ -69,6 +78,8 @@ func NewAgent(
 		responder:        responder,
 		selective:        selective,
 		claimants:        claimants,
+		addr:             addr,
+		tracker:          tracker,
 		maxDepth:         maxDepth,
 		maxClockDuration: maxClockDuration,
 		log:              log,
@@ -77,6 +88,7 @@ func NewAgent(
 
 // Act iterates the game & performs all of the next actions.
 func (a *Agent) Act(ctx context.Context) error {
+	a.urgency = gameTypes.GameUrgency{}
 	if a.tryResolve(ctx) {
 		return nil
 	}
@@ -97,6 +109,12 @@ func (a *Agent) Act(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("create game from contracts: %w", err)
 	}
+	a.urgency = a.gameUrgency(game)
+	if agree, err := a.agreeWithRootClaim(ctx, game); err != nil {
+		a.log.Warn("Failed to determine if root claim is correct", "err", err)
+	} else {
+		a.tracker.UpdateClaims(a.addr, game.Claims(), agree)
+	}
 
 	actions, err := a.solver.CalculateNextActions(ctx, game)
 	if err != nil {
@@ -142,10 +160,69 @@ func (a *Agent) performAction(ctx context.Context, wg *sync.WaitGroup, action ty
 		a.metrics.RecordGameL2Challenge()
 	}
 	actionLog.Info("Performing action")
+	store, persist := a.tracker.(GameStateStore)
+	if persist {
+		store.RecordMove(a.addr, action, false)
+	}
 	err := a.responder.PerformAction(ctx, action)
 	if err != nil {
 		actionLog.Error("Action failed", "err", err)
+	} else if persist {
+		store.RecordMove(a.addr, action, true)
+	}
+}
+
+// Urgency returns the urgency of the game as of the last call to Act.
+func (a *Agent) Urgency() gameTypes.GameUrgency {
+	return a.urgency
+}
+
+// gameUrgency finds the uncountered claim made by another actor with the earliest expiring chess clock.
+// If the honest actor disagrees with the claim, the claim wins once the clock expires, costing the bond
+// of its parent and forgoing the bond of the claim itself.
+func (a *Agent) gameUrgency(game types.Game) gameTypes.GameUrgency {
+	now := a.l1Clock.Now()
+	claims := game.Claims()
+	countered := make(map[int]bool)
+	for _, claim := range claims {
+		if !claim.IsRoot() {
+			countered[claim.ParentContractIndex] = true
+		}
+	}
+	var urgency gameTypes.GameUrgency
+	for _, claim := range claims {
+		if countered[claim.ContractIndex] || slices.Contains(a.claimants, claim.Claimant) {
+			continue
+		}
+		remaining := a.maxClockDuration - game.ChessClock(now, claim)
+		if remaining <= 0 {
+			// Too late to counter the claim.
+			continue
+		}
+		deadline := now.Add(remaining)
+		if urgency.Deadline.IsZero() || deadline.Before(urgency.Deadline) {
+			bond := new(big.Int)
+			if claim.Bond != nil {
+				bond.Add(bond, claim.Bond)
+			}
+			if parent, err := game.GetParent(claim); err == nil && parent.Bond != nil {
+				bond.Add(bond, parent.Bond)
+			}
+			urgency = gameTypes.GameUrgency{Deadline: deadline, BondAtRisk: bond}
+		}
+	}
+	return urgency
+}
+
+// agreeWithRootClaim determines if the honest actor agrees with the root claim.
+// The root claim can't change, so the result recorded before a restart is reused if available.
+func (a *Agent) agreeWithRootClaim(ctx context.Context, game types.Game) (bool, error) {
+	if store, ok := a.tracker.(GameStateStore); ok {
+		if agree, ok := store.KnownAgreeWithRootClaim(a.addr); ok {
+			return agree, nil
+		}
 	}
+	return a.solver.AgreeWithRootClaim(ctx, game)
 }
 
 // tryResolve resolves the game if it is in a winning state
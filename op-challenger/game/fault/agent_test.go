@@ -190,10 +190,69 @@ func TestLoadClaimsWhenGameNotResolvable(t *testing.T) {
 	require.NoError(t, agent.Act(context.Background()))
 
 	require.EqualValues(t, 2, claimLoader.callCount, "should load claims for unresolvable game")
+	tracker := agent.tracker.(*stubGameTracker)
+	require.Equal(t, claimLoader.claims, tracker.claims[agent.addr], "should track loaded claims")
+	require.True(t, tracker.agree[agent.addr], "should agree with correct root claim")
 	require.EqualValues(t, responder.callResolveClaimCount, 1, "should check if claim is resolvable")
 	require.Zero(t, responder.resolveClaimCount, "should not send resolveClaim")
 }
 
+func TestAgent_ResumesFromGameStateStore(t *testing.T) {
+	agent, claimLoader, responder := setupTestAgent(t)
+	responder.callResolveErr = errors.New("game is not resolvable")
+	responder.callResolveClaimErr = errors.New("claim is not resolvable")
+	store := &stubGameStateStore{agree: map[common.Address]bool{agent.addr: true}}
+	agent.tracker = store
+	depth := types.Depth(4)
+	claimBuilder := test.NewClaimBuilder(t, depth, alphabet.NewTraceProvider(big.NewInt(0), depth))
+	claimLoader.claims = []types.Claim{
+		claimBuilder.CreateRootClaim(test.WithInvalidValue(true)),
+	}
+
+	require.NoError(t, agent.Act(context.Background()))
+
+	require.True(t, store.stubGameTracker.agree[agent.addr], "should use the recorded root claim evaluation")
+	require.Len(t, store.moves, 2, "should record the move as scheduled and posted")
+	require.Equal(t, types.ActionTypeMove, store.moves[0].action.Type)
+	require.False(t, store.moves[0].posted)
+	require.True(t, store.moves[1].posted)
+}
+
+func TestAgent_GameUrgency(t *testing.T) {
+	agent, claimLoader, responder := setupTestAgent(t)
+	responder.callResolveErr = errors.New("game is not resolvable")
+	responder.callResolveClaimErr = errors.New("claim is not resolvable")
+	honest := common.Address{0xaa}
+	other := common.Address{0xbb}
+	agent.claimants = []common.Address{honest}
+	depth := types.Depth(4)
+	claimBuilder := test.NewClaimBuilder(t, depth, alphabet.NewTraceProvider(big.NewInt(0), depth))
+	withIndex := func(claim types.Claim, idx int, bond int64) types.Claim {
+		claim.ContractIndex = idx
+		claim.Bond = big.NewInt(bond)
+		return claim
+	}
+
+	root := withIndex(claimBuilder.CreateRootClaim(test.WithClaimant(honest), test.WithClock(l1Time.Add(-10*time.Minute), 0)), 0, 1)
+	uncountered := withIndex(claimBuilder.AttackClaim(root, test.WithClaimant(other), test.WithClock(l1Time.Add(-1*time.Minute), 0), test.WithInvalidValue(true)), 1, 2)
+	countered := withIndex(claimBuilder.AttackClaim(root, test.WithClaimant(other), test.WithClock(l1Time.Add(-2*time.Minute), 0), test.WithValue(common.Hash{0x01})), 2, 4)
+	counter := withIndex(claimBuilder.AttackClaim(countered, test.WithClaimant(honest), test.WithClock(l1Time.Add(-1*time.Minute), 0)), 3, 8)
+	expired := withIndex(claimBuilder.AttackClaim(root, test.WithClaimant(other), test.WithClock(l1Time.Add(-5*time.Minute), 0), test.WithValue(common.Hash{0x02})), 4, 16)
+	claimLoader.claims = []types.Claim{root, uncountered, countered, counter, expired}
+
+	require.NoError(t, agent.Act(context.Background()))
+
+	urgency := agent.Urgency()
+	require.Equal(t, l1Time.Add(2*time.Minute), urgency.Deadline, "should use clock of uncountered claim by other actor")
+	require.Equal(t, big.NewInt(3), urgency.BondAtRisk, "should include bonds of the claim and its parent")
+
+	claimLoader.claims = []types.Claim{root, counter}
+	claimLoader.claims[1].ParentContractIndex = 0
+	claimLoader.claims[1].ContractIndex = 1
+	require.NoError(t, agent.Act(context.Background()))
+	require.Equal(t, gameTypes.GameUrgency{}, agent.Urgency(), "should not be urgent when only honest claims are uncountered")
+}
+
 func setupTestAgent(t *testing.T) (*Agent, *stubClaimLoader, *stubResponder) {
 	logger := testlog.Logger(t, log.LevelInfo)
 	claimLoader := &stubClaimLoader{}
@@ -203,7 +262,7 @@ func setupTestAgent(t *testing.T) (*Agent, *stubClaimLoader, *stubResponder) {
 	responder := &stubResponder{}
 	systemClock := clock.NewDeterministicClock(time.UnixMilli(120200))
 	l1Clock := clock.NewDeterministicClock(l1Time)
-	agent := NewAgent(metrics.NoopMetrics, systemClock, l1Clock, claimLoader, depth, gameDuration, trace.NewSimpleTraceAccessor(provider), responder, logger, false, []common.Address{})
+	agent := NewAgent(metrics.NoopMetrics, systemClock, l1Clock, claimLoader, depth, gameDuration, trace.NewSimpleTraceAccessor(provider), responder, logger, false, []common.Address{}, common.Address{0xaa}, &stubGameTracker{})
 	return agent, claimLoader, responder
 }
 
@@ -14,11 +14,12 @@ import (
 )
 
 type TxSender interface {
-	SendAndWaitSimple(txPurpose string, txs ...txmgr.TxCandidate) error
+	SendAndWaitBatched(txPurpose string, txs ...txmgr.TxCandidate) []error
 }
 
 type BondClaimMetrics interface {
-	RecordBondClaimed(amount uint64)
+	RecordBondClaimed(amount *big.Int)
+	RecordBondsClaimable(amount *big.Int)
 }
 
 type BondContract interface {
@@ -48,49 +49,89 @@ func NewBondClaimer(l log.Logger, m BondClaimMetrics, contractCreator BondContra
 	}
 }
 
+// pendingClaim is a credit claim transaction awaiting submission.
+type pendingClaim struct {
+	game      types.GameMetadata
+	claimant  common.Address
+	credit    *big.Int
+	candidate txmgr.TxCandidate
+}
+
+// ClaimBonds claims all available credit for the configured claimants from the given games.
+// Claim transactions for all games are sent together as a single batch and awaited as a group.
 func (c *Claimer) ClaimBonds(ctx context.Context, games []types.GameMetadata) (err error) {
+	var pending []pendingClaim
+	claimable := new(big.Int)
 	for _, game := range games {
 		for _, claimant := range c.claimants {
-			err = errors.Join(err, c.claimBond(ctx, game, claimant))
+			claim, credit, claimErr := c.prepareClaim(ctx, game, claimant)
+			err = errors.Join(err, claimErr)
+			claimable.Add(claimable, credit)
+			if claim != nil {
+				pending = append(pending, *claim)
+			}
 		}
 	}
+	c.metrics.RecordBondsClaimable(claimable)
+	if len(pending) == 0 {
+		return err
+	}
+
+	candidates := make([]txmgr.TxCandidate, len(pending))
+	for i, claim := range pending {
+		candidates[i] = claim.candidate
+	}
+	c.logger.Info("Claiming credit", "count", len(candidates))
+	// Credit is paid to the claimant regardless of who claims it, so the claims can be made through a multicall.
+	for i, sendErr := range c.txSender.SendAndWaitBatched("claim credit", candidates...) {
+		claim := pending[i]
+		if sendErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to claim credit from game %v for %v: %w", claim.game.Proxy, claim.claimant, sendErr))
+			continue
+		}
+		c.metrics.RecordBondClaimed(claim.credit)
+	}
 	return err
 }
 
-func (c *Claimer) claimBond(ctx context.Context, game types.GameMetadata, addr common.Address) error {
+// prepareClaim creates the transaction to claim credit for addr from game.
+// Returns the credit found for addr in a resolved game, regardless of whether it can be claimed yet,
+// and a nil claim if there is nothing to claim right now.
+func (c *Claimer) prepareClaim(ctx context.Context, game types.GameMetadata, addr common.Address) (*pendingClaim, *big.Int, error) {
 	c.logger.Debug("Attempting to claim bonds for", "game", game.Proxy, "addr", addr)
+	noCredit := new(big.Int)
 
 	contract, err := c.contractCreator(game)
 	if err != nil {
-		return fmt.Errorf("failed to create bond contract: %w", err)
+		return nil, noCredit, fmt.Errorf("failed to create bond contract: %w", err)
 	}
 
 	credit, status, err := contract.GetCredit(ctx, addr)
 	if err != nil {
-		return fmt.Errorf("failed to get credit: %w", err)
+		return nil, noCredit, fmt.Errorf("failed to get credit: %w", err)
 	}
 
 	if status == types.GameStatusInProgress {
 		c.logger.Debug("Not claiming credit from in progress game", "game", game.Proxy, "addr", addr, "status", status)
-		return nil
+		return nil, noCredit, nil
 	}
 	if credit.Cmp(big.NewInt(0)) == 0 {
 		c.logger.Debug("No credit to claim", "game", game.Proxy, "addr", addr)
-		return nil
+		return nil, noCredit, nil
 	}
 
 	candidate, err := contract.ClaimCreditTx(ctx, addr)
 	if errors.Is(err, contracts.ErrSimulationFailed) {
 		c.logger.Debug("Credit still locked", "game", game.Proxy, "addr", addr)
-		return nil
+		return nil, credit, nil
 	} else if err != nil {
-		return fmt.Errorf("failed to create credit claim tx: %w", err)
-	}
-
-	if err = c.txSender.SendAndWaitSimple("claim credit", candidate); err != nil {
-		return fmt.Errorf("failed to claim credit: %w", err)
+		return nil, credit, fmt.Errorf("failed to create credit claim tx: %w", err)
 	}
 
-	c.metrics.RecordBondClaimed(credit.Uint64())
-	return nil
+	return &pendingClaim{
+		game:      game,
+		claimant:  addr,
+		credit:    credit,
+		candidate: candidate,
+	}, credit, nil
 }
@@ -28,7 +28,10 @@ func TestClaimer_ClaimBonds(t *testing.T) {
 		err := c.ClaimBonds(context.Background(), []types.GameMetadata{{Proxy: gameAddr}, {Proxy: gameAddr}, {Proxy: gameAddr}})
 		require.NoError(t, err)
 		require.Equal(t, 3, txSender.sends)
+		require.Equal(t, 1, txSender.batches, "should send all claims as a single batch")
 		require.Equal(t, 3, m.RecordBondClaimedCalls)
+		require.Equal(t, uint64(3), m.claimable)
+		require.Equal(t, uint64(3), m.claimed)
 	})
 
 	t.Run("BondClaimSucceeds", func(t *testing.T) {
@@ -54,6 +57,8 @@ func TestClaimer_ClaimBonds(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, 2, txSender.sends)
 		require.Equal(t, 2, m.RecordBondClaimedCalls)
+		require.Equal(t, uint64(3), m.claimable)
+		require.Equal(t, uint64(3), m.claimed)
 	})
 
 	t.Run("BondClaimSkippedForInProgressGame", func(t *testing.T) {
@@ -86,7 +91,9 @@ func TestClaimer_ClaimBonds(t *testing.T) {
 		err := c.ClaimBonds(context.Background(), []types.GameMetadata{{Proxy: gameAddr}})
 		require.NoError(t, err)
 		require.Equal(t, 0, txSender.sends)
+		require.Equal(t, 0, txSender.batches)
 		require.Equal(t, 0, m.RecordBondClaimedCalls)
+		require.Equal(t, uint64(1), m.claimable, "locked credit is still claimable")
 	})
 
 	t.Run("ZeroCreditReturnsNil", func(t *testing.T) {
@@ -128,14 +135,22 @@ func newTestClaimer(t *testing.T, claimants ...common.Address) (*Claimer, *mockC
 
 type mockClaimMetrics struct {
 	RecordBondClaimedCalls int
+	claimed                uint64
+	claimable              uint64
 }
 
-func (m *mockClaimMetrics) RecordBondClaimed(amount uint64) {
+func (m *mockClaimMetrics) RecordBondClaimed(amount *big.Int) {
 	m.RecordBondClaimedCalls++
+	m.claimed += amount.Uint64()
+}
+
+func (m *mockClaimMetrics) RecordBondsClaimable(amount *big.Int) {
+	m.claimable = amount.Uint64()
 }
 
 type mockTxSender struct {
 	sends      int
+	batches    int
 	sendFails  bool
 	statusFail bool
 }
@@ -144,15 +159,18 @@ func (s *mockTxSender) From() common.Address {
 	return common.HexToAddress("0x33333")
 }
 
-func (s *mockTxSender) SendAndWaitSimple(_ string, _ ...txmgr.TxCandidate) error {
-	s.sends++
-	if s.sendFails {
-		return mockTxMgrSendError
-	}
-	if s.statusFail {
-		return errors.New("transaction reverted")
+func (s *mockTxSender) SendAndWaitBatched(_ string, txs ...txmgr.TxCandidate) []error {
+	s.batches++
+	errs := make([]error, len(txs))
+	for i := range txs {
+		s.sends++
+		if s.sendFails {
+			errs[i] = mockTxMgrSendError
+		} else if s.statusFail {
+			errs[i] = errors.New("transaction reverted")
+		}
 	}
-	return nil
+	return errs
 }
 
 type stubBondContract struct {
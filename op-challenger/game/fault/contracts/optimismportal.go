@@ -0,0 +1,103 @@
+package contracts
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	"github.com/ethereum-optimism/optimism/packages/contracts-bedrock/snapshots"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+var (
+	methodProvenWithdrawals = "provenWithdrawals"
+
+	eventWithdrawalProvenExtension1 = "WithdrawalProvenExtension1"
+)
+
+// LogFilterer fetches logs matching a filter query, as provided by ethclient.Client.
+type LogFilterer interface {
+	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]ethTypes.Log, error)
+}
+
+type OptimismPortalContract struct {
+	metrics     metrics.ContractMetricer
+	multiCaller *batching.MultiCaller
+	contract    *batching.BoundContract
+	provenTopic common.Hash
+}
+
+// ProvenWithdrawal is a withdrawal proof submitted to the OptimismPortal.
+// Each proof submitter has its own proof of a withdrawal, against the dispute game of their choice.
+type ProvenWithdrawal struct {
+	WithdrawalHash common.Hash
+	ProofSubmitter common.Address
+	DisputeGame    common.Address
+	Timestamp      uint64
+	L1BlockNumber  uint64
+}
+
+func NewOptimismPortalContract(metrics metrics.ContractMetricer, addr common.Address, caller *batching.MultiCaller) *OptimismPortalContract {
+	contractAbi := snapshots.LoadOptimismPortal2ABI()
+	return &OptimismPortalContract{
+		metrics:     metrics,
+		multiCaller: caller,
+		contract:    batching.NewBoundContract(contractAbi, addr),
+		provenTopic: contractAbi.Events[eventWithdrawalProvenExtension1].ID,
+	}
+}
+
+func (p *OptimismPortalContract) Addr() common.Address {
+	return p.contract.Addr()
+}
+
+// GetProvenWithdrawals returns the withdrawal proofs submitted in the L1 blocks from fromBlock to toBlock (inclusive).
+// The dispute game of each proof is read as of toBlock, so proofs that were replaced after being submitted
+// report the game they are currently proven against.
+func (p *OptimismPortalContract) GetProvenWithdrawals(ctx context.Context, filterer LogFilterer, fromBlock uint64, toBlock uint64) ([]ProvenWithdrawal, error) {
+	defer p.metrics.StartContractRequest("GetProvenWithdrawals")()
+	logs, err := filterer.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(fromBlock),
+		ToBlock:   new(big.Int).SetUint64(toBlock),
+		Addresses: []common.Address{p.contract.Addr()},
+		Topics:    [][]common.Hash{{p.provenTopic}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch withdrawal proven logs: %w", err)
+	}
+	var proofs []ProvenWithdrawal
+	calls := make([]batching.Call, 0, len(logs))
+	for _, log := range logs {
+		if log.Removed {
+			continue
+		}
+		name, result, err := p.contract.DecodeEvent(&log)
+		if err != nil || name != eventWithdrawalProvenExtension1 {
+			continue
+		}
+		proof := ProvenWithdrawal{
+			WithdrawalHash: result.GetHash(0),
+			ProofSubmitter: result.GetAddress(1),
+			L1BlockNumber:  log.BlockNumber,
+		}
+		proofs = append(proofs, proof)
+		calls = append(calls, p.contract.Call(methodProvenWithdrawals, proof.WithdrawalHash, proof.ProofSubmitter))
+	}
+	if len(calls) == 0 {
+		return nil, nil
+	}
+	results, err := p.multiCaller.Call(ctx, rpcblock.ByNumber(toBlock), calls...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch proven withdrawals: %w", err)
+	}
+	for i, result := range results {
+		proofs[i].DisputeGame = result.GetAddress(0)
+		proofs[i].Timestamp = result.GetUint64(1)
+	}
+	return proofs, nil
+}
@@ -0,0 +1,96 @@
+package contracts
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	contractMetrics "github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	batchingTest "github.com/ethereum-optimism/optimism/op-service/sources/batching/test"
+	"github.com/ethereum-optimism/optimism/packages/contracts-bedrock/snapshots"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	portalAddr = common.HexToAddress("0xbEb5Fc579115071764c7423A4f12eDde41f106Ed")
+)
+
+type stubLogFilterer struct {
+	query ethereum.FilterQuery
+	logs  []ethTypes.Log
+	err   error
+}
+
+func (s *stubLogFilterer) FilterLogs(_ context.Context, q ethereum.FilterQuery) ([]ethTypes.Log, error) {
+	s.query = q
+	return s.logs, s.err
+}
+
+func TestOptimismPortal_GetProvenWithdrawals(t *testing.T) {
+	stubRpc, portal := setupOptimismPortalTest(t)
+	topic := snapshots.LoadOptimismPortal2ABI().Events[eventWithdrawalProvenExtension1].ID
+	provenLog := func(hash common.Hash, submitter common.Address, blockNum uint64) ethTypes.Log {
+		return ethTypes.Log{
+			Address:     portalAddr,
+			Topics:      []common.Hash{topic, hash, common.BytesToHash(submitter.Bytes())},
+			BlockNumber: blockNum,
+		}
+	}
+	hash1 := common.Hash{0xaa}
+	hash2 := common.Hash{0xbb}
+	submitter1 := common.Address{0x01}
+	submitter2 := common.Address{0x02}
+	game1 := common.Address{0x11}
+	game2 := common.Address{0x22}
+	removed := provenLog(common.Hash{0xcc}, submitter1, 105)
+	removed.Removed = true
+	filterer := &stubLogFilterer{
+		logs: []ethTypes.Log{
+			provenLog(hash1, submitter1, 101),
+			removed,
+			provenLog(hash2, submitter2, 110),
+		},
+	}
+	block := rpcblock.ByNumber(120)
+	stubRpc.SetResponse(portalAddr, methodProvenWithdrawals, block, []interface{}{hash1, submitter1}, []interface{}{game1, uint64(500)})
+	stubRpc.SetResponse(portalAddr, methodProvenWithdrawals, block, []interface{}{hash2, submitter2}, []interface{}{game2, uint64(600)})
+
+	proofs, err := portal.GetProvenWithdrawals(context.Background(), filterer, 100, 120)
+	require.NoError(t, err)
+	require.Equal(t, []ProvenWithdrawal{
+		{WithdrawalHash: hash1, ProofSubmitter: submitter1, DisputeGame: game1, Timestamp: 500, L1BlockNumber: 101},
+		{WithdrawalHash: hash2, ProofSubmitter: submitter2, DisputeGame: game2, Timestamp: 600, L1BlockNumber: 110},
+	}, proofs)
+	require.Equal(t, big.NewInt(100), filterer.query.FromBlock)
+	require.Equal(t, big.NewInt(120), filterer.query.ToBlock)
+	require.Equal(t, []common.Address{portalAddr}, filterer.query.Addresses)
+	require.Equal(t, [][]common.Hash{{topic}}, filterer.query.Topics)
+}
+
+func TestOptimismPortal_GetProvenWithdrawalsNoLogs(t *testing.T) {
+	_, portal := setupOptimismPortalTest(t)
+	proofs, err := portal.GetProvenWithdrawals(context.Background(), &stubLogFilterer{}, 100, 120)
+	require.NoError(t, err)
+	require.Empty(t, proofs)
+}
+
+func TestOptimismPortal_GetProvenWithdrawalsLogError(t *testing.T) {
+	_, portal := setupOptimismPortalTest(t)
+	expectedErr := errors.New("boom")
+	_, err := portal.GetProvenWithdrawals(context.Background(), &stubLogFilterer{err: expectedErr}, 100, 120)
+	require.ErrorIs(t, err, expectedErr)
+}
+
+func setupOptimismPortalTest(t *testing.T) (*batchingTest.AbiBasedRpc, *OptimismPortalContract) {
+	portalAbi := snapshots.LoadOptimismPortal2ABI()
+	stubRpc := batchingTest.NewAbiBasedRpc(t, portalAddr, portalAbi)
+	caller := batching.NewMultiCaller(stubRpc, batching.DefaultBatchSize)
+	portal := NewOptimismPortalContract(contractMetrics.NoopContractMetrics, portalAddr, caller)
+	return stubRpc, portal
+}
@@ -39,12 +39,33 @@ type L1HeaderSource interface {
 type TxSender interface {
 	From() common.Address
 	SendAndWaitSimple(txPurpose string, txs ...txmgr.TxCandidate) error
+	SendAndWaitBatched(txPurpose string, txs ...txmgr.TxCandidate) []error
+}
+
+// GameTracker records the challenger's view of the games it plays so it can be served by the API.
+type GameTracker interface {
+	UpdateStatus(addr common.Address, status gameTypes.GameStatus)
+	UpdateClaims(addr common.Address, claims []types.Claim, agreeWithRootClaim bool)
+}
+
+// GameStateStore is optionally implemented by a GameTracker that persists the challenger's progress in games,
+// so it can resume playing them after a restart.
+type GameStateStore interface {
+	// KnownStatus returns the last recorded status of the game, if any.
+	KnownStatus(addr common.Address) (gameTypes.GameStatus, bool)
+	// KnownAgreeWithRootClaim returns whether the honest actor agrees with the root claim of the game, if evaluated.
+	KnownAgreeWithRootClaim(addr common.Address) (bool, bool)
+	// RecordMove records an action scheduled in the game, and whether it has been posted.
+	RecordMove(addr common.Address, action types.Action, posted bool)
 }
 
 type GamePlayer struct {
 	act                actor
+	urgency            func() gameTypes.GameUrgency
+	addr               common.Address
 	loader             GameInfo
 	logger             log.Logger
+	tracker            GameTracker
 	syncValidator      SyncValidator
 	prestateValidators []Validator
 	status             gameTypes.GameStatus
@@ -82,26 +103,26 @@ func NewGamePlayer(
 	l1HeaderSource L1HeaderSource,
 	selective bool,
 	claimants []common.Address,
+	tracker GameTracker,
 ) (*GamePlayer, error) {
 	logger = logger.New("game", addr)
 
+	if store, ok := tracker.(GameStateStore); ok {
+		if status, ok := store.KnownStatus(addr); ok && status != gameTypes.GameStatusInProgress {
+			// The status of a resolved game can't change, so there is no need to fetch it again.
+			logger.Info("Game resolved before restart", "status", status)
+			return newResolvedGamePlayer(addr, logger, tracker, loader, validators, status), nil
+		}
+	}
+
 	status, err := loader.GetStatus(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch game status: %w", err)
 	}
+	tracker.UpdateStatus(addr, status)
 	if status != gameTypes.GameStatusInProgress {
 		logger.Info("Game already resolved", "status", status)
-		// Game is already complete so skip creating the trace provider, loading game inputs etc.
-		return &GamePlayer{
-			logger:             logger,
-			loader:             loader,
-			prestateValidators: validators,
-			status:             status,
-			// Act function does nothing because the game is already complete
-			act: func(ctx context.Context) error {
-				return nil
-			},
-		}, nil
+		return newResolvedGamePlayer(addr, logger, tracker, loader, validators, status), nil
 	}
 
 	maxClockDuration, err := loader.GetMaxClockDuration(ctx)
@@ -146,11 +167,14 @@ func NewGamePlayer(
 		return nil, fmt.Errorf("failed to create the responder: %w", err)
 	}
 
-	agent := NewAgent(m, systemClock, l1Clock, loader, gameDepth, maxClockDuration, accessor, responder, logger, selective, claimants)
+	agent := NewAgent(m, systemClock, l1Clock, loader, gameDepth, maxClockDuration, accessor, responder, logger, selective, claimants, addr, tracker)
 	return &GamePlayer{
 		act:                agent.Act,
+		urgency:            agent.Urgency,
+		addr:               addr,
 		loader:             loader,
 		logger:             logger,
+		tracker:            tracker,
 		status:             status,
 		gameL1Head:         l1Head,
 		syncValidator:      syncValidator,
@@ -158,6 +182,23 @@ func NewGamePlayer(
 	}, nil
 }
 
+// newResolvedGamePlayer creates the player for a game that is already complete,
+// skipping creating the trace provider, loading game inputs etc.
+func newResolvedGamePlayer(addr common.Address, logger log.Logger, tracker GameTracker, loader GameInfo, validators []Validator, status gameTypes.GameStatus) *GamePlayer {
+	return &GamePlayer{
+		addr:               addr,
+		logger:             logger,
+		tracker:            tracker,
+		loader:             loader,
+		prestateValidators: validators,
+		status:             status,
+		// Act function does nothing because the game is already complete
+		act: func(ctx context.Context) error {
+			return nil
+		},
+	}
+}
+
 func (g *GamePlayer) ValidatePrestate(ctx context.Context) error {
 	for _, validator := range g.prestateValidators {
 		if err := validator.Validate(ctx); err != nil {
@@ -171,6 +212,14 @@ func (g *GamePlayer) Status() gameTypes.GameStatus {
 	return g.status
 }
 
+// Urgency returns the urgency of the game as of the last time it was progressed.
+func (g *GamePlayer) Urgency() gameTypes.GameUrgency {
+	if g.urgency == nil || g.status != gameTypes.GameStatusInProgress {
+		return gameTypes.GameUrgency{}
+	}
+	return g.urgency()
+}
+
 func (g *GamePlayer) ProgressGame(ctx context.Context) gameTypes.GameStatus {
 	if g.status != gameTypes.GameStatusInProgress {
 		// Game is already complete so don't try to perform further actions.
@@ -195,6 +244,7 @@ func (g *GamePlayer) ProgressGame(ctx context.Context) gameTypes.GameStatus {
 	}
 	g.logGameStatus(ctx, status)
 	g.status = status
+	g.tracker.UpdateStatus(g.addr, status)
 	return status
 }
 
@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"testing"
 
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
@@ -67,6 +69,7 @@ func TestProgressGame_LogGameStatus(t *testing.T) {
 			status := game.ProgressGame(context.Background())
 			require.Equal(t, 1, gameState.callCount, "should perform next actions")
 			require.Equal(t, test.status, status)
+			require.Equal(t, test.status, game.tracker.(*stubGameTracker).statuses[game.addr])
 			levelFilter := testlog.NewLevelFilter(log.LevelInfo)
 			msgFilter := testlog.NewMessageFilter(test.logMsg)
 			errLog := handler.FindLog(levelFilter, msgFilter)
@@ -94,6 +97,17 @@ func TestDoNotActOnCompleteGame(t *testing.T) {
 	}
 }
 
+func TestNewGamePlayer_ResolvedBeforeRestart(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	addr := common.Address{0xaa}
+	store := &stubGameStateStore{known: map[common.Address]types.GameStatus{addr: types.GameStatusDefenderWon}}
+	// The game contract is not needed as the status is already known to be resolved.
+	player, err := NewGamePlayer(context.Background(), nil, nil, logger, nil, t.TempDir(), addr, nil, nil, nil, nil, nil, nil, false, nil, store)
+	require.NoError(t, err)
+	require.Equal(t, types.GameStatusDefenderWon, player.Status())
+	require.Equal(t, types.GameStatusDefenderWon, player.ProgressGame(context.Background()))
+}
+
 func TestValidateLocalNodeSync(t *testing.T) {
 	_, game, gameState, syncValidator := setupProgressGameTest(t)
 
@@ -167,8 +181,10 @@ func setupProgressGameTest(t *testing.T) (*testlog.CapturingHandler, *GamePlayer
 	syncValidator := &stubSyncValidator{}
 	game := &GamePlayer{
 		act:           gameState.Act,
+		addr:          common.Address{0xaa},
 		loader:        gameState,
 		logger:        logger,
+		tracker:       &stubGameTracker{},
 		syncValidator: syncValidator,
 		gameL1Head: eth.BlockID{
 			Hash:   common.Hash{0x1a},
@@ -178,6 +194,58 @@ func setupProgressGameTest(t *testing.T) (*testlog.CapturingHandler, *GamePlayer
 	return logs, game, gameState, syncValidator
 }
 
+type stubGameTracker struct {
+	statuses map[common.Address]types.GameStatus
+	claims   map[common.Address][]faultTypes.Claim
+	agree    map[common.Address]bool
+}
+
+func (s *stubGameTracker) UpdateStatus(addr common.Address, status types.GameStatus) {
+	if s.statuses == nil {
+		s.statuses = make(map[common.Address]types.GameStatus)
+	}
+	s.statuses[addr] = status
+}
+
+func (s *stubGameTracker) UpdateClaims(addr common.Address, claims []faultTypes.Claim, agreeWithRootClaim bool) {
+	if s.claims == nil {
+		s.claims = make(map[common.Address][]faultTypes.Claim)
+		s.agree = make(map[common.Address]bool)
+	}
+	s.claims[addr] = claims
+	s.agree[addr] = agreeWithRootClaim
+}
+
+type recordedMove struct {
+	action faultTypes.Action
+	posted bool
+}
+
+type stubGameStateStore struct {
+	stubGameTracker
+	known map[common.Address]types.GameStatus
+	agree map[common.Address]bool
+
+	l     sync.Mutex
+	moves []recordedMove
+}
+
+func (s *stubGameStateStore) KnownStatus(addr common.Address) (types.GameStatus, bool) {
+	status, ok := s.known[addr]
+	return status, ok
+}
+
+func (s *stubGameStateStore) KnownAgreeWithRootClaim(addr common.Address) (bool, bool) {
+	agree, ok := s.agree[addr]
+	return agree, ok
+}
+
+func (s *stubGameStateStore) RecordMove(_ common.Address, action faultTypes.Action, posted bool) {
+	s.l.Lock()
+	defer s.l.Unlock()
+	s.moves = append(s.moves, recordedMove{action: action, posted: posted})
+}
+
 type stubSyncValidator struct {
 	result error
 }
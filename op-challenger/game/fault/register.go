@@ -3,6 +3,7 @@ package fault
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/config"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/claims"
@@ -43,6 +44,17 @@ type RollupClient interface {
 	SyncStatusProvider
 }
 
+// registeredTraceTypes are the trace types that games can be registered for, in registration order.
+var registeredTraceTypes = []faultTypes.TraceType{
+	faultTypes.TraceTypeCannon,
+	faultTypes.TraceTypePermissioned,
+	faultTypes.TraceTypeAsterisc,
+	faultTypes.TraceTypeAsteriscKona,
+	faultTypes.TraceTypeFast,
+	faultTypes.TraceTypeAlphabet,
+	faultTypes.TraceTypeExternal,
+}
+
 func RegisterGameTypes(
 	ctx context.Context,
 	systemClock clock.Clock,
@@ -59,36 +71,62 @@ func RegisterGameTypes(
 	l1HeaderSource L1HeaderSource,
 	selective bool,
 	claimants []common.Address,
+	tracker GameTracker,
 ) (CloseFunc, error) {
 	l2Client, err := ethclient.DialContext(ctx, cfg.L2Rpc)
 	if err != nil {
 		return nil, fmt.Errorf("dial l2 client %v: %w", cfg.L2Rpc, err)
 	}
 	syncValidator := newSyncStatusValidator(rollupClient)
+	// Cannon and permissioned games share the cannon snapshot cache and execution limit.
+	cfg = withCannonSnapshotCache(cfg)
 
 	var registerTasks []*RegisterTask
-	if cfg.TraceTypeEnabled(faultTypes.TraceTypeCannon) {
-		registerTasks = append(registerTasks, NewCannonRegisterTask(faultTypes.CannonGameType, cfg, m, vm.NewOpProgramServerExecutor()))
-	}
-	if cfg.TraceTypeEnabled(faultTypes.TraceTypePermissioned) {
-		registerTasks = append(registerTasks, NewCannonRegisterTask(faultTypes.PermissionedGameType, cfg, m, vm.NewOpProgramServerExecutor()))
-	}
-	if cfg.TraceTypeEnabled(faultTypes.TraceTypeAsterisc) {
-		registerTasks = append(registerTasks, NewAsteriscRegisterTask(faultTypes.AsteriscGameType, cfg, m, vm.NewOpProgramServerExecutor()))
-	}
-	if cfg.TraceTypeEnabled(faultTypes.TraceTypeAsteriscKona) {
-		registerTasks = append(registerTasks, NewAsteriscRegisterTask(faultTypes.AsteriscKonaGameType, cfg, m, vm.NewKonaServerExecutor()))
-	}
-	if cfg.TraceTypeEnabled(faultTypes.TraceTypeFast) {
-		registerTasks = append(registerTasks, NewAlphabetRegisterTask(faultTypes.FastGameType))
-	}
-	if cfg.TraceTypeEnabled(faultTypes.TraceTypeAlphabet) {
-		registerTasks = append(registerTasks, NewAlphabetRegisterTask(faultTypes.AlphabetGameType))
+	for _, traceType := range registeredTraceTypes {
+		if cfg.TraceTypeEnabled(traceType) {
+			registerTasks = append(registerTasks, NewRegisterTask(traceType, cfg, m))
+		}
 	}
 	for _, task := range registerTasks {
-		if err := task.Register(ctx, registry, oracles, systemClock, l1Clock, logger, m, syncValidator, rollupClient, txSender, gameFactory, caller, l2Client, l1HeaderSource, selective, claimants); err != nil {
+		if err := task.Register(ctx, registry, oracles, systemClock, l1Clock, logger, m, syncValidator, rollupClient, txSender, gameFactory, caller, l2Client, l1HeaderSource, selective, claimants, tracker); err != nil {
 			return nil, fmt.Errorf("failed to register %v game type: %w", task.gameType, err)
 		}
 	}
 	return l2Client.Close, nil
 }
+
+// withCannonSnapshotCache returns a copy of cfg with the cannon snapshot cache created, if enabled.
+func withCannonSnapshotCache(cfg *config.Config) *config.Config {
+	if cfg.CannonSnapshotCacheSize == 0 && cfg.CannonMaxConcurrentVMs == 0 {
+		return cfg
+	}
+	updated := *cfg
+	updated.Cannon.Snapshots = vm.NewSnapshotCache(
+		filepath.Join(cfg.Datadir, "cannon-snapshots"),
+		cfg.CannonSnapshotCacheSize*1024*1024,
+		cfg.CannonMaxConcurrentVMs)
+	return &updated
+}
+
+// NewRegisterTask creates the RegisterTask for the game type played with traceType.
+// Returns nil if games cannot be played with the trace type.
+func NewRegisterTask(traceType faultTypes.TraceType, cfg *config.Config, m metrics.Metricer) *RegisterTask {
+	switch traceType {
+	case faultTypes.TraceTypeCannon:
+		return NewCannonRegisterTask(faultTypes.CannonGameType, cfg, m, vm.NewOpProgramServerExecutor())
+	case faultTypes.TraceTypePermissioned:
+		return NewCannonRegisterTask(faultTypes.PermissionedGameType, cfg, m, vm.NewOpProgramServerExecutor())
+	case faultTypes.TraceTypeAsterisc:
+		return NewAsteriscRegisterTask(faultTypes.AsteriscGameType, cfg, m, vm.NewOpProgramServerExecutor())
+	case faultTypes.TraceTypeAsteriscKona:
+		return NewAsteriscRegisterTask(faultTypes.AsteriscKonaGameType, cfg, m, vm.NewKonaServerExecutor())
+	case faultTypes.TraceTypeFast:
+		return NewAlphabetRegisterTask(faultTypes.FastGameType)
+	case faultTypes.TraceTypeAlphabet:
+		return NewAlphabetRegisterTask(faultTypes.AlphabetGameType)
+	case faultTypes.TraceTypeExternal:
+		return NewExternalRegisterTask(cfg.External.GameType, cfg)
+	default:
+		return nil
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/alphabet"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/asterisc"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/cannon"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/external"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/outputs"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/prestates"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/utils"
@@ -130,6 +131,30 @@ func NewAlphabetRegisterTask(gameType faultTypes.GameType) *RegisterTask {
 	}
 }
 
+func NewExternalRegisterTask(gameType faultTypes.GameType, cfg *config.Config) *RegisterTask {
+	return &RegisterTask{
+		gameType: gameType,
+		getPrestateProvider: func(prestateHash common.Hash) (faultTypes.PrestateProvider, error) {
+			return external.NewPrestateProvider(cfg.External, prestateHash), nil
+		},
+		newTraceAccessor: func(
+			logger log.Logger,
+			m metrics.Metricer,
+			l2Client utils.L2HeaderSource,
+			prestateProvider faultTypes.PrestateProvider,
+			vmPrestateProvider faultTypes.PrestateProvider,
+			rollupClient outputs.OutputRollupClient,
+			dir string,
+			l1Head eth.BlockID,
+			splitDepth faultTypes.Depth,
+			prestateBlock uint64,
+			poststateBlock uint64) (*trace.Accessor, error) {
+			provider := vmPrestateProvider.(*external.PrestateProvider)
+			return outputs.NewOutputExternalTraceAccessor(logger, m, l2Client, prestateProvider, provider, rollupClient, dir, l1Head, splitDepth, prestateBlock, poststateBlock)
+		},
+	}
+}
+
 func cachePrestates(
 	gameType faultTypes.GameType,
 	m caching.Metrics,
@@ -165,51 +190,26 @@ func (e *RegisterTask) Register(
 	l2Client utils.L2HeaderSource,
 	l1HeaderSource L1HeaderSource,
 	selective bool,
-	claimants []common.Address) error {
+	claimants []common.Address,
+	tracker GameTracker) error {
 
 	playerCreator := func(game types.GameMetadata, dir string) (scheduler.GamePlayer, error) {
 		contract, err := contracts.NewFaultDisputeGameContract(ctx, m, game.Proxy, caller)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create fault dispute game contracts: %w", err)
 		}
-		requiredPrestatehash, err := contract.GetAbsolutePrestateHash(ctx)
-		if err != nil {
-			return nil, fmt.Errorf("failed to load prestate hash for game %v: %w", game.Proxy, err)
-		}
-
-		vmPrestateProvider, err := e.getPrestateProvider(requiredPrestatehash)
+		creator, vmPrestateProvider, prestateProvider, err := e.newResourceCreator(ctx, m, game.Proxy, contract, rollupClient, l2Client, l1HeaderSource)
 		if err != nil {
-			return nil, fmt.Errorf("required prestate %v not available for game %v: %w", requiredPrestatehash, game.Proxy, err)
+			return nil, err
 		}
-
 		oracle, err := contract.GetOracle(ctx)
 		if err != nil {
 			return nil, fmt.Errorf("failed to load oracle for game %v: %w", game.Proxy, err)
 		}
 		oracles.RegisterOracle(oracle)
-		prestateBlock, poststateBlock, err := contract.GetBlockRange(ctx)
-		if err != nil {
-			return nil, err
-		}
-		splitDepth, err := contract.GetSplitDepth(ctx)
-		if err != nil {
-			return nil, fmt.Errorf("failed to load split depth: %w", err)
-		}
-		l1HeadID, err := loadL1Head(contract, ctx, l1HeaderSource)
-		if err != nil {
-			return nil, err
-		}
-		prestateProvider := outputs.NewPrestateProvider(rollupClient, prestateBlock)
-		creator := func(ctx context.Context, logger log.Logger, gameDepth faultTypes.Depth, dir string) (faultTypes.TraceAccessor, error) {
-			accessor, err := e.newTraceAccessor(logger, m, l2Client, prestateProvider, vmPrestateProvider, rollupClient, dir, l1HeadID, splitDepth, prestateBlock, poststateBlock)
-			if err != nil {
-				return nil, err
-			}
-			return accessor, nil
-		}
 		prestateValidator := NewPrestateValidator(e.gameType.String(), contract.GetAbsolutePrestateHash, vmPrestateProvider)
 		startingValidator := NewPrestateValidator("output root", contract.GetStartingRootHash, prestateProvider)
-		return NewGamePlayer(ctx, systemClock, l1Clock, logger, m, dir, game.Proxy, txSender, contract, syncValidator, []Validator{prestateValidator, startingValidator}, creator, l1HeaderSource, selective, claimants)
+		return NewGamePlayer(ctx, systemClock, l1Clock, logger, m, dir, game.Proxy, txSender, contract, syncValidator, []Validator{prestateValidator, startingValidator}, creator, l1HeaderSource, selective, claimants, tracker)
 	}
 	err := registerOracle(ctx, m, oracles, gameFactory, caller, e.gameType)
 	if err != nil {
@@ -224,6 +224,74 @@ func (e *RegisterTask) Register(
 	return nil
 }
 
+// NewTraceAccessor creates the trace accessor used to play the game at gameAddr, storing its data in dir.
+func (e *RegisterTask) NewTraceAccessor(
+	ctx context.Context,
+	logger log.Logger,
+	m metrics.Metricer,
+	gameAddr common.Address,
+	contract contracts.FaultDisputeGameContract,
+	rollupClient outputs.OutputRollupClient,
+	l2Client utils.L2HeaderSource,
+	l1HeaderSource L1HeaderSource,
+	dir string) (faultTypes.TraceAccessor, error) {
+	creator, _, _, err := e.newResourceCreator(ctx, m, gameAddr, contract, rollupClient, l2Client, l1HeaderSource)
+	if err != nil {
+		return nil, err
+	}
+	maxDepth, err := contract.GetMaxGameDepth(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load max game depth: %w", err)
+	}
+	return creator(ctx, logger, maxDepth, dir)
+}
+
+func (e *RegisterTask) newResourceCreator(
+	ctx context.Context,
+	m metrics.Metricer,
+	gameAddr common.Address,
+	contract contracts.FaultDisputeGameContract,
+	rollupClient outputs.OutputRollupClient,
+	l2Client utils.L2HeaderSource,
+	l1HeaderSource L1HeaderSource) (resourceCreator, faultTypes.PrestateProvider, faultTypes.PrestateProvider, error) {
+	// Check the claimed L2 block before loading the prestate, which may need to be downloaded.
+	prestateBlock, poststateBlock, err := contract.GetBlockRange(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if poststateBlock <= prestateBlock {
+		return nil, nil, nil, fmt.Errorf("%w: game %v claims block %v, which is not after its starting block %v",
+			types.ErrInvalidL2BlockNumber, gameAddr, poststateBlock, prestateBlock)
+	}
+
+	requiredPrestatehash, err := contract.GetAbsolutePrestateHash(ctx)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to load prestate hash for game %v: %w", gameAddr, err)
+	}
+
+	vmPrestateProvider, err := e.getPrestateProvider(requiredPrestatehash)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("required prestate %v not available for game %v: %w", requiredPrestatehash, gameAddr, err)
+	}
+	splitDepth, err := contract.GetSplitDepth(ctx)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to load split depth: %w", err)
+	}
+	l1HeadID, err := loadL1Head(contract, ctx, l1HeaderSource)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	prestateProvider := outputs.NewPrestateProvider(rollupClient, prestateBlock)
+	creator := func(ctx context.Context, logger log.Logger, gameDepth faultTypes.Depth, dir string) (faultTypes.TraceAccessor, error) {
+		accessor, err := e.newTraceAccessor(logger, m, l2Client, prestateProvider, vmPrestateProvider, rollupClient, dir, l1HeadID, splitDepth, prestateBlock, poststateBlock)
+		if err != nil {
+			return nil, err
+		}
+		return accessor, nil
+	}
+	return creator, vmPrestateProvider, prestateProvider, nil
+}
+
 func registerOracle(ctx context.Context, m metrics.Metricer, oracles OracleRegistry, gameFactory *contracts.DisputeGameFactoryContract, caller *batching.MultiCaller, gameType faultTypes.GameType) error {
 	implAddr, err := gameFactory.GetGameImpl(ctx, gameType)
 	if err != nil {
@@ -30,6 +30,8 @@ type Oracle interface {
 
 type TxSender interface {
 	SendAndWaitSimple(txPurpose string, txs ...txmgr.TxCandidate) error
+	// SendAndWaitBatched sends transactions that may be aggregated into a single multicall transaction.
+	SendAndWaitBatched(txPurpose string, txs ...txmgr.TxCandidate) []error
 }
 
 // FaultResponder implements the [Responder] interface to send onchain transactions.
@@ -84,7 +86,8 @@ func (r *FaultResponder) ResolveClaims(claimIdxs ...uint64) error {
 		}
 		txs = append(txs, candidate)
 	}
-	return r.sender.SendAndWaitSimple("resolve claim", txs...)
+	// Anyone can resolve a claim, so the resolutions can be made through a multicall.
+	return errors.Join(r.sender.SendAndWaitBatched("resolve claim", txs...)...)
 }
 
 func (r *FaultResponder) PerformAction(ctx context.Context, action types.Action) error {
@@ -100,6 +100,7 @@ func TestResolveClaim(t *testing.T) {
 		err := responder.ResolveClaims(0, 1, 2, 3)
 		require.NoError(t, err)
 		require.Equal(t, 4, mockTxMgr.sends)
+		require.Equal(t, 1, mockTxMgr.batches, "should send claim resolutions as a batch")
 	})
 }
 
@@ -340,6 +341,7 @@ func (m *mockOracle) GlobalDataExists(ctx context.Context, data *types.PreimageO
 type mockTxManager struct {
 	from      common.Address
 	sends     int
+	batches   int
 	sent      []txmgr.TxCandidate
 	sendFails bool
 }
@@ -355,6 +357,11 @@ func (m *mockTxManager) SendAndWaitSimple(_ string, txs ...txmgr.TxCandidate) er
 	return nil
 }
 
+func (m *mockTxManager) SendAndWaitBatched(purpose string, txs ...txmgr.TxCandidate) []error {
+	m.batches++
+	return []error{m.SendAndWaitSimple(purpose, txs...)}
+}
+
 func (m *mockTxManager) BlockNumber(_ context.Context) (uint64, error) {
 	panic("not implemented")
 }
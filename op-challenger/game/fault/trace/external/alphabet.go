@@ -0,0 +1,30 @@
+package external
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/alphabet"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+)
+
+var ErrMissingL2HeadNumber = errors.New("missing l2 head number")
+
+var _ Handler = AlphabetHandler{}
+
+// AlphabetHandler is the reference external trace provider, serving the alphabet trace.
+// It produces the same trace as the built in alphabet trace type.
+type AlphabetHandler struct{}
+
+func (AlphabetHandler) PrestateProvider(_ context.Context, _ common.Hash) (types.PrestateProvider, error) {
+	return alphabet.PrestateProvider, nil
+}
+
+func (AlphabetHandler) TraceProvider(_ context.Context, _ common.Hash, game GameInputs, depth types.Depth) (types.TraceProvider, error) {
+	if game.L2HeadNumber == nil {
+		return nil, ErrMissingL2HeadNumber
+	}
+	return alphabet.NewTraceProvider(game.L2HeadNumber.ToInt(), depth), nil
+}
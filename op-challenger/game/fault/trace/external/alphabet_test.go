@@ -0,0 +1,33 @@
+package external_test
+
+import (
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/external"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/external/test"
+)
+
+func TestAlphabetConformance(t *testing.T) {
+	test.RunConformanceTests(t, test.Subject{
+		Config: external.Config{
+			Command: os.Args[0],
+			Args:    []string{"external-test-handler", "alphabet"},
+		},
+		Prestate: common.Hash{0xaa},
+		Game: external.GameInputs{
+			L2HeadNumber:  (*hexutil.Big)(big.NewInt(10)),
+			L2BlockNumber: (*hexutil.Big)(big.NewInt(11)),
+			Dir:           t.TempDir(),
+		},
+		Depth: 4,
+		StateHash: func(state []byte) common.Hash {
+			return crypto.Keccak256Hash(state)
+		},
+	})
+}
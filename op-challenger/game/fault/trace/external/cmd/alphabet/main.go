@@ -0,0 +1,19 @@
+// alphabet is the reference external trace provider, serving the alphabet trace over the external trace provider protocol.
+//
+//	op-challenger --trace-type external --external-game-type <GAME_TYPE> --external-bin ./bin/external-alphabet ...
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/external"
+)
+
+func main() {
+	if err := external.Serve(context.Background(), external.AlphabetHandler{}, os.Stdin, os.Stdout); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
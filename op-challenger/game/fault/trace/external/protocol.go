@@ -0,0 +1,130 @@
+// Package external plugs trace providers that run as separate executables into the challenger,
+// so that alternative VMs and ZK provers can be used to play the bottom half of output root games.
+//
+// The challenger executes the provider's command once per request. A single JSON encoded [Request]
+// is written to the command's stdin, and the command must write a single JSON encoded [Response]
+// to stdout, and exit with status 0. Errors of the provider are reported in [Response.Error].
+// Anything written to stderr is included in the error if the command fails, but is otherwise ignored.
+//
+// Providers written in Go can implement [Handler] and call [Serve] to handle the protocol.
+package external
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/utils"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+)
+
+// ProtocolVersion is the version of the protocol implemented by this package.
+// Requests and responses with a different version are rejected.
+const ProtocolVersion = 1
+
+const (
+	// MethodAbsolutePrestate requests the commitment to the absolute prestate of the trace.
+	MethodAbsolutePrestate = "absolutePrestate"
+	// MethodGet requests the claim at a trace index.
+	MethodGet = "get"
+	// MethodGetStepData requests the data required to execute the step from a trace index.
+	MethodGetStepData = "getStepData"
+)
+
+// GameInputs identifies the output root subgame a request is for.
+type GameInputs struct {
+	// LocalContext is the local context of the subgame in the dispute game.
+	LocalContext common.Hash `json:"localContext"`
+	// L1Head is the L1 head of the dispute game.
+	L1Head common.Hash `json:"l1Head"`
+	// L2Head is the hash of the agreed L2 block.
+	L2Head common.Hash `json:"l2Head"`
+	// L2HeadNumber is the number of the agreed L2 block.
+	L2HeadNumber *hexutil.Big `json:"l2HeadNumber"`
+	// L2OutputRoot is the agreed output root.
+	L2OutputRoot common.Hash `json:"l2OutputRoot"`
+	// L2Claim is the disputed output root.
+	L2Claim common.Hash `json:"l2Claim"`
+	// L2BlockNumber is the L2 block number of the disputed output root.
+	L2BlockNumber *hexutil.Big `json:"l2BlockNumber"`
+	// Dir is a directory the provider may use to store data of the subgame between requests.
+	// It is deleted when the game is no longer played.
+	Dir string `json:"dir"`
+}
+
+func NewGameInputs(localContext common.Hash, l2HeadNumber *big.Int, inputs utils.LocalGameInputs, dir string) GameInputs {
+	return GameInputs{
+		LocalContext:  localContext,
+		L1Head:        inputs.L1Head,
+		L2Head:        inputs.L2Head,
+		L2HeadNumber:  (*hexutil.Big)(l2HeadNumber),
+		L2OutputRoot:  inputs.L2OutputRoot,
+		L2Claim:       inputs.L2Claim,
+		L2BlockNumber: (*hexutil.Big)(inputs.L2BlockNumber),
+		Dir:           dir,
+	}
+}
+
+type Request struct {
+	Version uint64 `json:"version"`
+	Method  string `json:"method"`
+	// Prestate is the absolute prestate hash of the game implementation, which identifies the program to prove.
+	Prestate common.Hash `json:"prestate"`
+	// Game is the subgame to provide the trace of. Not set for MethodAbsolutePrestate.
+	Game *GameInputs `json:"game,omitempty"`
+	// Depth is the maximum depth of the trace. Not set for MethodAbsolutePrestate.
+	Depth types.Depth `json:"depth,omitempty"`
+	// TraceIndex is the index into the trace. Not set for MethodAbsolutePrestate.
+	TraceIndex *hexutil.Big `json:"traceIndex,omitempty"`
+}
+
+type Response struct {
+	Version uint64 `json:"version"`
+	// Error describes why the request failed. All other fields are ignored if set.
+	Error string `json:"error,omitempty"`
+	// Commitment is the claim value for MethodAbsolutePrestate and MethodGet.
+	Commitment *common.Hash `json:"commitment,omitempty"`
+	// Prestate is the pre-state of the step, for MethodGetStepData.
+	Prestate hexutil.Bytes `json:"prestate,omitempty"`
+	// Proof is the proof data of the step, for MethodGetStepData.
+	Proof hexutil.Bytes `json:"proof,omitempty"`
+	// Preimage is the data to load into the preimage oracle prior to the step, for MethodGetStepData.
+	Preimage *PreimageData `json:"preimage,omitempty"`
+}
+
+type PreimageData struct {
+	Key hexutil.Bytes `json:"key"`
+	// Data is the preimage, usually prefixed with its length as an 8 byte big endian integer.
+	Data   hexutil.Bytes `json:"data"`
+	Offset uint32        `json:"offset"`
+
+	// Blob preimages only
+	BlobFieldIndex uint64        `json:"blobFieldIndex,omitempty"`
+	BlobCommitment hexutil.Bytes `json:"blobCommitment,omitempty"`
+	BlobProof      hexutil.Bytes `json:"blobProof,omitempty"`
+}
+
+func newPreimageData(data *types.PreimageOracleData) *PreimageData {
+	if data == nil {
+		return nil
+	}
+	return &PreimageData{
+		Key:            data.OracleKey,
+		Data:           data.GetPreimageWithSize(),
+		Offset:         data.OracleOffset,
+		BlobFieldIndex: data.BlobFieldIndex,
+		BlobCommitment: data.BlobCommitment,
+		BlobProof:      data.BlobProof,
+	}
+}
+
+func (d *PreimageData) oracleData() *types.PreimageOracleData {
+	if d == nil {
+		return nil
+	}
+	if len(d.BlobCommitment) > 0 {
+		return types.NewPreimageOracleBlobData(d.Key, d.Data, d.Offset, d.BlobFieldIndex, d.BlobCommitment, d.BlobProof)
+	}
+	return types.NewPreimageOracleData(d.Key, d.Data, d.Offset)
+}
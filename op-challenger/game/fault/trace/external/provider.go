@@ -0,0 +1,183 @@
+package external
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+)
+
+var (
+	ErrMissingCommitment = errors.New("external trace provider did not return a commitment")
+	ErrVersionMismatch   = errors.New("external trace provider protocol version mismatch")
+	ErrPositionTooDeep   = errors.New("position is deeper than the trace")
+)
+
+type Config struct {
+	// GameType is the game type played with the external trace provider.
+	GameType types.GameType
+	// Command is the path to the executable of the trace provider.
+	Command string
+	// Args are passed to the executable before any other arguments.
+	Args []string
+	// Timeout limits how long a single request may take. No limit is applied if 0.
+	Timeout time.Duration
+}
+
+// client executes the external trace provider for each request.
+type client struct {
+	cfg Config
+}
+
+func (c *client) call(ctx context.Context, req *Request) (*Response, error) {
+	req.Version = ProtocolVersion
+	in, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %v request: %w", req.Method, err)
+	}
+	if c.cfg.Timeout != 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.cfg.Timeout)
+		defer cancel()
+	}
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, c.cfg.Command, c.cfg.Args...)
+	cmd.Stdin = bytes.NewReader(in)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("external trace provider failed %v request: %w (stderr: %q)", req.Method, err, strings.TrimSpace(stderr.String()))
+	}
+	var resp Response
+	if err := json.Unmarshal(stdout.Bytes(), &resp); err != nil {
+		return nil, fmt.Errorf("invalid response from external trace provider to %v request: %w", req.Method, err)
+	}
+	if resp.Version != ProtocolVersion {
+		return nil, fmt.Errorf("%w: expected %v but got %v", ErrVersionMismatch, ProtocolVersion, resp.Version)
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("external trace provider failed %v request: %v", req.Method, resp.Error)
+	}
+	return &resp, nil
+}
+
+func (c *client) commitment(ctx context.Context, req *Request) (common.Hash, error) {
+	resp, err := c.call(ctx, req)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if resp.Commitment == nil {
+		return common.Hash{}, fmt.Errorf("%w for %v request", ErrMissingCommitment, req.Method)
+	}
+	return *resp.Commitment, nil
+}
+
+var _ types.PrestateProvider = (*PrestateProvider)(nil)
+
+// PrestateProvider loads the absolute prestate of games with the required prestate from the external trace provider.
+type PrestateProvider struct {
+	client   *client
+	prestate common.Hash
+
+	prestateCommitment common.Hash
+}
+
+func NewPrestateProvider(cfg Config, prestate common.Hash) *PrestateProvider {
+	return &PrestateProvider{
+		client:   &client{cfg: cfg},
+		prestate: prestate,
+	}
+}
+
+func (p *PrestateProvider) AbsolutePreStateCommitment(ctx context.Context) (common.Hash, error) {
+	if p.prestateCommitment != (common.Hash{}) {
+		return p.prestateCommitment, nil
+	}
+	commitment, err := p.client.commitment(ctx, &Request{Method: MethodAbsolutePrestate, Prestate: p.prestate})
+	if err != nil {
+		return common.Hash{}, err
+	}
+	p.prestateCommitment = commitment
+	return commitment, nil
+}
+
+// Prestate is the absolute prestate hash required by the game.
+func (p *PrestateProvider) Prestate() common.Hash {
+	return p.prestate
+}
+
+var _ types.TraceProvider = (*TraceProvider)(nil)
+
+// TraceProvider is a [types.TraceProvider] for a single subgame, that requests the trace from the external trace provider.
+type TraceProvider struct {
+	*PrestateProvider
+	logger log.Logger
+	game   GameInputs
+	depth  types.Depth
+}
+
+func NewTraceProvider(logger log.Logger, prestateProvider *PrestateProvider, game GameInputs, depth types.Depth) *TraceProvider {
+	return &TraceProvider{
+		PrestateProvider: prestateProvider,
+		logger:           logger,
+		game:             game,
+		depth:            depth,
+	}
+}
+
+func (p *TraceProvider) request(method string, pos types.Position) (*Request, error) {
+	if pos.Depth() > p.depth {
+		return nil, fmt.Errorf("%w depth: %v max: %v", ErrPositionTooDeep, pos.Depth(), p.depth)
+	}
+	return &Request{
+		Method:     method,
+		Prestate:   p.prestate,
+		Game:       &p.game,
+		Depth:      p.depth,
+		TraceIndex: (*hexutil.Big)(pos.TraceIndex(p.depth)),
+	}, nil
+}
+
+func (p *TraceProvider) Get(ctx context.Context, pos types.Position) (common.Hash, error) {
+	req, err := p.request(MethodGet, pos)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	start := time.Now()
+	commitment, err := p.client.commitment(ctx, req)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	p.logger.Trace("Loaded claim from external trace provider", "pos", pos, "commitment", commitment, "duration", time.Since(start))
+	return commitment, nil
+}
+
+func (p *TraceProvider) GetStepData(ctx context.Context, pos types.Position) ([]byte, []byte, *types.PreimageOracleData, error) {
+	req, err := p.request(MethodGetStepData, pos)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	start := time.Now()
+	resp, err := p.client.call(ctx, req)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	p.logger.Debug("Loaded step data from external trace provider", "pos", pos, "duration", time.Since(start))
+	return resp.Prestate, resp.Proof, resp.Preimage.oracleData(), nil
+}
+
+// GetL2BlockNumberChallenge is not supported by external trace providers, which only play the bottom half of
+// output root games. The L2 block number is challenged using the output root trace instead.
+func (p *TraceProvider) GetL2BlockNumberChallenge(_ context.Context) (*types.InvalidL2BlockNumberChallenge, error) {
+	return nil, types.ErrL2BlockNumberValid
+}
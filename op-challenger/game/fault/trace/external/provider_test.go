@@ -0,0 +1,187 @@
+package external
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/alphabet"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// testHandlerArg makes the test binary act as an external trace provider, instead of running the tests.
+const testHandlerArg = "external-test-handler"
+
+func TestMain(m *testing.M) {
+	if len(os.Args) == 3 && os.Args[1] == testHandlerArg {
+		runTestHandler(os.Args[2])
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+func runTestHandler(mode string) {
+	switch mode {
+	case "alphabet":
+		if err := Serve(context.Background(), AlphabetHandler{}, os.Stdin, os.Stdout); err != nil {
+			panic(err)
+		}
+	case "fail":
+		_, _ = fmt.Fprintln(os.Stderr, "boom")
+		os.Exit(3)
+	case "wrong-version":
+		_, _ = fmt.Fprintln(os.Stdout, `{"version":99}`)
+	case "no-commitment":
+		_, _ = fmt.Fprintf(os.Stdout, `{"version":%d}`+"\n", ProtocolVersion)
+	case "invalid-json":
+		_, _ = fmt.Fprintln(os.Stdout, "not json")
+	case "slow":
+		time.Sleep(time.Minute)
+	}
+}
+
+func testHandlerConfig(mode string) Config {
+	return Config{
+		Command: os.Args[0],
+		Args:    []string{testHandlerArg, mode},
+	}
+}
+
+func TestTraceProvider(t *testing.T) {
+	ctx := context.Background()
+	depth := types.Depth(4)
+	startingBlock := big.NewInt(5)
+	game := GameInputs{L2HeadNumber: (*hexutil.Big)(startingBlock), L2BlockNumber: (*hexutil.Big)(big.NewInt(6))}
+	newProvider := func(cfg Config) *TraceProvider {
+		return NewTraceProvider(testlog.Logger(t, log.LevelInfo), NewPrestateProvider(cfg, common.Hash{0xaa}), game, depth)
+	}
+
+	t.Run("MatchesAlphabet", func(t *testing.T) {
+		provider := newProvider(testHandlerConfig("alphabet"))
+		expected := alphabet.NewTraceProvider(startingBlock, depth)
+
+		expectedPrestate, err := expected.AbsolutePreStateCommitment(ctx)
+		require.NoError(t, err)
+		actualPrestate, err := provider.AbsolutePreStateCommitment(ctx)
+		require.NoError(t, err)
+		require.Equal(t, expectedPrestate, actualPrestate)
+
+		for _, pos := range []types.Position{types.NewPosition(depth, big.NewInt(0)), types.NewPosition(depth, big.NewInt(7)), types.NewPosition(2, big.NewInt(1))} {
+			expectedClaim, err := expected.Get(ctx, pos)
+			require.NoError(t, err)
+			actualClaim, err := provider.Get(ctx, pos)
+			require.NoError(t, err)
+			require.Equal(t, expectedClaim, actualClaim)
+
+			expectedPre, _, expectedData, err := expected.GetStepData(ctx, pos)
+			require.NoError(t, err)
+			actualPre, actualProof, actualData, err := provider.GetStepData(ctx, pos)
+			require.NoError(t, err)
+			require.Equal(t, expectedPre, actualPre)
+			require.Empty(t, actualProof)
+			require.Equal(t, expectedData, actualData)
+		}
+	})
+
+	t.Run("ProviderError", func(t *testing.T) {
+		provider := NewTraceProvider(testlog.Logger(t, log.LevelInfo), NewPrestateProvider(testHandlerConfig("alphabet"), common.Hash{0xaa}), GameInputs{}, depth)
+		_, err := provider.Get(ctx, types.NewPosition(depth, big.NewInt(0)))
+		require.ErrorContains(t, err, ErrMissingL2HeadNumber.Error())
+	})
+
+	t.Run("PositionTooDeep", func(t *testing.T) {
+		provider := newProvider(testHandlerConfig("alphabet"))
+		_, err := provider.Get(ctx, types.NewPosition(depth+1, big.NewInt(0)))
+		require.ErrorIs(t, err, ErrPositionTooDeep)
+		_, _, _, err = provider.GetStepData(ctx, types.NewPosition(depth+1, big.NewInt(0)))
+		require.ErrorIs(t, err, ErrPositionTooDeep)
+	})
+
+	t.Run("CommandFails", func(t *testing.T) {
+		_, err := newProvider(testHandlerConfig("fail")).Get(ctx, types.NewPosition(depth, big.NewInt(0)))
+		require.ErrorContains(t, err, "exit status 3")
+		require.ErrorContains(t, err, "boom")
+	})
+
+	t.Run("VersionMismatch", func(t *testing.T) {
+		_, err := newProvider(testHandlerConfig("wrong-version")).Get(ctx, types.NewPosition(depth, big.NewInt(0)))
+		require.ErrorIs(t, err, ErrVersionMismatch)
+	})
+
+	t.Run("MissingCommitment", func(t *testing.T) {
+		_, err := newProvider(testHandlerConfig("no-commitment")).AbsolutePreStateCommitment(ctx)
+		require.ErrorIs(t, err, ErrMissingCommitment)
+	})
+
+	t.Run("InvalidResponse", func(t *testing.T) {
+		_, err := newProvider(testHandlerConfig("invalid-json")).Get(ctx, types.NewPosition(depth, big.NewInt(0)))
+		require.ErrorContains(t, err, "invalid response")
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		cfg := testHandlerConfig("slow")
+		cfg.Timeout = 100 * time.Millisecond
+		_, err := newProvider(cfg).Get(ctx, types.NewPosition(depth, big.NewInt(0)))
+		require.ErrorContains(t, err, "killed")
+	})
+
+	t.Run("L2BlockNumberChallenge", func(t *testing.T) {
+		_, err := newProvider(testHandlerConfig("alphabet")).GetL2BlockNumberChallenge(ctx)
+		require.ErrorIs(t, err, types.ErrL2BlockNumberValid)
+	})
+}
+
+func TestServe(t *testing.T) {
+	serve := func(t *testing.T, req string) Response {
+		var out bytes.Buffer
+		require.NoError(t, Serve(context.Background(), AlphabetHandler{}, bytes.NewBufferString(req), &out))
+		var resp Response
+		require.NoError(t, json.Unmarshal(out.Bytes(), &resp))
+		require.Equal(t, uint64(ProtocolVersion), resp.Version)
+		return resp
+	}
+
+	t.Run("AbsolutePrestate", func(t *testing.T) {
+		resp := serve(t, fmt.Sprintf(`{"version":%d,"method":"absolutePrestate"}`, ProtocolVersion))
+		require.Empty(t, resp.Error)
+		expected, err := alphabet.PrestateProvider.AbsolutePreStateCommitment(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, &expected, resp.Commitment)
+	})
+
+	t.Run("InvalidRequest", func(t *testing.T) {
+		resp := serve(t, "{")
+		require.Contains(t, resp.Error, "invalid request")
+	})
+
+	t.Run("UnsupportedVersion", func(t *testing.T) {
+		resp := serve(t, `{"version":99,"method":"absolutePrestate"}`)
+		require.Contains(t, resp.Error, ErrVersionMismatch.Error())
+	})
+
+	t.Run("UnknownMethod", func(t *testing.T) {
+		resp := serve(t, fmt.Sprintf(`{"version":%d,"method":"foo"}`, ProtocolVersion))
+		require.Contains(t, resp.Error, ErrUnknownMethod.Error())
+	})
+
+	t.Run("MissingTraceIndex", func(t *testing.T) {
+		resp := serve(t, fmt.Sprintf(`{"version":%d,"method":"get","game":{},"depth":4}`, ProtocolVersion))
+		require.Contains(t, resp.Error, "must specify the game and trace index")
+	})
+
+	t.Run("MissingL2HeadNumber", func(t *testing.T) {
+		resp := serve(t, fmt.Sprintf(`{"version":%d,"method":"get","game":{},"depth":4,"traceIndex":"0x1"}`, ProtocolVersion))
+		require.Contains(t, resp.Error, ErrMissingL2HeadNumber.Error())
+	})
+}
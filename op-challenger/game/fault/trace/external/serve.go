@@ -0,0 +1,88 @@
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+)
+
+var ErrUnknownMethod = errors.New("unknown method")
+
+// Handler is implemented by external trace providers written in Go, to serve requests with [Serve].
+type Handler interface {
+	// PrestateProvider returns the provider of the absolute prestate commitment for the prestate hash.
+	PrestateProvider(ctx context.Context, prestate common.Hash) (types.PrestateProvider, error)
+
+	// TraceProvider returns the provider of the trace of the subgame, for the prestate hash.
+	TraceProvider(ctx context.Context, prestate common.Hash, game GameInputs, depth types.Depth) (types.TraceProvider, error)
+}
+
+// Serve reads a single request from in, handles it with handler and writes the response to out.
+// Failures to handle the request are reported in the response. An error is only returned if the response can't be written.
+func Serve(ctx context.Context, handler Handler, in io.Reader, out io.Writer) error {
+	resp := handle(ctx, handler, in)
+	resp.Version = ProtocolVersion
+	if err := json.NewEncoder(out).Encode(resp); err != nil {
+		return fmt.Errorf("failed to write response: %w", err)
+	}
+	return nil
+}
+
+func handle(ctx context.Context, handler Handler, in io.Reader) *Response {
+	var req Request
+	if err := json.NewDecoder(in).Decode(&req); err != nil {
+		return &Response{Error: fmt.Sprintf("invalid request: %v", err)}
+	}
+	if req.Version != ProtocolVersion {
+		return &Response{Error: fmt.Sprintf("%v: expected %v but got %v", ErrVersionMismatch, ProtocolVersion, req.Version)}
+	}
+	resp, err := handleRequest(ctx, handler, &req)
+	if err != nil {
+		return &Response{Error: err.Error()}
+	}
+	return resp
+}
+
+func handleRequest(ctx context.Context, handler Handler, req *Request) (*Response, error) {
+	switch req.Method {
+	case MethodAbsolutePrestate:
+		provider, err := handler.PrestateProvider(ctx, req.Prestate)
+		if err != nil {
+			return nil, err
+		}
+		commitment, err := provider.AbsolutePreStateCommitment(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return &Response{Commitment: &commitment}, nil
+	case MethodGet, MethodGetStepData:
+		if req.Game == nil || req.TraceIndex == nil {
+			return nil, fmt.Errorf("%v request must specify the game and trace index", req.Method)
+		}
+		provider, err := handler.TraceProvider(ctx, req.Prestate, *req.Game, req.Depth)
+		if err != nil {
+			return nil, err
+		}
+		pos := types.NewPosition(req.Depth, req.TraceIndex.ToInt())
+		if req.Method == MethodGet {
+			commitment, err := provider.Get(ctx, pos)
+			if err != nil {
+				return nil, err
+			}
+			return &Response{Commitment: &commitment}, nil
+		}
+		prestate, proof, preimage, err := provider.GetStepData(ctx, pos)
+		if err != nil {
+			return nil, err
+		}
+		return &Response{Prestate: prestate, Proof: proof, Preimage: newPreimageData(preimage)}, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownMethod, req.Method)
+	}
+}
@@ -0,0 +1,133 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math/big"
+	"os/exec"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/external"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// Subject describes the external trace provider checked by RunConformanceTests.
+type Subject struct {
+	Config   external.Config
+	Prestate common.Hash
+	Game     external.GameInputs
+	Depth    types.Depth
+	// StateHash returns the claim committing to a state returned as the prestate of GetStepData.
+	// The first byte of the claims, the VM status, is not compared, as it may not be derivable from the state alone.
+	StateHash func(state []byte) common.Hash
+}
+
+func withoutStatus(claim common.Hash) common.Hash {
+	claim[0] = 0
+	return claim
+}
+
+// RunConformanceTests checks that the external trace provider described by s implements the external trace provider
+// protocol, and that its trace is consistent, so that it can be used to play games.
+func RunConformanceTests(t *testing.T, s Subject) {
+	ctx := context.Background()
+	newProvider := func() *external.TraceProvider {
+		return external.NewTraceProvider(testlog.Logger(t, log.LevelInfo), external.NewPrestateProvider(s.Config, s.Prestate), s.Game, s.Depth)
+	}
+	lastIndex := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), uint(s.Depth)), big.NewInt(1))
+	indices := []*big.Int{
+		big.NewInt(0),
+		big.NewInt(1),
+		new(big.Int).Rsh(lastIndex, 1),
+		new(big.Int).Sub(lastIndex, big.NewInt(1)),
+	}
+
+	t.Run("AbsolutePrestate", func(t *testing.T) {
+		commitment, err := newProvider().AbsolutePreStateCommitment(ctx)
+		require.NoError(t, err)
+		require.NotEqual(t, common.Hash{}, commitment)
+		again, err := newProvider().AbsolutePreStateCommitment(ctx)
+		require.NoError(t, err)
+		require.Equal(t, commitment, again, "absolute prestate must be deterministic")
+	})
+
+	t.Run("FirstStepStartsFromAbsolutePrestate", func(t *testing.T) {
+		provider := newProvider()
+		commitment, err := provider.AbsolutePreStateCommitment(ctx)
+		require.NoError(t, err)
+		prestate, _, _, err := provider.GetStepData(ctx, types.NewPosition(s.Depth, big.NewInt(0)))
+		require.NoError(t, err)
+		require.Equal(t, withoutStatus(commitment), withoutStatus(s.StateHash(prestate)))
+	})
+
+	t.Run("ClaimsMatchStepData", func(t *testing.T) {
+		provider := newProvider()
+		for _, i := range indices {
+			claim, err := provider.Get(ctx, types.NewPosition(s.Depth, i))
+			require.NoError(t, err, "get claim %v", i)
+			again, err := newProvider().Get(ctx, types.NewPosition(s.Depth, i))
+			require.NoError(t, err, "get claim %v", i)
+			require.Equal(t, claim, again, "claim %v must be deterministic", i)
+
+			// The claim at i is the post-state of the step from i, which is the pre-state of the step from i+1.
+			prestate, _, _, err := provider.GetStepData(ctx, types.NewPosition(s.Depth, new(big.Int).Add(i, big.NewInt(1))))
+			require.NoError(t, err, "get step data %v", i)
+			require.Equal(t, withoutStatus(claim), withoutStatus(s.StateHash(prestate)), "claim %v must commit to the pre-state of the next step", i)
+		}
+	})
+
+	t.Run("ClaimsAboveMaxDepth", func(t *testing.T) {
+		// Claims above the max depth commit to the state at their rightmost descendant.
+		provider := newProvider()
+		pos := types.NewPosition(s.Depth-1, big.NewInt(0))
+		expected, err := provider.Get(ctx, types.NewPosition(s.Depth, pos.TraceIndex(s.Depth)))
+		require.NoError(t, err)
+		actual, err := provider.Get(ctx, pos)
+		require.NoError(t, err)
+		require.Equal(t, expected, actual)
+	})
+
+	t.Run("RejectsUnknownMethod", func(t *testing.T) {
+		resp := rawCall(t, s.Config, external.Request{Version: external.ProtocolVersion, Method: "unknown", Prestate: s.Prestate})
+		require.Equal(t, uint64(external.ProtocolVersion), resp.Version)
+		require.NotEmpty(t, resp.Error)
+	})
+
+	t.Run("RejectsUnsupportedVersion", func(t *testing.T) {
+		resp := rawCall(t, s.Config, external.Request{Version: external.ProtocolVersion + 1, Method: external.MethodAbsolutePrestate, Prestate: s.Prestate})
+		require.Equal(t, uint64(external.ProtocolVersion), resp.Version)
+		require.NotEmpty(t, resp.Error)
+	})
+
+	t.Run("RejectsMissingGame", func(t *testing.T) {
+		resp := rawCall(t, s.Config, external.Request{
+			Version:    external.ProtocolVersion,
+			Method:     external.MethodGet,
+			Prestate:   s.Prestate,
+			Depth:      s.Depth,
+			TraceIndex: (*hexutil.Big)(big.NewInt(0)),
+		})
+		require.NotEmpty(t, resp.Error)
+	})
+}
+
+// rawCall sends req to the external trace provider without any of the validation of [external.TraceProvider].
+func rawCall(t *testing.T, cfg external.Config, req external.Request) external.Response {
+	in, err := json.Marshal(req)
+	require.NoError(t, err)
+	var stdout bytes.Buffer
+	cmd := exec.Command(cfg.Command, cfg.Args...)
+	cmd.Stdin = bytes.NewReader(in)
+	cmd.Stdout = &stdout
+	require.NoError(t, cmd.Run(), "an error response must be returned with exit status 0")
+	var resp external.Response
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &resp))
+	return resp
+}
@@ -9,6 +9,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum-optimism/optimism/op-service/ioutil"
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -51,6 +52,9 @@ func (m *MultiPrestateProvider) fetchPrestate(hash common.Hash, dest string) err
 		return fmt.Errorf("failed to fetch prestate from %v: %w", prestateUrl, err)
 	}
 	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w from url %v: %w", ErrPrestateUnavailable, prestateUrl, types.ErrUnknownPrestate)
+	}
 	if resp.StatusCode != http.StatusOK {
 		return fmt.Errorf("%w from url %v: status %v", ErrPrestateUnavailable, prestateUrl, resp.StatusCode)
 	}
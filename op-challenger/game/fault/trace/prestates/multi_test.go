@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum-optimism/optimism/op-service/ioutil"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
@@ -80,10 +81,23 @@ func TestMissingPrestate(t *testing.T) {
 	hash := common.Hash{0xaa}
 	path, err := provider.PrestatePath(hash)
 	require.ErrorIs(t, err, ErrPrestateUnavailable)
+	require.ErrorIs(t, err, types.ErrUnknownPrestate)
 	_, err = os.Stat(path)
 	require.ErrorIs(t, err, os.ErrNotExist)
 }
 
+func TestUnavailablePrestate(t *testing.T) {
+	dir := t.TempDir()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(503)
+	}))
+	defer server.Close()
+	provider := NewMultiPrestateProvider(parseURL(t, server.URL), dir)
+	_, err := provider.PrestatePath(common.Hash{0xaa})
+	require.ErrorIs(t, err, ErrPrestateUnavailable)
+	require.NotErrorIs(t, err, types.ErrUnknownPrestate, "server errors must not mark the prestate as unknown")
+}
+
 func parseURL(t *testing.T, str string) *url.URL {
 	parsed, err := url.Parse(str)
 	require.NoError(t, err)
@@ -33,6 +33,9 @@ type Config struct {
 	SnapshotFreq uint   // Frequency of snapshots to create when executing (in VM instructions)
 	InfoFreq     uint   // Frequency of progress log messages (in VM instructions)
 	DebugInfo    bool
+	// Snapshots is the snapshot cache shared by all games using this VM. Snapshots are stored per game if nil.
+	// Not set from the command line, but created when the VM's game types are registered.
+	Snapshots *SnapshotCache
 
 	// Host Configuration
 	L1               string
@@ -82,6 +85,16 @@ func (e *Executor) GenerateProof(ctx context.Context, dir string, i uint64) erro
 // The proof is stored at the specified directory.
 func (e *Executor) DoGenerateProof(ctx context.Context, dir string, begin uint64, end uint64, extraVmArgs ...string) error {
 	snapshotDir := filepath.Join(dir, SnapsDir)
+	if e.cfg.Snapshots != nil {
+		sharedDir, release, err := e.cfg.Snapshots.Acquire(ctx, e.logger, SnapshotKey(e.absolutePreState, e.inputs))
+		if err != nil {
+			return fmt.Errorf("wait for vm execution slot: %w", err)
+		}
+		defer release()
+		if sharedDir != "" {
+			snapshotDir = sharedDir
+		}
+	}
 	start, err := e.selectSnapshot(e.logger, snapshotDir, e.absolutePreState, begin)
 	if err != nil {
 		return fmt.Errorf("find starting snapshot: %w", err)
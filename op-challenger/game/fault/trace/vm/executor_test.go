@@ -128,6 +128,20 @@ func TestGenerateProof(t *testing.T) {
 		// so expect that it will be omitted. We'll ultimately want asterisc to execute until the program exits.
 		require.NotContains(t, args, "--stop-at")
 	})
+
+	t.Run("SharedSnapshots", func(t *testing.T) {
+		cfg.Network = "mainnet"
+		cfg.RollupConfigPath = ""
+		cfg.L2GenesisPath = ""
+		cacheDir := filepath.Join(tempDir, "snapshotCache")
+		cfg.Snapshots = NewSnapshotCache(cacheDir, 1024*1024, 1)
+		defer func() { cfg.Snapshots = nil }()
+		_, _, args := captureExec(t, cfg, 150_000_000)
+		sharedDir := filepath.Join(cacheDir, SnapshotKey(prestate, inputs))
+		require.DirExists(t, sharedDir)
+		require.Equal(t, filepath.Join(sharedDir, "%d.json.gz"), args["--snapshot-fmt"])
+		require.Equal(t, filepath.Join(dir, utils.ProofsDir, "%d.json.gz"), args["--proof-fmt"], "proofs are stored per game")
+	})
 }
 
 type stubVmMetrics struct {
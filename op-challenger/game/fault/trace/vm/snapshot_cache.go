@@ -0,0 +1,209 @@
+package vm
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/utils"
+)
+
+// SnapshotCache shares VM snapshots between games that execute the same trace, and bounds the number of VM
+// executions that run concurrently across all games.
+//
+// Games execute the same trace when they use the same absolute prestate and the same local game inputs,
+// which is common when many games dispute claims over the same L2 block range. Executions of the same trace
+// are serialised, so each execution can resume from the snapshots written by the previous ones.
+// The disk space used by the cached snapshots is bounded by evicting the least recently used traces.
+type SnapshotCache struct {
+	dir      string
+	maxBytes uint64
+	// slots limits the number of concurrent VM executions. Nil if executions are not limited.
+	slots chan struct{}
+
+	mu      sync.Mutex
+	entries map[string]*snapshotEntry
+}
+
+type snapshotEntry struct {
+	// lock is held while a VM executes with the snapshots of the entry.
+	// A channel is used, rather than a mutex, so waiting for the lock can be cancelled.
+	lock chan struct{}
+	// users is the number of executions that are running or waiting to run with the snapshots of the entry.
+	users int
+}
+
+// NewSnapshotCache creates a SnapshotCache that stores snapshots in dir, using up to maxBytes of disk space.
+// Snapshots are not shared if maxBytes is 0. VM executions are not limited if maxConcurrency is 0.
+func NewSnapshotCache(dir string, maxBytes uint64, maxConcurrency uint) *SnapshotCache {
+	c := &SnapshotCache{
+		dir:      dir,
+		maxBytes: maxBytes,
+		entries:  make(map[string]*snapshotEntry),
+	}
+	if maxConcurrency > 0 {
+		c.slots = make(chan struct{}, maxConcurrency)
+	}
+	return c
+}
+
+// SnapshotKey returns the cache key of the trace executed from the absolute prestate with the given local inputs.
+func SnapshotKey(prestate string, inputs utils.LocalGameInputs) string {
+	var blockNum []byte
+	if inputs.L2BlockNumber != nil {
+		blockNum = inputs.L2BlockNumber.Bytes()
+	}
+	return crypto.Keccak256Hash(
+		[]byte(prestate),
+		inputs.L1Head[:],
+		inputs.L2Head[:],
+		inputs.L2OutputRoot[:],
+		inputs.L2Claim[:],
+		blockNum,
+	).Hex()
+}
+
+// Acquire waits until a VM execution can start for the trace with the given key.
+// It returns the directory to read and write snapshots in, or an empty string if snapshots are not shared,
+// and a function to call once the execution completes.
+func (c *SnapshotCache) Acquire(ctx context.Context, logger log.Logger, key string) (string, func(), error) {
+	var entry *snapshotEntry
+	if c.maxBytes > 0 {
+		c.mu.Lock()
+		entry = c.entries[key]
+		if entry == nil {
+			entry = &snapshotEntry{lock: make(chan struct{}, 1)}
+			c.entries[key] = entry
+		}
+		entry.users++
+		c.mu.Unlock()
+
+		select {
+		case entry.lock <- struct{}{}:
+		case <-ctx.Done():
+			c.done(logger, key, entry)
+			return "", nil, ctx.Err()
+		}
+	}
+	if c.slots != nil {
+		select {
+		case c.slots <- struct{}{}:
+		case <-ctx.Done():
+			if entry != nil {
+				<-entry.lock
+				c.done(logger, key, entry)
+			}
+			return "", nil, ctx.Err()
+		}
+	}
+	release := func() {
+		if c.slots != nil {
+			<-c.slots
+		}
+		if entry != nil {
+			<-entry.lock
+			c.done(logger, key, entry)
+		}
+	}
+	if entry == nil {
+		return "", release, nil
+	}
+	return filepath.Join(c.dir, key), release, nil
+}
+
+func (c *SnapshotCache) done(logger log.Logger, key string, entry *snapshotEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry.users--
+	if entry.users == 0 {
+		delete(c.entries, key)
+		// The modification time of the snapshot directory records when the trace was last used,
+		// so the least recently used traces can be found after a restart.
+		now := time.Now()
+		if err := os.Chtimes(filepath.Join(c.dir, key), now, now); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			logger.Warn("Failed to update snapshot cache entry", "key", key, "err", err)
+		}
+	}
+	if err := c.enforceQuota(logger); err != nil {
+		logger.Warn("Failed to enforce snapshot cache quota", "dir", c.dir, "err", err)
+	}
+}
+
+// enforceQuota removes the snapshots of the least recently used traces not currently in use,
+// until the snapshots use at most maxBytes of disk space.
+// Must be called with mu held.
+func (c *SnapshotCache) enforceQuota(logger log.Logger) error {
+	dirs, err := os.ReadDir(c.dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	type candidate struct {
+		key      string
+		size     uint64
+		lastUsed time.Time
+	}
+	var total uint64
+	var candidates []candidate
+	for _, dir := range dirs {
+		if !dir.IsDir() {
+			continue
+		}
+		size, modTime, err := dirUsage(filepath.Join(c.dir, dir.Name()))
+		if err != nil {
+			return err
+		}
+		total += size
+		if entry, ok := c.entries[dir.Name()]; ok && entry.users > 0 {
+			continue
+		}
+		candidates = append(candidates, candidate{key: dir.Name(), size: size, lastUsed: modTime})
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].lastUsed.Before(candidates[j].lastUsed)
+	})
+	for _, evict := range candidates {
+		if total <= c.maxBytes {
+			break
+		}
+		logger.Info("Evicting cached snapshots", "key", evict.key, "size", evict.size, "lastUsed", evict.lastUsed)
+		if err := os.RemoveAll(filepath.Join(c.dir, evict.key)); err != nil {
+			return err
+		}
+		total -= evict.size
+	}
+	return nil
+}
+
+// dirUsage returns the total size of the files in dir, and the modification time of dir.
+func dirUsage(dir string) (uint64, time.Time, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	var size uint64
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		size += uint64(info.Size())
+		return nil
+	})
+	return size, info.ModTime(), err
+}
@@ -0,0 +1,134 @@
+package vm
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/utils"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestSnapshotKey(t *testing.T) {
+	inputs := utils.LocalGameInputs{
+		L1Head:        common.Hash{0x11},
+		L2Head:        common.Hash{0x22},
+		L2OutputRoot:  common.Hash{0x33},
+		L2Claim:       common.Hash{0x44},
+		L2BlockNumber: big.NewInt(3333),
+	}
+	key := SnapshotKey("pre.json", inputs)
+	require.Equal(t, key, SnapshotKey("pre.json", inputs))
+	require.NotEqual(t, key, SnapshotKey("other.json", inputs))
+	otherClaim := inputs
+	otherClaim.L2Claim = common.Hash{0x55}
+	require.NotEqual(t, key, SnapshotKey("pre.json", otherClaim))
+	otherBlock := inputs
+	otherBlock.L2BlockNumber = big.NewInt(3334)
+	require.NotEqual(t, key, SnapshotKey("pre.json", otherBlock))
+}
+
+func TestSnapshotCache(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+
+	t.Run("Disabled", func(t *testing.T) {
+		cache := NewSnapshotCache(t.TempDir(), 0, 0)
+		dir, release, err := cache.Acquire(context.Background(), logger, "a")
+		require.NoError(t, err)
+		require.Empty(t, dir, "snapshots are not shared")
+		release()
+	})
+
+	t.Run("SharesDirForSameKey", func(t *testing.T) {
+		root := t.TempDir()
+		cache := NewSnapshotCache(root, 1024*1024, 0)
+		dir, release, err := cache.Acquire(context.Background(), logger, "a")
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join(root, "a"), dir)
+		release()
+		dir, release, err = cache.Acquire(context.Background(), logger, "a")
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join(root, "a"), dir)
+		release()
+	})
+
+	t.Run("SerialisesSameKey", func(t *testing.T) {
+		cache := NewSnapshotCache(t.TempDir(), 1024*1024, 0)
+		_, release, err := cache.Acquire(context.Background(), logger, "a")
+		require.NoError(t, err)
+
+		// Other traces can execute concurrently
+		_, releaseOther, err := cache.Acquire(context.Background(), logger, "b")
+		require.NoError(t, err)
+		releaseOther()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, _, err = cache.Acquire(ctx, logger, "a")
+		require.ErrorIs(t, err, context.DeadlineExceeded, "same trace must wait for the running execution")
+
+		acquired := make(chan struct{})
+		go func() {
+			_, release, err := cache.Acquire(context.Background(), logger, "a")
+			require.NoError(t, err)
+			release()
+			close(acquired)
+		}()
+		release()
+		<-acquired
+	})
+
+	t.Run("LimitsConcurrency", func(t *testing.T) {
+		cache := NewSnapshotCache(t.TempDir(), 0, 2)
+		_, release1, err := cache.Acquire(context.Background(), logger, "a")
+		require.NoError(t, err)
+		_, release2, err := cache.Acquire(context.Background(), logger, "b")
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, _, err = cache.Acquire(ctx, logger, "c")
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+
+		release1()
+		_, release3, err := cache.Acquire(context.Background(), logger, "c")
+		require.NoError(t, err)
+		release2()
+		release3()
+	})
+
+	t.Run("EvictsLeastRecentlyUsed", func(t *testing.T) {
+		root := t.TempDir()
+		cache := NewSnapshotCache(root, 250, 0)
+		writeSnapshot := func(key string, size int) {
+			dir, release, err := cache.Acquire(context.Background(), logger, key)
+			require.NoError(t, err)
+			require.NoError(t, os.MkdirAll(dir, 0755))
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "100.json.gz"), make([]byte, size), 0644))
+			release()
+		}
+		writeSnapshot("a", 100)
+		require.NoError(t, os.Chtimes(filepath.Join(root, "a"), time.Unix(100, 0), time.Unix(100, 0)))
+		writeSnapshot("b", 100)
+		require.DirExists(t, filepath.Join(root, "a"))
+		require.DirExists(t, filepath.Join(root, "b"))
+
+		// Snapshots in use are not evicted
+		dir, release, err := cache.Acquire(context.Background(), logger, "c")
+		require.NoError(t, err)
+		require.NoError(t, os.MkdirAll(dir, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "100.json.gz"), make([]byte, 100), 0644))
+		writeSnapshot("b", 100)
+		require.NoDirExists(t, filepath.Join(root, "a"), "least recently used trace is evicted")
+		require.DirExists(t, filepath.Join(root, "b"))
+		require.DirExists(t, filepath.Join(root, "c"))
+		release()
+	})
+}
@@ -65,9 +65,11 @@ const (
 	TraceTypeAsterisc     TraceType = "asterisc"
 	TraceTypeAsteriscKona TraceType = "asterisc-kona"
 	TraceTypePermissioned TraceType = "permissioned"
+	// TraceTypeExternal uses an external trace provider, for a configured game type.
+	TraceTypeExternal TraceType = "external"
 )
 
-var TraceTypes = []TraceType{TraceTypeAlphabet, TraceTypeCannon, TraceTypePermissioned, TraceTypeAsterisc, TraceTypeFast}
+var TraceTypes = []TraceType{TraceTypeAlphabet, TraceTypeCannon, TraceTypePermissioned, TraceTypeAsterisc, TraceTypeFast, TraceTypeExternal}
 
 func (t TraceType) String() string {
 	return string(t)
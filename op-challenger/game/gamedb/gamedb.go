@@ -0,0 +1,368 @@
+package gamedb
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+)
+
+var ErrInvalidEntry = errors.New("invalid db entry")
+
+const (
+	// Keys are prefixed with a constant byte to allow us to differentiate different "columns" within the data
+	keyPrefixGame byte = 0
+)
+
+func gameKey(addr common.Address) []byte {
+	key := make([]byte, 0, 1+common.AddressLength)
+	key = append(key, keyPrefixGame)
+	key = append(key, addr.Bytes()...)
+	return key
+}
+
+func gameKeyRange() *pebble.IterOptions {
+	return &pebble.IterOptions{
+		LowerBound: []byte{keyPrefixGame},
+		UpperBound: []byte{keyPrefixGame + 1},
+	}
+}
+
+// Move is an action the challenger scheduled in a game.
+type Move struct {
+	Type        faultTypes.ActionType `json:"type"`
+	ParentIndex int                   `json:"parentIndex"`
+	IsAttack    bool                  `json:"isAttack"`
+	Value       common.Hash           `json:"value"`
+	// Posted is set once the action was performed successfully.
+	Posted bool `json:"posted"`
+}
+
+func newMove(action faultTypes.Action) Move {
+	return Move{
+		Type:        action.Type,
+		ParentIndex: action.ParentClaim.ContractIndex,
+		IsAttack:    action.IsAttack,
+		Value:       action.Value,
+	}
+}
+
+// GameRecord is the challenger's progress in a game, as persisted in the database.
+type GameRecord struct {
+	Metadata types.GameMetadata
+	Status   types.GameStatus
+	// AgreeWithRootClaim is nil until the claims of the game have been evaluated.
+	AgreeWithRootClaim *bool
+	// Claims are the claims of the game when it was last progressed.
+	Claims []faultTypes.Claim
+	// Moves are the actions scheduled in the game, in the order they were first scheduled.
+	Moves []Move
+}
+
+type claimRecord struct {
+	Value               common.Hash    `json:"value"`
+	Bond                *big.Int       `json:"bond"`
+	Depth               uint64         `json:"depth"`
+	IndexAtDepth        *big.Int       `json:"indexAtDepth"`
+	CounteredBy         common.Address `json:"counteredBy"`
+	Claimant            common.Address `json:"claimant"`
+	ClockDuration       time.Duration  `json:"clockDuration"`
+	ClockTimestamp      time.Time      `json:"clockTimestamp"`
+	ContractIndex       int            `json:"contractIndex"`
+	ParentContractIndex int            `json:"parentContractIndex"`
+}
+
+type gameRecordJSON struct {
+	Metadata           types.GameMetadata `json:"metadata"`
+	Status             types.GameStatus   `json:"status"`
+	AgreeWithRootClaim *bool              `json:"agreeWithRootClaim,omitempty"`
+	Claims             []claimRecord      `json:"claims"`
+	Moves              []Move             `json:"moves"`
+}
+
+func encodeGameRecord(record *GameRecord) ([]byte, error) {
+	enc := gameRecordJSON{
+		Metadata:           record.Metadata,
+		Status:             record.Status,
+		AgreeWithRootClaim: record.AgreeWithRootClaim,
+		Claims:             make([]claimRecord, 0, len(record.Claims)),
+		Moves:              record.Moves,
+	}
+	for _, claim := range record.Claims {
+		enc.Claims = append(enc.Claims, claimRecord{
+			Value:               claim.Value,
+			Bond:                claim.Bond,
+			Depth:               uint64(claim.Depth()),
+			IndexAtDepth:        claim.IndexAtDepth(),
+			CounteredBy:         claim.CounteredBy,
+			Claimant:            claim.Claimant,
+			ClockDuration:       claim.Clock.Duration,
+			ClockTimestamp:      claim.Clock.Timestamp,
+			ContractIndex:       claim.ContractIndex,
+			ParentContractIndex: claim.ParentContractIndex,
+		})
+	}
+	return json.Marshal(enc)
+}
+
+func decodeGameRecord(key []byte, val []byte) (*GameRecord, error) {
+	if len(key) != 1+common.AddressLength || key[0] != keyPrefixGame {
+		return nil, ErrInvalidEntry
+	}
+	var dec gameRecordJSON
+	if err := json.Unmarshal(val, &dec); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidEntry, err)
+	}
+	if dec.Metadata.Proxy != common.BytesToAddress(key[1:]) {
+		return nil, fmt.Errorf("%w: game %v stored under key %x", ErrInvalidEntry, dec.Metadata.Proxy, key)
+	}
+	record := &GameRecord{
+		Metadata:           dec.Metadata,
+		Status:             dec.Status,
+		AgreeWithRootClaim: dec.AgreeWithRootClaim,
+		Claims:             make([]faultTypes.Claim, 0, len(dec.Claims)),
+		Moves:              dec.Moves,
+	}
+	for _, claim := range dec.Claims {
+		if claim.IndexAtDepth == nil || claim.Bond == nil {
+			return nil, fmt.Errorf("%w: incomplete claim %v of game %v", ErrInvalidEntry, claim.ContractIndex, dec.Metadata.Proxy)
+		}
+		record.Claims = append(record.Claims, faultTypes.Claim{
+			ClaimData: faultTypes.ClaimData{
+				Value:    claim.Value,
+				Bond:     claim.Bond,
+				Position: faultTypes.NewPosition(faultTypes.Depth(claim.Depth), claim.IndexAtDepth),
+			},
+			CounteredBy:         claim.CounteredBy,
+			Claimant:            claim.Claimant,
+			Clock:               faultTypes.NewClock(claim.ClockDuration, claim.ClockTimestamp),
+			ContractIndex:       claim.ContractIndex,
+			ParentContractIndex: claim.ParentContractIndex,
+		})
+	}
+	return record, nil
+}
+
+// GameDB persists the challenger's progress in the games it tracks, so it can resume after a restart
+// without re-fetching and re-evaluating every game.
+// All records are kept in memory and written through to the database. It is safe for concurrent use.
+type GameDB struct {
+	m   sync.RWMutex
+	log log.Logger
+	db  *pebble.DB
+
+	writeOpts *pebble.WriteOptions
+
+	games  map[common.Address]*GameRecord
+	closed bool
+}
+
+// NewGameDB opens the game database at path, and loads the games recorded before the last shutdown.
+func NewGameDB(logger log.Logger, path string) (*GameDB, error) {
+	db, err := pebble.Open(path, &pebble.Options{})
+	if err != nil {
+		return nil, err
+	}
+	d := &GameDB{
+		log:       logger,
+		db:        db,
+		writeOpts: &pebble.WriteOptions{Sync: true},
+		games:     make(map[common.Address]*GameRecord),
+	}
+	if err := d.load(); err != nil {
+		return nil, errors.Join(fmt.Errorf("failed to load games: %w", err), db.Close())
+	}
+	d.log.Info("Loaded game records", "games", len(d.games))
+	return d, nil
+}
+
+func (d *GameDB) load() error {
+	iter, err := d.db.NewIter(gameKeyRange())
+	if err != nil {
+		return fmt.Errorf("failed to create iterator: %w", err)
+	}
+	defer iter.Close()
+	for valid := iter.First(); valid; valid = iter.Next() {
+		val, err := iter.ValueAndErr()
+		if err != nil {
+			return fmt.Errorf("failed to read entry: %w", err)
+		}
+		record, err := decodeGameRecord(iter.Key(), val)
+		if err != nil {
+			return err
+		}
+		d.games[record.Metadata.Proxy] = record
+	}
+	return nil
+}
+
+// Games returns the recorded games, ordered by game index.
+func (d *GameDB) Games() []GameRecord {
+	d.m.RLock()
+	defer d.m.RUnlock()
+	games := make([]GameRecord, 0, len(d.games))
+	for _, record := range d.games {
+		games = append(games, copyRecord(record))
+	}
+	slices.SortFunc(games, func(a, b GameRecord) int {
+		return cmp.Compare(a.Metadata.Index, b.Metadata.Index)
+	})
+	return games
+}
+
+// Game returns the record of a single game. Returns false if the game is not recorded.
+func (d *GameDB) Game(addr common.Address) (GameRecord, bool) {
+	d.m.RLock()
+	defer d.m.RUnlock()
+	record, ok := d.games[addr]
+	if !ok {
+		return GameRecord{}, false
+	}
+	return copyRecord(record), true
+}
+
+// TrackGames sets the games currently being tracked. Records of games that are no longer included are deleted.
+func (d *GameDB) TrackGames(games []types.GameMetadata) error {
+	d.m.Lock()
+	defer d.m.Unlock()
+	batch := d.db.NewBatch()
+	defer batch.Close()
+	tracked := make(map[common.Address]*GameRecord, len(games))
+	for _, game := range games {
+		record, ok := d.games[game.Proxy]
+		if ok && record.Metadata == game {
+			tracked[game.Proxy] = record
+			continue
+		}
+		if !ok {
+			record = &GameRecord{Status: types.GameStatusInProgress}
+		}
+		record.Metadata = game
+		if err := d.set(batch, record); err != nil {
+			return err
+		}
+		tracked[game.Proxy] = record
+	}
+	for addr := range d.games {
+		if _, ok := tracked[addr]; !ok {
+			if err := batch.Delete(gameKey(addr), d.writeOpts); err != nil {
+				return fmt.Errorf("failed to delete game %v: %w", addr, err)
+			}
+		}
+	}
+	if err := batch.Commit(d.writeOpts); err != nil {
+		return fmt.Errorf("failed to commit tracked games: %w", err)
+	}
+	d.games = tracked
+	return nil
+}
+
+// UpdateStatus records the latest status of a tracked game.
+func (d *GameDB) UpdateStatus(addr common.Address, status types.GameStatus) error {
+	return d.update(addr, func(record *GameRecord) bool {
+		if record.Status == status {
+			return false
+		}
+		record.Status = status
+		return true
+	})
+}
+
+// UpdateClaims records the latest claims of a tracked game and whether the honest actor agrees with its root claim.
+func (d *GameDB) UpdateClaims(addr common.Address, claims []faultTypes.Claim, agreeWithRootClaim bool) error {
+	return d.update(addr, func(record *GameRecord) bool {
+		record.Claims = claims
+		record.AgreeWithRootClaim = &agreeWithRootClaim
+		return true
+	})
+}
+
+// RecordMove records an action scheduled in a tracked game, and whether it has been posted.
+func (d *GameDB) RecordMove(addr common.Address, action faultTypes.Action, posted bool) error {
+	move := newMove(action)
+	move.Posted = posted
+	return d.update(addr, func(record *GameRecord) bool {
+		idx := slices.IndexFunc(record.Moves, func(existing Move) bool {
+			existing.Posted = posted
+			return existing == move
+		})
+		if idx < 0 {
+			record.Moves = append(record.Moves, move)
+			return true
+		}
+		if record.Moves[idx].Posted || !posted {
+			// Don't downgrade a move that was already posted when it is scheduled again.
+			return false
+		}
+		record.Moves[idx].Posted = true
+		return true
+	})
+}
+
+// update applies fn to the record of a tracked game and persists it if fn reports it changed.
+// Updates to untracked games are ignored.
+func (d *GameDB) update(addr common.Address, fn func(record *GameRecord) bool) error {
+	d.m.Lock()
+	defer d.m.Unlock()
+	existing, ok := d.games[addr]
+	if !ok {
+		return nil
+	}
+	record := copyRecord(existing)
+	if !fn(&record) {
+		return nil
+	}
+	batch := d.db.NewBatch()
+	defer batch.Close()
+	if err := d.set(batch, &record); err != nil {
+		return err
+	}
+	if err := batch.Commit(d.writeOpts); err != nil {
+		return fmt.Errorf("failed to commit game %v: %w", addr, err)
+	}
+	d.games[addr] = &record
+	return nil
+}
+
+func (d *GameDB) set(batch *pebble.Batch, record *GameRecord) error {
+	if d.closed {
+		return errors.New("game db is closed")
+	}
+	val, err := encodeGameRecord(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode game %v: %w", record.Metadata.Proxy, err)
+	}
+	if err := batch.Set(gameKey(record.Metadata.Proxy), val, d.writeOpts); err != nil {
+		return fmt.Errorf("failed to record game %v: %w", record.Metadata.Proxy, err)
+	}
+	return nil
+}
+
+func copyRecord(record *GameRecord) GameRecord {
+	cpy := *record
+	cpy.Claims = slices.Clone(record.Claims)
+	cpy.Moves = slices.Clone(record.Moves)
+	return cpy
+}
+
+func (d *GameDB) Close() error {
+	d.m.Lock()
+	defer d.m.Unlock()
+	if d.closed {
+		// Already closed
+		return nil
+	}
+	d.closed = true
+	return d.db.Close()
+}
@@ -0,0 +1,118 @@
+package gamedb
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+var (
+	game1 = types.GameMetadata{Index: 1, GameType: 0, Timestamp: 100, Proxy: common.Address{0x01}}
+	game2 = types.GameMetadata{Index: 2, GameType: 1, Timestamp: 200, Proxy: common.Address{0x02}}
+)
+
+func newClaim(idx int, parentIdx int) faultTypes.Claim {
+	return faultTypes.Claim{
+		ClaimData: faultTypes.ClaimData{
+			Value: common.Hash{byte(idx)},
+			Bond:  big.NewInt(1000),
+			// Use positions with a non-zero index at depth, as a decoded zero big.Int does not compare equal to big.NewInt(0).
+			Position: faultTypes.NewPositionFromGIndex(big.NewInt(int64(2*idx + 3))),
+		},
+		Claimant:            common.Address{0xaa},
+		CounteredBy:         common.Address{0xbb},
+		Clock:               faultTypes.NewClock(5*time.Minute, time.Unix(1000, 0).UTC()),
+		ContractIndex:       idx,
+		ParentContractIndex: parentIdx,
+	}
+}
+
+func TestPersistGames(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	dir := t.TempDir()
+	db, err := NewGameDB(logger, dir)
+	require.NoError(t, err)
+
+	claims := []faultTypes.Claim{newClaim(0, -1), newClaim(1, 0)}
+	move := faultTypes.Action{Type: faultTypes.ActionTypeMove, ParentClaim: claims[1], IsAttack: true, Value: common.Hash{0xcc}}
+	require.NoError(t, db.TrackGames([]types.GameMetadata{game2, game1}))
+	require.NoError(t, db.UpdateStatus(game1.Proxy, types.GameStatusChallengerWon))
+	require.NoError(t, db.UpdateClaims(game2.Proxy, claims, false))
+	require.NoError(t, db.RecordMove(game2.Proxy, move, false))
+	require.NoError(t, db.Close())
+
+	db, err = NewGameDB(logger, dir)
+	require.NoError(t, err)
+	defer db.Close()
+	games := db.Games()
+	require.Len(t, games, 2)
+	require.Equal(t, game1, games[0].Metadata)
+	require.Equal(t, types.GameStatusChallengerWon, games[0].Status)
+	require.Nil(t, games[0].AgreeWithRootClaim)
+
+	require.Equal(t, game2, games[1].Metadata)
+	require.Equal(t, types.GameStatusInProgress, games[1].Status)
+	require.NotNil(t, games[1].AgreeWithRootClaim)
+	require.False(t, *games[1].AgreeWithRootClaim)
+	require.Equal(t, claims, games[1].Claims)
+	require.Equal(t, []Move{{Type: faultTypes.ActionTypeMove, ParentIndex: 1, IsAttack: true, Value: common.Hash{0xcc}}}, games[1].Moves)
+}
+
+func TestTrackGamesDropsUntrackedGames(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	dir := t.TempDir()
+	db, err := NewGameDB(logger, dir)
+	require.NoError(t, err)
+	require.NoError(t, db.TrackGames([]types.GameMetadata{game1, game2}))
+	require.NoError(t, db.UpdateStatus(game1.Proxy, types.GameStatusDefenderWon))
+
+	require.NoError(t, db.TrackGames([]types.GameMetadata{game1}))
+	_, ok := db.Game(game2.Proxy)
+	require.False(t, ok)
+	record, ok := db.Game(game1.Proxy)
+	require.True(t, ok)
+	require.Equal(t, types.GameStatusDefenderWon, record.Status, "should retain state of games still tracked")
+
+	// Updates for untracked games are ignored
+	require.NoError(t, db.UpdateStatus(game2.Proxy, types.GameStatusChallengerWon))
+	require.NoError(t, db.UpdateClaims(game2.Proxy, []faultTypes.Claim{newClaim(0, -1)}, true))
+	require.NoError(t, db.Close())
+
+	db, err = NewGameDB(logger, dir)
+	require.NoError(t, err)
+	defer db.Close()
+	games := db.Games()
+	require.Len(t, games, 1)
+	require.Equal(t, game1, games[0].Metadata)
+}
+
+func TestRecordMove(t *testing.T) {
+	db, err := NewGameDB(testlog.Logger(t, log.LevelInfo), t.TempDir())
+	require.NoError(t, err)
+	defer db.Close()
+	require.NoError(t, db.TrackGames([]types.GameMetadata{game1}))
+
+	parent := newClaim(0, -1)
+	attack := faultTypes.Action{Type: faultTypes.ActionTypeMove, ParentClaim: parent, IsAttack: true, Value: common.Hash{0xaa}}
+	defend := faultTypes.Action{Type: faultTypes.ActionTypeMove, ParentClaim: parent, IsAttack: false, Value: common.Hash{0xbb}}
+	require.NoError(t, db.RecordMove(game1.Proxy, attack, false))
+	require.NoError(t, db.RecordMove(game1.Proxy, defend, false))
+	require.NoError(t, db.RecordMove(game1.Proxy, attack, true))
+	// Scheduling a posted move again does not mark it as pending
+	require.NoError(t, db.RecordMove(game1.Proxy, attack, false))
+
+	record, ok := db.Game(game1.Proxy)
+	require.True(t, ok)
+	require.Equal(t, []Move{
+		{Type: faultTypes.ActionTypeMove, ParentIndex: 0, IsAttack: true, Value: common.Hash{0xaa}, Posted: true},
+		{Type: faultTypes.ActionTypeMove, ParentIndex: 0, IsAttack: false, Value: common.Hash{0xbb}},
+	}, record.Moves)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"sync"
 	"time"
 
@@ -19,6 +20,9 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 )
 
+// ErrNoGameAllowlist is returned when registering a game without a game allow list, as all games are played then.
+var ErrNoGameAllowlist = errors.New("no game allow list configured, all games are played")
+
 // gameSource loads information about the games available to play
 type gameSource interface {
 	GetGamesAtOrAfter(ctx context.Context, blockHash common.Hash, earliestTimestamp uint64) ([]types.GameMetadata, error)
@@ -41,6 +45,10 @@ type claimer interface {
 	Schedule(blockNumber uint64, games []types.GameMetadata) error
 }
 
+type gameTracker interface {
+	TrackGames(games []types.GameMetadata)
+}
+
 type gameMonitor struct {
 	logger       log.Logger
 	clock        RWClock
@@ -49,6 +57,8 @@ type gameMonitor struct {
 	preimages    preimageScheduler
 	gameWindow   time.Duration
 	claimer      claimer
+	tracker      gameTracker
+	allowLock    sync.Mutex
 	allowedGames []common.Address
 	l1HeadsSub   ethereum.Subscription
 	l1Source     *headSource
@@ -75,6 +85,7 @@ func newGameMonitor(
 	preimages preimageScheduler,
 	gameWindow time.Duration,
 	claimer claimer,
+	tracker gameTracker,
 	allowedGames []common.Address,
 	l1Source MinimalSubscriber,
 ) *gameMonitor {
@@ -86,21 +97,36 @@ func newGameMonitor(
 		source:       source,
 		gameWindow:   gameWindow,
 		claimer:      claimer,
+		tracker:      tracker,
 		allowedGames: allowedGames,
 		l1Source:     &headSource{inner: l1Source},
 	}
 }
 
 func (m *gameMonitor) allowedGame(game common.Address) bool {
+	m.allowLock.Lock()
+	defer m.allowLock.Unlock()
 	if len(m.allowedGames) == 0 {
 		return true
 	}
-	for _, allowed := range m.allowedGames {
-		if allowed == game {
-			return true
-		}
+	return slices.Contains(m.allowedGames, game)
+}
+
+// RegisterGame ensures a game is played, even if it is not on the allow list the challenger was started with.
+// The game is scheduled when the next L1 head is processed, like any other game within the game window.
+// Games can only be registered if the challenger was started with an allow list, as it plays all games otherwise.
+func (m *gameMonitor) RegisterGame(game common.Address) error {
+	m.allowLock.Lock()
+	defer m.allowLock.Unlock()
+	if len(m.allowedGames) == 0 {
+		return ErrNoGameAllowlist
 	}
-	return false
+	if slices.Contains(m.allowedGames, game) {
+		return nil
+	}
+	m.logger.Info("Registered game", "game", game)
+	m.allowedGames = append(m.allowedGames, game)
+	return nil
 }
 
 func (m *gameMonitor) progressGames(ctx context.Context, blockHash common.Hash, blockNumber uint64) error {
@@ -117,6 +143,7 @@ func (m *gameMonitor) progressGames(ctx context.Context, blockHash common.Hash,
 		}
 		gamesToPlay = append(gamesToPlay, game)
 	}
+	m.tracker.TrackGames(gamesToPlay)
 	if err := m.claimer.Schedule(blockNumber, gamesToPlay); err != nil {
 		return fmt.Errorf("failed to schedule bond claims: %w", err)
 	}
@@ -140,6 +140,27 @@ func TestMonitorOnlyScheduleSpecifiedGame(t *testing.T) {
 	require.Len(t, sched.Scheduled(), 1)
 	require.Equal(t, []common.Address{addr2}, sched.Scheduled()[0])
 	require.Equal(t, 1, stubClaimer.scheduledGames)
+	require.Equal(t, []types.GameMetadata{newFDG(addr2, 9999)}, monitor.tracker.(*stubGameTracker).games)
+}
+
+func TestMonitorRegisterGame(t *testing.T) {
+	addr1 := common.Address{0xaa}
+	addr2 := common.Address{0xbb}
+	monitor, source, sched, _, _, _ := setupMonitorTest(t, []common.Address{addr2})
+	source.games = []types.GameMetadata{newFDG(addr1, 9999), newFDG(addr2, 9999)}
+
+	require.NoError(t, monitor.RegisterGame(addr1))
+	require.NoError(t, monitor.RegisterGame(addr1))
+	require.NoError(t, monitor.progressGames(context.Background(), common.Hash{0x01}, 0))
+	require.Len(t, sched.Scheduled(), 1)
+	require.Equal(t, []common.Address{addr1, addr2}, sched.Scheduled()[0])
+	require.Equal(t, []common.Address{addr2, addr1}, monitor.allowedGames)
+}
+
+func TestMonitorRegisterGameWithoutAllowlist(t *testing.T) {
+	monitor, _, _, _, _, _ := setupMonitorTest(t, nil)
+	require.ErrorIs(t, monitor.RegisterGame(common.Address{0xaa}), ErrNoGameAllowlist)
+	require.Empty(t, monitor.allowedGames)
 }
 
 func newFDG(proxy common.Address, timestamp uint64) types.GameMetadata {
@@ -167,6 +188,7 @@ func setupMonitorTest(
 		preimages,
 		time.Duration(0),
 		stubClaimer,
+		&stubGameTracker{},
 		allowedGames,
 		mockHeadSource,
 	)
@@ -222,6 +244,14 @@ func (m *mockScheduler) Schedule(_ uint64, games []types.GameMetadata) error {
 	return m.scheduleErr
 }
 
+type stubGameTracker struct {
+	games []types.GameMetadata
+}
+
+func (s *stubGameTracker) TrackGames(games []types.GameMetadata) {
+	s.games = games
+}
+
 type mockSubscription struct {
 	errChan chan error
 	headers chan<- *ethtypes.Header
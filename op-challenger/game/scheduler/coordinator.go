@@ -1,10 +1,13 @@
 package scheduler
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
+	"math/big"
 	"slices"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
 
@@ -16,18 +19,30 @@ var errUnknownGame = errors.New("unknown game")
 
 type PlayerCreator func(game types.GameMetadata, dir string) (GamePlayer, error)
 
+type ClockReader interface {
+	Now() time.Time
+}
+
 type CoordinatorMetricer interface {
 	RecordActedL1Block(n uint64)
 	RecordGamesStatus(inProgress, defenderWon, challengerWon int)
 	RecordGameUpdateScheduled()
 	RecordGameUpdateCompleted()
+	RecordGameSkipped(reason string)
 }
 
 type gameState struct {
+	gameType              uint32
 	player                GamePlayer
 	inflight              bool
 	lastProcessedBlockNum uint64
 	status                types.GameStatus
+	// skipped is set when the game can never be honestly played, so it is no longer progressed.
+	skipped bool
+	// urgency is the urgency reported when the game was last progressed, at progressedAt.
+	// progressedAt is the zero time if the game hasn't been progressed yet.
+	urgency      types.GameUrgency
+	progressedAt time.Time
 }
 
 // coordinator manages the set of current games, queues games to be played (on separate worker threads) and
@@ -46,6 +61,17 @@ type coordinator struct {
 	states       map[common.Address]*gameState
 	disk         DiskManager
 
+	// gameTypeLimits is the maximum number of in-flight jobs for each game type.
+	// Game types without a limit are only limited by the number of workers.
+	gameTypeLimits map[uint32]uint
+	// gameTypeInflight is the current number of in-flight jobs for each game type.
+	gameTypeInflight map[uint32]uint
+
+	// urgentClockThreshold is how close to expiring a chess clock must be for the game to be urgent.
+	// While urgent games are waiting to be progressed, other games are deferred. Disabled when 0.
+	urgentClockThreshold time.Duration
+	clock                ClockReader
+
 	allowInvalidPrestate bool
 
 	// lastScheduledBlockNum is the highest block number that the coordinator has seen and scheduled jobs.
@@ -72,11 +98,23 @@ func (c *coordinator) schedule(ctx context.Context, games []types.GameMetadata,
 	var gamesDefenderWon int
 	var errs []error
 	var jobs []job
+	deferNonUrgent := false
+	if c.urgentClockThreshold > 0 {
+		games = c.sortByUrgency(games)
+		deferNonUrgent = slices.ContainsFunc(games, c.urgentGamePending)
+	} else if len(c.gameTypeLimits) > 0 {
+		// Give games that have waited longest priority, so that games skipped because of
+		// game type limits are not starved by games earlier in the list.
+		games = slices.Clone(games)
+		slices.SortStableFunc(games, func(a, b types.GameMetadata) int {
+			return cmp.Compare(c.lastProcessedBlockNum(a), c.lastProcessedBlockNum(b))
+		})
+	}
 	// Next collect all the jobs to schedule and ensure all games are recorded in the states map.
 	// Otherwise, results may start being processed before all games are recorded, resulting in existing
 	// data directories potentially being deleted for games that are required.
 	for _, game := range games {
-		if j, err := c.createJob(ctx, game, blockNumber); err != nil {
+		if j, err := c.createJob(ctx, game, blockNumber, deferNonUrgent); err != nil {
 			errs = append(errs, fmt.Errorf("failed to create job for game %v: %w", game.Proxy, err))
 		} else if j != nil {
 			jobs = append(jobs, *j)
@@ -114,31 +152,122 @@ func (c *coordinator) schedule(ctx context.Context, games []types.GameMetadata,
 	return errors.Join(errs...)
 }
 
+func (c *coordinator) lastProcessedBlockNum(game types.GameMetadata) uint64 {
+	if state, ok := c.states[game.Proxy]; ok {
+		return state.lastProcessedBlockNum
+	}
+	return c.lastScheduledBlockNum
+}
+
+// sortByUrgency orders games so that the games most at risk of losing bonds are progressed first: urgent games,
+// then the earliest chess clock deadline, the largest bond at risk and finally the games that waited longest.
+func (c *coordinator) sortByUrgency(games []types.GameMetadata) []types.GameMetadata {
+	now := c.clock.Now()
+	urgency := make(map[common.Address]types.GameUrgency, len(games))
+	urgent := make(map[common.Address]bool, len(games))
+	for _, game := range games {
+		if state, ok := c.states[game.Proxy]; ok {
+			urgency[game.Proxy] = state.urgency
+			urgent[game.Proxy] = c.isUrgent(state, now)
+		} else {
+			urgent[game.Proxy] = true
+		}
+	}
+	games = slices.Clone(games)
+	slices.SortStableFunc(games, func(a, b types.GameMetadata) int {
+		if urgent[a.Proxy] != urgent[b.Proxy] {
+			if urgent[a.Proxy] {
+				return -1
+			}
+			return 1
+		}
+		if res := compareDeadlines(urgency[a.Proxy].Deadline, urgency[b.Proxy].Deadline); res != 0 {
+			return res
+		}
+		if res := bondOrZero(urgency[b.Proxy].BondAtRisk).Cmp(bondOrZero(urgency[a.Proxy].BondAtRisk)); res != 0 {
+			return res
+		}
+		return cmp.Compare(c.lastProcessedBlockNum(a), c.lastProcessedBlockNum(b))
+	})
+	return games
+}
+
+// compareDeadlines orders earlier deadlines first, with games without a deadline last.
+func compareDeadlines(a, b time.Time) int {
+	switch {
+	case a.IsZero() && b.IsZero():
+		return 0
+	case a.IsZero():
+		return 1
+	case b.IsZero():
+		return -1
+	default:
+		return a.Compare(b)
+	}
+}
+
+func bondOrZero(bond *big.Int) *big.Int {
+	if bond == nil {
+		return new(big.Int)
+	}
+	return bond
+}
+
+// isUrgent returns true if a chess clock of the game expires within the urgent clock threshold.
+// Games that haven't been progressed within the threshold are also urgent as new claims may have been made.
+func (c *coordinator) isUrgent(state *gameState, now time.Time) bool {
+	if state.progressedAt.IsZero() || now.Sub(state.progressedAt) >= c.urgentClockThreshold {
+		return true
+	}
+	return !state.urgency.Deadline.IsZero() && state.urgency.Deadline.Sub(now) <= c.urgentClockThreshold
+}
+
+// urgentGamePending returns true if the game is urgent and waiting to be progressed.
+func (c *coordinator) urgentGamePending(game types.GameMetadata) bool {
+	state, ok := c.states[game.Proxy]
+	if !ok {
+		return true
+	}
+	return !state.inflight && !state.skipped && state.status == types.GameStatusInProgress && c.isUrgent(state, c.clock.Now())
+}
+
 // createJob updates the state for the specified game and returns the job to enqueue for it, if any
 // Returns (nil, nil) when there is no error and no job to enqueue
-func (c *coordinator) createJob(ctx context.Context, game types.GameMetadata, blockNumber uint64) (*job, error) {
+// If deferNonUrgent is set, games that aren't urgent are left to be progressed with a later block.
+func (c *coordinator) createJob(ctx context.Context, game types.GameMetadata, blockNumber uint64, deferNonUrgent bool) (*job, error) {
 	state, ok := c.states[game.Proxy]
 	if !ok {
 		// This is the first time we're seeing this game, so its last processed block
 		// is the last block the coordinator processed (it didn't exist yet).
-		state = &gameState{lastProcessedBlockNum: c.lastScheduledBlockNum}
+		state = &gameState{gameType: game.GameType, lastProcessedBlockNum: c.lastScheduledBlockNum}
 		c.states[game.Proxy] = state
 	}
 	if state.inflight {
 		c.logger.Debug("Not rescheduling already in-flight game", "game", game.Proxy)
 		return nil, nil
 	}
+	if state.skipped {
+		c.logger.Debug("Not scheduling invalid game", "game", game.Proxy)
+		state.lastProcessedBlockNum = blockNumber
+		return nil, nil
+	}
 	// Create the player separately to the state so we retry creating it if it fails on the first attempt.
 	if state.player == nil {
 		player, err := c.createPlayer(game, c.disk.DirForGame(game.Proxy))
 		if err != nil {
+			if c.skipInvalidGame(state, game, err, blockNumber) {
+				return nil, nil
+			}
 			return nil, fmt.Errorf("failed to create game player: %w", err)
 		}
 		if err := player.ValidatePrestate(ctx); err != nil {
-			if !c.allowInvalidPrestate || !errors.Is(err, types.ErrInvalidPrestate) {
+			if c.allowInvalidPrestate && errors.Is(err, types.ErrInvalidPrestate) {
+				c.logger.Error("Invalid prestate", "game", game.Proxy, "err", err)
+			} else if c.skipInvalidGame(state, game, err, blockNumber) {
+				return nil, nil
+			} else {
 				return nil, fmt.Errorf("failed to validate prestate: %w", err)
 			}
-			c.logger.Error("Invalid prestate", "game", game.Proxy, "err", err)
 		}
 		state.player = player
 		state.status = player.Status()
@@ -148,10 +277,35 @@ func (c *coordinator) createJob(ctx context.Context, game types.GameMetadata, bl
 		state.lastProcessedBlockNum = blockNumber
 		return nil, nil
 	}
+	if deferNonUrgent && !c.isUrgent(state, c.clock.Now()) {
+		// Leave the last processed block unchanged, so the game is retried with the next scheduled block.
+		c.logger.Debug("Deferring game, more urgent games are pending", "game", game.Proxy, "deadline", state.urgency.Deadline)
+		return nil, nil
+	}
+	if limit, ok := c.gameTypeLimits[game.GameType]; ok && c.gameTypeInflight[game.GameType] >= limit {
+		// Leave the last processed block unchanged, so the game is retried with the next scheduled block.
+		c.logger.Debug("Not scheduling game, game type concurrency limit reached", "game", game.Proxy, "gameType", game.GameType, "limit", limit)
+		return nil, nil
+	}
 	state.inflight = true
+	c.gameTypeInflight[game.GameType]++
 	return newJob(blockNumber, game.Proxy, state.player, state.status), nil
 }
 
+// skipInvalidGame marks the game as skipped if err indicates it can never be honestly played.
+// Returns true if the game was skipped.
+func (c *coordinator) skipInvalidGame(state *gameState, game types.GameMetadata, err error, blockNumber uint64) bool {
+	reason, ok := types.InvalidGameReason(err)
+	if !ok {
+		return false
+	}
+	c.logger.Warn("Skipping invalid game", "game", game.Proxy, "reason", reason, "err", err)
+	c.m.RecordGameSkipped(reason)
+	state.skipped = true
+	state.lastProcessedBlockNum = blockNumber
+	return true
+}
+
 func (c *coordinator) enqueueJob(ctx context.Context, j job) error {
 	for {
 		select {
@@ -173,8 +327,13 @@ func (c *coordinator) processResult(j job) error {
 		return fmt.Errorf("game %v received unexpected result: %w", j.addr, errUnknownGame)
 	}
 	state.inflight = false
+	c.gameTypeInflight[state.gameType]--
 	state.status = j.status
 	state.lastProcessedBlockNum = j.block
+	if c.urgentClockThreshold > 0 {
+		state.urgency = j.urgency
+		state.progressedAt = c.clock.Now()
+	}
 	c.deleteResolvedGameFiles()
 	c.m.RecordGameUpdateCompleted()
 	return nil
@@ -192,7 +351,7 @@ func (c *coordinator) deleteResolvedGameFiles() {
 	}
 }
 
-func newCoordinator(logger log.Logger, m CoordinatorMetricer, jobQueue chan<- job, resultQueue <-chan job, createPlayer PlayerCreator, disk DiskManager, allowInvalidPrestate bool) *coordinator {
+func newCoordinator(logger log.Logger, m CoordinatorMetricer, jobQueue chan<- job, resultQueue <-chan job, createPlayer PlayerCreator, disk DiskManager, gameTypeLimits map[uint32]uint, urgentClockThreshold time.Duration, clock ClockReader, allowInvalidPrestate bool) *coordinator {
 	return &coordinator{
 		logger:               logger,
 		m:                    m,
@@ -201,6 +360,10 @@ func newCoordinator(logger log.Logger, m CoordinatorMetricer, jobQueue chan<- jo
 		createPlayer:         createPlayer,
 		disk:                 disk,
 		states:               make(map[common.Address]*gameState),
+		gameTypeLimits:       gameTypeLimits,
+		gameTypeInflight:     make(map[uint32]uint),
+		urgentClockThreshold: urgentClockThreshold,
+		clock:                clock,
 		allowInvalidPrestate: allowInvalidPrestate,
 	}
 }
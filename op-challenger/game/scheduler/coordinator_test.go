@@ -3,11 +3,14 @@ package scheduler
 import (
 	"context"
 	"fmt"
+	"math/big"
 	"slices"
 	"testing"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler/test"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
@@ -49,6 +52,92 @@ func TestSkipSchedulingInflightGames(t *testing.T) {
 	require.Len(t, workQueue, 1, "should not reschedule in-flight game")
 }
 
+func TestScheduleWithGameTypeLimits(t *testing.T) {
+	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 10)
+	c.gameTypeLimits = map[uint32]uint{1: 1}
+	limited1 := types.GameMetadata{GameType: 1, Proxy: common.Address{0xaa}}
+	limited2 := types.GameMetadata{GameType: 1, Proxy: common.Address{0xbb}}
+	unlimited1 := types.GameMetadata{GameType: 2, Proxy: common.Address{0xcc}}
+	unlimited2 := types.GameMetadata{GameType: 2, Proxy: common.Address{0xdd}}
+	games := []types.GameMetadata{limited1, limited2, unlimited1, unlimited2}
+	ctx := context.Background()
+
+	require.NoError(t, c.schedule(ctx, games, 1))
+	require.Len(t, workQueue, 3, "should only schedule one game of limited type")
+	var jobs []job
+	for len(workQueue) > 0 {
+		jobs = append(jobs, <-workQueue)
+	}
+	require.Equal(t, limited1.Proxy, jobs[0].addr)
+	require.Equal(t, uint64(0), c.states[limited2.Proxy].lastProcessedBlockNum, "should not mark skipped game as processed")
+
+	// Still at the limit while the first game is in-flight
+	require.NoError(t, c.schedule(ctx, games, 2))
+	require.Empty(t, workQueue)
+
+	// Schedules the skipped game once the first completes
+	require.NoError(t, c.processResult(jobs[0]))
+	require.NoError(t, c.schedule(ctx, games, 3))
+	require.Len(t, workQueue, 1)
+	require.Equal(t, limited2.Proxy, (<-workQueue).addr, "should prioritise game that waited longest")
+}
+
+func TestScheduleByUrgency(t *testing.T) {
+	c, workQueue, _, games, _, _ := setupCoordinatorTest(t, 10)
+	cl := clock.NewDeterministicClock(time.Unix(10000, 0))
+	c.urgentClockThreshold = time.Hour
+	c.clock = cl
+	relaxed := common.Address{0xaa}
+	urgentSmallBond := common.Address{0xbb}
+	urgentLargeBond := common.Address{0xcc}
+	soonest := common.Address{0xdd}
+	gameAddrs := asGames(relaxed, urgentSmallBond, urgentLargeBond, soonest)
+	ctx := context.Background()
+	progress := func() {
+		for len(workQueue) > 0 {
+			j := <-workQueue
+			j.urgency = games.created[j.addr].Urgency()
+			require.NoError(t, c.processResult(j))
+		}
+	}
+	scheduled := func() []common.Address {
+		var addrs []common.Address
+		for len(workQueue) > 0 {
+			addrs = append(addrs, (<-workQueue).addr)
+		}
+		return addrs
+	}
+
+	// Games that haven't been progressed yet are all urgent
+	require.NoError(t, c.schedule(ctx, gameAddrs, 1))
+	require.Len(t, workQueue, 4)
+	now := cl.Now()
+	games.created[relaxed].UrgencyValue = types.GameUrgency{Deadline: now.Add(3 * time.Hour), BondAtRisk: big.NewInt(100)}
+	games.created[urgentSmallBond].UrgencyValue = types.GameUrgency{Deadline: now.Add(30 * time.Minute), BondAtRisk: big.NewInt(1)}
+	games.created[urgentLargeBond].UrgencyValue = types.GameUrgency{Deadline: now.Add(30 * time.Minute), BondAtRisk: big.NewInt(5)}
+	games.created[soonest].UrgencyValue = types.GameUrgency{Deadline: now.Add(10 * time.Minute), BondAtRisk: big.NewInt(1)}
+	progress()
+
+	// Urgent games are ordered by deadline then bond at risk, deferring the game that isn't urgent
+	require.NoError(t, c.schedule(ctx, gameAddrs, 2))
+	require.Equal(t, []common.Address{soonest, urgentLargeBond, urgentSmallBond}, scheduled())
+	require.Equal(t, uint64(1), c.states[relaxed].lastProcessedBlockNum, "should not mark deferred game as processed")
+
+	// Games that aren't urgent are progressed once no urgent games are waiting
+	require.NoError(t, c.schedule(ctx, gameAddrs, 3))
+	require.Equal(t, []common.Address{relaxed}, scheduled())
+	require.NoError(t, c.processResult(job{addr: relaxed, block: 3, urgency: games.created[relaxed].Urgency()}))
+
+	// The game becomes urgent again when it hasn't been progressed within the threshold
+	cl.AdvanceTime(30 * time.Minute)
+	for _, addr := range []common.Address{urgentSmallBond, urgentLargeBond, soonest} {
+		require.NoError(t, c.processResult(job{addr: addr, block: 3}))
+	}
+	cl.AdvanceTime(45 * time.Minute)
+	require.NoError(t, c.schedule(ctx, gameAddrs, 4))
+	require.Equal(t, []common.Address{relaxed}, scheduled(), "should progress game with stale urgency")
+}
+
 func TestExitWhenContextDoneWhileSchedulingJob(t *testing.T) {
 	// No space in buffer to schedule a job
 	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 0)
@@ -63,13 +152,41 @@ func TestExitWhenContextDoneWhileSchedulingJob(t *testing.T) {
 }
 
 func TestSchedule_PrestateValidationErrors(t *testing.T) {
-	c, _, _, games, _, _ := setupCoordinatorTest(t, 10)
+	c, workQueue, _, games, _, _ := setupCoordinatorTest(t, 10)
 	games.PrestateErr = types.ErrInvalidPrestate
 	gameAddr1 := common.Address{0xaa}
 	ctx := context.Background()
 
 	err := c.schedule(ctx, asGames(gameAddr1), 0)
-	require.Error(t, err)
+	require.NoError(t, err)
+	require.Empty(t, workQueue, "should not schedule game with invalid prestate")
+	require.Equal(t, []string{"invalid_prestate"}, c.m.(*stubSchedulerMetrics).skippedGames)
+
+	// Skipped games are not validated or scheduled again
+	games.PrestateErr = nil
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr1), 1))
+	require.Empty(t, workQueue, "should not schedule skipped game")
+	require.Len(t, c.m.(*stubSchedulerMetrics).skippedGames, 1, "should only record skipped game once")
+	require.Equal(t, uint64(1), c.m.(*stubSchedulerMetrics).actedL1Blocks, "skipped game should not hold back acted L1 block")
+}
+
+func TestSchedule_SkipInvalidGames(t *testing.T) {
+	c, workQueue, _, games, _, logs := setupCoordinatorTest(t, 10)
+	gameAddr1 := common.Address{0xaa}
+	gameAddr2 := common.Address{0xbb}
+	ctx := context.Background()
+	games.creationErrs = map[common.Address]error{
+		gameAddr1: fmt.Errorf("prestate: %w", types.ErrUnknownPrestate),
+		gameAddr2: fmt.Errorf("block: %w", types.ErrInvalidL2BlockNumber),
+	}
+
+	err := c.schedule(ctx, asGames(gameAddr1, gameAddr2), 0)
+	require.NoError(t, err)
+	require.Empty(t, workQueue, "should not schedule invalid games")
+	require.Equal(t, []string{"unknown_prestate", "invalid_l2_block_number"}, c.m.(*stubSchedulerMetrics).skippedGames)
+	warnLog := logs.FindLog(testlog.NewLevelFilter(log.LevelWarn), testlog.NewMessageFilter("Skipping invalid game"))
+	require.NotNil(t, warnLog)
+	require.Equal(t, gameAddr1, warnLog.AttrValue("game"))
 }
 
 func TestSchedule_SkipPrestateValidationErrors(t *testing.T) {
@@ -401,7 +518,7 @@ func setupCoordinatorTest(t *testing.T, bufferSize int) (*coordinator, <-chan jo
 		created: make(map[common.Address]*test.StubGamePlayer),
 	}
 	disk := &stubDiskManager{gameDirExists: make(map[common.Address]bool)}
-	c := newCoordinator(logger, &stubSchedulerMetrics{}, workQueue, resultQueue, games.CreateGame, disk, false)
+	c := newCoordinator(logger, &stubSchedulerMetrics{}, workQueue, resultQueue, games.CreateGame, disk, nil, 0, nil, false)
 	return c, workQueue, resultQueue, games, disk, logs
 }
 
@@ -409,6 +526,7 @@ type createdGames struct {
 	t               *testing.T
 	createCompleted common.Address
 	creationFails   common.Address
+	creationErrs    map[common.Address]error
 	created         map[common.Address]*test.StubGamePlayer
 	PrestateErr     error
 }
@@ -418,6 +536,9 @@ func (c *createdGames) CreateGame(fdg types.GameMetadata, dir string) (GamePlaye
 	if c.creationFails == addr {
 		return nil, fmt.Errorf("refusing to create player for game: %v", addr)
 	}
+	if err, ok := c.creationErrs[addr]; ok {
+		return nil, err
+	}
 	if _, exists := c.created[addr]; exists {
 		c.t.Fatalf("game %v already exists", addr)
 	}
@@ -439,6 +560,7 @@ func (c *createdGames) CreateGame(fdg types.GameMetadata, dir string) (GamePlaye
 
 type stubSchedulerMetrics struct {
 	actedL1Blocks uint64
+	skippedGames  []string
 }
 
 func (s *stubSchedulerMetrics) RecordActedL1Block(n uint64) {
@@ -449,6 +571,10 @@ func (s *stubSchedulerMetrics) RecordGamesStatus(_, _, _ int) {}
 func (s *stubSchedulerMetrics) RecordGameUpdateScheduled()    {}
 func (s *stubSchedulerMetrics) RecordGameUpdateCompleted()    {}
 
+func (s *stubSchedulerMetrics) RecordGameSkipped(reason string) {
+	s.skippedGames = append(s.skippedGames, reason)
+}
+
 type stubDiskManager struct {
 	gameDirExists map[common.Address]bool
 	deletedDirs   []common.Address
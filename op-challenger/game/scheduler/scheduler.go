@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"sync"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum/go-ethereum/log"
@@ -16,6 +17,7 @@ type SchedulerMetricer interface {
 	RecordGamesStatus(inProgress, defenderWon, challengerWon int)
 	RecordGameUpdateScheduled()
 	RecordGameUpdateCompleted()
+	RecordGameSkipped(reason string)
 	IncActiveExecutors()
 	DecActiveExecutors()
 	IncIdleExecutors()
@@ -39,7 +41,10 @@ type Scheduler struct {
 	cancel         func()
 }
 
-func NewScheduler(logger log.Logger, m SchedulerMetricer, disk DiskManager, maxConcurrency uint, createPlayer PlayerCreator, allowInvalidPrestate bool) *Scheduler {
+// NewScheduler creates a Scheduler that progresses games on up to maxConcurrency workers.
+// gameTypeLimits optionally limits the number of games of a game type that are progressed concurrently.
+// urgentClockThreshold optionally prioritises games with a chess clock expiring within the threshold, according to clock.
+func NewScheduler(logger log.Logger, m SchedulerMetricer, disk DiskManager, maxConcurrency uint, gameTypeLimits map[uint32]uint, urgentClockThreshold time.Duration, clock ClockReader, createPlayer PlayerCreator, allowInvalidPrestate bool) *Scheduler {
 	// Size job and results queues to be fairly small so backpressure is applied early
 	// but with enough capacity to keep the workers busy
 	jobQueue := make(chan job, maxConcurrency*2)
@@ -52,7 +57,7 @@ func NewScheduler(logger log.Logger, m SchedulerMetricer, disk DiskManager, maxC
 	return &Scheduler{
 		logger:         logger,
 		m:              m,
-		coordinator:    newCoordinator(logger, m, jobQueue, resultQueue, createPlayer, disk, allowInvalidPrestate),
+		coordinator:    newCoordinator(logger, m, jobQueue, resultQueue, createPlayer, disk, gameTypeLimits, urgentClockThreshold, clock, allowInvalidPrestate),
 		maxConcurrency: maxConcurrency,
 		scheduleQueue:  scheduleQueue,
 		jobQueue:       jobQueue,
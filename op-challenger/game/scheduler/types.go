@@ -14,6 +14,12 @@ type GamePlayer interface {
 	Status() types.GameStatus
 }
 
+// UrgencyReporter is optionally implemented by a GamePlayer to report how urgently the game needs to be progressed.
+type UrgencyReporter interface {
+	// Urgency returns the urgency of the game as of the last time it was progressed.
+	Urgency() types.GameUrgency
+}
+
 type DiskManager interface {
 	DirForGame(addr common.Address) string
 	RemoveAllExcept(addrs []common.Address) error
@@ -24,6 +30,8 @@ type job struct {
 	addr   common.Address
 	player GamePlayer
 	status types.GameStatus
+	// urgency is the urgency reported by the player after progressing the game.
+	urgency types.GameUrgency
 }
 
 func newJob(block uint64, addr common.Address, player GamePlayer, status types.GameStatus) *job {
@@ -17,6 +17,9 @@ func progressGames(ctx context.Context, in <-chan job, out chan<- job, wg *sync.
 		case j := <-in:
 			threadActive()
 			j.status = j.player.ProgressGame(ctx)
+			if reporter, ok := j.player.(UrgencyReporter); ok {
+				j.urgency = reporter.Urgency()
+			}
 			out <- j
 			threadIdle()
 		}
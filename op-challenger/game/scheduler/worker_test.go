@@ -2,6 +2,7 @@ package scheduler
 
 import (
 	"context"
+	"math/big"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -25,8 +26,9 @@ func TestWorkerShouldProcessJobsUntilContextDone(t *testing.T) {
 	wg.Add(1)
 	go progressGames(ctx, in, out, &wg, ms.ThreadActive, ms.ThreadIdle)
 
+	urgency := types.GameUrgency{Deadline: time.Unix(1000, 0), BondAtRisk: big.NewInt(5)}
 	in <- job{
-		player: &test.StubGamePlayer{StatusValue: types.GameStatusInProgress},
+		player: &test.StubGamePlayer{StatusValue: types.GameStatusInProgress, UrgencyValue: urgency},
 	}
 	waitErr := wait.For(context.Background(), 100*time.Millisecond, func() (bool, error) {
 		return ms.activeCalls.Load() >= 1, nil
@@ -50,6 +52,7 @@ func TestWorkerShouldProcessJobsUntilContextDone(t *testing.T) {
 
 	require.Equal(t, result1.status, types.GameStatusInProgress)
 	require.Equal(t, result2.status, types.GameStatusDefenderWon)
+	require.Equal(t, urgency, result1.urgency, "should record urgency reported by player")
 
 	// Cancel the context which should exit the worker
 	cancel()
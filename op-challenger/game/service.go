@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"path/filepath"
 	"sync/atomic"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak"
@@ -15,10 +16,12 @@ import (
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/api"
 	"github.com/ethereum-optimism/optimism/op-challenger/config"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/claims"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/gamedb"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/registry"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler"
 	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
@@ -33,6 +36,10 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 )
 
+// gameDBDir is the directory in the datadir the game database is stored in.
+// It must not start with the game directory prefix, or it would be removed with the data of resolved games.
+const gameDBDir = "gamedb"
+
 type Service struct {
 	logger  log.Logger
 	metrics metrics.Metricer
@@ -50,7 +57,9 @@ type Service struct {
 	l1Clock     *clock.SimpleClock
 
 	claimants []common.Address
-	claimer   *claims.BondClaimScheduler
+	// bondClaimants are the claimants to claim bonds for, which differ from claimants if the bond claimant is overridden.
+	bondClaimants []common.Address
+	claimer       *claims.BondClaimScheduler
 
 	factoryContract *contracts.DisputeGameFactoryContract
 	registry        *registry.GameTypeRegistry
@@ -63,6 +72,11 @@ type Service struct {
 	pprofService *oppprof.Service
 	metricsSrv   *httputil.HTTPServer
 
+	tracker     *api.Tracker
+	gameDB      *gamedb.GameDB
+	gameTracker *persistentTracker
+	apiServer   *api.Server
+
 	balanceMetricer io.Closer
 
 	stopped atomic.Bool
@@ -90,6 +104,10 @@ func (s *Service) initFromConfig(ctx context.Context, cfg *config.Config) error
 		return fmt.Errorf("failed to init tx manager: %w", err)
 	}
 	s.initClaimants(cfg)
+	s.tracker = api.NewTracker(s.claimants)
+	if err := s.initGameDB(cfg); err != nil {
+		return fmt.Errorf("failed to init game db: %w", err)
+	}
 	if err := s.initL1Client(ctx, cfg); err != nil {
 		return fmt.Errorf("failed to init l1 client: %w", err)
 	}
@@ -122,6 +140,9 @@ func (s *Service) initFromConfig(ctx context.Context, cfg *config.Config) error
 	}
 
 	s.initMonitor(cfg)
+	if err := s.initAPIServer(cfg); err != nil {
+		return fmt.Errorf("failed to init api server: %w", err)
+	}
 
 	s.metrics.RecordInfo(version.SimpleWithMeta)
 	s.metrics.RecordUp()
@@ -131,6 +152,21 @@ func (s *Service) initFromConfig(ctx context.Context, cfg *config.Config) error
 func (s *Service) initClaimants(cfg *config.Config) {
 	claimants := []common.Address{s.txSender.From()}
 	s.claimants = append(claimants, cfg.AdditionalBondClaimants...)
+	s.bondClaimants = s.claimants
+	if cfg.BondClaimant != (common.Address{}) {
+		s.logger.Info("Claiming bonds for configured bond claimant instead of the tx sender", "claimant", cfg.BondClaimant)
+		s.bondClaimants = append([]common.Address{cfg.BondClaimant}, cfg.AdditionalBondClaimants...)
+	}
+}
+
+func (s *Service) initGameDB(cfg *config.Config) error {
+	db, err := gamedb.NewGameDB(s.logger, filepath.Join(cfg.Datadir, gameDBDir))
+	if err != nil {
+		return fmt.Errorf("failed to open game db: %w", err)
+	}
+	s.gameDB = db
+	s.gameTracker = newPersistentTracker(s.logger, s.tracker, db)
+	return nil
 }
 
 func (s *Service) initTxManager(ctx context.Context, cfg *config.Config) error {
@@ -139,12 +175,17 @@ func (s *Service) initTxManager(ctx context.Context, cfg *config.Config) error {
 		return fmt.Errorf("failed to create the transaction manager: %w", err)
 	}
 	s.txMgr = txMgr
-	s.txSender = sender.NewTxSender(ctx, s.logger, txMgr, cfg.MaxPendingTx)
+	var batcher *txmgr.Multicall3Batcher
+	if cfg.MulticallAddress != (common.Address{}) {
+		batcher = txmgr.NewMulticall3Batcher(cfg.MulticallAddress, int(cfg.MulticallMaxCalls))
+	}
+	s.txSender = sender.NewTxSender(ctx, s.logger, txMgr, cfg.MaxPendingTx, batcher)
 	return nil
 }
 
 func (s *Service) initL1Client(ctx context.Context, cfg *config.Config) error {
-	l1Client, err := dial.DialEthClientWithTimeout(ctx, dial.DefaultDialTimeout, s.logger, cfg.L1EthRpc)
+	l1Client, err := dial.DialEthClientWithTimeout(ctx, dial.DefaultDialTimeout, s.logger, cfg.L1EthRpc,
+		client.CircuitBreakerDialOptions(s.logger, cfg.L1EthRpc, cfg.L1CircuitBreaker, s.metrics)...)
 	if err != nil {
 		return fmt.Errorf("failed to dial L1: %w", err)
 	}
@@ -169,6 +210,7 @@ func (s *Service) initPProf(cfg *oppprof.CLIConfig) error {
 		cfg.ProfileType,
 		cfg.ProfileDir,
 		cfg.ProfileFilename,
+		cfg.Upload,
 	)
 
 	if err := s.pprofService.Start(); err != nil {
@@ -197,6 +239,23 @@ func (s *Service) initMetricsServer(cfg *opmetrics.CLIConfig) error {
 	return nil
 }
 
+func (s *Service) initAPIServer(cfg *config.Config) error {
+	if !cfg.APIEnabled {
+		return nil
+	}
+	s.logger.Debug("starting api server", "addr", cfg.APIListenAddr, "port", cfg.APIListenPort)
+	var registrar api.GameRegistrar
+	if cfg.APIGameRegistration {
+		registrar = s.monitor
+	}
+	apiServer := api.NewServer(s.logger, cfg.APIListenAddr, cfg.APIListenPort, s.tracker, s.txSender, registrar)
+	if err := apiServer.Start(); err != nil {
+		return err
+	}
+	s.apiServer = apiServer
+	return nil
+}
+
 func (s *Service) initFactoryContract(cfg *config.Config) error {
 	factoryContract := contracts.NewDisputeGameFactoryContract(s.metrics, cfg.GameFactoryAddress,
 		batching.NewMultiCaller(s.l1Client.Client(), batching.DefaultBatchSize))
@@ -205,7 +264,7 @@ func (s *Service) initFactoryContract(cfg *config.Config) error {
 }
 
 func (s *Service) initBondClaims() error {
-	claimer := claims.NewBondClaimer(s.logger, s.metrics, s.registry.CreateBondContract, s.txSender, s.claimants...)
+	claimer := claims.NewBondClaimer(s.logger, s.metrics, s.registry.CreateBondContract, s.txSender, s.bondClaimants...)
 	s.claimer = claims.NewBondClaimScheduler(s.logger, s.metrics, claimer)
 	return nil
 }
@@ -226,7 +285,7 @@ func (s *Service) registerGameTypes(ctx context.Context, cfg *config.Config) err
 	gameTypeRegistry := registry.NewGameTypeRegistry()
 	oracles := registry.NewOracleRegistry()
 	caller := batching.NewMultiCaller(s.l1Client.Client(), batching.DefaultBatchSize)
-	closer, err := fault.RegisterGameTypes(ctx, s.systemClock, s.l1Clock, s.logger, s.metrics, cfg, gameTypeRegistry, oracles, s.rollupClient, s.txSender, s.factoryContract, caller, s.l1Client, cfg.SelectiveClaimResolution, s.claimants)
+	closer, err := fault.RegisterGameTypes(ctx, s.systemClock, s.l1Clock, s.logger, s.metrics, cfg, gameTypeRegistry, oracles, s.rollupClient, s.txSender, s.factoryContract, caller, s.l1Client, cfg.SelectiveClaimResolution, s.claimants, s.gameTracker)
 	if err != nil {
 		return err
 	}
@@ -238,7 +297,11 @@ func (s *Service) registerGameTypes(ctx context.Context, cfg *config.Config) err
 
 func (s *Service) initScheduler(cfg *config.Config) error {
 	disk := newDiskManager(cfg.Datadir)
-	s.sched = scheduler.NewScheduler(s.logger, s.metrics, disk, cfg.MaxConcurrency, s.registry.CreatePlayer, cfg.AllowInvalidPrestate)
+	gameTypeLimits := make(map[uint32]uint)
+	for traceType, limit := range cfg.TraceTypeMaxConcurrency {
+		gameTypeLimits[uint32(cfg.GameType(traceType))] = limit
+	}
+	s.sched = scheduler.NewScheduler(s.logger, s.metrics, disk, cfg.MaxConcurrency, gameTypeLimits, cfg.UrgentClockThreshold, s.l1Clock, s.registry.CreatePlayer, cfg.AllowInvalidPrestate)
 	return nil
 }
 
@@ -251,7 +314,7 @@ func (s *Service) initLargePreimages() error {
 }
 
 func (s *Service) initMonitor(cfg *config.Config) {
-	s.monitor = newGameMonitor(s.logger, s.l1Clock, s.factoryContract, s.sched, s.preimages, cfg.GameWindow, s.claimer, cfg.GameAllowlist, s.pollClient)
+	s.monitor = newGameMonitor(s.logger, s.l1Clock, s.factoryContract, s.sched, s.preimages, cfg.GameWindow, s.claimer, s.gameTracker, cfg.GameAllowlist, s.pollClient)
 }
 
 func (s *Service) Start(ctx context.Context) error {
@@ -289,6 +352,11 @@ func (s *Service) Stop(ctx context.Context) error {
 	if s.faultGamesCloser != nil {
 		s.faultGamesCloser()
 	}
+	if s.gameDB != nil {
+		if err := s.gameDB.Close(); err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to close game db: %w", err))
+		}
+	}
 	if s.pprofService != nil {
 		if err := s.pprofService.Stop(ctx); err != nil {
 			result = errors.Join(result, fmt.Errorf("failed to close pprof server: %w", err))
@@ -318,6 +386,11 @@ func (s *Service) Stop(ctx context.Context) error {
 			result = errors.Join(result, fmt.Errorf("failed to close metrics server: %w", err))
 		}
 	}
+	if s.apiServer != nil {
+		if err := s.apiServer.Stop(ctx); err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to close api server: %w", err))
+		}
+	}
 	s.stopped.Store(true)
 	s.logger.Info("stopped challenger game service", "err", result)
 	return result
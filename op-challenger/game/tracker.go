@@ -0,0 +1,84 @@
+package game
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/api"
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/gamedb"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+)
+
+// persistentTracker records the challenger's view of the games it plays in the API tracker,
+// and persists it in the game database so it can resume after a restart.
+type persistentTracker struct {
+	*api.Tracker
+	logger log.Logger
+	db     *gamedb.GameDB
+}
+
+// newPersistentTracker restores the games recorded in db into tracker and returns the tracker to record updates with.
+func newPersistentTracker(logger log.Logger, tracker *api.Tracker, db *gamedb.GameDB) *persistentTracker {
+	records := db.Games()
+	for _, record := range records {
+		tracker.RestoreGame(record.Metadata, record.Status, record.Claims, record.AgreeWithRootClaim)
+		if pending := pendingMoves(record.Moves); pending > 0 {
+			logger.Info("Resuming game with moves that were not confirmed before restart", "game", record.Metadata.Proxy, "moves", pending)
+		}
+	}
+	if len(records) > 0 {
+		logger.Info("Restored games from game database", "games", len(records))
+	}
+	return &persistentTracker{Tracker: tracker, logger: logger, db: db}
+}
+
+func pendingMoves(moves []gamedb.Move) int {
+	var pending int
+	for _, move := range moves {
+		if !move.Posted {
+			pending++
+		}
+	}
+	return pending
+}
+
+func (t *persistentTracker) TrackGames(games []types.GameMetadata) {
+	t.Tracker.TrackGames(games)
+	if err := t.db.TrackGames(games); err != nil {
+		t.logger.Error("Failed to persist tracked games", "err", err)
+	}
+}
+
+func (t *persistentTracker) UpdateStatus(addr common.Address, status types.GameStatus) {
+	t.Tracker.UpdateStatus(addr, status)
+	if err := t.db.UpdateStatus(addr, status); err != nil {
+		t.logger.Error("Failed to persist game status", "game", addr, "err", err)
+	}
+}
+
+func (t *persistentTracker) UpdateClaims(addr common.Address, claims []faultTypes.Claim, agreeWithRootClaim bool) {
+	t.Tracker.UpdateClaims(addr, claims, agreeWithRootClaim)
+	if err := t.db.UpdateClaims(addr, claims, agreeWithRootClaim); err != nil {
+		t.logger.Error("Failed to persist game claims", "game", addr, "err", err)
+	}
+}
+
+func (t *persistentTracker) KnownStatus(addr common.Address) (types.GameStatus, bool) {
+	record, ok := t.db.Game(addr)
+	return record.Status, ok
+}
+
+func (t *persistentTracker) KnownAgreeWithRootClaim(addr common.Address) (bool, bool) {
+	record, ok := t.db.Game(addr)
+	if !ok || record.AgreeWithRootClaim == nil {
+		return false, false
+	}
+	return *record.AgreeWithRootClaim, true
+}
+
+func (t *persistentTracker) RecordMove(addr common.Address, action faultTypes.Action, posted bool) {
+	if err := t.db.RecordMove(addr, action, posted); err != nil {
+		t.logger.Error("Failed to persist game move", "game", addr, "err", err)
+	}
+}
@@ -0,0 +1,50 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/api"
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/gamedb"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestPersistentTracker_RestoresGames(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	dir := t.TempDir()
+	resolved := types.GameMetadata{Index: 1, Proxy: common.Address{0x01}}
+	inProgress := types.GameMetadata{Index: 2, Proxy: common.Address{0x02}}
+
+	db, err := gamedb.NewGameDB(logger, dir)
+	require.NoError(t, err)
+	tracker := newPersistentTracker(logger, api.NewTracker(nil), db)
+	tracker.TrackGames([]types.GameMetadata{resolved, inProgress})
+	tracker.UpdateStatus(resolved.Proxy, types.GameStatusDefenderWon)
+	tracker.UpdateClaims(inProgress.Proxy, []faultTypes.Claim{}, false)
+	require.NoError(t, db.Close())
+
+	db, err = gamedb.NewGameDB(logger, dir)
+	require.NoError(t, err)
+	defer db.Close()
+	apiTracker := api.NewTracker(nil)
+	tracker = newPersistentTracker(logger, apiTracker, db)
+	require.Len(t, apiTracker.Games(), 2, "should restore games into the api tracker")
+
+	status, ok := tracker.KnownStatus(resolved.Proxy)
+	require.True(t, ok)
+	require.Equal(t, types.GameStatusDefenderWon, status)
+	_, ok = tracker.KnownAgreeWithRootClaim(resolved.Proxy)
+	require.False(t, ok, "root claim of resolved game was not evaluated")
+
+	agree, ok := tracker.KnownAgreeWithRootClaim(inProgress.Proxy)
+	require.True(t, ok)
+	require.False(t, agree)
+
+	_, ok = tracker.KnownStatus(common.Address{0x03})
+	require.False(t, ok)
+}
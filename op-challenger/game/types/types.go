@@ -3,11 +3,34 @@ package types
 import (
 	"errors"
 	"fmt"
+	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 )
 
-var ErrInvalidPrestate = errors.New("absolute prestate does not match")
+var (
+	ErrInvalidPrestate = errors.New("absolute prestate does not match")
+	// ErrUnknownPrestate indicates the absolute prestate of the game is not one of the available prestates.
+	ErrUnknownPrestate = errors.New("unknown absolute prestate")
+	// ErrInvalidL2BlockNumber indicates the game claims an output root for an L2 block that can't be disputed.
+	ErrInvalidL2BlockNumber = errors.New("invalid L2 block number")
+)
+
+// InvalidGameReason returns the reason the game can never be honestly played, if err indicates it.
+// The reason is suitable for use as a metric label.
+func InvalidGameReason(err error) (string, bool) {
+	switch {
+	case errors.Is(err, ErrInvalidPrestate):
+		return "invalid_prestate", true
+	case errors.Is(err, ErrUnknownPrestate):
+		return "unknown_prestate", true
+	case errors.Is(err, ErrInvalidL2BlockNumber):
+		return "invalid_l2_block_number", true
+	default:
+		return "", false
+	}
+}
 
 type GameStatus uint8
 
@@ -45,3 +68,12 @@ type GameMetadata struct {
 	Timestamp uint64
 	Proxy     common.Address
 }
+
+// GameUrgency describes how urgently a game needs to be progressed for the honest actor not to lose bonds.
+// The zero GameUrgency means no chess clock of the honest actor is running.
+type GameUrgency struct {
+	// Deadline is when the earliest running chess clock of the honest actor expires.
+	Deadline time.Time
+	// BondAtRisk is the bond lost if the claim with the earliest deadline isn't countered in time.
+	BondAtRisk *big.Int
+}
@@ -2,8 +2,10 @@ package metrics
 
 import (
 	"io"
+	"math/big"
 	"time"
 
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/httputil"
 	"github.com/ethereum-optimism/optimism/op-service/sources/caching"
 	"github.com/ethereum/go-ethereum/common"
@@ -33,6 +35,9 @@ type Metricer interface {
 	// Record contract metrics
 	contractMetrics.ContractMetricer
 
+	// Record L1 RPC circuit breaker metrics
+	opmetrics.CircuitBreakerMetricer
+
 	RecordActedL1Block(n uint64)
 
 	RecordGameStep()
@@ -47,12 +52,14 @@ type Metricer interface {
 	RecordPreimageChallengeFailed()
 
 	RecordBondClaimFailed()
-	RecordBondClaimed(amount uint64)
+	RecordBondClaimed(amount *big.Int)
+	RecordBondsClaimable(amount *big.Int)
 
 	RecordGamesStatus(inProgress, defenderWon, challengerWon int)
 
 	RecordGameUpdateScheduled()
 	RecordGameUpdateCompleted()
+	RecordGameSkipped(reason string)
 
 	RecordLargePreimageCount(count int)
 
@@ -73,6 +80,7 @@ type Metrics struct {
 	txmetrics.TxMetrics
 	*opmetrics.CacheMetrics
 	*contractMetrics.ContractMetrics
+	opmetrics.CircuitBreakerMetrics
 
 	info prometheus.GaugeVec
 	up   prometheus.Gauge
@@ -81,6 +89,8 @@ type Metrics struct {
 
 	bondClaimFailures prometheus.Counter
 	bondsClaimed      prometheus.Counter
+	bondsClaimedEth   prometheus.Counter
+	bondsClaimable    prometheus.Gauge
 
 	preimageChallenged      prometheus.Counter
 	preimageChallengeFailed prometheus.Counter
@@ -99,6 +109,7 @@ type Metrics struct {
 
 	trackedGames  prometheus.GaugeVec
 	inflightGames prometheus.Gauge
+	skippedGames  prometheus.CounterVec
 }
 
 func (m *Metrics) Registry() *prometheus.Registry {
@@ -122,6 +133,8 @@ func NewMetrics() *Metrics {
 
 		ContractMetrics: contractMetrics.MakeContractMetrics(Namespace, factory),
 
+		CircuitBreakerMetrics: opmetrics.MakeCircuitBreakerMetrics(Namespace, factory),
+
 		info: *factory.NewGaugeVec(prometheus.GaugeOpts{
 			Namespace: Namespace,
 			Name:      "info",
@@ -195,6 +208,16 @@ func NewMetrics() *Metrics {
 			Name:      "bonds",
 			Help:      "Number of bonds claimed by the challenge agent",
 		}),
+		bondsClaimedEth: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "bonds_claimed_eth",
+			Help:      "Value of the bonds claimed by the challenge agent in ETH",
+		}),
+		bondsClaimable: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "bonds_claimable",
+			Help:      "Value of unclaimed credit in resolved games for the configured claimants in ETH, including credit that is still locked",
+		}),
 		preimageChallenged: factory.NewCounter(prometheus.CounterOpts{
 			Namespace: Namespace,
 			Name:      "preimage_challenged",
@@ -227,6 +250,13 @@ func NewMetrics() *Metrics {
 			Name:      "inflight_games",
 			Help:      "Number of games being tracked by the challenger",
 		}),
+		skippedGames: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "skipped_games",
+			Help:      "Number of games skipped by the challenger because they can never be honestly played",
+		}, []string{
+			"reason",
+		}),
 	}
 }
 
@@ -286,8 +316,13 @@ func (m *Metrics) RecordBondClaimFailed() {
 	m.bondClaimFailures.Add(1)
 }
 
-func (m *Metrics) RecordBondClaimed(amount uint64) {
-	m.bondsClaimed.Add(float64(amount))
+func (m *Metrics) RecordBondClaimed(amount *big.Int) {
+	m.bondsClaimed.Add(1)
+	m.bondsClaimedEth.Add(eth.WeiToEther(amount))
+}
+
+func (m *Metrics) RecordBondsClaimable(amount *big.Int) {
+	m.bondsClaimable.Set(eth.WeiToEther(amount))
 }
 
 func (m *Metrics) RecordVmExecutionTime(vmType string, dur time.Duration) {
@@ -339,3 +374,7 @@ func (m *Metrics) RecordGameUpdateScheduled() {
 func (m *Metrics) RecordGameUpdateCompleted() {
 	m.inflightGames.Sub(1)
 }
+
+func (m *Metrics) RecordGameSkipped(reason string) {
+	m.skippedGames.WithLabelValues(reason).Inc()
+}
@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"io"
+	"math/big"
 	"time"
 
 	contractMetrics "github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts/metrics"
@@ -9,12 +10,14 @@ import (
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
 
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	txmetrics "github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
 )
 
 type NoopMetricsImpl struct {
 	txmetrics.NoopTxMetrics
 	contractMetrics.NoopMetrics
+	opmetrics.NoopCircuitBreakerMetrics
 }
 
 func (i *NoopMetricsImpl) StartBalanceMetrics(l log.Logger, client *ethclient.Client, account common.Address) io.Closer {
@@ -36,8 +39,9 @@ func (*NoopMetricsImpl) RecordPreimageChallenged()      {}
 func (*NoopMetricsImpl) RecordPreimageChallengeFailed() {}
 func (*NoopMetricsImpl) RecordLargePreimageCount(_ int) {}
 
-func (*NoopMetricsImpl) RecordBondClaimFailed()   {}
-func (*NoopMetricsImpl) RecordBondClaimed(uint64) {}
+func (*NoopMetricsImpl) RecordBondClaimFailed()        {}
+func (*NoopMetricsImpl) RecordBondClaimed(*big.Int)    {}
+func (*NoopMetricsImpl) RecordBondsClaimable(*big.Int) {}
 
 func (*NoopMetricsImpl) RecordVmExecutionTime(_ string, _ time.Duration) {}
 func (*NoopMetricsImpl) RecordVmMemoryUsed(_ string, _ uint64)           {}
@@ -48,6 +52,7 @@ func (*NoopMetricsImpl) RecordGamesStatus(inProgress, defenderWon, challengerWon
 
 func (*NoopMetricsImpl) RecordGameUpdateScheduled() {}
 func (*NoopMetricsImpl) RecordGameUpdateCompleted() {}
+func (*NoopMetricsImpl) RecordGameSkipped(string)   {}
 
 func (*NoopMetricsImpl) IncActiveExecutors() {}
 func (*NoopMetricsImpl) DecActiveExecutors() {}
@@ -0,0 +1,111 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// CheckedClaim is a claim with the value of the local trace at its position.
+type CheckedClaim struct {
+	types.Claim
+	// Bottom is true if the claim is in the bottom (execution trace) game.
+	Bottom bool
+	// TraceIndex is the trace index of the claim, relative to the start of its top or bottom game.
+	TraceIndex *big.Int
+	LocalValue common.Hash
+}
+
+func (c CheckedClaim) Agree() bool {
+	return c.Value == c.LocalValue
+}
+
+// ClaimCheck is the result of locally re-executing the trace for a claim and its ancestors.
+type ClaimCheck struct {
+	// Claims are the claims from the root claim to the checked claim.
+	Claims []CheckedClaim
+	// FirstDivergence and LastDivergence bound the first instruction of the bottom game at which the local trace
+	// diverges from the claims. They are equal if the instruction is known exactly, and nil if the local trace
+	// agrees with all bottom game claims.
+	FirstDivergence *big.Int
+	LastDivergence  *big.Int
+}
+
+// Claim returns the checked claim.
+func (c ClaimCheck) Claim() CheckedClaim {
+	return c.Claims[len(c.Claims)-1]
+}
+
+// CheckClaim compares the claim at claimIdx, and each of its ancestors, to the values of the local trace.
+func CheckClaim(ctx context.Context, game types.Game, accessor types.TraceAccessor, splitDepth types.Depth, claimIdx uint64) (ClaimCheck, error) {
+	claims := game.Claims()
+	if claimIdx >= uint64(len(claims)) {
+		return ClaimCheck{}, fmt.Errorf("claim %v does not exist, game has %v claims", claimIdx, len(claims))
+	}
+	var path []types.Claim
+	for claim := claims[claimIdx]; ; {
+		path = append(path, claim)
+		if claim.IsRoot() {
+			break
+		}
+		parent, err := game.GetParent(claim)
+		if err != nil {
+			return ClaimCheck{}, fmt.Errorf("failed to load parent of claim %v: %w", claim.ContractIndex, err)
+		}
+		claim = parent
+	}
+	slices.Reverse(path)
+
+	// The top game runs from depth 0 to split depth *inclusive*.
+	bottomDepth := game.MaxDepth() - splitDepth - 1
+	var result ClaimCheck
+	for _, claim := range path {
+		value, err := accessor.Get(ctx, game, claim, claim.Position)
+		if err != nil {
+			return ClaimCheck{}, fmt.Errorf("failed to compute local value of claim %v: %w", claim.ContractIndex, err)
+		}
+		checked := CheckedClaim{
+			Claim:      claim,
+			Bottom:     claim.Depth() > splitDepth,
+			LocalValue: value,
+		}
+		if checked.Bottom {
+			relativePos, err := claim.Position.RelativeToAncestorAtDepth(splitDepth + 1)
+			if err != nil {
+				return ClaimCheck{}, fmt.Errorf("failed to calculate relative position of claim %v: %w", claim.ContractIndex, err)
+			}
+			checked.TraceIndex = relativePos.TraceIndex(bottomDepth)
+		} else {
+			checked.TraceIndex = claim.TraceIndex(splitDepth)
+		}
+		result.Claims = append(result.Claims, checked)
+	}
+	result.FirstDivergence, result.LastDivergence = divergence(result.Claims)
+	return result, nil
+}
+
+// divergence finds the range of instructions that the first divergent instruction must be in.
+// The first divergent instruction comes after the latest agreed bottom game claim before the earliest disagreed one.
+// The absolute prestate is always agreed, so the range starts at the first instruction if there is no agreed claim.
+func divergence(claims []CheckedClaim) (*big.Int, *big.Int) {
+	var disagreed *big.Int
+	for _, claim := range claims {
+		if claim.Bottom && !claim.Agree() && (disagreed == nil || claim.TraceIndex.Cmp(disagreed) < 0) {
+			disagreed = claim.TraceIndex
+		}
+	}
+	if disagreed == nil {
+		return nil, nil
+	}
+	agreed := big.NewInt(-1)
+	for _, claim := range claims {
+		if claim.Bottom && claim.Agree() && claim.TraceIndex.Cmp(disagreed) < 0 && claim.TraceIndex.Cmp(agreed) > 0 {
+			agreed = claim.TraceIndex
+		}
+	}
+	return new(big.Int).Add(agreed, big.NewInt(1)), disagreed
+}
@@ -0,0 +1,94 @@
+package runner
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/alphabet"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckClaim(t *testing.T) {
+	maxDepth := types.Depth(4)
+	splitDepth := types.Depth(1)
+	provider := alphabet.NewTraceProvider(big.NewInt(0), maxDepth)
+	accessor := trace.NewSimpleTraceAccessor(provider)
+	invalid := common.Hash{0xaa}
+
+	// createClaims creates a chain of claims where each claim is a child of the previous one.
+	createClaims := func(t *testing.T, honest []bool, positions ...types.Position) []types.Claim {
+		claims := make([]types.Claim, 0, len(positions))
+		for i, pos := range positions {
+			value := invalid
+			if honest[i] {
+				var err error
+				value, err = provider.Get(context.Background(), pos)
+				require.NoError(t, err)
+			}
+			claims = append(claims, types.Claim{
+				ClaimData:           types.ClaimData{Value: value, Position: pos},
+				ContractIndex:       i,
+				ParentContractIndex: i - 1,
+			})
+		}
+		return claims
+	}
+	positions := []types.Position{
+		types.RootPosition,
+		types.NewPositionFromGIndex(big.NewInt(2)),  // Split depth, trace index 0
+		types.NewPositionFromGIndex(big.NewInt(4)),  // Bottom game trace index 3
+		types.NewPositionFromGIndex(big.NewInt(8)),  // Bottom game trace index 1
+		types.NewPositionFromGIndex(big.NewInt(16)), // Bottom game trace index 0
+	}
+
+	t.Run("Agree", func(t *testing.T) {
+		claims := createClaims(t, []bool{true, true, true, true, true}, positions...)
+		check, err := CheckClaim(context.Background(), types.NewGameState(claims, maxDepth), accessor, splitDepth, 4)
+		require.NoError(t, err)
+		require.Len(t, check.Claims, 5)
+		require.True(t, check.Claim().Agree())
+		require.Equal(t, claims[4].Value, check.Claim().LocalValue)
+		require.Nil(t, check.FirstDivergence)
+		require.Nil(t, check.LastDivergence)
+	})
+
+	t.Run("DisagreeTopGame", func(t *testing.T) {
+		claims := createClaims(t, []bool{false, false}, positions[:2]...)
+		check, err := CheckClaim(context.Background(), types.NewGameState(claims, maxDepth), accessor, splitDepth, 1)
+		require.NoError(t, err)
+		require.False(t, check.Claim().Agree())
+		require.False(t, check.Claim().Bottom)
+		require.Equal(t, uint64(0), check.Claim().TraceIndex.Uint64())
+		require.Nil(t, check.FirstDivergence)
+	})
+
+	t.Run("DivergenceRange", func(t *testing.T) {
+		claims := createClaims(t, []bool{false, false, false, true}, positions[:4]...)
+		check, err := CheckClaim(context.Background(), types.NewGameState(claims, maxDepth), accessor, splitDepth, 3)
+		require.NoError(t, err)
+		require.True(t, check.Claim().Agree())
+		require.True(t, check.Claim().Bottom)
+		require.Equal(t, uint64(1), check.Claim().TraceIndex.Uint64())
+		require.Equal(t, uint64(2), check.FirstDivergence.Uint64())
+		require.Equal(t, uint64(3), check.LastDivergence.Uint64())
+	})
+
+	t.Run("ExactDivergence", func(t *testing.T) {
+		claims := createClaims(t, []bool{false, false, false, true, false}, positions...)
+		check, err := CheckClaim(context.Background(), types.NewGameState(claims, maxDepth), accessor, splitDepth, 4)
+		require.NoError(t, err)
+		require.False(t, check.Claim().Agree())
+		require.Equal(t, uint64(0), check.FirstDivergence.Uint64())
+		require.Equal(t, uint64(0), check.LastDivergence.Uint64())
+	})
+
+	t.Run("UnknownClaim", func(t *testing.T) {
+		claims := createClaims(t, []bool{true}, positions[0])
+		_, err := CheckClaim(context.Background(), types.NewGameState(claims, maxDepth), accessor, splitDepth, 1)
+		require.ErrorContains(t, err, "does not exist")
+	})
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"sync"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 	"github.com/ethereum/go-ethereum/common"
@@ -13,19 +16,35 @@ import (
 
 var ErrTransactionReverted = errors.New("transaction published but reverted")
 
+// PendingTx is a transaction that has been queued for sending but has not yet completed.
+type PendingTx struct {
+	Purpose string          `json:"purpose"`
+	To      *common.Address `json:"to,omitempty"`
+	Since   time.Time       `json:"since"`
+}
+
 type TxSender struct {
 	log log.Logger
 
-	txMgr txmgr.TxManager
-	queue *txmgr.Queue[int]
+	txMgr   txmgr.TxManager
+	queue   *txmgr.Queue[int]
+	batcher *txmgr.Multicall3Batcher
+
+	pendingLock sync.Mutex
+	nextPending uint64
+	pending     map[uint64]PendingTx
 }
 
-func NewTxSender(ctx context.Context, logger log.Logger, txMgr txmgr.TxManager, maxPending uint64) *TxSender {
+// NewTxSender creates a TxSender. If batcher is not nil, transactions sent with SendAndWaitBatched
+// are aggregated into multicall transactions.
+func NewTxSender(ctx context.Context, logger log.Logger, txMgr txmgr.TxManager, maxPending uint64, batcher *txmgr.Multicall3Batcher) *TxSender {
 	queue := txmgr.NewQueue[int](ctx, txMgr, maxPending)
 	return &TxSender{
-		log:   logger,
-		txMgr: txMgr,
-		queue: queue,
+		log:     logger,
+		txMgr:   txMgr,
+		queue:   queue,
+		batcher: batcher,
+		pending: make(map[uint64]PendingTx),
 	}
 }
 
@@ -34,15 +53,43 @@ func (s *TxSender) From() common.Address {
 }
 
 func (s *TxSender) SendAndWaitDetailed(txPurpose string, txs ...txmgr.TxCandidate) []error {
+	return s.sendAndWait(txPurpose, txs, func(receiptsCh chan txmgr.TxReceipt[int]) {
+		for i, tx := range txs {
+			s.queue.Send(i, tx, receiptsCh)
+		}
+	})
+}
+
+// SendAndWaitBatched is like SendAndWaitDetailed, but aggregates the transactions into multicall transactions
+// if batching is enabled. It must only be used for calls that have the same effect regardless of msg.sender,
+// as the calls are made by the multicall contract.
+func (s *TxSender) SendAndWaitBatched(txPurpose string, txs ...txmgr.TxCandidate) []error {
+	if s.batcher == nil || len(txs) < 2 {
+		return s.SendAndWaitDetailed(txPurpose, txs...)
+	}
+	return s.sendAndWait(txPurpose, txs, func(receiptsCh chan txmgr.TxReceipt[int]) {
+		ids := make([]int, len(txs))
+		for i := range txs {
+			ids[i] = i
+		}
+		txmgr.SendBatched(s.queue, s.batcher, ids, txs, receiptsCh)
+	})
+}
+
+// sendAndWait sends the txs with send, which must send exactly one receipt per tx, identified by its index in txs.
+func (s *TxSender) sendAndWait(txPurpose string, txs []txmgr.TxCandidate, send func(receiptsCh chan txmgr.TxReceipt[int])) []error {
 	receiptsCh := make(chan txmgr.TxReceipt[int], len(txs))
+	pendingIDs := make([]uint64, len(txs))
 	for i, tx := range txs {
-		s.queue.Send(i, tx, receiptsCh)
+		pendingIDs[i] = s.addPending(txPurpose, tx)
 	}
+	send(receiptsCh)
 	completed := 0
 	errs := make([]error, len(txs))
 	for completed < len(txs) {
 		rcpt := <-receiptsCh
 		completed++
+		s.removePending(pendingIDs[rcpt.ID])
 		if rcpt.Err != nil {
 			errs[rcpt.ID] = rcpt.Err
 		} else if rcpt.Receipt != nil {
@@ -60,3 +107,34 @@ func (s *TxSender) SendAndWaitSimple(txPurpose string, txs ...txmgr.TxCandidate)
 	errs := s.SendAndWaitDetailed(txPurpose, txs...)
 	return errors.Join(errs...)
 }
+
+// PendingTxs returns the transactions that are currently queued or awaiting a receipt, oldest first.
+func (s *TxSender) PendingTxs() []PendingTx {
+	s.pendingLock.Lock()
+	defer s.pendingLock.Unlock()
+	ids := make([]uint64, 0, len(s.pending))
+	for id := range s.pending {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	txs := make([]PendingTx, 0, len(ids))
+	for _, id := range ids {
+		txs = append(txs, s.pending[id])
+	}
+	return txs
+}
+
+func (s *TxSender) addPending(txPurpose string, tx txmgr.TxCandidate) uint64 {
+	s.pendingLock.Lock()
+	defer s.pendingLock.Unlock()
+	id := s.nextPending
+	s.nextPending++
+	s.pending[id] = PendingTx{Purpose: txPurpose, To: tx.To, Since: time.Now()}
+	return id
+}
+
+func (s *TxSender) removePending(id uint64) {
+	s.pendingLock.Lock()
+	defer s.pendingLock.Unlock()
+	delete(s.pending, id)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/stretchr/testify/require"
@@ -21,7 +22,7 @@ func TestSendAndWaitQueueWithMaxPending(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 	txMgr := &stubTxMgr{sending: make(map[byte]chan *types.Receipt)}
-	sender := NewTxSender(ctx, testlog.Logger(t, log.LevelInfo), txMgr, 5)
+	sender := NewTxSender(ctx, testlog.Logger(t, log.LevelInfo), txMgr, 5, nil)
 
 	tx := func(i byte) txmgr.TxCandidate {
 		return txmgr.TxCandidate{TxData: []byte{i}}
@@ -56,6 +57,8 @@ func TestSendAndWaitQueueWithMaxPending(t *testing.T) {
 
 	require.Len(t, batch1, 0, "Should not have completed batch1")
 	require.Len(t, batch2, 0, "Should not have completed batch2")
+	require.Len(t, sender.PendingTxs(), 5)
+	require.Equal(t, "testing", sender.PendingTxs()[0].Purpose)
 
 	// Send a third batch after the first set have started sending to avoid races
 	batch3 := sendAsync(tx(6))
@@ -87,6 +90,7 @@ func TestSendAndWaitQueueWithMaxPending(t *testing.T) {
 
 	txMgr.txSuccess(tx(4))
 	require.Len(t, wait(batch2), 2, "Batch2 should complete")
+	require.Empty(t, sender.PendingTxs())
 }
 
 func TestSendAndWaitReturnIndividualErrors(t *testing.T) {
@@ -100,7 +104,7 @@ func TestSendAndWaitReturnIndividualErrors(t *testing.T) {
 			2: types.ReceiptStatusSuccessful,
 		},
 	}
-	sender := NewTxSender(ctx, testlog.Logger(t, log.LevelInfo), txMgr, 500)
+	sender := NewTxSender(ctx, testlog.Logger(t, log.LevelInfo), txMgr, 500, nil)
 
 	tx := func(i byte) txmgr.TxCandidate {
 		return txmgr.TxCandidate{TxData: []byte{i}}
@@ -113,10 +117,68 @@ func TestSendAndWaitReturnIndividualErrors(t *testing.T) {
 	require.NoError(t, errs[2])
 }
 
+func TestSendAndWaitBatched(t *testing.T) {
+	multicallAddr := common.Address{0xca, 0x11}
+	target := common.Address{0xaa}
+	aggregate3 := crypto.Keccak256([]byte("aggregate3((address,bool,bytes)[])"))[0]
+	tx := func(i byte) txmgr.TxCandidate {
+		return txmgr.TxCandidate{TxData: []byte{i}, To: &target}
+	}
+
+	t.Run("Aggregated", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		txMgr := &stubTxMgr{
+			sending:    make(map[byte]chan *types.Receipt),
+			syncStatus: map[byte]uint64{aggregate3: types.ReceiptStatusSuccessful},
+		}
+		sender := NewTxSender(ctx, testlog.Logger(t, log.LevelInfo), txMgr, 500, txmgr.NewMulticall3Batcher(multicallAddr, 10))
+		errs := sender.SendAndWaitBatched("testing", tx(0), tx(1), tx(2))
+		require.Equal(t, []error{nil, nil, nil}, errs)
+		require.Len(t, txMgr.sent, 1, "should send a single multicall")
+		require.Equal(t, multicallAddr, *txMgr.sent[0].To)
+	})
+
+	t.Run("RetryIndividuallyOnFailure", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		txMgr := &stubTxMgr{
+			sending: make(map[byte]chan *types.Receipt),
+			syncStatus: map[byte]uint64{
+				aggregate3: types.ReceiptStatusFailed,
+				0:          types.ReceiptStatusSuccessful,
+				1:          types.ReceiptStatusFailed,
+				2:          types.ReceiptStatusSuccessful,
+			},
+		}
+		sender := NewTxSender(ctx, testlog.Logger(t, log.LevelInfo), txMgr, 500, txmgr.NewMulticall3Batcher(multicallAddr, 10))
+		errs := sender.SendAndWaitBatched("testing", tx(0), tx(1), tx(2))
+		require.Len(t, errs, 3)
+		require.NoError(t, errs[0])
+		require.ErrorIs(t, errs[1], ErrTransactionReverted)
+		require.NoError(t, errs[2])
+		require.Len(t, txMgr.sent, 4, "should send the multicall then each tx")
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		txMgr := &stubTxMgr{
+			sending:    make(map[byte]chan *types.Receipt),
+			syncStatus: map[byte]uint64{0: types.ReceiptStatusSuccessful, 1: types.ReceiptStatusSuccessful},
+		}
+		sender := NewTxSender(ctx, testlog.Logger(t, log.LevelInfo), txMgr, 500, nil)
+		errs := sender.SendAndWaitBatched("testing", tx(0), tx(1))
+		require.Equal(t, []error{nil, nil}, errs)
+		require.Len(t, txMgr.sent, 2)
+	})
+}
+
 type stubTxMgr struct {
 	m          sync.Mutex
 	sending    map[byte]chan *types.Receipt
 	syncStatus map[byte]uint64
+	sent       []txmgr.TxCandidate
 }
 
 func (s *stubTxMgr) IsClosed() bool {
@@ -131,6 +193,7 @@ func (s *stubTxMgr) Send(ctx context.Context, candidate txmgr.TxCandidate) (*typ
 func (s *stubTxMgr) recordTx(candidate txmgr.TxCandidate) chan *types.Receipt {
 	s.m.Lock()
 	defer s.m.Unlock()
+	s.sent = append(s.sent, candidate)
 	id := candidate.TxData[0]
 	if _, ok := s.sending[id]; ok {
 		// Shouldn't happen if tests are well written, but double check...
@@ -3,42 +3,114 @@ package tools
 import (
 	"context"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts/metrics"
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 )
 
+// CreatedGame describes a dispute game created by the GameCreator.
+type CreatedGame struct {
+	Address common.Address
+	// AbsolutePrestate is the prestate a challenger must use to play the game.
+	AbsolutePrestate common.Hash
+}
+
 type GameCreator struct {
 	contract *contracts.DisputeGameFactoryContract
+	caller   *batching.MultiCaller
 	txMgr    txmgr.TxManager
 }
 
-func NewGameCreator(contract *contracts.DisputeGameFactoryContract, txMgr txmgr.TxManager) *GameCreator {
+func NewGameCreator(contract *contracts.DisputeGameFactoryContract, caller *batching.MultiCaller, txMgr txmgr.TxManager) *GameCreator {
 	return &GameCreator{
 		contract: contract,
+		caller:   caller,
 		txMgr:    txMgr,
 	}
 }
 
-func (g *GameCreator) CreateGame(ctx context.Context, outputRoot common.Hash, traceType uint64, l2BlockNum uint64) (common.Address, error) {
-	txCandidate, err := g.contract.CreateTx(ctx, uint32(traceType), outputRoot, l2BlockNum)
+// CreateGame creates a dispute game for the output root at the L2 block, paying the initial bond required by the factory.
+func (g *GameCreator) CreateGame(ctx context.Context, outputRoot common.Hash, gameType faultTypes.GameType, l2BlockNum uint64) (CreatedGame, error) {
+	existing, err := g.contract.GetGameFromParameters(ctx, uint32(gameType), outputRoot, l2BlockNum)
 	if err != nil {
-		return common.Address{}, fmt.Errorf("failed to create tx: %w", err)
+		return CreatedGame{}, fmt.Errorf("failed to check for existing game: %w", err)
+	}
+	if existing != (common.Address{}) {
+		return CreatedGame{}, fmt.Errorf("game already exists: %v", existing)
+	}
+	prestate, err := g.requiredPrestate(ctx, gameType)
+	if err != nil {
+		return CreatedGame{}, err
+	}
+
+	txCandidate, err := g.contract.CreateTx(ctx, uint32(gameType), outputRoot, l2BlockNum)
+	if err != nil {
+		return CreatedGame{}, fmt.Errorf("failed to create tx: %w", err)
 	}
 
 	rct, err := g.txMgr.Send(ctx, txCandidate)
 	if err != nil {
-		return common.Address{}, fmt.Errorf("failed to send tx: %w", err)
+		return CreatedGame{}, fmt.Errorf("failed to send tx: %w", err)
 	}
 	if rct.Status != types.ReceiptStatusSuccessful {
-		return common.Address{}, fmt.Errorf("game creation transaction (%v) reverted", rct.TxHash.Hex())
+		return CreatedGame{}, fmt.Errorf("game creation transaction (%v) reverted", rct.TxHash.Hex())
 	}
 
 	gameAddr, _, _, err := g.contract.DecodeDisputeGameCreatedLog(rct)
 	if err != nil {
-		return common.Address{}, fmt.Errorf("failed to decode game created: %w", err)
+		return CreatedGame{}, fmt.Errorf("failed to decode game created: %w", err)
+	}
+	return CreatedGame{Address: gameAddr, AbsolutePrestate: prestate}, nil
+}
+
+// requiredPrestate loads the absolute prestate of the factory's implementation for the game type.
+func (g *GameCreator) requiredPrestate(ctx context.Context, gameType faultTypes.GameType) (common.Hash, error) {
+	implAddr, err := g.contract.GetGameImpl(ctx, gameType)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to load implementation of game type %v: %w", gameType, err)
+	}
+	if implAddr == (common.Address{}) {
+		return common.Hash{}, fmt.Errorf("no implementation for game type %v", gameType)
+	}
+	impl, err := contracts.NewFaultDisputeGameContract(ctx, metrics.NoopContractMetrics, implAddr, g.caller)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to create bindings for game implementation %v: %w", implAddr, err)
+	}
+	prestate, err := impl.GetAbsolutePrestateHash(ctx)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to load absolute prestate of game implementation %v: %w", implAddr, err)
+	}
+	return prestate, nil
+}
+
+// RegisterGame registers a game with a running challenger through its API, so it plays the game
+// even if the game is not on the challenger's allow list. The challenger must be started with --api.game-registration.
+func RegisterGame(ctx context.Context, apiURL string, game common.Address) error {
+	endpoint, err := url.JoinPath(apiURL, "games", game.Hex())
+	if err != nil {
+		return fmt.Errorf("invalid challenger API URL %q: %w", apiURL, err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to register game: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("failed to register game: unexpected status %v: %s", resp.Status, strings.TrimSpace(string(msg)))
 	}
-	return gameAddr, nil
+	return nil
 }
@@ -0,0 +1,147 @@
+package conductor
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+
+	"github.com/ethereum-optimism/optimism/op-conductor/consensus"
+	conductorrpc "github.com/ethereum-optimism/optimism/op-conductor/rpc"
+)
+
+// bootstrapPollInterval is the interval to check if a joining follower caught up with the cluster.
+const bootstrapPollInterval = time.Second
+
+var errBootstrapPending = errors.New("follower not caught up with the cluster yet")
+
+// clusterLeader is the conductor API of the cluster leader that a new follower joins the cluster through.
+type clusterLeader interface {
+	BootstrapFollower(ctx context.Context, id string, addr string) (*conductorrpc.FollowerSnapshot, error)
+	AddServerAsVoter(ctx context.Context, id string, addr string, version uint64) error
+}
+
+// BootstrapFollower adds a new follower to the cluster as a non-voter, and returns the cluster membership
+// and the latest unsafe payload the follower has to catch up with before being promoted to voter.
+func (oc *OpConductor) BootstrapFollower(_ context.Context, id string, addr string) (*conductorrpc.FollowerSnapshot, error) {
+	if err := oc.cons.AddNonVoter(id, addr, 0); err != nil {
+		return nil, errors.Wrap(err, "failed to add follower as non-voter")
+	}
+	membership, err := oc.cons.ClusterMembership()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get cluster membership")
+	}
+	unsafeHead, err := oc.cons.LatestUnsafePayload()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get latest unsafe payload")
+	}
+	oc.log.Info("bootstrapping follower", "id", id, "addr", addr, "version", membership.Version)
+	return &conductorrpc.FollowerSnapshot{
+		Membership: membership,
+		UnsafeHead: unsafeHead,
+	}, nil
+}
+
+// startJoin joins the cluster through the leader at cfg.RaftJoin, in the background.
+func (oc *OpConductor) startJoin(ctx context.Context) error {
+	c, err := rpc.DialContext(ctx, oc.cfg.RaftJoin)
+	if err != nil {
+		return errors.Wrap(err, "failed to dial cluster leader")
+	}
+	oc.wg.Add(1)
+	go func() {
+		defer oc.wg.Done()
+		defer c.Close()
+		ctx, cancel := context.WithTimeout(oc.shutdownCtx, oc.cfg.RaftJoinTimeout)
+		defer cancel()
+		if err := oc.joinCluster(ctx, conductorrpc.NewAPIClient(c)); err != nil {
+			oc.log.Error("failed to join cluster", "leader", oc.cfg.RaftJoin, "err", err)
+		}
+	}()
+	return nil
+}
+
+// joinCluster joins the cluster of the leader as a non-voter, and promotes itself to voter once the cluster state
+// has been replicated to it, up to at least the unsafe head the leader had when the follower joined, and the local
+// engine is at the replicated unsafe head.
+func (oc *OpConductor) joinCluster(ctx context.Context, leader clusterLeader) error {
+	id, addr := oc.cons.ServerID(), oc.cfg.ConsensusServerAddr()
+	if membership, err := oc.cons.ClusterMembership(); err == nil && isVoter(membership, id) {
+		oc.log.Info("already a voter of the cluster, not joining", "id", id)
+		return nil
+	}
+
+	snapshot, err := leader.BootstrapFollower(ctx, id, addr)
+	if err != nil {
+		return errors.Wrap(err, "failed to bootstrap from leader")
+	}
+	oc.log.Info("joined cluster as non-voter", "id", id, "version", snapshot.Membership.Version)
+	if snapshot.UnsafeHead != nil {
+		// Best effort, the node also syncs up to the unsafe head by itself.
+		if err := oc.ctrl.PostUnsafePayload(ctx, snapshot.UnsafeHead); err != nil {
+			oc.log.Warn("failed to post unsafe head snapshot to op-node", "err", err)
+		}
+	}
+
+	ticker := time.NewTicker(bootstrapPollInterval)
+	defer ticker.Stop()
+	for {
+		err := oc.verifyBootstrap(ctx, snapshot)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, errBootstrapPending) {
+			oc.log.Warn("failed to verify bootstrapped state", "err", err)
+		}
+		select {
+		case <-ctx.Done():
+			return errors.Wrap(ctx.Err(), "timed out waiting to catch up with the cluster")
+		case <-ticker.C:
+		}
+	}
+
+	if err := leader.AddServerAsVoter(ctx, id, addr, 0); err != nil {
+		return errors.Wrap(err, "failed to promote to voter")
+	}
+	oc.log.Info("promoted to voter", "id", id)
+	return nil
+}
+
+// verifyBootstrap returns nil if the unsafe head applied by the local server is at or beyond the unsafe head of
+// the snapshot, and the local engine is at the applied unsafe head.
+func (oc *OpConductor) verifyBootstrap(ctx context.Context, snapshot *conductorrpc.FollowerSnapshot) error {
+	if snapshot.UnsafeHead == nil {
+		return nil
+	}
+	status, err := oc.cons.ClusterStatus()
+	if err != nil {
+		return errors.Wrap(err, "failed to get cluster status")
+	}
+	expected := snapshot.UnsafeHead.ExecutionPayload.ID()
+	applied := status.UnsafeHead
+	if applied == nil || applied.Number < expected.Number {
+		return fmt.Errorf("%w: applied unsafe head %v behind snapshot %v", errBootstrapPending, applied, expected)
+	}
+	if applied.Number == expected.Number && applied.Hash != expected.Hash {
+		return fmt.Errorf("applied unsafe head %v does not match snapshot %v", applied, expected)
+	}
+	unsafeInNode, err := oc.ctrl.LatestUnsafeBlock(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get latest unsafe block from EL")
+	}
+	if unsafeInNode.Hash() != applied.Hash {
+		return fmt.Errorf("%w: engine unsafe head %v, applied unsafe head %v", errBootstrapPending, unsafeInNode.NumberU64(), applied)
+	}
+	return nil
+}
+
+func isVoter(membership *consensus.ClusterMembership, id string) bool {
+	for _, srv := range membership.Servers {
+		if srv.ID == id {
+			return srv.Suffrage == consensus.Voter
+		}
+	}
+	return false
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/urfave/cli/v2"
 
 	"github.com/ethereum-optimism/optimism/op-conductor/flags"
+	"github.com/ethereum-optimism/optimism/op-conductor/health"
 	opnode "github.com/ethereum-optimism/optimism/op-node"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
@@ -34,6 +35,13 @@ type Config struct {
 	// RaftBootstrap is true if this node should bootstrap a new raft cluster.
 	RaftBootstrap bool
 
+	// RaftJoin is the conductor RPC endpoint of the cluster leader to join the cluster through, as a non-voter that
+	// is promoted to voter once it caught up with the cluster. Joining is disabled if empty.
+	RaftJoin string
+
+	// RaftJoinTimeout is the maximum time to catch up with the cluster after joining it.
+	RaftJoinTimeout time.Duration
+
 	// RaftSnapshotInterval is the interval to check if a snapshot should be taken.
 	RaftSnapshotInterval time.Duration
 
@@ -81,6 +89,12 @@ func (c *Config) Check() error {
 	if c.RaftStorageDir == "" {
 		return fmt.Errorf("missing raft storage directory")
 	}
+	if c.RaftJoin != "" && c.RaftBootstrap {
+		return fmt.Errorf("cannot both bootstrap and join a raft cluster")
+	}
+	if c.RaftJoin != "" && c.RaftJoinTimeout <= 0 {
+		return fmt.Errorf("raft join timeout must be positive")
+	}
 	if c.NodeRPC == "" {
 		return fmt.Errorf("missing node RPC")
 	}
@@ -105,6 +119,11 @@ func (c *Config) Check() error {
 	return nil
 }
 
+// ConsensusServerAddr returns the address the consensus server is reachable at by the other cluster members.
+func (c *Config) ConsensusServerAddr() string {
+	return fmt.Sprintf("%s:%d", c.ConsensusAddr, c.ConsensusPort)
+}
+
 // NewConfig parses the Config from the provided flags or environment variables.
 func NewConfig(ctx *cli.Context, log log.Logger) (*Config, error) {
 	if err := flags.CheckRequired(ctx); err != nil {
@@ -122,6 +141,8 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*Config, error) {
 		RaftBootstrap:         ctx.Bool(flags.RaftBootstrap.Name),
 		RaftServerID:          ctx.String(flags.RaftServerID.Name),
 		RaftStorageDir:        ctx.String(flags.RaftStorageDir.Name),
+		RaftJoin:              ctx.String(flags.RaftJoin.Name),
+		RaftJoinTimeout:       ctx.Duration(flags.RaftJoinTimeout.Name),
 		RaftSnapshotInterval:  ctx.Duration(flags.RaftSnapshotInterval.Name),
 		RaftSnapshotThreshold: ctx.Uint64(flags.RaftSnapshotThreshold.Name),
 		RaftTrailingLogs:      ctx.Uint64(flags.RaftTrailingLogs.Name),
@@ -134,6 +155,15 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*Config, error) {
 			SafeEnabled:    ctx.Bool(flags.HealthCheckSafeEnabled.Name),
 			SafeInterval:   ctx.Uint64(flags.HealthCheckSafeInterval.Name),
 			MinPeerCount:   ctx.Uint64(flags.HealthCheckMinPeerCount.Name),
+			Score: health.ScoreConfig{
+				Threshold:        ctx.Float64(flags.HealthCheckScoreThreshold.Name),
+				UnsafeLagPenalty: ctx.Float64(flags.HealthCheckUnsafeLagPenalty.Name),
+				TargetPeerCount:  ctx.Uint64(flags.HealthCheckTargetPeerCount.Name),
+				PeerPenalty:      ctx.Float64(flags.HealthCheckPeerPenalty.Name),
+				ErrorPenalty:     ctx.Float64(flags.HealthCheckErrorPenalty.Name),
+				ErrorWindow:      ctx.Uint64(flags.HealthCheckErrorWindow.Name),
+			},
+			MinLeadershipDuration: ctx.Duration(flags.HealthCheckMinLeadershipDuration.Name),
 		},
 		RollupCfg:      *rollupCfg,
 		RPCEnableProxy: ctx.Bool(flags.RPCEnableProxy.Name),
@@ -160,6 +190,13 @@ type HealthCheckConfig struct {
 
 	// MinPeerCount is the minimum number of peers required for the sequencer to be healthy.
 	MinPeerCount uint64
+
+	// Score configures the health score, below which a healthy leader proactively transfers leadership.
+	Score health.ScoreConfig
+
+	// MinLeadershipDuration is the minimum time a degraded leader holds leadership before transferring it,
+	// and the minimum time between transfer attempts, to prevent leadership from flapping between servers.
+	MinLeadershipDuration time.Duration
 }
 
 func (c *HealthCheckConfig) Check() error {
@@ -172,5 +209,11 @@ func (c *HealthCheckConfig) Check() error {
 	if c.MinPeerCount == 0 {
 		return fmt.Errorf("missing minimum peer count")
 	}
+	if err := c.Score.Check(); err != nil {
+		return errors.Wrap(err, "invalid health score config")
+	}
+	if c.Score.Enabled() && c.MinLeadershipDuration <= 0 {
+		return fmt.Errorf("minimum leadership duration must be positive when health scoring is enabled")
+	}
 	return nil
 }
@@ -28,6 +28,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/httputil"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/oppprof"
 	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
 )
@@ -148,10 +149,9 @@ func (c *OpConductor) initConsensus(ctx context.Context) error {
 		return nil
 	}
 
-	serverAddr := fmt.Sprintf("%s:%d", c.cfg.ConsensusAddr, c.cfg.ConsensusPort)
 	raftConsensusConfig := &consensus.RaftConsensusConfig{
 		ServerID:          c.cfg.RaftServerID,
-		ServerAddr:        serverAddr,
+		ServerAddr:        c.cfg.ConsensusServerAddr(),
 		StorageDir:        c.cfg.RaftStorageDir,
 		Bootstrap:         c.cfg.RaftBootstrap,
 		RollupCfg:         &c.cfg.RollupCfg,
@@ -193,6 +193,7 @@ func (c *OpConductor) initHealthMonitor(ctx context.Context) error {
 		c.cfg.HealthCheck.SafeInterval,
 		c.cfg.HealthCheck.MinPeerCount,
 		c.cfg.HealthCheck.SafeEnabled,
+		c.cfg.HealthCheck.Score,
 		&c.cfg.RollupCfg,
 		node,
 		p2p,
@@ -203,13 +204,21 @@ func (c *OpConductor) initHealthMonitor(ctx context.Context) error {
 }
 
 func (oc *OpConductor) initRPCServer(ctx context.Context) error {
+	limits, err := oc.cfg.RPC.Limits()
+	if err != nil {
+		return errors.Wrap(err, "invalid RPC limits")
+	}
+	api := conductorrpc.NewAPIBackend(oc.log, oc)
+	statusHandler := conductorrpc.NewStatusHandler(oc.log, api)
 	server := oprpc.NewServer(
 		oc.cfg.RPC.ListenAddr,
 		oc.cfg.RPC.ListenPort,
 		oc.version,
 		oprpc.WithLogger(oc.log),
+		oprpc.WithLimits(limits, oc.metrics),
+		oprpc.WithHTTPHandler(conductorrpc.StatusPath, statusHandler),
+		oprpc.WithHTTPHandler(conductorrpc.StatusJSONPath, statusHandler),
 	)
-	api := conductorrpc.NewAPIBackend(oc.log, oc)
 	server.AddAPI(rpc.API{
 		Namespace: conductorrpc.RPCNamespace,
 		Version:   oc.version,
@@ -273,6 +282,13 @@ type OpConductor struct {
 	hcerr     error // error from health check
 	prevState *state
 
+	// degraded is true if the sequencer is healthy, but its health score is below the threshold.
+	degraded atomic.Bool
+	// leaderSince is when leadership was last acquired.
+	leaderSince time.Time
+	// lastDegradedTransfer is when leadership was last transferred, or attempted to be transferred, because of degradation.
+	lastDegradedTransfer time.Time
+
 	healthUpdateCh <-chan error
 	leaderUpdateCh <-chan bool
 	loopActionFn   func() // loopActionFn defines the logic to be executed inside control loop.
@@ -290,6 +306,7 @@ type OpConductor struct {
 
 	rpcServer     *oprpc.Server
 	metricsServer *httputil.HTTPServer
+	pprofService  *oppprof.Service
 
 	retryBackoff func() time.Duration
 }
@@ -343,9 +360,28 @@ func (oc *OpConductor) Start(ctx context.Context) error {
 		oc.metricsServer = metricsServer
 	}
 
+	oc.pprofService = oppprof.New(
+		oc.cfg.PprofConfig.ListenEnabled,
+		oc.cfg.PprofConfig.ListenAddr,
+		oc.cfg.PprofConfig.ListenPort,
+		oc.cfg.PprofConfig.ProfileType,
+		oc.cfg.PprofConfig.ProfileDir,
+		oc.cfg.PprofConfig.ProfileFilename,
+		oc.cfg.PprofConfig.Upload,
+	)
+	if err := oc.pprofService.Start(); err != nil {
+		return errors.Wrap(err, "failed to start pprof service")
+	}
+
 	oc.wg.Add(1)
 	go oc.loop()
 
+	if oc.cfg.RaftJoin != "" {
+		if err := oc.startJoin(ctx); err != nil {
+			return errors.Wrap(err, "failed to join cluster")
+		}
+	}
+
 	oc.metrics.RecordInfo(oc.version)
 	oc.metrics.RecordUp()
 
@@ -396,6 +432,12 @@ func (oc *OpConductor) Stop(ctx context.Context) error {
 		}
 	}
 
+	if oc.pprofService != nil {
+		if err := oc.pprofService.Stop(ctx); err != nil {
+			result = multierror.Append(result, errors.Wrap(err, "failed to stop pprof service"))
+		}
+	}
+
 	if result.ErrorOrNil() != nil {
 		oc.log.Error("failed to stop OpConductor", "err", result.ErrorOrNil())
 		return result.ErrorOrNil()
@@ -502,6 +544,11 @@ func (oc *OpConductor) ClusterMembership(_ context.Context) (*consensus.ClusterM
 	return oc.cons.ClusterMembership()
 }
 
+// ClusterStatus returns current cluster's leadership and membership status, as observed by this conductor.
+func (oc *OpConductor) ClusterStatus(_ context.Context) (*consensus.ClusterStatus, error) {
+	return oc.cons.ClusterStatus()
+}
+
 // LatestUnsafePayload returns the latest unsafe payload envelope from FSM in a strongly consistent fashion.
 func (oc *OpConductor) LatestUnsafePayload(_ context.Context) (*eth.ExecutionPayloadEnvelope, error) {
 	return oc.cons.LatestUnsafePayload()
@@ -556,6 +603,9 @@ func (oc *OpConductor) queueAction() {
 func (oc *OpConductor) handleLeaderUpdate(leader bool) {
 	oc.log.Info("Leadership status changed", "server", oc.cons.ServerID(), "leader", leader)
 
+	if leader && !oc.leader.Load() {
+		oc.leaderSince = time.Now()
+	}
 	oc.leader.Store(leader)
 	oc.queueAction()
 }
@@ -563,6 +613,17 @@ func (oc *OpConductor) handleLeaderUpdate(leader bool) {
 // handleHealthUpdate handles health update from health monitor.
 func (oc *OpConductor) handleHealthUpdate(hcerr error) {
 	oc.log.Debug("received health update", "server", oc.cons.ServerID(), "error", hcerr)
+	// A degraded sequencer is still healthy, but a degraded leader may hand over leadership to a follower.
+	degraded := errors.Is(hcerr, health.ErrSequencerDegraded)
+	if degraded {
+		oc.log.Warn("Sequencer is degraded", "server", oc.cons.ServerID(), "err", hcerr)
+		hcerr = nil
+		if oc.leader.Load() {
+			// queue an action for every update, as leadership can only be transferred after the minimum leadership duration.
+			oc.queueAction()
+		}
+	}
+	oc.degraded.Store(degraded)
 	healthy := hcerr == nil
 	if !healthy {
 		oc.log.Error("Sequencer is unhealthy", "server", oc.cons.ServerID(), "err", hcerr)
@@ -652,7 +713,10 @@ func (oc *OpConductor) action() {
 		// start sequencer
 		err = oc.startSequencer()
 	case status.leader && status.healthy && status.active:
-		// normal leader, do nothing
+		// normal leader, unless it is degraded and should hand over leadership to a follower.
+		if oc.shouldTransferDegradedLeadership() {
+			err = oc.transferDegradedLeadership()
+		}
 	}
 
 	oc.log.Debug("exiting action with status and error", "status", status, "err", err)
@@ -673,6 +737,35 @@ func (oc *OpConductor) action() {
 	}
 }
 
+// shouldTransferDegradedLeadership returns whether a degraded leader should transfer leadership.
+// Leadership is only transferred once it was held for the minimum leadership duration,
+// and at most once per minimum leadership duration, to prevent flapping between degraded servers,
+// or endless attempts when there is no follower to transfer leadership to.
+func (oc *OpConductor) shouldTransferDegradedLeadership() bool {
+	if !oc.degraded.Load() {
+		return false
+	}
+	now := time.Now()
+	minDuration := oc.cfg.HealthCheck.MinLeadershipDuration
+	return now.Sub(oc.leaderSince) >= minDuration && now.Sub(oc.lastDegradedTransfer) >= minDuration
+}
+
+// transferDegradedLeadership stops sequencing and transfers leadership away from a degraded leader.
+// If the leadership transfer fails, the sequencer is restarted by the next action, and the transfer is not retried
+// before the minimum leadership duration passes again.
+func (oc *OpConductor) transferDegradedLeadership() error {
+	oc.lastDegradedTransfer = time.Now()
+	oc.log.Warn("Transferring leadership away from degraded sequencer", "server", oc.cons.ServerID())
+	if err := oc.stopSequencer(); err != nil {
+		return err
+	}
+	if err := oc.transferLeader(); err != nil {
+		oc.log.Error("Failed to transfer leadership away from degraded sequencer", "server", oc.cons.ServerID(), "err", err)
+		oc.queueAction()
+	}
+	return nil
+}
+
 // transferLeader tries to transfer leadership to another server.
 func (oc *OpConductor) transferLeader() error {
 	// TransferLeader here will do round robin to try to transfer leadership to the next healthy node.
@@ -3,6 +3,7 @@ package conductor
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math/big"
 	"sync"
 	"testing"
@@ -16,10 +17,12 @@ import (
 	"github.com/stretchr/testify/suite"
 
 	clientmocks "github.com/ethereum-optimism/optimism/op-conductor/client/mocks"
+	"github.com/ethereum-optimism/optimism/op-conductor/consensus"
 	consensusmocks "github.com/ethereum-optimism/optimism/op-conductor/consensus/mocks"
 	"github.com/ethereum-optimism/optimism/op-conductor/health"
 	healthmocks "github.com/ethereum-optimism/optimism/op-conductor/health/mocks"
 	"github.com/ethereum-optimism/optimism/op-conductor/metrics"
+	conductorrpc "github.com/ethereum-optimism/optimism/op-conductor/rpc"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
@@ -851,6 +854,61 @@ func (s *OpConductorTestSuite) TestFailureAndRetry4() {
 	}, 2*time.Second, 100*time.Millisecond)
 }
 
+// In this test, we have a degraded leader that held leadership for the minimum leadership duration,
+// and expect it to stop sequencing and transfer leadership.
+// [leader, healthy, sequencing] -- become degraded --> [follower, healthy, not sequencing]
+func (s *OpConductorTestSuite) TestDegradedLeaderTransfersLeadership() {
+	s.enableSynchronization()
+
+	// set initial state
+	cfg := *s.conductor.cfg
+	cfg.HealthCheck.MinLeadershipDuration = time.Minute
+	s.conductor.cfg = &cfg
+	s.conductor.leader.Store(true)
+	s.conductor.healthy.Store(true)
+	s.conductor.seqActive.Store(true)
+	s.conductor.leaderSince = time.Now().Add(-2 * time.Minute)
+
+	s.ctrl.EXPECT().StopSequencer(mock.Anything).Return(common.Hash{}, nil).Times(1)
+	s.cons.EXPECT().TransferLeader().Return(nil).Times(1)
+
+	// become degraded
+	s.updateHealthStatusAndExecuteAction(fmt.Errorf("%w: score 40.0", health.ErrSequencerDegraded))
+
+	// expect to step down as leader and stop sequencing, while staying healthy
+	s.False(s.conductor.leader.Load())
+	s.True(s.conductor.healthy.Load())
+	s.False(s.conductor.seqActive.Load())
+	s.True(s.conductor.degraded.Load())
+	s.ctrl.AssertCalled(s.T(), "StopSequencer", mock.Anything)
+	s.cons.AssertCalled(s.T(), "TransferLeader")
+}
+
+// In this test, we have a degraded leader that did not hold leadership for the minimum leadership duration yet,
+// and expect it to keep sequencing.
+func (s *OpConductorTestSuite) TestDegradedLeaderHoldsMinLeadershipDuration() {
+	s.enableSynchronization()
+
+	// set initial state
+	cfg := *s.conductor.cfg
+	cfg.HealthCheck.MinLeadershipDuration = time.Minute
+	s.conductor.cfg = &cfg
+	s.conductor.leader.Store(true)
+	s.conductor.healthy.Store(true)
+	s.conductor.seqActive.Store(true)
+	s.conductor.leaderSince = time.Now()
+
+	// become degraded
+	s.updateHealthStatusAndExecuteAction(fmt.Errorf("%w: score 40.0", health.ErrSequencerDegraded))
+
+	// expect to stay as leader and keep sequencing
+	s.True(s.conductor.leader.Load())
+	s.True(s.conductor.healthy.Load())
+	s.True(s.conductor.seqActive.Load())
+	s.ctrl.AssertNotCalled(s.T(), "StopSequencer", mock.Anything)
+	s.cons.AssertNotCalled(s.T(), "TransferLeader")
+}
+
 func (s *OpConductorTestSuite) TestConductorRestart() {
 	// set initial state
 	s.conductor.leader.Store(false)
@@ -875,6 +933,74 @@ func (s *OpConductorTestSuite) TestHandleInitError() {
 	s.False(ok)
 }
 
+func (s *OpConductorTestSuite) TestBootstrapFollower() {
+	membership := &consensus.ClusterMembership{Servers: []consensus.ServerInfo{{ID: "SequencerA"}, {ID: "SequencerB", Suffrage: consensus.Nonvoter}}, Version: 3}
+	payload := &eth.ExecutionPayloadEnvelope{ExecutionPayload: &eth.ExecutionPayload{BlockNumber: 10, BlockHash: [32]byte{1}}}
+	s.cons.EXPECT().AddNonVoter("SequencerB", "127.0.0.1:50051", uint64(0)).Return(nil).Times(1)
+	s.cons.EXPECT().ClusterMembership().Return(membership, nil).Times(1)
+	s.cons.EXPECT().LatestUnsafePayload().Return(payload, nil).Times(1)
+
+	snapshot, err := s.conductor.BootstrapFollower(s.ctx, "SequencerB", "127.0.0.1:50051")
+	s.NoError(err)
+	s.Equal(membership, snapshot.Membership)
+	s.Equal(payload, snapshot.UnsafeHead)
+}
+
+type stubClusterLeader struct {
+	snapshot *conductorrpc.FollowerSnapshot
+	voters   []string
+}
+
+func (l *stubClusterLeader) BootstrapFollower(_ context.Context, _ string, _ string) (*conductorrpc.FollowerSnapshot, error) {
+	return l.snapshot, nil
+}
+
+func (l *stubClusterLeader) AddServerAsVoter(_ context.Context, id string, addr string, _ uint64) error {
+	l.voters = append(l.voters, id+"@"+addr)
+	return nil
+}
+
+func (s *OpConductorTestSuite) TestJoinCluster() {
+	payload := &eth.ExecutionPayloadEnvelope{ExecutionPayload: &eth.ExecutionPayload{BlockNumber: 10, BlockHash: [32]byte{1}}}
+	leader := &stubClusterLeader{snapshot: &conductorrpc.FollowerSnapshot{
+		Membership: &consensus.ClusterMembership{Version: 3},
+		UnsafeHead: payload,
+	}}
+	applied := &eth.BlockID{Number: 11, Hash: [32]byte{2}}
+	s.cons.EXPECT().ClusterMembership().Return(&consensus.ClusterMembership{}, nil).Times(1)
+	s.ctrl.EXPECT().PostUnsafePayload(mock.Anything, payload).Return(nil).Times(1)
+	// the engine is behind the applied unsafe head at first
+	s.cons.EXPECT().ClusterStatus().Return(&consensus.ClusterStatus{UnsafeHead: applied}, nil)
+	s.ctrl.EXPECT().LatestUnsafeBlock(mock.Anything).Return(&testutils.MockBlockInfo{InfoNum: 10, InfoHash: [32]byte{1}}, nil).Times(1)
+	s.ctrl.EXPECT().LatestUnsafeBlock(mock.Anything).Return(&testutils.MockBlockInfo{InfoNum: 11, InfoHash: [32]byte{2}}, nil).Times(1)
+
+	s.NoError(s.conductor.joinCluster(s.ctx, leader))
+	s.Equal([]string{"SequencerA@127.0.0.1:50050"}, leader.voters)
+	s.ctrl.AssertNumberOfCalls(s.T(), "LatestUnsafeBlock", 2)
+}
+
+func (s *OpConductorTestSuite) TestJoinClusterAlreadyVoter() {
+	leader := &stubClusterLeader{}
+	s.cons.EXPECT().ClusterMembership().Return(&consensus.ClusterMembership{Servers: []consensus.ServerInfo{{ID: "SequencerA", Suffrage: consensus.Voter}}}, nil).Times(1)
+
+	s.NoError(s.conductor.joinCluster(s.ctx, leader))
+	s.Empty(leader.voters)
+}
+
+func (s *OpConductorTestSuite) TestVerifyBootstrap() {
+	snapshot := &conductorrpc.FollowerSnapshot{UnsafeHead: &eth.ExecutionPayloadEnvelope{ExecutionPayload: &eth.ExecutionPayload{BlockNumber: 10, BlockHash: [32]byte{1}}}}
+
+	s.cons.EXPECT().ClusterStatus().Return(&consensus.ClusterStatus{UnsafeHead: &eth.BlockID{Number: 9}}, nil).Times(1)
+	s.ErrorIs(s.conductor.verifyBootstrap(s.ctx, snapshot), errBootstrapPending)
+
+	s.cons.EXPECT().ClusterStatus().Return(&consensus.ClusterStatus{UnsafeHead: &eth.BlockID{Number: 10, Hash: [32]byte{2}}}, nil).Times(1)
+	err := s.conductor.verifyBootstrap(s.ctx, snapshot)
+	s.ErrorContains(err, "does not match snapshot")
+	s.NotErrorIs(err, errBootstrapPending)
+
+	s.NoError(s.conductor.verifyBootstrap(s.ctx, &conductorrpc.FollowerSnapshot{}), "nothing to catch up with")
+}
+
 func TestControlLoop(t *testing.T) {
 	suite.Run(t, new(OpConductorTestSuite))
 }
@@ -1,6 +1,8 @@
 package consensus
 
 import (
+	"time"
+
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
@@ -38,6 +40,44 @@ type ServerInfo struct {
 	Suffrage ServerSuffrage `json:"suffrage"`
 }
 
+// MemberStatus reports the status of a cluster member, as observed by the local server.
+type MemberStatus struct {
+	ServerInfo
+	// Leader is true if the member is the current leader of the cluster.
+	Leader bool `json:"leader"`
+	// Reachable is false if the leader failed to heartbeat the member.
+	// Heartbeats are only tracked by the leader, so members are always reported reachable by followers.
+	Reachable bool `json:"reachable"`
+	// LastContact is the last time the leader heard from the member, if the member is unreachable.
+	LastContact *time.Time `json:"lastContact,omitempty"`
+	// LastCommittedUnsafeHead is the latest unsafe head committed to the cluster while the member was leader.
+	// Nil if the local server has not observed the member as leader.
+	LastCommittedUnsafeHead *eth.BlockID `json:"lastCommittedUnsafeHead,omitempty"`
+}
+
+// LeadershipTransfer is a change of cluster leadership observed by the local server.
+type LeadershipTransfer struct {
+	// From and To are the server IDs of the previous and the new leader. Empty if there was no known leader.
+	From string    `json:"from"`
+	To   string    `json:"to"`
+	Time time.Time `json:"time"`
+}
+
+// ClusterStatus reports the leadership and membership of the cluster, as observed by the local server.
+type ClusterStatus struct {
+	// ServerID is the server ID of the local server.
+	ServerID string `json:"serverID"`
+	// LeaderID is the server ID of the current leader. Empty if there is no known leader.
+	LeaderID string         `json:"leaderID"`
+	Members  []MemberStatus `json:"members"`
+	// Version is the version of the cluster membership configuration.
+	Version uint64 `json:"version"`
+	// UnsafeHead is the latest unsafe head applied by the local server.
+	UnsafeHead *eth.BlockID `json:"unsafeHead,omitempty"`
+	// LeadershipTransfers are the most recent leadership changes, oldest first.
+	LeadershipTransfers []LeadershipTransfer `json:"leadershipTransfers"`
+}
+
 // Consensus defines the consensus interface for leadership election.
 //
 //go:generate mockery --name Consensus --output mocks/ --with-expecter=true
@@ -68,6 +108,8 @@ type Consensus interface {
 	TransferLeaderTo(id, addr string) error
 	// ClusterMembership returns the current cluster membership configuration and associated version.
 	ClusterMembership() (*ClusterMembership, error)
+	// ClusterStatus returns the leadership and membership status of the cluster, as observed by the local server.
+	ClusterStatus() (*ClusterStatus, error)
 
 	// CommitPayload commits latest unsafe payload to the FSM in a strongly consistent fashion.
 	CommitUnsafePayload(payload *eth.ExecutionPayloadEnvelope) error
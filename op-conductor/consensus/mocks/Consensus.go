@@ -175,6 +175,63 @@ func (_c *Consensus_ClusterMembership_Call) RunAndReturn(run func() (*consensus.
 	return _c
 }
 
+// ClusterStatus provides a mock function with given fields:
+func (_m *Consensus) ClusterStatus() (*consensus.ClusterStatus, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClusterStatus")
+	}
+
+	var r0 *consensus.ClusterStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*consensus.ClusterStatus, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *consensus.ClusterStatus); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*consensus.ClusterStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Consensus_ClusterStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClusterStatus'
+type Consensus_ClusterStatus_Call struct {
+	*mock.Call
+}
+
+// ClusterStatus is a helper method to define mock.On call
+func (_e *Consensus_Expecter) ClusterStatus() *Consensus_ClusterStatus_Call {
+	return &Consensus_ClusterStatus_Call{Call: _e.mock.On("ClusterStatus")}
+}
+
+func (_c *Consensus_ClusterStatus_Call) Run(run func()) *Consensus_ClusterStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Consensus_ClusterStatus_Call) Return(_a0 *consensus.ClusterStatus, _a1 error) *Consensus_ClusterStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Consensus_ClusterStatus_Call) RunAndReturn(run func() (*consensus.ClusterStatus, error)) *Consensus_ClusterStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CommitUnsafePayload provides a mock function with given fields: payload
 func (_m *Consensus) CommitUnsafePayload(payload *eth.ExecutionPayloadEnvelope) error {
 	ret := _m.Called(payload)
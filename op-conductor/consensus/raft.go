@@ -6,6 +6,7 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/log"
@@ -30,6 +31,11 @@ type RaftConsensus struct {
 	r        *raft.Raft
 
 	unsafeTracker *unsafeHeadTracker
+
+	observer   *clusterObserver
+	observerCh chan raft.Observation
+	raftObs    *raft.Observer
+	closeObs   sync.Once
 }
 
 type RaftConsensusConfig struct {
@@ -126,12 +132,22 @@ func NewRaftConsensus(log log.Logger, cfg *RaftConsensusConfig) (*RaftConsensus,
 		}
 	}
 
+	// Observations are dropped rather than blocking raft if the observer falls behind.
+	observer := newClusterObserver(cfg.ServerID, fsm.UnsafeHead)
+	observerCh := make(chan raft.Observation, 64)
+	raftObs := raft.NewObserver(observerCh, false, nil)
+	r.RegisterObserver(raftObs)
+	go observer.run(observerCh)
+
 	return &RaftConsensus{
 		log:           log,
 		r:             r,
 		serverID:      raft.ServerID(cfg.ServerID),
 		unsafeTracker: fsm,
 		rollupCfg:     cfg.RollupCfg,
+		observer:      observer,
+		observerCh:    observerCh,
+		raftObs:       raftObs,
 	}, nil
 }
 
@@ -229,6 +245,10 @@ func (rc *RaftConsensus) TransferLeaderTo(id string, addr string) error {
 
 // Shutdown implements Consensus, it shuts down the consensus protocol client.
 func (rc *RaftConsensus) Shutdown() error {
+	rc.closeObs.Do(func() {
+		rc.r.DeregisterObserver(rc.raftObs)
+		close(rc.observerCh)
+	})
 	if err := rc.r.Shutdown().Error(); err != nil {
 		rc.log.Error("failed to shutdown raft", "err", err)
 		return err
@@ -283,3 +303,14 @@ func (rc *RaftConsensus) ClusterMembership() (*ClusterMembership, error) {
 		Version: future.Index(),
 	}, nil
 }
+
+// ClusterStatus implements Consensus, it returns the current cluster status, as observed by the local server.
+// Unlike LatestUnsafePayload, the reported unsafe head is the latest one applied locally, which may lag behind the cluster.
+func (rc *RaftConsensus) ClusterStatus() (*ClusterStatus, error) {
+	membership, err := rc.ClusterMembership()
+	if err != nil {
+		return nil, err
+	}
+	_, leaderID := rc.r.LeaderWithID()
+	return rc.observer.status(string(leaderID), membership), nil
+}
@@ -0,0 +1,120 @@
+package consensus
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hashicorp/raft"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// maxLeadershipTransfers is the number of recent leadership transfers reported in the cluster status.
+const maxLeadershipTransfers = 20
+
+// clusterObserver tracks the raft events needed to report the cluster status:
+// leadership changes, and the heartbeat failures of peers while the local server is leader.
+type clusterObserver struct {
+	serverID   string
+	unsafeHead func() *eth.ExecutionPayloadEnvelope
+	now        func() time.Time
+
+	mu          sync.Mutex
+	leaderID    string
+	unreachable map[string]time.Time // last contact of peers that failed to heartbeat
+	leaderHeads map[string]eth.BlockID
+	transfers   []LeadershipTransfer
+}
+
+func newClusterObserver(serverID string, unsafeHead func() *eth.ExecutionPayloadEnvelope) *clusterObserver {
+	return &clusterObserver{
+		serverID:    serverID,
+		unsafeHead:  unsafeHead,
+		now:         time.Now,
+		unreachable: make(map[string]time.Time),
+		leaderHeads: make(map[string]eth.BlockID),
+	}
+}
+
+// run processes observations until the channel is closed.
+func (o *clusterObserver) run(ch <-chan raft.Observation) {
+	for obs := range ch {
+		o.observe(obs.Data)
+	}
+}
+
+func (o *clusterObserver) observe(data any) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	switch data := data.(type) {
+	case raft.LeaderObservation:
+		leaderID := string(data.LeaderID)
+		if leaderID == o.leaderID {
+			return
+		}
+		// The unsafe head when leadership changes is the last one committed by the previous leader.
+		if head := o.currentUnsafeHead(); o.leaderID != "" && head != nil {
+			o.leaderHeads[o.leaderID] = *head
+		}
+		o.transfers = append(o.transfers, LeadershipTransfer{From: o.leaderID, To: leaderID, Time: o.now()})
+		if len(o.transfers) > maxLeadershipTransfers {
+			o.transfers = o.transfers[len(o.transfers)-maxLeadershipTransfers:]
+		}
+		o.leaderID = leaderID
+		if leaderID != o.serverID {
+			// Only the leader heartbeats its peers.
+			clear(o.unreachable)
+		}
+	case raft.FailedHeartbeatObservation:
+		o.unreachable[string(data.PeerID)] = data.LastContact
+	case raft.ResumedHeartbeatObservation:
+		delete(o.unreachable, string(data.PeerID))
+	case raft.PeerObservation:
+		if data.Removed {
+			delete(o.unreachable, string(data.Peer.ID))
+			delete(o.leaderHeads, string(data.Peer.ID))
+		}
+	}
+}
+
+func (o *clusterObserver) currentUnsafeHead() *eth.BlockID {
+	head := o.unsafeHead()
+	if head == nil {
+		return nil
+	}
+	id := head.ExecutionPayload.ID()
+	return &id
+}
+
+// status combines the observed events with the current cluster membership into the cluster status.
+func (o *clusterObserver) status(leaderID string, membership *ClusterMembership) *ClusterStatus {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	head := o.currentUnsafeHead()
+	members := make([]MemberStatus, 0, len(membership.Servers))
+	for _, srv := range membership.Servers {
+		member := MemberStatus{
+			ServerInfo: srv,
+			Leader:     srv.ID == leaderID,
+			Reachable:  true,
+		}
+		if lastContact, ok := o.unreachable[srv.ID]; ok {
+			member.Reachable = false
+			member.LastContact = &lastContact
+		}
+		if member.Leader {
+			member.LastCommittedUnsafeHead = head
+		} else if leaderHead, ok := o.leaderHeads[srv.ID]; ok {
+			member.LastCommittedUnsafeHead = &leaderHead
+		}
+		members = append(members, member)
+	}
+	return &ClusterStatus{
+		ServerID:            o.serverID,
+		LeaderID:            leaderID,
+		Members:             members,
+		Version:             membership.Version,
+		UnsafeHead:          head,
+		LeadershipTransfers: append([]LeadershipTransfer{}, o.transfers...),
+	}
+}
@@ -0,0 +1,71 @@
+package consensus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/hashicorp/raft"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+func TestClusterObserver(t *testing.T) {
+	var head *eth.ExecutionPayloadEnvelope
+	setHead := func(num uint64) eth.BlockID {
+		head = &eth.ExecutionPayloadEnvelope{ExecutionPayload: &eth.ExecutionPayload{
+			BlockNumber: eth.Uint64Quantity(num),
+			BlockHash:   common.Hash{byte(num)},
+		}}
+		return head.ExecutionPayload.ID()
+	}
+	now := time.Unix(1000, 0)
+	o := newClusterObserver("a", func() *eth.ExecutionPayloadEnvelope { return head })
+	o.now = func() time.Time { return now }
+	membership := &ClusterMembership{
+		Servers: []ServerInfo{
+			{ID: "a", Addr: "a:50050", Suffrage: Voter},
+			{ID: "b", Addr: "b:50050", Suffrage: Voter},
+			{ID: "c", Addr: "c:50050", Suffrage: Nonvoter},
+		},
+		Version: 3,
+	}
+
+	o.observe(raft.LeaderObservation{LeaderID: "b", LeaderAddr: "b:50050"})
+	headB := setHead(10)
+	now = now.Add(time.Minute)
+	o.observe(raft.LeaderObservation{LeaderID: "a", LeaderAddr: "a:50050"})
+	headA := setHead(12)
+	lastContact := now.Add(-5 * time.Second)
+	o.observe(raft.FailedHeartbeatObservation{PeerID: "c", LastContact: lastContact})
+
+	status := o.status("a", membership)
+	require.Equal(t, "a", status.ServerID)
+	require.Equal(t, "a", status.LeaderID)
+	require.Equal(t, uint64(3), status.Version)
+	require.Equal(t, &headA, status.UnsafeHead)
+	require.Equal(t, []MemberStatus{
+		{ServerInfo: membership.Servers[0], Leader: true, Reachable: true, LastCommittedUnsafeHead: &headA},
+		{ServerInfo: membership.Servers[1], Reachable: true, LastCommittedUnsafeHead: &headB},
+		{ServerInfo: membership.Servers[2], Reachable: false, LastContact: &lastContact},
+	}, status.Members)
+	require.Equal(t, []LeadershipTransfer{
+		{From: "", To: "b", Time: time.Unix(1000, 0)},
+		{From: "b", To: "a", Time: time.Unix(1060, 0)},
+	}, status.LeadershipTransfers)
+
+	o.observe(raft.ResumedHeartbeatObservation{PeerID: "c"})
+	require.True(t, o.status("a", membership).Members[2].Reachable, "member must be reachable after heartbeats resume")
+
+	o.observe(raft.FailedHeartbeatObservation{PeerID: "c", LastContact: lastContact})
+	o.observe(raft.LeaderObservation{LeaderID: "b", LeaderAddr: "b:50050"})
+	status = o.status("b", membership)
+	require.True(t, status.Members[2].Reachable, "followers must not report stale heartbeat failures")
+	require.Equal(t, &headA, status.Members[0].LastCommittedUnsafeHead)
+
+	for i := 0; i < maxLeadershipTransfers; i++ {
+		o.observe(raft.LeaderObservation{LeaderID: raft.ServerID([]string{"a", "b"}[i%2])})
+	}
+	require.Len(t, o.status("b", membership).LeadershipTransfers, maxLeadershipTransfers)
+}
@@ -80,4 +80,13 @@ func TestCommitAndRead(t *testing.T) {
 	unsafeHead, err := cons.LatestUnsafePayload()
 	require.NoError(t, err)
 	require.Equal(t, payload, unsafeHead)
+
+	status, err := cons.ClusterStatus()
+	require.NoError(t, err)
+	require.Equal(t, "SequencerA", status.LeaderID)
+	require.Len(t, status.Members, 1)
+	require.True(t, status.Members[0].Leader)
+	headID := payload.ExecutionPayload.ID()
+	require.Equal(t, &headID, status.UnsafeHead)
+	require.Equal(t, &headID, status.Members[0].LastCommittedUnsafeHead)
 }
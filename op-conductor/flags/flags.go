@@ -35,6 +35,17 @@ var (
 		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "RAFT_BOOTSTRAP"),
 		Value:   false,
 	}
+	RaftJoin = &cli.StringFlag{
+		Name:    "raft.join",
+		Usage:   "Conductor RPC endpoint of the cluster leader to join the cluster through. The node joins as a non-voter, and is promoted to voter once it caught up with the cluster",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "RAFT_JOIN"),
+	}
+	RaftJoinTimeout = &cli.DurationFlag{
+		Name:    "raft.join-timeout",
+		Usage:   "Maximum time to catch up with the cluster after joining it",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "RAFT_JOIN_TIMEOUT"),
+		Value:   10 * time.Minute,
+	}
 	RaftServerID = &cli.StringFlag{
 		Name:    "raft.server.id",
 		Usage:   "Unique ID for this server used by raft consensus",
@@ -100,6 +111,46 @@ var (
 		Usage:   "Minimum number of peers required to be considered healthy",
 		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "HEALTHCHECK_MIN_PEER_COUNT"),
 	}
+	HealthCheckScoreThreshold = &cli.Float64Flag{
+		Name:    "healthcheck.score-threshold",
+		Usage:   "Health score (0-100) below which a healthy leader is degraded and proactively transfers leadership. Disabled if 0",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "HEALTHCHECK_SCORE_THRESHOLD"),
+	}
+	HealthCheckUnsafeLagPenalty = &cli.Float64Flag{
+		Name:    "healthcheck.unsafe-lag-penalty",
+		Usage:   "Health score penalty per second that the unsafe head is behind now, beyond one block time",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "HEALTHCHECK_UNSAFE_LAG_PENALTY"),
+		Value:   10,
+	}
+	HealthCheckTargetPeerCount = &cli.Uint64Flag{
+		Name:    "healthcheck.target-peer-count",
+		Usage:   "Number of peers below which the health score is penalized for every missing peer",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "HEALTHCHECK_TARGET_PEER_COUNT"),
+	}
+	HealthCheckPeerPenalty = &cli.Float64Flag{
+		Name:    "healthcheck.peer-penalty",
+		Usage:   "Health score penalty per peer below the target peer count",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "HEALTHCHECK_PEER_PENALTY"),
+		Value:   5,
+	}
+	HealthCheckErrorPenalty = &cli.Float64Flag{
+		Name:    "healthcheck.error-penalty",
+		Usage:   "Health score penalty per failed health check within the error window",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "HEALTHCHECK_ERROR_PENALTY"),
+		Value:   20,
+	}
+	HealthCheckErrorWindow = &cli.Uint64Flag{
+		Name:    "healthcheck.error-window",
+		Usage:   "Number of recent health checks that failures are counted over for the health score",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "HEALTHCHECK_ERROR_WINDOW"),
+		Value:   10,
+	}
+	HealthCheckMinLeadershipDuration = &cli.DurationFlag{
+		Name:    "healthcheck.min-leadership-duration",
+		Usage:   "Minimum time a degraded leader holds leadership before transferring it, to prevent flapping",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "HEALTHCHECK_MIN_LEADERSHIP_DURATION"),
+		Value:   5 * time.Minute,
+	}
 	Paused = &cli.BoolFlag{
 		Name:    "paused",
 		Usage:   "Whether the conductor is paused",
@@ -130,8 +181,17 @@ var optionalFlags = []cli.Flag{
 	Paused,
 	RPCEnableProxy,
 	RaftBootstrap,
+	RaftJoin,
+	RaftJoinTimeout,
 	HealthCheckSafeEnabled,
 	HealthCheckSafeInterval,
+	HealthCheckScoreThreshold,
+	HealthCheckUnsafeLagPenalty,
+	HealthCheckTargetPeerCount,
+	HealthCheckPeerPenalty,
+	HealthCheckErrorPenalty,
+	HealthCheckErrorWindow,
+	HealthCheckMinLeadershipDuration,
 	RaftSnapshotInterval,
 	RaftSnapshotThreshold,
 	RaftTrailingLogs,
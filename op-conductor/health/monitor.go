@@ -3,6 +3,7 @@ package health
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sync"
 	"time"
 
@@ -35,7 +36,8 @@ type HealthMonitor interface {
 // interval is the interval between health checks measured in seconds.
 // safeInterval is the interval between safe head progress measured in seconds.
 // minPeerCount is the minimum number of peers required for the sequencer to be healthy.
-func NewSequencerHealthMonitor(log log.Logger, metrics metrics.Metricer, interval, unsafeInterval, safeInterval, minPeerCount uint64, safeEnabled bool, rollupCfg *rollup.Config, node dial.RollupClientInterface, p2p p2p.API) HealthMonitor {
+// scoreCfg configures the health score, which reports a healthy sequencer as degraded if it is too low.
+func NewSequencerHealthMonitor(log log.Logger, metrics metrics.Metricer, interval, unsafeInterval, safeInterval, minPeerCount uint64, safeEnabled bool, scoreCfg ScoreConfig, rollupCfg *rollup.Config, node dial.RollupClientInterface, p2p p2p.API) HealthMonitor {
 	return &SequencerHealthMonitor{
 		log:            log,
 		metrics:        metrics,
@@ -46,6 +48,8 @@ func NewSequencerHealthMonitor(log log.Logger, metrics metrics.Metricer, interva
 		safeEnabled:    safeEnabled,
 		safeInterval:   safeInterval,
 		minPeerCount:   minPeerCount,
+		scoreCfg:       scoreCfg,
+		errorHistory:   newErrorHistory(scoreCfg.ErrorWindow),
 		timeProviderFn: currentTimeProvicer,
 		node:           node,
 		p2p:            p2p,
@@ -69,6 +73,9 @@ type SequencerHealthMonitor struct {
 	lastSeenUnsafeNum  uint64
 	lastSeenUnsafeTime uint64
 
+	scoreCfg     ScoreConfig
+	errorHistory *errorHistory
+
 	timeProviderFn func() uint64
 
 	node dial.RollupClientInterface
@@ -118,7 +125,18 @@ func (hm *SequencerHealthMonitor) loop(ctx context.Context) {
 			return
 		case <-ticker.C:
 			err := hm.healthCheck(ctx)
-			hm.metrics.RecordHealthCheck(err == nil, err)
+			// A degraded sequencer is still healthy, its degradation is recorded separately.
+			// The degraded error is not used as label, as it includes the health score.
+			degraded := errors.Is(err, ErrSequencerDegraded)
+			if degraded {
+				hm.metrics.RecordHealthCheck(true, nil)
+			} else {
+				hm.metrics.RecordHealthCheck(err == nil, err)
+			}
+			hm.metrics.RecordHealthDegraded(degraded)
+			if hm.errorHistory != nil {
+				hm.errorHistory.add(err != nil && !degraded)
+			}
 			// Ensure that we exit cleanly if told to shutdown while still waiting to publish the health update
 			select {
 			case hm.healthUpdateCh <- err:
@@ -135,6 +153,7 @@ func (hm *SequencerHealthMonitor) loop(ctx context.Context) {
 // 2. unsafe head is not too far behind now (measured by unsafeInterval)
 // 3. safe head is progressing every configured batch submission interval
 // 4. peer count is above the configured minimum
+// If the sequencer is healthy, but its health score is below the configured threshold, it is reported as degraded.
 func (hm *SequencerHealthMonitor) healthCheck(ctx context.Context) error {
 	status, err := hm.node.SyncStatus(ctx)
 	if err != nil {
@@ -209,6 +228,26 @@ func (hm *SequencerHealthMonitor) healthCheck(ctx context.Context) error {
 		return ErrSequencerNotHealthy
 	}
 
+	if hm.scoreCfg.Enabled() {
+		var recentErrors uint64
+		if hm.errorHistory != nil {
+			recentErrors = hm.errorHistory.errors
+		}
+		score := hm.scoreCfg.Score(curUnsafeTimeDiff, hm.rollupCfg.BlockTime, uint64(stats.Connected), recentErrors)
+		hm.metrics.RecordHealthScore(score)
+		if score < hm.scoreCfg.Threshold {
+			hm.log.Warn(
+				"sequencer health score is below the threshold",
+				"score", score,
+				"threshold", hm.scoreCfg.Threshold,
+				"cur_unsafe_time_diff", curUnsafeTimeDiff,
+				"peer_count", stats.Connected,
+				"recent_errors", recentErrors,
+			)
+			return fmt.Errorf("%w: score %.1f", ErrSequencerDegraded, score)
+		}
+	}
+
 	hm.log.Info("sequencer is healthy")
 	return nil
 }
@@ -205,6 +205,66 @@ func (s *HealthMonitorTestSuite) TestHealthyWithUnsafeLag() {
 	s.NoError(monitor.Stop())
 }
 
+func (s *HealthMonitorTestSuite) TestDegradedLowHealthScore() {
+	s.T().Parallel()
+	now := uint64(time.Now().Unix())
+
+	rc := &testutils.MockRollupClient{}
+	// unsafe head is 5 seconds behind, which is healthy, but penalized beyond one block time.
+	ss1 := mockSyncStatus(now-5, 5, now-8, 1)
+	rc.ExpectSyncStatus(ss1, nil)
+
+	tp := &timeProvider{now: now}
+	pc := &p2pMocks.API{}
+	pc.EXPECT().PeerStats(mock.Anything).Return(&p2p.PeerStats{Connected: healthyPeerCount}, nil)
+	scoreCfg := ScoreConfig{
+		Threshold:        50,
+		UnsafeLagPenalty: 10,
+		TargetPeerCount:  healthyPeerCount + 6,
+		PeerPenalty:      5,
+	}
+	m := &healthCheckMetrics{}
+	monitor := &SequencerHealthMonitor{
+		log:            s.log,
+		interval:       s.interval,
+		metrics:        m,
+		healthUpdateCh: make(chan error),
+		rollupCfg:      s.rollupCfg,
+		unsafeInterval: 60,
+		safeInterval:   60,
+		minPeerCount:   s.minPeerCount,
+		scoreCfg:       scoreCfg,
+		errorHistory:   newErrorHistory(scoreCfg.ErrorWindow),
+		timeProviderFn: tp.Now,
+		node:           rc,
+		p2p:            pc,
+	}
+	s.NoError(monitor.Start(context.Background()))
+
+	// score = 100 - (5 - 2) * 10 - 6 * 5 = 40
+	healthy := <-monitor.Subscribe()
+	s.ErrorIs(healthy, ErrSequencerDegraded)
+
+	s.NoError(monitor.Stop())
+	s.NotEmpty(m.successes)
+	s.NotContains(m.successes, false, "degraded sequencer is recorded as healthy")
+	s.True(m.degraded)
+}
+
+type healthCheckMetrics struct {
+	metrics.NoopMetricsImpl
+	successes []bool
+	degraded  bool
+}
+
+func (m *healthCheckMetrics) RecordHealthCheck(success bool, err error) {
+	m.successes = append(m.successes, success)
+}
+
+func (m *healthCheckMetrics) RecordHealthDegraded(degraded bool) {
+	m.degraded = degraded
+}
+
 func mockSyncStatus(unsafeTime, unsafeNum, safeTime, safeNum uint64) *eth.SyncStatus {
 	return &eth.SyncStatus{
 		UnsafeL2: eth.L2BlockRef{
@@ -0,0 +1,93 @@
+package health
+
+import (
+	"errors"
+	"fmt"
+)
+
+// maxScore is the health score of a sequencer without any signs of degradation.
+const maxScore = 100
+
+var ErrSequencerDegraded = errors.New("sequencer is degraded")
+
+// ScoreConfig configures the health scoring of a sequencer that passes all health checks.
+// The score starts at 100, and is reduced by a penalty for every sign of degradation.
+// A sequencer with a score below the threshold is reported as degraded, but is still considered healthy.
+type ScoreConfig struct {
+	// Threshold is the score below which the sequencer is degraded. Scoring is disabled if 0.
+	Threshold float64
+
+	// UnsafeLagPenalty is the penalty per second that the unsafe head is behind now, beyond one block time.
+	UnsafeLagPenalty float64
+
+	// TargetPeerCount is the number of peers below which PeerPenalty is applied for every missing peer.
+	TargetPeerCount uint64
+
+	// PeerPenalty is the penalty per peer below the target peer count.
+	PeerPenalty float64
+
+	// ErrorPenalty is the penalty per failed health check within the last ErrorWindow health checks.
+	ErrorPenalty float64
+
+	// ErrorWindow is the number of recent health checks that failures are counted over.
+	ErrorWindow uint64
+}
+
+func (c *ScoreConfig) Enabled() bool {
+	return c.Threshold > 0
+}
+
+func (c *ScoreConfig) Check() error {
+	if !c.Enabled() {
+		return nil
+	}
+	if c.Threshold > maxScore {
+		return fmt.Errorf("score threshold %v must not be above %d", c.Threshold, maxScore)
+	}
+	if c.UnsafeLagPenalty < 0 || c.PeerPenalty < 0 || c.ErrorPenalty < 0 {
+		return errors.New("score penalties must not be negative")
+	}
+	if c.ErrorPenalty > 0 && c.ErrorWindow == 0 {
+		return errors.New("missing error window")
+	}
+	return nil
+}
+
+// Score returns the health score, given the current signs of degradation.
+func (c *ScoreConfig) Score(unsafeLag, blockTime, peerCount, recentErrors uint64) float64 {
+	score := float64(maxScore)
+	if unsafeLag > blockTime {
+		score -= float64(unsafeLag-blockTime) * c.UnsafeLagPenalty
+	}
+	if peerCount < c.TargetPeerCount {
+		score -= float64(c.TargetPeerCount-peerCount) * c.PeerPenalty
+	}
+	score -= float64(recentErrors) * c.ErrorPenalty
+	return max(score, 0)
+}
+
+// errorHistory tracks which of the most recent health checks failed.
+type errorHistory struct {
+	results []bool
+	next    int
+	errors  uint64
+}
+
+func newErrorHistory(window uint64) *errorHistory {
+	return &errorHistory{results: make([]bool, window)}
+}
+
+// add records the result of a health check, evicting the oldest result once the window is full.
+func (h *errorHistory) add(failed bool) {
+	if len(h.results) == 0 {
+		return
+	}
+	if h.results[h.next] {
+		h.errors--
+	}
+	h.results[h.next] = failed
+	if failed {
+		h.errors++
+	}
+	h.next = (h.next + 1) % len(h.results)
+}
@@ -0,0 +1,42 @@
+package health
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScore(t *testing.T) {
+	cfg := ScoreConfig{
+		Threshold:        50,
+		UnsafeLagPenalty: 10,
+		TargetPeerCount:  10,
+		PeerPenalty:      5,
+		ErrorPenalty:     20,
+		ErrorWindow:      3,
+	}
+	require.NoError(t, cfg.Check())
+
+	require.Equal(t, float64(100), cfg.Score(2, 2, 10, 0), "no penalty within one block time and at target peers")
+	require.Equal(t, float64(70), cfg.Score(5, 2, 10, 0))
+	require.Equal(t, float64(90), cfg.Score(0, 2, 8, 0))
+	require.Equal(t, float64(60), cfg.Score(0, 2, 20, 2))
+	require.Equal(t, float64(0), cfg.Score(100, 2, 0, 3), "score does not go below 0")
+
+	cfg.ErrorWindow = 0
+	require.Error(t, cfg.Check())
+	require.NoError(t, (&ScoreConfig{}).Check(), "disabled scoring is valid")
+}
+
+func TestErrorHistory(t *testing.T) {
+	h := newErrorHistory(3)
+	h.add(true)
+	h.add(false)
+	h.add(true)
+	require.Equal(t, uint64(2), h.errors)
+	h.add(false) // evicts the first failure
+	require.Equal(t, uint64(1), h.errors)
+	h.add(false)
+	h.add(false)
+	require.Equal(t, uint64(0), h.errors)
+}
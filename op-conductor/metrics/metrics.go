@@ -18,7 +18,11 @@ type Metricer interface {
 	RecordStartSequencer(success bool)
 	RecordStopSequencer(success bool)
 	RecordHealthCheck(success bool, err error)
+	RecordHealthScore(score float64)
+	RecordHealthDegraded(degraded bool)
 	RecordLoopExecutionTime(duration float64)
+
+	opmetrics.RPCServerMetricer
 }
 
 // Metrics implementation must implement RegistryMetricer to allow the metrics server to work.
@@ -38,7 +42,12 @@ type Metrics struct {
 	sequencerStops  *prometheus.CounterVec
 	stateChanges    *prometheus.CounterVec
 
+	healthScore    prometheus.Gauge
+	healthDegraded prometheus.Gauge
+
 	loopExecutionTime prometheus.Histogram
+
+	opmetrics.RPCServerMetrics
 }
 
 func (m *Metrics) Registry() *prometheus.Registry {
@@ -97,12 +106,24 @@ func NewMetrics() *Metrics {
 			"healthy",
 			"active",
 		}),
+		healthScore: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "health_score",
+			Help:      "Health score of the sequencer, from 0 to 100, if health scoring is enabled",
+		}),
+		healthDegraded: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "health_degraded",
+			Help:      "1 if the last healthcheck found the sequencer healthy, but its health score below the threshold",
+		}),
 		loopExecutionTime: factory.NewHistogram(prometheus.HistogramOpts{
 			Namespace: Namespace,
 			Name:      "loop_execution_time",
 			Help:      "Time (in seconds) to execute conductor loop iteration",
 			Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
 		}),
+
+		RPCServerMetrics: opmetrics.MakeRPCServerMetrics(Namespace, factory),
 	}
 }
 
@@ -131,6 +152,20 @@ func (m *Metrics) RecordHealthCheck(success bool, err error) {
 	m.healthChecks.WithLabelValues(strconv.FormatBool(success), errStr).Inc()
 }
 
+// RecordHealthScore sets the healthScore gauge.
+func (m *Metrics) RecordHealthScore(score float64) {
+	m.healthScore.Set(score)
+}
+
+// RecordHealthDegraded sets the healthDegraded gauge.
+func (m *Metrics) RecordHealthDegraded(degraded bool) {
+	if degraded {
+		m.healthDegraded.Set(1)
+	} else {
+		m.healthDegraded.Set(0)
+	}
+}
+
 // RecordLeaderTransfer increments the leaderTransfers counter.
 func (m *Metrics) RecordLeaderTransfer(success bool) {
 	m.leaderTransfers.WithLabelValues(strconv.FormatBool(success)).Inc()
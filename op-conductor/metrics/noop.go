@@ -1,6 +1,12 @@
 package metrics
 
-type NoopMetricsImpl struct{}
+import (
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+)
+
+type NoopMetricsImpl struct {
+	opmetrics.NoopRPCMetrics
+}
 
 var NoopMetrics Metricer = new(NoopMetricsImpl)
 
@@ -11,4 +17,6 @@ func (*NoopMetricsImpl) RecordLeaderTransfer(success bool)
 func (*NoopMetricsImpl) RecordStartSequencer(success bool)                        {}
 func (*NoopMetricsImpl) RecordStopSequencer(success bool)                         {}
 func (*NoopMetricsImpl) RecordHealthCheck(success bool, err error)                {}
+func (*NoopMetricsImpl) RecordHealthScore(score float64)                          {}
+func (*NoopMetricsImpl) RecordHealthDegraded(degraded bool)                       {}
 func (*NoopMetricsImpl) RecordLoopExecutionTime(duration float64)                 {}
@@ -49,6 +49,11 @@ type API interface {
 	TransferLeaderToServer(ctx context.Context, id string, addr string) error
 	// ClusterMembership returns the current cluster membership configuration.
 	ClusterMembership(ctx context.Context) (*consensus.ClusterMembership, error)
+	// BootstrapFollower adds a new follower to the cluster as a non-voter, and returns the snapshot of the cluster
+	// state the follower has to catch up with, before it is promoted to voter with AddServerAsVoter.
+	BootstrapFollower(ctx context.Context, id string, addr string) (*FollowerSnapshot, error)
+	// Status returns the status of the conductor and of its cluster. It is also served over HTTP by the StatusHandler.
+	Status(ctx context.Context) (*Status, error)
 
 	// APIs called by op-node
 	// Active returns true if op-conductor is active (not paused or stopped).
@@ -57,6 +62,22 @@ type API interface {
 	CommitUnsafePayload(ctx context.Context, payload *eth.ExecutionPayloadEnvelope) error
 }
 
+// Status reports the status of the conductor, and of the cluster it is a member of.
+type Status struct {
+	Paused           bool `json:"paused"`
+	Stopped          bool `json:"stopped"`
+	SequencerHealthy bool `json:"sequencerHealthy"`
+
+	Cluster *consensus.ClusterStatus `json:"cluster"`
+}
+
+// FollowerSnapshot is the cluster state that a new follower receives from the leader when joining the cluster.
+type FollowerSnapshot struct {
+	Membership *consensus.ClusterMembership `json:"membership"`
+	// UnsafeHead is the latest unsafe payload committed to the cluster. Nil if no payload was committed yet.
+	UnsafeHead *eth.ExecutionPayloadEnvelope `json:"unsafeHead,omitempty"`
+}
+
 // ExecutionProxyAPI defines the methods proxied to the execution rpc backend
 // This should include all methods that are called by op-batcher or op-proposer
 type ExecutionProxyAPI interface {
@@ -27,6 +27,8 @@ type conductor interface {
 	TransferLeaderToServer(ctx context.Context, id string, addr string) error
 	CommitUnsafePayload(ctx context.Context, payload *eth.ExecutionPayloadEnvelope) error
 	ClusterMembership(ctx context.Context) (*consensus.ClusterMembership, error)
+	ClusterStatus(ctx context.Context) (*consensus.ClusterStatus, error)
+	BootstrapFollower(ctx context.Context, id string, addr string) (*FollowerSnapshot, error)
 }
 
 // APIBackend is the backend implementation of the API.
@@ -142,3 +144,22 @@ func (api *APIBackend) SequencerHealthy(ctx context.Context) (bool, error) {
 func (api *APIBackend) ClusterMembership(ctx context.Context) (*consensus.ClusterMembership, error) {
 	return api.con.ClusterMembership(ctx)
 }
+
+// BootstrapFollower implements API.
+func (api *APIBackend) BootstrapFollower(ctx context.Context, id string, addr string) (*FollowerSnapshot, error) {
+	return api.con.BootstrapFollower(ctx, id, addr)
+}
+
+// Status implements API.
+func (api *APIBackend) Status(ctx context.Context) (*Status, error) {
+	cluster, err := api.con.ClusterStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Status{
+		Paused:           api.con.Paused(),
+		Stopped:          api.con.Stopped(),
+		SequencerHealthy: api.con.SequencerHealthy(ctx),
+		Cluster:          cluster,
+	}, nil
+}
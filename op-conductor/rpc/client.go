@@ -130,3 +130,17 @@ func (c *APIClient) ClusterMembership(ctx context.Context) (*consensus.ClusterMe
 	err := c.c.CallContext(ctx, &clusterMembership, prefixRPC("clusterMembership"))
 	return &clusterMembership, err
 }
+
+// BootstrapFollower implements API.
+func (c *APIClient) BootstrapFollower(ctx context.Context, id string, addr string) (*FollowerSnapshot, error) {
+	var snapshot FollowerSnapshot
+	err := c.c.CallContext(ctx, &snapshot, prefixRPC("bootstrapFollower"), id, addr)
+	return &snapshot, err
+}
+
+// Status implements API.
+func (c *APIClient) Status(ctx context.Context) (*Status, error) {
+	var status Status
+	err := c.c.CallContext(ctx, &status, prefixRPC("status"))
+	return &status, err
+}
@@ -0,0 +1,79 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	// StatusPath serves the status of the conductor and its cluster as a human-readable page.
+	StatusPath = "/status"
+	// StatusJSONPath serves the status of the conductor and its cluster as JSON, see Status.
+	StatusJSONPath = "/status.json"
+)
+
+const statusTimeout = 5 * time.Second
+
+var statusPage = template.Must(template.New("status").Parse(`<!DOCTYPE html>
+<html>
+<head><title>op-conductor {{.Cluster.ServerID}}</title></head>
+<body>
+<h1>op-conductor {{.Cluster.ServerID}}</h1>
+<p>Paused: {{.Paused}} &middot; Stopped: {{.Stopped}} &middot; Sequencer healthy: {{.SequencerHealthy}}</p>
+<p>Leader: {{if .Cluster.LeaderID}}{{.Cluster.LeaderID}}{{else}}none{{end}} &middot; Membership version: {{.Cluster.Version}} &middot; Unsafe head: {{with .Cluster.UnsafeHead}}{{.}}{{else}}none{{end}}</p>
+<h2>Members</h2>
+<table border="1">
+<tr><th>ID</th><th>Address</th><th>Suffrage</th><th>Leader</th><th>Reachable</th><th>Last contact</th><th>Last committed unsafe head</th></tr>
+{{range .Cluster.Members}}<tr><td>{{.ID}}</td><td>{{.Addr}}</td><td>{{.Suffrage}}</td><td>{{.Leader}}</td><td>{{.Reachable}}</td><td>{{with .LastContact}}{{.}}{{end}}</td><td>{{with .LastCommittedUnsafeHead}}{{.}}{{end}}</td></tr>
+{{end}}</table>
+<h2>Recent leadership transfers</h2>
+<table border="1">
+<tr><th>Time</th><th>From</th><th>To</th></tr>
+{{range .Cluster.LeadershipTransfers}}<tr><td>{{.Time}}</td><td>{{.From}}</td><td>{{.To}}</td></tr>
+{{end}}</table>
+</body>
+</html>
+`))
+
+// StatusHandler serves the read-only status of the conductor and its cluster over HTTP,
+// so operators can monitor the cluster without access to the admin RPC methods.
+type StatusHandler struct {
+	log log.Logger
+	api API
+}
+
+func NewStatusHandler(log log.Logger, api API) *StatusHandler {
+	return &StatusHandler{log: log, api: api}
+}
+
+func (h *StatusHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), statusTimeout)
+	defer cancel()
+	status, err := h.api.Status(ctx)
+	if err != nil {
+		h.log.Warn("Failed to get conductor status", "err", err)
+		http.Error(w, "failed to get status", http.StatusInternalServerError)
+		return
+	}
+	if r.URL.Path == StatusJSONPath {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(status); err != nil {
+			h.log.Warn("Failed to write conductor status", "err", err)
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := statusPage.Execute(w, status); err != nil {
+		h.log.Warn("Failed to render conductor status", "err", err)
+	}
+}
@@ -0,0 +1,80 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-conductor/consensus"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type stubStatusAPI struct {
+	API
+	status *Status
+	err    error
+}
+
+func (s *stubStatusAPI) Status(_ context.Context) (*Status, error) {
+	return s.status, s.err
+}
+
+func TestStatusHandler(t *testing.T) {
+	head := eth.BlockID{Hash: common.Hash{0xaa}, Number: 10}
+	status := &Status{
+		SequencerHealthy: true,
+		Cluster: &consensus.ClusterStatus{
+			ServerID: "sequencer-a",
+			LeaderID: "sequencer-a",
+			Members: []consensus.MemberStatus{
+				{ServerInfo: consensus.ServerInfo{ID: "sequencer-a", Addr: "a:50050"}, Leader: true, Reachable: true, LastCommittedUnsafeHead: &head},
+				{ServerInfo: consensus.ServerInfo{ID: "sequencer-b", Addr: "b:50050"}, Reachable: false},
+			},
+			Version:    2,
+			UnsafeHead: &head,
+		},
+	}
+	api := &stubStatusAPI{status: status}
+	handler := NewStatusHandler(testlog.Logger(t, log.LevelInfo), api)
+
+	t.Run("JSON", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, StatusJSONPath, nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		var result Status
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+		require.Equal(t, status, &result)
+	})
+
+	t.Run("Page", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, StatusPath, nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Contains(t, rec.Header().Get("Content-Type"), "text/html")
+		require.Contains(t, rec.Body.String(), "sequencer-b")
+		require.Contains(t, rec.Body.String(), head.String())
+	})
+
+	t.Run("ReadOnly", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, StatusJSONPath, nil))
+		require.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		api.err = errors.New("boom")
+		defer func() { api.err = nil }()
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, StatusJSONPath, nil))
+		require.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
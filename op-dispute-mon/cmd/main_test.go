@@ -234,6 +234,25 @@ func TestMaxConcurrency(t *testing.T) {
 	})
 }
 
+func TestOptimismPortalAddress(t *testing.T) {
+	t.Run("NotRequired", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Equal(t, common.Address{}, cfg.OptimismPortalAddress)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		addr := common.Address{0xbb}
+		cfg := configForArgs(t, addRequiredArgs("--optimism-portal-address", addr.Hex()))
+		require.Equal(t, addr, cfg.OptimismPortalAddress)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		verifyArgsInvalid(t,
+			"invalid optimism portal address: invalid address: 0xnope",
+			addRequiredArgs("--optimism-portal-address", "0xnope"))
+	})
+}
+
 func verifyArgsInvalid(t *testing.T, messageContains string, cliArgs []string) {
 	_, _, err := dryRunWithArgs(cliArgs)
 	require.ErrorContains(t, err, messageContains)
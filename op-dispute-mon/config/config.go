@@ -45,6 +45,10 @@ type Config struct {
 	IgnoredGames    []common.Address // Games to exclude from monitoring
 	MaxConcurrency  uint             // Maximum number of threads to use when fetching game data
 
+	// OptimismPortalAddress is the address of the OptimismPortal to check proven withdrawals for.
+	// Proven withdrawals are not checked if unset.
+	OptimismPortalAddress common.Address
+
 	MetricsConfig opmetrics.CLIConfig
 	PprofConfig   oppprof.CLIConfig
 }
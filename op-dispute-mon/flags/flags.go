@@ -71,6 +71,11 @@ var (
 		EnvVars: prefixEnvVars("MAX_CONCURRENCY"),
 		Value:   config.DefaultMaxConcurrency,
 	}
+	OptimismPortalAddressFlag = &cli.StringFlag{
+		Name:    "optimism-portal-address",
+		Usage:   "Address of the OptimismPortal contract. When set, withdrawals proven against invalid games are reported.",
+		EnvVars: prefixEnvVars("OPTIMISM_PORTAL_ADDRESS"),
+	}
 )
 
 // requiredFlags are checked by [CheckRequired]
@@ -88,6 +93,7 @@ var optionalFlags = []cli.Flag{
 	GameWindowFlag,
 	IgnoredGamesFlag,
 	MaxConcurrencyFlag,
+	OptimismPortalAddressFlag,
 }
 
 func init() {
@@ -147,6 +153,14 @@ func NewConfigFromCLI(ctx *cli.Context) (*config.Config, error) {
 		return nil, fmt.Errorf("%v must not be 0", MaxConcurrencyFlag.Name)
 	}
 
+	var portalAddress common.Address
+	if ctx.IsSet(OptimismPortalAddressFlag.Name) {
+		portalAddress, err = opservice.ParseAddress(ctx.String(OptimismPortalAddressFlag.Name))
+		if err != nil {
+			return nil, fmt.Errorf("invalid optimism portal address: %w", err)
+		}
+	}
+
 	metricsConfig := opmetrics.ReadCLIConfig(ctx)
 	pprofConfig := oppprof.ReadCLIConfig(ctx)
 
@@ -161,6 +175,8 @@ func NewConfigFromCLI(ctx *cli.Context) (*config.Config, error) {
 		IgnoredGames:    ignoredGames,
 		MaxConcurrency:  maxConcurrency,
 
+		OptimismPortalAddress: portalAddress,
+
 		MetricsConfig: metricsConfig,
 		PprofConfig:   pprofConfig,
 	}, nil
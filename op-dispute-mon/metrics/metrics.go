@@ -51,6 +51,60 @@ const (
 	CreditAboveNonWithdrawable
 )
 
+type WithdrawalStatus uint8
+
+const (
+	// WithdrawalLocked is a withdrawal request that is still within the DelayedWETH withdrawal delay.
+	WithdrawalLocked WithdrawalStatus = iota
+	// WithdrawalUnlocked is a withdrawal request past the withdrawal delay that can be paid from the DelayedWETH balance.
+	WithdrawalUnlocked
+	// WithdrawalBlocked is a withdrawal request past the withdrawal delay that exceeds the remaining DelayedWETH balance.
+	WithdrawalBlocked
+)
+
+func (s WithdrawalStatus) String() string {
+	switch s {
+	case WithdrawalLocked:
+		return "locked"
+	case WithdrawalUnlocked:
+		return "unlocked"
+	case WithdrawalBlocked:
+		return "blocked"
+	default:
+		panic(fmt.Errorf("unknown withdrawal status: %d", s))
+	}
+}
+
+type ProvenWithdrawalStatus uint8
+
+const (
+	// ProvenWithdrawalValid is a withdrawal proven against a game whose root claim is correct.
+	ProvenWithdrawalValid ProvenWithdrawalStatus = iota
+	// ProvenWithdrawalDisputed is a withdrawal proven against a game whose root claim is incorrect,
+	// but the game is forecast to resolve, or has resolved, in favour of the challenger.
+	ProvenWithdrawalDisputed
+	// ProvenWithdrawalInvalid is a withdrawal proven against a game whose root claim is incorrect
+	// and the game is forecast to resolve, or has resolved, in favour of the defender.
+	ProvenWithdrawalInvalid
+	// ProvenWithdrawalUnknownGame is a withdrawal proven against a game that is not being monitored.
+	ProvenWithdrawalUnknownGame
+)
+
+func (s ProvenWithdrawalStatus) String() string {
+	switch s {
+	case ProvenWithdrawalValid:
+		return "valid"
+	case ProvenWithdrawalDisputed:
+		return "disputed"
+	case ProvenWithdrawalInvalid:
+		return "invalid"
+	case ProvenWithdrawalUnknownGame:
+		return "unknown_game"
+	default:
+		panic(fmt.Errorf("unknown proven withdrawal status: %d", s))
+	}
+}
+
 type GameAgreementStatus uint8
 
 const (
@@ -169,6 +223,12 @@ type Metricer interface {
 
 	RecordWithdrawalRequests(delayedWeth common.Address, matches bool, count int)
 
+	RecordWithdrawalUnlocks(delayedWeth common.Address, status WithdrawalStatus, count int, amount *big.Int)
+
+	RecordHonestNextWithdrawalUnlocks(map[common.Address]uint64)
+
+	RecordProvenWithdrawals(status ProvenWithdrawalStatus, count int)
+
 	RecordOutputFetchTime(timestamp float64)
 
 	RecordGameAgreement(status GameAgreementStatus, count int)
@@ -179,6 +239,10 @@ type Metricer interface {
 
 	RecordIgnoredGames(count int)
 
+	RecordForecastResults(correct, invalid int)
+
+	RecordNextInvalidForecastResolution(timestamp uint64)
+
 	RecordBondCollateral(addr common.Address, required, available *big.Int)
 
 	RecordL2Challenges(agreement bool, count int)
@@ -207,7 +271,11 @@ type Metrics struct {
 	honestActorClaims prometheus.GaugeVec
 	honestActorBonds  prometheus.GaugeVec
 
-	withdrawalRequests prometheus.GaugeVec
+	withdrawalRequests      prometheus.GaugeVec
+	withdrawalUnlocks       prometheus.GaugeVec
+	withdrawalUnlockAmounts prometheus.GaugeVec
+	honestNextUnlocks       prometheus.GaugeVec
+	provenWithdrawals       prometheus.GaugeVec
 
 	info prometheus.GaugeVec
 	up   prometheus.Gauge
@@ -222,6 +290,8 @@ type Metrics struct {
 	latestProposals            prometheus.GaugeVec
 	ignoredGames               prometheus.Gauge
 	failedGames                prometheus.Gauge
+	forecastResults            prometheus.GaugeVec
+	nextInvalidResolution      prometheus.Gauge
 	l2Challenges               prometheus.GaugeVec
 
 	requiredCollateral  prometheus.GaugeVec
@@ -326,6 +396,36 @@ func NewMetrics() *Metrics {
 			"delayedWETH",
 			"credits",
 		}),
+		withdrawalUnlocks: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "withdrawal_unlocks",
+			Help:      "Number of pending withdrawal requests categorised by the source DelayedWETH contract and whether the withdrawal delay has passed and the request can be paid from the contract balance",
+		}, []string{
+			"delayedWETH",
+			"status",
+		}),
+		withdrawalUnlockAmounts: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "withdrawal_unlock_amount",
+			Help:      "Amount (ETH) of pending withdrawal requests categorised by the source DelayedWETH contract and whether the withdrawal delay has passed and the request can be paid from the contract balance",
+		}, []string{
+			"delayedWETH",
+			"status",
+		}),
+		honestNextUnlocks: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "honest_actor_next_withdrawal_unlock",
+			Help:      "Earliest time in unix seconds at which a locked withdrawal request of an honest actor unlocks, or 0 if there is none",
+		}, []string{
+			"actor",
+		}),
+		provenWithdrawals: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "proven_withdrawals",
+			Help:      "Number of withdrawals proven in the OptimismPortal categorised by the validity of the dispute game they were proven against",
+		}, []string{
+			"status",
+		}),
 		gamesAgreement: *factory.NewGaugeVec(prometheus.GaugeOpts{
 			Namespace: Namespace,
 			Name:      "games_agreement",
@@ -352,6 +452,16 @@ func NewMetrics() *Metrics {
 			Name:      "ignored_games",
 			Help:      "Number of games present in the game window but ignored via config",
 		}),
+		forecastResults: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "forecast_results",
+			Help:      "Number of in progress games forecast to resolve with the correct or an invalid result, assuming all clocks expire",
+		}, []string{"result"}),
+		nextInvalidResolution: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "next_invalid_forecast_resolution",
+			Help:      "Earliest time in unix seconds at which a game forecast to resolve with an invalid result becomes resolvable, or 0 if there is none",
+		}),
 		requiredCollateral: *factory.NewGaugeVec(prometheus.GaugeOpts{
 			Namespace: Namespace,
 			Name:      "bond_collateral_required",
@@ -491,6 +601,21 @@ func (m *Metrics) RecordWithdrawalRequests(delayedWeth common.Address, matches b
 	m.withdrawalRequests.WithLabelValues(delayedWeth.Hex(), credits).Set(float64(count))
 }
 
+func (m *Metrics) RecordWithdrawalUnlocks(delayedWeth common.Address, status WithdrawalStatus, count int, amount *big.Int) {
+	m.withdrawalUnlocks.WithLabelValues(delayedWeth.Hex(), status.String()).Set(float64(count))
+	m.withdrawalUnlockAmounts.WithLabelValues(delayedWeth.Hex(), status.String()).Set(weiToEther(amount))
+}
+
+func (m *Metrics) RecordHonestNextWithdrawalUnlocks(unlocks map[common.Address]uint64) {
+	for addr, unlock := range unlocks {
+		m.honestNextUnlocks.WithLabelValues(addr.Hex()).Set(float64(unlock))
+	}
+}
+
+func (m *Metrics) RecordProvenWithdrawals(status ProvenWithdrawalStatus, count int) {
+	m.provenWithdrawals.WithLabelValues(status.String()).Set(float64(count))
+}
+
 func (m *Metrics) Document() []opmetrics.DocumentedMetric {
 	return m.factory.Document()
 }
@@ -520,6 +645,15 @@ func (m *Metrics) RecordFailedGames(count int) {
 	m.failedGames.Set(float64(count))
 }
 
+func (m *Metrics) RecordForecastResults(correct, invalid int) {
+	m.forecastResults.WithLabelValues("correct").Set(float64(correct))
+	m.forecastResults.WithLabelValues("invalid").Set(float64(invalid))
+}
+
+func (m *Metrics) RecordNextInvalidForecastResolution(timestamp uint64) {
+	m.nextInvalidResolution.Set(float64(timestamp))
+}
+
 func (m *Metrics) RecordBondCollateral(addr common.Address, required, available *big.Int) {
 	balanceLabel := "sufficient"
 	zeroBalanceLabel := "insufficient"
@@ -34,6 +34,13 @@ func (*NoopMetricsImpl) RecordClaims(_ *ClaimStatuses) {}
 
 func (*NoopMetricsImpl) RecordWithdrawalRequests(_ common.Address, _ bool, _ int) {}
 
+func (*NoopMetricsImpl) RecordWithdrawalUnlocks(_ common.Address, _ WithdrawalStatus, _ int, _ *big.Int) {
+}
+
+func (*NoopMetricsImpl) RecordHonestNextWithdrawalUnlocks(map[common.Address]uint64) {}
+
+func (*NoopMetricsImpl) RecordProvenWithdrawals(_ ProvenWithdrawalStatus, _ int) {}
+
 func (*NoopMetricsImpl) RecordOutputFetchTime(_ float64) {}
 
 func (*NoopMetricsImpl) RecordGameAgreement(_ GameAgreementStatus, _ int) {}
@@ -46,6 +53,10 @@ func (*NoopMetricsImpl) RecordIgnoredGames(_ int) {}
 
 func (*NoopMetricsImpl) RecordFailedGames(_ int) {}
 
+func (*NoopMetricsImpl) RecordForecastResults(_, _ int) {}
+
+func (*NoopMetricsImpl) RecordNextInvalidForecastResolution(_ uint64) {}
+
 func (*NoopMetricsImpl) RecordBondCollateral(_ common.Address, _, _ *big.Int) {}
 
 func (*NoopMetricsImpl) RecordL2Challenges(_ bool, _ int) {}
@@ -2,7 +2,9 @@ package mon
 
 import (
 	"errors"
+	"time"
 
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum-optimism/optimism/op-dispute-mon/metrics"
 	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/transform"
@@ -20,6 +22,8 @@ type ForecastMetrics interface {
 	RecordLatestProposals(validTimestamp, invalidTimestamp uint64)
 	RecordIgnoredGames(count int)
 	RecordFailedGames(count int)
+	RecordForecastResults(correct, invalid int)
+	RecordNextInvalidForecastResolution(timestamp uint64)
 }
 
 type forecastBatch struct {
@@ -36,17 +40,26 @@ type forecastBatch struct {
 	LatestValidProposalL2Block uint64
 	LatestInvalidProposal      uint64
 	LatestValidProposal        uint64
+
+	// ForecastCorrect and ForecastInvalid count the in progress games by whether they are forecast
+	// to resolve with the correct result, assuming all clocks expire without further moves.
+	ForecastCorrect int
+	ForecastInvalid int
+	// NextInvalidResolution is the earliest time a game forecast to resolve with an invalid result becomes resolvable.
+	NextInvalidResolution uint64
 }
 
 type Forecast struct {
 	logger  log.Logger
 	metrics ForecastMetrics
+	clock   RClock
 }
 
-func NewForecast(logger log.Logger, metrics ForecastMetrics) *Forecast {
+func NewForecast(logger log.Logger, metrics ForecastMetrics, clock RClock) *Forecast {
 	return &Forecast{
 		logger:  logger,
 		metrics: metrics,
+		clock:   clock,
 	}
 }
 
@@ -74,6 +87,9 @@ func (f *Forecast) recordBatch(batch forecastBatch, ignoredCount, failedCount in
 	f.metrics.RecordLatestValidProposalL2Block(batch.LatestValidProposalL2Block)
 	f.metrics.RecordLatestProposals(batch.LatestValidProposal, batch.LatestInvalidProposal)
 
+	f.metrics.RecordForecastResults(batch.ForecastCorrect, batch.ForecastInvalid)
+	f.metrics.RecordNextInvalidForecastResolution(batch.NextInvalidResolution)
+
 	f.metrics.RecordIgnoredGames(ignoredCount)
 	f.metrics.RecordFailedGames(failedCount)
 }
@@ -122,47 +138,68 @@ func (f *Forecast) forecastGame(game *monTypes.EnrichedGameData, metrics *foreca
 		return nil
 	}
 
-	var forecastStatus types.GameStatus
-	// Games that have their block number challenged are won
-	// by the challenger since the counter is proven on-chain.
 	if game.BlockNumberChallenged {
 		f.logger.Debug("Found game with challenged block number",
 			"game", game.Proxy, "blockNum", game.L2BlockNumber, "agreement", agreement)
-		// If the block number is challenged the challenger will always win
-		forecastStatus = types.GameStatusChallengerWon
+	}
+	forecastStatus := forecastResult(game)
+
+	resolvableAt := f.resolvableAt(game)
+	if forecastStatus == expectedResult {
+		metrics.ForecastCorrect++
+		f.logger.Debug("Forecasting expected game result", "status", forecastStatus,
+			"game", game.Proxy, "blockNum", game.L2BlockNumber,
+			"rootClaim", game.RootClaim, "expected", expected, "resolvableAt", resolvableAt)
 	} else {
-		// Otherwise we go through the resolution process to determine who would win based on the current claims
-		tree := transform.CreateBidirectionalTree(game.Claims)
-		forecastStatus = Resolve(tree)
+		metrics.ForecastInvalid++
+		if metrics.NextInvalidResolution == 0 || uint64(resolvableAt.Unix()) < metrics.NextInvalidResolution {
+			metrics.NextInvalidResolution = uint64(resolvableAt.Unix())
+		}
+		f.logger.Warn("Forecasting unexpected game result", "status", forecastStatus,
+			"game", game.Proxy, "blockNum", game.L2BlockNumber,
+			"rootClaim", game.RootClaim, "expected", expected, "resolvableAt", resolvableAt)
 	}
 
-	if agreement {
+	switch {
+	case agreement && forecastStatus == types.GameStatusChallengerWon:
 		// If we agree with the output root proposal, the Defender should win, defending that claim.
-		if forecastStatus == types.GameStatusChallengerWon {
-			metrics.AgreeChallengerAhead++
-			f.logger.Warn("Forecasting unexpected game result", "status", forecastStatus,
-				"game", game.Proxy, "blockNum", game.L2BlockNumber,
-				"rootClaim", game.RootClaim, "expected", expected)
-		} else {
-			metrics.AgreeDefenderAhead++
-			f.logger.Debug("Forecasting expected game result", "status", forecastStatus,
-				"game", game.Proxy, "blockNum", game.L2BlockNumber,
-				"rootClaim", game.RootClaim, "expected", expected)
-		}
-	} else {
+		metrics.AgreeChallengerAhead++
+	case agreement:
+		metrics.AgreeDefenderAhead++
+	case forecastStatus == types.GameStatusDefenderWon:
 		// If we disagree with the output root proposal, the Challenger should win, challenging that claim.
-		if forecastStatus == types.GameStatusDefenderWon {
-			metrics.DisagreeDefenderAhead++
-			f.logger.Warn("Forecasting unexpected game result", "status", forecastStatus,
-				"game", game.Proxy, "blockNum", game.L2BlockNumber,
-				"rootClaim", game.RootClaim, "expected", expected)
-		} else {
-			metrics.DisagreeChallengerAhead++
-			f.logger.Debug("Forecasting expected game result", "status", forecastStatus,
-				"game", game.Proxy, "blockNum", game.L2BlockNumber,
-				"rootClaim", game.RootClaim, "expected", expected)
-		}
+		metrics.DisagreeDefenderAhead++
+	default:
+		metrics.DisagreeChallengerAhead++
 	}
 
 	return nil
 }
+
+// forecastResult returns the status an in progress game would resolve with if no further moves are made.
+func forecastResult(game *monTypes.EnrichedGameData) types.GameStatus {
+	// Games that have their block number challenged are won
+	// by the challenger since the counter is proven on-chain.
+	if game.BlockNumberChallenged {
+		return types.GameStatusChallengerWon
+	}
+	// Otherwise we go through the resolution process to determine who would win based on the current claims
+	tree := transform.CreateBidirectionalTree(game.Claims)
+	return Resolve(tree)
+}
+
+// resolvableAt returns the time at which the game becomes resolvable if no further moves are made:
+// when the chess clocks of all claims have expired, so none of them can be countered any more.
+func (f *Forecast) resolvableAt(game *monTypes.EnrichedGameData) time.Time {
+	now := f.clock.Now()
+	maxChessTime := time.Duration(game.MaxClockDuration) * time.Second
+	var remaining time.Duration
+	for _, claim := range game.Claims {
+		var parent faultTypes.Claim
+		if !claim.IsRoot() {
+			parent = game.Claims[claim.ParentContractIndex].Claim
+		}
+		remaining = max(remaining, maxChessTime-faultTypes.ChessClock(now, claim.Claim, parent))
+	}
+	return now.Add(remaining)
+}
@@ -4,11 +4,13 @@ import (
 	"math"
 	"math/big"
 	"testing"
+	"time"
 
 	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum-optimism/optimism/op-dispute-mon/metrics"
 	monTypes "github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
@@ -296,14 +298,47 @@ func TestForecast_Forecast_MultipleGames(t *testing.T) {
 	require.EqualValues(t, 8, m.latestValidProposalL2Block)
 	require.EqualValues(t, 7, m.latestInvalidProposal)
 	require.EqualValues(t, 8, m.latestValidProposal)
+	require.Equal(t, 2, m.forecastCorrect)
+	require.Equal(t, 2, m.forecastInvalid)
 }
 
+func TestForecast_Forecast_ResolvableAt(t *testing.T) {
+	forecast, m, logs := setupForecastTest(t)
+	maxClock := time.Hour
+	gameAt := func(claimTimes ...time.Duration) []monTypes.EnrichedClaim {
+		claims := createDeepClaimList()[:len(claimTimes)]
+		for i := range claims {
+			claims[i].Clock = faultTypes.NewClock(claimTimes[i], forecastTestTime.Add(-claimTimes[i]))
+		}
+		return claims
+	}
+	games := []*monTypes.EnrichedGameData{
+		// Invalid forecast: the root claim was posted 20 minutes ago and countered 10 minutes ago.
+		// The defender has 30 minutes left to respond, but the root claim can still be countered for 40 minutes.
+		{Status: types.GameStatusInProgress, MaxClockDuration: uint64(maxClock.Seconds()), Claims: gameAt(20*time.Minute, 10*time.Minute), AgreeWithClaim: true, ExpectedRootClaim: mockRootClaim},
+		// Invalid forecast: the root claim was posted 30 minutes ago and has not been countered.
+		{Status: types.GameStatusInProgress, MaxClockDuration: uint64(maxClock.Seconds()), Claims: gameAt(30 * time.Minute), AgreeWithClaim: false, ExpectedRootClaim: mockRootClaim},
+		// Correct forecast.
+		{Status: types.GameStatusInProgress, MaxClockDuration: uint64(maxClock.Seconds()), Claims: gameAt(5 * time.Minute), AgreeWithClaim: true, ExpectedRootClaim: mockRootClaim},
+	}
+	forecast.Forecast(games, 0, 0)
+	require.Equal(t, 1, m.forecastCorrect)
+	require.Equal(t, 2, m.forecastInvalid)
+	require.EqualValues(t, forecastTestTime.Add(30*time.Minute).Unix(), m.nextInvalidResolution)
+
+	l := logs.FindLog(testlog.NewLevelFilter(log.LevelWarn), testlog.NewMessageFilter(unexpectedResultLog))
+	require.NotNil(t, l)
+	require.Equal(t, forecastTestTime.Add(40*time.Minute), l.AttrValue("resolvableAt"))
+}
+
+var forecastTestTime = time.Unix(1_000_000, 0)
+
 func setupForecastTest(t *testing.T) (*Forecast, *mockForecastMetrics, *testlog.CapturingHandler) {
 	logger, capturedLogs := testlog.CaptureLogger(t, log.LvlDebug)
 	m := &mockForecastMetrics{
 		gameAgreement: zeroGameAgreement(),
 	}
-	return NewForecast(logger, m), m, capturedLogs
+	return NewForecast(logger, m, clock.NewDeterministicClock(forecastTestTime)), m, capturedLogs
 }
 
 func zeroGameAgreement() map[metrics.GameAgreementStatus]int {
@@ -326,6 +361,18 @@ type mockForecastMetrics struct {
 	latestInvalidProposal      uint64
 	latestValidProposal        uint64
 	contractCreationFails      int
+	forecastCorrect            int
+	forecastInvalid            int
+	nextInvalidResolution      uint64
+}
+
+func (m *mockForecastMetrics) RecordForecastResults(correct, invalid int) {
+	m.forecastCorrect = correct
+	m.forecastInvalid = invalid
+}
+
+func (m *mockForecastMetrics) RecordNextInvalidForecastResolution(timestamp uint64) {
+	m.nextInvalidResolution = timestamp
 }
 
 func (m *mockForecastMetrics) RecordFailedGames(count int) {
@@ -37,15 +37,16 @@ type gameMonitor struct {
 	gameWindow      time.Duration
 	monitorInterval time.Duration
 
-	forecast         ForecastResolution
-	bonds            Bonds
-	resolutions      Resolutions
-	claims           Monitor
-	withdrawals      Monitor
-	l2Challenges     Monitor
-	extract          Extract
-	fetchBlockHash   BlockHashFetcher
-	fetchBlockNumber BlockNumberFetcher
+	forecast          ForecastResolution
+	bonds             Bonds
+	resolutions       Resolutions
+	claims            Monitor
+	withdrawals       Monitor
+	provenWithdrawals Monitor
+	l2Challenges      Monitor
+	extract           Extract
+	fetchBlockHash    BlockHashFetcher
+	fetchBlockNumber  BlockNumberFetcher
 }
 
 func newGameMonitor(
@@ -60,28 +61,30 @@ func newGameMonitor(
 	resolutions Resolutions,
 	claims Monitor,
 	withdrawals Monitor,
+	provenWithdrawals Monitor,
 	l2Challenges Monitor,
 	extract Extract,
 	fetchBlockNumber BlockNumberFetcher,
 	fetchBlockHash BlockHashFetcher,
 ) *gameMonitor {
 	return &gameMonitor{
-		logger:           logger,
-		clock:            cl,
-		ctx:              ctx,
-		done:             make(chan struct{}),
-		metrics:          metrics,
-		monitorInterval:  monitorInterval,
-		gameWindow:       gameWindow,
-		forecast:         forecast,
-		bonds:            bonds,
-		resolutions:      resolutions,
-		claims:           claims,
-		withdrawals:      withdrawals,
-		l2Challenges:     l2Challenges,
-		extract:          extract,
-		fetchBlockNumber: fetchBlockNumber,
-		fetchBlockHash:   fetchBlockHash,
+		logger:            logger,
+		clock:             cl,
+		ctx:               ctx,
+		done:              make(chan struct{}),
+		metrics:           metrics,
+		monitorInterval:   monitorInterval,
+		gameWindow:        gameWindow,
+		forecast:          forecast,
+		bonds:             bonds,
+		resolutions:       resolutions,
+		claims:            claims,
+		withdrawals:       withdrawals,
+		provenWithdrawals: provenWithdrawals,
+		l2Challenges:      l2Challenges,
+		extract:           extract,
+		fetchBlockNumber:  fetchBlockNumber,
+		fetchBlockHash:    fetchBlockHash,
 	}
 }
 
@@ -106,6 +109,7 @@ func (m *gameMonitor) monitorGames() error {
 	m.bonds(enrichedGames)
 	m.claims(enrichedGames)
 	m.withdrawals(enrichedGames)
+	m.provenWithdrawals(enrichedGames)
 	m.l2Challenges(enrichedGames)
 	timeTaken := m.clock.Since(start)
 	m.metrics.RecordMonitorDuration(timeTaken)
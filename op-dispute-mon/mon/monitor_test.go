@@ -127,6 +127,7 @@ func setupMonitorTest(t *testing.T) (*gameMonitor, *mockExtractor, *mockForecast
 	resolutions := &mockResolutionMonitor{}
 	claims := &mockMonitor{}
 	withdrawals := &mockMonitor{}
+	provenWithdrawals := &mockMonitor{}
 	l2Challenges := &mockMonitor{}
 	monitor := newGameMonitor(
 		context.Background(),
@@ -140,6 +141,7 @@ func setupMonitorTest(t *testing.T) (*gameMonitor, *mockExtractor, *mockForecast
 		resolutions.CheckResolutions,
 		claims.Check,
 		withdrawals.Check,
+		provenWithdrawals.Check,
 		l2Challenges.Check,
 		extractor.Extract,
 		fetchBlockNum,
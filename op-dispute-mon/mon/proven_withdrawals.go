@@ -0,0 +1,143 @@
+package mon
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/metrics"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// maxProofLogRange is the maximum number of L1 blocks to fetch withdrawal proof logs for in a single request.
+const maxProofLogRange = 1000
+
+type ProvenWithdrawalMetrics interface {
+	RecordProvenWithdrawals(status metrics.ProvenWithdrawalStatus, count int)
+}
+
+type ProvenWithdrawalFetcher func(ctx context.Context, fromBlock uint64, toBlock uint64) ([]contracts.ProvenWithdrawal, error)
+
+type proofKey struct {
+	withdrawalHash common.Hash
+	proofSubmitter common.Address
+}
+
+// ProvenWithdrawalMonitor correlates the withdrawals proven in the OptimismPortal with the
+// validity of the dispute games they are proven against.
+// A withdrawal proven against a game with an invalid root claim that is forecast to resolve in favour of the
+// defender can be finalized once the game resolves, so is reported as an early warning of a bridge exploit.
+type ProvenWithdrawalMonitor struct {
+	ctx              context.Context
+	logger           log.Logger
+	metrics          ProvenWithdrawalMetrics
+	fetchBlockNumber BlockNumberFetcher
+	fetchProofs      ProvenWithdrawalFetcher
+
+	// lookback is the number of L1 blocks before the head to include proofs from.
+	lookback  uint64
+	proofs    map[proofKey]contracts.ProvenWithdrawal
+	lastBlock uint64
+}
+
+func NewProvenWithdrawalMonitor(ctx context.Context, logger log.Logger, metrics ProvenWithdrawalMetrics, fetchBlockNumber BlockNumberFetcher, fetchProofs ProvenWithdrawalFetcher, lookback uint64) *ProvenWithdrawalMonitor {
+	return &ProvenWithdrawalMonitor{
+		ctx:              ctx,
+		logger:           logger,
+		metrics:          metrics,
+		fetchBlockNumber: fetchBlockNumber,
+		fetchProofs:      fetchProofs,
+		lookback:         lookback,
+		proofs:           make(map[proofKey]contracts.ProvenWithdrawal),
+	}
+}
+
+func (m *ProvenWithdrawalMonitor) CheckProvenWithdrawals(games []*types.EnrichedGameData) {
+	if err := m.updateProofs(); err != nil {
+		// Continue with the proofs found so far, they are still worth checking against the latest game data.
+		m.logger.Error("Failed to update proven withdrawals", "err", err)
+	}
+	gamesByAddr := make(map[common.Address]*types.EnrichedGameData, len(games))
+	for _, game := range games {
+		gamesByAddr[game.Proxy] = game
+	}
+	counts := map[metrics.ProvenWithdrawalStatus]int{
+		metrics.ProvenWithdrawalValid:       0,
+		metrics.ProvenWithdrawalDisputed:    0,
+		metrics.ProvenWithdrawalInvalid:     0,
+		metrics.ProvenWithdrawalUnknownGame: 0,
+	}
+	for _, proof := range m.proofs {
+		game, ok := gamesByAddr[proof.DisputeGame]
+		if !ok {
+			counts[metrics.ProvenWithdrawalUnknownGame]++
+			m.logger.Warn("Withdrawal proven against unknown game", "withdrawalHash", proof.WithdrawalHash,
+				"proofSubmitter", proof.ProofSubmitter, "game", proof.DisputeGame, "l1Block", proof.L1BlockNumber)
+			continue
+		}
+		status := m.proofStatus(game)
+		counts[status]++
+		switch status {
+		case metrics.ProvenWithdrawalInvalid:
+			m.logger.Error("Withdrawal proven against invalid game forecast to resolve as valid", "withdrawalHash", proof.WithdrawalHash,
+				"proofSubmitter", proof.ProofSubmitter, "game", game.Proxy, "blockNum", game.L2BlockNumber,
+				"rootClaim", game.RootClaim, "correctClaim", game.ExpectedRootClaim, "status", game.Status)
+		case metrics.ProvenWithdrawalDisputed:
+			m.logger.Warn("Withdrawal proven against invalid game", "withdrawalHash", proof.WithdrawalHash,
+				"proofSubmitter", proof.ProofSubmitter, "game", game.Proxy, "blockNum", game.L2BlockNumber,
+				"rootClaim", game.RootClaim, "correctClaim", game.ExpectedRootClaim, "status", game.Status)
+		}
+	}
+	for status, count := range counts {
+		m.metrics.RecordProvenWithdrawals(status, count)
+	}
+}
+
+func (m *ProvenWithdrawalMonitor) proofStatus(game *types.EnrichedGameData) metrics.ProvenWithdrawalStatus {
+	if game.AgreeWithClaim {
+		return metrics.ProvenWithdrawalValid
+	}
+	result := game.Status
+	if result == gameTypes.GameStatusInProgress {
+		result = forecastResult(game)
+	}
+	if result == gameTypes.GameStatusDefenderWon {
+		return metrics.ProvenWithdrawalInvalid
+	}
+	return metrics.ProvenWithdrawalDisputed
+}
+
+// updateProofs fetches the proofs submitted since the last update, and drops proofs submitted before the lookback window.
+func (m *ProvenWithdrawalMonitor) updateProofs() error {
+	head, err := m.fetchBlockNumber(m.ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch block number: %w", err)
+	}
+	var minBlock uint64
+	if head > m.lookback {
+		minBlock = head - m.lookback
+	}
+	for key, proof := range m.proofs {
+		if proof.L1BlockNumber < minBlock {
+			delete(m.proofs, key)
+		}
+	}
+	from := max(m.lastBlock+1, minBlock)
+	for from <= head {
+		to := min(from+maxProofLogRange-1, head)
+		proofs, err := m.fetchProofs(m.ctx, from, to)
+		if err != nil {
+			return fmt.Errorf("failed to fetch proven withdrawals from block %v to %v: %w", from, to, err)
+		}
+		for _, proof := range proofs {
+			// A later proof by the same submitter replaces the earlier one.
+			m.proofs[proofKey{proof.WithdrawalHash, proof.ProofSubmitter}] = proof
+		}
+		m.lastBlock = to
+		from = to + 1
+	}
+	return nil
+}
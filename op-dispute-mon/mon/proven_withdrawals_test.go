@@ -0,0 +1,190 @@
+package mon
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/metrics"
+	monTypes "github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	validGame             = common.Address{0xa1}
+	disputedResolvedGame  = common.Address{0xa2}
+	disputedForecastGame  = common.Address{0xa3}
+	invalidResolvedGame   = common.Address{0xa4}
+	invalidForecastGame   = common.Address{0xa5}
+	blockChallengedGame   = common.Address{0xa6}
+	unmonitoredGame       = common.Address{0xa7}
+	provenWithdrawalGames = []*monTypes.EnrichedGameData{
+		{GameMetadata: types.GameMetadata{Proxy: validGame}, Status: types.GameStatusInProgress, AgreeWithClaim: true},
+		{GameMetadata: types.GameMetadata{Proxy: disputedResolvedGame}, Status: types.GameStatusChallengerWon},
+		{GameMetadata: types.GameMetadata{Proxy: disputedForecastGame}, Status: types.GameStatusInProgress, Claims: createDeepClaimList()[:2]},
+		{GameMetadata: types.GameMetadata{Proxy: invalidResolvedGame}, Status: types.GameStatusDefenderWon},
+		{GameMetadata: types.GameMetadata{Proxy: invalidForecastGame}, Status: types.GameStatusInProgress, Claims: createDeepClaimList()},
+		{GameMetadata: types.GameMetadata{Proxy: blockChallengedGame}, Status: types.GameStatusInProgress, Claims: createDeepClaimList(), BlockNumberChallenged: true},
+	}
+)
+
+func TestProvenWithdrawals_Correlation(t *testing.T) {
+	monitor, fetcher, m, logs := setupProvenWithdrawalsTest(t, 100)
+	fetcher.head = 50
+	fetcher.proofs = []contracts.ProvenWithdrawal{
+		{WithdrawalHash: common.Hash{0x01}, DisputeGame: validGame, L1BlockNumber: 10},
+		{WithdrawalHash: common.Hash{0x02}, DisputeGame: disputedResolvedGame, L1BlockNumber: 10},
+		{WithdrawalHash: common.Hash{0x03}, DisputeGame: disputedForecastGame, L1BlockNumber: 10},
+		{WithdrawalHash: common.Hash{0x04}, DisputeGame: invalidResolvedGame, L1BlockNumber: 10},
+		{WithdrawalHash: common.Hash{0x05}, DisputeGame: invalidForecastGame, L1BlockNumber: 10},
+		{WithdrawalHash: common.Hash{0x06}, DisputeGame: blockChallengedGame, L1BlockNumber: 10},
+		{WithdrawalHash: common.Hash{0x07}, DisputeGame: unmonitoredGame, L1BlockNumber: 10},
+	}
+	monitor.CheckProvenWithdrawals(provenWithdrawalGames)
+
+	require.Equal(t, map[metrics.ProvenWithdrawalStatus]int{
+		metrics.ProvenWithdrawalValid:       1,
+		metrics.ProvenWithdrawalDisputed:    3,
+		metrics.ProvenWithdrawalInvalid:     2,
+		metrics.ProvenWithdrawalUnknownGame: 1,
+	}, m.counts)
+
+	invalidLogs := logs.FindLogs(testlog.NewLevelFilter(log.LevelError), testlog.NewMessageFilter("Withdrawal proven against invalid game forecast to resolve as valid"))
+	require.Len(t, invalidLogs, 2)
+	games := []common.Address{invalidLogs[0].AttrValue("game").(common.Address), invalidLogs[1].AttrValue("game").(common.Address)}
+	require.ElementsMatch(t, []common.Address{invalidResolvedGame, invalidForecastGame}, games)
+	require.NotNil(t, logs.FindLog(testlog.NewLevelFilter(log.LevelWarn), testlog.NewMessageFilter("Withdrawal proven against unknown game")))
+}
+
+func TestProvenWithdrawals_Update(t *testing.T) {
+	t.Run("InitialLookback", func(t *testing.T) {
+		monitor, fetcher, _, _ := setupProvenWithdrawalsTest(t, 100)
+		fetcher.head = 2500
+		monitor.CheckProvenWithdrawals(nil)
+		require.Equal(t, [][2]uint64{{2400, 2500}}, fetcher.ranges)
+	})
+
+	t.Run("FromStartWhenLookbackBeforeGenesis", func(t *testing.T) {
+		monitor, fetcher, _, _ := setupProvenWithdrawalsTest(t, 100)
+		fetcher.head = 50
+		monitor.CheckProvenWithdrawals(nil)
+		require.Equal(t, [][2]uint64{{1, 50}}, fetcher.ranges)
+	})
+
+	t.Run("ChunkedRanges", func(t *testing.T) {
+		monitor, fetcher, _, _ := setupProvenWithdrawalsTest(t, 2500)
+		fetcher.head = 3000
+		monitor.CheckProvenWithdrawals(nil)
+		require.Equal(t, [][2]uint64{{500, 1499}, {1500, 2499}, {2500, 3000}}, fetcher.ranges)
+	})
+
+	t.Run("IncrementalUpdates", func(t *testing.T) {
+		monitor, fetcher, _, _ := setupProvenWithdrawalsTest(t, 100)
+		fetcher.head = 500
+		monitor.CheckProvenWithdrawals(nil)
+		fetcher.head = 510
+		monitor.CheckProvenWithdrawals(nil)
+		// No new blocks
+		monitor.CheckProvenWithdrawals(nil)
+		require.Equal(t, [][2]uint64{{400, 500}, {501, 510}}, fetcher.ranges)
+	})
+
+	t.Run("ReplacedProof", func(t *testing.T) {
+		monitor, fetcher, m, _ := setupProvenWithdrawalsTest(t, 100)
+		fetcher.head = 500
+		fetcher.proofs = []contracts.ProvenWithdrawal{
+			{WithdrawalHash: common.Hash{0x01}, ProofSubmitter: common.Address{0x01}, DisputeGame: invalidResolvedGame, L1BlockNumber: 450},
+		}
+		monitor.CheckProvenWithdrawals(provenWithdrawalGames)
+		require.Equal(t, 1, m.counts[metrics.ProvenWithdrawalInvalid])
+
+		fetcher.head = 510
+		fetcher.proofs = []contracts.ProvenWithdrawal{
+			{WithdrawalHash: common.Hash{0x01}, ProofSubmitter: common.Address{0x01}, DisputeGame: validGame, L1BlockNumber: 505},
+		}
+		monitor.CheckProvenWithdrawals(provenWithdrawalGames)
+		require.Equal(t, 0, m.counts[metrics.ProvenWithdrawalInvalid])
+		require.Equal(t, 1, m.counts[metrics.ProvenWithdrawalValid])
+	})
+
+	t.Run("PruneOldProofs", func(t *testing.T) {
+		monitor, fetcher, m, _ := setupProvenWithdrawalsTest(t, 100)
+		fetcher.head = 500
+		fetcher.proofs = []contracts.ProvenWithdrawal{
+			{WithdrawalHash: common.Hash{0x01}, DisputeGame: validGame, L1BlockNumber: 420},
+		}
+		monitor.CheckProvenWithdrawals(provenWithdrawalGames)
+		require.Equal(t, 1, m.counts[metrics.ProvenWithdrawalValid])
+
+		fetcher.head = 530
+		fetcher.proofs = nil
+		monitor.CheckProvenWithdrawals(provenWithdrawalGames)
+		require.Equal(t, 0, m.counts[metrics.ProvenWithdrawalValid])
+	})
+
+	t.Run("FetchErrorRetriesRange", func(t *testing.T) {
+		monitor, fetcher, m, logs := setupProvenWithdrawalsTest(t, 100)
+		fetcher.head = 500
+		fetcher.proofs = []contracts.ProvenWithdrawal{
+			{WithdrawalHash: common.Hash{0x01}, DisputeGame: invalidResolvedGame, L1BlockNumber: 450},
+		}
+		monitor.CheckProvenWithdrawals(provenWithdrawalGames)
+
+		fetcher.head = 510
+		fetcher.err = errors.New("boom")
+		monitor.CheckProvenWithdrawals(provenWithdrawalGames)
+		require.NotNil(t, logs.FindLog(testlog.NewLevelFilter(log.LevelError), testlog.NewMessageFilter("Failed to update proven withdrawals")))
+		// Existing proofs are still checked
+		require.Equal(t, 1, m.counts[metrics.ProvenWithdrawalInvalid])
+
+		fetcher.err = nil
+		monitor.CheckProvenWithdrawals(provenWithdrawalGames)
+		require.Equal(t, [][2]uint64{{400, 500}, {501, 510}, {501, 510}}, fetcher.ranges)
+	})
+}
+
+func setupProvenWithdrawalsTest(t *testing.T, lookback uint64) (*ProvenWithdrawalMonitor, *stubProofFetcher, *stubProvenWithdrawalMetrics, *testlog.CapturingHandler) {
+	logger, logs := testlog.CaptureLogger(t, log.LvlDebug)
+	fetcher := &stubProofFetcher{}
+	m := &stubProvenWithdrawalMetrics{counts: make(map[metrics.ProvenWithdrawalStatus]int)}
+	monitor := NewProvenWithdrawalMonitor(context.Background(), logger, m, fetcher.BlockNumber, fetcher.FetchProofs, lookback)
+	return monitor, fetcher, m, logs
+}
+
+type stubProofFetcher struct {
+	head   uint64
+	proofs []contracts.ProvenWithdrawal
+	ranges [][2]uint64
+	err    error
+}
+
+func (s *stubProofFetcher) BlockNumber(_ context.Context) (uint64, error) {
+	return s.head, nil
+}
+
+func (s *stubProofFetcher) FetchProofs(_ context.Context, fromBlock uint64, toBlock uint64) ([]contracts.ProvenWithdrawal, error) {
+	s.ranges = append(s.ranges, [2]uint64{fromBlock, toBlock})
+	if s.err != nil {
+		return nil, s.err
+	}
+	var proofs []contracts.ProvenWithdrawal
+	for _, proof := range s.proofs {
+		if proof.L1BlockNumber >= fromBlock && proof.L1BlockNumber <= toBlock {
+			proofs = append(proofs, proof)
+		}
+	}
+	return proofs, nil
+}
+
+type stubProvenWithdrawalMetrics struct {
+	counts map[metrics.ProvenWithdrawalStatus]int
+}
+
+func (s *stubProvenWithdrawalMetrics) RecordProvenWithdrawals(status metrics.ProvenWithdrawalStatus, count int) {
+	s.counts[status] = count
+}
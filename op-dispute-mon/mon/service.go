@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math/big"
 	"sync/atomic"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/bonds"
 	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
@@ -28,6 +29,9 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
 )
 
+// l1BlockTime is the L1 block time, used to convert the game window to a number of L1 blocks.
+const l1BlockTime = 12 * time.Second
+
 type Service struct {
 	logger       log.Logger
 	metrics      metrics.Metricer
@@ -38,14 +42,15 @@ type Service struct {
 
 	cl clock.Clock
 
-	extractor    *extract.Extractor
-	forecast     *Forecast
-	bonds        *bonds.Bonds
-	game         *extract.GameCallerCreator
-	resolutions  *ResolutionMonitor
-	claims       *ClaimMonitor
-	withdrawals  *WithdrawalMonitor
-	rollupClient *sources.RollupClient
+	extractor         *extract.Extractor
+	forecast          *Forecast
+	bonds             *bonds.Bonds
+	game              *extract.GameCallerCreator
+	resolutions       *ResolutionMonitor
+	claims            *ClaimMonitor
+	withdrawals       *WithdrawalMonitor
+	provenWithdrawals *ProvenWithdrawalMonitor
+	rollupClient      *sources.RollupClient
 
 	l1Client *ethclient.Client
 
@@ -91,6 +96,7 @@ func (s *Service) initFromConfig(ctx context.Context, cfg *config.Config) error
 	s.initClaimMonitor(cfg)
 	s.initResolutionMonitor()
 	s.initWithdrawalMonitor()
+	s.initProvenWithdrawalMonitor(ctx, cfg)
 
 	s.initGameCallerCreator() // Must be called before initForecast
 
@@ -116,7 +122,21 @@ func (s *Service) initResolutionMonitor() {
 }
 
 func (s *Service) initWithdrawalMonitor() {
-	s.withdrawals = NewWithdrawalMonitor(s.logger, s.metrics)
+	s.withdrawals = NewWithdrawalMonitor(s.logger, s.cl, s.honestActors, s.metrics)
+}
+
+func (s *Service) initProvenWithdrawalMonitor(ctx context.Context, cfg *config.Config) {
+	if cfg.OptimismPortalAddress == (common.Address{}) {
+		return
+	}
+	portal := contracts.NewOptimismPortalContract(s.metrics, cfg.OptimismPortalAddress,
+		batching.NewMultiCaller(s.l1Client.Client(), batching.DefaultBatchSize))
+	fetchProofs := func(ctx context.Context, fromBlock uint64, toBlock uint64) ([]contracts.ProvenWithdrawal, error) {
+		return portal.GetProvenWithdrawals(ctx, s.l1Client, fromBlock, toBlock)
+	}
+	// Proofs are only relevant while the games they are proven against are monitored.
+	lookback := uint64(cfg.GameWindow / l1BlockTime)
+	s.provenWithdrawals = NewProvenWithdrawalMonitor(ctx, s.logger, s.metrics, s.l1Client.BlockNumber, fetchProofs, lookback)
 }
 
 func (s *Service) initGameCallerCreator() {
@@ -141,7 +161,7 @@ func (s *Service) initExtractor(cfg *config.Config) {
 }
 
 func (s *Service) initForecast(cfg *config.Config) {
-	s.forecast = NewForecast(s.logger, s.metrics)
+	s.forecast = NewForecast(s.logger, s.metrics, s.cl)
 }
 
 func (s *Service) initBonds() {
@@ -174,6 +194,7 @@ func (s *Service) initPProf(cfg *oppprof.CLIConfig) error {
 		cfg.ProfileType,
 		cfg.ProfileDir,
 		cfg.ProfileFilename,
+		cfg.Upload,
 	)
 
 	if err := s.pprofService.Start(); err != nil {
@@ -217,6 +238,10 @@ func (s *Service) initMonitor(ctx context.Context, cfg *config.Config) {
 		return block.Hash(), nil
 	}
 	l2ChallengesMonitor := NewL2ChallengesMonitor(s.logger, s.metrics)
+	provenWithdrawals := func(games []*types.EnrichedGameData) {}
+	if s.provenWithdrawals != nil {
+		provenWithdrawals = s.provenWithdrawals.CheckProvenWithdrawals
+	}
 	s.monitor = newGameMonitor(
 		ctx,
 		s.logger,
@@ -229,6 +254,7 @@ func (s *Service) initMonitor(ctx context.Context, cfg *config.Config) {
 		s.resolutions.CheckResolutions,
 		s.claims.CheckClaims,
 		s.withdrawals.CheckWithdrawals,
+		provenWithdrawals,
 		l2ChallengesMonitor.CheckL2Challenges,
 		s.extractor.Extract,
 		s.l1Client.BlockNumber,
@@ -1,6 +1,11 @@
 package mon
 
 import (
+	"math/big"
+	"sort"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/metrics"
 	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
@@ -8,27 +13,45 @@ import (
 
 type WithdrawalMetrics interface {
 	RecordWithdrawalRequests(delayedWeth common.Address, matches bool, count int)
+	RecordWithdrawalUnlocks(delayedWeth common.Address, status metrics.WithdrawalStatus, count int, amount *big.Int)
+	RecordHonestNextWithdrawalUnlocks(map[common.Address]uint64)
 }
 
 type WithdrawalMonitor struct {
-	logger  log.Logger
-	metrics WithdrawalMetrics
+	logger       log.Logger
+	clock        RClock
+	honestActors types.HonestActors
+	metrics      WithdrawalMetrics
 }
 
-func NewWithdrawalMonitor(logger log.Logger, metrics WithdrawalMetrics) *WithdrawalMonitor {
+func NewWithdrawalMonitor(logger log.Logger, clock RClock, honestActors types.HonestActors, metrics WithdrawalMetrics) *WithdrawalMonitor {
 	return &WithdrawalMonitor{
-		logger:  logger,
-		metrics: metrics,
+		logger:       logger,
+		clock:        clock,
+		honestActors: honestActors,
+		metrics:      metrics,
 	}
 }
 
+// pendingWithdrawal is a withdrawal request that has not yet been withdrawn from DelayedWETH.
+type pendingWithdrawal struct {
+	game      common.Address
+	recipient common.Address
+	amount    *big.Int
+	unlock    uint64
+}
+
 func (w *WithdrawalMonitor) CheckWithdrawals(games []*types.EnrichedGameData) {
 	matching := make(map[common.Address]int)
 	divergent := make(map[common.Address]int)
+	pending := make(map[common.Address][]pendingWithdrawal)
+	balances := make(map[common.Address]*big.Int)
 	for _, game := range games {
 		matches, diverges := w.validateGameWithdrawals(game)
 		matching[game.WETHContract] += matches
 		divergent[game.WETHContract] += diverges
+		pending[game.WETHContract] = append(pending[game.WETHContract], pendingWithdrawals(game)...)
+		balances[game.WETHContract] = game.ETHCollateral
 	}
 	for contract, count := range matching {
 		w.metrics.RecordWithdrawalRequests(contract, true, count)
@@ -36,6 +59,7 @@ func (w *WithdrawalMonitor) CheckWithdrawals(games []*types.EnrichedGameData) {
 	for contract, count := range divergent {
 		w.metrics.RecordWithdrawalRequests(contract, false, count)
 	}
+	w.checkUnlocks(pending, balances)
 }
 
 func (w *WithdrawalMonitor) validateGameWithdrawals(game *types.EnrichedGameData) (int, int) {
@@ -51,3 +75,73 @@ func (w *WithdrawalMonitor) validateGameWithdrawals(game *types.EnrichedGameData
 	}
 	return matching, divergent
 }
+
+// checkUnlocks categorises the pending withdrawals of each DelayedWETH contract by whether their withdrawal delay has
+// passed, and whether the contract's balance can pay out the unlocked withdrawals in the order they unlocked.
+// Unlocked withdrawals that exceed the remaining balance are blocked.
+func (w *WithdrawalMonitor) checkUnlocks(pending map[common.Address][]pendingWithdrawal, balances map[common.Address]*big.Int) {
+	now := uint64(w.clock.Now().Unix())
+	honestNextUnlocks := make(map[common.Address]uint64)
+	for actor := range w.honestActors {
+		honestNextUnlocks[actor] = 0
+	}
+	for contract, withdrawals := range pending {
+		sort.Slice(withdrawals, func(i, j int) bool {
+			return withdrawals[i].unlock < withdrawals[j].unlock
+		})
+		counts := make(map[metrics.WithdrawalStatus]int)
+		amounts := map[metrics.WithdrawalStatus]*big.Int{
+			metrics.WithdrawalLocked:   big.NewInt(0),
+			metrics.WithdrawalUnlocked: big.NewInt(0),
+			metrics.WithdrawalBlocked:  big.NewInt(0),
+		}
+		remaining := new(big.Int)
+		if balance := balances[contract]; balance != nil {
+			remaining.Set(balance)
+		}
+		for _, withdrawal := range withdrawals {
+			status := metrics.WithdrawalLocked
+			if withdrawal.unlock <= now {
+				if remaining.Cmp(withdrawal.amount) >= 0 {
+					status = metrics.WithdrawalUnlocked
+					remaining.Sub(remaining, withdrawal.amount)
+				} else {
+					status = metrics.WithdrawalBlocked
+					w.logger.Error("Unlocked withdrawal exceeds DelayedWETH balance", "delayedWETH", contract, "game", withdrawal.game,
+						"recipient", withdrawal.recipient, "amount", withdrawal.amount, "remaining", remaining, "unlock", withdrawal.unlock)
+				}
+			}
+			counts[status]++
+			amounts[status].Add(amounts[status], withdrawal.amount)
+
+			if next, ok := honestNextUnlocks[withdrawal.recipient]; ok && status == metrics.WithdrawalLocked && (next == 0 || withdrawal.unlock < next) {
+				honestNextUnlocks[withdrawal.recipient] = withdrawal.unlock
+			}
+		}
+		for status, amount := range amounts {
+			w.metrics.RecordWithdrawalUnlocks(contract, status, counts[status], amount)
+		}
+	}
+	w.metrics.RecordHonestNextWithdrawalUnlocks(honestNextUnlocks)
+}
+
+// pendingWithdrawals returns the withdrawal requests of the game that have not been withdrawn.
+func pendingWithdrawals(game *types.EnrichedGameData) []pendingWithdrawal {
+	var pending []pendingWithdrawal
+	for recipient, request := range game.WithdrawalRequests {
+		if !isPending(request) {
+			continue
+		}
+		pending = append(pending, pendingWithdrawal{
+			game:      game.Proxy,
+			recipient: recipient,
+			amount:    request.Amount,
+			unlock:    request.Timestamp.Uint64() + uint64(game.WETHDelay.Seconds()),
+		})
+	}
+	return pending
+}
+
+func isPending(request *contracts.WithdrawalRequest) bool {
+	return request != nil && request.Amount != nil && request.Amount.Sign() > 0 && request.Timestamp != nil && request.Timestamp.Sign() > 0
+}
@@ -3,9 +3,12 @@ package mon
 import (
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/metrics"
 	monTypes "github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
@@ -61,11 +64,8 @@ func makeGames() []*monTypes.EnrichedGameData {
 
 func TestCheckWithdrawals(t *testing.T) {
 	logger := testlog.Logger(t, log.LvlInfo)
-	metrics := &stubWithdrawalsMetrics{
-		matching:  make(map[common.Address]int),
-		divergent: make(map[common.Address]int),
-	}
-	withdrawals := NewWithdrawalMonitor(logger, metrics)
+	metrics := newStubWithdrawalsMetrics()
+	withdrawals := NewWithdrawalMonitor(logger, clock.NewDeterministicClock(time.Unix(1000, 0)), nil, metrics)
 	withdrawals.CheckWithdrawals(makeGames())
 
 	require.Equal(t, metrics.matchCalls, 2)
@@ -82,11 +82,101 @@ func TestCheckWithdrawals(t *testing.T) {
 	require.Equal(t, metrics.divergent[weth2], 1)
 }
 
+func TestCheckWithdrawalUnlocks(t *testing.T) {
+	honest := common.Address{0x01}
+	other := common.Address{0x02}
+	request := func(amount int64, timestamp int64) *contracts.WithdrawalRequest {
+		return &contracts.WithdrawalRequest{Amount: big.NewInt(amount), Timestamp: big.NewInt(timestamp)}
+	}
+	games := []*monTypes.EnrichedGameData{
+		{
+			Credits: map[common.Address]*big.Int{honest: big.NewInt(5), other: big.NewInt(3)},
+			WithdrawalRequests: map[common.Address]*contracts.WithdrawalRequest{
+				honest: request(5, 800), // Unlocks at 900
+				other:  request(3, 850), // Unlocks at 950
+			},
+			WETHContract:  weth1,
+			WETHDelay:     100 * time.Second,
+			ETHCollateral: big.NewInt(7),
+		},
+		{
+			Credits: map[common.Address]*big.Int{honest: big.NewInt(2), other: big.NewInt(1)},
+			WithdrawalRequests: map[common.Address]*contracts.WithdrawalRequest{
+				honest: request(2, 950), // Unlocks at 1050
+				other:  request(0, 0),   // No withdrawal requested
+			},
+			WETHContract:  weth1,
+			WETHDelay:     100 * time.Second,
+			ETHCollateral: big.NewInt(7),
+		},
+		{
+			Credits: map[common.Address]*big.Int{other: big.NewInt(4)},
+			WithdrawalRequests: map[common.Address]*contracts.WithdrawalRequest{
+				other: request(4, 990), // Unlocks at 1000
+			},
+			WETHContract:  weth2,
+			WETHDelay:     10 * time.Second,
+			ETHCollateral: big.NewInt(4),
+		},
+	}
+	logger, logs := testlog.CaptureLogger(t, log.LvlInfo)
+	m := newStubWithdrawalsMetrics()
+	withdrawals := NewWithdrawalMonitor(logger, clock.NewDeterministicClock(time.Unix(1000, 0)), monTypes.NewHonestActors([]common.Address{honest}), m)
+	withdrawals.CheckWithdrawals(games)
+
+	// The first unlocked withdrawal is paid from the balance, leaving too little for the second one.
+	require.Equal(t, 1, m.unlockCounts[weth1][metrics.WithdrawalUnlocked])
+	require.Equal(t, big.NewInt(5), m.unlockAmounts[weth1][metrics.WithdrawalUnlocked])
+	require.Equal(t, 1, m.unlockCounts[weth1][metrics.WithdrawalBlocked])
+	require.Equal(t, big.NewInt(3), m.unlockAmounts[weth1][metrics.WithdrawalBlocked])
+	require.Equal(t, 1, m.unlockCounts[weth1][metrics.WithdrawalLocked])
+	require.Equal(t, big.NewInt(2), m.unlockAmounts[weth1][metrics.WithdrawalLocked])
+
+	// A withdrawal unlocks once the delay has passed.
+	require.Equal(t, 1, m.unlockCounts[weth2][metrics.WithdrawalUnlocked])
+	require.Equal(t, 0, m.unlockCounts[weth2][metrics.WithdrawalBlocked])
+	require.Equal(t, 0, m.unlockCounts[weth2][metrics.WithdrawalLocked])
+	require.Equal(t, big.NewInt(0), m.unlockAmounts[weth2][metrics.WithdrawalLocked])
+
+	require.Equal(t, map[common.Address]uint64{honest: 1050}, m.honestNextUnlocks)
+
+	levelFilter := testlog.NewLevelFilter(log.LevelError)
+	msgFilter := testlog.NewMessageFilter("Unlocked withdrawal exceeds DelayedWETH balance")
+	l := logs.FindLog(levelFilter, msgFilter)
+	require.NotNil(t, l)
+	require.Equal(t, other, l.AttrValue("recipient"))
+}
+
 type stubWithdrawalsMetrics struct {
-	matchCalls   int
-	divergeCalls int
-	matching     map[common.Address]int
-	divergent    map[common.Address]int
+	matchCalls        int
+	divergeCalls      int
+	matching          map[common.Address]int
+	divergent         map[common.Address]int
+	unlockCounts      map[common.Address]map[metrics.WithdrawalStatus]int
+	unlockAmounts     map[common.Address]map[metrics.WithdrawalStatus]*big.Int
+	honestNextUnlocks map[common.Address]uint64
+}
+
+func newStubWithdrawalsMetrics() *stubWithdrawalsMetrics {
+	return &stubWithdrawalsMetrics{
+		matching:      make(map[common.Address]int),
+		divergent:     make(map[common.Address]int),
+		unlockCounts:  make(map[common.Address]map[metrics.WithdrawalStatus]int),
+		unlockAmounts: make(map[common.Address]map[metrics.WithdrawalStatus]*big.Int),
+	}
+}
+
+func (s *stubWithdrawalsMetrics) RecordWithdrawalUnlocks(addr common.Address, status metrics.WithdrawalStatus, count int, amount *big.Int) {
+	if s.unlockCounts[addr] == nil {
+		s.unlockCounts[addr] = make(map[metrics.WithdrawalStatus]int)
+		s.unlockAmounts[addr] = make(map[metrics.WithdrawalStatus]*big.Int)
+	}
+	s.unlockCounts[addr][status] = count
+	s.unlockAmounts[addr][status] = amount
+}
+
+func (s *stubWithdrawalsMetrics) RecordHonestNextWithdrawalUnlocks(unlocks map[common.Address]uint64) {
+	s.honestNextUnlocks = unlocks
 }
 
 func (s *stubWithdrawalsMetrics) RecordWithdrawalRequests(addr common.Address, matches bool, count int) {
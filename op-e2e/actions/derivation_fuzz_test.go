@@ -0,0 +1,139 @@
+package actions
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// FuzzDerivationInputs submits mutated frames, channels and batches to L1, followed by the valid batcher data,
+// and feeds them through the derivation pipeline of two verifiers: one that derives after every L1 block,
+// and one that derives the whole L1 chain at once. It checks that:
+//   - the derivation pipeline does not panic,
+//   - both verifiers derive the same safe head and drop the same batches,
+//   - the invalid data does not stall the safe head for longer than the sequencing window,
+//   - the L2 chain of the sequencer becomes safe if the data was not mutated.
+//
+// Failing inputs are persisted to testdata/fuzz/FuzzDerivationInputs by the fuzzer,
+// and replayed as regression tests by go test.
+func FuzzDerivationInputs(f *testing.F) {
+	f.Add(false, uint8(MutateNothing), uint8(0), uint16(0), []byte{})
+	f.Add(true, uint8(MutateNothing), uint8(0), uint16(0), []byte{})
+	f.Add(false, uint8(MutateBatch), uint8(batchFieldParentHash), uint16(1), []byte{0xff})
+	f.Add(true, uint8(MutateBatch), uint8(batchFieldTimestamp), uint16(2), []byte{0xfe})
+	f.Add(false, uint8(MutateBatch), uint8(batchFieldTxBytes), uint16(0x0130), []byte{0x01, 0x02})
+	f.Add(true, uint8(MutateBatch), uint8(batchFieldDuplicateBatch), uint16(0), []byte{})
+	f.Add(false, uint8(MutateChannel), uint8(channelFieldRLPBytes), uint16(7), []byte{0x80})
+	f.Add(true, uint8(MutateChannel), uint8(channelFieldTruncateCompressed), uint16(40), []byte{})
+	f.Add(false, uint8(MutateFrame), uint8(frameFieldIsLast), uint16(0x0201), []byte{})
+	f.Add(true, uint8(MutateFrame), uint8(frameFieldReverseFrames), uint16(0x0100), []byte{})
+	f.Add(false, uint8(MutateFrame), uint8(frameFieldLength), uint16(0), []byte{0x00, 0x00, 0x10})
+	f.Fuzz(func(gt *testing.T, spanBatch bool, layer uint8, field uint8, index uint16, value []byte) {
+		mutation := NewDerivationMutation(layer, field, index, value)
+		t := NewDefaultTesting(gt)
+		p := &e2eutils.TestParams{
+			MaxSequencerDrift:   20,
+			SequencerWindowSize: 4,
+			ChannelTimeout:      4,
+			L1BlockTime:         12,
+		}
+		dp := e2eutils.MakeDeployParams(t, p)
+		sd := e2eutils.Setup(t, dp, defaultAlloc)
+		logger := testlog.Logger(t, log.LevelError)
+		miner, seqEngine, sequencer := setupSequencerTest(t, sd, logger)
+		_, verifier := setupVerifier(t, sd, logger, miner.L1Client(t, sd.RollupCfg), miner.BlobStore(), &sync.Config{})
+		verifEngineAtOnce, verifierAtOnce := setupVerifier(t, sd, logger, miner.L1Client(t, sd.RollupCfg), miner.BlobStore(), &sync.Config{})
+		batcher := NewL2Batcher(logger, sd.RollupCfg, DefaultBatcherCfg(dp),
+			sequencer.RollupClient(), miner.EthClient(), seqEngine.EthClient(), seqEngine.EngineClient(t, sd.RollupCfg))
+
+		deriveNext := func() {
+			verifier.ActL1HeadSignal(t)
+			verifier.ActL2PipelineFull(t)
+		}
+
+		// Build L2 blocks with user transactions, on top of the first L1 block.
+		sequencer.ActL2PipelineFull(t)
+		miner.ActEmptyBlock(t)
+		deriveNext()
+		sequencer.ActL1HeadSignal(t)
+		cl := seqEngine.EthClient()
+		signer := types.LatestSigner(sd.L2Cfg.Config)
+		for i := 0; i < 3; i++ {
+			n, err := cl.PendingNonceAt(t.Ctx(), dp.Addresses.Alice)
+			require.NoError(t, err)
+			tx := types.MustSignNewTx(dp.Secrets.Alice, signer, &types.DynamicFeeTx{
+				ChainID:   sd.L2Cfg.Config.ChainID,
+				Nonce:     n,
+				GasTipCap: big.NewInt(2 * params.GWei),
+				GasFeeCap: new(big.Int).Add(miner.l1Chain.CurrentBlock().BaseFee, big.NewInt(2*params.GWei)),
+				Gas:       params.TxGas,
+				To:        &dp.Addresses.Bob,
+				Value:     e2eutils.Ether(1),
+			})
+			require.NoError(t, cl.SendTransaction(t.Ctx(), tx))
+			sequencer.ActL2StartBlock(t)
+			seqEngine.ActL2IncludeTx(dp.Addresses.Alice)(t)
+			sequencer.ActL2EndBlock(t)
+		}
+		sequencer.ActBuildToL1Head(t)
+		unsafe := sequencer.L2Unsafe()
+
+		var blocks []*types.Block
+		for i := uint64(1); i <= unsafe.Number; i++ {
+			block, err := cl.BlockByNumber(t.Ctx(), new(big.Int).SetUint64(i))
+			require.NoError(t, err)
+			blocks = append(blocks, block)
+		}
+		payloads, err := MutatedBatcherData(sd.RollupCfg, blocks, spanBatch, mutation)
+		if err != nil {
+			gt.Skipf("mutation %v can't be encoded: %v", mutation, err)
+		}
+
+		// Submit the mutated data, followed by the valid data in the next L1 block.
+		for _, payload := range payloads {
+			batcher.ActL2BatchSubmitRaw(t, payload)
+		}
+		miner.ActL1StartBlock(12)(t)
+		for range payloads {
+			miner.ActL1IncludeTx(dp.Addresses.Batcher)(t)
+		}
+		miner.ActL1EndBlock(t)
+		deriveNext()
+
+		batcher.ActSubmitAll(t)
+		miner.ActL1StartBlock(12)(t)
+		miner.ActL1IncludeTx(dp.Addresses.Batcher)(t)
+		miner.ActL1EndBlock(t)
+		deriveNext()
+
+		// Expire the sequencing window of the L1 blocks carrying the data.
+		for i := uint64(0); i < sd.RollupCfg.SeqWindowSize; i++ {
+			miner.ActEmptyBlock(t)
+			deriveNext()
+		}
+		verifierAtOnce.ActL1HeadSignal(t)
+		verifierAtOnce.ActL2PipelineFull(t)
+
+		safe := verifier.L2Safe()
+		require.Equal(t, safe, verifierAtOnce.L2Safe(), "derivation must not depend on when the L1 blocks are processed, mutation: %v", mutation)
+		require.Equal(t, verifier.derivation.BatchDrops().Counts, verifierAtOnce.derivation.BatchDrops().Counts,
+			"batches must be dropped consistently, mutation: %v", mutation)
+		l1Head := miner.l1Chain.CurrentBlock().Number.Uint64()
+		require.GreaterOrEqual(t, safe.L1Origin.Number+sd.RollupCfg.SeqWindowSize, l1Head,
+			"safe head must not stall for longer than the sequencing window, mutation: %v", mutation)
+
+		if mutation.Layer == MutateNothing {
+			block, err := verifEngineAtOnce.EthClient().BlockByNumber(t.Ctx(), new(big.Int).SetUint64(unsafe.Number))
+			require.NoError(t, err)
+			require.Equal(t, unsafe.Hash, block.Hash(), "valid data must make the sequencer's chain safe")
+		}
+	})
+}
@@ -0,0 +1,328 @@
+package actions
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+)
+
+// MutationLayer is the layer of the batcher data encoding that a DerivationMutation is applied to.
+type MutationLayer uint8
+
+const (
+	MutateNothing MutationLayer = iota
+	// MutateBatch mutates the fields of the batches, before they are encoded into the channel.
+	MutateBatch
+	// MutateChannel mutates the channel data: the RLP encoded batches, or the compressed channel.
+	MutateChannel
+	// MutateFrame mutates the frames the channel is split into.
+	MutateFrame
+	numMutationLayers
+)
+
+// Fields of the batches mutated by MutateBatch.
+const (
+	batchFieldParentHash = iota
+	batchFieldEpochNum
+	batchFieldEpochHash
+	batchFieldTimestamp
+	batchFieldTxBytes
+	batchFieldDropTx
+	batchFieldDuplicateTx
+	batchFieldAppendTx
+	batchFieldDropBatch
+	batchFieldDuplicateBatch
+	batchFieldSwapBatches
+	numBatchFields
+)
+
+// Fields of the channel data mutated by MutateChannel.
+const (
+	channelFieldRLPBytes = iota
+	channelFieldTruncateRLP
+	channelFieldAppendRLP
+	channelFieldCompressedBytes
+	channelFieldTruncateCompressed
+	channelFieldAppendCompressed
+	numChannelFields
+)
+
+// Fields of the frames mutated by MutateFrame.
+const (
+	frameFieldChannelID = iota
+	frameFieldFrameNumber
+	frameFieldIsLast
+	frameFieldData
+	frameFieldDropFrame
+	frameFieldDuplicateFrame
+	frameFieldReverseFrames
+	frameFieldLength
+	frameFieldTrailingBytes
+	numFrameFields
+)
+
+// maxMutatedFrames is the max number of frames the channel is split into when mutating frames.
+const maxMutatedFrames = 3
+
+// DerivationMutation describes a single mutation of the batcher data submitted to L1, as decoded from fuzzer inputs.
+type DerivationMutation struct {
+	Layer MutationLayer
+	// Field selects the field of the layer that is mutated.
+	Field uint8
+	// Index selects which element of the field is mutated, e.g. the batch, transaction, frame or byte offset.
+	Index uint16
+	// Value is mixed into the mutated field.
+	Value []byte
+}
+
+func NewDerivationMutation(layer uint8, field uint8, index uint16, value []byte) DerivationMutation {
+	m := DerivationMutation{Layer: MutationLayer(layer % uint8(numMutationLayers)), Index: index, Value: value}
+	switch m.Layer {
+	case MutateBatch:
+		m.Field = field % numBatchFields
+	case MutateChannel:
+		m.Field = field % numChannelFields
+	case MutateFrame:
+		m.Field = field % numFrameFields
+	}
+	return m
+}
+
+func (m DerivationMutation) String() string {
+	return fmt.Sprintf("layer %d field %d index %d value %x", m.Layer, m.Field, m.Index, m.Value)
+}
+
+// delta returns the value as a small signed offset.
+func (m DerivationMutation) delta() int64 {
+	if len(m.Value) == 0 {
+		return 1
+	}
+	return int64(int8(m.Value[0]))
+}
+
+// MutatedBatcherData encodes the L2 blocks as batches into a single channel, applies the mutation,
+// and returns the calldata of the batcher transactions that carry the frames of the channel.
+// An error is returned if the mutated batches can't be encoded, e.g. a span batch with invalid transactions.
+func MutatedBatcherData(rollupCfg *rollup.Config, blocks []*types.Block, spanBatch bool, m DerivationMutation) ([][]byte, error) {
+	batches := make([]*derive.SingularBatch, 0, len(blocks))
+	seqNums := make([]uint64, 0, len(blocks))
+	for _, block := range blocks {
+		batch, l1Info, err := derive.BlockToSingularBatch(rollupCfg, block)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert block %d to batch: %w", block.NumberU64(), err)
+		}
+		batches = append(batches, batch)
+		seqNums = append(seqNums, l1Info.SequenceNumber)
+	}
+	if m.Layer == MutateBatch {
+		batches, seqNums = mutateBatches(batches, seqNums, m)
+	}
+
+	var rlpData bytes.Buffer
+	if spanBatch {
+		if err := encodeSpanBatch(&rlpData, rollupCfg, batches, seqNums); err != nil {
+			return nil, err
+		}
+	} else {
+		for _, batch := range batches {
+			if err := rlp.Encode(&rlpData, derive.NewBatchData(batch)); err != nil {
+				return nil, fmt.Errorf("failed to encode batch: %w", err)
+			}
+		}
+	}
+	uncompressed := rlpData.Bytes()
+	if m.Layer == MutateChannel && m.Field <= channelFieldAppendRLP {
+		uncompressed = mutateBytes(uncompressed, m.Field-channelFieldRLPBytes, m)
+	}
+
+	var compressed bytes.Buffer
+	zw := zlib.NewWriter(&compressed)
+	if _, err := zw.Write(uncompressed); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	channelData := compressed.Bytes()
+	if m.Layer == MutateChannel && m.Field >= channelFieldCompressedBytes {
+		channelData = mutateBytes(channelData, m.Field-channelFieldCompressedBytes, m)
+	}
+
+	numFrames := 1
+	if m.Layer == MutateFrame {
+		numFrames = 1 + int(m.Index>>8)%maxMutatedFrames
+	}
+	frames := splitFrames(derive.ChannelID{0xf0, 0x22}, channelData, numFrames)
+	var trailing []byte
+	if m.Layer == MutateFrame {
+		frames, trailing = mutateFrames(frames, m)
+	}
+
+	payloads := make([][]byte, 0, len(frames))
+	for i, frame := range frames {
+		var buf bytes.Buffer
+		buf.WriteByte(derive.DerivationVersion0)
+		if err := frame.MarshalBinary(&buf); err != nil {
+			return nil, fmt.Errorf("failed to encode frame: %w", err)
+		}
+		payload := buf.Bytes()
+		if m.Layer == MutateFrame && m.Field == frameFieldLength && i == int(m.Index)%len(frames) {
+			// The frame data length follows the version byte, channel ID and frame number.
+			xorInto(payload, 1+derive.ChannelIDLength+2, m.Value)
+		}
+		if i == len(frames)-1 {
+			payload = append(payload, trailing...)
+		}
+		payloads = append(payloads, payload)
+	}
+	return payloads, nil
+}
+
+func encodeSpanBatch(w *bytes.Buffer, rollupCfg *rollup.Config, batches []*derive.SingularBatch, seqNums []uint64) error {
+	// Span batches can only be built from batches ordered by timestamp.
+	order := make([]int, len(batches))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return batches[order[i]].Timestamp < batches[order[j]].Timestamp })
+	span := derive.NewSpanBatch(rollupCfg.Genesis.L2Time, rollupCfg.L2ChainID)
+	for _, i := range order {
+		if err := span.AppendSingularBatch(batches[i], seqNums[i]); err != nil {
+			return fmt.Errorf("failed to add batch to span batch: %w", err)
+		}
+	}
+	raw, err := span.ToRawSpanBatch()
+	if err != nil {
+		return fmt.Errorf("failed to convert span batch: %w", err)
+	}
+	if err := rlp.Encode(w, derive.NewBatchData(raw)); err != nil {
+		return fmt.Errorf("failed to encode span batch: %w", err)
+	}
+	return nil
+}
+
+func mutateBatches(batches []*derive.SingularBatch, seqNums []uint64, m DerivationMutation) ([]*derive.SingularBatch, []uint64) {
+	if len(batches) == 0 {
+		return batches, seqNums
+	}
+	i := int(m.Index) % len(batches)
+	batch := batches[i]
+	switch m.Field {
+	case batchFieldParentHash:
+		xorInto(batch.ParentHash[:], 0, m.Value)
+	case batchFieldEpochNum:
+		batch.EpochNum = rollup.Epoch(uint64(batch.EpochNum) + uint64(m.delta()))
+	case batchFieldEpochHash:
+		xorInto(batch.EpochHash[:], 0, m.Value)
+	case batchFieldTimestamp:
+		batch.Timestamp += uint64(m.delta())
+	case batchFieldTxBytes:
+		if len(batch.Transactions) > 0 {
+			j := int(m.Index>>8) % len(batch.Transactions)
+			batch.Transactions[j] = mutateBytes(batch.Transactions[j], 0, DerivationMutation{Index: m.Index >> 4, Value: m.Value})
+		}
+	case batchFieldDropTx:
+		if len(batch.Transactions) > 0 {
+			j := int(m.Index>>8) % len(batch.Transactions)
+			batch.Transactions = append(batch.Transactions[:j:j], batch.Transactions[j+1:]...)
+		}
+	case batchFieldDuplicateTx:
+		if len(batch.Transactions) > 0 {
+			j := int(m.Index>>8) % len(batch.Transactions)
+			batch.Transactions = append(batch.Transactions[:j+1:j+1], batch.Transactions[j:]...)
+		}
+	case batchFieldAppendTx:
+		batch.Transactions = append(batch.Transactions, hexutil.Bytes(common.CopyBytes(m.Value)))
+	case batchFieldDropBatch:
+		batches = append(batches[:i:i], batches[i+1:]...)
+		seqNums = append(seqNums[:i:i], seqNums[i+1:]...)
+	case batchFieldDuplicateBatch:
+		dup := *batch
+		batches = append(batches[:i+1:i+1], append([]*derive.SingularBatch{&dup}, batches[i+1:]...)...)
+		seqNums = append(seqNums[:i+1:i+1], seqNums[i:]...)
+	case batchFieldSwapBatches:
+		j := (i + 1) % len(batches)
+		batches[i], batches[j] = batches[j], batches[i]
+		seqNums[i], seqNums[j] = seqNums[j], seqNums[i]
+	}
+	return batches, seqNums
+}
+
+// mutateBytes flips, truncates or appends to the data, depending on the op: 0, 1 or 2 respectively.
+func mutateBytes(data []byte, op uint8, m DerivationMutation) []byte {
+	data = common.CopyBytes(data)
+	switch op {
+	case 0:
+		if len(data) > 0 {
+			xorInto(data, int(m.Index)%len(data), m.Value)
+		}
+	case 1:
+		if len(data) > 0 {
+			data = data[:int(m.Index)%len(data)]
+		}
+	case 2:
+		data = append(data, m.Value...)
+	}
+	return data
+}
+
+func splitFrames(id derive.ChannelID, data []byte, n int) []derive.Frame {
+	size := (len(data) + n - 1) / n
+	frames := make([]derive.Frame, 0, n)
+	for i := 0; i < n; i++ {
+		start, end := min(i*size, len(data)), min((i+1)*size, len(data))
+		frames = append(frames, derive.Frame{
+			ID:          id,
+			FrameNumber: uint16(i),
+			Data:        common.CopyBytes(data[start:end]),
+			IsLast:      i == n-1,
+		})
+	}
+	return frames
+}
+
+// mutateFrames mutates the frames, and returns the bytes to append to the frames in their batcher transaction.
+func mutateFrames(frames []derive.Frame, m DerivationMutation) ([]derive.Frame, []byte) {
+	i := int(m.Index) % len(frames)
+	frame := &frames[i]
+	switch m.Field {
+	case frameFieldChannelID:
+		xorInto(frame.ID[:], 0, m.Value)
+	case frameFieldFrameNumber:
+		frame.FrameNumber += uint16(m.delta())
+	case frameFieldIsLast:
+		frame.IsLast = !frame.IsLast
+	case frameFieldData:
+		frame.Data = mutateBytes(frame.Data, 0, m)
+	case frameFieldDropFrame:
+		frames = append(frames[:i:i], frames[i+1:]...)
+	case frameFieldDuplicateFrame:
+		frames = append(frames[:i+1:i+1], frames[i:]...)
+	case frameFieldReverseFrames:
+		for l, r := 0, len(frames)-1; l < r; l, r = l+1, r-1 {
+			frames[l], frames[r] = frames[r], frames[l]
+		}
+	case frameFieldTrailingBytes:
+		return frames, m.Value
+	}
+	return frames, nil
+}
+
+// xorInto xors value into data at the offset, for as far as data extends.
+func xorInto(data []byte, offset int, value []byte) {
+	for i, b := range value {
+		if offset+i >= len(data) {
+			return
+		}
+		data[offset+i] ^= b
+	}
+}
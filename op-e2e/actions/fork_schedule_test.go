@@ -0,0 +1,78 @@
+package actions
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// TestForkBoundaryTransitions activates each hard fork after genesis, with all earlier forks active at genesis,
+// and checks that the verifier derives the same chain as the sequencer across the fork boundary.
+func TestForkBoundaryTransitions(gt *testing.T) {
+	for _, fork := range e2eutils.ScheduledForks {
+		fork := fork
+		if fork == rollup.Interop {
+			// Interop requires a dependency set, and is covered by the interop tests.
+			continue
+		}
+		gt.Run(string(fork), func(gt *testing.T) {
+			ForkBoundaryTransition(gt, e2eutils.ForkSchedule{fork: 24})
+		})
+	}
+}
+
+// TestForkActivationSequence activates several hard forks after genesis, at different times.
+func TestForkActivationSequence(gt *testing.T) {
+	ForkBoundaryTransition(gt, e2eutils.ForkSchedule{
+		rollup.Ecotone: 12,
+		rollup.Fjord:   48,
+		rollup.Granite: 60,
+	})
+}
+
+// ForkBoundaryTransition runs a sequencer and verifier with the given fork schedule until all scheduled forks are
+// active, and checks that the verifier derives the same chain as the sequencer.
+func ForkBoundaryTransition(gt *testing.T, schedule e2eutils.ForkSchedule) {
+	t := NewDefaultTesting(gt)
+	dp := e2eutils.MakeDeployParams(t, defaultRollupTestParams)
+	require.NoError(t, e2eutils.ApplyForkSchedule(dp.DeployConfig, schedule))
+	log := testlog.Logger(t, log.LevelDebug)
+	require.NoError(t, dp.DeployConfig.Check(log), "fork schedule must result in a valid config")
+
+	sd := e2eutils.Setup(t, dp, defaultAlloc)
+	_, _, miner, sequencer, _, verifier, _, batcher := setupReorgTestActors(t, dp, sd, log)
+
+	var lastActivation uint64
+	for fork, offset := range schedule {
+		activation := e2eutils.ForkActivationTime(sd.RollupCfg, fork)
+		require.NotNil(t, activation, "fork %s must be scheduled in the rollup config", fork)
+		require.Equal(t, sd.RollupCfg.Genesis.L2Time+offset, *activation, "fork %s activation time", fork)
+		lastActivation = max(lastActivation, *activation)
+	}
+
+	// Build and batch the L2 chain until a few blocks after the last fork activation.
+	for sequencer.L2Unsafe().Time < lastActivation+2*sd.RollupCfg.BlockTime {
+		miner.ActEmptyBlock(t)
+		sequencer.ActL1HeadSignal(t)
+		sequencer.ActBuildToL1Head(t)
+
+		batcher.ActSubmitAll(t)
+		miner.ActL1StartBlock(12)(t)
+		miner.ActL1IncludeTx(dp.Addresses.Batcher)(t)
+		miner.ActL1EndBlock(t)
+	}
+
+	sequencer.ActL1HeadSignal(t)
+	sequencer.ActL2PipelineFull(t)
+	verifier.ActL1HeadSignal(t)
+	verifier.ActL2PipelineFull(t)
+
+	require.Greater(t, verifier.L2Safe().Time, lastActivation, "verifier must derive past the last fork activation")
+	require.Equal(t, sequencer.SyncStatus().SafeL2, verifier.SyncStatus().SafeL2, "verifier must derive the same chain")
+	require.Equal(t, sequencer.L2Unsafe(), verifier.L2Safe(), "verifier must derive all sequenced blocks")
+}
@@ -453,6 +453,12 @@ func (s *L2Batcher) ActL2BatchSubmitGarbage(t Testing, kind GarbageKind) {
 		t.Fatalf("Unexpected garbage kind: %v", kind)
 	}
 
+	s.ActL2BatchSubmitRaw(t, outputFrame)
+}
+
+// ActL2BatchSubmitRaw submits the given data as-is to the batch inbox, in a calldata transaction.
+// The data is not required to be a valid batcher transaction.
+func (s *L2Batcher) ActL2BatchSubmitRaw(t Testing, data []byte) {
 	nonce, err := s.l1.PendingNonceAt(t.Ctx(), s.batcherAddr)
 	require.NoError(t, err, "need batcher nonce")
 
@@ -467,7 +473,7 @@ func (s *L2Batcher) ActL2BatchSubmitGarbage(t Testing, kind GarbageKind) {
 		To:        &s.rollupCfg.BatchInboxAddress,
 		GasTipCap: gasTipCap,
 		GasFeeCap: gasFeeCap,
-		Data:      outputFrame,
+		Data:      data,
 	}
 	gas, err := core.IntrinsicGas(rawTx.Data, nil, false, true, true, false)
 	require.NoError(t, err, "need to compute intrinsic gas")
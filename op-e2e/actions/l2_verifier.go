@@ -10,6 +10,8 @@ import (
 	"golang.org/x/time/rate"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	gethevent "github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
 	gnode "github.com/ethereum/go-ethereum/node"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -49,8 +51,9 @@ type L2Verifier struct {
 	drainer event.Drainer
 
 	// L2 rollup
-	engine     *engine.EngineController
-	derivation *derive.DerivationPipeline
+	engine      *engine.EngineController
+	derivation  *derive.DerivationPipeline
+	attrHandler *attributes.AttributesHandler
 
 	safeHeadListener rollup.SafeHeadListener
 	syncCfg          *sync.Config
@@ -75,9 +78,11 @@ type L2API interface {
 	engine.Engine
 	L2BlockRefByNumber(ctx context.Context, num uint64) (eth.L2BlockRef, error)
 	InfoByHash(ctx context.Context, hash common.Hash) (eth.BlockInfo, error)
+	FetchReceipts(ctx context.Context, blockHash common.Hash) (eth.BlockInfo, types.Receipts, error)
 	// GetProof returns a proof of the account, it may return a nil result without error if the address was not found.
 	GetProof(ctx context.Context, address common.Address, storage []common.Hash, blockTag string) (*eth.AccountResult, error)
 	OutputV0AtBlock(ctx context.Context, blockHash common.Hash) (*eth.OutputV0, error)
+	InfoAndTxsByNumber(ctx context.Context, number uint64) (eth.BlockInfo, types.Transactions, error)
 }
 
 type safeDB interface {
@@ -109,7 +114,7 @@ func NewL2Verifier(t Testing, log log.Logger, l1 derive.L1Fetcher,
 	}
 
 	if interopBackend != nil {
-		sys.Register("interop", interop.NewInteropDeriver(log, cfg, ctx, interopBackend, eng), opts)
+		sys.Register("interop", interop.NewInteropDeriver(log, cfg, ctx, interopBackend, eng, nil), opts)
 	}
 
 	metrics := &testutils.TestDerivationMetrics{}
@@ -130,8 +135,8 @@ func NewL2Verifier(t Testing, log log.Logger, l1 derive.L1Fetcher,
 	}
 	sys.Register("finalizer", finalizer, opts)
 
-	sys.Register("attributes-handler",
-		attributes.NewAttributesHandler(log, cfg, ctx, eng), opts)
+	attrHandler := attributes.NewAttributesHandler(log, cfg, ctx, eng)
+	sys.Register("attributes-handler", attrHandler, opts)
 
 	pipeline := derive.NewDerivationPipeline(log, cfg, l1, blobsSrc, altDASrc, eng, metrics)
 	sys.Register("pipeline", derive.NewPipelineDeriver(ctx, pipeline), opts)
@@ -163,6 +168,7 @@ func NewL2Verifier(t Testing, log log.Logger, l1 derive.L1Fetcher,
 		eng:               eng,
 		engine:            ec,
 		derivation:        pipeline,
+		attrHandler:       attrHandler,
 		safeHeadListener:  safeHeadListener,
 		syncCfg:           syncCfg,
 		drainer:           executor,
@@ -218,6 +224,10 @@ func (s *l2VerifierBackend) ResetDerivationPipeline(ctx context.Context) error {
 	return nil
 }
 
+func (s *l2VerifierBackend) ResetDerivationPipelineTo(ctx context.Context, num uint64) error {
+	return errors.New("resetting the L2Verifier derivation to a block is not supported")
+}
+
 func (s *l2VerifierBackend) StartSequencer(ctx context.Context, blockHash common.Hash) error {
 	return nil
 }
@@ -234,6 +244,27 @@ func (s *l2VerifierBackend) OverrideLeader(ctx context.Context) error {
 	return nil
 }
 
+func (s *l2VerifierBackend) SubmitBuilderPayload(ctx context.Context, envelope *eth.ExecutionPayloadEnvelope) error {
+	return errors.New("submitting builder payloads to the L2Verifier sequencer is not supported")
+}
+
+func (s *l2VerifierBackend) SubscribeHeadUpdates(ch chan<- eth.HeadUpdate) gethevent.Subscription {
+	return s.verifier.syncStatus.SubscribeHeadUpdates(ch)
+}
+
+func (s *l2VerifierBackend) BatchDrops(ctx context.Context) (*derive.BatchDropReport, error) {
+	report := s.verifier.derivation.BatchDrops()
+	return &report, nil
+}
+
+func (s *l2VerifierBackend) AttributesMismatches(ctx context.Context) ([]attributes.AttributesMismatch, error) {
+	return s.verifier.attrHandler.Mismatches(), nil
+}
+
+func (s *l2VerifierBackend) EventTrace(ctx context.Context) (*event.TraceDump, error) {
+	return nil, errors.New("tracing the L2Verifier event system is not supported")
+}
+
 func (s *l2VerifierBackend) OnUnsafeL2Payload(ctx context.Context, envelope *eth.ExecutionPayloadEnvelope) error {
 	return nil
 }
@@ -0,0 +1,112 @@
+package e2eutils
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/ethereum-optimism/optimism/op-chain-ops/genesis"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+)
+
+// ScheduledForks are the L2 hard forks that can be scheduled with a ForkSchedule, in activation order.
+var ScheduledForks = []rollup.ForkName{
+	rollup.Regolith,
+	rollup.Canyon,
+	rollup.Delta,
+	rollup.Ecotone,
+	rollup.Fjord,
+	rollup.Granite,
+	rollup.Interop,
+}
+
+// ForkSchedule maps L2 hard forks to their activation time, in seconds after the L2 genesis block.
+// Forks that are not in the schedule activate together with the closest scheduled fork before them,
+// or at genesis if there is none. Forks after the last scheduled fork are not activated.
+// For example, {Ecotone: 12, Fjord: 48} activates Regolith, Canyon and Delta at genesis,
+// Ecotone at genesis+12, Fjord at genesis+48, and does not activate Granite.
+type ForkSchedule map[rollup.ForkName]uint64
+
+// ApplyForkSchedule configures the L2 hard fork activation times of the deploy config to match the schedule,
+// overriding the forks activated by default. The L2 genesis and rollup config created by Setup follow the schedule.
+func ApplyForkSchedule(deployConfig *genesis.DeployConfig, schedule ForkSchedule) error {
+	if len(schedule) == 0 {
+		return fmt.Errorf("fork schedule must contain at least one of %v", ScheduledForks)
+	}
+	for fork := range schedule {
+		if forkTimeOffset(deployConfig, fork) == nil {
+			return fmt.Errorf("fork %s cannot be scheduled", fork)
+		}
+	}
+	last := 0
+	for i, fork := range ScheduledForks {
+		if _, ok := schedule[fork]; ok {
+			last = i
+		}
+	}
+	var activation uint64
+	for i, fork := range ScheduledForks {
+		offset := forkTimeOffset(deployConfig, fork)
+		if i > last {
+			*offset = nil
+			continue
+		}
+		if t, ok := schedule[fork]; ok {
+			if t < activation {
+				return fmt.Errorf("fork %s at %d must not activate before earlier forks at %d", fork, t, activation)
+			}
+			activation = t
+		}
+		forkOffset := hexutil.Uint64(activation)
+		*offset = &forkOffset
+	}
+	return nil
+}
+
+// ForkActivationTime returns the activation timestamp of the L2 hard fork in the rollup config,
+// or nil if the fork is not scheduled.
+func ForkActivationTime(cfg *rollup.Config, fork rollup.ForkName) *uint64 {
+	switch fork {
+	case rollup.Regolith:
+		return cfg.RegolithTime
+	case rollup.Canyon:
+		return cfg.CanyonTime
+	case rollup.Delta:
+		return cfg.DeltaTime
+	case rollup.Ecotone:
+		return cfg.EcotoneTime
+	case rollup.Fjord:
+		return cfg.FjordTime
+	case rollup.Granite:
+		return cfg.GraniteTime
+	case rollup.Holocene:
+		return cfg.HoloceneTime
+	case rollup.Interop:
+		return cfg.InteropTime
+	default:
+		return nil
+	}
+}
+
+// forkTimeOffset returns the deploy config field holding the activation time offset of the L2 hard fork,
+// or nil if the fork cannot be configured in the deploy config.
+func forkTimeOffset(deployConfig *genesis.DeployConfig, fork rollup.ForkName) **hexutil.Uint64 {
+	switch fork {
+	case rollup.Regolith:
+		return &deployConfig.L2GenesisRegolithTimeOffset
+	case rollup.Canyon:
+		return &deployConfig.L2GenesisCanyonTimeOffset
+	case rollup.Delta:
+		return &deployConfig.L2GenesisDeltaTimeOffset
+	case rollup.Ecotone:
+		return &deployConfig.L2GenesisEcotoneTimeOffset
+	case rollup.Fjord:
+		return &deployConfig.L2GenesisFjordTimeOffset
+	case rollup.Granite:
+		return &deployConfig.L2GenesisGraniteTimeOffset
+	case rollup.Interop:
+		return &deployConfig.L2GenesisInteropTimeOffset
+	default:
+		return nil
+	}
+}
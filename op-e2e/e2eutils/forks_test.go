@@ -0,0 +1,59 @@
+package e2eutils
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-chain-ops/genesis"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+)
+
+func TestApplyForkSchedule(t *testing.T) {
+	offset := func(v uint64) *hexutil.Uint64 {
+		return (*hexutil.Uint64)(&v)
+	}
+
+	t.Run("FillEarlierForks", func(t *testing.T) {
+		deployConfig := &genesis.DeployConfig{}
+		deployConfig.L2GenesisGraniteTimeOffset = offset(0)
+		require.NoError(t, ApplyForkSchedule(deployConfig, ForkSchedule{rollup.Ecotone: 12, rollup.Granite: 48}))
+		require.Equal(t, offset(0), deployConfig.L2GenesisRegolithTimeOffset)
+		require.Equal(t, offset(0), deployConfig.L2GenesisCanyonTimeOffset)
+		require.Equal(t, offset(0), deployConfig.L2GenesisDeltaTimeOffset)
+		require.Equal(t, offset(12), deployConfig.L2GenesisEcotoneTimeOffset)
+		require.Equal(t, offset(12), deployConfig.L2GenesisFjordTimeOffset)
+		require.Equal(t, offset(48), deployConfig.L2GenesisGraniteTimeOffset)
+		require.Nil(t, deployConfig.L2GenesisInteropTimeOffset)
+	})
+
+	t.Run("DisableLaterForks", func(t *testing.T) {
+		deployConfig := &genesis.DeployConfig{}
+		deployConfig.L2GenesisFjordTimeOffset = offset(0)
+		deployConfig.L2GenesisGraniteTimeOffset = offset(0)
+		require.NoError(t, ApplyForkSchedule(deployConfig, ForkSchedule{rollup.Delta: 24}))
+		require.Equal(t, offset(24), deployConfig.L2GenesisDeltaTimeOffset)
+		require.Nil(t, deployConfig.L2GenesisEcotoneTimeOffset)
+		require.Nil(t, deployConfig.L2GenesisFjordTimeOffset)
+		require.Nil(t, deployConfig.L2GenesisGraniteTimeOffset)
+	})
+
+	t.Run("RejectOutOfOrder", func(t *testing.T) {
+		err := ApplyForkSchedule(&genesis.DeployConfig{}, ForkSchedule{rollup.Ecotone: 48, rollup.Fjord: 12})
+		require.ErrorContains(t, err, "must not activate before earlier forks")
+	})
+
+	t.Run("RejectUnsupportedFork", func(t *testing.T) {
+		require.ErrorContains(t, ApplyForkSchedule(&genesis.DeployConfig{}, ForkSchedule{rollup.Holocene: 12}), "cannot be scheduled")
+		require.Error(t, ApplyForkSchedule(&genesis.DeployConfig{}, ForkSchedule{}))
+	})
+}
+
+func TestForkActivationTime(t *testing.T) {
+	ecotone := uint64(12)
+	cfg := &rollup.Config{EcotoneTime: &ecotone}
+	require.Equal(t, &ecotone, ForkActivationTime(cfg, rollup.Ecotone))
+	require.Nil(t, ForkActivationTime(cfg, rollup.Fjord))
+	require.Nil(t, ForkActivationTime(cfg, rollup.Bedrock))
+}
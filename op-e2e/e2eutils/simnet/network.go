@@ -0,0 +1,267 @@
+// Package simnet simulates adverse network conditions between the P2P nodes of an op-e2e system.
+package simnet
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"sync"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+)
+
+// LinkConditions describes the conditions of the network link between two nodes.
+type LinkConditions struct {
+	// Latency delays every write to a stream over the link.
+	Latency time.Duration
+	// Bandwidth limits the bytes per second sent over the link. Unlimited if 0.
+	Bandwidth float64
+	// DropRate is the probability, between 0 and 1, that a gossip message sent over the link is dropped.
+	// Streams are reliable, so only gossip messages are dropped, and other protocols are not affected.
+	DropRate float64
+}
+
+func (c LinkConditions) Check() error {
+	if c.Latency < 0 {
+		return errors.New("latency cannot be negative")
+	}
+	if c.Bandwidth < 0 {
+		return errors.New("bandwidth cannot be negative")
+	}
+	if c.DropRate < 0 || c.DropRate > 1 {
+		return fmt.Errorf("drop rate must be between 0 and 1, was %f", c.DropRate)
+	}
+	return nil
+}
+
+// pair is an unordered pair of node names.
+type pair struct {
+	a, b string
+}
+
+func newPair(a, b string) pair {
+	if a > b {
+		a, b = b, a
+	}
+	return pair{a: a, b: b}
+}
+
+// Network manages the links between the named nodes of a mocknet, to simulate latency, bandwidth limits,
+// dropped gossip messages and network partitions.
+// Nodes are added with AddPeer, and should use the GossipOption of the network to apply the gossip drop rate.
+type Network struct {
+	net mocknet.Mocknet
+
+	mu    sync.Mutex
+	rng   *rand.Rand
+	peers map[string]peer.ID
+	names map[peer.ID]string
+	// links are the linked nodes, with the conditions of their link.
+	links map[pair]LinkConditions
+	// connected are the linked nodes that were connected, to restore the connections when healing a partition.
+	connected map[pair]struct{}
+	// cut are the links that are removed by a partition.
+	cut map[pair]struct{}
+}
+
+func New(net mocknet.Mocknet) *Network {
+	return &Network{
+		net:       net,
+		rng:       rand.New(rand.NewSource(time.Now().UnixNano())),
+		peers:     make(map[string]peer.ID),
+		names:     make(map[peer.ID]string),
+		links:     make(map[pair]LinkConditions),
+		connected: make(map[pair]struct{}),
+		cut:       make(map[pair]struct{}),
+	}
+}
+
+// AddPeer registers the mocknet host of the named node.
+func (n *Network) AddPeer(name string, h host.Host) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.peers[name] = h.ID()
+	n.names[h.ID()] = name
+}
+
+func (n *Network) peerIDs(a, b string) (peer.ID, peer.ID, error) {
+	idA, ok := n.peers[a]
+	if !ok {
+		return "", "", fmt.Errorf("unknown node %s", a)
+	}
+	idB, ok := n.peers[b]
+	if !ok {
+		return "", "", fmt.Errorf("unknown node %s", b)
+	}
+	return idA, idB, nil
+}
+
+// Link creates a link between the nodes, which allows them to connect.
+func (n *Network) Link(a, b string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	idA, idB, err := n.peerIDs(a, b)
+	if err != nil {
+		return err
+	}
+	if _, err := n.net.LinkPeers(idA, idB); err != nil {
+		return fmt.Errorf("failed to link %s and %s: %w", a, b, err)
+	}
+	p := newPair(a, b)
+	cond := n.links[p]
+	n.links[p] = cond
+	n.applyLinkOptions(idA, idB, cond)
+	return nil
+}
+
+// Connect connects the linked nodes.
+func (n *Network) Connect(a, b string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	idA, idB, err := n.peerIDs(a, b)
+	if err != nil {
+		return err
+	}
+	if _, err := n.net.ConnectPeers(idA, idB); err != nil {
+		return fmt.Errorf("failed to connect %s and %s: %w", a, b, err)
+	}
+	n.connected[newPair(a, b)] = struct{}{}
+	return nil
+}
+
+// SetConditions sets the conditions of the link between the nodes, in both directions.
+// The conditions apply to existing connections, and are kept when a partition of the link is healed.
+func (n *Network) SetConditions(a, b string, cond LinkConditions) error {
+	if err := cond.Check(); err != nil {
+		return err
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	idA, idB, err := n.peerIDs(a, b)
+	if err != nil {
+		return err
+	}
+	p := newPair(a, b)
+	if _, ok := n.links[p]; !ok {
+		return fmt.Errorf("no link between %s and %s", a, b)
+	}
+	n.links[p] = cond
+	n.applyLinkOptions(idA, idB, cond)
+	return nil
+}
+
+// SetAllConditions sets the conditions of all links.
+func (n *Network) SetAllConditions(cond LinkConditions) error {
+	n.mu.Lock()
+	var pairs []pair
+	for p := range n.links {
+		pairs = append(pairs, p)
+	}
+	n.mu.Unlock()
+	for _, p := range pairs {
+		if err := n.SetConditions(p.a, p.b, cond); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *Network) applyLinkOptions(idA, idB peer.ID, cond LinkConditions) {
+	for _, l := range n.net.LinksBetweenPeers(idA, idB) {
+		l.SetOptions(mocknet.LinkOptions{Latency: cond.Latency, Bandwidth: cond.Bandwidth})
+	}
+}
+
+// Partition disconnects and unlinks the nodes of each group from the nodes of the other groups.
+// Nodes that are not part of any group are not affected.
+func (n *Network) Partition(groups ...[]string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	group := make(map[string]int)
+	for i, nodes := range groups {
+		for _, name := range nodes {
+			if _, ok := n.peers[name]; !ok {
+				return fmt.Errorf("unknown node %s", name)
+			}
+			if j, ok := group[name]; ok && j != i {
+				return fmt.Errorf("node %s is part of multiple groups", name)
+			}
+			group[name] = i
+		}
+	}
+	for p := range n.links {
+		groupA, okA := group[p.a]
+		groupB, okB := group[p.b]
+		if !okA || !okB || groupA == groupB {
+			continue
+		}
+		if _, ok := n.cut[p]; ok {
+			continue
+		}
+		idA, idB, _ := n.peerIDs(p.a, p.b)
+		if err := n.net.DisconnectPeers(idA, idB); err != nil {
+			return fmt.Errorf("failed to disconnect %s and %s: %w", p.a, p.b, err)
+		}
+		if err := n.net.UnlinkPeers(idA, idB); err != nil {
+			return fmt.Errorf("failed to unlink %s and %s: %w", p.a, p.b, err)
+		}
+		n.cut[p] = struct{}{}
+	}
+	return nil
+}
+
+// Heal restores the links removed by partitions, with their previous conditions,
+// and reconnects the nodes that were connected.
+func (n *Network) Heal() error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for p := range n.cut {
+		idA, idB, _ := n.peerIDs(p.a, p.b)
+		if _, err := n.net.LinkPeers(idA, idB); err != nil {
+			return fmt.Errorf("failed to link %s and %s: %w", p.a, p.b, err)
+		}
+		n.applyLinkOptions(idA, idB, n.links[p])
+		if _, ok := n.connected[p]; ok {
+			if _, err := n.net.ConnectPeers(idA, idB); err != nil {
+				return fmt.Errorf("failed to connect %s and %s: %w", p.a, p.b, err)
+			}
+		}
+		delete(n.cut, p)
+	}
+	return nil
+}
+
+// Partitioned returns whether the link between the nodes is removed by a partition.
+func (n *Network) Partitioned(a, b string) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	_, ok := n.cut[newPair(a, b)]
+	return ok
+}
+
+// GossipOption returns the gossip option for the named node,
+// which drops incoming gossip messages according to the drop rate of the link they are received over.
+func (n *Network) GossipOption(name string) pubsub.Option {
+	return pubsub.WithAppSpecificRpcInspector(func(from peer.ID, rpc *pubsub.RPC) error {
+		n.mu.Lock()
+		defer n.mu.Unlock()
+		sender, ok := n.names[from]
+		if !ok {
+			return nil
+		}
+		rate := n.links[newPair(sender, name)].DropRate
+		if rate == 0 {
+			return nil
+		}
+		rpc.Publish = slices.DeleteFunc(rpc.Publish, func(*pb.Message) bool {
+			return n.rng.Float64() < rate
+		})
+		return nil
+	})
+}
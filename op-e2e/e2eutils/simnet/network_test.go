@@ -0,0 +1,114 @@
+package simnet
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/network"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupNetwork(t *testing.T, names ...string) (*Network, mocknet.Mocknet) {
+	mn := mocknet.New()
+	t.Cleanup(func() { _ = mn.Close() })
+	n := New(mn)
+	for _, name := range names {
+		h, err := mn.GenPeer()
+		require.NoError(t, err)
+		n.AddPeer(name, h)
+	}
+	return n, mn
+}
+
+func TestNetworkConditions(t *testing.T) {
+	n, mn := setupNetwork(t, "a", "b")
+	require.NoError(t, n.Link("a", "b"))
+	require.NoError(t, n.SetConditions("b", "a", LinkConditions{Latency: 50 * time.Millisecond, Bandwidth: 1000}))
+	for _, l := range mn.LinksBetweenPeers(n.peers["a"], n.peers["b"]) {
+		require.Equal(t, mocknet.LinkOptions{Latency: 50 * time.Millisecond, Bandwidth: 1000}, l.Options())
+	}
+
+	require.ErrorContains(t, n.SetConditions("a", "b", LinkConditions{DropRate: 1.5}), "drop rate")
+	require.ErrorContains(t, n.SetConditions("a", "c", LinkConditions{}), "unknown node c")
+}
+
+func TestNetworkPartition(t *testing.T) {
+	n, mn := setupNetwork(t, "a", "b", "c")
+	require.NoError(t, n.Link("a", "b"))
+	require.NoError(t, n.Link("b", "c"))
+	require.NoError(t, n.Link("a", "c"))
+	require.NoError(t, n.Connect("a", "b"))
+	require.NoError(t, n.Connect("b", "c"))
+	require.NoError(t, n.SetConditions("a", "b", LinkConditions{Latency: time.Millisecond}))
+	connectedness := func(a, b string) network.Connectedness {
+		return mn.Net(n.peers[a]).Connectedness(n.peers[b])
+	}
+
+	require.ErrorContains(t, n.Partition([]string{"a", "b"}, []string{"b"}), "multiple groups")
+	require.NoError(t, n.Partition([]string{"a"}, []string{"b"}))
+	require.True(t, n.Partitioned("a", "b"))
+	require.False(t, n.Partitioned("b", "c"))
+	require.Equal(t, network.NotConnected, connectedness("a", "b"))
+	require.Equal(t, network.Connected, connectedness("b", "c"))
+	_, err := mn.ConnectPeers(n.peers["a"], n.peers["b"])
+	require.Error(t, err, "cannot connect across a partition")
+
+	require.NoError(t, n.Heal())
+	require.False(t, n.Partitioned("a", "b"))
+	require.Equal(t, network.Connected, connectedness("a", "b"))
+	require.Equal(t, network.NotConnected, connectedness("a", "c"), "nodes that were not connected stay disconnected")
+	for _, l := range mn.LinksBetweenPeers(n.peers["a"], n.peers["b"]) {
+		require.Equal(t, time.Millisecond, l.Options().Latency, "conditions are restored")
+	}
+}
+
+func TestNetworkGossipDropRate(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	n, mn := setupNetwork(t, "a", "b")
+	require.NoError(t, n.Link("a", "b"))
+
+	topics := make(map[string]*pubsub.Topic)
+	subs := make(map[string]*pubsub.Subscription)
+	for _, name := range []string{"a", "b"} {
+		ps, err := pubsub.NewGossipSub(ctx, mn.Host(n.peers[name]), n.GossipOption(name))
+		require.NoError(t, err)
+		topic, err := ps.Join("test")
+		require.NoError(t, err)
+		sub, err := topic.Subscribe()
+		require.NoError(t, err)
+		topics[name], subs[name] = topic, sub
+	}
+	require.NoError(t, n.Connect("a", "b"))
+	require.Eventually(t, func() bool {
+		return len(topics["a"].ListPeers()) == 1 && len(topics["b"].ListPeers()) == 1
+	}, 10*time.Second, 10*time.Millisecond)
+	// Give the gossip mesh a few heartbeats to form
+	time.Sleep(2 * time.Second)
+
+	// expect receives the next message on the subscription of b that was not published by b itself.
+	expect := func(data string) {
+		for {
+			msg, err := subs["b"].Next(ctx)
+			require.NoError(t, err)
+			if msg.ReceivedFrom != n.peers["b"] {
+				require.Equal(t, data, string(msg.Data))
+				return
+			}
+		}
+	}
+	require.NoError(t, topics["a"].Publish(ctx, []byte("first")))
+	expect("first")
+
+	require.NoError(t, n.SetConditions("a", "b", LinkConditions{DropRate: 1}))
+	require.NoError(t, topics["a"].Publish(ctx, []byte("dropped")))
+	// Give b time to receive the message, if it were not dropped
+	time.Sleep(time.Second)
+
+	require.NoError(t, n.SetConditions("a", "b", LinkConditions{}))
+	require.NoError(t, topics["a"].Publish(ctx, []byte("second")))
+	expect("second")
+}
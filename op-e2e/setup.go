@@ -21,6 +21,7 @@ import (
 
 	ds "github.com/ipfs/go-datastore"
 	dsSync "github.com/ipfs/go-datastore/sync"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	ic "github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/peer"
@@ -49,6 +50,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/geth"
 	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/opnode"
 	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/services"
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/simnet"
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	rollupNode "github.com/ethereum-optimism/optimism/op-node/node"
 	"github.com/ethereum-optimism/optimism/op-node/p2p"
@@ -269,6 +271,10 @@ type SystemConfig struct {
 	// Enables req-resp sync in the P2P nodes
 	P2PReqRespSync bool
 
+	// Conditions of the P2P links between the nodes of the topology, e.g. to add latency.
+	// The conditions can be changed for each link after starting the system, see System.Network.
+	P2PLinkConditions simnet.LinkConditions
+
 	// If the proposer can make proposals for L2 blocks derived from L1 blocks which are not finalized on L1 yet.
 	NonFinalizedProposals bool
 
@@ -319,6 +325,8 @@ type System struct {
 	L2OutputSubmitter *l2os.ProposerService
 	BatchSubmitter    *bss.BatcherService
 	Mocknet           mocknet.Mocknet
+	// Network controls the conditions of the P2P links between the nodes, including partitions.
+	Network *simnet.Network
 
 	L1BeaconAPIAddr endpoint.RestHTTP
 
@@ -650,6 +658,7 @@ func (cfg SystemConfig) Start(t *testing.T, _opts ...SystemConfigOption) (*Syste
 	}
 
 	sys.Mocknet = mocknet.New()
+	sys.Network = simnet.New(sys.Mocknet)
 
 	p2pNodes := make(map[string]*p2p.Prepared)
 	if cfg.P2PTopology != nil {
@@ -669,32 +678,35 @@ func (cfg SystemConfig) Start(t *testing.T, _opts ...SystemConfigOption) (*Syste
 			}
 			// TODO we can enable discv5 in the testnodes to test discovery of new peers.
 			// Would need to mock though, and the discv5 implementation does not provide nice mocks here.
+			sys.Network.AddPeer(name, h)
 			p := &p2p.Prepared{
 				HostP2P:           h,
 				LocalNode:         nil,
 				UDPv5:             nil,
 				EnableReqRespSync: cfg.P2PReqRespSync,
+				GossipOptions:     []pubsub.Option{sys.Network.GossipOption(name)},
 			}
 			p2pNodes[name] = p
 			return p, nil
 		}
 		for k, vs := range cfg.P2PTopology {
-			peerA, err := initHostMaybe(k)
-			if err != nil {
+			if _, err := initHostMaybe(k); err != nil {
 				return nil, fmt.Errorf("failed to setup mocknet peer %s", k)
 			}
 			for _, v := range vs {
 				v = strings.TrimPrefix(v, "~")
-				peerB, err := initHostMaybe(v)
-				if err != nil {
+				if _, err := initHostMaybe(v); err != nil {
 					return nil, fmt.Errorf("failed to setup mocknet peer %s (peer of %s)", v, k)
 				}
-				if _, err := sys.Mocknet.LinkPeers(peerA.HostP2P.ID(), peerB.HostP2P.ID()); err != nil {
-					return nil, fmt.Errorf("failed to setup mocknet link between %s and %s", k, v)
+				if err := sys.Network.Link(k, v); err != nil {
+					return nil, fmt.Errorf("failed to setup mocknet link between %s and %s: %w", k, v, err)
 				}
 				// connect the peers after starting the full rollup node
 			}
 		}
+		if err := sys.Network.SetAllConditions(cfg.P2PLinkConditions); err != nil {
+			return nil, fmt.Errorf("invalid P2P link conditions: %w", err)
+		}
 	}
 
 	// Rollup nodes
@@ -740,16 +752,14 @@ func (cfg SystemConfig) Start(t *testing.T, _opts ...SystemConfigOption) (*Syste
 		// so GossipSub and other p2p protocols can be started before the connections go live.
 		// This way protocol negotiation happens correctly.
 		for k, vs := range cfg.P2PTopology {
-			peerA := p2pNodes[k]
 			for _, v := range vs {
 				unconnected := strings.HasPrefix(v, "~")
 				if unconnected {
 					v = v[1:]
 				}
 				if !unconnected {
-					peerB := p2pNodes[v]
-					if _, err := sys.Mocknet.ConnectPeers(peerA.HostP2P.ID(), peerB.HostP2P.ID()); err != nil {
-						return nil, fmt.Errorf("failed to setup mocknet connection between %s and %s", k, v)
+					if err := sys.Network.Connect(k, v); err != nil {
+						return nil, fmt.Errorf("failed to setup mocknet connection between %s and %s: %w", k, v, err)
 					}
 				}
 			}
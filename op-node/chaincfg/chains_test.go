@@ -145,3 +145,21 @@ var sepoliaDev0Cfg = rollup.Config{
 func u64Ptr(v uint64) *uint64 {
 	return &v
 }
+
+func TestLoadChain(t *testing.T) {
+	byName, err := LoadChain("sepolia")
+	require.NoError(t, err)
+	require.Equal(t, "op-sepolia", byName.Name)
+	require.Equal(t, uint64(11155420), byName.ChainID)
+	require.Equal(t, sepoliaCfg, *byName.Rollup)
+	require.Equal(t, sepoliaCfg.L1SystemConfigAddress, common.Address(byName.Addresses.SystemConfigProxy))
+	require.NotEqual(t, common.Address{}, common.Address(byName.Addresses.DisputeGameFactoryProxy))
+	require.NotEqual(t, common.Address{}, byName.SuperchainConfig)
+
+	byID, err := LoadChain("11155420")
+	require.NoError(t, err)
+	require.Equal(t, byName, byID)
+
+	_, err = LoadChain("not-a-network")
+	require.ErrorContains(t, err, "unknown chain: not-a-network")
+}
@@ -0,0 +1,66 @@
+package chaincfg
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/superchain-registry/superchain"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+)
+
+// Chain is a chain of the embedded superchain-registry, with the configuration
+// the OP Stack services of the chain are started with.
+type Chain struct {
+	// Name is the network name of the chain, as accepted by the --network flag.
+	Name    string
+	ChainID uint64
+	// Rollup is the rollup config of the chain. It includes the genesis system config.
+	Rollup *rollup.Config
+	// Addresses are the L1 contract addresses of the chain.
+	Addresses superchain.AddressList
+	// SuperchainConfig is the address of the SuperchainConfig contract of the superchain the chain is part of,
+	// or the zero address if it is not known.
+	SuperchainConfig common.Address
+}
+
+// ChainByID returns a chain, from known available configurations, by L2 chain ID.
+// ChainByID returns nil when the chain ID is unknown.
+func ChainByID(chainID uint64) *superchain.ChainConfig {
+	return superchain.OPChains[chainID]
+}
+
+// LoadChain resolves a chain of the superchain-registry by network name or by L2 chain ID.
+func LoadChain(network string) (*Chain, error) {
+	chainCfg := ChainByName(network)
+	if chainCfg == nil {
+		if chainID, err := strconv.ParseUint(network, 10, 64); err == nil {
+			chainCfg = ChainByID(chainID)
+		}
+	}
+	if chainCfg == nil {
+		return nil, fmt.Errorf("unknown chain: %v (Valid options: %v)", network, strings.Join(AvailableNetworks(), ", "))
+	}
+	rollupCfg, err := rollup.LoadOPStackRollupConfig(chainCfg.ChainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load rollup config of chain %v: %w", network, err)
+	}
+	addrs, ok := superchain.Addresses[chainCfg.ChainID]
+	if !ok {
+		return nil, fmt.Errorf("no addresses available for chain %v", network)
+	}
+	chain := &Chain{
+		Name:             chainCfg.Chain + "-" + chainCfg.Superchain,
+		ChainID:          chainCfg.ChainID,
+		Rollup:           rollupCfg,
+		Addresses:        *addrs,
+		SuperchainConfig: common.Address(addrs.SuperchainConfig),
+	}
+	if sc, ok := superchain.Superchains[chainCfg.Superchain]; ok && chain.SuperchainConfig == (common.Address{}) && sc.Config.SuperchainConfigAddr != nil {
+		chain.SuperchainConfig = common.Address(*sc.Config.SuperchainConfigAddr)
+	}
+	return chain, nil
+}
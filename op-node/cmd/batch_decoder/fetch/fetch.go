@@ -14,6 +14,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -85,13 +86,40 @@ func fetchBatchesPerBlock(ctx context.Context, client *ethclient.Client, beacon
 	invalidBatchCount := uint64(0)
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
-	block, err := client.BlockByNumber(ctx, new(big.Int).SetUint64(number))
+	// The block is decoded with the op-service RPC block type rather than fetched with the ethclient,
+	// as the go-ethereum transaction type does not support set-code transactions yet.
+	var rpcBlock *sources.RPCBlock
+	if err := client.Client().CallContext(ctx, &rpcBlock, "eth_getBlockByNumber", hexutil.EncodeUint64(number), true); err != nil {
+		return 0, 0, err
+	}
+	if rpcBlock == nil {
+		return 0, 0, ethereum.NotFound
+	}
+	block, txs, err := rpcBlock.Info(true, false)
 	if err != nil {
 		return 0, 0, err
 	}
 	fmt.Println("Fetched block: ", number)
+	// Set-code transactions are never batcher transactions, so batches are not derived from them.
+	// They carry no blobs, so the blob index is not affected by skipping them.
+	setCodeTxs := rpcBlock.SetCodeTxs()
+	for i, tx := range setCodeTxs {
+		if tx.To == config.BatchInbox {
+			hash, err := tx.Hash()
+			if err != nil {
+				return 0, 0, fmt.Errorf("failed to hash set-code tx %d: %w", i, err)
+			}
+			fmt.Printf("Ignoring set-code transaction (%s) to the batch inbox\n", hash.String())
+		}
+	}
+	txIndex := 0   // index of each transaction in the block, including set-code transactions
 	blobIndex := 0 // index of each blob in the block's blob sidecar
-	for i, tx := range block.Transactions() {
+	for _, tx := range txs {
+		for setCodeTxs[txIndex] != nil {
+			txIndex++
+		}
+		i := txIndex
+		txIndex++
 		if tx.To() != nil && *tx.To() == config.BatchInbox {
 			sender, err := signer.Sender(tx)
 			if err != nil {
@@ -122,12 +150,7 @@ func fetchBatchesPerBlock(ctx context.Context, client *ethclient.Client, beacon
 					hashes = append(hashes, idh)
 					blobIndex += 1
 				}
-				blobs, err := beacon.GetBlobs(ctx, eth.L1BlockRef{
-					Hash:       block.Hash(),
-					Number:     block.Number().Uint64(),
-					ParentHash: block.ParentHash(),
-					Time:       block.Time(),
-				}, hashes)
+				blobs, err := beacon.GetBlobs(ctx, eth.InfoToL1BlockRef(block), hashes)
 				if err != nil {
 					log.Fatal(fmt.Errorf("failed to fetch blobs: %w", err))
 				}
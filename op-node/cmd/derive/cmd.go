@@ -0,0 +1,163 @@
+package derive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+
+	opnode "github.com/ethereum-optimism/optimism/op-node"
+	"github.com/ethereum-optimism/optimism/op-node/flags"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	rollupderive "github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	opflags "github.com/ethereum-optimism/optimism/op-service/flags"
+	"github.com/ethereum-optimism/optimism/op-service/ioutil"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	"github.com/ethereum-optimism/optimism/packages/contracts-bedrock/snapshots"
+)
+
+var (
+	l1RPCFlag = &cli.StringFlag{
+		Name:     "l1",
+		Usage:    "RPC URL of the L1 execution node. The system config is read at the start block, so this must serve historical state",
+		Required: true,
+	}
+	l1BeaconFlag = &cli.StringFlag{
+		Name:  "l1.beacon",
+		Usage: "HTTP endpoint of the L1 beacon node, required to derive batches submitted as blobs",
+	}
+	startFlag = &cli.Uint64Flag{
+		Name:     "start",
+		Usage:    "First L1 block to read batcher data from",
+		Required: true,
+	}
+	endFlag = &cli.Uint64Flag{
+		Name:     "end",
+		Usage:    "Last L1 block to read batcher data from",
+		Required: true,
+	}
+	outFlag = &cli.PathFlag{
+		Name:  "out",
+		Usage: "Path to write the derived blocks to as JSON lines. Defaults to stdout",
+	}
+)
+
+var Flags = []cli.Flag{
+	l1RPCFlag,
+	l1BeaconFlag,
+	startFlag,
+	endFlag,
+	outFlag,
+	opflags.CLINetworkFlag(flags.EnvVarPrefix, ""),
+	opflags.CLIRollupConfigFlag(flags.EnvVarPrefix, ""),
+}
+
+var Command = &cli.Command{
+	Name:  "derive",
+	Usage: "Derives the payload attributes of the batches submitted in a range of L1 blocks, without an execution engine",
+	Description: "Runs the L1 stages of the derivation pipeline over the L1 block range, and prints the payload attributes " +
+		"derived from each batch as JSON lines.\n" +
+		"The batches are not validated against the L2 chain, so batches that a rollup node would drop are included. " +
+		"Channels opened before the start block are not derived, and sequence numbers assume that each epoch starts " +
+		"with the first L2 block at or after its L1 origin time, unless the batch follows the previous one.",
+	Flags:  Flags,
+	Action: Main,
+}
+
+func Main(cliCtx *cli.Context) error {
+	logger := oplog.NewLogger(oplog.AppOut(cliCtx), oplog.ReadCLIConfig(cliCtx))
+	ctx := cliCtx.Context
+	start, end := cliCtx.Uint64(startFlag.Name), cliCtx.Uint64(endFlag.Name)
+	if end < start {
+		return fmt.Errorf("end block %d is before start block %d", end, start)
+	}
+
+	rollupCfg, err := opnode.NewRollupConfig(logger, cliCtx.String(opflags.NetworkFlagName), cliCtx.String(opflags.RollupConfigFlagName))
+	if err != nil {
+		return err
+	}
+	if err := rollupCfg.Check(); err != nil {
+		return fmt.Errorf("invalid rollup config: %w", err)
+	}
+
+	rpc, err := client.NewRPC(ctx, logger, cliCtx.String(l1RPCFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to dial L1 RPC: %w", err)
+	}
+	defer rpc.Close()
+	l1, err := sources.NewL1Client(rpc, logger, nil, sources.L1ClientDefaultConfig(rollupCfg, false, sources.RPCKindStandard))
+	if err != nil {
+		return fmt.Errorf("failed to create L1 client: %w", err)
+	}
+	var blobs rollupderive.L1BlobsFetcher
+	if addr := cliCtx.String(l1BeaconFlag.Name); addr != "" {
+		beaconCl := sources.NewBeaconHTTPClient(client.NewBasicHTTPClient(addr, logger))
+		blobs = sources.NewL1BeaconClient(beaconCl, sources.L1BeaconClientConfig{})
+	}
+
+	// Batcher data can't be included before the L1 genesis block, and the genesis system config applies to it.
+	start = max(start, rollupCfg.Genesis.L1.Number)
+	startRef, err := l1.L1BlockRefByNumber(ctx, start)
+	if err != nil {
+		return fmt.Errorf("failed to fetch start block %d: %w", start, err)
+	}
+	sysCfg := rollupCfg.Genesis.SystemConfig
+	if start > rollupCfg.Genesis.L1.Number {
+		sysCfg, err = systemConfigAt(ctx, rpc, rollupCfg, startRef.Hash)
+		if err != nil {
+			return err
+		}
+	}
+
+	var out io.Writer = os.Stdout
+	if path := cliCtx.Path(outFlag.Name); path != "" {
+		f, err := ioutil.OpenCompressed(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+		if err != nil {
+			return fmt.Errorf("failed to open output file: %w", err)
+		}
+		defer f.Close()
+		out = f
+	}
+	enc := json.NewEncoder(out)
+
+	count := 0
+	err = NewSimulator(logger, rollupCfg, l1, blobs).Run(ctx, startRef, sysCfg, end, func(block *DerivedBlock) error {
+		count++
+		return enc.Encode(block)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to derive L1 blocks %d to %d: %w", start, end, err)
+	}
+	logger.Info("Derived blocks", "start", start, "end", end, "blocks", count)
+	return nil
+}
+
+// systemConfigAt reads the system config from the SystemConfig contract at the given L1 block.
+func systemConfigAt(ctx context.Context, rpc client.RPC, rollupCfg *rollup.Config, block common.Hash) (eth.SystemConfig, error) {
+	caller := batching.NewMultiCaller(rpc, batching.DefaultBatchSize)
+	contract := batching.NewBoundContract(snapshots.LoadSystemConfigABI(), rollupCfg.L1SystemConfigAddress)
+	results, err := caller.Call(ctx, rpcblock.ByHash(block),
+		contract.Call("batcherHash"),
+		contract.Call("gasLimit"),
+		contract.Call("overhead"),
+		contract.Call("scalar"))
+	if err != nil {
+		return eth.SystemConfig{}, fmt.Errorf("failed to read system config at L1 block %s: %w", block, err)
+	}
+	batcherHash := results[0].GetBytes32(0)
+	return eth.SystemConfig{
+		BatcherAddr: common.BytesToAddress(batcherHash[:]),
+		GasLimit:    results[1].GetUint64(0),
+		Overhead:    eth.Bytes32(common.BigToHash(results[2].GetBigInt(0))),
+		Scalar:      eth.Bytes32(common.BigToHash(results[3].GetBigInt(0))),
+	}, nil
+}
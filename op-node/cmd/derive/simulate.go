@@ -0,0 +1,233 @@
+package derive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+
+	altda "github.com/ethereum-optimism/optimism/op-alt-da"
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	rollupderive "github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// maxTemporaryErrors is the number of consecutive temporary errors after which the simulation is aborted.
+const maxTemporaryErrors = 10
+
+// DerivedBlock is an L2 block input derived from a batch, as output by the simulation.
+type DerivedBlock struct {
+	// L1InclusionBlock is the L1 block that completed the channel the batch was read from.
+	L1InclusionBlock eth.BlockID `json:"l1InclusionBlock"`
+	BatchType        int         `json:"batchType"`
+	Timestamp        uint64      `json:"timestamp"`
+	Epoch            eth.BlockID `json:"epoch"`
+	SequenceNumber   uint64      `json:"sequenceNumber"`
+	// ParentHash is the L2 parent hash committed to by a singular batch. Span batches only commit to a prefix of it.
+	ParentHash *common.Hash           `json:"parentHash,omitempty"`
+	Attributes *eth.PayloadAttributes `json:"attributes,omitempty"`
+	// Error is set when no attributes could be derived from the batch.
+	Error string `json:"error,omitempty"`
+}
+
+// Simulator runs the L1 stages of the derivation pipeline, and builds the payload attributes of the batches
+// it reads without an execution engine.
+//
+// Without the L2 chain, the batches are not validated against the safe head like the batch queue does,
+// so the output includes batches that a rollup node would drop. The L2 parent of each batch is synthesized
+// from the batch itself: the sequence number is tracked across consecutive batches, and otherwise assumes
+// the epoch started with the first L2 block at or after the L1 origin time.
+type Simulator struct {
+	log log.Logger
+	cfg *rollup.Config
+	l1  rollupderive.L1Fetcher
+
+	traversal   *rollupderive.L1Traversal
+	stages      []rollupderive.ResettableStage
+	reader      *rollupderive.ChannelInReader
+	attrBuilder *rollupderive.FetchingAttributesBuilder
+
+	prev *eth.L2BlockRef
+}
+
+func NewSimulator(log log.Logger, cfg *rollup.Config, l1 rollupderive.L1Fetcher, blobs rollupderive.L1BlobsFetcher) *Simulator {
+	s := &Simulator{log: log, cfg: cfg, l1: l1}
+	s.traversal = rollupderive.NewL1Traversal(log, cfg, l1)
+	dataSrc := rollupderive.NewDataSourceFactory(log, cfg, l1, blobs, altda.Disabled)
+	l1Src := rollupderive.NewL1Retrieval(log, dataSrc, s.traversal)
+	frameQueue := rollupderive.NewFrameQueue(log, l1Src)
+	bank := rollupderive.NewChannelBank(log, cfg, frameQueue, l1, metrics.NoopMetrics)
+	s.reader = rollupderive.NewChannelInReader(cfg, log, bank, metrics.NoopMetrics)
+	s.stages = []rollupderive.ResettableStage{s.traversal, l1Src, frameQueue, bank, s.reader}
+	s.attrBuilder = rollupderive.NewFetchingAttributesBuilder(cfg, l1, sysCfgFetcher{s.traversal})
+	return s
+}
+
+// Run derives the batches submitted in the L1 blocks from start up to and including end,
+// and passes the derived blocks to out in the order the batches are read.
+// Channels that were opened before the start block are not derived.
+func (s *Simulator) Run(ctx context.Context, start eth.L1BlockRef, sysCfg eth.SystemConfig, end uint64, out func(*DerivedBlock) error) error {
+	for _, stage := range s.stages {
+		if err := stage.Reset(ctx, start, sysCfg); err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("failed to reset derivation stages: %w", err)
+		}
+	}
+	temporaryErrs := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		batch, err := s.reader.NextBatch(ctx)
+		switch {
+		case errors.Is(err, io.EOF):
+			if s.traversal.Origin().Number >= end {
+				return nil
+			}
+			if err := s.traversal.AdvanceL1Block(ctx); err != nil && !errors.Is(err, io.EOF) {
+				return fmt.Errorf("failed to advance L1 origin: %w", err)
+			}
+			continue
+		case errors.Is(err, rollupderive.NotEnoughData):
+			continue
+		case errors.Is(err, rollupderive.ErrTemporary):
+			temporaryErrs++
+			if temporaryErrs >= maxTemporaryErrors {
+				return fmt.Errorf("too many consecutive temporary errors: %w", err)
+			}
+			s.log.Warn("Temporary error reading batch", "origin", s.reader.Origin(), "err", err)
+			continue
+		case err != nil:
+			s.log.Warn("Skipping invalid batch", "origin", s.reader.Origin(), "err", err)
+			continue
+		}
+		temporaryErrs = 0
+		blocks, err := s.deriveBatch(ctx, batch)
+		if err != nil {
+			return err
+		}
+		for _, block := range blocks {
+			if err := out(block); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *Simulator) deriveBatch(ctx context.Context, batch rollupderive.Batch) ([]*DerivedBlock, error) {
+	inclusion := s.reader.Origin().ID()
+	if singular, ok := batch.AsSingularBatch(); ok {
+		parentHash := singular.ParentHash
+		block := &DerivedBlock{
+			L1InclusionBlock: inclusion,
+			BatchType:        rollupderive.SingularBatchType,
+			Timestamp:        singular.Timestamp,
+			ParentHash:       &parentHash,
+		}
+		epoch, err := s.l1.L1BlockRefByNumber(ctx, uint64(singular.EpochNum))
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch epoch %d of batch: %w", singular.EpochNum, err)
+		}
+		if epoch.Hash != singular.EpochHash {
+			block.Epoch = singular.Epoch()
+			block.Error = fmt.Sprintf("batch epoch hash %s does not match canonical L1 block %s", singular.EpochHash, epoch)
+			return []*DerivedBlock{block}, nil
+		}
+		return []*DerivedBlock{s.deriveBlock(ctx, block, parentHash, epoch, singular.Transactions)}, nil
+	}
+	span, ok := batch.AsSpanBatch()
+	if !ok {
+		return nil, fmt.Errorf("unrecognized batch type: %d", batch.GetBatchType())
+	}
+	blocks := make([]*DerivedBlock, 0, span.GetBlockCount())
+	for i := 0; i < span.GetBlockCount(); i++ {
+		block := &DerivedBlock{
+			L1InclusionBlock: inclusion,
+			BatchType:        rollupderive.SpanBatchType,
+			Timestamp:        span.GetBlockTimestamp(i),
+		}
+		epoch, err := s.l1.L1BlockRefByNumber(ctx, span.GetBlockEpochNum(i))
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch epoch %d of span batch: %w", span.GetBlockEpochNum(i), err)
+		}
+		blocks = append(blocks, s.deriveBlock(ctx, block, common.Hash{}, epoch, span.GetBlockTransactions(i)))
+	}
+	return blocks, nil
+}
+
+// deriveBlock builds the payload attributes of the block on top of a parent synthesized from the batch.
+func (s *Simulator) deriveBlock(ctx context.Context, block *DerivedBlock, parentHash common.Hash, epoch eth.L1BlockRef, txs []hexutil.Bytes) *DerivedBlock {
+	block.Epoch = epoch.ID()
+	block.SequenceNumber = s.sequenceNumber(block.Timestamp, epoch)
+	parent := syntheticParent(s.cfg, parentHash, block.Timestamp, epoch, block.SequenceNumber)
+
+	attrs, err := s.attrBuilder.PreparePayloadAttributes(ctx, parent, epoch.ID())
+	if err != nil {
+		block.Error = err.Error()
+		s.prev = nil
+		return block
+	}
+	attrs.NoTxPool = true
+	attrs.Transactions = append(attrs.Transactions, txs...)
+	block.Attributes = attrs
+
+	s.prev = &eth.L2BlockRef{Time: block.Timestamp, L1Origin: epoch.ID(), SequenceNumber: block.SequenceNumber}
+	return block
+}
+
+// sequenceNumber returns the sequence number of the L2 block at the given timestamp within its epoch.
+// It continues the sequence of the previous block if that is the parent, and otherwise assumes the epoch
+// started with the first L2 block at or after the L1 origin time.
+func (s *Simulator) sequenceNumber(timestamp uint64, epoch eth.L1BlockRef) uint64 {
+	if s.prev != nil && s.prev.Time+s.cfg.BlockTime == timestamp {
+		if s.prev.L1Origin == epoch.ID() {
+			return s.prev.SequenceNumber + 1
+		}
+		return 0
+	}
+	return epochSequenceNumber(s.cfg, timestamp, epoch.Time)
+}
+
+func epochSequenceNumber(cfg *rollup.Config, timestamp uint64, epochTime uint64) uint64 {
+	if timestamp <= epochTime || timestamp <= cfg.Genesis.L2Time {
+		return 0
+	}
+	// The first L2 block at or after the L1 origin time.
+	first := cfg.Genesis.L2Time
+	if epochTime > first {
+		first += (epochTime - first + cfg.BlockTime - 1) / cfg.BlockTime * cfg.BlockTime
+	}
+	if timestamp <= first {
+		return 0
+	}
+	return (timestamp - first) / cfg.BlockTime
+}
+
+// syntheticParent returns an L2 parent of the block with the given timestamp, epoch and sequence number,
+// with the L1 origin that is required to build the attributes of the block on top of it.
+func syntheticParent(cfg *rollup.Config, parentHash common.Hash, timestamp uint64, epoch eth.L1BlockRef, seqNum uint64) eth.L2BlockRef {
+	parent := eth.L2BlockRef{
+		Hash: parentHash,
+		Time: timestamp - cfg.BlockTime,
+	}
+	if seqNum > 0 {
+		parent.L1Origin = epoch.ID()
+		parent.SequenceNumber = seqNum - 1
+	} else {
+		parent.L1Origin = eth.BlockID{Hash: epoch.ParentHash, Number: epoch.Number - 1}
+	}
+	return parent
+}
+
+// sysCfgFetcher serves the system config of the L1 traversal, as the L2 chain is not available.
+type sysCfgFetcher struct {
+	traversal *rollupderive.L1Traversal
+}
+
+func (f sysCfgFetcher) SystemConfigByL2Hash(_ context.Context, _ common.Hash) (eth.SystemConfig, error) {
+	return f.traversal.SystemConfig(), nil
+}
@@ -0,0 +1,68 @@
+package derive
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+func TestEpochSequenceNumber(t *testing.T) {
+	cfg := &rollup.Config{BlockTime: 2, Genesis: rollup.Genesis{L2Time: 1000}}
+	tests := []struct {
+		name      string
+		timestamp uint64
+		epochTime uint64
+		expected  uint64
+	}{
+		{name: "Genesis", timestamp: 1000, epochTime: 990, expected: 0},
+		{name: "EpochBeforeGenesis", timestamp: 1006, epochTime: 990, expected: 3},
+		{name: "AlignedEpochStart", timestamp: 1012, epochTime: 1012, expected: 0},
+		{name: "AlignedEpoch", timestamp: 1016, epochTime: 1012, expected: 2},
+		{name: "UnalignedEpochStart", timestamp: 1014, epochTime: 1013, expected: 0},
+		{name: "UnalignedEpoch", timestamp: 1020, epochTime: 1013, expected: 3},
+		{name: "BeforeEpoch", timestamp: 1010, epochTime: 1012, expected: 0},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, epochSequenceNumber(cfg, test.timestamp, test.epochTime))
+		})
+	}
+}
+
+func TestSequenceNumberFollowsPreviousBlock(t *testing.T) {
+	cfg := &rollup.Config{BlockTime: 2, Genesis: rollup.Genesis{L2Time: 1000}}
+	s := &Simulator{cfg: cfg}
+	epoch := eth.L1BlockRef{Hash: common.Hash{0xaa}, Number: 10, Time: 1012}
+	next := eth.L1BlockRef{Hash: common.Hash{0xbb}, Number: 11, ParentHash: epoch.Hash, Time: 1024}
+
+	// Without a previous block, the sequence number is derived from the epoch time.
+	require.Equal(t, uint64(4), s.sequenceNumber(1020, epoch))
+
+	// A delayed L1 origin continues the sequence of the previous block.
+	s.prev = &eth.L2BlockRef{Time: 1024, L1Origin: epoch.ID(), SequenceNumber: 6}
+	require.Equal(t, uint64(7), s.sequenceNumber(1026, epoch))
+	// A new L1 origin starts a new epoch.
+	require.Equal(t, uint64(0), s.sequenceNumber(1026, next))
+	// A gap after the previous block falls back to the epoch time.
+	require.Equal(t, uint64(2), s.sequenceNumber(1028, next))
+}
+
+func TestSyntheticParent(t *testing.T) {
+	cfg := &rollup.Config{BlockTime: 2}
+	epoch := eth.L1BlockRef{Hash: common.Hash{0xaa}, Number: 10, ParentHash: common.Hash{0x99}}
+	parentHash := common.Hash{0x01}
+
+	first := syntheticParent(cfg, parentHash, 1020, epoch, 0)
+	require.Equal(t, parentHash, first.Hash)
+	require.Equal(t, uint64(1018), first.Time)
+	require.Equal(t, eth.BlockID{Hash: epoch.ParentHash, Number: 9}, first.L1Origin)
+
+	later := syntheticParent(cfg, parentHash, 1020, epoch, 3)
+	require.Equal(t, epoch.ID(), later.L1Origin)
+	require.Equal(t, uint64(2), later.SequenceNumber)
+}
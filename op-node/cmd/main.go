@@ -9,8 +9,11 @@ import (
 
 	"github.com/ethereum/go-ethereum/log"
 
+	"github.com/ethereum-optimism/optimism/op-bootnode/bootnode"
+	bootnodeflags "github.com/ethereum-optimism/optimism/op-bootnode/flags"
 	opnode "github.com/ethereum-optimism/optimism/op-node"
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
+	"github.com/ethereum-optimism/optimism/op-node/cmd/derive"
 	"github.com/ethereum-optimism/optimism/op-node/cmd/genesis"
 	"github.com/ethereum-optimism/optimism/op-node/cmd/networks"
 	"github.com/ethereum-optimism/optimism/op-node/cmd/p2p"
@@ -54,6 +57,7 @@ func main() {
 			Name:        "genesis",
 			Subcommands: genesis.Subcommands,
 		},
+		derive.Command,
 		{
 			Name:        "doc",
 			Subcommands: doc.NewSubcommands(metrics.NewMetrics("default")),
@@ -62,6 +66,12 @@ func main() {
 			Name:        "networks",
 			Subcommands: networks.Subcommands,
 		},
+		{
+			Name:   "bootnode",
+			Usage:  "Run only the peer discovery stack, without derivation or an execution engine",
+			Flags:  cliapp.ProtectFlags(bootnodeflags.Flags),
+			Action: bootnode.Main,
+		},
 	}
 
 	ctx := ctxinterrupt.WithSignalWaiterMain(context.Background())
@@ -13,6 +13,8 @@ import (
 	opflags "github.com/ethereum-optimism/optimism/op-service/flags"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
+	"github.com/ethereum-optimism/optimism/op-service/optracing"
+	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
 )
 
@@ -60,7 +62,7 @@ var (
 	}
 	L2EngineJWTSecret = &cli.StringFlag{
 		Name:        "l2.jwt-secret",
-		Usage:       "Path to JWT secret key. Keys are 32 bytes, hex encoded in a file. A new key will be generated if the file is empty.",
+		Usage:       "Path to JWT secret key. Keys are 32 bytes, hex encoded in a file. A new key will be generated if the file is empty. The key is reloaded from the file when the engine rejects it.",
 		EnvVars:     prefixEnvVars("L2_ENGINE_AUTH"),
 		Value:       "",
 		Destination: new(string),
@@ -80,6 +82,13 @@ var (
 		Hidden:  true, // hidden for now during early testing.
 		EnvVars: prefixEnvVars("SUPERVISOR"),
 	}
+	InteropDependencySet = &cli.Uint64SliceFlag{
+		Name: "interop.dependency-set",
+		Usage: "Chain IDs of the chains that executing messages in L2 blocks may reference, checked before consulting the supervisor. " +
+			"Unrestricted if not set. Applies only to Interop-enabled networks.",
+		Hidden:  true, // hidden for now during early testing.
+		EnvVars: prefixEnvVars("INTEROP_DEPENDENCY_SET"),
+	}
 	/* Optional Flags */
 	BeaconHeader = &cli.StringFlag{
 		Name:     "l1.beacon-header",
@@ -95,6 +104,21 @@ var (
 		EnvVars:  prefixEnvVars("L1_BEACON_FALLBACKS", "L1_BEACON_ARCHIVER"),
 		Category: L1RPCCategory,
 	}
+	BeaconArchiveAddr = &cli.StringFlag{
+		Name: "l1.blob-archive",
+		Usage: "Address of a blob archive service with a Beacon-API compatible blob sidecars endpoint (e.g. blob-archiver). " +
+			"Only used to fetch blob sidecars that neither the l1.beacon nor the l1.beacon-fallbacks endpoints can serve.",
+		EnvVars:  prefixEnvVars("L1_BLOB_ARCHIVE"),
+		Category: L1RPCCategory,
+	}
+	BeaconRateLimits = &cli.StringSliceFlag{
+		Name: "l1.beacon-rate-limits",
+		Usage: "Optional self-imposed rate-limits of L1 Beacon-API endpoints, as <endpoint>=<requests / second>[:<burst>]. " +
+			"Endpoints are named 'beacon' for the l1.beacon endpoint, 'fallback-<n>' for the n-th of the l1.beacon-fallbacks, " +
+			"and 'archive' for the l1.blob-archive. The burst defaults to 1.",
+		EnvVars:  prefixEnvVars("L1_BEACON_RATE_LIMITS"),
+		Category: L1RPCCategory,
+	}
 	BeaconCheckIgnore = &cli.BoolFlag{
 		Name:     "l1.beacon.ignore",
 		Usage:    "When false, halts op-node startup if the healthcheck to the Beacon-node endpoint fails.",
@@ -121,6 +145,25 @@ var (
 		}(),
 		Category: RollupCategory,
 	}
+	L2CheckpointHashFlag = &cli.StringFlag{
+		Name: "l2.checkpoint.hash",
+		Usage: "Hash of a trusted L2 block to bootstrap execution-layer sync from. " +
+			"The engine is instructed to sync to this block, and derivation starts from it once the sync completes.",
+		EnvVars:  prefixEnvVars("L2_CHECKPOINT_HASH"),
+		Category: RollupCategory,
+	}
+	L2CheckpointOutputRootFlag = &cli.StringFlag{
+		Name:     "l2.checkpoint.output-root",
+		Usage:    "Output root of the trusted L2 checkpoint block, used to verify the checkpoint block.",
+		EnvVars:  prefixEnvVars("L2_CHECKPOINT_OUTPUT_ROOT"),
+		Category: RollupCategory,
+	}
+	L2CheckpointRPCFlag = &cli.StringFlag{
+		Name:     "l2.checkpoint.rpc",
+		Usage:    "L2 execution client RPC endpoint to fetch the checkpoint block from. It does not need to be trusted.",
+		EnvVars:  prefixEnvVars("L2_CHECKPOINT_RPC"),
+		Category: RollupCategory,
+	}
 	RPCListenAddr = &cli.StringFlag{
 		Name:     "rpc.addr",
 		Usage:    "RPC listening address",
@@ -173,11 +216,26 @@ var (
 	}
 	L1RPCRateLimit = &cli.Float64Flag{
 		Name:     "l1.rpc-rate-limit",
-		Usage:    "Optional self-imposed global rate-limit on L1 RPC requests, specified in requests / second. Disabled if set to 0.",
+		Usage:    "Optional self-imposed rate-limit on the requests to each L1 RPC endpoint, specified in requests / second. Disabled if set to 0.",
 		EnvVars:  prefixEnvVars("L1_RPC_RATE_LIMIT"),
 		Value:    0,
 		Category: L1RPCCategory,
 	}
+	L1RPCRateLimitBurst = &cli.IntFlag{
+		Name:     "l1.rpc-rate-limit-burst",
+		Usage:    "Number of requests that may be made to an L1 RPC endpoint at once, within its rate-limit. Defaults to the l1.rpc-max-batch-size if set to 0.",
+		EnvVars:  prefixEnvVars("L1_RPC_RATE_LIMIT_BURST"),
+		Value:    0,
+		Category: L1RPCCategory,
+	}
+	L1RPCEndpointRateLimits = &cli.StringSliceFlag{
+		Name: "l1.rpc-endpoint-rate-limits",
+		Usage: "Rate-limits of individual L1 RPC endpoints, overriding the l1.rpc-rate-limit, as <endpoint>=<requests / second>[:<burst>]. " +
+			"Endpoints are named 'primary' for the l1 endpoint, and 'fallback-<n>' for the n-th of the l1.fallbacks. " +
+			"Requests are spread to other endpoints while an endpoint has no budget left.",
+		EnvVars:  prefixEnvVars("L1_RPC_ENDPOINT_RATE_LIMITS"),
+		Category: L1RPCCategory,
+	}
 	L1RPCMaxBatchSize = &cli.IntFlag{
 		Name:     "l1.rpc-max-batch-size",
 		Usage:    "Maximum number of RPC requests to bundle, e.g. during L1 blocks receipt fetching. The L1 RPC rate limit counts this as N items, but allows it to burst at once.",
@@ -185,6 +243,34 @@ var (
 		Value:    20,
 		Category: L1RPCCategory,
 	}
+	L1RPCDetectReceiptsMethods = &cli.BoolFlag{
+		Name:     "l1.rpc-detect-receipts-methods",
+		Usage:    "Detect which receipt fetching methods of the L1 RPC kind are supported by the L1 RPC at startup, and never try the unsupported ones.",
+		EnvVars:  prefixEnvVars("L1_RPC_DETECT_RECEIPTS_METHODS"),
+		Category: L1RPCCategory,
+	}
+	L1RPCCircuitBreakerThreshold = &cli.IntFlag{
+		Name: "l1.rpc-circuit-breaker-threshold",
+		Usage: "Number of consecutive failed requests to an L1 RPC endpoint after which requests to it fail fast " +
+			"until the endpoint recovers. Disabled if set to 0.",
+		EnvVars:  prefixEnvVars("L1_RPC_CIRCUIT_BREAKER_THRESHOLD"),
+		Value:    0,
+		Category: L1RPCCategory,
+	}
+	L1RPCCircuitBreakerOpenDuration = &cli.DurationFlag{
+		Name:     "l1.rpc-circuit-breaker-open-duration",
+		Usage:    "Duration to fail requests to a failing L1 RPC endpoint for, before probing whether it recovered.",
+		EnvVars:  prefixEnvVars("L1_RPC_CIRCUIT_BREAKER_OPEN_DURATION"),
+		Value:    30 * time.Second,
+		Category: L1RPCCategory,
+	}
+	L1ReceiptsCacheMaxBytes = &cli.IntFlag{
+		Name:     "l1.receipts-cache-max-bytes",
+		Usage:    "Approximate maximum memory size in bytes of the cached L1 receipts. Disabled if set to 0.",
+		EnvVars:  prefixEnvVars("L1_RECEIPTS_CACHE_MAX_BYTES"),
+		Value:    0,
+		Category: L1RPCCategory,
+	}
 	L1HTTPPollInterval = &cli.DurationFlag{
 		Name:     "l1.http-poll-interval",
 		Usage:    "Polling interval for latest-block subscription when using an HTTP RPC provider. Ignored for other types of RPC endpoints.",
@@ -192,6 +278,33 @@ var (
 		Value:    time.Second * 12,
 		Category: L1RPCCategory,
 	}
+	L1FallbackAddrs = &cli.StringSliceFlag{
+		Name:     "l1.fallbacks",
+		Usage:    "Addresses of L1 User JSON-RPC fallback endpoints. Requests fail over to the next endpoint when an endpoint is unavailable, times out or is rate limited.",
+		EnvVars:  prefixEnvVars("L1_FALLBACKS"),
+		Category: L1RPCCategory,
+	}
+	L1Quorum = &cli.IntFlag{
+		Name:     "l1.quorum",
+		Usage:    "Number of L1 endpoints, including the fallbacks, that must agree on the hashes of the safe and finalized L1 blocks. Disabled if set to 0 or 1.",
+		EnvVars:  prefixEnvVars("L1_QUORUM"),
+		Value:    0,
+		Category: L1RPCCategory,
+	}
+	L2StandbyEngineAddr = &cli.StringFlag{
+		Name: "l2.standby",
+		Usage: "Address of a standby L2 Engine JSON-RPC endpoint, authenticated with the same JWT secret. " +
+			"The node fails over to the standby engine after persistent failures of the active engine, if the standby engine has the finalized L2 block.",
+		EnvVars:  prefixEnvVars("L2_STANDBY"),
+		Category: RollupCategory,
+	}
+	L2EngineFailoverThreshold = &cli.IntFlag{
+		Name:     "l2.failover-threshold",
+		Usage:    "Number of consecutive failed requests to the active L2 Engine after which the node fails over to the standby engine. Only used if a standby engine is configured.",
+		EnvVars:  prefixEnvVars("L2_FAILOVER_THRESHOLD"),
+		Value:    sources.DefaultEngineFailoverThreshold,
+		Category: RollupCategory,
+	}
 	L2EngineKind = &cli.GenericFlag{
 		Name: "l2.enginekind",
 		Usage: "The kind of engine client, used to control the behavior of optimism in respect to different types of engine clients. Valid options: " +
@@ -210,6 +323,46 @@ var (
 		Value:    0,
 		Category: L1RPCCategory,
 	}
+	L1ConfirmationDepth = &cli.Uint64Flag{
+		Name: "l1.confirmation-depth",
+		Usage: "Number of L1 blocks after which L1 blocks are treated as confirmed, even if the L1 safe label has not caught up yet. " +
+			"Derivation only reads confirmed L1 blocks, which are cached by number until a reorg of them is detected. " +
+			"Protects against L1 providers that serve shallow reorgs inconsistently. Disabled if 0.",
+		EnvVars:  prefixEnvVars("L1_CONFIRMATION_DEPTH"),
+		Value:    0,
+		Category: L1RPCCategory,
+	}
+	VerifierStallTimeout = &cli.DurationFlag{
+		Name: "verifier.stall-timeout",
+		Usage: "Duration after which a derivation pipeline stage that did not advance is logged and counted as stalled. " +
+			"Disabled if 0.",
+		EnvVars:  prefixEnvVars("VERIFIER_STALL_TIMEOUT"),
+		Value:    0,
+		Category: OperationsCategory,
+	}
+	EventTraceSizeFlag = &cli.IntFlag{
+		Name: "events.trace-size",
+		Usage: "Number of most recent driver event-system trace entries to keep for debugging, " +
+			"served with the queued events by the optimism_eventTrace RPC. Disabled if 0.",
+		EnvVars:  prefixEnvVars("EVENTS_TRACE_SIZE"),
+		Value:    0,
+		Category: OperationsCategory,
+	}
+	EventLatencyBudgetFlag = &cli.DurationFlag{
+		Name:     "events.latency-budget",
+		Usage:    "Duration after which the processing of an event by a driver event-system deriver is logged as slow. Disabled if 0.",
+		EnvVars:  prefixEnvVars("EVENTS_LATENCY_BUDGET"),
+		Value:    0,
+		Category: OperationsCategory,
+	}
+	EventDeadlockTimeoutFlag = &cli.DurationFlag{
+		Name: "events.deadlock-timeout",
+		Usage: "Duration after which a driver event-system deriver that is still processing an event is logged as " +
+			"potentially deadlocked. Disabled if 0.",
+		EnvVars:  prefixEnvVars("EVENTS_DEADLOCK_TIMEOUT"),
+		Value:    0,
+		Category: OperationsCategory,
+	}
 	SequencerEnabledFlag = &cli.BoolFlag{
 		Name:     "sequencer.enabled",
 		Usage:    "Enable sequencing of new L2 blocks. A separate batch submitter has to be deployed to publish the data for verifiers.",
@@ -229,6 +382,41 @@ var (
 		Value:    0,
 		Category: SequencerCategory,
 	}
+	SequencerThrottleSlowLatencyFlag = &cli.DurationFlag{
+		Name:     "sequencer.throttle.slow-latency",
+		Usage:    "Execution engine latency, to make a sequenced block the unsafe head, at which block production is slowed down. Disabled if 0.",
+		EnvVars:  prefixEnvVars("SEQUENCER_THROTTLE_SLOW_LATENCY"),
+		Value:    0,
+		Category: SequencerCategory,
+	}
+	SequencerThrottlePauseLatencyFlag = &cli.DurationFlag{
+		Name:     "sequencer.throttle.pause-latency",
+		Usage:    "Execution engine latency, to make a sequenced block the unsafe head, at which block production is paused. Disabled if 0.",
+		EnvVars:  prefixEnvVars("SEQUENCER_THROTTLE_PAUSE_LATENCY"),
+		Value:    0,
+		Category: SequencerCategory,
+	}
+	SequencerThrottleMaxBacklogFlag = &cli.Uint64Flag{
+		Name:     "sequencer.throttle.max-backlog",
+		Usage:    "Number of L2 blocks the unsafe head may be behind the wall-clock before block production is slowed down. Disabled if 0.",
+		EnvVars:  prefixEnvVars("SEQUENCER_THROTTLE_MAX_BACKLOG"),
+		Value:    0,
+		Category: SequencerCategory,
+	}
+	SequencerThrottleSlowDelayFlag = &cli.DurationFlag{
+		Name:     "sequencer.throttle.slow-delay",
+		Usage:    "Minimum time between the starts of block building jobs while block production is slowed down. Must be lower than the block time.",
+		EnvVars:  prefixEnvVars("SEQUENCER_THROTTLE_SLOW_DELAY"),
+		Value:    time.Second,
+		Category: SequencerCategory,
+	}
+	SequencerThrottlePauseDurationFlag = &cli.DurationFlag{
+		Name:     "sequencer.throttle.pause-duration",
+		Usage:    "Time to pause block production for, before building a new block to probe the execution engine latency again.",
+		EnvVars:  prefixEnvVars("SEQUENCER_THROTTLE_PAUSE_DURATION"),
+		Value:    time.Second * 12,
+		Category: SequencerCategory,
+	}
 	SequencerL1Confs = &cli.Uint64Flag{
 		Name:     "sequencer.l1-confs",
 		Usage:    "Number of L1 blocks to keep distance from the L1 head as a sequencer for picking an L1 origin.",
@@ -243,6 +431,19 @@ var (
 		Value:    time.Second * 12 * 32,
 		Category: L1RPCCategory,
 	}
+	L1FinalizedCheckpointHashFlag = &cli.StringFlag{
+		Name: "l1.finalized-checkpoint.hash",
+		Usage: "Hash of a trusted finalized L1 block, to finalize the L2 blocks derived from it when the finality of the L1 node lags behind or is not available. " +
+			"The checkpoint is only used once it is a canonical ancestor of the L1 safe head.",
+		EnvVars:  prefixEnvVars("L1_FINALIZED_CHECKPOINT_HASH"),
+		Category: L1RPCCategory,
+	}
+	L1FinalizedCheckpointNumberFlag = &cli.Uint64Flag{
+		Name:     "l1.finalized-checkpoint.number",
+		Usage:    "Number of the trusted finalized L1 block set with --l1.finalized-checkpoint.hash.",
+		EnvVars:  prefixEnvVars("L1_FINALIZED_CHECKPOINT_NUMBER"),
+		Category: L1RPCCategory,
+	}
 	RuntimeConfigReloadIntervalFlag = &cli.DurationFlag{
 		Name:     "l1.runtime-config-reload-interval",
 		Usage:    "Poll interval for reloading the runtime config, useful when config events are not being picked up. Disabled if 0 or negative.",
@@ -316,6 +517,19 @@ var (
 		EnvVars:  prefixEnvVars("SAFEDB_PATH"),
 		Category: OperationsCategory,
 	}
+	RecordDir = &cli.StringFlag{
+		Name:     "record-dir",
+		Usage:    "Directory to record all L1 RPC, L1 Beacon API and engine API responses to, for later replay with --replay-dir. Disabled if not set.",
+		EnvVars:  prefixEnvVars("RECORD_DIR"),
+		Category: OperationsCategory,
+	}
+	ReplayDir = &cli.StringFlag{
+		Name: "replay-dir",
+		Usage: "Directory with a recording made with --record-dir, to re-run derivation from, without connecting to the L1, L1 Beacon or L2 endpoints. " +
+			"The endpoint settings must match those used for the recording. Disabled if not set.",
+		EnvVars:  prefixEnvVars("REPLAY_DIR"),
+		Category: OperationsCategory,
+	}
 	/* Deprecated Flags */
 	L2EngineSyncEnabled = &cli.BoolFlag{
 		Name:    "l2.engine-sync",
@@ -382,26 +596,54 @@ var requiredFlags = []cli.Flag{
 
 var optionalFlags = []cli.Flag{
 	SupervisorAddr,
+	InteropDependencySet,
 	BeaconAddr,
 	BeaconHeader,
 	BeaconFallbackAddrs,
+	BeaconArchiveAddr,
+	BeaconRateLimits,
 	BeaconCheckIgnore,
 	BeaconFetchAllSidecars,
 	SyncModeFlag,
+	L2CheckpointHashFlag,
+	L2CheckpointOutputRootFlag,
+	L2CheckpointRPCFlag,
 	RPCListenAddr,
 	RPCListenPort,
 	L1TrustRPC,
 	L1RPCProviderKind,
 	L1RPCRateLimit,
+	L1RPCRateLimitBurst,
+	L1RPCEndpointRateLimits,
 	L1RPCMaxBatchSize,
 	L1RPCMaxConcurrency,
+	L1RPCDetectReceiptsMethods,
+	L1RPCCircuitBreakerThreshold,
+	L1RPCCircuitBreakerOpenDuration,
+	L1ReceiptsCacheMaxBytes,
 	L1HTTPPollInterval,
+	L1FallbackAddrs,
+	L1Quorum,
+	L2StandbyEngineAddr,
+	L2EngineFailoverThreshold,
 	VerifierL1Confs,
+	L1ConfirmationDepth,
+	VerifierStallTimeout,
+	EventTraceSizeFlag,
+	EventLatencyBudgetFlag,
+	EventDeadlockTimeoutFlag,
 	SequencerEnabledFlag,
 	SequencerStoppedFlag,
 	SequencerMaxSafeLagFlag,
+	SequencerThrottleSlowLatencyFlag,
+	SequencerThrottlePauseLatencyFlag,
+	SequencerThrottleMaxBacklogFlag,
+	SequencerThrottleSlowDelayFlag,
+	SequencerThrottlePauseDurationFlag,
 	SequencerL1Confs,
 	L1EpochPollIntervalFlag,
+	L1FinalizedCheckpointHashFlag,
+	L1FinalizedCheckpointNumberFlag,
 	RuntimeConfigReloadIntervalFlag,
 	RPCEnableAdmin,
 	RPCAdminPersistence,
@@ -418,6 +660,8 @@ var optionalFlags = []cli.Flag{
 	ConductorRpcFlag,
 	ConductorRpcTimeoutFlag,
 	SafeDBPath,
+	RecordDir,
+	ReplayDir,
 	L2EngineKind,
 }
 
@@ -438,6 +682,8 @@ func init() {
 	optionalFlags = append(optionalFlags, P2PFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, oplog.CLIFlagsWithCategory(EnvVarPrefix, OperationsCategory)...)
 	optionalFlags = append(optionalFlags, oppprof.CLIFlagsWithCategory(EnvVarPrefix, OperationsCategory)...)
+	optionalFlags = append(optionalFlags, optracing.CLIFlagsWithCategory(EnvVarPrefix, OperationsCategory)...)
+	optionalFlags = append(optionalFlags, oprpc.LimitsCLIFlags(EnvVarPrefix, OperationsCategory)...)
 	optionalFlags = append(optionalFlags, DeprecatedFlags...)
 	optionalFlags = append(optionalFlags, opflags.CLIFlags(EnvVarPrefix, RollupCategory)...)
 	optionalFlags = append(optionalFlags, altda.CLIFlags(EnvVarPrefix, AltDACategory)...)
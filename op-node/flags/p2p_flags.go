@@ -7,6 +7,7 @@ import (
 	"github.com/urfave/cli/v2"
 
 	"github.com/ethereum-optimism/optimism/op-node/p2p"
+	optls "github.com/ethereum-optimism/optimism/op-service/tls"
 )
 
 func p2pEnv(envprefix, v string) []string {
@@ -14,47 +15,55 @@ func p2pEnv(envprefix, v string) []string {
 }
 
 var (
-	DisableP2PName          = "p2p.disable"
-	NoDiscoveryName         = "p2p.no-discovery"
-	ScoringName             = "p2p.scoring"
-	PeerScoringName         = "p2p.scoring.peers"
-	PeerScoreBandsName      = "p2p.score.bands"
-	BanningName             = "p2p.ban.peers"
-	BanningThresholdName    = "p2p.ban.threshold"
-	BanningDurationName     = "p2p.ban.duration"
-	TopicScoringName        = "p2p.scoring.topics"
-	P2PPrivPathName         = "p2p.priv.path"
-	P2PPrivRawName          = "p2p.priv.raw"
-	ListenIPName            = "p2p.listen.ip"
-	ListenTCPPortName       = "p2p.listen.tcp"
-	ListenUDPPortName       = "p2p.listen.udp"
-	AdvertiseIPName         = "p2p.advertise.ip"
-	AdvertiseTCPPortName    = "p2p.advertise.tcp"
-	AdvertiseUDPPortName    = "p2p.advertise.udp"
-	BootnodesName           = "p2p.bootnodes"
-	StaticPeersName         = "p2p.static"
-	NetRestrictName         = "p2p.netrestrict"
-	HostMuxName             = "p2p.mux"
-	HostSecurityName        = "p2p.security"
-	PeersLoName             = "p2p.peers.lo"
-	PeersHiName             = "p2p.peers.hi"
-	PeersGraceName          = "p2p.peers.grace"
-	NATName                 = "p2p.nat"
-	UserAgentName           = "p2p.useragent"
-	TimeoutNegotiationName  = "p2p.timeout.negotiation"
-	TimeoutAcceptName       = "p2p.timeout.accept"
-	TimeoutDialName         = "p2p.timeout.dial"
-	PeerstorePathName       = "p2p.peerstore.path"
-	DiscoveryPathName       = "p2p.discovery.path"
-	SequencerP2PKeyName     = "p2p.sequencer.key"
-	GossipMeshDName         = "p2p.gossip.mesh.d"
-	GossipMeshDloName       = "p2p.gossip.mesh.lo"
-	GossipMeshDhiName       = "p2p.gossip.mesh.dhi"
-	GossipMeshDlazyName     = "p2p.gossip.mesh.dlazy"
-	GossipFloodPublishName  = "p2p.gossip.mesh.floodpublish"
-	SyncReqRespName         = "p2p.sync.req-resp"
-	SyncOnlyReqToStaticName = "p2p.sync.onlyreqtostatic"
-	P2PPingName             = "p2p.ping"
+	DisableP2PName         = "p2p.disable"
+	NoDiscoveryName        = "p2p.no-discovery"
+	ScoringName            = "p2p.scoring"
+	PeerScoringName        = "p2p.scoring.peers"
+	PeerScoreBandsName     = "p2p.score.bands"
+	BanningName            = "p2p.ban.peers"
+	BanningThresholdName   = "p2p.ban.threshold"
+	BanningDurationName    = "p2p.ban.duration"
+	PeerScoreMetricsName   = "p2p.scoring.metrics.per-peer"
+	TopicScoringName       = "p2p.scoring.topics"
+	P2PPrivPathName        = "p2p.priv.path"
+	P2PPrivRawName         = "p2p.priv.raw"
+	ListenIPName           = "p2p.listen.ip"
+	ListenTCPPortName      = "p2p.listen.tcp"
+	ListenUDPPortName      = "p2p.listen.udp"
+	AdvertiseIPName        = "p2p.advertise.ip"
+	AdvertiseTCPPortName   = "p2p.advertise.tcp"
+	AdvertiseUDPPortName   = "p2p.advertise.udp"
+	BootnodesName          = "p2p.bootnodes"
+	StaticPeersName        = "p2p.static"
+	NetRestrictName        = "p2p.netrestrict"
+	HostMuxName            = "p2p.mux"
+	HostSecurityName       = "p2p.security"
+	PeersLoName            = "p2p.peers.lo"
+	PeersHiName            = "p2p.peers.hi"
+	PeersGraceName         = "p2p.peers.grace"
+	NATName                = "p2p.nat"
+	UserAgentName          = "p2p.useragent"
+	TimeoutNegotiationName = "p2p.timeout.negotiation"
+	TimeoutAcceptName      = "p2p.timeout.accept"
+	TimeoutDialName        = "p2p.timeout.dial"
+	PeerstorePathName      = "p2p.peerstore.path"
+	DiscoveryPathName      = "p2p.discovery.path"
+	SequencerP2PKeyName    = "p2p.sequencer.key"
+	// SequencerP2PSignerPrefix prefixes the flags of the remote signer for p2p application messages
+	SequencerP2PSignerPrefix       = "p2p.sequencer.signer"
+	SequencerP2PSignerEndpointName = SequencerP2PSignerPrefix + ".endpoint"
+	SequencerP2PSignerAddressName  = SequencerP2PSignerPrefix + ".address"
+	GossipMeshDName                = "p2p.gossip.mesh.d"
+	GossipMeshDloName              = "p2p.gossip.mesh.lo"
+	GossipMeshDhiName              = "p2p.gossip.mesh.dhi"
+	GossipMeshDlazyName            = "p2p.gossip.mesh.dlazy"
+	GossipFloodPublishName         = "p2p.gossip.mesh.floodpublish"
+	GossipValidationWorkersName    = "p2p.gossip.validation.workers"
+	GossipValidationQueueName      = "p2p.gossip.validation.queue-size"
+	GossipValidationPeerQueueName  = "p2p.gossip.validation.peer-queue-size"
+	SyncReqRespName                = "p2p.sync.req-resp"
+	SyncOnlyReqToStaticName        = "p2p.sync.onlyreqtostatic"
+	P2PPingName                    = "p2p.ping"
 )
 
 func deprecatedP2PFlags(envPrefix string) []cli.Flag {
@@ -87,7 +96,7 @@ func deprecatedP2PFlags(envPrefix string) []cli.Flag {
 // None of these flags are strictly required.
 // Some are hidden if they are too technical, or not recommended.
 func P2PFlags(envPrefix string) []cli.Flag {
-	return []cli.Flag{
+	flags := []cli.Flag{
 		&cli.BoolFlag{
 			Name:     DisableP2PName,
 			Usage:    "Completely disable the P2P stack",
@@ -135,6 +144,13 @@ func P2PFlags(envPrefix string) []cli.Flag {
 			EnvVars:  p2pEnv(envPrefix, "PEER_BANNING_DURATION"),
 			Category: P2PCategory,
 		},
+		&cli.BoolFlag{
+			Name:     PeerScoreMetricsName,
+			Usage:    "Export the score components of every peer as separate metrics series. The number of series grows with the number of peers.",
+			Required: false,
+			EnvVars:  p2pEnv(envPrefix, "SCORING_METRICS_PER_PEER"),
+			Category: P2PCategory,
+		},
 		&cli.StringFlag{
 			Name: P2PPrivPathName,
 			Usage: "Read the hex-encoded 32-byte private key for the peer ID from this txt file. Created if not already exists." +
@@ -216,6 +232,7 @@ func P2PFlags(envPrefix string) []cli.Flag {
 		&cli.StringFlag{
 			Name: StaticPeersName,
 			Usage: "Comma-separated multiaddr-format peer list. Static connections to make and maintain, these peers will be regarded as trusted. " +
+				"Disconnected static peers are redialed with exponential backoff, and are protected from connection pruning and score-based bans. " +
 				"Addresses of the local peer are ignored. Duplicate/Alternative addresses for the same peer all apply, but only a single connection per peer is maintained.",
 			Required: false,
 			Value:    "",
@@ -335,13 +352,30 @@ func P2PFlags(envPrefix string) []cli.Flag {
 			Category:  P2PCategory,
 		},
 		&cli.StringFlag{
-			Name:     SequencerP2PKeyName,
-			Usage:    "Hex-encoded private key for signing off on p2p application messages as sequencer.",
+			Name: SequencerP2PKeyName,
+			Usage: "Hex-encoded private key for signing off on p2p application messages as sequencer. " +
+				"Additional comma-separated keys are switched to when the p2p sequencer address in the L1 SystemConfig is rotated to them.",
 			Required: false,
 			Value:    "",
 			EnvVars:  p2pEnv(envPrefix, "SEQUENCER_KEY"),
 			Category: P2PCategory,
 		},
+		&cli.StringFlag{
+			Name: SequencerP2PSignerEndpointName,
+			Usage: "Endpoint of a remote signer (op-signer protocol) for signing off on p2p application messages as sequencer. " +
+				"Alternative to " + SequencerP2PKeyName + ". Gossip of new blocks is halted while the remote signer is unreachable.",
+			Required: false,
+			EnvVars:  p2pEnv(envPrefix, "SEQUENCER_SIGNER_ENDPOINT"),
+			Category: P2PCategory,
+		},
+		&cli.StringFlag{
+			Name: SequencerP2PSignerAddressName,
+			Usage: "Address of the sequencer key held by the remote signer. Signatures of the remote signer are checked against it. " +
+				"Switched to the p2p sequencer address in the L1 SystemConfig when it is rotated.",
+			Required: false,
+			EnvVars:  p2pEnv(envPrefix, "SEQUENCER_SIGNER_ADDRESS"),
+			Category: P2PCategory,
+		},
 		&cli.UintFlag{
 			Name:     GossipMeshDName,
 			Usage:    "Configure GossipSub topic stable mesh target count, a.k.a. desired outbound degree, number of peers to gossip to",
@@ -386,6 +420,33 @@ func P2PFlags(envPrefix string) []cli.Flag {
 			EnvVars:  p2pEnv(envPrefix, "GOSSIP_FLOOD_PUBLISH"),
 			Category: P2PCategory,
 		},
+		&cli.UintFlag{
+			Name:     GossipValidationWorkersName,
+			Usage:    "Number of gossip messages that are validated concurrently, across all topics",
+			Required: false,
+			Hidden:   true,
+			Value:    p2p.DefaultGossipValidationWorkers,
+			EnvVars:  p2pEnv(envPrefix, "GOSSIP_VALIDATION_WORKERS"),
+			Category: P2PCategory,
+		},
+		&cli.UintFlag{
+			Name:     GossipValidationQueueName,
+			Usage:    "Maximum number of gossip messages waiting to be validated, per priority. Messages from the node itself and static peers are prioritized, additional messages are ignored.",
+			Required: false,
+			Hidden:   true,
+			Value:    p2p.DefaultGossipValidationQueueSize,
+			EnvVars:  p2pEnv(envPrefix, "GOSSIP_VALIDATION_QUEUE_SIZE"),
+			Category: P2PCategory,
+		},
+		&cli.UintFlag{
+			Name:     GossipValidationPeerQueueName,
+			Usage:    "Maximum number of gossip messages of a single peer waiting to be validated, additional messages are ignored.",
+			Required: false,
+			Hidden:   true,
+			Value:    p2p.DefaultGossipValidationPeerQueueSize,
+			EnvVars:  p2pEnv(envPrefix, "GOSSIP_VALIDATION_PEER_QUEUE_SIZE"),
+			Category: P2PCategory,
+		},
 		&cli.BoolFlag{
 			Name:     SyncReqRespName,
 			Usage:    "Enables P2P req-resp alternative sync method, on both server and client side.",
@@ -411,4 +472,9 @@ func P2PFlags(envPrefix string) []cli.Flag {
 			EnvVars:  p2pEnv(envPrefix, "PING"),
 		},
 	}
+	for _, flag := range optls.CLIFlagsWithFlagPrefix(envPrefix+"_P2P_SEQUENCER_SIGNER", SequencerP2PSignerPrefix) {
+		flag.(*cli.StringFlag).Category = P2PCategory
+		flags = append(flags, flag)
+	}
+	return flags
 }
@@ -17,6 +17,7 @@ import (
 
 	pb "github.com/libp2p/go-libp2p-pubsub/pb"
 	libp2pmetrics "github.com/libp2p/go-libp2p/core/metrics"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/collectors"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -32,6 +33,7 @@ type Metricer interface {
 	RecordInfo(version string)
 	RecordUp()
 	RecordRPCServerRequest(method string) func()
+	RecordRPCServerRejection(method string, reason string)
 	RecordRPCClientRequest(method string) func(err error)
 	RecordRPCClientResponse(method string, err error)
 	SetDerivationIdle(status bool)
@@ -48,11 +50,21 @@ type Metricer interface {
 	RecordL2Ref(name string, ref eth.L2BlockRef)
 	RecordUnsafePayloadsBuffer(length uint64, memSize uint64, next eth.BlockID)
 	RecordDerivedBatches(batchType string)
+	RecordBatchDrop(reason string)
+	RecordDerivationStageItems(stage string, itemsIn uint64, itemsOut uint64)
+	RecordDerivationStageBufferedBytes(stage string, bytes uint64)
+	RecordDerivationStageAdvance(stage string, t time.Time)
+	RecordDerivationStageStall(stage string)
 	CountSequencedTxs(count int)
 	RecordL1ReorgDepth(d uint64)
 	RecordSequencerInconsistentL1Origin(from eth.BlockID, to eth.BlockID)
 	RecordSequencerReset()
+	RecordSequencerEngineLatency(latency time.Duration)
+	RecordSequencerThrottleLevel(level uint8)
 	RecordGossipEvent(evType int32)
+	RecordGossipValidationQueueDepth(priority string, depth int)
+	RecordGossipValidation(result string, wait time.Duration, duration time.Duration)
+	RecordGossipValidationDropped(reason string)
 	IncPeerCount()
 	DecPeerCount()
 	IncStreamCount()
@@ -67,6 +79,7 @@ type Metricer interface {
 	RecordFrame()
 	// P2P Metrics
 	SetPeerScores(allScores []store.PeerScores)
+	SetPeerScoresByPeer(scores map[peer.ID]store.PeerScores)
 	ClientPayloadByNumberEvent(num uint64, resultCode byte, duration time.Duration)
 	ServerPayloadByNumberEvent(num uint64, resultCode byte, duration time.Duration)
 	PayloadsQuarantineSize(n int)
@@ -74,6 +87,7 @@ type Metricer interface {
 	RecordIPUnban()
 	RecordDial(allow bool)
 	RecordAccept(allow bool)
+	RecordBlobSidecarsFetch(source string, hit bool)
 	ReportProtocolVersions(local, engine, recommended, required params.ProtocolVersion)
 }
 
@@ -108,6 +122,13 @@ type Metrics struct {
 	EventsRateLimited *metrics.Event
 
 	DerivedBatches metrics.EventVec
+	DroppedBatches metrics.EventVec
+
+	DerivationStageItemsIn       *prometheus.CounterVec
+	DerivationStageItemsOut      *prometheus.CounterVec
+	DerivationStageBufferedBytes *prometheus.GaugeVec
+	DerivationStageLastAdvance   *prometheus.GaugeVec
+	DerivationStageStalls        metrics.EventVec
 
 	P2PReqDurationSeconds *prometheus.HistogramVec
 	P2PReqTotal           *prometheus.CounterVec
@@ -126,6 +147,9 @@ type Metrics struct {
 	SequencerSealingDurationSeconds prometheus.Histogram
 	SequencerSealingTotal           prometheus.Counter
 
+	SequencerEngineLatencySeconds prometheus.Histogram
+	SequencerThrottleLevel        prometheus.Gauge
+
 	UnsafePayloadsBufferLen     prometheus.Gauge
 	UnsafePayloadsBufferMemSize prometheus.Gauge
 
@@ -146,12 +170,20 @@ type Metrics struct {
 	PeerCount         prometheus.Gauge
 	StreamCount       prometheus.Gauge
 	GossipEventsTotal *prometheus.CounterVec
-	BandwidthTotal    *prometheus.GaugeVec
-	PeerUnbans        prometheus.Counter
-	IPUnbans          prometheus.Counter
-	Dials             *prometheus.CounterVec
-	Accepts           *prometheus.CounterVec
-	PeerScores        *prometheus.HistogramVec
+
+	GossipValidationQueueDepth   *prometheus.GaugeVec
+	GossipValidationWaitSeconds  prometheus.Histogram
+	GossipValidationSeconds      *prometheus.HistogramVec
+	GossipValidationDroppedTotal *prometheus.CounterVec
+	BandwidthTotal               *prometheus.GaugeVec
+	PeerUnbans                   prometheus.Counter
+	IPUnbans                     prometheus.Counter
+	Dials                        *prometheus.CounterVec
+	Accepts                      *prometheus.CounterVec
+	PeerScores                   *prometheus.HistogramVec
+	PeerScoresByPeer             *prometheus.GaugeVec
+
+	L1BeaconBlobFetches *prometheus.CounterVec
 
 	ChannelInputBytes prometheus.Counter
 
@@ -237,6 +269,33 @@ func NewMetrics(procName string) *Metrics {
 		EventsRateLimited: metrics.NewEvent(factory, ns, "events", "rate_limited", "events rate limiter hits"),
 
 		DerivedBatches: metrics.NewEventVec(factory, ns, "", "derived_batches", "derived batches", []string{"type"}),
+		DroppedBatches: metrics.NewEventVec(factory, ns, "", "dropped_batches", "dropped batches, by drop reason", []string{"reason"}),
+
+		DerivationStageItemsIn: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Subsystem: "derivation_stage",
+			Name:      "items_in",
+			Help:      "Number of items taken by a derivation pipeline stage from the previous stage",
+		}, []string{"stage"}),
+		DerivationStageItemsOut: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Subsystem: "derivation_stage",
+			Name:      "items_out",
+			Help:      "Number of items provided by a derivation pipeline stage to the next stage",
+		}, []string{"stage"}),
+		DerivationStageBufferedBytes: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Subsystem: "derivation_stage",
+			Name:      "buffered_bytes",
+			Help:      "Approximate size of the data buffered by a derivation pipeline stage",
+		}, []string{"stage"}),
+		DerivationStageLastAdvance: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Subsystem: "derivation_stage",
+			Name:      "last_advance_unix",
+			Help:      "Time a derivation pipeline stage last provided an item to the next stage",
+		}, []string{"stage"}),
+		DerivationStageStalls: metrics.NewEventVec(factory, ns, "derivation_stage", "stalls", "derivation pipeline stage stalls", []string{"stage"}),
 
 		SequencerInconsistentL1Origin: metrics.NewEvent(factory, ns, "", "sequencer_inconsistent_l1_origin", "events when the sequencer selects an inconsistent L1 origin"),
 		SequencerResets:               metrics.NewEvent(factory, ns, "", "sequencer_resets", "sequencer resets"),
@@ -267,6 +326,38 @@ func NewMetrics(procName string) *Metrics {
 			Help:      "Count of total transactions sequenced",
 		}),
 
+		GossipValidationQueueDepth: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Subsystem: "p2p",
+			Name:      "gossip_validation_queue_depth",
+			Help:      "Number of gossip messages waiting to be validated, by priority",
+		}, []string{
+			"priority",
+		}),
+		GossipValidationWaitSeconds: factory.NewHistogram(prometheus.HistogramOpts{
+			Namespace: ns,
+			Subsystem: "p2p",
+			Name:      "gossip_validation_wait_seconds",
+			Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+			Help:      "Histogram of the time gossip messages wait for a validation worker",
+		}),
+		GossipValidationSeconds: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: ns,
+			Subsystem: "p2p",
+			Name:      "gossip_validation_seconds",
+			Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+			Help:      "Histogram of the gossip message validation time, by validation result",
+		}, []string{
+			"result",
+		}),
+		GossipValidationDroppedTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Subsystem: "p2p",
+			Name:      "gossip_validation_dropped_total",
+			Help:      "Count of gossip messages that were ignored without validation, by reason",
+		}, []string{
+			"reason",
+		}),
 		PeerCount: factory.NewGauge(prometheus.GaugeOpts{
 			Namespace: ns,
 			Subsystem: "p2p",
@@ -279,6 +370,12 @@ func NewMetrics(procName string) *Metrics {
 			Help:      "Histogram of currently connected peer scores",
 			Buckets:   []float64{-100, -40, -20, -10, -5, -2, -1, -0.5, -0.05, 0, 0.05, 0.5, 1, 2, 5, 10, 20, 40},
 		}, []string{"type"}),
+		PeerScoresByPeer: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Subsystem: "p2p",
+			Name:      "peer_score",
+			Help:      "Score components of each scored peer. Only recorded when per-peer score metrics are enabled.",
+		}, []string{"peer", "type"}),
 		StreamCount: factory.NewGauge(prometheus.GaugeOpts{
 			Namespace: ns,
 			Subsystem: "p2p",
@@ -326,6 +423,13 @@ func NewMetrics(procName string) *Metrics {
 			Help:      "Count of incoming dial attempts to accept, with label to filter to allowed attempts",
 		}, []string{"allow"}),
 
+		L1BeaconBlobFetches: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Subsystem: "l1_beacon",
+			Name:      "blob_fetches",
+			Help:      "Count of blob sidecar requests per source (primary, fallback_<i>, archive), with label to filter to hits",
+		}, []string{"source", "hit"}),
+
 		headChannelOpenedEvent: metrics.NewEvent(factory, ns, "", "head_channel", "New channel at the front of the channel bank"),
 		channelTimedOutEvent:   metrics.NewEvent(factory, ns, "", "channel_timeout", "Channel has timed out"),
 		frameAddedEvent:        metrics.NewEvent(factory, ns, "", "frame_added", "New frame ingested in the channel bank"),
@@ -406,6 +510,17 @@ func NewMetrics(procName string) *Metrics {
 			Name:      "sequencer_sealing_total",
 			Help:      "Number of sequencer block sealing jobs",
 		}),
+		SequencerEngineLatencySeconds: factory.NewHistogram(prometheus.HistogramOpts{
+			Namespace: ns,
+			Name:      "sequencer_engine_latency_seconds",
+			Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+			Help:      "Histogram of the time for the engine to make a sequenced block the unsafe head",
+		}),
+		SequencerThrottleLevel: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "sequencer_throttle_level",
+			Help:      "Throttle level of block production: 0 for none, 1 for slowed down, 2 for paused",
+		}),
 
 		ProtocolVersionDelta: factory.NewGaugeVec(prometheus.GaugeOpts{
 			Namespace: ns,
@@ -450,6 +565,26 @@ func (m *Metrics) SetPeerScores(allScores []store.PeerScores) {
 	}
 }
 
+// SetPeerScoresByPeer updates the per-peer score metrics.
+// Series of peers that are no longer scored are removed.
+func (m *Metrics) SetPeerScoresByPeer(scores map[peer.ID]store.PeerScores) {
+	m.PeerScoresByPeer.Reset()
+	for id, s := range scores {
+		p := id.String()
+		m.PeerScoresByPeer.WithLabelValues(p, "total").Set(s.Gossip.Total)
+		m.PeerScoresByPeer.WithLabelValues(p, "ipColocation").Set(s.Gossip.IPColocationFactor)
+		m.PeerScoresByPeer.WithLabelValues(p, "behavioralPenalty").Set(s.Gossip.BehavioralPenalty)
+		m.PeerScoresByPeer.WithLabelValues(p, "blocksFirstMessage").Set(s.Gossip.Blocks.FirstMessageDeliveries)
+		m.PeerScoresByPeer.WithLabelValues(p, "blocksTimeInMesh").Set(s.Gossip.Blocks.TimeInMesh)
+		m.PeerScoresByPeer.WithLabelValues(p, "blocksMessageDeliveries").Set(s.Gossip.Blocks.MeshMessageDeliveries)
+		m.PeerScoresByPeer.WithLabelValues(p, "blocksInvalidMessageDeliveries").Set(s.Gossip.Blocks.InvalidMessageDeliveries)
+
+		m.PeerScoresByPeer.WithLabelValues(p, "reqRespValidResponses").Set(s.ReqResp.ValidResponses)
+		m.PeerScoresByPeer.WithLabelValues(p, "reqRespErrorResponses").Set(s.ReqResp.ErrorResponses)
+		m.PeerScoresByPeer.WithLabelValues(p, "reqRespRejectedPayloads").Set(s.ReqResp.RejectedPayloads)
+	}
+}
+
 // RecordInfo sets a pseudo-metric that contains versioning and
 // config info for the opnode.
 func (m *Metrics) RecordInfo(version string) {
@@ -516,6 +651,27 @@ func (m *Metrics) RecordDerivedBatches(batchType string) {
 	m.DerivedBatches.Record(batchType)
 }
 
+func (m *Metrics) RecordBatchDrop(reason string) {
+	m.DroppedBatches.Record(reason)
+}
+
+func (m *Metrics) RecordDerivationStageItems(stage string, itemsIn uint64, itemsOut uint64) {
+	m.DerivationStageItemsIn.WithLabelValues(stage).Add(float64(itemsIn))
+	m.DerivationStageItemsOut.WithLabelValues(stage).Add(float64(itemsOut))
+}
+
+func (m *Metrics) RecordDerivationStageBufferedBytes(stage string, bytes uint64) {
+	m.DerivationStageBufferedBytes.WithLabelValues(stage).Set(float64(bytes))
+}
+
+func (m *Metrics) RecordDerivationStageAdvance(stage string, t time.Time) {
+	m.DerivationStageLastAdvance.WithLabelValues(stage).Set(float64(t.Unix()))
+}
+
+func (m *Metrics) RecordDerivationStageStall(stage string) {
+	m.DerivationStageStalls.Record(stage)
+}
+
 func (m *Metrics) CountSequencedTxs(count int) {
 	m.TransactionsSequencedTotal.Add(float64(count))
 }
@@ -534,10 +690,31 @@ func (m *Metrics) RecordSequencerReset() {
 	m.SequencerResets.Record()
 }
 
+func (m *Metrics) RecordSequencerEngineLatency(latency time.Duration) {
+	m.SequencerEngineLatencySeconds.Observe(latency.Seconds())
+}
+
+func (m *Metrics) RecordSequencerThrottleLevel(level uint8) {
+	m.SequencerThrottleLevel.Set(float64(level))
+}
+
 func (m *Metrics) RecordGossipEvent(evType int32) {
 	m.GossipEventsTotal.WithLabelValues(pb.TraceEvent_Type_name[evType]).Inc()
 }
 
+func (m *Metrics) RecordGossipValidationQueueDepth(priority string, depth int) {
+	m.GossipValidationQueueDepth.WithLabelValues(priority).Set(float64(depth))
+}
+
+func (m *Metrics) RecordGossipValidation(result string, wait time.Duration, duration time.Duration) {
+	m.GossipValidationWaitSeconds.Observe(wait.Seconds())
+	m.GossipValidationSeconds.WithLabelValues(result).Observe(duration.Seconds())
+}
+
+func (m *Metrics) RecordGossipValidationDropped(reason string) {
+	m.GossipValidationDroppedTotal.WithLabelValues(reason).Inc()
+}
+
 func (m *Metrics) IncPeerCount() {
 	m.PeerCount.Inc()
 }
@@ -663,6 +840,15 @@ func (m *Metrics) RecordAccept(allow bool) {
 		m.Accepts.WithLabelValues("false").Inc()
 	}
 }
+
+func (m *Metrics) RecordBlobSidecarsFetch(source string, hit bool) {
+	if hit {
+		m.L1BeaconBlobFetches.WithLabelValues(source, "true").Inc()
+	} else {
+		m.L1BeaconBlobFetches.WithLabelValues(source, "false").Inc()
+	}
+}
+
 func (m *Metrics) ReportProtocolVersions(local, engine, recommended, required params.ProtocolVersion) {
 	m.ProtocolVersionDelta.WithLabelValues("local_recommended").Set(float64(local.Compare(recommended)))
 	m.ProtocolVersionDelta.WithLabelValues("local_required").Set(float64(local.Compare(required)))
@@ -725,6 +911,21 @@ func (n *noopMetricer) RecordUnsafePayloadsBuffer(length uint64, memSize uint64,
 func (n *noopMetricer) RecordDerivedBatches(batchType string) {
 }
 
+func (n *noopMetricer) RecordBatchDrop(reason string) {
+}
+
+func (n *noopMetricer) RecordDerivationStageItems(stage string, itemsIn uint64, itemsOut uint64) {
+}
+
+func (n *noopMetricer) RecordDerivationStageBufferedBytes(stage string, bytes uint64) {
+}
+
+func (n *noopMetricer) RecordDerivationStageAdvance(stage string, t time.Time) {
+}
+
+func (n *noopMetricer) RecordDerivationStageStall(stage string) {
+}
+
 func (n *noopMetricer) CountSequencedTxs(count int) {
 }
 
@@ -737,12 +938,30 @@ func (n *noopMetricer) RecordSequencerInconsistentL1Origin(from eth.BlockID, to
 func (n *noopMetricer) RecordSequencerReset() {
 }
 
+func (n *noopMetricer) RecordSequencerEngineLatency(latency time.Duration) {
+}
+
+func (n *noopMetricer) RecordSequencerThrottleLevel(level uint8) {
+}
+
 func (n *noopMetricer) RecordGossipEvent(evType int32) {
 }
 
+func (n *noopMetricer) RecordGossipValidationQueueDepth(priority string, depth int) {
+}
+
+func (n *noopMetricer) RecordGossipValidation(result string, wait time.Duration, duration time.Duration) {
+}
+
+func (n *noopMetricer) RecordGossipValidationDropped(reason string) {
+}
+
 func (n *noopMetricer) SetPeerScores(allScores []store.PeerScores) {
 }
 
+func (n *noopMetricer) SetPeerScoresByPeer(scores map[peer.ID]store.PeerScores) {
+}
+
 func (n *noopMetricer) IncPeerCount() {
 }
 
@@ -802,3 +1021,6 @@ func (n *noopMetricer) RecordAccept(allow bool) {
 }
 func (n *noopMetricer) ReportProtocolVersions(local, engine, recommended, required params.ProtocolVersion) {
 }
+
+func (n *noopMetricer) RecordBlobSidecarsFetch(source string, hit bool) {
+}
@@ -7,10 +7,17 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	gethevent "github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/ethereum-optimism/optimism/op-node/node/safedb"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/attributes"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
 	"github.com/ethereum-optimism/optimism/op-node/version"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/metrics"
@@ -23,17 +30,24 @@ type l2EthClient interface {
 	// Optionally keys of the account storage trie can be specified to include with corresponding values in the proof.
 	GetProof(ctx context.Context, address common.Address, storage []common.Hash, blockTag string) (*eth.AccountResult, error)
 	OutputV0AtBlock(ctx context.Context, blockHash common.Hash) (*eth.OutputV0, error)
+	InfoAndTxsByNumber(ctx context.Context, number uint64) (eth.BlockInfo, types.Transactions, error)
 }
 
 type driverClient interface {
 	SyncStatus(ctx context.Context) (*eth.SyncStatus, error)
 	BlockRefWithStatus(ctx context.Context, num uint64) (eth.L2BlockRef, *eth.SyncStatus, error)
 	ResetDerivationPipeline(context.Context) error
+	ResetDerivationPipelineTo(ctx context.Context, num uint64) error
 	StartSequencer(ctx context.Context, blockHash common.Hash) error
 	StopSequencer(context.Context) (common.Hash, error)
 	SequencerActive(context.Context) (bool, error)
 	OnUnsafeL2Payload(ctx context.Context, payload *eth.ExecutionPayloadEnvelope) error
 	OverrideLeader(ctx context.Context) error
+	SubmitBuilderPayload(ctx context.Context, envelope *eth.ExecutionPayloadEnvelope) error
+	SubscribeHeadUpdates(ch chan<- eth.HeadUpdate) gethevent.Subscription
+	BatchDrops(ctx context.Context) (*derive.BatchDropReport, error)
+	AttributesMismatches(ctx context.Context) ([]attributes.AttributesMismatch, error)
+	EventTrace(ctx context.Context) (*event.TraceDump, error)
 }
 
 type SafeDBReader interface {
@@ -52,9 +66,16 @@ func NewAdminAPI(dr driverClient, m metrics.RPCMetricer, log log.Logger) *adminA
 	}
 }
 
-func (n *adminAPI) ResetDerivationPipeline(ctx context.Context) error {
+// ResetDerivationPipeline resets the derivation pipeline.
+// If a block number is given, the forkchoice state is rewound to that L2 block first, and the chain is re-derived
+// forward from there, without wiping the datadir. The block must not be ahead of the safe head,
+// and the sequencer must be stopped.
+func (n *adminAPI) ResetDerivationPipeline(ctx context.Context, blockNumber *hexutil.Uint64) error {
 	recordDur := n.M.RecordRPCServerRequest("admin_resetDerivationPipeline")
 	defer recordDur()
+	if blockNumber != nil {
+		return n.dr.ResetDerivationPipelineTo(ctx, uint64(*blockNumber))
+	}
 	return n.dr.ResetDerivationPipeline(ctx)
 }
 
@@ -98,11 +119,31 @@ func (n *adminAPI) OverrideLeader(ctx context.Context) error {
 	return n.dr.OverrideLeader(ctx)
 }
 
+// SubmitBuilderPayload allows an external block builder to submit a candidate payload for the next block of the sequencer.
+// The sequencer adopts the payload if it matches the attributes of the next block, and falls back to local block building otherwise.
+// Like all admin methods, it should only be exposed to trusted block builders.
+func (n *adminAPI) SubmitBuilderPayload(ctx context.Context, envelope *eth.ExecutionPayloadEnvelope) error {
+	recordDur := n.M.RecordRPCServerRequest("admin_submitBuilderPayload")
+	defer recordDur()
+
+	payload := envelope.ExecutionPayload
+	if actual, ok := envelope.CheckBlockHash(); !ok {
+		return fmt.Errorf("payload has bad block hash: %s, actual block hash is: %s", payload.BlockHash.String(), actual.String())
+	}
+
+	return n.dr.SubmitBuilderPayload(ctx, envelope)
+}
+
+type safetyTracer interface {
+	Trace(ctx context.Context, block eth.L2BlockRef, status *eth.SyncStatus) (*derive.SafetyTrace, error)
+}
+
 type nodeAPI struct {
 	config *rollup.Config
 	client l2EthClient
 	dr     driverClient
 	safeDB SafeDBReader
+	tracer safetyTracer
 	log    log.Logger
 	m      metrics.RPCMetricer
 }
@@ -156,12 +197,122 @@ func (n *nodeAPI) SafeHeadAtL1Block(ctx context.Context, number hexutil.Uint64)
 	}, nil
 }
 
+// SafetyTrace explains how a safe L2 block became safe: the L1 block that included its batch,
+// the channel and batcher transactions that carried it, and whether it was a span batch.
+// The L1 blocks are re-derived on demand, so the L1 and beacon endpoints must still serve them.
+func (n *nodeAPI) SafetyTrace(ctx context.Context, number hexutil.Uint64) (*derive.SafetyTrace, error) {
+	recordDur := n.m.RecordRPCServerRequest("optimism_safetyTrace")
+	defer recordDur()
+	if n.tracer == nil {
+		return nil, errors.New("safety trace is not enabled")
+	}
+	ref, status, err := n.dr.BlockRefWithStatus(ctx, uint64(number))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get L2 block ref with sync status: %w", err)
+	}
+	return n.tracer.Trace(ctx, ref, status)
+}
+
 func (n *nodeAPI) SyncStatus(ctx context.Context) (*eth.SyncStatus, error) {
 	recordDur := n.m.RecordRPCServerRequest("optimism_syncStatus")
 	defer recordDur()
 	return n.dr.SyncStatus(ctx)
 }
 
+// BatchDrops returns the number of dropped batches per drop reason, and the most recently dropped batches.
+func (n *nodeAPI) BatchDrops(ctx context.Context) (*derive.BatchDropReport, error) {
+	recordDur := n.m.RecordRPCServerRequest("optimism_batchDrops")
+	defer recordDur()
+	return n.dr.BatchDrops(ctx)
+}
+
+// AttributesMismatches returns the most recent derived attributes that did not match the existing unsafe block,
+// oldest first, with all the fields in which they differ.
+func (n *nodeAPI) AttributesMismatches(ctx context.Context) ([]attributes.AttributesMismatch, error) {
+	recordDur := n.m.RecordRPCServerRequest("optimism_attributesMismatches")
+	defer recordDur()
+	return n.dr.AttributesMismatches(ctx)
+}
+
+// EventTrace returns the recent events of the driver event system, the events being processed and the queued events.
+func (n *nodeAPI) EventTrace(ctx context.Context) (*event.TraceDump, error) {
+	recordDur := n.m.RecordRPCServerRequest("optimism_eventTrace")
+	defer recordDur()
+	return n.dr.EventTrace(ctx)
+}
+
+// BatchPreview previews the channel that a batcher would submit next: a span batch of the unsafe blocks after
+// the safe head, with its compressed size and estimated L1 cost. Blocks are added until the channel is full,
+// the unsafe head is reached, or the maximum number of blocks is added.
+// Blocks that a batcher already submitted, but which are not derived yet, are included.
+func (n *nodeAPI) BatchPreview(ctx context.Context, args *derive.ChannelPreviewArgs) (*derive.ChannelPreview, error) {
+	recordDur := n.m.RecordRPCServerRequest("optimism_batchPreview")
+	defer recordDur()
+	opts := args.WithDefaults()
+	if !derive.ValidCompressionAlgo(opts.CompressionAlgo) {
+		return nil, fmt.Errorf("invalid compression algo: %v", opts.CompressionAlgo)
+	}
+	status, err := n.dr.SyncStatus(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sync status: %w", err)
+	}
+	builder, err := derive.NewChannelPreviewBuilder(n.config, opts.CompressionAlgo, uint64(opts.TargetSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create channel: %w", err)
+	}
+	last := min(status.UnsafeL2.Number, status.SafeL2.Number+uint64(opts.MaxBlocks))
+	for num := status.SafeL2.Number + 1; num <= last; num++ {
+		info, txs, err := n.client.InfoAndTxsByNumber(ctx, num)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get L2 block %d: %w", num, err)
+		}
+		headerRLP, err := info.HeaderRLP()
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode header of L2 block %d: %w", num, err)
+		}
+		var header types.Header
+		if err := rlp.DecodeBytes(headerRLP, &header); err != nil {
+			return nil, fmt.Errorf("failed to decode header of L2 block %d: %w", num, err)
+		}
+		if added, err := builder.AddBlock(types.NewBlockWithHeader(&header).WithBody(types.Body{Transactions: txs})); err != nil {
+			return nil, err
+		} else if !added {
+			break
+		}
+	}
+	return builder.Preview(uint64(opts.MaxCalldataFrameSize))
+}
+
+// HeadUpdates subscribes to updates of the unsafe, safe and finalized L2 heads.
+// Only available over websocket: optimism_subscribe("headUpdates").
+func (n *nodeAPI) HeadUpdates(ctx context.Context) (*gethrpc.Subscription, error) {
+	notifier, supported := gethrpc.NotifierFromContext(ctx)
+	if !supported {
+		return nil, gethrpc.ErrNotificationsUnsupported
+	}
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		updates := make(chan eth.HeadUpdate, 20)
+		sub := n.dr.SubscribeHeadUpdates(updates)
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case update := <-updates:
+				if err := notifier.Notify(rpcSub.ID, update); err != nil {
+					n.log.Warn("Failed to notify head update", "id", rpcSub.ID, "err", err)
+					return
+				}
+			case <-rpcSub.Err():
+				return
+			case <-sub.Err():
+				return
+			}
+		}
+	}()
+	return rpcSub, nil
+}
+
 func (n *nodeAPI) RollupConfig(_ context.Context) (*rollup.Config, error) {
 	recordDur := n.m.RecordRPCServerRequest("optimism_rollupConfig")
 	defer recordDur()
@@ -0,0 +1,152 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
+)
+
+// checkpointSyncInterval is the interval at which the checkpoint is re-sent to the engine,
+// to check if the engine finished syncing to it.
+const checkpointSyncInterval = 12 * time.Second
+
+var (
+	ErrCheckpointRequiresELSync     = errors.New("checkpoint sync requires execution-layer sync mode")
+	ErrCheckpointMissingOutputRoot  = errors.New("checkpoint output root must be set")
+	ErrCheckpointMissingRPC         = errors.New("checkpoint rpc must be set")
+	ErrCheckpointOutputRootMismatch = errors.New("checkpoint output root mismatch")
+	ErrCheckpointBlockHashMismatch  = errors.New("checkpoint block hash mismatch")
+)
+
+// CheckpointConfig configures a trusted L2 block to bootstrap execution-layer sync from,
+// so the node does not have to wait for an unsafe block from p2p to start syncing.
+type CheckpointConfig struct {
+	// BlockHash is the hash of the trusted checkpoint block. Checkpoint sync is disabled if zero.
+	BlockHash common.Hash
+	// OutputRoot is the trusted output root of the checkpoint block.
+	OutputRoot common.Hash
+	// RPC is the L2 execution client RPC to fetch the checkpoint block from.
+	// All data fetched from it is verified against the trusted block hash and output root.
+	RPC string
+}
+
+func (c *CheckpointConfig) Enabled() bool {
+	return c.BlockHash != (common.Hash{})
+}
+
+func (c *CheckpointConfig) Check(mode sync.Mode) error {
+	if !c.Enabled() {
+		return nil
+	}
+	if mode != sync.ELSync {
+		return ErrCheckpointRequiresELSync
+	}
+	if c.OutputRoot == (common.Hash{}) {
+		return ErrCheckpointMissingOutputRoot
+	}
+	if c.RPC == "" {
+		return ErrCheckpointMissingRPC
+	}
+	return nil
+}
+
+// checkpointSource is the subset of the L2 client used to fetch the checkpoint block.
+type checkpointSource interface {
+	PayloadByHash(ctx context.Context, hash common.Hash) (*eth.ExecutionPayloadEnvelope, error)
+	OutputV0AtBlock(ctx context.Context, blockHash common.Hash) (*eth.OutputV0, error)
+}
+
+// fetchCheckpoint fetches the checkpoint block, and verifies it matches the trusted block hash and output root.
+func fetchCheckpoint(ctx context.Context, src checkpointSource, cfg *CheckpointConfig) (*eth.ExecutionPayloadEnvelope, error) {
+	envelope, err := src.PayloadByHash(ctx, cfg.BlockHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch checkpoint block %s: %w", cfg.BlockHash, err)
+	}
+	payload := envelope.ExecutionPayload
+	if payload.BlockHash != cfg.BlockHash {
+		return nil, fmt.Errorf("%w: expected %s but got %s", ErrCheckpointBlockHashMismatch, cfg.BlockHash, payload.BlockHash)
+	}
+	if actual, ok := envelope.CheckBlockHash(); !ok {
+		return nil, fmt.Errorf("%w: payload of %s hashes to %s", ErrCheckpointBlockHashMismatch, cfg.BlockHash, actual)
+	}
+	output, err := src.OutputV0AtBlock(ctx, cfg.BlockHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch checkpoint output: %w", err)
+	}
+	// The output must commit to the exact same block and state as the fetched payload.
+	if output.BlockHash != payload.BlockHash || output.StateRoot != payload.StateRoot {
+		return nil, fmt.Errorf("%w: output does not match checkpoint block %s", ErrCheckpointOutputRootMismatch, payload.ID())
+	}
+	if actual := eth.OutputRoot(output); common.Hash(actual) != cfg.OutputRoot {
+		return nil, fmt.Errorf("%w: expected %s but got %s", ErrCheckpointOutputRootMismatch, cfg.OutputRoot, actual)
+	}
+	return envelope, nil
+}
+
+func (n *OpNode) initCheckpoint(ctx context.Context, cfg *Config) error {
+	if !cfg.Checkpoint.Enabled() {
+		return nil
+	}
+	// Like the engine controller, only EL sync if the engine has not finalized any blocks yet.
+	finalized, err := n.l2Source.L2BlockRefByLabel(ctx, eth.Finalized)
+	if err == nil && finalized.Hash != cfg.Rollup.Genesis.L2.Hash && !cfg.Sync.SupportsPostFinalizationELSync {
+		n.log.Warn("Ignoring checkpoint, engine already has a finalized block", "finalized", finalized.ID())
+		return nil
+	} else if err != nil && !errors.Is(err, ethereum.NotFound) {
+		return fmt.Errorf("failed to fetch finalized head: %w", err)
+	}
+
+	rpc, err := client.NewRPC(ctx, n.log, cfg.Checkpoint.RPC, client.WithDialBackoff(10))
+	if err != nil {
+		return fmt.Errorf("failed to dial checkpoint RPC: %w", err)
+	}
+	defer rpc.Close()
+	src, err := sources.NewL2Client(rpc, n.log, nil, sources.L2ClientDefaultConfig(&cfg.Rollup, false))
+	if err != nil {
+		return fmt.Errorf("failed to create checkpoint client: %w", err)
+	}
+	envelope, err := fetchCheckpoint(ctx, src, &cfg.Checkpoint)
+	if err != nil {
+		return err
+	}
+	n.log.Info("Loaded L2 checkpoint", "checkpoint", envelope.ExecutionPayload.ID(), "output_root", cfg.Checkpoint.OutputRoot)
+	n.checkpoint = envelope
+	return nil
+}
+
+// driveCheckpointSync sends the checkpoint block to the engine until the engine finished syncing to it,
+// after which the checkpoint is finalized and derivation continues from it.
+func (n *OpNode) driveCheckpointSync(ctx context.Context, envelope *eth.ExecutionPayloadEnvelope) {
+	ticker := time.NewTicker(checkpointSyncInterval)
+	defer ticker.Stop()
+	target := envelope.ExecutionPayload.ID()
+	for {
+		status, err := n.l2Driver.SyncStatus(ctx)
+		if err != nil {
+			n.log.Warn("Failed to get sync status", "err", err)
+		} else if status.FinalizedL2.Number >= target.Number {
+			n.log.Info("Finished checkpoint sync", "checkpoint", target, "finalized", status.FinalizedL2.ID())
+			return
+		} else if err := n.l2Driver.OnUnsafeL2Payload(ctx, envelope); err != nil {
+			n.log.Warn("Failed to send checkpoint to engine", "checkpoint", target, "err", err)
+		} else {
+			n.log.Info("Syncing engine to checkpoint", "checkpoint", target, "unsafe", status.UnsafeL2.ID())
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+var _ checkpointSource = (*sources.L2Client)(nil)
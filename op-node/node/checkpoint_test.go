@@ -0,0 +1,96 @@
+package node
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+type stubCheckpointSource struct {
+	envelope *eth.ExecutionPayloadEnvelope
+	output   *eth.OutputV0
+}
+
+func (s *stubCheckpointSource) PayloadByHash(_ context.Context, _ common.Hash) (*eth.ExecutionPayloadEnvelope, error) {
+	return s.envelope, nil
+}
+
+func (s *stubCheckpointSource) OutputV0AtBlock(_ context.Context, _ common.Hash) (*eth.OutputV0, error) {
+	return s.output, nil
+}
+
+func TestCheckpointConfigCheck(t *testing.T) {
+	valid := CheckpointConfig{BlockHash: common.Hash{0x01}, OutputRoot: common.Hash{0x02}, RPC: "http://localhost:8545"}
+	require.NoError(t, valid.Check(sync.ELSync))
+	require.NoError(t, (&CheckpointConfig{}).Check(sync.CLSync), "disabled checkpoint is valid")
+	require.ErrorIs(t, valid.Check(sync.CLSync), ErrCheckpointRequiresELSync)
+
+	cfg := valid
+	cfg.OutputRoot = common.Hash{}
+	require.ErrorIs(t, cfg.Check(sync.ELSync), ErrCheckpointMissingOutputRoot)
+
+	cfg = valid
+	cfg.RPC = ""
+	require.ErrorIs(t, cfg.Check(sync.ELSync), ErrCheckpointMissingRPC)
+}
+
+func TestFetchCheckpoint(t *testing.T) {
+	block := types.NewBlock(&types.Header{
+		Number:     big.NewInt(100),
+		Root:       common.Hash{0xaa},
+		BaseFee:    big.NewInt(7),
+		Difficulty: common.Big0,
+	}, &types.Body{Withdrawals: []*types.Withdrawal{}}, nil, trie.NewStackTrie(nil))
+	canyonTime := uint64(0)
+	envelope, err := eth.BlockAsPayloadEnv(block, &canyonTime)
+	require.NoError(t, err)
+	output := &eth.OutputV0{
+		StateRoot:                eth.Bytes32(block.Root()),
+		MessagePasserStorageRoot: eth.Bytes32{0xbb},
+		BlockHash:                block.Hash(),
+	}
+	validCfg := func() *CheckpointConfig {
+		return &CheckpointConfig{
+			BlockHash:  block.Hash(),
+			OutputRoot: common.Hash(eth.OutputRoot(output)),
+			RPC:        "http://localhost:8545",
+		}
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		result, err := fetchCheckpoint(context.Background(), &stubCheckpointSource{envelope: envelope, output: output}, validCfg())
+		require.NoError(t, err)
+		require.Equal(t, envelope, result)
+	})
+
+	t.Run("WrongOutputRoot", func(t *testing.T) {
+		cfg := validCfg()
+		cfg.OutputRoot = common.Hash{0xcc}
+		_, err := fetchCheckpoint(context.Background(), &stubCheckpointSource{envelope: envelope, output: output}, cfg)
+		require.ErrorIs(t, err, ErrCheckpointOutputRootMismatch)
+	})
+
+	t.Run("OutputForDifferentState", func(t *testing.T) {
+		otherOutput := *output
+		otherOutput.StateRoot = eth.Bytes32{0xdd}
+		cfg := validCfg()
+		cfg.OutputRoot = common.Hash(eth.OutputRoot(&otherOutput))
+		_, err := fetchCheckpoint(context.Background(), &stubCheckpointSource{envelope: envelope, output: &otherOutput}, cfg)
+		require.ErrorIs(t, err, ErrCheckpointOutputRootMismatch)
+	})
+
+	t.Run("TamperedPayload", func(t *testing.T) {
+		tampered := *envelope.ExecutionPayload
+		tampered.StateRoot = eth.Bytes32{0xdd}
+		_, err := fetchCheckpoint(context.Background(), &stubCheckpointSource{envelope: &eth.ExecutionPayloadEnvelope{ExecutionPayload: &tampered}, output: output}, validCfg())
+		require.ErrorIs(t, err, ErrCheckpointBlockHashMismatch)
+	})
+}
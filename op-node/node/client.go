@@ -5,15 +5,16 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-service/client"
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
 
 	"github.com/ethereum/go-ethereum/log"
-	gn "github.com/ethereum/go-ethereum/node"
 	"github.com/ethereum/go-ethereum/rpc"
 )
 
@@ -27,12 +28,15 @@ type L1EndpointSetup interface {
 	// Setup a RPC client to a L1 node to pull rollup input-data from.
 	// The results of the RPC client may be trusted for faster processing, or strictly validated.
 	// The kind of the RPC may be non-basic, to optimize RPC usage.
-	Setup(ctx context.Context, log log.Logger, rollupCfg *rollup.Config) (cl client.RPC, rpcCfg *sources.L1ClientConfig, err error)
+	// The state of the circuit breakers of the RPC endpoints, if any, is recorded to the metrics.
+	Setup(ctx context.Context, log log.Logger, rollupCfg *rollup.Config, m opmetrics.CircuitBreakerMetricer) (cl client.RPC, rpcCfg *sources.L1ClientConfig, err error)
 	Check() error
 }
 
 type L1BeaconEndpointSetup interface {
-	Setup(ctx context.Context, log log.Logger) (cl sources.BeaconClient, fb []sources.BlobSideCarsFetcher, err error)
+	// Setup returns the beacon client, the fallbacks for blob sidecars retrieval,
+	// and the blob archive that is tried last, which may be nil.
+	Setup(ctx context.Context, log log.Logger) (cl sources.BeaconClient, fb []sources.BlobSideCarsFetcher, archive sources.BlobSideCarsFetcher, err error)
 	// ShouldIgnoreBeaconCheck returns true if the Beacon-node version check should not halt startup.
 	ShouldIgnoreBeaconCheck() bool
 	ShouldFetchAllSidecars() bool
@@ -47,6 +51,18 @@ type L2EndpointConfig struct {
 	// JWT secrets for L2 Engine API authentication during HTTP or initial Websocket communication.
 	// Any value for an IPC connection.
 	L2EngineJWTSecret [32]byte
+
+	// L2EngineJWTSecretPath is the file the JWT secret was read from. If set, the secret is reloaded
+	// from the file when the engine rejects it, so the secret can be rotated without a restart.
+	L2EngineJWTSecretPath string
+
+	// L2StandbyEngineAddr is the address of a standby L2 Engine JSON-RPC endpoint, which is failed over to
+	// after persistent failures of the L2EngineAddr endpoint. It uses the same JWT secret. Optional.
+	L2StandbyEngineAddr string
+
+	// L2EngineFailoverThreshold is the number of consecutive failed requests to the active engine
+	// after which the standby engine becomes active.
+	L2EngineFailoverThreshold int
 }
 
 var _ L2EndpointSetup = (*L2EndpointConfig)(nil)
@@ -55,6 +71,9 @@ func (cfg *L2EndpointConfig) Check() error {
 	if cfg.L2EngineAddr == "" {
 		return errors.New("empty L2 Engine Address")
 	}
+	if cfg.L2StandbyEngineAddr != "" && cfg.L2EngineFailoverThreshold < 1 {
+		return fmt.Errorf("L2 engine failover threshold must be at least 1, was %d", cfg.L2EngineFailoverThreshold)
+	}
 
 	return nil
 }
@@ -63,7 +82,8 @@ func (cfg *L2EndpointConfig) Setup(ctx context.Context, log log.Logger, rollupCf
 	if err := cfg.Check(); err != nil {
 		return nil, nil, err
 	}
-	auth := rpc.WithHTTPAuth(gn.NewJWTAuth(cfg.L2EngineJWTSecret))
+	secret := client.NewReloadableJWTSecret(cfg.L2EngineJWTSecretPath, cfg.L2EngineJWTSecret)
+	auth := rpc.WithHTTPAuth(secret.HTTPAuth())
 	opts := []client.RPCOption{
 		client.WithGethRPCOptions(auth),
 		client.WithDialBackoff(10),
@@ -72,8 +92,17 @@ func (cfg *L2EndpointConfig) Setup(ctx context.Context, log log.Logger, rollupCf
 	if err != nil {
 		return nil, nil, err
 	}
+	var engine client.RPC = client.NewJWTReloadRPC(log, l2Node, secret)
+	if cfg.L2StandbyEngineAddr != "" {
+		standby, err := client.NewRPC(ctx, log, cfg.L2StandbyEngineAddr, opts...)
+		if err != nil {
+			l2Node.Close()
+			return nil, nil, fmt.Errorf("failed to dial L2 standby engine address (%s): %w", cfg.L2StandbyEngineAddr, err)
+		}
+		engine = sources.NewEngineFailoverRPC(log, engine, client.NewJWTReloadRPC(log, standby, secret), cfg.L2EngineFailoverThreshold)
+	}
 
-	return l2Node, sources.EngineClientDefaultConfig(rollupCfg), nil
+	return engine, sources.EngineClientDefaultConfig(rollupCfg), nil
 }
 
 // PreparedL2Endpoints enables testing with in-process pre-setup RPC connections to L2 engines
@@ -97,6 +126,14 @@ func (p *PreparedL2Endpoints) Setup(ctx context.Context, log log.Logger, rollupC
 type L1EndpointConfig struct {
 	L1NodeAddr string // Address of L1 User JSON-RPC endpoint to use (eth namespace required)
 
+	// L1FallbackAddrs are the addresses of L1 User JSON-RPC endpoints to fail over to, in order,
+	// when the L1NodeAddr endpoint is unavailable.
+	L1FallbackAddrs []string
+
+	// L1Quorum is the number of L1 endpoints that must agree on the safe and finalized L1 blocks.
+	// This protects against a single faulty or malicious L1 endpoint. 0 or 1 trusts the active endpoint.
+	L1Quorum int
+
 	// L1TrustRPC: if we trust the L1 RPC we do not have to validate L1 response contents like headers
 	// against block hashes, or cached transaction sender addresses.
 	// Thus we can sync faster at the risk of the source RPC being wrong.
@@ -106,9 +143,18 @@ type L1EndpointConfig struct {
 	// to inform the optimal usage of the RPC for transaction receipts fetching.
 	L1RPCKind sources.RPCProviderKind
 
-	// RateLimit specifies a self-imposed rate-limit on L1 requests. 0 is no rate-limit.
+	// RateLimit specifies a self-imposed rate-limit on the requests to each L1 endpoint. 0 is no rate-limit.
 	RateLimit float64
 
+	// RateLimitBurst specifies the number of requests that may be made to an L1 endpoint at once.
+	// 0 defaults to the BatchSize. It cannot be less than the BatchSize, since a batch counts as BatchSize requests.
+	RateLimitBurst int
+
+	// EndpointRateLimits overrides the rate-limit of individual L1 endpoints, by endpoint name:
+	// "primary" for the L1NodeAddr, and "fallback-<n>" for the n-th of the L1FallbackAddrs.
+	// Requests are spread to other endpoints while an endpoint has no budget left.
+	EndpointRateLimits map[string]client.RateBudget
+
 	// BatchSize specifies the maximum batch-size, which also applies as L1 rate-limit burst amount (if set).
 	BatchSize int
 
@@ -120,6 +166,16 @@ type L1EndpointConfig struct {
 	// It is recommended to use websockets or IPC for efficient following of the changing block.
 	// Setting this to 0 disables polling.
 	HttpPollInterval time.Duration
+
+	// DetectReceiptsMethods enables detection of the receipt fetching methods supported by the L1 RPC at startup.
+	DetectReceiptsMethods bool
+
+	// ReceiptsCacheMaxBytes bounds the approximate memory size of the cached L1 receipts. 0 disables the bound.
+	ReceiptsCacheMaxBytes int
+
+	// CircuitBreaker configures a circuit breaker for each of the L1 endpoints,
+	// to fail requests to a failing endpoint fast. Disabled if the failure threshold is 0.
+	CircuitBreaker client.CircuitBreakerConfig
 }
 
 var _ L1EndpointSetup = (*L1EndpointConfig)(nil)
@@ -131,29 +187,99 @@ func (cfg *L1EndpointConfig) Check() error {
 	if cfg.RateLimit < 0 {
 		return fmt.Errorf("rate limit cannot be negative")
 	}
+	if cfg.RateLimitBurst != 0 && cfg.RateLimitBurst < cfg.BatchSize {
+		return fmt.Errorf("rate limit burst cannot be less than the batch size %d, was %d", cfg.BatchSize, cfg.RateLimitBurst)
+	}
+	for name, budget := range cfg.EndpointRateLimits {
+		if !slices.Contains(cfg.endpointNames(), name) {
+			return fmt.Errorf("rate limit of unknown L1 endpoint %q, expected one of %v", name, cfg.endpointNames())
+		}
+		if err := budget.Check(); err != nil {
+			return fmt.Errorf("invalid rate limit of L1 endpoint %q: %w", name, err)
+		}
+		if budget.Enabled() && budget.Burst < cfg.BatchSize {
+			return fmt.Errorf("rate limit burst of L1 endpoint %q cannot be less than the batch size %d, was %d", name, cfg.BatchSize, budget.Burst)
+		}
+	}
 	if cfg.MaxConcurrency < 1 {
 		return fmt.Errorf("max concurrent requests cannot be less than 1, was %d", cfg.MaxConcurrency)
 	}
+	if cfg.ReceiptsCacheMaxBytes < 0 {
+		return fmt.Errorf("receipts cache max bytes cannot be negative, was %d", cfg.ReceiptsCacheMaxBytes)
+	}
+	if cfg.L1Quorum < 0 || cfg.L1Quorum > 1+len(cfg.L1FallbackAddrs) {
+		return fmt.Errorf("L1 quorum must be between 0 and the %d L1 endpoints, was %d", 1+len(cfg.L1FallbackAddrs), cfg.L1Quorum)
+	}
+	if err := cfg.CircuitBreaker.Check(); err != nil {
+		return fmt.Errorf("invalid L1 circuit breaker: %w", err)
+	}
 	return nil
 }
 
-func (cfg *L1EndpointConfig) Setup(ctx context.Context, log log.Logger, rollupCfg *rollup.Config) (client.RPC, *sources.L1ClientConfig, error) {
+func (cfg *L1EndpointConfig) Setup(ctx context.Context, log log.Logger, rollupCfg *rollup.Config, m opmetrics.CircuitBreakerMetricer) (client.RPC, *sources.L1ClientConfig, error) {
 	opts := []client.RPCOption{
 		client.WithHttpPollInterval(cfg.HttpPollInterval),
 		client.WithDialBackoff(10),
 	}
-	if cfg.RateLimit != 0 {
-		opts = append(opts, client.WithRateLimit(cfg.RateLimit, cfg.BatchSize))
+	if cfg.CircuitBreaker.Enabled() {
+		opts = append(opts, client.WithCircuitBreaker(cfg.CircuitBreaker, m))
 	}
+	names := cfg.endpointNames()
 
-	l1Node, err := client.NewRPC(ctx, log, cfg.L1NodeAddr, opts...)
+	primaryLimiter := cfg.rateBudget(names[0]).Limiter()
+	l1Node, err := client.NewRPC(ctx, log, cfg.L1NodeAddr, append(opts, client.WithRateLimiter(primaryLimiter))...)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to dial L1 address (%s): %w", cfg.L1NodeAddr, err)
 	}
+	if len(cfg.L1FallbackAddrs) > 0 {
+		endpoints := []client.PoolEndpoint{{Name: names[0], RPC: l1Node, Limiter: primaryLimiter}}
+		for i, addr := range cfg.L1FallbackAddrs {
+			limiter := cfg.rateBudget(names[i+1]).Limiter()
+			fallback, err := client.NewRPC(ctx, log, addr, append(opts, client.WithRateLimiter(limiter))...)
+			if err != nil {
+				for _, e := range endpoints {
+					e.RPC.Close()
+				}
+				return nil, nil, fmt.Errorf("failed to dial L1 fallback address (%s): %w", addr, err)
+			}
+			endpoints = append(endpoints, client.PoolEndpoint{Name: names[i+1], RPC: fallback, Limiter: limiter})
+		}
+		l1Node, err = client.NewPoolRPC(log, endpoints, cfg.L1Quorum, client.DefaultPoolCooldown, client.DefaultPoolAttemptTimeout)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create L1 endpoint pool: %w", err)
+		}
+	}
+	return l1Node, cfg.clientConfig(rollupCfg), nil
+}
+
+// endpointNames returns the names of the L1 endpoints, as used in logs and to configure their rate-limits.
+func (cfg *L1EndpointConfig) endpointNames() []string {
+	names := []string{"primary"}
+	for i := range cfg.L1FallbackAddrs {
+		names = append(names, fmt.Sprintf("fallback-%d", i+1))
+	}
+	return names
+}
+
+// rateBudget returns the rate-limit of the named L1 endpoint.
+func (cfg *L1EndpointConfig) rateBudget(name string) client.RateBudget {
+	if budget, ok := cfg.EndpointRateLimits[name]; ok {
+		return budget
+	}
+	burst := cfg.RateLimitBurst
+	if burst == 0 {
+		burst = cfg.BatchSize
+	}
+	return client.RateBudget{Limit: cfg.RateLimit, Burst: burst}
+}
+
+func (cfg *L1EndpointConfig) clientConfig(rollupCfg *rollup.Config) *sources.L1ClientConfig {
 	rpcCfg := sources.L1ClientDefaultConfig(rollupCfg, cfg.L1TrustRPC, cfg.L1RPCKind)
 	rpcCfg.MaxRequestsPerBatch = cfg.BatchSize
 	rpcCfg.MaxConcurrentRequests = cfg.MaxConcurrency
-	return l1Node, rpcCfg, nil
+	rpcCfg.DetectReceiptsMethods = cfg.DetectReceiptsMethods
+	rpcCfg.ReceiptsCacheMaxBytes = cfg.ReceiptsCacheMaxBytes
+	return rpcCfg
 }
 
 // PreparedL1Endpoint enables testing with an in-process pre-setup RPC connection to L1
@@ -165,7 +291,7 @@ type PreparedL1Endpoint struct {
 
 var _ L1EndpointSetup = (*PreparedL1Endpoint)(nil)
 
-func (p *PreparedL1Endpoint) Setup(ctx context.Context, log log.Logger, rollupCfg *rollup.Config) (client.RPC, *sources.L1ClientConfig, error) {
+func (p *PreparedL1Endpoint) Setup(ctx context.Context, log log.Logger, rollupCfg *rollup.Config, m opmetrics.CircuitBreakerMetricer) (client.RPC, *sources.L1ClientConfig, error) {
 	return p.Client, sources.L1ClientDefaultConfig(rollupCfg, p.TrustRPC, p.RPCProviderKind), nil
 }
 
@@ -181,35 +307,58 @@ type L1BeaconEndpointConfig struct {
 	BeaconAddr             string   // Address of L1 User Beacon-API endpoint to use (beacon namespace required)
 	BeaconHeader           string   // Optional HTTP header for all requests to L1 Beacon
 	BeaconFallbackAddrs    []string // Addresses of L1 Beacon-API fallback endpoints (only for blob sidecars retrieval)
+	BeaconArchiveAddr      string   // Optional address of a blob archive, tried after the fallback endpoints (only for blob sidecars retrieval)
 	BeaconCheckIgnore      bool     // When false, halt startup if the beacon version endpoint fails
 	BeaconFetchAllSidecars bool     // Whether to fetch all blob sidecars and filter locally
+
+	// BeaconRateLimits are optional self-imposed rate-limits of the Beacon-API endpoints, by endpoint name:
+	// "beacon" for the BeaconAddr, "fallback-<n>" for the n-th of the BeaconFallbackAddrs, and "archive" for the BeaconArchiveAddr.
+	BeaconRateLimits map[string]client.RateBudget
 }
 
 var _ L1BeaconEndpointSetup = (*L1BeaconEndpointConfig)(nil)
 
-func (cfg *L1BeaconEndpointConfig) Setup(ctx context.Context, log log.Logger) (cl sources.BeaconClient, fb []sources.BlobSideCarsFetcher, err error) {
+func (cfg *L1BeaconEndpointConfig) Setup(ctx context.Context, log log.Logger) (cl sources.BeaconClient, fb []sources.BlobSideCarsFetcher, archive sources.BlobSideCarsFetcher, err error) {
 	var opts []client.BasicHTTPClientOption
 	if cfg.BeaconHeader != "" {
 		hdr, err := parseHTTPHeader(cfg.BeaconHeader)
 		if err != nil {
-			return nil, nil, fmt.Errorf("parsing beacon header: %w", err)
+			return nil, nil, nil, fmt.Errorf("parsing beacon header: %w", err)
 		}
 		opts = append(opts, client.WithHeader(hdr))
 	}
 
-	for _, addr := range cfg.BeaconFallbackAddrs {
-		b := client.NewBasicHTTPClient(addr, log)
+	for i, addr := range cfg.BeaconFallbackAddrs {
+		b := client.NewBasicHTTPClient(addr, log, client.WithHTTPRateLimit(cfg.BeaconRateLimits[fmt.Sprintf("fallback-%d", i+1)]))
 		fb = append(fb, sources.NewBeaconHTTPClient(b))
 	}
 
+	if cfg.BeaconArchiveAddr != "" {
+		b := client.NewBasicHTTPClient(cfg.BeaconArchiveAddr, log, client.WithHTTPRateLimit(cfg.BeaconRateLimits["archive"]))
+		archive = sources.NewBeaconHTTPClient(b)
+	}
+
+	opts = append(opts, client.WithHTTPRateLimit(cfg.BeaconRateLimits["beacon"]))
 	a := client.NewBasicHTTPClient(cfg.BeaconAddr, log, opts...)
-	return sources.NewBeaconHTTPClient(a), fb, nil
+	return sources.NewBeaconHTTPClient(a), fb, archive, nil
 }
 
 func (cfg *L1BeaconEndpointConfig) Check() error {
 	if cfg.BeaconAddr == "" && !cfg.BeaconCheckIgnore {
 		return errors.New("expected L1 Beacon API endpoint, but got none")
 	}
+	names := []string{"beacon", "archive"}
+	for i := range cfg.BeaconFallbackAddrs {
+		names = append(names, fmt.Sprintf("fallback-%d", i+1))
+	}
+	for name, budget := range cfg.BeaconRateLimits {
+		if !slices.Contains(names, name) {
+			return fmt.Errorf("rate limit of unknown L1 Beacon endpoint %q, expected one of %v", name, names)
+		}
+		if err := budget.Check(); err != nil {
+			return fmt.Errorf("invalid rate limit of L1 Beacon endpoint %q: %w", name, err)
+		}
+	}
 	return nil
 }
 
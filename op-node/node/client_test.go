@@ -6,6 +6,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/client"
 )
 
 func TestParseHTTPHeader(t *testing.T) {
@@ -84,9 +86,64 @@ func TestL1BeaconEndpointConfig_Setup(t *testing.T) {
 	} {
 		t.Run(test.desc, func(t *testing.T) {
 			cfg := L1BeaconEndpointConfig{BeaconFallbackAddrs: test.baa}
-			_, fb, err := cfg.Setup(context.Background(), nil)
+			_, fb, archive, err := cfg.Setup(context.Background(), nil)
 			require.NoError(t, err)
 			require.Len(t, fb, test.len)
+			require.Nil(t, archive)
 		})
 	}
+
+	t.Run("archive", func(t *testing.T) {
+		cfg := L1BeaconEndpointConfig{BeaconArchiveAddr: "http://blob.archive"}
+		_, fb, archive, err := cfg.Setup(context.Background(), nil)
+		require.NoError(t, err)
+		require.Empty(t, fb)
+		require.NotNil(t, archive)
+	})
+}
+
+func TestL1EndpointConfig_CheckRateLimits(t *testing.T) {
+	newCfg := func() *L1EndpointConfig {
+		return &L1EndpointConfig{
+			L1FallbackAddrs: []string{"http://fallback"},
+			RateLimit:       10,
+			BatchSize:       20,
+			MaxConcurrency:  10,
+		}
+	}
+	cfg := newCfg()
+	cfg.EndpointRateLimits = map[string]client.RateBudget{"fallback-1": {Limit: 5, Burst: 20}}
+	require.NoError(t, cfg.Check())
+	require.Equal(t, client.RateBudget{Limit: 10, Burst: 20}, cfg.rateBudget("primary"))
+	require.Equal(t, client.RateBudget{Limit: 5, Burst: 20}, cfg.rateBudget("fallback-1"))
+
+	cfg.RateLimitBurst = 40
+	require.Equal(t, client.RateBudget{Limit: 10, Burst: 40}, cfg.rateBudget("primary"))
+
+	cfg = newCfg()
+	cfg.RateLimitBurst = 10
+	require.ErrorContains(t, cfg.Check(), "less than the batch size")
+
+	cfg = newCfg()
+	cfg.EndpointRateLimits = map[string]client.RateBudget{"fallback-2": {Limit: 5, Burst: 20}}
+	require.ErrorContains(t, cfg.Check(), "unknown L1 endpoint")
+
+	cfg = newCfg()
+	cfg.EndpointRateLimits = map[string]client.RateBudget{"primary": {Limit: 5, Burst: 10}}
+	require.ErrorContains(t, cfg.Check(), "less than the batch size")
+}
+
+func TestL1BeaconEndpointConfig_CheckRateLimits(t *testing.T) {
+	cfg := L1BeaconEndpointConfig{
+		BeaconAddr:          "http://beacon",
+		BeaconFallbackAddrs: []string{"http://fallback"},
+		BeaconRateLimits: map[string]client.RateBudget{
+			"beacon":     {Limit: 5, Burst: 1},
+			"fallback-1": {Limit: 1, Burst: 1},
+			"archive":    {Limit: 1, Burst: 2},
+		},
+	}
+	require.NoError(t, cfg.Check())
+	cfg.BeaconRateLimits["fallback-2"] = client.RateBudget{Limit: 1, Burst: 1}
+	require.ErrorContains(t, cfg.Check(), "unknown L1 Beacon endpoint")
 }
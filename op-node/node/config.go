@@ -14,6 +14,8 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
+	"github.com/ethereum-optimism/optimism/op-service/optracing"
+	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
 	"github.com/ethereum/go-ethereum/log"
 )
 
@@ -41,6 +43,9 @@ type Config struct {
 
 	Pprof oppprof.CLIConfig
 
+	// Tracing configures the export of OpenTelemetry traces
+	Tracing optracing.CLIConfig
+
 	// Used to poll the L1 for new finalized or safe blocks
 	L1EpochPollInterval time.Duration
 
@@ -60,6 +65,12 @@ type Config struct {
 
 	Sync sync.Config
 
+	// Checkpoint is an optional trusted L2 block to bootstrap execution-layer sync from.
+	Checkpoint CheckpointConfig
+
+	// L1FinalityCheckpoint is an optional trusted finalized L1 block, for when the L1 node's finality lags behind.
+	L1FinalityCheckpoint L1FinalityCheckpointConfig
+
 	// To halt when detecting the node does not support a signaled protocol version
 	// change of the given severity (major/minor/patch). Disabled if empty.
 	RollupHalt string
@@ -80,6 +91,8 @@ type RPCConfig struct {
 	ListenAddr  string
 	ListenPort  int
 	EnableAdmin bool
+	// Limits are applied to the requests to the RPC server.
+	Limits oprpc.LimitsConfig
 }
 
 func (cfg *RPCConfig) HttpEndpoint() string {
@@ -149,12 +162,18 @@ func (cfg *Config) Check() error {
 	if err := cfg.Rollup.Check(); err != nil {
 		return fmt.Errorf("rollup config error: %w", err)
 	}
+	if err := cfg.RPC.Limits.Check(); err != nil {
+		return fmt.Errorf("rpc limits config error: %w", err)
+	}
 	if err := cfg.Metrics.Check(); err != nil {
 		return fmt.Errorf("metrics config error: %w", err)
 	}
 	if err := cfg.Pprof.Check(); err != nil {
 		return fmt.Errorf("pprof config error: %w", err)
 	}
+	if err := cfg.Tracing.Check(); err != nil {
+		return fmt.Errorf("tracing config error: %w", err)
+	}
 	if cfg.P2P != nil {
 		if err := cfg.P2P.Check(); err != nil {
 			return fmt.Errorf("p2p config error: %w", err)
@@ -171,6 +190,15 @@ func (cfg *Config) Check() error {
 			return fmt.Errorf("sequencer must be enabled when conductor is enabled")
 		}
 	}
+	if err := cfg.Driver.SequencerThrottle.Check(cfg.Rollup.BlockTime); err != nil {
+		return fmt.Errorf("sequencer throttle config error: %w", err)
+	}
+	if err := cfg.Checkpoint.Check(cfg.Sync.SyncMode); err != nil {
+		return fmt.Errorf("checkpoint config error: %w", err)
+	}
+	if err := cfg.L1FinalityCheckpoint.Check(&cfg.Rollup); err != nil {
+		return fmt.Errorf("L1 finality checkpoint config error: %w", err)
+	}
 	if err := cfg.AltDA.Check(); err != nil {
 		return fmt.Errorf("altDA config error: %w", err)
 	}
@@ -0,0 +1,128 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+var (
+	ErrL1FinalityCheckpointMissingNumber  = errors.New("L1 finality checkpoint number must be set")
+	ErrL1FinalityCheckpointBeforeGenesis  = errors.New("L1 finality checkpoint is before the L1 genesis block")
+	ErrL1FinalityCheckpointNotCanonical   = errors.New("L1 finality checkpoint is not canonical")
+	ErrL1FinalityCheckpointAheadOfL1Safe  = errors.New("L1 finality checkpoint is ahead of the L1 safe head")
+	errL1FinalityCheckpointNotYetResolved = errors.New("L1 finality checkpoint not resolved yet")
+)
+
+// L1FinalityCheckpointConfig configures a trusted finalized L1 block, to finalize L2 blocks derived from it
+// when the finality of the L1 node is lagging behind, or is not available at all, like with light L1 clients.
+type L1FinalityCheckpointConfig struct {
+	// Hash is the hash of the trusted finalized L1 block. The checkpoint is disabled if zero.
+	Hash common.Hash
+	// Number is the number of the trusted finalized L1 block.
+	Number uint64
+}
+
+func (c *L1FinalityCheckpointConfig) Enabled() bool {
+	return c.Hash != (common.Hash{})
+}
+
+func (c *L1FinalityCheckpointConfig) Check(rollupCfg *rollup.Config) error {
+	if !c.Enabled() {
+		return nil
+	}
+	if c.Number == 0 {
+		return ErrL1FinalityCheckpointMissingNumber
+	}
+	if c.Number < rollupCfg.Genesis.L1.Number {
+		return fmt.Errorf("%w: checkpoint %d, genesis %d", ErrL1FinalityCheckpointBeforeGenesis, c.Number, rollupCfg.Genesis.L1.Number)
+	}
+	return nil
+}
+
+func (c *L1FinalityCheckpointConfig) ID() eth.BlockID {
+	return eth.BlockID{Hash: c.Hash, Number: c.Number}
+}
+
+// l1FinalityCheckpointRetryInterval is the interval to resolve the L1 finality checkpoint at,
+// if polling for L1 epoch updates is disabled.
+const l1FinalityCheckpointRetryInterval = 12 * time.Second
+
+// l1CheckpointSource is the subset of the L1 client used to verify the L1 finality checkpoint.
+type l1CheckpointSource interface {
+	L1BlockRefByLabel(ctx context.Context, label eth.BlockLabel) (eth.L1BlockRef, error)
+	L1BlockRefByNumber(ctx context.Context, num uint64) (eth.L1BlockRef, error)
+}
+
+// resolveL1FinalityCheckpoint resolves the checkpoint to its L1 block, if it is a canonical ancestor of the current
+// L1 safe head. errL1FinalityCheckpointNotYetResolved is returned while the L1 safe head is behind the checkpoint.
+func resolveL1FinalityCheckpoint(ctx context.Context, src l1CheckpointSource, checkpoint eth.BlockID) (eth.L1BlockRef, error) {
+	safe, err := src.L1BlockRefByLabel(ctx, eth.Safe)
+	if err != nil {
+		return eth.L1BlockRef{}, fmt.Errorf("failed to fetch L1 safe head: %w", err)
+	}
+	if safe.Number < checkpoint.Number {
+		return eth.L1BlockRef{}, fmt.Errorf("%w: %w: checkpoint %s, safe head %s",
+			errL1FinalityCheckpointNotYetResolved, ErrL1FinalityCheckpointAheadOfL1Safe, checkpoint, safe.ID())
+	}
+	ref, err := src.L1BlockRefByNumber(ctx, checkpoint.Number)
+	if err != nil {
+		return eth.L1BlockRef{}, fmt.Errorf("failed to fetch L1 block %d: %w", checkpoint.Number, err)
+	}
+	if ref.Hash != checkpoint.Hash {
+		return eth.L1BlockRef{}, fmt.Errorf("%w: checkpoint %s, canonical block %s", ErrL1FinalityCheckpointNotCanonical, checkpoint, ref.ID())
+	}
+	// The safe head may have been reorged between the two fetches, so check it is still canonical.
+	if canonicalSafe, err := src.L1BlockRefByNumber(ctx, safe.Number); err != nil {
+		return eth.L1BlockRef{}, fmt.Errorf("failed to fetch L1 block %d: %w", safe.Number, err)
+	} else if canonicalSafe.Hash != safe.Hash {
+		return eth.L1BlockRef{}, fmt.Errorf("%w: L1 safe head %s reorged", errL1FinalityCheckpointNotYetResolved, safe.ID())
+	}
+	return ref, nil
+}
+
+// driveL1FinalityCheckpoint resolves the L1 finality checkpoint, retrying while the L1 safe head is behind it,
+// and signals it as the finalized L1 block once resolved.
+func (n *OpNode) driveL1FinalityCheckpoint(ctx context.Context, checkpoint eth.BlockID, interval time.Duration) {
+	if interval <= 0 {
+		interval = l1FinalityCheckpointRetryInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		fetchCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		ref, err := resolveL1FinalityCheckpoint(fetchCtx, n.l1Source, checkpoint)
+		cancel()
+		switch {
+		case err == nil:
+			n.log.Info("Resolved L1 finality checkpoint", "checkpoint", ref)
+			n.l1FinalityCheckpoint.Store(&ref)
+			n.OnNewL1Finalized(ctx, ref)
+			return
+		case errors.Is(err, ErrL1FinalityCheckpointNotCanonical):
+			n.log.Error("Ignoring L1 finality checkpoint", "checkpoint", checkpoint, "err", err)
+			return
+		case errors.Is(err, errL1FinalityCheckpointNotYetResolved):
+			n.log.Info("Waiting for L1 safe head to reach the L1 finality checkpoint", "err", err)
+		default:
+			n.log.Warn("Failed to resolve L1 finality checkpoint", "checkpoint", checkpoint, "err", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// behindL1FinalityCheckpoint returns true if the finalized L1 block is older than the resolved L1 finality checkpoint.
+func (n *OpNode) behindL1FinalityCheckpoint(finalized eth.L1BlockRef) bool {
+	checkpoint := n.l1FinalityCheckpoint.Load()
+	return checkpoint != nil && finalized.Number < checkpoint.Number
+}
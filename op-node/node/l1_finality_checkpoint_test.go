@@ -0,0 +1,94 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+type stubL1CheckpointSource struct {
+	safe   eth.L1BlockRef
+	blocks map[uint64]eth.L1BlockRef
+}
+
+func (s *stubL1CheckpointSource) L1BlockRefByLabel(_ context.Context, _ eth.BlockLabel) (eth.L1BlockRef, error) {
+	return s.safe, nil
+}
+
+func (s *stubL1CheckpointSource) L1BlockRefByNumber(_ context.Context, num uint64) (eth.L1BlockRef, error) {
+	ref, ok := s.blocks[num]
+	if !ok {
+		return eth.L1BlockRef{}, errors.New("not found")
+	}
+	return ref, nil
+}
+
+func TestL1FinalityCheckpointConfigCheck(t *testing.T) {
+	rollupCfg := &rollup.Config{Genesis: rollup.Genesis{L1: eth.BlockID{Number: 10}}}
+	require.NoError(t, (&L1FinalityCheckpointConfig{}).Check(rollupCfg), "disabled checkpoint is valid")
+	require.NoError(t, (&L1FinalityCheckpointConfig{Hash: common.Hash{0x01}, Number: 10}).Check(rollupCfg))
+	require.ErrorIs(t, (&L1FinalityCheckpointConfig{Hash: common.Hash{0x01}}).Check(rollupCfg), ErrL1FinalityCheckpointMissingNumber)
+	require.ErrorIs(t, (&L1FinalityCheckpointConfig{Hash: common.Hash{0x01}, Number: 9}).Check(rollupCfg), ErrL1FinalityCheckpointBeforeGenesis)
+}
+
+func TestResolveL1FinalityCheckpoint(t *testing.T) {
+	checkpoint := eth.L1BlockRef{Hash: common.Hash{0x01}, Number: 100}
+	safe := eth.L1BlockRef{Hash: common.Hash{0x02}, Number: 110}
+	newSrc := func() *stubL1CheckpointSource {
+		return &stubL1CheckpointSource{
+			safe:   safe,
+			blocks: map[uint64]eth.L1BlockRef{checkpoint.Number: checkpoint, safe.Number: safe},
+		}
+	}
+
+	t.Run("Canonical", func(t *testing.T) {
+		ref, err := resolveL1FinalityCheckpoint(context.Background(), newSrc(), checkpoint.ID())
+		require.NoError(t, err)
+		require.Equal(t, checkpoint, ref)
+	})
+
+	t.Run("AtSafeHead", func(t *testing.T) {
+		src := newSrc()
+		src.safe = checkpoint
+		ref, err := resolveL1FinalityCheckpoint(context.Background(), src, checkpoint.ID())
+		require.NoError(t, err)
+		require.Equal(t, checkpoint, ref)
+	})
+
+	t.Run("AheadOfSafeHead", func(t *testing.T) {
+		src := newSrc()
+		src.safe = eth.L1BlockRef{Hash: common.Hash{0x03}, Number: 99}
+		_, err := resolveL1FinalityCheckpoint(context.Background(), src, checkpoint.ID())
+		require.ErrorIs(t, err, ErrL1FinalityCheckpointAheadOfL1Safe)
+		require.ErrorIs(t, err, errL1FinalityCheckpointNotYetResolved)
+	})
+
+	t.Run("NotCanonical", func(t *testing.T) {
+		_, err := resolveL1FinalityCheckpoint(context.Background(), newSrc(), eth.BlockID{Hash: common.Hash{0xff}, Number: checkpoint.Number})
+		require.ErrorIs(t, err, ErrL1FinalityCheckpointNotCanonical)
+		require.NotErrorIs(t, err, errL1FinalityCheckpointNotYetResolved)
+	})
+
+	t.Run("SafeHeadReorged", func(t *testing.T) {
+		src := newSrc()
+		src.blocks[safe.Number] = eth.L1BlockRef{Hash: common.Hash{0x04}, Number: safe.Number}
+		_, err := resolveL1FinalityCheckpoint(context.Background(), src, checkpoint.ID())
+		require.ErrorIs(t, err, errL1FinalityCheckpointNotYetResolved)
+	})
+}
+
+func TestBehindL1FinalityCheckpoint(t *testing.T) {
+	n := &OpNode{}
+	require.False(t, n.behindL1FinalityCheckpoint(eth.L1BlockRef{Number: 1}), "unresolved checkpoint")
+
+	n.l1FinalityCheckpoint.Store(&eth.L1BlockRef{Hash: common.Hash{0x01}, Number: 100})
+	require.True(t, n.behindL1FinalityCheckpoint(eth.L1BlockRef{Number: 99}))
+	require.False(t, n.behindL1FinalityCheckpoint(eth.L1BlockRef{Number: 100}))
+	require.False(t, n.behindL1FinalityCheckpoint(eth.L1BlockRef{Number: 101}))
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/libp2p/go-libp2p/core/peer"
 
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
 
@@ -23,12 +24,14 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/p2p"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/conductor"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
 	"github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/httputil"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
+	"github.com/ethereum-optimism/optimism/op-service/optracing"
 	"github.com/ethereum-optimism/optimism/op-service/retry"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
 )
@@ -65,13 +68,20 @@ type OpNode struct {
 
 	rollupHalt string // when to halt the rollup, disabled if empty
 
-	pprofService *oppprof.Service
-	metricsSrv   *httputil.HTTPServer
+	pprofService   *oppprof.Service
+	tracingService *optracing.Service
+	metricsSrv     *httputil.HTTPServer
 
 	beacon *sources.L1BeaconClient
 
 	supervisor *sources.SupervisorClient
 
+	// checkpoint is the verified trusted L2 block to bootstrap EL sync from. Nil if checkpoint sync is disabled.
+	checkpoint *eth.ExecutionPayloadEnvelope
+
+	// l1FinalityCheckpoint is the resolved trusted finalized L1 block. Nil until the checkpoint is resolved.
+	l1FinalityCheckpoint atomic.Pointer[eth.L1BlockRef]
+
 	// some resources cannot be stopped directly, like the p2p gossipsub router (not our design),
 	// and depend on this ctx to be closed.
 	resourcesCtx   context.Context
@@ -126,6 +136,10 @@ func (n *OpNode) init(ctx context.Context, cfg *Config) error {
 	if err := n.initTracer(ctx, cfg); err != nil {
 		return fmt.Errorf("failed to init the trace: %w", err)
 	}
+	// Start exporting traces before any of the traced components are started.
+	if err := n.initTracing(ctx, cfg); err != nil {
+		return fmt.Errorf("failed to init tracing: %w", err)
+	}
 	if err := n.initL1(ctx, cfg); err != nil {
 		return fmt.Errorf("failed to init L1: %w", err)
 	}
@@ -135,12 +149,15 @@ func (n *OpNode) init(ctx context.Context, cfg *Config) error {
 	if err := n.initL2(ctx, cfg); err != nil {
 		return fmt.Errorf("failed to init L2: %w", err)
 	}
-	if err := n.initRuntimeConfig(ctx, cfg); err != nil { // depends on L2, to signal initial runtime values to
-		return fmt.Errorf("failed to init the runtime config: %w", err)
+	if err := n.initCheckpoint(ctx, cfg); err != nil {
+		return fmt.Errorf("failed to init L2 checkpoint: %w", err)
 	}
 	if err := n.initP2PSigner(ctx, cfg); err != nil {
 		return fmt.Errorf("failed to init the P2P signer: %w", err)
 	}
+	if err := n.initRuntimeConfig(ctx, cfg); err != nil { // depends on L2 and the P2P signer, to signal initial runtime values to
+		return fmt.Errorf("failed to init the runtime config: %w", err)
+	}
 	if err := n.initP2P(cfg); err != nil {
 		return fmt.Errorf("failed to init the P2P stack: %w", err)
 	}
@@ -169,7 +186,7 @@ func (n *OpNode) initTracer(ctx context.Context, cfg *Config) error {
 }
 
 func (n *OpNode) initL1(ctx context.Context, cfg *Config) error {
-	l1Node, rpcCfg, err := cfg.L1.Setup(ctx, n.log, &cfg.Rollup)
+	l1Node, rpcCfg, err := cfg.L1.Setup(ctx, n.log, &cfg.Rollup, &n.metrics.RPCClientMetrics)
 	if err != nil {
 		return fmt.Errorf("failed to get L1 RPC client: %w", err)
 	}
@@ -179,6 +196,11 @@ func (n *OpNode) initL1(ctx context.Context, cfg *Config) error {
 	if err != nil {
 		return fmt.Errorf("failed to create L1 source: %w", err)
 	}
+	if rpcCfg.DetectReceiptsMethods {
+		if err := n.l1Source.DetectReceiptsMethods(ctx); err != nil {
+			n.log.Warn("Failed to detect supported L1 receipt fetching methods", "err", err)
+		}
+	}
 
 	if err := cfg.Rollup.ValidateL1Config(ctx, n.l1Source); err != nil {
 		return fmt.Errorf("failed to validate the L1 config: %w", err)
@@ -210,9 +232,10 @@ func (n *OpNode) initL1(ctx context.Context, cfg *Config) error {
 
 func (n *OpNode) initRuntimeConfig(ctx context.Context, cfg *Config) error {
 	// attempt to load runtime config, repeat N times
-	n.runCfg = NewRuntimeConfig(n.log, n.l1Source, &cfg.Rollup)
+	// Other nodes observe a rotation of the p2p block signer within about one reload interval of this node.
+	n.runCfg = NewRuntimeConfig(n.log, n.l1Source, &cfg.Rollup, cfg.RuntimeConfigReloadInterval)
 
-	confDepth := cfg.Driver.VerifierConfDepth
+	confDepth := cfg.Driver.DerivationConfDepth()
 	reload := func(ctx context.Context) (eth.L1BlockRef, error) {
 		fetchCtx, fetchCancel := context.WithTimeout(ctx, time.Second*10)
 		l1Head, err := n.l1Source.L1BlockRefByLabel(fetchCtx, eth.Unsafe)
@@ -242,6 +265,7 @@ func (n *OpNode) initRuntimeConfig(ctx context.Context, cfg *Config) error {
 			n.log.Error("failed to fetch runtime config data", "err", err)
 			return l1Head, err
 		}
+		n.rotateP2PSigner()
 
 		err = n.handleProtocolVersionsUpdate(ctx)
 		return l1Head, err
@@ -314,12 +338,14 @@ func (n *OpNode) initL1BeaconAPI(ctx context.Context, cfg *Config) error {
 
 	// We always initialize a client. We will get an error on requests if the client does not work.
 	// This way the op-node can continue non-L1 functionality when the user chooses to ignore the Beacon API requirement.
-	beaconClient, fallbacks, err := cfg.Beacon.Setup(ctx, n.log)
+	beaconClient, fallbacks, archive, err := cfg.Beacon.Setup(ctx, n.log)
 	if err != nil {
 		return fmt.Errorf("failed to setup L1 Beacon API client: %w", err)
 	}
 	beaconCfg := sources.L1BeaconClientConfig{
 		FetchAllSidecars: cfg.Beacon.ShouldFetchAllSidecars(),
+		Archive:          archive,
+		Metrics:          n.metrics,
 	}
 	n.beacon = sources.NewL1BeaconClient(beaconClient, beaconCfg, fallbacks...)
 
@@ -412,6 +438,10 @@ func (n *OpNode) initL2(ctx context.Context, cfg *Config) error {
 	}
 	n.l2Driver = driver.NewDriver(&cfg.Driver, &cfg.Rollup, n.l2Source, n.l1Source,
 		n.supervisor, n.beacon, n, n, n.log, n.metrics, cfg.ConfigPersistence, n.safeDB, &cfg.Sync, sequencerConductor, altDA)
+	// The standby engine may be at different heads than the failed engine: re-sync the forkchoice state after a failover.
+	if failover, ok := rpcClient.(*sources.EngineFailoverRPC); ok {
+		failover.OnFailover(n.l2Driver.ResetEngine)
+	}
 	return nil
 }
 
@@ -423,6 +453,11 @@ func (n *OpNode) initRPCServer(cfg *Config) error {
 	if n.p2pEnabled() {
 		server.EnableP2P(p2p.NewP2PAPIBackend(n.p2pNode, n.log, n.metrics))
 	}
+	var blobs derive.L1BlobsFetcher
+	if n.beacon != nil {
+		blobs = n.beacon
+	}
+	server.EnableSafetyTrace(NewSafetyTracer(n.log.New("rpc", "safety-trace"), &cfg.Rollup, n.l1Source, blobs, n.l2Source, n.safeDB))
 	if cfg.RPC.EnableAdmin {
 		server.EnableAdminAPI(NewAdminAPI(n.l2Driver, n.metrics, n.log))
 		n.log.Info("Admin RPC enabled")
@@ -458,6 +493,7 @@ func (n *OpNode) initPProf(cfg *Config) error {
 		cfg.Pprof.ProfileType,
 		cfg.Pprof.ProfileDir,
 		cfg.Pprof.ProfileFilename,
+		cfg.Pprof.Upload,
 	)
 
 	if err := n.pprofService.Start(); err != nil {
@@ -467,6 +503,14 @@ func (n *OpNode) initPProf(cfg *Config) error {
 	return nil
 }
 
+func (n *OpNode) initTracing(ctx context.Context, cfg *Config) error {
+	n.tracingService = optracing.New(n.log, cfg.Tracing, "op-node", n.appVersion)
+	if err := n.tracingService.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start tracing service: %w", err)
+	}
+	return nil
+}
+
 func (n *OpNode) p2pEnabled() bool {
 	return n.cfg.P2PEnabled()
 }
@@ -498,6 +542,26 @@ func (n *OpNode) initP2PSigner(ctx context.Context, cfg *Config) (err error) {
 	return
 }
 
+// rotateP2PSigner switches the p2p signer to the key of the p2p sequencer address of the runtime config,
+// if the signer supports key rotation. Gossip is not interrupted: peers accept blocks of the previous key for a while.
+func (n *OpNode) rotateP2PSigner() {
+	signer, ok := n.p2pSigner.(p2p.RotatingSigner)
+	if !ok {
+		return
+	}
+	addr := n.runCfg.P2PSequencerAddress()
+	previous := signer.Address()
+	if addr == (common.Address{}) || addr == previous {
+		return
+	}
+	if err := signer.SetAddress(addr); err != nil {
+		n.log.Warn("Failed to rotate p2p signer to the p2p sequencer address of the runtime config",
+			"signer", previous, "p2p_seq_address", addr, "err", err)
+		return
+	}
+	n.log.Info("Rotated p2p signer", "previous", previous, "new", addr)
+}
+
 func (n *OpNode) Start(ctx context.Context) error {
 	n.log.Info("Starting execution engine driver")
 	// start driving engine: sync blocks by deriving them from L1 and driving them into the engine
@@ -505,6 +569,12 @@ func (n *OpNode) Start(ctx context.Context) error {
 		n.log.Error("Could not start a rollup node", "err", err)
 		return err
 	}
+	if n.checkpoint != nil {
+		go n.driveCheckpointSync(n.resourcesCtx, n.checkpoint)
+	}
+	if n.cfg.L1FinalityCheckpoint.Enabled() {
+		go n.driveL1FinalityCheckpoint(n.resourcesCtx, n.cfg.L1FinalityCheckpoint.ID(), n.cfg.L1EpochPollInterval)
+	}
 	log.Info("Rollup node started")
 	return nil
 }
@@ -539,6 +609,10 @@ func (n *OpNode) OnNewL1Finalized(ctx context.Context, sig eth.L1BlockRef) {
 	if n.l2Driver == nil {
 		return
 	}
+	if n.behindL1FinalityCheckpoint(sig) {
+		n.log.Debug("Ignoring L1 finalized block behind the L1 finality checkpoint", "finalized", sig)
+		return
+	}
 	// Pass on the event to the L2 Engine
 	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
 	defer cancel()
@@ -720,7 +794,12 @@ func (n *OpNode) Stop(ctx context.Context) error {
 		}
 	}
 
-	// Close metrics and pprof only after we are done idling
+	// Close metrics, pprof and tracing only after we are done idling
+	if n.tracingService != nil {
+		if err := n.tracingService.Stop(ctx); err != nil {
+			result = multierror.Append(result, fmt.Errorf("failed to stop tracing: %w", err))
+		}
+	}
 	if n.pprofService != nil {
 		if err := n.pprofService.Stop(ctx); err != nil {
 			result = multierror.Append(result, fmt.Errorf("failed to close pprof server: %w", err))
@@ -743,5 +822,5 @@ func (n *OpNode) HTTPEndpoint() string {
 	if n.server == nil {
 		return ""
 	}
-	return fmt.Sprintf("http://%s", n.server.Addr().String())
+	return fmt.Sprintf("http://%s", n.server.Endpoint())
 }
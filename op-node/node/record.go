@@ -0,0 +1,281 @@
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// Files within a record directory, one per recorded endpoint.
+const (
+	recordL1File     = "l1.jsonl"
+	recordL2File     = "l2.jsonl"
+	recordBeaconFile = "beacon.jsonl"
+)
+
+// RecordingL1Endpoint records all L1 RPC responses of the wrapped endpoint to a file in Dir.
+type RecordingL1Endpoint struct {
+	L1EndpointSetup
+	Dir string
+}
+
+var _ L1EndpointSetup = (*RecordingL1Endpoint)(nil)
+
+func (r *RecordingL1Endpoint) Setup(ctx context.Context, log log.Logger, rollupCfg *rollup.Config, m opmetrics.CircuitBreakerMetricer) (client.RPC, *sources.L1ClientConfig, error) {
+	cl, rpcCfg, err := r.L1EndpointSetup.Setup(ctx, log, rollupCfg, m)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := os.MkdirAll(r.Dir, 0o755); err != nil {
+		cl.Close()
+		return nil, nil, fmt.Errorf("failed to create record dir: %w", err)
+	}
+	recording, err := client.NewRecordingRPC(cl, filepath.Join(r.Dir, recordL1File))
+	if err != nil {
+		cl.Close()
+		return nil, nil, err
+	}
+	log.Info("Recording L1 RPC responses", "dir", r.Dir)
+	return recording, rpcCfg, nil
+}
+
+// ReplayL1Endpoint serves all L1 RPC requests from a recording in Dir, without connecting to any L1 node.
+// The L1 head is followed by polling the recorded latest block.
+type ReplayL1Endpoint struct {
+	// Config is used for the L1 client settings only, and must match the settings used for the recording.
+	Config *L1EndpointConfig
+	Dir    string
+}
+
+var _ L1EndpointSetup = (*ReplayL1Endpoint)(nil)
+
+func (r *ReplayL1Endpoint) Setup(ctx context.Context, log log.Logger, rollupCfg *rollup.Config, m opmetrics.CircuitBreakerMetricer) (client.RPC, *sources.L1ClientConfig, error) {
+	replay, err := client.NewReplayRPC(filepath.Join(r.Dir, recordL1File))
+	if err != nil {
+		return nil, nil, err
+	}
+	log.Info("Replaying recorded L1 RPC responses", "dir", r.Dir)
+	return client.NewPollingClient(ctx, log, replay, client.WithPollRate(r.Config.HttpPollInterval)), r.Config.clientConfig(rollupCfg), nil
+}
+
+func (r *ReplayL1Endpoint) Check() error {
+	if r.Config == nil {
+		return errors.New("replayed L1 endpoint config cannot be nil")
+	}
+	return r.Config.Check()
+}
+
+// RecordingL2Endpoint records all engine API exchanges of the wrapped endpoint to a file in Dir.
+type RecordingL2Endpoint struct {
+	L2EndpointSetup
+	Dir string
+}
+
+var _ L2EndpointSetup = (*RecordingL2Endpoint)(nil)
+
+func (r *RecordingL2Endpoint) Setup(ctx context.Context, log log.Logger, rollupCfg *rollup.Config) (client.RPC, *sources.EngineClientConfig, error) {
+	cl, rpcCfg, err := r.L2EndpointSetup.Setup(ctx, log, rollupCfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := os.MkdirAll(r.Dir, 0o755); err != nil {
+		cl.Close()
+		return nil, nil, fmt.Errorf("failed to create record dir: %w", err)
+	}
+	recording, err := client.NewRecordingRPC(cl, filepath.Join(r.Dir, recordL2File))
+	if err != nil {
+		cl.Close()
+		return nil, nil, err
+	}
+	log.Info("Recording engine API exchanges", "dir", r.Dir)
+	return recording, rpcCfg, nil
+}
+
+// ReplayL2Endpoint serves all engine API requests from a recording in Dir, without connecting to any execution engine.
+type ReplayL2Endpoint struct {
+	Dir string
+}
+
+var _ L2EndpointSetup = (*ReplayL2Endpoint)(nil)
+
+func (r *ReplayL2Endpoint) Setup(ctx context.Context, log log.Logger, rollupCfg *rollup.Config) (client.RPC, *sources.EngineClientConfig, error) {
+	replay, err := client.NewReplayRPC(filepath.Join(r.Dir, recordL2File))
+	if err != nil {
+		return nil, nil, err
+	}
+	log.Info("Replaying recorded engine API exchanges", "dir", r.Dir)
+	return replay, sources.EngineClientDefaultConfig(rollupCfg), nil
+}
+
+func (r *ReplayL2Endpoint) Check() error {
+	if r.Dir == "" {
+		return errors.New("empty replay dir")
+	}
+	return nil
+}
+
+// RecordingL1BeaconEndpoint records all L1 Beacon API responses of the wrapped endpoint, including those of
+// the fallback endpoints, to a file in Dir.
+type RecordingL1BeaconEndpoint struct {
+	L1BeaconEndpointSetup
+	Dir string
+}
+
+var _ L1BeaconEndpointSetup = (*RecordingL1BeaconEndpoint)(nil)
+
+func (r *RecordingL1BeaconEndpoint) Setup(ctx context.Context, log log.Logger) (sources.BeaconClient, []sources.BlobSideCarsFetcher, sources.BlobSideCarsFetcher, error) {
+	cl, fallbacks, archive, err := r.L1BeaconEndpointSetup.Setup(ctx, log)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if err := os.MkdirAll(r.Dir, 0o755); err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to create record dir: %w", err)
+	}
+	rec, err := client.NewCallRecorder(filepath.Join(r.Dir, recordBeaconFile))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	recordedFallbacks := make([]sources.BlobSideCarsFetcher, len(fallbacks))
+	for i, fb := range fallbacks {
+		recordedFallbacks[i] = &recordingBlobFetcher{f: fb, rec: rec, prefix: fallbackPrefix(i)}
+	}
+	var recordedArchive sources.BlobSideCarsFetcher
+	if archive != nil {
+		recordedArchive = &recordingBlobFetcher{f: archive, rec: rec, prefix: archivePrefix}
+	}
+	log.Info("Recording L1 Beacon API responses", "dir", r.Dir)
+	return &recordingBeaconClient{cl: cl, recordingBlobFetcher: recordingBlobFetcher{f: cl, rec: rec}}, recordedFallbacks, recordedArchive, nil
+}
+
+// ReplayL1BeaconEndpoint serves all L1 Beacon API requests from a recording in Dir,
+// without connecting to any Beacon node.
+type ReplayL1BeaconEndpoint struct {
+	// L1BeaconEndpointSetup is used for the beacon settings only, its Setup is never called.
+	L1BeaconEndpointSetup
+	Dir string
+	// Fallbacks is the number of fallback endpoints that were used for the recording.
+	Fallbacks int
+	// Archive is whether a blob archive was used for the recording.
+	Archive bool
+}
+
+var _ L1BeaconEndpointSetup = (*ReplayL1BeaconEndpoint)(nil)
+
+func (r *ReplayL1BeaconEndpoint) Setup(ctx context.Context, log log.Logger) (sources.BeaconClient, []sources.BlobSideCarsFetcher, sources.BlobSideCarsFetcher, error) {
+	replayer, err := client.LoadCallReplayer(filepath.Join(r.Dir, recordBeaconFile))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	fallbacks := make([]sources.BlobSideCarsFetcher, r.Fallbacks)
+	for i := range fallbacks {
+		fallbacks[i] = &replayBlobFetcher{replayer: replayer, prefix: fallbackPrefix(i)}
+	}
+	var archive sources.BlobSideCarsFetcher
+	if r.Archive {
+		archive = &replayBlobFetcher{replayer: replayer, prefix: archivePrefix}
+	}
+	log.Info("Replaying recorded L1 Beacon API responses", "dir", r.Dir)
+	return &replayBeaconClient{replayBlobFetcher{replayer: replayer}}, fallbacks, archive, nil
+}
+
+func (r *ReplayL1BeaconEndpoint) Check() error {
+	if r.Dir == "" {
+		return errors.New("empty replay dir")
+	}
+	return nil
+}
+
+const archivePrefix = "archive_"
+
+func fallbackPrefix(i int) string {
+	return fmt.Sprintf("fallback%d_", i)
+}
+
+type recordingBlobFetcher struct {
+	f      sources.BlobSideCarsFetcher
+	rec    *client.CallRecorder
+	prefix string
+}
+
+func (r *recordingBlobFetcher) BeaconBlobSideCars(ctx context.Context, fetchAllSidecars bool, slot uint64, hashes []eth.IndexedBlobHash) (eth.APIGetBlobSidecarsResponse, error) {
+	resp, err := r.f.BeaconBlobSideCars(ctx, fetchAllSidecars, slot, hashes)
+	return resp, record(r.rec, r.prefix+"beaconBlobSideCars", []any{fetchAllSidecars, slot, hashes}, resp, err)
+}
+
+type recordingBeaconClient struct {
+	cl sources.BeaconClient
+	recordingBlobFetcher
+}
+
+func (r *recordingBeaconClient) NodeVersion(ctx context.Context) (string, error) {
+	version, err := r.cl.NodeVersion(ctx)
+	return version, record(r.rec, "nodeVersion", nil, version, err)
+}
+
+func (r *recordingBeaconClient) ConfigSpec(ctx context.Context) (eth.APIConfigResponse, error) {
+	resp, err := r.cl.ConfigSpec(ctx)
+	return resp, record(r.rec, "configSpec", nil, resp, err)
+}
+
+func (r *recordingBeaconClient) BeaconGenesis(ctx context.Context) (eth.APIGenesisResponse, error) {
+	resp, err := r.cl.BeaconGenesis(ctx)
+	return resp, record(r.rec, "beaconGenesis", nil, resp, err)
+}
+
+// record persists the result of a call, and returns the error of the call, or the error of recording it.
+func record(rec *client.CallRecorder, method string, params any, result any, callErr error) error {
+	var encoded json.RawMessage
+	if callErr == nil {
+		var err error
+		if encoded, err = json.Marshal(result); err != nil {
+			return fmt.Errorf("failed to encode result of %v: %w", method, err)
+		}
+	}
+	if err := rec.Record(method, params, encoded, callErr); err != nil {
+		return err
+	}
+	return callErr
+}
+
+type replayBlobFetcher struct {
+	replayer *client.CallReplayer
+	prefix   string
+}
+
+func (r *replayBlobFetcher) BeaconBlobSideCars(ctx context.Context, fetchAllSidecars bool, slot uint64, hashes []eth.IndexedBlobHash) (eth.APIGetBlobSidecarsResponse, error) {
+	var resp eth.APIGetBlobSidecarsResponse
+	err := r.replayer.Replay(r.prefix+"beaconBlobSideCars", []any{fetchAllSidecars, slot, hashes}, &resp)
+	return resp, err
+}
+
+type replayBeaconClient struct {
+	replayBlobFetcher
+}
+
+func (r *replayBeaconClient) NodeVersion(ctx context.Context) (string, error) {
+	var version string
+	err := r.replayer.Replay("nodeVersion", nil, &version)
+	return version, err
+}
+
+func (r *replayBeaconClient) ConfigSpec(ctx context.Context) (eth.APIConfigResponse, error) {
+	var resp eth.APIConfigResponse
+	err := r.replayer.Replay("configSpec", nil, &resp)
+	return resp, err
+}
+
+func (r *replayBeaconClient) BeaconGenesis(ctx context.Context) (eth.APIGenesisResponse, error) {
+	var resp eth.APIGenesisResponse
+	err := r.replayer.Replay("beaconGenesis", nil, &resp)
+	return resp, err
+}
@@ -0,0 +1,97 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+type stubBeaconClient struct {
+	sidecars eth.APIGetBlobSidecarsResponse
+	err      error
+}
+
+func (s *stubBeaconClient) NodeVersion(ctx context.Context) (string, error) {
+	return "stub/v1", nil
+}
+
+func (s *stubBeaconClient) ConfigSpec(ctx context.Context) (eth.APIConfigResponse, error) {
+	return eth.APIConfigResponse{Data: eth.ReducedConfigData{SecondsPerSlot: 12}}, nil
+}
+
+func (s *stubBeaconClient) BeaconGenesis(ctx context.Context) (eth.APIGenesisResponse, error) {
+	return eth.APIGenesisResponse{Data: eth.ReducedGenesisData{GenesisTime: 1000}}, nil
+}
+
+func (s *stubBeaconClient) BeaconBlobSideCars(ctx context.Context, fetchAllSidecars bool, slot uint64, hashes []eth.IndexedBlobHash) (eth.APIGetBlobSidecarsResponse, error) {
+	return s.sidecars, s.err
+}
+
+type stubBeaconEndpoint struct {
+	L1BeaconEndpointConfig
+	cl       sources.BeaconClient
+	fallback sources.BlobSideCarsFetcher
+	archive  sources.BlobSideCarsFetcher
+}
+
+func (s *stubBeaconEndpoint) Setup(ctx context.Context, log log.Logger) (sources.BeaconClient, []sources.BlobSideCarsFetcher, sources.BlobSideCarsFetcher, error) {
+	return s.cl, []sources.BlobSideCarsFetcher{s.fallback}, s.archive, nil
+}
+
+func TestRecordReplayBeacon(t *testing.T) {
+	ctx := context.Background()
+	logger := testlog.Logger(t, log.LevelInfo)
+	dir := t.TempDir()
+	hashes := []eth.IndexedBlobHash{{Index: 1, Hash: common.Hash{0xaa}}}
+	sidecars := eth.APIGetBlobSidecarsResponse{Data: []*eth.APIBlobSidecar{{Index: 1}}}
+
+	recording := &RecordingL1BeaconEndpoint{
+		L1BeaconEndpointSetup: &stubBeaconEndpoint{
+			cl:       &stubBeaconClient{err: errors.New("primary unavailable")},
+			fallback: &stubBeaconClient{err: errors.New("fallback unavailable")},
+			archive:  &stubBeaconClient{sidecars: sidecars},
+		},
+		Dir: dir,
+	}
+	cl, fallbacks, archive, err := recording.Setup(ctx, logger)
+	require.NoError(t, err)
+	version, err := cl.NodeVersion(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "stub/v1", version)
+	genesis, err := cl.BeaconGenesis(ctx)
+	require.NoError(t, err)
+	_, err = cl.BeaconBlobSideCars(ctx, false, 5, hashes)
+	require.ErrorContains(t, err, "primary unavailable")
+	_, err = fallbacks[0].BeaconBlobSideCars(ctx, false, 5, hashes)
+	require.ErrorContains(t, err, "fallback unavailable")
+	_, err = archive.BeaconBlobSideCars(ctx, false, 5, hashes)
+	require.NoError(t, err)
+
+	replay := &ReplayL1BeaconEndpoint{Dir: dir, Fallbacks: 1, Archive: true}
+	cl, fallbacks, archive, err = replay.Setup(ctx, logger)
+	require.NoError(t, err)
+	require.Len(t, fallbacks, 1)
+	require.NotNil(t, archive)
+	version, err = cl.NodeVersion(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "stub/v1", version)
+	replayedGenesis, err := cl.BeaconGenesis(ctx)
+	require.NoError(t, err)
+	require.Equal(t, genesis, replayedGenesis)
+	_, err = cl.ConfigSpec(ctx)
+	require.ErrorContains(t, err, "no recorded response for configSpec")
+	_, err = cl.BeaconBlobSideCars(ctx, false, 5, hashes)
+	require.ErrorContains(t, err, "primary unavailable")
+	_, err = fallbacks[0].BeaconBlobSideCars(ctx, false, 5, hashes)
+	require.ErrorContains(t, err, "fallback unavailable")
+	replayedSidecars, err := archive.BeaconBlobSideCars(ctx, false, 5, hashes)
+	require.NoError(t, err)
+	require.Equal(t, sidecars, replayedSidecars)
+}
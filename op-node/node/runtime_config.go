@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
@@ -55,6 +56,13 @@ type RuntimeConfig struct {
 	l1Ref eth.L1BlockRef
 
 	runtimeConfigData
+
+	// prevP2PBlockSignerAddr is the p2p block signer that was replaced by the current one at p2pSignerRotatedAt.
+	// Blocks signed by it are still accepted for signerRotationGrace, until all nodes have observed the rotation.
+	prevP2PBlockSignerAddr common.Address
+	p2pSignerRotatedAt     time.Time
+	signerRotationGrace    time.Duration
+	now                    func() time.Time
 }
 
 // runtimeConfigData is a flat bundle of configurable data, easy and light to copy around.
@@ -66,13 +74,20 @@ type runtimeConfigData struct {
 	required    params.ProtocolVersion
 }
 
-var _ p2p.GossipRuntimeConfig = (*RuntimeConfig)(nil)
+var (
+	_ p2p.GossipRuntimeConfig         = (*RuntimeConfig)(nil)
+	_ p2p.SignerRotationRuntimeConfig = (*RuntimeConfig)(nil)
+)
 
-func NewRuntimeConfig(log log.Logger, l1Client RuntimeCfgL1Source, rollupCfg *rollup.Config) *RuntimeConfig {
+// NewRuntimeConfig creates a RuntimeConfig. After a rotation of the p2p block signer,
+// blocks signed by the previous signer are accepted for the signerRotationGrace duration.
+func NewRuntimeConfig(log log.Logger, l1Client RuntimeCfgL1Source, rollupCfg *rollup.Config, signerRotationGrace time.Duration) *RuntimeConfig {
 	return &RuntimeConfig{
-		log:       log,
-		l1Client:  l1Client,
-		rollupCfg: rollupCfg,
+		log:                 log,
+		l1Client:            l1Client,
+		rollupCfg:           rollupCfg,
+		signerRotationGrace: signerRotationGrace,
+		now:                 time.Now,
 	}
 }
 
@@ -82,6 +97,15 @@ func (r *RuntimeConfig) P2PSequencerAddress() common.Address {
 	return r.p2pBlockSignerAddr
 }
 
+func (r *RuntimeConfig) PreviousP2PSequencerAddress() (common.Address, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.prevP2PBlockSignerAddr == (common.Address{}) || r.now().Sub(r.p2pSignerRotatedAt) > r.signerRotationGrace {
+		return common.Address{}, false
+	}
+	return r.prevP2PBlockSignerAddr, true
+}
+
 func (r *RuntimeConfig) RequiredProtocolVersion() params.ProtocolVersion {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -119,7 +143,14 @@ func (r *RuntimeConfig) Load(ctx context.Context, l1Ref eth.L1BlockRef) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	r.l1Ref = l1Ref
-	r.p2pBlockSignerAddr = common.BytesToAddress(p2pSignerVal[:])
+	if p2pSignerAddr := common.BytesToAddress(p2pSignerVal[:]); p2pSignerAddr != r.p2pBlockSignerAddr {
+		if r.p2pBlockSignerAddr != (common.Address{}) {
+			r.log.Info("p2p sequencer address rotated", "previous", r.p2pBlockSignerAddr, "new", p2pSignerAddr, "grace", r.signerRotationGrace)
+			r.prevP2PBlockSignerAddr = r.p2pBlockSignerAddr
+			r.p2pSignerRotatedAt = r.now()
+		}
+		r.p2pBlockSignerAddr = p2pSignerAddr
+	}
 	r.required = requiredProtVersion
 	r.recommended = recommendedProtoVersion
 	r.log.Info("loaded new runtime config values!", "p2p_seq_address", r.p2pBlockSignerAddr)
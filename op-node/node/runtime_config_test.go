@@ -0,0 +1,57 @@
+package node
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestRuntimeConfigSignerRotation(t *testing.T) {
+	l1 := &testutils.MockEthClient{}
+	rollupCfg := &rollup.Config{L1SystemConfigAddress: common.Address{0xaa}}
+	now := time.Unix(1000, 0)
+	r := NewRuntimeConfig(testlog.Logger(t, log.LevelCrit), l1, rollupCfg, time.Minute)
+	r.now = func() time.Time { return now }
+
+	load := func(num uint64, signer common.Address) {
+		ref := eth.L1BlockRef{Number: num, Hash: common.Hash{byte(num)}}
+		l1.ExpectReadStorageAt(context.Background(), rollupCfg.L1SystemConfigAddress,
+			UnsafeBlockSignerAddressSystemConfigStorageSlot, ref.Hash, common.BytesToHash(signer[:]), nil)
+		require.NoError(t, r.Load(context.Background(), ref))
+	}
+
+	first, second := common.Address{0x01}, common.Address{0x02}
+	load(1, first)
+	require.Equal(t, first, r.P2PSequencerAddress())
+	_, ok := r.PreviousP2PSequencerAddress()
+	require.False(t, ok, "initial load is not a rotation")
+
+	load(2, first)
+	_, ok = r.PreviousP2PSequencerAddress()
+	require.False(t, ok, "unchanged signer is not a rotation")
+
+	load(3, second)
+	require.Equal(t, second, r.P2PSequencerAddress())
+	previous, ok := r.PreviousP2PSequencerAddress()
+	require.True(t, ok)
+	require.Equal(t, first, previous)
+
+	// Reloading the rotated signer does not extend the grace period of the previous signer
+	now = now.Add(30 * time.Second)
+	load(4, second)
+	_, ok = r.PreviousP2PSequencerAddress()
+	require.True(t, ok)
+	now = now.Add(31 * time.Second)
+	_, ok = r.PreviousP2PSequencerAddress()
+	require.False(t, ok, "previous signer is not accepted after the grace period")
+	l1.AssertExpectations(t)
+}
@@ -0,0 +1,129 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/node/safedb"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+type systemConfigSource interface {
+	SystemConfigByL2Hash(ctx context.Context, hash common.Hash) (eth.SystemConfig, error)
+}
+
+// SafetyTracer reconstructs how L2 blocks became safe, by re-deriving the L1 blocks that carried their batches.
+// If the safe head database is enabled, it is used to find the L1 block that made an L2 block safe,
+// so only the channel timeout before it is re-derived. Otherwise the sequencing window of the block's L1 origin is.
+type SafetyTracer struct {
+	log    log.Logger
+	cfg    *rollup.Config
+	l1     derive.SafetyTraceL1Fetcher
+	blobs  derive.L1BlobsFetcher
+	l2     systemConfigSource
+	safeDB SafeDBReader
+}
+
+func NewSafetyTracer(log log.Logger, cfg *rollup.Config, l1 derive.SafetyTraceL1Fetcher, blobs derive.L1BlobsFetcher, l2 systemConfigSource, safeDB SafeDBReader) *SafetyTracer {
+	return &SafetyTracer{
+		log:    log,
+		cfg:    cfg,
+		l1:     l1,
+		blobs:  blobs,
+		l2:     l2,
+		safeDB: safeDB,
+	}
+}
+
+// Trace returns the safety trace of a safe L2 block. The status must be the current sync status.
+func (t *SafetyTracer) Trace(ctx context.Context, block eth.L2BlockRef, status *eth.SyncStatus) (*derive.SafetyTrace, error) {
+	if block.Number > status.SafeL2.Number {
+		return nil, fmt.Errorf("block %s is not safe yet, safe head is %s", block, status.SafeL2)
+	}
+	if block.Number <= t.cfg.Genesis.L2.Number {
+		return nil, fmt.Errorf("block %s is at or before genesis, and was not derived from a batch", block)
+	}
+	sysCfg, err := t.l2.SystemConfigByL2Hash(ctx, block.Hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get system config of block %s: %w", block, err)
+	}
+
+	origin := block.L1Origin.Number
+	if inclusion, err := t.inclusionBlock(ctx, block, status.CurrentL1.Number); err == nil {
+		from := inclusion - min(inclusion, t.channelTimeout(ctx, inclusion))
+		trace, err := t.trace(ctx, block, sysCfg.BatcherAddr, from, inclusion, derive.SafetyTraceSourceSafeDB)
+		if !errors.Is(err, derive.ErrBatchNotFound) {
+			return trace, err
+		}
+		// The safe head database may be inconsistent with the chain after a reset, fall back to the sequencing window.
+		t.log.Warn("Batch not found before the inclusion block recorded in the safe head database", "block", block, "inclusion", inclusion)
+	} else if !errors.Is(err, safedb.ErrNotEnabled) && !errors.Is(err, safedb.ErrNotFound) {
+		return nil, fmt.Errorf("failed to look up inclusion block of %s: %w", block, err)
+	}
+	from := origin - min(origin, t.channelTimeout(ctx, origin))
+	to := min(origin+t.cfg.SeqWindowSize, status.CurrentL1.Number)
+	return t.trace(ctx, block, sysCfg.BatcherAddr, from, to, derive.SafetyTraceSourceSeqWindow)
+}
+
+func (t *SafetyTracer) trace(ctx context.Context, block eth.L2BlockRef, batcherAddr common.Address, from, to uint64, source string) (*derive.SafetyTrace, error) {
+	from = max(from, t.cfg.Genesis.L1.Number)
+	t.log.Debug("Tracing safety of block", "block", block, "source", source, "from", from, "to", to)
+	trace, err := derive.TraceSafety(ctx, t.log, t.cfg, t.l1, t.blobs, batcherAddr, block, from, to)
+	if err != nil {
+		return nil, err
+	}
+	trace.Source = source
+	return trace, nil
+}
+
+// inclusionBlock looks up the first L1 block at which the safe head was at or after the block.
+// The safe head database is searched from the block's L1 origin up to the given L1 block.
+func (t *SafetyTracer) inclusionBlock(ctx context.Context, block eth.L2BlockRef, maxL1 uint64) (uint64, error) {
+	origin := block.L1Origin.Number
+	if maxL1 < origin {
+		return 0, safedb.ErrNotFound
+	}
+	var searchErr error
+	i := sort.Search(int(maxL1-origin+1), func(i int) bool {
+		if searchErr != nil {
+			return true
+		}
+		_, safeHead, err := t.safeDB.SafeHeadAtL1(ctx, origin+uint64(i))
+		if errors.Is(err, safedb.ErrNotFound) {
+			return false
+		} else if err != nil {
+			searchErr = err
+			return true
+		}
+		return safeHead.Number >= block.Number
+	})
+	if searchErr != nil {
+		return 0, searchErr
+	}
+	if uint64(i) > maxL1-origin {
+		return 0, safedb.ErrNotFound
+	}
+	l1, _, err := t.safeDB.SafeHeadAtL1(ctx, origin+uint64(i))
+	if err != nil {
+		return 0, err
+	}
+	return l1.Number, nil
+}
+
+// channelTimeout returns the channel timeout at the time of the given L1 block.
+// The Bedrock timeout is used if the block can't be fetched, as it is the longer one.
+func (t *SafetyTracer) channelTimeout(ctx context.Context, l1Num uint64) uint64 {
+	spec := rollup.NewChainSpec(t.cfg)
+	ref, err := t.l1.L1BlockRefByNumber(ctx, l1Num)
+	if err != nil {
+		return t.cfg.ChannelTimeoutBedrock
+	}
+	return spec.ChannelTimeout(ref.Time)
+}
@@ -0,0 +1,55 @@
+package node
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/node/safedb"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// stubSafeDB holds safe head updates, ordered by L1 block.
+type stubSafeDB []struct {
+	l1, l2 uint64
+}
+
+func (s stubSafeDB) SafeHeadAtL1(_ context.Context, l1BlockNum uint64) (eth.BlockID, eth.BlockID, error) {
+	for i := len(s) - 1; i >= 0; i-- {
+		if s[i].l1 <= l1BlockNum {
+			return eth.BlockID{Number: s[i].l1}, eth.BlockID{Number: s[i].l2}, nil
+		}
+	}
+	return eth.BlockID{}, eth.BlockID{}, safedb.ErrNotFound
+}
+
+func TestSafetyTracerInclusionBlock(t *testing.T) {
+	db := stubSafeDB{{l1: 105, l2: 10}, {l1: 108, l2: 14}, {l1: 120, l2: 30}}
+	tracer := NewSafetyTracer(testlog.Logger(t, log.LevelInfo), &rollup.Config{}, nil, nil, nil, db)
+	inclusion := func(l2, origin uint64) (uint64, error) {
+		return tracer.inclusionBlock(context.Background(), eth.L2BlockRef{Number: l2, L1Origin: eth.BlockID{Number: origin}}, 130)
+	}
+
+	num, err := inclusion(10, 100)
+	require.NoError(t, err)
+	require.Equal(t, uint64(105), num)
+
+	num, err = inclusion(12, 104)
+	require.NoError(t, err)
+	require.Equal(t, uint64(108), num)
+
+	num, err = inclusion(30, 110)
+	require.NoError(t, err)
+	require.Equal(t, uint64(120), num)
+
+	_, err = inclusion(31, 110)
+	require.ErrorIs(t, err, safedb.ErrNotFound, "block is not safe in the searched range")
+
+	tracer.safeDB = safedb.Disabled
+	_, err = inclusion(10, 100)
+	require.ErrorIs(t, err, safedb.ErrNotEnabled)
+}
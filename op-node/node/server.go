@@ -3,36 +3,34 @@ package node
 import (
 	"context"
 	"fmt"
-	"net"
 	"net/http"
-	"strconv"
 
-	ophttp "github.com/ethereum-optimism/optimism/op-service/httputil"
 	"github.com/ethereum/go-ethereum/log"
-	"github.com/ethereum/go-ethereum/node"
 	"github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/ethereum-optimism/optimism/op-node/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/p2p"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
 )
 
 type rpcServer struct {
-	endpoint   string
+	rpcCfg     *RPCConfig
 	apis       []rpc.API
-	httpServer *ophttp.HTTPServer
+	server     *oprpc.Server
 	appVersion string
 	log        log.Logger
+	metrics    metrics.Metricer
+	nodeAPI    *nodeAPI
 	sources.L2Client
 }
 
 func newRPCServer(rpcCfg *RPCConfig, rollupCfg *rollup.Config, l2Client l2EthClient, dr driverClient, safedb SafeDBReader, log log.Logger, appVersion string, m metrics.Metricer) (*rpcServer, error) {
 	api := NewNodeAPI(rollupCfg, l2Client, dr, safedb, log.New("rpc", "node"), m)
-	// TODO: extend RPC config with options for WS, IPC and HTTP RPC connections
-	endpoint := net.JoinHostPort(rpcCfg.ListenAddr, strconv.Itoa(rpcCfg.ListenPort))
+	// TODO: extend RPC config with options for IPC RPC connections
 	r := &rpcServer{
-		endpoint: endpoint,
+		rpcCfg: rpcCfg,
 		apis: []rpc.API{{
 			Namespace:     "optimism",
 			Service:       api,
@@ -40,10 +38,16 @@ func newRPCServer(rpcCfg *RPCConfig, rollupCfg *rollup.Config, l2Client l2EthCli
 		}},
 		appVersion: appVersion,
 		log:        log,
+		metrics:    m,
+		nodeAPI:    api,
 	}
 	return r, nil
 }
 
+func (s *rpcServer) EnableSafetyTrace(tracer safetyTracer) {
+	s.nodeAPI.tracer = tracer
+}
+
 func (s *rpcServer) EnableAdminAPI(api *adminAPI) {
 	s.apis = append(s.apis, rpc.API{
 		Namespace:     "admin",
@@ -63,35 +67,31 @@ func (s *rpcServer) EnableP2P(backend *p2p.APIBackend) {
 }
 
 func (s *rpcServer) Start() error {
-	srv := rpc.NewServer()
-	if err := node.RegisterApis(s.apis, nil, srv); err != nil {
-		return err
-	}
-
-	// The CORS and VHosts arguments below must be set in order for
-	// other services to connect to the opnode. VHosts in particular
-	// defaults to localhost, which will prevent containers from
-	// calling into the opnode without an "invalid host" error.
-	nodeHandler := node.NewHTTPHandlerStack(srv, []string{"*"}, []string{"*"}, nil)
-
-	mux := http.NewServeMux()
-	mux.Handle("/", nodeHandler)
-	mux.HandleFunc("/healthz", healthzHandler(s.appVersion))
-
-	hs, err := ophttp.StartHTTPServer(s.endpoint, mux)
-	if err != nil {
+	// The server allows all CORS origins and VHosts by default, which must be the case in order for
+	// other services to connect to the opnode. VHosts in particular would otherwise default to localhost,
+	// which would prevent containers from calling into the opnode without an "invalid host" error.
+	// Websocket connections are served on the same endpoint, to support subscriptions.
+	server := oprpc.NewServer(s.rpcCfg.ListenAddr, s.rpcCfg.ListenPort, s.appVersion,
+		oprpc.WithAPIs(s.apis),
+		oprpc.WithWebsocket(),
+		oprpc.WithHealthzHandler(healthzHandler(s.appVersion)),
+		oprpc.WithLimits(s.rpcCfg.Limits, s.metrics),
+		oprpc.WithLogger(s.log),
+	)
+	if err := server.Start(); err != nil {
 		return fmt.Errorf("failed to start HTTP RPC server: %w", err)
 	}
-	s.httpServer = hs
+	s.server = server
 	return nil
 }
 
 func (r *rpcServer) Stop(ctx context.Context) error {
-	return r.httpServer.Stop(ctx)
+	return r.server.Stop()
 }
 
-func (r *rpcServer) Addr() net.Addr {
-	return r.httpServer.Addr()
+// Endpoint returns the address the server listens on.
+func (r *rpcServer) Endpoint() string {
+	return r.server.Endpoint()
 }
 
 func healthzHandler(appVersion string) http.HandlerFunc {
@@ -3,19 +3,26 @@ package node
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"math/rand"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
+	gethevent "github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/ethereum-optimism/optimism/op-node/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/attributes"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
 	"github.com/ethereum-optimism/optimism/op-node/version"
 	rpcclient "github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
@@ -109,7 +116,7 @@ func TestOutputAtBlock(t *testing.T) {
 		require.NoError(t, server.Stop(context.Background()))
 	}()
 
-	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Endpoint(), rpcclient.WithDialBackoff(3))
 	require.NoError(t, err)
 
 	var out *eth.OutputResponse
@@ -145,7 +152,7 @@ func TestVersion(t *testing.T) {
 		require.NoError(t, server.Stop(context.Background()))
 	}()
 
-	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Endpoint(), rpcclient.WithDialBackoff(3))
 	assert.NoError(t, err)
 
 	var out string
@@ -191,7 +198,7 @@ func TestSyncStatus(t *testing.T) {
 		require.NoError(t, server.Stop(context.Background()))
 	}()
 
-	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Endpoint(), rpcclient.WithDialBackoff(3))
 	assert.NoError(t, err)
 
 	var out *eth.SyncStatus
@@ -200,6 +207,132 @@ func TestSyncStatus(t *testing.T) {
 	assert.Equal(t, status, out)
 }
 
+func TestEventTrace(t *testing.T) {
+	log := testlog.Logger(t, log.LevelError)
+	l2Client := &testutils.MockL2Client{}
+	drClient := &mockDriverClient{}
+	safeReader := &mockSafeDBReader{}
+	start := time.Unix(1000, 0).UTC()
+	end := event.TraceEntry{Kind: event.TraceDeriveEnd, Name: "engine", EventName: "foo", EmitContext: 1, DerivContext: 2, EventTime: start}
+	end.DeriveEnd.Duration = time.Second
+	end.DeriveEnd.Effect = true
+	dump := &event.TraceDump{
+		Entries: []event.TraceEntry{
+			{Kind: event.TraceEmit, Name: "sync", EventName: "foo", EmitContext: 1, EventTime: start},
+			end,
+		},
+		Active:          []event.ActiveDerivation{{Name: "pipeline", EventName: "bar", EmitContext: 3, DerivContext: 4, StartTime: start, Elapsed: time.Minute}},
+		Queue:           []event.QueuedEvent{{EventName: "baz", EmitContext: 5}},
+		SlowDerivations: map[string]uint64{"engine": 1},
+	}
+	drClient.On("EventTrace").Return(dump)
+
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	rollupCfg := &rollup.Config{}
+	server, err := newRPCServer(rpcCfg, rollupCfg, l2Client, drClient, safeReader, log, "0.0", metrics.NoopMetrics)
+	require.NoError(t, err)
+	require.NoError(t, server.Start())
+	defer func() {
+		require.NoError(t, server.Stop(context.Background()))
+	}()
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Endpoint(), rpcclient.WithDialBackoff(3))
+	require.NoError(t, err)
+
+	var out *event.TraceDump
+	require.NoError(t, client.CallContext(context.Background(), &out, "optimism_eventTrace"))
+	require.Equal(t, dump, out)
+}
+
+func TestResetDerivationPipeline(t *testing.T) {
+	log := testlog.Logger(t, log.LevelError)
+	l2Client := &testutils.MockL2Client{}
+	drClient := &mockDriverClient{}
+	safeReader := &mockSafeDBReader{}
+	drClient.On("ResetDerivationPipeline").Return(nil).Once()
+	drClient.On("ResetDerivationPipelineTo", uint64(42)).Return(nil).Once()
+	drClient.On("ResetDerivationPipelineTo", uint64(100)).Return(errors.New("ahead of safe head")).Once()
+
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	rollupCfg := &rollup.Config{
+		// ignore other rollup config info in this test
+	}
+	server, err := newRPCServer(rpcCfg, rollupCfg, l2Client, drClient, safeReader, log, "0.0", metrics.NoopMetrics)
+	require.NoError(t, err)
+	server.EnableAdminAPI(NewAdminAPI(drClient, metrics.NoopMetrics, log))
+	require.NoError(t, server.Start())
+	defer func() {
+		require.NoError(t, server.Stop(context.Background()))
+	}()
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Endpoint(), rpcclient.WithDialBackoff(3))
+	require.NoError(t, err)
+
+	require.NoError(t, client.CallContext(context.Background(), nil, "admin_resetDerivationPipeline"))
+	require.NoError(t, client.CallContext(context.Background(), nil, "admin_resetDerivationPipeline", hexutil.Uint64(42)))
+	err = client.CallContext(context.Background(), nil, "admin_resetDerivationPipeline", hexutil.Uint64(100))
+	require.ErrorContains(t, err, "ahead of safe head")
+	drClient.Mock.AssertExpectations(t)
+}
+
+func TestHeadUpdates(t *testing.T) {
+	log := testlog.Logger(t, log.LevelError)
+	l2Client := &testutils.MockL2Client{}
+	drClient := &mockDriverClient{}
+	safeReader := &mockSafeDBReader{}
+
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	rollupCfg := &rollup.Config{
+		// ignore other rollup config info in this test
+	}
+	server, err := newRPCServer(rpcCfg, rollupCfg, l2Client, drClient, safeReader, log, "0.0", metrics.NoopMetrics)
+	require.NoError(t, err)
+	require.NoError(t, server.Start())
+	defer func() {
+		require.NoError(t, server.Stop(context.Background()))
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	client, err := gethrpc.DialContext(ctx, "ws://"+server.Endpoint())
+	require.NoError(t, err)
+	defer client.Close()
+
+	updates := make(chan eth.HeadUpdate, 1)
+	sub, err := client.Subscribe(ctx, "optimism", updates, "headUpdates")
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	rng := rand.New(rand.NewSource(1234))
+	expected := eth.HeadUpdate{
+		Kind: eth.HeadUpdateSafe,
+		Head: testutils.RandomL2BlockRef(rng),
+		L1:   testutils.RandomBlockID(rng),
+	}
+	// The driver-side subscription is registered asynchronously, so wait for it before sending.
+	require.Eventually(t, func() bool {
+		return drClient.headUpdates.Send(expected) > 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	select {
+	case update := <-updates:
+		require.Equal(t, expected, update)
+	case err := <-sub.Err():
+		t.Fatalf("subscription failed: %v", err)
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for head update")
+	}
+}
+
 func TestSafeHeadAtL1Block(t *testing.T) {
 	log := testlog.Logger(t, log.LevelError)
 	l2Client := &testutils.MockL2Client{}
@@ -234,7 +367,7 @@ func TestSafeHeadAtL1Block(t *testing.T) {
 		require.NoError(t, server.Stop(context.Background()))
 	}()
 
-	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Endpoint(), rpcclient.WithDialBackoff(3))
 	require.NoError(t, err)
 
 	var out *eth.SafeHeadResponse
@@ -248,6 +381,8 @@ func TestSafeHeadAtL1Block(t *testing.T) {
 
 type mockDriverClient struct {
 	mock.Mock
+
+	headUpdates gethevent.FeedOf[eth.HeadUpdate]
 }
 
 func (c *mockDriverClient) ExpectBlockRefWithStatus(num uint64, ref eth.L2BlockRef, status *eth.SyncStatus, err error) {
@@ -264,7 +399,11 @@ func (c *mockDriverClient) SyncStatus(ctx context.Context) (*eth.SyncStatus, err
 }
 
 func (c *mockDriverClient) ResetDerivationPipeline(ctx context.Context) error {
-	return c.Mock.MethodCalled("ResetDerivationPipeline").Get(0).(error)
+	return c.Mock.MethodCalled("ResetDerivationPipeline").Error(0)
+}
+
+func (c *mockDriverClient) ResetDerivationPipelineTo(ctx context.Context, num uint64) error {
+	return c.Mock.MethodCalled("ResetDerivationPipelineTo", num).Error(0)
 }
 
 func (c *mockDriverClient) StartSequencer(ctx context.Context, blockHash common.Hash) error {
@@ -283,10 +422,30 @@ func (c *mockDriverClient) OnUnsafeL2Payload(ctx context.Context, payload *eth.E
 	return c.Mock.MethodCalled("OnUnsafeL2Payload").Get(0).(error)
 }
 
+func (c *mockDriverClient) SubscribeHeadUpdates(ch chan<- eth.HeadUpdate) gethevent.Subscription {
+	return c.headUpdates.Subscribe(ch)
+}
+
 func (c *mockDriverClient) OverrideLeader(ctx context.Context) error {
 	return c.Mock.MethodCalled("OverrideLeader").Get(0).(error)
 }
 
+func (c *mockDriverClient) BatchDrops(ctx context.Context) (*derive.BatchDropReport, error) {
+	return c.Mock.MethodCalled("BatchDrops").Get(0).(*derive.BatchDropReport), nil
+}
+
+func (c *mockDriverClient) AttributesMismatches(ctx context.Context) ([]attributes.AttributesMismatch, error) {
+	return c.Mock.MethodCalled("AttributesMismatches").Get(0).([]attributes.AttributesMismatch), nil
+}
+
+func (c *mockDriverClient) EventTrace(ctx context.Context) (*event.TraceDump, error) {
+	return c.Mock.MethodCalled("EventTrace").Get(0).(*event.TraceDump), nil
+}
+
+func (c *mockDriverClient) SubmitBuilderPayload(ctx context.Context, envelope *eth.ExecutionPayloadEnvelope) error {
+	return c.Mock.MethodCalled("SubmitBuilderPayload").Get(0).(error)
+}
+
 type mockSafeDBReader struct {
 	mock.Mock
 }
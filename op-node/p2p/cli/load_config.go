@@ -110,6 +110,7 @@ func loadBanningOptions(conf *p2p.Config, ctx *cli.Context) error {
 	conf.BanningEnabled = ctx.Bool(flags.BanningName)
 	conf.BanningThreshold = ctx.Float64(flags.BanningThresholdName)
 	conf.BanningDuration = ctx.Duration(flags.BanningDurationName)
+	conf.PerPeerScoreMetrics = ctx.Bool(flags.PeerScoreMetricsName)
 	return nil
 }
 
@@ -340,5 +341,10 @@ func loadGossipOptions(conf *p2p.Config, ctx *cli.Context) error {
 	conf.MeshDHi = ctx.Int(flags.GossipMeshDhiName)
 	conf.MeshDLazy = ctx.Int(flags.GossipMeshDlazyName)
 	conf.FloodPublish = ctx.Bool(flags.GossipFloodPublishName)
+	conf.GossipValidation = p2p.GossipValidationConfig{
+		Workers:       int(ctx.Uint(flags.GossipValidationWorkersName)),
+		QueueSize:     int(ctx.Uint(flags.GossipValidationQueueName)),
+		PeerQueueSize: int(ctx.Uint(flags.GossipValidationPeerQueueName)),
+	}
 	return nil
 }
@@ -1,34 +1,63 @@
 package cli
 
 import (
+	"crypto/ecdsa"
+	"errors"
 	"fmt"
 	"strings"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/urfave/cli/v2"
 
 	"github.com/ethereum-optimism/optimism/op-node/flags"
 	"github.com/ethereum-optimism/optimism/op-node/p2p"
+	optls "github.com/ethereum-optimism/optimism/op-service/tls"
 )
 
-// TODO: implement remote signer setup (config to authenticated endpoint)
-// and remote signer itself (e.g. a open http client to make signing requests)
-
 // LoadSignerSetup loads a configuration for a Signer to be set up later
-func LoadSignerSetup(ctx *cli.Context) (p2p.SignerSetup, error) {
+func LoadSignerSetup(ctx *cli.Context, logger log.Logger) (p2p.SignerSetup, error) {
 	key := ctx.String(flags.SequencerP2PKeyName)
+	endpoint := ctx.String(flags.SequencerP2PSignerEndpointName)
+	if key != "" && endpoint != "" {
+		return nil, fmt.Errorf("only one of %s and %s can be set", flags.SequencerP2PKeyName, flags.SequencerP2PSignerEndpointName)
+	}
 	if key != "" {
 		// Mnemonics are bad because they leak *all* keys when they leak.
 		// Unencrypted keys from file are bad because they are easy to leak (and we are not checking file permissions).
-		priv, err := crypto.HexToECDSA(strings.TrimPrefix(key, "0x"))
-		if err != nil {
-			return nil, fmt.Errorf("failed to read batch submitter key: %w", err)
+		// Additional keys are only signed with after a rotation of the p2p sequencer address in the L1 SystemConfig.
+		var keys []*ecdsa.PrivateKey
+		for _, k := range strings.Split(key, ",") {
+			priv, err := crypto.HexToECDSA(strings.TrimPrefix(strings.TrimSpace(k), "0x"))
+			if err != nil {
+				return nil, fmt.Errorf("failed to read batch submitter key: %w", err)
+			}
+			keys = append(keys, priv)
 		}
 
-		return &p2p.PreparedSigner{Signer: p2p.NewLocalSigner(priv)}, nil
+		return &p2p.PreparedSigner{Signer: p2p.NewLocalSigner(keys[0], keys[1:]...)}, nil
 	}
 
-	// TODO: create remote signer
+	if endpoint != "" {
+		addr := ctx.String(flags.SequencerP2PSignerAddressName)
+		if !common.IsHexAddress(addr) {
+			return nil, fmt.Errorf("%s must be set to the address of the remote signer key", flags.SequencerP2PSignerAddressName)
+		}
+		tlsConfig := optls.ReadCLIConfigWithPrefix(ctx, flags.SequencerP2PSignerPrefix)
+		if err := tlsConfig.Check(); err != nil {
+			return nil, fmt.Errorf("invalid remote signer TLS config: %w", err)
+		}
+		return &p2p.RemoteSignerSetup{
+			Log:       logger,
+			Endpoint:  endpoint,
+			Address:   common.HexToAddress(addr),
+			TLSConfig: tlsConfig,
+		}, nil
+	}
+	if ctx.IsSet(flags.SequencerP2PSignerAddressName) {
+		return nil, errors.New("remote signer address is set, but the remote signer endpoint is not")
+	}
 
 	return nil, nil
 }
@@ -58,6 +58,8 @@ type SetupP2P interface {
 	BanPeers() bool
 	BanThreshold() float64
 	BanDuration() time.Duration
+	// PeerScoreMetrics returns true if the score components of every peer should be exported as metrics.
+	PeerScoreMetrics() bool
 	GossipSetupConfigurables
 	ReqRespSyncEnabled() bool
 }
@@ -84,6 +86,10 @@ type Config struct {
 	BanningThreshold float64
 	BanningDuration  time.Duration
 
+	// Whether to export the score components of every peer as separate metrics series.
+	// Not enabled by default, as the number of series grows with the number of peers.
+	PerPeerScoreMetrics bool
+
 	ListenIP      net.IP
 	ListenTCPPort uint16
 
@@ -115,6 +121,9 @@ type Config struct {
 	// FloodPublish publishes messages from ourselves to peers outside of the gossip topic mesh but supporting the same topic.
 	FloodPublish bool
 
+	// GossipValidation configures the workers that gossip messages are validated by.
+	GossipValidation GossipValidationConfig
+
 	// If true a NAT manager will host a NAT port mapping that is updated with PMP and UPNP by libp2p/go-nat
 	NAT bool
 
@@ -169,10 +178,18 @@ func (conf *Config) BanDuration() time.Duration {
 	return conf.BanningDuration
 }
 
+func (conf *Config) PeerScoreMetrics() bool {
+	return conf.PerPeerScoreMetrics
+}
+
 func (conf *Config) ReqRespSyncEnabled() bool {
 	return conf.EnableReqRespSync
 }
 
+func (conf *Config) GossipValidationConfig() GossipValidationConfig {
+	return conf.GossipValidation.WithDefaults()
+}
+
 const maxMeshParam = 1000
 
 func (conf *Config) Check() error {
@@ -205,5 +222,8 @@ func (conf *Config) Check() error {
 	if conf.MeshDLazy <= 0 || conf.MeshDLazy > maxMeshParam {
 		return fmt.Errorf("mesh Dlazy param must not be 0 or exceed %d, but got %d", maxMeshParam, conf.MeshDLazy)
 	}
+	if err := conf.GossipValidation.Check(); err != nil {
+		return err
+	}
 	return nil
 }
@@ -0,0 +1,165 @@
+package p2p
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+)
+
+// NodeIterSource is the subset of the discv5 service that the crawler needs.
+type NodeIterSource interface {
+	RandomNodes() enode.Iterator
+	AllNodes() []*enode.Node
+}
+
+// ENRRecord is a single entry of the ENR registry, as served over HTTP.
+type ENRRecord struct {
+	ENR       string    `json:"enr"`
+	NodeID    string    `json:"nodeID"`
+	PeerID    string    `json:"peerID,omitempty"`
+	Addr      string    `json:"addr,omitempty"`
+	ChainID   uint64    `json:"chainID"`
+	FirstSeen time.Time `json:"firstSeen"`
+	LastSeen  time.Time `json:"lastSeen"`
+}
+
+// ENRRegistry keeps track of all discovered node records that advertise the OP Stack identifier of the chain.
+// Records that have not been seen again within the expiry duration are dropped.
+type ENRRegistry struct {
+	mu      sync.RWMutex
+	records map[enode.ID]*ENRRecord
+	clock   clock.Clock
+	expiry  time.Duration
+}
+
+func NewENRRegistry(clock clock.Clock, expiry time.Duration) *ENRRegistry {
+	return &ENRRegistry{
+		records: make(map[enode.ID]*ENRRecord),
+		clock:   clock,
+		expiry:  expiry,
+	}
+}
+
+// Add records the node, or refreshes the last-seen time and ENR of an already known node.
+func (r *ENRRegistry) Add(node *enode.Node) {
+	var dat OpStackENRData
+	_ = node.Load(&dat) // nodes are expected to be filtered already, the chain ID is informational only
+	now := r.clock.Now()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rec, ok := r.records[node.ID()]
+	if !ok {
+		rec = &ENRRecord{NodeID: node.ID().String(), FirstSeen: now}
+		r.records[node.ID()] = rec
+	}
+	rec.ENR = node.String()
+	rec.ChainID = dat.chainID
+	rec.LastSeen = now
+	if info, _, err := enrToAddrInfo(node); err == nil {
+		rec.PeerID = info.ID.String()
+		rec.Addr = info.Addrs[0].String()
+	}
+}
+
+// Prune removes all records that have not been seen within the expiry duration.
+func (r *ENRRegistry) Prune() {
+	cutoff := r.clock.Now().Add(-r.expiry)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id, rec := range r.records {
+		if rec.LastSeen.Before(cutoff) {
+			delete(r.records, id)
+		}
+	}
+}
+
+// Records returns a copy of all unexpired records, most recently seen first.
+func (r *ENRRegistry) Records() []ENRRecord {
+	cutoff := r.clock.Now().Add(-r.expiry)
+	r.mu.RLock()
+	out := make([]ENRRecord, 0, len(r.records))
+	for _, rec := range r.records {
+		if rec.LastSeen.Before(cutoff) {
+			continue
+		}
+		out = append(out, *rec)
+	}
+	r.mu.RUnlock()
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].LastSeen.Equal(out[j].LastSeen) {
+			return out[i].LastSeen.After(out[j].LastSeen)
+		}
+		return out[i].NodeID < out[j].NodeID
+	})
+	return out
+}
+
+// ServeHTTP serves the registry as a JSON list of records.
+// With the "format=text" query parameter, only the ENRs are served, one per line,
+// suitable for use as a bootnodes list.
+func (r *ENRRegistry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	records := r.Records()
+	if req.URL.Query().Get("format") == "text" {
+		w.Header().Set("Content-Type", "text/plain")
+		for _, rec := range records {
+			_, _ = w.Write([]byte(rec.ENR + "\n"))
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(records)
+}
+
+// Crawl walks the discv5 DHT and adds every node record matching the chain to the registry,
+// until the context is cancelled or the DHT iteration stops.
+// Unlike the DiscoveryProcess, no connections are made: the crawler only collects records.
+func Crawl(ctx context.Context, log log.Logger, cfg *rollup.Config, src NodeIterSource, reg *ENRRegistry, interval time.Duration) {
+	filter := FilterEnodes(log, cfg)
+	iter := enode.Filter(src.RandomNodes(), filter)
+	go func() {
+		<-ctx.Done()
+		iter.Close() // unblocks any pending Next call
+	}()
+
+	// the table may already have nodes from a previous run or the bootnodes
+	for _, node := range src.AllNodes() {
+		if filter(node) {
+			reg.Add(node)
+		}
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	pruneTicker := time.NewTicker(time.Minute)
+	defer pruneTicker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("stopped DHT crawler")
+			return
+		case <-pruneTicker.C:
+			reg.Prune()
+		case <-ticker.C:
+			if !iter.Next() {
+				log.Info("discv5 DHT iteration stopped, closing crawler now...")
+				return
+			}
+			node := iter.Node()
+			reg.Add(node)
+			log.Debug("crawled node record", "nodeID", node.ID())
+		}
+	}
+}
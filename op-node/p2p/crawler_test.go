@@ -0,0 +1,95 @@
+package p2p
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/p2p/enr"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+)
+
+func testNodeRecord(t *testing.T, chainID uint64) *enode.Node {
+	priv, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	db, err := enode.OpenDB("")
+	require.NoError(t, err)
+	t.Cleanup(db.Close)
+	local := enode.NewLocalNode(db, priv)
+	local.SetStaticIP(net.IPv4(127, 0, 0, 1))
+	local.Set(enr.TCP(9222))
+	local.Set(&OpStackENRData{chainID: chainID, version: 0})
+	return local.Node()
+}
+
+func TestENRRegistry(t *testing.T) {
+	clk := clock.NewDeterministicClock(time.Unix(1000, 0))
+	reg := NewENRRegistry(clk, time.Hour)
+
+	a := testNodeRecord(t, 10)
+	b := testNodeRecord(t, 10)
+	reg.Add(a)
+	clk.AdvanceTime(time.Minute)
+	reg.Add(b)
+
+	records := reg.Records()
+	require.Len(t, records, 2)
+	require.Equal(t, b.String(), records[0].ENR, "most recently seen first")
+	require.Equal(t, a.String(), records[1].ENR)
+	require.Equal(t, uint64(10), records[1].ChainID)
+	require.NotEmpty(t, records[1].PeerID)
+	require.Equal(t, "/ip4/127.0.0.1/tcp/9222", records[1].Addr)
+
+	// re-adding refreshes the last-seen time, but keeps the first-seen time
+	clk.AdvanceTime(time.Minute)
+	reg.Add(a)
+	records = reg.Records()
+	require.Equal(t, a.String(), records[0].ENR)
+	require.Equal(t, time.Unix(1000, 0), records[0].FirstSeen)
+
+	// b expires first
+	clk.AdvanceTime(time.Hour - time.Second)
+	require.Len(t, reg.Records(), 1)
+	reg.Prune()
+	require.Len(t, reg.records, 1)
+	clk.AdvanceTime(time.Minute)
+	require.Empty(t, reg.Records())
+}
+
+func TestENRRegistryHTTP(t *testing.T) {
+	clk := clock.NewDeterministicClock(time.Unix(1000, 0))
+	reg := NewENRRegistry(clk, time.Hour)
+	node := testNodeRecord(t, 10)
+	reg.Add(node)
+
+	t.Run("json", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		reg.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/enrs", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		var out []ENRRecord
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &out))
+		require.Len(t, out, 1)
+		require.Equal(t, node.String(), out[0].ENR)
+		require.Equal(t, node.ID().String(), out[0].NodeID)
+	})
+	t.Run("text", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		reg.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/enrs?format=text", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, node.String(), strings.TrimSpace(rec.Body.String()))
+	})
+	t.Run("method", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		reg.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/enrs", nil))
+		require.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+}
@@ -20,11 +20,16 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/optracing"
 )
 
+var publishTracer = optracing.Tracer("op-node/p2p")
+
 const (
 	// maxGossipSize limits the total size of gossip RPC containers as well as decompressed individual messages.
 	maxGossipSize = 10 * (1 << 20)
@@ -55,12 +60,22 @@ type GossipSetupConfigurables interface {
 	PeerScoringParams() *ScoringParams
 	// ConfigureGossip creates configuration options to apply to the GossipSub setup
 	ConfigureGossip(rollupCfg *rollup.Config) []pubsub.Option
+	// GossipValidationConfig configures the workers that gossip messages are validated by.
+	GossipValidationConfig() GossipValidationConfig
 }
 
 type GossipRuntimeConfig interface {
 	P2PSequencerAddress() common.Address
 }
 
+// SignerRotationRuntimeConfig is an optional extension of GossipRuntimeConfig,
+// for runtime configs that keep track of rotations of the p2p sequencer address.
+type SignerRotationRuntimeConfig interface {
+	// PreviousP2PSequencerAddress returns the p2p sequencer address that was replaced by the current one,
+	// if blocks signed by it are still accepted while the rotation propagates to the sequencer and its peers.
+	PreviousP2PSequencerAddress() (common.Address, bool)
+}
+
 //go:generate mockery --name GossipMetricer
 type GossipMetricer interface {
 	RecordGossipEvent(evType int32)
@@ -183,6 +198,13 @@ func NewGossipSub(p2pCtx context.Context, h host.Host, cfg *rollup.Config, gossi
 		pubsub.WithBlacklist(denyList),
 		pubsub.WithEventTracer(&gossipTracer{m: m}),
 	}
+	// Static peers are direct peers: messages are always exchanged with them,
+	// and they are exempt from the score thresholds that would otherwise graylist them.
+	if extra, ok := h.(ExtraHostFeatures); ok {
+		if staticPeers := extra.StaticPeers(); len(staticPeers) > 0 {
+			gossipOpts = append(gossipOpts, pubsub.WithDirectPeers(staticPeers))
+		}
+	}
 	gossipOpts = append(gossipOpts, ConfigurePeerScoring(gossipConf, scorer, log)...)
 	gossipOpts = append(gossipOpts, gossipConf.ConfigureGossip(cfg)...)
 	return pubsub.NewGossipSub(p2pCtx, h, gossipOpts...)
@@ -429,12 +451,18 @@ func verifyBlockSignature(log log.Logger, cfg *rollup.Config, runCfg GossipRunti
 	// In the future we may load & validate block metadata before checking the signature.
 	// And then check the signer based on the metadata, to support e.g. multiple p2p signers at the same time.
 	// For now we only have one signer at a time and thus check the address directly.
-	// This means we may drop old payloads upon key rotation,
-	// but this can be recovered from like any other missed unsafe payload.
+	// Upon key rotation the previous signer remains accepted for a while,
+	// since the sequencer and its peers do not all observe the rotation at the same time.
 	if expected := runCfg.P2PSequencerAddress(); expected == (common.Address{}) {
 		log.Warn("no configured p2p sequencer address, ignoring gossiped block", "peer", id, "addr", addr)
 		return pubsub.ValidationIgnore
 	} else if addr != expected {
+		if rotation, ok := runCfg.(SignerRotationRuntimeConfig); ok {
+			if previous, ok := rotation.PreviousP2PSequencerAddress(); ok && addr == previous {
+				log.Debug("accepting block of previous p2p sequencer address during key rotation", "peer", id, "addr", addr, "expected", expected)
+				return pubsub.ValidationAccept
+			}
+		}
 		log.Warn("unexpected block author", "err", err, "peer", id, "addr", addr, "expected", expected)
 		return pubsub.ValidationReject
 	}
@@ -522,7 +550,11 @@ func (p *publisher) BlocksTopicV3Peers() []peer.ID {
 	return p.blocksV3.topic.ListPeers()
 }
 
-func (p *publisher) PublishL2Payload(ctx context.Context, envelope *eth.ExecutionPayloadEnvelope, signer Signer) error {
+func (p *publisher) PublishL2Payload(ctx context.Context, envelope *eth.ExecutionPayloadEnvelope, signer Signer) (outErr error) {
+	ctx, span := publishTracer.Start(ctx, "p2p.PublishL2Payload", trace.WithAttributes(
+		attribute.Int64("number", int64(envelope.ExecutionPayload.BlockNumber)),
+		attribute.String("hash", envelope.ExecutionPayload.BlockHash.String())))
+	defer func() { optracing.End(span, outErr) }()
 	res := msgBufPool.Get().(*[]byte)
 	buf := bytes.NewBuffer((*res)[:0])
 	defer func() {
@@ -553,6 +585,7 @@ func (p *publisher) PublishL2Payload(ctx context.Context, envelope *eth.Executio
 	// compress the full message
 	// This also copies the data, freeing up the original buffer to go back into the pool
 	out := snappy.Encode(nil, data)
+	span.SetAttributes(attribute.Int("size", len(out)))
 
 	if p.cfg.IsEcotone(uint64(envelope.ExecutionPayload.Timestamp)) {
 		return p.blocksV3.topic.Publish(ctx, out)
@@ -570,28 +603,29 @@ func (p *publisher) Close() error {
 	return errors.Join(e1, e2)
 }
 
-func JoinGossip(self peer.ID, ps *pubsub.PubSub, log log.Logger, cfg *rollup.Config, runCfg GossipRuntimeConfig, gossipIn GossipIn) (GossipOut, error) {
+// JoinGossip joins the blocks topics. The gossip messages are validated on the given validation pool.
+func JoinGossip(self peer.ID, ps *pubsub.PubSub, log log.Logger, cfg *rollup.Config, runCfg GossipRuntimeConfig, gossipIn GossipIn, pool *ValidationPool) (GossipOut, error) {
 	p2pCtx, p2pCancel := context.WithCancel(context.Background())
 
 	v1Logger := log.New("topic", "blocksV1")
-	blocksV1Validator := guardGossipValidator(log, logValidationResult(self, "validated blockv1", v1Logger, BuildBlocksValidator(v1Logger, cfg, runCfg, eth.BlockV1)))
-	blocksV1, err := newBlockTopic(p2pCtx, blocksTopicV1(cfg), ps, v1Logger, gossipIn, blocksV1Validator)
+	blocksV1Validator := pool.Wrap(guardGossipValidator(log, logValidationResult(self, "validated blockv1", v1Logger, BuildBlocksValidator(v1Logger, cfg, runCfg, eth.BlockV1))))
+	blocksV1, err := newBlockTopic(p2pCtx, blocksTopicV1(cfg), ps, v1Logger, gossipIn, blocksV1Validator, pool.TopicConcurrency())
 	if err != nil {
 		p2pCancel()
 		return nil, fmt.Errorf("failed to setup blocks v1 p2p: %w", err)
 	}
 
 	v2Logger := log.New("topic", "blocksV2")
-	blocksV2Validator := guardGossipValidator(log, logValidationResult(self, "validated blockv2", v2Logger, BuildBlocksValidator(v2Logger, cfg, runCfg, eth.BlockV2)))
-	blocksV2, err := newBlockTopic(p2pCtx, blocksTopicV2(cfg), ps, v2Logger, gossipIn, blocksV2Validator)
+	blocksV2Validator := pool.Wrap(guardGossipValidator(log, logValidationResult(self, "validated blockv2", v2Logger, BuildBlocksValidator(v2Logger, cfg, runCfg, eth.BlockV2))))
+	blocksV2, err := newBlockTopic(p2pCtx, blocksTopicV2(cfg), ps, v2Logger, gossipIn, blocksV2Validator, pool.TopicConcurrency())
 	if err != nil {
 		p2pCancel()
 		return nil, fmt.Errorf("failed to setup blocks v2 p2p: %w", err)
 	}
 
 	v3Logger := log.New("topic", "blocksV3")
-	blocksV3Validator := guardGossipValidator(log, logValidationResult(self, "validated blockv3", v3Logger, BuildBlocksValidator(v3Logger, cfg, runCfg, eth.BlockV3)))
-	blocksV3, err := newBlockTopic(p2pCtx, blocksTopicV3(cfg), ps, v3Logger, gossipIn, blocksV3Validator)
+	blocksV3Validator := pool.Wrap(guardGossipValidator(log, logValidationResult(self, "validated blockv3", v3Logger, BuildBlocksValidator(v3Logger, cfg, runCfg, eth.BlockV3))))
+	blocksV3, err := newBlockTopic(p2pCtx, blocksTopicV3(cfg), ps, v3Logger, gossipIn, blocksV3Validator, pool.TopicConcurrency())
 	if err != nil {
 		p2pCancel()
 		return nil, fmt.Errorf("failed to setup blocks v3 p2p: %w", err)
@@ -608,11 +642,11 @@ func JoinGossip(self peer.ID, ps *pubsub.PubSub, log log.Logger, cfg *rollup.Con
 	}, nil
 }
 
-func newBlockTopic(ctx context.Context, topicId string, ps *pubsub.PubSub, log log.Logger, gossipIn GossipIn, validator pubsub.ValidatorEx) (*blockTopic, error) {
+func newBlockTopic(ctx context.Context, topicId string, ps *pubsub.PubSub, log log.Logger, gossipIn GossipIn, validator pubsub.ValidatorEx, concurrency int) (*blockTopic, error) {
 	err := ps.RegisterTopicValidator(topicId,
 		validator,
 		pubsub.WithValidatorTimeout(3*time.Second),
-		pubsub.WithValidatorConcurrency(4))
+		pubsub.WithValidatorConcurrency(concurrency))
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to register gossip topic: %w", err)
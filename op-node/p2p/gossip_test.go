@@ -84,6 +84,22 @@ func TestVerifyBlockSignature(t *testing.T) {
 		require.Equal(t, pubsub.ValidationReject, result)
 	})
 
+	t.Run("PreviousSigner", func(t *testing.T) {
+		runCfg := &testutils.MockRuntimeConfig{
+			P2PSeqAddress:         common.HexToAddress("0x1234"),
+			PreviousP2PSeqAddress: crypto.PubkeyToAddress(secrets.SequencerP2P.PublicKey),
+		}
+		signer := &PreparedSigner{Signer: NewLocalSigner(secrets.SequencerP2P)}
+		sig, err := signer.Sign(context.Background(), SigningDomainBlocksV1, cfg.L2ChainID, msg)
+		require.NoError(t, err)
+		result := verifyBlockSignature(logger, cfg, runCfg, peerId, sig[:65], msg)
+		require.Equal(t, pubsub.ValidationAccept, result)
+
+		runCfg.PreviousP2PSeqAddress = common.HexToAddress("0x5678")
+		result = verifyBlockSignature(logger, cfg, runCfg, peerId, sig[:65], msg)
+		require.Equal(t, pubsub.ValidationReject, result)
+	})
+
 	t.Run("InvalidSignature", func(t *testing.T) {
 		runCfg := &testutils.MockRuntimeConfig{P2PSeqAddress: crypto.PubkeyToAddress(secrets.SequencerP2P.PublicKey)}
 		sig := make([]byte, 65)
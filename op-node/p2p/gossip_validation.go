@@ -0,0 +1,327 @@
+package p2p
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	DefaultGossipValidationWorkers       = 4
+	DefaultGossipValidationQueueSize     = maxValidateQueue
+	DefaultGossipValidationPeerQueueSize = 16
+)
+
+// Reasons for dropping a gossip message without validating it, as recorded in the metrics.
+const (
+	validationDropPeerQueueFull = "peer_queue_full"
+	validationDropQueueFull     = "queue_full"
+	validationDropExpired       = "expired"
+	validationDropClosed        = "closed"
+)
+
+// GossipValidationConfig configures the pool of workers that gossip messages are validated by.
+// Zero values are replaced by their defaults.
+type GossipValidationConfig struct {
+	// Workers is the number of messages that are validated concurrently, across all topics.
+	Workers int
+	// QueueSize is the maximum number of messages waiting to be validated, per priority.
+	QueueSize int
+	// PeerQueueSize is the maximum number of messages of a single peer waiting to be validated.
+	PeerQueueSize int
+}
+
+func (c GossipValidationConfig) Check() error {
+	if c.Workers < 0 {
+		return fmt.Errorf("gossip validation workers must not be negative, but got %d", c.Workers)
+	}
+	if c.QueueSize < 0 {
+		return fmt.Errorf("gossip validation queue size must not be negative, but got %d", c.QueueSize)
+	}
+	if c.PeerQueueSize < 0 {
+		return fmt.Errorf("gossip validation peer queue size must not be negative, but got %d", c.PeerQueueSize)
+	}
+	return nil
+}
+
+// WithDefaults returns a copy of the config, with the zero values replaced by their defaults.
+func (c GossipValidationConfig) WithDefaults() GossipValidationConfig {
+	if c.Workers == 0 {
+		c.Workers = DefaultGossipValidationWorkers
+	}
+	if c.QueueSize == 0 {
+		c.QueueSize = DefaultGossipValidationQueueSize
+	}
+	if c.PeerQueueSize == 0 {
+		c.PeerQueueSize = DefaultGossipValidationPeerQueueSize
+	}
+	return c
+}
+
+type GossipValidationMetricer interface {
+	RecordGossipValidationQueueDepth(priority string, depth int)
+	RecordGossipValidation(result string, wait time.Duration, duration time.Duration)
+	RecordGossipValidationDropped(reason string)
+}
+
+type validationPriority int
+
+const (
+	// validationPriorityHigh is used for messages published by ourselves and by static peers.
+	validationPriorityHigh validationPriority = iota
+	validationPriorityNormal
+	numValidationPriorities
+)
+
+func (p validationPriority) String() string {
+	switch p {
+	case validationPriorityHigh:
+		return "high"
+	case validationPriorityNormal:
+		return "normal"
+	default:
+		return fmt.Sprintf("unknown_%d", int(p))
+	}
+}
+
+type validationJob struct {
+	ctx      context.Context
+	from     peer.ID
+	msg      *pubsub.Message
+	fn       pubsub.ValidatorEx
+	queuedAt time.Time
+	// result is buffered, so workers never block on a validator that stopped waiting.
+	result chan pubsub.ValidationResult
+}
+
+type peerValidationQueue struct {
+	id   peer.ID
+	jobs []*validationJob
+}
+
+// validationQueue queues the jobs of a single priority.
+// Jobs are taken from the peers with pending jobs in round-robin order,
+// so a peer that floods us with messages only delays its own messages.
+type validationQueue struct {
+	peers map[peer.ID]*peerValidationQueue
+	// ring holds the peers with pending jobs, in the order they are served.
+	ring []*peerValidationQueue
+	size int
+}
+
+func (q *validationQueue) push(job *validationJob, maxSize int, maxPeerSize int) (dropReason string) {
+	if q.size >= maxSize {
+		return validationDropQueueFull
+	}
+	pq, ok := q.peers[job.from]
+	if !ok {
+		pq = &peerValidationQueue{id: job.from}
+		q.peers[job.from] = pq
+		q.ring = append(q.ring, pq)
+	}
+	if len(pq.jobs) >= maxPeerSize {
+		return validationDropPeerQueueFull
+	}
+	pq.jobs = append(pq.jobs, job)
+	q.size++
+	return ""
+}
+
+func (q *validationQueue) pop() *validationJob {
+	pq := q.ring[0]
+	job := pq.jobs[0]
+	pq.jobs[0] = nil
+	pq.jobs = pq.jobs[1:]
+	q.ring[0] = nil
+	q.ring = q.ring[1:]
+	if len(pq.jobs) > 0 {
+		q.ring = append(q.ring, pq)
+	} else {
+		delete(q.peers, pq.id)
+	}
+	q.size--
+	return job
+}
+
+// ValidationPool validates gossip messages on a fixed number of workers, instead of in the pubsub validation pipeline.
+// Messages wait in bounded queues, per priority and per peer, and are dropped with ValidationIgnore if the queues are full,
+// so that expensive validation, e.g. of signatures, can't back up the handling of all other gossip.
+// Messages published by ourselves and by static peers are always validated before those of other peers.
+type ValidationPool struct {
+	log log.Logger
+	cfg GossipValidationConfig
+	m   GossipValidationMetricer
+
+	self          peer.ID
+	priorityPeers map[peer.ID]struct{}
+
+	mu     sync.Mutex
+	cond   *sync.Cond
+	queues [numValidationPriorities]validationQueue
+	closed bool
+
+	wg sync.WaitGroup
+}
+
+// NewValidationPool creates a validation pool and starts its workers. The metrics are optional, and may be nil.
+func NewValidationPool(log log.Logger, cfg GossipValidationConfig, m GossipValidationMetricer, self peer.ID, priorityPeers []peer.ID) *ValidationPool {
+	cfg = cfg.WithDefaults()
+	p := &ValidationPool{
+		log:           log,
+		cfg:           cfg,
+		m:             m,
+		self:          self,
+		priorityPeers: make(map[peer.ID]struct{}, len(priorityPeers)),
+	}
+	p.cond = sync.NewCond(&p.mu)
+	for _, id := range priorityPeers {
+		p.priorityPeers[id] = struct{}{}
+	}
+	for i := range p.queues {
+		p.queues[i].peers = make(map[peer.ID]*peerValidationQueue)
+	}
+	p.wg.Add(cfg.Workers)
+	for i := 0; i < cfg.Workers; i++ {
+		go p.worker()
+	}
+	return p
+}
+
+// TopicConcurrency is the number of concurrent validations pubsub should allow per topic,
+// so that messages are throttled by the pool's queues rather than by pubsub.
+func (p *ValidationPool) TopicConcurrency() int {
+	return p.cfg.QueueSize + p.cfg.Workers
+}
+
+// Wrap returns a validator that runs fn on the pool, and waits for its result.
+func (p *ValidationPool) Wrap(fn pubsub.ValidatorEx) pubsub.ValidatorEx {
+	return func(ctx context.Context, id peer.ID, message *pubsub.Message) pubsub.ValidationResult {
+		job := &validationJob{
+			ctx:      ctx,
+			from:     id,
+			msg:      message,
+			fn:       fn,
+			queuedAt: time.Now(),
+			result:   make(chan pubsub.ValidationResult, 1),
+		}
+		if reason := p.submit(job); reason != "" {
+			p.log.Debug("Dropping gossip message without validation", "peer", id, "reason", reason)
+			p.recordDropped(reason)
+			return pubsub.ValidationIgnore
+		}
+		select {
+		case res := <-job.result:
+			return res
+		case <-ctx.Done():
+			return pubsub.ValidationIgnore
+		}
+	}
+}
+
+func (p *ValidationPool) priority(id peer.ID) validationPriority {
+	if id == p.self {
+		return validationPriorityHigh
+	}
+	if _, ok := p.priorityPeers[id]; ok {
+		return validationPriorityHigh
+	}
+	return validationPriorityNormal
+}
+
+func (p *ValidationPool) submit(job *validationJob) (dropReason string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return validationDropClosed
+	}
+	prio := p.priority(job.from)
+	if reason := p.queues[prio].push(job, p.cfg.QueueSize, p.cfg.PeerQueueSize); reason != "" {
+		return reason
+	}
+	p.recordQueueDepth(prio)
+	p.cond.Signal()
+	return ""
+}
+
+// next blocks until there is a job to run, and returns nil when the pool is closed.
+func (p *ValidationPool) next() *validationJob {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for {
+		if p.closed {
+			return nil
+		}
+		for prio := range p.queues {
+			if p.queues[prio].size > 0 {
+				job := p.queues[prio].pop()
+				p.recordQueueDepth(validationPriority(prio))
+				return job
+			}
+		}
+		p.cond.Wait()
+	}
+}
+
+func (p *ValidationPool) worker() {
+	defer p.wg.Done()
+	for {
+		job := p.next()
+		if job == nil {
+			return
+		}
+		p.run(job)
+	}
+}
+
+func (p *ValidationPool) run(job *validationJob) {
+	start := time.Now()
+	if job.ctx.Err() != nil {
+		// pubsub stopped waiting for the result, don't spend time on validating the message.
+		p.recordDropped(validationDropExpired)
+		job.result <- pubsub.ValidationIgnore
+		return
+	}
+	res := job.fn(job.ctx, job.from, job.msg)
+	if p.m != nil {
+		p.m.RecordGossipValidation(validationResultString(res), start.Sub(job.queuedAt), time.Since(start))
+	}
+	job.result <- res
+}
+
+func (p *ValidationPool) recordQueueDepth(prio validationPriority) {
+	if p.m != nil {
+		p.m.RecordGossipValidationQueueDepth(prio.String(), p.queues[prio].size)
+	}
+}
+
+func (p *ValidationPool) recordDropped(reason string) {
+	if p.m != nil {
+		p.m.RecordGossipValidationDropped(reason)
+	}
+}
+
+// Close stops the workers. Messages that are still queued are dropped with ValidationIgnore.
+func (p *ValidationPool) Close() {
+	p.mu.Lock()
+	p.closed = true
+	var pending []*validationJob
+	for prio := range p.queues {
+		for p.queues[prio].size > 0 {
+			pending = append(pending, p.queues[prio].pop())
+		}
+		p.recordQueueDepth(validationPriority(prio))
+	}
+	p.cond.Broadcast()
+	p.mu.Unlock()
+	for _, job := range pending {
+		p.recordDropped(validationDropClosed)
+		job.result <- pubsub.ValidationIgnore
+	}
+	p.wg.Wait()
+}
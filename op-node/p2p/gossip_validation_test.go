@@ -0,0 +1,169 @@
+package p2p
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type validationRecorder struct {
+	mu      sync.Mutex
+	order   []peer.ID
+	dropped map[string]int
+}
+
+func (r *validationRecorder) RecordGossipValidationQueueDepth(priority string, depth int) {}
+
+func (r *validationRecorder) RecordGossipValidation(result string, wait time.Duration, duration time.Duration) {
+}
+
+func (r *validationRecorder) RecordGossipValidationDropped(reason string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.dropped[reason]++
+}
+
+func (r *validationRecorder) Dropped(reason string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.dropped[reason]
+}
+
+func (r *validationRecorder) validator(ctx context.Context, id peer.ID, message *pubsub.Message) pubsub.ValidationResult {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.order = append(r.order, id)
+	return pubsub.ValidationAccept
+}
+
+func (r *validationRecorder) Order() []peer.ID {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]peer.ID(nil), r.order...)
+}
+
+// setupBlockedPool creates a pool with a single worker, which is blocked until the returned function is called.
+func setupBlockedPool(t *testing.T, cfg GossipValidationConfig, self peer.ID, priorityPeers []peer.ID) (*ValidationPool, *validationRecorder, func()) {
+	cfg.Workers = 1
+	rec := &validationRecorder{dropped: make(map[string]int)}
+	pool := NewValidationPool(testlog.Logger(t, log.LevelDebug), cfg, rec, self, priorityPeers)
+	t.Cleanup(pool.Close)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	go pool.Wrap(func(ctx context.Context, id peer.ID, message *pubsub.Message) pubsub.ValidationResult {
+		close(started)
+		<-release
+		return pubsub.ValidationAccept
+	})(context.Background(), "blocker", &pubsub.Message{})
+	<-started
+	return pool, rec, func() { close(release) }
+}
+
+// submitAsync validates a message of each of the peers in order, and waits until they are all queued.
+func submitAsync(t *testing.T, pool *ValidationPool, rec *validationRecorder, peers ...peer.ID) []chan pubsub.ValidationResult {
+	results := make([]chan pubsub.ValidationResult, len(peers))
+	for i, id := range peers {
+		results[i] = make(chan pubsub.ValidationResult, 1)
+		queued := func() int {
+			pool.mu.Lock()
+			defer pool.mu.Unlock()
+			return pool.queues[validationPriorityHigh].size + pool.queues[validationPriorityNormal].size
+		}
+		before := queued()
+		go func(res chan pubsub.ValidationResult, id peer.ID) {
+			res <- pool.Wrap(rec.validator)(context.Background(), id, &pubsub.Message{})
+		}(results[i], id)
+		require.Eventually(t, func() bool { return queued() > before || len(results[i]) > 0 }, time.Second, time.Millisecond)
+	}
+	return results
+}
+
+func TestValidationPool(t *testing.T) {
+	t.Run("RoundRobinPeers", func(t *testing.T) {
+		pool, rec, release := setupBlockedPool(t, GossipValidationConfig{}, "self", nil)
+		results := submitAsync(t, pool, rec, "a", "a", "a", "b", "c", "b")
+		release()
+		for _, res := range results {
+			require.Equal(t, pubsub.ValidationAccept, <-res)
+		}
+		require.Equal(t, []peer.ID{"a", "b", "c", "a", "b", "a"}, rec.Order())
+	})
+
+	t.Run("PrioritizeSelfAndStaticPeers", func(t *testing.T) {
+		pool, rec, release := setupBlockedPool(t, GossipValidationConfig{}, "self", []peer.ID{"static"})
+		results := submitAsync(t, pool, rec, "a", "b", "static", "self")
+		release()
+		for _, res := range results {
+			require.Equal(t, pubsub.ValidationAccept, <-res)
+		}
+		require.Equal(t, []peer.ID{"static", "self", "a", "b"}, rec.Order())
+	})
+
+	t.Run("PeerQueueFull", func(t *testing.T) {
+		pool, rec, release := setupBlockedPool(t, GossipValidationConfig{PeerQueueSize: 2}, "self", nil)
+		results := submitAsync(t, pool, rec, "a", "a", "b")
+		require.Equal(t, pubsub.ValidationIgnore, pool.Wrap(rec.validator)(context.Background(), "a", &pubsub.Message{}))
+		require.Equal(t, 1, rec.Dropped(validationDropPeerQueueFull))
+		release()
+		for _, res := range results {
+			require.Equal(t, pubsub.ValidationAccept, <-res)
+		}
+	})
+
+	t.Run("QueueFull", func(t *testing.T) {
+		pool, rec, release := setupBlockedPool(t, GossipValidationConfig{QueueSize: 2}, "self", nil)
+		results := submitAsync(t, pool, rec, "a", "b")
+		require.Equal(t, pubsub.ValidationIgnore, pool.Wrap(rec.validator)(context.Background(), "c", &pubsub.Message{}))
+		require.Equal(t, 1, rec.Dropped(validationDropQueueFull))
+		// The high priority queue is separate
+		results = append(results, submitAsync(t, pool, rec, "self")...)
+		release()
+		for _, res := range results {
+			require.Equal(t, pubsub.ValidationAccept, <-res)
+		}
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		pool, rec, release := setupBlockedPool(t, GossipValidationConfig{}, "self", nil)
+		ctx, cancel := context.WithCancel(context.Background())
+		res := make(chan pubsub.ValidationResult, 1)
+		go func() {
+			res <- pool.Wrap(rec.validator)(ctx, "a", &pubsub.Message{})
+		}()
+		require.Eventually(t, func() bool {
+			pool.mu.Lock()
+			defer pool.mu.Unlock()
+			return pool.queues[validationPriorityNormal].size == 1
+		}, time.Second, time.Millisecond)
+		cancel()
+		require.Equal(t, pubsub.ValidationIgnore, <-res)
+		release()
+		require.Eventually(t, func() bool { return rec.Dropped(validationDropExpired) == 1 }, time.Second, time.Millisecond)
+		require.Empty(t, rec.Order(), "expired message must not be validated")
+	})
+
+	t.Run("Close", func(t *testing.T) {
+		pool, rec, release := setupBlockedPool(t, GossipValidationConfig{}, "self", nil)
+		results := submitAsync(t, pool, rec, "a", "b")
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			release()
+		}()
+		pool.Close()
+		for _, res := range results {
+			require.Equal(t, pubsub.ValidationIgnore, <-res)
+		}
+		require.Equal(t, 2, rec.Dropped(validationDropClosed))
+		require.Equal(t, pubsub.ValidationIgnore, pool.Wrap(rec.validator)(context.Background(), "a", &pubsub.Message{}))
+	})
+}
@@ -34,12 +34,23 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/p2p/gating"
 	"github.com/ethereum-optimism/optimism/op-node/p2p/store"
 	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/retry"
 )
 
 const (
 	staticPeerTag = "static"
+	// staticPeerPollInterval is the interval at which the connectedness of static peers is checked.
+	// Disconnected peers are only redialed once their reconnect backoff has passed.
+	staticPeerPollInterval = time.Second
 )
 
+// staticPeerReconnectStrategy is the backoff between reconnect attempts of an unreachable static peer.
+var staticPeerReconnectStrategy retry.Strategy = &retry.ExponentialStrategy{
+	Min:       time.Second,
+	Max:       time.Minute,
+	MaxJitter: time.Second,
+}
+
 type HostNewStream interface {
 	NewStream(ctx context.Context, p peer.ID, pids ...protocol.ID) (network.Stream, error)
 }
@@ -49,6 +60,7 @@ type ExtraHostFeatures interface {
 	ConnectionGater() gating.BlockingConnectionGater
 	ConnectionManager() connmgr.ConnManager
 	IsStatic(peerID peer.ID) bool
+	StaticPeers() []peer.AddrInfo
 	SyncOnlyReqToStatic() bool
 }
 
@@ -81,6 +93,14 @@ func (e *extraHost) IsStatic(peerID peer.ID) bool {
 	return exists
 }
 
+func (e *extraHost) StaticPeers() []peer.AddrInfo {
+	out := make([]peer.AddrInfo, 0, len(e.staticPeers))
+	for _, addr := range e.staticPeers {
+		out = append(out, *addr)
+	}
+	return out
+}
+
 func (e *extraHost) SyncOnlyReqToStatic() bool {
 	return e.syncOnlyReqToStatic
 }
@@ -118,37 +138,79 @@ func (e *extraHost) dialStaticPeer(ctx context.Context, addr *peer.AddrInfo) err
 	return nil
 }
 
+// staticPeerBackoff tracks the reconnect attempts of a disconnected static peer.
+type staticPeerBackoff struct {
+	attempts int
+	next     time.Time
+}
+
+func (b *staticPeerBackoff) due(now time.Time) bool {
+	return !now.Before(b.next)
+}
+
+// failed records a failed reconnect attempt, and returns the time until the next attempt.
+func (b *staticPeerBackoff) failed(now time.Time, strategy retry.Strategy) time.Duration {
+	delay := strategy.Duration(b.attempts)
+	b.attempts++
+	b.next = now.Add(delay)
+	return delay
+}
+
+func (b *staticPeerBackoff) reset() {
+	*b = staticPeerBackoff{}
+}
+
 func (e *extraHost) monitorStaticPeers() {
-	tick := time.NewTicker(time.Minute)
+	tick := time.NewTicker(staticPeerPollInterval)
 	defer tick.Stop()
 
+	backoffs := make(map[peer.ID]*staticPeerBackoff, len(e.staticPeers))
+	for _, addr := range e.staticPeers {
+		backoffs[addr.ID] = new(staticPeerBackoff)
+	}
+
 	for {
 		select {
 		case <-tick.C:
+			now := time.Now()
 			ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
 			var wg sync.WaitGroup
 
-			e.log.Debug("polling static peers", "peers", len(e.staticPeers))
-			for _, addr := range e.staticPeers {
+			errs := make([]error, len(e.staticPeers))
+			for i, addr := range e.staticPeers {
+				b := backoffs[addr.ID]
 				connectedness := e.Network().Connectedness(addr.ID)
 				e.log.Trace("static peer connectedness", "peer", addr.ID, "connectedness", connectedness)
 
 				if connectedness == network.Connected {
+					if b.attempts > 0 {
+						e.log.Info("reconnected to static peer", "peer", addr.ID, "attempts", b.attempts)
+					}
+					b.reset()
+					continue
+				}
+				if !b.due(now) {
 					continue
 				}
 
 				wg.Add(1)
-				go func(addr *peer.AddrInfo) {
-					e.log.Warn("static peer disconnected, reconnecting", "peer", addr.ID)
-					if err := e.dialStaticPeer(ctx, addr); err != nil {
-						e.log.Warn("error reconnecting to static peer", "peer", addr.ID, "err", err)
-					}
+				go func(i int, addr *peer.AddrInfo) {
+					e.log.Warn("static peer disconnected, reconnecting", "peer", addr.ID, "attempt", b.attempts+1)
+					errs[i] = e.dialStaticPeer(ctx, addr)
 					wg.Done()
-				}(addr)
+				}(i, addr)
 			}
 
 			wg.Wait()
 			cancel()
+			for i, err := range errs {
+				if err == nil {
+					continue
+				}
+				addr := e.staticPeers[i]
+				delay := backoffs[addr.ID].failed(time.Now(), staticPeerReconnectStrategy)
+				e.log.Warn("error reconnecting to static peer", "peer", addr.ID, "retry_in", delay, "err", err)
+			}
 		case <-e.quitC:
 			return
 		}
@@ -28,6 +28,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/p2p/store"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/retry"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum-optimism/optimism/op-service/testutils"
 )
@@ -409,3 +410,24 @@ func TestP2PMocknet(t *testing.T) {
 	require.Equal(t, hostA.Network().Connectedness(hostC.ID()), network.Connected)
 	require.Equal(t, hostB.Network().Connectedness(hostC.ID()), network.Connected)
 }
+
+func TestStaticPeerBackoff(t *testing.T) {
+	strategy := &retry.ExponentialStrategy{Min: time.Second, Max: 10 * time.Second}
+	now := time.Unix(1000, 0)
+	var b staticPeerBackoff
+	require.True(t, b.due(now), "new static peer must be dialed immediately")
+
+	require.Equal(t, 2*time.Second, b.failed(now, strategy))
+	require.False(t, b.due(now.Add(time.Second)))
+	require.True(t, b.due(now.Add(2*time.Second)))
+
+	require.Equal(t, 3*time.Second, b.failed(now, strategy))
+	require.Equal(t, 5*time.Second, b.failed(now, strategy))
+	require.Equal(t, 9*time.Second, b.failed(now, strategy))
+	require.Equal(t, 10*time.Second, b.failed(now, strategy), "backoff must be capped")
+	require.False(t, b.due(now.Add(9*time.Second)))
+
+	b.reset()
+	require.True(t, b.due(now), "backoff must reset once connected")
+	require.Equal(t, 2*time.Second, b.failed(now, strategy))
+}
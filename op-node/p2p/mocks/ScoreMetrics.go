@@ -5,6 +5,8 @@ package mocks
 import (
 	mock "github.com/stretchr/testify/mock"
 
+	peer "github.com/libp2p/go-libp2p/core/peer"
+
 	store "github.com/ethereum-optimism/optimism/op-node/p2p/store"
 )
 
@@ -18,6 +20,11 @@ func (_m *ScoreMetrics) SetPeerScores(_a0 []store.PeerScores) {
 	_m.Called(_a0)
 }
 
+// SetPeerScoresByPeer provides a mock function with given fields: _a0
+func (_m *ScoreMetrics) SetPeerScoresByPeer(_a0 map[peer.ID]store.PeerScores) {
+	_m.Called(_a0)
+}
+
 type mockConstructorTestingTNewScoreMetrics interface {
 	mock.TestingT
 	Cleanup(func())
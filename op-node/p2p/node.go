@@ -46,6 +46,7 @@ type NodeP2P struct {
 	dv5Udp   *discover.UDPv5  // p2p discovery service
 	gs       *pubsub.PubSub   // p2p gossip router
 	gsOut    GossipOut        // p2p gossip application interface for publishing
+	gsVal    *ValidationPool  // p2p gossip validation workers
 	syncCl   *SyncClient
 	syncSrv  *ReqRespServer
 }
@@ -151,9 +152,11 @@ func (n *NodeP2P) init(
 			// register the sync protocol with libp2p host
 			payloadByNumber := MakeStreamHandler(resourcesCtx, log.New("serve", "payloads_by_number"), n.syncSrv.HandleSyncRequest)
 			n.host.SetStreamHandler(PayloadByNumberProtocolID(rollupCfg.L2ChainID), payloadByNumber)
+			payloadsByRange := MakeStreamHandler(resourcesCtx, log.New("serve", "payloads_by_range"), n.syncSrv.HandleRangeSyncRequest)
+			n.host.SetStreamHandler(PayloadsByRangeProtocolID(rollupCfg.L2ChainID), payloadsByRange)
 		}
 	}
-	n.scorer = NewScorer(rollupCfg, eps, metrics, n.appScorer, log)
+	n.scorer = NewScorer(rollupCfg, eps, metrics, n.appScorer, log, setup.PeerScoreMetrics())
 	// notify of any new connections/streams/etc.
 	n.host.Network().Notify(NewNetworkNotifier(log, metrics))
 	// note: the IDDelta functionality was removed from libP2P, and no longer needs to be explicitly disabled.
@@ -161,7 +164,18 @@ func (n *NodeP2P) init(
 	if err != nil {
 		return fmt.Errorf("failed to start gossipsub router: %w", err)
 	}
-	n.gsOut, err = JoinGossip(n.host.ID(), n.gs, log, rollupCfg, runCfg, gossipIn)
+	var staticPeers []peer.ID
+	if extra, ok := n.host.(ExtraHostFeatures); ok {
+		for _, info := range extra.StaticPeers() {
+			staticPeers = append(staticPeers, info.ID)
+		}
+	}
+	var valMetrics GossipValidationMetricer
+	if metrics != nil {
+		valMetrics = metrics
+	}
+	n.gsVal = NewValidationPool(log.New("p2p", "validation"), setup.GossipValidationConfig(), valMetrics, n.host.ID(), staticPeers)
+	n.gsOut, err = JoinGossip(n.host.ID(), n.gs, log, rollupCfg, runCfg, gossipIn, n.gsVal)
 	if err != nil {
 		return fmt.Errorf("failed to join blocks gossip topic: %w", err)
 	}
@@ -285,6 +299,9 @@ func (n *NodeP2P) Close() error {
 			result = multierror.Append(result, fmt.Errorf("failed to close gossip cleanly: %w", err))
 		}
 	}
+	if n.gsVal != nil {
+		n.gsVal.Close()
+	}
 	if n.host != nil {
 		if err := n.host.Close(); err != nil {
 			result = multierror.Append(result, fmt.Errorf("failed to close p2p host cleanly: %w", err))
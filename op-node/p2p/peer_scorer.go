@@ -17,6 +17,8 @@ type scorer struct {
 	appScorer ApplicationScorer
 	log       log.Logger
 	cfg       *rollup.Config
+
+	perPeerMetrics bool
 }
 
 // Peerstore is a subset of the libp2p peerstore.Peerstore interface.
@@ -43,16 +45,19 @@ type Scorer interface {
 //go:generate mockery --name ScoreMetrics --output mocks/
 type ScoreMetrics interface {
 	SetPeerScores([]store.PeerScores)
+	SetPeerScoresByPeer(map[peer.ID]store.PeerScores)
 }
 
 // NewScorer returns a new peer scorer.
-func NewScorer(cfg *rollup.Config, peerStore Peerstore, metricer ScoreMetrics, appScorer ApplicationScorer, log log.Logger) Scorer {
+// If perPeerMetrics is true, the score components of every peer are exported as separate metrics series.
+func NewScorer(cfg *rollup.Config, peerStore Peerstore, metricer ScoreMetrics, appScorer ApplicationScorer, log log.Logger, perPeerMetrics bool) Scorer {
 	return &scorer{
-		peerStore: peerStore,
-		metricer:  metricer,
-		appScorer: appScorer,
-		log:       log,
-		cfg:       cfg,
+		peerStore:      peerStore,
+		metricer:       metricer,
+		appScorer:      appScorer,
+		log:            log,
+		cfg:            cfg,
+		perPeerMetrics: perPeerMetrics,
 	}
 }
 
@@ -64,6 +69,10 @@ func (s *scorer) SnapshotHook() pubsub.ExtendedPeerScoreInspectFn {
 	blocksTopicName := blocksTopicV1(s.cfg)
 	return func(m map[peer.ID]*pubsub.PeerScoreSnapshot) {
 		allScores := make([]store.PeerScores, 0, len(m))
+		var byPeer map[peer.ID]store.PeerScores
+		if s.perPeerMetrics {
+			byPeer = make(map[peer.ID]store.PeerScores, len(m))
+		}
 		// Now set the new scores.
 		for id, snap := range m {
 			diff := store.GossipScores{
@@ -82,9 +91,15 @@ func (s *scorer) SnapshotHook() pubsub.ExtendedPeerScoreInspectFn {
 				s.log.Warn("Unable to update peer gossip score", "err", err)
 			} else {
 				allScores = append(allScores, peerScores)
+				if byPeer != nil {
+					byPeer[id] = peerScores
+				}
 			}
 		}
 		s.metricer.SetPeerScores(allScores)
+		if byPeer != nil {
+			s.metricer.SetPeerScoresByPeer(byPeer)
+		}
 	}
 }
 
@@ -46,6 +46,7 @@ func (testSuite *PeerScorerTestSuite) TestScorer_SnapshotHook() {
 		testSuite.mockMetricer,
 		&p2p.NoopApplicationScorer{},
 		testSuite.logger,
+		false,
 	)
 	inspectFn := scorer.SnapshotHook()
 
@@ -78,3 +79,28 @@ func (testSuite *PeerScorerTestSuite) TestScorer_SnapshotHook() {
 	}
 	inspectFn(snapshotMap)
 }
+
+// TestScorer_SnapshotHookPerPeerMetrics tests the snapshot hook records per-peer score metrics when enabled.
+func (testSuite *PeerScorerTestSuite) TestScorer_SnapshotHookPerPeerMetrics() {
+	scorer := p2p.NewScorer(
+		&rollup.Config{L2ChainID: big.NewInt(123)},
+		testSuite.mockStore,
+		testSuite.mockMetricer,
+		&p2p.NoopApplicationScorer{},
+		testSuite.logger,
+		true,
+	)
+	inspectFn := scorer.SnapshotHook()
+
+	scores := store.PeerScores{Gossip: store.GossipScores{Total: -100}}
+	testSuite.mockStore.On("SetScore", peer.ID("peer1"), &store.GossipScores{Total: float64(-100)}).Return(scores, nil).Once()
+	testSuite.mockMetricer.On("SetPeerScores", []store.PeerScores{scores}).Return(nil).Once()
+	testSuite.mockMetricer.On("SetPeerScoresByPeer", map[peer.ID]store.PeerScores{"peer1": scores}).Return(nil).Once()
+
+	inspectFn(map[peer.ID]*pubsub.PeerScoreSnapshot{
+		peer.ID("peer1"): {
+			Score: -100,
+		},
+	})
+	testSuite.mockMetricer.AssertExpectations(testSuite.T())
+}
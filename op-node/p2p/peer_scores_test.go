@@ -114,7 +114,7 @@ func newGossipSubs(testSuite *PeerScoresTestSuite, ctx context.Context, hosts []
 
 		scorer := p2p.NewScorer(
 			&rollup.Config{L2ChainID: big.NewInt(123)},
-			extPeerStore, testSuite.mockMetricer, &discriminatingAppScorer{badPeer: hosts[0].ID()}, logger)
+			extPeerStore, testSuite.mockMetricer, &discriminatingAppScorer{badPeer: hosts[0].ID()}, logger, false)
 		opts = append(opts, p2p.ConfigurePeerScoring(&p2p.Config{
 			ScoringParams: &p2p.ScoringParams{
 				PeerScoring: pubsub.PeerScoreParams{
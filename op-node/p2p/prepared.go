@@ -25,6 +25,9 @@ type Prepared struct {
 	UDPv5     *discover.UDPv5
 
 	EnableReqRespSync bool
+
+	// GossipOptions are additional options of the gossip service, e.g. to inspect gossip in tests.
+	GossipOptions []pubsub.Option
 }
 
 var _ SetupP2P = (*Prepared)(nil)
@@ -64,9 +67,13 @@ func (p *Prepared) Discovery(log log.Logger, rollupCfg *rollup.Config, tcpPort u
 }
 
 func (p *Prepared) ConfigureGossip(rollupCfg *rollup.Config) []pubsub.Option {
-	return []pubsub.Option{
+	return append([]pubsub.Option{
 		pubsub.WithGossipSubParams(BuildGlobalGossipParams(rollupCfg)),
-	}
+	}, p.GossipOptions...)
+}
+
+func (p *Prepared) GossipValidationConfig() GossipValidationConfig {
+	return GossipValidationConfig{}.WithDefaults()
 }
 
 func (p *Prepared) PeerScoringParams() *ScoringParams {
@@ -85,6 +92,10 @@ func (p *Prepared) BanDuration() time.Duration {
 	return 1 * time.Hour
 }
 
+func (p *Prepared) PeerScoreMetrics() bool {
+	return false
+}
+
 func (p *Prepared) Disabled() bool {
 	return false
 }
@@ -0,0 +1,216 @@
+package p2p
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/signer"
+	optls "github.com/ethereum-optimism/optimism/op-service/tls"
+)
+
+const (
+	// RemoteSignerTimeout bounds the duration of a signing request, so an unresponsive signer does not stall gossip.
+	RemoteSignerTimeout = 2 * time.Second
+	// RemoteSignerRetryInterval is the duration gossip is halted for after a signing request failed.
+	RemoteSignerRetryInterval = 10 * time.Second
+)
+
+var (
+	ErrRemoteSignerUnavailable = errors.New("remote signer is unavailable")
+	errSignerClosed            = errors.New("signer is closed")
+)
+
+var _ RotatingSigner = (*RemoteSigner)(nil)
+
+type blockPayloadSigner interface {
+	SignBlockPayload(ctx context.Context, args *signer.BlockPayloadArgs) ([65]byte, error)
+	Close()
+}
+
+// RemoteSigner signs gossiped block payloads with a remote signer service, via opsigner_signBlockPayload.
+// If the remote signer cannot be reached, or returns an invalid signature, signing fails fast for
+// RemoteSignerRetryInterval. This halts the gossip of new blocks, but not block production,
+// which is decoupled from gossip.
+type RemoteSigner struct {
+	log  log.Logger
+	dial func() (blockPayloadSigner, error)
+	now  func() time.Time
+
+	// mu guards the fields below. It is never held during requests to the remote signer.
+	mu      sync.Mutex
+	sender  common.Address
+	client  blockPayloadSigner
+	retryAt time.Time
+	failing bool
+	closed  bool
+}
+
+// NewRemoteSigner creates a RemoteSigner that signs with the key of the sender address, held by the remote signer
+// at the given endpoint. The connection to the remote signer is established on first use.
+func NewRemoteSigner(logger log.Logger, endpoint string, sender common.Address, tlsConfig optls.CLIConfig) *RemoteSigner {
+	return &RemoteSigner{
+		log:    logger,
+		sender: sender,
+		dial: func() (blockPayloadSigner, error) {
+			return signer.NewSignerClient(logger, endpoint, tlsConfig)
+		},
+		now: time.Now,
+	}
+}
+
+func (s *RemoteSigner) Sign(ctx context.Context, domain [32]byte, chainID *big.Int, encodedMsg []byte) (*[65]byte, error) {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil, errSignerClosed
+	}
+	if s.now().Before(s.retryAt) {
+		s.mu.Unlock()
+		return nil, ErrRemoteSignerUnavailable
+	}
+	sender := s.sender
+	s.mu.Unlock()
+
+	sig, err := s.sign(ctx, sender, domain, chainID, encodedMsg)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if sender != s.sender {
+		// The key was rotated during the request, its result says nothing about the availability of the new key.
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrRemoteSignerUnavailable, err)
+		}
+		return sig, nil
+	}
+	if err != nil {
+		if !s.failing {
+			s.log.Error("Remote signer failed, halting gossip of new blocks", "err", err, "retry_interval", RemoteSignerRetryInterval)
+		}
+		s.failing = true
+		s.retryAt = s.now().Add(RemoteSignerRetryInterval)
+		return nil, fmt.Errorf("%w: %w", ErrRemoteSignerUnavailable, err)
+	}
+	if s.failing {
+		s.log.Info("Remote signer recovered, resuming gossip of new blocks")
+		s.failing = false
+	}
+	return sig, nil
+}
+
+func (s *RemoteSigner) sign(ctx context.Context, sender common.Address, domain [32]byte, chainID *big.Int, encodedMsg []byte) (*[65]byte, error) {
+	client, err := s.connect()
+	if err != nil {
+		return nil, err
+	}
+	signingHash, err := SigningHash(domain, chainID, encodedMsg)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, RemoteSignerTimeout)
+	defer cancel()
+	sig, err := client.SignBlockPayload(ctx, &signer.BlockPayloadArgs{
+		Domain:        domain,
+		ChainID:       (*hexutil.Big)(chainID),
+		PayloadHash:   crypto.Keccak256Hash(encodedMsg),
+		SenderAddress: &sender,
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Gossiping a block with a bad signature gets the node penalized by its peers, so check it before use.
+	pub, err := crypto.SigToPub(signingHash[:], sig[:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature from remote signer: %w", err)
+	}
+	if addr := crypto.PubkeyToAddress(*pub); addr != sender {
+		return nil, fmt.Errorf("remote signer signed with key of %s, expected %s", addr, sender)
+	}
+	return &sig, nil
+}
+
+// connect returns the client of the remote signer, and connects to it first if not connected yet.
+// Must be called without mu held, as connecting makes a request to the remote signer.
+func (s *RemoteSigner) connect() (blockPayloadSigner, error) {
+	s.mu.Lock()
+	client := s.client
+	s.mu.Unlock()
+	if client != nil {
+		return client, nil
+	}
+	client, err := s.dial()
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to remote signer: %w", err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		client.Close()
+		return nil, errSignerClosed
+	}
+	if s.client != nil {
+		// Connected by a concurrent request in the meantime
+		client.Close()
+		return s.client, nil
+	}
+	s.client = client
+	return client, nil
+}
+
+func (s *RemoteSigner) Address() common.Address {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sender
+}
+
+// SetAddress switches to signing with the key of the given address, which must be held by the remote signer.
+// A signing failure of the previous key is not waited out, the new key is tried on the next signing request.
+func (s *RemoteSigner) SetAddress(addr common.Address) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return errSignerClosed
+	}
+	s.sender = addr
+	s.retryAt = time.Time{}
+	return nil
+}
+
+func (s *RemoteSigner) Close() error {
+	s.mu.Lock()
+	s.closed = true
+	client := s.client
+	s.client = nil
+	s.mu.Unlock()
+	if client != nil {
+		client.Close()
+	}
+	return nil
+}
+
+// RemoteSignerSetup sets up a RemoteSigner.
+type RemoteSignerSetup struct {
+	Log       log.Logger
+	Endpoint  string
+	Address   common.Address
+	TLSConfig optls.CLIConfig
+}
+
+// SetupSigner creates the RemoteSigner. An unreachable remote signer does not prevent the node from starting,
+// so blocks can still be produced: gossip is halted until the remote signer can be reached.
+func (r *RemoteSignerSetup) SetupSigner(ctx context.Context) (Signer, error) {
+	s := NewRemoteSigner(r.Log, r.Endpoint, r.Address, r.TLSConfig)
+	if _, err := s.connect(); err != nil {
+		r.Log.Warn("Remote signer is unavailable, gossip of new blocks is halted until it can be reached",
+			"endpoint", r.Endpoint, "err", err)
+	}
+	return s, nil
+}
@@ -0,0 +1,198 @@
+package p2p
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/signer"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// stubBlockPayloadSigner signs block payloads with a local key, like op-signer would.
+type stubBlockPayloadSigner struct {
+	signer *LocalSigner
+	err    error
+	calls  int
+	closed bool
+	// block, if not nil, blocks signing requests until it is closed
+	block chan struct{}
+}
+
+func (s *stubBlockPayloadSigner) SignBlockPayload(ctx context.Context, args *signer.BlockPayloadArgs) ([65]byte, error) {
+	s.calls++
+	if s.block != nil {
+		<-s.block
+	}
+	if s.err != nil {
+		return [65]byte{}, s.err
+	}
+	var msgInput [32 + 32 + 32]byte
+	copy(msgInput[:32], args.Domain[:])
+	args.ChainID.ToInt().FillBytes(msgInput[32:64])
+	copy(msgInput[64:], args.PayloadHash[:])
+	sig, err := crypto.Sign(crypto.Keccak256(msgInput[:]), s.signer.priv)
+	if err != nil {
+		return [65]byte{}, err
+	}
+	return [65]byte(sig), nil
+}
+
+func (s *stubBlockPayloadSigner) Close() {
+	s.closed = true
+}
+
+func TestRemoteSigner(t *testing.T) {
+	chainID := big.NewInt(100)
+	payload := []byte("arbitraryData")
+	setup := func(t *testing.T) (*RemoteSigner, *stubBlockPayloadSigner, *time.Time, *LocalSigner) {
+		priv, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		stub := &stubBlockPayloadSigner{signer: NewLocalSigner(priv)}
+		now := time.Unix(1000, 0)
+		s := &RemoteSigner{
+			log:    testlog.Logger(t, log.LevelCrit),
+			sender: crypto.PubkeyToAddress(priv.PublicKey),
+			dial: func() (blockPayloadSigner, error) {
+				return stub, nil
+			},
+			now: func() time.Time { return now },
+		}
+		return s, stub, &now, stub.signer
+	}
+
+	t.Run("MatchesLocalSigner", func(t *testing.T) {
+		s, _, _, local := setup(t)
+		sig, err := s.Sign(context.Background(), SigningDomainBlocksV1, chainID, payload)
+		require.NoError(t, err)
+		expected, err := local.Sign(context.Background(), SigningDomainBlocksV1, chainID, payload)
+		require.NoError(t, err)
+		require.Equal(t, expected, sig)
+	})
+
+	t.Run("HaltsAfterFailure", func(t *testing.T) {
+		s, stub, now, _ := setup(t)
+		stub.err = errors.New("connection refused")
+		_, err := s.Sign(context.Background(), SigningDomainBlocksV1, chainID, payload)
+		require.ErrorIs(t, err, ErrRemoteSignerUnavailable)
+		require.ErrorContains(t, err, "connection refused")
+
+		// Fails fast without contacting the signer until the retry interval passed
+		stub.err = nil
+		_, err = s.Sign(context.Background(), SigningDomainBlocksV1, chainID, payload)
+		require.ErrorIs(t, err, ErrRemoteSignerUnavailable)
+		require.Equal(t, 1, stub.calls)
+
+		*now = now.Add(RemoteSignerRetryInterval)
+		_, err = s.Sign(context.Background(), SigningDomainBlocksV1, chainID, payload)
+		require.NoError(t, err)
+		require.Equal(t, 2, stub.calls)
+	})
+
+	t.Run("RejectsWrongKey", func(t *testing.T) {
+		s, stub, _, _ := setup(t)
+		other, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		stub.signer = NewLocalSigner(other)
+		_, err = s.Sign(context.Background(), SigningDomainBlocksV1, chainID, payload)
+		require.ErrorIs(t, err, ErrRemoteSignerUnavailable)
+		require.ErrorContains(t, err, "expected "+s.sender.String())
+	})
+
+	t.Run("SetAddress", func(t *testing.T) {
+		s, stub, _, _ := setup(t)
+		other, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		stub.signer = NewLocalSigner(other)
+		_, err = s.Sign(context.Background(), SigningDomainBlocksV1, chainID, payload)
+		require.ErrorIs(t, err, ErrRemoteSignerUnavailable)
+
+		// The rotated key is used right away, without waiting for the retry interval of the failed key
+		addr := crypto.PubkeyToAddress(other.PublicKey)
+		require.NoError(t, s.SetAddress(addr))
+		require.Equal(t, addr, s.Address())
+		_, err = s.Sign(context.Background(), SigningDomainBlocksV1, chainID, payload)
+		require.NoError(t, err)
+	})
+
+	t.Run("RetriesConnect", func(t *testing.T) {
+		s, stub, now, _ := setup(t)
+		dialErr := errors.New("dial failed")
+		s.dial = func() (blockPayloadSigner, error) {
+			if dialErr != nil {
+				return nil, dialErr
+			}
+			return stub, nil
+		}
+		_, err := s.Sign(context.Background(), SigningDomainBlocksV1, chainID, payload)
+		require.ErrorContains(t, err, "dial failed")
+
+		dialErr = nil
+		*now = now.Add(RemoteSignerRetryInterval)
+		_, err = s.Sign(context.Background(), SigningDomainBlocksV1, chainID, payload)
+		require.NoError(t, err)
+	})
+
+	t.Run("Closed", func(t *testing.T) {
+		s, stub, _, _ := setup(t)
+		_, err := s.Sign(context.Background(), SigningDomainBlocksV1, chainID, payload)
+		require.NoError(t, err)
+		require.NoError(t, s.Close())
+		require.True(t, stub.closed, "client must be closed")
+		_, err = s.Sign(context.Background(), SigningDomainBlocksV1, chainID, payload)
+		require.ErrorContains(t, err, "closed")
+	})
+
+	t.Run("NotLockedDuringRequest", func(t *testing.T) {
+		s, stub, _, _ := setup(t)
+		stub.block = make(chan struct{})
+		done := make(chan error)
+		go func() {
+			_, err := s.Sign(context.Background(), SigningDomainBlocksV1, chainID, payload)
+			done <- err
+		}()
+		addr := s.sender
+		require.Eventually(t, func() bool {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			return s.client != nil
+		}, 5*time.Second, time.Millisecond)
+		// The signer can be used while a request is pending
+		require.Equal(t, addr, s.Address())
+		close(stub.block)
+		require.NoError(t, <-done)
+	})
+
+	t.Run("RotatedDuringRequest", func(t *testing.T) {
+		s, stub, _, _ := setup(t)
+		stub.block = make(chan struct{})
+		done := make(chan error)
+		go func() {
+			_, err := s.Sign(context.Background(), SigningDomainBlocksV1, chainID, payload)
+			done <- err
+		}()
+		require.Eventually(t, func() bool {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			return s.client != nil
+		}, 5*time.Second, time.Millisecond)
+		other, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		require.NoError(t, s.SetAddress(crypto.PubkeyToAddress(other.PublicKey)))
+		stub.err = errors.New("unknown key")
+		close(stub.block)
+		require.ErrorIs(t, <-done, ErrRemoteSignerUnavailable)
+
+		// A failure of the previous key does not halt signing with the new key
+		stub.err = nil
+		stub.signer = NewLocalSigner(other)
+		_, err = s.Sign(context.Background(), SigningDomainBlocksV1, chainID, payload)
+		require.NoError(t, err)
+	})
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"crypto/ecdsa"
 	"errors"
+	"fmt"
 	"io"
 	"math/big"
+	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -39,17 +41,40 @@ func BlockSigningHash(cfg *rollup.Config, payloadBytes []byte) (common.Hash, err
 	return SigningHash(SigningDomainBlocksV1, cfg.L2ChainID, payloadBytes)
 }
 
+// RotatingSigner is a Signer that can switch the key it signs with at runtime,
+// to follow a rotation of the unsafe block signer in the L1 SystemConfig.
+type RotatingSigner interface {
+	Signer
+	// Address returns the address of the key that is signed with.
+	Address() common.Address
+	// SetAddress switches to signing with the key of the given address.
+	// An error is returned if the signer has no key for the address.
+	SetAddress(addr common.Address) error
+}
+
 // LocalSigner is suitable for testing
 type LocalSigner struct {
+	mu     sync.Mutex
 	priv   *ecdsa.PrivateKey
+	keys   map[common.Address]*ecdsa.PrivateKey
 	hasher func(domain [32]byte, chainID *big.Int, payloadBytes []byte) (common.Hash, error)
 }
 
-func NewLocalSigner(priv *ecdsa.PrivateKey) *LocalSigner {
-	return &LocalSigner{priv: priv, hasher: SigningHash}
+var _ RotatingSigner = (*LocalSigner)(nil)
+
+// NewLocalSigner creates a LocalSigner that signs with priv.
+// The signer can be rotated to any of the rotationKeys, or back to priv, with SetAddress.
+func NewLocalSigner(priv *ecdsa.PrivateKey, rotationKeys ...*ecdsa.PrivateKey) *LocalSigner {
+	keys := make(map[common.Address]*ecdsa.PrivateKey, 1+len(rotationKeys))
+	for _, key := range append([]*ecdsa.PrivateKey{priv}, rotationKeys...) {
+		keys[crypto.PubkeyToAddress(key.PublicKey)] = key
+	}
+	return &LocalSigner{priv: priv, keys: keys, hasher: SigningHash}
 }
 
 func (s *LocalSigner) Sign(ctx context.Context, domain [32]byte, chainID *big.Int, encodedMsg []byte) (sig *[65]byte, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	if s.priv == nil {
 		return nil, errors.New("signer is closed")
 	}
@@ -64,8 +89,34 @@ func (s *LocalSigner) Sign(ctx context.Context, domain [32]byte, chainID *big.In
 	return (*[65]byte)(signature), nil
 }
 
+func (s *LocalSigner) Address() common.Address {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.priv == nil {
+		return common.Address{}
+	}
+	return crypto.PubkeyToAddress(s.priv.PublicKey)
+}
+
+func (s *LocalSigner) SetAddress(addr common.Address) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.priv == nil {
+		return errors.New("signer is closed")
+	}
+	key, ok := s.keys[addr]
+	if !ok {
+		return fmt.Errorf("no local key for p2p signer address %s", addr)
+	}
+	s.priv = key
+	return nil
+}
+
 func (s *LocalSigner) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.priv = nil
+	s.keys = nil
 	return nil
 }
 
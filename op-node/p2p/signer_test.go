@@ -1,9 +1,13 @@
 package p2p
 
 import (
+	"context"
 	"math/big"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/stretchr/testify/require"
 )
@@ -65,3 +69,35 @@ func TestSigningHash_LimitChainID(t *testing.T) {
 	_, err := SigningHash(SigningDomainBlocksV1, cfg.L2ChainID, []byte("arbitraryData"))
 	require.ErrorContains(t, err, "chain_id is too large")
 }
+
+func TestLocalSignerRotation(t *testing.T) {
+	chainID := big.NewInt(100)
+	payload := []byte("arbitraryData")
+	priv, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	next, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	s := NewLocalSigner(priv, next)
+	require.Equal(t, crypto.PubkeyToAddress(priv.PublicKey), s.Address())
+
+	signer := func(t *testing.T) common.Address {
+		sig, err := s.Sign(context.Background(), SigningDomainBlocksV1, chainID, payload)
+		require.NoError(t, err)
+		signingHash, err := SigningHash(SigningDomainBlocksV1, chainID, payload)
+		require.NoError(t, err)
+		pub, err := crypto.SigToPub(signingHash[:], sig[:])
+		require.NoError(t, err)
+		return crypto.PubkeyToAddress(*pub)
+	}
+	require.Equal(t, crypto.PubkeyToAddress(priv.PublicKey), signer(t))
+
+	require.NoError(t, s.SetAddress(crypto.PubkeyToAddress(next.PublicKey)))
+	require.Equal(t, crypto.PubkeyToAddress(next.PublicKey), s.Address())
+	require.Equal(t, crypto.PubkeyToAddress(next.PublicKey), signer(t))
+
+	require.ErrorContains(t, s.SetAddress(common.Address{0x42}), "no local key")
+	require.Equal(t, crypto.PubkeyToAddress(next.PublicKey), signer(t), "keeps signing with the current key")
+
+	require.NoError(t, s.Close())
+	require.ErrorContains(t, s.SetAddress(crypto.PubkeyToAddress(priv.PublicKey)), "closed")
+}
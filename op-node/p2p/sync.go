@@ -59,6 +59,9 @@ const (
 	// and eventually kick the peer based on degraded scoring if it's really not serving us well.
 	// TODO(CLI-4009): Use a backoff rather than this mechanism.
 	clientErrRateCost = peerServerBlocksBurst
+	// Do not serve more than 8 payloads in response to a single payloads-by-range request.
+	// Every payload counts as a request towards the rate-limits.
+	maxPayloadsByRange = 8
 )
 
 const (
@@ -79,6 +82,10 @@ func PayloadByNumberProtocolID(l2ChainID *big.Int) protocol.ID {
 	return protocol.ID(fmt.Sprintf("/opstack/req/payload_by_number/%d/0", l2ChainID))
 }
 
+func PayloadsByRangeProtocolID(l2ChainID *big.Int) protocol.ID {
+	return protocol.ID(fmt.Sprintf("/opstack/req/payloads_by_range/%d/0", l2ChainID))
+}
+
 type requestHandlerFn func(ctx context.Context, log log.Logger, stream network.Stream)
 
 func MakeStreamHandler(resourcesCtx context.Context, log log.Logger, fn requestHandlerFn) network.StreamHandler {
@@ -109,8 +116,10 @@ type syncResult struct {
 	peer    peer.ID
 }
 
+// peerRequest requests count consecutive payloads, starting at num.
 type peerRequest struct {
 	num        uint64
+	count      uint64
 	rangeReqId uint64
 }
 
@@ -233,6 +242,7 @@ type SyncClient struct {
 
 	newStreamFn     newStreamFn
 	payloadByNumber protocol.ID
+	payloadsByRange protocol.ID
 
 	peersLock sync.Mutex
 	// syncing worker per peer
@@ -291,6 +301,7 @@ func NewSyncClient(log log.Logger, cfg *rollup.Config, host HostNewStream, rcv r
 		appScorer:           appScorer,
 		newStreamFn:         host.NewStream,
 		payloadByNumber:     PayloadByNumberProtocolID(cfg.L2ChainID),
+		payloadsByRange:     PayloadsByRangeProtocolID(cfg.L2ChainID),
 		peers:               make(map[peer.ID]context.CancelFunc),
 		quarantineByNum:     make(map[uint64]common.Hash),
 		rangeRequests:       make(chan rangeRequest), // blocking
@@ -438,10 +449,34 @@ func (s *SyncClient) onRangeRequest(ctx context.Context, req rangeRequest) {
 	s.trusted.Add(req.end.Hash, struct{}{})
 	s.trusted.Add(req.end.ParentHash, struct{}{})
 
+	// Consecutive missing blocks are batched into a single peer request, which peers serve by range.
+	pr := peerRequest{rangeReqId: req.id}
+	schedule := func() bool {
+		if pr.count == 0 {
+			return true
+		}
+		log.Debug("Scheduling P2P block request", "num", pr.num, "count", pr.count, "rangeReqId", req.id)
+		select {
+		case s.peerRequests <- pr:
+			for i := uint64(0); i < pr.count; i++ {
+				s.inFlight.set(pr.num+i, true)
+			}
+			pr.count = 0
+			return true
+		case <-ctx.Done():
+			log.Info("did not schedule full P2P sync range", "current", pr.num, "err", ctx.Err())
+			return false
+		default: // peers may all be busy processing requests already
+			log.Info("no peers ready to handle block requests for more P2P requests for L2 block history", "current", pr.num)
+			return false
+		}
+	}
+
 	// Now try to fetch lower numbers than current end, to traverse back towards the updated start.
 	for i := uint64(0); ; i++ {
 		num := req.end.Number - 1 - i
 		if num <= req.start {
+			schedule()
 			return
 		}
 		// check if we have something in quarantine already
@@ -458,20 +493,18 @@ func (s *SyncClient) onRangeRequest(ctx context.Context, req rangeRequest) {
 			log.Debug("request still in-flight, not rescheduling sync request", "num", num)
 			continue // request still in flight
 		}
-		pr := peerRequest{num: num, rangeReqId: req.id}
 
-		log.Debug("Scheduling P2P block request", "num", num, "rangeReqId", req.id)
-		// schedule number
-		select {
-		case s.peerRequests <- pr:
-			s.inFlight.set(num, true)
-		case <-ctx.Done():
-			log.Info("did not schedule full P2P sync range", "current", num, "err", ctx.Err())
-			return
-		default: // peers may all be busy processing requests already
-			log.Info("no peers ready to handle block requests for more P2P requests for L2 block history", "current", num)
+		// extend the batch downwards if it's consecutive, and schedule it otherwise
+		if pr.count > 0 && pr.num == num+1 && pr.count < maxPayloadsByRange {
+			pr.num = num
+			pr.count++
+			continue
+		}
+		if !schedule() {
 			return
 		}
+		pr.num = num
+		pr.count = 1
 	}
 }
 
@@ -559,6 +592,12 @@ func (s *SyncClient) peerLoop(ctx context.Context, id peer.ID) {
 	log := s.log.New("peer", id)
 	log.Info("Starting P2P sync client event loop")
 
+	// Assume the peer serves payloads by range, until it negotiates the payload-by-number protocol instead.
+	byRange := true
+	request := func(ctx context.Context, id peer.ID, pr peerRequest) error {
+		return s.doRangeRequest(ctx, id, pr, &byRange)
+	}
+
 	// Implement the same rate limits as the server does per-peer,
 	// so we don't be too aggressive to the server.
 	rl := rate.NewLimiter(peerServerBlocksRateLimit, peerServerBlocksBurst)
@@ -586,20 +625,31 @@ func (s *SyncClient) peerLoop(ctx context.Context, id peer.ID) {
 		select {
 		case pr := <-peerRequests:
 			if !s.activeRangeRequests.get(pr.rangeReqId) {
-				log.Debug("dropping cancelled p2p sync request", "num", pr.num)
-				s.inFlight.delete(pr.num)
+				log.Debug("dropping cancelled p2p sync request", "num", pr.num, "count", pr.count)
+				for i := uint64(0); i < pr.count; i++ {
+					s.inFlight.delete(pr.num + i)
+				}
 				continue
 			}
 
+			// Every payload of the request counts towards the rate-limits, like it does for the server.
+			if pr.count > 1 {
+				if err := s.globalRL.WaitN(ctx, int(pr.count-1)); err != nil {
+					return
+				}
+				if err := rl.WaitN(ctx, int(pr.count-1)); err != nil {
+					return
+				}
+			}
+
 			// We already established the peer is available w.r.t. rate-limiting,
 			// and this is the only loop over this peer, so we can request now.
 			start := time.Now()
 
 			resultCode := ResultCodeSuccess
-			err := panicGuard(s.doRequest)(ctx, id, pr.num)
+			err := panicGuard(request)(ctx, id, pr)
 			if err != nil {
-				s.inFlight.delete(pr.num)
-				log.Warn("failed p2p sync request", "num", pr.num, "err", err)
+				log.Warn("failed p2p sync request", "num", pr.num, "count", pr.count, "err", err)
 				resultCode = ResultCodeNotFoundErr
 				sendResponseError := true
 
@@ -622,7 +672,7 @@ func (s *SyncClient) peerLoop(ctx context.Context, id peer.ID) {
 					return
 				}
 			} else {
-				log.Debug("completed p2p sync request", "num", pr.num)
+				log.Debug("completed p2p sync request", "num", pr.num, "count", pr.count)
 				s.appScorer.onValidResponse(id)
 			}
 
@@ -718,6 +768,116 @@ func (s *SyncClient) doRequest(ctx context.Context, id peer.ID, expectedBlockNum
 	return nil
 }
 
+var errRangeNotSupported = errors.New("peer does not support payloads-by-range requests")
+
+// doRangeRequest requests the payloads of the peer request with a single payloads-by-range request,
+// or with a payload-by-number request per payload if the peer does not support requests by range.
+// Like the sync itself, the payloads are requested from high to low.
+// Once done, the payloads that were not received are no longer marked as in-flight.
+func (s *SyncClient) doRangeRequest(ctx context.Context, id peer.ID, pr peerRequest, byRange *bool) error {
+	received := uint64(0)
+	defer func() {
+		// received results are cleared from the in-flight requests when they are processed
+		for i := uint64(0); i < pr.count-received; i++ {
+			s.inFlight.delete(pr.num + i)
+		}
+	}()
+	if *byRange {
+		n, err := s.requestRange(ctx, id, pr.num, pr.count)
+		received = n
+		if !errors.Is(err, errRangeNotSupported) {
+			return err
+		}
+		s.log.Debug("peer does not serve payloads by range, requesting payloads by number", "peer", id)
+		*byRange = false
+	}
+	for ; received < pr.count; received++ {
+		if err := s.doRequest(ctx, id, pr.num+pr.count-1-received); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// requestRange requests count payloads starting at the given block number, and returns how many were received.
+// The peer serves the payloads from high to low, and may serve fewer payloads than requested if it does not have all of them.
+func (s *SyncClient) requestRange(ctx context.Context, id peer.ID, start uint64, count uint64) (uint64, error) {
+	// open stream to peer, the payload-by-number protocol is negotiated if the peer does not support the range protocol.
+	reqCtx, reqCancel := context.WithTimeout(ctx, streamTimeout)
+	str, err := s.newStreamFn(reqCtx, id, s.payloadsByRange, s.payloadByNumber)
+	reqCancel()
+	if err != nil {
+		return 0, fmt.Errorf("failed to open stream: %w", err)
+	}
+	defer str.Close()
+	if str.Protocol() != s.payloadsByRange {
+		return 0, errRangeNotSupported
+	}
+	// set write timeout (if available)
+	_ = str.SetWriteDeadline(time.Now().Add(clientWriteRequestTimeout))
+	if err := binary.Write(str, binary.LittleEndian, [2]uint64{start, count}); err != nil {
+		return 0, fmt.Errorf("failed to write request (%d, %d): %w", start, count, err)
+	}
+	if err := str.CloseWrite(); err != nil {
+		return 0, fmt.Errorf("failed to close writer side while making request: %w", err)
+	}
+
+	for n := uint64(0); n < count; n++ {
+		// set read timeout per payload (if available)
+		_ = str.SetReadDeadline(time.Now().Add(clientReadResponsetimeout))
+		expectedBlockNum := start + count - 1 - n
+		envelope, err := s.readRangeChunk(str, expectedBlockNum)
+		if errors.Is(err, io.EOF) && n > 0 {
+			return n, nil
+		} else if err != nil {
+			return n, err
+		}
+		if err := verifyBlock(envelope, expectedBlockNum); err != nil {
+			return n, fmt.Errorf("received execution payload is invalid: %w", err)
+		}
+		select {
+		case s.results <- syncResult{payload: envelope, peer: id}:
+		case <-ctx.Done():
+			return n, fmt.Errorf("failed to process response, sync client is too busy: %w", ctx.Err())
+		}
+	}
+	if err := str.CloseRead(); err != nil {
+		return count, fmt.Errorf("failed to close reading side")
+	}
+	return count, nil
+}
+
+// readRangeChunk reads a single payload of a payloads-by-range response. It returns io.EOF if the response ended.
+func (s *SyncClient) readRangeChunk(str io.Reader, expectedBlockNum uint64) (*eth.ExecutionPayloadEnvelope, error) {
+	var result [1]byte
+	if _, err := io.ReadFull(str, result[:]); errors.Is(err, io.EOF) {
+		return nil, io.EOF
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read result part of response: %w", err)
+	}
+	if res := result[0]; res != 0 {
+		return nil, requestResultErr(res)
+	}
+	var header [8]byte
+	if _, err := io.ReadFull(str, header[:]); err != nil {
+		return nil, fmt.Errorf("failed to read header part of response: %w", err)
+	}
+	version := binary.LittleEndian.Uint32(header[:4])
+	size := binary.LittleEndian.Uint32(header[4:])
+	if size > maxGossipSize {
+		return nil, fmt.Errorf("payload of %d bytes exceeds max size %d", size, maxGossipSize)
+	}
+
+	// payload is SSZ encoded with Snappy framed compression, limited to the size of the chunk.
+	r := snappy.NewReader(io.LimitReader(str, int64(size)))
+	data, err := io.ReadAll(io.LimitReader(r, maxGossipSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	isCanyon := s.cfg.IsCanyon(s.cfg.TimestampForBlock(expectedBlockNum))
+	return readExecutionPayload(version, data, isCanyon)
+}
+
 // panicGuard is a generic function that takes another function with generic arguments and returns an error.
 // It recovers from any panic that occurs during the execution of the function.
 func panicGuard[T, S, U any](fn func(T, S, U) error) func(T, S, U) error {
@@ -848,35 +1008,56 @@ func (srv *ReqRespServer) HandleSyncRequest(ctx context.Context, log log.Logger,
 
 var errInvalidRequest = errors.New("invalid request")
 
-func (srv *ReqRespServer) handleSyncRequest(ctx context.Context, stream network.Stream) (uint64, error) {
-	peerId := stream.Conn().RemotePeer()
-
+// waitRateLimits takes n tokens from the global and the peer rate-limiters, waiting for them if necessary.
+func (srv *ReqRespServer) waitRateLimits(ctx context.Context, peerId peer.ID, n int) error {
 	// take a token from the global rate-limiter,
 	// to make sure there's not too much concurrent server work between different peers.
-	if err := srv.globalRequestsRL.Wait(ctx); err != nil {
-		return 0, fmt.Errorf("timed out waiting for global sync rate limit: %w", err)
+	if err := srv.globalRequestsRL.WaitN(ctx, n); err != nil {
+		return fmt.Errorf("timed out waiting for global sync rate limit: %w", err)
 	}
 
 	// find rate limiting data of peer, or add otherwise
 	srv.peerStatsLock.Lock()
+	defer srv.peerStatsLock.Unlock()
 	ps, _ := srv.peerRateLimits.Get(peerId)
 	if ps == nil {
 		ps = &peerStat{
 			Requests: rate.NewLimiter(peerServerBlocksRateLimit, peerServerBlocksBurst),
 		}
 		srv.peerRateLimits.Add(peerId, ps)
-		ps.Requests.Reserve() // count the hit, but make it delay the next request rather than immediately waiting
+		ps.Requests.ReserveN(time.Now(), n) // count the hit, but make it delay the next request rather than immediately waiting
 	} else {
 		// Only wait if it's an existing peer, otherwise the instant rate-limit Wait call always errors.
 
 		// If the requester thinks we're taking too long, then it's their problem and they can disconnect.
 		// We'll disconnect ourselves only when failing to read/write,
 		// if the work is invalid (range validation), or when individual sub tasks timeout.
-		if err := ps.Requests.Wait(ctx); err != nil {
-			return 0, fmt.Errorf("timed out waiting for global sync rate limit: %w", err)
+		if err := ps.Requests.WaitN(ctx, n); err != nil {
+			return fmt.Errorf("timed out waiting for global sync rate limit: %w", err)
 		}
 	}
-	srv.peerStatsLock.Unlock()
+	return nil
+}
+
+// checkServable checks the requested block number is within the range of blocks that may be served.
+func (srv *ReqRespServer) checkServable(num uint64) error {
+	if num < srv.cfg.Genesis.L2.Number {
+		return fmt.Errorf("cannot serve request for L2 block %d before genesis %d: %w", num, srv.cfg.Genesis.L2.Number, errInvalidRequest)
+	}
+	max, err := srv.cfg.TargetBlockNumber(uint64(time.Now().Unix()))
+	if err != nil {
+		return fmt.Errorf("cannot determine max target block number to verify request: %w", errInvalidRequest)
+	}
+	if num > max {
+		return fmt.Errorf("cannot serve request for L2 block %d after max expected block (%v): %w", num, max, errInvalidRequest)
+	}
+	return nil
+}
+
+func (srv *ReqRespServer) handleSyncRequest(ctx context.Context, stream network.Stream) (uint64, error) {
+	if err := srv.waitRateLimits(ctx, stream.Conn().RemotePeer(), 1); err != nil {
+		return 0, err
+	}
 
 	// Set read deadline, if available
 	_ = stream.SetReadDeadline(time.Now().Add(serverReadRequestTimeout))
@@ -891,15 +1072,8 @@ func (srv *ReqRespServer) handleSyncRequest(ctx context.Context, stream network.
 	}
 
 	// Check the request is within the expected range of blocks
-	if req < srv.cfg.Genesis.L2.Number {
-		return req, fmt.Errorf("cannot serve request for L2 block %d before genesis %d: %w", req, srv.cfg.Genesis.L2.Number, errInvalidRequest)
-	}
-	max, err := srv.cfg.TargetBlockNumber(uint64(time.Now().Unix()))
-	if err != nil {
-		return req, fmt.Errorf("cannot determine max target block number to verify request: %w", errInvalidRequest)
-	}
-	if req > max {
-		return req, fmt.Errorf("cannot serve request for L2 block %d after max expected block (%v): %w", req, max, errInvalidRequest)
+	if err := srv.checkServable(req); err != nil {
+		return req, err
 	}
 
 	envelope, err := srv.l2.PayloadByNumber(ctx, req)
@@ -944,3 +1118,127 @@ func (srv *ReqRespServer) handleSyncRequest(ctx context.Context, stream network.
 
 	return req, nil
 }
+
+// HandleRangeSyncRequest is a stream handler function to register the L2 unsafe payloads by range alt-sync protocol.
+// See MakeStreamHandler to transform this into a LibP2P handler function.
+//
+// The request consists of the first block number and the number of blocks, and the payloads are served from high to low.
+// Each payload is prefixed with its result code, version and compressed size. If a payload cannot be served,
+// the response ends with the result code of the failure, after the payloads that were served.
+//
+// The caller must Close the stream.
+func (srv *ReqRespServer) HandleRangeSyncRequest(ctx context.Context, log log.Logger, stream network.Stream) {
+	// may stay 0 if we fail to decode the request
+	start := time.Now()
+
+	ctx, cancel := context.WithTimeout(ctx, maxThrottleDelay)
+	req, err := srv.handleRangeSyncRequest(ctx, stream)
+	cancel()
+
+	resultCode := ResultCodeSuccess
+	if err != nil {
+		log.Warn("failed to serve p2p sync range request", "req", req, "err", err)
+		if errors.Is(err, ethereum.NotFound) {
+			resultCode = ResultCodeNotFoundErr
+		} else if errors.Is(err, errInvalidRequest) {
+			resultCode = ResultCodeInvalidErr
+		} else {
+			resultCode = ResultCodeUnknownErr
+		}
+		// try to write error code, so the other peer can understand the reason for failure.
+		_, _ = stream.Write([]byte{resultCode})
+	} else {
+		log.Debug("successfully served sync range response", "req", req)
+	}
+	srv.metrics.ServerPayloadByNumberEvent(req[0], resultCode, time.Since(start))
+}
+
+func (srv *ReqRespServer) handleRangeSyncRequest(ctx context.Context, stream network.Stream) ([2]uint64, error) {
+	// Set read deadline, if available
+	_ = stream.SetReadDeadline(time.Now().Add(serverReadRequestTimeout))
+
+	// Read the request: the first block number, and the number of blocks
+	var req [2]uint64
+	if err := binary.Read(stream, binary.LittleEndian, &req); err != nil {
+		return req, fmt.Errorf("failed to read requested block range: %w", err)
+	}
+	if err := stream.CloseRead(); err != nil {
+		return req, fmt.Errorf("failed to close reading-side of a P2P sync request call: %w", err)
+	}
+	first, count := req[0], req[1]
+	if count == 0 || count > maxPayloadsByRange {
+		return req, fmt.Errorf("cannot serve request for %d L2 blocks, max is %d: %w", count, maxPayloadsByRange, errInvalidRequest)
+	}
+	last := first + count - 1
+	if last < first {
+		return req, fmt.Errorf("requested L2 block range overflows: %w", errInvalidRequest)
+	}
+
+	// Check the request is within the expected range of blocks
+	if err := srv.checkServable(first); err != nil {
+		return req, err
+	}
+	if err := srv.checkServable(last); err != nil {
+		return req, err
+	}
+
+	// every payload counts as a request
+	if err := srv.waitRateLimits(ctx, stream.Conn().RemotePeer(), int(count)); err != nil {
+		return req, err
+	}
+
+	for num := last; num >= first; num-- {
+		envelope, err := srv.l2.PayloadByNumber(ctx, num)
+		if err != nil {
+			if errors.Is(err, ethereum.NotFound) {
+				return req, fmt.Errorf("peer requested unknown block %d by range: %w", num, err)
+			} else {
+				return req, fmt.Errorf("failed to retrieve payload %d to serve to peer: %w", num, err)
+			}
+		}
+		// We set write deadline per payload, if available, to safely write without blocking on a throttling peer connection
+		_ = stream.SetWriteDeadline(time.Now().Add(serverWriteChunkTimeout))
+		if err := srv.writeRangeChunk(stream, envelope); err != nil {
+			return req, err
+		}
+		if num == 0 {
+			break
+		}
+	}
+	return req, nil
+}
+
+// writeRangeChunk writes a payload of a payloads-by-range response.
+func (srv *ReqRespServer) writeRangeChunk(w io.Writer, envelope *eth.ExecutionPayloadEnvelope) error {
+	// The payload is SSZ encoded with Snappy framed compression, and buffered to prefix it with its size.
+	var buf bytes.Buffer
+	sw := snappy.NewBufferedWriter(&buf)
+	var version uint32
+	if srv.cfg.IsEcotone(uint64(envelope.ExecutionPayload.Timestamp)) {
+		version = 1
+		if _, err := envelope.MarshalSSZ(sw); err != nil {
+			return fmt.Errorf("failed to encode payload of sync response: %w", err)
+		}
+	} else {
+		if _, err := envelope.ExecutionPayload.MarshalSSZ(sw); err != nil {
+			return fmt.Errorf("failed to encode payload of sync response: %w", err)
+		}
+	}
+	if err := sw.Close(); err != nil {
+		return fmt.Errorf("failed to compress payload of sync response: %w", err)
+	}
+
+	// 0 - resultCode: success = 0
+	// 1:5 - version (little endian)
+	// 5:9 - size of the compressed payload (little endian)
+	var header [9]byte
+	binary.LittleEndian.PutUint32(header[1:5], version)
+	binary.LittleEndian.PutUint32(header[5:9], uint32(buf.Len()))
+	if _, err := w.Write(header[:]); err != nil {
+		return fmt.Errorf("failed to write response header data: %w", err)
+	}
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("failed to write payload to sync response: %w", err)
+	}
+	return nil
+}
@@ -1,8 +1,11 @@
 package p2p
 
 import (
+	"bytes"
 	"context"
+	"encoding/binary"
 	"fmt"
+	"io"
 	"math/big"
 	"strings"
 	"sync"
@@ -191,6 +194,157 @@ func TestSinglePeerSync(t *testing.T) {
 	}
 }
 
+func TestSinglePeerRangeSync(t *testing.T) {
+	t.Parallel() // Takes a while, but can run in parallel
+
+	log := testlog.Logger(t, log.LevelError)
+
+	cfg, payloads := setupSyncTestData(25)
+
+	// Serving payloads: just load them from the map, if they exist
+	servePayload := mockPayloadFn(func(n uint64) (*eth.ExecutionPayloadEnvelope, error) {
+		p, ok := payloads.getPayload(n)
+		if !ok {
+			return nil, ethereum.NotFound
+		}
+		return p, nil
+	})
+
+	// collect received payloads in a buffered channel, so we can verify we get everything
+	received := make(chan *eth.ExecutionPayloadEnvelope, 100)
+	receivePayload := receivePayloadFn(func(ctx context.Context, from peer.ID, payload *eth.ExecutionPayloadEnvelope) error {
+		received <- payload
+		return nil
+	})
+
+	// Setup 2 minimal test hosts to attach the sync protocol to
+	mnet, err := mocknet.FullMeshConnected(2)
+	require.NoError(t, err, "failed to setup mocknet")
+	defer mnet.Close()
+	hosts := mnet.Hosts()
+	hostA, hostB := hosts[0], hosts[1]
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Setup host A as the server, serving only payloads by range
+	srv := NewReqRespServer(cfg, servePayload, metrics.NoopMetrics)
+	payloadsByRange := MakeStreamHandler(ctx, log.New("role", "server"), srv.HandleRangeSyncRequest)
+	hostA.SetStreamHandler(PayloadsByRangeProtocolID(cfg.L2ChainID), payloadsByRange)
+
+	// Setup host B as the client
+	cl := NewSyncClient(log.New("role", "client"), cfg, hostB, receivePayload, metrics.NoopMetrics, &NoopApplicationScorer{})
+	cl.AddPeer(hostA.ID())
+	cl.Start()
+	defer cl.Close()
+
+	expectPayloads := func(from, to uint64) {
+		for i := from; i > to; i-- {
+			p := <-received
+			require.Equal(t, i, uint64(p.ExecutionPayload.BlockNumber), "expecting payloads in order")
+			exp, ok := payloads.getPayload(i)
+			require.True(t, ok, "expecting known payload")
+			require.Equal(t, exp.ExecutionPayload.BlockHash, p.ExecutionPayload.BlockHash, "expecting the correct payload")
+			require.Equal(t, exp.ParentBeaconBlockRoot, p.ParentBeaconBlockRoot)
+		}
+	}
+
+	// create a gap, so the range request is only partially served
+	bl13, _ := payloads.getPayload(13)
+	payloads.deletePayload(13)
+	rangeReqId, err := cl.RequestL2Range(ctx, payloads.getBlockRef(4), payloads.getBlockRef(20))
+	require.NoError(t, err)
+	expectPayloads(19, 13)
+
+	// the served payloads above the gap are received, and the range request is cancelled at the gap
+	require.Eventually(t, func() bool {
+		return !cl.activeRangeRequests.get(rangeReqId)
+	}, 30*time.Second, 100*time.Millisecond, "expecting range request to be cancelled")
+	// the request of the missing block, and requests of the lower blocks that were still scheduled, complete
+	for _, num := range []uint64{13, 5} {
+		require.Eventually(t, func() bool {
+			isInFlight, err := cl.isInFlight(ctx, num)
+			return err == nil && !isInFlight
+		}, 30*time.Second, 100*time.Millisecond, "expecting request of block %d to complete", num)
+	}
+	require.Zero(t, len(received), "there is a gap, should not see other payloads yet")
+
+	// fill the gap, and sync the remainder of the range
+	payloads.addPayload(bl13)
+	_, err = cl.RequestL2Range(ctx, payloads.getBlockRef(4), payloads.getBlockRef(14))
+	require.NoError(t, err)
+	expectPayloads(13, 4)
+}
+
+func TestServeRangeSyncRequest(t *testing.T) {
+	cfg, payloads := setupSyncTestData(25)
+	srv := NewReqRespServer(cfg, mockPayloadFn(func(n uint64) (*eth.ExecutionPayloadEnvelope, error) {
+		p, ok := payloads.getPayload(n)
+		if !ok {
+			return nil, ethereum.NotFound
+		}
+		return p, nil
+	}), metrics.NoopMetrics)
+
+	mnet, err := mocknet.FullMeshConnected(2)
+	require.NoError(t, err, "failed to setup mocknet")
+	defer mnet.Close()
+	hosts := mnet.Hosts()
+	hostA, hostB := hosts[0], hosts[1]
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	hostA.SetStreamHandler(PayloadsByRangeProtocolID(cfg.L2ChainID),
+		MakeStreamHandler(ctx, testlog.Logger(t, log.LevelError), srv.HandleRangeSyncRequest))
+
+	request := func(first, count uint64) []byte {
+		str, err := hostB.NewStream(ctx, hostA.ID(), PayloadsByRangeProtocolID(cfg.L2ChainID))
+		require.NoError(t, err)
+		defer str.Close()
+		var req [16]byte
+		binary.LittleEndian.PutUint64(req[:8], first)
+		binary.LittleEndian.PutUint64(req[8:], count)
+		_, err = str.Write(req[:])
+		require.NoError(t, err)
+		require.NoError(t, str.CloseWrite())
+		resp, err := io.ReadAll(str)
+		require.NoError(t, err)
+		return resp
+	}
+
+	cl := &SyncClient{cfg: cfg}
+	t.Run("FullRange", func(t *testing.T) {
+		r := bytes.NewReader(request(10, 4))
+		for num := uint64(13); num >= 10; num-- {
+			envelope, err := cl.readRangeChunk(r, num)
+			require.NoError(t, err)
+			require.NoError(t, verifyBlock(envelope, num))
+		}
+		_, err := cl.readRangeChunk(r, 9)
+		require.ErrorIs(t, err, io.EOF)
+	})
+	t.Run("PartialRange", func(t *testing.T) {
+		payloads.deletePayload(11)
+		r := bytes.NewReader(request(10, 4))
+		for num := uint64(13); num >= 12; num-- {
+			_, err := cl.readRangeChunk(r, num)
+			require.NoError(t, err)
+		}
+		_, err := cl.readRangeChunk(r, 11)
+		require.Equal(t, requestResultErr(ResultCodeNotFoundErr), err)
+	})
+	t.Run("TooManyBlocks", func(t *testing.T) {
+		_, err := cl.readRangeChunk(bytes.NewReader(request(1, maxPayloadsByRange+1)), maxPayloadsByRange)
+		require.Equal(t, requestResultErr(ResultCodeInvalidErr), err)
+	})
+	t.Run("FutureBlocks", func(t *testing.T) {
+		max, err := cfg.TargetBlockNumber(uint64(time.Now().Unix()))
+		require.NoError(t, err)
+		_, err = cl.readRangeChunk(bytes.NewReader(request(max, 2)), max+1)
+		require.Equal(t, requestResultErr(ResultCodeInvalidErr), err)
+	})
+}
+
 func TestMultiPeerSync(t *testing.T) {
 	t.Parallel() // Takes a while, but can run in parallel
 
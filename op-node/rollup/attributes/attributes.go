@@ -36,6 +36,8 @@ type AttributesHandler struct {
 
 	attributes     *derive.AttributesWithParent
 	sentAttributes bool
+
+	mismatches *MismatchTracker
 }
 
 func NewAttributesHandler(log log.Logger, cfg *rollup.Config, ctx context.Context, l2 L2) *AttributesHandler {
@@ -45,9 +47,16 @@ func NewAttributesHandler(log log.Logger, cfg *rollup.Config, ctx context.Contex
 		ctx:        ctx,
 		l2:         l2,
 		attributes: nil,
+		mismatches: NewMismatchTracker(DefaultRecentMismatches),
 	}
 }
 
+// Mismatches returns the most recent derived attributes that did not match the existing unsafe block, oldest first.
+// The mismatches are read without blocking the processing of attributes.
+func (eq *AttributesHandler) Mismatches() []AttributesMismatch {
+	return eq.mismatches.Recent()
+}
+
 func (eq *AttributesHandler) AttachEmitter(em event.Emitter) {
 	eq.emitter = em
 }
@@ -178,8 +187,11 @@ func (eq *AttributesHandler) consolidateNextSafeAttributes(attributes *derive.At
 		return
 	}
 	if err := AttributesMatchBlock(eq.cfg, attributes.Attributes, onto.Hash, envelope, eq.log); err != nil {
+		mismatch := newAttributesMismatch(eq.log, attributes, onto, envelope, err)
+		eq.mismatches.record(mismatch)
 		eq.log.Warn("L2 reorg: existing unsafe block does not match derived attributes from L1",
-			"err", err, "unsafe", envelope.ExecutionPayload.ID(), "pending_safe", onto)
+			append([]any{"err", err, "unsafe", envelope.ExecutionPayload.ID(), "pending_safe", onto,
+				"derived_from", attributes.DerivedFrom}, mismatch.LogValues()...)...)
 
 		eq.sentAttributes = true
 		// geth cannot wind back a chain without reorging to a new, previously non-canonical, block
@@ -261,6 +261,16 @@ func TestAttributesHandler(t *testing.T) {
 			l2.AssertExpectations(t)
 			emitter.AssertExpectations(t)
 			require.NotNil(t, ah.attributes, "still have attributes, processing still unconfirmed")
+			mismatches := ah.Mismatches()
+			require.Len(t, mismatches, 1, "mismatch is recorded")
+			require.Equal(t, refA1.ID(), mismatches[0].Unsafe)
+			require.Equal(t, refA0.ID(), mismatches[0].PendingSafe)
+			require.Equal(t, refBAlt.ID(), mismatches[0].DerivedFrom)
+			require.Equal(t, []FieldMismatch{{
+				Field:    "feeRecipient",
+				Expected: payloadA1Alt.ExecutionPayload.FeeRecipient.String(),
+				Actual:   payloadA1.ExecutionPayload.FeeRecipient.String(),
+			}}, mismatches[0].Fields)
 
 			emitter.ExpectOnce(derive.PipelineStepEvent{PendingSafe: refA1Alt})
 			// recognize reorg as complete
@@ -0,0 +1,191 @@
+package attributes
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/trie"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// DefaultRecentMismatches is the number of recent attributes mismatches kept by a MismatchTracker by default.
+const DefaultRecentMismatches = 20
+
+// FieldMismatch is a field of the derived attributes that differs from the unsafe block.
+type FieldMismatch struct {
+	Field    string `json:"field"`
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+}
+
+// AttributesMismatch describes derived attributes that did not match the existing unsafe block,
+// which was then reorged out in favor of a block built from the attributes.
+type AttributesMismatch struct {
+	// Unsafe is the unsafe block the attributes were compared with.
+	Unsafe eth.BlockID `json:"unsafe"`
+	// PendingSafe is the pending safe head the attributes build on.
+	PendingSafe eth.BlockID `json:"pendingSafe"`
+	// DerivedFrom is the L1 block the attributes were derived from.
+	DerivedFrom eth.BlockID `json:"derivedFrom"`
+	// Err is the first mismatch found by AttributesMatchBlock.
+	Err string `json:"err"`
+	// Fields are all the fields that differ. The expected values are those of the derived attributes.
+	Fields []FieldMismatch `json:"fields"`
+	// MissingUnsafeTxs are the hashes of derived transactions that are not in the unsafe block.
+	MissingUnsafeTxs []common.Hash `json:"missingUnsafeTxs"`
+	// MissingSafeTxs are the hashes of unsafe block transactions that are not in the derived attributes.
+	MissingSafeTxs []common.Hash `json:"missingSafeTxs"`
+	// DetectedAt is the unix time in seconds at which the mismatch was detected.
+	DetectedAt uint64 `json:"detectedAt"`
+}
+
+// LogValues returns the differing fields as key-value pairs for structured logging.
+func (m *AttributesMismatch) LogValues() []any {
+	values := make([]any, 0, 2*len(m.Fields)+4)
+	for _, f := range m.Fields {
+		values = append(values, "expected_"+f.Field, f.Expected, "actual_"+f.Field, f.Actual)
+	}
+	if len(m.MissingUnsafeTxs) > 0 || len(m.MissingSafeTxs) > 0 {
+		values = append(values, "missing_unsafe_txs", m.MissingUnsafeTxs, "missing_safe_txs", m.MissingSafeTxs)
+	}
+	return values
+}
+
+// DiffAttributesBlock compares all the fields checked by AttributesMatchBlock,
+// and returns the fields in which the attributes differ from the block.
+// Unlike AttributesMatchBlock, it does not stop at the first mismatch.
+func DiffAttributesBlock(attrs *eth.PayloadAttributes, parentHash common.Hash, envelope *eth.ExecutionPayloadEnvelope) []FieldMismatch {
+	block := envelope.ExecutionPayload
+	var diff []FieldMismatch
+	add := func(field string, expected, actual any) {
+		expectedStr, actualStr := fmt.Sprint(expected), fmt.Sprint(actual)
+		if expectedStr != actualStr {
+			diff = append(diff, FieldMismatch{Field: field, Expected: expectedStr, Actual: actualStr})
+		}
+	}
+	add("parentHash", parentHash, block.ParentHash)
+	add("timestamp", uint64(attrs.Timestamp), uint64(block.Timestamp))
+	add("prevRandao", common.Hash(attrs.PrevRandao), common.Hash(block.PrevRandao))
+	if attrs.GasLimit == nil {
+		add("gasLimit", "nil", uint64(block.GasLimit))
+	} else {
+		add("gasLimit", uint64(*attrs.GasLimit), uint64(block.GasLimit))
+	}
+	add("feeRecipient", attrs.SuggestedFeeRecipient, block.FeeRecipient)
+	add("parentBeaconBlockRoot", optional(attrs.ParentBeaconBlockRoot), optional(envelope.ParentBeaconBlockRoot))
+	if checkWithdrawalsMatch(attrs.Withdrawals, block.Withdrawals) != nil {
+		add("withdrawals", withdrawalsSummary(attrs.Withdrawals), withdrawalsSummary(block.Withdrawals))
+	}
+
+	add("txCount", len(attrs.Transactions), len(block.Transactions))
+	add("txListHash", txListHash(attrs.Transactions), txListHash(block.Transactions))
+	expectedDeposits, actualDeposits := deposits(attrs.Transactions), deposits(block.Transactions)
+	add("depositCount", len(expectedDeposits), len(actualDeposits))
+	add("depositListHash", txListHash(expectedDeposits), txListHash(actualDeposits))
+	return diff
+}
+
+// newAttributesMismatch describes the mismatch between the attributes on top of the pending safe head and the block.
+func newAttributesMismatch(l log.Logger, attributes *derive.AttributesWithParent, onto eth.L2BlockRef, envelope *eth.ExecutionPayloadEnvelope, err error) AttributesMismatch {
+	mismatch := AttributesMismatch{
+		Unsafe:      envelope.ExecutionPayload.ID(),
+		PendingSafe: onto.ID(),
+		DerivedFrom: attributes.DerivedFrom.ID(),
+		Err:         err.Error(),
+		Fields:      DiffAttributesBlock(attributes.Attributes, onto.Hash, envelope),
+	}
+	missingSafe, missingUnsafe, err := getMissingTxnHashes(l, attributes.Attributes.Transactions, envelope.ExecutionPayload.Transactions)
+	if err != nil {
+		l.Warn("Failed to get missing txn hashes of attributes mismatch", "err", err)
+	} else {
+		mismatch.MissingSafeTxs = missingSafe
+		mismatch.MissingUnsafeTxs = missingUnsafe
+	}
+	return mismatch
+}
+
+func optional[T any](v *T) any {
+	if v == nil {
+		return "nil"
+	}
+	return *v
+}
+
+func withdrawalsSummary(w *types.Withdrawals) string {
+	if w == nil {
+		return "nil"
+	}
+	return fmt.Sprintf("%d withdrawals (root %s)", len(*w), types.DeriveSha(*w, trie.NewStackTrie(nil)))
+}
+
+// txListHash commits to the transaction list, as the hash of the concatenated transaction hashes.
+func txListHash(txs []hexutil.Bytes) common.Hash {
+	hashes := make([]byte, 0, len(txs)*common.HashLength)
+	for _, tx := range txs {
+		hashes = append(hashes, crypto.Keccak256(tx)...)
+	}
+	return crypto.Keccak256Hash(hashes)
+}
+
+func deposits(txs []hexutil.Bytes) []hexutil.Bytes {
+	var out []hexutil.Bytes
+	for _, tx := range txs {
+		if len(tx) > 0 && tx[0] == types.DepositTxType {
+			out = append(out, tx)
+		}
+	}
+	return out
+}
+
+// MismatchTracker keeps a ring buffer of the most recent attributes mismatches.
+// It is safe for concurrent use, so it can be read from RPC calls while the attributes are processed.
+type MismatchTracker struct {
+	now func() time.Time
+
+	mu     sync.Mutex
+	recent []AttributesMismatch
+	// next is the index in recent the next mismatch is written to, once the ring buffer is full.
+	next int
+	size int
+}
+
+func NewMismatchTracker(size int) *MismatchTracker {
+	return &MismatchTracker{
+		now:    time.Now,
+		recent: make([]AttributesMismatch, 0, size),
+		size:   size,
+	}
+}
+
+func (t *MismatchTracker) record(mismatch AttributesMismatch) {
+	mismatch.DetectedAt = uint64(t.now().Unix())
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.size == 0 {
+		return
+	}
+	if len(t.recent) < t.size {
+		t.recent = append(t.recent, mismatch)
+		return
+	}
+	t.recent[t.next] = mismatch
+	t.next = (t.next + 1) % t.size
+}
+
+// Recent returns the recent attributes mismatches, oldest first.
+func (t *MismatchTracker) Recent() []AttributesMismatch {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	recent := make([]AttributesMismatch, 0, len(t.recent))
+	recent = append(recent, t.recent[t.next:]...)
+	recent = append(recent, t.recent[:t.next]...)
+	return recent
+}
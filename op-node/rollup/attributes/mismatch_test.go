@@ -0,0 +1,71 @@
+package attributes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+func TestDiffAttributesBlock(t *testing.T) {
+	t.Run("match", func(t *testing.T) {
+		for _, args := range []args{ecotoneArgs(), canyonArgs(), bedrockArgs(), ecotoneMismatchParentBeaconBlockRootPtr()} {
+			require.Empty(t, DiffAttributesBlock(args.attrs, args.parentHash, args.envelope))
+		}
+	})
+
+	t.Run("all mismatched fields", func(t *testing.T) {
+		args := ecotoneArgs()
+		args.attrs.Timestamp = 2000
+		gasLimit := eth.Uint64Quantity(2000)
+		args.attrs.GasLimit = &gasLimit
+		args.attrs.ParentBeaconBlockRoot = nil
+		diff := DiffAttributesBlock(args.attrs, args.parentHash, args.envelope)
+		require.Equal(t, []FieldMismatch{
+			{Field: "timestamp", Expected: "2000", Actual: "123"},
+			{Field: "gasLimit", Expected: "2000", Actual: "1000"},
+			{Field: "parentBeaconBlockRoot", Expected: "nil", Actual: validParentBeaconRoot.String()},
+		}, diff)
+	})
+
+	t.Run("transactions", func(t *testing.T) {
+		args := ecotoneArgs()
+		deposit := hexutil.Bytes{types.DepositTxType, 0x01}
+		otherDeposit := hexutil.Bytes{types.DepositTxType, 0x02}
+		tx := hexutil.Bytes{types.DynamicFeeTxType, 0x03}
+		args.attrs.Transactions = []hexutil.Bytes{deposit, otherDeposit}
+		args.envelope.ExecutionPayload.Transactions = []hexutil.Bytes{deposit, tx}
+		diff := DiffAttributesBlock(args.attrs, args.parentHash, args.envelope)
+		fields := make(map[string]FieldMismatch)
+		for _, f := range diff {
+			fields[f.Field] = f
+		}
+		require.NotContains(t, fields, "txCount")
+		require.Contains(t, fields, "txListHash")
+		require.Equal(t, FieldMismatch{Field: "depositCount", Expected: "2", Actual: "1"}, fields["depositCount"])
+		require.Equal(t, txListHash([]hexutil.Bytes{deposit, otherDeposit}).String(), fields["depositListHash"].Expected)
+		require.Equal(t, txListHash([]hexutil.Bytes{deposit}).String(), fields["depositListHash"].Actual)
+	})
+}
+
+func TestMismatchTracker(t *testing.T) {
+	tracker := NewMismatchTracker(2)
+	tracker.now = func() time.Time { return time.Unix(1000, 0) }
+	mismatch := func(n uint64) AttributesMismatch {
+		return AttributesMismatch{Unsafe: eth.BlockID{Hash: common.Hash{byte(n)}, Number: n}}
+	}
+	tracker.record(mismatch(1))
+	tracker.record(mismatch(2))
+	tracker.record(mismatch(3))
+
+	recent := tracker.Recent()
+	require.Len(t, recent, 2, "only the most recent mismatches are kept")
+	require.Equal(t, uint64(2), recent[0].Unsafe.Number, "recent mismatches are ordered oldest first")
+	require.Equal(t, uint64(3), recent[1].Unsafe.Number)
+	require.Equal(t, uint64(1000), recent[1].DetectedAt)
+}
@@ -0,0 +1,67 @@
+package confdepth
+
+import (
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// confirmedCache caches the references of confirmed L1 blocks by number.
+// Caching by number is only safe for blocks that are not reorged, so the cache is invalidated
+// when a reorg of any of its blocks is detected:
+//   - when the L1 head moves back, blocks that are no longer confirmation-depth deep are dropped.
+//   - when a block does not link up with its cached neighbours, a reorg deeper than the
+//     confirmation depth happened, and the whole cache is dropped.
+type confirmedCache struct {
+	depth uint64
+	size  uint64
+
+	mu   sync.Mutex
+	refs map[uint64]eth.L1BlockRef
+	// head is the number of the L1 head the cache was last checked against.
+	head uint64
+}
+
+func newConfirmedCache(depth uint64, size uint64) *confirmedCache {
+	return &confirmedCache{
+		depth: depth,
+		size:  size,
+		refs:  make(map[uint64]eth.L1BlockRef),
+	}
+}
+
+// Get returns the cached block at the given number, if it is confirmed with respect to the given L1 head.
+func (c *confirmedCache) Get(num uint64, l1Head eth.L1BlockRef) (eth.L1BlockRef, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if l1Head.Number < c.head {
+		for n := range c.refs {
+			if n+c.depth > l1Head.Number {
+				delete(c.refs, n)
+			}
+		}
+	}
+	c.head = l1Head.Number
+	ref, ok := c.refs[num]
+	return ref, ok
+}
+
+// Add caches a confirmed block.
+func (c *confirmedCache) Add(ref eth.L1BlockRef) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if parent, ok := c.refs[ref.Number-1]; ok && ref.Number > 0 && parent.Hash != ref.ParentHash {
+		clear(c.refs)
+	} else if child, ok := c.refs[ref.Number+1]; ok && child.ParentHash != ref.Hash {
+		clear(c.refs)
+	}
+	c.refs[ref.Number] = ref
+	if uint64(len(c.refs)) > c.size {
+		// Evict the blocks furthest behind the newly cached block.
+		for n := range c.refs {
+			if n+c.size <= ref.Number || n >= ref.Number+c.size {
+				delete(c.refs, n)
+			}
+		}
+	}
+}
@@ -18,12 +18,25 @@ type confDepth struct {
 	derive.L1Fetcher
 	l1Head func() eth.L1BlockRef
 	depth  uint64
+	// cache holds confirmed blocks by number, if enabled.
+	cache *confirmedCache
 }
 
 func NewConfDepth(depth uint64, l1Head func() eth.L1BlockRef, fetcher derive.L1Fetcher) *confDepth {
 	return &confDepth{L1Fetcher: fetcher, l1Head: l1Head, depth: depth}
 }
 
+// NewCachedConfDepth is like NewConfDepth, but caches up to cacheSize confirmed blocks by number,
+// to not fetch them again from L1 providers that may serve shallow reorgs inconsistently.
+// The cache is invalidated when a reorg of a confirmed block is detected.
+func NewCachedConfDepth(depth uint64, cacheSize uint64, l1Head func() eth.L1BlockRef, fetcher derive.L1Fetcher) *confDepth {
+	cd := NewConfDepth(depth, l1Head, fetcher)
+	if depth > 0 && cacheSize > 0 {
+		cd.cache = newConfirmedCache(depth, cacheSize)
+	}
+	return cd
+}
+
 // L1BlockRefByNumber is used for L1 traversal and for finding a safe common point between the L2 engine and L1 chain.
 // Any block numbers that are within confirmation depth of the L1 head are mocked to be "not found",
 // effectively hiding the uncertain part of the L1 chain.
@@ -33,10 +46,24 @@ func (c *confDepth) L1BlockRefByNumber(ctx context.Context, num uint64) (eth.L1B
 	if l1Head == (eth.L1BlockRef{}) {
 		return c.L1Fetcher.L1BlockRefByNumber(ctx, num)
 	}
-	if num == 0 || c.depth == 0 || num+c.depth <= l1Head.Number {
+	if num == 0 || c.depth == 0 {
+		return c.L1Fetcher.L1BlockRefByNumber(ctx, num)
+	}
+	if num+c.depth > l1Head.Number {
+		return eth.L1BlockRef{}, ethereum.NotFound
+	}
+	if c.cache == nil {
 		return c.L1Fetcher.L1BlockRefByNumber(ctx, num)
 	}
-	return eth.L1BlockRef{}, ethereum.NotFound
+	if ref, ok := c.cache.Get(num, l1Head); ok {
+		return ref, nil
+	}
+	ref, err := c.L1Fetcher.L1BlockRefByNumber(ctx, num)
+	if err != nil {
+		return eth.L1BlockRef{}, err
+	}
+	c.cache.Add(ref)
+	return ref, nil
 }
 
 var _ derive.L1Fetcher = (*confDepth)(nil)
@@ -70,3 +70,53 @@ func TestConfDepth(t *testing.T) {
 		t.Run(tc.name, tc.Run)
 	}
 }
+
+func TestCachedConfDepth(t *testing.T) {
+	chain := func(fork byte, n uint64) eth.L1BlockRef {
+		return eth.L1BlockRef{Number: n, Hash: common.Hash{fork, byte(n)}, ParentHash: common.Hash{fork, byte(n - 1)}}
+	}
+	l1Head := chain(0, 10)
+	l1Fetcher := &testutils.MockL1Source{}
+	cd := NewCachedConfDepth(2, 100, func() eth.L1BlockRef { return l1Head }, l1Fetcher)
+	ctx := context.Background()
+
+	t.Run("CachesConfirmed", func(t *testing.T) {
+		l1Fetcher.ExpectL1BlockRefByNumber(7, chain(0, 7), nil)
+		for i := 0; i < 2; i++ {
+			ref, err := cd.L1BlockRefByNumber(ctx, 7)
+			require.NoError(t, err)
+			require.Equal(t, chain(0, 7), ref)
+		}
+		l1Fetcher.AssertExpectations(t)
+	})
+
+	t.Run("NotConfirmed", func(t *testing.T) {
+		_, err := cd.L1BlockRefByNumber(ctx, 9)
+		require.ErrorIs(t, err, ethereum.NotFound)
+	})
+
+	t.Run("HeadMovedBack", func(t *testing.T) {
+		l1Head = chain(1, 8)
+		defer func() { l1Head = chain(0, 10) }()
+		l1Fetcher.ExpectL1BlockRefByNumber(6, chain(1, 6), nil)
+		ref, err := cd.L1BlockRefByNumber(ctx, 6)
+		require.NoError(t, err)
+		require.Equal(t, chain(1, 6), ref)
+		_, err = cd.L1BlockRefByNumber(ctx, 7)
+		require.ErrorIs(t, err, ethereum.NotFound, "block is no longer confirmed")
+		l1Fetcher.AssertExpectations(t)
+	})
+
+	t.Run("DeepReorg", func(t *testing.T) {
+		// Block 7 of the original chain does not link up with the cached block 6 of the other chain,
+		// so the cache is dropped, and block 6 is fetched again.
+		l1Fetcher.ExpectL1BlockRefByNumber(7, chain(0, 7), nil)
+		_, err := cd.L1BlockRefByNumber(ctx, 7)
+		require.NoError(t, err)
+		l1Fetcher.ExpectL1BlockRefByNumber(6, chain(0, 6), nil)
+		ref, err := cd.L1BlockRefByNumber(ctx, 6)
+		require.NoError(t, err)
+		require.Equal(t, chain(0, 6), ref)
+		l1Fetcher.AssertExpectations(t)
+	})
+}
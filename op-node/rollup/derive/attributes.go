@@ -26,6 +26,7 @@ type SystemConfigL2Fetcher interface {
 // FetchingAttributesBuilder fetches inputs for the building of L2 payload attributes on the fly.
 type FetchingAttributesBuilder struct {
 	rollupCfg *rollup.Config
+	spec      *rollup.ChainSpec
 	l1        L1ReceiptsFetcher
 	l2        SystemConfigL2Fetcher
 }
@@ -33,6 +34,7 @@ type FetchingAttributesBuilder struct {
 func NewFetchingAttributesBuilder(rollupCfg *rollup.Config, l1 L1ReceiptsFetcher, l2 SystemConfigL2Fetcher) *FetchingAttributesBuilder {
 	return &FetchingAttributesBuilder{
 		rollupCfg: rollupCfg,
+		spec:      rollup.NewChainSpec(rollupCfg),
 		l1:        l1,
 		l2:        l2,
 	}
@@ -121,11 +123,21 @@ func (ba *FetchingAttributesBuilder) PreparePayloadAttributes(ctx context.Contex
 		return nil, NewCriticalError(fmt.Errorf("failed to create l1InfoTx: %w", err))
 	}
 
-	txs := make([]hexutil.Bytes, 0, 1+len(depositTxs)+len(upgradeTxs))
+	txs := make([]hexutil.Bytes, 0, 2+len(depositTxs)+len(upgradeTxs))
 	txs = append(txs, l1InfoTx)
 	txs = append(txs, depositTxs...)
 	txs = append(txs, upgradeTxs...)
 
+	// Blocks beyond the max sequencer drift are deposit-only. The chain may mark them explicitly.
+	if ba.rollupCfg.SequencerDriftPolicy == rollup.SequencerDriftOfflineMarker &&
+		nextL2Time > l1Info.Time()+ba.spec.MaxSequencerDrift(l1Info.Time()) {
+		offlineTx, err := SequencerOfflineDepositBytes(l1Info, seqNumber)
+		if err != nil {
+			return nil, NewCriticalError(fmt.Errorf("failed to create sequencer-offline marker tx: %w", err))
+		}
+		txs = append(txs, offlineTx)
+	}
+
 	var withdrawals *types.Withdrawals
 	if ba.rollupCfg.IsCanyon(nextL2Time) {
 		withdrawals = &types.Withdrawals{}
@@ -42,8 +42,12 @@ type AttributesQueue struct {
 	prev         *BatchQueue
 	batch        *SingularBatch
 	isLastInSpan bool
+
+	counters stageCounters
 }
 
+var _ StatsProvider = (*AttributesQueue)(nil)
+
 func NewAttributesQueue(log log.Logger, cfg *rollup.Config, builder AttributesBuilder, prev *BatchQueue) *AttributesQueue {
 	return &AttributesQueue{
 		log:     log,
@@ -66,6 +70,7 @@ func (aq *AttributesQueue) NextAttributes(ctx context.Context, parent eth.L2Bloc
 		}
 		aq.batch = batch
 		aq.isLastInSpan = isLastInSpan
+		aq.counters.itemsIn++
 	}
 
 	// Actually generate the next attributes
@@ -81,6 +86,7 @@ func (aq *AttributesQueue) NextAttributes(ctx context.Context, parent eth.L2Bloc
 		}
 		aq.batch = nil
 		aq.isLastInSpan = false
+		aq.counters.itemsOut++
 		return &attr, nil
 	}
 
@@ -114,6 +120,16 @@ func (aq *AttributesQueue) createNextAttributes(ctx context.Context, batch *Sing
 	return attrs, nil
 }
 
+// Stats returns the number of batches taken, the number of attributes provided,
+// and the size of the transactions of the batch that attributes are being prepared for.
+func (aq *AttributesQueue) Stats() StageStats {
+	var buffered uint64
+	if aq.batch != nil {
+		buffered = batchTxBytes(aq.batch)
+	}
+	return StageStats{ItemsIn: aq.counters.itemsIn, ItemsOut: aq.counters.itemsOut, BufferedBytes: buffered}
+}
+
 func (aq *AttributesQueue) Reset(ctx context.Context, _ eth.L1BlockRef, _ eth.SystemConfig) error {
 	aq.batch = nil
 	aq.isLastInSpan = false // overwritten later, but set for consistency
@@ -195,6 +195,49 @@ func TestPreparePayloadAttributes(t *testing.T) {
 		require.Equal(t, l1InfoTx, []byte(attrs.Transactions[0]))
 		require.True(t, attrs.NoTxPool)
 	})
+	t.Run("sequencer drift offline marker", func(t *testing.T) {
+		rng := rand.New(rand.NewSource(1234))
+		markerCfg := *cfg
+		markerCfg.MaxSequencerDrift = 600
+		markerCfg.SequencerDriftPolicy = rollup.SequencerDriftOfflineMarker
+		l2Parent := testutils.RandomL2BlockRef(rng)
+		l1Info := testutils.RandomBlockInfo(rng)
+		l1Info.InfoHash = l2Parent.L1Origin.Hash
+		l1Info.InfoNum = l2Parent.L1Origin.Number
+		epoch := l1Info.ID()
+		seqNumber := l2Parent.SequenceNumber + 1
+
+		prepare := func(l1Time uint64) *eth.PayloadAttributes {
+			l1Info.InfoTime = l1Time
+			l1Fetcher := &testutils.MockL1Source{}
+			defer l1Fetcher.AssertExpectations(t)
+			l1Fetcher.ExpectInfoByHash(epoch.Hash, l1Info, nil)
+			l1CfgFetcher := &testutils.MockL2Client{}
+			defer l1CfgFetcher.AssertExpectations(t)
+			l1CfgFetcher.ExpectSystemConfigByL2Hash(l2Parent.Hash, testSysCfg, nil)
+			attrBuilder := NewFetchingAttributesBuilder(&markerCfg, l1Fetcher, l1CfgFetcher)
+			attrs, err := attrBuilder.PreparePayloadAttributes(context.Background(), l2Parent, epoch)
+			require.NoError(t, err)
+			return attrs
+		}
+
+		// Within the sequencer drift, no marker is included
+		attrs := prepare(l2Parent.Time + cfg.BlockTime - markerCfg.MaxSequencerDrift)
+		require.Len(t, attrs.Transactions, 1)
+
+		attrs = prepare(l2Parent.Time + cfg.BlockTime - markerCfg.MaxSequencerDrift - 1)
+		require.Len(t, attrs.Transactions, 2)
+		offlineTx, err := SequencerOfflineDepositBytes(l1Info, seqNumber)
+		require.NoError(t, err)
+		require.Equal(t, offlineTx, attrs.Transactions[1])
+		require.True(t, attrs.NoTxPool)
+
+		var tx types.Transaction
+		require.NoError(t, tx.UnmarshalBinary(offlineTx))
+		require.Equal(t, L1InfoDepositerAddress, *tx.To())
+		require.Equal(t, SequencerOfflineFuncBytes4, tx.Data()[:4])
+		require.NotEqual(t, (&L1InfoDepositSource{L1BlockHash: epoch.Hash, SeqNumber: seqNumber}).SourceHash(), tx.SourceHash())
+	})
 	// Test that the payload attributes builder changes the deposit format based on L2-time-based regolith activation
 	t.Run("regolith", func(t *testing.T) {
 		testCases := []struct {
@@ -0,0 +1,131 @@
+package derive
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// BatchDropReason classifies why a batch was dropped by the batch validation rules.
+type BatchDropReason string
+
+const (
+	BatchDropUnknownType           BatchDropReason = "unknown_type"
+	BatchDropOldTimestamp          BatchDropReason = "old_timestamp"
+	BatchDropMisalignedTimestamp   BatchDropReason = "misaligned_timestamp"
+	BatchDropBadParent             BatchDropReason = "bad_parent"
+	BatchDropSeqWindowExpired      BatchDropReason = "seq_window_expired"
+	BatchDropOldEpoch              BatchDropReason = "old_epoch"
+	BatchDropFutureEpoch           BatchDropReason = "future_epoch"
+	BatchDropBadEpochHash          BatchDropReason = "bad_epoch_hash"
+	BatchDropTimestampBeforeOrigin BatchDropReason = "timestamp_before_origin"
+	BatchDropSequencerDrift        BatchDropReason = "sequencer_drift"
+	BatchDropInvalidTx             BatchDropReason = "invalid_tx"
+	BatchDropSpanBeforeDelta       BatchDropReason = "span_before_delta"
+	BatchDropNoNewBlocks           BatchDropReason = "no_new_blocks"
+	BatchDropOverlapMismatch       BatchDropReason = "overlap_mismatch"
+)
+
+// DefaultRecentBatchDrops is the number of recently dropped batches kept by a BatchDropTracker by default.
+const DefaultRecentBatchDrops = 100
+
+// DroppedBatch describes a batch that was dropped.
+type DroppedBatch struct {
+	Reason    BatchDropReason `json:"reason"`
+	BatchType int             `json:"batchType"`
+	// Timestamp is the timestamp of the first block of the batch.
+	Timestamp uint64 `json:"timestamp"`
+	// ParentCheck is the parent hash of a singular batch, or the truncated parent hash of a span batch.
+	ParentCheck hexutil.Bytes `json:"parentCheck"`
+	// L1InclusionBlock is the L1 block the batch was included in.
+	L1InclusionBlock eth.BlockID `json:"l1InclusionBlock"`
+	// SafeHead is the L2 safe head the batch was checked against.
+	SafeHead eth.BlockID `json:"safeHead"`
+	// DroppedAt is the unix time in seconds at which the batch was dropped.
+	DroppedAt uint64 `json:"droppedAt"`
+}
+
+// BatchDropReport reports the batches dropped since the node started.
+type BatchDropReport struct {
+	// Counts are the numbers of dropped batches by reason.
+	Counts map[BatchDropReason]uint64 `json:"counts"`
+	// Recent are the most recently dropped batches, oldest first.
+	Recent []DroppedBatch `json:"recent"`
+}
+
+// BatchDropTracker counts dropped batches by reason, and keeps a ring buffer of the most recently dropped batches.
+// It is safe for concurrent use, so it can be read from RPC calls while the pipeline runs.
+type BatchDropTracker struct {
+	metrics Metrics
+	now     func() time.Time
+
+	mu     sync.Mutex
+	counts map[BatchDropReason]uint64
+	recent []DroppedBatch
+	// next is the index in recent the next drop is written to, once the ring buffer is full.
+	next int
+	size int
+}
+
+func NewBatchDropTracker(metrics Metrics, size int) *BatchDropTracker {
+	return &BatchDropTracker{
+		metrics: metrics,
+		now:     time.Now,
+		counts:  make(map[BatchDropReason]uint64),
+		recent:  make([]DroppedBatch, 0, size),
+		size:    size,
+	}
+}
+
+// record records that batch was dropped for the given reason, when checked against the safe head.
+// Recording on a nil tracker is a no-op.
+func (t *BatchDropTracker) record(reason BatchDropReason, batch *BatchWithL1InclusionBlock, safeHead eth.L2BlockRef) {
+	if t == nil {
+		return
+	}
+	drop := DroppedBatch{
+		Reason:           reason,
+		BatchType:        batch.GetBatchType(),
+		Timestamp:        batch.GetTimestamp(),
+		L1InclusionBlock: batch.L1InclusionBlock.ID(),
+		SafeHead:         safeHead.ID(),
+		DroppedAt:        uint64(t.now().Unix()),
+	}
+	if singular, ok := batch.AsSingularBatch(); ok {
+		drop.ParentCheck = common.CopyBytes(singular.ParentHash[:20])
+	} else if span, ok := batch.AsSpanBatch(); ok {
+		drop.ParentCheck = common.CopyBytes(span.ParentCheck[:])
+	}
+	t.metrics.RecordBatchDrop(string(reason))
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.counts[reason]++
+	if t.size == 0 {
+		return
+	}
+	if len(t.recent) < t.size {
+		t.recent = append(t.recent, drop)
+		return
+	}
+	t.recent[t.next] = drop
+	t.next = (t.next + 1) % t.size
+}
+
+// Report returns the drop counts and the recently dropped batches.
+func (t *BatchDropTracker) Report() BatchDropReport {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	counts := make(map[BatchDropReason]uint64, len(t.counts))
+	for reason, count := range t.counts {
+		counts[reason] = count
+	}
+	recent := make([]DroppedBatch, 0, len(t.recent))
+	recent = append(recent, t.recent[t.next:]...)
+	recent = append(recent, t.recent[:t.next]...)
+	return BatchDropReport{Counts: counts, Recent: recent}
+}
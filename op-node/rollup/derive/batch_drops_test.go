@@ -0,0 +1,57 @@
+package derive
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+type batchDropMetrics struct {
+	testutils.TestDerivationMetrics
+	drops []string
+}
+
+func (m *batchDropMetrics) RecordBatchDrop(reason string) {
+	m.drops = append(m.drops, reason)
+}
+
+func TestBatchDropTracker(t *testing.T) {
+	m := &batchDropMetrics{}
+	tracker := NewBatchDropTracker(m, 2)
+	tracker.now = func() time.Time { return time.Unix(1000, 0) }
+	safeHead := eth.L2BlockRef{Hash: mockHash(10, 2), Number: 5}
+	l1 := eth.L1BlockRef{Hash: mockHash(20, 1), Number: 7}
+	batch := func(timestamp uint64) *BatchWithL1InclusionBlock {
+		return &BatchWithL1InclusionBlock{
+			L1InclusionBlock: l1,
+			Batch:            &SingularBatch{ParentHash: mockHash(timestamp, 2), Timestamp: timestamp},
+		}
+	}
+
+	tracker.record(BatchDropOldTimestamp, batch(8), safeHead)
+	tracker.record(BatchDropBadParent, batch(12), safeHead)
+	tracker.record(BatchDropBadParent, batch(14), safeHead)
+
+	report := tracker.Report()
+	require.Equal(t, map[BatchDropReason]uint64{BatchDropOldTimestamp: 1, BatchDropBadParent: 2}, report.Counts)
+	require.Len(t, report.Recent, 2, "only the most recent drops are kept")
+	require.Equal(t, uint64(12), report.Recent[0].Timestamp, "recent drops are ordered oldest first")
+	require.Equal(t, uint64(14), report.Recent[1].Timestamp)
+	require.Equal(t, DroppedBatch{
+		Reason:           BatchDropBadParent,
+		BatchType:        SingularBatchType,
+		Timestamp:        14,
+		ParentCheck:      mockHash(14, 2).Bytes()[:20],
+		L1InclusionBlock: l1.ID(),
+		SafeHead:         safeHead.ID(),
+		DroppedAt:        1000,
+	}, report.Recent[1])
+	require.Equal(t, []string{"old_timestamp", "bad_parent", "bad_parent"}, m.drops)
+
+	var nilTracker *BatchDropTracker
+	nilTracker.record(BatchDropBadParent, batch(16), safeHead)
+}
@@ -60,8 +60,15 @@ type BatchQueue struct {
 	nextSpan []*SingularBatch
 
 	l2 SafeBlockFetcher
+
+	counters stageCounters
+
+	// drops tracks the dropped batches, may be nil.
+	drops *BatchDropTracker
 }
 
+var _ StatsProvider = (*BatchQueue)(nil)
+
 // NewBatchQueue creates a BatchQueue, which should be Reset(origin) before use.
 func NewBatchQueue(log log.Logger, cfg *rollup.Config, prev NextBatchProvider, l2 SafeBlockFetcher) *BatchQueue {
 	return &BatchQueue{
@@ -99,6 +106,7 @@ func (bq *BatchQueue) NextBatch(ctx context.Context, parent eth.L2BlockRef) (*Si
 		if bq.nextSpan[0].Timestamp == parent.Time+bq.config.BlockTime {
 			// Pop first one and return.
 			nextBatch := bq.popNextBatch(parent)
+			bq.counters.itemsOut++
 			// len(bq.nextSpan) == 0 means it's the last batch of the span.
 			return nextBatch, len(bq.nextSpan) == 0, nil
 		} else {
@@ -203,6 +211,7 @@ func (bq *BatchQueue) NextBatch(ctx context.Context, parent eth.L2BlockRef) (*Si
 
 	// If the nextBatch is derived from the span batch, len(bq.nextSpan) == 0 means it's the last batch of the span.
 	// For singular batches, len(bq.nextSpan) == 0 is always true.
+	bq.counters.itemsOut++
 	return nextBatch, len(bq.nextSpan) == 0, nil
 }
 
@@ -220,16 +229,31 @@ func (bq *BatchQueue) Reset(ctx context.Context, base eth.L1BlockRef, _ eth.Syst
 	return io.EOF
 }
 
+// Stats returns the number of batches added, the number of singular batches provided,
+// and the size of the transactions of the buffered batches.
+func (bq *BatchQueue) Stats() StageStats {
+	var buffered uint64
+	for _, b := range bq.batches {
+		buffered += batchTxBytes(b.Batch)
+	}
+	for _, b := range bq.nextSpan {
+		buffered += batchTxBytes(b)
+	}
+	return StageStats{ItemsIn: bq.counters.itemsIn, ItemsOut: bq.counters.itemsOut, BufferedBytes: buffered}
+}
+
 func (bq *BatchQueue) AddBatch(ctx context.Context, batch Batch, parent eth.L2BlockRef) {
 	if len(bq.l1Blocks) == 0 {
 		panic(fmt.Errorf("cannot add batch with timestamp %d, no origin was prepared", batch.GetTimestamp()))
 	}
+	bq.counters.itemsIn++
 	data := BatchWithL1InclusionBlock{
 		L1InclusionBlock: bq.origin,
 		Batch:            batch,
 	}
-	validity := CheckBatch(ctx, bq.config, bq.log, bq.l1Blocks, parent, &data, bq.l2)
+	validity, reason := CheckBatch(ctx, bq.config, bq.log, bq.l1Blocks, parent, &data, bq.l2)
 	if validity == BatchDrop {
+		bq.drops.record(reason, &data, parent)
 		return // if we do drop the batch, CheckBatch will log the drop reason with WARN level.
 	}
 	batch.LogContext(bq.log).Debug("Adding batch")
@@ -265,7 +289,7 @@ func (bq *BatchQueue) deriveNextBatch(ctx context.Context, outOfData bool, paren
 	var remaining []*BatchWithL1InclusionBlock
 batchLoop:
 	for i, batch := range bq.batches {
-		validity := CheckBatch(ctx, bq.config, bq.log.New("batch_index", i), bq.l1Blocks, parent, batch, bq.l2)
+		validity, reason := CheckBatch(ctx, bq.config, bq.log.New("batch_index", i), bq.l1Blocks, parent, batch, bq.l2)
 		switch validity {
 		case BatchFuture:
 			remaining = append(remaining, batch)
@@ -274,7 +298,9 @@ batchLoop:
 			batch.Batch.LogContext(bq.log).Warn("Dropping batch",
 				"parent", parent.ID(),
 				"parent_time", parent.Time,
+				"reason", reason,
 			)
+			bq.drops.record(reason, batch, parent)
 			continue
 		case BatchAccept:
 			nextBatch = batch
@@ -1582,8 +1582,9 @@ func TestValidBatch(t *testing.T) {
 		if mod := testCase.ConfigMod; mod != nil {
 			mod(rcfg)
 		}
-		validity := CheckBatch(ctx, rcfg, logger, testCase.L1Blocks, testCase.L2SafeHead, &testCase.Batch, &l2Client)
+		validity, reason := CheckBatch(ctx, rcfg, logger, testCase.L1Blocks, testCase.L2SafeHead, &testCase.Batch, &l2Client)
 		require.Equal(t, testCase.Expected, validity, "batch check must return expected validity level")
+		require.Equal(t, validity == BatchDrop, reason != "", "only dropped batches must have a drop reason")
 		if expLog := testCase.ExpectedLog; expLog != "" {
 			// Check if ExpectedLog is contained in the log buffer
 			containsFilter := testlog.NewMessageContainsFilter(expLog)
@@ -10,8 +10,11 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/optracing"
 )
 
 type blobOrCalldata struct {
@@ -77,7 +80,10 @@ func (ds *BlobDataSource) Next(ctx context.Context) (eth.Data, error) {
 // transactions in the referenced block. Returns an empty (non-nil) array if no batcher
 // transactions are found. It returns ResetError if it cannot find the referenced block or a
 // referenced blob, or TemporaryError for any other failure to fetch a block or blob.
-func (ds *BlobDataSource) open(ctx context.Context) ([]blobOrCalldata, error) {
+func (ds *BlobDataSource) open(ctx context.Context) (_ []blobOrCalldata, outErr error) {
+	ctx, span := deriveTracer.Start(ctx, "derive.FetchBlobData", trace.WithAttributes(
+		attribute.Int64("number", int64(ds.ref.Number))))
+	defer func() { optracing.End(span, outErr) }()
 	_, txs, err := ds.fetcher.InfoAndTxsByHash(ctx, ds.ref.Hash)
 	if err != nil {
 		if errors.Is(err, ethereum.NotFound) {
@@ -88,6 +94,7 @@ func (ds *BlobDataSource) open(ctx context.Context) ([]blobOrCalldata, error) {
 
 	data, hashes := dataAndHashesFromTxs(txs, &ds.dsCfg, ds.batcherAddr, ds.log)
 
+	span.SetAttributes(attribute.Int("blobs", len(hashes)))
 	if len(hashes) == 0 {
 		// there are no blobs to fetch so we can return immediately
 		return data, nil
@@ -10,6 +10,7 @@ import (
 	"github.com/andybalholm/brotli"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/klauspost/compress/zstd"
 )
 
 const (
@@ -158,7 +159,7 @@ func (ch *Channel) Reader() io.Reader {
 // The L1Inclusion block is also provided at creation time.
 // Warning: the batch reader can read every batch-type.
 // The caller of the batch-reader should filter the results.
-func BatchReader(r io.Reader, maxRLPBytesPerChannel uint64, isFjord bool) (func() (*BatchData, error), error) {
+func BatchReader(r io.Reader, maxRLPBytesPerChannel uint64, isFjord bool, isZstd bool) (func() (*BatchData, error), error) {
 	// use buffered reader so can peek the first byte
 	bufReader := bufio.NewReader(r)
 	compressionType, err := bufReader.Peek(1)
@@ -189,6 +190,23 @@ func BatchReader(r io.Reader, maxRLPBytesPerChannel uint64, isFjord bool) (func(
 		}
 		zr = brotli.NewReader(bufReader)
 		comprAlgo = Brotli
+	} else if compressionType[0] == ChannelVersionZstd {
+		// Zstd compressed batches are only accepted once zstd channel compression is activated
+		if !isZstd {
+			return nil, fmt.Errorf("cannot accept zstd compressed batch before zstd activation")
+		}
+		// discard the first byte
+		_, err := bufReader.Discard(1)
+		if err != nil {
+			return nil, err
+		}
+		// Decode synchronously, so the decoder does not start goroutines that would need to be closed
+		dec, err := zstd.NewReader(bufReader, zstd.WithDecoderConcurrency(1), zstd.WithDecoderMaxWindow(ZstdWindowSize))
+		if err != nil {
+			return nil, err
+		}
+		zr = dec
+		comprAlgo = Zstd
 	} else {
 		return nil, fmt.Errorf("cannot distinguish the compression algo used given type byte %v", compressionType[0])
 	}
@@ -39,9 +39,14 @@ type ChannelBank struct {
 
 	prev    NextFrameProvider
 	fetcher L1Fetcher
+
+	counters stageCounters
 }
 
-var _ ResettableStage = (*ChannelBank)(nil)
+var (
+	_ ResettableStage = (*ChannelBank)(nil)
+	_ StatsProvider   = (*ChannelBank)(nil)
+)
 
 // NewChannelBank creates a ChannelBank, which should be Reset(origin) before use.
 func NewChannelBank(log log.Logger, cfg *rollup.Config, prev NextFrameProvider, fetcher L1Fetcher, m Metrics) *ChannelBank {
@@ -60,12 +65,17 @@ func (cb *ChannelBank) Origin() eth.L1BlockRef {
 	return cb.prev.Origin()
 }
 
-func (cb *ChannelBank) prune() {
-	// check total size
+func (cb *ChannelBank) totalSize() uint64 {
 	totalSize := uint64(0)
 	for _, ch := range cb.channels {
 		totalSize += ch.size
 	}
+	return totalSize
+}
+
+func (cb *ChannelBank) prune() {
+	// check total size
+	totalSize := cb.totalSize()
 	// prune until it is reasonable again. The high-priority channel failed to be read, so we start pruning there.
 	for totalSize > cb.spec.MaxChannelBankSize(cb.Origin().Time) {
 		id := cb.channelQueue[0]
@@ -83,6 +93,7 @@ func (cb *ChannelBank) IngestFrame(f Frame) {
 	origin := cb.Origin()
 	log := cb.log.New("origin", origin, "channel", f.ID, "length", len(f.Data), "frame_number", f.FrameNumber, "is_last", f.IsLast)
 	log.Debug("channel bank got new data")
+	cb.counters.itemsIn++
 
 	currentCh, ok := cb.channels[f.ID]
 	if !ok {
@@ -169,9 +180,15 @@ func (cb *ChannelBank) tryReadChannelAtIndex(i int) (data []byte, err error) {
 	r := ch.Reader()
 	// Suppress error here. io.ReadAll does return nil instead of io.EOF though.
 	data, _ = io.ReadAll(r)
+	cb.counters.itemsOut++
 	return data, nil
 }
 
+// Stats returns the number of frames ingested, the number of channels read, and the size of the buffered channels.
+func (cb *ChannelBank) Stats() StageStats {
+	return StageStats{ItemsIn: cb.counters.itemsIn, ItemsOut: cb.counters.itemsOut, BufferedBytes: cb.totalSize()}
+}
+
 // NextData pulls the next piece of data from the channel bank.
 // Note that it attempts to pull data out of the channel bank prior to
 // loading data in (unlike most other stages). This is to ensure maintain
@@ -7,10 +7,16 @@ import (
 	"io"
 
 	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
 )
 
 const (
 	ChannelVersionBrotli byte = 0x01
+	ChannelVersionZstd   byte = 0x02
+
+	// ZstdWindowSize is the window size used to compress zstd channels,
+	// and the maximum window size accepted when decompressing them.
+	ZstdWindowSize = 8 << 20
 )
 
 type ChannelCompressor interface {
@@ -66,6 +72,16 @@ func (bc *BrotliCompressor) Reset() {
 	bc.CompressorWriter.Reset(bc.compressed)
 }
 
+type ZstdCompressor struct {
+	BaseChannelCompressor
+}
+
+func (zc *ZstdCompressor) Reset() {
+	zc.compressed.Reset()
+	zc.compressed.WriteByte(ChannelVersionZstd)
+	zc.CompressorWriter.Reset(zc.compressed)
+}
+
 func NewChannelCompressor(algo CompressionAlgo) (ChannelCompressor, error) {
 	compressed := &bytes.Buffer{}
 	if algo == Zlib {
@@ -88,6 +104,21 @@ func NewChannelCompressor(algo CompressionAlgo) (ChannelCompressor, error) {
 				compressed:       compressed,
 			},
 		}, nil
+	} else if algo.IsZstd() {
+		compressed.WriteByte(ChannelVersionZstd)
+		writer, err := zstd.NewWriter(compressed,
+			zstd.WithEncoderLevel(zstd.SpeedBestCompression),
+			zstd.WithWindowSize(ZstdWindowSize),
+			zstd.WithEncoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+		return &ZstdCompressor{
+			BaseChannelCompressor{
+				CompressorWriter: writer,
+				compressed:       compressed,
+			},
+		}, nil
 	} else {
 		return nil, fmt.Errorf("unsupported compression algorithm: %s", algo)
 	}
@@ -38,7 +38,12 @@ func TestChannelCompressor_NewReset(t *testing.T) {
 		},
 		{
 			name:              "zstd",
-			algo:              CompressionAlgo("zstd"),
+			algo:              Zstd,
+			expectedResetSize: 1,
+		},
+		{
+			name:              "invalid",
+			algo:              CompressionAlgo("invalid"),
 			expectedResetSize: 0,
 			expectErr:         true,
 		},
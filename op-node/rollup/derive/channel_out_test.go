@@ -2,6 +2,7 @@ package derive
 
 import (
 	"bytes"
+	"errors"
 	"io"
 	"math/big"
 	"math/rand"
@@ -450,7 +451,7 @@ func testSpanChannelOut_MaxBlocksPerSpanBatch(t *testing.T, tt maxBlocksTest) {
 	require.False(t, ch.IsReady())
 	require.NoError(t, ch.AddFrame(frame, l1Origin))
 	require.True(t, ch.IsReady())
-	br, err := BatchReader(ch.Reader(), spec.MaxRLPBytesPerChannel(0), true)
+	br, err := BatchReader(ch.Reader(), spec.MaxRLPBytesPerChannel(0), true, true)
 	require.NoError(t, err)
 
 	sbs := make([]*SingularBatch, 0, tt.numBatches-1)
@@ -487,3 +488,71 @@ func testSpanChannelOut_MaxBlocksPerSpanBatch(t *testing.T, tt maxBlocksTest) {
 		require.Equalf(t, batch0, batch, "iteration %d", i)
 	}
 }
+
+func TestSpanChannelOut_SplitAtEpochs(t *testing.T) {
+	const numBatches, blocksPerEpoch = 12, 3
+	cout, bs := SpanChannelAndBatches(t, 100_000, numBatches, Zlib, WithSpanBatchSplitAtEpochs())
+	for i, b := range bs {
+		b.EpochNum = rollup.Epoch(rollupCfg.Genesis.L1.Number + 42_000 + uint64(i/blocksPerEpoch))
+		b.EpochHash = common.Hash{0xde, 0xad, byte(b.EpochNum)}
+		require.NoErrorf(t, cout.AddSingularBatch(b, uint64(i%blocksPerEpoch)), "iteration %d", i)
+	}
+	require.NoError(t, cout.Close())
+
+	var frameBuf bytes.Buffer
+	_, err := cout.OutputFrame(&frameBuf, 100_000+FrameV0OverHeadSize)
+	require.ErrorIs(t, err, io.EOF)
+	var frame Frame
+	require.NoError(t, frame.UnmarshalBinary(&frameBuf))
+	l1Origin := eth.L1BlockRef{Number: rollupCfg.Genesis.L1.Number + 42_000}
+	ch := NewChannel(frame.ID, l1Origin)
+	require.NoError(t, ch.AddFrame(frame, l1Origin))
+	br, err := BatchReader(ch.Reader(), rollup.NewChainSpec(&rollupCfg).MaxRLPBytesPerChannel(0), true, true)
+	require.NoError(t, err)
+
+	var elems []*SpanBatchElement
+	for {
+		bd, err := br()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		sb, err := DeriveSpanBatch(bd, rollupCfg.BlockTime, rollupCfg.Genesis.L2Time, cout.spanBatch.ChainID)
+		require.NoError(t, err)
+		if len(elems) > 0 {
+			require.NotEqual(t, elems[len(elems)-1].EpochNum, sb.Batches[0].EpochNum, "span batches must only be split at epoch boundaries")
+		}
+		elems = append(elems, sb.Batches...)
+	}
+	require.Len(t, elems, numBatches)
+	for i, elem := range elems {
+		require.Equalf(t, bs[i].EpochNum, elem.EpochNum, "iteration %d", i)
+		require.Equalf(t, bs[i].Timestamp, elem.Timestamp, "iteration %d", i)
+		require.Equalf(t, bs[i].Transactions, elem.Transactions, "iteration %d", i)
+	}
+}
+
+func TestSpanChannelOut_SplitIfSmaller(t *testing.T) {
+	rng := rand.New(rand.NewSource(0x5417))
+	cout, _ := SpanChannelAndBatches(t, 100_000, 0, Zlib, WithSpanBatchSplitAtEpochs())
+	random := make([]byte, 1000)
+	_, _ = rng.Read(random)
+	cout.inactiveRLP().Write(random[:600])
+	cout.activeRLP().Write(random)
+	spanBatch := NewSpanBatch(cout.spanBatch.GenesisTimestamp, cout.spanBatch.ChainID)
+
+	// a split that compresses worse is discarded
+	worse := make([]byte, 2000)
+	_, _ = rng.Read(worse)
+	require.NoError(t, cout.splitIfSmaller(worse, spanBatch))
+	require.Equal(t, random, cout.activeRLP().Bytes())
+	require.Zero(t, cout.sealedRLPBytes)
+	require.NotSame(t, spanBatch, cout.spanBatch)
+
+	// a split that compresses better seals the previous span batch
+	better := append(bytes.Clone(random[:600]), make([]byte, 1000)...)
+	require.NoError(t, cout.splitIfSmaller(better, spanBatch))
+	require.Equal(t, better, cout.activeRLP().Bytes())
+	require.Equal(t, 600, cout.sealedRLPBytes)
+	require.Same(t, spanBatch, cout.spanBatch)
+}
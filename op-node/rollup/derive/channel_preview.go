@@ -0,0 +1,200 @@
+package derive
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+const (
+	// maxBlobFrameSize is the maximum size of a frame in a blob, after the derivation version byte.
+	maxBlobFrameSize = eth.MaxBlobDataSize - 1
+	// DefaultPreviewCalldataFrameSize is the default maximum size of a frame in a calldata transaction,
+	// matching the default maximum L1 transaction size of the batcher.
+	DefaultPreviewCalldataFrameSize = 120_000 - 1
+	// DefaultPreviewTargetSize is the default target size of the compressed data of a previewed channel:
+	// the data that fits in the frames of a transaction with the maximum number of blobs.
+	DefaultPreviewTargetSize = maxBlobsPerTx * (maxBlobFrameSize - FrameV0OverHeadSize)
+
+	maxBlobsPerTx = params.MaxBlobGasPerBlock / params.BlobTxBlobGasPerBlob
+	// calldataGasPerByte is the calldata gas per byte, assuming that, as compressed data, all bytes are non-zero.
+	calldataGasPerByte = params.TxDataNonZeroGasEIP2028
+)
+
+// DefaultPreviewMaxBlocks is the default maximum number of blocks added to a previewed channel.
+const DefaultPreviewMaxBlocks = 1000
+
+// ChannelPreviewArgs are the optional parameters of a channel preview. Zero values select the defaults.
+type ChannelPreviewArgs struct {
+	// CompressionAlgo defaults to zlib, like the batcher.
+	CompressionAlgo      CompressionAlgo `json:"compressionAlgo,omitempty"`
+	TargetSize           hexutil.Uint64  `json:"targetSize,omitempty"`
+	MaxCalldataFrameSize hexutil.Uint64  `json:"maxCalldataFrameSize,omitempty"`
+	MaxBlocks            hexutil.Uint64  `json:"maxBlocks,omitempty"`
+}
+
+// WithDefaults returns the args, with the defaults for any unset parameter.
+func (a *ChannelPreviewArgs) WithDefaults() ChannelPreviewArgs {
+	var out ChannelPreviewArgs
+	if a != nil {
+		out = *a
+	}
+	if out.CompressionAlgo == "" {
+		out.CompressionAlgo = Zlib
+	}
+	if out.TargetSize == 0 {
+		out.TargetSize = DefaultPreviewTargetSize
+	}
+	if out.MaxCalldataFrameSize == 0 {
+		out.MaxCalldataFrameSize = DefaultPreviewCalldataFrameSize
+	}
+	if out.MaxBlocks == 0 {
+		out.MaxBlocks = DefaultPreviewMaxBlocks
+	}
+	return out
+}
+
+// ChannelPreview describes the channel a batcher would build from a range of L2 blocks,
+// and estimates the cost of submitting it to L1.
+// The costs are estimated with the L1 fees of the L1 origin of the last block, without priority fees.
+type ChannelPreview struct {
+	CompressionAlgo CompressionAlgo `json:"compressionAlgo"`
+	TargetSize      uint64          `json:"targetSize"`
+	// Blocks is the number of blocks in the channel, starting at FirstBlock.
+	Blocks     uint64      `json:"blocks"`
+	FirstBlock eth.BlockID `json:"firstBlock"`
+	LastBlock  eth.BlockID `json:"lastBlock"`
+	// Full is true if the channel reached its target size, and the blocks after LastBlock go into the next channel.
+	Full bool `json:"full"`
+	// Transactions is the number of non-deposit transactions in the blocks.
+	Transactions     uint64 `json:"transactions"`
+	UncompressedSize uint64 `json:"uncompressedSize"`
+	CompressedSize   uint64 `json:"compressedSize"`
+
+	L1BaseFee     *hexutil.Big `json:"l1BaseFee"`
+	L1BlobBaseFee *hexutil.Big `json:"l1BlobBaseFee,omitempty"`
+
+	// CalldataTxs is the number of calldata transactions required to submit the channel.
+	CalldataTxs  uint64       `json:"calldataTxs"`
+	CalldataGas  uint64       `json:"calldataGas"`
+	CalldataCost *hexutil.Big `json:"calldataCost"`
+	// Blobs is the number of blobs required to submit the channel, with a frame per blob.
+	Blobs      uint64 `json:"blobs"`
+	BlobTxs    uint64 `json:"blobTxs"`
+	BlobTxsGas uint64 `json:"blobTxsGas"`
+	BlobGas    uint64 `json:"blobGas"`
+	// BlobCost is only set if the blob base fee is known, from Ecotone onwards.
+	BlobCost *hexutil.Big `json:"blobCost,omitempty"`
+}
+
+// ChannelPreviewBuilder builds a span batch channel from L2 blocks, like the batcher, to preview its size and cost.
+type ChannelPreviewBuilder struct {
+	rollupCfg *rollup.Config
+	co        *SpanChannelOut
+	preview   ChannelPreview
+	l1Info    *L1BlockInfo
+}
+
+func NewChannelPreviewBuilder(rollupCfg *rollup.Config, algo CompressionAlgo, targetSize uint64) (*ChannelPreviewBuilder, error) {
+	co, err := NewSpanChannelOut(rollupCfg.Genesis.L2Time, rollupCfg.L2ChainID, targetSize, algo, rollup.NewChainSpec(rollupCfg))
+	if err != nil {
+		return nil, err
+	}
+	return &ChannelPreviewBuilder{
+		rollupCfg: rollupCfg,
+		co:        co,
+		preview:   ChannelPreview{CompressionAlgo: algo, TargetSize: targetSize},
+	}, nil
+}
+
+// AddBlock adds the next block to the channel. It returns false, without adding the block, if the channel is full.
+func (b *ChannelPreviewBuilder) AddBlock(block *types.Block) (bool, error) {
+	if b.preview.Full {
+		return false, nil
+	}
+	batch, l1Info, err := BlockToSingularBatch(b.rollupCfg, block)
+	if err != nil {
+		return false, fmt.Errorf("failed to convert block %s to batch: %w", eth.ToBlockID(block), err)
+	}
+	err = b.co.AddSingularBatch(batch, l1Info.SequenceNumber)
+	if errors.Is(err, ErrTooManyRLPBytes) {
+		// The batch is still in the active RLP buffer, revert it.
+		b.co.swapRLP()
+		b.preview.Full = true
+		return false, nil
+	} else if errors.Is(err, ErrCompressorFull) {
+		b.preview.Full = true
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("failed to add block %s to channel: %w", eth.ToBlockID(block), err)
+	}
+	if b.preview.Blocks == 0 {
+		b.preview.FirstBlock = eth.ToBlockID(block)
+	}
+	b.preview.Blocks++
+	b.preview.LastBlock = eth.ToBlockID(block)
+	b.preview.Transactions += uint64(len(batch.Transactions))
+	b.l1Info = l1Info
+	if b.co.FullErr() != nil {
+		// The block filled up the channel exactly, or is the first block and exceeds the target size.
+		b.preview.Full = true
+	}
+	return true, nil
+}
+
+// Preview closes the channel and returns the preview of it.
+// The calldata transactions are estimated to use maxCalldataFrameSize frames.
+func (b *ChannelPreviewBuilder) Preview(maxCalldataFrameSize uint64) (*ChannelPreview, error) {
+	if maxCalldataFrameSize <= FrameV0OverHeadSize {
+		return nil, ErrMaxFrameSizeTooSmall
+	}
+	out := b.preview
+	if out.Blocks == 0 {
+		return &out, nil
+	}
+	if err := b.co.Close(); err != nil && !errors.Is(err, ErrChannelOutAlreadyClosed) {
+		return nil, fmt.Errorf("failed to close channel: %w", err)
+	}
+	out.UncompressedSize = uint64(b.co.InputBytes())
+	out.CompressedSize = uint64(b.co.ReadyBytes())
+
+	baseFee := new(big.Int)
+	if b.l1Info.BaseFee != nil {
+		baseFee.Set(b.l1Info.BaseFee)
+	}
+	out.L1BaseFee = (*hexutil.Big)(baseFee)
+
+	out.CalldataTxs = frameCount(out.CompressedSize, maxCalldataFrameSize)
+	// Each calldata transaction holds the derivation version byte and a frame.
+	calldataBytes := out.CompressedSize + out.CalldataTxs*(1+FrameV0OverHeadSize)
+	out.CalldataGas = out.CalldataTxs*params.TxGas + calldataBytes*calldataGasPerByte
+	out.CalldataCost = (*hexutil.Big)(new(big.Int).Mul(baseFee, new(big.Int).SetUint64(out.CalldataGas)))
+
+	out.Blobs = frameCount(out.CompressedSize, maxBlobFrameSize)
+	out.BlobTxs = (out.Blobs + maxBlobsPerTx - 1) / maxBlobsPerTx
+	out.BlobTxsGas = out.BlobTxs * params.TxGas
+	out.BlobGas = out.Blobs * params.BlobTxBlobGasPerBlob
+	if b.l1Info.BlobBaseFee != nil {
+		out.L1BlobBaseFee = (*hexutil.Big)(new(big.Int).Set(b.l1Info.BlobBaseFee))
+		cost := new(big.Int).Mul(b.l1Info.BlobBaseFee, new(big.Int).SetUint64(out.BlobGas))
+		cost.Add(cost, new(big.Int).Mul(baseFee, new(big.Int).SetUint64(out.BlobTxsGas)))
+		out.BlobCost = (*hexutil.Big)(cost)
+	}
+	return &out, nil
+}
+
+// frameCount returns the number of frames of at most maxFrameSize bytes that hold size bytes of channel data.
+func frameCount(size uint64, maxFrameSize uint64) uint64 {
+	perFrame := maxFrameSize - FrameV0OverHeadSize
+	if size == 0 {
+		return 1
+	}
+	return (size + perFrame - 1) / perFrame
+}
@@ -0,0 +1,112 @@
+package derive
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func previewTestBlocks(t *testing.T, rng *rand.Rand, cfg *rollup.Config, count int) []*types.Block {
+	l1Info := &testutils.MockBlockInfo{
+		InfoHash:        testutils.RandomHash(rng),
+		InfoNum:         100,
+		InfoBaseFee:     big.NewInt(7_000_000_000),
+		InfoBlobBaseFee: big.NewInt(3),
+	}
+	signer := types.NewLondonSigner(cfg.L2ChainID)
+	blocks := make([]*types.Block, 0, count)
+	for i := 0; i < count; i++ {
+		l2Time := cfg.Genesis.L2Time + uint64(i+1)*cfg.BlockTime
+		l1InfoTx, err := L1InfoDeposit(cfg, eth.SystemConfig{}, uint64(i), l1Info, l2Time)
+		require.NoError(t, err)
+		txs := []*types.Transaction{types.NewTx(l1InfoTx)}
+		for j := 0; j < 5; j++ {
+			txs = append(txs, testutils.RandomTx(rng, big.NewInt(int64(rng.Uint32())), signer))
+		}
+		header := &types.Header{Number: big.NewInt(int64(i + 1)), Time: l2Time, ParentHash: testutils.RandomHash(rng)}
+		blocks = append(blocks, types.NewBlockWithHeader(header).WithBody(types.Body{Transactions: txs}))
+	}
+	return blocks
+}
+
+func TestChannelPreview(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	cfg := rollupCfg
+	zero := uint64(0)
+	cfg.RegolithTime = &zero
+	cfg.CanyonTime = &zero
+	cfg.DeltaTime = &zero
+	cfg.EcotoneTime = &zero
+	blocks := previewTestBlocks(t, rng, &cfg, 20)
+
+	t.Run("AllBlocks", func(t *testing.T) {
+		b, err := NewChannelPreviewBuilder(&cfg, Zlib, DefaultPreviewTargetSize)
+		require.NoError(t, err)
+		for _, block := range blocks {
+			added, err := b.AddBlock(block)
+			require.NoError(t, err)
+			require.True(t, added)
+		}
+		preview, err := b.Preview(DefaultPreviewCalldataFrameSize)
+		require.NoError(t, err)
+		require.False(t, preview.Full)
+		require.EqualValues(t, 20, preview.Blocks)
+		require.Equal(t, eth.ToBlockID(blocks[0]), preview.FirstBlock)
+		require.Equal(t, eth.ToBlockID(blocks[19]), preview.LastBlock)
+		require.EqualValues(t, 100, preview.Transactions)
+		require.NotZero(t, preview.UncompressedSize)
+		require.NotZero(t, preview.CompressedSize)
+
+		require.EqualValues(t, 1, preview.CalldataTxs)
+		require.Equal(t, params.TxGas+(preview.CompressedSize+1+FrameV0OverHeadSize)*16, preview.CalldataGas)
+		require.Equal(t, new(big.Int).Mul(big.NewInt(7_000_000_000), new(big.Int).SetUint64(preview.CalldataGas)), preview.CalldataCost.ToInt())
+
+		require.EqualValues(t, 1, preview.Blobs)
+		require.EqualValues(t, 1, preview.BlobTxs)
+		require.Equal(t, uint64(params.BlobTxBlobGasPerBlob), preview.BlobGas)
+		require.Equal(t, new(big.Int).SetUint64(params.BlobTxBlobGasPerBlob*3+params.TxGas*7_000_000_000), preview.BlobCost.ToInt())
+	})
+
+	t.Run("Full", func(t *testing.T) {
+		b, err := NewChannelPreviewBuilder(&cfg, Zlib, 20_000)
+		require.NoError(t, err)
+		var added uint64
+		for _, block := range blocks {
+			ok, err := b.AddBlock(block)
+			require.NoError(t, err)
+			if !ok {
+				break
+			}
+			added++
+		}
+		require.Greater(t, added, uint64(1))
+		require.Less(t, added, uint64(len(blocks)))
+		ok, err := b.AddBlock(blocks[len(blocks)-1])
+		require.NoError(t, err)
+		require.False(t, ok, "must not add blocks to a full channel")
+
+		preview, err := b.Preview(1_000)
+		require.NoError(t, err)
+		require.True(t, preview.Full)
+		require.Equal(t, added, preview.Blocks)
+		require.LessOrEqual(t, preview.CompressedSize, uint64(20_000))
+		require.Equal(t, (preview.CompressedSize+1_000-FrameV0OverHeadSize-1)/(1_000-FrameV0OverHeadSize), preview.CalldataTxs)
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		b, err := NewChannelPreviewBuilder(&cfg, Brotli10, DefaultPreviewTargetSize)
+		require.NoError(t, err)
+		preview, err := b.Preview(DefaultPreviewCalldataFrameSize)
+		require.NoError(t, err)
+		require.Zero(t, preview.Blocks)
+		require.Nil(t, preview.CalldataCost)
+	})
+}
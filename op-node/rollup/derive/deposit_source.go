@@ -13,9 +13,10 @@ type UserDepositSource struct {
 }
 
 const (
-	UserDepositSourceDomain    = 0
-	L1InfoDepositSourceDomain  = 1
-	UpgradeDepositSourceDomain = 2
+	UserDepositSourceDomain             = 0
+	L1InfoDepositSourceDomain           = 1
+	UpgradeDepositSourceDomain          = 2
+	SequencerOfflineDepositSourceDomain = 4 // domain 3 is reserved for interop system deposits
 )
 
 func (dep *UserDepositSource) SourceHash() common.Hash {
@@ -63,3 +64,22 @@ func (dep *UpgradeDepositSource) SourceHash() common.Hash {
 	copy(domainInput[32:], intentHash[:])
 	return crypto.Keccak256Hash(domainInput[:])
 }
+
+// SequencerOfflineDepositSource identifies the sequencer-offline marker deposit of an L2 block,
+// by the L1 origin and sequence number of the block, like the L1 info deposit.
+type SequencerOfflineDepositSource struct {
+	L1BlockHash common.Hash
+	SeqNumber   uint64
+}
+
+func (dep *SequencerOfflineDepositSource) SourceHash() common.Hash {
+	var input [32 * 2]byte
+	copy(input[:32], dep.L1BlockHash[:])
+	binary.BigEndian.PutUint64(input[32*2-8:], dep.SeqNumber)
+	depositIDHash := crypto.Keccak256Hash(input[:])
+
+	var domainInput [32 * 2]byte
+	binary.BigEndian.PutUint64(domainInput[32-8:32], SequencerOfflineDepositSourceDomain)
+	copy(domainInput[32:], depositIDHash[:])
+	return crypto.Keccak256Hash(domainInput[:])
+}
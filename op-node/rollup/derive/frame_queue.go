@@ -9,7 +9,10 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
-var _ NextFrameProvider = &FrameQueue{}
+var (
+	_ NextFrameProvider = &FrameQueue{}
+	_ StatsProvider     = &FrameQueue{}
+)
 
 type NextDataProvider interface {
 	NextData(context.Context) ([]byte, error)
@@ -20,6 +23,8 @@ type FrameQueue struct {
 	log    log.Logger
 	frames []Frame
 	prev   NextDataProvider
+
+	counters stageCounters
 }
 
 func NewFrameQueue(log log.Logger, prev NextDataProvider) *FrameQueue {
@@ -39,6 +44,7 @@ func (fq *FrameQueue) NextFrame(ctx context.Context) (Frame, error) {
 		if data, err := fq.prev.NextData(ctx); err != nil {
 			return Frame{}, err
 		} else {
+			fq.counters.itemsIn++
 			if new, err := ParseFrames(data); err == nil {
 				fq.frames = append(fq.frames, new...)
 			} else {
@@ -53,9 +59,19 @@ func (fq *FrameQueue) NextFrame(ctx context.Context) (Frame, error) {
 
 	ret := fq.frames[0]
 	fq.frames = fq.frames[1:]
+	fq.counters.itemsOut++
 	return ret, nil
 }
 
+// Stats returns the number of pieces of data parsed, the number of frames provided, and the size of the queued frames.
+func (fq *FrameQueue) Stats() StageStats {
+	var buffered uint64
+	for _, f := range fq.frames {
+		buffered += uint64(len(f.Data))
+	}
+	return StageStats{ItemsIn: fq.counters.itemsIn, ItemsOut: fq.counters.itemsOut, BufferedBytes: buffered}
+}
+
 func (fq *FrameQueue) Reset(_ context.Context, _ eth.L1BlockRef, _ eth.SystemConfig) error {
 	fq.frames = fq.frames[:0]
 	return io.EOF
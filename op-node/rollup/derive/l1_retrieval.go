@@ -27,9 +27,14 @@ type L1Retrieval struct {
 	prev    NextBlockProvider
 
 	datas DataIter
+
+	counters stageCounters
 }
 
-var _ ResettableStage = (*L1Retrieval)(nil)
+var (
+	_ ResettableStage = (*L1Retrieval)(nil)
+	_ StatsProvider   = (*L1Retrieval)(nil)
+)
 
 func NewL1Retrieval(log log.Logger, dataSrc DataAvailabilitySource, prev NextBlockProvider) *L1Retrieval {
 	return &L1Retrieval{
@@ -57,6 +62,7 @@ func (l1r *L1Retrieval) NextData(ctx context.Context) ([]byte, error) {
 		if l1r.datas, err = l1r.dataSrc.OpenData(ctx, next, l1r.prev.SystemConfig().BatcherAddr); err != nil {
 			return nil, fmt.Errorf("failed to open data source: %w", err)
 		}
+		l1r.counters.itemsIn++
 	}
 
 	l1r.log.Debug("fetching next piece of data")
@@ -68,10 +74,16 @@ func (l1r *L1Retrieval) NextData(ctx context.Context) ([]byte, error) {
 		// CalldataSource appropriately wraps the error so avoid double wrapping errors here.
 		return nil, err
 	} else {
+		l1r.counters.itemsOut++
 		return data, nil
 	}
 }
 
+// Stats returns the number of L1 blocks opened and the number of pieces of data retrieved from them.
+func (l1r *L1Retrieval) Stats() StageStats {
+	return StageStats{ItemsIn: l1r.counters.itemsIn, ItemsOut: l1r.counters.itemsOut}
+}
+
 // Reset re-initializes the L1 Retrieval stage to block of it's `next` progress.
 // Note that we open up the `l1r.datas` here because it is required to maintain the
 // internal invariants that later propagate up the derivation pipeline.
@@ -10,9 +10,12 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/optracing"
 )
 
 // L1 Traversal fetches the next L1 block and exposes it through the progress API
@@ -57,8 +60,11 @@ func (l1t *L1Traversal) NextL1Block(_ context.Context) (eth.L1BlockRef, error) {
 }
 
 // AdvanceL1Block advances the internal state of L1 Traversal
-func (l1t *L1Traversal) AdvanceL1Block(ctx context.Context) error {
+func (l1t *L1Traversal) AdvanceL1Block(ctx context.Context) (outErr error) {
 	origin := l1t.block
+	ctx, span := deriveTracer.Start(ctx, "derive.AdvanceL1Block", trace.WithAttributes(
+		attribute.Int64("number", int64(origin.Number+1))))
+	defer func() { optracing.End(span, outErr) }()
 	nextL1Origin, err := l1t.l1Blocks.L1BlockRefByNumber(ctx, origin.Number+1)
 	if errors.Is(err, ethereum.NotFound) {
 		l1t.log.Debug("can't find next L1 block info (yet)", "number", origin.Number+1, "origin", origin)
@@ -5,14 +5,20 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/optracing"
 )
 
+var deriveTracer = optracing.Tracer("op-node/rollup/derive")
+
 type Metrics interface {
 	RecordL1Ref(name string, ref eth.L1BlockRef)
 	RecordL2Ref(name string, ref eth.L2BlockRef)
@@ -21,8 +27,13 @@ type Metrics interface {
 	RecordChannelTimedOut()
 	RecordFrame()
 	RecordDerivedBatches(batchType string)
+	RecordBatchDrop(reason string)
 	SetDerivationIdle(idle bool)
 	RecordPipelineReset()
+	RecordDerivationStageItems(stage string, itemsIn uint64, itemsOut uint64)
+	RecordDerivationStageBufferedBytes(stage string, bytes uint64)
+	RecordDerivationStageAdvance(stage string, t time.Time)
+	RecordDerivationStageStall(stage string)
 }
 
 type L1Fetcher interface {
@@ -73,6 +84,10 @@ type DerivationPipeline struct {
 	engineIsReset  bool
 
 	metrics Metrics
+
+	stageTracker *stageTracker
+
+	batchDrops *BatchDropTracker
 }
 
 // NewDerivationPipeline creates a DerivationPipeline, to turn L1 data into L2 block-inputs.
@@ -87,6 +102,8 @@ func NewDerivationPipeline(log log.Logger, rollupCfg *rollup.Config, l1Fetcher L
 	bank := NewChannelBank(log, rollupCfg, frameQueue, l1Fetcher, metrics)
 	chInReader := NewChannelInReader(rollupCfg, log, bank, metrics)
 	batchQueue := NewBatchQueue(log, rollupCfg, chInReader, l2Source)
+	batchDrops := NewBatchDropTracker(metrics, DefaultRecentBatchDrops)
+	batchQueue.drops = batchDrops
 	attrBuilder := NewFetchingAttributesBuilder(rollupCfg, l1Fetcher, l2Source)
 	attributesQueue := NewAttributesQueue(log, rollupCfg, attrBuilder, batchQueue)
 
@@ -95,6 +112,13 @@ func NewDerivationPipeline(log log.Logger, rollupCfg *rollup.Config, l1Fetcher L
 	// Note: The ResetEngine is the only reset that can fail.
 	stages := []ResettableStage{l1Traversal, l1Src, altDA, frameQueue, bank, chInReader, batchQueue, attributesQueue}
 
+	tracker := newStageTracker(log, metrics)
+	tracker.add(StageL1Retrieval, l1Src)
+	tracker.add(StageFrameQueue, frameQueue)
+	tracker.add(StageChannelBank, bank)
+	tracker.add(StageBatchQueue, batchQueue)
+	tracker.add(StageAttributesQueue, attributesQueue)
+
 	return &DerivationPipeline{
 		log:       log,
 		rollupCfg: rollupCfg,
@@ -106,9 +130,24 @@ func NewDerivationPipeline(log log.Logger, rollupCfg *rollup.Config, l1Fetcher L
 		traversal: l1Traversal,
 		attrib:    attributesQueue,
 		l2:        l2Source,
+
+		stageTracker: tracker,
+		batchDrops:   batchDrops,
 	}
 }
 
+// BatchDrops reports the batches that were dropped by the batch validation rules.
+func (dp *DerivationPipeline) BatchDrops() BatchDropReport {
+	return dp.batchDrops.Report()
+}
+
+// SetStallTimeout enables stall detection: a stage that did not provide any items to the next stage
+// for the timeout is logged and counted in the metrics. Stalls are checked whenever the pipeline steps.
+// Stall detection is disabled if the timeout is 0.
+func (dp *DerivationPipeline) SetStallTimeout(timeout time.Duration) {
+	dp.stageTracker.stallTimeout = timeout
+}
+
 // DerivationReady returns true if the derivation pipeline is ready to be used.
 // When it's being reset its state is inconsistent, and should not be used externally.
 func (dp *DerivationPipeline) DerivationReady() bool {
@@ -120,6 +159,7 @@ func (dp *DerivationPipeline) Reset() {
 	dp.resetSysConfig = eth.SystemConfig{}
 	dp.resetL2Safe = eth.L2BlockRef{}
 	dp.engineIsReset = false
+	dp.stageTracker.reset()
 }
 
 // Origin is the L1 block of the inner-most stage of the derivation pipeline,
@@ -136,6 +176,18 @@ func (dp *DerivationPipeline) Origin() eth.L1BlockRef {
 // When Step returns nil, it should be called again, to continue the derivation process.
 func (dp *DerivationPipeline) Step(ctx context.Context, pendingSafeHead eth.L2BlockRef) (outAttrib *AttributesWithParent, outErr error) {
 	defer dp.metrics.RecordL1Ref("l1_derived", dp.Origin())
+	defer dp.stageTracker.record()
+
+	ctx, span := deriveTracer.Start(ctx, "derive.Step", trace.WithAttributes(
+		attribute.Int64("origin", int64(dp.origin.Number)),
+		attribute.Int64("pending_safe", int64(pendingSafeHead.Number))))
+	defer func() {
+		if outAttrib != nil && outAttrib.Attributes != nil {
+			span.SetAttributes(attribute.Int64("derived_from", int64(outAttrib.DerivedFrom.Number)),
+				attribute.Int("txs", len(outAttrib.Attributes.Transactions)))
+		}
+		optracing.End(span, outErr)
+	}()
 
 	dp.metrics.SetDerivationIdle(false)
 	defer func() {
@@ -0,0 +1,243 @@
+package derive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+var ErrBatchNotFound = errors.New("batch not found")
+
+// SafetyTrace explains how an L2 block became safe: the batch that carried it,
+// and the channel and batcher transactions the batch was submitted with.
+type SafetyTrace struct {
+	Block eth.L2BlockRef `json:"block"`
+	// InclusionBlock is the L1 block that completed the channel of the batch.
+	InclusionBlock eth.L1BlockRef `json:"inclusionBlock"`
+	ChannelID      ChannelID      `json:"channelID"`
+	// SpanBatch is true if the block was carried by a span batch, and false for a singular batch.
+	SpanBatch bool `json:"spanBatch"`
+	// BatchTimestamp is the timestamp of the first block of the batch.
+	BatchTimestamp uint64 `json:"batchTimestamp"`
+	// BatchBlocks is the number of blocks in the batch.
+	BatchBlocks int `json:"batchBlocks"`
+	// Frames are the frames of the channel, in the order they were included on L1.
+	Frames []SafetyTraceFrame `json:"frames"`
+	// Source is how the L1 range to re-derive was found, see SafetyTraceSourceSafeDB and SafetyTraceSourceSeqWindow.
+	Source string `json:"source"`
+}
+
+const (
+	// SafetyTraceSourceSafeDB means the inclusion block was looked up in the safe head database.
+	SafetyTraceSourceSafeDB = "safedb"
+	// SafetyTraceSourceSeqWindow means the sequencing window of the block's L1 origin was re-derived.
+	SafetyTraceSourceSeqWindow = "seq-window"
+)
+
+// SafetyTraceFrame is a frame of a traced channel.
+type SafetyTraceFrame struct {
+	TxHash      common.Hash `json:"txHash"`
+	L1Block     eth.BlockID `json:"l1Block"`
+	L1Time      uint64      `json:"l1Time"`
+	FrameNumber uint16      `json:"frameNumber"`
+	IsLast      bool        `json:"isLast"`
+	// Blob is true if the frame was submitted in a blob, and false for calldata.
+	Blob bool `json:"blob"`
+}
+
+type SafetyTraceL1Fetcher interface {
+	L1BlockRefByNumber(ctx context.Context, num uint64) (eth.L1BlockRef, error)
+	L1TransactionFetcher
+}
+
+type tracedChannel struct {
+	ch     *Channel
+	frames []SafetyTraceFrame
+}
+
+// TraceSafety re-derives the L1 blocks from..to (inclusive) to find the batch that carried the given L2 block.
+// All channels that are completed in the range are decoded, including channels that opened before from,
+// but only frames within the range are read. The first batch that matches the block is returned.
+// Alt-DA is not supported.
+func TraceSafety(ctx context.Context, logger log.Logger, cfg *rollup.Config, l1 SafetyTraceL1Fetcher, blobs L1BlobsFetcher,
+	batcherAddr common.Address, block eth.L2BlockRef, from, to uint64) (*SafetyTrace, error) {
+	if cfg.AltDAEnabled() {
+		return nil, errors.New("safety trace does not support alt-DA")
+	}
+	dsCfg := DataSourceConfig{
+		l1Signer:          cfg.L1Signer(),
+		batchInboxAddress: cfg.BatchInboxAddress,
+	}
+	spec := rollup.NewChainSpec(cfg)
+	channels := make(map[ChannelID]*tracedChannel)
+	for num := from; num <= to; num++ {
+		ref, err := l1.L1BlockRefByNumber(ctx, num)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get L1 block %d: %w", num, err)
+		}
+		frames, err := traceFrames(ctx, logger, &dsCfg, l1, blobs, batcherAddr, ref)
+		if err != nil {
+			return nil, err
+		}
+		for _, tf := range frames {
+			tc, ok := channels[tf.frame.ID]
+			if !ok {
+				tc = &tracedChannel{ch: NewChannel(tf.frame.ID, ref)}
+				channels[tf.frame.ID] = tc
+			}
+			if err := tc.ch.AddFrame(tf.frame, ref); err != nil {
+				logger.Debug("Ignoring frame", "channel", tf.frame.ID, "frame", tf.frame.FrameNumber, "err", err)
+				continue
+			}
+			tc.frames = append(tc.frames, tf.trace)
+			if !tc.ch.IsReady() {
+				continue
+			}
+			delete(channels, tf.frame.ID)
+			trace, err := traceChannel(cfg, spec, tc.ch, block, ref)
+			if errors.Is(err, ErrBatchNotFound) {
+				continue
+			} else if err != nil {
+				logger.Debug("Failed to decode channel", "channel", tf.frame.ID, "err", err)
+				continue
+			}
+			trace.Frames = tc.frames
+			return trace, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: block %s in L1 blocks %d to %d", ErrBatchNotFound, block, from, to)
+}
+
+type tracedFrame struct {
+	frame Frame
+	trace SafetyTraceFrame
+}
+
+// traceFrames returns the frames of the batcher transactions in the given L1 block, from calldata and blobs.
+func traceFrames(ctx context.Context, logger log.Logger, dsCfg *DataSourceConfig, l1 L1TransactionFetcher, blobsFetcher L1BlobsFetcher,
+	batcherAddr common.Address, ref eth.L1BlockRef) ([]tracedFrame, error) {
+	_, txs, err := l1.InfoAndTxsByHash(ctx, ref.Hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transactions of L1 block %s: %w", ref, err)
+	}
+	var out []tracedFrame
+	addFrames := func(data []byte, tx *types.Transaction, blob bool) {
+		frames, err := ParseFrames(data)
+		if err != nil {
+			logger.Debug("Ignoring batcher data", "tx", tx.Hash(), "err", err)
+			return
+		}
+		for _, f := range frames {
+			out = append(out, tracedFrame{
+				frame: f,
+				trace: SafetyTraceFrame{
+					TxHash:      tx.Hash(),
+					L1Block:     ref.ID(),
+					L1Time:      ref.Time,
+					FrameNumber: f.FrameNumber,
+					IsLast:      f.IsLast,
+					Blob:        blob,
+				},
+			})
+		}
+	}
+
+	var hashes []eth.IndexedBlobHash
+	var blobTxs []*types.Transaction
+	blobIndex := 0
+	for _, tx := range txs {
+		if !isValidBatchTx(tx, dsCfg.l1Signer, dsCfg.batchInboxAddress, batcherAddr, logger) {
+			blobIndex += len(tx.BlobHashes())
+			continue
+		}
+		if tx.Type() != types.BlobTxType {
+			addFrames(tx.Data(), tx, false)
+			continue
+		}
+		for _, h := range tx.BlobHashes() {
+			hashes = append(hashes, eth.IndexedBlobHash{Index: uint64(blobIndex), Hash: h})
+			blobTxs = append(blobTxs, tx)
+			blobIndex++
+		}
+	}
+	if len(hashes) == 0 {
+		return out, nil
+	}
+	if blobsFetcher == nil {
+		return nil, fmt.Errorf("L1 block %s has batcher blobs, but no beacon endpoint is configured", ref)
+	}
+	blobs, err := blobsFetcher.GetBlobs(ctx, ref, hashes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blobs of L1 block %s: %w", ref, err)
+	}
+	if len(blobs) != len(hashes) {
+		return nil, fmt.Errorf("expected %d blobs in L1 block %s, got %d", len(hashes), ref, len(blobs))
+	}
+	for i, blob := range blobs {
+		data, err := blob.ToData()
+		if err != nil {
+			logger.Debug("Ignoring undecodable blob", "tx", blobTxs[i].Hash(), "index", hashes[i].Index, "err", err)
+			continue
+		}
+		addFrames(data, blobTxs[i], true)
+	}
+	return out, nil
+}
+
+// traceChannel decodes the batches of a completed channel and returns the trace of the one that carries the block.
+func traceChannel(cfg *rollup.Config, spec *rollup.ChainSpec, ch *Channel, block eth.L2BlockRef, inclusion eth.L1BlockRef) (*SafetyTrace, error) {
+	nextBatch, err := BatchReader(ch.Reader(), spec.MaxRLPBytesPerChannel(inclusion.Time), cfg.IsFjord(inclusion.Time), cfg.IsZstd(inclusion.Time))
+	if err != nil {
+		return nil, err
+	}
+	for {
+		batchData, err := nextBatch()
+		if errors.Is(err, io.EOF) {
+			return nil, ErrBatchNotFound
+		} else if err != nil {
+			return nil, err
+		}
+		trace := &SafetyTrace{
+			Block:          block,
+			InclusionBlock: inclusion,
+			ChannelID:      ch.id,
+		}
+		switch batchData.GetBatchType() {
+		case SingularBatchType:
+			batch, err := GetSingularBatch(batchData)
+			if err != nil {
+				return nil, err
+			}
+			if batch.Timestamp != block.Time || uint64(batch.EpochNum) != block.L1Origin.Number || batch.ParentHash != block.ParentHash {
+				continue
+			}
+			trace.BatchTimestamp = batch.Timestamp
+			trace.BatchBlocks = 1
+			return trace, nil
+		case SpanBatchType:
+			batch, err := DeriveSpanBatch(batchData, cfg.BlockTime, cfg.Genesis.L2Time, cfg.L2ChainID)
+			if err != nil {
+				return nil, err
+			}
+			for i := 0; i < batch.GetBlockCount(); i++ {
+				if batch.GetBlockTimestamp(i) != block.Time || batch.GetBlockEpochNum(i) != block.L1Origin.Number {
+					continue
+				}
+				trace.SpanBatch = true
+				trace.BatchTimestamp = batch.GetTimestamp()
+				trace.BatchBlocks = batch.GetBlockCount()
+				return trace, nil
+			}
+		default:
+			return nil, fmt.Errorf("unknown batch type %d", batchData.GetBatchType())
+		}
+	}
+}
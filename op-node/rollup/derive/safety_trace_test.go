@@ -0,0 +1,129 @@
+package derive
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+type stubTraceL1 struct {
+	refs map[uint64]eth.L1BlockRef
+	txs  map[common.Hash]types.Transactions
+}
+
+func (s *stubTraceL1) L1BlockRefByNumber(_ context.Context, num uint64) (eth.L1BlockRef, error) {
+	ref, ok := s.refs[num]
+	if !ok {
+		return eth.L1BlockRef{}, ethereum.NotFound
+	}
+	return ref, nil
+}
+
+func (s *stubTraceL1) InfoAndTxsByHash(_ context.Context, hash common.Hash) (eth.BlockInfo, types.Transactions, error) {
+	return nil, s.txs[hash], nil
+}
+
+func TestTraceSafety(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	cfg := rollupCfg
+	zero := uint64(0)
+	cfg.RegolithTime = &zero
+	cfg.CanyonTime = &zero
+	cfg.DeltaTime = &zero
+	cfg.EcotoneTime = &zero
+	cfg.BatchInboxAddress = testutils.RandomAddress(rng)
+	batcherKey := testutils.RandomKey()
+	batcherAddr := crypto.PubkeyToAddress(batcherKey.PublicKey)
+	l1Signer := cfg.L1Signer()
+
+	blocks := previewTestBlocks(t, rng, &cfg, 10)
+	co, err := NewSpanChannelOut(cfg.Genesis.L2Time, cfg.L2ChainID, 100_000, Zlib, rollup.NewChainSpec(&cfg))
+	require.NoError(t, err)
+	for _, block := range blocks[:8] {
+		require.NoError(t, co.AddBlock(&cfg, block))
+	}
+	require.NoError(t, co.Close())
+	var frames [][]byte
+	for {
+		var buf bytes.Buffer
+		buf.WriteByte(DerivationVersion0)
+		_, err := co.OutputFrame(&buf, 1000)
+		frames = append(frames, buf.Bytes())
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+	}
+	require.Greater(t, len(frames), 1)
+
+	batchTx := func(data []byte) *types.Transaction {
+		tx, err := types.SignNewTx(batcherKey, l1Signer, &types.DynamicFeeTx{
+			ChainID:   l1Signer.ChainID(),
+			GasTipCap: big.NewInt(2 * params.GWei),
+			GasFeeCap: big.NewInt(30 * params.GWei),
+			Gas:       100_000,
+			To:        &cfg.BatchInboxAddress,
+			Data:      data,
+		})
+		require.NoError(t, err)
+		return tx
+	}
+	l1 := &stubTraceL1{refs: make(map[uint64]eth.L1BlockRef), txs: make(map[common.Hash]types.Transactions)}
+	for num := uint64(100); num <= 103; num++ {
+		ref := eth.L1BlockRef{Hash: testutils.RandomHash(rng), Number: num, Time: 1000 + num*12}
+		l1.refs[num] = ref
+	}
+	// The first frame is included in the L1 block 101, the others in 102, along with a tx that is not from the batcher.
+	tx0 := batchTx(frames[0])
+	l1.txs[l1.refs[101].Hash] = types.Transactions{tx0}
+	var rest types.Transactions
+	for _, frame := range frames[1:] {
+		rest = append(rest, batchTx(frame))
+	}
+	rest = append(rest, testutils.RandomTx(rng, big.NewInt(1), l1Signer))
+	l1.txs[l1.refs[102].Hash] = rest
+
+	blockRef := func(block *types.Block) eth.L2BlockRef {
+		return eth.L2BlockRef{
+			Hash:       block.Hash(),
+			Number:     block.NumberU64(),
+			ParentHash: block.ParentHash(),
+			Time:       block.Time(),
+			L1Origin:   eth.BlockID{Number: 100},
+		}
+	}
+	logger := testlog.Logger(t, log.LevelDebug)
+
+	trace, err := TraceSafety(context.Background(), logger, &cfg, l1, nil, batcherAddr, blockRef(blocks[5]), 100, 103)
+	require.NoError(t, err)
+	require.Equal(t, l1.refs[102], trace.InclusionBlock)
+	require.Equal(t, co.ID(), trace.ChannelID)
+	require.True(t, trace.SpanBatch)
+	require.Equal(t, blocks[0].Time(), trace.BatchTimestamp)
+	require.Equal(t, 8, trace.BatchBlocks)
+	require.Len(t, trace.Frames, len(frames))
+	require.Equal(t, SafetyTraceFrame{TxHash: tx0.Hash(), L1Block: l1.refs[101].ID(), L1Time: l1.refs[101].Time, FrameNumber: 0}, trace.Frames[0])
+	require.True(t, trace.Frames[len(frames)-1].IsLast)
+
+	_, err = TraceSafety(context.Background(), logger, &cfg, l1, nil, batcherAddr, blockRef(blocks[9]), 100, 103)
+	require.ErrorIs(t, err, ErrBatchNotFound, "block is not in the channel")
+
+	_, err = TraceSafety(context.Background(), logger, &cfg, l1, nil, batcherAddr, blockRef(blocks[5]), 102, 103)
+	require.ErrorIs(t, err, ErrBatchNotFound, "channel is incomplete in the range")
+}
@@ -0,0 +1,47 @@
+package derive
+
+import (
+	"encoding/binary"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+const (
+	SequencerOfflineFuncSignature = "sequencerOffline(uint64,uint64)"
+	// sequencerOfflineDepositGas covers the intrinsic gas of the marker deposit, which calls an account without code.
+	sequencerOfflineDepositGas = 50_000
+)
+
+var SequencerOfflineFuncBytes4 = crypto.Keccak256([]byte(SequencerOfflineFuncSignature))[:4]
+
+// SequencerOfflineDepositBytes returns the sequencer-offline marker deposit of the L2 block with the given L1 origin
+// and sequence number. It is included in blocks that exceed the max sequencer drift,
+// if the chain uses the rollup.SequencerDriftOfflineMarker policy.
+// The marker is a call from the L1 info depositor account to itself, so it has no effect on the L2 state
+// other than the nonce of the depositor, and encodes the L1 origin number and the sequence number.
+func SequencerOfflineDepositBytes(l1Info eth.BlockInfo, seqNumber uint64) (hexutil.Bytes, error) {
+	source := SequencerOfflineDepositSource{
+		L1BlockHash: l1Info.Hash(),
+		SeqNumber:   seqNumber,
+	}
+	data := make([]byte, 4+32*2)
+	copy(data[:4], SequencerOfflineFuncBytes4)
+	binary.BigEndian.PutUint64(data[4+32-8:4+32], l1Info.NumberU64())
+	binary.BigEndian.PutUint64(data[4+32*2-8:], seqNumber)
+	to := L1InfoDepositerAddress
+	return types.NewTx(&types.DepositTx{
+		SourceHash:          source.SourceHash(),
+		From:                L1InfoDepositerAddress,
+		To:                  &to,
+		Mint:                nil,
+		Value:               big.NewInt(0),
+		Gas:                 sequencerOfflineDepositGas,
+		IsSystemTransaction: false,
+		Data:                data,
+	}).MarshalBinary()
+}
@@ -45,6 +45,10 @@ type SpanChannelOut struct {
 	// to seal full span batches (that have reached the max block count) in the rlp slices.
 	sealedRLPBytes int
 
+	// splitAtEpochs enables starting a new span batch at an epoch boundary, if the channel
+	// compresses smaller with the new span batch than by extending the current one.
+	splitAtEpochs bool
+
 	chainSpec *rollup.ChainSpec
 }
 
@@ -65,6 +69,14 @@ func WithMaxBlocksPerSpanBatch(maxBlock int) SpanChannelOutOption {
 	}
 }
 
+// WithSpanBatchSplitAtEpochs enables splitting span batches at epoch boundaries, when the split
+// improves the compression of the channel. Each split costs an extra compression of the channel.
+func WithSpanBatchSplitAtEpochs() SpanChannelOutOption {
+	return func(co *SpanChannelOut) {
+		co.splitAtEpochs = true
+	}
+}
+
 func NewSpanChannelOut(genesisTimestamp uint64, chainID *big.Int, targetOutputSize uint64, compressionAlgo CompressionAlgo, chainSpec *rollup.ChainSpec, opts ...SpanChannelOutOption) (*SpanChannelOut, error) {
 	c := &SpanChannelOut{
 		id:        ChannelID{},
@@ -153,6 +165,15 @@ func (co *SpanChannelOut) AddSingularBatch(batch *SingularBatch, seqNum uint64)
 	}
 
 	co.ensureOpenSpanBatch()
+	// the encoding of the channel if the batch starts a new span batch, to compare with extending the current one
+	var split []byte
+	var splitSpanBatch *SpanBatch
+	if co.splitAtEpochs && seqNum == 0 && co.spanBatch.GetBlockCount() > 0 {
+		var err error
+		if split, splitSpanBatch, err = co.encodeSplit(batch, seqNum); err != nil {
+			return err
+		}
+	}
 	// update the SpanBatch with the SingularBatch
 	if err := co.spanBatch.AppendSingularBatch(batch, seqNum); err != nil {
 		return fmt.Errorf("failed to append SingularBatch to SpanBatch: %w", err)
@@ -172,6 +193,11 @@ func (co *SpanChannelOut) AddSingularBatch(batch *SingularBatch, seqNum uint64)
 	if err = rlp.Encode(active, NewBatchData(rawSpanBatch)); err != nil {
 		return fmt.Errorf("failed to encode RawSpanBatch into bytes: %w", err)
 	}
+	if split != nil {
+		if err := co.splitIfSmaller(split, splitSpanBatch); err != nil {
+			return err
+		}
+	}
 
 	// Fjord increases the max RLP bytes per channel. Activation of this change in the derivation pipeline
 	// is dependent on the timestamp of the L1 block that this channel got included in. So using the timestamp
@@ -238,6 +264,63 @@ func (co *SpanChannelOut) ensureOpenSpanBatch() {
 	co.resetSpanBatch()
 }
 
+// encodeSplit encodes the channel with the current span batch sealed, and the batch in a new span batch.
+// The active RLP buffer must hold the encoding of the current span batch.
+func (co *SpanChannelOut) encodeSplit(batch *SingularBatch, seqNum uint64) ([]byte, *SpanBatch, error) {
+	spanBatch := NewSpanBatch(co.spanBatch.GenesisTimestamp, co.spanBatch.ChainID)
+	if err := spanBatch.AppendSingularBatch(batch, seqNum); err != nil {
+		return nil, nil, fmt.Errorf("failed to append SingularBatch to SpanBatch: %w", err)
+	}
+	rawSpanBatch, err := spanBatch.ToRawSpanBatch()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to convert SpanBatch into RawSpanBatch: %w", err)
+	}
+	split := bytes.NewBuffer(bytes.Clone(co.activeRLP().Bytes()))
+	if err := rlp.Encode(split, NewBatchData(rawSpanBatch)); err != nil {
+		return nil, nil, fmt.Errorf("failed to encode RawSpanBatch into bytes: %w", err)
+	}
+	return split.Bytes(), spanBatch, nil
+}
+
+// splitIfSmaller replaces the encoding in the active RLP buffer, which extends the current span batch,
+// with the split encoding if that compresses smaller. On a split, the previous span batch is sealed,
+// which the inactive RLP buffer holds the encoding of.
+func (co *SpanChannelOut) splitIfSmaller(split []byte, spanBatch *SpanBatch) error {
+	active := co.activeRLP()
+	extendedLen, err := co.compressedLen(active.Bytes())
+	if err != nil {
+		return err
+	}
+	splitLen, err := co.compressedLen(split)
+	if err != nil {
+		return err
+	}
+	// the compressor doesn't hold the compressed active RLP buffer anymore
+	co.compressor.Reset()
+	co.lastCompressedRLPSize = 0
+	if splitLen >= extendedLen {
+		return nil
+	}
+	co.sealedRLPBytes = co.inactiveRLP().Len()
+	co.spanBatch = spanBatch
+	active.Reset()
+	// err is guaranteed to always be nil
+	_, _ = active.Write(split)
+	return nil
+}
+
+// compressedLen returns the compressed size of the data, using the channel's compressor.
+func (co *SpanChannelOut) compressedLen(data []byte) (int, error) {
+	co.compressor.Reset()
+	if _, err := co.compressor.Write(data); err != nil {
+		return 0, err
+	}
+	if err := co.compressor.Close(); err != nil {
+		return 0, err
+	}
+	return co.compressor.Len(), nil
+}
+
 // compress compresses the active RLP buffer and checks if the compressed data is over the target size.
 // it resets all the compression buffers because Span Batches aren't meant to be compressed incrementally.
 func (co *SpanChannelOut) compress() error {
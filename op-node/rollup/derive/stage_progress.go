@@ -0,0 +1,126 @@
+package derive
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// Names of the derivation pipeline stages, as used in metrics and logs.
+const (
+	StageL1Retrieval     = "l1_retrieval"
+	StageFrameQueue      = "frame_queue"
+	StageChannelBank     = "channel_bank"
+	StageBatchQueue      = "batch_queue"
+	StageAttributesQueue = "attributes_queue"
+)
+
+// StageStats describes the progress of a derivation pipeline stage.
+type StageStats struct {
+	// ItemsIn is the number of items the stage took from the previous stage.
+	ItemsIn uint64
+	// ItemsOut is the number of items the stage provided to the next stage.
+	ItemsOut uint64
+	// BufferedBytes is the approximate size of the data buffered by the stage.
+	BufferedBytes uint64
+}
+
+// StatsProvider is a pipeline stage that reports its progress.
+type StatsProvider interface {
+	Stats() StageStats
+}
+
+// stageCounters counts the items that went in and out of a stage. The counters are not reset when the stage is.
+type stageCounters struct {
+	itemsIn  uint64
+	itemsOut uint64
+}
+
+// stageProgress tracks the progress of a single stage, between steps of the pipeline.
+type stageProgress struct {
+	name  string
+	stage StatsProvider
+
+	last        StageStats
+	lastAdvance time.Time
+	stalled     bool
+}
+
+// stageTracker records the progress of the pipeline stages to the metrics,
+// and detects stages that did not provide any items to the next stage for longer than the stall timeout.
+type stageTracker struct {
+	log     log.Logger
+	metrics Metrics
+	now     func() time.Time
+
+	// stallTimeout is the duration after which a stage that did not advance is considered stalled.
+	// Stall detection is disabled if 0.
+	stallTimeout time.Duration
+
+	stages []*stageProgress
+}
+
+func newStageTracker(log log.Logger, metrics Metrics) *stageTracker {
+	return &stageTracker{
+		log:     log,
+		metrics: metrics,
+		now:     time.Now,
+	}
+}
+
+func (t *stageTracker) add(name string, stage StatsProvider) {
+	t.stages = append(t.stages, &stageProgress{name: name, stage: stage})
+}
+
+// record records the progress of all stages since the previous call, and checks for stalled stages.
+func (t *stageTracker) record() {
+	now := t.now()
+	for _, p := range t.stages {
+		stats := p.stage.Stats()
+		if stats.ItemsIn != p.last.ItemsIn || stats.ItemsOut != p.last.ItemsOut {
+			t.metrics.RecordDerivationStageItems(p.name, stats.ItemsIn-p.last.ItemsIn, stats.ItemsOut-p.last.ItemsOut)
+		}
+		if stats.ItemsOut != p.last.ItemsOut || p.lastAdvance.IsZero() {
+			if p.stalled {
+				t.log.Info("Derivation stage advanced again", "stage", p.name, "stalled_for", now.Sub(p.lastAdvance))
+				p.stalled = false
+			}
+			p.lastAdvance = now
+			t.metrics.RecordDerivationStageAdvance(p.name, now)
+		}
+		t.metrics.RecordDerivationStageBufferedBytes(p.name, stats.BufferedBytes)
+		p.last = stats
+
+		if t.stallTimeout != 0 && !p.stalled && now.Sub(p.lastAdvance) >= t.stallTimeout {
+			p.stalled = true
+			t.log.Warn("Derivation stage stalled", "stage", p.name, "last_advance", p.lastAdvance,
+				"items_in", stats.ItemsIn, "items_out", stats.ItemsOut, "buffered_bytes", stats.BufferedBytes)
+			t.metrics.RecordDerivationStageStall(p.name)
+		}
+	}
+}
+
+// reset restarts the stall timeout of all stages, as stages do not advance while the pipeline is being reset.
+func (t *stageTracker) reset() {
+	now := t.now()
+	for _, p := range t.stages {
+		p.lastAdvance = now
+		p.stalled = false
+	}
+}
+
+// batchTxBytes returns the size of the transactions of the batch.
+func batchTxBytes(batch Batch) (size uint64) {
+	if singular, ok := batch.AsSingularBatch(); ok {
+		for _, tx := range singular.Transactions {
+			size += uint64(len(tx))
+		}
+	} else if span, ok := batch.AsSpanBatch(); ok {
+		for _, elem := range span.Batches {
+			for _, tx := range elem.Transactions {
+				size += uint64(len(tx))
+			}
+		}
+	}
+	return size
+}
@@ -0,0 +1,160 @@
+package derive
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+type stageMetrics struct {
+	testutils.TestDerivationMetrics
+	itemsIn  map[string]uint64
+	itemsOut map[string]uint64
+	buffered map[string]uint64
+	stalls   map[string]int
+}
+
+func newStageMetrics() *stageMetrics {
+	return &stageMetrics{
+		itemsIn:  make(map[string]uint64),
+		itemsOut: make(map[string]uint64),
+		buffered: make(map[string]uint64),
+		stalls:   make(map[string]int),
+	}
+}
+
+func (m *stageMetrics) RecordDerivationStageItems(stage string, itemsIn uint64, itemsOut uint64) {
+	m.itemsIn[stage] += itemsIn
+	m.itemsOut[stage] += itemsOut
+}
+
+func (m *stageMetrics) RecordDerivationStageBufferedBytes(stage string, bytes uint64) {
+	m.buffered[stage] = bytes
+}
+
+func (m *stageMetrics) RecordDerivationStageStall(stage string) {
+	m.stalls[stage]++
+}
+
+type fixedStats struct {
+	stats StageStats
+}
+
+func (s *fixedStats) Stats() StageStats {
+	return s.stats
+}
+
+func TestStageTracker(t *testing.T) {
+	m := newStageMetrics()
+	tracker := newStageTracker(testlog.Logger(t, log.LevelInfo), m)
+	now := time.Unix(1000, 0)
+	tracker.now = func() time.Time { return now }
+	tracker.stallTimeout = time.Minute
+
+	first, second := &fixedStats{}, &fixedStats{}
+	tracker.add("first", first)
+	tracker.add("second", second)
+	tracker.record()
+
+	first.stats = StageStats{ItemsIn: 3, ItemsOut: 2, BufferedBytes: 100}
+	second.stats = StageStats{ItemsIn: 2, BufferedBytes: 50}
+	now = now.Add(30 * time.Second)
+	tracker.record()
+	require.Equal(t, uint64(3), m.itemsIn["first"])
+	require.Equal(t, uint64(2), m.itemsOut["first"])
+	require.Equal(t, uint64(100), m.buffered["first"])
+	require.Equal(t, uint64(2), m.itemsIn["second"])
+	require.Equal(t, uint64(50), m.buffered["second"])
+
+	// The second stage took items, but did not provide any for the stall timeout
+	now = now.Add(30 * time.Second)
+	tracker.record()
+	require.Zero(t, m.stalls["first"])
+	require.Equal(t, 1, m.stalls["second"])
+
+	// A stall is only counted once
+	now = now.Add(time.Minute)
+	tracker.record()
+	require.Equal(t, 1, m.stalls["first"])
+	require.Equal(t, 1, m.stalls["second"])
+
+	// Advancing clears the stall
+	second.stats.ItemsOut = 1
+	tracker.record()
+	now = now.Add(59 * time.Second)
+	tracker.record()
+	require.Equal(t, 1, m.stalls["second"])
+	now = now.Add(time.Second)
+	tracker.record()
+	require.Equal(t, 2, m.stalls["second"])
+
+	// Resets restart the stall timeout
+	tracker.reset()
+	now = now.Add(59 * time.Second)
+	tracker.record()
+	require.Equal(t, 1, m.stalls["first"])
+	require.Equal(t, 2, m.stalls["second"])
+}
+
+func TestStageTrackerDisabled(t *testing.T) {
+	m := newStageMetrics()
+	tracker := newStageTracker(testlog.Logger(t, log.LevelInfo), m)
+	now := time.Unix(1000, 0)
+	tracker.now = func() time.Time { return now }
+	stage := &fixedStats{}
+	tracker.add("stage", stage)
+	tracker.record()
+	now = now.Add(24 * time.Hour)
+	tracker.record()
+	require.Zero(t, m.stalls["stage"])
+}
+
+type dataList struct {
+	data [][]byte
+}
+
+func (d *dataList) NextData(_ context.Context) ([]byte, error) {
+	if len(d.data) == 0 {
+		return nil, io.EOF
+	}
+	next := d.data[0]
+	d.data = d.data[1:]
+	return next, nil
+}
+
+func (d *dataList) Origin() eth.L1BlockRef {
+	return eth.L1BlockRef{}
+}
+
+func TestFrameQueueStats(t *testing.T) {
+	frames := []Frame{
+		{ID: ChannelID{0x01}, FrameNumber: 0, Data: make([]byte, 10)},
+		{ID: ChannelID{0x01}, FrameNumber: 1, Data: make([]byte, 20), IsLast: true},
+	}
+	var data bytes.Buffer
+	data.WriteByte(DerivationVersion0)
+	for _, f := range frames {
+		require.NoError(t, f.MarshalBinary(&data))
+	}
+	fq := NewFrameQueue(testlog.Logger(t, log.LevelInfo), &dataList{data: [][]byte{data.Bytes()}})
+
+	frame, err := fq.NextFrame(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, frames[0], frame)
+	require.Equal(t, StageStats{ItemsIn: 1, ItemsOut: 1, BufferedBytes: 20}, fq.Stats())
+
+	_, err = fq.NextFrame(context.Background())
+	require.NoError(t, err)
+	_, err = fq.NextFrame(context.Background())
+	require.ErrorIs(t, err, io.EOF)
+	require.Equal(t, StageStats{ItemsIn: 1, ItemsOut: 2}, fq.Stats())
+}
@@ -14,6 +14,8 @@ const (
 	Brotli9  CompressionAlgo = "brotli-9"
 	Brotli10 CompressionAlgo = "brotli-10"
 	Brotli11 CompressionAlgo = "brotli-11"
+	// Zstd is experimental, and only accepted once zstd channel compression is activated in the rollup config.
+	Zstd CompressionAlgo = "zstd"
 )
 
 var CompressionAlgos = []CompressionAlgo{
@@ -22,6 +24,7 @@ var CompressionAlgos = []CompressionAlgo{
 	Brotli9,
 	Brotli10,
 	Brotli11,
+	Zstd,
 }
 
 var brotliRegexp = regexp.MustCompile(`^brotli(|-(9|10|11))$`)
@@ -47,6 +50,10 @@ func (algo *CompressionAlgo) IsBrotli() bool {
 	return brotliRegexp.MatchString(algo.String())
 }
 
+func (algo *CompressionAlgo) IsZstd() bool {
+	return *algo == Zstd
+}
+
 func GetBrotliLevel(algo CompressionAlgo) int {
 	switch algo {
 	case Brotli9:
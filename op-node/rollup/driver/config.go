@@ -1,9 +1,36 @@
 package driver
 
+import (
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/sequencing"
+)
+
 type Config struct {
 	// VerifierConfDepth is the distance to keep from the L1 head when reading L1 data for L2 derivation.
 	VerifierConfDepth uint64 `json:"verifier_conf_depth"`
 
+	// L1ConfirmationDepth is the number of blocks after which L1 blocks are considered confirmed,
+	// regardless of the L1 safe label. Derivation only reads confirmed L1 blocks, and caches them by number.
+	// Disabled if 0.
+	L1ConfirmationDepth uint64 `json:"l1_confirmation_depth"`
+
+	// VerifierStallTimeout is the duration after which a derivation pipeline stage that did not advance
+	// is considered stalled. Stall detection is disabled if 0.
+	VerifierStallTimeout time.Duration `json:"verifier_stall_timeout"`
+
+	// EventTraceSize is the number of most recent event-system trace entries kept to debug stalls,
+	// served together with the queued events by the optimism_eventTrace RPC. Disabled if 0.
+	EventTraceSize int `json:"event_trace_size"`
+
+	// EventLatencyBudget is the duration after which the processing of an event by a deriver is logged as slow.
+	// Disabled if 0.
+	EventLatencyBudget time.Duration `json:"event_latency_budget"`
+
+	// EventDeadlockTimeout is the duration after which a deriver that is still processing an event
+	// is logged as potentially deadlocked. Disabled if 0.
+	EventDeadlockTimeout time.Duration `json:"event_deadlock_timeout"`
+
 	// SequencerConfDepth is the distance to keep from the L1 head as origin when sequencing new L2 blocks.
 	// If this distance is too large, the sequencer may:
 	// - not adopt a L1 origin within the allowed time (rollup.Config.MaxSequencerDrift)
@@ -20,4 +47,22 @@ type Config struct {
 	// SequencerMaxSafeLag is the maximum number of L2 blocks for restricting the distance between L2 safe and unsafe.
 	// Disabled if 0.
 	SequencerMaxSafeLag uint64 `json:"sequencer_max_safe_lag"`
+
+	// SequencerThrottle configures throttling of block production when the execution engine falls behind.
+	SequencerThrottle sequencing.ThrottleConfig `json:"sequencer_throttle"`
+
+	// InteropDependencySet is the set of chain IDs that executing messages in L2 blocks may reference.
+	// Messages are checked against it before blocks are checked with the supervisor. Unrestricted if empty.
+	InteropDependencySet []uint64 `json:"interop_dependency_set"`
+}
+
+// EventTracing returns true if the event system is traced for debugging.
+func (c *Config) EventTracing() bool {
+	return c.EventTraceSize > 0 || c.EventLatencyBudget > 0 || c.EventDeadlockTimeout > 0
+}
+
+// DerivationConfDepth is the distance to keep from the L1 head when reading L1 data for L2 derivation,
+// which is the largest of the verifier and L1 confirmation depths.
+func (c *Config) DerivationConfDepth() uint64 {
+	return max(c.VerifierConfDepth, c.L1ConfirmationDepth)
 }
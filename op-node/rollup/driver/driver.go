@@ -2,8 +2,11 @@ package driver
 
 import (
 	"context"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	gethevent "github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
 
 	altda "github.com/ethereum-optimism/optimism/op-alt-da"
@@ -30,6 +33,10 @@ var (
 	ErrSequencerAlreadyStopped = sequencing.ErrSequencerAlreadyStopped
 )
 
+// l1ConfirmedCacheSize is the number of confirmed L1 blocks cached by number for derivation,
+// when an L1 confirmation depth is configured.
+const l1ConfirmedCacheSize = 1000
+
 type Metrics interface {
 	RecordPipelineReset()
 	RecordPublishingError()
@@ -45,6 +52,11 @@ type Metrics interface {
 	RecordFrame()
 
 	RecordDerivedBatches(batchType string)
+	RecordBatchDrop(reason string)
+	RecordDerivationStageItems(stage string, itemsIn uint64, itemsOut uint64)
+	RecordDerivationStageBufferedBytes(stage string, bytes uint64)
+	RecordDerivationStageAdvance(stage string, t time.Time)
+	RecordDerivationStageStall(stage string)
 
 	RecordUnsafePayloadsBuffer(length uint64, memSize uint64, next eth.BlockID)
 
@@ -68,6 +80,7 @@ type L2Chain interface {
 	L2BlockRefByLabel(ctx context.Context, label eth.BlockLabel) (eth.L2BlockRef, error)
 	L2BlockRefByHash(ctx context.Context, l2Hash common.Hash) (eth.L2BlockRef, error)
 	L2BlockRefByNumber(ctx context.Context, num uint64) (eth.L2BlockRef, error)
+	FetchReceipts(ctx context.Context, blockHash common.Hash) (eth.BlockInfo, types.Receipts, error)
 }
 
 type DerivationPipeline interface {
@@ -76,6 +89,7 @@ type DerivationPipeline interface {
 	Origin() eth.L1BlockRef
 	DerivationReady() bool
 	ConfirmEngineReset()
+	BatchDrops() derive.BatchDropReport
 }
 
 type EngineController interface {
@@ -119,6 +133,7 @@ type SyncStatusTracker interface {
 	event.Deriver
 	SyncStatus() *eth.SyncStatus
 	L1Head() eth.L1BlockRef
+	SubscribeHeadUpdates(ch chan<- eth.HeadUpdate) gethevent.Subscription
 }
 
 type Network interface {
@@ -172,22 +187,31 @@ func NewDriver(
 
 	var executor event.Executor
 	var drain func() error
+	var queued func() []event.AnnotatedEvent
 	// This instantiation will be one of more options: soon there will be a parallel events executor
 	{
 		s := event.NewGlobalSynchronous(driverCtx)
 		executor = s
 		drain = s.Drain
+		queued = s.Queued
 	}
 	sys := event.NewSystem(log, executor)
 	sys.AddTracer(event.NewMetricsTracer(metrics))
 
+	var eventTracer *event.DebugTracer
+	if driverCfg.EventTracing() {
+		eventTracer = event.NewDebugTracer(log, driverCfg.EventTraceSize,
+			driverCfg.EventLatencyBudget, driverCfg.EventDeadlockTimeout, queued)
+		sys.AddTracer(eventTracer)
+	}
+
 	opts := event.DefaultRegisterOpts()
 
 	// If interop is scheduled we start the driver.
 	// It will then be ready to pick up verification work
 	// as soon as we reach the upgrade time (if the upgrade is not already active).
 	if cfg.InteropTime != nil {
-		interopDeriver := interop.NewInteropDeriver(log, cfg, driverCtx, supervisor, l2)
+		interopDeriver := interop.NewInteropDeriver(log, cfg, driverCtx, supervisor, l2, interop.NewDependencySet(driverCfg.InteropDependencySet))
 		sys.Register("interop", interopDeriver, opts)
 	}
 
@@ -198,7 +222,12 @@ func NewDriver(
 	sys.Register("l1-blocks", l1Tracker, opts)
 
 	l1 = NewMeteredL1Fetcher(l1Tracker, metrics)
-	verifConfDepth := confdepth.NewConfDepth(driverCfg.VerifierConfDepth, statusTracker.L1Head, l1)
+	var verifConfDepth derive.L1Fetcher
+	if driverCfg.L1ConfirmationDepth > 0 {
+		verifConfDepth = confdepth.NewCachedConfDepth(driverCfg.DerivationConfDepth(), l1ConfirmedCacheSize, statusTracker.L1Head, l1)
+	} else {
+		verifConfDepth = confdepth.NewConfDepth(driverCfg.VerifierConfDepth, statusTracker.L1Head, l1)
+	}
 
 	ec := engine.NewEngineController(l2, log, metrics, cfg, syncCfg,
 		sys.Register("engine-controller", nil, opts))
@@ -217,10 +246,11 @@ func NewDriver(
 	}
 	sys.Register("finalizer", finalizer, opts)
 
-	sys.Register("attributes-handler",
-		attributes.NewAttributesHandler(log, cfg, driverCtx, l2), opts)
+	attrHandler := attributes.NewAttributesHandler(log, cfg, driverCtx, l2)
+	sys.Register("attributes-handler", attrHandler, opts)
 
 	derivationPipeline := derive.NewDerivationPipeline(log, cfg, verifConfDepth, l1Blobs, altDA, l2, metrics)
+	derivationPipeline.SetStallTimeout(driverCfg.VerifierStallTimeout)
 
 	sys.Register("pipeline",
 		derive.NewPipelineDeriver(driverCtx, derivationPipeline), opts)
@@ -251,8 +281,10 @@ func NewDriver(
 		attrBuilder := derive.NewFetchingAttributesBuilder(cfg, l1, l2)
 		sequencerConfDepth := confdepth.NewConfDepth(driverCfg.SequencerConfDepth, statusTracker.L1Head, l1)
 		findL1Origin := sequencing.NewL1OriginSelector(log, cfg, sequencerConfDepth)
-		sequencer = sequencing.NewSequencer(driverCtx, log, cfg, attrBuilder, findL1Origin,
+		seq := sequencing.NewSequencer(driverCtx, log, cfg, attrBuilder, findL1Origin,
 			sequencerStateListener, sequencerConductor, asyncGossiper, metrics)
+		seq.SetThrottle(driverCfg.SequencerThrottle)
+		sequencer = seq
 		sys.Register("sequencer", sequencer, opts)
 	} else {
 		sequencer = sequencing.DisabledSequencer{}
@@ -261,13 +293,17 @@ func NewDriver(
 	driverEmitter := sys.Register("driver", nil, opts)
 	driver := &Driver{
 		eventSys:         sys,
+		eventTracer:      eventTracer,
 		statusTracker:    statusTracker,
 		SyncDeriver:      syncDeriver,
+		attrHandler:      attrHandler,
 		sched:            schedDeriv,
 		emitter:          driverEmitter,
 		drain:            drain,
 		stateReq:         make(chan chan struct{}),
 		forceReset:       make(chan chan struct{}, 10),
+		forceResetTo:     make(chan resetToRequest, 10),
+		engineReset:      make(chan struct{}, 1),
 		driverConfig:     driverCfg,
 		driverCtx:        driverCtx,
 		driverCancel:     driverCancel,
@@ -8,9 +8,11 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	gethevent "github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/attributes"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/clsync"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
@@ -28,10 +30,15 @@ type SyncStatus = eth.SyncStatus
 type Driver struct {
 	eventSys event.System
 
+	// eventTracer traces the event system for debugging. Nil if event tracing is disabled.
+	eventTracer *event.DebugTracer
+
 	statusTracker SyncStatusTracker
 
 	*SyncDeriver
 
+	attrHandler *attributes.AttributesHandler
+
 	sched *StepSchedulingDeriver
 
 	emitter event.Emitter
@@ -44,6 +51,14 @@ type Driver struct {
 	// It tells the caller that the reset occurred by closing the passed in channel.
 	forceReset chan chan struct{}
 
+	// Upon receiving a request in this channel, the forkchoice state is rewound to the requested L2 block,
+	// and the derivation pipeline is reset to re-derive the chain from there.
+	forceResetTo chan resetToRequest
+
+	// Upon receiving a signal in this channel, the engine state is reset:
+	// the forkchoice state is re-synced from the heads of the (new) execution engine.
+	engineReset chan struct{}
+
 	// Driver config: verifier and sequencer settings.
 	// May not be modified after starting the Driver.
 	driverConfig *Config
@@ -94,6 +109,14 @@ func (s *Driver) Start() error {
 	s.wg.Add(1)
 	go s.eventLoop()
 
+	if s.eventTracer != nil {
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.eventTracer.WatchDeadlocks(s.driverCtx)
+		}()
+	}
+
 	return nil
 }
 
@@ -248,7 +271,9 @@ func (s *Driver) eventLoop() {
 					s.log.Info("Failed to turn execution payload into a block ref", "id", envelope.ExecutionPayload.ID(), "err", err)
 					continue
 				}
-				if ref.Number <= s.Engine.UnsafeL2Head().Number {
+				// Re-inserting the current unsafe head is allowed, to check if the engine finished syncing to it.
+				if unsafeHead := s.Engine.UnsafeL2Head(); ref.Number < unsafeHead.Number ||
+					(ref.Number == unsafeHead.Number && ref.Hash != unsafeHead.Hash) {
 					continue
 				}
 				s.log.Info("Optimistically inserting unsafe L2 execution payload to drive EL sync", "id", envelope.ExecutionPayload.ID())
@@ -276,6 +301,11 @@ func (s *Driver) eventLoop() {
 			s.Derivation.Reset()
 			s.metrics.RecordPipelineReset()
 			close(respCh)
+		case req := <-s.forceResetTo:
+			req.result <- s.resetDerivationTo(req.number)
+		case <-s.engineReset:
+			s.log.Warn("Execution engine changed, resetting engine state")
+			s.emitter.Emit(rollup.ResetEvent{Err: errEngineChanged})
 		case <-s.driverCtx.Done():
 			return
 		}
@@ -491,6 +521,73 @@ func (s *Driver) ResetDerivationPipeline(ctx context.Context) error {
 	}
 }
 
+var errEngineChanged = errors.New("execution engine changed")
+
+// ResetEngine requests the engine state to be re-synced from the heads of the execution engine,
+// e.g. after failing over to another execution engine. It does not block,
+// and multiple requests while a reset is pending are coalesced.
+func (s *Driver) ResetEngine() {
+	select {
+	case s.engineReset <- struct{}{}:
+	default:
+	}
+}
+
+type resetToRequest struct {
+	number uint64
+	result chan error
+}
+
+// ResetDerivationPipelineTo rewinds the forkchoice state to the given L2 block, which must not be ahead of the safe head,
+// and resets the derivation pipeline to re-derive the chain forward from there.
+// It waits for the reset to occur, and is not supported while the sequencer is active.
+func (s *Driver) ResetDerivationPipelineTo(ctx context.Context, num uint64) error {
+	req := resetToRequest{number: num, result: make(chan error, 1)}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case s.forceResetTo <- req:
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-req.result:
+			return err
+		}
+	}
+}
+
+// resetDerivationTo handles a ResetDerivationPipelineTo request. It must be called from the event loop.
+func (s *Driver) resetDerivationTo(num uint64) error {
+	if s.sequencer.Active() {
+		return errors.New("cannot reset derivation to a block while the sequencer is active")
+	}
+	if num < s.Config.Genesis.L2.Number {
+		return fmt.Errorf("cannot reset derivation to block %d before genesis block %d", num, s.Config.Genesis.L2.Number)
+	}
+	safe := s.Engine.SafeL2Head()
+	if num > safe.Number {
+		return fmt.Errorf("cannot reset derivation to block %d ahead of safe head %s", num, safe)
+	}
+	ref, err := s.L2.L2BlockRefByNumber(s.driverCtx, num)
+	if err != nil {
+		return fmt.Errorf("failed to fetch L2 block %d to reset to: %w", num, err)
+	}
+	finalized := s.Engine.Finalized()
+	if finalized.Number > ref.Number {
+		finalized = ref
+	}
+	s.log.Warn("Derivation pipeline is manually reset to L2 block", "block", ref,
+		"prev_unsafe", s.Engine.UnsafeL2Head(), "prev_safe", safe, "prev_finalized", s.Engine.Finalized())
+	s.Derivation.Reset()
+	s.metrics.RecordPipelineReset()
+	s.emitter.Emit(engine.ForceEngineResetEvent{
+		Unsafe:    ref,
+		Safe:      ref,
+		Finalized: finalized,
+	})
+	return nil
+}
+
 func (s *Driver) StartSequencer(ctx context.Context, blockHash common.Hash) error {
 	return s.sequencer.Start(ctx, blockHash)
 }
@@ -507,11 +604,42 @@ func (s *Driver) OverrideLeader(ctx context.Context) error {
 	return s.sequencer.OverrideLeader(ctx)
 }
 
+func (s *Driver) SubmitBuilderPayload(ctx context.Context, envelope *eth.ExecutionPayloadEnvelope) error {
+	return s.sequencer.SubmitBuilderPayload(ctx, envelope)
+}
+
 // SyncStatus blocks the driver event loop and captures the syncing status.
 func (s *Driver) SyncStatus(ctx context.Context) (*eth.SyncStatus, error) {
 	return s.statusTracker.SyncStatus(), nil
 }
 
+// BatchDrops reports the batches dropped by the derivation pipeline since the node started.
+// The report is captured without blocking the driver event loop.
+func (s *Driver) BatchDrops(ctx context.Context) (*derive.BatchDropReport, error) {
+	report := s.Derivation.BatchDrops()
+	return &report, nil
+}
+
+// AttributesMismatches reports the most recent derived attributes that did not match the existing unsafe block.
+// The mismatches are captured without blocking the driver event loop.
+func (s *Driver) AttributesMismatches(ctx context.Context) ([]attributes.AttributesMismatch, error) {
+	return s.attrHandler.Mismatches(), nil
+}
+
+// EventTrace dumps the recent events of the event system, the events being processed and the queued events.
+// The dump is captured without blocking the driver event loop, so it can be used while the event loop is stalled.
+func (s *Driver) EventTrace(ctx context.Context) (*event.TraceDump, error) {
+	if s.eventTracer == nil {
+		return nil, errors.New("event tracing is disabled")
+	}
+	return s.eventTracer.Dump(), nil
+}
+
+// SubscribeHeadUpdates subscribes to updates of the unsafe, safe and finalized L2 heads.
+func (s *Driver) SubscribeHeadUpdates(ch chan<- eth.HeadUpdate) gethevent.Subscription {
+	return s.statusTracker.SubscribeHeadUpdates(ch)
+}
+
 // BlockRefWithStatus blocks the driver event loop and captures the syncing status,
 // along with an L2 block reference by number consistent with that same status.
 // If the event loop is too busy and the context expires, a context error is returned.
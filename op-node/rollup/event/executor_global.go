@@ -62,6 +62,13 @@ func (gs *GlobalSyncExec) Enqueue(ev AnnotatedEvent) error {
 	return nil
 }
 
+// Queued returns a copy of the events that are queued up to be processed, in processing order.
+func (gs *GlobalSyncExec) Queued() []AnnotatedEvent {
+	gs.eventsLock.Lock()
+	defer gs.eventsLock.Unlock()
+	return slices.Clone(gs.events)
+}
+
 func (gs *GlobalSyncExec) pop() AnnotatedEvent {
 	gs.eventsLock.Lock()
 	defer gs.eventsLock.Unlock()
@@ -0,0 +1,237 @@
+package event
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// ActiveDerivation is the processing of an event by a deriver that has started but not yet ended.
+type ActiveDerivation struct {
+	Name         string        `json:"name"`
+	EventName    string        `json:"event"`
+	EmitContext  uint64        `json:"emitContext"`
+	DerivContext uint64        `json:"derivContext"`
+	StartTime    time.Time     `json:"startTime"`
+	Elapsed      time.Duration `json:"elapsed"`
+
+	// reported is set once the derivation has been reported as potentially deadlocked.
+	reported bool
+}
+
+// QueuedEvent is an event that was emitted but is not yet processed by the executor.
+type QueuedEvent struct {
+	EventName   string `json:"event"`
+	EmitContext uint64 `json:"emitContext"`
+}
+
+// TraceDump is a snapshot of the event system, to debug stalls.
+type TraceDump struct {
+	// Entries are the most recent trace entries, oldest first.
+	Entries []TraceEntry `json:"entries"`
+	// Active are the derivations that are in progress, oldest first.
+	Active []ActiveDerivation `json:"active"`
+	// Queue is the events waiting to be processed, in processing order.
+	Queue []QueuedEvent `json:"queue"`
+	// SlowDerivations is the number of derivations, per deriver, that exceeded the latency budget.
+	SlowDerivations map[string]uint64 `json:"slowDerivations"`
+}
+
+// DebugTracer keeps the most recent trace entries, so they can be dumped together with the queued events
+// when debugging stalls of the event system.
+// Derivations that exceed the latency budget are logged as slow. Derivations that have not ended within
+// the deadlock timeout are logged by WatchDeadlocks, as the deriver may be blocked forever.
+type DebugTracer struct {
+	log             log.Logger
+	latencyBudget   time.Duration
+	deadlockTimeout time.Duration
+	// queued returns the events waiting to be processed. May be nil.
+	queued func() []AnnotatedEvent
+
+	l       sync.Mutex
+	entries []TraceEntry
+	// next is the index in entries to write the next entry to, once entries is at capacity.
+	next   int
+	active map[uint64]*ActiveDerivation
+	slow   map[string]uint64
+}
+
+var _ Tracer = (*DebugTracer)(nil)
+
+// NewDebugTracer creates a DebugTracer that keeps up to size trace entries.
+// The latency budget and deadlock timeout are disabled if 0.
+// queued optionally provides the events waiting to be processed, to include in dumps.
+func NewDebugTracer(log log.Logger, size int, latencyBudget time.Duration, deadlockTimeout time.Duration, queued func() []AnnotatedEvent) *DebugTracer {
+	return &DebugTracer{
+		log:             log,
+		latencyBudget:   latencyBudget,
+		deadlockTimeout: deadlockTimeout,
+		queued:          queued,
+		entries:         make([]TraceEntry, 0, size),
+		active:          make(map[uint64]*ActiveDerivation),
+		slow:            make(map[string]uint64),
+	}
+}
+
+// record adds the entry, overwriting the oldest entry once at capacity. The lock must be held.
+func (dt *DebugTracer) record(entry TraceEntry) {
+	if cap(dt.entries) == 0 {
+		return
+	}
+	if len(dt.entries) < cap(dt.entries) {
+		dt.entries = append(dt.entries, entry)
+		return
+	}
+	dt.entries[dt.next] = entry
+	dt.next = (dt.next + 1) % len(dt.entries)
+}
+
+func (dt *DebugTracer) OnDeriveStart(name string, ev AnnotatedEvent, derivContext uint64, startTime time.Time) {
+	dt.l.Lock()
+	defer dt.l.Unlock()
+	dt.active[derivContext] = &ActiveDerivation{
+		Name:         name,
+		EventName:    ev.Event.String(),
+		EmitContext:  ev.EmitContext,
+		DerivContext: derivContext,
+		StartTime:    startTime,
+	}
+	dt.record(TraceEntry{
+		Kind:         TraceDeriveStart,
+		Name:         name,
+		EventName:    ev.Event.String(),
+		EmitContext:  ev.EmitContext,
+		DerivContext: derivContext,
+		EventTime:    startTime,
+	})
+}
+
+func (dt *DebugTracer) OnDeriveEnd(name string, ev AnnotatedEvent, derivContext uint64, startTime time.Time, duration time.Duration, effect bool) {
+	dt.l.Lock()
+	defer dt.l.Unlock()
+	delete(dt.active, derivContext)
+	entry := TraceEntry{
+		Kind:         TraceDeriveEnd,
+		Name:         name,
+		EventName:    ev.Event.String(),
+		EmitContext:  ev.EmitContext,
+		DerivContext: derivContext,
+		EventTime:    startTime,
+	}
+	entry.DeriveEnd.Duration = duration
+	entry.DeriveEnd.Effect = effect
+	dt.record(entry)
+	if dt.latencyBudget > 0 && duration > dt.latencyBudget {
+		dt.slow[name]++
+		dt.log.Warn("Event deriver exceeded latency budget", "deriver", name, "event", ev.Event,
+			"duration", duration, "budget", dt.latencyBudget, "deriv_context", derivContext)
+	}
+}
+
+func (dt *DebugTracer) OnRateLimited(name string, derivContext uint64) {
+	dt.l.Lock()
+	defer dt.l.Unlock()
+	dt.record(TraceEntry{
+		Kind:         TraceRateLimited,
+		Name:         name,
+		DerivContext: derivContext,
+	})
+}
+
+func (dt *DebugTracer) OnEmit(name string, ev AnnotatedEvent, derivContext uint64, emitTime time.Time) {
+	dt.l.Lock()
+	defer dt.l.Unlock()
+	dt.record(TraceEntry{
+		Kind:         TraceEmit,
+		Name:         name,
+		EventName:    ev.Event.String(),
+		EmitContext:  ev.EmitContext,
+		DerivContext: derivContext,
+		EventTime:    emitTime,
+	})
+}
+
+// Dump returns a snapshot of the recent trace entries, the active derivations and the queued events.
+// It does not depend on the event system making progress, so it can be used while the system is stalled.
+func (dt *DebugTracer) Dump() *TraceDump {
+	queue := dt.queuedEvents()
+	dt.l.Lock()
+	defer dt.l.Unlock()
+	dump := &TraceDump{
+		Entries:         make([]TraceEntry, 0, len(dt.entries)),
+		Active:          dt.activeDerivations(time.Now()),
+		Queue:           queue,
+		SlowDerivations: make(map[string]uint64, len(dt.slow)),
+	}
+	dump.Entries = append(dump.Entries, dt.entries[dt.next:]...)
+	dump.Entries = append(dump.Entries, dt.entries[:dt.next]...)
+	for name, count := range dt.slow {
+		dump.SlowDerivations[name] = count
+	}
+	return dump
+}
+
+func (dt *DebugTracer) queuedEvents() []QueuedEvent {
+	if dt.queued == nil {
+		return nil
+	}
+	events := dt.queued()
+	queue := make([]QueuedEvent, 0, len(events))
+	for _, ev := range events {
+		queue = append(queue, QueuedEvent{EventName: ev.Event.String(), EmitContext: ev.EmitContext})
+	}
+	return queue
+}
+
+// activeDerivations returns a copy of the active derivations, oldest first. The lock must be held.
+func (dt *DebugTracer) activeDerivations(now time.Time) []ActiveDerivation {
+	active := make([]ActiveDerivation, 0, len(dt.active))
+	for _, a := range dt.active {
+		v := *a
+		v.Elapsed = now.Sub(v.StartTime)
+		active = append(active, v)
+	}
+	slices.SortFunc(active, func(a, b ActiveDerivation) int {
+		return cmp.Compare(a.DerivContext, b.DerivContext)
+	})
+	return active
+}
+
+// WatchDeadlocks periodically checks for derivations that have not ended within the deadlock timeout,
+// until the context is done. It returns immediately if the deadlock timeout is disabled.
+func (dt *DebugTracer) WatchDeadlocks(ctx context.Context) {
+	if dt.deadlockTimeout <= 0 {
+		return
+	}
+	ticker := time.NewTicker(dt.deadlockTimeout / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			dt.checkDeadlocks(now)
+		}
+	}
+}
+
+// checkDeadlocks logs the derivations that have not ended within the deadlock timeout.
+// Each derivation is only reported once.
+func (dt *DebugTracer) checkDeadlocks(now time.Time) {
+	queued := len(dt.queuedEvents())
+	dt.l.Lock()
+	defer dt.l.Unlock()
+	for _, a := range dt.active {
+		elapsed := now.Sub(a.StartTime)
+		if a.reported || elapsed < dt.deadlockTimeout {
+			continue
+		}
+		a.reported = true
+		dt.log.Error("Event deriver did not finish processing event, event system may be deadlocked",
+			"deriver", a.Name, "event", a.EventName, "elapsed", elapsed, "deriv_context", a.DerivContext, "queued", queued)
+	}
+}
@@ -0,0 +1,98 @@
+package event
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestDebugTracer(t *testing.T) {
+	logger, logs := testlog.CaptureLogger(t, log.LevelWarn)
+	ex := NewGlobalSynchronous(context.Background())
+	sys := NewSystem(testlog.Logger(t, log.LevelError), ex)
+	tracer := NewDebugTracer(logger, 3, 0, 0, ex.Queued)
+	sys.AddTracer(tracer)
+
+	foo := DeriverFunc(func(ev Event) bool {
+		return true
+	})
+	em := sys.Register("foo", foo, DefaultRegisterOpts())
+	em.Emit(TestEvent{})
+	em.Emit(TestEvent{})
+
+	dump := tracer.Dump()
+	require.Len(t, dump.Entries, 2)
+	require.Equal(t, TraceEmit, dump.Entries[0].Kind)
+	require.Equal(t, []QueuedEvent{
+		{EventName: "X", EmitContext: 1},
+		{EventName: "X", EmitContext: 2},
+	}, dump.Queue)
+
+	require.NoError(t, ex.Drain())
+	dump = tracer.Dump()
+	require.Len(t, dump.Entries, 3, "should only keep the most recent entries")
+	require.Equal(t, TraceDeriveEnd, dump.Entries[0].Kind)
+	require.Equal(t, uint64(1), dump.Entries[0].EmitContext)
+	require.Equal(t, TraceDeriveStart, dump.Entries[1].Kind)
+	require.Equal(t, TraceDeriveEnd, dump.Entries[2].Kind)
+	require.Equal(t, uint64(2), dump.Entries[2].EmitContext)
+	require.Empty(t, dump.Active)
+	require.Empty(t, dump.Queue)
+	require.Nil(t, logs.FindLog(testlog.NewLevelFilter(log.LevelWarn)), "should not log without latency budget")
+}
+
+func TestDebugTracerLatencyBudget(t *testing.T) {
+	logger, logs := testlog.CaptureLogger(t, log.LevelWarn)
+	tracer := NewDebugTracer(logger, 0, time.Second, 0, nil)
+	ev := AnnotatedEvent{Event: TestEvent{}, EmitContext: 1}
+	start := time.Unix(1000, 0)
+
+	tracer.OnDeriveStart("fast", ev, 1, start)
+	tracer.OnDeriveEnd("fast", ev, 1, start, time.Second, true)
+	require.Nil(t, logs.FindLog(testlog.NewMessageFilter("Event deriver exceeded latency budget")))
+
+	tracer.OnDeriveStart("slow", ev, 2, start)
+	tracer.OnDeriveEnd("slow", ev, 2, start, 2*time.Second, true)
+	require.NotNil(t, logs.FindLog(
+		testlog.NewMessageFilter("Event deriver exceeded latency budget"),
+		testlog.NewAttributesFilter("deriver", "slow")))
+
+	dump := tracer.Dump()
+	require.Empty(t, dump.Entries, "should not keep entries when size is 0")
+	require.Equal(t, map[string]uint64{"slow": 1}, dump.SlowDerivations)
+}
+
+func TestDebugTracerDeadlock(t *testing.T) {
+	logger, logs := testlog.CaptureLogger(t, log.LevelWarn)
+	tracer := NewDebugTracer(logger, 10, 0, time.Minute, nil)
+	ev := AnnotatedEvent{Event: TestEvent{}, EmitContext: 1}
+	start := time.Unix(1000, 0)
+	deadlockFilter := testlog.NewMessageContainsFilter("may be deadlocked")
+
+	tracer.OnDeriveStart("foo", ev, 1, start)
+	tracer.OnDeriveStart("bar", ev, 2, start.Add(time.Second))
+	dump := tracer.Dump()
+	require.Len(t, dump.Active, 2)
+	require.Equal(t, "foo", dump.Active[0].Name)
+	require.Equal(t, "bar", dump.Active[1].Name)
+
+	tracer.checkDeadlocks(start.Add(30 * time.Second))
+	require.Nil(t, logs.FindLog(deadlockFilter))
+
+	tracer.checkDeadlocks(start.Add(time.Minute))
+	require.Len(t, logs.FindLogs(deadlockFilter), 1)
+	require.NotNil(t, logs.FindLog(deadlockFilter, testlog.NewAttributesFilter("deriver", "foo")))
+
+	tracer.checkDeadlocks(start.Add(2 * time.Minute))
+	require.Len(t, logs.FindLogs(deadlockFilter), 2, "should report each derivation once")
+	require.NotNil(t, logs.FindLog(deadlockFilter, testlog.NewAttributesFilter("deriver", "bar")))
+
+	tracer.OnDeriveEnd("foo", ev, 1, start, 2*time.Minute, true)
+	require.Len(t, tracer.Dump().Active, 1)
+}
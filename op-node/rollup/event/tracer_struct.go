@@ -1,6 +1,7 @@
 package event
 
 import (
+	"fmt"
 	"sync"
 	"time"
 )
@@ -14,6 +15,35 @@ const (
 	TraceEmit
 )
 
+func (k TraceEntryKind) String() string {
+	switch k {
+	case TraceDeriveStart:
+		return "derive_start"
+	case TraceDeriveEnd:
+		return "derive_end"
+	case TraceRateLimited:
+		return "rate_limited"
+	case TraceEmit:
+		return "emit"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(k))
+	}
+}
+
+func (k TraceEntryKind) MarshalText() ([]byte, error) {
+	return []byte(k.String()), nil
+}
+
+func (k *TraceEntryKind) UnmarshalText(text []byte) error {
+	for _, kind := range []TraceEntryKind{TraceDeriveStart, TraceDeriveEnd, TraceRateLimited, TraceEmit} {
+		if kind.String() == string(text) {
+			*k = kind
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown trace entry kind %q", text)
+}
+
 type TraceEntry struct {
 	Kind TraceEntryKind
 
@@ -0,0 +1,34 @@
+package interop
+
+import (
+	"errors"
+
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+var ErrNotInDependencySet = errors.New("executing message from chain outside of dependency set")
+
+// DependencySet is the set of chains that this chain may execute messages from.
+// The chain itself is always part of its own dependency set.
+// An empty dependency set does not restrict the chains, and leaves all message checks to the supervisor.
+type DependencySet []types.ChainID
+
+func NewDependencySet(chainIDs []uint64) DependencySet {
+	if len(chainIDs) == 0 {
+		return nil
+	}
+	set := make(DependencySet, len(chainIDs))
+	for i, id := range chainIDs {
+		set[i] = types.ChainIDFromUInt64(id)
+	}
+	return set
+}
+
+func (s DependencySet) Contains(chainID types.ChainID) bool {
+	for _, id := range s {
+		if id == chainID {
+			return true
+		}
+	}
+	return false
+}
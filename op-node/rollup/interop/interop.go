@@ -2,16 +2,20 @@ package interop
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/source/contracts"
 	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
 )
 
@@ -24,6 +28,7 @@ type InteropBackend interface {
 
 type L2Source interface {
 	L2BlockRefByNumber(context.Context, uint64) (eth.L2BlockRef, error)
+	FetchReceipts(ctx context.Context, blockHash common.Hash) (eth.BlockInfo, ethtypes.Receipts, error)
 }
 
 // InteropDeriver watches for update events (either real changes to block safety,
@@ -47,6 +52,10 @@ type InteropDeriver struct {
 	backend InteropBackend
 	l2      L2Source
 
+	// depSet restricts the chains that executing messages may reference, before the block is checked by the backend.
+	depSet DependencySet
+	inbox  *contracts.CrossL2Inbox
+
 	emitter event.Emitter
 
 	mu sync.Mutex
@@ -56,7 +65,7 @@ var _ event.Deriver = (*InteropDeriver)(nil)
 var _ event.AttachEmitter = (*InteropDeriver)(nil)
 
 func NewInteropDeriver(log log.Logger, cfg *rollup.Config,
-	driverCtx context.Context, backend InteropBackend, l2 L2Source) *InteropDeriver {
+	driverCtx context.Context, backend InteropBackend, l2 L2Source, depSet DependencySet) *InteropDeriver {
 	return &InteropDeriver{
 		log:         log,
 		cfg:         cfg,
@@ -65,6 +74,8 @@ func NewInteropDeriver(log log.Logger, cfg *rollup.Config,
 		derivedFrom: make(map[common.Hash]eth.L1BlockRef),
 		backend:     backend,
 		l2:          l2,
+		depSet:      depSet,
+		inbox:       contracts.NewCrossL2Inbox(),
 	}
 }
 
@@ -95,6 +106,10 @@ func (d *InteropDeriver) OnEvent(ev event.Event) bool {
 			d.log.Warn("Failed to fetch next cross-unsafe candidate", "err", err)
 			break
 		}
+		if err := d.checkDependencies(ctx, candidate); err != nil {
+			d.log.Warn("Failed to check executing messages of unsafe block", "block", candidate, "err", err)
+			break
+		}
 		blockSafety, err := d.backend.CheckBlock(ctx, d.chainID, candidate.Hash, candidate.Number)
 		if err != nil {
 			d.log.Warn("Failed to check interop safety of unsafe block", "err", err)
@@ -125,6 +140,10 @@ func (d *InteropDeriver) OnEvent(ev event.Event) bool {
 			d.log.Warn("Failed to fetch next cross-safe candidate", "err", err)
 			break
 		}
+		if err := d.checkDependencies(ctx, candidate); err != nil {
+			d.log.Warn("Failed to check executing messages of local-safe block", "block", candidate, "err", err)
+			break
+		}
 		blockSafety, err := d.backend.CheckBlock(ctx, d.chainID, candidate.Hash, candidate.Number)
 		if err != nil {
 			d.log.Warn("Failed to check interop safety of local-safe block", "err", err)
@@ -159,3 +178,30 @@ func (d *InteropDeriver) OnEvent(ev event.Event) bool {
 	}
 	return true
 }
+
+// checkDependencies checks that all executing messages in the block reference chains in the dependency set.
+// It is a no-op if the dependency set is empty.
+func (d *InteropDeriver) checkDependencies(ctx context.Context, candidate eth.L2BlockRef) error {
+	if len(d.depSet) == 0 {
+		return nil
+	}
+	_, receipts, err := d.l2.FetchReceipts(ctx, candidate.Hash)
+	if err != nil {
+		return fmt.Errorf("failed to fetch receipts: %w", err)
+	}
+	for _, rcpt := range receipts {
+		for _, l := range rcpt.Logs {
+			msg, err := d.inbox.DecodeExecutingMessageLog(l)
+			if errors.Is(err, contracts.ErrEventNotFound) {
+				continue
+			} else if err != nil {
+				return fmt.Errorf("invalid executing message in log %d: %w", l.Index, err)
+			}
+			chainID := types.ChainIDFromUInt64(uint64(msg.Chain))
+			if chainID != d.chainID && !d.depSet.Contains(chainID) {
+				return fmt.Errorf("%w: chain %d in log %d", ErrNotInDependencySet, msg.Chain, l.Index)
+			}
+		}
+	}
+	return nil
+}
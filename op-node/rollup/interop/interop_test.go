@@ -8,13 +8,18 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/predeploys"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum-optimism/optimism/op-service/testutils"
 	supervisortypes "github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+	"github.com/ethereum-optimism/optimism/packages/contracts-bedrock/snapshots"
 )
 
 func TestInteropDeriver(t *testing.T) {
@@ -27,7 +32,7 @@ func TestInteropDeriver(t *testing.T) {
 		L2ChainID:   big.NewInt(42),
 	}
 	chainID := supervisortypes.ChainIDFromBig(cfg.L2ChainID)
-	interopDeriver := NewInteropDeriver(logger, cfg, context.Background(), interopBackend, l2Source)
+	interopDeriver := NewInteropDeriver(logger, cfg, context.Background(), interopBackend, l2Source, nil)
 	interopDeriver.AttachEmitter(emitter)
 	rng := rand.New(rand.NewSource(123))
 
@@ -134,3 +139,82 @@ func TestInteropDeriver(t *testing.T) {
 		l2Source.AssertExpectations(t)
 	})
 }
+
+func TestInteropDependencySet(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	cfg := &rollup.Config{
+		InteropTime: new(uint64),
+		L2ChainID:   big.NewInt(42),
+	}
+	chainID := supervisortypes.ChainIDFromBig(cfg.L2ChainID)
+	depSet := NewDependencySet([]uint64{900, 901})
+	rng := rand.New(rand.NewSource(123))
+
+	checkUnsafe := func(t *testing.T, execChainIDs ...uint64) {
+		l2Source := &testutils.MockL2Client{}
+		emitter := &testutils.MockEmitter{}
+		interopBackend := &testutils.MockInteropBackend{}
+		interopDeriver := NewInteropDeriver(logger, cfg, context.Background(), interopBackend, l2Source, depSet)
+		interopDeriver.AttachEmitter(emitter)
+
+		crossUnsafe := testutils.RandomL2BlockRef(rng)
+		candidate := testutils.NextRandomL2Ref(rng, 2, crossUnsafe, crossUnsafe.L1Origin)
+		l2Source.ExpectL2BlockRefByNumber(candidate.Number, candidate, nil)
+		rcpt := &types.Receipt{Logs: []*types.Log{{Address: common.Address{0xaa}}}}
+		for _, id := range execChainIDs {
+			rcpt.Logs = append(rcpt.Logs, executingMessageLog(t, id))
+		}
+		l2Source.ExpectFetchReceipts(candidate.Hash, eth.BlockInfo(nil), types.Receipts{rcpt}, nil)
+		allowed := true
+		for _, id := range execChainIDs {
+			allowed = allowed && (id == cfg.L2ChainID.Uint64() || depSet.Contains(supervisortypes.ChainIDFromUInt64(id)))
+		}
+		if allowed {
+			interopBackend.ExpectCheckBlock(chainID, candidate.Number, supervisortypes.CrossUnsafe, nil)
+			emitter.ExpectOnce(engine.PromoteCrossUnsafeEvent{Ref: candidate})
+		}
+		interopDeriver.OnEvent(engine.CrossUnsafeUpdateEvent{
+			CrossUnsafe: crossUnsafe,
+			LocalUnsafe: candidate,
+		})
+		interopBackend.AssertExpectations(t)
+		emitter.AssertExpectations(t)
+		l2Source.AssertExpectations(t)
+	}
+
+	t.Run("no executing messages", func(t *testing.T) {
+		checkUnsafe(t)
+	})
+	t.Run("messages from dependencies", func(t *testing.T) {
+		checkUnsafe(t, 900, 901, 42)
+	})
+	t.Run("message from outside dependency set", func(t *testing.T) {
+		checkUnsafe(t, 900, 902)
+	})
+}
+
+func executingMessageLog(t *testing.T, chainID uint64) *types.Log {
+	abi := snapshots.LoadCrossL2InboxABI()
+	event := abi.Events["ExecutingMessage"]
+	payloadHash := common.Hash{0x01}
+	identifier := struct {
+		Origin      common.Address
+		BlockNumber *big.Int
+		LogIndex    *big.Int
+		Timestamp   *big.Int
+		ChainId     *big.Int
+	}{
+		Origin:      common.Address{0xbb},
+		BlockNumber: big.NewInt(100),
+		LogIndex:    big.NewInt(1),
+		Timestamp:   big.NewInt(1000),
+		ChainId:     new(big.Int).SetUint64(chainID),
+	}
+	data, err := event.Inputs.Pack(payloadHash, identifier)
+	require.NoError(t, err)
+	return &types.Log{
+		Address: predeploys.CrossL2InboxAddr,
+		Topics:  []common.Hash{event.ID, payloadHash},
+		Data:    data,
+	}
+}
@@ -4,15 +4,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"sync/atomic"
 	"time"
 
 	"github.com/protolambda/ctxlock"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/attributes"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/conductor"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
@@ -36,6 +39,8 @@ type Metrics interface {
 	RecordSequencerInconsistentL1Origin(from eth.BlockID, to eth.BlockID)
 	RecordSequencerReset()
 	RecordSequencingError()
+	RecordSequencerEngineLatency(latency time.Duration)
+	RecordSequencerThrottleLevel(level uint8)
 }
 
 type SequencerStateListener interface {
@@ -68,8 +73,15 @@ type BuildingState struct {
 
 	Started time.Time
 
+	// Attributes the block is built with, to validate payloads of an external block builder against.
+	Attributes *eth.PayloadAttributes
+
 	// Set once known
 	Ref eth.L2BlockRef
+
+	// Local is the locally sealed block, held on to while the engine validates the payload of an external
+	// block builder, so it can be published instead if the builder payload fails to insert.
+	Local *engine.BuildSealedEvent
 }
 
 // Sequencer implements the sequencing interface of the driver: it starts and completes block building jobs.
@@ -114,6 +126,17 @@ type Sequencer struct {
 	latest     BuildingState
 	latestHead eth.L2BlockRef
 
+	throttle      ThrottleConfig
+	throttleLevel ThrottleLevel
+	// inserting is the latest sequenced block that was sent to the engine for insertion, if not confirmed yet.
+	inserting        eth.L2BlockRef
+	insertingStarted time.Time
+	// engineLatency is the latency of the engine to make the latest sequenced block the unsafe head.
+	engineLatency time.Duration
+
+	// builderPayload is the payload submitted by an external block builder for the next block, if any.
+	builderPayload *eth.ExecutionPayloadEnvelope
+
 	// toBlockRef converts a payload to a block-ref, and is only configurable for test-purposes
 	toBlockRef func(rollupCfg *rollup.Config, payload *eth.ExecutionPayload) (eth.L2BlockRef, error)
 }
@@ -143,6 +166,13 @@ func NewSequencer(driverCtx context.Context, log log.Logger, rollupCfg *rollup.C
 	}
 }
 
+// SetThrottle configures throttling of block production when the engine falls behind. See ThrottleConfig.
+func (d *Sequencer) SetThrottle(cfg ThrottleConfig) {
+	d.l.Lock()
+	defer d.l.Unlock()
+	d.throttle = cfg
+}
+
 func (d *Sequencer) AttachEmitter(em event.Emitter) {
 	d.emitter = em
 }
@@ -262,6 +292,26 @@ func (d *Sequencer) onBuildSealed(x engine.BuildSealedEvent) {
 		"txs", len(x.Envelope.ExecutionPayload.Transactions),
 		"time", uint64(x.Envelope.ExecutionPayload.Timestamp))
 
+	if builderEnvelope, builderRef, ok := d.adoptBuilderPayload(x.Envelope); ok {
+		// The builder payload is only committed and gossiped once the engine accepted it,
+		// so an invalid builder payload can still be replaced by the locally sealed block.
+		d.emitter.Emit(engine.PayloadProcessEvent{
+			IsLastInSpan: x.IsLastInSpan,
+			DerivedFrom:  x.DerivedFrom,
+			Envelope:     builderEnvelope,
+			Ref:          builderRef,
+		})
+		d.latest.Ref = builderRef
+		d.latest.Local = &x
+		d.inserting = builderRef
+		d.insertingStarted = d.timeNow()
+		return
+	}
+	d.publishAndInsert(x)
+}
+
+// publishAndInsert commits the sealed block to the conductor, gossips it, and then inserts it into the engine.
+func (d *Sequencer) publishAndInsert(x engine.BuildSealedEvent) {
 	// generous timeout, the conductor is important
 	ctx, cancel := context.WithTimeout(d.ctx, time.Second*30)
 	defer cancel()
@@ -283,6 +333,76 @@ func (d *Sequencer) onBuildSealed(x engine.BuildSealedEvent) {
 		Ref:          x.Ref,
 	})
 	d.latest.Ref = x.Ref
+	d.inserting = x.Ref
+	d.insertingStarted = d.timeNow()
+}
+
+// fallbackToLocal publishes and inserts the locally sealed block, after the builder payload failed to insert.
+func (d *Sequencer) fallbackToLocal(err error) {
+	local := d.latest.Local
+	d.latest.Local = nil
+	d.log.Warn("Builder payload failed to insert, falling back to locally built block",
+		"builder", d.latest.Ref, "local", local.Envelope.ExecutionPayload.ID(), "err", err)
+	d.publishAndInsert(*local)
+}
+
+// adoptBuilderPayload takes the pending builder payload, and returns it if it can replace the locally sealed block.
+// If the builder payload does not match the attributes of the block, the locally sealed block is used instead.
+func (d *Sequencer) adoptBuilderPayload(local *eth.ExecutionPayloadEnvelope) (*eth.ExecutionPayloadEnvelope, eth.L2BlockRef, bool) {
+	candidate := d.builderPayload
+	d.builderPayload = nil
+	if candidate == nil {
+		return nil, eth.L2BlockRef{}, false
+	}
+	if err := checkBuilderPayload(d.rollupCfg, d.latest.Attributes, d.latest.Onto, candidate, d.log); err != nil {
+		d.log.Warn("Rejected builder payload, falling back to locally built block",
+			"builder", candidate.ExecutionPayload.ID(), "local", local.ExecutionPayload.ID(), "err", err)
+		return nil, eth.L2BlockRef{}, false
+	}
+	ref, err := d.toBlockRef(d.rollupCfg, candidate.ExecutionPayload)
+	if err != nil {
+		d.log.Warn("Builder payload could not be turned into block-ref, falling back to locally built block",
+			"builder", candidate.ExecutionPayload.ID(), "local", local.ExecutionPayload.ID(), "err", err)
+		return nil, eth.L2BlockRef{}, false
+	}
+	d.log.Info("Adopted builder payload", "block", candidate.ExecutionPayload.ID(),
+		"txs", len(candidate.ExecutionPayload.Transactions), "local", local.ExecutionPayload.ID())
+	return candidate, ref, true
+}
+
+// checkBuilderPayload checks that a payload of an external block builder is a valid block for the given attributes.
+// The payload must start with the transactions forced by the attributes, such as the deposits,
+// and may only append non-deposit transactions, if the attributes allow transactions from the transaction pool.
+func checkBuilderPayload(rollupCfg *rollup.Config, attrs *eth.PayloadAttributes, parent eth.L2BlockRef,
+	envelope *eth.ExecutionPayloadEnvelope, l log.Logger) error {
+	if attrs == nil {
+		return errors.New("no attributes to check builder payload against")
+	}
+	payload := envelope.ExecutionPayload
+	if uint64(payload.BlockNumber) != parent.Number+1 {
+		return fmt.Errorf("block number does not match. expected: %d. got: %d", parent.Number+1, uint64(payload.BlockNumber))
+	}
+	if len(payload.Transactions) < len(attrs.Transactions) {
+		return fmt.Errorf("payload has %d transactions, expected at least the %d forced transactions",
+			len(payload.Transactions), len(attrs.Transactions))
+	}
+	extra := payload.Transactions[len(attrs.Transactions):]
+	if attrs.NoTxPool && len(extra) > 0 {
+		return fmt.Errorf("payload has %d transactions from the transaction pool, but the transaction pool is disabled", len(extra))
+	}
+	for i, tx := range extra {
+		if len(tx) == 0 {
+			return fmt.Errorf("transaction %d is empty", len(attrs.Transactions)+i)
+		}
+		if tx[0] == types.DepositTxType {
+			return fmt.Errorf("transaction %d is a deposit, deposits can only be forced by the attributes", len(attrs.Transactions)+i)
+		}
+	}
+	// With the builder transactions appended to the forced transactions,
+	// the payload has to match the attributes exactly.
+	withTxs := *attrs
+	withTxs.Transactions = append(slices.Clone(attrs.Transactions), extra...)
+	return attributes.AttributesMatchBlock(rollupCfg, &withTxs, parent.Hash, envelope, l)
 }
 
 func (d *Sequencer) onPayloadSealInvalid(x engine.PayloadSealInvalidEvent) {
@@ -309,6 +429,10 @@ func (d *Sequencer) onPayloadInvalid(x engine.PayloadInvalidEvent) {
 	if d.latest.Ref.Hash != x.Envelope.ExecutionPayload.BlockHash {
 		return // not a payload from the sequencer
 	}
+	if d.latest.Local != nil {
+		d.fallbackToLocal(x.Err)
+		return
+	}
 	d.log.Error("Sequencer could not insert payload",
 		"block", x.Envelope.ExecutionPayload.ID(), "err", x.Err)
 	d.handleInvalid()
@@ -321,6 +445,18 @@ func (d *Sequencer) onPayloadSuccess(x engine.PayloadSuccessEvent) {
 		// Not a payload that was built by this sequencer. We can ignore it, and continue upon forkchoice update.
 		return
 	}
+	if d.latest.Local != nil {
+		// The engine accepted the builder payload, now it can be published.
+		d.latest.Local = nil
+		ctx, cancel := context.WithTimeout(d.ctx, time.Second*30)
+		defer cancel()
+		if err := d.conductor.CommitUnsafePayload(ctx, x.Envelope); err != nil {
+			d.emitter.Emit(rollup.EngineTemporaryErrorEvent{
+				Err: fmt.Errorf("failed to commit builder payload to conductor: %w", err)})
+			return
+		}
+		d.asyncGossip.Gossip(x.Envelope)
+	}
 	d.latest = BuildingState{}
 	d.log.Info("Sequencer inserted block",
 		"block", x.Ref, "parent", x.Envelope.ExecutionPayload.ParentID())
@@ -381,6 +517,10 @@ func (d *Sequencer) onEngineTemporaryError(x rollup.EngineTemporaryErrorEvent) {
 		d.log.Debug("Engine reported temporary error, but sequencer is not using engine", "err", x.Err)
 		return
 	}
+	if d.latest.Local != nil {
+		d.fallbackToLocal(x.Err)
+		return
+	}
 	d.log.Error("Engine failed temporarily, backing off sequencer", "err", x.Err)
 	if errors.Is(x.Err, engine.ErrEngineSyncing) { // if it is syncing we can back off by more
 		d.nextAction = d.timeNow().Add(30 * time.Second)
@@ -408,6 +548,7 @@ func (d *Sequencer) onReset(x rollup.ResetEvent) {
 		d.emitter.Emit(engine.BuildCancelEvent{Info: d.latest.Info})
 	}
 	d.latest = BuildingState{}
+	d.builderPayload = nil
 	// no action to perform until we get a reset-confirmation
 	d.nextActionOK = false
 }
@@ -424,6 +565,12 @@ func (d *Sequencer) onEngineResetConfirmedEvent(x engine.EngineResetConfirmedEve
 func (d *Sequencer) onForkchoiceUpdate(x engine.ForkchoiceUpdateEvent) {
 	d.log.Debug("Sequencer is processing forkchoice update", "unsafe", x.UnsafeL2Head, "latest", d.latestHead)
 
+	if d.inserting != (eth.L2BlockRef{}) && d.inserting.Hash == x.UnsafeL2Head.Hash {
+		d.engineLatency = d.timeNow().Sub(d.insertingStarted)
+		d.metrics.RecordSequencerEngineLatency(d.engineLatency)
+		d.inserting = eth.L2BlockRef{}
+	}
+
 	if !d.active.Load() {
 		d.latestHead = x.UnsafeL2Head
 		return
@@ -455,10 +602,40 @@ func (d *Sequencer) onForkchoiceUpdate(x engine.ForkchoiceUpdateEvent) {
 			// otherwise start instantly
 			d.nextAction = now
 		}
+		d.applyThrottle(now, payloadTime)
 	}
 	d.latestHead = x.UnsafeL2Head
 }
 
+// applyThrottle delays the next action if the engine is falling behind, based on the throttle config.
+func (d *Sequencer) applyThrottle(now time.Time, payloadTime time.Time) {
+	var backlog uint64
+	if lag := now.Sub(payloadTime); lag > 0 {
+		backlog = uint64(lag / (time.Duration(d.rollupCfg.BlockTime) * time.Second))
+	}
+	level := d.throttle.Level(d.engineLatency, backlog)
+	if level != d.throttleLevel {
+		if level == ThrottleNone {
+			d.log.Info("Engine caught up, sequencer stopped throttling",
+				"latency", d.engineLatency, "backlog", backlog)
+		} else {
+			d.log.Warn("Engine is falling behind, sequencer is throttling block production", "level", level,
+				"latency", d.engineLatency, "backlog", backlog)
+		}
+		d.throttleLevel = level
+		d.metrics.RecordSequencerThrottleLevel(uint8(level))
+		d.emitter.Emit(SequencerThrottleEvent{Level: level, Latency: d.engineLatency, Backlog: backlog})
+	}
+	switch level {
+	case ThrottleSlow:
+		if next := now.Add(d.throttle.SlowDelay); next.After(d.nextAction) {
+			d.nextAction = next
+		}
+	case ThrottlePause:
+		d.nextAction = now.Add(d.throttle.PauseDuration)
+	}
+}
+
 // StartBuildingBlock initiates a block building job on top of the given L2 head, safe and finalized blocks, and using the provided l1Origin.
 func (d *Sequencer) startBuildingBlock() {
 	ctx := d.ctx
@@ -551,7 +728,7 @@ func (d *Sequencer) startBuildingBlock() {
 
 	// Reset building state, and remember what we are building on.
 	// If we get a forkchoice update that conflicts, we will have to abort building.
-	d.latest = BuildingState{Onto: l2Head}
+	d.latest = BuildingState{Onto: l2Head, Attributes: attrs}
 
 	d.emitter.Emit(engine.BuildStartEvent{
 		Attributes: withParent,
@@ -663,6 +840,7 @@ func (d *Sequencer) Stop(ctx context.Context) (hash common.Hash, err error) {
 	// Cancel any inflight block building. If we don't cancel this, we can resume sequencing an old block
 	// even if we've received new unsafe heads in the interim, causing us to introduce a re-org.
 	d.latest = BuildingState{} // By wiping this state we cannot continue from it later.
+	d.builderPayload = nil
 
 	d.nextActionOK = false
 	d.active.Store(false)
@@ -670,6 +848,27 @@ func (d *Sequencer) Stop(ctx context.Context) (hash common.Hash, err error) {
 	return d.latestHead.Hash, nil
 }
 
+// SubmitBuilderPayload submits a payload of an external block builder, as candidate for the next block.
+// The candidate replaces the locally built block when sealing, if it matches the attributes of the block.
+// Otherwise the sequencer falls back to the locally built block.
+func (d *Sequencer) SubmitBuilderPayload(ctx context.Context, envelope *eth.ExecutionPayloadEnvelope) error {
+	if err := d.l.LockCtx(ctx); err != nil {
+		return err
+	}
+	defer d.l.Unlock()
+
+	if !d.active.Load() {
+		return ErrSequencerAlreadyStopped
+	}
+	payload := envelope.ExecutionPayload
+	if payload.ParentHash != d.latestHead.Hash {
+		return fmt.Errorf("builder payload %s does not build on the current head %s", payload.ID(), d.latestHead)
+	}
+	d.log.Info("Received builder payload", "block", payload.ID(), "txs", len(payload.Transactions))
+	d.builderPayload = envelope
+	return nil
+}
+
 func (d *Sequencer) SetMaxSafeLag(ctx context.Context, v uint64) error {
 	d.maxSafeLag.Store(v)
 	return nil
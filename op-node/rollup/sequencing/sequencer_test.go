@@ -3,13 +3,16 @@ package sequencing
 import (
 	"context"
 	"encoding/binary"
+	"errors"
 	"math/rand" // nosemgrep
+	"slices"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-node/metrics"
@@ -124,13 +127,15 @@ func (c *FakeConductor) Close() {
 }
 
 type FakeAsyncGossip struct {
-	payload *eth.ExecutionPayloadEnvelope
-	started bool
-	stopped bool
+	payload  *eth.ExecutionPayloadEnvelope
+	gossiped *eth.ExecutionPayloadEnvelope
+	started  bool
+	stopped  bool
 }
 
 func (f *FakeAsyncGossip) Gossip(payload *eth.ExecutionPayloadEnvelope) {
 	f.payload = payload
+	f.gossiped = payload
 }
 
 func (f *FakeAsyncGossip) Get() *eth.ExecutionPayloadEnvelope {
@@ -563,6 +568,248 @@ func TestSequencerBuild(t *testing.T) {
 	require.Equal(t, testClock.Now(), nextTime, "start asap on the next block")
 }
 
+func TestSequencerBuilderPayload(t *testing.T) {
+	// sequence runs a sequencer up to sealing a block, with a payload of an external block builder,
+	// and returns the built-locally and the processed payloads.
+	sequence := func(t *testing.T, modify func(builder *eth.ExecutionPayloadEnvelope)) (seq *Sequencer, deps *sequencerTestDeps,
+		emitter *testutils.MockEmitter, local, processed *eth.ExecutionPayloadEnvelope) {
+		logger := testlog.Logger(t, log.LevelError)
+		seq, deps = createSequencer(logger)
+		testClock := clock.NewSimpleClock()
+		seq.timeNow = testClock.Now
+		testClock.SetTime(30000)
+		emitter = &testutils.MockEmitter{}
+		seq.AttachEmitter(emitter)
+
+		emitter.ExpectOnce(engine.ForkchoiceRequestEvent{})
+		require.NoError(t, seq.Init(context.Background(), true))
+		emitter.AssertExpectations(t)
+
+		head := eth.L2BlockRef{
+			Hash:     common.Hash{0x22},
+			Number:   100,
+			L1Origin: eth.BlockID{Hash: common.Hash{0x11, 0xa}, Number: 1000},
+			Time:     uint64(testClock.Now().Unix()),
+		}
+		seq.OnEvent(engine.ForkchoiceUpdateEvent{UnsafeL2Head: head})
+		l1Origin := eth.L1BlockRef{Hash: common.Hash{0x11, 0xa}, Number: 1000, Time: 29998}
+		deps.l1OriginSelector.l1OriginFn = func(l2Head eth.L2BlockRef) (eth.L1BlockRef, error) {
+			return l1Origin, nil
+		}
+
+		var sentAttributes *derive.AttributesWithParent
+		emitter.ExpectOnceRun(func(ev event.Event) {
+			x, ok := ev.(engine.BuildStartEvent)
+			require.True(t, ok)
+			sentAttributes = x.Attributes
+		})
+		seq.OnEvent(SequencerActionEvent{})
+		emitter.AssertExpectations(t)
+		attrs := sentAttributes.Attributes
+
+		payloadInfo := eth.PayloadInfo{ID: eth.PayloadID{0x42}, Timestamp: uint64(attrs.Timestamp)}
+		seq.OnEvent(engine.BuildStartedEvent{Info: payloadInfo, BuildStarted: testClock.Now(), Parent: head})
+
+		local = &eth.ExecutionPayloadEnvelope{
+			ParentBeaconBlockRoot: attrs.ParentBeaconBlockRoot,
+			ExecutionPayload: &eth.ExecutionPayload{
+				ParentHash:   head.Hash,
+				FeeRecipient: attrs.SuggestedFeeRecipient,
+				PrevRandao:   attrs.PrevRandao,
+				BlockNumber:  eth.Uint64Quantity(head.Number + 1),
+				GasLimit:     *attrs.GasLimit,
+				BlockHash:    common.Hash{0x12, 0x34},
+				Timestamp:    attrs.Timestamp,
+				Transactions: attrs.Transactions,
+			},
+		}
+		builderPayload := *local.ExecutionPayload
+		builderPayload.BlockHash = common.Hash{0x56, 0x78}
+		builderPayload.Transactions = append(slices.Clone(attrs.Transactions), eth.Data{types.DynamicFeeTxType, 0x01})
+		builder := &eth.ExecutionPayloadEnvelope{
+			ParentBeaconBlockRoot: local.ParentBeaconBlockRoot,
+			ExecutionPayload:      &builderPayload,
+		}
+		modify(builder)
+		require.NoError(t, seq.SubmitBuilderPayload(context.Background(), builder))
+
+		emitter.ExpectOnceRun(func(ev event.Event) {
+			x, ok := ev.(engine.PayloadProcessEvent)
+			require.True(t, ok)
+			processed = x.Envelope
+			require.Equal(t, x.Envelope.ExecutionPayload.BlockHash, x.Ref.Hash)
+		})
+		seq.OnEvent(engine.BuildSealedEvent{
+			Info:     payloadInfo,
+			Envelope: local,
+			Ref:      eth.L2BlockRef{Hash: local.ExecutionPayload.BlockHash, Number: head.Number + 1},
+		})
+		emitter.AssertExpectations(t)
+		return seq, deps, emitter, local, processed
+	}
+
+	t.Run("Adopt", func(t *testing.T) {
+		seq, deps, _, local, processed := sequence(t, func(builder *eth.ExecutionPayloadEnvelope) {})
+		require.NotEqual(t, local, processed)
+		require.Equal(t, common.Hash{0x56, 0x78}, processed.ExecutionPayload.BlockHash)
+		require.Nil(t, deps.conductor.committed, "must not commit the builder payload before the engine accepted it")
+		require.Nil(t, deps.asyncGossip.gossiped, "must not gossip the builder payload before the engine accepted it")
+
+		ref := eth.L2BlockRef{Hash: processed.ExecutionPayload.BlockHash, Number: uint64(processed.ExecutionPayload.BlockNumber)}
+		seq.OnEvent(engine.PayloadSuccessEvent{Envelope: processed, Ref: ref})
+		require.Equal(t, processed, deps.conductor.committed, "must commit the inserted builder payload")
+		require.Equal(t, processed, deps.asyncGossip.gossiped, "must gossip the inserted builder payload")
+		require.Nil(t, deps.asyncGossip.payload, "inserted payload no longer needs to be held on to")
+		require.Equal(t, BuildingState{}, seq.latest)
+	})
+	t.Run("InvalidNewPayload", func(t *testing.T) {
+		seq, deps, emitter, local, processed := sequence(t, func(builder *eth.ExecutionPayloadEnvelope) {})
+		require.NotEqual(t, local, processed)
+
+		emitter.ExpectOnce(engine.PayloadProcessEvent{
+			Envelope: local,
+			Ref:      eth.L2BlockRef{Hash: local.ExecutionPayload.BlockHash, Number: uint64(local.ExecutionPayload.BlockNumber)},
+		})
+		seq.OnEvent(engine.PayloadInvalidEvent{Envelope: processed, Err: errors.New("invalid state root")})
+		emitter.AssertExpectations(t)
+		require.Equal(t, local, deps.conductor.committed, "must commit the locally built block")
+		require.Equal(t, local, deps.asyncGossip.gossiped, "must gossip the locally built block")
+		require.Equal(t, local.ExecutionPayload.BlockHash, seq.latest.Ref.Hash)
+		require.Nil(t, seq.latest.Local)
+	})
+	fallbacks := map[string]func(builder *eth.ExecutionPayloadEnvelope){
+		"GasLimit": func(builder *eth.ExecutionPayloadEnvelope) {
+			builder.ExecutionPayload.GasLimit++
+		},
+		"Timestamp": func(builder *eth.ExecutionPayloadEnvelope) {
+			builder.ExecutionPayload.Timestamp++
+		},
+		"MissingDeposits": func(builder *eth.ExecutionPayloadEnvelope) {
+			builder.ExecutionPayload.Transactions = builder.ExecutionPayload.Transactions[1:]
+		},
+		"ExtraDeposit": func(builder *eth.ExecutionPayloadEnvelope) {
+			builder.ExecutionPayload.Transactions = append(builder.ExecutionPayload.Transactions, eth.Data{types.DepositTxType, 0x02})
+		},
+	}
+	for name, modify := range fallbacks {
+		t.Run(name, func(t *testing.T) {
+			_, deps, _, local, processed := sequence(t, modify)
+			require.Equal(t, local, processed, "must fall back to the locally built block")
+			require.Equal(t, local, deps.conductor.committed, "must commit the locally built block")
+			require.Equal(t, local, deps.asyncGossip.gossiped, "must gossip the locally built block")
+		})
+	}
+}
+
+func TestSequencerSubmitBuilderPayload(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelError)
+	seq, deps := createSequencer(logger)
+	emitter := &testutils.MockEmitter{}
+	seq.AttachEmitter(emitter)
+	deps.conductor.leader = true
+
+	emitter.ExpectOnce(engine.ForkchoiceRequestEvent{})
+	require.NoError(t, seq.Init(context.Background(), false))
+	seq.OnEvent(engine.ForkchoiceUpdateEvent{UnsafeL2Head: eth.L2BlockRef{Hash: common.Hash{0xaa}}})
+
+	envelope := &eth.ExecutionPayloadEnvelope{ExecutionPayload: &eth.ExecutionPayload{ParentHash: common.Hash{0xaa}}}
+	require.ErrorIs(t, seq.SubmitBuilderPayload(context.Background(), envelope), ErrSequencerAlreadyStopped)
+
+	require.NoError(t, seq.Start(context.Background(), common.Hash{0xaa}))
+	require.NoError(t, seq.SubmitBuilderPayload(context.Background(), envelope))
+
+	stale := &eth.ExecutionPayloadEnvelope{ExecutionPayload: &eth.ExecutionPayload{ParentHash: common.Hash{0xbb}}}
+	require.ErrorContains(t, seq.SubmitBuilderPayload(context.Background(), stale), "does not build on the current head")
+
+	_, err := seq.Stop(context.Background())
+	require.NoError(t, err)
+	require.Nil(t, seq.builderPayload, "builder payload is dropped when stopping")
+}
+
+func TestSequencerThrottle(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelError)
+	seq, _ := createSequencer(logger)
+	testClock := clock.NewSimpleClock()
+	seq.timeNow = testClock.Now
+	testClock.SetTime(30000)
+	emitter := &testutils.MockEmitter{}
+	seq.AttachEmitter(emitter)
+	seq.SetThrottle(ThrottleConfig{
+		SlowLatency:   time.Second,
+		PauseLatency:  5 * time.Second,
+		MaxBacklog:    4,
+		SlowDelay:     1500 * time.Millisecond,
+		PauseDuration: 12 * time.Second,
+	})
+
+	emitter.ExpectOnce(engine.ForkchoiceRequestEvent{})
+	require.NoError(t, seq.Init(context.Background(), true))
+	emitter.AssertExpectations(t)
+
+	head := eth.L2BlockRef{Hash: common.Hash{0x22}, Number: 100, Time: uint64(testClock.Now().Unix())}
+	seq.OnEvent(engine.ForkchoiceUpdateEvent{UnsafeL2Head: head})
+	next, ok := seq.NextAction()
+	require.True(t, ok)
+	require.Equal(t, testClock.Now(), next, "not throttled without engine latency measurement")
+
+	// insert mocks the engine making a sequenced block canonical, after the given latency.
+	insert := func(latency time.Duration, age time.Duration) {
+		head = eth.L2BlockRef{
+			Hash:   common.Hash{byte(head.Number + 1)},
+			Number: head.Number + 1,
+			Time:   uint64(testClock.Now().Add(-age).Unix()),
+		}
+		seq.inserting = head
+		seq.insertingStarted = testClock.Now().Add(-latency)
+		seq.OnEvent(engine.ForkchoiceUpdateEvent{UnsafeL2Head: head})
+		emitter.AssertExpectations(t)
+	}
+
+	emitter.ExpectOnce(SequencerThrottleEvent{Level: ThrottleSlow, Latency: 3 * time.Second})
+	insert(3*time.Second, 0)
+	next, _ = seq.NextAction()
+	require.Equal(t, testClock.Now().Add(1500*time.Millisecond), next, "slowed down")
+
+	// No event is emitted if the level does not change
+	insert(2*time.Second, 0)
+	next, _ = seq.NextAction()
+	require.Equal(t, testClock.Now().Add(1500*time.Millisecond), next, "still slowed down")
+
+	emitter.ExpectOnce(SequencerThrottleEvent{Level: ThrottlePause, Latency: 6 * time.Second})
+	insert(6*time.Second, 0)
+	next, _ = seq.NextAction()
+	require.Equal(t, testClock.Now().Add(12*time.Second), next, "paused")
+
+	emitter.ExpectOnce(SequencerThrottleEvent{Level: ThrottleNone, Latency: 10 * time.Millisecond})
+	insert(10*time.Millisecond, 0)
+	next, _ = seq.NextAction()
+	require.Equal(t, testClock.Now(), next, "no longer throttled")
+
+	// Falling behind the wall-clock by too many blocks slows down block production too.
+	emitter.ExpectOnce(SequencerThrottleEvent{Level: ThrottleSlow, Latency: 10 * time.Millisecond, Backlog: 4})
+	insert(10*time.Millisecond, 10*time.Second)
+	next, _ = seq.NextAction()
+	require.Equal(t, testClock.Now().Add(1500*time.Millisecond), next, "slowed down by backlog")
+}
+
+func TestThrottleConfig(t *testing.T) {
+	blockTime := uint64(2)
+	require.NoError(t, (&ThrottleConfig{}).Check(blockTime), "disabled by default")
+	require.Error(t, (&ThrottleConfig{SlowLatency: time.Second}).Check(blockTime), "slow delay required")
+	require.Error(t, (&ThrottleConfig{MaxBacklog: 10}).Check(blockTime), "slow delay required")
+	require.Error(t, (&ThrottleConfig{MaxBacklog: 10, SlowDelay: 2 * time.Second}).Check(blockTime),
+		"slow delay must be lower than the block time")
+	require.Error(t, (&ThrottleConfig{PauseLatency: time.Second}).Check(blockTime), "pause duration required")
+	require.Error(t, (&ThrottleConfig{
+		SlowLatency: 2 * time.Second, PauseLatency: time.Second,
+		SlowDelay: time.Second, PauseDuration: time.Second,
+	}).Check(blockTime), "pause latency below slow latency")
+	require.NoError(t, (&ThrottleConfig{
+		SlowLatency: time.Second, PauseLatency: 2 * time.Second,
+		SlowDelay: time.Second, PauseDuration: time.Second,
+	}).Check(blockTime))
+}
+
 type sequencerTestDeps struct {
 	cfg              *rollup.Config
 	attribBuilder    *FakeAttributesBuilder
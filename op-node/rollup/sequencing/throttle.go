@@ -0,0 +1,93 @@
+package sequencing
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ThrottleLevel identifies how much block production is throttled by.
+type ThrottleLevel uint8
+
+const (
+	// ThrottleNone is the default: blocks are produced as soon as possible.
+	ThrottleNone ThrottleLevel = iota
+	// ThrottleSlow enforces a minimum delay between the starts of block building jobs.
+	ThrottleSlow
+	// ThrottlePause pauses block production, before probing the engine latency with a new block.
+	ThrottlePause
+)
+
+func (l ThrottleLevel) String() string {
+	switch l {
+	case ThrottleNone:
+		return "none"
+	case ThrottleSlow:
+		return "slow"
+	case ThrottlePause:
+		return "pause"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(l))
+	}
+}
+
+// ThrottleConfig configures throttling of block production when the execution engine falls behind.
+// The engine latency is measured per sequenced block, from requesting the insertion of the block,
+// until the forkchoice update that makes it the unsafe head.
+// The backlog is the number of blocks the unsafe head is behind the wall-clock time,
+// which the sequencer would otherwise try to catch up on by producing blocks back-to-back.
+type ThrottleConfig struct {
+	// SlowLatency is the engine latency at or above which block production is slowed down. Disabled if 0.
+	SlowLatency time.Duration `json:"slow_latency"`
+	// PauseLatency is the engine latency at or above which block production is paused. Disabled if 0.
+	PauseLatency time.Duration `json:"pause_latency"`
+	// MaxBacklog is the backlog in blocks at or above which block production is slowed down. Disabled if 0.
+	MaxBacklog uint64 `json:"max_backlog"`
+	// SlowDelay is the minimum time between the starts of block building jobs while slowed down.
+	SlowDelay time.Duration `json:"slow_delay"`
+	// PauseDuration is how long block production is paused for, before a new block is built to probe the engine.
+	PauseDuration time.Duration `json:"pause_duration"`
+}
+
+// Check checks the config against the block time of the chain, in seconds.
+// While slowed down, blocks must still be produced faster than the block time,
+// or the sequencer would never catch up with the wall-clock time.
+func (c *ThrottleConfig) Check(blockTime uint64) error {
+	if c.SlowLatency != 0 || c.MaxBacklog != 0 {
+		if c.SlowDelay <= 0 {
+			return errors.New("slow delay must be positive when slowing down is enabled")
+		}
+		if maxDelay := time.Duration(blockTime) * time.Second; c.SlowDelay >= maxDelay {
+			return fmt.Errorf("slow delay %s must be lower than the block time %s", c.SlowDelay, maxDelay)
+		}
+	}
+	if c.PauseLatency != 0 && c.PauseDuration <= 0 {
+		return errors.New("pause duration must be positive when pausing is enabled")
+	}
+	if c.SlowLatency != 0 && c.PauseLatency != 0 && c.PauseLatency < c.SlowLatency {
+		return fmt.Errorf("pause latency %s must not be lower than slow latency %s", c.PauseLatency, c.SlowLatency)
+	}
+	return nil
+}
+
+// Level returns the throttle level to apply, given the latest engine latency and backlog.
+func (c *ThrottleConfig) Level(latency time.Duration, backlog uint64) ThrottleLevel {
+	if c.PauseLatency != 0 && latency >= c.PauseLatency {
+		return ThrottlePause
+	}
+	if (c.SlowLatency != 0 && latency >= c.SlowLatency) || (c.MaxBacklog != 0 && backlog >= c.MaxBacklog) {
+		return ThrottleSlow
+	}
+	return ThrottleNone
+}
+
+// SequencerThrottleEvent is emitted when the throttle level of the sequencer changes.
+type SequencerThrottleEvent struct {
+	Level   ThrottleLevel
+	Latency time.Duration
+	Backlog uint64
+}
+
+func (ev SequencerThrottleEvent) String() string {
+	return "sequencer-throttle"
+}
@@ -1,9 +1,11 @@
 package status
 
 import (
+	"errors"
 	"sync"
 	"sync/atomic"
 
+	gethevent "github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
@@ -30,6 +32,9 @@ func (ev L1SafeEvent) String() string {
 	return "l1-safe"
 }
 
+// ErrSlowSubscriber is the error of a head update subscription that was closed because its channel was full.
+var ErrSlowSubscriber = errors.New("head update subscriber is too slow")
+
 type Metrics interface {
 	RecordL1ReorgDepth(d uint64)
 	RecordL1Ref(name string, ref eth.L1BlockRef)
@@ -44,6 +49,9 @@ type StatusTracker struct {
 
 	metrics Metrics
 
+	subsMu sync.Mutex
+	subs   map[*headSubscription]struct{}
+
 	mu sync.RWMutex
 }
 
@@ -51,6 +59,7 @@ func NewStatusTracker(log log.Logger, metrics Metrics) *StatusTracker {
 	st := &StatusTracker{
 		log:     log,
 		metrics: metrics,
+		subs:    make(map[*headSubscription]struct{}),
 	}
 	st.data = eth.SyncStatus{}
 	st.published.Store(&eth.SyncStatus{})
@@ -58,6 +67,15 @@ func NewStatusTracker(log log.Logger, metrics Metrics) *StatusTracker {
 }
 
 func (st *StatusTracker) OnEvent(ev event.Event) bool {
+	updates, ok := st.onEvent(ev)
+	// Notify subscribers outside the lock, so slow subscribers don't block SyncStatus readers
+	for _, update := range updates {
+		st.sendHeadUpdate(update)
+	}
+	return ok
+}
+
+func (st *StatusTracker) onEvent(ev event.Event) ([]eth.HeadUpdate, bool) {
 	st.mu.Lock()
 	defer st.mu.Unlock()
 
@@ -118,18 +136,92 @@ func (st *StatusTracker) OnEvent(ev event.Event) bool {
 		st.data.SafeL2 = x.Safe
 		st.data.FinalizedL2 = x.Finalized
 	default: // other events do not affect the sync status
-		return false
+		return nil, false
 	}
 
 	// If anything changes, then copy the state to the published SyncStatus
 	// @dev: If this becomes a performance bottleneck during sync (because mem copies onto heap, and 1KB comparisons),
 	// we can rate-limit updates of the published data.
 	published := *st.published.Load()
+	var updates []eth.HeadUpdate
 	if st.data != published {
+		updates = headUpdates(&published, &st.data)
 		published = st.data
 		st.published.Store(&published)
 	}
-	return true
+	return updates, true
+}
+
+// headUpdates returns the updates of the L2 head labels between the previous and next sync status.
+// Heads that are reset to the zero value, e.g. during a derivation pipeline reset, are not reported.
+func headUpdates(prev, next *eth.SyncStatus) []eth.HeadUpdate {
+	var updates []eth.HeadUpdate
+	if next.UnsafeL2 != prev.UnsafeL2 && next.UnsafeL2 != (eth.L2BlockRef{}) {
+		updates = append(updates, eth.HeadUpdate{Kind: eth.HeadUpdateUnsafe, Head: next.UnsafeL2, L1: next.UnsafeL2.L1Origin})
+	}
+	if next.SafeL2 != prev.SafeL2 && next.SafeL2 != (eth.L2BlockRef{}) {
+		updates = append(updates, eth.HeadUpdate{Kind: eth.HeadUpdateSafe, Head: next.SafeL2, L1: next.CurrentL1.ID()})
+	}
+	if next.FinalizedL2 != prev.FinalizedL2 && next.FinalizedL2 != (eth.L2BlockRef{}) {
+		updates = append(updates, eth.HeadUpdate{Kind: eth.HeadUpdateFinalized, Head: next.FinalizedL2, L1: next.FinalizedL1.ID()})
+	}
+	return updates
+}
+
+// SubscribeHeadUpdates subscribes to updates of the unsafe, safe and finalized L2 heads.
+// Updates are sent without blocking event processing, so the channel must be buffered.
+// If the channel is full when an update is sent, the subscription is closed with ErrSlowSubscriber.
+func (st *StatusTracker) SubscribeHeadUpdates(ch chan<- eth.HeadUpdate) gethevent.Subscription {
+	sub := &headSubscription{st: st, ch: ch, err: make(chan error, 1)}
+	st.subsMu.Lock()
+	defer st.subsMu.Unlock()
+	st.subs[sub] = struct{}{}
+	return sub
+}
+
+// sendHeadUpdate sends the update to all subscribers, closing the subscriptions of subscribers that are behind.
+func (st *StatusTracker) sendHeadUpdate(update eth.HeadUpdate) {
+	st.subsMu.Lock()
+	subs := make([]*headSubscription, 0, len(st.subs))
+	for sub := range st.subs {
+		subs = append(subs, sub)
+	}
+	st.subsMu.Unlock()
+	for _, sub := range subs {
+		select {
+		case sub.ch <- update:
+		default:
+			st.log.Warn("Closing head update subscription of slow subscriber", "update", update.Kind, "head", update.Head.ID())
+			sub.close(ErrSlowSubscriber)
+		}
+	}
+}
+
+type headSubscription struct {
+	st   *StatusTracker
+	ch   chan<- eth.HeadUpdate
+	err  chan error
+	once sync.Once
+}
+
+func (s *headSubscription) Err() <-chan error {
+	return s.err
+}
+
+func (s *headSubscription) Unsubscribe() {
+	s.close(nil)
+}
+
+func (s *headSubscription) close(err error) {
+	s.once.Do(func() {
+		s.st.subsMu.Lock()
+		delete(s.st.subs, s)
+		s.st.subsMu.Unlock()
+		if err != nil {
+			s.err <- err
+		}
+		close(s.err)
+	})
 }
 
 // SyncStatus is thread safe, and reads the latest view of L1 and L2 block labels
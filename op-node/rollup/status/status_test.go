@@ -0,0 +1,112 @@
+package status
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/finality"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestHeadUpdates(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	st := NewStatusTracker(testlog.Logger(t, log.LevelDebug), metrics.NoopMetrics)
+	updates := make(chan eth.HeadUpdate, 10)
+	sub := st.SubscribeHeadUpdates(updates)
+	defer sub.Unsubscribe()
+
+	expectUpdates := func(expected ...eth.HeadUpdate) {
+		for _, exp := range expected {
+			select {
+			case update := <-updates:
+				require.Equal(t, exp, update)
+			default:
+				t.Fatalf("missing head update %v", exp)
+			}
+		}
+		require.Empty(t, updates, "no unexpected head updates")
+	}
+
+	currentL1 := testutils.RandomBlockRef(rng)
+	st.OnEvent(derive.DeriverL1StatusEvent{Origin: currentL1})
+	expectUpdates()
+
+	finalizedL1 := testutils.RandomBlockRef(rng)
+	st.OnEvent(finality.FinalizeL1Event{FinalizedL1: finalizedL1})
+	expectUpdates()
+
+	unsafe := testutils.RandomL2BlockRef(rng)
+	safe := testutils.RandomL2BlockRef(rng)
+	finalized := testutils.RandomL2BlockRef(rng)
+	st.OnEvent(engine.ForkchoiceUpdateEvent{UnsafeL2Head: unsafe, SafeL2Head: safe, FinalizedL2Head: finalized})
+	expectUpdates(
+		eth.HeadUpdate{Kind: eth.HeadUpdateUnsafe, Head: unsafe, L1: unsafe.L1Origin},
+		eth.HeadUpdate{Kind: eth.HeadUpdateSafe, Head: safe, L1: currentL1.ID()},
+		eth.HeadUpdate{Kind: eth.HeadUpdateFinalized, Head: finalized, L1: finalizedL1.ID()},
+	)
+
+	// Only the changed head is reported
+	newUnsafe := testutils.NextRandomL2Ref(rng, 2, unsafe, unsafe.L1Origin)
+	st.OnEvent(engine.ForkchoiceUpdateEvent{UnsafeL2Head: newUnsafe, SafeL2Head: safe, FinalizedL2Head: finalized})
+	expectUpdates(eth.HeadUpdate{Kind: eth.HeadUpdateUnsafe, Head: newUnsafe, L1: newUnsafe.L1Origin})
+
+	// Heads cleared by a reset are not reported
+	st.OnEvent(rollup.ResetEvent{})
+	expectUpdates()
+}
+
+func TestHeadUpdates_SlowSubscriber(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	st := NewStatusTracker(testlog.Logger(t, log.LevelDebug), metrics.NoopMetrics)
+	// The slow subscriber never reads its updates
+	slowUpdates := make(chan eth.HeadUpdate, 1)
+	slowSub := st.SubscribeHeadUpdates(slowUpdates)
+	updates := make(chan eth.HeadUpdate, 10)
+	sub := st.SubscribeHeadUpdates(updates)
+	defer sub.Unsubscribe()
+
+	unsafe := testutils.RandomL2BlockRef(rng)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 3; i++ {
+			unsafe = testutils.NextRandomL2Ref(rng, 2, unsafe, unsafe.L1Origin)
+			st.OnEvent(engine.ForkchoiceUpdateEvent{UnsafeL2Head: unsafe})
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("event processing blocked by slow subscriber")
+	}
+
+	require.ErrorIs(t, <-slowSub.Err(), ErrSlowSubscriber)
+	_, open := <-slowSub.Err()
+	require.False(t, open, "error channel is closed after the error")
+	require.Len(t, slowUpdates, 1)
+	require.Len(t, updates, 3, "other subscribers still receive all updates")
+
+	// Unsubscribing after the subscription was closed is a no-op
+	slowSub.Unsubscribe()
+}
+
+func TestHeadUpdates_Unsubscribe(t *testing.T) {
+	st := NewStatusTracker(testlog.Logger(t, log.LevelDebug), metrics.NoopMetrics)
+	updates := make(chan eth.HeadUpdate, 10)
+	sub := st.SubscribeHeadUpdates(updates)
+	sub.Unsubscribe()
+	_, open := <-sub.Err()
+	require.False(t, open)
+	st.OnEvent(engine.ForkchoiceUpdateEvent{UnsafeL2Head: testutils.RandomL2BlockRef(rand.New(rand.NewSource(1)))})
+	require.Empty(t, updates)
+}
@@ -35,6 +35,8 @@ var (
 	ErrChainIDsSame                  = errors.New("L1 and L2 chain IDs must be different")
 	ErrL1ChainIDNotPositive          = errors.New("L1 chain ID must be non-zero and positive")
 	ErrL2ChainIDNotPositive          = errors.New("L2 chain ID must be non-zero and positive")
+	ErrInvalidSequencerDriftPolicy   = errors.New("invalid sequencer drift policy")
+	ErrZstdBeforeFjord               = errors.New("zstd channel compression must not activate before Fjord")
 )
 
 type Genesis struct {
@@ -63,6 +65,19 @@ type AltDAConfig struct {
 	DAResolveWindow uint64 `json:"da_resolve_window"`
 }
 
+// SequencerDriftPolicy defines the blocks that are produced once the sequencer drift is exhausted,
+// i.e. when the L2 time exceeds the L1 origin time by more than the max sequencer drift,
+// because the sequencer was offline or could not adopt a new L1 origin.
+type SequencerDriftPolicy string
+
+const (
+	// SequencerDriftDepositOnly produces deposit-only blocks. This is the default.
+	SequencerDriftDepositOnly SequencerDriftPolicy = "deposit-only"
+	// SequencerDriftOfflineMarker produces deposit-only blocks that additionally include a sequencer-offline
+	// system deposit, which marks the block as produced while the sequencer drift was exhausted.
+	SequencerDriftOfflineMarker SequencerDriftPolicy = "offline-marker"
+)
+
 type Config struct {
 	// Genesis anchor point of the rollup
 	Genesis Genesis `json:"genesis"`
@@ -79,6 +94,10 @@ type Config struct {
 	// the max sequencer drift for a given block based on the block's L1 origin.
 	// Chains that activate Fjord at genesis may leave this field empty.
 	MaxSequencerDrift uint64 `json:"max_sequencer_drift,omitempty"`
+	// SequencerDriftPolicy defines the blocks that are produced once the max sequencer drift is exceeded.
+	// This is part of the block-derivation process, and must be the same network-wide to stay in consensus.
+	// Deposit-only blocks are produced if empty.
+	SequencerDriftPolicy SequencerDriftPolicy `json:"sequencer_drift_policy,omitempty"`
 	// Number of epochs (L1 blocks) per sequencing window, including the epoch L1 origin block itself
 	SeqWindowSize uint64 `json:"seq_window_size"`
 	// Number of L1 blocks between when a channel can be opened and when it must be closed by.
@@ -122,6 +141,11 @@ type Config struct {
 	// Active if InteropTime != nil && L2 block timestamp >= *InteropTime, inactive otherwise.
 	InteropTime *uint64 `json:"interop_time,omitempty"`
 
+	// ZstdTime sets the activation time of zstd channel compression, an experimental feature activated like a hardfork.
+	// Channels compressed with zstd are only accepted from L1 blocks at or past this time.
+	// Active if ZstdTime != nil && L1 origin timestamp >= *ZstdTime, inactive otherwise.
+	ZstdTime *uint64 `json:"zstd_time,omitempty"`
+
 	// Note: below addresses are part of the block-derivation process,
 	// and required to be the same network-wide to stay in consensus.
 
@@ -262,6 +286,11 @@ func (cfg *Config) Check() error {
 	if cfg.MaxSequencerDrift == 0 {
 		return ErrInvalidMaxSeqDrift
 	}
+	switch cfg.SequencerDriftPolicy {
+	case "", SequencerDriftDepositOnly, SequencerDriftOfflineMarker:
+	default:
+		return fmt.Errorf("%w: %q", ErrInvalidSequencerDriftPolicy, cfg.SequencerDriftPolicy)
+	}
 	if cfg.Genesis.L1.Hash == (common.Hash{}) {
 		return ErrMissingGenesisL1Hash
 	}
@@ -326,6 +355,9 @@ func (cfg *Config) Check() error {
 	if err := checkFork(cfg.GraniteTime, cfg.HoloceneTime, Granite, Holocene); err != nil {
 		return err
 	}
+	if cfg.ZstdTime != nil && (cfg.FjordTime == nil || *cfg.ZstdTime < *cfg.FjordTime) {
+		return ErrZstdBeforeFjord
+	}
 
 	return nil
 }
@@ -407,6 +439,11 @@ func (c *Config) IsInterop(timestamp uint64) bool {
 	return c.InteropTime != nil && timestamp >= *c.InteropTime
 }
 
+// IsZstd returns true if zstd channel compression is active at or past the given timestamp.
+func (c *Config) IsZstd(timestamp uint64) bool {
+	return c.ZstdTime != nil && timestamp >= *c.ZstdTime
+}
+
 func (c *Config) IsRegolithActivationBlock(l2BlockTime uint64) bool {
 	return c.IsRegolith(l2BlockTime) &&
 		l2BlockTime >= c.BlockTime &&
@@ -575,8 +612,14 @@ func (c *Config) Description(l2Chains map[string]string) string {
 	banner += fmt.Sprintf("  - Granite: %s\n", fmtForkTimeOrUnset(c.GraniteTime))
 	banner += fmt.Sprintf("  - Holocene: %s\n", fmtForkTimeOrUnset(c.HoloceneTime))
 	banner += fmt.Sprintf("  - Interop: %s\n", fmtForkTimeOrUnset(c.InteropTime))
+	if c.ZstdTime != nil {
+		banner += fmt.Sprintf("Zstd channel compression: %s\n", fmtForkTimeOrUnset(c.ZstdTime))
+	}
 	// Report the protocol version
 	banner += fmt.Sprintf("Node supports up to OP-Stack Protocol Version: %s\n", OPStackSupport)
+	if c.SequencerDriftPolicy == SequencerDriftOfflineMarker {
+		banner += "Sequencer-offline markers are included once the sequencer drift is exceeded\n"
+	}
 	if c.AltDAConfig != nil {
 		banner += fmt.Sprintf("Node supports Alt-DA Mode with CommitmentType %v\n", c.AltDAConfig.CommitmentType)
 	}
@@ -611,6 +654,8 @@ func (c *Config) LogDescription(log log.Logger, l2Chains map[string]string) {
 		"granite_time", fmtForkTimeOrUnset(c.GraniteTime),
 		"holocene_time", fmtForkTimeOrUnset(c.HoloceneTime),
 		"interop_time", fmtForkTimeOrUnset(c.InteropTime),
+		"zstd_time", fmtForkTimeOrUnset(c.ZstdTime),
+		"sequencer_drift_policy", c.SequencerDriftPolicy,
 		"alt_da", c.AltDAConfig != nil,
 	)
 }
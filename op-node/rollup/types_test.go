@@ -389,6 +389,26 @@ func TestConfig_Check(t *testing.T) {
 			modifier:    func(cfg *Config) { cfg.SeqWindowSize = 1 },
 			expectedErr: ErrInvalidSeqWindowSize,
 		},
+		{
+			name:        "UnknownSequencerDriftPolicy",
+			modifier:    func(cfg *Config) { cfg.SequencerDriftPolicy = "unknown" },
+			expectedErr: ErrInvalidSequencerDriftPolicy,
+		},
+		{
+			name:        "ZstdWithoutFjord",
+			modifier:    func(cfg *Config) { cfg.ZstdTime = new(uint64) },
+			expectedErr: ErrZstdBeforeFjord,
+		},
+		{
+			name: "ZstdBeforeFjord",
+			modifier: func(cfg *Config) {
+				regolithTime, canyonTime, deltaTime, ecotoneTime := uint64(1), uint64(1), uint64(1), uint64(1)
+				fjordTime, zstdTime := uint64(10), uint64(9)
+				cfg.RegolithTime, cfg.CanyonTime, cfg.DeltaTime, cfg.EcotoneTime = &regolithTime, &canyonTime, &deltaTime, &ecotoneTime
+				cfg.FjordTime, cfg.ZstdTime = &fjordTime, &zstdTime
+			},
+			expectedErr: ErrZstdBeforeFjord,
+		},
 		{
 			name:        "NoL1Genesis",
 			modifier:    func(cfg *Config) { cfg.Genesis.L1.Hash = common.Hash{} },
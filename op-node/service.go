@@ -12,6 +12,8 @@ import (
 	altda "github.com/ethereum-optimism/optimism/op-alt-da"
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
+	"github.com/ethereum-optimism/optimism/op-service/optracing"
+	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -24,7 +26,9 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/sequencing"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
+	"github.com/ethereum-optimism/optimism/op-service/client"
 	opflags "github.com/ethereum-optimism/optimism/op-service/flags"
 )
 
@@ -48,7 +52,7 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 
 	driverConfig := NewDriverConfig(ctx)
 
-	p2pSignerSetup, err := p2pcli.LoadSignerSetup(ctx)
+	p2pSignerSetup, err := p2pcli.LoadSignerSetup(ctx, log)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load p2p signer: %w", err)
 	}
@@ -58,7 +62,15 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 		return nil, fmt.Errorf("failed to load p2p config: %w", err)
 	}
 
-	l1Endpoint := NewL1EndpointConfig(ctx)
+	l1Endpoint, err := NewL1EndpointConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load l1 endpoints info: %w", err)
+	}
+
+	beaconEndpoint, err := NewBeaconEndpointConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load l1 beacon endpoints info: %w", err)
+	}
 
 	l2Endpoint, err := NewL2EndpointConfig(ctx, log)
 	if err != nil {
@@ -70,6 +82,16 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 		return nil, fmt.Errorf("failed to create the sync config: %w", err)
 	}
 
+	checkpointConfig, err := NewCheckpointConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load l2 checkpoint config: %w", err)
+	}
+
+	rpcLimits, err := oprpc.ReadLimitsConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load rpc limits: %w", err)
+	}
+
 	haltOption := ctx.String(flags.RollupHalt.Name)
 	if haltOption == "none" {
 		haltOption = ""
@@ -86,12 +108,13 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 		L2:         l2Endpoint,
 		Rollup:     *rollupConfig,
 		Driver:     *driverConfig,
-		Beacon:     NewBeaconEndpointConfig(ctx),
+		Beacon:     beaconEndpoint,
 		Supervisor: NewSupervisorEndpointConfig(ctx),
 		RPC: node.RPCConfig{
 			ListenAddr:  ctx.String(flags.RPCListenAddr.Name),
 			ListenPort:  ctx.Int(flags.RPCListenPort.Name),
 			EnableAdmin: ctx.Bool(flags.RPCEnableAdmin.Name),
+			Limits:      rpcLimits,
 		},
 		Metrics: node.MetricsConfig{
 			Enabled:    ctx.Bool(flags.MetricsEnabledFlag.Name),
@@ -99,6 +122,7 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 			ListenPort: ctx.Int(flags.MetricsPortFlag.Name),
 		},
 		Pprof:                       oppprof.ReadCLIConfig(ctx),
+		Tracing:                     optracing.ReadCLIConfig(ctx),
 		P2P:                         p2pConfig,
 		P2PSigner:                   p2pSignerSetup,
 		L1EpochPollInterval:         ctx.Duration(flags.L1EpochPollIntervalFlag.Name),
@@ -106,6 +130,8 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 		ConfigPersistence:           configPersistence,
 		SafeDBPath:                  ctx.String(flags.SafeDBPath.Name),
 		Sync:                        *syncConfig,
+		Checkpoint:                  checkpointConfig,
+		L1FinalityCheckpoint:        NewL1FinalityCheckpointConfig(ctx),
 		RollupHalt:                  haltOption,
 
 		ConductorEnabled:    ctx.Bool(flags.ConductorEnabledFlag.Name),
@@ -115,6 +141,10 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 		AltDA: altda.ReadCLIConfig(ctx),
 	}
 
+	if err := applyRecordReplay(ctx, cfg, l1Endpoint); err != nil {
+		return nil, err
+	}
+
 	if err := cfg.LoadPersisted(log); err != nil {
 		return nil, fmt.Errorf("failed to load driver config: %w", err)
 	}
@@ -130,32 +160,107 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 	return cfg, nil
 }
 
+// applyRecordReplay wraps the L1, L1 Beacon and L2 endpoints of the config to record their responses,
+// or to replay previously recorded responses instead of connecting to them.
+func applyRecordReplay(ctx *cli.Context, cfg *node.Config, l1Endpoint *node.L1EndpointConfig) error {
+	recordDir := ctx.String(flags.RecordDir.Name)
+	replayDir := ctx.String(flags.ReplayDir.Name)
+	switch {
+	case recordDir != "" && replayDir != "":
+		return fmt.Errorf("flags %s and %s cannot be used together", flags.RecordDir.Name, flags.ReplayDir.Name)
+	case recordDir != "":
+		cfg.L1 = &node.RecordingL1Endpoint{L1EndpointSetup: cfg.L1, Dir: recordDir}
+		cfg.L2 = &node.RecordingL2Endpoint{L2EndpointSetup: cfg.L2, Dir: recordDir}
+		if cfg.Beacon != nil {
+			cfg.Beacon = &node.RecordingL1BeaconEndpoint{L1BeaconEndpointSetup: cfg.Beacon, Dir: recordDir}
+		}
+	case replayDir != "":
+		cfg.L1 = &node.ReplayL1Endpoint{Config: l1Endpoint, Dir: replayDir}
+		cfg.L2 = &node.ReplayL2Endpoint{Dir: replayDir}
+		if cfg.Beacon != nil {
+			cfg.Beacon = &node.ReplayL1BeaconEndpoint{
+				L1BeaconEndpointSetup: cfg.Beacon,
+				Dir:                   replayDir,
+				Fallbacks:             len(ctx.StringSlice(flags.BeaconFallbackAddrs.Name)),
+				Archive:               ctx.String(flags.BeaconArchiveAddr.Name) != "",
+			}
+		}
+	}
+	return nil
+}
+
 func NewSupervisorEndpointConfig(ctx *cli.Context) node.SupervisorEndpointSetup {
 	return &node.SupervisorEndpointConfig{
 		SupervisorAddr: ctx.String(flags.SupervisorAddr.Name),
 	}
 }
 
-func NewBeaconEndpointConfig(ctx *cli.Context) node.L1BeaconEndpointSetup {
+func NewBeaconEndpointConfig(ctx *cli.Context) (node.L1BeaconEndpointSetup, error) {
+	rateLimits, err := parseRateLimits(ctx.StringSlice(flags.BeaconRateLimits.Name), 1)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", flags.BeaconRateLimits.Name, err)
+	}
 	return &node.L1BeaconEndpointConfig{
 		BeaconAddr:             ctx.String(flags.BeaconAddr.Name),
 		BeaconHeader:           ctx.String(flags.BeaconHeader.Name),
 		BeaconFallbackAddrs:    ctx.StringSlice(flags.BeaconFallbackAddrs.Name),
+		BeaconArchiveAddr:      ctx.String(flags.BeaconArchiveAddr.Name),
 		BeaconCheckIgnore:      ctx.Bool(flags.BeaconCheckIgnore.Name),
 		BeaconFetchAllSidecars: ctx.Bool(flags.BeaconFetchAllSidecars.Name),
-	}
+		BeaconRateLimits:       rateLimits,
+	}, nil
 }
 
-func NewL1EndpointConfig(ctx *cli.Context) *node.L1EndpointConfig {
+func NewL1EndpointConfig(ctx *cli.Context) (*node.L1EndpointConfig, error) {
+	burst := ctx.Int(flags.L1RPCRateLimitBurst.Name)
+	if burst == 0 {
+		burst = ctx.Int(flags.L1RPCMaxBatchSize.Name)
+	}
+	rateLimits, err := parseRateLimits(ctx.StringSlice(flags.L1RPCEndpointRateLimits.Name), burst)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", flags.L1RPCEndpointRateLimits.Name, err)
+	}
 	return &node.L1EndpointConfig{
 		L1NodeAddr:       ctx.String(flags.L1NodeAddr.Name),
+		L1FallbackAddrs:  ctx.StringSlice(flags.L1FallbackAddrs.Name),
+		L1Quorum:         ctx.Int(flags.L1Quorum.Name),
 		L1TrustRPC:       ctx.Bool(flags.L1TrustRPC.Name),
 		L1RPCKind:        sources.RPCProviderKind(strings.ToLower(ctx.String(flags.L1RPCProviderKind.Name))),
 		RateLimit:        ctx.Float64(flags.L1RPCRateLimit.Name),
+		RateLimitBurst:   ctx.Int(flags.L1RPCRateLimitBurst.Name),
 		BatchSize:        ctx.Int(flags.L1RPCMaxBatchSize.Name),
 		HttpPollInterval: ctx.Duration(flags.L1HTTPPollInterval.Name),
 		MaxConcurrency:   ctx.Int(flags.L1RPCMaxConcurrency.Name),
+
+		EndpointRateLimits: rateLimits,
+
+		DetectReceiptsMethods: ctx.Bool(flags.L1RPCDetectReceiptsMethods.Name),
+		ReceiptsCacheMaxBytes: ctx.Int(flags.L1ReceiptsCacheMaxBytes.Name),
+		CircuitBreaker: client.CircuitBreakerConfig{
+			FailureThreshold: ctx.Int(flags.L1RPCCircuitBreakerThreshold.Name),
+			OpenDuration:     ctx.Duration(flags.L1RPCCircuitBreakerOpenDuration.Name),
+		},
+	}, nil
+}
+
+// parseRateLimits parses rate-limits of the form <endpoint>=<requests / second>[:<burst>], by endpoint name.
+func parseRateLimits(values []string, defaultBurst int) (map[string]client.RateBudget, error) {
+	rateLimits := make(map[string]client.RateBudget, len(values))
+	for _, v := range values {
+		name, budgetStr, ok := strings.Cut(v, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("expected <endpoint>=<requests / second>[:<burst>], got %q", v)
+		}
+		if _, ok := rateLimits[name]; ok {
+			return nil, fmt.Errorf("duplicate rate-limit of endpoint %q", name)
+		}
+		budget, err := client.ParseRateBudget(budgetStr, defaultBurst)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate-limit of endpoint %q: %w", name, err)
+		}
+		rateLimits[name] = budget
 	}
+	return rateLimits, nil
 }
 
 func NewL2EndpointConfig(ctx *cli.Context, log log.Logger) (*node.L2EndpointConfig, error) {
@@ -167,11 +272,10 @@ func NewL2EndpointConfig(ctx *cli.Context, log log.Logger) (*node.L2EndpointConf
 		return nil, fmt.Errorf("file-name of jwt secret is empty")
 	}
 	if data, err := os.ReadFile(fileName); err == nil {
-		jwtSecret := common.FromHex(strings.TrimSpace(string(data)))
-		if len(jwtSecret) != 32 {
-			return nil, fmt.Errorf("invalid jwt secret in path %s, not 32 hex-formatted bytes", fileName)
+		secret, err = client.ParseJWTSecret(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", fileName, err)
 		}
-		copy(secret[:], jwtSecret)
 	} else {
 		log.Warn("Failed to read JWT secret from file, generating a new one now. Configure L2 geth with --authrpc.jwt-secret=" + fmt.Sprintf("%q", fileName))
 		if _, err := io.ReadFull(rand.Reader, secret[:]); err != nil {
@@ -183,8 +287,11 @@ func NewL2EndpointConfig(ctx *cli.Context, log log.Logger) (*node.L2EndpointConf
 	}
 
 	return &node.L2EndpointConfig{
-		L2EngineAddr:      l2Addr,
-		L2EngineJWTSecret: secret,
+		L2EngineAddr:              l2Addr,
+		L2EngineJWTSecret:         secret,
+		L2EngineJWTSecretPath:     fileName,
+		L2StandbyEngineAddr:       ctx.String(flags.L2StandbyEngineAddr.Name),
+		L2EngineFailoverThreshold: ctx.Int(flags.L2EngineFailoverThreshold.Name),
 	}, nil
 }
 
@@ -198,11 +305,24 @@ func NewConfigPersistence(ctx *cli.Context) node.ConfigPersistence {
 
 func NewDriverConfig(ctx *cli.Context) *driver.Config {
 	return &driver.Config{
-		VerifierConfDepth:   ctx.Uint64(flags.VerifierL1Confs.Name),
-		SequencerConfDepth:  ctx.Uint64(flags.SequencerL1Confs.Name),
-		SequencerEnabled:    ctx.Bool(flags.SequencerEnabledFlag.Name),
-		SequencerStopped:    ctx.Bool(flags.SequencerStoppedFlag.Name),
-		SequencerMaxSafeLag: ctx.Uint64(flags.SequencerMaxSafeLagFlag.Name),
+		VerifierConfDepth:    ctx.Uint64(flags.VerifierL1Confs.Name),
+		L1ConfirmationDepth:  ctx.Uint64(flags.L1ConfirmationDepth.Name),
+		VerifierStallTimeout: ctx.Duration(flags.VerifierStallTimeout.Name),
+		EventTraceSize:       ctx.Int(flags.EventTraceSizeFlag.Name),
+		EventLatencyBudget:   ctx.Duration(flags.EventLatencyBudgetFlag.Name),
+		EventDeadlockTimeout: ctx.Duration(flags.EventDeadlockTimeoutFlag.Name),
+		SequencerConfDepth:   ctx.Uint64(flags.SequencerL1Confs.Name),
+		SequencerEnabled:     ctx.Bool(flags.SequencerEnabledFlag.Name),
+		SequencerStopped:     ctx.Bool(flags.SequencerStoppedFlag.Name),
+		SequencerMaxSafeLag:  ctx.Uint64(flags.SequencerMaxSafeLagFlag.Name),
+		SequencerThrottle: sequencing.ThrottleConfig{
+			SlowLatency:   ctx.Duration(flags.SequencerThrottleSlowLatencyFlag.Name),
+			PauseLatency:  ctx.Duration(flags.SequencerThrottlePauseLatencyFlag.Name),
+			MaxBacklog:    ctx.Uint64(flags.SequencerThrottleMaxBacklogFlag.Name),
+			SlowDelay:     ctx.Duration(flags.SequencerThrottleSlowDelayFlag.Name),
+			PauseDuration: ctx.Duration(flags.SequencerThrottlePauseDurationFlag.Name),
+		},
+		InteropDependencySet: ctx.Uint64Slice(flags.InteropDependencySet.Name),
 	}
 }
 
@@ -277,6 +397,46 @@ func applyOverrides(ctx *cli.Context, rollupConfig *rollup.Config) {
 	}
 }
 
+func NewCheckpointConfig(ctx *cli.Context) (node.CheckpointConfig, error) {
+	blockHash, err := parseHashFlag(ctx, flags.L2CheckpointHashFlag.Name)
+	if err != nil {
+		return node.CheckpointConfig{}, err
+	}
+	outputRoot, err := parseHashFlag(ctx, flags.L2CheckpointOutputRootFlag.Name)
+	if err != nil {
+		return node.CheckpointConfig{}, err
+	}
+	return node.CheckpointConfig{
+		BlockHash:  blockHash,
+		OutputRoot: outputRoot,
+		RPC:        ctx.String(flags.L2CheckpointRPCFlag.Name),
+	}, nil
+}
+
+// parseHashFlag parses the value of the named flag as a 0x-prefixed 32 byte hash.
+// It returns the zero hash if the flag is not set.
+func parseHashFlag(ctx *cli.Context, name string) (common.Hash, error) {
+	value := ctx.String(name)
+	if value == "" {
+		return common.Hash{}, nil
+	}
+	b, err := hexutil.Decode(value)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("invalid %s %q: %w", name, value, err)
+	}
+	if len(b) != common.HashLength {
+		return common.Hash{}, fmt.Errorf("invalid %s %q: expected %d bytes but got %d", name, value, common.HashLength, len(b))
+	}
+	return common.BytesToHash(b), nil
+}
+
+func NewL1FinalityCheckpointConfig(ctx *cli.Context) node.L1FinalityCheckpointConfig {
+	return node.L1FinalityCheckpointConfig{
+		Hash:   common.HexToHash(ctx.String(flags.L1FinalizedCheckpointHashFlag.Name)),
+		Number: ctx.Uint64(flags.L1FinalizedCheckpointNumberFlag.Name),
+	}
+}
+
 func NewSyncConfig(ctx *cli.Context, log log.Logger) (*sync.Config, error) {
 	if ctx.IsSet(flags.L2EngineSyncEnabled.Name) && ctx.IsSet(flags.SyncModeFlag.Name) {
 		return nil, errors.New("cannot set both --l2.engine-sync and --syncmode at the same time")
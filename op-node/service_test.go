@@ -0,0 +1,58 @@
+package opnode
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-node/flags"
+)
+
+func TestNewCheckpointConfig(t *testing.T) {
+	hash := common.Hash{0xaa}
+	outputRoot := common.Hash{0xbb}
+	parse := func(t *testing.T, args ...string) *cli.Context {
+		set := flag.NewFlagSet("test", flag.ContinueOnError)
+		for _, f := range []cli.Flag{flags.L2CheckpointHashFlag, flags.L2CheckpointOutputRootFlag, flags.L2CheckpointRPCFlag} {
+			require.NoError(t, f.Apply(set))
+		}
+		require.NoError(t, set.Parse(args))
+		return cli.NewContext(cli.NewApp(), set, nil)
+	}
+
+	t.Run("Unset", func(t *testing.T) {
+		cfg, err := NewCheckpointConfig(parse(t))
+		require.NoError(t, err)
+		require.False(t, cfg.Enabled())
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg, err := NewCheckpointConfig(parse(t,
+			"--l2.checkpoint.hash", hash.Hex(),
+			"--l2.checkpoint.output-root", outputRoot.Hex(),
+			"--l2.checkpoint.rpc", "http://localhost:8545"))
+		require.NoError(t, err)
+		require.Equal(t, hash, cfg.BlockHash)
+		require.Equal(t, outputRoot, cfg.OutputRoot)
+		require.Equal(t, "http://localhost:8545", cfg.RPC)
+	})
+
+	for _, invalid := range []string{
+		"0xaa",                       // too short
+		hash.Hex() + "00",            // too long
+		hash.Hex()[2:],               // missing 0x prefix
+		"0x" + "zz" + hash.Hex()[4:], // not hex
+	} {
+		t.Run("InvalidHash-"+invalid, func(t *testing.T) {
+			_, err := NewCheckpointConfig(parse(t, "--l2.checkpoint.hash", invalid))
+			require.ErrorContains(t, err, "invalid l2.checkpoint.hash")
+		})
+		t.Run("InvalidOutputRoot-"+invalid, func(t *testing.T) {
+			_, err := NewCheckpointConfig(parse(t, "--l2.checkpoint.hash", hash.Hex(), "--l2.checkpoint.output-root", invalid))
+			require.ErrorContains(t, err, "invalid l2.checkpoint.output-root")
+		})
+	}
+}
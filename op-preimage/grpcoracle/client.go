@@ -0,0 +1,130 @@
+package grpcoracle
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-preimage/grpcoracle/pb"
+)
+
+// Client implements the pre-image Oracle and Hinter over a gRPC stream to a pre-image server.
+// Hints are buffered, and sent along with the next pre-image request.
+type Client struct {
+	conn   *grpc.ClientConn
+	stream pb.PreimageOracle_PreimagesClient
+	cancel context.CancelFunc
+
+	mu    sync.Mutex
+	hints []string
+}
+
+var (
+	_ preimage.Oracle = (*Client)(nil)
+	_ preimage.Hinter = (*Client)(nil)
+)
+
+// NewClient connects to the pre-image server at addr, authenticating with authToken if it is not empty.
+// The connection is secured with tlsConfig, which is required if an auth token is used, or insecure if nil.
+// Addresses prefixed with unix:// are unix domain socket paths.
+func NewClient(ctx context.Context, addr string, authToken string, tlsConfig *tls.Config) (*Client, error) {
+	if authToken != "" && tlsConfig == nil {
+		return nil, ErrAuthTokenWithoutTLS
+	}
+	transportCreds := insecure.NewCredentials()
+	if tlsConfig != nil {
+		transportCreds = credentials.NewTLS(tlsConfig)
+	}
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(transportCreds),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(MaxMessageSize), grpc.MaxCallSendMsgSize(MaxMessageSize)),
+	}
+	if authToken != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(bearerToken(authToken)))
+	}
+	conn, err := grpc.NewClient(addr, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pre-image client: %w", err)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	stream, err := pb.NewPreimageOracleClient(conn).Preimages(ctx)
+	if err != nil {
+		cancel()
+		_ = conn.Close()
+		return nil, fmt.Errorf("failed to open pre-image stream: %w", err)
+	}
+	return &Client{
+		conn:   conn,
+		stream: stream,
+		cancel: cancel,
+	}, nil
+}
+
+// Get implements the Oracle interface, and panics if the pre-image cannot be retrieved.
+func (c *Client) Get(key preimage.Key) []byte {
+	values, err := c.GetBatch([]preimage.Key{key})
+	if err != nil {
+		panic(fmt.Errorf("failed to get pre-image of key %s (%T): %w", key, key, err))
+	}
+	return values[0]
+}
+
+// GetBatch retrieves the pre-images of all keys with a single request, in the order of the keys.
+func (c *Client) GetBatch(keys []preimage.Key) ([][]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	req := &pb.PreimageRequest{
+		Hints: c.hints,
+		Keys:  make([][]byte, len(keys)),
+	}
+	for i, key := range keys {
+		k := key.PreimageKey()
+		req.Keys[i] = k[:]
+	}
+	if err := c.stream.Send(req); err != nil {
+		return nil, fmt.Errorf("failed to send pre-image request: %w", err)
+	}
+	c.hints = nil
+	resp, err := c.stream.Recv()
+	if err != nil {
+		return nil, fmt.Errorf("failed to receive pre-image response: %w", err)
+	}
+	if len(resp.Values) != len(keys) {
+		return nil, fmt.Errorf("expected %d pre-images but got %d", len(keys), len(resp.Values))
+	}
+	return resp.Values, nil
+}
+
+// Hint implements the Hinter interface.
+// The hint is sent to the server with the next pre-image request.
+func (c *Client) Hint(v preimage.Hint) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.hints = append(c.hints, v.Hint())
+}
+
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_ = c.stream.CloseSend()
+	c.cancel()
+	return c.conn.Close()
+}
+
+// bearerToken authenticates every request with a static bearer token.
+type bearerToken string
+
+func (t bearerToken) GetRequestMetadata(_ context.Context, _ ...string) (map[string]string, error) {
+	return map[string]string{authMetadataKey: bearerPrefix + string(t)}, nil
+}
+
+// RequireTransportSecurity returns true, so the token is never sent over an insecure connection.
+func (t bearerToken) RequireTransportSecurity() bool {
+	return true
+}
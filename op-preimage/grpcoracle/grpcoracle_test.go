@@ -0,0 +1,218 @@
+package grpcoracle
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	optls "github.com/ethereum-optimism/optimism/op-service/tls"
+)
+
+type rawHint string
+
+func (h rawHint) Hint() string {
+	return string(h)
+}
+
+type testHost struct {
+	preimages map[[32]byte][]byte
+	hints     []string
+}
+
+func (h *testHost) get(key [32]byte) ([]byte, error) {
+	v, ok := h.preimages[key]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return v, nil
+}
+
+func (h *testHost) hint(hint string) error {
+	h.hints = append(h.hints, hint)
+	// Hints make pre-images available, like the prefetcher does
+	data := []byte(hint)
+	h.preimages[preimage.Keccak256Key(crypto.Keccak256Hash(data)).PreimageKey()] = data
+	return nil
+}
+
+func startServer(t *testing.T, addr string, authToken string, tlsConfig *tls.Config) (*testHost, string) {
+	host := &testHost{preimages: make(map[[32]byte][]byte)}
+	srv, err := NewGRPCServer(testlog.Logger(t, log.LevelError), host.get, host.hint, authToken, tlsConfig)
+	require.NoError(t, err)
+	lis, err := Listen(addr)
+	require.NoError(t, err)
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+	t.Cleanup(srv.Stop)
+	return host, lis.Addr().String()
+}
+
+func newTestClient(t *testing.T, addr string, authToken string, tlsConfig *tls.Config) *Client {
+	cl, err := NewClient(context.Background(), addr, authToken, tlsConfig)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = cl.Close()
+	})
+	return cl
+}
+
+// connectAndGet connects to the server and requests an empty batch.
+// Connection errors may be reported when opening the stream, or on the first request.
+func connectAndGet(addr string, tlsConfig *tls.Config) error {
+	cl, err := NewClient(context.Background(), addr, "", tlsConfig)
+	if err != nil {
+		return err
+	}
+	defer cl.Close()
+	_, err = cl.GetBatch(nil)
+	return err
+}
+
+func TestGRPCOracle(t *testing.T) {
+	t.Run("GetBatch", func(t *testing.T) {
+		host, addr := startServer(t, "127.0.0.1:0", "", nil)
+		cl := newTestClient(t, addr, "", nil)
+		key1 := preimage.Keccak256Key(crypto.Keccak256Hash([]byte("a")))
+		key2 := preimage.LocalIndexKey(1)
+		host.preimages[key1.PreimageKey()] = []byte("a")
+		host.preimages[key2.PreimageKey()] = []byte("local")
+
+		values, err := cl.GetBatch([]preimage.Key{key1, key2})
+		require.NoError(t, err)
+		require.Equal(t, [][]byte{[]byte("a"), []byte("local")}, values)
+		require.Equal(t, []byte("local"), cl.Get(key2))
+	})
+
+	t.Run("HintsSentWithNextRequest", func(t *testing.T) {
+		host, addr := startServer(t, "127.0.0.1:0", "", nil)
+		cl := newTestClient(t, addr, "", nil)
+		cl.Hint(rawHint("hello"))
+		cl.Hint(rawHint("world"))
+		require.Equal(t, []byte("world"), cl.Get(preimage.Keccak256Key(crypto.Keccak256Hash([]byte("world")))))
+		require.Equal(t, []string{"hello", "world"}, host.hints)
+	})
+
+	t.Run("MissingPreimage", func(t *testing.T) {
+		_, addr := startServer(t, "127.0.0.1:0", "", nil)
+		cl := newTestClient(t, addr, "", nil)
+		_, err := cl.GetBatch([]preimage.Key{preimage.LocalIndexKey(1)})
+		require.ErrorContains(t, err, "not found")
+		require.Panics(t, func() {
+			cl.Get(preimage.LocalIndexKey(1))
+		})
+	})
+
+	t.Run("Auth", func(t *testing.T) {
+		serverTLS, clientTLS := testTLSConfigs(t)
+		host, addr := startServer(t, "127.0.0.1:0", "secret", serverTLS)
+		key := preimage.LocalIndexKey(1)
+		host.preimages[key.PreimageKey()] = []byte("local")
+
+		_, err := newTestClient(t, addr, "", clientTLS).GetBatch([]preimage.Key{key})
+		require.ErrorContains(t, err, "invalid auth token")
+		_, err = newTestClient(t, addr, "wrong", clientTLS).GetBatch([]preimage.Key{key})
+		require.ErrorContains(t, err, "invalid auth token")
+		require.Equal(t, []byte("local"), newTestClient(t, addr, "secret", clientTLS).Get(key))
+	})
+
+	t.Run("AuthRequiresTLS", func(t *testing.T) {
+		_, err := NewGRPCServer(testlog.Logger(t, log.LevelError), nil, nil, "secret", nil)
+		require.ErrorIs(t, err, ErrAuthTokenWithoutTLS)
+		_, err = NewClient(context.Background(), "127.0.0.1:0", "secret", nil)
+		require.ErrorIs(t, err, ErrAuthTokenWithoutTLS)
+	})
+
+	t.Run("TLSRequiresClientCert", func(t *testing.T) {
+		serverTLS, clientTLS := testTLSConfigs(t)
+		_, addr := startServer(t, "127.0.0.1:0", "", serverTLS)
+		noCert := &tls.Config{MinVersion: tls.VersionTLS13, RootCAs: clientTLS.RootCAs}
+		require.Error(t, connectAndGet(addr, noCert))
+		require.Error(t, connectAndGet(addr, nil), "insecure clients are rejected")
+		require.NoError(t, connectAndGet(addr, clientTLS))
+	})
+
+	t.Run("UnixSocket", func(t *testing.T) {
+		addr := "unix://" + filepath.Join(t.TempDir(), "preimage.sock")
+		host, _ := startServer(t, addr, "", nil)
+		key := preimage.LocalIndexKey(1)
+		host.preimages[key.PreimageKey()] = []byte("local")
+		require.Equal(t, []byte("local"), newTestClient(t, addr, "", nil).Get(key))
+	})
+}
+
+// testTLSConfigs creates a CA, and server and client TLS configs with certificates signed by it.
+func testTLSConfigs(t *testing.T) (*tls.Config, *tls.Config) {
+	dir := t.TempDir()
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+	caCert, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+	writePEM(t, filepath.Join(dir, "ca.crt"), "CERTIFICATE", caDER)
+
+	writeCert := func(name string, serial int64) optls.CLIConfig {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(serial),
+			Subject:      pkix.Name{CommonName: name},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+			DNSNames:     []string{"localhost"},
+			IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, caCert, &key.PublicKey, caKey)
+		require.NoError(t, err)
+		keyDER, err := x509.MarshalECPrivateKey(key)
+		require.NoError(t, err)
+		cfg := optls.CLIConfig{
+			TLSCaCert: filepath.Join(dir, "ca.crt"),
+			TLSCert:   filepath.Join(dir, name+".crt"),
+			TLSKey:    filepath.Join(dir, name+".key"),
+		}
+		writePEM(t, cfg.TLSCert, "CERTIFICATE", der)
+		writePEM(t, cfg.TLSKey, "EC PRIVATE KEY", keyDER)
+		return cfg
+	}
+	logger := testlog.Logger(t, log.LevelError)
+	serverTLS, err := ServerTLSConfig(logger, writeCert("server", 2))
+	require.NoError(t, err)
+	clientTLS, err := ClientTLSConfig(logger, writeCert("client", 3))
+	require.NoError(t, err)
+	return serverTLS, clientTLS
+}
+
+func writePEM(t *testing.T, path string, blockType string, der []byte) {
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600))
+}
@@ -0,0 +1,230 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v5.27.3
+// source: preimage.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PreimageRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// hints are processed in order, before any of the keys are served.
+	Hints []string `protobuf:"bytes,1,rep,name=hints,proto3" json:"hints,omitempty"`
+	// keys are the 32-byte type-prefixed pre-image keys to serve.
+	Keys [][]byte `protobuf:"bytes,2,rep,name=keys,proto3" json:"keys,omitempty"`
+}
+
+func (x *PreimageRequest) Reset() {
+	*x = PreimageRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_preimage_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PreimageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreimageRequest) ProtoMessage() {}
+
+func (x *PreimageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_preimage_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreimageRequest.ProtoReflect.Descriptor instead.
+func (*PreimageRequest) Descriptor() ([]byte, []int) {
+	return file_preimage_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PreimageRequest) GetHints() []string {
+	if x != nil {
+		return x.Hints
+	}
+	return nil
+}
+
+func (x *PreimageRequest) GetKeys() [][]byte {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+type PreimageResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// values are the pre-images of the requested keys, in the order of the keys.
+	Values [][]byte `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+}
+
+func (x *PreimageResponse) Reset() {
+	*x = PreimageResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_preimage_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PreimageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreimageResponse) ProtoMessage() {}
+
+func (x *PreimageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_preimage_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreimageResponse.ProtoReflect.Descriptor instead.
+func (*PreimageResponse) Descriptor() ([]byte, []int) {
+	return file_preimage_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PreimageResponse) GetValues() [][]byte {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+var File_preimage_proto protoreflect.FileDescriptor
+
+var file_preimage_proto_rawDesc = []byte{
+	0x0a, 0x0e, 0x70, 0x72, 0x65, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x14, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x73, 0x6d, 0x2e, 0x70, 0x72, 0x65, 0x69, 0x6d,
+	0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x22, 0x3b, 0x0a, 0x0f, 0x50, 0x72, 0x65, 0x69, 0x6d, 0x61,
+	0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x68, 0x69, 0x6e,
+	0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x68, 0x69, 0x6e, 0x74, 0x73, 0x12,
+	0x12, 0x0a, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x04, 0x6b,
+	0x65, 0x79, 0x73, 0x22, 0x2a, 0x0a, 0x10, 0x50, 0x72, 0x65, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x32,
+	0x70, 0x0a, 0x0e, 0x50, 0x72, 0x65, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x4f, 0x72, 0x61, 0x63, 0x6c,
+	0x65, 0x12, 0x5e, 0x0a, 0x09, 0x50, 0x72, 0x65, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x12, 0x25,
+	0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x73, 0x6d, 0x2e, 0x70, 0x72, 0x65, 0x69, 0x6d, 0x61,
+	0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x65, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x73, 0x6d,
+	0x2e, 0x70, 0x72, 0x65, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x65,
+	0x69, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x28, 0x01, 0x30,
+	0x01, 0x42, 0x41, 0x5a, 0x3f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2d, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x73,
+	0x6d, 0x2f, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x73, 0x6d, 0x2f, 0x6f, 0x70, 0x2d, 0x70, 0x72,
+	0x65, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x6f, 0x72, 0x61, 0x63, 0x6c,
+	0x65, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_preimage_proto_rawDescOnce sync.Once
+	file_preimage_proto_rawDescData = file_preimage_proto_rawDesc
+)
+
+func file_preimage_proto_rawDescGZIP() []byte {
+	file_preimage_proto_rawDescOnce.Do(func() {
+		file_preimage_proto_rawDescData = protoimpl.X.CompressGZIP(file_preimage_proto_rawDescData)
+	})
+	return file_preimage_proto_rawDescData
+}
+
+var file_preimage_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_preimage_proto_goTypes = []any{
+	(*PreimageRequest)(nil),  // 0: optimism.preimage.v1.PreimageRequest
+	(*PreimageResponse)(nil), // 1: optimism.preimage.v1.PreimageResponse
+}
+var file_preimage_proto_depIdxs = []int32{
+	0, // 0: optimism.preimage.v1.PreimageOracle.Preimages:input_type -> optimism.preimage.v1.PreimageRequest
+	1, // 1: optimism.preimage.v1.PreimageOracle.Preimages:output_type -> optimism.preimage.v1.PreimageResponse
+	1, // [1:2] is the sub-list for method output_type
+	0, // [0:1] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_preimage_proto_init() }
+func file_preimage_proto_init() {
+	if File_preimage_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_preimage_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*PreimageRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_preimage_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*PreimageResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_preimage_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_preimage_proto_goTypes,
+		DependencyIndexes: file_preimage_proto_depIdxs,
+		MessageInfos:      file_preimage_proto_msgTypes,
+	}.Build()
+	File_preimage_proto = out.File
+	file_preimage_proto_rawDesc = nil
+	file_preimage_proto_goTypes = nil
+	file_preimage_proto_depIdxs = nil
+}
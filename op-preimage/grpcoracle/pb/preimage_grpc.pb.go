@@ -0,0 +1,127 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.27.3
+// source: preimage.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	PreimageOracle_Preimages_FullMethodName = "/optimism.preimage.v1.PreimageOracle/Preimages"
+)
+
+// PreimageOracleClient is the client API for PreimageOracle service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// PreimageOracle serves pre-images to fault proof programs that do not run in the same process as the host,
+// or cannot use the file-descriptor based pre-image oracle ABI.
+type PreimageOracleClient interface {
+	// Preimages streams batches of hints and pre-image requests to the host.
+	// The host sends exactly one response for every request, in the order of the requests.
+	// The stream is terminated with an error status if any of the hints or pre-images cannot be served.
+	Preimages(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[PreimageRequest, PreimageResponse], error)
+}
+
+type preimageOracleClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPreimageOracleClient(cc grpc.ClientConnInterface) PreimageOracleClient {
+	return &preimageOracleClient{cc}
+}
+
+func (c *preimageOracleClient) Preimages(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[PreimageRequest, PreimageResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PreimageOracle_ServiceDesc.Streams[0], PreimageOracle_Preimages_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[PreimageRequest, PreimageResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PreimageOracle_PreimagesClient = grpc.BidiStreamingClient[PreimageRequest, PreimageResponse]
+
+// PreimageOracleServer is the server API for PreimageOracle service.
+// All implementations must embed UnimplementedPreimageOracleServer
+// for forward compatibility.
+//
+// PreimageOracle serves pre-images to fault proof programs that do not run in the same process as the host,
+// or cannot use the file-descriptor based pre-image oracle ABI.
+type PreimageOracleServer interface {
+	// Preimages streams batches of hints and pre-image requests to the host.
+	// The host sends exactly one response for every request, in the order of the requests.
+	// The stream is terminated with an error status if any of the hints or pre-images cannot be served.
+	Preimages(grpc.BidiStreamingServer[PreimageRequest, PreimageResponse]) error
+	mustEmbedUnimplementedPreimageOracleServer()
+}
+
+// UnimplementedPreimageOracleServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPreimageOracleServer struct{}
+
+func (UnimplementedPreimageOracleServer) Preimages(grpc.BidiStreamingServer[PreimageRequest, PreimageResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method Preimages not implemented")
+}
+func (UnimplementedPreimageOracleServer) mustEmbedUnimplementedPreimageOracleServer() {}
+func (UnimplementedPreimageOracleServer) testEmbeddedByValue()                        {}
+
+// UnsafePreimageOracleServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PreimageOracleServer will
+// result in compilation errors.
+type UnsafePreimageOracleServer interface {
+	mustEmbedUnimplementedPreimageOracleServer()
+}
+
+func RegisterPreimageOracleServer(s grpc.ServiceRegistrar, srv PreimageOracleServer) {
+	// If the following call pancis, it indicates UnimplementedPreimageOracleServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PreimageOracle_ServiceDesc, srv)
+}
+
+func _PreimageOracle_Preimages_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(PreimageOracleServer).Preimages(&grpc.GenericServerStream[PreimageRequest, PreimageResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PreimageOracle_PreimagesServer = grpc.BidiStreamingServer[PreimageRequest, PreimageResponse]
+
+// PreimageOracle_ServiceDesc is the grpc.ServiceDesc for PreimageOracle service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PreimageOracle_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "optimism.preimage.v1.PreimageOracle",
+	HandlerType: (*PreimageOracleServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Preimages",
+			Handler:       _PreimageOracle_Preimages_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "preimage.proto",
+}
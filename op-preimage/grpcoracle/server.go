@@ -0,0 +1,137 @@
+package grpcoracle
+
+import (
+	"crypto/subtle"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-preimage/grpcoracle/pb"
+)
+
+//go:generate protoc --go_out=pb --go_opt=paths=source_relative --go-grpc_out=pb --go-grpc_opt=paths=source_relative --proto_path=pb preimage.proto
+
+const (
+	// MaxMessageSize is the maximum size of a gRPC message, which limits the combined size of a batch of pre-images.
+	MaxMessageSize = 64 * 1024 * 1024
+
+	authMetadataKey = "authorization"
+	bearerPrefix    = "Bearer "
+)
+
+// oracleServer implements the PreimageOracle gRPC service.
+type oracleServer struct {
+	pb.UnimplementedPreimageOracleServer
+
+	log log.Logger
+
+	// mu serializes all requests, as the pre-image getter and hint handler are not required to be thread-safe.
+	mu     sync.Mutex
+	getter preimage.PreimageGetter
+	hinter preimage.HintHandler
+}
+
+// NewGRPCServer creates a gRPC server that serves pre-images from getter, and routes hints to hinter.
+// If authToken is not empty, every stream must be authenticated with the token as bearer token.
+// Connections are secured with tlsConfig, which is required if an auth token is used, or insecure if nil.
+func NewGRPCServer(logger log.Logger, getter preimage.PreimageGetter, hinter preimage.HintHandler, authToken string, tlsConfig *tls.Config) (*grpc.Server, error) {
+	if authToken != "" && tlsConfig == nil {
+		return nil, ErrAuthTokenWithoutTLS
+	}
+	opts := []grpc.ServerOption{
+		grpc.MaxRecvMsgSize(MaxMessageSize),
+		grpc.MaxSendMsgSize(MaxMessageSize),
+	}
+	if tlsConfig != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+	if authToken != "" {
+		opts = append(opts, grpc.StreamInterceptor(authInterceptor(authToken)))
+	}
+	srv := grpc.NewServer(opts...)
+	pb.RegisterPreimageOracleServer(srv, &oracleServer{
+		log:    logger,
+		getter: getter,
+		hinter: hinter,
+	})
+	return srv, nil
+}
+
+func (s *oracleServer) Preimages(stream pb.PreimageOracle_PreimagesServer) error {
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		resp, err := s.handle(req)
+		if err != nil {
+			s.log.Error("Failed to serve pre-image request", "err", err)
+			return err
+		}
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *oracleServer) handle(req *pb.PreimageRequest) (*pb.PreimageResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, hint := range req.Hints {
+		if err := s.hinter(hint); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to process hint %q: %v", hint, err)
+		}
+	}
+	values := make([][]byte, 0, len(req.Keys))
+	for _, k := range req.Keys {
+		if len(k) != 32 {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid pre-image key length: %d", len(k))
+		}
+		value, err := s.getter([32]byte(k))
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to serve pre-image 0x%x: %v", k, err)
+		}
+		values = append(values, value)
+	}
+	return &pb.PreimageResponse{Values: values}, nil
+}
+
+func authInterceptor(authToken string) grpc.StreamServerInterceptor {
+	expected := []byte(bearerPrefix + authToken)
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		md, _ := metadata.FromIncomingContext(ss.Context())
+		values := md.Get(authMetadataKey)
+		if len(values) != 1 || subtle.ConstantTimeCompare([]byte(values[0]), expected) != 1 {
+			return status.Error(codes.Unauthenticated, "invalid auth token")
+		}
+		return handler(srv, ss)
+	}
+}
+
+// Listen creates a listener for the given address.
+// Addresses prefixed with unix:// are unix domain socket paths, all other addresses are TCP host:port addresses.
+func Listen(addr string) (net.Listener, error) {
+	network := "tcp"
+	if path, ok := strings.CutPrefix(addr, "unix://"); ok {
+		network, addr = "unix", path
+	}
+	lis, err := net.Listen(network, addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+	return lis, nil
+}
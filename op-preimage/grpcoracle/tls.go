@@ -0,0 +1,73 @@
+package grpcoracle
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	optls "github.com/ethereum-optimism/optimism/op-service/tls"
+	"github.com/ethereum-optimism/optimism/op-service/tls/certman"
+)
+
+// ErrAuthTokenWithoutTLS is returned when an auth token is configured without TLS,
+// as the bearer token would be sent in plain text.
+var ErrAuthTokenWithoutTLS = errors.New("auth token requires TLS")
+
+// ServerTLSConfig creates a mutual TLS config for the server from the files of cfg.
+// The server certificate is reloaded when it changes, and clients must present a certificate signed by the CA.
+func ServerTLSConfig(logger log.Logger, cfg optls.CLIConfig) (*tls.Config, error) {
+	caPool, cm, err := loadTLSFiles(logger, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		MinVersion:     tls.VersionTLS13,
+		GetCertificate: cm.GetCertificate,
+		ClientCAs:      caPool,
+		ClientAuth:     tls.RequireAndVerifyClientCert,
+	}, nil
+}
+
+// ClientTLSConfig creates a mutual TLS config for the client from the files of cfg.
+// The client certificate is reloaded when it changes, and the server must present a certificate signed by the CA.
+func ClientTLSConfig(logger log.Logger, cfg optls.CLIConfig) (*tls.Config, error) {
+	caPool, cm, err := loadTLSFiles(logger, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		MinVersion:           tls.VersionTLS13,
+		RootCAs:              caPool,
+		GetClientCertificate: cm.GetClientCertificate,
+	}, nil
+}
+
+func loadTLSFiles(logger log.Logger, cfg optls.CLIConfig) (*x509.CertPool, *certman.CertMan, error) {
+	if err := cfg.Check(); err != nil {
+		return nil, nil, err
+	}
+	if !cfg.TLSEnabled() {
+		return nil, nil, errors.New("tls is not configured")
+	}
+	caCert, err := os.ReadFile(cfg.TLSCaCert)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read tls ca cert: %w", err)
+	}
+	caPool := x509.NewCertPool()
+	if !caPool.AppendCertsFromPEM(caCert) {
+		return nil, nil, fmt.Errorf("no certificates found in tls ca cert %v", cfg.TLSCaCert)
+	}
+	// certman watches for newer certificates and automatically reloads them
+	cm, err := certman.New(logger, cfg.TLSCert, cfg.TLSKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read tls cert or key: %w", err)
+	}
+	if err := cm.Watch(); err != nil {
+		return nil, nil, fmt.Errorf("failed to watch tls cert and key: %w", err)
+	}
+	return caPool, cm, nil
+}
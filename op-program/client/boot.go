@@ -22,11 +22,24 @@ const (
 	// These local keys are only used for custom chains
 	L2ChainConfigLocalIndex
 	RollupConfigLocalIndex
+
+	// This local key is only used in interop mode
+	AgreedPrestateLocalIndex
+
+	// These local keys are only used for custom chains, to enable derivation checkpoints and progress hints.
+	// They are never set onchain, so hosts that enable either always boot the program with a custom chain config.
+	CheckpointIntervalLocalIndex
+	ProgressIntervalLocalIndex
 )
 
 // CustomChainIDIndicator is used to detect when the program should load custom chain configuration
 const CustomChainIDIndicator = uint64(math.MaxUint64)
 
+// InteropChainIDIndicator is used to detect when the program should validate a super root claim, covering multiple chains.
+// In interop mode the L2 output root and claim are super roots, the claim block number is the timestamp of the claimed
+// super root, and the chain and rollup configs are lists with the config of each chain.
+const InteropChainIDIndicator = uint64(math.MaxUint64 - 1)
+
 type BootInfo struct {
 	L1Head             common.Hash
 	L2OutputRoot       common.Hash
@@ -36,6 +49,28 @@ type BootInfo struct {
 
 	L2ChainConfig *params.ChainConfig
 	RollupConfig  *rollup.Config
+
+	// CheckpointInterval is the number of L2 blocks between the output roots included in progress hints,
+	// for the host to resume derivation from. Disabled if 0.
+	CheckpointInterval uint64
+	// ProgressInterval is the number of L2 blocks between progress hints, if enabled by the host.
+	// If 0, progress is only hinted every driver.DefaultProgressInterval blocks.
+	ProgressInterval uint64
+}
+
+type BootInfoInterop struct {
+	L1Head common.Hash
+	// AgreedPrestate is the preimage of the agreed super root.
+	AgreedPrestate []byte
+	// AgreedSuperRoot is the agreed super root to start derivation from.
+	AgreedSuperRoot common.Hash
+	// Claim is the claimed super root to verify.
+	Claim common.Hash
+	// ClaimTimestamp is the timestamp of the claimed super root.
+	ClaimTimestamp uint64
+
+	L2ChainConfigs []*params.ChainConfig
+	RollupConfigs  []*rollup.Config
 }
 
 type oracleClient interface {
@@ -59,6 +94,7 @@ func (br *BootstrapClient) BootInfo() *BootInfo {
 
 	var l2ChainConfig *params.ChainConfig
 	var rollupConfig *rollup.Config
+	var checkpointInterval, progressInterval uint64
 	if l2ChainID == CustomChainIDIndicator {
 		l2ChainConfig = new(params.ChainConfig)
 		err := json.Unmarshal(br.r.Get(L2ChainConfigLocalIndex), &l2ChainConfig)
@@ -70,6 +106,8 @@ func (br *BootstrapClient) BootInfo() *BootInfo {
 		if err != nil {
 			panic("failed to bootstrap rollup config")
 		}
+		checkpointInterval = binary.BigEndian.Uint64(br.r.Get(CheckpointIntervalLocalIndex))
+		progressInterval = binary.BigEndian.Uint64(br.r.Get(ProgressIntervalLocalIndex))
 	} else {
 		var err error
 		rollupConfig, err = chainconfig.RollupConfigByChainID(l2ChainID)
@@ -90,5 +128,39 @@ func (br *BootstrapClient) BootInfo() *BootInfo {
 		L2ChainID:          l2ChainID,
 		L2ChainConfig:      l2ChainConfig,
 		RollupConfig:       rollupConfig,
+		CheckpointInterval: checkpointInterval,
+		ProgressInterval:   progressInterval,
+	}
+}
+
+// IsInterop returns true if the program should run in interop mode, and load its boot info with BootInfoInterop.
+func (br *BootstrapClient) IsInterop() bool {
+	return binary.BigEndian.Uint64(br.r.Get(L2ChainIDLocalIndex)) == InteropChainIDIndicator
+}
+
+func (br *BootstrapClient) BootInfoInterop() *BootInfoInterop {
+	l1Head := common.BytesToHash(br.r.Get(L1HeadLocalIndex))
+	agreedSuperRoot := common.BytesToHash(br.r.Get(L2OutputRootLocalIndex))
+	agreedPrestate := br.r.Get(AgreedPrestateLocalIndex)
+	claim := common.BytesToHash(br.r.Get(L2ClaimLocalIndex))
+	claimTimestamp := binary.BigEndian.Uint64(br.r.Get(L2ClaimBlockNumberLocalIndex))
+
+	var l2ChainConfigs []*params.ChainConfig
+	if err := json.Unmarshal(br.r.Get(L2ChainConfigLocalIndex), &l2ChainConfigs); err != nil {
+		panic("failed to bootstrap l2ChainConfigs")
+	}
+	var rollupConfigs []*rollup.Config
+	if err := json.Unmarshal(br.r.Get(RollupConfigLocalIndex), &rollupConfigs); err != nil {
+		panic("failed to bootstrap rollup configs")
+	}
+
+	return &BootInfoInterop{
+		L1Head:          l1Head,
+		AgreedPrestate:  agreedPrestate,
+		AgreedSuperRoot: agreedSuperRoot,
+		Claim:           claim,
+		ClaimTimestamp:  claimTimestamp,
+		L2ChainConfigs:  l2ChainConfigs,
+		RollupConfigs:   rollupConfigs,
 	}
 }
@@ -7,9 +7,11 @@ import (
 	"testing"
 
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
 	"github.com/ethereum-optimism/optimism/op-program/chainconfig"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/stretchr/testify/require"
 )
 
@@ -37,6 +39,8 @@ func TestBootstrapClient_CustomChain(t *testing.T) {
 		L2ChainID:          CustomChainIDIndicator,
 		L2ChainConfig:      chainconfig.OPSepoliaChainConfig,
 		RollupConfig:       chaincfg.Sepolia,
+		CheckpointInterval: 100,
+		ProgressInterval:   1,
 	}
 	mockOracle := &mockBoostrapOracle{bootInfo, true}
 	readBootInfo := NewBootstrapClient(mockOracle).BootInfo()
@@ -85,6 +89,63 @@ func (o *mockBoostrapOracle) Get(key preimage.Key) []byte {
 		}
 		b, _ := json.Marshal(o.b.RollupConfig)
 		return b
+	case CheckpointIntervalLocalIndex.PreimageKey():
+		if !o.custom {
+			panic(fmt.Sprintf("unexpected oracle request for preimage key %x", key.PreimageKey()))
+		}
+		return binary.BigEndian.AppendUint64(nil, o.b.CheckpointInterval)
+	case ProgressIntervalLocalIndex.PreimageKey():
+		if !o.custom {
+			panic(fmt.Sprintf("unexpected oracle request for preimage key %x", key.PreimageKey()))
+		}
+		return binary.BigEndian.AppendUint64(nil, o.b.ProgressInterval)
+	default:
+		panic("unknown key")
+	}
+}
+
+func TestBootstrapClient_Interop(t *testing.T) {
+	bootInfo := &BootInfoInterop{
+		L1Head:          common.HexToHash("0x1111"),
+		AgreedPrestate:  []byte{1, 2, 3},
+		AgreedSuperRoot: common.HexToHash("0x2222"),
+		Claim:           common.HexToHash("0x3333"),
+		ClaimTimestamp:  7000,
+		L2ChainConfigs:  []*params.ChainConfig{chainconfig.OPSepoliaChainConfig},
+		RollupConfigs:   []*rollup.Config{chaincfg.Sepolia},
+	}
+	mockOracle := &mockInteropBootstrapOracle{bootInfo}
+	client := NewBootstrapClient(mockOracle)
+	require.True(t, client.IsInterop())
+	require.EqualValues(t, bootInfo, client.BootInfoInterop())
+
+	require.False(t, NewBootstrapClient(&mockBoostrapOracle{&BootInfo{L2ChainID: CustomChainIDIndicator}, true}).IsInterop())
+}
+
+type mockInteropBootstrapOracle struct {
+	b *BootInfoInterop
+}
+
+func (o *mockInteropBootstrapOracle) Get(key preimage.Key) []byte {
+	switch key.PreimageKey() {
+	case L1HeadLocalIndex.PreimageKey():
+		return o.b.L1Head[:]
+	case L2OutputRootLocalIndex.PreimageKey():
+		return o.b.AgreedSuperRoot[:]
+	case AgreedPrestateLocalIndex.PreimageKey():
+		return o.b.AgreedPrestate
+	case L2ClaimLocalIndex.PreimageKey():
+		return o.b.Claim[:]
+	case L2ClaimBlockNumberLocalIndex.PreimageKey():
+		return binary.BigEndian.AppendUint64(nil, o.b.ClaimTimestamp)
+	case L2ChainIDLocalIndex.PreimageKey():
+		return binary.BigEndian.AppendUint64(nil, InteropChainIDIndicator)
+	case L2ChainConfigLocalIndex.PreimageKey():
+		b, _ := json.Marshal(o.b.L2ChainConfigs)
+		return b
+	case RollupConfigLocalIndex.PreimageKey():
+		b, _ := json.Marshal(o.b.RollupConfigs)
+		return b
 	default:
 		panic("unknown key")
 	}
@@ -18,13 +18,9 @@ type L2Source interface {
 }
 
 func ValidateClaim(log log.Logger, l2ClaimBlockNum uint64, claimedOutputRoot eth.Bytes32, src L2Source) error {
-	l2Head, err := src.L2BlockRefByLabel(context.Background(), eth.Safe)
-	if err != nil {
-		return fmt.Errorf("cannot retrieve safe head: %w", err)
-	}
-	outputRoot, err := src.L2OutputRoot(min(l2ClaimBlockNum, l2Head.Number))
+	outputRoot, l2Head, err := OutputRootAtClaim(l2ClaimBlockNum, src)
 	if err != nil {
-		return fmt.Errorf("calculate L2 output root: %w", err)
+		return err
 	}
 	log.Info("Validating claim", "head", l2Head, "output", outputRoot, "claim", claimedOutputRoot)
 	if claimedOutputRoot != outputRoot {
@@ -32,3 +28,17 @@ func ValidateClaim(log log.Logger, l2ClaimBlockNum uint64, claimedOutputRoot eth
 	}
 	return nil
 }
+
+// OutputRootAtClaim returns the output root at the claimed block number, or at the safe head if the safe head
+// did not reach the claimed block. The safe head is returned along with the output root.
+func OutputRootAtClaim(l2ClaimBlockNum uint64, src L2Source) (eth.Bytes32, eth.L2BlockRef, error) {
+	l2Head, err := src.L2BlockRefByLabel(context.Background(), eth.Safe)
+	if err != nil {
+		return eth.Bytes32{}, eth.L2BlockRef{}, fmt.Errorf("cannot retrieve safe head: %w", err)
+	}
+	outputRoot, err := src.L2OutputRoot(min(l2ClaimBlockNum, l2Head.Number))
+	if err != nil {
+		return eth.Bytes32{}, eth.L2BlockRef{}, fmt.Errorf("calculate L2 output root: %w", err)
+	}
+	return outputRoot, l2Head, nil
+}
@@ -13,8 +13,15 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
 )
 
+// L2Source is the L2 engine the program derives blocks with.
+type L2Source interface {
+	engine.Engine
+	OutputRooter
+}
+
 type EndCondition interface {
 	Closing() bool
 	Result() error
@@ -29,8 +36,13 @@ type Driver struct {
 	deriver event.Deriver
 }
 
+// NewDriver creates a driver that derives L2 blocks until the target block number is reached.
+// Derivation progress is hinted to progress, if not nil, every progressInterval blocks, or every
+// DefaultProgressInterval blocks if 0, with the output root of the safe head every checkpointInterval blocks.
+// Checkpoints are disabled if checkpointInterval is 0.
 func NewDriver(logger log.Logger, cfg *rollup.Config, l1Source derive.L1Fetcher,
-	l1BlobsSource derive.L1BlobsFetcher, l2Source engine.Engine, targetBlockNum uint64) *Driver {
+	l1BlobsSource derive.L1BlobsFetcher, l2Source L2Source, targetBlockNum uint64, progress preimage.Hinter,
+	checkpointInterval uint64, progressInterval uint64) *Driver {
 
 	d := &Driver{
 		logger: logger,
@@ -48,11 +60,16 @@ func NewDriver(logger log.Logger, cfg *rollup.Config, l1Source derive.L1Fetcher,
 	engResetDeriv.AttachEmitter(d)
 
 	prog := &ProgramDeriver{
-		logger:         logger,
-		Emitter:        d,
-		closing:        false,
-		result:         nil,
-		targetBlockNum: targetBlockNum,
+		logger:             logger,
+		Emitter:            d,
+		closing:            false,
+		result:             nil,
+		targetBlockNum:     targetBlockNum,
+		progress:           progress,
+		outputs:            l2Source,
+		checkpointInterval: checkpointInterval,
+		progressInterval:   progressInterval,
+		chainID:            cfg.L2ChainID.Uint64(),
 	}
 
 	d.deriver = &event.DeriverMux{
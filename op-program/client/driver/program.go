@@ -9,8 +9,15 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
+// OutputRooter computes the output root of a canonical L2 block.
+type OutputRooter interface {
+	L2OutputRoot(blockNum uint64) (eth.Bytes32, error)
+}
+
 // ProgramDeriver expresses how engine and derivation events are
 // translated and monitored to execute the pure L1 to L2 state transition.
 //
@@ -23,6 +30,19 @@ type ProgramDeriver struct {
 	closing        bool
 	result         error
 	targetBlockNum uint64
+
+	// progress receives the progress hints, if not nil.
+	progress preimage.Hinter
+	outputs  OutputRooter
+	chainID  uint64
+	// checkpointInterval is the number of blocks between the output roots included in progress hints.
+	// Output roots are not computed if 0.
+	checkpointInterval uint64
+	// progressInterval is the number of blocks between progress hints. DefaultProgressInterval is used if 0.
+	progressInterval uint64
+	reported         bool
+	lastReported     uint64
+	lastCheckpoint   uint64
 }
 
 func (d *ProgramDeriver) Closing() bool {
@@ -58,6 +78,7 @@ func (d *ProgramDeriver) OnEvent(ev event.Event) bool {
 		// and continue with the next.
 		d.Emitter.Emit(engine.PendingSafeRequestEvent{})
 	case engine.ForkchoiceUpdateEvent:
+		d.reportProgress(x.SafeL2Head)
 		if x.SafeL2Head.Number >= d.targetBlockNum {
 			d.logger.Info("Derivation complete: reached L2 block", "head", x.SafeL2Head)
 			d.closing = true
@@ -88,3 +109,35 @@ func (d *ProgramDeriver) OnEvent(ev event.Event) bool {
 	}
 	return true
 }
+
+// reportProgress hints the new safe head to the host every progressInterval blocks,
+// including its output root every checkpointInterval blocks.
+// The first safe head is the agreed block that derivation starts from.
+func (d *ProgramDeriver) reportProgress(safeHead eth.L2BlockRef) {
+	if d.progress == nil || (d.reported && safeHead.Number <= d.lastReported) {
+		return
+	}
+	checkpoint := d.reported && d.checkpointInterval > 0 && d.outputs != nil &&
+		safeHead.Number >= d.lastCheckpoint+d.checkpointInterval
+	interval := d.progressInterval
+	if interval == 0 {
+		interval = DefaultProgressInterval
+	}
+	if d.reported && !checkpoint && safeHead.Number < d.lastReported+interval {
+		return
+	}
+	progress := Progress{ChainID: d.chainID, SafeHead: safeHead}
+	if !d.reported {
+		d.lastCheckpoint = safeHead.Number
+	} else if checkpoint {
+		if root, err := d.outputs.L2OutputRoot(safeHead.Number); err != nil {
+			d.logger.Warn("Failed to compute output root for checkpoint", "head", safeHead, "err", err)
+		} else {
+			progress.OutputRoot = &root
+			d.lastCheckpoint = safeHead.Number
+		}
+	}
+	d.reported = true
+	d.lastReported = safeHead.Number
+	d.progress.Hint(ProgressHint(progress))
+}
@@ -2,15 +2,18 @@ package driver
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum-optimism/optimism/op-service/testutils"
@@ -159,3 +162,144 @@ type TestEvent struct{}
 func (ev TestEvent) String() string {
 	return "test-event"
 }
+
+func TestProgramDeriverProgress(t *testing.T) {
+	const interval = 100
+	newProgram := func(t *testing.T) (*ProgramDeriver, *stubHinter, *stubOutputs) {
+		hinter := &stubHinter{}
+		outputs := &stubOutputs{}
+		prog := &ProgramDeriver{
+			logger:             testlog.Logger(t, log.LevelInfo),
+			Emitter:            &testutils.MockEmitter{},
+			targetBlockNum:     1000,
+			progress:           hinter,
+			outputs:            outputs,
+			chainID:            10,
+			checkpointInterval: interval,
+			progressInterval:   1,
+		}
+		return prog, hinter, outputs
+	}
+	update := func(p *ProgramDeriver, num uint64) {
+		p.OnEvent(engine.ForkchoiceUpdateEvent{SafeL2Head: eth.L2BlockRef{Number: num}})
+	}
+
+	t.Run("reports new safe heads", func(t *testing.T) {
+		p, hinter, _ := newProgram(t)
+		update(p, 500)
+		update(p, 500)
+		update(p, 501)
+		require.Len(t, hinter.progress, 2)
+		require.Equal(t, Progress{ChainID: 10, SafeHead: eth.L2BlockRef{Number: 500}}, hinter.progress[0])
+		require.Equal(t, Progress{ChainID: 10, SafeHead: eth.L2BlockRef{Number: 501}}, hinter.progress[1])
+	})
+	t.Run("checkpoints every interval", func(t *testing.T) {
+		p, hinter, outputs := newProgram(t)
+		for num := uint64(500); num <= 500+2*interval; num++ {
+			update(p, num)
+		}
+		var checkpoints []uint64
+		for _, progress := range hinter.progress {
+			if progress.OutputRoot != nil {
+				require.Equal(t, eth.Bytes32{byte(progress.SafeHead.Number)}, *progress.OutputRoot)
+				checkpoints = append(checkpoints, progress.SafeHead.Number)
+			}
+		}
+		require.Equal(t, []uint64{500 + interval, 500 + 2*interval}, checkpoints)
+		require.Equal(t, checkpoints, outputs.requested)
+	})
+	t.Run("retries failed checkpoint", func(t *testing.T) {
+		p, hinter, outputs := newProgram(t)
+		update(p, 500)
+		outputs.err = errors.New("boom")
+		update(p, 500+interval)
+		require.Nil(t, hinter.progress[1].OutputRoot)
+		outputs.err = nil
+		update(p, 501+interval)
+		require.NotNil(t, hinter.progress[2].OutputRoot)
+	})
+	t.Run("checkpoints disabled", func(t *testing.T) {
+		p, hinter, outputs := newProgram(t)
+		p.checkpointInterval = 0
+		for num := uint64(500); num <= 500+interval; num++ {
+			update(p, num)
+		}
+		require.Len(t, hinter.progress, interval+1)
+		require.Empty(t, outputs.requested, "must not compute output roots for progress hints")
+	})
+	t.Run("coarse progress if not enabled by host", func(t *testing.T) {
+		p, hinter, outputs := newProgram(t)
+		p.checkpointInterval = 0
+		p.progressInterval = 0
+		for num := uint64(500); num <= 500+2*DefaultProgressInterval; num++ {
+			update(p, num)
+		}
+		require.Len(t, hinter.progress, 3)
+		require.Equal(t, uint64(500+DefaultProgressInterval), hinter.progress[1].SafeHead.Number)
+		require.Equal(t, uint64(500+2*DefaultProgressInterval), hinter.progress[2].SafeHead.Number)
+		require.Empty(t, outputs.requested)
+	})
+	t.Run("checkpoints with coarse progress", func(t *testing.T) {
+		p, hinter, outputs := newProgram(t)
+		p.progressInterval = 0
+		for num := uint64(500); num <= 500+2*interval; num++ {
+			update(p, num)
+		}
+		require.Len(t, hinter.progress, 3, "only the start and the checkpoints are hinted")
+		require.Equal(t, []uint64{500 + interval, 500 + 2*interval}, outputs.requested)
+	})
+	t.Run("disabled", func(t *testing.T) {
+		p, _, outputs := newProgram(t)
+		p.progress = nil
+		for num := uint64(500); num <= 500+interval; num++ {
+			update(p, num)
+		}
+		require.Empty(t, outputs.requested)
+	})
+}
+
+func TestProgressHint(t *testing.T) {
+	root := eth.Bytes32{0xaa}
+	progress := Progress{
+		ChainID:    10,
+		SafeHead:   eth.L2BlockRef{Hash: common.Hash{0x01}, Number: 42, L1Origin: eth.BlockID{Hash: common.Hash{0x02}, Number: 7}},
+		OutputRoot: &root,
+	}
+	parsed, ok, err := ParseProgressHint(ProgressHint(progress).Hint())
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, progress, *parsed)
+
+	_, ok, err = ParseProgressHint("l2-block-header 0x1234")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	_, ok, err = ParseProgressHint(HintProgress + " 0xnothex")
+	require.Error(t, err)
+	require.True(t, ok)
+}
+
+type stubHinter struct {
+	progress []Progress
+}
+
+func (s *stubHinter) Hint(v preimage.Hint) {
+	progress, ok, err := ParseProgressHint(v.Hint())
+	if err != nil || !ok {
+		panic(fmt.Errorf("unexpected hint %v: %w", v.Hint(), err))
+	}
+	s.progress = append(s.progress, *progress)
+}
+
+type stubOutputs struct {
+	requested []uint64
+	err       error
+}
+
+func (s *stubOutputs) L2OutputRoot(blockNum uint64) (eth.Bytes32, error) {
+	if s.err != nil {
+		return eth.Bytes32{}, s.err
+	}
+	s.requested = append(s.requested, blockNum)
+	return eth.Bytes32{byte(blockNum)}, nil
+}
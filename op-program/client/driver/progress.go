@@ -0,0 +1,59 @@
+package driver
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// DefaultProgressInterval is the number of L2 blocks between progress hints, if the host did not enable progress hints.
+// Hints are cheap for the host to ignore, but still cost steps when the program runs in a fault proof VM.
+const DefaultProgressInterval = 1000
+
+// HintProgress is the hint type the client program reports its derivation progress to the host with.
+// It is not a request for pre-images: hosts must not forward it to the prefetcher.
+const HintProgress = "progress"
+
+// Progress is the derivation progress of the client program on a single chain.
+type Progress struct {
+	ChainID  uint64         `json:"chainID"`
+	SafeHead eth.L2BlockRef `json:"safeHead"`
+	// OutputRoot is the output root of SafeHead, which the host can resume derivation from.
+	// Only set at checkpoints, if the host enabled them.
+	OutputRoot *eth.Bytes32 `json:"outputRoot,omitempty"`
+}
+
+type ProgressHint Progress
+
+var _ preimage.Hint = ProgressHint{}
+
+func (p ProgressHint) Hint() string {
+	data, err := json.Marshal(Progress(p))
+	if err != nil {
+		panic(fmt.Errorf("failed to encode progress: %w", err))
+	}
+	return HintProgress + " " + hexutil.Encode(data)
+}
+
+// ParseProgressHint decodes the progress reported by a progress hint.
+// It returns false if the hint is not a progress hint.
+func ParseProgressHint(hint string) (*Progress, bool, error) {
+	hintType, dataStr, _ := strings.Cut(hint, " ")
+	if hintType != HintProgress {
+		return nil, false, nil
+	}
+	data, err := hexutil.Decode(dataStr)
+	if err != nil {
+		return nil, true, fmt.Errorf("invalid progress hint data: %w", err)
+	}
+	var progress Progress
+	if err := json.Unmarshal(data, &progress); err != nil {
+		return nil, true, fmt.Errorf("invalid progress: %w", err)
+	}
+	return &progress, true, nil
+}
@@ -0,0 +1,145 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-program/client/claim"
+	"github.com/ethereum-optimism/optimism/op-program/client/interop"
+	"github.com/ethereum-optimism/optimism/op-program/client/l1"
+	"github.com/ethereum-optimism/optimism/op-program/client/l2"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// runInteropProgram validates a super root claim. Each chain of the agreed super root is derived to the claimed
+// timestamp, and the messages executed in the derived blocks are checked against the initiating messages of all chains.
+// Blocks with invalid executing messages are not replaced with deposit-only blocks, instead the program fails.
+func runInteropProgram(logger log.Logger, bootInfo *BootInfoInterop, l1Oracle l1.Oracle, l2Oracle l2.Oracle, receiptsOracle l2.ReceiptsOracle, progress preimage.Hinter) error {
+	if crypto.Keccak256Hash(bootInfo.AgreedPrestate) != bootInfo.AgreedSuperRoot {
+		return fmt.Errorf("agreed prestate does not match agreed super root %v", bootInfo.AgreedSuperRoot)
+	}
+	agreed, err := eth.UnmarshalSuperRoot(bootInfo.AgreedPrestate)
+	if err != nil {
+		return fmt.Errorf("invalid agreed prestate: %w", err)
+	}
+	if bootInfo.ClaimTimestamp < agreed.Timestamp {
+		return fmt.Errorf("claim timestamp %v is before agreed timestamp %v", bootInfo.ClaimTimestamp, agreed.Timestamp)
+	}
+
+	claimed := &eth.SuperV1{Timestamp: bootInfo.ClaimTimestamp}
+	chains := make(map[uint64]interop.Chain, len(agreed.Chains))
+	derived := make([]*oracleChain, 0, len(agreed.Chains))
+	for _, c := range agreed.Chains {
+		chainLogger := logger.New("chain", c.ChainID)
+		rollupCfg, l2Cfg, err := interopChainConfig(bootInfo, c.ChainID)
+		if err != nil {
+			return err
+		}
+		claimBlockNum, err := rollupCfg.TargetBlockNumber(bootInfo.ClaimTimestamp)
+		if err != nil {
+			return fmt.Errorf("chain %v: %w", c.ChainID, err)
+		}
+		backend, engine, err := deriveL2(chainLogger, rollupCfg, l2Cfg, bootInfo.L1Head, common.Hash(c.Output), claimBlockNum, l1Oracle, l2Oracle, progress, 0, 0)
+		if err != nil {
+			return fmt.Errorf("chain %v: %w", c.ChainID, err)
+		}
+		outputRoot, safeHead, err := claim.OutputRootAtClaim(claimBlockNum, engine)
+		if err != nil {
+			return fmt.Errorf("chain %v: %w", c.ChainID, err)
+		}
+		agreedHead, err := agreedBlockNumber(engine, l2Oracle, c.Output)
+		if err != nil {
+			return fmt.Errorf("chain %v: %w", c.ChainID, err)
+		}
+		chainLogger.Info("Derived chain", "head", safeHead, "output", outputRoot)
+		claimed.Chains = append(claimed.Chains, eth.ChainIDAndOutput{ChainID: c.ChainID, Output: outputRoot})
+		chain := &oracleChain{
+			backend:  backend,
+			receipts: receiptsOracle,
+			agreed:   agreedHead,
+			head:     min(claimBlockNum, safeHead.Number),
+		}
+		chains[c.ChainID] = chain
+		derived = append(derived, chain)
+	}
+
+	for i, chain := range derived {
+		for n := chain.agreed + 1; n <= chain.head; n++ {
+			block, receipts, _ := chain.ReceiptsByNumber(n)
+			if err := interop.CheckMessages(chains, block, receipts); err != nil {
+				return fmt.Errorf("chain %v: %w", agreed.Chains[i].ChainID, err)
+			}
+		}
+	}
+
+	superRoot := eth.SuperRoot(claimed)
+	logger.Info("Validating claim", "superRoot", superRoot, "claim", bootInfo.Claim)
+	if eth.Bytes32(bootInfo.Claim) != superRoot {
+		return fmt.Errorf("%w: claim: %v actual: %v", claim.ErrClaimNotValid, bootInfo.Claim, superRoot)
+	}
+	return nil
+}
+
+func interopChainConfig(bootInfo *BootInfoInterop, chainID uint64) (*rollup.Config, *params.ChainConfig, error) {
+	var rollupCfg *rollup.Config
+	for _, cfg := range bootInfo.RollupConfigs {
+		if cfg.L2ChainID != nil && cfg.L2ChainID.Uint64() == chainID {
+			rollupCfg = cfg
+		}
+	}
+	var l2Cfg *params.ChainConfig
+	for _, cfg := range bootInfo.L2ChainConfigs {
+		if cfg.ChainID != nil && cfg.ChainID.Uint64() == chainID {
+			l2Cfg = cfg
+		}
+	}
+	if rollupCfg == nil || l2Cfg == nil {
+		return nil, nil, fmt.Errorf("missing config for chain %v", chainID)
+	}
+	return rollupCfg, l2Cfg, nil
+}
+
+func agreedBlockNumber(engine *l2.OracleEngine, l2Oracle l2.Oracle, outputRoot eth.Bytes32) (uint64, error) {
+	output, ok := l2Oracle.OutputByRoot(common.Hash(outputRoot)).(*eth.OutputV0)
+	if !ok {
+		return 0, fmt.Errorf("unsupported output version of agreed output root %v", outputRoot)
+	}
+	ref, err := engine.L2BlockRefByHash(context.Background(), output.BlockHash)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load agreed block %v: %w", output.BlockHash, err)
+	}
+	return ref.Number, nil
+}
+
+// oracleChain provides the blocks of a derived chain, up to the block the chain was derived to.
+type oracleChain struct {
+	backend  *l2.OracleBackedL2Chain
+	receipts l2.ReceiptsOracle
+	agreed   uint64
+	head     uint64
+}
+
+var _ interop.Chain = (*oracleChain)(nil)
+
+func (c *oracleChain) ReceiptsByNumber(n uint64) (*types.Block, types.Receipts, bool) {
+	if n > c.head {
+		return nil, nil, false
+	}
+	hash := c.backend.GetCanonicalHash(n)
+	if hash == (common.Hash{}) {
+		return nil, nil, false
+	}
+	// Derived blocks were executed locally, only the receipts of earlier blocks are loaded from the oracle.
+	if receipts, ok := c.backend.InsertedReceipts(hash); ok {
+		return c.backend.GetBlockByHash(hash), receipts, true
+	}
+	block, receipts := c.receipts.ReceiptsByBlockHash(hash)
+	return block, receipts, true
+}
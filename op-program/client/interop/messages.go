@@ -0,0 +1,124 @@
+package interop
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/holiman/uint256"
+
+	"github.com/ethereum-optimism/optimism/op-service/predeploys"
+	supTypes "github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+var ErrInvalidMessage = errors.New("invalid executing message")
+
+// ExecutingMessageEventTopic is the topic of the ExecutingMessage event emitted by the CrossL2Inbox.
+var ExecutingMessageEventTopic = crypto.Keccak256Hash([]byte("ExecutingMessage(bytes32,(address,uint256,uint256,uint256,uint256))"))
+
+// executingMessageDataLen is the length of the ABI encoded identifier in the ExecutingMessage event data.
+const executingMessageDataLen = 5 * 32
+
+// Chain provides the blocks of a chain that executing messages may refer to.
+type Chain interface {
+	// ReceiptsByNumber returns the canonical block with the given number and its receipts.
+	// Returns false if the block is after the block the chain is validated up to.
+	ReceiptsByNumber(n uint64) (*types.Block, types.Receipts, bool)
+}
+
+// DecodeExecutingMessage decodes the message executed by a log of the CrossL2Inbox.
+// Returns nil if the log is not an ExecutingMessage event.
+func DecodeExecutingMessage(l *types.Log) (*supTypes.Message, error) {
+	if l.Address != predeploys.CrossL2InboxAddr || len(l.Topics) == 0 || l.Topics[0] != ExecutingMessageEventTopic {
+		return nil, nil
+	}
+	if len(l.Topics) != 2 || len(l.Data) != executingMessageDataLen {
+		return nil, fmt.Errorf("malformed ExecutingMessage event with %d topics and %d bytes of data", len(l.Topics), len(l.Data))
+	}
+	word := func(i int) *uint256.Int {
+		return new(uint256.Int).SetBytes(l.Data[i*32 : (i+1)*32])
+	}
+	blockNumber, logIndex, timestamp := word(1), word(2), word(3)
+	if !blockNumber.IsUint64() || !logIndex.IsUint64() || !timestamp.IsUint64() {
+		return nil, fmt.Errorf("malformed ExecutingMessage event identifier: %x", l.Data)
+	}
+	return &supTypes.Message{
+		Identifier: supTypes.Identifier{
+			Origin:      common.BytesToAddress(l.Data[:32]),
+			BlockNumber: blockNumber.Uint64(),
+			LogIndex:    logIndex.Uint64(),
+			Timestamp:   timestamp.Uint64(),
+			ChainID:     supTypes.ChainID(*word(4)),
+		},
+		PayloadHash: l.Topics[1],
+	}, nil
+}
+
+// MessagePayloadHash returns the hash of the message payload of a log, which is the concatenation of its topics and data.
+func MessagePayloadHash(l *types.Log) common.Hash {
+	payload := make([]byte, 0, len(l.Topics)*32+len(l.Data))
+	for _, topic := range l.Topics {
+		payload = append(payload, topic.Bytes()...)
+	}
+	payload = append(payload, l.Data...)
+	return crypto.Keccak256Hash(payload)
+}
+
+// CheckMessages checks that every message executed in the block refers to an initiating message of the chains.
+func CheckMessages(chains map[uint64]Chain, block *types.Block, receipts types.Receipts) error {
+	for _, rcpt := range receipts {
+		for _, l := range rcpt.Logs {
+			msg, err := DecodeExecutingMessage(l)
+			if err != nil {
+				return fmt.Errorf("%w: tx %v: %w", ErrInvalidMessage, rcpt.TxHash, err)
+			}
+			if msg == nil {
+				continue
+			}
+			if err := checkMessage(chains, block, msg); err != nil {
+				return fmt.Errorf("%w: tx %v in block %v: %w", ErrInvalidMessage, rcpt.TxHash, block.NumberU64(), err)
+			}
+		}
+	}
+	return nil
+}
+
+func checkMessage(chains map[uint64]Chain, block *types.Block, msg *supTypes.Message) error {
+	id := msg.Identifier
+	chainID := (*uint256.Int)(&id.ChainID)
+	if !chainID.IsUint64() {
+		return fmt.Errorf("unknown chain %v", id.ChainID)
+	}
+	chain, ok := chains[chainID.Uint64()]
+	if !ok {
+		return fmt.Errorf("unknown chain %v", id.ChainID)
+	}
+	if id.Timestamp > block.Time() {
+		return fmt.Errorf("initiating message timestamp %v is after executing block timestamp %v", id.Timestamp, block.Time())
+	}
+	initBlock, receipts, ok := chain.ReceiptsByNumber(id.BlockNumber)
+	if !ok {
+		return fmt.Errorf("initiating block %v of chain %v is not known", id.BlockNumber, id.ChainID)
+	}
+	if initBlock.Time() != id.Timestamp {
+		return fmt.Errorf("initiating block %v has timestamp %v but message timestamp is %v", id.BlockNumber, initBlock.Time(), id.Timestamp)
+	}
+	logIndex := uint64(0)
+	for _, rcpt := range receipts {
+		for _, l := range rcpt.Logs {
+			if logIndex == id.LogIndex {
+				if l.Address != id.Origin {
+					return fmt.Errorf("initiating log %v has origin %v but message origin is %v", logIndex, l.Address, id.Origin)
+				}
+				if hash := MessagePayloadHash(l); hash != msg.PayloadHash {
+					return fmt.Errorf("initiating log %v has payload hash %v but message payload hash is %v", logIndex, hash, msg.PayloadHash)
+				}
+				return nil
+			}
+			logIndex++
+		}
+	}
+	return fmt.Errorf("initiating block %v has no log %v", id.BlockNumber, id.LogIndex)
+}
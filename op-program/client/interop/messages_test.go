@@ -0,0 +1,76 @@
+package interop
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/predeploys"
+)
+
+type stubChain struct {
+	blocks   map[uint64]*types.Block
+	receipts map[uint64]types.Receipts
+}
+
+func (c *stubChain) ReceiptsByNumber(n uint64) (*types.Block, types.Receipts, bool) {
+	block, ok := c.blocks[n]
+	return block, c.receipts[n], ok
+}
+
+func executingLog(origin common.Address, blockNum, logIndex, timestamp, chainID uint64, payloadHash common.Hash) *types.Log {
+	data := make([]byte, executingMessageDataLen)
+	copy(data[12:32], origin[:])
+	new(big.Int).SetUint64(blockNum).FillBytes(data[32:64])
+	new(big.Int).SetUint64(logIndex).FillBytes(data[64:96])
+	new(big.Int).SetUint64(timestamp).FillBytes(data[96:128])
+	new(big.Int).SetUint64(chainID).FillBytes(data[128:160])
+	return &types.Log{
+		Address: predeploys.CrossL2InboxAddr,
+		Topics:  []common.Hash{ExecutingMessageEventTopic, payloadHash},
+		Data:    data,
+	}
+}
+
+func TestCheckMessages(t *testing.T) {
+	origin := common.Address{0xaa}
+	initLog := &types.Log{Address: origin, Topics: []common.Hash{{0x01}}, Data: []byte{0x02}}
+	initBlock := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(5), Time: 100})
+	chains := map[uint64]Chain{
+		900: &stubChain{
+			blocks: map[uint64]*types.Block{5: initBlock},
+			receipts: map[uint64]types.Receipts{5: {
+				{Logs: []*types.Log{{Address: common.Address{0xbb}}}},
+				{Logs: []*types.Log{initLog}},
+			}},
+		},
+	}
+	execBlock := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(3), Time: 102})
+	check := func(l *types.Log) error {
+		return CheckMessages(chains, execBlock, types.Receipts{{Logs: []*types.Log{{Address: origin}, l}}})
+	}
+	payloadHash := MessagePayloadHash(initLog)
+
+	require.NoError(t, check(executingLog(origin, 5, 1, 100, 900, payloadHash)))
+	// Logs that are not executing messages are ignored
+	require.NoError(t, check(&types.Log{Address: predeploys.CrossL2InboxAddr}))
+
+	tests := map[string]*types.Log{
+		"UnknownChain":     executingLog(origin, 5, 1, 100, 901, payloadHash),
+		"UnknownBlock":     executingLog(origin, 6, 1, 100, 900, payloadHash),
+		"FutureTimestamp":  executingLog(origin, 5, 1, 103, 900, payloadHash),
+		"WrongTimestamp":   executingLog(origin, 5, 1, 99, 900, payloadHash),
+		"UnknownLog":       executingLog(origin, 5, 2, 100, 900, payloadHash),
+		"WrongOrigin":      executingLog(common.Address{0xbb}, 5, 1, 100, 900, payloadHash),
+		"WrongPayloadHash": executingLog(origin, 5, 1, 100, 900, common.Hash{0xcc}),
+		"Malformed":        {Address: predeploys.CrossL2InboxAddr, Topics: []common.Hash{ExecutingMessageEventTopic}},
+	}
+	for name, l := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.ErrorIs(t, check(l), ErrInvalidMessage)
+		})
+	}
+}
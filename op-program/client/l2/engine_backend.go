@@ -34,8 +34,9 @@ type OracleBackedL2Chain struct {
 	earliestIndexedBlock *types.Header
 
 	// Inserted blocks
-	blocks map[common.Hash]*types.Block
-	db     ethdb.KeyValueStore
+	blocks   map[common.Hash]*types.Block
+	receipts map[common.Hash]types.Receipts
+	db       ethdb.KeyValueStore
 }
 
 var _ engineapi.EngineBackend = (*OracleBackedL2Chain)(nil)
@@ -65,6 +66,7 @@ func NewOracleBackedL2Chain(logger log.Logger, oracle Oracle, precompileOracle e
 		finalized:  head.Header(),
 		oracleHead: head.Header(),
 		blocks:     make(map[common.Hash]*types.Block),
+		receipts:   make(map[common.Hash]types.Receipts),
 		db:         NewOracleBackedDB(oracle),
 		vmCfg: vm.Config{
 			PrecompileOverrides: engineapi.CreatePrecompileOverrides(precompileOracle),
@@ -201,9 +203,17 @@ func (o *OracleBackedL2Chain) InsertBlockWithoutSetHead(block *types.Block) erro
 		return fmt.Errorf("commit block: %w", err)
 	}
 	o.blocks[block.Hash()] = block
+	o.receipts[block.Hash()] = processor.Receipts()
 	return nil
 }
 
+// InsertedReceipts returns the receipts of a block inserted into the chain.
+// Returns false if the block was not inserted, but loaded from the oracle.
+func (o *OracleBackedL2Chain) InsertedReceipts(hash common.Hash) (types.Receipts, bool) {
+	receipts, ok := o.receipts[hash]
+	return receipts, ok
+}
+
 func (o *OracleBackedL2Chain) SetCanonical(head *types.Block) (common.Hash, error) {
 	oldHead := o.head
 	o.head = head.Header()
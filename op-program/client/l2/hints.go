@@ -12,6 +12,7 @@ const (
 	HintL2Code         = "l2-code"
 	HintL2StateNode    = "l2-state-node"
 	HintL2Output       = "l2-output"
+	HintL2Receipts     = "l2-receipts"
 )
 
 type BlockHeaderHint common.Hash
@@ -53,3 +54,11 @@ var _ preimage.Hint = L2OutputHint{}
 func (l L2OutputHint) Hint() string {
 	return HintL2Output + " " + (common.Hash)(l).String()
 }
+
+type ReceiptsHint common.Hash
+
+var _ preimage.Hint = ReceiptsHint{}
+
+func (l ReceiptsHint) Hint() string {
+	return HintL2Receipts + " " + (common.Hash)(l).String()
+}
@@ -36,6 +36,13 @@ type Oracle interface {
 	OutputByRoot(root common.Hash) eth.Output
 }
 
+// ReceiptsOracle defines the API used to retrieve the receipts of L2 blocks.
+// Receipts are only needed to validate cross-chain messages in interop mode.
+type ReceiptsOracle interface {
+	// ReceiptsByBlockHash retrieves the block with the given hash, and its receipts.
+	ReceiptsByBlockHash(blockHash common.Hash) (*types.Block, types.Receipts)
+}
+
 // PreimageOracle implements Oracle using by interfacing with the pure preimage.Oracle
 // to fetch pre-images to decode into the requested data.
 type PreimageOracle struct {
@@ -43,7 +50,10 @@ type PreimageOracle struct {
 	hint   preimage.Hinter
 }
 
-var _ Oracle = (*PreimageOracle)(nil)
+var (
+	_ Oracle         = (*PreimageOracle)(nil)
+	_ ReceiptsOracle = (*PreimageOracle)(nil)
+)
 
 func NewPreimageOracle(raw preimage.Oracle, hint preimage.Hinter) *PreimageOracle {
 	return &PreimageOracle{
@@ -83,6 +93,23 @@ func (p *PreimageOracle) LoadTransactions(blockHash common.Hash, txHash common.H
 	return txs
 }
 
+func (p *PreimageOracle) ReceiptsByBlockHash(blockHash common.Hash) (*types.Block, types.Receipts) {
+	block := p.BlockByHash(blockHash)
+
+	p.hint.Hint(ReceiptsHint(blockHash))
+
+	opaqueReceipts := mpt.ReadTrie(block.ReceiptHash(), func(key common.Hash) []byte {
+		return p.oracle.Get(preimage.Keccak256Key(key))
+	})
+
+	txHashes := eth.TransactionsToHashes(block.Transactions())
+	receipts, err := eth.DecodeRawReceipts(eth.ToBlockID(block), opaqueReceipts, txHashes)
+	if err != nil {
+		panic(fmt.Errorf("bad receipts data for block %s: %w", blockHash, err))
+	}
+	return block, receipts
+}
+
 func (p *PreimageOracle) NodeByHash(nodeHash common.Hash) []byte {
 	p.hint.Hint(StateNodeHint(nodeHash))
 	return p.oracle.Get(preimage.Keccak256Key(nodeHash))
@@ -41,9 +41,16 @@ func RunProgram(logger log.Logger, preimageOracle io.ReadWriter, preimageHinter
 	pClient := preimage.NewOracleClient(preimageOracle)
 	hClient := preimage.NewHintWriter(preimageHinter)
 	l1PreimageOracle := l1.NewCachingOracle(l1.NewPreimageOracle(pClient, hClient))
-	l2PreimageOracle := l2.NewCachingOracle(l2.NewPreimageOracle(pClient, hClient))
+	l2RawOracle := l2.NewPreimageOracle(pClient, hClient)
+	l2PreimageOracle := l2.NewCachingOracle(l2RawOracle)
 
-	bootInfo := NewBootstrapClient(pClient).BootInfo()
+	bootstrap := NewBootstrapClient(pClient)
+	if bootstrap.IsInterop() {
+		bootInfo := bootstrap.BootInfoInterop()
+		logger.Info("Program Bootstrapped in interop mode", "bootInfo", bootInfo)
+		return runInteropProgram(logger, bootInfo, l1PreimageOracle, l2PreimageOracle, l2RawOracle, hClient)
+	}
+	bootInfo := bootstrap.BootInfo()
 	logger.Info("Program Bootstrapped", "bootInfo", bootInfo)
 	return runDerivation(
 		logger,
@@ -55,23 +62,37 @@ func RunProgram(logger log.Logger, preimageOracle io.ReadWriter, preimageHinter
 		bootInfo.L2ClaimBlockNumber,
 		l1PreimageOracle,
 		l2PreimageOracle,
+		hClient,
+		bootInfo.CheckpointInterval,
+		bootInfo.ProgressInterval,
 	)
 }
 
 // runDerivation executes the L2 state transition, given a minimal interface to retrieve data.
-func runDerivation(logger log.Logger, cfg *rollup.Config, l2Cfg *params.ChainConfig, l1Head common.Hash, l2OutputRoot common.Hash, l2Claim common.Hash, l2ClaimBlockNum uint64, l1Oracle l1.Oracle, l2Oracle l2.Oracle) error {
+func runDerivation(logger log.Logger, cfg *rollup.Config, l2Cfg *params.ChainConfig, l1Head common.Hash, l2OutputRoot common.Hash, l2Claim common.Hash, l2ClaimBlockNum uint64, l1Oracle l1.Oracle, l2Oracle l2.Oracle, progress preimage.Hinter, checkpointInterval uint64, progressInterval uint64) error {
+	_, l2Source, err := deriveL2(logger, cfg, l2Cfg, l1Head, l2OutputRoot, l2ClaimBlockNum, l1Oracle, l2Oracle, progress, checkpointInterval, progressInterval)
+	if err != nil {
+		return err
+	}
+	return claim.ValidateClaim(logger, l2ClaimBlockNum, eth.Bytes32(l2Claim), l2Source)
+}
+
+// deriveL2 derives the L2 chain from the agreed output root, until the claimed block is reached or the L1 data runs out.
+// Derivation progress is hinted to progress, if not nil, every progressInterval blocks,
+// with checkpoints every checkpointInterval blocks, if not 0.
+func deriveL2(logger log.Logger, cfg *rollup.Config, l2Cfg *params.ChainConfig, l1Head common.Hash, l2OutputRoot common.Hash, l2ClaimBlockNum uint64, l1Oracle l1.Oracle, l2Oracle l2.Oracle, progress preimage.Hinter, checkpointInterval uint64, progressInterval uint64) (*l2.OracleBackedL2Chain, *l2.OracleEngine, error) {
 	l1Source := l1.NewOracleL1Client(logger, l1Oracle, l1Head)
 	l1BlobsSource := l1.NewBlobFetcher(logger, l1Oracle)
 	engineBackend, err := l2.NewOracleBackedL2Chain(logger, l2Oracle, l1Oracle /* kzg oracle */, l2Cfg, l2OutputRoot)
 	if err != nil {
-		return fmt.Errorf("failed to create oracle-backed L2 chain: %w", err)
+		return nil, nil, fmt.Errorf("failed to create oracle-backed L2 chain: %w", err)
 	}
 	l2Source := l2.NewOracleEngine(cfg, logger, engineBackend)
 
 	logger.Info("Starting derivation")
-	d := cldr.NewDriver(logger, cfg, l1Source, l1BlobsSource, l2Source, l2ClaimBlockNum)
+	d := cldr.NewDriver(logger, cfg, l1Source, l1BlobsSource, l2Source, l2ClaimBlockNum, progress, checkpointInterval, progressInterval)
 	if err := d.RunComplete(); err != nil {
-		return fmt.Errorf("failed to run program to completion: %w", err)
+		return nil, nil, fmt.Errorf("failed to run program to completion: %w", err)
 	}
-	return claim.ValidateClaim(logger, l2ClaimBlockNum, eth.Bytes32(l2Claim), l2Source)
+	return engineBackend, l2Source, nil
 }
@@ -0,0 +1,102 @@
+package host
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-program/client/claim"
+	"github.com/ethereum-optimism/optimism/op-program/host/config"
+	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
+	"github.com/ethereum-optimism/optimism/op-program/host/metrics"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// preimageRecorder records all pre-images served to the client program.
+type preimageRecorder struct {
+	mu        sync.Mutex
+	preimages map[common.Hash][]byte
+}
+
+func newPreimageRecorder() *preimageRecorder {
+	return &preimageRecorder{preimages: make(map[common.Hash][]byte)}
+}
+
+func (r *preimageRecorder) wrap(getter preimage.PreimageGetter) preimage.PreimageGetter {
+	return func(key [32]byte) ([]byte, error) {
+		value, err := getter(key)
+		if err != nil {
+			return nil, err
+		}
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.preimages[key] = slices.Clone(value)
+		return value, nil
+	}
+}
+
+// ExportArchive runs the fault proof program to verify the configured claim, and writes every pre-image
+// it requested to an archive at path. The archive includes the local pre-images describing the claim.
+// When the client program runs in-process, the archive is also written if the claim is found to be invalid,
+// so the result can be audited.
+// Returns the digest of the archive.
+func ExportArchive(ctx context.Context, logger log.Logger, cfg *config.Config, path string) (common.Hash, error) {
+	if err := cfg.Check(); err != nil {
+		return common.Hash{}, fmt.Errorf("invalid config: %w", err)
+	}
+	recorder := newPreimageRecorder()
+	programErr := faultProofProgram(ctx, logger, cfg, metrics.NoopMetrics, recorder.wrap, nil)
+	if programErr != nil && !errors.Is(programErr, claim.ErrClaimNotValid) {
+		return common.Hash{}, programErr
+	}
+
+	tmpPath := path + ".tmp"
+	f, err := os.Create(tmpPath)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to create archive: %w", err)
+	}
+	digest, err := kvstore.WriteArchive(f, recorder.preimages)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return common.Hash{}, fmt.Errorf("failed to write archive: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return common.Hash{}, fmt.Errorf("failed to move archive into place: %w", err)
+	}
+	logger.Info("Exported pre-image archive", "path", path, "preimages", len(recorder.preimages), "digest", digest)
+	return digest, programErr
+}
+
+// ImportArchive imports the pre-images of the archive at path into the kv store configured by the data dir and
+// data format, so the claim can be verified offline.
+func ImportArchive(logger log.Logger, cfg *config.Config, path string) (common.Hash, error) {
+	if cfg.DataDir == "" {
+		return common.Hash{}, config.ErrDataDirRequired
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer f.Close()
+	kv, err := openKV(logger, cfg, metrics.NoopMetrics)
+	if kv != nil {
+		defer kv.Close()
+	}
+	if err != nil {
+		return common.Hash{}, err
+	}
+	count, digest, err := kvstore.ImportArchive(f, kv)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to import archive: %w", err)
+	}
+	logger.Info("Imported pre-image archive", "path", path, "preimages", count, "digest", digest)
+	return digest, nil
+}
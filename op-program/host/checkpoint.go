@@ -0,0 +1,96 @@
+package host
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/ethereum-optimism/optimism/op-program/client/driver"
+	"github.com/ethereum-optimism/optimism/op-program/host/config"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Checkpoint is an L2 block derived by the client program that an interrupted verification of the same claim
+// can resume derivation from, instead of starting again from the agreed output root.
+type Checkpoint struct {
+	// Key is the result cache key of the claim the checkpoint was derived for.
+	Key common.Hash `json:"key"`
+	// L2Head is the hash of the derived L2 block.
+	L2Head common.Hash `json:"l2Head"`
+	// L2HeadNumber is the number of the derived L2 block.
+	L2HeadNumber uint64 `json:"l2HeadNumber"`
+	// L2OutputRoot is the output root of the derived L2 block.
+	L2OutputRoot common.Hash `json:"l2OutputRoot"`
+}
+
+// resumeConfig returns a copy of cfg that starts derivation from the checkpoint.
+func (c *Checkpoint) resumeConfig(cfg *config.Config) *config.Config {
+	resumed := *cfg
+	resumed.L2Head = c.L2Head
+	resumed.L2OutputRoot = c.L2OutputRoot
+	return &resumed
+}
+
+// checkpointer stores the latest checkpoint of the verification of a claim in a file.
+type checkpointer struct {
+	path       string
+	key        common.Hash
+	chainID    uint64
+	claimBlock uint64
+}
+
+func newCheckpointer(cfg *config.Config) (*checkpointer, error) {
+	key, err := resultCacheKey(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &checkpointer{
+		path:       cfg.CheckpointFile,
+		key:        key,
+		chainID:    cfg.Rollup.L2ChainID.Uint64(),
+		claimBlock: cfg.L2ClaimBlockNumber,
+	}, nil
+}
+
+// load returns the stored checkpoint, or nil if there is no checkpoint for the claim.
+func (c *checkpointer) load() (*Checkpoint, error) {
+	checkpoint, err := jsonutil.LoadJSON[Checkpoint](c.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to load checkpoint: %w", err)
+	}
+	if checkpoint.Key != c.key {
+		// Left behind by the verification of a different claim.
+		return nil, nil
+	}
+	return checkpoint, nil
+}
+
+// save stores the progress as the checkpoint, if it includes the output root of a block before the claimed block.
+// It returns false if the progress is not a checkpoint.
+func (c *checkpointer) save(progress *driver.Progress) (bool, error) {
+	if progress.OutputRoot == nil || progress.ChainID != c.chainID || progress.SafeHead.Number >= c.claimBlock {
+		return false, nil
+	}
+	checkpoint := &Checkpoint{
+		Key:          c.key,
+		L2Head:       progress.SafeHead.Hash,
+		L2HeadNumber: progress.SafeHead.Number,
+		L2OutputRoot: common.Hash(*progress.OutputRoot),
+	}
+	if err := jsonutil.WriteJSON(c.path, checkpoint, 0o644); err != nil {
+		return false, fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	return true, nil
+}
+
+// remove deletes the stored checkpoint, once the verification no longer needs to be resumed.
+func (c *checkpointer) remove() error {
+	if err := os.Remove(c.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to remove checkpoint: %w", err)
+	}
+	return nil
+}
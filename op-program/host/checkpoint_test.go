@@ -0,0 +1,84 @@
+package host
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-program/client/driver"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckpointer(t *testing.T) {
+	cfg := cacheTestConfig(t)
+	cfg.CheckpointFile = filepath.Join(t.TempDir(), "checkpoint.json")
+	checkpoints, err := newCheckpointer(cfg)
+	require.NoError(t, err)
+	chainID := cfg.Rollup.L2ChainID.Uint64()
+	root := eth.Bytes32{0xcc}
+	progress := func(num uint64, root *eth.Bytes32) *driver.Progress {
+		return &driver.Progress{ChainID: chainID, SafeHead: eth.L2BlockRef{Hash: common.Hash{0xab}, Number: num}, OutputRoot: root}
+	}
+
+	checkpoint, err := checkpoints.load()
+	require.NoError(t, err)
+	require.Nil(t, checkpoint)
+
+	saved, err := checkpoints.save(progress(900, nil))
+	require.NoError(t, err)
+	require.False(t, saved, "progress without an output root is not a checkpoint")
+	saved, err = checkpoints.save(progress(cfg.L2ClaimBlockNumber, &root))
+	require.NoError(t, err)
+	require.False(t, saved, "the claimed block is not a checkpoint")
+	otherChain := progress(900, &root)
+	otherChain.ChainID++
+	saved, err = checkpoints.save(otherChain)
+	require.NoError(t, err)
+	require.False(t, saved)
+	_, err = os.Stat(cfg.CheckpointFile)
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	saved, err = checkpoints.save(progress(900, &root))
+	require.NoError(t, err)
+	require.True(t, saved)
+	checkpoint, err = checkpoints.load()
+	require.NoError(t, err)
+	require.Equal(t, &Checkpoint{
+		Key:          checkpoints.key,
+		L2Head:       common.Hash{0xab},
+		L2HeadNumber: 900,
+		L2OutputRoot: common.Hash(root),
+	}, checkpoint)
+
+	resumed := checkpoint.resumeConfig(cfg)
+	require.Equal(t, checkpoint.L2Head, resumed.L2Head)
+	require.Equal(t, checkpoint.L2OutputRoot, resumed.L2OutputRoot)
+	require.Equal(t, common.Hash{0x22}, cfg.L2Head, "original config must not be modified")
+
+	otherClaim := cacheTestConfig(t)
+	otherClaim.CheckpointFile = cfg.CheckpointFile
+	otherClaim.L2Claim = common.Hash{0xaa}
+	otherCheckpoints, err := newCheckpointer(otherClaim)
+	require.NoError(t, err)
+	checkpoint, err = otherCheckpoints.load()
+	require.NoError(t, err)
+	require.Nil(t, checkpoint, "checkpoints of other claims must not be resumed from")
+
+	require.NoError(t, checkpoints.remove())
+	checkpoint, err = checkpoints.load()
+	require.NoError(t, err)
+	require.Nil(t, checkpoint)
+	require.NoError(t, checkpoints.remove(), "removing a missing checkpoint is not an error")
+}
+
+func TestCheckpointer_InvalidFile(t *testing.T) {
+	cfg := cacheTestConfig(t)
+	cfg.CheckpointFile = filepath.Join(t.TempDir(), "checkpoint.json")
+	require.NoError(t, os.WriteFile(cfg.CheckpointFile, []byte("not json"), 0o644))
+	checkpoints, err := newCheckpointer(cfg)
+	require.NoError(t, err)
+	_, err = checkpoints.load()
+	require.ErrorContains(t, err, "failed to load checkpoint")
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/ethereum-optimism/optimism/op-program/host"
+	"github.com/ethereum-optimism/optimism/op-program/host/config"
+	"github.com/ethereum-optimism/optimism/op-program/host/flags"
+	"github.com/ethereum-optimism/optimism/op-program/host/types"
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/ctxinterrupt"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/urfave/cli/v2"
+)
+
+var ArchiveFileFlag = &cli.PathFlag{
+	Name:     "archive",
+	Usage:    "Path of the pre-image archive",
+	EnvVars:  opservice.PrefixEnvVar(flags.EnvVarPrefix, "ARCHIVE"),
+	Required: true,
+}
+
+var ArchiveCommand = &cli.Command{
+	Name:  "archive",
+	Usage: "Export and import archives of all pre-images required to verify a claim",
+	Subcommands: []*cli.Command{
+		{
+			Name: "export",
+			Usage: "Verify a claim and export all pre-images used to an archive. " +
+				"Accepts the same flags as verifying a claim.",
+			Flags:  append(slices.Clone(flags.Flags), ArchiveFileFlag),
+			Action: exportArchive,
+		},
+		{
+			Name:   "import",
+			Usage:  "Import the pre-images of an archive into a data dir, to verify the claim offline",
+			Flags:  append(oplog.CLIFlags(flags.EnvVarPrefix), flags.DataDir, flags.DataFormat, ArchiveFileFlag),
+			Action: importArchive,
+		},
+	},
+}
+
+func exportArchive(ctx *cli.Context) error {
+	logger, err := setupLogging(ctx)
+	if err != nil {
+		return err
+	}
+	cfg, err := config.NewConfigFromCLI(logger, ctx)
+	if err != nil {
+		return err
+	}
+	hostCtx, stop := ctxinterrupt.WithSignalWaiter(ctx.Context)
+	defer stop()
+	_, err = host.ExportArchive(ctxinterrupt.WithCancelOnInterrupt(hostCtx), logger, cfg, ctx.Path(ArchiveFileFlag.Name))
+	return err
+}
+
+func importArchive(ctx *cli.Context) error {
+	logger, err := setupLogging(ctx)
+	if err != nil {
+		return err
+	}
+	dataFormat := types.DataFormat(ctx.String(flags.DataFormat.Name))
+	if !slices.Contains(types.SupportedDataFormats, dataFormat) {
+		return fmt.Errorf("%w: %v", config.ErrInvalidDataFormat, dataFormat)
+	}
+	cfg := &config.Config{
+		DataDir:    ctx.String(flags.DataDir.Name),
+		DataFormat: dataFormat,
+	}
+	_, err = host.ImportArchive(logger, cfg, ctx.Path(ArchiveFileFlag.Name))
+	return err
+}
@@ -41,6 +41,7 @@ func run(args []string, action ConfigAction) error {
 	app := cli.NewApp()
 	app.Version = VersionWithMeta
 	app.Flags = flags.Flags
+	app.Commands = []*cli.Command{ArchiveCommand}
 	app.Name = "op-program"
 	app.Usage = "Optimism Fault Proof Program"
 	app.Description = "The Optimism Fault Proof Program fault proof program that runs through the rollup state-transition to verify an L2 output from L1 inputs."
@@ -4,18 +4,23 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	"github.com/ethereum-optimism/optimism/op-program/chainconfig"
 	"github.com/ethereum-optimism/optimism/op-program/host/config"
+	"github.com/ethereum-optimism/optimism/op-program/host/flags"
 	"github.com/ethereum-optimism/optimism/op-program/host/types"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/stretchr/testify/require"
 )
@@ -135,12 +140,73 @@ func TestDataFormat(t *testing.T) {
 	})
 }
 
+func TestDataMaxSize(t *testing.T) {
+	t.Run("DefaultUnlimited", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Zero(t, cfg.DataMaxSize)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--data.max-size", "512", "--l1", "http://localhost:8545", "--l2", "http://localhost:9545"))
+		require.Equal(t, uint64(512*1024*1024), cfg.DataMaxSize)
+	})
+}
+
+func TestMetrics(t *testing.T) {
+	t.Run("DefaultDisabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.False(t, cfg.MetricsConfig.Enabled)
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--metrics.enabled", "--metrics.port", "7301"))
+		require.True(t, cfg.MetricsConfig.Enabled)
+		require.Equal(t, 7301, cfg.MetricsConfig.ListenPort)
+	})
+}
+
+func TestRemoteKV(t *testing.T) {
+	t.Run("DefaultDisabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Empty(t, cfg.RemoteKVEndpoint)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(
+			"--remotekv.endpoint", "storage.googleapis.com",
+			"--remotekv.bucket", "bucket",
+			"--remotekv.prefix", "preimages",
+			"--remotekv.access-key-id", "id",
+			"--remotekv.access-key-secret", "secret",
+			"--remotekv.insecure"))
+		require.Equal(t, "storage.googleapis.com", cfg.RemoteKVEndpoint)
+		require.Equal(t, "bucket", cfg.RemoteKVBucket)
+		require.Equal(t, "preimages", cfg.RemoteKVPrefix)
+		require.Equal(t, "id", cfg.RemoteKVAccessKeyID)
+		require.Equal(t, "secret", cfg.RemoteKVAccessKeySecret)
+		require.True(t, cfg.RemoteKVInsecure)
+	})
+}
+
 func TestL2(t *testing.T) {
 	expected := "https://example.com:8545"
 	cfg := configForArgs(t, addRequiredArgs("--l2", expected))
 	require.Equal(t, expected, cfg.L2URL)
 }
 
+func TestL2ChainData(t *testing.T) {
+	t.Run("NotRequired", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Equal(t, "", cfg.L2ChainDataDir)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--l2.chaindata", "/geth/chaindata", "--l1", "http://localhost:8545"))
+		require.Equal(t, "/geth/chaindata", cfg.L2ChainDataDir)
+		require.True(t, cfg.FetchingEnabled())
+	})
+}
+
 func TestL2Genesis(t *testing.T) {
 	t.Run("RequiredWithCustomNetwork", func(t *testing.T) {
 		rollupCfgFile := writeValidRollupConfig(t)
@@ -301,6 +367,45 @@ func TestExec(t *testing.T) {
 	})
 }
 
+func TestOutputReport(t *testing.T) {
+	t.Run("DefaultEmpty", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Equal(t, "", cfg.OutputReport)
+	})
+	t.Run("Set", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--output-report", "/tmp/report.json"))
+		require.Equal(t, "/tmp/report.json", cfg.OutputReport)
+	})
+}
+
+func TestResultCache(t *testing.T) {
+	t.Run("DefaultDisabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Equal(t, "", cfg.ResultCacheDir)
+		require.False(t, cfg.ForceRerun)
+	})
+	t.Run("Set", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--result-cache", "/tmp/results", "--force-rerun"))
+		require.Equal(t, "/tmp/results", cfg.ResultCacheDir)
+		require.True(t, cfg.ForceRerun)
+	})
+}
+
+func TestProgress(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Equal(t, "", cfg.ProgressOutput)
+		require.Equal(t, 30*time.Second, cfg.ProgressInterval)
+		require.Equal(t, "", cfg.CheckpointFile)
+	})
+	t.Run("Set", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--progress-output", "-", "--progress-interval", "5m", "--checkpoint-file", "/tmp/checkpoint.json"))
+		require.Equal(t, "-", cfg.ProgressOutput)
+		require.Equal(t, 5*time.Minute, cfg.ProgressInterval)
+		require.Equal(t, "/tmp/checkpoint.json", cfg.CheckpointFile)
+	})
+}
+
 func TestServerMode(t *testing.T) {
 	t.Run("DefaultFalse", func(t *testing.T) {
 		cfg := configForArgs(t, addRequiredArgs())
@@ -323,6 +428,100 @@ func TestServerMode(t *testing.T) {
 	})
 }
 
+func TestGRPC(t *testing.T) {
+	t.Run("DefaultEmpty", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Empty(t, cfg.GRPCAddr)
+		require.Empty(t, cfg.GRPCAuthToken)
+		require.False(t, cfg.GRPCTLS.TLSEnabled())
+	})
+	t.Run("Set", func(t *testing.T) {
+		tokenFile := filepath.Join(t.TempDir(), "token")
+		require.NoError(t, os.WriteFile(tokenFile, []byte("secret\n"), 0o600))
+		cfg := configForArgs(t, addRequiredArgs("--server", "--grpc.addr", "unix:///tmp/preimage.sock",
+			"--grpc.auth-token-file", tokenFile,
+			"--grpc.tls.ca", "ca.crt", "--grpc.tls.cert", "tls.crt", "--grpc.tls.key", "tls.key"))
+		require.Equal(t, "unix:///tmp/preimage.sock", cfg.GRPCAddr)
+		require.Equal(t, "secret", cfg.GRPCAuthToken)
+		require.Equal(t, "ca.crt", cfg.GRPCTLS.TLSCaCert)
+		require.Equal(t, "tls.crt", cfg.GRPCTLS.TLSCert)
+		require.Equal(t, "tls.key", cfg.GRPCTLS.TLSKey)
+	})
+	t.Run("TokenFromEnv", func(t *testing.T) {
+		t.Setenv(flags.GRPCAuthTokenEnvVar, "secret")
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Equal(t, "secret", cfg.GRPCAuthToken)
+	})
+	t.Run("TokenFromFileAndEnv", func(t *testing.T) {
+		t.Setenv(flags.GRPCAuthTokenEnvVar, "secret")
+		tokenFile := filepath.Join(t.TempDir(), "token")
+		require.NoError(t, os.WriteFile(tokenFile, []byte("secret"), 0o600))
+		verifyArgsInvalid(t, config.ErrGRPCAuthTokenConflict.Error(), addRequiredArgs("--grpc.auth-token-file", tokenFile))
+	})
+	t.Run("NoTokenFlag", func(t *testing.T) {
+		verifyArgsInvalid(t, "flag provided but not defined: -grpc.auth-token", addRequiredArgs("--grpc.auth-token", "secret"))
+	})
+}
+
+func TestPrefetch(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Zero(t, cfg.PrefetchWorkers)
+		require.Equal(t, 4, cfg.PrefetchL1Concurrency)
+		require.Equal(t, 8, cfg.PrefetchL2Concurrency)
+		require.False(t, cfg.PrefetchLookahead)
+	})
+	t.Run("Set", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--prefetch.workers", "16", "--prefetch.l1-concurrency", "2",
+			"--prefetch.l2-concurrency", "12", "--prefetch.lookahead"))
+		require.Equal(t, 16, cfg.PrefetchWorkers)
+		require.Equal(t, 2, cfg.PrefetchL1Concurrency)
+		require.Equal(t, 12, cfg.PrefetchL2Concurrency)
+		require.True(t, cfg.PrefetchLookahead)
+	})
+}
+
+func TestInterop(t *testing.T) {
+	prestate := []byte{1, 2, 3}
+	interopArgs := func(t *testing.T, except string, args ...string) []string {
+		req := map[string]string{
+			"--l1.head":               l1HeadValue,
+			"--l2.outputroot":         crypto.Keccak256Hash(prestate).Hex(),
+			"--l2.claim":              l2ClaimValue,
+			"--l2.agreed-prestate":    hexutil.Encode(prestate),
+			"--l2.claim-timestamp":    "7000",
+			"--interop.rollup-config": writeValidRollupConfig(t),
+			"--interop.l2-genesis":    writeValidGenesis(t),
+		}
+		delete(req, except)
+		return append(toArgList(req), args...)
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, interopArgs(t, "", "--interop.l2", "http://example.com:8545", "--l1", "http://example.com:9545"))
+		require.True(t, cfg.InteropEnabled())
+		require.Equal(t, prestate, cfg.AgreedPrestate)
+		require.Equal(t, uint64(7000), cfg.L2ClaimTimestamp)
+		require.Len(t, cfg.InteropChains, 1)
+		require.Equal(t, chaincfg.Sepolia, cfg.InteropChains[0].Rollup)
+		require.Equal(t, l2GenesisConfig, cfg.InteropChains[0].L2ChainConfig)
+		require.Equal(t, "http://example.com:8545", cfg.InteropChains[0].L2URL)
+	})
+
+	t.Run("ClaimTimestampRequired", func(t *testing.T) {
+		verifyArgsInvalid(t, "flag l2.claim-timestamp is required in interop mode", interopArgs(t, "--l2.claim-timestamp"))
+	})
+
+	t.Run("GenesisRequiredForEachChain", func(t *testing.T) {
+		verifyArgsInvalid(t, "flag interop.l2-genesis must be set for each chain",
+			interopArgs(t, "", "--interop.rollup-config", writeValidRollupConfig(t)))
+	})
+
+	t.Run("InvalidPrestate", func(t *testing.T) {
+		verifyArgsInvalid(t, config.ErrInvalidAgreedPrestate.Error(), interopArgs(t, "--l2.agreed-prestate", "--l2.agreed-prestate", "0xzz"))
+	})
+}
+
 func verifyArgsInvalid(t *testing.T, messageContains string, cliArgs []string) {
 	_, _, err := runWithArgs(cliArgs)
 	require.ErrorContains(t, err, messageContains)
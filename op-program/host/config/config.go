@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"os"
 	"slices"
+	"strings"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	"github.com/ethereum-optimism/optimism/op-program/host/types"
@@ -13,28 +15,61 @@ import (
 	opnode "github.com/ethereum-optimism/optimism/op-node"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-program/host/flags"
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
+	optls "github.com/ethereum-optimism/optimism/op-service/tls"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/urfave/cli/v2"
 )
 
+// DefaultCheckpointInterval is the number of L2 blocks between the checkpoints of the client program,
+// if a checkpoint file is configured.
+const DefaultCheckpointInterval = 100
+
 var (
-	ErrMissingRollupConfig = errors.New("missing rollup config")
-	ErrMissingL2Genesis    = errors.New("missing l2 genesis")
-	ErrInvalidL1Head       = errors.New("invalid l1 head")
-	ErrInvalidL2Head       = errors.New("invalid l2 head")
-	ErrInvalidL2OutputRoot = errors.New("invalid l2 output root")
-	ErrL1AndL2Inconsistent = errors.New("l1 and l2 options must be specified together or both omitted")
-	ErrInvalidL2Claim      = errors.New("invalid l2 claim")
-	ErrInvalidL2ClaimBlock = errors.New("invalid l2 claim block number")
-	ErrDataDirRequired     = errors.New("datadir must be specified when in non-fetching mode")
-	ErrNoExecInServerMode  = errors.New("exec command must not be set when in server mode")
-	ErrInvalidDataFormat   = errors.New("invalid data format")
+	ErrMissingRollupConfig      = errors.New("missing rollup config")
+	ErrMissingL2Genesis         = errors.New("missing l2 genesis")
+	ErrInvalidL1Head            = errors.New("invalid l1 head")
+	ErrInvalidL2Head            = errors.New("invalid l2 head")
+	ErrInvalidL2OutputRoot      = errors.New("invalid l2 output root")
+	ErrL1AndL2Inconsistent      = errors.New("l1 and l2 options must be specified together or both omitted")
+	ErrInvalidL2Claim           = errors.New("invalid l2 claim")
+	ErrInvalidL2ClaimBlock      = errors.New("invalid l2 claim block number")
+	ErrDataDirRequired          = errors.New("datadir must be specified when in non-fetching mode")
+	ErrNoExecInServerMode       = errors.New("exec command must not be set when in server mode")
+	ErrNoReportInServerMode     = errors.New("output report must not be set when in server mode")
+	ErrNoCacheInServerMode      = errors.New("result cache must not be set when in server mode")
+	ErrNoCheckpointInServerMode = errors.New("checkpoint file must not be set when in server mode")
+	ErrInvalidProgressInterval  = errors.New("progress interval must not be negative")
+	ErrInvalidDataFormat        = errors.New("invalid data format")
+	ErrMissingRemoteBucket      = errors.New("remote kv bucket must be specified when remote kv endpoint is set")
+	ErrGRPCWithoutServer        = errors.New("grpc address must only be set when in server mode")
+	ErrGRPCAuthWithoutTLS       = errors.New("grpc auth token requires grpc tls")
+	ErrGRPCAuthTokenConflict    = errors.New("grpc auth token must not be set both as file and env var")
+	ErrInvalidPrefetch          = errors.New("prefetch workers and concurrency limits must not be negative")
+	ErrMaxSizeRequiresFetching  = errors.New("data max size must only be set when fetching is enabled")
+
+	ErrInvalidAgreedPrestate = errors.New("agreed prestate does not match l2 output root")
+	ErrInvalidClaimTimestamp = errors.New("invalid l2 claim timestamp")
+	ErrMissingInteropChains  = errors.New("missing interop chains")
+
+	ErrL2URLAndChainData     = errors.New("l2 rpc and l2 chaindata must not both be specified")
+	ErrChainDataWithInterop  = errors.New("l2 chaindata is not supported in interop mode")
+	ErrCheckpointWithInterop = errors.New("checkpoint file is not supported in interop mode")
 )
 
+// InteropChain is the configuration of one of the chains covered by the super roots in interop mode.
+type InteropChain struct {
+	Rollup        *rollup.Config
+	L2ChainConfig *params.ChainConfig
+	L2URL         string
+}
+
 type Config struct {
 	Rollup *rollup.Config
 	// DataDir is the directory to read/write pre-image data from/to.
@@ -44,6 +79,20 @@ type Config struct {
 	// DataFormat specifies the format to use for on-disk storage. Only applies when DataDir is set.
 	DataFormat types.DataFormat
 
+	// DataMaxSize is the maximum total size, in bytes, of the stored pre-images.
+	// The least recently used pre-images are evicted when exceeded, and fetched again when required.
+	// Unlimited if 0.
+	DataMaxSize uint64
+
+	// RemoteKVEndpoint is the endpoint of an S3 compatible object store used to share pre-images.
+	// If not set, pre-images are only stored locally.
+	RemoteKVEndpoint        string
+	RemoteKVBucket          string
+	RemoteKVPrefix          string
+	RemoteKVAccessKeyID     string
+	RemoteKVAccessKeySecret string
+	RemoteKVInsecure        bool
+
 	// L1Head is the block hash of the L1 chain head block
 	L1Head      common.Hash
 	L1URL       string
@@ -56,6 +105,9 @@ type Config struct {
 	// L2OutputRoot is the agreed L2 output root to start derivation from
 	L2OutputRoot common.Hash
 	L2URL        string
+	// L2ChainDataDir is the chaindata directory of an op-geth node to read L2 data from, instead of L2URL.
+	// The node must be stopped, use the hash state scheme and retain the state from L2Head onwards.
+	L2ChainDataDir string
 	// L2Claim is the claimed L2 output root to verify
 	L2Claim common.Hash
 	// L2ClaimBlockNumber is the block number the claimed L2 output root is from
@@ -67,15 +119,92 @@ type Config struct {
 	// If unset, the fault proof client is run in the same process.
 	ExecCmd string
 
+	// OutputReport is the path to write a JSON report of the verification of the claim to.
+	// No report is written if unset.
+	OutputReport string
+
+	// ResultCacheDir is the directory to cache the results of verifications in, keyed by all their inputs.
+	// A claim found valid or invalid before is not verified again. Results are not cached if unset.
+	ResultCacheDir string
+	// ForceRerun runs the program even if the result of the verification is cached, and updates the cached result.
+	ForceRerun bool
+
+	// ProgressOutput is the path to append JSON progress records of the client program to, or - for stdout.
+	// No progress records are written if unset.
+	ProgressOutput string
+	// ProgressInterval is the interval at which the progress of the client program is logged and written.
+	// Progress is not reported if 0.
+	ProgressInterval time.Duration
+	// CheckpointFile is the path to write derivation checkpoints of the client program to.
+	// A matching checkpoint in the file is resumed from instead of the agreed output root. Not checkpointed if unset.
+	CheckpointFile string
+
 	// ServerMode indicates that the program should run in pre-image server mode and wait for requests.
 	// No client program is run.
 	ServerMode bool
 
+	// GRPCAddr is the address to serve pre-images on over gRPC when in server mode.
+	// If not set, pre-images are served over the file descriptor based pre-image oracle.
+	GRPCAddr string
+	// GRPCAuthToken is the bearer token gRPC clients must authenticate with. Authentication is disabled if empty.
+	// The token can only be used over TLS.
+	GRPCAuthToken string
+	// GRPCTLS configures mutual TLS for the gRPC server. TLS is disabled if no files are set.
+	GRPCTLS optls.CLIConfig
+
+	// PrefetchWorkers is the number of hints prefetched concurrently in the background.
+	// Background prefetching is disabled if 0, and hints are only fetched when the client requests a pre-image.
+	PrefetchWorkers int
+	// PrefetchL1Concurrency and PrefetchL2Concurrency limit the number of concurrent background prefetches
+	// per L1 and L2 hint type respectively. Unlimited if 0.
+	PrefetchL1Concurrency int
+	PrefetchL2Concurrency int
+	// PrefetchLookahead enables prefetching the transactions and receipts of hinted L1 blocks before they are hinted.
+	PrefetchLookahead bool
+
+	MetricsConfig opmetrics.CLIConfig
+
 	// IsCustomChainConfig indicates that the program uses a custom chain configuration
 	IsCustomChainConfig bool
+
+	// AgreedPrestate is the preimage of the agreed super root. If set, the program runs in interop mode:
+	// L2OutputRoot is the hash of AgreedPrestate, L2Claim is the claimed super root at L2ClaimTimestamp,
+	// and the chains are configured by InteropChains rather than Rollup, L2ChainConfig, L2URL and L2Head.
+	AgreedPrestate   []byte
+	L2ClaimTimestamp uint64
+	InteropChains    []InteropChain
+}
+
+// CheckpointInterval returns the number of L2 blocks between the checkpoints of the client program,
+// or 0 if no checkpoint file is configured.
+func (c *Config) CheckpointInterval() uint64 {
+	if c.CheckpointFile == "" {
+		return 0
+	}
+	return DefaultCheckpointInterval
+}
+
+// ProgressHintInterval returns the number of L2 blocks between the progress hints of the client program,
+// or 0 if no progress output is configured and the client only hints progress at its default coarse interval.
+func (c *Config) ProgressHintInterval() uint64 {
+	if c.ProgressOutput == "" {
+		return 0
+	}
+	return 1
+}
+
+// InteropEnabled returns true if the program validates a super root claim, covering multiple chains.
+func (c *Config) InteropEnabled() bool {
+	return len(c.AgreedPrestate) > 0
 }
 
 func (c *Config) Check() error {
+	if c.InteropEnabled() {
+		if err := c.checkInterop(); err != nil {
+			return err
+		}
+		return c.checkCommon()
+	}
 	if c.Rollup == nil {
 		return ErrMissingRollupConfig
 	}
@@ -97,24 +226,107 @@ func (c *Config) Check() error {
 	if c.L2ChainConfig == nil {
 		return ErrMissingL2Genesis
 	}
-	if (c.L1URL != "") != (c.L2URL != "") {
+	if c.L2URL != "" && c.L2ChainDataDir != "" {
+		return ErrL2URLAndChainData
+	}
+	if (c.L1URL != "") != (c.L2URL != "" || c.L2ChainDataDir != "") {
 		return ErrL1AndL2Inconsistent
 	}
+	return c.checkCommon()
+}
+
+func (c *Config) checkInterop() error {
+	if len(c.InteropChains) == 0 {
+		return ErrMissingInteropChains
+	}
+	if c.L2ChainDataDir != "" {
+		return ErrChainDataWithInterop
+	}
+	if c.CheckpointFile != "" {
+		return ErrCheckpointWithInterop
+	}
+	for _, chain := range c.InteropChains {
+		if chain.Rollup == nil {
+			return ErrMissingRollupConfig
+		}
+		if err := chain.Rollup.Check(); err != nil {
+			return err
+		}
+		if chain.L2ChainConfig == nil {
+			return ErrMissingL2Genesis
+		}
+		if (c.L1URL != "") != (chain.L2URL != "") {
+			return ErrL1AndL2Inconsistent
+		}
+	}
+	if c.L1Head == (common.Hash{}) {
+		return ErrInvalidL1Head
+	}
+	if c.L2OutputRoot == (common.Hash{}) {
+		return ErrInvalidL2OutputRoot
+	}
+	if crypto.Keccak256Hash(c.AgreedPrestate) != c.L2OutputRoot {
+		return ErrInvalidAgreedPrestate
+	}
+	if c.L2ClaimTimestamp == 0 {
+		return ErrInvalidClaimTimestamp
+	}
+	return nil
+}
+
+// checkCommon checks the options that do not depend on the chains the program runs for.
+func (c *Config) checkCommon() error {
 	if !c.FetchingEnabled() && c.DataDir == "" {
 		return ErrDataDirRequired
 	}
 	if c.ServerMode && c.ExecCmd != "" {
 		return ErrNoExecInServerMode
 	}
+	if c.ServerMode && c.OutputReport != "" {
+		return ErrNoReportInServerMode
+	}
+	if c.ServerMode && c.ResultCacheDir != "" {
+		return ErrNoCacheInServerMode
+	}
+	if c.ServerMode && c.CheckpointFile != "" {
+		return ErrNoCheckpointInServerMode
+	}
+	if c.ProgressInterval < 0 {
+		return ErrInvalidProgressInterval
+	}
 	if c.DataDir != "" && !slices.Contains(types.SupportedDataFormats, c.DataFormat) {
 		return ErrInvalidDataFormat
 	}
+	if c.RemoteKVEndpoint != "" && c.RemoteKVBucket == "" {
+		return ErrMissingRemoteBucket
+	}
+	if c.DataMaxSize != 0 && !c.FetchingEnabled() {
+		return ErrMaxSizeRequiresFetching
+	}
+	if c.GRPCAddr != "" && !c.ServerMode {
+		return ErrGRPCWithoutServer
+	}
+	if err := c.GRPCTLS.Check(); err != nil {
+		return fmt.Errorf("invalid grpc tls config: %w", err)
+	}
+	if c.GRPCAuthToken != "" && !c.GRPCTLS.TLSEnabled() {
+		return ErrGRPCAuthWithoutTLS
+	}
+	if c.PrefetchWorkers < 0 || c.PrefetchL1Concurrency < 0 || c.PrefetchL2Concurrency < 0 {
+		return ErrInvalidPrefetch
+	}
+	if err := c.MetricsConfig.Check(); err != nil {
+		return err
+	}
 	return nil
 }
 
 func (c *Config) FetchingEnabled() bool {
 	// TODO: Include Beacon URL once cancun is active on all chains we fault prove.
-	return c.L1URL != "" && c.L2URL != ""
+	if c.InteropEnabled() {
+		return c.L1URL != "" && len(c.InteropChains) > 0 && c.InteropChains[0].L2URL != ""
+	}
+	return c.L1URL != "" && (c.L2URL != "" || c.L2ChainDataDir != "")
 }
 
 // NewConfig creates a Config with all optional values set to the CLI default value
@@ -130,16 +342,20 @@ func NewConfig(
 	_, err := params.LoadOPStackChainConfig(l2Genesis.ChainID.Uint64())
 	isCustomConfig := err != nil
 	return &Config{
-		Rollup:              rollupCfg,
-		L2ChainConfig:       l2Genesis,
-		L1Head:              l1Head,
-		L2Head:              l2Head,
-		L2OutputRoot:        l2OutputRoot,
-		L2Claim:             l2Claim,
-		L2ClaimBlockNumber:  l2ClaimBlockNum,
-		L1RPCKind:           sources.RPCKindStandard,
-		IsCustomChainConfig: isCustomConfig,
-		DataFormat:          types.DataFormatFile,
+		Rollup:                rollupCfg,
+		L2ChainConfig:         l2Genesis,
+		L1Head:                l1Head,
+		L2Head:                l2Head,
+		L2OutputRoot:          l2OutputRoot,
+		L2Claim:               l2Claim,
+		L2ClaimBlockNumber:    l2ClaimBlockNum,
+		L1RPCKind:             sources.RPCKindStandard,
+		IsCustomChainConfig:   isCustomConfig,
+		DataFormat:            types.DataFormatFile,
+		PrefetchL1Concurrency: flags.PrefetchL1Concurrency.Value,
+		PrefetchL2Concurrency: flags.PrefetchL2Concurrency.Value,
+		ProgressInterval:      flags.ProgressInterval.Value,
+		MetricsConfig:         opmetrics.DefaultCLIConfig(),
 	}
 }
 
@@ -147,6 +363,9 @@ func NewConfigFromCLI(log log.Logger, ctx *cli.Context) (*Config, error) {
 	if err := flags.CheckRequired(ctx); err != nil {
 		return nil, err
 	}
+	if ctx.IsSet(flags.AgreedPrestate.Name) {
+		return newInteropConfigFromCLI(log, ctx)
+	}
 	rollupCfg, err := opnode.NewRollupConfigFromCLI(log, ctx)
 	if err != nil {
 		return nil, err
@@ -155,22 +374,9 @@ func NewConfigFromCLI(log log.Logger, ctx *cli.Context) (*Config, error) {
 	if l2Head == (common.Hash{}) {
 		return nil, ErrInvalidL2Head
 	}
-	l2OutputRoot := common.HexToHash(ctx.String(flags.L2OutputRoot.Name))
-	if l2OutputRoot == (common.Hash{}) {
-		return nil, ErrInvalidL2OutputRoot
-	}
-	strClaim := ctx.String(flags.L2Claim.Name)
-	l2Claim := common.HexToHash(strClaim)
-	// Require a valid hash, with the zero hash explicitly allowed.
-	if l2Claim == (common.Hash{}) &&
-		strClaim != "0x0000000000000000000000000000000000000000000000000000000000000000" &&
-		strClaim != "0000000000000000000000000000000000000000000000000000000000000000" {
-		return nil, fmt.Errorf("%w: %v", ErrInvalidL2Claim, strClaim)
-	}
-	l2ClaimBlockNum := ctx.Uint64(flags.L2BlockNumber.Name)
-	l1Head := common.HexToHash(ctx.String(flags.L1Head.Name))
-	if l1Head == (common.Hash{}) {
-		return nil, ErrInvalidL1Head
+	cfg, err := newCommonConfigFromCLI(ctx)
+	if err != nil {
+		return nil, err
 	}
 	l2GenesisPath := ctx.String(flags.L2GenesisPath.Name)
 	var l2ChainConfig *params.ChainConfig
@@ -193,31 +399,135 @@ func NewConfigFromCLI(log log.Logger, ctx *cli.Context) (*Config, error) {
 	if err != nil {
 		return nil, fmt.Errorf("invalid genesis: %w", err)
 	}
+	cfg.Rollup = rollupCfg
+	cfg.L2URL = ctx.String(flags.L2NodeAddr.Name)
+	cfg.L2ChainConfig = l2ChainConfig
+	cfg.L2Head = l2Head
+	cfg.L2ClaimBlockNumber = ctx.Uint64(flags.L2BlockNumber.Name)
+	cfg.IsCustomChainConfig = isCustomConfig
+	return cfg, nil
+}
+
+func newInteropConfigFromCLI(log log.Logger, ctx *cli.Context) (*Config, error) {
+	agreedPrestate, err := hexutil.Decode(ctx.String(flags.AgreedPrestate.Name))
+	if err != nil || len(agreedPrestate) == 0 {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAgreedPrestate, ctx.String(flags.AgreedPrestate.Name))
+	}
+	cfg, err := newCommonConfigFromCLI(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rollupPaths := ctx.StringSlice(flags.InteropRollupConfigs.Name)
+	genesisPaths := ctx.StringSlice(flags.InteropL2GenesisPaths.Name)
+	l2URLs := ctx.StringSlice(flags.InteropL2NodeAddrs.Name)
+	for i, rollupPath := range rollupPaths {
+		rollupCfg, err := opnode.NewRollupConfig(log, "", rollupPath)
+		if err != nil {
+			return nil, err
+		}
+		l2ChainConfig, err := loadChainConfigFromGenesis(genesisPaths[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid genesis: %w", err)
+		}
+		chain := InteropChain{Rollup: rollupCfg, L2ChainConfig: l2ChainConfig}
+		if len(l2URLs) > 0 {
+			chain.L2URL = l2URLs[i]
+		}
+		cfg.InteropChains = append(cfg.InteropChains, chain)
+	}
+	cfg.AgreedPrestate = agreedPrestate
+	cfg.L2ClaimTimestamp = ctx.Uint64(flags.L2ClaimTimestamp.Name)
+	cfg.IsCustomChainConfig = true
+	return cfg, nil
+}
+
+// newCommonConfigFromCLI creates a Config with the options that do not depend on the chains the program runs for.
+func newCommonConfigFromCLI(ctx *cli.Context) (*Config, error) {
+	l2OutputRoot := common.HexToHash(ctx.String(flags.L2OutputRoot.Name))
+	if l2OutputRoot == (common.Hash{}) {
+		return nil, ErrInvalidL2OutputRoot
+	}
+	strClaim := ctx.String(flags.L2Claim.Name)
+	l2Claim := common.HexToHash(strClaim)
+	// Require a valid hash, with the zero hash explicitly allowed.
+	if l2Claim == (common.Hash{}) &&
+		strClaim != "0x0000000000000000000000000000000000000000000000000000000000000000" &&
+		strClaim != "0000000000000000000000000000000000000000000000000000000000000000" {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidL2Claim, strClaim)
+	}
+	l1Head := common.HexToHash(ctx.String(flags.L1Head.Name))
+	if l1Head == (common.Hash{}) {
+		return nil, ErrInvalidL1Head
+	}
 	dbFormat := types.DataFormat(ctx.String(flags.DataFormat.Name))
 	if !slices.Contains(types.SupportedDataFormats, dbFormat) {
 		return nil, fmt.Errorf("invalid %w: %v", ErrInvalidDataFormat, dbFormat)
 	}
+	grpcAuthToken, err := readGRPCAuthToken(ctx.Path(flags.GRPCAuthTokenFile.Name))
+	if err != nil {
+		return nil, err
+	}
 	return &Config{
-		Rollup:              rollupCfg,
-		DataDir:             ctx.String(flags.DataDir.Name),
-		DataFormat:          dbFormat,
-		L2URL:               ctx.String(flags.L2NodeAddr.Name),
-		L2ChainConfig:       l2ChainConfig,
-		L2Head:              l2Head,
-		L2OutputRoot:        l2OutputRoot,
-		L2Claim:             l2Claim,
-		L2ClaimBlockNumber:  l2ClaimBlockNum,
-		L1Head:              l1Head,
-		L1URL:               ctx.String(flags.L1NodeAddr.Name),
-		L1BeaconURL:         ctx.String(flags.L1BeaconAddr.Name),
-		L1TrustRPC:          ctx.Bool(flags.L1TrustRPC.Name),
-		L1RPCKind:           sources.RPCProviderKind(ctx.String(flags.L1RPCProviderKind.Name)),
-		ExecCmd:             ctx.String(flags.Exec.Name),
-		ServerMode:          ctx.Bool(flags.Server.Name),
-		IsCustomChainConfig: isCustomConfig,
+		DataDir:                 ctx.String(flags.DataDir.Name),
+		DataFormat:              dbFormat,
+		DataMaxSize:             ctx.Uint64(flags.DataMaxSize.Name) * 1024 * 1024,
+		RemoteKVEndpoint:        ctx.String(flags.RemoteKVEndpoint.Name),
+		RemoteKVBucket:          ctx.String(flags.RemoteKVBucket.Name),
+		RemoteKVPrefix:          ctx.String(flags.RemoteKVPrefix.Name),
+		RemoteKVAccessKeyID:     ctx.String(flags.RemoteKVAccessKeyID.Name),
+		RemoteKVAccessKeySecret: ctx.String(flags.RemoteKVAccessKeySecret.Name),
+		RemoteKVInsecure:        ctx.Bool(flags.RemoteKVInsecure.Name),
+		L2OutputRoot:            l2OutputRoot,
+		L2Claim:                 l2Claim,
+		L1Head:                  l1Head,
+		L2ChainDataDir:          ctx.String(flags.L2ChainData.Name),
+		L1URL:                   ctx.String(flags.L1NodeAddr.Name),
+		L1BeaconURL:             ctx.String(flags.L1BeaconAddr.Name),
+		L1TrustRPC:              ctx.Bool(flags.L1TrustRPC.Name),
+		L1RPCKind:               sources.RPCProviderKind(ctx.String(flags.L1RPCProviderKind.Name)),
+		ExecCmd:                 ctx.String(flags.Exec.Name),
+		OutputReport:            ctx.Path(flags.OutputReport.Name),
+		ResultCacheDir:          ctx.Path(flags.ResultCache.Name),
+		ForceRerun:              ctx.Bool(flags.ForceRerun.Name),
+		ProgressOutput:          ctx.Path(flags.ProgressOutput.Name),
+		ProgressInterval:        ctx.Duration(flags.ProgressInterval.Name),
+		CheckpointFile:          ctx.Path(flags.CheckpointFile.Name),
+		ServerMode:              ctx.Bool(flags.Server.Name),
+		GRPCAddr:                ctx.String(flags.GRPCAddr.Name),
+		GRPCAuthToken:           grpcAuthToken,
+		GRPCTLS: optls.CLIConfig{
+			TLSCaCert: ctx.Path(flags.GRPCTLSCaCert.Name),
+			TLSCert:   ctx.Path(flags.GRPCTLSCert.Name),
+			TLSKey:    ctx.Path(flags.GRPCTLSKey.Name),
+		},
+		PrefetchWorkers:       ctx.Int(flags.PrefetchWorkers.Name),
+		PrefetchL1Concurrency: ctx.Int(flags.PrefetchL1Concurrency.Name),
+		PrefetchL2Concurrency: ctx.Int(flags.PrefetchL2Concurrency.Name),
+		PrefetchLookahead:     ctx.Bool(flags.PrefetchLookahead.Name),
+		MetricsConfig:         opmetrics.ReadCLIConfig(ctx),
 	}, nil
 }
 
+// readGRPCAuthToken reads the gRPC auth token from the file at path if set, or from the auth token env var otherwise.
+func readGRPCAuthToken(path string) (string, error) {
+	envToken := os.Getenv(flags.GRPCAuthTokenEnvVar)
+	if path == "" {
+		return envToken, nil
+	}
+	if envToken != "" {
+		return "", ErrGRPCAuthTokenConflict
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read grpc auth token file: %w", err)
+	}
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", fmt.Errorf("grpc auth token file %v is empty", path)
+	}
+	return token, nil
+}
+
 func loadChainConfigFromGenesis(path string) (*params.ChainConfig, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -4,12 +4,15 @@ import (
 	"fmt"
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-program/chainconfig"
 	"github.com/ethereum-optimism/optimism/op-program/host/types"
+	optls "github.com/ethereum-optimism/optimism/op-service/tls"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/stretchr/testify/require"
 )
@@ -111,6 +114,25 @@ func TestFetchingArgConsistency(t *testing.T) {
 		cfg.L2URL = "https://example.com:4678"
 		require.NoError(t, cfg.Check())
 	})
+	t.Run("AllowL2ChainDataWithL1", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.L1URL = "https://example.com:1234"
+		cfg.L2ChainDataDir = "/geth/chaindata"
+		require.NoError(t, cfg.Check())
+		require.True(t, cfg.FetchingEnabled())
+	})
+	t.Run("RequireL1WhenL2ChainDataSet", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.L2ChainDataDir = "/geth/chaindata"
+		require.ErrorIs(t, cfg.Check(), ErrL1AndL2Inconsistent)
+	})
+	t.Run("NotBothL2URLAndChainData", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.L1URL = "https://example.com:1234"
+		cfg.L2URL = "https://example.com:4678"
+		cfg.L2ChainDataDir = "/geth/chaindata"
+		require.ErrorIs(t, cfg.Check(), ErrL2URLAndChainData)
+	})
 }
 
 func TestFetchingEnabled(t *testing.T) {
@@ -154,6 +176,16 @@ func TestRequireDataDirInNonFetchingMode(t *testing.T) {
 	require.ErrorIs(t, err, ErrDataDirRequired)
 }
 
+func TestDataMaxSizeRequiresFetching(t *testing.T) {
+	cfg := validConfig()
+	cfg.DataMaxSize = 1024
+	require.ErrorIs(t, cfg.Check(), ErrMaxSizeRequiresFetching)
+
+	cfg.L1URL = "https://example.com:1234"
+	cfg.L2URL = "https://example.com:1234"
+	require.NoError(t, cfg.Check())
+}
+
 func TestRejectExecAndServerMode(t *testing.T) {
 	cfg := validConfig()
 	cfg.ServerMode = true
@@ -162,6 +194,38 @@ func TestRejectExecAndServerMode(t *testing.T) {
 	require.ErrorIs(t, err, ErrNoExecInServerMode)
 }
 
+func TestRejectReportAndServerMode(t *testing.T) {
+	cfg := validConfig()
+	cfg.ServerMode = true
+	cfg.OutputReport = "report.json"
+	err := cfg.Check()
+	require.ErrorIs(t, err, ErrNoReportInServerMode)
+}
+
+func TestRejectResultCacheAndServerMode(t *testing.T) {
+	cfg := validConfig()
+	cfg.ServerMode = true
+	cfg.ResultCacheDir = "results"
+	err := cfg.Check()
+	require.ErrorIs(t, err, ErrNoCacheInServerMode)
+}
+
+func TestRejectCheckpointAndServerMode(t *testing.T) {
+	cfg := validConfig()
+	cfg.ServerMode = true
+	cfg.CheckpointFile = "checkpoint.json"
+	err := cfg.Check()
+	require.ErrorIs(t, err, ErrNoCheckpointInServerMode)
+}
+
+func TestProgressInterval(t *testing.T) {
+	cfg := validConfig()
+	cfg.ProgressInterval = 0
+	require.NoError(t, cfg.Check(), "progress reports can be disabled")
+	cfg.ProgressInterval = -time.Second
+	require.ErrorIs(t, cfg.Check(), ErrInvalidProgressInterval)
+}
+
 func TestIsCustomChainConfig(t *testing.T) {
 	t.Run("nonCustom", func(t *testing.T) {
 		cfg := validConfig()
@@ -191,8 +255,114 @@ func TestDBFormat(t *testing.T) {
 	}
 }
 
+func TestRemoteKVBucketRequired(t *testing.T) {
+	cfg := validConfig()
+	cfg.RemoteKVEndpoint = "storage.googleapis.com"
+	require.ErrorIs(t, cfg.Check(), ErrMissingRemoteBucket)
+
+	cfg.RemoteKVBucket = "preimages"
+	require.NoError(t, cfg.Check())
+}
+
+func TestGRPCAuthRequiresTLS(t *testing.T) {
+	cfg := validConfig()
+	cfg.ServerMode = true
+	cfg.GRPCAddr = "127.0.0.1:9000"
+	cfg.GRPCAuthToken = "secret"
+	require.ErrorIs(t, cfg.Check(), ErrGRPCAuthWithoutTLS)
+	cfg.GRPCTLS = optls.CLIConfig{TLSCaCert: "ca.crt", TLSCert: "tls.crt", TLSKey: "tls.key"}
+	require.NoError(t, cfg.Check())
+	cfg.GRPCTLS.TLSKey = ""
+	require.ErrorContains(t, cfg.Check(), "invalid grpc tls config")
+}
+
+func TestGRPCRequiresServerMode(t *testing.T) {
+	cfg := validConfig()
+	cfg.GRPCAddr = "127.0.0.1:9000"
+	require.ErrorIs(t, cfg.Check(), ErrGRPCWithoutServer)
+
+	cfg.ServerMode = true
+	require.NoError(t, cfg.Check())
+}
+
+func TestPrefetchMustNotBeNegative(t *testing.T) {
+	cfg := validConfig()
+	cfg.PrefetchWorkers = -1
+	require.ErrorIs(t, cfg.Check(), ErrInvalidPrefetch)
+
+	cfg = validConfig()
+	cfg.PrefetchL2Concurrency = -1
+	require.ErrorIs(t, cfg.Check(), ErrInvalidPrefetch)
+}
+
 func validConfig() *Config {
 	cfg := NewConfig(validRollupConfig, validL2Genesis, validL1Head, validL2Head, validL2OutputRoot, validL2Claim, validL2ClaimBlockNum)
 	cfg.DataDir = "/tmp/configTest"
 	return cfg
 }
+
+func TestInteropConfig(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		require.NoError(t, validInteropConfig().Check())
+	})
+
+	t.Run("AgreedPrestateMustMatchOutputRoot", func(t *testing.T) {
+		cfg := validInteropConfig()
+		cfg.L2OutputRoot = validL2OutputRoot
+		require.ErrorIs(t, cfg.Check(), ErrInvalidAgreedPrestate)
+	})
+
+	t.Run("ChainsRequired", func(t *testing.T) {
+		cfg := validInteropConfig()
+		cfg.InteropChains = nil
+		require.ErrorIs(t, cfg.Check(), ErrMissingInteropChains)
+	})
+
+	t.Run("ChainConfigRequired", func(t *testing.T) {
+		cfg := validInteropConfig()
+		cfg.InteropChains[0].L2ChainConfig = nil
+		require.ErrorIs(t, cfg.Check(), ErrMissingL2Genesis)
+	})
+
+	t.Run("ClaimTimestampRequired", func(t *testing.T) {
+		cfg := validInteropConfig()
+		cfg.L2ClaimTimestamp = 0
+		require.ErrorIs(t, cfg.Check(), ErrInvalidClaimTimestamp)
+	})
+
+	t.Run("L2HeadNotRequired", func(t *testing.T) {
+		cfg := validInteropConfig()
+		cfg.L2Head = common.Hash{}
+		require.NoError(t, cfg.Check())
+	})
+
+	t.Run("L2ChainDataNotSupported", func(t *testing.T) {
+		cfg := validInteropConfig()
+		cfg.L2ChainDataDir = "/geth/chaindata"
+		require.ErrorIs(t, cfg.Check(), ErrChainDataWithInterop)
+	})
+
+	t.Run("CheckpointNotSupported", func(t *testing.T) {
+		cfg := validInteropConfig()
+		cfg.CheckpointFile = "checkpoint.json"
+		require.ErrorIs(t, cfg.Check(), ErrCheckpointWithInterop)
+	})
+
+	t.Run("FetchingEnabled", func(t *testing.T) {
+		cfg := validInteropConfig()
+		cfg.L1URL = "https://example.com:1234"
+		require.ErrorIs(t, cfg.Check(), ErrL1AndL2Inconsistent)
+		cfg.InteropChains[0].L2URL = "https://example.com:5678"
+		require.NoError(t, cfg.Check())
+		require.True(t, cfg.FetchingEnabled())
+	})
+}
+
+func validInteropConfig() *Config {
+	cfg := validConfig()
+	cfg.AgreedPrestate = []byte{1, 2, 3}
+	cfg.L2OutputRoot = crypto.Keccak256Hash(cfg.AgreedPrestate)
+	cfg.L2ClaimTimestamp = 7000
+	cfg.InteropChains = []InteropChain{{Rollup: validRollupConfig, L2ChainConfig: validL2Genesis}}
+	return cfg
+}
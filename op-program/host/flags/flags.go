@@ -3,6 +3,7 @@ package flags
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-program/host/types"
 	"github.com/urfave/cli/v2"
@@ -11,11 +12,16 @@ import (
 	service "github.com/ethereum-optimism/optimism/op-service"
 	openum "github.com/ethereum-optimism/optimism/op-service/enum"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
 )
 
 const EnvVarPrefix = "OP_PROGRAM"
 
+// GRPCAuthTokenEnvVar is the env var the gRPC auth token is read from, if it isn't read from a file.
+// The token is not accepted as flag, so it doesn't show up in the process list.
+var GRPCAuthTokenEnvVar = EnvVarPrefix + "_GRPC_AUTH_TOKEN"
+
 func prefixEnvVars(name string) []string {
 	return service.PrefixEnvVar(EnvVarPrefix, name)
 }
@@ -38,15 +44,55 @@ var (
 	}
 	DataFormat = &cli.StringFlag{
 		Name:    "data.format",
-		Usage:   fmt.Sprintf("Format to use for preimage data storage. Available formats: %s", openum.EnumString(types.SupportedDataFormats)),
+		Usage:   fmt.Sprintf("Format to use for preimage data storage. Available formats: %s. Pre-images stored in file format are migrated automatically when using pebble.", openum.EnumString(types.SupportedDataFormats)),
 		EnvVars: prefixEnvVars("DATA_FORMAT"),
 		Value:   string(types.DataFormatFile),
 	}
+	DataMaxSize = &cli.Uint64Flag{
+		Name:    "data.max-size",
+		Usage:   "Maximum total size, in MiB, of the stored pre-images. The least recently used pre-images are evicted when exceeded, and fetched again when required. Requires fetching to be enabled. Unlimited if 0.",
+		EnvVars: prefixEnvVars("DATA_MAX_SIZE"),
+	}
+	RemoteKVEndpoint = &cli.StringFlag{
+		Name:    "remotekv.endpoint",
+		Usage:   "Endpoint of an S3 compatible object store used to share pre-images between instances (e.g. storage.googleapis.com for GCS). Only keccak256 and sha256 pre-images are shared, and they are verified before use. Pre-images are still cached locally.",
+		EnvVars: prefixEnvVars("REMOTEKV_ENDPOINT"),
+	}
+	RemoteKVBucket = &cli.StringFlag{
+		Name:    "remotekv.bucket",
+		Usage:   "Bucket of the remote pre-image store",
+		EnvVars: prefixEnvVars("REMOTEKV_BUCKET"),
+	}
+	RemoteKVPrefix = &cli.StringFlag{
+		Name:    "remotekv.prefix",
+		Usage:   "Prefix of the pre-image object names in the remote pre-image store bucket",
+		EnvVars: prefixEnvVars("REMOTEKV_PREFIX"),
+	}
+	RemoteKVAccessKeyID = &cli.StringFlag{
+		Name:    "remotekv.access-key-id",
+		Usage:   "Access key ID of the remote pre-image store",
+		EnvVars: prefixEnvVars("REMOTEKV_ACCESS_KEY_ID"),
+	}
+	RemoteKVAccessKeySecret = &cli.StringFlag{
+		Name:    "remotekv.access-key-secret",
+		Usage:   "Access key secret of the remote pre-image store",
+		EnvVars: prefixEnvVars("REMOTEKV_ACCESS_KEY_SECRET"),
+	}
+	RemoteKVInsecure = &cli.BoolFlag{
+		Name:    "remotekv.insecure",
+		Usage:   "Connect to the remote pre-image store without TLS",
+		EnvVars: prefixEnvVars("REMOTEKV_INSECURE"),
+	}
 	L2NodeAddr = &cli.StringFlag{
 		Name:    "l2",
 		Usage:   "Address of L2 JSON-RPC endpoint to use (eth and debug namespace required)",
 		EnvVars: prefixEnvVars("L2_RPC"),
 	}
+	L2ChainData = &cli.StringFlag{
+		Name:    "l2.chaindata",
+		Usage:   "Chaindata directory of a stopped op-geth node to read L2 data from, instead of the L2 JSON-RPC endpoint. Requires the hash state scheme.",
+		EnvVars: prefixEnvVars("L2_CHAINDATA"),
+	}
 	L1Head = &cli.StringFlag{
 		Name:    "l1.head",
 		Usage:   "Hash of the L1 head block. Derivation stops after this block is processed.",
@@ -77,6 +123,32 @@ var (
 		Usage:   "Path to the op-geth genesis file",
 		EnvVars: prefixEnvVars("L2_GENESIS"),
 	}
+	AgreedPrestate = &cli.StringFlag{
+		Name: "l2.agreed-prestate",
+		Usage: "Preimage of the agreed super root, hex encoded. Enables interop mode, where l2.outputroot is the agreed super root " +
+			"and l2.claim is the claimed super root, covering the chains configured with the interop flags.",
+		EnvVars: prefixEnvVars("L2_AGREED_PRESTATE"),
+	}
+	L2ClaimTimestamp = &cli.Uint64Flag{
+		Name:    "l2.claim-timestamp",
+		Usage:   "Timestamp of the claimed super root. Only used in interop mode.",
+		EnvVars: prefixEnvVars("L2_CLAIM_TIMESTAMP"),
+	}
+	InteropRollupConfigs = &cli.StringSliceFlag{
+		Name:    "interop.rollup-config",
+		Usage:   "Paths to the rollup chain parameters of each chain covered by the super root. Only used in interop mode.",
+		EnvVars: prefixEnvVars("INTEROP_ROLLUP_CONFIG"),
+	}
+	InteropL2GenesisPaths = &cli.StringSliceFlag{
+		Name:    "interop.l2-genesis",
+		Usage:   "Paths to the op-geth genesis files of each chain, in the same order as interop.rollup-config. Only used in interop mode.",
+		EnvVars: prefixEnvVars("INTEROP_L2_GENESIS"),
+	}
+	InteropL2NodeAddrs = &cli.StringSliceFlag{
+		Name:    "interop.l2",
+		Usage:   "Addresses of the L2 JSON-RPC endpoints of each chain, in the same order as interop.rollup-config. Only used in interop mode.",
+		EnvVars: prefixEnvVars("INTEROP_L2_RPC"),
+	}
 	L1NodeAddr = &cli.StringFlag{
 		Name:    "l1",
 		Usage:   "Address of L1 JSON-RPC endpoint to use (eth namespace required)",
@@ -107,11 +179,106 @@ var (
 		Usage:   "Run the specified client program as a separate process detached from the host. Default is to run the client program in the host process.",
 		EnvVars: prefixEnvVars("EXEC"),
 	}
+	OutputReport = &cli.PathFlag{
+		Name:      "output-report",
+		Usage:     "Path to write a JSON report of the verification of the claim to, including the result and the number of pre-images used by type.",
+		EnvVars:   prefixEnvVars("OUTPUT_REPORT"),
+		TakesFile: true,
+	}
+	ResultCache = &cli.PathFlag{
+		Name: "result-cache",
+		Usage: "Directory to cache the results of verifications in. Claims found valid or invalid with the same inputs before " +
+			"are not verified again, and the cached result is returned instead. Failures to verify a claim are not cached.",
+		EnvVars:   prefixEnvVars("RESULT_CACHE"),
+		TakesFile: true,
+	}
+	ForceRerun = &cli.BoolFlag{
+		Name:    "force-rerun",
+		Usage:   "Verify the claim even if its result is cached, and replace the cached result.",
+		EnvVars: prefixEnvVars("FORCE_RERUN"),
+	}
+	ProgressOutput = &cli.PathFlag{
+		Name: "progress-output",
+		Usage: "Path to append JSON progress records of the client program to, including the blocks derived, pre-images fetched " +
+			"and current L1 origin. Use - to write to stdout. No progress records are written if unset, and the client program " +
+			"then only reports its progress every 1000 blocks.",
+		EnvVars:   prefixEnvVars("PROGRESS_OUTPUT"),
+		TakesFile: true,
+	}
+	ProgressInterval = &cli.DurationFlag{
+		Name:    "progress-interval",
+		Usage:   "Interval at which the progress of the client program is logged, and written to the progress output.",
+		EnvVars: prefixEnvVars("PROGRESS_INTERVAL"),
+		Value:   30 * time.Second,
+	}
+	CheckpointFile = &cli.PathFlag{
+		Name: "checkpoint-file",
+		Usage: "Path to periodically write the derivation checkpoint of the client program to. If the file holds a checkpoint " +
+			"for the same claim, the program resumes from it rather than from the agreed output root. " +
+			"The checkpoint is removed once the claim is found valid or invalid. The client program computes the output root of " +
+			"a checkpoint every 100 L2 blocks, which is only enabled by this flag. Not supported in interop mode.",
+		EnvVars:   prefixEnvVars("CHECKPOINT_FILE"),
+		TakesFile: true,
+	}
 	Server = &cli.BoolFlag{
 		Name:    "server",
 		Usage:   "Run in pre-image server mode without executing any client program.",
 		EnvVars: prefixEnvVars("SERVER"),
 	}
+	GRPCAddr = &cli.StringFlag{
+		Name: "grpc.addr",
+		Usage: "Serve pre-images over gRPC on the given address, e.g. 127.0.0.1:9000 or unix:///tmp/preimage.sock, " +
+			"instead of the file descriptor based pre-image oracle. Requires server mode.",
+		EnvVars: prefixEnvVars("GRPC_ADDR"),
+	}
+	GRPCAuthTokenFile = &cli.PathFlag{
+		Name: "grpc.auth-token-file",
+		Usage: "Path to a file containing the bearer token that gRPC clients must authenticate with. " +
+			"The token may instead be set with the " + GRPCAuthTokenEnvVar + " env var. " +
+			"Authentication is disabled if neither is set. Requires TLS.",
+		EnvVars:   prefixEnvVars("GRPC_AUTH_TOKEN_FILE"),
+		TakesFile: true,
+	}
+	GRPCTLSCaCert = &cli.PathFlag{
+		Name:      "grpc.tls.ca",
+		Usage:     "Path to the CA certificate that gRPC client certificates must be signed by. TLS is disabled if no TLS files are set.",
+		EnvVars:   prefixEnvVars("GRPC_TLS_CA"),
+		TakesFile: true,
+	}
+	GRPCTLSCert = &cli.PathFlag{
+		Name:      "grpc.tls.cert",
+		Usage:     "Path to the TLS certificate of the gRPC server",
+		EnvVars:   prefixEnvVars("GRPC_TLS_CERT"),
+		TakesFile: true,
+	}
+	GRPCTLSKey = &cli.PathFlag{
+		Name:      "grpc.tls.key",
+		Usage:     "Path to the TLS key of the gRPC server",
+		EnvVars:   prefixEnvVars("GRPC_TLS_KEY"),
+		TakesFile: true,
+	}
+	PrefetchWorkers = &cli.IntFlag{
+		Name:    "prefetch.workers",
+		Usage:   "Number of hints to prefetch concurrently in the background, ahead of the pre-image requests of the client. Background prefetching is disabled if 0.",
+		EnvVars: prefixEnvVars("PREFETCH_WORKERS"),
+	}
+	PrefetchL1Concurrency = &cli.IntFlag{
+		Name:    "prefetch.l1-concurrency",
+		Usage:   "Maximum number of concurrent background prefetches per L1 hint type. Unlimited if 0.",
+		EnvVars: prefixEnvVars("PREFETCH_L1_CONCURRENCY"),
+		Value:   4,
+	}
+	PrefetchL2Concurrency = &cli.IntFlag{
+		Name:    "prefetch.l2-concurrency",
+		Usage:   "Maximum number of concurrent background prefetches per L2 hint type. Unlimited if 0.",
+		EnvVars: prefixEnvVars("PREFETCH_L2_CONCURRENCY"),
+		Value:   8,
+	}
+	PrefetchLookahead = &cli.BoolFlag{
+		Name:    "prefetch.lookahead",
+		Usage:   "Prefetch the transactions and receipts of hinted L1 blocks in the background, before they are hinted. Requires background prefetching.",
+		EnvVars: prefixEnvVars("PREFETCH_LOOKAHEAD"),
+	}
 )
 
 // Flags contains the list of configuration options available to the binary.
@@ -130,23 +297,65 @@ var programFlags = []cli.Flag{
 	Network,
 	DataDir,
 	DataFormat,
+	DataMaxSize,
+	RemoteKVEndpoint,
+	RemoteKVBucket,
+	RemoteKVPrefix,
+	RemoteKVAccessKeyID,
+	RemoteKVAccessKeySecret,
+	RemoteKVInsecure,
 	L2NodeAddr,
+	L2ChainData,
 	L2GenesisPath,
+	AgreedPrestate,
+	L2ClaimTimestamp,
+	InteropRollupConfigs,
+	InteropL2GenesisPaths,
+	InteropL2NodeAddrs,
 	L1NodeAddr,
 	L1BeaconAddr,
 	L1TrustRPC,
 	L1RPCProviderKind,
 	Exec,
+	OutputReport,
+	ResultCache,
+	ForceRerun,
+	ProgressOutput,
+	ProgressInterval,
+	CheckpointFile,
 	Server,
+	GRPCAddr,
+	GRPCAuthTokenFile,
+	GRPCTLSCaCert,
+	GRPCTLSCert,
+	GRPCTLSKey,
+	PrefetchWorkers,
+	PrefetchL1Concurrency,
+	PrefetchL2Concurrency,
+	PrefetchLookahead,
 }
 
 func init() {
 	Flags = append(Flags, oplog.CLIFlags(EnvVarPrefix)...)
+	Flags = append(Flags, opmetrics.CLIFlags(EnvVarPrefix)...)
 	Flags = append(Flags, requiredFlags...)
 	Flags = append(Flags, programFlags...)
 }
 
+// interopRequiredFlags replace the required flags in interop mode.
+var interopRequiredFlags = []cli.Flag{
+	L1Head,
+	L2OutputRoot,
+	L2Claim,
+	L2ClaimTimestamp,
+	InteropRollupConfigs,
+	InteropL2GenesisPaths,
+}
+
 func CheckRequired(ctx *cli.Context) error {
+	if ctx.IsSet(AgreedPrestate.Name) {
+		return checkRequiredInterop(ctx)
+	}
 	rollupConfig := ctx.String(RollupConfig.Name)
 	network := ctx.String(Network.Name)
 	if rollupConfig == "" && network == "" {
@@ -165,3 +374,19 @@ func CheckRequired(ctx *cli.Context) error {
 	}
 	return nil
 }
+
+func checkRequiredInterop(ctx *cli.Context) error {
+	for _, flag := range interopRequiredFlags {
+		if !ctx.IsSet(flag.Names()[0]) {
+			return fmt.Errorf("flag %s is required in interop mode", flag.Names()[0])
+		}
+	}
+	chains := len(ctx.StringSlice(InteropRollupConfigs.Name))
+	if len(ctx.StringSlice(InteropL2GenesisPaths.Name)) != chains {
+		return fmt.Errorf("flag %s must be set for each chain", InteropL2GenesisPaths.Name)
+	}
+	if addrs := ctx.StringSlice(InteropL2NodeAddrs.Name); len(addrs) != 0 && len(addrs) != chains {
+		return fmt.Errorf("flag %s must be set for each chain", InteropL2NodeAddrs.Name)
+	}
+	return nil
+}
@@ -2,29 +2,42 @@ package host
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"io"
 	"io/fs"
 	"os"
 	"os/exec"
+	"slices"
 
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-preimage/grpcoracle"
 	cl "github.com/ethereum-optimism/optimism/op-program/client"
+	"github.com/ethereum-optimism/optimism/op-program/client/claim"
 	"github.com/ethereum-optimism/optimism/op-program/host/config"
 	"github.com/ethereum-optimism/optimism/op-program/host/flags"
 	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
+	"github.com/ethereum-optimism/optimism/op-program/host/metrics"
 	"github.com/ethereum-optimism/optimism/op-program/host/prefetcher"
 	"github.com/ethereum-optimism/optimism/op-program/host/types"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
 	"github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/ctxinterrupt"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/objectstore"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 )
 
+// prefetchQueueSize is the number of hints that can be queued for background prefetching.
+const prefetchQueueSize = 1000
+
 type L2Source struct {
 	*L2Client
 	*sources.DebugClient
@@ -35,26 +48,136 @@ func Main(logger log.Logger, cfg *config.Config) error {
 		return fmt.Errorf("invalid config: %w", err)
 	}
 	opservice.ValidateEnvVars(flags.EnvVarPrefix, flags.Flags, logger)
-	cfg.Rollup.LogDescription(logger, chaincfg.L2ChainIDToNetworkDisplayName)
+	if cfg.InteropEnabled() {
+		for _, chain := range cfg.InteropChains {
+			chain.Rollup.LogDescription(logger, chaincfg.L2ChainIDToNetworkDisplayName)
+		}
+	} else {
+		cfg.Rollup.LogDescription(logger, chaincfg.L2ChainIDToNetworkDisplayName)
+	}
 
 	hostCtx, stop := ctxinterrupt.WithSignalWaiter(context.Background())
 	defer stop()
 	ctx := ctxinterrupt.WithCancelOnInterrupt(hostCtx)
+
+	m := metrics.NoopMetrics
+	if cfg.MetricsConfig.Enabled {
+		promMetrics := metrics.NewMetrics()
+		logger.Info("Starting metrics server", "addr", cfg.MetricsConfig.ListenAddr, "port", cfg.MetricsConfig.ListenPort)
+		metricsSrv, err := opmetrics.StartServer(promMetrics.Registry(), cfg.MetricsConfig.ListenAddr, cfg.MetricsConfig.ListenPort)
+		if err != nil {
+			return fmt.Errorf("failed to start metrics server: %w", err)
+		}
+		defer func() {
+			if err := metricsSrv.Stop(context.Background()); err != nil {
+				logger.Error("Failed to stop metrics server", "err", err)
+			}
+		}()
+		m = promMetrics
+	}
+
 	if cfg.ServerMode {
+		if cfg.GRPCAddr != "" {
+			return preimageGRPCServer(ctx, logger, cfg, m)
+		}
 		preimageChan := preimage.ClientPreimageChannel()
 		hinterChan := preimage.ClientHinterChannel()
-		return PreimageServer(ctx, logger, cfg, preimageChan, hinterChan)
+		return preimageServer(ctx, logger, cfg, m, preimageChan, hinterChan, nil, nil)
 	}
 
-	if err := FaultProofProgram(ctx, logger, cfg); err != nil {
+	var cache *resultCache
+	var cacheKey common.Hash
+	if cfg.ResultCacheDir != "" {
+		key, err := resultCacheKey(cfg)
+		if err != nil {
+			return err
+		}
+		cache, cacheKey = newResultCache(cfg.ResultCacheDir), key
+		if cfg.ForceRerun {
+			logger.Info("Ignoring cached result", "key", cacheKey)
+		} else if report, err := cache.get(cacheKey); err != nil {
+			logger.Warn("Failed to read cached result, verifying claim", "key", cacheKey, "err", err)
+		} else if report != nil {
+			return cachedResult(logger, cfg, cacheKey, report)
+		}
+	}
+
+	runCfg := cfg
+	var checkpoints *checkpointer
+	var resumedFrom *uint64
+	if cfg.CheckpointFile != "" {
+		var err error
+		checkpoints, err = newCheckpointer(cfg)
+		if err != nil {
+			return err
+		}
+		if checkpoint, err := checkpoints.load(); err != nil {
+			logger.Warn("Failed to read checkpoint, starting from agreed output root", "path", cfg.CheckpointFile, "err", err)
+		} else if checkpoint != nil {
+			logger.Info("Resuming from checkpoint", "path", cfg.CheckpointFile, "l2Head", checkpoint.L2Head, "number", checkpoint.L2HeadNumber)
+			runCfg = checkpoint.resumeConfig(cfg)
+			resumedFrom = &checkpoint.L2HeadNumber
+		}
+	}
+
+	var recorder *reportRecorder
+	var wrap getterWrapper
+	if cfg.OutputReport != "" || cache != nil {
+		recorder = newReportRecorder(cfg)
+		wrap = recorder.wrap
+	}
+	err := faultProofProgram(ctx, logger, runCfg, m, wrap, newProgressTracker(logger, cfg, checkpoints))
+	if checkpoints != nil {
+		if result, _ := programResult(err); result != ResultError {
+			if removeErr := checkpoints.remove(); removeErr != nil {
+				logger.Warn("Failed to remove checkpoint", "path", cfg.CheckpointFile, "err", removeErr)
+			}
+		}
+	}
+	if recorder != nil {
+		report := recorder.report(cfg, err)
+		report.ResumedFrom = resumedFrom
+		if cache != nil {
+			if cacheErr := cache.put(cacheKey, report); cacheErr != nil {
+				logger.Warn("Failed to cache result", "key", cacheKey, "err", cacheErr)
+			}
+		}
+		if writeErr := jsonutil.WriteJSON(cfg.OutputReport, report, 0o644); writeErr != nil {
+			return errors.Join(err, fmt.Errorf("failed to write report: %w", writeErr))
+		}
+		if cfg.OutputReport != "" {
+			logger.Info("Wrote verification report", "path", cfg.OutputReport, "result", report.Result)
+		}
+	}
+	if err != nil {
 		return err
 	}
 	log.Info("Claim successfully verified")
 	return nil
 }
 
+// cachedResult returns the result of a previous verification of the claim, instead of running the program again.
+func cachedResult(logger log.Logger, cfg *config.Config, key common.Hash, report *Report) error {
+	logger.Info("Using cached result", "key", key, "result", report.Result)
+	report.Cached = true
+	if err := jsonutil.WriteJSON(cfg.OutputReport, report, 0o644); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	if report.Result == ResultInvalid {
+		return fmt.Errorf("%w: cached result: %s", claim.ErrClaimNotValid, report.Reason)
+	}
+	log.Info("Claim successfully verified")
+	return nil
+}
+
 // FaultProofProgram is the programmatic entry-point for the fault proof program
 func FaultProofProgram(ctx context.Context, logger log.Logger, cfg *config.Config) error {
+	return faultProofProgram(ctx, logger, cfg, metrics.NoopMetrics, nil, nil)
+}
+
+// faultProofProgram runs the fault proof program. If wrap is not nil, the pre-image getter is wrapped with it.
+// If progress is nil, progress is tracked as configured by cfg, without checkpoints.
+func faultProofProgram(ctx context.Context, logger log.Logger, cfg *config.Config, m metrics.Metricer, wrap getterWrapper, progress *progressTracker) error {
 	var (
 		serverErr chan error
 		pClientRW preimage.FileChannel
@@ -91,7 +214,7 @@ func FaultProofProgram(ctx context.Context, logger log.Logger, cfg *config.Confi
 	serverErr = make(chan error)
 	go func() {
 		defer close(serverErr)
-		serverErr <- PreimageServer(ctx, logger, cfg, pHostRW, hHostRW)
+		serverErr <- preimageServer(ctx, logger, cfg, m, pHostRW, hHostRW, wrap, progress)
 	}()
 
 	var cmd *exec.Cmd
@@ -124,6 +247,15 @@ func FaultProofProgram(ctx context.Context, logger log.Logger, cfg *config.Confi
 // If either returns an error both handlers are stopped.
 // The supplied preimageChannel and hintChannel will be closed before this function returns.
 func PreimageServer(ctx context.Context, logger log.Logger, cfg *config.Config, preimageChannel preimage.FileChannel, hintChannel preimage.FileChannel) error {
+	return preimageServer(ctx, logger, cfg, metrics.NoopMetrics, preimageChannel, hintChannel, nil, nil)
+}
+
+// getterWrapper wraps the pre-image getter used to serve pre-images to the client program.
+type getterWrapper func(getter preimage.PreimageGetter) preimage.PreimageGetter
+
+// preimageServer serves the pre-images and hints of the client program. If progress is nil, a tracker without
+// checkpoints is used.
+func preimageServer(ctx context.Context, logger log.Logger, cfg *config.Config, m metrics.Metricer, preimageChannel preimage.FileChannel, hintChannel preimage.FileChannel, wrap getterWrapper, progress *progressTracker) error {
 	var serverDone chan error
 	var hinterDone chan error
 	logger.Info("Starting preimage server")
@@ -147,22 +279,103 @@ func PreimageServer(ctx context.Context, logger log.Logger, cfg *config.Config,
 		}
 	}()
 
-	if cfg.DataDir == "" {
-		logger.Info("Using in-memory storage")
-		kv = kvstore.NewMemKV()
-	} else {
-		logger.Info("Creating disk storage", "datadir", cfg.DataDir, "format", cfg.DataFormat)
-		if err := os.MkdirAll(cfg.DataDir, 0755); err != nil {
-			return fmt.Errorf("creating datadir: %w", err)
+	kv, preimageGetter, hinter, err := preparePreimageSource(ctx, logger, cfg, m)
+	if err != nil {
+		return err
+	}
+	if wrap != nil {
+		preimageGetter = wrap(preimageGetter)
+	}
+	if progress == nil {
+		progress = newProgressTracker(logger, cfg, nil)
+	}
+	progress.start()
+	defer progress.stop()
+	preimageGetter = progress.wrap(preimageGetter)
+	hinter = progress.handleHints(hinter)
+
+	serverDone = launchOracleServer(logger, preimageChannel, preimageGetter)
+	hinterDone = routeHints(logger, hintChannel, hinter)
+	select {
+	case err := <-serverDone:
+		return err
+	case err := <-hinterDone:
+		return err
+	case <-ctx.Done():
+		logger.Info("Shutting down")
+		return ctx.Err()
+	}
+}
+
+// PreimageGRPCServer serves hints and preimage requests over gRPC on the configured address.
+// This method blocks until the context is done or the gRPC server fails.
+func PreimageGRPCServer(ctx context.Context, logger log.Logger, cfg *config.Config) error {
+	return preimageGRPCServer(ctx, logger, cfg, metrics.NoopMetrics)
+}
+
+func preimageGRPCServer(ctx context.Context, logger log.Logger, cfg *config.Config, m metrics.Metricer) error {
+	logger.Info("Starting gRPC preimage server", "addr", cfg.GRPCAddr)
+	kv, preimageGetter, hinter, err := preparePreimageSource(ctx, logger, cfg, m)
+	if kv != nil {
+		defer kv.Close()
+	}
+	if err != nil {
+		return err
+	}
+	progress := newProgressTracker(logger, cfg, nil)
+	progress.start()
+	defer progress.stop()
+	preimageGetter = progress.wrap(preimageGetter)
+	hinter = progress.handleHints(hinter)
+	var tlsConfig *tls.Config
+	if cfg.GRPCTLS.TLSEnabled() {
+		tlsConfig, err = grpcoracle.ServerTLSConfig(logger, cfg.GRPCTLS)
+		if err != nil {
+			return fmt.Errorf("failed to load grpc tls config: %w", err)
 		}
-		switch cfg.DataFormat {
-		case types.DataFormatFile:
-			kv = kvstore.NewFileKV(cfg.DataDir)
-		case types.DataFormatPebble:
-			kv = kvstore.NewPebbleKV(cfg.DataDir)
-		default:
-			return fmt.Errorf("invalid data format: %s", cfg.DataFormat)
+	}
+	server, err := grpcoracle.NewGRPCServer(logger, preimageGetter, hinter, cfg.GRPCAuthToken, tlsConfig)
+	if err != nil {
+		return err
+	}
+	lis, err := grpcoracle.Listen(cfg.GRPCAddr)
+	if err != nil {
+		return err
+	}
+	serverDone := make(chan error, 1)
+	go func() {
+		serverDone <- server.Serve(lis)
+	}()
+	select {
+	case err := <-serverDone:
+		return err
+	case <-ctx.Done():
+		logger.Info("Shutting down")
+		server.Stop()
+		return ctx.Err()
+	}
+}
+
+// preparePreimageSource opens the configured kv store, and creates the preimage getter and hint handler using it.
+// The returned kv store must be closed by the caller, also if an error is returned.
+func preparePreimageSource(ctx context.Context, logger log.Logger, cfg *config.Config, m metrics.Metricer) (kvstore.KV, preimage.PreimageGetter, preimage.HintHandler, error) {
+	kv, err := openKV(logger, cfg, m)
+	if err != nil {
+		return kv, nil, nil, err
+	}
+	if cfg.RemoteKVEndpoint != "" {
+		logger.Info("Using remote pre-image store", "endpoint", cfg.RemoteKVEndpoint, "bucket", cfg.RemoteKVBucket, "prefix", cfg.RemoteKVPrefix)
+		remote, err := objectstore.NewS3Store(objectstore.S3Config{
+			Endpoint:        cfg.RemoteKVEndpoint,
+			Bucket:          cfg.RemoteKVBucket,
+			AccessKeyID:     cfg.RemoteKVAccessKeyID,
+			AccessKeySecret: cfg.RemoteKVAccessKeySecret,
+			Insecure:        cfg.RemoteKVInsecure,
+		})
+		if err != nil {
+			return kv, nil, nil, fmt.Errorf("failed to create remote pre-image store: %w", err)
 		}
+		kv = kvstore.NewRemoteKV(logger, kv, remote, cfg.RemoteKVPrefix)
 	}
 
 	var (
@@ -172,7 +385,7 @@ func PreimageServer(ctx context.Context, logger log.Logger, cfg *config.Config,
 	if cfg.FetchingEnabled() {
 		prefetch, err := makePrefetcher(ctx, logger, kv, cfg)
 		if err != nil {
-			return fmt.Errorf("failed to create prefetcher: %w", err)
+			return kv, nil, nil, fmt.Errorf("failed to create prefetcher: %w", err)
 		}
 		getPreimage = func(key common.Hash) ([]byte, error) { return prefetch.GetPreimage(ctx, key) }
 		hinter = prefetch.Hint
@@ -187,18 +400,48 @@ func PreimageServer(ctx context.Context, logger log.Logger, cfg *config.Config,
 
 	localPreimageSource := kvstore.NewLocalPreimageSource(cfg)
 	splitter := kvstore.NewPreimageSourceSplitter(localPreimageSource.Get, getPreimage)
-	preimageGetter := preimage.WithVerification(splitter.Get)
+	return kv, preimage.WithVerification(splitter.Get), hinter, nil
+}
 
-	serverDone = launchOracleServer(logger, preimageChannel, preimageGetter)
-	hinterDone = routeHints(logger, hintChannel, hinter)
-	select {
-	case err := <-serverDone:
-		return err
-	case err := <-hinterDone:
-		return err
-	case <-ctx.Done():
-		logger.Info("Shutting down")
-		return ctx.Err()
+// openKV opens the local kv store configured by the data dir and data format,
+// bounded to the configured max size if set.
+// The returned kv store must be closed by the caller, also if an error is returned.
+func openKV(logger log.Logger, cfg *config.Config, m metrics.Metricer) (kvstore.KV, error) {
+	kv, err := openLocalKV(logger, cfg)
+	if err != nil || cfg.DataMaxSize == 0 {
+		return kv, err
+	}
+	bounded, err := kvstore.NewBoundedKV(logger, m, kv, cfg.DataMaxSize)
+	if err != nil {
+		return kv, fmt.Errorf("failed to bound pre-image store size: %w", err)
+	}
+	return bounded, nil
+}
+
+func openLocalKV(logger log.Logger, cfg *config.Config) (kvstore.EvictableKV, error) {
+	if cfg.DataDir == "" {
+		logger.Info("Using in-memory storage")
+		return kvstore.NewMemKV(), nil
+	}
+	logger.Info("Creating disk storage", "datadir", cfg.DataDir, "format", cfg.DataFormat)
+	if err := os.MkdirAll(cfg.DataDir, 0755); err != nil {
+		return nil, fmt.Errorf("creating datadir: %w", err)
+	}
+	switch cfg.DataFormat {
+	case types.DataFormatFile:
+		return kvstore.NewFileKV(cfg.DataDir), nil
+	case types.DataFormatPebble:
+		pebbleKV := kvstore.NewPebbleKV(cfg.DataDir)
+		migrated, err := kvstore.MigrateFileKV(cfg.DataDir, pebbleKV)
+		if err != nil {
+			return pebbleKV, fmt.Errorf("failed to migrate file data to pebble: %w", err)
+		}
+		if migrated > 0 {
+			logger.Info("Migrated file pre-images to pebble", "count", migrated)
+		}
+		return pebbleKV, nil
+	default:
+		return nil, fmt.Errorf("invalid data format: %s", cfg.DataFormat)
 	}
 }
 
@@ -209,26 +452,88 @@ func makePrefetcher(ctx context.Context, logger log.Logger, kv kvstore.KV, cfg *
 		return nil, fmt.Errorf("failed to setup L1 RPC: %w", err)
 	}
 
-	logger.Info("Connecting to L2 node", "l2", cfg.L2URL)
-	l2RPC, err := client.NewRPC(ctx, logger, cfg.L2URL, client.WithDialBackoff(10))
-	if err != nil {
-		return nil, fmt.Errorf("failed to setup L2 RPC: %w", err)
+	l1RollupCfg := cfg.Rollup
+	if cfg.InteropEnabled() {
+		// All chains of the dependency set share the same L1 chain
+		l1RollupCfg = cfg.InteropChains[0].Rollup
 	}
-
-	l1ClCfg := sources.L1ClientDefaultConfig(cfg.Rollup, cfg.L1TrustRPC, cfg.L1RPCKind)
-	l2ClCfg := sources.L2ClientDefaultConfig(cfg.Rollup, true)
+	l1ClCfg := sources.L1ClientDefaultConfig(l1RollupCfg, cfg.L1TrustRPC, cfg.L1RPCKind)
 	l1Cl, err := sources.NewL1Client(l1RPC, logger, nil, l1ClCfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create L1 client: %w", err)
 	}
 	l1Beacon := sources.NewBeaconHTTPClient(client.NewBasicHTTPClient(cfg.L1BeaconURL, logger))
 	l1BlobFetcher := sources.NewL1BeaconClient(l1Beacon, sources.L1BeaconClientConfig{FetchAllSidecars: false})
-	l2Cl, err := NewL2Client(l2RPC, logger, nil, &L2ClientConfig{L2ClientConfig: l2ClCfg, L2Head: cfg.L2Head})
+
+	var l2Source prefetcher.L2Source
+	if cfg.InteropEnabled() {
+		l2Source, err = makeInteropL2Sources(ctx, logger, cfg)
+	} else if cfg.L2ChainDataDir != "" {
+		logger.Info("Opening L2 chaindata", "dir", cfg.L2ChainDataDir)
+		l2Source, err = NewChainDataL2Source(cfg.L2ChainDataDir, cfg.L2ChainConfig, cfg.L2Head)
+	} else {
+		l2Source, err = makeL2Source(ctx, logger, cfg.Rollup, cfg.L2URL, cfg.L2Head)
+	}
+	if err != nil {
+		return nil, err
+	}
+	prefetch := prefetcher.NewPrefetcher(logger, l1Cl, l1BlobFetcher, l2Source, kv)
+	prefetch.StartAsync(ctx, prefetcher.AsyncConfig{
+		Workers:         cfg.PrefetchWorkers,
+		HintConcurrency: prefetcher.HintConcurrency(cfg.PrefetchL1Concurrency, cfg.PrefetchL2Concurrency),
+		QueueSize:       prefetchQueueSize,
+		Lookahead:       cfg.PrefetchLookahead,
+	})
+	return prefetch, nil
+}
+
+func makeL2Source(ctx context.Context, logger log.Logger, rollupCfg *rollup.Config, l2URL string, l2Head common.Hash) (*L2Source, error) {
+	logger.Info("Connecting to L2 node", "l2", l2URL)
+	l2RPC, err := client.NewRPC(ctx, logger, l2URL, client.WithDialBackoff(10))
+	if err != nil {
+		return nil, fmt.Errorf("failed to setup L2 RPC: %w", err)
+	}
+	l2ClCfg := sources.L2ClientDefaultConfig(rollupCfg, true)
+	l2Cl, err := NewL2Client(l2RPC, logger, nil, &L2ClientConfig{L2ClientConfig: l2ClCfg, L2Head: l2Head})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create L2 client: %w", err)
 	}
-	l2DebugCl := &L2Source{L2Client: l2Cl, DebugClient: sources.NewDebugClient(l2RPC.CallContext)}
-	return prefetcher.NewPrefetcher(logger, l1Cl, l1BlobFetcher, l2DebugCl, kv), nil
+	return &L2Source{L2Client: l2Cl, DebugClient: sources.NewDebugClient(l2RPC.CallContext)}, nil
+}
+
+// makeInteropL2Sources creates the L2 sources of the chains covered by the agreed super root.
+// The L2 head of each chain is the block at the timestamp of the agreed super root.
+func makeInteropL2Sources(ctx context.Context, logger log.Logger, cfg *config.Config) (*L2Sources, error) {
+	agreed, err := eth.UnmarshalSuperRoot(cfg.AgreedPrestate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid agreed prestate: %w", err)
+	}
+	l2Sources := NewL2Sources()
+	for _, c := range agreed.Chains {
+		idx := slices.IndexFunc(cfg.InteropChains, func(chain config.InteropChain) bool {
+			return chain.Rollup.L2ChainID.Uint64() == c.ChainID
+		})
+		if idx < 0 {
+			return nil, fmt.Errorf("no config for chain %v of the agreed super root", c.ChainID)
+		}
+		chain := cfg.InteropChains[idx]
+		agreedBlockNum, err := chain.Rollup.TargetBlockNumber(agreed.Timestamp)
+		if err != nil {
+			return nil, fmt.Errorf("chain %v: %w", c.ChainID, err)
+		}
+		// Create the source without a head first, to look up the agreed block.
+		source, err := makeL2Source(ctx, logger, chain.Rollup, chain.L2URL, common.Hash{})
+		if err != nil {
+			return nil, fmt.Errorf("chain %v: %w", c.ChainID, err)
+		}
+		agreedBlock, err := source.L2BlockRefByNumber(ctx, agreedBlockNum)
+		if err != nil {
+			return nil, fmt.Errorf("chain %v: failed to fetch agreed block %v: %w", c.ChainID, agreedBlockNum, err)
+		}
+		source.l2Head = agreedBlock.Hash
+		l2Sources.AddChain(source, common.Hash(c.Output))
+	}
+	return l2Sources, nil
 }
 
 func routeHints(logger log.Logger, hHostRW io.ReadWriter, hinter preimage.HintHandler) chan error {
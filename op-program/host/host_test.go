@@ -3,11 +3,13 @@ package host
 import (
 	"context"
 	"errors"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-preimage/grpcoracle"
 	"github.com/ethereum-optimism/optimism/op-program/chainconfig"
 	"github.com/ethereum-optimism/optimism/op-program/client"
 	"github.com/ethereum-optimism/optimism/op-program/client/l1"
@@ -54,6 +56,47 @@ func TestServerMode(t *testing.T) {
 	require.ErrorIs(t, waitFor(result), kvstore.ErrNotFound)
 }
 
+func TestGRPCServerMode(t *testing.T) {
+	l1Head := common.Hash{0x11}
+	l2OutputRoot := common.Hash{0x33}
+	cfg := config.NewConfig(chaincfg.Sepolia, chainconfig.OPSepoliaChainConfig, l1Head, common.Hash{0x22}, l2OutputRoot, common.Hash{0x44}, 1000)
+	cfg.DataDir = t.TempDir()
+	cfg.ServerMode = true
+	cfg.GRPCAddr = "unix://" + filepath.Join(cfg.DataDir, "preimage.sock")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	logger := testlog.Logger(t, log.LevelTrace)
+	result := make(chan error)
+	go func() {
+		result <- PreimageGRPCServer(ctx, logger, cfg)
+	}()
+
+	var pClient *grpcoracle.Client
+	require.Eventually(t, func() bool {
+		cl, err := grpcoracle.NewClient(ctx, cfg.GRPCAddr, "", nil)
+		if err != nil {
+			return false
+		}
+		if _, err := cl.GetBatch(nil); err != nil {
+			_ = cl.Close()
+			return false
+		}
+		pClient = cl
+		return true
+	}, 30*time.Second, 10*time.Millisecond)
+	defer pClient.Close()
+
+	values, err := pClient.GetBatch([]preimage.Key{client.L1HeadLocalIndex, client.L2OutputRootLocalIndex})
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{l1Head.Bytes(), l2OutputRoot.Bytes()}, values)
+
+	_, err = pClient.GetBatch([]preimage.Key{preimage.Keccak256Key(common.HexToHash("0x1234"))})
+	require.ErrorContains(t, err, kvstore.ErrNotFound.Error())
+
+	cancel()
+	require.ErrorIs(t, waitFor(result), context.Canceled)
+}
+
 func waitFor(ch chan error) error {
 	timeout := time.After(30 * time.Second)
 	select {
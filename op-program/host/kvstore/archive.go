@@ -0,0 +1,177 @@
+package kvstore
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// archiveMagic identifies a pre-image archive. It is followed by the archive format version.
+var archiveMagic = []byte("OPPA")
+
+const archiveVersion = 1
+
+var ErrInvalidArchive = errors.New("invalid pre-image archive")
+
+// WriteArchive writes the pre-images as a gzip compressed archive.
+// The pre-images are written in key order so the same set of pre-images always produces the same archive.
+// The archive ends with a keccak256 digest of its uncompressed content, which identifies the archive and is
+// verified when reading it. Returns the digest.
+func WriteArchive(w io.Writer, preimages map[common.Hash][]byte) (common.Hash, error) {
+	gz, err := gzip.NewWriterLevel(w, gzip.BestCompression)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	hasher := crypto.NewKeccakState()
+	out := io.MultiWriter(gz, hasher)
+
+	header := append(slices.Clone(archiveMagic), archiveVersion)
+	header = binary.AppendUvarint(header, uint64(len(preimages)))
+	if _, err := out.Write(header); err != nil {
+		return common.Hash{}, err
+	}
+	keys := make([]common.Hash, 0, len(preimages))
+	for key := range preimages {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, func(a, b common.Hash) int {
+		return bytes.Compare(a[:], b[:])
+	})
+	for _, key := range keys {
+		value := preimages[key]
+		entry := binary.AppendUvarint(slices.Clone(key[:]), uint64(len(value)))
+		if _, err := out.Write(entry); err != nil {
+			return common.Hash{}, err
+		}
+		if _, err := out.Write(value); err != nil {
+			return common.Hash{}, err
+		}
+	}
+	var digest common.Hash
+	_, _ = hasher.Read(digest[:])
+	if _, err := gz.Write(digest[:]); err != nil {
+		return common.Hash{}, err
+	}
+	if err := gz.Close(); err != nil {
+		return common.Hash{}, err
+	}
+	return digest, nil
+}
+
+// ReadArchive reads an archive written by WriteArchive, calling fn with each pre-image in key order.
+// Returns the digest of the archive after verifying it matches the content. Note that fn may have been called for
+// pre-images of an archive that turns out to be invalid, so callers should not trust the pre-images until
+// ReadArchive returns successfully.
+func ReadArchive(r io.Reader, fn func(key common.Hash, value []byte) error) (common.Hash, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("%w: %w", ErrInvalidArchive, err)
+	}
+	defer gz.Close()
+	buffered := bufio.NewReader(gz)
+	in := &hashingReader{r: buffered, h: crypto.NewKeccakState()}
+
+	header := make([]byte, len(archiveMagic)+1)
+	if _, err := io.ReadFull(in, header); err != nil {
+		return common.Hash{}, fmt.Errorf("%w: failed to read header: %w", ErrInvalidArchive, err)
+	}
+	if !bytes.Equal(header[:len(archiveMagic)], archiveMagic) {
+		return common.Hash{}, fmt.Errorf("%w: unexpected magic bytes %x", ErrInvalidArchive, header[:len(archiveMagic)])
+	}
+	if header[len(archiveMagic)] != archiveVersion {
+		return common.Hash{}, fmt.Errorf("%w: unsupported version %d", ErrInvalidArchive, header[len(archiveMagic)])
+	}
+	count, err := binary.ReadUvarint(in)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("%w: failed to read pre-image count: %w", ErrInvalidArchive, err)
+	}
+	for i := uint64(0); i < count; i++ {
+		var key common.Hash
+		if _, err := io.ReadFull(in, key[:]); err != nil {
+			return common.Hash{}, fmt.Errorf("%w: failed to read key of pre-image %d: %w", ErrInvalidArchive, i, err)
+		}
+		size, err := binary.ReadUvarint(in)
+		if err != nil {
+			return common.Hash{}, fmt.Errorf("%w: failed to read size of pre-image %v: %w", ErrInvalidArchive, key, err)
+		}
+		value, err := readN(in, size)
+		if err != nil {
+			return common.Hash{}, fmt.Errorf("%w: failed to read pre-image %v: %w", ErrInvalidArchive, key, err)
+		}
+		if err := fn(key, value); err != nil {
+			return common.Hash{}, err
+		}
+	}
+	var expected common.Hash
+	_, _ = in.h.Read(expected[:])
+
+	// The digest itself is not part of the hashed content, so it is read without hashing.
+	var digest common.Hash
+	if _, err := io.ReadFull(buffered, digest[:]); err != nil {
+		return common.Hash{}, fmt.Errorf("%w: failed to read digest: %w", ErrInvalidArchive, err)
+	}
+	if digest != expected {
+		return common.Hash{}, fmt.Errorf("%w: digest %v does not match content %v", ErrInvalidArchive, digest, expected)
+	}
+	return digest, nil
+}
+
+// ImportArchive writes the pre-images of an archive to dest, after verifying the digest of the archive.
+// Local pre-images are skipped, as they describe the claim the archive was exported for and are always served
+// from the program configuration instead. Returns the number of pre-images imported and the digest of the archive.
+func ImportArchive(r io.Reader, dest KV) (int, common.Hash, error) {
+	preimages := make(map[common.Hash][]byte)
+	digest, err := ReadArchive(r, func(key common.Hash, value []byte) error {
+		if preimage.KeyType(key[0]) != preimage.LocalKeyType {
+			preimages[key] = value
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, common.Hash{}, err
+	}
+	for key, value := range preimages {
+		if err := dest.Put(key, value); err != nil {
+			return 0, common.Hash{}, fmt.Errorf("failed to import pre-image %v: %w", key, err)
+		}
+	}
+	return len(preimages), digest, nil
+}
+
+// readN reads n bytes from r, growing the buffer as data arrives, so a corrupt size cannot exhaust memory upfront.
+func readN(r io.Reader, n uint64) ([]byte, error) {
+	var out bytes.Buffer
+	if _, err := io.CopyN(&out, r, int64(n)); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// hashingReader hashes all data read through it.
+type hashingReader struct {
+	r *bufio.Reader
+	h crypto.KeccakState
+}
+
+func (h *hashingReader) Read(p []byte) (int, error) {
+	n, err := h.r.Read(p)
+	h.h.Write(p[:n])
+	return n, err
+}
+
+func (h *hashingReader) ReadByte() (byte, error) {
+	b, err := h.r.ReadByte()
+	if err == nil {
+		h.h.Write([]byte{b})
+	}
+	return b, err
+}
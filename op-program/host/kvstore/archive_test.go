@@ -0,0 +1,116 @@
+package kvstore
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"testing"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func archivePreimages() map[common.Hash][]byte {
+	preimages := make(map[common.Hash][]byte)
+	for _, val := range [][]byte{{1, 2, 3}, {4, 5, 6}, {}, bytes.Repeat([]byte{7}, 5000)} {
+		preimages[preimage.Keccak256Key(preimage.Keccak256(val)).PreimageKey()] = val
+	}
+	preimages[preimage.LocalIndexKey(1).PreimageKey()] = common.Hash{0x11}.Bytes()
+	return preimages
+}
+
+func TestArchiveRoundTrip(t *testing.T) {
+	preimages := archivePreimages()
+	var buf bytes.Buffer
+	digest, err := WriteArchive(&buf, preimages)
+	require.NoError(t, err)
+
+	read := make(map[common.Hash][]byte)
+	var lastKey common.Hash
+	readDigest, err := ReadArchive(bytes.NewReader(buf.Bytes()), func(key common.Hash, value []byte) error {
+		require.Positive(t, bytes.Compare(key[:], lastKey[:]), "pre-images must be in key order")
+		lastKey = key
+		read[key] = value
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, digest, readDigest)
+	require.Equal(t, preimages, read)
+}
+
+func TestArchiveDeterministic(t *testing.T) {
+	var a, b bytes.Buffer
+	digestA, err := WriteArchive(&a, archivePreimages())
+	require.NoError(t, err)
+	digestB, err := WriteArchive(&b, archivePreimages())
+	require.NoError(t, err)
+	require.Equal(t, digestA, digestB)
+	require.Equal(t, a.Bytes(), b.Bytes())
+}
+
+func TestArchiveCorrupted(t *testing.T) {
+	var buf bytes.Buffer
+	_, err := WriteArchive(&buf, archivePreimages())
+	require.NoError(t, err)
+	gz, err := gzip.NewReader(&buf)
+	require.NoError(t, err)
+	content, err := io.ReadAll(gz)
+	require.NoError(t, err)
+
+	rewrite := func(content []byte) *bytes.Buffer {
+		var out bytes.Buffer
+		gz := gzip.NewWriter(&out)
+		_, err := gz.Write(content)
+		require.NoError(t, err)
+		require.NoError(t, gz.Close())
+		return &out
+	}
+	noop := func(common.Hash, []byte) error { return nil }
+
+	t.Run("ModifiedValue", func(t *testing.T) {
+		modified := bytes.Clone(content)
+		modified[len(modified)-40] ^= 0xff
+		_, err := ReadArchive(rewrite(modified), noop)
+		require.ErrorIs(t, err, ErrInvalidArchive)
+	})
+
+	t.Run("Truncated", func(t *testing.T) {
+		_, err := ReadArchive(rewrite(content[:len(content)-50]), noop)
+		require.ErrorIs(t, err, ErrInvalidArchive)
+	})
+
+	t.Run("WrongMagic", func(t *testing.T) {
+		modified := bytes.Clone(content)
+		modified[0] = 'X'
+		_, err := ReadArchive(rewrite(modified), noop)
+		require.ErrorIs(t, err, ErrInvalidArchive)
+	})
+
+	t.Run("NotGzip", func(t *testing.T) {
+		_, err := ReadArchive(bytes.NewReader(content), noop)
+		require.ErrorIs(t, err, ErrInvalidArchive)
+	})
+}
+
+func TestImportArchive(t *testing.T) {
+	preimages := archivePreimages()
+	var buf bytes.Buffer
+	digest, err := WriteArchive(&buf, preimages)
+	require.NoError(t, err)
+
+	kv := NewMemKV()
+	count, importedDigest, err := ImportArchive(&buf, kv)
+	require.NoError(t, err)
+	require.Equal(t, digest, importedDigest)
+	require.Equal(t, len(preimages)-1, count)
+	for key, value := range preimages {
+		actual, err := kv.Get(key)
+		if preimage.KeyType(key[0]) == preimage.LocalKeyType {
+			require.ErrorIs(t, err, ErrNotFound, "local pre-images should not be imported")
+			continue
+		}
+		require.NoError(t, err)
+		require.Equal(t, value, actual)
+	}
+}
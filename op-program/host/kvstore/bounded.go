@@ -0,0 +1,146 @@
+package kvstore
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// EvictableKV is a KV store that pre-images can be removed from, so its size can be bounded.
+type EvictableKV interface {
+	KV
+
+	// Delete removes the pre-image with key k from the key-value store.
+	// Deleting a pre-image that is not stored is not an error.
+	Delete(k common.Hash) error
+
+	// ForEach calls fn with the key and value size, in bytes, of every pre-image in the key-value store.
+	// Iteration stops at the first error returned by fn.
+	ForEach(fn func(k common.Hash, size uint64) error) error
+}
+
+// BoundedKVMetrics records the size of a BoundedKV, and the pre-images evicted from it.
+type BoundedKVMetrics interface {
+	RecordPreimageStoreSize(size uint64)
+	RecordPreimageEviction()
+}
+
+type boundedEntry struct {
+	key  common.Hash
+	size uint64
+}
+
+// BoundedKV is a key-value store that bounds the total size of the pre-images in the underlying store.
+// When a Put exceeds the maximum size, the least recently used pre-images are evicted until the store fits.
+// Evicted pre-images are reported as ErrNotFound by Get, so they are fetched again when fetching is enabled.
+// The size only accounts for pre-image values: the disk usage of the underlying store also includes the
+// overhead of its format, e.g. the hex encoding of FileKV.
+// Pre-images already in the store when the BoundedKV is created are considered least recently used.
+// BoundedKV is safe for concurrent use.
+type BoundedKV struct {
+	log     log.Logger
+	m       BoundedKVMetrics
+	store   EvictableKV
+	maxSize uint64
+
+	mu      sync.Mutex
+	size    uint64
+	lru     *list.List // of *boundedEntry, most recently used first
+	entries map[common.Hash]*list.Element
+}
+
+// NewBoundedKV creates a BoundedKV that limits the total size of the pre-images in store to maxSize bytes.
+// The pre-images already in store are indexed, and evicted if they exceed maxSize.
+func NewBoundedKV(logger log.Logger, m BoundedKVMetrics, store EvictableKV, maxSize uint64) (*BoundedKV, error) {
+	b := &BoundedKV{
+		log:     logger,
+		m:       m,
+		store:   store,
+		maxSize: maxSize,
+		lru:     list.New(),
+		entries: make(map[common.Hash]*list.Element),
+	}
+	err := store.ForEach(func(k common.Hash, size uint64) error {
+		b.entries[k] = b.lru.PushBack(&boundedEntry{key: k, size: size})
+		b.size += size
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to index pre-image store: %w", err)
+	}
+	logger.Info("Opened size-bounded pre-image store", "preimages", len(b.entries), "size", b.size, "max_size", maxSize)
+	if err := b.evict(); err != nil {
+		return nil, err
+	}
+	b.m.RecordPreimageStoreSize(b.size)
+	return b, nil
+}
+
+func (b *BoundedKV) Put(k common.Hash, v []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.store.Put(k, v); err != nil {
+		return err
+	}
+	size := uint64(len(v))
+	if elem, ok := b.entries[k]; ok {
+		entry := elem.Value.(*boundedEntry)
+		b.size = b.size - entry.size + size
+		entry.size = size
+		b.lru.MoveToFront(elem)
+	} else {
+		b.entries[k] = b.lru.PushFront(&boundedEntry{key: k, size: size})
+		b.size += size
+	}
+	err := b.evict()
+	b.m.RecordPreimageStoreSize(b.size)
+	return err
+}
+
+func (b *BoundedKV) Get(k common.Hash) ([]byte, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	v, err := b.store.Get(k)
+	if err != nil {
+		return nil, err
+	}
+	if elem, ok := b.entries[k]; ok {
+		b.lru.MoveToFront(elem)
+	}
+	return v, nil
+}
+
+// evict removes the least recently used pre-images until the store fits within the maximum size.
+// The most recently used pre-image is never evicted, even if it exceeds the maximum size by itself,
+// so the pre-image that was just stored can always be read. Must be called with mu held.
+func (b *BoundedKV) evict() error {
+	for b.size > b.maxSize && b.lru.Len() > 1 {
+		elem := b.lru.Back()
+		entry := elem.Value.(*boundedEntry)
+		if err := b.store.Delete(entry.key); err != nil {
+			return fmt.Errorf("failed to evict pre-image %s: %w", entry.key, err)
+		}
+		b.lru.Remove(elem)
+		delete(b.entries, entry.key)
+		b.size -= entry.size
+		b.m.RecordPreimageEviction()
+		b.log.Trace("Evicted pre-image", "key", entry.key, "size", entry.size)
+	}
+	return nil
+}
+
+// Size returns the total size, in bytes, of the pre-images in the store.
+func (b *BoundedKV) Size() uint64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.size
+}
+
+func (b *BoundedKV) Close() error {
+	return b.store.Close()
+}
+
+var _ KV = (*BoundedKV)(nil)
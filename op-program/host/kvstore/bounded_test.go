@@ -0,0 +1,109 @@
+package kvstore
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type stubBoundedKVMetrics struct {
+	size      uint64
+	evictions int
+}
+
+func (s *stubBoundedKVMetrics) RecordPreimageStoreSize(size uint64) {
+	s.size = size
+}
+
+func (s *stubBoundedKVMetrics) RecordPreimageEviction() {
+	s.evictions++
+}
+
+func newTestBoundedKV(t *testing.T, store EvictableKV, maxSize uint64) (*BoundedKV, *stubBoundedKVMetrics) {
+	m := &stubBoundedKVMetrics{}
+	kv, err := NewBoundedKV(testlog.Logger(t, log.LevelInfo), m, store, maxSize)
+	require.NoError(t, err)
+	return kv, m
+}
+
+func TestBoundedKV(t *testing.T) {
+	t.Run("KV", func(t *testing.T) {
+		kv, _ := newTestBoundedKV(t, NewMemKV(), 1024)
+		kvTest(t, kv)
+	})
+
+	t.Run("EvictLeastRecentlyUsed", func(t *testing.T) {
+		store := NewMemKV()
+		kv, m := newTestBoundedKV(t, store, 10)
+		require.NoError(t, kv.Put(common.Hash{0xaa}, make([]byte, 4)))
+		require.NoError(t, kv.Put(common.Hash{0xbb}, make([]byte, 4)))
+		// Reading 0xaa makes 0xbb the least recently used pre-image.
+		_, err := kv.Get(common.Hash{0xaa})
+		require.NoError(t, err)
+		require.NoError(t, kv.Put(common.Hash{0xcc}, make([]byte, 4)))
+
+		_, err = kv.Get(common.Hash{0xbb})
+		require.ErrorIs(t, err, ErrNotFound, "evicted pre-image must not be found")
+		_, err = store.Get(common.Hash{0xbb})
+		require.ErrorIs(t, err, ErrNotFound, "evicted pre-image must be deleted from the store")
+		_, err = kv.Get(common.Hash{0xaa})
+		require.NoError(t, err)
+		_, err = kv.Get(common.Hash{0xcc})
+		require.NoError(t, err)
+
+		require.Equal(t, uint64(8), kv.Size())
+		require.Equal(t, uint64(8), m.size)
+		require.Equal(t, 1, m.evictions)
+
+		// Evicted pre-images can be stored again.
+		require.NoError(t, kv.Put(common.Hash{0xbb}, make([]byte, 4)))
+		_, err = kv.Get(common.Hash{0xbb})
+		require.NoError(t, err)
+		require.Equal(t, 2, m.evictions)
+	})
+
+	t.Run("ReplaceValue", func(t *testing.T) {
+		kv, m := newTestBoundedKV(t, NewMemKV(), 10)
+		require.NoError(t, kv.Put(common.Hash{0xaa}, make([]byte, 4)))
+		require.NoError(t, kv.Put(common.Hash{0xaa}, make([]byte, 6)))
+		require.Equal(t, uint64(6), kv.Size())
+		require.Zero(t, m.evictions)
+	})
+
+	t.Run("KeepOversizedPreimage", func(t *testing.T) {
+		kv, m := newTestBoundedKV(t, NewMemKV(), 10)
+		require.NoError(t, kv.Put(common.Hash{0xaa}, make([]byte, 4)))
+		require.NoError(t, kv.Put(common.Hash{0xbb}, make([]byte, 20)))
+		dat, err := kv.Get(common.Hash{0xbb})
+		require.NoError(t, err, "most recent pre-image must be kept, even if it exceeds the max size")
+		require.Len(t, dat, 20)
+		require.Equal(t, 1, m.evictions)
+	})
+
+	t.Run("IndexExistingPreimages", func(t *testing.T) {
+		store := NewFileKV(t.TempDir())
+		require.NoError(t, store.Put(common.Hash{0xaa}, make([]byte, 4)))
+		require.NoError(t, store.Put(common.Hash{0xbb}, make([]byte, 4)))
+		require.NoError(t, store.Put(common.Hash{0xcc}, make([]byte, 4)))
+
+		kv, m := newTestBoundedKV(t, store, 8)
+		require.Equal(t, uint64(8), kv.Size())
+		require.Equal(t, uint64(8), m.size)
+		require.Equal(t, 1, m.evictions, "existing pre-images exceeding the max size must be evicted")
+
+		require.NoError(t, kv.Put(common.Hash{0xdd}, make([]byte, 4)))
+		found := 0
+		for _, k := range []common.Hash{{0xaa}, {0xbb}, {0xcc}, {0xdd}} {
+			if _, err := kv.Get(k); err == nil {
+				found++
+			}
+		}
+		require.Equal(t, 2, found)
+		_, err := kv.Get(common.Hash{0xdd})
+		require.NoError(t, err, "new pre-image must not be evicted")
+	})
+}
@@ -90,8 +90,46 @@ func (d *FileKV) Get(k common.Hash) ([]byte, error) {
 	return hex.DecodeString(string(dat))
 }
 
+func (d *FileKV) Delete(k common.Hash) error {
+	d.Lock()
+	defer d.Unlock()
+	if err := os.Remove(d.pathKey(k)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove pre-image file %s: %w", k, err)
+	}
+	return nil
+}
+
+// ForEach reports the decoded size of each pre-image, which is half the size of its hex-encoded file.
+func (d *FileKV) ForEach(fn func(k common.Hash, size uint64) error) error {
+	d.RLock()
+	defer d.RUnlock()
+	entries, err := os.ReadDir(d.path)
+	if err != nil {
+		return fmt.Errorf("failed to read directory %s: %w", d.path, err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		key, ok := parseFileKVName(entry.Name())
+		if !ok {
+			continue
+		}
+		info, err := entry.Info()
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return fmt.Errorf("failed to stat pre-image file %s: %w", entry.Name(), err)
+		}
+		if err := fn(key, uint64(info.Size())/2); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (d *FileKV) Close() error {
 	return nil
 }
 
-var _ KV = (*FileKV)(nil)
+var _ EvictableKV = (*FileKV)(nil)
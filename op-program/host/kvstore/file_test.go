@@ -17,6 +17,12 @@ func TestDiskKV(t *testing.T) {
 	kvTest(t, kv)
 }
 
+func TestDiskKV_Evictable(t *testing.T) {
+	kv := NewFileKV(t.TempDir())
+	defer kv.Close()
+	evictableKVTest(t, kv)
+}
+
 func TestCreateMissingDirectory(t *testing.T) {
 	tmp := t.TempDir()
 	dir := filepath.Join(tmp, "data")
@@ -51,3 +51,24 @@ func kvTest(t *testing.T, kv KV) {
 		require.NoError(t, kv.Put(common.Hash{0xdd}, []byte{4, 2}))
 	})
 }
+
+func evictableKVTest(t *testing.T, kv EvictableKV) {
+	require.NoError(t, kv.Put(common.Hash{0xaa}, []byte("hello world")))
+	require.NoError(t, kv.Put(common.Hash{0xbb}, []byte{}))
+	require.NoError(t, kv.Put(common.Hash{0xcc}, []byte{4, 2}))
+
+	sizes := make(map[common.Hash]uint64)
+	require.NoError(t, kv.ForEach(func(k common.Hash, size uint64) error {
+		sizes[k] = size
+		return nil
+	}))
+	require.Equal(t, map[common.Hash]uint64{{0xaa}: 11, {0xbb}: 0, {0xcc}: 2}, sizes)
+
+	require.NoError(t, kv.Delete(common.Hash{0xaa}))
+	_, err := kv.Get(common.Hash{0xaa})
+	require.ErrorIs(t, err, ErrNotFound, "deleted pre-image must not be found")
+	require.NoError(t, kv.Delete(common.Hash{0xaa}), "deleting a missing pre-image must not fail")
+	dat, err := kv.Get(common.Hash{0xcc})
+	require.NoError(t, err, "other pre-images must not be deleted")
+	require.Equal(t, []byte{4, 2}, dat)
+}
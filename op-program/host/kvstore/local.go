@@ -4,9 +4,11 @@ import (
 	"encoding/binary"
 	"encoding/json"
 
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-program/client"
 	"github.com/ethereum-optimism/optimism/op-program/host/config"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
 )
 
 type LocalPreimageSource struct {
@@ -25,9 +27,15 @@ var (
 	l2ChainIDKey          = client.L2ChainIDLocalIndex.PreimageKey()
 	l2ChainConfigKey      = client.L2ChainConfigLocalIndex.PreimageKey()
 	rollupKey             = client.RollupConfigLocalIndex.PreimageKey()
+	agreedPrestateKey     = client.AgreedPrestateLocalIndex.PreimageKey()
+	checkpointIntervalKey = client.CheckpointIntervalLocalIndex.PreimageKey()
+	progressIntervalKey   = client.ProgressIntervalLocalIndex.PreimageKey()
 )
 
 func (s *LocalPreimageSource) Get(key common.Hash) ([]byte, error) {
+	if s.config.InteropEnabled() {
+		return s.getInterop(key)
+	}
 	switch [32]byte(key) {
 	case l1HeadKey:
 		return s.config.L1Head.Bytes(), nil
@@ -39,9 +47,11 @@ func (s *LocalPreimageSource) Get(key common.Hash) ([]byte, error) {
 		return binary.BigEndian.AppendUint64(nil, s.config.L2ClaimBlockNumber), nil
 	case l2ChainIDKey:
 		// The CustomChainIDIndicator informs the client to rely on the L2ChainConfigKey to
-		// read the chain config. Otherwise, it'll attempt to read a non-existent hardcoded chain config
+		// read the chain config. Otherwise, it'll attempt to read a non-existent hardcoded chain config.
+		// It is also used when checkpoints or progress output are enabled, as the client only reads
+		// the checkpoint and progress intervals for custom chains.
 		var chainID uint64
-		if s.config.IsCustomChainConfig {
+		if s.config.IsCustomChainConfig || s.config.CheckpointInterval() > 0 || s.config.ProgressHintInterval() > 0 {
 			chainID = client.CustomChainIDIndicator
 		} else {
 			chainID = s.config.L2ChainConfig.ChainID.Uint64()
@@ -51,6 +61,41 @@ func (s *LocalPreimageSource) Get(key common.Hash) ([]byte, error) {
 		return json.Marshal(s.config.L2ChainConfig)
 	case rollupKey:
 		return json.Marshal(s.config.Rollup)
+	case checkpointIntervalKey:
+		return binary.BigEndian.AppendUint64(nil, s.config.CheckpointInterval()), nil
+	case progressIntervalKey:
+		return binary.BigEndian.AppendUint64(nil, s.config.ProgressHintInterval()), nil
+	default:
+		return nil, ErrNotFound
+	}
+}
+
+func (s *LocalPreimageSource) getInterop(key common.Hash) ([]byte, error) {
+	switch [32]byte(key) {
+	case l1HeadKey:
+		return s.config.L1Head.Bytes(), nil
+	case l2OutputRootKey:
+		return s.config.L2OutputRoot.Bytes(), nil
+	case agreedPrestateKey:
+		return s.config.AgreedPrestate, nil
+	case l2ClaimKey:
+		return s.config.L2Claim.Bytes(), nil
+	case l2ClaimBlockNumberKey:
+		return binary.BigEndian.AppendUint64(nil, s.config.L2ClaimTimestamp), nil
+	case l2ChainIDKey:
+		return binary.BigEndian.AppendUint64(nil, client.InteropChainIDIndicator), nil
+	case l2ChainConfigKey:
+		chainConfigs := make([]*params.ChainConfig, len(s.config.InteropChains))
+		for i, chain := range s.config.InteropChains {
+			chainConfigs[i] = chain.L2ChainConfig
+		}
+		return json.Marshal(chainConfigs)
+	case rollupKey:
+		rollupConfigs := make([]*rollup.Config, len(s.config.InteropChains))
+		for i, chain := range s.config.InteropChains {
+			rollupConfigs[i] = chain.Rollup
+		}
+		return json.Marshal(rollupConfigs)
 	default:
 		return nil, ErrNotFound
 	}
@@ -6,7 +6,9 @@ import (
 	"testing"
 
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-program/client"
 	"github.com/ethereum-optimism/optimism/op-program/host/config"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/params"
@@ -35,6 +37,80 @@ func TestLocalPreimageSource(t *testing.T) {
 		{"L2ChainID", l2ChainIDKey, binary.BigEndian.AppendUint64(nil, cfg.L2ChainConfig.ChainID.Uint64())},
 		{"Rollup", rollupKey, asJson(t, cfg.Rollup)},
 		{"ChainConfig", l2ChainConfigKey, asJson(t, cfg.L2ChainConfig)},
+		{"CheckpointInterval", checkpointIntervalKey, binary.BigEndian.AppendUint64(nil, 0)},
+		{"ProgressInterval", progressIntervalKey, binary.BigEndian.AppendUint64(nil, 0)},
+		{"Unknown", preimage.LocalIndexKey(1000).PreimageKey(), nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := source.Get(test.key)
+			if test.expected == nil {
+				require.ErrorIs(t, err, ErrNotFound)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func TestLocalPreimageSource_Checkpoints(t *testing.T) {
+	cfg := &config.Config{
+		Rollup:         chaincfg.Sepolia,
+		L2ChainConfig:  params.GoerliChainConfig,
+		CheckpointFile: "checkpoint.json",
+	}
+	source := NewLocalPreimageSource(cfg)
+	// The client only reads the checkpoint interval for custom chains.
+	result, err := source.Get(l2ChainIDKey)
+	require.NoError(t, err)
+	require.Equal(t, binary.BigEndian.AppendUint64(nil, client.CustomChainIDIndicator), result)
+	result, err = source.Get(checkpointIntervalKey)
+	require.NoError(t, err)
+	require.Equal(t, binary.BigEndian.AppendUint64(nil, config.DefaultCheckpointInterval), result)
+}
+
+func TestLocalPreimageSource_ProgressOutput(t *testing.T) {
+	cfg := &config.Config{
+		Rollup:         chaincfg.Sepolia,
+		L2ChainConfig:  params.GoerliChainConfig,
+		ProgressOutput: "progress.jsonl",
+	}
+	source := NewLocalPreimageSource(cfg)
+	// The client only reads the progress interval for custom chains.
+	result, err := source.Get(l2ChainIDKey)
+	require.NoError(t, err)
+	require.Equal(t, binary.BigEndian.AppendUint64(nil, client.CustomChainIDIndicator), result)
+	result, err = source.Get(progressIntervalKey)
+	require.NoError(t, err)
+	require.Equal(t, binary.BigEndian.AppendUint64(nil, 1), result)
+}
+
+func TestLocalPreimageSource_Interop(t *testing.T) {
+	cfg := &config.Config{
+		L1Head:           common.HexToHash("0x1111"),
+		AgreedPrestate:   []byte{1, 2, 3},
+		L2OutputRoot:     common.HexToHash("0x2222"),
+		L2Claim:          common.HexToHash("0x3333"),
+		L2ClaimTimestamp: 7000,
+		InteropChains: []config.InteropChain{
+			{Rollup: chaincfg.Sepolia, L2ChainConfig: params.GoerliChainConfig},
+		},
+	}
+	source := NewLocalPreimageSource(cfg)
+	tests := []struct {
+		name     string
+		key      common.Hash
+		expected []byte
+	}{
+		{"L1Head", l1HeadKey, cfg.L1Head.Bytes()},
+		{"L2OutputRoot", l2OutputRootKey, cfg.L2OutputRoot.Bytes()},
+		{"AgreedPrestate", agreedPrestateKey, cfg.AgreedPrestate},
+		{"L2Claim", l2ClaimKey, cfg.L2Claim.Bytes()},
+		{"L2ClaimTimestamp", l2ClaimBlockNumberKey, binary.BigEndian.AppendUint64(nil, cfg.L2ClaimTimestamp)},
+		{"L2ChainID", l2ChainIDKey, binary.BigEndian.AppendUint64(nil, client.InteropChainIDIndicator)},
+		{"Rollup", rollupKey, asJson(t, []*rollup.Config{chaincfg.Sepolia})},
+		{"ChainConfig", l2ChainConfigKey, asJson(t, []*params.ChainConfig{params.GoerliChainConfig})},
 		{"Unknown", preimage.LocalIndexKey(1000).PreimageKey(), nil},
 	}
 	for _, test := range tests {
@@ -15,7 +15,7 @@ type MemKV struct {
 	m map[common.Hash][]byte
 }
 
-var _ KV = (*MemKV)(nil)
+var _ EvictableKV = (*MemKV)(nil)
 
 func NewMemKV() *MemKV {
 	return &MemKV{m: make(map[common.Hash][]byte)}
@@ -38,6 +38,24 @@ func (m *MemKV) Get(k common.Hash) ([]byte, error) {
 	return slices.Clone(v), nil
 }
 
+func (m *MemKV) Delete(k common.Hash) error {
+	m.Lock()
+	defer m.Unlock()
+	delete(m.m, k)
+	return nil
+}
+
+func (m *MemKV) ForEach(fn func(k common.Hash, size uint64) error) error {
+	m.RLock()
+	defer m.RUnlock()
+	for k, v := range m.m {
+		if err := fn(k, uint64(len(v))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (m *MemKV) Close() error {
 	return nil
 }
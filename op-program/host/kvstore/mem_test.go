@@ -6,3 +6,7 @@ func TestMemKV(t *testing.T) {
 	kv := NewMemKV()
 	kvTest(t, kv)
 }
+
+func TestMemKV_Evictable(t *testing.T) {
+	evictableKVTest(t, NewMemKV())
+}
@@ -0,0 +1,68 @@
+package kvstore
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// MigrateFileKV moves all pre-images stored by a FileKV in the given directory into dest.
+// Each file is only removed after its pre-image has been successfully written to dest, so an interrupted
+// migration can be resumed by calling MigrateFileKV again.
+// Files that do not match the FileKV naming scheme are left untouched.
+// Returns the number of pre-images migrated.
+func MigrateFileKV(dir string, dest KV) (int, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read directory %s: %w", dir, err)
+	}
+	count := 0
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		key, ok := parseFileKVName(entry.Name())
+		if !ok {
+			continue
+		}
+		filePath := filepath.Join(dir, entry.Name())
+		dat, err := os.ReadFile(filePath)
+		if err != nil {
+			return count, fmt.Errorf("failed to read pre-image file %s: %w", filePath, err)
+		}
+		value, err := hex.DecodeString(string(dat))
+		if err != nil {
+			return count, fmt.Errorf("failed to decode pre-image file %s: %w", filePath, err)
+		}
+		if err := dest.Put(key, value); err != nil {
+			return count, fmt.Errorf("failed to migrate pre-image %s: %w", key, err)
+		}
+		if err := os.Remove(filePath); err != nil {
+			return count, fmt.Errorf("failed to remove migrated pre-image file %s: %w", filePath, err)
+		}
+		count++
+	}
+	return count, nil
+}
+
+// parseFileKVName parses the key from a file name created by FileKV.
+// Temporary files from incomplete writes are not matched.
+func parseFileKVName(name string) (common.Hash, bool) {
+	hexKey, ok := strings.CutSuffix(name, ".txt")
+	if !ok {
+		return common.Hash{}, false
+	}
+	hexKey, ok = strings.CutPrefix(hexKey, "0x")
+	if !ok || len(hexKey) != 2*common.HashLength {
+		return common.Hash{}, false
+	}
+	key, err := hex.DecodeString(hexKey)
+	if err != nil {
+		return common.Hash{}, false
+	}
+	return common.BytesToHash(key), true
+}
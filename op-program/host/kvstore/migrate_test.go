@@ -0,0 +1,42 @@
+package kvstore
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateFileKV(t *testing.T) {
+	dir := t.TempDir()
+	fileKV := NewFileKV(dir)
+	values := [][]byte{{1, 2, 3}, {4, 5, 6}, {}}
+	for _, val := range values {
+		require.NoError(t, fileKV.Put(crypto.Keccak256Hash(val), val))
+	}
+	// Files not created by FileKV must be left alone
+	unrelated := filepath.Join(dir, "unrelated.txt")
+	require.NoError(t, os.WriteFile(unrelated, []byte("hello"), 0644))
+
+	pebbleKV := NewPebbleKV(dir)
+	defer pebbleKV.Close()
+	count, err := MigrateFileKV(dir, pebbleKV)
+	require.NoError(t, err)
+	require.Equal(t, len(values), count)
+
+	for _, val := range values {
+		key := crypto.Keccak256Hash(val)
+		actual, err := pebbleKV.Get(key)
+		require.NoError(t, err)
+		require.Equal(t, val, actual)
+		_, err = fileKV.Get(key)
+		require.ErrorIs(t, err, ErrNotFound, "migrated file should be removed")
+	}
+	require.FileExists(t, unrelated)
+
+	count, err = MigrateFileKV(dir, pebbleKV)
+	require.NoError(t, err)
+	require.Zero(t, count, "should not migrate again")
+}
@@ -58,6 +58,30 @@ func (d *PebbleKV) Get(k common.Hash) ([]byte, error) {
 	return ret, nil
 }
 
+func (d *PebbleKV) Delete(k common.Hash) error {
+	d.Lock()
+	defer d.Unlock()
+	return d.db.Delete(k.Bytes(), pebble.Sync)
+}
+
+func (d *PebbleKV) ForEach(fn func(k common.Hash, size uint64) error) error {
+	d.RLock()
+	defer d.RUnlock()
+	iter, err := d.db.NewIter(nil)
+	if err != nil {
+		return fmt.Errorf("failed to create pebble iterator: %w", err)
+	}
+	for iter.First(); iter.Valid(); iter.Next() {
+		if len(iter.Key()) != common.HashLength {
+			continue
+		}
+		if err := fn(common.BytesToHash(iter.Key()), uint64(len(iter.Value()))); err != nil {
+			return errors.Join(err, iter.Close())
+		}
+	}
+	return iter.Close()
+}
+
 func (d *PebbleKV) Close() error {
 	d.Lock()
 	defer d.Unlock()
@@ -65,4 +89,4 @@ func (d *PebbleKV) Close() error {
 	return d.db.Close()
 }
 
-var _ KV = (*PebbleKV)(nil)
+var _ EvictableKV = (*PebbleKV)(nil)
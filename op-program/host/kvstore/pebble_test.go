@@ -17,6 +17,12 @@ func TestPebbleKV(t *testing.T) {
 	kvTest(t, kv)
 }
 
+func TestPebbleKV_Evictable(t *testing.T) {
+	kv := NewPebbleKV(t.TempDir())
+	defer kv.Close()
+	evictableKVTest(t, kv)
+}
+
 func TestPebbleKV_CreateMissingDirectory(t *testing.T) {
 	tmp := t.TempDir()
 	dir := filepath.Join(tmp, "data")
@@ -0,0 +1,176 @@
+package kvstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-service/objectstore"
+	"github.com/ethereum-optimism/optimism/op-service/retry"
+)
+
+const (
+	remoteMaxAttempts     = 5
+	remoteUploadTimeout   = 30 * time.Second
+	remoteReadTimeout     = 5 * time.Second
+	remoteCloseTimeout    = 30 * time.Second
+	remoteUploadQueueSize = 1024
+)
+
+// RemoteKV is a key-value store that shares pre-images through a remote object store.
+// All pre-images are cached in a local KV store: writes go to the local store and are uploaded to the remote store
+// in the background, and reads that miss the local store are served from the remote store and cached locally.
+// Only keccak256 and sha256 pre-images are shared, as they are verified against their key before they are cached.
+// The remote store is best-effort: failures are logged, but never fail or block the local operation.
+// Uploads are retried with backoff, while reads are attempted once so the pre-image can be fetched from its source.
+type RemoteKV struct {
+	log    log.Logger
+	local  KV
+	remote objectstore.Store
+	prefix string
+	verify preimage.PreimageGetter
+
+	maxAttempts int
+	strategy    retry.Strategy
+
+	uploadsLock sync.Mutex
+	uploads     chan remoteUpload
+	closed      bool
+	uploadsDone chan struct{}
+	ctx         context.Context
+	cancel      context.CancelFunc
+}
+
+type remoteUpload struct {
+	key   common.Hash
+	value []byte
+}
+
+// NewRemoteKV creates a RemoteKV that caches pre-images in local, and shares them through the remote store.
+// All objects are stored under the given prefix, which may be empty.
+func NewRemoteKV(logger log.Logger, local KV, remote objectstore.Store, prefix string) *RemoteKV {
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &RemoteKV{
+		log:         logger,
+		local:       local,
+		remote:      remote,
+		prefix:      prefix,
+		maxAttempts: remoteMaxAttempts,
+		strategy:    retry.Exponential(),
+		uploads:     make(chan remoteUpload, remoteUploadQueueSize),
+		uploadsDone: make(chan struct{}),
+		ctx:         ctx,
+		cancel:      cancel,
+	}
+	r.verify = preimage.WithVerification(r.getRemote)
+	go r.uploadLoop()
+	return r
+}
+
+func (r *RemoteKV) objectName(k common.Hash) string {
+	return path.Join(r.prefix, k.String())
+}
+
+// isShared returns true if pre-images with the given key can be verified, and so may be shared through the remote store.
+func isShared(k common.Hash) bool {
+	switch preimage.KeyType(k[0]) {
+	case preimage.Keccak256KeyType, preimage.Sha256KeyType:
+		return true
+	default:
+		return false
+	}
+}
+
+func (r *RemoteKV) Put(k common.Hash, v []byte) error {
+	if err := r.local.Put(k, v); err != nil {
+		return err
+	}
+	if !isShared(k) {
+		return nil
+	}
+	r.uploadsLock.Lock()
+	defer r.uploadsLock.Unlock()
+	if r.closed {
+		return nil
+	}
+	select {
+	case r.uploads <- remoteUpload{key: k, value: v}:
+	default:
+		r.log.Warn("Dropping pre-image upload to remote store, queue is full", "key", k)
+	}
+	return nil
+}
+
+func (r *RemoteKV) uploadLoop() {
+	defer close(r.uploadsDone)
+	for upload := range r.uploads {
+		r.upload(upload)
+	}
+}
+
+func (r *RemoteKV) upload(upload remoteUpload) {
+	ctx, cancel := context.WithTimeout(r.ctx, remoteUploadTimeout)
+	defer cancel()
+	_, err := retry.Do(ctx, r.maxAttempts, r.strategy, func() (struct{}, error) {
+		return struct{}{}, r.remote.PutObject(ctx, r.objectName(upload.key), upload.value)
+	})
+	if err != nil && r.ctx.Err() == nil {
+		r.log.Warn("Failed to store pre-image in remote store", "key", upload.key, "err", err)
+	}
+}
+
+func (r *RemoteKV) Get(k common.Hash) ([]byte, error) {
+	v, err := r.local.Get(k)
+	if !errors.Is(err, ErrNotFound) || !isShared(k) {
+		return v, err
+	}
+	v, err = r.verify(k)
+	if errors.Is(err, ErrNotFound) {
+		return nil, ErrNotFound
+	} else if err != nil {
+		r.log.Warn("Failed to load pre-image from remote store", "key", k, "err", err)
+		return nil, ErrNotFound
+	}
+	if err := r.local.Put(k, v); err != nil {
+		return nil, fmt.Errorf("failed to cache remote pre-image %s: %w", k, err)
+	}
+	return v, nil
+}
+
+func (r *RemoteKV) getRemote(k [32]byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(r.ctx, remoteReadTimeout)
+	defer cancel()
+	v, err := r.remote.GetObject(ctx, r.objectName(k))
+	if errors.Is(err, objectstore.ErrNotFound) {
+		return nil, ErrNotFound
+	}
+	return v, err
+}
+
+// Close waits for pending uploads to the remote store, for up to remoteCloseTimeout, and closes the local store.
+func (r *RemoteKV) Close() error {
+	r.uploadsLock.Lock()
+	if !r.closed {
+		r.closed = true
+		close(r.uploads)
+	}
+	r.uploadsLock.Unlock()
+	select {
+	case <-r.uploadsDone:
+	case <-time.After(remoteCloseTimeout):
+		r.log.Warn("Abandoning pending pre-image uploads to remote store", "pending", len(r.uploads))
+		r.cancel()
+		<-r.uploadsDone
+	}
+	r.cancel()
+	return r.local.Close()
+}
+
+var _ KV = (*RemoteKV)(nil)
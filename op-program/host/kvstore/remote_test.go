@@ -0,0 +1,167 @@
+package kvstore
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-service/objectstore"
+	"github.com/ethereum-optimism/optimism/op-service/retry"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestRemoteKV(t *testing.T) {
+	t.Run("KV", func(t *testing.T) {
+		kv := newTestRemoteKV(t, NewMemKV(), objectstore.NewMemStore(), "preimages")
+		kvTest(t, kv)
+	})
+
+	t.Run("ShareBetweenInstances", func(t *testing.T) {
+		remote := objectstore.NewMemStore()
+		writer := newTestRemoteKV(t, NewMemKV(), remote, "preimages")
+		reader := newTestRemoteKV(t, NewMemKV(), remote, "preimages")
+
+		value := []byte("hello")
+		key := keccakKey(value)
+		require.NoError(t, writer.Put(key, value))
+		require.NoError(t, writer.Close(), "close waits for pending uploads")
+		require.Contains(t, remote.Objects(), "preimages/"+key.String())
+
+		dat, err := reader.Get(key)
+		require.NoError(t, err)
+		require.Equal(t, value, dat)
+
+		// The pre-image is now cached locally.
+		dat, err = reader.local.Get(key)
+		require.NoError(t, err)
+		require.Equal(t, value, dat)
+	})
+
+	t.Run("ShareSha256", func(t *testing.T) {
+		remote := objectstore.NewMemStore()
+		writer := newTestRemoteKV(t, NewMemKV(), remote, "")
+		reader := newTestRemoteKV(t, NewMemKV(), remote, "")
+
+		value := []byte("hello")
+		key := common.Hash(preimage.Sha256Key(sha256.Sum256(value)).PreimageKey())
+		require.NoError(t, writer.Put(key, value))
+		require.NoError(t, writer.Close())
+
+		dat, err := reader.Get(key)
+		require.NoError(t, err)
+		require.Equal(t, value, dat)
+	})
+
+	t.Run("RejectIncorrectData", func(t *testing.T) {
+		remote := objectstore.NewMemStore()
+		kv := newTestRemoteKV(t, NewMemKV(), remote, "")
+		key := keccakKey([]byte("hello"))
+		remote.SetObject(key.String(), []byte("forged"))
+
+		_, err := kv.Get(key)
+		require.ErrorIs(t, err, ErrNotFound)
+		_, err = kv.local.Get(key)
+		require.ErrorIs(t, err, ErrNotFound, "incorrect data must not be cached")
+	})
+
+	t.Run("UnverifiableKeysAreNotShared", func(t *testing.T) {
+		remote := objectstore.NewMemStore()
+		kv := newTestRemoteKV(t, NewMemKV(), remote, "")
+		localKey := common.Hash(preimage.LocalIndexKey(1).PreimageKey())
+		blobKey := common.Hash(preimage.BlobKey(common.Hash{0xaa}).PreimageKey())
+		remote.SetObject(blobKey.String(), []byte("hello"))
+
+		_, err := kv.Get(blobKey)
+		require.ErrorIs(t, err, ErrNotFound)
+		require.NoError(t, kv.Put(localKey, []byte("hello")))
+		require.NoError(t, kv.Close())
+		require.Zero(t, remote.Gets())
+		require.NotContains(t, remote.Objects(), localKey.String())
+	})
+
+	t.Run("NotFoundIsNotRetried", func(t *testing.T) {
+		remote := objectstore.NewMemStore()
+		kv := newTestRemoteKV(t, NewMemKV(), remote, "")
+		_, err := kv.Get(keccakKey([]byte("hello")))
+		require.ErrorIs(t, err, ErrNotFound)
+		require.Equal(t, 1, remote.Gets())
+	})
+
+	t.Run("ReadsFailFast", func(t *testing.T) {
+		remote := objectstore.NewMemStore()
+		kv := newTestRemoteKV(t, NewMemKV(), remote, "")
+		value := []byte("hello")
+		key := keccakKey(value)
+		remote.SetObject(key.String(), value)
+		remote.FailNext(1)
+
+		// Reads fall back to not found, so the pre-image can be fetched from the source instead
+		_, err := kv.Get(key)
+		require.ErrorIs(t, err, ErrNotFound)
+		require.Equal(t, 1, remote.Gets())
+	})
+
+	t.Run("RetryUploads", func(t *testing.T) {
+		remote := objectstore.NewMemStore()
+		kv := newTestRemoteKV(t, NewMemKV(), remote, "")
+		value := []byte("hello")
+		key := keccakKey(value)
+		remote.FailNext(2)
+
+		require.NoError(t, kv.Put(key, value))
+		require.NoError(t, kv.Close())
+		require.Equal(t, value, remote.Objects()[key.String()])
+	})
+
+	t.Run("UploadsDoNotBlock", func(t *testing.T) {
+		remote := objectstore.NewMemStore()
+		release := remote.BlockPuts()
+		kv := newTestRemoteKV(t, NewMemKV(), remote, "")
+
+		for i := 0; i < remoteUploadQueueSize+10; i++ {
+			value := []byte{byte(i), byte(i >> 8)}
+			require.NoError(t, kv.Put(keccakKey(value), value))
+		}
+		dat, err := kv.Get(keccakKey([]byte{0, 0}))
+		require.NoError(t, err, "writes are stored locally while uploads are pending")
+		require.Equal(t, []byte{0, 0}, dat)
+		release()
+		require.NoError(t, kv.Close())
+	})
+
+	t.Run("RemoteUnavailable", func(t *testing.T) {
+		remote := objectstore.NewMemStore()
+		kv := newTestRemoteKV(t, NewMemKV(), remote, "")
+		remote.FailNext(1000)
+
+		value := []byte("hello")
+		key := keccakKey(value)
+		// Writes are still stored locally
+		require.NoError(t, kv.Put(key, value))
+		dat, err := kv.Get(key)
+		require.NoError(t, err)
+		require.Equal(t, value, dat)
+
+		// Reads fall back to not found, so the pre-image can be fetched from the source instead
+		_, err = kv.Get(keccakKey([]byte("other")))
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func keccakKey(value []byte) common.Hash {
+	return common.Hash(preimage.Keccak256Key(crypto.Keccak256Hash(value)).PreimageKey())
+}
+
+func newTestRemoteKV(t *testing.T, local KV, remote objectstore.Store, prefix string) *RemoteKV {
+	kv := NewRemoteKV(testlog.Logger(t, log.LevelError), local, remote, prefix)
+	kv.strategy = retry.Fixed(0)
+	t.Cleanup(func() {
+		_ = kv.Close()
+	})
+	return kv
+}
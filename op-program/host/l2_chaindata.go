@@ -0,0 +1,140 @@
+package host
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/triedb"
+	"github.com/ethereum/go-ethereum/triedb/hashdb"
+
+	"github.com/ethereum-optimism/optimism/op-program/host/prefetcher"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/predeploys"
+)
+
+// ChainDataL2Source serves L2 data directly from the chaindata directory of an op-geth node, instead of RPC.
+// The database is opened read-only, so the node must be stopped, or the directory must be a copy.
+// State is read by trie node hash, so the node must use the hash-based state scheme and retain the state
+// of the agreed L2 head and the blocks after it, as archive nodes do.
+type ChainDataL2Source struct {
+	db          ethdb.Database
+	stateDB     state.Database
+	chainConfig *params.ChainConfig
+
+	// l2Head is the L2 block hash that we use to fetch L2 output
+	l2Head common.Hash
+}
+
+var _ prefetcher.L2Source = (*ChainDataL2Source)(nil)
+
+// NewChainDataL2Source opens the chaindata directory read-only. Ancient data is read from the ancient
+// directory inside of it, like op-geth does by default.
+func NewChainDataL2Source(dir string, chainConfig *params.ChainConfig, l2Head common.Hash) (*ChainDataL2Source, error) {
+	db, err := rawdb.Open(rawdb.OpenOptions{
+		Directory:         dir,
+		AncientsDirectory: filepath.Join(dir, "ancient"),
+		Namespace:         "op-program/l2/",
+		Cache:             128,
+		Handles:           64,
+		ReadOnly:          true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open L2 chaindata %v: %w", dir, err)
+	}
+	if scheme := rawdb.ReadStateScheme(db); scheme != rawdb.HashScheme {
+		_ = db.Close()
+		return nil, fmt.Errorf("L2 chaindata %v uses the %q state scheme, but the %q scheme is required to read state by hash", dir, scheme, rawdb.HashScheme)
+	}
+	return &ChainDataL2Source{
+		db:          db,
+		stateDB:     state.NewDatabaseWithConfig(db, &triedb.Config{HashDB: hashdb.Defaults}),
+		chainConfig: chainConfig,
+		l2Head:      l2Head,
+	}, nil
+}
+
+func (s *ChainDataL2Source) header(blockHash common.Hash) (*types.Header, error) {
+	num := rawdb.ReadHeaderNumber(s.db, blockHash)
+	if num == nil {
+		return nil, fmt.Errorf("block %v: %w", blockHash, ethereum.NotFound)
+	}
+	header := rawdb.ReadHeader(s.db, blockHash, *num)
+	if header == nil {
+		return nil, fmt.Errorf("header of block %v: %w", blockHash, ethereum.NotFound)
+	}
+	return header, nil
+}
+
+func (s *ChainDataL2Source) InfoAndTxsByHash(_ context.Context, blockHash common.Hash) (eth.BlockInfo, types.Transactions, error) {
+	header, err := s.header(blockHash)
+	if err != nil {
+		return nil, nil, err
+	}
+	body := rawdb.ReadBody(s.db, blockHash, header.Number.Uint64())
+	if body == nil {
+		return nil, nil, fmt.Errorf("body of block %v: %w", blockHash, ethereum.NotFound)
+	}
+	return eth.HeaderBlockInfo(header), body.Transactions, nil
+}
+
+func (s *ChainDataL2Source) NodeByHash(_ context.Context, hash common.Hash) ([]byte, error) {
+	node := rawdb.ReadLegacyTrieNode(s.db, hash)
+	if len(node) == 0 {
+		return nil, fmt.Errorf("trie node %v: %w", hash, ethereum.NotFound)
+	}
+	return node, nil
+}
+
+func (s *ChainDataL2Source) CodeByHash(_ context.Context, hash common.Hash) ([]byte, error) {
+	code := rawdb.ReadCode(s.db, hash)
+	if len(code) == 0 {
+		return nil, fmt.Errorf("code %v: %w", hash, ethereum.NotFound)
+	}
+	return code, nil
+}
+
+func (s *ChainDataL2Source) FetchReceipts(_ context.Context, blockHash common.Hash) (eth.BlockInfo, types.Receipts, error) {
+	header, err := s.header(blockHash)
+	if err != nil {
+		return nil, nil, err
+	}
+	receipts := rawdb.ReadReceipts(s.db, blockHash, header.Number.Uint64(), header.Time, s.chainConfig)
+	if receipts == nil {
+		return nil, nil, fmt.Errorf("receipts of block %v: %w", blockHash, ethereum.NotFound)
+	}
+	return eth.HeaderBlockInfo(header), receipts, nil
+}
+
+func (s *ChainDataL2Source) OutputByRoot(_ context.Context, l2OutputRoot common.Hash) (eth.Output, error) {
+	header, err := s.header(s.l2Head)
+	if err != nil {
+		return nil, err
+	}
+	st, err := state.New(header.Root, s.stateDB, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open state of block %v: %w", s.l2Head, err)
+	}
+	output := &eth.OutputV0{
+		StateRoot:                eth.Bytes32(header.Root),
+		MessagePasserStorageRoot: eth.Bytes32(st.GetStorageRoot(predeploys.L2ToL1MessagePasserAddr)),
+		BlockHash:                s.l2Head,
+	}
+	actualOutputRoot := eth.OutputRoot(output)
+	if actualOutputRoot != eth.Bytes32(l2OutputRoot) {
+		// Same as the RPC source: only the output at the l2 head is referenced, and there is no chance of recovery.
+		panic(fmt.Errorf("output root %v from specified L2 block %v does not match requested output root %v", actualOutputRoot, s.l2Head, l2OutputRoot))
+	}
+	return output, nil
+}
+
+func (s *ChainDataL2Source) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,135 @@
+package host
+
+import (
+	"context"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/ethereum/go-ethereum/triedb"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/predeploys"
+)
+
+func TestChainDataL2Source(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+	code := []byte{0x60, 0x01, 0x60, 0x00, 0x55}
+	genesis := &core.Genesis{
+		Config: params.AllEthashProtocolChanges,
+		Alloc: types.GenesisAlloc{
+			sender: {Balance: big.NewInt(params.Ether)},
+			predeploys.L2ToL1MessagePasserAddr: {
+				Code:    code,
+				Storage: map[common.Hash]common.Hash{{0x01}: {0x02}},
+			},
+		},
+	}
+	dir := filepath.Join(t.TempDir(), "chaindata")
+	db, err := rawdb.Open(rawdb.OpenOptions{Type: "pebble", Directory: dir, AncientsDirectory: filepath.Join(dir, "ancient")})
+	require.NoError(t, err)
+	cacheCfg := core.DefaultCacheConfigWithScheme(rawdb.HashScheme)
+	cacheCfg.TrieDirtyDisabled = true // archive, commit the state of every block
+	chain, err := core.NewBlockChain(db, cacheCfg, genesis, nil, ethash.NewFaker(), vm.Config{}, nil, nil)
+	require.NoError(t, err)
+	signer := types.LatestSigner(genesis.Config)
+	_, blocks, _ := core.GenerateChainWithGenesis(genesis, ethash.NewFaker(), 2, func(i int, gen *core.BlockGen) {
+		tx := types.MustSignNewTx(key, signer, &types.LegacyTx{
+			Nonce:    uint64(i),
+			To:       &common.Address{0xaa},
+			Value:    big.NewInt(1),
+			Gas:      params.TxGas,
+			GasPrice: gen.BaseFee(),
+		})
+		gen.AddTx(tx)
+	})
+	_, err = chain.InsertChain(blocks)
+	require.NoError(t, err)
+	chain.Stop()
+	require.NoError(t, db.Close())
+
+	head := blocks[0]
+	source, err := NewChainDataL2Source(dir, genesis.Config, head.Hash())
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, source.Close()) })
+	ctx := context.Background()
+
+	t.Run("InfoAndTxsByHash", func(t *testing.T) {
+		info, txs, err := source.InfoAndTxsByHash(ctx, blocks[1].Hash())
+		require.NoError(t, err)
+		require.Equal(t, blocks[1].Hash(), info.Hash())
+		require.Equal(t, blocks[1].NumberU64(), info.NumberU64())
+		require.Len(t, txs, 1)
+		require.Equal(t, blocks[1].Transactions()[0].Hash(), txs[0].Hash())
+
+		_, _, err = source.InfoAndTxsByHash(ctx, common.Hash{0xbb})
+		require.ErrorIs(t, err, ethereum.NotFound)
+	})
+
+	t.Run("FetchReceipts", func(t *testing.T) {
+		info, receipts, err := source.FetchReceipts(ctx, blocks[1].Hash())
+		require.NoError(t, err)
+		require.Equal(t, blocks[1].Hash(), info.Hash())
+		require.Len(t, receipts, 1)
+		require.Equal(t, blocks[1].ReceiptHash(), types.DeriveSha(receipts, trie.NewStackTrie(nil)))
+	})
+
+	t.Run("NodeByHash", func(t *testing.T) {
+		node, err := source.NodeByHash(ctx, head.Root())
+		require.NoError(t, err)
+		require.Equal(t, head.Root(), crypto.Keccak256Hash(node))
+
+		_, err = source.NodeByHash(ctx, common.Hash{0xbb})
+		require.ErrorIs(t, err, ethereum.NotFound)
+	})
+
+	t.Run("CodeByHash", func(t *testing.T) {
+		actual, err := source.CodeByHash(ctx, crypto.Keccak256Hash(code))
+		require.NoError(t, err)
+		require.Equal(t, code, actual)
+	})
+
+	t.Run("OutputByRoot", func(t *testing.T) {
+		// The message passer storage is not modified after genesis.
+		memDB := rawdb.NewMemoryDatabase()
+		genesisRoot := genesis.MustCommit(memDB, triedb.NewDatabase(memDB, triedb.HashDefaults)).Root()
+		genesisState, err := state.New(genesisRoot, state.NewDatabase(memDB), nil)
+		require.NoError(t, err)
+		expected := &eth.OutputV0{
+			StateRoot:                eth.Bytes32(head.Root()),
+			MessagePasserStorageRoot: eth.Bytes32(genesisState.GetStorageRoot(predeploys.L2ToL1MessagePasserAddr)),
+			BlockHash:                head.Hash(),
+		}
+		output, err := source.OutputByRoot(ctx, common.Hash(eth.OutputRoot(expected)))
+		require.NoError(t, err)
+		require.Equal(t, expected, output)
+
+		require.Panics(t, func() {
+			_, _ = source.OutputByRoot(ctx, common.Hash{0xbb})
+		})
+	})
+}
+
+func TestChainDataL2SourceRequiresHashScheme(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "chaindata")
+	db, err := rawdb.Open(rawdb.OpenOptions{Type: "pebble", Directory: dir, AncientsDirectory: filepath.Join(dir, "ancient")})
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	_, err = NewChainDataL2Source(dir, params.AllEthashProtocolChanges, common.Hash{})
+	require.ErrorContains(t, err, "state scheme")
+}
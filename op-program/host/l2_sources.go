@@ -0,0 +1,90 @@
+package host
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-program/host/prefetcher"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// L2Sources serves the L2 data of all chains covered by a super root.
+// Blocks, state and code are requested by hash, which is unique across chains, so each chain is tried in turn
+// until one serves the request.
+type L2Sources struct {
+	sources []*L2Source
+	// outputs maps the agreed output root of each chain to the source of the chain.
+	outputs map[common.Hash]*L2Source
+}
+
+var _ prefetcher.L2Source = (*L2Sources)(nil)
+
+func NewL2Sources() *L2Sources {
+	return &L2Sources{outputs: make(map[common.Hash]*L2Source)}
+}
+
+// AddChain adds the source of a chain, with the agreed output root of the chain.
+func (s *L2Sources) AddChain(source *L2Source, agreedOutputRoot common.Hash) {
+	s.sources = append(s.sources, source)
+	s.outputs[agreedOutputRoot] = source
+}
+
+func (s *L2Sources) InfoAndTxsByHash(ctx context.Context, blockHash common.Hash) (eth.BlockInfo, types.Transactions, error) {
+	var errs []error
+	for _, source := range s.sources {
+		info, txs, err := source.InfoAndTxsByHash(ctx, blockHash)
+		if err == nil {
+			return info, txs, nil
+		}
+		errs = append(errs, err)
+	}
+	return nil, nil, errors.Join(errs...)
+}
+
+func (s *L2Sources) NodeByHash(ctx context.Context, hash common.Hash) ([]byte, error) {
+	return firstResult(s.sources, func(source *L2Source) ([]byte, error) {
+		return source.NodeByHash(ctx, hash)
+	})
+}
+
+func (s *L2Sources) CodeByHash(ctx context.Context, hash common.Hash) ([]byte, error) {
+	return firstResult(s.sources, func(source *L2Source) ([]byte, error) {
+		return source.CodeByHash(ctx, hash)
+	})
+}
+
+func (s *L2Sources) FetchReceipts(ctx context.Context, blockHash common.Hash) (eth.BlockInfo, types.Receipts, error) {
+	var errs []error
+	for _, source := range s.sources {
+		info, receipts, err := source.FetchReceipts(ctx, blockHash)
+		if err == nil {
+			return info, receipts, nil
+		}
+		errs = append(errs, err)
+	}
+	return nil, nil, errors.Join(errs...)
+}
+
+func (s *L2Sources) OutputByRoot(ctx context.Context, root common.Hash) (eth.Output, error) {
+	source, ok := s.outputs[root]
+	if !ok {
+		return nil, fmt.Errorf("output root %v is not the agreed output root of any chain", root)
+	}
+	return source.OutputByRoot(ctx, root)
+}
+
+func firstResult[T any](sources []*L2Source, fn func(source *L2Source) (T, error)) (T, error) {
+	var errs []error
+	for _, source := range sources {
+		result, err := fn(source)
+		if err == nil {
+			return result, nil
+		}
+		errs = append(errs, err)
+	}
+	var empty T
+	return empty, errors.Join(errs...)
+}
@@ -0,0 +1,56 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+)
+
+const Namespace = "op_program"
+
+type Metricer interface {
+	RecordPreimageStoreSize(size uint64)
+	RecordPreimageEviction()
+}
+
+// Metrics implementation must implement RegistryMetricer to allow the metrics server to work.
+var _ opmetrics.RegistryMetricer = (*Metrics)(nil)
+
+type Metrics struct {
+	registry *prometheus.Registry
+
+	preimageStoreSize      prometheus.Gauge
+	preimageStoreEvictions prometheus.Counter
+}
+
+var _ Metricer = (*Metrics)(nil)
+
+func NewMetrics() *Metrics {
+	registry := opmetrics.NewRegistry()
+	factory := opmetrics.With(registry)
+	return &Metrics{
+		registry: registry,
+		preimageStoreSize: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "preimage_store_size_bytes",
+			Help:      "Total size of the pre-images in the size-bounded pre-image store",
+		}),
+		preimageStoreEvictions: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "preimage_store_evictions_total",
+			Help:      "Number of pre-images evicted from the size-bounded pre-image store",
+		}),
+	}
+}
+
+func (m *Metrics) Registry() *prometheus.Registry {
+	return m.registry
+}
+
+func (m *Metrics) RecordPreimageStoreSize(size uint64) {
+	m.preimageStoreSize.Set(float64(size))
+}
+
+func (m *Metrics) RecordPreimageEviction() {
+	m.preimageStoreEvictions.Inc()
+}
@@ -0,0 +1,8 @@
+package metrics
+
+type NoopMetricsImpl struct{}
+
+var NoopMetrics Metricer = new(NoopMetricsImpl)
+
+func (*NoopMetricsImpl) RecordPreimageStoreSize(size uint64) {}
+func (*NoopMetricsImpl) RecordPreimageEviction()             {}
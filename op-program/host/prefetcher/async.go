@@ -0,0 +1,175 @@
+package prefetcher
+
+import (
+	"context"
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+
+	"github.com/ethereum-optimism/optimism/op-program/client/l1"
+	"github.com/ethereum-optimism/optimism/op-program/client/l2"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// completedHintsCacheSize is the number of completed hints remembered, to skip prefetching repeated hints.
+const completedHintsCacheSize = 10_000
+
+// l1HintTypes and l2HintTypes are the hint types that fetch from the L1 and L2 sources respectively.
+var (
+	l1HintTypes = []string{l1.HintL1BlockHeader, l1.HintL1Transactions, l1.HintL1Receipts, l1.HintL1Blob, l1.HintL1Precompile, l1.HintL1PrecompileV2}
+	l2HintTypes = []string{l2.HintL2BlockHeader, l2.HintL2Transactions, l2.HintL2StateNode, l2.HintL2Code, l2.HintL2Output}
+)
+
+// AsyncConfig configures prefetching of hints in the background, ahead of the pre-image requests of the client.
+type AsyncConfig struct {
+	// Workers is the number of hints prefetched concurrently. Background prefetching is disabled if 0.
+	Workers int
+	// HintConcurrency limits the number of concurrent background prefetches per hint type.
+	// Hint types that are not included are only limited by the number of workers.
+	HintConcurrency map[string]int
+	// QueueSize is the number of hints that can be queued for prefetching. Further hints are dropped,
+	// and only fetched once the client requests a pre-image for them.
+	QueueSize int
+	// Lookahead enables prefetching the transactions and receipts of hinted L1 blocks,
+	// before the client hints them.
+	Lookahead bool
+}
+
+// HintConcurrency returns hint concurrency limits, with the same limit for all L1 and all L2 hint types respectively.
+func HintConcurrency(l1Limit int, l2Limit int) map[string]int {
+	limits := make(map[string]int, len(l1HintTypes)+len(l2HintTypes))
+	for _, hintType := range l1HintTypes {
+		limits[hintType] = l1Limit
+	}
+	for _, hintType := range l2HintTypes {
+		limits[hintType] = l2Limit
+	}
+	return limits
+}
+
+// inflightFetch is a prefetch in progress, that duplicate requests for the same hint wait for.
+type inflightFetch struct {
+	done chan struct{}
+	err  error
+}
+
+// asyncFetcher prefetches queued hints in a worker pool, and coalesces concurrent prefetches of the same hint.
+type asyncFetcher struct {
+	p   *Prefetcher
+	cfg AsyncConfig
+
+	queue     chan string
+	limits    map[string]chan struct{}
+	completed *lru.Cache[string, struct{}]
+
+	mu       sync.Mutex
+	inflight map[string]*inflightFetch
+}
+
+// StartAsync starts prefetching hints in the background as they are received, until ctx is done.
+// Pre-image requests for a hint that is already being prefetched wait for that prefetch to complete.
+// It is a no-op if cfg.Workers is 0. Must be called before any hints are received.
+func (p *Prefetcher) StartAsync(ctx context.Context, cfg AsyncConfig) {
+	if cfg.Workers <= 0 {
+		return
+	}
+	completed, _ := lru.New[string, struct{}](completedHintsCacheSize)
+	a := &asyncFetcher{
+		p:         p,
+		cfg:       cfg,
+		queue:     make(chan string, max(cfg.QueueSize, 1)),
+		limits:    make(map[string]chan struct{}),
+		completed: completed,
+		inflight:  make(map[string]*inflightFetch),
+	}
+	for hintType, limit := range cfg.HintConcurrency {
+		if limit > 0 {
+			a.limits[hintType] = make(chan struct{}, limit)
+		}
+	}
+	for i := 0; i < cfg.Workers; i++ {
+		go a.worker(ctx)
+	}
+	p.async = a
+}
+
+// enqueue queues the hint for prefetching, unless it was prefetched recently.
+// The hint is dropped if the queue is full.
+func (a *asyncFetcher) enqueue(hint string) {
+	if a.completed.Contains(hint) {
+		return
+	}
+	select {
+	case a.queue <- hint:
+	default:
+		a.p.logger.Debug("Prefetch queue full, dropping hint", "hint", hint)
+	}
+}
+
+func (a *asyncFetcher) worker(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case hint := <-a.queue:
+			a.prefetchQueued(ctx, hint)
+		}
+	}
+}
+
+func (a *asyncFetcher) prefetchQueued(ctx context.Context, hint string) {
+	// Hints may have been queued multiple times before the first prefetch completed.
+	if a.completed.Contains(hint) {
+		return
+	}
+	hintType, hintBytes, err := parseHint(hint)
+	if err != nil {
+		a.p.logger.Warn("Ignoring invalid hint", "hint", hint, "err", err)
+		return
+	}
+	if limit, ok := a.limits[hintType]; ok {
+		select {
+		case limit <- struct{}{}:
+		case <-ctx.Done():
+			return
+		}
+		defer func() { <-limit }()
+	}
+	if err := a.fetch(ctx, hint); err != nil {
+		a.p.logger.Debug("Background prefetch failed", "hint", hint, "err", err)
+		return
+	}
+	if a.cfg.Lookahead && hintType == l1.HintL1BlockHeader && len(hintBytes) == common.HashLength {
+		// Derivation reads the transactions of every L1 block it traverses, and the receipts of most.
+		hash := common.Hash(hintBytes)
+		a.enqueue(l1.TransactionsHint(hash).Hint())
+		a.enqueue(l1.ReceiptsHint(hash).Hint())
+	}
+}
+
+// fetch prefetches the hint, or waits for the result of a prefetch of the same hint that is already in progress.
+func (a *asyncFetcher) fetch(ctx context.Context, hint string) error {
+	a.mu.Lock()
+	if f, ok := a.inflight[hint]; ok {
+		a.mu.Unlock()
+		select {
+		case <-f.done:
+			return f.err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	f := &inflightFetch{done: make(chan struct{})}
+	a.inflight[hint] = f
+	a.mu.Unlock()
+
+	f.err = a.p.prefetch(ctx, hint)
+	if f.err == nil {
+		a.completed.Add(hint, struct{}{})
+	}
+	a.mu.Lock()
+	delete(a.inflight, hint)
+	a.mu.Unlock()
+	close(f.done)
+	return f.err
+}
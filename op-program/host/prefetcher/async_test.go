@@ -0,0 +1,183 @@
+package prefetcher
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-program/client/l1"
+	"github.com/ethereum-optimism/optimism/op-program/client/l2"
+	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestAsyncPrefetch(t *testing.T) {
+	rng := rand.New(rand.NewSource(123))
+
+	t.Run("PrefetchBeforeRequest", func(t *testing.T) {
+		prefetcher, l2Src, kv := createAsyncPrefetcher(t, AsyncConfig{Workers: 2, QueueSize: 10})
+		node := testutils.RandomData(rng, 30)
+		hash := crypto.Keccak256Hash(node)
+		l2Src.nodes[hash] = node
+		close(l2Src.gate)
+
+		require.NoError(t, prefetcher.Hint(l2.StateNodeHint(hash).Hint()))
+		require.Eventually(t, func() bool {
+			_, err := kv.Get(preimage.Keccak256Key(hash).PreimageKey())
+			return err == nil
+		}, 10*time.Second, 10*time.Millisecond)
+
+		pre, err := prefetcher.GetPreimage(context.Background(), preimage.Keccak256Key(hash).PreimageKey())
+		require.NoError(t, err)
+		require.Equal(t, node, pre)
+		require.Equal(t, 1, l2Src.callCount(hash))
+	})
+
+	t.Run("CoalesceDuplicateRequests", func(t *testing.T) {
+		prefetcher, l2Src, _ := createAsyncPrefetcher(t, AsyncConfig{Workers: 4, QueueSize: 10})
+		node := testutils.RandomData(rng, 30)
+		hash := crypto.Keccak256Hash(node)
+		l2Src.nodes[hash] = node
+
+		hint := l2.StateNodeHint(hash).Hint()
+		require.NoError(t, prefetcher.Hint(hint))
+		require.NoError(t, prefetcher.Hint(hint))
+		result := make(chan []byte, 1)
+		go func() {
+			pre, err := prefetcher.GetPreimage(context.Background(), preimage.Keccak256Key(hash).PreimageKey())
+			require.NoError(t, err)
+			result <- pre
+		}()
+		require.Eventually(t, func() bool {
+			return l2Src.callCount(hash) == 1
+		}, 10*time.Second, 10*time.Millisecond)
+		close(l2Src.gate)
+
+		require.Equal(t, node, <-result)
+		require.Equal(t, 1, l2Src.callCount(hash))
+	})
+
+	t.Run("HintConcurrencyLimit", func(t *testing.T) {
+		cfg := AsyncConfig{Workers: 4, QueueSize: 10, HintConcurrency: HintConcurrency(4, 1)}
+		prefetcher, l2Src, kv := createAsyncPrefetcher(t, cfg)
+		var hashes []common.Hash
+		for i := 0; i < 3; i++ {
+			node := testutils.RandomData(rng, 30)
+			hash := crypto.Keccak256Hash(node)
+			l2Src.nodes[hash] = node
+			hashes = append(hashes, hash)
+			require.NoError(t, prefetcher.Hint(l2.StateNodeHint(hash).Hint()))
+		}
+		close(l2Src.gate)
+
+		require.Eventually(t, func() bool {
+			for _, hash := range hashes {
+				if _, err := kv.Get(preimage.Keccak256Key(hash).PreimageKey()); err != nil {
+					return false
+				}
+			}
+			return true
+		}, 10*time.Second, 10*time.Millisecond)
+		require.Equal(t, 1, l2Src.maxActiveCalls())
+	})
+
+	t.Run("LookaheadL1Block", func(t *testing.T) {
+		block, rcpts := testutils.RandomBlock(rng, 2)
+		hash := block.Hash()
+		logger := testlog.Logger(t, log.LevelDebug)
+		kv := kvstore.NewMemKV()
+		l1Source := new(testutils.MockL1Source)
+		l1Source.ExpectInfoByHash(hash, eth.BlockToInfo(block), nil)
+		l1Source.ExpectInfoAndTxsByHash(hash, eth.BlockToInfo(block), block.Transactions(), nil)
+		l1Source.ExpectFetchReceipts(hash, eth.BlockToInfo(block), rcpts, nil)
+		defer l1Source.AssertExpectations(t)
+		prefetcher := NewPrefetcher(logger, l1Source, new(testutils.MockBlobsFetcher), newGatedL2Source(), kv)
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+		prefetcher.StartAsync(ctx, AsyncConfig{Workers: 2, QueueSize: 10, Lookahead: true})
+
+		require.NoError(t, prefetcher.Hint(l1.BlockHeaderHint(hash).Hint()))
+		require.Eventually(t, func() bool {
+			for _, key := range []common.Hash{hash, block.TxHash(), block.ReceiptHash()} {
+				if _, err := kv.Get(preimage.Keccak256Key(key).PreimageKey()); err != nil {
+					return false
+				}
+			}
+			return true
+		}, 10*time.Second, 10*time.Millisecond)
+	})
+}
+
+func createAsyncPrefetcher(t *testing.T, cfg AsyncConfig) (*Prefetcher, *gatedL2Source, kvstore.KV) {
+	logger := testlog.Logger(t, log.LevelDebug)
+	kv := kvstore.NewMemKV()
+	l2Src := newGatedL2Source()
+	prefetcher := NewPrefetcher(logger, new(testutils.MockL1Source), new(testutils.MockBlobsFetcher), l2Src, kv)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	prefetcher.StartAsync(ctx, cfg)
+	return prefetcher, l2Src, kv
+}
+
+// gatedL2Source serves state nodes, blocking all requests until the gate is closed.
+type gatedL2Source struct {
+	L2Source
+	gate  chan struct{}
+	nodes map[common.Hash][]byte
+
+	mu        sync.Mutex
+	calls     map[common.Hash]int
+	active    int
+	maxActive int
+}
+
+func newGatedL2Source() *gatedL2Source {
+	return &gatedL2Source{
+		gate:  make(chan struct{}),
+		nodes: make(map[common.Hash][]byte),
+		calls: make(map[common.Hash]int),
+	}
+}
+
+func (s *gatedL2Source) NodeByHash(ctx context.Context, hash common.Hash) ([]byte, error) {
+	s.mu.Lock()
+	s.calls[hash]++
+	s.active++
+	s.maxActive = max(s.maxActive, s.active)
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		s.active--
+		s.mu.Unlock()
+	}()
+	select {
+	case <-s.gate:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	// Give other requests the chance to run concurrently, if they are not limited.
+	time.Sleep(10 * time.Millisecond)
+	return s.nodes[hash], nil
+}
+
+func (s *gatedL2Source) callCount(hash common.Hash) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls[hash]
+}
+
+func (s *gatedL2Source) maxActiveCalls() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.maxActive
+}
@@ -19,8 +19,10 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
+	lru "github.com/hashicorp/golang-lru/v2"
 )
 
 var (
@@ -28,6 +30,10 @@ var (
 	precompileFailure = [1]byte{0}
 )
 
+// blobCacheSize is the number of verified blobs kept in memory, so repeated hints for a blob
+// don't fetch it from the beacon API or verify its KZG commitment again.
+const blobCacheSize = 16
+
 var acceleratedPrecompiles = []common.Address{
 	common.BytesToAddress([]byte{0x1}),  // ecrecover
 	common.BytesToAddress([]byte{0x8}),  // bn256Pairing
@@ -50,6 +56,7 @@ type L2Source interface {
 	NodeByHash(ctx context.Context, hash common.Hash) ([]byte, error)
 	CodeByHash(ctx context.Context, hash common.Hash) ([]byte, error)
 	OutputByRoot(ctx context.Context, root common.Hash) (eth.Output, error)
+	FetchReceipts(ctx context.Context, blockHash common.Hash) (eth.BlockInfo, types.Receipts, error)
 }
 
 type Prefetcher struct {
@@ -59,21 +66,35 @@ type Prefetcher struct {
 	l2Fetcher     L2Source
 	lastHint      string
 	kvStore       kvstore.KV
+	async         *asyncFetcher
+	// blobs caches verified blobs by versioned hash.
+	blobs *lru.Cache[common.Hash, *verifiedBlob]
+}
+
+// verifiedBlob is a blob that was checked to match its KZG commitment.
+type verifiedBlob struct {
+	commitment eth.Bytes48
+	blob       *eth.Blob
 }
 
 func NewPrefetcher(logger log.Logger, l1Fetcher L1Source, l1BlobFetcher L1BlobSource, l2Fetcher L2Source, kvStore kvstore.KV) *Prefetcher {
+	blobs, _ := lru.New[common.Hash, *verifiedBlob](blobCacheSize)
 	return &Prefetcher{
 		logger:        logger,
 		l1Fetcher:     NewRetryingL1Source(logger, l1Fetcher),
 		l1BlobFetcher: NewRetryingL1BlobSource(logger, l1BlobFetcher),
 		l2Fetcher:     NewRetryingL2Source(logger, l2Fetcher),
 		kvStore:       kvStore,
+		blobs:         blobs,
 	}
 }
 
 func (p *Prefetcher) Hint(hint string) error {
 	p.logger.Trace("Received hint", "hint", hint)
 	p.lastHint = hint
+	if p.async != nil {
+		p.async.enqueue(hint)
+	}
 	return nil
 }
 
@@ -85,7 +106,7 @@ func (p *Prefetcher) GetPreimage(ctx context.Context, key common.Hash) ([]byte,
 	// before we get to read it.
 	for errors.Is(err, kvstore.ErrNotFound) && p.lastHint != "" {
 		hint := p.lastHint
-		if err := p.prefetch(ctx, hint); err != nil {
+		if err := p.fetch(ctx, hint); err != nil {
 			return nil, fmt.Errorf("prefetch failed: %w", err)
 		}
 		pre, err = p.kvStore.Get(key)
@@ -96,6 +117,14 @@ func (p *Prefetcher) GetPreimage(ctx context.Context, key common.Hash) ([]byte,
 	return pre, err
 }
 
+// fetch prefetches the hint, joining a background prefetch of the same hint if there is one.
+func (p *Prefetcher) fetch(ctx context.Context, hint string) error {
+	if p.async != nil {
+		return p.async.fetch(ctx, hint)
+	}
+	return p.prefetch(ctx, hint)
+}
+
 func (p *Prefetcher) prefetch(ctx context.Context, hint string) error {
 	hintType, hintBytes, err := parseHint(hint)
 	if err != nil {
@@ -141,43 +170,7 @@ func (p *Prefetcher) prefetch(ctx context.Context, hint string) error {
 		if len(hintBytes) != 48 {
 			return fmt.Errorf("invalid blob hint: %x", hint)
 		}
-
-		blobVersionHash := common.Hash(hintBytes[:32])
-		blobHashIndex := binary.BigEndian.Uint64(hintBytes[32:40])
-		refTimestamp := binary.BigEndian.Uint64(hintBytes[40:48])
-
-		// Fetch the blob sidecar for the indexed blob hash passed in the hint.
-		indexedBlobHash := eth.IndexedBlobHash{
-			Hash:  blobVersionHash,
-			Index: blobHashIndex,
-		}
-		// We pass an `eth.L1BlockRef`, but `GetBlobSidecars` only uses the timestamp, which we received in the hint.
-		sidecars, err := p.l1BlobFetcher.GetBlobSidecars(ctx, eth.L1BlockRef{Time: refTimestamp}, []eth.IndexedBlobHash{indexedBlobHash})
-		if err != nil || len(sidecars) != 1 {
-			return fmt.Errorf("failed to fetch blob sidecars for %s %d: %w", blobVersionHash, blobHashIndex, err)
-		}
-		sidecar := sidecars[0]
-
-		// Put the preimage for the versioned hash into the kv store
-		if err = p.kvStore.Put(preimage.Sha256Key(blobVersionHash).PreimageKey(), sidecar.KZGCommitment[:]); err != nil {
-			return err
-		}
-
-		// Put all of the blob's field elements into the kv store. There should be 4096. The preimage oracle key for
-		// each field element is the keccak256 hash of `abi.encodePacked(sidecar.KZGCommitment, uint256(i))`
-		blobKey := make([]byte, 80)
-		copy(blobKey[:48], sidecar.KZGCommitment[:])
-		for i := 0; i < params.BlobTxFieldElementsPerBlob; i++ {
-			binary.BigEndian.PutUint64(blobKey[72:], uint64(i))
-			blobKeyHash := crypto.Keccak256Hash(blobKey)
-			if err := p.kvStore.Put(preimage.Keccak256Key(blobKeyHash).PreimageKey(), blobKey); err != nil {
-				return err
-			}
-			if err = p.kvStore.Put(preimage.BlobKey(blobKeyHash).PreimageKey(), sidecar.Blob[i<<5:(i+1)<<5]); err != nil {
-				return err
-			}
-		}
-		return nil
+		return p.prefetchBlob(ctx, hintBytes)
 	case l1.HintL1Precompile:
 		if len(hintBytes) < 20 {
 			return fmt.Errorf("invalid precompile hint: %x", hint)
@@ -283,10 +276,85 @@ func (p *Prefetcher) prefetch(ctx context.Context, hint string) error {
 			return fmt.Errorf("failed to fetch L2 output root %s: %w", hash, err)
 		}
 		return p.kvStore.Put(preimage.Keccak256Key(hash).PreimageKey(), output.Marshal())
+	case l2.HintL2Receipts:
+		if len(hintBytes) != 32 {
+			return fmt.Errorf("invalid L2 receipts hint: %x", hint)
+		}
+		hash := common.Hash(hintBytes)
+		_, receipts, err := p.l2Fetcher.FetchReceipts(ctx, hash)
+		if err != nil {
+			return fmt.Errorf("failed to fetch L2 block %s receipts: %w", hash, err)
+		}
+		return p.storeReceipts(receipts)
 	}
 	return fmt.Errorf("unknown hint type: %v", hintType)
 }
 
+// prefetchBlob fetches the whole blob of the hint, verifies it against its KZG commitment and stores the preimages
+// of the commitment and of all the field elements of the blob.
+func (p *Prefetcher) prefetchBlob(ctx context.Context, hintBytes []byte) error {
+	blobVersionHash := common.Hash(hintBytes[:32])
+	blobHashIndex := binary.BigEndian.Uint64(hintBytes[32:40])
+	refTimestamp := binary.BigEndian.Uint64(hintBytes[40:48])
+
+	verified, ok := p.blobs.Get(blobVersionHash)
+	if !ok {
+		// Fetch the blob sidecar for the indexed blob hash passed in the hint.
+		indexedBlobHash := eth.IndexedBlobHash{
+			Hash:  blobVersionHash,
+			Index: blobHashIndex,
+		}
+		// We pass an `eth.L1BlockRef`, but `GetBlobSidecars` only uses the timestamp, which we received in the hint.
+		sidecars, err := p.l1BlobFetcher.GetBlobSidecars(ctx, eth.L1BlockRef{Time: refTimestamp}, []eth.IndexedBlobHash{indexedBlobHash})
+		if err != nil || len(sidecars) != 1 {
+			return fmt.Errorf("failed to fetch blob sidecars for %s %d: %w", blobVersionHash, blobHashIndex, err)
+		}
+		verified, err = verifyBlob(blobVersionHash, sidecars[0])
+		if err != nil {
+			return fmt.Errorf("invalid blob sidecar for %s %d: %w", blobVersionHash, blobHashIndex, err)
+		}
+		p.blobs.Add(blobVersionHash, verified)
+	}
+
+	// Put the preimage for the versioned hash into the kv store
+	if err := p.kvStore.Put(preimage.Sha256Key(blobVersionHash).PreimageKey(), verified.commitment[:]); err != nil {
+		return err
+	}
+
+	// Put all of the blob's field elements into the kv store. There should be 4096. The preimage oracle key for
+	// each field element is the keccak256 hash of `abi.encodePacked(sidecar.KZGCommitment, uint256(i))`
+	blobKey := make([]byte, 80)
+	copy(blobKey[:48], verified.commitment[:])
+	for i := 0; i < params.BlobTxFieldElementsPerBlob; i++ {
+		binary.BigEndian.PutUint64(blobKey[72:], uint64(i))
+		blobKeyHash := crypto.Keccak256Hash(blobKey)
+		if err := p.kvStore.Put(preimage.Keccak256Key(blobKeyHash).PreimageKey(), blobKey); err != nil {
+			return err
+		}
+		if err := p.kvStore.Put(preimage.BlobKey(blobKeyHash).PreimageKey(), verified.blob[i<<5:(i+1)<<5]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// verifyBlob checks that the commitment of the sidecar matches the versioned hash, and that the commitment
+// computed from the blob matches the commitment of the sidecar.
+// The blob is verified without its KZG proof, so it doesn't rely on the beacon node serving proofs.
+func verifyBlob(versionedHash common.Hash, sidecar *eth.BlobSidecar) (*verifiedBlob, error) {
+	if hash := eth.KZGToVersionedHash(kzg4844.Commitment(sidecar.KZGCommitment)); hash != versionedHash {
+		return nil, fmt.Errorf("commitment has versioned hash %s", hash)
+	}
+	commitment, err := sidecar.Blob.ComputeKZGCommitment()
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute blob commitment: %w", err)
+	}
+	if eth.Bytes48(commitment) != sidecar.KZGCommitment {
+		return nil, fmt.Errorf("blob does not match commitment %s", sidecar.KZGCommitment)
+	}
+	return &verifiedBlob{commitment: sidecar.KZGCommitment, blob: &sidecar.Blob}, nil
+}
+
 func (p *Prefetcher) storeReceipts(receipts types.Receipts) error {
 	opaqueReceipts, err := eth.EncodeReceipts(receipts)
 	if err != nil {
@@ -224,6 +224,65 @@ func TestFetchL1Blob(t *testing.T) {
 			require.Equal(t, fieldElemKey, actual)
 		}
 	})
+
+	blobHint := func(blobHash eth.IndexedBlobHash) string {
+		meta := make([]byte, 16)
+		binary.BigEndian.PutUint64(meta[0:8], blobHash.Index)
+		binary.BigEndian.PutUint64(meta[8:16], l1Ref.Time)
+		return l1.BlobHint(append(blobHash.Hash[:], meta...)).Hint()
+	}
+
+	t.Run("Cached", func(t *testing.T) {
+		prefetcher, _, blobFetcher, _, kv := createPrefetcher(t)
+		blobFetcher.ExpectOnGetBlobSidecars(
+			context.Background(),
+			l1Ref,
+			[]eth.IndexedBlobHash{blobHash},
+			(eth.Bytes48)(commitment),
+			[]*eth.Blob{&blob},
+			nil,
+		)
+		defer blobFetcher.AssertExpectations(t)
+		require.NoError(t, prefetcher.prefetch(context.Background(), blobHint(blobHash)))
+
+		// Fetching the blob again, even at a different index, uses the verified blob without fetching it.
+		require.NoError(t, kv.(*kvstore.MemKV).Delete(preimage.Sha256Key(versionedHash).PreimageKey()))
+		require.NoError(t, prefetcher.prefetch(context.Background(), blobHint(eth.IndexedBlobHash{Hash: versionedHash, Index: 1})))
+		oracle := l1.NewPreimageOracle(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
+		require.EqualValues(t, blob[:], oracle.GetBlob(l1Ref, blobHash)[:])
+	})
+
+	t.Run("InvalidCommitment", func(t *testing.T) {
+		prefetcher, _, blobFetcher, _, _ := createPrefetcher(t)
+		otherBlob := GetRandBlob(t, 0xbaa)
+		blobFetcher.ExpectOnGetBlobSidecars(
+			context.Background(),
+			l1Ref,
+			[]eth.IndexedBlobHash{blobHash},
+			(eth.Bytes48)(commitment),
+			[]*eth.Blob{&otherBlob},
+			nil,
+		)
+		defer blobFetcher.AssertExpectations(t)
+		err := prefetcher.prefetch(context.Background(), blobHint(blobHash))
+		require.ErrorContains(t, err, "blob does not match commitment")
+	})
+
+	t.Run("WrongVersionedHash", func(t *testing.T) {
+		prefetcher, _, blobFetcher, _, _ := createPrefetcher(t)
+		otherHash := eth.IndexedBlobHash{Hash: common.Hash{0x01, 0xaa}, Index: blobHash.Index}
+		blobFetcher.ExpectOnGetBlobSidecars(
+			context.Background(),
+			l1Ref,
+			[]eth.IndexedBlobHash{otherHash},
+			(eth.Bytes48)(commitment),
+			[]*eth.Blob{&blob},
+			nil,
+		)
+		defer blobFetcher.AssertExpectations(t)
+		err := prefetcher.prefetch(context.Background(), blobHint(otherHash))
+		require.ErrorContains(t, err, "commitment has versioned hash")
+	})
 }
 
 func TestFetchPrecompileResult(t *testing.T) {
@@ -435,6 +494,22 @@ func TestFetchL2Block(t *testing.T) {
 	})
 }
 
+func TestFetchL2Receipts(t *testing.T) {
+	rng := rand.New(rand.NewSource(123))
+	block, receipts := testutils.RandomBlock(rng, 10)
+	hash := block.Hash()
+
+	prefetcher, _, _, l2Cl, _ := createPrefetcher(t)
+	l2Cl.ExpectInfoAndTxsByHash(hash, eth.BlockToInfo(block), block.Transactions(), nil)
+	l2Cl.ExpectFetchReceipts(hash, eth.BlockToInfo(block), receipts, nil)
+	defer l2Cl.MockL2Client.AssertExpectations(t)
+
+	oracle := l2.NewPreimageOracle(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
+	result, actualReceipts := oracle.ReceiptsByBlockHash(hash)
+	require.EqualValues(t, hash, result.Hash())
+	assertReceiptsEqual(t, receipts, actualReceipts)
+}
+
 func TestFetchL2Transactions(t *testing.T) {
 	rng := rand.New(rand.NewSource(123))
 	block, rcpts := testutils.RandomBlock(rng, 10)
@@ -0,0 +1,212 @@
+package host
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-program/client/driver"
+	"github.com/ethereum-optimism/optimism/op-program/host/config"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// ProgressRecord is a record of the progress of the client program, written to the progress output.
+type ProgressRecord struct {
+	Time time.Time `json:"time"`
+	// ElapsedSeconds is the time since the pre-image server started.
+	ElapsedSeconds float64 `json:"elapsedSeconds"`
+	// Preimages is the number of pre-images served to the client program.
+	Preimages uint64 `json:"preimages"`
+	// Chains is the derivation progress of each chain, ordered by chain ID. Empty until the client program reports progress.
+	Chains []ChainProgress `json:"chains"`
+}
+
+// ChainProgress is the derivation progress of the client program on a single chain.
+type ChainProgress struct {
+	ChainID  uint64      `json:"chainID"`
+	SafeHead eth.BlockID `json:"safeHead"`
+	L1Origin eth.BlockID `json:"l1Origin"`
+	// BlocksDerived is the number of L2 blocks derived since the first block the client program reported.
+	BlocksDerived uint64 `json:"blocksDerived"`
+}
+
+type chainProgress struct {
+	start    uint64
+	progress driver.Progress
+}
+
+// progressTracker handles the progress hints of the client program, and periodically reports its progress.
+// Progress hints are consumed, rather than forwarded to the prefetcher, as no pre-images are fetched for them.
+type progressTracker struct {
+	logger      log.Logger
+	interval    time.Duration
+	output      string
+	checkpoints *checkpointer
+
+	mu        sync.Mutex
+	startTime time.Time
+	preimages uint64
+	chains    map[uint64]*chainProgress
+
+	stopReports chan struct{}
+	reportsDone chan struct{}
+}
+
+// newProgressTracker creates a tracker reporting progress as configured by cfg.
+// Checkpoints are saved with checkpoints, if not nil.
+func newProgressTracker(logger log.Logger, cfg *config.Config, checkpoints *checkpointer) *progressTracker {
+	return &progressTracker{
+		logger:      logger,
+		interval:    cfg.ProgressInterval,
+		output:      cfg.ProgressOutput,
+		checkpoints: checkpoints,
+		startTime:   time.Now(),
+		chains:      make(map[uint64]*chainProgress),
+	}
+}
+
+// wrap counts the pre-images served by getter.
+func (p *progressTracker) wrap(getter preimage.PreimageGetter) preimage.PreimageGetter {
+	return func(key [32]byte) ([]byte, error) {
+		value, err := getter(key)
+		if err == nil {
+			p.mu.Lock()
+			p.preimages++
+			p.mu.Unlock()
+		}
+		return value, err
+	}
+}
+
+// handleHints handles progress hints, and forwards all other hints to hinter.
+func (p *progressTracker) handleHints(hinter preimage.HintHandler) preimage.HintHandler {
+	return func(hint string) error {
+		progress, ok, err := driver.ParseProgressHint(hint)
+		if !ok {
+			return hinter(hint)
+		}
+		if err != nil {
+			// Progress is informational only, so must not fail the verification.
+			p.logger.Warn("Ignoring invalid progress hint", "err", err)
+			return nil
+		}
+		p.update(progress)
+		return nil
+	}
+}
+
+func (p *progressTracker) update(progress *driver.Progress) {
+	p.mu.Lock()
+	chain, ok := p.chains[progress.ChainID]
+	if !ok {
+		chain = &chainProgress{start: progress.SafeHead.Number}
+		p.chains[progress.ChainID] = chain
+	}
+	chain.progress = *progress
+	p.mu.Unlock()
+
+	if p.checkpoints == nil {
+		return
+	}
+	if saved, err := p.checkpoints.save(progress); err != nil {
+		p.logger.Warn("Failed to save checkpoint", "head", progress.SafeHead, "err", err)
+	} else if saved {
+		p.logger.Info("Saved checkpoint", "head", progress.SafeHead.ID(), "path", p.checkpoints.path)
+	}
+}
+
+// record returns the current progress.
+func (p *progressTracker) record() *ProgressRecord {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := time.Now()
+	record := &ProgressRecord{
+		Time:           now,
+		ElapsedSeconds: now.Sub(p.startTime).Seconds(),
+		Preimages:      p.preimages,
+		Chains:         make([]ChainProgress, 0, len(p.chains)),
+	}
+	for chainID, chain := range p.chains {
+		record.Chains = append(record.Chains, ChainProgress{
+			ChainID:       chainID,
+			SafeHead:      chain.progress.SafeHead.ID(),
+			L1Origin:      chain.progress.SafeHead.L1Origin,
+			BlocksDerived: chain.progress.SafeHead.Number - chain.start,
+		})
+	}
+	slices.SortFunc(record.Chains, func(a, b ChainProgress) int { return cmp.Compare(a.ChainID, b.ChainID) })
+	return record
+}
+
+// report logs the current progress, and writes it to the progress output if configured.
+func (p *progressTracker) report() {
+	record := p.record()
+	attrs := []any{"elapsed", time.Duration(record.ElapsedSeconds * float64(time.Second)).Round(time.Second), "preimages", record.Preimages}
+	for _, chain := range record.Chains {
+		attrs = append(attrs, "chain", chain.ChainID, "safeHead", chain.SafeHead, "l1Origin", chain.L1Origin, "blocksDerived", chain.BlocksDerived)
+	}
+	p.logger.Info("Client program progress", attrs...)
+	if err := p.write(record); err != nil {
+		p.logger.Warn("Failed to write progress", "path", p.output, "err", err)
+	}
+}
+
+// write appends the record to the progress output as a line of JSON.
+func (p *progressTracker) write(record *ProgressRecord) error {
+	if p.output == "" {
+		return nil
+	}
+	var out io.Writer = os.Stdout
+	if p.output != "-" {
+		f, err := os.OpenFile(p.output, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+		if err != nil {
+			return fmt.Errorf("failed to open progress output: %w", err)
+		}
+		defer f.Close()
+		out = f
+	}
+	if err := json.NewEncoder(out).Encode(record); err != nil {
+		return fmt.Errorf("failed to write progress record: %w", err)
+	}
+	return nil
+}
+
+// start reports progress every interval until stop is called. Progress is not reported if the interval is 0.
+func (p *progressTracker) start() {
+	if p.interval == 0 {
+		return
+	}
+	p.stopReports = make(chan struct{})
+	p.reportsDone = make(chan struct{})
+	go func() {
+		defer close(p.reportsDone)
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				p.report()
+			case <-p.stopReports:
+				return
+			}
+		}
+	}()
+}
+
+// stop stops the periodic reports and reports the final progress.
+func (p *progressTracker) stop() {
+	if p.stopReports == nil {
+		return
+	}
+	close(p.stopReports)
+	<-p.reportsDone
+	p.stopReports = nil
+	p.report()
+}
@@ -0,0 +1,130 @@
+package host
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-program/client/driver"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgressTracker_Hints(t *testing.T) {
+	cfg := cacheTestConfig(t)
+	tracker := newProgressTracker(testlog.Logger(t, log.LevelInfo), cfg, nil)
+	var forwarded []string
+	hinter := tracker.handleHints(func(hint string) error {
+		forwarded = append(forwarded, hint)
+		return nil
+	})
+
+	require.NoError(t, hinter("l2-block-header 0x1234"))
+	require.NoError(t, hinter(driver.ProgressHint{ChainID: 10, SafeHead: eth.L2BlockRef{Number: 100}}.Hint()))
+	require.NoError(t, hinter(driver.HintProgress+" 0xnothex"), "invalid progress must not fail the verification")
+	require.Equal(t, []string{"l2-block-header 0x1234"}, forwarded, "progress hints must not be forwarded")
+}
+
+func TestProgressTracker_Record(t *testing.T) {
+	cfg := cacheTestConfig(t)
+	tracker := newProgressTracker(testlog.Logger(t, log.LevelInfo), cfg, nil)
+	hinter := tracker.handleHints(func(hint string) error { return nil })
+	getter := tracker.wrap(func(key [32]byte) ([]byte, error) {
+		if key == ([32]byte{}) {
+			return nil, errors.New("not found")
+		}
+		return []byte{1}, nil
+	})
+	for i := 1; i <= 3; i++ {
+		_, err := getter([32]byte{byte(i)})
+		require.NoError(t, err)
+	}
+	_, err := getter([32]byte{})
+	require.Error(t, err)
+
+	progress := func(chainID uint64, num uint64) {
+		l1Origin := eth.BlockID{Hash: common.Hash{byte(num)}, Number: num / 10}
+		require.NoError(t, hinter(driver.ProgressHint{ChainID: chainID, SafeHead: eth.L2BlockRef{Hash: common.Hash{byte(num)}, Number: num, L1Origin: l1Origin}}.Hint()))
+	}
+	progress(20, 50)
+	progress(10, 100)
+	progress(10, 120)
+
+	record := tracker.record()
+	require.EqualValues(t, 3, record.Preimages, "failed requests must not be counted")
+	require.Equal(t, []ChainProgress{
+		{ChainID: 10, SafeHead: eth.BlockID{Hash: common.Hash{120}, Number: 120}, L1Origin: eth.BlockID{Hash: common.Hash{120}, Number: 12}, BlocksDerived: 20},
+		{ChainID: 20, SafeHead: eth.BlockID{Hash: common.Hash{50}, Number: 50}, L1Origin: eth.BlockID{Hash: common.Hash{50}, Number: 5}, BlocksDerived: 0},
+	}, record.Chains)
+}
+
+func TestProgressTracker_Output(t *testing.T) {
+	cfg := cacheTestConfig(t)
+	cfg.ProgressOutput = filepath.Join(t.TempDir(), "progress.jsonl")
+	tracker := newProgressTracker(testlog.Logger(t, log.LevelInfo), cfg, nil)
+	hinter := tracker.handleHints(func(hint string) error { return nil })
+	require.NoError(t, hinter(driver.ProgressHint{ChainID: 10, SafeHead: eth.L2BlockRef{Number: 100}}.Hint()))
+	tracker.report()
+	require.NoError(t, hinter(driver.ProgressHint{ChainID: 10, SafeHead: eth.L2BlockRef{Number: 110}}.Hint()))
+	tracker.report()
+
+	f, err := os.Open(cfg.ProgressOutput)
+	require.NoError(t, err)
+	defer f.Close()
+	var records []ProgressRecord
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var record ProgressRecord
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		records = append(records, record)
+	}
+	require.NoError(t, scanner.Err())
+	require.Len(t, records, 2)
+	require.EqualValues(t, 0, records[0].Chains[0].BlocksDerived)
+	require.EqualValues(t, 10, records[1].Chains[0].BlocksDerived)
+}
+
+func TestProgressTracker_Checkpoints(t *testing.T) {
+	cfg := cacheTestConfig(t)
+	cfg.CheckpointFile = filepath.Join(t.TempDir(), "checkpoint.json")
+	checkpoints, err := newCheckpointer(cfg)
+	require.NoError(t, err)
+	tracker := newProgressTracker(testlog.Logger(t, log.LevelInfo), cfg, checkpoints)
+	hinter := tracker.handleHints(func(hint string) error { return nil })
+
+	root := eth.Bytes32{0xcc}
+	require.NoError(t, hinter(driver.ProgressHint{ChainID: checkpoints.chainID, SafeHead: eth.L2BlockRef{Number: 900}, OutputRoot: &root}.Hint()))
+	checkpoint, err := checkpoints.load()
+	require.NoError(t, err)
+	require.NotNil(t, checkpoint)
+	require.EqualValues(t, 900, checkpoint.L2HeadNumber)
+}
+
+func TestProgressTracker_StartStop(t *testing.T) {
+	cfg := cacheTestConfig(t)
+	cfg.ProgressOutput = filepath.Join(t.TempDir(), "progress.jsonl")
+
+	cfg.ProgressInterval = 0
+	disabled := newProgressTracker(testlog.Logger(t, log.LevelInfo), cfg, nil)
+	disabled.start()
+	disabled.stop()
+	_, err := os.Stat(cfg.ProgressOutput)
+	require.ErrorIs(t, err, os.ErrNotExist, "progress must not be reported when disabled")
+
+	cfg.ProgressInterval = time.Hour
+	tracker := newProgressTracker(testlog.Logger(t, log.LevelInfo), cfg, nil)
+	tracker.start()
+	tracker.stop()
+	tracker.stop()
+	data, err := os.ReadFile(cfg.ProgressOutput)
+	require.NoError(t, err)
+	require.Equal(t, 1, bytes.Count(data, []byte("\n")), "final progress must be reported once when stopped")
+}
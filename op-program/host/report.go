@@ -0,0 +1,159 @@
+package host
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"sync"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-program/client/claim"
+	"github.com/ethereum-optimism/optimism/op-program/host/config"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+const (
+	ResultValid   = "valid"
+	ResultInvalid = "invalid"
+	ResultError   = "error"
+)
+
+// invalidClaimExitCode is the exit code of a client program that found the claim to be invalid.
+const invalidClaimExitCode = 1
+
+// Report is the machine-readable record of the verification of a claim.
+type Report struct {
+	// Claim is the claimed L2 output root, or super root in interop mode.
+	Claim common.Hash `json:"claim"`
+	// ClaimBlockNumber is the L2 block number of the claim. Not set in interop mode.
+	ClaimBlockNumber uint64 `json:"claimBlockNumber,omitempty"`
+	// ClaimTimestamp is the timestamp of the claimed super root. Only set in interop mode.
+	ClaimTimestamp uint64 `json:"claimTimestamp,omitempty"`
+	// L1Head is the L1 block that limits the L1 data available to derive the claim from.
+	L1Head common.Hash `json:"l1Head"`
+	// AgreedOutputRoot is the agreed L2 output root, or super root in interop mode, that derivation starts from.
+	AgreedOutputRoot common.Hash `json:"agreedOutputRoot"`
+	// AgreedL2Head is the L2 block of the agreed output root. Not set in interop mode.
+	AgreedL2Head *common.Hash `json:"agreedL2Head,omitempty"`
+	// AgreedL2BlockNumber is the number of AgreedL2Head, if the client program read it.
+	AgreedL2BlockNumber *uint64 `json:"agreedL2BlockNumber,omitempty"`
+	// BlocksDerived is the number of L2 blocks derived from the agreed block to the claimed block.
+	// Only set if the claim is valid, as the client program stops early if it runs out of L1 data.
+	BlocksDerived *uint64 `json:"blocksDerived,omitempty"`
+	// ResumedFrom is the number of the checkpointed L2 block that derivation resumed from, if resumed from a checkpoint.
+	ResumedFrom *uint64 `json:"resumedFrom,omitempty"`
+	// Preimages is the number of distinct pre-images served to the client program, by key type.
+	Preimages map[string]int `json:"preimages"`
+	// Result is ResultValid, ResultInvalid or ResultError.
+	Result string `json:"result"`
+	// Reason describes why the claim is invalid, or why the verification failed.
+	Reason string `json:"reason,omitempty"`
+	// Cached is true if the result was loaded from the result cache, rather than by running the program.
+	Cached bool `json:"cached,omitempty"`
+}
+
+// reportRecorder records the pre-images served to the client program, to report on the verification.
+type reportRecorder struct {
+	l2Head common.Hash
+
+	mu             sync.Mutex
+	keys           map[[32]byte]struct{}
+	agreedL2Number *uint64
+}
+
+func newReportRecorder(cfg *config.Config) *reportRecorder {
+	r := &reportRecorder{keys: make(map[[32]byte]struct{})}
+	if !cfg.InteropEnabled() {
+		r.l2Head = cfg.L2Head
+	}
+	return r
+}
+
+func (r *reportRecorder) wrap(getter preimage.PreimageGetter) preimage.PreimageGetter {
+	l2HeadKey := preimage.Keccak256Key(r.l2Head).PreimageKey()
+	return func(key [32]byte) ([]byte, error) {
+		value, err := getter(key)
+		if err != nil {
+			return nil, err
+		}
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.keys[key] = struct{}{}
+		if r.l2Head != (common.Hash{}) && key == l2HeadKey && r.agreedL2Number == nil {
+			var header types.Header
+			if err := rlp.DecodeBytes(value, &header); err == nil {
+				num := header.Number.Uint64()
+				r.agreedL2Number = &num
+			}
+		}
+		return value, nil
+	}
+}
+
+// report creates the report of the verification of the claim configured by cfg, which resulted in programErr.
+func (r *reportRecorder) report(cfg *config.Config, programErr error) *Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := &Report{
+		Claim:            cfg.L2Claim,
+		L1Head:           cfg.L1Head,
+		AgreedOutputRoot: cfg.L2OutputRoot,
+		Preimages:        make(map[string]int),
+	}
+	if cfg.InteropEnabled() {
+		out.ClaimTimestamp = cfg.L2ClaimTimestamp
+	} else {
+		l2Head := cfg.L2Head
+		out.AgreedL2Head = &l2Head
+		out.ClaimBlockNumber = cfg.L2ClaimBlockNumber
+	}
+	for key := range r.keys {
+		out.Preimages[keyTypeName(preimage.KeyType(key[0]))]++
+	}
+	out.Result, out.Reason = programResult(programErr)
+	if r.agreedL2Number != nil {
+		num := *r.agreedL2Number
+		out.AgreedL2BlockNumber = &num
+		if out.Result == ResultValid && cfg.L2ClaimBlockNumber >= *r.agreedL2Number {
+			derived := cfg.L2ClaimBlockNumber - *r.agreedL2Number
+			out.BlocksDerived = &derived
+		}
+	}
+	return out
+}
+
+// programResult classifies the result of the fault proof program.
+func programResult(err error) (result string, reason string) {
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		return ResultValid, ""
+	case errors.Is(err, claim.ErrClaimNotValid):
+		return ResultInvalid, err.Error()
+	case errors.As(err, &exitErr) && exitErr.ExitCode() == invalidClaimExitCode:
+		return ResultInvalid, "client program found the claim to be invalid"
+	default:
+		return ResultError, err.Error()
+	}
+}
+
+func keyTypeName(t preimage.KeyType) string {
+	switch t {
+	case preimage.LocalKeyType:
+		return "local"
+	case preimage.Keccak256KeyType:
+		return "keccak256"
+	case preimage.GlobalGenericKeyType:
+		return "global_generic"
+	case preimage.Sha256KeyType:
+		return "sha256"
+	case preimage.BlobKeyType:
+		return "blob"
+	case preimage.PrecompileKeyType:
+		return "precompile"
+	default:
+		return fmt.Sprintf("unknown_%d", t)
+	}
+}
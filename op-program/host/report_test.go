@@ -0,0 +1,88 @@
+package host
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"os/exec"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-program/chainconfig"
+	"github.com/ethereum-optimism/optimism/op-program/client/claim"
+	"github.com/ethereum-optimism/optimism/op-program/host/config"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportRecorder(t *testing.T) {
+	l2Header := &types.Header{Number: big.NewInt(990), Difficulty: big.NewInt(0)}
+	l2HeaderRLP, err := rlp.EncodeToBytes(l2Header)
+	require.NoError(t, err)
+	cfg := config.NewConfig(chaincfg.Sepolia, chainconfig.OPSepoliaChainConfig, common.Hash{0x11}, l2Header.Hash(), common.Hash{0x33}, common.Hash{0x44}, 1000)
+
+	preimages := map[[32]byte][]byte{
+		preimage.LocalIndexKey(1).PreimageKey():                {0x01},
+		preimage.Keccak256Key(l2Header.Hash()).PreimageKey():   l2HeaderRLP,
+		preimage.Keccak256Key(common.Hash{0xaa}).PreimageKey(): {0x02},
+		preimage.BlobKey(common.Hash{0xbb}).PreimageKey():      {0x03},
+	}
+	recorder := newReportRecorder(cfg)
+	getter := recorder.wrap(func(key [32]byte) ([]byte, error) {
+		if value, ok := preimages[key]; ok {
+			return value, nil
+		}
+		return nil, errors.New("not found")
+	})
+	for key := range preimages {
+		_, err := getter(key)
+		require.NoError(t, err)
+		_, err = getter(key) // pre-images requested again must be counted once
+		require.NoError(t, err)
+	}
+	_, err = getter(preimage.Sha256Key(common.Hash{0xcc}).PreimageKey())
+	require.Error(t, err)
+
+	report := recorder.report(cfg, nil)
+	agreedNumber, derived := uint64(990), uint64(10)
+	l2Head := l2Header.Hash()
+	require.Equal(t, &Report{
+		Claim:               common.Hash{0x44},
+		ClaimBlockNumber:    1000,
+		L1Head:              common.Hash{0x11},
+		AgreedOutputRoot:    common.Hash{0x33},
+		AgreedL2Head:        &l2Head,
+		AgreedL2BlockNumber: &agreedNumber,
+		BlocksDerived:       &derived,
+		Preimages:           map[string]int{"local": 1, "keccak256": 2, "blob": 1},
+		Result:              ResultValid,
+	}, report)
+
+	invalid := recorder.report(cfg, fmt.Errorf("%w: claim: %v actual: %v", claim.ErrClaimNotValid, common.Hash{0x44}, common.Hash{0x55}))
+	require.Equal(t, ResultInvalid, invalid.Result)
+	require.Contains(t, invalid.Reason, "invalid claim")
+	require.Nil(t, invalid.BlocksDerived, "blocks derived is unknown for invalid claims")
+	require.Equal(t, &agreedNumber, invalid.AgreedL2BlockNumber)
+}
+
+func TestProgramResult(t *testing.T) {
+	result, reason := programResult(nil)
+	require.Equal(t, ResultValid, result)
+	require.Empty(t, reason)
+
+	result, _ = programResult(fmt.Errorf("wrapped: %w", claim.ErrClaimNotValid))
+	require.Equal(t, ResultInvalid, result)
+
+	exitErr := exec.Command("sh", "-c", "exit 1").Run()
+	require.Error(t, exitErr)
+	result, _ = programResult(fmt.Errorf("failed to wait for child program: %w", exitErr))
+	require.Equal(t, ResultInvalid, result, "exit code 1 of the client program is an invalid claim")
+
+	exitErr = exec.Command("sh", "-c", "exit 2").Run()
+	result, reason = programResult(fmt.Errorf("failed to wait for child program: %w", exitErr))
+	require.Equal(t, ResultError, result)
+	require.Contains(t, reason, "exit status 2")
+}
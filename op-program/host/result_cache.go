@@ -0,0 +1,135 @@
+package host
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-program/host/config"
+	"github.com/ethereum-optimism/optimism/op-program/host/version"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// resultCacheInputs are all the inputs that determine the result of the verification of a claim.
+// The sources of the L1 and L2 data are not included, as the data is verified against the L1 head and agreed output.
+type resultCacheInputs struct {
+	Version            string                `json:"version"`
+	ExecDigest         *common.Hash          `json:"execDigest,omitempty"`
+	L1Head             common.Hash           `json:"l1Head"`
+	L2Head             common.Hash           `json:"l2Head"`
+	L2OutputRoot       common.Hash           `json:"l2OutputRoot"`
+	AgreedPrestate     hexutil.Bytes         `json:"agreedPrestate,omitempty"`
+	L2Claim            common.Hash           `json:"l2Claim"`
+	L2ClaimBlockNumber uint64                `json:"l2ClaimBlockNumber"`
+	L2ClaimTimestamp   uint64                `json:"l2ClaimTimestamp"`
+	Rollups            []*rollup.Config      `json:"rollups"`
+	L2ChainConfigs     []*params.ChainConfig `json:"l2ChainConfigs"`
+}
+
+// resultCacheKey returns the key of the result of verifying the claim configured by cfg.
+// If the client program runs in a separate process, the key includes the digest of its executable,
+// so results are not reused after the client program changes.
+func resultCacheKey(cfg *config.Config) (common.Hash, error) {
+	inputs := resultCacheInputs{
+		Version:            version.Version,
+		L1Head:             cfg.L1Head,
+		L2Head:             cfg.L2Head,
+		L2OutputRoot:       cfg.L2OutputRoot,
+		AgreedPrestate:     cfg.AgreedPrestate,
+		L2Claim:            cfg.L2Claim,
+		L2ClaimBlockNumber: cfg.L2ClaimBlockNumber,
+		L2ClaimTimestamp:   cfg.L2ClaimTimestamp,
+	}
+	if cfg.InteropEnabled() {
+		for _, chain := range cfg.InteropChains {
+			inputs.Rollups = append(inputs.Rollups, chain.Rollup)
+			inputs.L2ChainConfigs = append(inputs.L2ChainConfigs, chain.L2ChainConfig)
+		}
+	} else {
+		inputs.Rollups = []*rollup.Config{cfg.Rollup}
+		inputs.L2ChainConfigs = []*params.ChainConfig{cfg.L2ChainConfig}
+	}
+	if cfg.ExecCmd != "" {
+		digest, err := execDigest(cfg.ExecCmd)
+		if err != nil {
+			return common.Hash{}, err
+		}
+		inputs.ExecDigest = &digest
+	}
+	data, err := json.Marshal(inputs)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to encode verification inputs: %w", err)
+	}
+	return crypto.Keccak256Hash(data), nil
+}
+
+func execDigest(cmd string) (common.Hash, error) {
+	path, err := exec.LookPath(cmd)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to find client program: %w", err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to open client program: %w", err)
+	}
+	defer f.Close()
+	hasher := crypto.NewKeccakState()
+	if _, err := io.Copy(hasher, f); err != nil {
+		return common.Hash{}, fmt.Errorf("failed to read client program: %w", err)
+	}
+	var digest common.Hash
+	_, _ = hasher.Read(digest[:])
+	return digest, nil
+}
+
+// resultCache stores the reports of completed verifications in a directory, one file per verification key.
+// Only verifications that found the claim valid or invalid are stored, failures to verify are always retried.
+type resultCache struct {
+	dir string
+}
+
+func newResultCache(dir string) *resultCache {
+	return &resultCache{dir: dir}
+}
+
+func (c *resultCache) path(key common.Hash) string {
+	return filepath.Join(c.dir, key.Hex()+".json")
+}
+
+// get returns the cached report of the verification with the given key, or nil if the verification is not cached.
+func (c *resultCache) get(key common.Hash) (*Report, error) {
+	report, err := jsonutil.LoadJSON[Report](c.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to load cached result: %w", err)
+	}
+	if report.Result != ResultValid && report.Result != ResultInvalid {
+		return nil, fmt.Errorf("cached result has unexpected result %q", report.Result)
+	}
+	return report, nil
+}
+
+// put stores the report of the verification with the given key, unless the verification failed.
+func (c *resultCache) put(key common.Hash, report *Report) error {
+	if report.Result != ResultValid && report.Result != ResultInvalid {
+		return nil
+	}
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create result cache dir: %w", err)
+	}
+	if err := jsonutil.WriteJSON(c.path(key), report, 0o644); err != nil {
+		return fmt.Errorf("failed to write cached result: %w", err)
+	}
+	return nil
+}
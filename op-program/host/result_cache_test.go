@@ -0,0 +1,123 @@
+package host
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
+	"github.com/ethereum-optimism/optimism/op-program/chainconfig"
+	"github.com/ethereum-optimism/optimism/op-program/client/claim"
+	"github.com/ethereum-optimism/optimism/op-program/host/config"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func cacheTestConfig(t *testing.T) *config.Config {
+	cfg := config.NewConfig(chaincfg.Sepolia, chainconfig.OPSepoliaChainConfig, common.Hash{0x11}, common.Hash{0x22}, common.Hash{0x33}, common.Hash{0x44}, 1000)
+	cfg.DataDir = t.TempDir()
+	cfg.ResultCacheDir = t.TempDir()
+	return cfg
+}
+
+func TestResultCacheKey(t *testing.T) {
+	cfg := cacheTestConfig(t)
+	key, err := resultCacheKey(cfg)
+	require.NoError(t, err)
+
+	same := cacheTestConfig(t)
+	same.L1URL = "http://localhost:8545"
+	sameKey, err := resultCacheKey(same)
+	require.NoError(t, err)
+	require.Equal(t, key, sameKey, "the data sources must not change the key")
+
+	modifiers := map[string]func(cfg *config.Config){
+		"L1Head":             func(cfg *config.Config) { cfg.L1Head = common.Hash{0xaa} },
+		"L2Head":             func(cfg *config.Config) { cfg.L2Head = common.Hash{0xaa} },
+		"L2OutputRoot":       func(cfg *config.Config) { cfg.L2OutputRoot = common.Hash{0xaa} },
+		"L2Claim":            func(cfg *config.Config) { cfg.L2Claim = common.Hash{0xaa} },
+		"L2ClaimBlockNumber": func(cfg *config.Config) { cfg.L2ClaimBlockNumber++ },
+		"Rollup":             func(cfg *config.Config) { cfg.Rollup = chaincfg.Mainnet },
+		"L2ChainConfig":      func(cfg *config.Config) { cfg.L2ChainConfig = chainconfig.OPMainnetChainConfig },
+		"ExecCmd":            func(cfg *config.Config) { cfg.ExecCmd = "sh" },
+	}
+	for name, modify := range modifiers {
+		name, modify := name, modify
+		t.Run(name, func(t *testing.T) {
+			cfg := cacheTestConfig(t)
+			modify(cfg)
+			modifiedKey, err := resultCacheKey(cfg)
+			require.NoError(t, err)
+			require.NotEqual(t, key, modifiedKey)
+		})
+	}
+}
+
+func TestResultCacheKey_ExecDigest(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "client")
+	require.NoError(t, os.WriteFile(path, []byte("v1"), 0o755))
+	cfg := cacheTestConfig(t)
+	cfg.ExecCmd = path
+	key, err := resultCacheKey(cfg)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(path, []byte("v2"), 0o755))
+	updatedKey, err := resultCacheKey(cfg)
+	require.NoError(t, err)
+	require.NotEqual(t, key, updatedKey, "results must not be reused after the client program changes")
+
+	cfg.ExecCmd = filepath.Join(t.TempDir(), "missing")
+	_, err = resultCacheKey(cfg)
+	require.ErrorContains(t, err, "failed to find client program")
+}
+
+func TestResultCache(t *testing.T) {
+	cache := newResultCache(filepath.Join(t.TempDir(), "results"))
+	key := common.Hash{0x01}
+	report, err := cache.get(key)
+	require.NoError(t, err)
+	require.Nil(t, report)
+
+	require.NoError(t, cache.put(key, &Report{Result: ResultError, Reason: "timeout"}))
+	report, err = cache.get(key)
+	require.NoError(t, err)
+	require.Nil(t, report, "failures to verify must not be cached")
+
+	valid := &Report{Claim: common.Hash{0x44}, Result: ResultValid, Preimages: map[string]int{"local": 1}}
+	require.NoError(t, cache.put(key, valid))
+	report, err = cache.get(key)
+	require.NoError(t, err)
+	require.Equal(t, valid, report)
+
+	report, err = cache.get(common.Hash{0x02})
+	require.NoError(t, err)
+	require.Nil(t, report)
+}
+
+func TestMain_CachedResult(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	cfg := cacheTestConfig(t)
+	cfg.OutputReport = filepath.Join(t.TempDir(), "report.json")
+	key, err := resultCacheKey(cfg)
+	require.NoError(t, err)
+	cache := newResultCache(cfg.ResultCacheDir)
+
+	// No L1 or L2 sources are configured, so the claim can only be verified from the cache.
+	require.NoError(t, cache.put(key, &Report{Claim: cfg.L2Claim, Result: ResultInvalid, Reason: "invalid claim"}))
+	err = Main(logger, cfg)
+	require.ErrorIs(t, err, claim.ErrClaimNotValid)
+	report, err := jsonutil.LoadJSON[Report](cfg.OutputReport)
+	require.NoError(t, err)
+	require.Equal(t, ResultInvalid, report.Result)
+	require.True(t, report.Cached)
+
+	require.NoError(t, cache.put(key, &Report{Claim: cfg.L2Claim, Result: ResultValid}))
+	require.NoError(t, Main(logger, cfg))
+	report, err = jsonutil.LoadJSON[Report](cfg.OutputReport)
+	require.NoError(t, err)
+	require.Equal(t, ResultValid, report.Result)
+	require.True(t, report.Cached)
+}
@@ -18,6 +18,7 @@ const (
 	methodGameCount   = "gameCount"
 	methodGameAtIndex = "gameAtIndex"
 	methodInitBonds   = "initBonds"
+	methodGameImpls   = "gameImpls"
 	methodCreateGame  = "create"
 	methodVersion     = "version"
 
@@ -90,6 +91,18 @@ func (f *DisputeGameFactory) HasProposedSince(ctx context.Context, proposer comm
 	}
 }
 
+// GameImpl returns the implementation address registered for the given game type.
+// The zero address is returned if no implementation is registered and games of that type cannot be created.
+func (f *DisputeGameFactory) GameImpl(ctx context.Context, gameType uint32) (common.Address, error) {
+	cCtx, cancel := context.WithTimeout(ctx, f.networkTimeout)
+	defer cancel()
+	result, err := f.caller.SingleCall(cCtx, rpcblock.Latest, f.contract.Call(methodGameImpls, gameType))
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to fetch game impl for type %v: %w", gameType, err)
+	}
+	return result.GetAddress(0), nil
+}
+
 func (f *DisputeGameFactory) ProposalTx(ctx context.Context, gameType uint32, outputRoot common.Hash, l2BlockNum uint64) (txmgr.TxCandidate, error) {
 	cCtx, cancel := context.WithTimeout(ctx, f.networkTimeout)
 	defer cancel()
@@ -194,6 +194,21 @@ func TestProposalTx(t *testing.T) {
 	require.Truef(t, bond.Cmp(tx.Value) == 0, "Expected bond %v but was %v", bond, tx.Value)
 }
 
+func TestGameImpl(t *testing.T) {
+	stubRpc, factory := setupDisputeGameFactoryTest(t)
+	impl := common.Address{0xbb}
+	stubRpc.SetResponse(factoryAddr, methodGameImpls, rpcblock.Latest, []interface{}{uint32(1)}, []interface{}{impl})
+	stubRpc.SetResponse(factoryAddr, methodGameImpls, rpcblock.Latest, []interface{}{uint32(2)}, []interface{}{common.Address{}})
+
+	actual, err := factory.GameImpl(context.Background(), 1)
+	require.NoError(t, err)
+	require.Equal(t, impl, actual)
+
+	actual, err = factory.GameImpl(context.Background(), 2)
+	require.NoError(t, err)
+	require.Equal(t, common.Address{}, actual)
+}
+
 func withClaims(stubRpc *batchingTest.AbiBasedRpc, games ...gameMetadata) {
 	gameAbi := snapshots.LoadFaultDisputeGameABI()
 	stubRpc.SetResponse(factoryAddr, methodGameCount, rpcblock.Latest, nil, []interface{}{big.NewInt(int64(len(games)))})
@@ -0,0 +1,39 @@
+package contracts
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	"github.com/ethereum-optimism/optimism/packages/contracts-bedrock/snapshots"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const methodPaused = "paused"
+
+type SuperchainConfig struct {
+	caller         *batching.MultiCaller
+	contract       *batching.BoundContract
+	networkTimeout time.Duration
+}
+
+func NewSuperchainConfig(addr common.Address, caller *batching.MultiCaller, networkTimeout time.Duration) *SuperchainConfig {
+	return &SuperchainConfig{
+		caller:         caller,
+		contract:       batching.NewBoundContract(snapshots.LoadSuperchainConfigABI(), addr),
+		networkTimeout: networkTimeout,
+	}
+}
+
+// Paused returns true if the superchain is paused by the guardian.
+func (c *SuperchainConfig) Paused(ctx context.Context) (bool, error) {
+	cCtx, cancel := context.WithTimeout(ctx, c.networkTimeout)
+	defer cancel()
+	result, err := c.caller.SingleCall(cCtx, rpcblock.Latest, c.contract.Call(methodPaused))
+	if err != nil {
+		return false, fmt.Errorf("failed to get paused status: %w", err)
+	}
+	return result.GetBool(0), nil
+}
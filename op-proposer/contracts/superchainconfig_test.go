@@ -0,0 +1,29 @@
+package contracts
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	batchingTest "github.com/ethereum-optimism/optimism/op-service/sources/batching/test"
+	"github.com/ethereum-optimism/optimism/packages/contracts-bedrock/snapshots"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+var superchainConfigAddr = common.Address{0xee, 0xee}
+
+func TestSuperchainConfigPaused(t *testing.T) {
+	for _, paused := range []bool{true, false} {
+		stubRpc := batchingTest.NewAbiBasedRpc(t, superchainConfigAddr, snapshots.LoadSuperchainConfigABI())
+		caller := batching.NewMultiCaller(stubRpc, batching.DefaultBatchSize)
+		config := NewSuperchainConfig(superchainConfigAddr, caller, time.Minute)
+		stubRpc.SetResponse(superchainConfigAddr, methodPaused, rpcblock.Latest, nil, []interface{}{paused})
+
+		actual, err := config.Paused(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, paused, actual)
+	}
+}
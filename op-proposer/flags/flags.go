@@ -7,6 +7,7 @@ import (
 	"github.com/urfave/cli/v2"
 
 	opservice "github.com/ethereum-optimism/optimism/op-service"
+	opflags "github.com/ethereum-optimism/optimism/op-service/flags"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
@@ -66,6 +67,12 @@ var (
 		Value:   0,
 		EnvVars: prefixEnvVars("GAME_TYPE"),
 	}
+	DisputeGameTypesFlag = &cli.UintSliceFlag{
+		Name: "game-types",
+		Usage: "Prioritized, comma-separated list of dispute game types to create via the configured DisputeGameFactory. " +
+			"If proposing with a game type fails, the next one is used. Overrides game-type if set.",
+		EnvVars: prefixEnvVars("GAME_TYPES"),
+	}
 	ActiveSequencerCheckDurationFlag = &cli.DurationFlag{
 		Name:    "active-sequencer-check-duration",
 		Usage:   "The duration between checks to determine the active sequencer endpoint.",
@@ -79,6 +86,69 @@ var (
 		Value:   false,
 		EnvVars: prefixEnvVars("WAIT_NODE_SYNC"),
 	}
+	VerifyRollupRpcFlag = &cli.StringFlag{
+		Name: "verify-rollup-rpc",
+		Usage: "HTTP provider URL for a secondary rollup node. If set, output roots are only proposed " +
+			"if the secondary rollup node agrees with them.",
+		EnvVars: prefixEnvVars("VERIFY_ROLLUP_RPC"),
+	}
+	ProposalBatchMaxSizeFlag = &cli.Uint64Flag{
+		Name: "proposal-batch.max-size",
+		Usage: "Maximum number of output proposals to submit together. Enables proposal batching if greater than 1. " +
+			"Each proposal is sent in its own transaction from the proposer account.",
+		Value:   1,
+		EnvVars: prefixEnvVars("PROPOSAL_BATCH_MAX_SIZE"),
+	}
+	ProposalBatchMaxWaitFlag = &cli.DurationFlag{
+		Name: "proposal-batch.max-wait",
+		Usage: "Maximum duration to hold back a pending output proposal while the batch fills up. " +
+			"Must stay well below 256 L1 blocks, as L2OutputOracle proposals reference a recent L1 block hash.",
+		Value:   10 * time.Minute,
+		EnvVars: prefixEnvVars("PROPOSAL_BATCH_MAX_WAIT"),
+	}
+	SuperchainConfigAddressFlag = &cli.StringFlag{
+		Name: "superchain-config-address",
+		Usage: "Address of the SuperchainConfig contract. If set, no output proposals are made " +
+			"while the superchain is paused.",
+		EnvVars: prefixEnvVars("SUPERCHAIN_CONFIG_ADDRESS"),
+	}
+	DryRunFlag = &cli.BoolFlag{
+		Name: "dry-run",
+		Usage: "Compute output proposals and simulate the proposal transactions with eth_call, " +
+			"but log and record them instead of submitting them.",
+		EnvVars: prefixEnvVars("DRY_RUN"),
+	}
+	OutputArchiveEndpointFlag = &cli.StringFlag{
+		Name: "output-archive.endpoint",
+		Usage: "Endpoint of an S3 compatible object store to publish the output root proofs of proposed outputs to " +
+			"(e.g. storage.googleapis.com for GCS), so withdrawals can be proven without an archive node. Disabled if empty.",
+		EnvVars: prefixEnvVars("OUTPUT_ARCHIVE_ENDPOINT"),
+	}
+	OutputArchiveBucketFlag = &cli.StringFlag{
+		Name:    "output-archive.bucket",
+		Usage:   "Bucket of the output archive",
+		EnvVars: prefixEnvVars("OUTPUT_ARCHIVE_BUCKET"),
+	}
+	OutputArchivePrefixFlag = &cli.StringFlag{
+		Name:    "output-archive.prefix",
+		Usage:   "Prefix of the output object names in the output archive bucket. Outputs are named <prefix>/<l2 block number>.json",
+		EnvVars: prefixEnvVars("OUTPUT_ARCHIVE_PREFIX"),
+	}
+	OutputArchiveAccessKeyIDFlag = &cli.StringFlag{
+		Name:    "output-archive.access-key-id",
+		Usage:   "Access key ID of the output archive",
+		EnvVars: prefixEnvVars("OUTPUT_ARCHIVE_ACCESS_KEY_ID"),
+	}
+	OutputArchiveAccessKeySecretFlag = &cli.StringFlag{
+		Name:    "output-archive.access-key-secret",
+		Usage:   "Access key secret of the output archive",
+		EnvVars: prefixEnvVars("OUTPUT_ARCHIVE_ACCESS_KEY_SECRET"),
+	}
+	OutputArchiveInsecureFlag = &cli.BoolFlag{
+		Name:    "output-archive.insecure",
+		Usage:   "Connect to the output archive without TLS",
+		EnvVars: prefixEnvVars("OUTPUT_ARCHIVE_INSECURE"),
+	}
 	// Legacy Flags
 	L2OutputHDPathFlag = txmgr.L2OutputHDPathFlag
 )
@@ -96,8 +166,21 @@ var optionalFlags = []cli.Flag{
 	DisputeGameFactoryAddressFlag,
 	ProposalIntervalFlag,
 	DisputeGameTypeFlag,
+	DisputeGameTypesFlag,
 	ActiveSequencerCheckDurationFlag,
 	WaitNodeSyncFlag,
+	VerifyRollupRpcFlag,
+	ProposalBatchMaxSizeFlag,
+	ProposalBatchMaxWaitFlag,
+	SuperchainConfigAddressFlag,
+	DryRunFlag,
+	OutputArchiveEndpointFlag,
+	OutputArchiveBucketFlag,
+	OutputArchivePrefixFlag,
+	OutputArchiveAccessKeyIDFlag,
+	OutputArchiveAccessKeySecretFlag,
+	OutputArchiveInsecureFlag,
+	opflags.CLINetworkFlag(EnvVarPrefix, ""),
 }
 
 func init() {
@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"io"
+	"strconv"
 
 	"github.com/prometheus/client_golang/prometheus"
 
@@ -34,6 +35,10 @@ type Metricer interface {
 	StartBalanceMetrics(l log.Logger, client *ethclient.Client, account common.Address) io.Closer
 
 	RecordL2BlocksProposed(l2ref eth.L2BlockRef)
+	RecordL2BlocksDryRunProposed(l2ref eth.L2BlockRef)
+	RecordGameTypeAvailable(gameType uint32, available bool)
+	RecordOutputRootMismatch(mismatch bool)
+	RecordSuperchainPaused(paused bool)
 }
 
 type Metrics struct {
@@ -47,6 +52,12 @@ type Metrics struct {
 
 	info prometheus.GaugeVec
 	up   prometheus.Gauge
+
+	gameTypeAvailable prometheus.GaugeVec
+
+	outputRootMismatch prometheus.Gauge
+
+	superchainPaused prometheus.Gauge
 }
 
 var _ Metricer = (*Metrics)(nil)
@@ -81,6 +92,23 @@ func NewMetrics(procName string) *Metrics {
 			Name:      "up",
 			Help:      "1 if the op-proposer has finished starting up",
 		}),
+		gameTypeAvailable: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "game_type_available",
+			Help:      "1 if the last proposal attempt with the dispute game type succeeded, 0 if it failed",
+		}, []string{
+			"game_type",
+		}),
+		outputRootMismatch: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "output_root_mismatch",
+			Help:      "1 if the last output to propose mismatched the verification rollup node, 0 if it matched",
+		}),
+		superchainPaused: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "superchain_paused",
+			Help:      "1 if proposals are paused because the superchain is paused, or its pause status is unknown, 0 otherwise",
+		}),
 	}
 }
 
@@ -105,7 +133,8 @@ func (m *Metrics) RecordUp() {
 }
 
 const (
-	BlockProposed = "proposed"
+	BlockProposed       = "proposed"
+	BlockDryRunProposed = "dry_run_proposed"
 )
 
 // RecordL2BlocksProposed should be called when new L2 block is proposed
@@ -113,6 +142,33 @@ func (m *Metrics) RecordL2BlocksProposed(l2ref eth.L2BlockRef) {
 	m.RecordL2Ref(BlockProposed, l2ref)
 }
 
+// RecordL2BlocksDryRunProposed should be called when a new L2 block proposal is simulated in dry-run mode
+func (m *Metrics) RecordL2BlocksDryRunProposed(l2ref eth.L2BlockRef) {
+	m.RecordL2Ref(BlockDryRunProposed, l2ref)
+}
+
+// RecordGameTypeAvailable records whether the last proposal attempt with the given game type succeeded
+func (m *Metrics) RecordGameTypeAvailable(gameType uint32, available bool) {
+	m.gameTypeAvailable.WithLabelValues(strconv.FormatUint(uint64(gameType), 10)).Set(boolToFloat64(available))
+}
+
+// RecordOutputRootMismatch records whether the last verified output mismatched the verification rollup node
+func (m *Metrics) RecordOutputRootMismatch(mismatch bool) {
+	m.outputRootMismatch.Set(boolToFloat64(mismatch))
+}
+
+// RecordSuperchainPaused records whether proposals are paused because the superchain is paused
+func (m *Metrics) RecordSuperchainPaused(paused bool) {
+	m.superchainPaused.Set(boolToFloat64(paused))
+}
+
+func boolToFloat64(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
 func (m *Metrics) Document() []opmetrics.DocumentedMetric {
 	return m.factory.Document()
 }
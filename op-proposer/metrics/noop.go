@@ -23,7 +23,11 @@ var NoopMetrics Metricer = new(noopMetrics)
 func (*noopMetrics) RecordInfo(version string) {}
 func (*noopMetrics) RecordUp()                 {}
 
-func (*noopMetrics) RecordL2BlocksProposed(l2ref eth.L2BlockRef) {}
+func (*noopMetrics) RecordL2BlocksProposed(l2ref eth.L2BlockRef)       {}
+func (*noopMetrics) RecordL2BlocksDryRunProposed(l2ref eth.L2BlockRef) {}
+func (*noopMetrics) RecordGameTypeAvailable(uint32, bool)              {}
+func (*noopMetrics) RecordOutputRootMismatch(bool)                     {}
+func (*noopMetrics) RecordSuperchainPaused(bool)                       {}
 
 func (*noopMetrics) StartBalanceMetrics(log.Logger, *ethclient.Client, common.Address) io.Closer {
 	return nil
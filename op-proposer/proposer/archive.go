@@ -0,0 +1,193 @@
+package proposer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/objectstore"
+	"github.com/ethereum-optimism/optimism/op-service/retry"
+)
+
+const (
+	archiveMaxAttempts  = 5
+	archiveTimeout      = time.Minute
+	archiveCloseTimeout = 30 * time.Second
+	archiveQueueSize    = 64
+)
+
+// OutputRootProof is the preimage of an output root, as used to prove withdrawals against the output.
+type OutputRootProof struct {
+	Version                  eth.Bytes32 `json:"version"`
+	StateRoot                common.Hash `json:"stateRoot"`
+	MessagePasserStorageRoot common.Hash `json:"messagePasserStorageRoot"`
+	LatestBlockhash          common.Hash `json:"latestBlockhash"`
+}
+
+// ProposedOutput is the archived record of a proposed output, including the proof of its output root.
+type ProposedOutput struct {
+	OutputRoot      eth.Bytes32     `json:"outputRoot"`
+	OutputRootProof OutputRootProof `json:"outputRootProof"`
+	// BlockRef is the L2 block of the output.
+	BlockRef eth.L2BlockRef `json:"blockRef"`
+	// ProposalTxHash is the hash of the L1 transaction that proposed the output.
+	ProposalTxHash common.Hash `json:"proposalTxHash"`
+	// ProposalL1Block is the L1 block that included the proposal transaction.
+	ProposalL1Block eth.BlockID `json:"proposalL1Block"`
+}
+
+// OutputArchive publishes the proofs of proposed outputs to an object store, so withdrawals can be proven,
+// and outputs served, without access to an L2 archive node.
+// Each output is stored as a JSON object named after its L2 block number, under the archive prefix.
+// Archiving is best-effort: outputs are queued and stored in the background, and failures are logged.
+type OutputArchive struct {
+	log    log.Logger
+	store  objectstore.Store
+	prefix string
+
+	maxAttempts int
+	strategy    retry.Strategy
+
+	jobsLock sync.Mutex
+	jobs     chan archiveJob
+	closed   bool
+	jobsDone chan struct{}
+	ctx      context.Context
+	cancel   context.CancelFunc
+}
+
+type archiveJob struct {
+	receipt *types.Receipt
+	outputs []*eth.OutputResponse
+}
+
+// NewOutputArchive creates an OutputArchive that stores outputs under the given prefix, which may be empty.
+// The archive must be closed to store the pending outputs and stop the background routine.
+func NewOutputArchive(logger log.Logger, store objectstore.Store, prefix string) *OutputArchive {
+	ctx, cancel := context.WithCancel(context.Background())
+	a := &OutputArchive{
+		log:         logger,
+		store:       store,
+		prefix:      prefix,
+		maxAttempts: archiveMaxAttempts,
+		strategy:    retry.Exponential(),
+		jobs:        make(chan archiveJob, archiveQueueSize),
+		jobsDone:    make(chan struct{}),
+		ctx:         ctx,
+		cancel:      cancel,
+	}
+	go a.archiveLoop()
+	return a
+}
+
+// ObjectName returns the name of the object the output of the L2 block is stored as.
+func (a *OutputArchive) ObjectName(l2BlockNumber uint64) string {
+	return path.Join(a.prefix, fmt.Sprintf("%d.json", l2BlockNumber))
+}
+
+// Publish stores the outputs proposed by the transaction with the given receipt.
+// All outputs are attempted, and the errors of all failed outputs are returned.
+func (a *OutputArchive) Publish(ctx context.Context, receipt *types.Receipt, outputs []*eth.OutputResponse) error {
+	l1Block := eth.BlockID{Hash: receipt.BlockHash}
+	if receipt.BlockNumber != nil {
+		l1Block.Number = receipt.BlockNumber.Uint64()
+	}
+	var errs []error
+	for _, output := range outputs {
+		record := ProposedOutput{
+			OutputRoot: output.OutputRoot,
+			OutputRootProof: OutputRootProof{
+				Version:                  output.Version,
+				StateRoot:                output.StateRoot,
+				MessagePasserStorageRoot: output.WithdrawalStorageRoot,
+				LatestBlockhash:          output.BlockRef.Hash,
+			},
+			BlockRef:        output.BlockRef,
+			ProposalTxHash:  receipt.TxHash,
+			ProposalL1Block: l1Block,
+		}
+		data, err := json.Marshal(record)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to encode output %v: %w", output.BlockRef, err))
+			continue
+		}
+		name := a.ObjectName(output.BlockRef.Number)
+		_, err = retry.Do(ctx, a.maxAttempts, a.strategy, func() (struct{}, error) {
+			return struct{}{}, a.store.PutObject(ctx, name, data)
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to store output %v: %w", output.BlockRef, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Archive queues the outputs proposed by the transaction with the given receipt, to be stored in the background.
+// The outputs are dropped if the queue is full, or the archive is closed.
+func (a *OutputArchive) Archive(receipt *types.Receipt, outputs []*eth.OutputResponse) {
+	a.jobsLock.Lock()
+	defer a.jobsLock.Unlock()
+	if a.closed {
+		return
+	}
+	select {
+	case a.jobs <- archiveJob{receipt: receipt, outputs: outputs}:
+	default:
+		a.log.Warn("Dropping proposed outputs, archive queue is full", "tx_hash", receipt.TxHash)
+	}
+}
+
+func (a *OutputArchive) archiveLoop() {
+	defer close(a.jobsDone)
+	for job := range a.jobs {
+		a.archive(job)
+	}
+}
+
+func (a *OutputArchive) archive(job archiveJob) {
+	ctx, cancel := context.WithTimeout(a.ctx, archiveTimeout)
+	defer cancel()
+	if err := a.Publish(ctx, job.receipt, job.outputs); err != nil {
+		a.log.Warn("Failed to archive proposed outputs", "tx_hash", job.receipt.TxHash, "err", err)
+		return
+	}
+	for _, output := range job.outputs {
+		a.log.Info("Archived proposed output", "block", output.BlockRef, "object", a.ObjectName(output.BlockRef.Number))
+	}
+}
+
+// Close waits for the queued outputs to be stored, for up to archiveCloseTimeout, and stops the background routine.
+func (a *OutputArchive) Close() {
+	a.jobsLock.Lock()
+	if !a.closed {
+		a.closed = true
+		close(a.jobs)
+	}
+	a.jobsLock.Unlock()
+	select {
+	case <-a.jobsDone:
+	case <-time.After(archiveCloseTimeout):
+		a.log.Warn("Abandoning pending output archiving", "pending", len(a.jobs))
+		a.cancel()
+		<-a.jobsDone
+	}
+	a.cancel()
+}
+
+// archiveOutputs queues the proposed outputs to be published to the output archive, if configured.
+// Archiving is best-effort, and doesn't delay or affect the proposal.
+func (l *L2OutputSubmitter) archiveOutputs(receipt *types.Receipt, outputs []*eth.OutputResponse) {
+	if l.OutputArchive == nil {
+		return
+	}
+	l.OutputArchive.Archive(receipt, outputs)
+}
@@ -0,0 +1,120 @@
+package proposer
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-proposer/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/objectstore"
+	"github.com/ethereum-optimism/optimism/op-service/retry"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func newTestOutputArchive(t *testing.T, logger log.Logger, store objectstore.Store) *OutputArchive {
+	archive := NewOutputArchive(logger, store, "outputs/op")
+	archive.strategy = retry.Fixed(0)
+	t.Cleanup(archive.Close)
+	return archive
+}
+
+var (
+	archivedOutputs = []*eth.OutputResponse{
+		{
+			Version:               supportedL2OutputVersion,
+			OutputRoot:            eth.Bytes32{0xaa},
+			BlockRef:              eth.L2BlockRef{Hash: common.Hash{0x01}, Number: 42},
+			WithdrawalStorageRoot: common.Hash{0x02},
+			StateRoot:             common.Hash{0x03},
+		},
+		{
+			Version:    supportedL2OutputVersion,
+			OutputRoot: eth.Bytes32{0xbb},
+			BlockRef:   eth.L2BlockRef{Hash: common.Hash{0x04}, Number: 84},
+		},
+	}
+	archivedReceipt = &types.Receipt{
+		Status:      types.ReceiptStatusSuccessful,
+		TxHash:      common.Hash{0xcc},
+		BlockHash:   common.Hash{0xdd},
+		BlockNumber: big.NewInt(100),
+	}
+)
+
+func TestOutputArchive_Publish(t *testing.T) {
+	store := objectstore.NewMemStore()
+	archive := newTestOutputArchive(t, testlog.Logger(t, log.LevelError), store)
+	require.Equal(t, "outputs/op/42.json", archive.ObjectName(42))
+	store.FailNext(2)
+
+	require.NoError(t, archive.Publish(context.Background(), archivedReceipt, archivedOutputs))
+	objects := store.Objects()
+	require.Len(t, objects, 2)
+
+	var record ProposedOutput
+	require.NoError(t, json.Unmarshal(objects["outputs/op/42.json"], &record))
+	require.Equal(t, ProposedOutput{
+		OutputRoot: eth.Bytes32{0xaa},
+		OutputRootProof: OutputRootProof{
+			Version:                  supportedL2OutputVersion,
+			StateRoot:                common.Hash{0x03},
+			MessagePasserStorageRoot: common.Hash{0x02},
+			LatestBlockhash:          common.Hash{0x01},
+		},
+		BlockRef:        eth.L2BlockRef{Hash: common.Hash{0x01}, Number: 42},
+		ProposalTxHash:  common.Hash{0xcc},
+		ProposalL1Block: eth.BlockID{Hash: common.Hash{0xdd}, Number: 100},
+	}, record)
+	require.NoError(t, json.Unmarshal(objects["outputs/op/84.json"], &record))
+	require.Equal(t, eth.Bytes32{0xbb}, record.OutputRoot)
+}
+
+func TestOutputArchive_PublishFailure(t *testing.T) {
+	store := objectstore.NewMemStore()
+	archive := newTestOutputArchive(t, testlog.Logger(t, log.LevelError), store)
+	store.FailNext(archiveMaxAttempts)
+
+	err := archive.Publish(context.Background(), archivedReceipt, archivedOutputs)
+	require.ErrorContains(t, err, "failed to store output")
+	require.Contains(t, store.Objects(), "outputs/op/84.json", "other outputs must still be archived")
+	require.NotContains(t, store.Objects(), "outputs/op/42.json")
+}
+
+func TestL2OutputSubmitter_ArchiveOutputs(t *testing.T) {
+	store := objectstore.NewMemStore()
+	lgr, logs := testlog.CaptureLogger(t, log.LevelInfo)
+	archive := NewOutputArchive(lgr, store, "outputs/op")
+	archive.strategy = retry.Fixed(0)
+	ps := &L2OutputSubmitter{DriverSetup: DriverSetup{
+		Log:           lgr,
+		Metr:          metrics.NoopMetrics,
+		OutputArchive: archive,
+	}}
+
+	// Archiving happens in the background, and does not block the proposer.
+	release := store.BlockPuts()
+	ps.archiveOutputs(archivedReceipt, archivedOutputs)
+	require.Empty(t, store.Objects())
+	release()
+	require.Eventually(t, func() bool {
+		return len(store.Objects()) == 2
+	}, 10*time.Second, 10*time.Millisecond)
+
+	store.FailNext(archiveMaxAttempts)
+	ps.archiveOutputs(archivedReceipt, archivedOutputs)
+	archive.Close() // waits for the queued outputs
+	require.Len(t, logs.FindLogs(testlog.NewMessageFilter("Archived proposed output")), 2)
+	require.NotNil(t, logs.FindLog(testlog.NewMessageFilter("Failed to archive proposed outputs")))
+
+	ps.archiveOutputs(archivedReceipt, archivedOutputs) // archiving after closing is a no-op
+	ps.OutputArchive = nil
+	ps.archiveOutputs(archivedReceipt, archivedOutputs) // disabled archiving is a no-op
+}
@@ -2,11 +2,16 @@ package proposer
 
 import (
 	"errors"
+	"fmt"
+	"slices"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/urfave/cli/v2"
 
+	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	"github.com/ethereum-optimism/optimism/op-proposer/flags"
+	opflags "github.com/ethereum-optimism/optimism/op-service/flags"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
@@ -56,11 +61,70 @@ type CLIConfig struct {
 	// DisputeGameType is the type of dispute game to create when submitting an output proposal.
 	DisputeGameType uint32
 
+	// DisputeGameTypes is the prioritized list of dispute game types to create when submitting an output
+	// proposal. If a game type is unavailable, the next one is used. Overrides DisputeGameType if set.
+	DisputeGameTypes []uint32
+
 	// ActiveSequencerCheckDuration is the duration between checks to determine the active sequencer endpoint.
 	ActiveSequencerCheckDuration time.Duration
 
 	// Whether to wait for the sequencer to sync to a recent block at startup.
 	WaitNodeSync bool
+
+	// VerifyRollupRpc is the HTTP provider URL for a secondary rollup node, to verify output roots against
+	// before proposing them. Verification is disabled if empty.
+	VerifyRollupRpc string
+
+	// ProposalBatchMaxSize is the maximum number of output proposals to submit together.
+	// Each proposal is sent in its own transaction from the proposer account.
+	// Proposal batching is disabled if not greater than 1.
+	ProposalBatchMaxSize uint64
+
+	// ProposalBatchMaxWait is the maximum duration to hold back a pending output proposal while the batch fills up.
+	ProposalBatchMaxWait time.Duration
+
+	// SuperchainConfigAddress is the SuperchainConfig contract address. If set, no output proposals are made
+	// while the superchain is paused.
+	SuperchainConfigAddress string
+
+	// DryRun computes and simulates the output proposals, but never submits them.
+	DryRun bool
+
+	// OutputArchiveEndpoint is the endpoint of an S3 compatible object store to publish the proofs of
+	// proposed outputs to. Output archiving is disabled if empty.
+	OutputArchiveEndpoint        string
+	OutputArchiveBucket          string
+	OutputArchivePrefix          string
+	OutputArchiveAccessKeyID     string
+	OutputArchiveAccessKeySecret string
+	OutputArchiveInsecure        bool
+
+	// Network is the superchain-registry network the DisputeGameFactory, L2OutputOracle and SuperchainConfig
+	// addresses default to, if none of the DisputeGameFactory and L2OutputOracle addresses are set.
+	Network string
+}
+
+// applyNetwork defaults the contract addresses to those of the Network in the superchain-registry.
+// The DisputeGameFactory is used in preference to the L2OutputOracle if the chain has one.
+func (c *CLIConfig) applyNetwork() error {
+	if c.Network == "" || c.DGFAddress != "" || c.L2OOAddress != "" {
+		return nil
+	}
+	chain, err := chaincfg.LoadChain(c.Network)
+	if err != nil {
+		return err
+	}
+	if addr := common.Address(chain.Addresses.DisputeGameFactoryProxy); addr != (common.Address{}) {
+		c.DGFAddress = addr.Hex()
+	} else if addr := common.Address(chain.Addresses.L2OutputOracleProxy); addr != (common.Address{}) {
+		c.L2OOAddress = addr.Hex()
+	} else {
+		return fmt.Errorf("neither the `DisputeGameFactory` nor `L2OutputOracle` address is available for chain %v", c.Network)
+	}
+	if c.SuperchainConfigAddress == "" && chain.SuperchainConfig != (common.Address{}) {
+		c.SuperchainConfigAddress = chain.SuperchainConfig.Hex()
+	}
+	return nil
 }
 
 func (c *CLIConfig) Check() error {
@@ -89,6 +153,20 @@ func (c *CLIConfig) Check() error {
 	if c.ProposalInterval != 0 && c.DGFAddress == "" {
 		return errors.New("the `ProposalInterval` was provided but the `DisputeGameFactory` address was not set")
 	}
+	if len(c.DisputeGameTypes) > 0 && c.DGFAddress == "" {
+		return errors.New("the `DisputeGameTypes` were provided but the `DisputeGameFactory` address was not set")
+	}
+	if c.ProposalBatchMaxSize > 1 && c.ProposalBatchMaxWait == 0 {
+		return errors.New("the `ProposalBatchMaxSize` is greater than 1 but the `ProposalBatchMaxWait` was not set")
+	}
+	if c.OutputArchiveEndpoint != "" && c.OutputArchiveBucket == "" {
+		return errors.New("the `OutputArchiveEndpoint` was provided but the `OutputArchiveBucket` was not set")
+	}
+	for i, gameType := range c.DisputeGameTypes {
+		if slices.Contains(c.DisputeGameTypes[:i], gameType) {
+			return fmt.Errorf("duplicate game type %v in `DisputeGameTypes`", gameType)
+		}
+	}
 
 	return nil
 }
@@ -111,7 +189,31 @@ func NewConfig(ctx *cli.Context) *CLIConfig {
 		DGFAddress:                   ctx.String(flags.DisputeGameFactoryAddressFlag.Name),
 		ProposalInterval:             ctx.Duration(flags.ProposalIntervalFlag.Name),
 		DisputeGameType:              uint32(ctx.Uint(flags.DisputeGameTypeFlag.Name)),
+		DisputeGameTypes:             toUint32s(ctx.UintSlice(flags.DisputeGameTypesFlag.Name)),
 		ActiveSequencerCheckDuration: ctx.Duration(flags.ActiveSequencerCheckDurationFlag.Name),
 		WaitNodeSync:                 ctx.Bool(flags.WaitNodeSyncFlag.Name),
+		VerifyRollupRpc:              ctx.String(flags.VerifyRollupRpcFlag.Name),
+		ProposalBatchMaxSize:         ctx.Uint64(flags.ProposalBatchMaxSizeFlag.Name),
+		ProposalBatchMaxWait:         ctx.Duration(flags.ProposalBatchMaxWaitFlag.Name),
+		SuperchainConfigAddress:      ctx.String(flags.SuperchainConfigAddressFlag.Name),
+		DryRun:                       ctx.Bool(flags.DryRunFlag.Name),
+		OutputArchiveEndpoint:        ctx.String(flags.OutputArchiveEndpointFlag.Name),
+		OutputArchiveBucket:          ctx.String(flags.OutputArchiveBucketFlag.Name),
+		OutputArchivePrefix:          ctx.String(flags.OutputArchivePrefixFlag.Name),
+		OutputArchiveAccessKeyID:     ctx.String(flags.OutputArchiveAccessKeyIDFlag.Name),
+		OutputArchiveAccessKeySecret: ctx.String(flags.OutputArchiveAccessKeySecretFlag.Name),
+		OutputArchiveInsecure:        ctx.Bool(flags.OutputArchiveInsecureFlag.Name),
+		Network:                      ctx.String(opflags.NetworkFlagName),
+	}
+}
+
+func toUint32s(values []uint) []uint32 {
+	if len(values) == 0 {
+		return nil
+	}
+	out := make([]uint32, len(values))
+	for i, v := range values {
+		out[i] = uint32(v)
 	}
+	return out
 }
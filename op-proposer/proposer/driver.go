@@ -5,12 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/ethereum-optimism/optimism/op-proposer/bindings"
 	"github.com/ethereum-optimism/optimism/op-proposer/contracts"
 	"github.com/ethereum-optimism/optimism/op-proposer/metrics"
+	"github.com/ethereum-optimism/optimism/op-proposer/proposer/rpc"
 	"github.com/ethereum-optimism/optimism/op-service/dial"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
@@ -19,13 +21,17 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/log"
 )
 
 var (
 	supportedL2OutputVersion = eth.Bytes32{}
 	ErrProposerNotRunning    = errors.New("proposer is not running")
+	ErrGameTypeUnavailable   = errors.New("game type has no registered implementation")
+	ErrOutputRootMismatch    = errors.New("output root mismatches verification rollup node")
 )
 
 type L1Client interface {
@@ -42,14 +48,20 @@ type L1Client interface {
 type L2OOContract interface {
 	Version(*bind.CallOpts) (string, error)
 	NextBlockNumber(*bind.CallOpts) (*big.Int, error)
+	SubmissionInterval(*bind.CallOpts) (*big.Int, error)
 }
 
 type DGFContract interface {
 	Version(ctx context.Context) (string, error)
 	HasProposedSince(ctx context.Context, proposer common.Address, cutoff time.Time, gameType uint32) (bool, time.Time, error)
+	GameImpl(ctx context.Context, gameType uint32) (common.Address, error)
 	ProposalTx(ctx context.Context, gameType uint32, outputRoot common.Hash, l2BlockNum uint64) (txmgr.TxCandidate, error)
 }
 
+type SuperchainConfigContract interface {
+	Paused(ctx context.Context) (bool, error)
+}
+
 type RollupClient interface {
 	SyncStatus(ctx context.Context) (*eth.SyncStatus, error)
 	OutputAtBlock(ctx context.Context, blockNum uint64) (*eth.OutputResponse, error)
@@ -65,6 +77,13 @@ type DriverSetup struct {
 
 	// RollupProvider's RollupClient() is used to retrieve output roots from
 	RollupProvider dial.RollupProvider
+
+	// VerifyRollupProvider's RollupClient() is used to verify output roots before they are proposed.
+	// Verification is disabled if nil.
+	VerifyRollupProvider dial.RollupProvider
+
+	// OutputArchive publishes the proofs of successfully proposed outputs. Archiving is disabled if nil.
+	OutputArchive *OutputArchive
 }
 
 // L2OutputSubmitter is responsible for proposing outputs
@@ -84,6 +103,27 @@ type L2OutputSubmitter struct {
 	l2ooABI      *abi.ABI
 
 	dgfContract DGFContract
+
+	// superchainConfig is used to pause proposals while the superchain is paused. Nil if not configured.
+	superchainConfig SuperchainConfigContract
+
+	statusLock     sync.Mutex
+	gameTypeStatus map[uint32]rpc.GameTypeStatus
+	proposalStatus rpc.ProposalStatus
+
+	// l2ooSubmissionInterval is the number of L2 blocks between L2OO outputs.
+	// Only set if proposal batching or dry-run mode is enabled.
+	l2ooSubmissionInterval uint64
+	// pending holds the outputs waiting to be proposed in a batch. Only accessed by the driver loop.
+	pending []pendingOutput
+	// lastDryRun is the last output that was proposed in dry-run mode. Only accessed by the driver loop.
+	// Dry-run proposals don't change the contract state, so the next output is based on it instead.
+	lastDryRun *pendingOutput
+}
+
+type pendingOutput struct {
+	output *eth.OutputResponse
+	added  time.Time
 }
 
 // NewL2OutputSubmitter creates a new L2 Output Submitter
@@ -98,13 +138,25 @@ func NewL2OutputSubmitter(setup DriverSetup) (_ *L2OutputSubmitter, err error) {
 		}
 	}()
 
+	var submitter *L2OutputSubmitter
 	if setup.Cfg.L2OutputOracleAddr != nil {
-		return newL2OOSubmitter(ctx, cancel, setup)
+		submitter, err = newL2OOSubmitter(ctx, cancel, setup)
 	} else if setup.Cfg.DisputeGameFactoryAddr != nil {
-		return newDGFSubmitter(ctx, cancel, setup)
+		submitter, err = newDGFSubmitter(ctx, cancel, setup)
 	} else {
 		return nil, errors.New("neither the `L2OutputOracle` nor `DisputeGameFactory` addresses were provided")
 	}
+	if err != nil {
+		return nil, err
+	}
+	if setup.Cfg.DryRun {
+		setup.Log.Warn("Running in dry-run mode, output proposals are only simulated and never submitted")
+	}
+	if setup.Cfg.SuperchainConfigAddr != nil {
+		submitter.superchainConfig = contracts.NewSuperchainConfig(*setup.Cfg.SuperchainConfigAddr, setup.Multicaller, setup.Cfg.NetworkTimeout)
+		log.Info("Pausing proposals while the superchain is paused", "superchainConfig", setup.Cfg.SuperchainConfigAddr)
+	}
+	return submitter, nil
 }
 
 func newL2OOSubmitter(ctx context.Context, cancel context.CancelFunc, setup DriverSetup) (*L2OutputSubmitter, error) {
@@ -123,6 +175,16 @@ func newL2OOSubmitter(ctx context.Context, cancel context.CancelFunc, setup Driv
 	}
 	log.Info("Connected to L2OutputOracle", "address", setup.Cfg.L2OutputOracleAddr, "version", version)
 
+	var submissionInterval uint64
+	if setup.Cfg.ProposalBatchMaxSize > 1 || setup.Cfg.DryRun {
+		interval, err := l2ooContract.SubmissionInterval(&bind.CallOpts{Context: cCtx})
+		if err != nil {
+			cancel()
+			return nil, fmt.Errorf("failed to get L2OO submission interval: %w", err)
+		}
+		submissionInterval = interval.Uint64()
+	}
+
 	parsed, err := bindings.L2OutputOracleMetaData.GetAbi()
 	if err != nil {
 		cancel()
@@ -135,8 +197,9 @@ func newL2OOSubmitter(ctx context.Context, cancel context.CancelFunc, setup Driv
 		ctx:         ctx,
 		cancel:      cancel,
 
-		l2ooContract: l2ooContract,
-		l2ooABI:      parsed,
+		l2ooContract:           l2ooContract,
+		l2ooABI:                parsed,
+		l2ooSubmissionInterval: submissionInterval,
 	}, nil
 }
 
@@ -156,7 +219,8 @@ func newDGFSubmitter(ctx context.Context, cancel context.CancelFunc, setup Drive
 		ctx:         ctx,
 		cancel:      cancel,
 
-		dgfContract: dgfCaller,
+		dgfContract:    dgfCaller,
+		gameTypeStatus: make(map[uint32]rpc.GameTypeStatus),
 	}, nil
 }
 
@@ -222,17 +286,26 @@ func (l *L2OutputSubmitter) FetchL2OOOutput(ctx context.Context) (*eth.OutputRes
 		return nil, false, fmt.Errorf("L2OutputOracle contract not set, cannot fetch next output info")
 	}
 
-	cCtx, cancel := context.WithTimeout(ctx, l.Cfg.NetworkTimeout)
-	defer cancel()
-	callOpts := &bind.CallOpts{
-		From:    l.Txmgr.From(),
-		Context: cCtx,
-	}
-	nextCheckpointBlockBig, err := l.l2ooContract.NextBlockNumber(callOpts)
-	if err != nil {
-		return nil, false, fmt.Errorf("querying next block number: %w", err)
+	var nextCheckpointBlock uint64
+	if last := l.lastPending(); last != nil {
+		// The pending outputs are not proposed yet, so the next output follows the last pending one.
+		nextCheckpointBlock = last.output.BlockRef.Number + l.l2ooSubmissionInterval
+	} else {
+		cCtx, cancel := context.WithTimeout(ctx, l.Cfg.NetworkTimeout)
+		defer cancel()
+		callOpts := &bind.CallOpts{
+			From:    l.Txmgr.From(),
+			Context: cCtx,
+		}
+		nextCheckpointBlockBig, err := l.l2ooContract.NextBlockNumber(callOpts)
+		if err != nil {
+			return nil, false, fmt.Errorf("querying next block number: %w", err)
+		}
+		nextCheckpointBlock = nextCheckpointBlockBig.Uint64()
+		if l.lastDryRun != nil {
+			nextCheckpointBlock = max(nextCheckpointBlock, l.lastDryRun.output.BlockRef.Number+l.l2ooSubmissionInterval)
+		}
 	}
-	nextCheckpointBlock := nextCheckpointBlockBig.Uint64()
 	// Fetch the current L2 heads
 	currentBlockNumber, err := l.FetchCurrentBlockNumber(ctx)
 	if err != nil {
@@ -250,13 +323,7 @@ func (l *L2OutputSubmitter) FetchL2OOOutput(ctx context.Context) (*eth.OutputRes
 		return nil, false, fmt.Errorf("fetching output: %w", err)
 	}
 
-	// Always propose if it's part of the Finalized L2 chain. Or if allowed, if it's part of the safe L2 chain.
-	if output.BlockRef.Number > output.Status.FinalizedL2.Number && (!l.Cfg.AllowNonFinalized || output.BlockRef.Number > output.Status.SafeL2.Number) {
-		l.Log.Debug("Not proposing yet, L2 block is not ready for proposal",
-			"l2_proposal", output.BlockRef,
-			"l2_safe", output.Status.SafeL2,
-			"l2_finalized", output.Status.FinalizedL2,
-			"allow_non_finalized", l.Cfg.AllowNonFinalized)
+	if !l.readyToPropose(output) {
 		return output, false, nil
 	}
 	return output, true, nil
@@ -268,15 +335,31 @@ func (l *L2OutputSubmitter) FetchL2OOOutput(ctx context.Context) (*eth.OutputRes
 // The passed context is expected to be a lifecycle context. A network timeout
 // context will be derived from it.
 func (l *L2OutputSubmitter) FetchDGFOutput(ctx context.Context) (*eth.OutputResponse, bool, error) {
-	cutoff := time.Now().Add(-l.Cfg.ProposalInterval)
-	proposedRecently, proposalTime, err := l.dgfContract.HasProposedSince(ctx, l.Txmgr.From(), cutoff, l.Cfg.DisputeGameType)
-	if err != nil {
-		return nil, false, fmt.Errorf("could not check for recent proposal: %w", err)
-	}
-
-	if proposedRecently {
-		l.Log.Debug("Duration since last game not past proposal interval", "duration", time.Since(proposalTime))
+	last := l.lastPending()
+	if last != nil {
+		// The pending outputs are not proposed yet, so the interval is measured from the last pending output.
+		if since := time.Since(last.added); since < l.Cfg.ProposalInterval {
+			l.Log.Debug("Duration since last pending output not past proposal interval", "duration", since)
+			return nil, false, nil
+		}
+	} else if l.lastDryRun != nil && time.Since(l.lastDryRun.added) < l.Cfg.ProposalInterval {
+		l.Log.Debug("Duration since last dry-run proposal not past proposal interval", "duration", time.Since(l.lastDryRun.added))
 		return nil, false, nil
+	} else {
+		cutoff := time.Now().Add(-l.Cfg.ProposalInterval)
+		// A proposal made with any of the configured game types counts, so falling back to another game type
+		// doesn't result in additional proposals.
+		for _, gameType := range l.Cfg.GameTypes() {
+			proposedRecently, proposalTime, err := l.dgfContract.HasProposedSince(ctx, l.Txmgr.From(), cutoff, gameType)
+			if err != nil {
+				return nil, false, fmt.Errorf("could not check for recent proposal: %w", err)
+			}
+
+			if proposedRecently {
+				l.Log.Debug("Duration since last game not past proposal interval", "duration", time.Since(proposalTime), "gameType", gameType)
+				return nil, false, nil
+			}
+		}
 	}
 	l.Log.Info("No proposals found for at least proposal interval, submitting proposal now", "proposalInterval", l.Cfg.ProposalInterval)
 
@@ -290,15 +373,98 @@ func (l *L2OutputSubmitter) FetchDGFOutput(ctx context.Context) (*eth.OutputResp
 		l.Log.Info("Skipping proposal for genesis block")
 		return nil, false, nil
 	}
+	if last != nil && currentBlockNumber <= last.output.BlockRef.Number {
+		l.Log.Debug("Skipping proposal, no new L2 block since last pending output", "block", currentBlockNumber)
+		return nil, false, nil
+	}
 
 	output, err := l.FetchOutput(ctx, currentBlockNumber)
 	if err != nil {
 		return nil, false, fmt.Errorf("could not fetch output at current block number %d: %w", currentBlockNumber, err)
 	}
+	// The sync status may have changed since the current block number was fetched, so check the output again.
+	if !l.readyToPropose(output) {
+		return nil, false, nil
+	}
 
 	return output, true, nil
 }
 
+// readyToPropose returns true if the output is part of the finalized L2 chain, or if allowed, of the safe L2 chain.
+func (l *L2OutputSubmitter) readyToPropose(output *eth.OutputResponse) bool {
+	block := output.BlockRef.Number
+	ready := block <= output.Status.FinalizedL2.Number || (l.Cfg.AllowNonFinalized && block <= output.Status.SafeL2.Number)
+	var reason string
+	if !ready {
+		l.Log.Debug("Not proposing yet, L2 block is not ready for proposal",
+			"l2_proposal", output.BlockRef,
+			"l2_safe", output.Status.SafeL2,
+			"l2_finalized", output.Status.FinalizedL2,
+			"allow_non_finalized", l.Cfg.AllowNonFinalized)
+		if l.Cfg.AllowNonFinalized {
+			reason = fmt.Sprintf("L2 block %d is not safe", block)
+		} else {
+			reason = fmt.Sprintf("L2 block %d is not finalized", block)
+		}
+	}
+	l.updateProposalStatus(func(status *rpc.ProposalStatus) {
+		status.SafeL2 = output.Status.SafeL2.ID()
+		status.FinalizedL2 = output.Status.FinalizedL2.ID()
+		status.BlockedReason = reason
+	})
+	return ready
+}
+
+// superchainPaused returns true if proposals must be paused because the superchain is paused.
+// Proposals are paused as well if the pause status cannot be checked.
+func (l *L2OutputSubmitter) superchainPaused(ctx context.Context) bool {
+	if l.superchainConfig == nil {
+		return false
+	}
+	paused, err := l.superchainConfig.Paused(ctx)
+	var reason string
+	if err != nil {
+		l.Log.Warn("Failed to check superchain pause status, not proposing", "err", err)
+		reason = fmt.Sprintf("failed to check superchain pause status: %v", err)
+		paused = true
+	} else if paused {
+		reason = "superchain is paused"
+	}
+	l.Metr.RecordSuperchainPaused(paused)
+	l.updateProposalStatus(func(status *rpc.ProposalStatus) {
+		if paused != status.SuperchainPaused {
+			if paused {
+				l.Log.Warn("Pausing proposals", "reason", reason)
+			} else {
+				l.Log.Info("Resuming proposals, superchain is no longer paused")
+			}
+		}
+		// Only clear the blocked reason if it was set while paused, as it is set by readyToPropose otherwise.
+		if paused || status.SuperchainPaused {
+			status.BlockedReason = reason
+		}
+		status.SuperchainPaused = paused
+	})
+	return paused
+}
+
+func (l *L2OutputSubmitter) updateProposalStatus(update func(status *rpc.ProposalStatus)) {
+	l.statusLock.Lock()
+	defer l.statusLock.Unlock()
+	update(&l.proposalStatus)
+	l.proposalStatus.UpdatedAt = time.Now()
+}
+
+// ProposalStatus returns whether the proposer is currently allowed to propose, and why not.
+func (l *L2OutputSubmitter) ProposalStatus() rpc.ProposalStatus {
+	l.statusLock.Lock()
+	defer l.statusLock.Unlock()
+	status := l.proposalStatus
+	status.AllowNonFinalized = l.Cfg.AllowNonFinalized
+	status.DryRun = l.Cfg.DryRun
+	return status
+}
+
 // FetchCurrentBlockNumber gets the current block number from the [L2OutputSubmitter]'s [RollupClient]. If the `AllowNonFinalized` configuration
 // option is set, it will return the safe head block number, and if not, it will return the finalized head block number.
 func (l *L2OutputSubmitter) FetchCurrentBlockNumber(ctx context.Context) (uint64, error) {
@@ -338,6 +504,34 @@ func (l *L2OutputSubmitter) FetchOutput(ctx context.Context, block uint64) (*eth
 	return output, nil
 }
 
+// VerifyOutput checks that the verification rollup node agrees with the output to propose.
+// An error is returned if the output cannot be verified, in which case the output must not be proposed.
+func (l *L2OutputSubmitter) VerifyOutput(ctx context.Context, output *eth.OutputResponse) error {
+	if l.VerifyRollupProvider == nil {
+		return nil
+	}
+	rollupClient, err := l.VerifyRollupProvider.RollupClient(ctx)
+	if err != nil {
+		return fmt.Errorf("getting verification rollup client: %w", err)
+	}
+	cCtx, cancel := context.WithTimeout(ctx, l.Cfg.NetworkTimeout)
+	defer cancel()
+	expected, err := rollupClient.OutputAtBlock(cCtx, output.BlockRef.Number)
+	if err != nil {
+		return fmt.Errorf("fetching verification output at block %d: %w", output.BlockRef.Number, err)
+	}
+	if expected.OutputRoot != output.OutputRoot || expected.BlockRef.Hash != output.BlockRef.Hash {
+		l.Metr.RecordOutputRootMismatch(true)
+		l.Log.Error("Output root mismatches verification rollup node, refusing to propose",
+			"block", output.BlockRef, "output", output.OutputRoot,
+			"verify_block", expected.BlockRef, "verify_output", expected.OutputRoot)
+		return fmt.Errorf("%w at block %d: %s, verification node has %s",
+			ErrOutputRootMismatch, output.BlockRef.Number, output.OutputRoot, expected.OutputRoot)
+	}
+	l.Metr.RecordOutputRootMismatch(false)
+	return nil
+}
+
 // ProposeL2OutputTxData creates the transaction data for the ProposeL2Output function
 func (l *L2OutputSubmitter) ProposeL2OutputTxData(output *eth.OutputResponse) ([]byte, error) {
 	return proposeL2OutputTxData(l.l2ooABI, output)
@@ -353,10 +547,153 @@ func proposeL2OutputTxData(abi *abi.ABI, output *eth.OutputResponse) ([]byte, er
 		new(big.Int).SetUint64(output.Status.CurrentL1.Number))
 }
 
+// ProposeL2OutputDGFTxCandidate creates the transaction candidate to propose the output with the primary game type.
 func (l *L2OutputSubmitter) ProposeL2OutputDGFTxCandidate(ctx context.Context, output *eth.OutputResponse) (txmgr.TxCandidate, error) {
+	return l.proposeL2OutputDGFTxCandidate(ctx, l.Cfg.GameTypes()[0], output)
+}
+
+func (l *L2OutputSubmitter) proposeL2OutputDGFTxCandidate(ctx context.Context, gameType uint32, output *eth.OutputResponse) (txmgr.TxCandidate, error) {
 	cCtx, cancel := context.WithTimeout(ctx, l.Cfg.NetworkTimeout)
 	defer cancel()
-	return l.dgfContract.ProposalTx(cCtx, l.Cfg.DisputeGameType, common.Hash(output.OutputRoot), output.BlockRef.Number)
+	return l.dgfContract.ProposalTx(cCtx, gameType, common.Hash(output.OutputRoot), output.BlockRef.Number)
+}
+
+// sendDGFProposal proposes the output with the first configured game type that is available.
+// If the game type is unavailable or the proposal reverts, the next game type in priority order is tried.
+// Any other error, e.g. a transient RPC or tx manager failure, is returned without trying other game types.
+func (l *L2OutputSubmitter) sendDGFProposal(ctx context.Context, output *eth.OutputResponse) (*types.Receipt, error) {
+	var errs []error
+	gameTypes := l.Cfg.GameTypes()
+	for i, gameType := range gameTypes {
+		receipt, err := l.sendDGFProposalWithGameType(ctx, gameType, output)
+		if err == nil && receipt != nil && receipt.Status == types.ReceiptStatusFailed {
+			err = fmt.Errorf("%w: tx %v", errProposalReverted, receipt.TxHash)
+		}
+		l.recordGameTypeStatus(gameType, err)
+		if err == nil {
+			return receipt, nil
+		}
+		if errors.Is(err, errProposalReverted) && i == len(gameTypes)-1 {
+			// There is no game type left to fall back to, the reverted receipt is handled by the caller.
+			return receipt, nil
+		}
+		errs = append(errs, fmt.Errorf("game type %v: %w", gameType, err))
+		if !isGameTypeFallbackError(err) {
+			return nil, errors.Join(errs...)
+		}
+		l.Log.Warn("Failed to propose output with game type", "gameType", gameType, "err", err)
+	}
+	return nil, errors.Join(errs...)
+}
+
+// errProposalReverted is the error of a proposal transaction that was included but reverted.
+var errProposalReverted = errors.New("proposal transaction reverted")
+
+// isGameTypeFallbackError returns true if err shows that the game type can't be proposed with right now,
+// because it has no implementation or the proposal reverted, so the next game type should be tried.
+func isGameTypeFallbackError(err error) bool {
+	if errors.Is(err, ErrGameTypeUnavailable) || errors.Is(err, errProposalReverted) {
+		return true
+	}
+	var revertErr interface{ ErrorData() interface{} }
+	if errors.As(err, &revertErr) && revertErr.ErrorData() != nil {
+		return true
+	}
+	return strings.Contains(err.Error(), vm.ErrExecutionReverted.Error())
+}
+
+func (l *L2OutputSubmitter) sendDGFProposalWithGameType(ctx context.Context, gameType uint32, output *eth.OutputResponse) (*types.Receipt, error) {
+	cCtx, cancel := context.WithTimeout(ctx, l.Cfg.NetworkTimeout)
+	impl, err := l.dgfContract.GameImpl(cCtx, gameType)
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+	if impl == (common.Address{}) {
+		return nil, ErrGameTypeUnavailable
+	}
+	candidate, err := l.proposeL2OutputDGFTxCandidate(ctx, gameType, output)
+	if err != nil {
+		return nil, err
+	}
+	l.Log.Info("Proposing output root with game type", "gameType", gameType, "impl", impl)
+	return l.send(ctx, candidate, output)
+}
+
+// send submits the proposal transaction of the output. In dry-run mode, the transaction is only
+// simulated, and a nil receipt is returned if the simulation succeeded.
+func (l *L2OutputSubmitter) send(ctx context.Context, candidate txmgr.TxCandidate, output *eth.OutputResponse) (*types.Receipt, error) {
+	if !l.Cfg.DryRun {
+		return l.Txmgr.Send(ctx, candidate)
+	}
+	cCtx, cancel := context.WithTimeout(ctx, l.Cfg.NetworkTimeout)
+	defer cancel()
+	_, err := l.L1Client.CallContract(cCtx, ethereum.CallMsg{
+		From:  l.Txmgr.From(),
+		To:    candidate.To,
+		Gas:   candidate.GasLimit,
+		Value: candidate.Value,
+		Data:  candidate.TxData,
+	}, nil)
+
+	proposal := &rpc.DryRunProposal{
+		To:   candidate.To,
+		Data: candidate.TxData,
+		Time: time.Now(),
+	}
+	if candidate.Value != nil {
+		proposal.Value = (*hexutil.Big)(candidate.Value)
+	}
+	proposal.Outputs = append(proposal.Outputs, rpc.DryRunOutput{Block: output.BlockRef.ID(), OutputRoot: output.OutputRoot})
+	if err != nil {
+		proposal.Error = err.Error()
+	}
+	l.statusLock.Lock()
+	l.proposalStatus.LastDryRunProposal = proposal
+	l.statusLock.Unlock()
+
+	if err != nil {
+		return nil, fmt.Errorf("dry-run proposal transaction failed: %w", err)
+	}
+	l.Log.Info("Dry run: simulated proposal transaction, not submitting it",
+		"to", candidate.To, "value", candidate.Value, "data", hexutil.Bytes(candidate.TxData), "block", output.BlockRef)
+	return nil, nil
+}
+
+func (l *L2OutputSubmitter) recordGameTypeStatus(gameType uint32, err error) {
+	l.statusLock.Lock()
+	defer l.statusLock.Unlock()
+	status := l.gameTypeStatus[gameType]
+	status.GameType = gameType
+	status.Available = err == nil
+	if err != nil {
+		status.LastError = err.Error()
+	} else {
+		status.LastError = ""
+		status.LastProposal = time.Now()
+	}
+	l.gameTypeStatus[gameType] = status
+	l.Metr.RecordGameTypeAvailable(gameType, status.Available)
+}
+
+// GameTypeStatuses returns the status of each configured game type, in priority order.
+// Game types that haven't been used yet are reported as available.
+func (l *L2OutputSubmitter) GameTypeStatuses() []rpc.GameTypeStatus {
+	if l.dgfContract == nil {
+		return nil
+	}
+	l.statusLock.Lock()
+	defer l.statusLock.Unlock()
+	gameTypes := l.Cfg.GameTypes()
+	statuses := make([]rpc.GameTypeStatus, 0, len(gameTypes))
+	for _, gameType := range gameTypes {
+		status, ok := l.gameTypeStatus[gameType]
+		if !ok {
+			status = rpc.GameTypeStatus{GameType: gameType, Available: true}
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
 }
 
 // We wait until l1head advances beyond blocknum. This is used to make sure proposal tx won't
@@ -387,8 +724,12 @@ func (l *L2OutputSubmitter) waitForL1Head(ctx context.Context, blockNum uint64)
 	return nil
 }
 
-// sendTransaction creates & sends transactions through the underlying transaction manager.
+// sendTransaction creates & sends a transaction proposing the output through the underlying transaction manager.
+// Proposals are always sent by the proposer account itself.
 func (l *L2OutputSubmitter) sendTransaction(ctx context.Context, output *eth.OutputResponse) error {
+	if err := l.VerifyOutput(ctx, output); err != nil {
+		return err
+	}
 	err := l.waitForL1Head(ctx, output.Status.HeadL1.Number+1)
 	if err != nil {
 		return err
@@ -397,11 +738,7 @@ func (l *L2OutputSubmitter) sendTransaction(ctx context.Context, output *eth.Out
 	l.Log.Info("Proposing output root", "output", output.OutputRoot, "block", output.BlockRef)
 	var receipt *types.Receipt
 	if l.Cfg.DisputeGameFactoryAddr != nil {
-		candidate, err := l.ProposeL2OutputDGFTxCandidate(ctx, output)
-		if err != nil {
-			return err
-		}
-		receipt, err = l.Txmgr.Send(ctx, candidate)
+		receipt, err = l.sendDGFProposal(ctx, output)
 		if err != nil {
 			return err
 		}
@@ -410,15 +747,19 @@ func (l *L2OutputSubmitter) sendTransaction(ctx context.Context, output *eth.Out
 		if err != nil {
 			return err
 		}
-		receipt, err = l.Txmgr.Send(ctx, txmgr.TxCandidate{
+		receipt, err = l.send(ctx, txmgr.TxCandidate{
 			TxData:   data,
 			To:       l.Cfg.L2OutputOracleAddr,
 			GasLimit: 0,
-		})
+		}, output)
 		if err != nil {
 			return err
 		}
 	}
+	if receipt == nil {
+		// Simulated in dry-run mode.
+		return nil
+	}
 
 	if receipt.Status == types.ReceiptStatusFailed {
 		l.Log.Error("Proposer tx successfully published but reverted", "tx_hash", receipt.TxHash)
@@ -427,6 +768,7 @@ func (l *L2OutputSubmitter) sendTransaction(ctx context.Context, output *eth.Out
 			"tx_hash", receipt.TxHash,
 			"l1blocknum", output.Status.CurrentL1.Number,
 			"l1blockhash", output.Status.CurrentL1.Hash)
+		l.archiveOutputs(receipt, []*eth.OutputResponse{output})
 	}
 	return nil
 }
@@ -449,6 +791,15 @@ func (l *L2OutputSubmitter) loop() {
 			default:
 			}
 
+			// Pending outputs are kept while paused, and proposed once the superchain is unpaused.
+			if l.superchainPaused(ctx) {
+				continue
+			}
+
+			if l.pendingDue() {
+				l.proposePending(ctx)
+			}
+
 			// A note on retrying: the outer ticker already runs on a short
 			// poll interval, which has a default value of 6 seconds. So no
 			// retry logic is needed around output fetching here.
@@ -468,7 +819,11 @@ func (l *L2OutputSubmitter) loop() {
 				continue
 			}
 
-			l.proposeOutput(ctx, output)
+			if l.Cfg.ProposalBatchMaxSize > 1 {
+				l.addPending(ctx, output)
+			} else {
+				l.proposeOutput(ctx, output)
+			}
 		case <-l.done:
 			return
 		}
@@ -493,7 +848,17 @@ func (l *L2OutputSubmitter) waitNodeSync() error {
 	return dial.WaitRollupSync(l.ctx, l.Log, rollupClient, l1head, time.Second*12)
 }
 
-func (l *L2OutputSubmitter) proposeOutput(ctx context.Context, output *eth.OutputResponse) {
+// proposeOutput proposes the outputs in order, each in its own transaction.
+// It stops at the first failed proposal, as the later outputs build on it.
+func (l *L2OutputSubmitter) proposeOutput(ctx context.Context, outputs ...*eth.OutputResponse) {
+	for _, output := range outputs {
+		if !l.proposeSingleOutput(ctx, output) {
+			return
+		}
+	}
+}
+
+func (l *L2OutputSubmitter) proposeSingleOutput(ctx context.Context, output *eth.OutputResponse) bool {
 	cCtx, cancel := context.WithTimeout(ctx, 10*time.Minute)
 	defer cancel()
 
@@ -503,7 +868,45 @@ func (l *L2OutputSubmitter) proposeOutput(ctx context.Context, output *eth.Outpu
 			"l1blocknum", output.Status.CurrentL1.Number,
 			"l1blockhash", output.Status.CurrentL1.Hash,
 			"l1head", output.Status.HeadL1.Number)
-		return
+		return false
+	}
+	if l.Cfg.DryRun {
+		l.lastDryRun = &pendingOutput{output: output, added: time.Now()}
+		l.Metr.RecordL2BlocksDryRunProposed(output.BlockRef)
+		return true
 	}
 	l.Metr.RecordL2BlocksProposed(output.BlockRef)
+	return true
+}
+
+// addPending adds the output to the pending batch, and proposes the batch once it is full.
+func (l *L2OutputSubmitter) addPending(ctx context.Context, output *eth.OutputResponse) {
+	l.pending = append(l.pending, pendingOutput{output: output, added: time.Now()})
+	l.Log.Info("Added output to pending proposal batch", "block", output.BlockRef, "pending", len(l.pending))
+	if uint64(len(l.pending)) >= l.Cfg.ProposalBatchMaxSize {
+		l.proposePending(ctx)
+	}
+}
+
+// pendingDue returns true if the oldest pending output has waited for the maximum batch wait time.
+func (l *L2OutputSubmitter) pendingDue() bool {
+	return len(l.pending) > 0 && time.Since(l.pending[0].added) >= l.Cfg.ProposalBatchMaxWait
+}
+
+// proposePending proposes all pending outputs back to back.
+// The remaining pending outputs are dropped if a proposal fails, so the next outputs are fetched based on the chain state.
+func (l *L2OutputSubmitter) proposePending(ctx context.Context) {
+	outputs := make([]*eth.OutputResponse, 0, len(l.pending))
+	for _, pending := range l.pending {
+		outputs = append(outputs, pending.output)
+	}
+	l.pending = nil
+	l.proposeOutput(ctx, outputs...)
+}
+
+func (l *L2OutputSubmitter) lastPending() *pendingOutput {
+	if len(l.pending) == 0 {
+		return nil
+	}
+	return &l.pending[len(l.pending)-1]
 }
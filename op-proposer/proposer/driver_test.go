@@ -2,6 +2,7 @@ package proposer
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
 	"testing"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-proposer/bindings"
 	"github.com/ethereum-optimism/optimism/op-proposer/metrics"
+	"github.com/ethereum-optimism/optimism/op-proposer/proposer/rpc"
 	"github.com/ethereum-optimism/optimism/op-service/dial"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
@@ -16,6 +18,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 	txmgrmocks "github.com/ethereum-optimism/optimism/op-service/txmgr/mocks"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -38,8 +41,14 @@ func (m *MockL2OOContract) NextBlockNumber(opts *bind.CallOpts) (*big.Int, error
 	return args.Get(0).(*big.Int), args.Error(1)
 }
 
+func (m *MockL2OOContract) SubmissionInterval(opts *bind.CallOpts) (*big.Int, error) {
+	args := m.Called(opts)
+	return args.Get(0).(*big.Int), args.Error(1)
+}
+
 type StubDGFContract struct {
 	hasProposedCount int
+	impls            map[uint32]common.Address
 }
 
 func (m *StubDGFContract) HasProposedSince(_ context.Context, _ common.Address, _ time.Time, _ uint32) (bool, time.Time, error) {
@@ -47,8 +56,13 @@ func (m *StubDGFContract) HasProposedSince(_ context.Context, _ common.Address,
 	return false, time.Unix(1000, 0), nil
 }
 
-func (m *StubDGFContract) ProposalTx(_ context.Context, _ uint32, _ common.Hash, _ uint64) (txmgr.TxCandidate, error) {
-	panic("not implemented")
+func (m *StubDGFContract) GameImpl(_ context.Context, gameType uint32) (common.Address, error) {
+	return m.impls[gameType], nil
+}
+
+func (m *StubDGFContract) ProposalTx(_ context.Context, gameType uint32, _ common.Hash, _ uint64) (txmgr.TxCandidate, error) {
+	// Encode the game type so tests can identify the proposal
+	return txmgr.TxCandidate{TxData: []byte{byte(gameType)}}, nil
 }
 
 func (m *StubDGFContract) Version(_ context.Context) (string, error) {
@@ -179,3 +193,509 @@ func TestL2OutputSubmitter_OutputRetry(t *testing.T) {
 		})
 	}
 }
+
+func TestL2OutputSubmitter_GameTypeFallback(t *testing.T) {
+	output := &eth.OutputResponse{
+		Version:  supportedL2OutputVersion,
+		BlockRef: eth.L2BlockRef{Number: 42},
+		Status:   &eth.SyncStatus{HeadL1: eth.L1BlockRef{Number: 10}},
+	}
+	forGameType := func(gameType uint32) interface{} {
+		return mock.MatchedBy(func(candidate txmgr.TxCandidate) bool {
+			return len(candidate.TxData) == 1 && candidate.TxData[0] == byte(gameType)
+		})
+	}
+	setupFallback := func(t *testing.T) (*L2OutputSubmitter, *StubDGFContract, *txmgrmocks.TxManager) {
+		dgfAddr := common.Address{0xdd}
+		m := txmgrmocks.NewTxManager(t)
+		m.On("BlockNumber", mock.Anything).Return(uint64(100), nil)
+		dgf := &StubDGFContract{impls: map[uint32]common.Address{
+			0: {0x01},
+			1: {0x02},
+		}}
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+		return &L2OutputSubmitter{
+			DriverSetup: DriverSetup{
+				Log:  testlog.Logger(t, log.LevelDebug),
+				Metr: metrics.NoopMetrics,
+				Cfg: ProposerConfig{
+					PollInterval:           time.Microsecond,
+					DisputeGameFactoryAddr: &dgfAddr,
+					DisputeGameTypes:       []uint32{1, 2, 0},
+				},
+				Txmgr: m,
+			},
+			done:           make(chan struct{}),
+			ctx:            ctx,
+			cancel:         cancel,
+			dgfContract:    dgf,
+			gameTypeStatus: make(map[uint32]rpc.GameTypeStatus),
+		}, dgf, m
+	}
+
+	t.Run("UsePrimary", func(t *testing.T) {
+		ps, _, m := setupFallback(t)
+		m.On("Send", mock.Anything, forGameType(1)).Return(&types.Receipt{Status: types.ReceiptStatusSuccessful}, nil).Once()
+		require.NoError(t, ps.sendTransaction(context.Background(), output))
+		statuses := ps.GameTypeStatuses()
+		require.Len(t, statuses, 3)
+		for _, status := range statuses {
+			require.True(t, status.Available)
+		}
+		require.NotZero(t, statuses[0].LastProposal)
+		require.Zero(t, statuses[2].LastProposal)
+	})
+
+	t.Run("FallbackOnFailure", func(t *testing.T) {
+		ps, _, m := setupFallback(t)
+		m.On("Send", mock.Anything, forGameType(1)).Return(nil, errors.New("execution reverted")).Once()
+		// Game type 2 has no impl registered, so must be skipped without sending
+		m.On("Send", mock.Anything, forGameType(0)).Return(&types.Receipt{Status: types.ReceiptStatusSuccessful}, nil).Once()
+		require.NoError(t, ps.sendTransaction(context.Background(), output))
+
+		statuses := ps.GameTypeStatuses()
+		require.Equal(t, uint32(1), statuses[0].GameType)
+		require.False(t, statuses[0].Available)
+		require.Contains(t, statuses[0].LastError, "execution reverted")
+		require.Equal(t, uint32(2), statuses[1].GameType)
+		require.False(t, statuses[1].Available)
+		require.Contains(t, statuses[1].LastError, ErrGameTypeUnavailable.Error())
+		require.Equal(t, uint32(0), statuses[2].GameType)
+		require.True(t, statuses[2].Available)
+	})
+
+	t.Run("FallbackOnMinedRevert", func(t *testing.T) {
+		ps, _, m := setupFallback(t)
+		m.On("Send", mock.Anything, forGameType(1)).Return(&types.Receipt{Status: types.ReceiptStatusFailed}, nil).Once()
+		m.On("Send", mock.Anything, forGameType(0)).Return(&types.Receipt{Status: types.ReceiptStatusSuccessful}, nil).Once()
+		require.NoError(t, ps.sendTransaction(context.Background(), output))
+		statuses := ps.GameTypeStatuses()
+		require.False(t, statuses[0].Available)
+		require.True(t, statuses[2].Available)
+	})
+
+	t.Run("MinedRevertOnLastGameType", func(t *testing.T) {
+		ps, _, m := setupFallback(t)
+		m.On("Send", mock.Anything, forGameType(1)).Return(&types.Receipt{Status: types.ReceiptStatusFailed}, nil).Once()
+		m.On("Send", mock.Anything, forGameType(0)).Return(&types.Receipt{Status: types.ReceiptStatusFailed}, nil).Once()
+		require.NoError(t, ps.sendTransaction(context.Background(), output))
+		statuses := ps.GameTypeStatuses()
+		require.Equal(t, uint32(0), statuses[2].GameType)
+		require.False(t, statuses[2].Available)
+		require.Contains(t, statuses[2].LastError, errProposalReverted.Error())
+		require.Zero(t, statuses[2].LastProposal)
+	})
+
+	t.Run("NoFallbackOnTransientError", func(t *testing.T) {
+		ps, _, m := setupFallback(t)
+		sendErr := errors.New("connection refused")
+		m.On("Send", mock.Anything, forGameType(1)).Return(nil, sendErr).Once()
+		err := ps.sendTransaction(context.Background(), output)
+		require.ErrorIs(t, err, sendErr)
+		m.AssertNotCalled(t, "Send", mock.Anything, forGameType(0))
+		statuses := ps.GameTypeStatuses()
+		require.False(t, statuses[0].Available)
+		require.Zero(t, statuses[2].LastProposal)
+	})
+
+	t.Run("NoFallbackOnCancel", func(t *testing.T) {
+		ps, _, m := setupFallback(t)
+		m.On("Send", mock.Anything, forGameType(1)).Return(nil, context.Canceled).Once()
+		err := ps.sendTransaction(context.Background(), output)
+		require.ErrorIs(t, err, context.Canceled)
+		m.AssertNotCalled(t, "Send", mock.Anything, forGameType(0))
+	})
+
+	t.Run("AllUnavailable", func(t *testing.T) {
+		ps, dgf, _ := setupFallback(t)
+		dgf.impls = nil
+		err := ps.sendTransaction(context.Background(), output)
+		require.ErrorIs(t, err, ErrGameTypeUnavailable)
+	})
+}
+
+func TestL2OutputSubmitter_VerifyOutput(t *testing.T) {
+	output := &eth.OutputResponse{
+		Version:    supportedL2OutputVersion,
+		OutputRoot: eth.Bytes32{0xaa},
+		BlockRef:   eth.L2BlockRef{Hash: common.Hash{0x01}, Number: 42},
+		Status:     &eth.SyncStatus{HeadL1: eth.L1BlockRef{Number: 10}},
+	}
+	setupVerify := func(t *testing.T) (*L2OutputSubmitter, *mockRollupEndpointProvider) {
+		verifier := newEndpointProvider()
+		return &L2OutputSubmitter{
+			DriverSetup: DriverSetup{
+				Log:                  testlog.Logger(t, log.LevelCrit),
+				Metr:                 metrics.NoopMetrics,
+				Cfg:                  ProposerConfig{NetworkTimeout: time.Second},
+				Txmgr:                txmgrmocks.NewTxManager(t),
+				VerifyRollupProvider: verifier,
+			},
+			done: make(chan struct{}),
+		}, verifier
+	}
+
+	t.Run("Disabled", func(t *testing.T) {
+		ps, _ := setupVerify(t)
+		ps.VerifyRollupProvider = nil
+		require.NoError(t, ps.VerifyOutput(context.Background(), output))
+	})
+
+	t.Run("Match", func(t *testing.T) {
+		ps, verifier := setupVerify(t)
+		verifier.rollupClient.ExpectOutputAtBlock(42, output, nil)
+		require.NoError(t, ps.VerifyOutput(context.Background(), output))
+	})
+
+	t.Run("OutputRootMismatch", func(t *testing.T) {
+		ps, verifier := setupVerify(t)
+		verifier.rollupClient.ExpectOutputAtBlock(42, &eth.OutputResponse{
+			OutputRoot: eth.Bytes32{0xbb},
+			BlockRef:   output.BlockRef,
+		}, nil)
+		// The proposal must be blocked before any transaction is sent
+		require.ErrorIs(t, ps.sendTransaction(context.Background(), output), ErrOutputRootMismatch)
+	})
+
+	t.Run("BlockHashMismatch", func(t *testing.T) {
+		ps, verifier := setupVerify(t)
+		verifier.rollupClient.ExpectOutputAtBlock(42, &eth.OutputResponse{
+			OutputRoot: output.OutputRoot,
+			BlockRef:   eth.L2BlockRef{Hash: common.Hash{0x02}, Number: 42},
+		}, nil)
+		require.ErrorIs(t, ps.VerifyOutput(context.Background(), output), ErrOutputRootMismatch)
+	})
+
+	t.Run("VerifierUnavailable", func(t *testing.T) {
+		ps, verifier := setupVerify(t)
+		verifier.rollupClient.ExpectOutputAtBlock(42, nil, errors.New("boom"))
+		err := ps.VerifyOutput(context.Background(), output)
+		require.ErrorContains(t, err, "boom")
+		require.NotErrorIs(t, err, ErrOutputRootMismatch)
+	})
+}
+
+func TestL2OutputSubmitter_ProposalBatching(t *testing.T) {
+	proposerAddr := common.Address{0xab}
+
+	t.Run("L2OO", func(t *testing.T) {
+		ps, ep, l2ooContract, _, m, _ := setup(t, "L2OO")
+		ps.Cfg.ProposalBatchMaxSize = 3
+		ps.Cfg.ProposalBatchMaxWait = time.Hour
+		ps.l2ooSubmissionInterval = 10
+
+		status := &eth.SyncStatus{FinalizedL2: eth.L2BlockRef{Number: 100}}
+		ep.rollupClient.On("SyncStatus").Return(status, nil).Times(3)
+		for _, block := range []uint64{40, 50, 60} {
+			ep.rollupClient.ExpectOutputAtBlock(block, &eth.OutputResponse{
+				Version:    supportedL2OutputVersion,
+				OutputRoot: eth.Bytes32{byte(block)},
+				BlockRef:   eth.L2BlockRef{Number: block},
+				Status:     status,
+			}, nil)
+		}
+		// The next block number is only queried from the contract while no outputs are pending
+		m.On("From").Return(proposerAddr).Once()
+		l2ooContract.On("NextBlockNumber", mock.AnythingOfType("*bind.CallOpts")).Return(big.NewInt(40), nil).Once()
+		m.On("BlockNumber", mock.Anything).Return(uint64(100), nil).Twice()
+		m.On("Send", mock.Anything, mock.Anything).Return(&types.Receipt{Status: types.ReceiptStatusSuccessful}, nil).Twice()
+
+		ps.wg.Add(1)
+		ps.loop()
+
+		ep.rollupClient.AssertExpectations(t)
+		l2ooContract.AssertExpectations(t)
+		require.Empty(t, ps.pending)
+
+		// Each output is proposed in its own transaction, sent directly to the L2OO by the proposer account
+		candidates := sentCandidates(m)
+		require.Len(t, candidates, 3)
+		for i, candidate := range candidates {
+			require.Equal(t, ps.Cfg.L2OutputOracleAddr, candidate.To)
+			args, err := ps.l2ooABI.Methods["proposeL2Output"].Inputs.Unpack(candidate.TxData[4:])
+			require.NoError(t, err)
+			require.Equal(t, big.NewInt(int64(40+10*i)), args[1])
+		}
+	})
+
+	t.Run("StopsAtFailedProposal", func(t *testing.T) {
+		ps, _, _, _, m, _ := setup(t, "L2OO")
+		ps.Cfg.ProposalBatchMaxSize = 3
+		ps.Cfg.ProposalBatchMaxWait = time.Hour
+		m.ExpectedCalls = nil
+		m.On("BlockNumber", mock.Anything).Return(uint64(100), nil).Once()
+		m.On("Send", mock.Anything, mock.Anything).Return(nil, errors.New("boom")).Once()
+		for _, block := range []uint64{40, 50, 60} {
+			ps.addPending(context.Background(), &eth.OutputResponse{
+				Version:  supportedL2OutputVersion,
+				BlockRef: eth.L2BlockRef{Number: block},
+				Status:   &eth.SyncStatus{},
+			})
+		}
+		require.Empty(t, ps.pending)
+		require.Len(t, sentCandidates(m), 1, "later outputs must not be proposed after a failed proposal")
+	})
+
+	t.Run("DGFWaitsForProposalInterval", func(t *testing.T) {
+		dgf := &StubDGFContract{}
+		ps := &L2OutputSubmitter{
+			DriverSetup: DriverSetup{
+				Log:  testlog.Logger(t, log.LevelDebug),
+				Metr: metrics.NoopMetrics,
+				Cfg: ProposerConfig{
+					ProposalInterval:     time.Hour,
+					ProposalBatchMaxSize: 3,
+				},
+			},
+			dgfContract: dgf,
+			pending:     []pendingOutput{{output: &eth.OutputResponse{BlockRef: eth.L2BlockRef{Number: 42}}, added: time.Now()}},
+		}
+
+		_, shouldPropose, err := ps.FetchDGFOutput(context.Background())
+		require.NoError(t, err)
+		require.False(t, shouldPropose)
+		// The pending output counts as the last proposal, so the chain is not checked
+		require.Zero(t, dgf.hasProposedCount)
+	})
+
+	t.Run("FlushAfterMaxWait", func(t *testing.T) {
+		ps, _, _, _, m, _ := setup(t, "L2OO")
+		ps.Cfg.ProposalBatchMaxSize = 3
+		ps.Cfg.ProposalBatchMaxWait = time.Minute
+		output := &eth.OutputResponse{Version: supportedL2OutputVersion, BlockRef: eth.L2BlockRef{Number: 40}, Status: &eth.SyncStatus{}}
+		ps.addPending(context.Background(), output)
+		require.False(t, ps.pendingDue())
+		ps.pending[0].added = time.Now().Add(-time.Minute)
+		require.True(t, ps.pendingDue())
+		ps.proposePending(context.Background())
+		require.Empty(t, ps.pending)
+		require.Len(t, sentCandidates(m), 1)
+	})
+}
+
+type stubDryRunL1 struct {
+	L1Client
+	calls   []ethereum.CallMsg
+	callErr error
+}
+
+func (s *stubDryRunL1) CallContract(_ context.Context, call ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+	s.calls = append(s.calls, call)
+	return nil, s.callErr
+}
+
+func TestL2OutputSubmitter_DryRun(t *testing.T) {
+	proposerAddr := common.Address{0xab}
+	output := &eth.OutputResponse{
+		Version:    supportedL2OutputVersion,
+		OutputRoot: eth.Bytes32{0xaa},
+		BlockRef:   eth.L2BlockRef{Number: 40},
+		Status:     &eth.SyncStatus{HeadL1: eth.L1BlockRef{Number: 10}, FinalizedL2: eth.L2BlockRef{Number: 45}},
+	}
+	setupDryRun := func(t *testing.T) (*L2OutputSubmitter, *stubDryRunL1, *MockL2OOContract, *mockRollupEndpointProvider) {
+		l2ooAddr := common.Address{0x0a}
+		m := txmgrmocks.NewTxManager(t)
+		m.On("BlockNumber", mock.Anything).Return(uint64(100), nil).Maybe()
+		m.On("From").Return(proposerAddr).Maybe()
+		l1 := &stubDryRunL1{}
+		l2oo := new(MockL2OOContract)
+		ep := newEndpointProvider()
+		parsed, err := bindings.L2OutputOracleMetaData.GetAbi()
+		require.NoError(t, err)
+		return &L2OutputSubmitter{
+			DriverSetup: DriverSetup{
+				Log:  testlog.Logger(t, log.LevelDebug),
+				Metr: metrics.NoopMetrics,
+				Cfg: ProposerConfig{
+					PollInterval:       time.Microsecond,
+					NetworkTimeout:     time.Second,
+					L2OutputOracleAddr: &l2ooAddr,
+					DryRun:             true,
+				},
+				Txmgr:          m,
+				L1Client:       l1,
+				RollupProvider: ep,
+			},
+			done:                   make(chan struct{}),
+			l2ooContract:           l2oo,
+			l2ooABI:                parsed,
+			l2ooSubmissionInterval: 10,
+		}, l1, l2oo, ep
+	}
+
+	t.Run("SimulateInsteadOfSend", func(t *testing.T) {
+		ps, l1, l2oo, ep := setupDryRun(t)
+		ps.proposeOutput(context.Background(), output)
+
+		// The txmgr mock fails the test if Send is called
+		require.Len(t, l1.calls, 1)
+		require.Equal(t, proposerAddr, l1.calls[0].From)
+		require.Equal(t, ps.Cfg.L2OutputOracleAddr, l1.calls[0].To)
+		status := ps.ProposalStatus()
+		require.True(t, status.DryRun)
+		require.NotNil(t, status.LastDryRunProposal)
+		require.Equal(t, []rpc.DryRunOutput{{Block: output.BlockRef.ID(), OutputRoot: output.OutputRoot}}, status.LastDryRunProposal.Outputs)
+		require.Equal(t, l1.calls[0].Data, []byte(status.LastDryRunProposal.Data))
+		require.Empty(t, status.LastDryRunProposal.Error)
+
+		// The next output follows the dry-run proposal, as the contract is unchanged
+		l2oo.On("NextBlockNumber", mock.AnythingOfType("*bind.CallOpts")).Return(big.NewInt(40), nil).Once()
+		ep.rollupClient.On("SyncStatus").Return(output.Status, nil).Once()
+		_, shouldPropose, err := ps.FetchL2OOOutput(context.Background())
+		require.NoError(t, err)
+		require.False(t, shouldPropose, "block 50 is not finalized yet")
+		l2oo.AssertExpectations(t)
+		ep.rollupClient.AssertExpectations(t)
+	})
+
+	t.Run("SimulationFails", func(t *testing.T) {
+		ps, l1, _, _ := setupDryRun(t)
+		l1.callErr = errors.New("execution reverted")
+		require.ErrorContains(t, ps.sendTransaction(context.Background(), output), "execution reverted")
+		status := ps.ProposalStatus()
+		require.NotNil(t, status.LastDryRunProposal)
+		require.Contains(t, status.LastDryRunProposal.Error, "execution reverted")
+
+		ps.proposeOutput(context.Background(), output)
+		require.Nil(t, ps.lastDryRun, "failed simulation must not count as proposal")
+	})
+
+	t.Run("DGFWaitsForProposalInterval", func(t *testing.T) {
+		dgf := &StubDGFContract{}
+		ps := &L2OutputSubmitter{
+			DriverSetup: DriverSetup{
+				Log:  testlog.Logger(t, log.LevelDebug),
+				Metr: metrics.NoopMetrics,
+				Cfg:  ProposerConfig{ProposalInterval: time.Hour, DryRun: true},
+			},
+			dgfContract: dgf,
+			lastDryRun:  &pendingOutput{output: output, added: time.Now()},
+		}
+		_, shouldPropose, err := ps.FetchDGFOutput(context.Background())
+		require.NoError(t, err)
+		require.False(t, shouldPropose)
+		require.Zero(t, dgf.hasProposedCount)
+	})
+}
+
+func sentCandidates(m *txmgrmocks.TxManager) []txmgr.TxCandidate {
+	var candidates []txmgr.TxCandidate
+	for _, call := range m.Calls {
+		if call.Method == "Send" {
+			candidates = append(candidates, call.Arguments.Get(1).(txmgr.TxCandidate))
+		}
+	}
+	return candidates
+}
+
+type stubSuperchainConfig struct {
+	paused bool
+	err    error
+}
+
+func (s *stubSuperchainConfig) Paused(_ context.Context) (bool, error) {
+	return s.paused, s.err
+}
+
+func TestL2OutputSubmitter_ProposalGating(t *testing.T) {
+	newSubmitter := func(t *testing.T, allowNonFinalized bool) (*L2OutputSubmitter, *mockRollupEndpointProvider) {
+		ep := newEndpointProvider()
+		m := txmgrmocks.NewTxManager(t)
+		m.On("From").Return(common.Address{0xab}).Maybe()
+		return &L2OutputSubmitter{
+			DriverSetup: DriverSetup{
+				Log:  testlog.Logger(t, log.LevelDebug),
+				Metr: metrics.NoopMetrics,
+				Cfg: ProposerConfig{
+					ProposalInterval:  time.Hour,
+					AllowNonFinalized: allowNonFinalized,
+				},
+				Txmgr:          m,
+				RollupProvider: ep,
+			},
+			dgfContract: &StubDGFContract{},
+		}, ep
+	}
+
+	t.Run("SuperchainPaused", func(t *testing.T) {
+		ps, _ := newSubmitter(t, false)
+		superchainConfig := &stubSuperchainConfig{paused: true}
+		ps.superchainConfig = superchainConfig
+		require.True(t, ps.superchainPaused(context.Background()))
+		status := ps.ProposalStatus()
+		require.True(t, status.SuperchainPaused)
+		require.Equal(t, "superchain is paused", status.BlockedReason)
+
+		superchainConfig.paused = false
+		require.False(t, ps.superchainPaused(context.Background()))
+		status = ps.ProposalStatus()
+		require.False(t, status.SuperchainPaused)
+		require.Empty(t, status.BlockedReason)
+	})
+
+	t.Run("PauseStatusUnavailable", func(t *testing.T) {
+		ps, _ := newSubmitter(t, false)
+		ps.superchainConfig = &stubSuperchainConfig{err: errors.New("boom")}
+		require.True(t, ps.superchainPaused(context.Background()), "proposals must be paused if the pause status is unknown")
+		status := ps.ProposalStatus()
+		require.True(t, status.SuperchainPaused)
+		require.Contains(t, status.BlockedReason, "boom")
+	})
+
+	t.Run("KeepsNotReadyReason", func(t *testing.T) {
+		ps, _ := newSubmitter(t, false)
+		superchainConfig := &stubSuperchainConfig{paused: false}
+		ps.superchainConfig = superchainConfig
+		require.False(t, ps.readyToPropose(&eth.OutputResponse{
+			BlockRef: eth.L2BlockRef{Number: 42},
+			Status:   &eth.SyncStatus{FinalizedL2: eth.L2BlockRef{Number: 40}},
+		}))
+		require.False(t, ps.superchainPaused(context.Background()))
+		require.Equal(t, "L2 block 42 is not finalized", ps.ProposalStatus().BlockedReason)
+
+		superchainConfig.paused = true
+		require.True(t, ps.superchainPaused(context.Background()))
+		require.Equal(t, "superchain is paused", ps.ProposalStatus().BlockedReason)
+	})
+
+	t.Run("NoSuperchainConfig", func(t *testing.T) {
+		ps, _ := newSubmitter(t, false)
+		require.False(t, ps.superchainPaused(context.Background()))
+	})
+
+	for _, allowNonFinalized := range []bool{false, true} {
+		allowNonFinalized := allowNonFinalized
+		t.Run(fmt.Sprintf("DGFRechecksOutput-AllowNonFinalized-%v", allowNonFinalized), func(t *testing.T) {
+			ps, ep := newSubmitter(t, allowNonFinalized)
+			ep.rollupClient.On("SyncStatus").Return(&eth.SyncStatus{
+				SafeL2:      eth.L2BlockRef{Number: 42},
+				FinalizedL2: eth.L2BlockRef{Number: 42},
+			}, nil).Once()
+			// The L2 chain was reorged by the time the output is fetched, so block 42 is only safe now
+			fetchedStatus := &eth.SyncStatus{
+				SafeL2:      eth.L2BlockRef{Number: 42},
+				FinalizedL2: eth.L2BlockRef{Number: 40},
+			}
+			ep.rollupClient.ExpectOutputAtBlock(42, &eth.OutputResponse{
+				Version:  supportedL2OutputVersion,
+				BlockRef: eth.L2BlockRef{Number: 42},
+				Status:   fetchedStatus,
+			}, nil)
+
+			_, shouldPropose, err := ps.FetchDGFOutput(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, allowNonFinalized, shouldPropose)
+
+			status := ps.ProposalStatus()
+			require.Equal(t, allowNonFinalized, status.AllowNonFinalized)
+			require.Equal(t, fetchedStatus.SafeL2.ID(), status.SafeL2)
+			require.Equal(t, fetchedStatus.FinalizedL2.ID(), status.FinalizedL2)
+			if allowNonFinalized {
+				require.Empty(t, status.BlockedReason)
+			} else {
+				require.Equal(t, "L2 block 42 is not finalized", status.BlockedReason)
+			}
+		})
+	}
+}
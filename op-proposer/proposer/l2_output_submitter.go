@@ -20,6 +20,9 @@ func Main(version string) cliapp.LifecycleAction {
 			return nil, err
 		}
 		cfg := NewConfig(cliCtx)
+		if err := cfg.applyNetwork(); err != nil {
+			return nil, fmt.Errorf("invalid network: %w", err)
+		}
 		if err := cfg.Check(); err != nil {
 			return nil, fmt.Errorf("invalid CLI flags: %w", err)
 		}
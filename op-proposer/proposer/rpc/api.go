@@ -2,10 +2,14 @@ package rpc
 
 import (
 	"context"
+	"time"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/log"
 	gethrpc "github.com/ethereum/go-ethereum/rpc"
 
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/rpc"
 )
@@ -13,6 +17,58 @@ import (
 type ProposerDriver interface {
 	StartL2OutputSubmitting() error
 	StopL2OutputSubmitting() error
+	GameTypeStatuses() []GameTypeStatus
+	ProposalStatus() ProposalStatus
+}
+
+// GameTypeStatus reports the health of a dispute game type the proposer is configured to use.
+type GameTypeStatus struct {
+	GameType uint32 `json:"gameType"`
+	// Available is false if the last proposal attempt with this game type failed.
+	Available bool `json:"available"`
+	// LastError is the error of the last failed proposal attempt, if the game type is unavailable.
+	LastError string `json:"lastError,omitempty"`
+	// LastProposal is the time of the last successful proposal with this game type.
+	LastProposal time.Time `json:"lastProposal"`
+}
+
+// ProposalStatus reports whether the proposer is currently allowed to propose, based on the chain safety signals.
+type ProposalStatus struct {
+	// SuperchainPaused is true if proposals are paused because the superchain is paused,
+	// or because its pause status could not be checked.
+	SuperchainPaused bool `json:"superchainPaused"`
+	// AllowNonFinalized is true if safe, but non-finalized, L2 blocks may be proposed.
+	AllowNonFinalized bool `json:"allowNonFinalized"`
+	// SafeL2 and FinalizedL2 are the L2 heads of the rollup node at the last proposal check.
+	SafeL2      eth.BlockID `json:"safeL2"`
+	FinalizedL2 eth.BlockID `json:"finalizedL2"`
+	// BlockedReason explains why the last proposal check did not allow a proposal. Empty if proposals are not blocked.
+	BlockedReason string `json:"blockedReason,omitempty"`
+	// UpdatedAt is the time of the last proposal check.
+	UpdatedAt time.Time `json:"updatedAt"`
+	// DryRun is true if proposals are only simulated, and never submitted.
+	DryRun bool `json:"dryRun"`
+	// LastDryRunProposal is the last proposal transaction that was simulated in dry-run mode. Nil if none was.
+	LastDryRunProposal *DryRunProposal `json:"lastDryRunProposal,omitempty"`
+}
+
+// DryRunProposal is a proposal transaction that was simulated, instead of submitted, in dry-run mode.
+type DryRunProposal struct {
+	// Outputs are the proposed L2 blocks and their output roots.
+	Outputs []DryRunOutput `json:"outputs"`
+	// To, Value and Data are the transaction that would have been submitted.
+	To    *common.Address `json:"to"`
+	Value *hexutil.Big    `json:"value,omitempty"`
+	Data  hexutil.Bytes   `json:"data"`
+	// Error is the reason the simulation of the transaction failed. Empty if it succeeded.
+	Error string `json:"error,omitempty"`
+	// Time is the time the transaction was simulated.
+	Time time.Time `json:"time"`
+}
+
+type DryRunOutput struct {
+	Block      eth.BlockID `json:"block"`
+	OutputRoot eth.Bytes32 `json:"outputRoot"`
 }
 
 type adminAPI struct {
@@ -41,3 +97,13 @@ func (a *adminAPI) StartProposer(_ context.Context) error {
 func (a *adminAPI) StopProposer(ctx context.Context) error {
 	return a.b.StopL2OutputSubmitting()
 }
+
+// GameTypeStatuses returns the status of each configured dispute game type, in priority order.
+func (a *adminAPI) GameTypeStatuses(_ context.Context) ([]GameTypeStatus, error) {
+	return a.b.GameTypeStatuses(), nil
+}
+
+// ProposalStatus returns whether the proposer is currently allowed to propose, and why not.
+func (a *adminAPI) ProposalStatus(_ context.Context) (ProposalStatus, error) {
+	return a.b.ProposalStatus(), nil
+}
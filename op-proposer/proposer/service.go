@@ -16,6 +16,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/dial"
 	"github.com/ethereum-optimism/optimism/op-service/httputil"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/objectstore"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
 	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
 	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
@@ -39,6 +40,9 @@ type ProposerConfig struct {
 	L2OutputOracleAddr     *common.Address
 	DisputeGameFactoryAddr *common.Address
 	DisputeGameType        uint32
+	// DisputeGameTypes is the prioritized list of game types to propose with.
+	// If empty, only DisputeGameType is used.
+	DisputeGameTypes []uint32
 
 	// AllowNonFinalized enables the proposal of safe, but non-finalized L2 blocks.
 	// The L1 block-hash embedded in the proposal TX is checked and should ensure the proposal
@@ -47,6 +51,27 @@ type ProposerConfig struct {
 	AllowNonFinalized bool
 
 	WaitNodeSync bool
+
+	// ProposalBatchMaxSize is the number of outputs that are held back and proposed together,
+	// each in its own transaction from the proposer account. Proposal batching is disabled if not greater than 1.
+	ProposalBatchMaxSize uint64
+	// ProposalBatchMaxWait is the maximum duration a pending proposal is held back while the batch fills up.
+	ProposalBatchMaxWait time.Duration
+
+	// SuperchainConfigAddr is the SuperchainConfig contract to check the pause status of before proposing.
+	// Proposals are not gated on the pause status if nil.
+	SuperchainConfigAddr *common.Address
+
+	// DryRun only simulates the proposal transactions with eth_call, and records them instead of submitting them.
+	DryRun bool
+}
+
+// GameTypes returns the prioritized list of dispute game types to propose with.
+func (c *ProposerConfig) GameTypes() []uint32 {
+	if len(c.DisputeGameTypes) > 0 {
+		return c.DisputeGameTypes
+	}
+	return []uint32{c.DisputeGameType}
 }
 
 type ProposerService struct {
@@ -58,6 +83,10 @@ type ProposerService struct {
 	TxManager      txmgr.TxManager
 	L1Client       *ethclient.Client
 	RollupProvider dial.RollupProvider
+	// VerifyRollupProvider is nil if output root verification is disabled.
+	VerifyRollupProvider dial.RollupProvider
+	// OutputArchive is nil if output archiving is disabled.
+	OutputArchive *OutputArchive
 
 	driver *L2OutputSubmitter
 
@@ -93,9 +122,19 @@ func (ps *ProposerService) initFromCLIConfig(ctx context.Context, version string
 	ps.NetworkTimeout = cfg.TxMgrConfig.NetworkTimeout
 	ps.AllowNonFinalized = cfg.AllowNonFinalized
 	ps.WaitNodeSync = cfg.WaitNodeSync
+	ps.DryRun = cfg.DryRun
 
 	ps.initL2ooAddress(cfg)
 	ps.initDGF(cfg)
+	if err := ps.initProposalBatching(cfg); err != nil {
+		return err
+	}
+	if err := ps.initSuperchainConfig(cfg); err != nil {
+		return err
+	}
+	if err := ps.initOutputArchive(cfg); err != nil {
+		return err
+	}
 
 	if err := ps.initRPCClients(ctx, cfg); err != nil {
 		return err
@@ -140,6 +179,14 @@ func (ps *ProposerService) initRPCClients(ctx context.Context, cfg *CLIConfig) e
 		return fmt.Errorf("failed to build L2 endpoint provider: %w", err)
 	}
 	ps.RollupProvider = rollupProvider
+
+	if cfg.VerifyRollupRpc != "" {
+		verifyProvider, err := dial.NewStaticL2RollupProvider(ctx, ps.Log, cfg.VerifyRollupRpc)
+		if err != nil {
+			return fmt.Errorf("failed to build verification L2 endpoint provider: %w", err)
+		}
+		ps.VerifyRollupProvider = verifyProvider
+	}
 	return nil
 }
 
@@ -176,6 +223,7 @@ func (ps *ProposerService) initPProf(cfg *CLIConfig) error {
 		cfg.PprofConfig.ProfileType,
 		cfg.PprofConfig.ProfileDir,
 		cfg.PprofConfig.ProfileFilename,
+		cfg.PprofConfig.Upload,
 	)
 
 	if err := ps.pprofService.Start(); err != nil {
@@ -222,17 +270,59 @@ func (ps *ProposerService) initDGF(cfg *CLIConfig) {
 	ps.DisputeGameFactoryAddr = &dgfAddress
 	ps.ProposalInterval = cfg.ProposalInterval
 	ps.DisputeGameType = cfg.DisputeGameType
+	ps.DisputeGameTypes = cfg.DisputeGameTypes
+}
+
+func (ps *ProposerService) initProposalBatching(cfg *CLIConfig) error {
+	ps.ProposalBatchMaxSize = max(cfg.ProposalBatchMaxSize, 1)
+	ps.ProposalBatchMaxWait = cfg.ProposalBatchMaxWait
+	return nil
+}
+
+func (ps *ProposerService) initSuperchainConfig(cfg *CLIConfig) error {
+	if cfg.SuperchainConfigAddress == "" {
+		return nil
+	}
+	addr, err := opservice.ParseAddress(cfg.SuperchainConfigAddress)
+	if err != nil {
+		return fmt.Errorf("invalid superchain config address: %w", err)
+	}
+	ps.SuperchainConfigAddr = &addr
+	return nil
+}
+
+func (ps *ProposerService) initOutputArchive(cfg *CLIConfig) error {
+	if cfg.OutputArchiveEndpoint == "" {
+		return nil
+	}
+	store, err := objectstore.NewS3Store(objectstore.S3Config{
+		Endpoint:        cfg.OutputArchiveEndpoint,
+		Bucket:          cfg.OutputArchiveBucket,
+		AccessKeyID:     cfg.OutputArchiveAccessKeyID,
+		AccessKeySecret: cfg.OutputArchiveAccessKeySecret,
+		Insecure:        cfg.OutputArchiveInsecure,
+		ContentType:     "application/json",
+	})
+	if err != nil {
+		return fmt.Errorf("failed to init output archive: %w", err)
+	}
+	ps.OutputArchive = NewOutputArchive(ps.Log, store, cfg.OutputArchivePrefix)
+	ps.Log.Info("Archiving proposed outputs", "endpoint", cfg.OutputArchiveEndpoint,
+		"bucket", cfg.OutputArchiveBucket, "prefix", cfg.OutputArchivePrefix)
+	return nil
 }
 
 func (ps *ProposerService) initDriver() error {
 	driver, err := NewL2OutputSubmitter(DriverSetup{
-		Log:            ps.Log,
-		Metr:           ps.Metrics,
-		Cfg:            ps.ProposerConfig,
-		Txmgr:          ps.TxManager,
-		L1Client:       ps.L1Client,
-		Multicaller:    batching.NewMultiCaller(ps.L1Client.Client(), batching.DefaultBatchSize),
-		RollupProvider: ps.RollupProvider,
+		Log:                  ps.Log,
+		Metr:                 ps.Metrics,
+		Cfg:                  ps.ProposerConfig,
+		Txmgr:                ps.TxManager,
+		L1Client:             ps.L1Client,
+		Multicaller:          batching.NewMultiCaller(ps.L1Client.Client(), batching.DefaultBatchSize),
+		RollupProvider:       ps.RollupProvider,
+		VerifyRollupProvider: ps.VerifyRollupProvider,
+		OutputArchive:        ps.OutputArchive,
 	})
 	if err != nil {
 		return err
@@ -242,11 +332,16 @@ func (ps *ProposerService) initDriver() error {
 }
 
 func (ps *ProposerService) initRPCServer(cfg *CLIConfig) error {
+	limits, err := cfg.RPCConfig.Limits()
+	if err != nil {
+		return fmt.Errorf("invalid RPC limits: %w", err)
+	}
 	server := oprpc.NewServer(
 		cfg.RPCConfig.ListenAddr,
 		cfg.RPCConfig.ListenPort,
 		ps.Version,
 		oprpc.WithLogger(ps.Log),
+		oprpc.WithLimits(limits, ps.Metrics),
 	)
 	if cfg.RPCConfig.EnableAdmin {
 		adminAPI := rpc.NewAdminAPI(ps.driver, ps.Metrics, ps.Log)
@@ -295,6 +390,10 @@ func (ps *ProposerService) Stop(ctx context.Context) error {
 		}
 	}
 
+	if ps.OutputArchive != nil {
+		ps.OutputArchive.Close()
+	}
+
 	if ps.rpcServer != nil {
 		// TODO(7685): the op-service RPC server is not built on top of op-service httputil Server, and has poor shutdown
 		if err := ps.rpcServer.Stop(); err != nil {
@@ -330,6 +429,10 @@ func (ps *ProposerService) Stop(ctx context.Context) error {
 		ps.RollupProvider.Close()
 	}
 
+	if ps.VerifyRollupProvider != nil {
+		ps.VerifyRollupProvider.Close()
+	}
+
 	if result == nil {
 		ps.stopped.Store(true)
 		ps.Log.Info("L2Output Submitter stopped")
@@ -0,0 +1,281 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-service/metrics"
+)
+
+// ErrCircuitOpen is returned for requests that are rejected because the circuit breaker of the endpoint is open.
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+type CircuitState int
+
+const (
+	CircuitClosed CircuitState = iota
+	CircuitHalfOpen
+	CircuitOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitHalfOpen:
+		return "half-open"
+	case CircuitOpen:
+		return "open"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(s))
+	}
+}
+
+type CircuitBreakerConfig struct {
+	// FailureThreshold is the number of consecutive failed requests after which the circuit opens.
+	// The circuit breaker is disabled if 0.
+	FailureThreshold int
+	// OpenDuration is how long the circuit stays open before a probe request is let through.
+	OpenDuration time.Duration
+}
+
+func (c CircuitBreakerConfig) Enabled() bool {
+	return c.FailureThreshold > 0
+}
+
+func (c CircuitBreakerConfig) Check() error {
+	if c.FailureThreshold < 0 {
+		return fmt.Errorf("circuit breaker failure threshold cannot be negative, was %d", c.FailureThreshold)
+	}
+	if c.Enabled() && c.OpenDuration <= 0 {
+		return errors.New("circuit breaker open duration must be positive")
+	}
+	return nil
+}
+
+// CircuitBreaker stops requests to an endpoint after consecutive failures, so a misbehaving endpoint fails fast
+// instead of consuming the timeout of every request.
+// After the open duration, the circuit is half-open: a single probe request is let through,
+// which closes the circuit if it succeeds, and opens it again if it fails.
+// Only failures of the endpoint itself count: JSON-RPC errors are valid responses of a healthy endpoint,
+// except for rate limiting errors.
+type CircuitBreaker struct {
+	log      log.Logger
+	endpoint string
+	cfg      CircuitBreakerConfig
+	m        metrics.CircuitBreakerMetricer
+	now      func() time.Time
+
+	mu       sync.Mutex
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// NewCircuitBreaker creates a circuit breaker for the endpoint, which is used to label logs and metrics.
+func NewCircuitBreaker(lgr log.Logger, endpoint string, cfg CircuitBreakerConfig, m metrics.CircuitBreakerMetricer) *CircuitBreaker {
+	if m == nil {
+		m = &metrics.NoopCircuitBreakerMetrics{}
+	}
+	b := &CircuitBreaker{
+		log:      lgr.New("endpoint", endpoint),
+		endpoint: endpoint,
+		cfg:      cfg,
+		m:        m,
+		now:      time.Now,
+	}
+	m.RecordCircuitBreakerState(endpoint, int(CircuitClosed))
+	return b
+}
+
+// State returns the current state of the circuit.
+func (b *CircuitBreaker) State() CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// Do calls fn if the circuit allows it, and records whether the endpoint failed.
+func (b *CircuitBreaker) Do(ctx context.Context, fn func() error) error {
+	if err := b.allow(); err != nil {
+		return err
+	}
+	err := fn()
+	switch {
+	case errors.Is(ctx.Err(), context.Canceled):
+		// The caller gave up on the request, so it says nothing about the health of the endpoint.
+		b.abort()
+	case err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded):
+		// The endpoint did not respond within the deadline of the caller.
+		b.complete(true)
+	default:
+		b.complete(err != nil && IsEndpointFailure(ctx, err))
+	}
+	return err
+}
+
+func (b *CircuitBreaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case CircuitOpen:
+		if b.now().Sub(b.openedAt) < b.cfg.OpenDuration {
+			break
+		}
+		b.setState(CircuitHalfOpen)
+		fallthrough
+	case CircuitHalfOpen:
+		if b.probing {
+			break
+		}
+		b.probing = true
+		return nil
+	default:
+		return nil
+	}
+	b.m.RecordCircuitBreakerRejection(b.endpoint)
+	return fmt.Errorf("%w: %s", ErrCircuitOpen, b.endpoint)
+}
+
+func (b *CircuitBreaker) complete(failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	wasProbe := b.state == CircuitHalfOpen && b.probing
+	if wasProbe {
+		b.probing = false
+	}
+	if !failed {
+		b.failures = 0
+		if wasProbe {
+			b.log.Info("Endpoint recovered, closing circuit")
+			b.setState(CircuitClosed)
+		}
+		return
+	}
+	b.failures++
+	if wasProbe || (b.state == CircuitClosed && b.failures >= b.cfg.FailureThreshold) {
+		b.log.Warn("Endpoint failing, opening circuit", "failures", b.failures, "duration", b.cfg.OpenDuration)
+		b.openedAt = b.now()
+		b.setState(CircuitOpen)
+	}
+}
+
+func (b *CircuitBreaker) abort() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == CircuitHalfOpen {
+		b.probing = false
+	}
+}
+
+func (b *CircuitBreaker) setState(state CircuitState) {
+	b.state = state
+	b.m.RecordCircuitBreakerState(b.endpoint, int(state))
+}
+
+// HTTPClient returns an HTTP client that sends its requests through the circuit breaker.
+// This allows go-ethereum RPC clients to use the circuit breaker, see rpc.WithHTTPClient.
+// Only HTTP transport errors, server errors and rate limiting count as failures of the endpoint.
+func (b *CircuitBreaker) HTTPClient() *http.Client {
+	return &http.Client{Transport: &circuitBreakerTransport{b: b, base: http.DefaultTransport}}
+}
+
+type circuitBreakerTransport struct {
+	b    *CircuitBreaker
+	base http.RoundTripper
+}
+
+func (t *circuitBreakerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var resp *http.Response
+	err := t.b.Do(req.Context(), func() error {
+		var err error
+		resp, err = t.base.RoundTrip(req)
+		if err != nil {
+			return err
+		}
+		return responseError(resp)
+	})
+	if resp != nil {
+		// Server errors are returned as response, to be handled by the RPC client.
+		return resp, nil
+	}
+	return nil, err
+}
+
+func responseError(resp *http.Response) error {
+	if resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests {
+		return rpc.HTTPError{StatusCode: resp.StatusCode, Status: resp.Status}
+	}
+	return nil
+}
+
+// CircuitBreakerRPC is an RPC client that sends all requests through a circuit breaker.
+type CircuitBreakerRPC struct {
+	c RPC
+	b *CircuitBreaker
+}
+
+var _ RPC = (*CircuitBreakerRPC)(nil)
+
+func NewCircuitBreakerRPC(c RPC, b *CircuitBreaker) *CircuitBreakerRPC {
+	return &CircuitBreakerRPC{c: c, b: b}
+}
+
+func (c *CircuitBreakerRPC) Close() {
+	c.c.Close()
+}
+
+func (c *CircuitBreakerRPC) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	return c.b.Do(ctx, func() error {
+		return c.c.CallContext(ctx, result, method, args...)
+	})
+}
+
+// BatchCallContext sends the batch through the circuit breaker.
+// Errors of individual batch elements are responses of the endpoint, so only the error of the batch as a whole counts.
+func (c *CircuitBreakerRPC) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	return c.b.Do(ctx, func() error {
+		return c.c.BatchCallContext(ctx, b)
+	})
+}
+
+func (c *CircuitBreakerRPC) EthSubscribe(ctx context.Context, channel any, args ...any) (ethereum.Subscription, error) {
+	var sub ethereum.Subscription
+	err := c.b.Do(ctx, func() error {
+		var err error
+		sub, err = c.c.EthSubscribe(ctx, channel, args...)
+		return err
+	})
+	return sub, err
+}
+
+// EndpointName returns the host of the endpoint address, to identify the endpoint in logs and metrics
+// without exposing credentials that may be part of the address.
+func EndpointName(addr string) string {
+	u, err := url.Parse(addr)
+	if err != nil || u.Host == "" {
+		return "unknown"
+	}
+	return u.Host
+}
+
+// CircuitBreakerDialOptions returns the go-ethereum RPC client options to send requests to the HTTP endpoint
+// through a circuit breaker. Returns no options if the circuit breaker is disabled.
+// The options have no effect on websocket endpoints.
+func CircuitBreakerDialOptions(lgr log.Logger, addr string, cfg CircuitBreakerConfig, m metrics.CircuitBreakerMetricer) []rpc.ClientOption {
+	if !cfg.Enabled() {
+		return nil
+	}
+	breaker := NewCircuitBreaker(lgr, EndpointName(addr), cfg, m)
+	return []rpc.ClientOption{rpc.WithHTTPClient(breaker.HTTPClient())}
+}
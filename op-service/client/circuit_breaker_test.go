@@ -0,0 +1,151 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type testCircuitMetrics struct {
+	states     map[string]int
+	rejections int
+}
+
+func (m *testCircuitMetrics) RecordCircuitBreakerState(endpoint string, state int) {
+	m.states[endpoint] = state
+}
+
+func (m *testCircuitMetrics) RecordCircuitBreakerRejection(endpoint string) {
+	m.rejections++
+}
+
+func newTestCircuitBreaker(t *testing.T) (*CircuitBreaker, *testCircuitMetrics, *time.Time) {
+	m := &testCircuitMetrics{states: make(map[string]int)}
+	b := NewCircuitBreaker(testlog.Logger(t, log.LevelInfo), "l1", CircuitBreakerConfig{
+		FailureThreshold: 3,
+		OpenDuration:     time.Minute,
+	}, m)
+	now := time.Unix(1000, 0)
+	b.now = func() time.Time { return now }
+	return b, m, &now
+}
+
+func TestCircuitBreakerRPC(t *testing.T) {
+	ctx := context.Background()
+	b, m, now := newTestCircuitBreaker(t)
+	stub := &poolTestRPC{err: errors.New("connection refused")}
+	cl := NewCircuitBreakerRPC(stub, b)
+	var result any
+
+	// JSON-RPC errors are responses of a healthy endpoint
+	stub.err = &poolTestRPCError{code: -32000}
+	for i := 0; i < 5; i++ {
+		require.ErrorIs(t, cl.CallContext(ctx, &result, "eth_call"), stub.err)
+	}
+	require.Equal(t, CircuitClosed, b.State())
+
+	// Consecutive failures open the circuit, rejecting requests without calling the endpoint
+	stub.err = errors.New("connection refused")
+	for i := 0; i < 3; i++ {
+		require.ErrorContains(t, cl.CallContext(ctx, &result, "eth_chainId"), "connection refused")
+	}
+	require.Equal(t, CircuitOpen, b.State())
+	require.Equal(t, int(CircuitOpen), m.states["l1"])
+	calls := stub.calls
+	require.ErrorIs(t, cl.BatchCallContext(ctx, nil), ErrCircuitOpen)
+	require.Equal(t, calls, stub.calls)
+	require.Equal(t, 1, m.rejections)
+
+	// A failed probe opens the circuit again
+	*now = now.Add(time.Minute)
+	require.ErrorContains(t, cl.CallContext(ctx, &result, "eth_chainId"), "connection refused")
+	require.Equal(t, CircuitOpen, b.State())
+	require.ErrorIs(t, cl.CallContext(ctx, &result, "eth_chainId"), ErrCircuitOpen)
+
+	// A successful probe closes the circuit
+	*now = now.Add(time.Minute)
+	stub.err = nil
+	require.NoError(t, cl.CallContext(ctx, &result, "eth_chainId"))
+	require.Equal(t, CircuitClosed, b.State())
+	require.Equal(t, int(CircuitClosed), m.states["l1"])
+}
+
+func TestCircuitBreakerHalfOpenSingleProbe(t *testing.T) {
+	b, _, now := newTestCircuitBreaker(t)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, b.allow())
+		b.complete(true)
+	}
+	*now = now.Add(time.Minute)
+	require.NoError(t, b.allow())
+	require.Equal(t, CircuitHalfOpen, b.State())
+	require.ErrorIs(t, b.allow(), ErrCircuitOpen, "only a single probe at a time")
+
+	// A probe cancelled by the caller lets the next request probe
+	b.abort()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.ErrorIs(t, b.Do(ctx, func() error { return ctx.Err() }), context.Canceled)
+	require.Equal(t, CircuitHalfOpen, b.State())
+	require.NoError(t, b.allow())
+}
+
+func TestCircuitBreakerDeadlineCountsAsFailure(t *testing.T) {
+	b, _, _ := newTestCircuitBreaker(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+	for i := 0; i < 3; i++ {
+		require.ErrorIs(t, b.Do(ctx, func() error { return ctx.Err() }), context.DeadlineExceeded)
+	}
+	require.Equal(t, CircuitOpen, b.State())
+}
+
+func TestCircuitBreakerHTTPClient(t *testing.T) {
+	status := http.StatusServiceUnavailable
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	b, _, _ := newTestCircuitBreaker(t)
+	cl := b.HTTPClient()
+
+	// Client errors are responses of a healthy endpoint
+	status = http.StatusBadRequest
+	for i := 0; i < 3; i++ {
+		resp, err := cl.Get(server.URL)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		resp.Body.Close()
+	}
+	require.Equal(t, CircuitClosed, b.State())
+
+	// Server errors are returned to the caller, and open the circuit
+	status = http.StatusServiceUnavailable
+	for i := 0; i < 3; i++ {
+		resp, err := cl.Get(server.URL)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		resp.Body.Close()
+	}
+	require.Equal(t, CircuitOpen, b.State())
+	_, err := cl.Get(server.URL)
+	require.ErrorIs(t, err, ErrCircuitOpen)
+	require.Equal(t, 6, requests)
+}
+
+func TestEndpointName(t *testing.T) {
+	require.Equal(t, "eth.example.com:8545", EndpointName("https://eth.example.com:8545/v2/secret-key"))
+	require.Equal(t, "localhost:8545", EndpointName("ws://user:pass@localhost:8545"))
+	require.Equal(t, "unknown", EndpointName("not a url"))
+}
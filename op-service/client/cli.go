@@ -0,0 +1,47 @@
+package client
+
+import (
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+)
+
+const (
+	CircuitBreakerThresholdFlagName    = "rpc-client.circuit-breaker.threshold"
+	CircuitBreakerOpenDurationFlagName = "rpc-client.circuit-breaker.open-duration"
+
+	DefaultCircuitBreakerOpenDuration = 30 * time.Second
+)
+
+func CircuitBreakerCLIFlags(envPrefix string) []cli.Flag {
+	return CircuitBreakerCLIFlagsWithCategory(envPrefix, "")
+}
+
+func CircuitBreakerCLIFlagsWithCategory(envPrefix string, category string) []cli.Flag {
+	return []cli.Flag{
+		&cli.IntFlag{
+			Name: CircuitBreakerThresholdFlagName,
+			Usage: "Number of consecutive failed requests to an L1 RPC endpoint after which requests to it fail fast " +
+				"until the endpoint recovers. Disabled if 0.",
+			Value:    0,
+			EnvVars:  opservice.PrefixEnvVar(envPrefix, "RPC_CLIENT_CIRCUIT_BREAKER_THRESHOLD"),
+			Category: category,
+		},
+		&cli.DurationFlag{
+			Name:     CircuitBreakerOpenDurationFlagName,
+			Usage:    "Duration to fail requests to a failing L1 RPC endpoint for, before probing whether it recovered.",
+			Value:    DefaultCircuitBreakerOpenDuration,
+			EnvVars:  opservice.PrefixEnvVar(envPrefix, "RPC_CLIENT_CIRCUIT_BREAKER_OPEN_DURATION"),
+			Category: category,
+		},
+	}
+}
+
+func ReadCircuitBreakerCLIConfig(ctx *cli.Context) CircuitBreakerConfig {
+	return CircuitBreakerConfig{
+		FailureThreshold: ctx.Int(CircuitBreakerThresholdFlagName),
+		OpenDuration:     ctx.Duration(CircuitBreakerOpenDurationFlagName),
+	}
+}
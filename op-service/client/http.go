@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/log"
+	"golang.org/x/time/rate"
 )
 
 const (
@@ -25,8 +26,9 @@ type BasicHTTPClient struct {
 	endpoint string
 	header   http.Header // optional header to use in every request
 
-	log    log.Logger
-	client *http.Client
+	log     log.Logger
+	client  *http.Client
+	limiter *rate.Limiter // optional self-imposed rate limit of requests
 }
 
 func NewBasicHTTPClient(endpoint string, log log.Logger, opts ...BasicHTTPClientOption) *BasicHTTPClient {
@@ -59,12 +61,24 @@ func WithHeader(h http.Header) BasicHTTPClientOption {
 	})
 }
 
+// WithHTTPRateLimit limits the rate of requests to the endpoint to the budget.
+func WithHTTPRateLimit(budget RateBudget) BasicHTTPClientOption {
+	return BasicHTTPClientOptionFn(func(c *BasicHTTPClient) {
+		c.limiter = budget.Limiter()
+	})
+}
+
 var ErrNoEndpoint = errors.New("no endpoint is configured")
 
 func (cl *BasicHTTPClient) Get(ctx context.Context, p string, query url.Values, headers http.Header) (*http.Response, error) {
 	if cl.endpoint == "" {
 		return nil, ErrNoEndpoint
 	}
+	if cl.limiter != nil {
+		if err := cl.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
 	target, err := url.Parse(cl.endpoint)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse endpoint URL: %w", err)
@@ -0,0 +1,129 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// ReloadableJWTSecret is a JWT secret for engine API authentication that can be reloaded from its file,
+// so the secret can be rotated without a restart.
+type ReloadableJWTSecret struct {
+	path string
+
+	mu     sync.Mutex
+	secret [32]byte
+}
+
+// NewReloadableJWTSecret creates a JWT secret that was loaded from path.
+// The secret cannot be reloaded if path is empty.
+func NewReloadableJWTSecret(path string, secret [32]byte) *ReloadableJWTSecret {
+	return &ReloadableJWTSecret{path: path, secret: secret}
+}
+
+// ParseJWTSecret parses a 32 byte hex encoded JWT secret.
+func ParseJWTSecret(data []byte) ([32]byte, error) {
+	var secret [32]byte
+	jwtSecret := common.FromHex(strings.TrimSpace(string(data)))
+	if len(jwtSecret) != 32 {
+		return secret, errors.New("invalid jwt secret, not 32 hex-formatted bytes")
+	}
+	copy(secret[:], jwtSecret)
+	return secret, nil
+}
+
+// Reload reads the secret from its file again, and returns whether it changed.
+func (s *ReloadableJWTSecret) Reload() (bool, error) {
+	if s.path == "" {
+		return false, nil
+	}
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return false, fmt.Errorf("failed to read jwt secret: %w", err)
+	}
+	secret, err := ParseJWTSecret(data)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", s.path, err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	changed := secret != s.secret
+	s.secret = secret
+	return changed, nil
+}
+
+// HTTPAuth authenticates each HTTP request with the current secret.
+// Websocket connections are only authenticated when they are established.
+func (s *ReloadableJWTSecret) HTTPAuth() rpc.HTTPAuth {
+	return func(h http.Header) error {
+		s.mu.Lock()
+		secret := s.secret
+		s.mu.Unlock()
+		return node.NewJWTAuth(secret)(h)
+	}
+}
+
+// JWTReloadRPC reloads the JWT secret when the endpoint rejects the authentication of a request,
+// and retries the request once if the secret changed.
+type JWTReloadRPC struct {
+	RPC
+	log    log.Logger
+	secret *ReloadableJWTSecret
+}
+
+var _ RPC = (*JWTReloadRPC)(nil)
+
+// NewJWTReloadRPC wraps an RPC that authenticates its requests with the secret.
+func NewJWTReloadRPC(log log.Logger, rpc RPC, secret *ReloadableJWTSecret) *JWTReloadRPC {
+	return &JWTReloadRPC{RPC: rpc, log: log, secret: secret}
+}
+
+func (r *JWTReloadRPC) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	return r.do(func() error {
+		return r.RPC.CallContext(ctx, result, method, args...)
+	})
+}
+
+func (r *JWTReloadRPC) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	return r.do(func() error {
+		return r.RPC.BatchCallContext(ctx, b)
+	})
+}
+
+func (r *JWTReloadRPC) EthSubscribe(ctx context.Context, channel any, args ...any) (ethereum.Subscription, error) {
+	var sub ethereum.Subscription
+	err := r.do(func() error {
+		var err error
+		sub, err = r.RPC.EthSubscribe(ctx, channel, args...)
+		return err
+	})
+	return sub, err
+}
+
+func (r *JWTReloadRPC) do(fn func() error) error {
+	err := fn()
+	var httpErr rpc.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusUnauthorized {
+		return err
+	}
+	changed, reloadErr := r.secret.Reload()
+	if reloadErr != nil {
+		r.log.Warn("Failed to reload JWT secret", "err", reloadErr)
+		return err
+	}
+	if !changed {
+		return err
+	}
+	r.log.Info("Reloaded JWT secret, retrying request")
+	return fn()
+}
@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// jwtTestRPC rejects requests that are not authenticated with the expected secret.
+type jwtTestRPC struct {
+	poolTestRPC
+	secret   *ReloadableJWTSecret
+	expected [32]byte
+}
+
+func (m *jwtTestRPC) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	m.calls++
+	m.secret.mu.Lock()
+	defer m.secret.mu.Unlock()
+	if m.secret.secret != m.expected {
+		return rpc.HTTPError{StatusCode: http.StatusUnauthorized, Status: "401 Unauthorized"}
+	}
+	return nil
+}
+
+func TestJWTReloadRPC(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jwt.txt")
+	oldSecret, newSecret := [32]byte{0x01}, [32]byte{0x02}
+	require.NoError(t, os.WriteFile(path, []byte(hexutil.Encode(oldSecret[:])), 0o600))
+
+	secret := NewReloadableJWTSecret(path, oldSecret)
+	inner := &jwtTestRPC{secret: secret, expected: oldSecret}
+	cl := NewJWTReloadRPC(testlog.Logger(t, log.LevelInfo), inner, secret)
+	require.NoError(t, cl.CallContext(context.Background(), nil, "eth_chainId"))
+	require.Equal(t, 1, inner.calls)
+
+	// The engine rotated its secret, but the file was not updated yet.
+	inner.expected = newSecret
+	inner.calls = 0
+	require.ErrorAs(t, cl.CallContext(context.Background(), nil, "eth_chainId"), new(rpc.HTTPError))
+	require.Equal(t, 1, inner.calls, "must not retry if the secret did not change")
+
+	require.NoError(t, os.WriteFile(path, []byte(hexutil.Encode(newSecret[:])+"\n"), 0o600))
+	inner.calls = 0
+	require.NoError(t, cl.CallContext(context.Background(), nil, "eth_chainId"))
+	require.Equal(t, 2, inner.calls, "must retry with the reloaded secret")
+
+	require.NoError(t, os.WriteFile(path, []byte("0xinvalid"), 0o600))
+	changed, err := secret.Reload()
+	require.Error(t, err)
+	require.False(t, changed)
+	require.NoError(t, cl.CallContext(context.Background(), nil, "eth_chainId"), "invalid secret file must not replace the secret")
+}
+
+func TestParseJWTSecret(t *testing.T) {
+	secret, err := ParseJWTSecret([]byte(" 0x" + "ab" + "00000000000000000000000000000000000000000000000000000000000000\n"))
+	require.NoError(t, err)
+	require.Equal(t, [32]byte{0xab}, secret)
+
+	_, err = ParseJWTSecret([]byte("0xabcd"))
+	require.Error(t, err)
+}
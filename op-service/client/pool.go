@@ -0,0 +1,411 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"golang.org/x/time/rate"
+)
+
+var ErrNoQuorum = errors.New("endpoints did not reach quorum")
+
+// limitExceededCode is the JSON-RPC error code for requests that a provider rejects because of its rate limits.
+const limitExceededCode = -32005
+
+// DefaultPoolCooldown is the time an endpoint of a pool is avoided for, after it fails a request.
+const DefaultPoolCooldown = 30 * time.Second
+
+// DefaultPoolAttemptTimeout is the time an endpoint of a pool is given to serve a request,
+// before the request fails over to the next endpoint.
+const DefaultPoolAttemptTimeout = 10 * time.Second
+
+// PoolEndpoint is an endpoint of a PoolRPC.
+type PoolEndpoint struct {
+	// Name identifies the endpoint in logs. It must not contain credentials.
+	Name string
+	RPC  RPC
+	// Limiter is the optional rate limiter of the RPC, see WithRateLimiter. The pool does not take from it,
+	// but spreads requests to another endpoint when this endpoint has no budget left.
+	Limiter *rate.Limiter
+}
+
+type poolEndpoint struct {
+	PoolEndpoint
+	unhealthyUntil time.Time
+}
+
+// PoolRPC is an RPC that is served by several endpoints.
+// Requests are made to a single endpoint, failing over to the next endpoint when an endpoint
+// returns a connection error, does not respond within the attempt timeout, or is rate limited. The endpoint that served the last request
+// successfully is used until it fails. While it has no rate limit budget left, requests are spread to the
+// other healthy endpoints that do, rather than waiting for its budget.
+// Subscriptions are moved to the new active endpoint when the pool fails over.
+// Optionally, compared to trusting a single endpoint, the safe and finalized blocks can be read from all
+// endpoints, and are only accepted if a quorum of the endpoints agrees on their hash.
+type PoolRPC struct {
+	log            log.Logger
+	quorum         int
+	cooldown       time.Duration
+	attemptTimeout time.Duration
+
+	mu        sync.Mutex
+	endpoints []*poolEndpoint
+	active    int
+	// changed is closed and replaced when the active endpoint changes or an endpoint fails.
+	changed chan struct{}
+}
+
+var _ RPC = (*PoolRPC)(nil)
+
+// NewPoolRPC creates a PoolRPC of the endpoints, preferring them in the given order.
+// A quorum greater than 1 requires that many endpoints to agree on the safe and finalized blocks.
+// An attempt timeout of 0 lets each endpoint use up the full deadline of the request.
+func NewPoolRPC(log log.Logger, endpoints []PoolEndpoint, quorum int, cooldown time.Duration, attemptTimeout time.Duration) (*PoolRPC, error) {
+	if len(endpoints) == 0 {
+		return nil, errors.New("no endpoints")
+	}
+	if quorum > len(endpoints) {
+		return nil, fmt.Errorf("quorum of %d exceeds the %d endpoints", quorum, len(endpoints))
+	}
+	p := &PoolRPC{
+		log:            log,
+		quorum:         quorum,
+		cooldown:       cooldown,
+		attemptTimeout: attemptTimeout,
+		changed:        make(chan struct{}),
+	}
+	for _, e := range endpoints {
+		p.endpoints = append(p.endpoints, &poolEndpoint{PoolEndpoint: e})
+	}
+	return p, nil
+}
+
+func (p *PoolRPC) Close() {
+	for _, e := range p.endpoints {
+		e.RPC.Close()
+	}
+}
+
+func (p *PoolRPC) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	if p.quorum > 1 && isQuorumCall(method, args) {
+		return p.quorumCall(ctx, result, method, args...)
+	}
+	_, err := p.do(ctx, 1, func(ctx context.Context, e RPC) error {
+		return e.CallContext(ctx, result, method, args...)
+	})
+	return err
+}
+
+func (p *PoolRPC) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	_, err := p.do(ctx, len(b), func(ctx context.Context, e RPC) error {
+		return e.BatchCallContext(ctx, b)
+	})
+	return err
+}
+
+// EthSubscribe subscribes with the endpoint that serves the request.
+// When the pool fails over to another endpoint, or the endpoint of the subscription fails a request,
+// the subscription is moved to the endpoint that serves the next subscribe request.
+// Notifications sent around the move may be missed. The subscription fails if it cannot be moved.
+func (p *PoolRPC) EthSubscribe(ctx context.Context, channel any, args ...any) (ethereum.Subscription, error) {
+	subCtx, cancel := context.WithCancel(context.Background())
+	s := &poolSubscription{
+		pool:    p,
+		channel: channel,
+		args:    args,
+		err:     make(chan error, 1),
+		ctx:     subCtx,
+		cancel:  cancel,
+		done:    make(chan struct{}),
+	}
+	if err := s.subscribe(ctx); err != nil {
+		cancel()
+		return nil, err
+	}
+	go s.loop()
+	return s, nil
+}
+
+// do makes the request of n items to each endpoint in turn until one serves it, starting at the active endpoint.
+// Unhealthy endpoints are only tried after all healthy endpoints failed.
+// It returns the index of the endpoint that served the request.
+func (p *PoolRPC) do(ctx context.Context, n int, fn func(ctx context.Context, e RPC) error) (int, error) {
+	var err error
+	order, spread := p.order(n)
+	for j, i := range order {
+		e := p.endpoints[i]
+		attemptCtx, cancel := p.attemptContext(ctx)
+		err = fn(attemptCtx, e.RPC)
+		failed := err != nil && p.isAttemptFailure(ctx, attemptCtx, err)
+		cancel()
+		if err == nil {
+			// A request spread to another endpoint for its budget does not make it the active endpoint.
+			if !spread || j > 0 {
+				p.setActive(i)
+			}
+			return i, nil
+		}
+		if !failed {
+			return i, err
+		}
+		p.markUnhealthy(i, err)
+		if ctx.Err() != nil {
+			// The deadline of the request passed, there is no time left to try the other endpoints.
+			return i, err
+		}
+	}
+	return -1, err
+}
+
+// attemptContext returns the context of a request attempt with a single endpoint.
+func (p *PoolRPC) attemptContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if p.attemptTimeout == 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, p.attemptTimeout)
+}
+
+// isAttemptFailure returns true if the error of the request attempt shows that the endpoint failed.
+// An endpoint that did not respond before the deadline failed. A request cancelled by the caller does not.
+func (p *PoolRPC) isAttemptFailure(ctx context.Context, attemptCtx context.Context, err error) bool {
+	return errors.Is(attemptCtx.Err(), context.DeadlineExceeded) || IsEndpointFailure(ctx, err)
+}
+
+// order returns the order to try the endpoints in for a request of n items.
+// If the request is spread to an endpoint other than the active endpoint for its budget, spread is true.
+func (p *PoolRPC) order(n int) (order []int, spread bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := time.Now()
+	healthy := make([]int, 0, len(p.endpoints))
+	var unhealthy []int
+	for j := range p.endpoints {
+		i := (p.active + j) % len(p.endpoints)
+		if now.Before(p.endpoints[i].unhealthyUntil) {
+			unhealthy = append(unhealthy, i)
+		} else {
+			healthy = append(healthy, i)
+		}
+	}
+	if len(healthy) > 1 && !p.endpoints[healthy[0]].hasBudget(now, n) {
+		for j := 1; j < len(healthy); j++ {
+			if i := healthy[j]; p.endpoints[i].hasBudget(now, n) {
+				healthy = append([]int{i}, append(healthy[:j:j], healthy[j+1:]...)...)
+				spread = true
+				break
+			}
+		}
+	}
+	return append(healthy, unhealthy...), spread
+}
+
+// hasBudget returns true if the rate limit of the endpoint allows n requests right away.
+func (e *poolEndpoint) hasBudget(now time.Time, n int) bool {
+	return e.Limiter == nil || e.Limiter.TokensAt(now) >= float64(n)
+}
+
+func (p *PoolRPC) setActive(i int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.active != i {
+		p.log.Info("Switched RPC endpoint", "from", p.endpoints[p.active].Name, "to", p.endpoints[i].Name)
+		p.active = i
+		p.notifyChanged()
+	}
+	p.endpoints[i].unhealthyUntil = time.Time{}
+}
+
+func (p *PoolRPC) markUnhealthy(i int, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.log.Warn("RPC endpoint failed", "endpoint", p.endpoints[i].Name, "err", err)
+	p.endpoints[i].unhealthyUntil = time.Now().Add(p.cooldown)
+	p.notifyChanged()
+}
+
+// notifyChanged wakes up the subscriptions to check if they have to move to another endpoint.
+// It must be called with the lock held.
+func (p *PoolRPC) notifyChanged() {
+	close(p.changed)
+	p.changed = make(chan struct{})
+}
+
+// subscriptionState returns whether a subscription with endpoint i, made while the active endpoint was active,
+// has to move to another endpoint, and the channel that is closed when this may change.
+func (p *PoolRPC) subscriptionState(i int, active int) (move bool, changed <-chan struct{}) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.active != active || time.Now().Before(p.endpoints[i].unhealthyUntil), p.changed
+}
+
+// IsEndpointFailure returns true if the error indicates that the endpoint cannot serve requests,
+// rather than that the request itself failed.
+func IsEndpointFailure(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		// The caller gave up on the request, the endpoint is not at fault.
+		return false
+	}
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) {
+		return rpcErr.ErrorCode() == limitExceededCode
+	}
+	return true
+}
+
+// isQuorumCall returns true if the call fetches the safe or finalized block.
+func isQuorumCall(method string, args []any) bool {
+	if method != "eth_getBlockByNumber" || len(args) == 0 {
+		return false
+	}
+	label, ok := args[0].(string)
+	return ok && (label == "safe" || label == "finalized")
+}
+
+// quorumCall makes the call to all endpoints, and returns the result that a quorum of the endpoints agrees on.
+// Endpoints agree if they return the same block hash.
+func (p *PoolRPC) quorumCall(ctx context.Context, result any, method string, args ...any) error {
+	type response struct {
+		raw json.RawMessage
+		err error
+	}
+	responses := make([]response, len(p.endpoints))
+	var wg sync.WaitGroup
+	for i, e := range p.endpoints {
+		wg.Add(1)
+		go func(i int, e *poolEndpoint) {
+			defer wg.Done()
+			attemptCtx, cancel := p.attemptContext(ctx)
+			defer cancel()
+			responses[i].err = e.RPC.CallContext(attemptCtx, &responses[i].raw, method, args...)
+			if responses[i].err != nil && p.isAttemptFailure(ctx, attemptCtx, responses[i].err) {
+				p.markUnhealthy(i, responses[i].err)
+			}
+		}(i, e)
+	}
+	wg.Wait()
+
+	votes := make(map[common.Hash]int)
+	results := make(map[common.Hash]json.RawMessage)
+	var errs []error
+	for i, res := range responses {
+		if res.err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", p.endpoints[i].Name, res.err))
+			continue
+		}
+		// A null result, for a block that does not exist yet, is a vote for the zero hash.
+		var block struct {
+			Hash common.Hash `json:"hash"`
+		}
+		if err := json.Unmarshal(res.raw, &block); err != nil {
+			errs = append(errs, fmt.Errorf("%s: invalid block: %w", p.endpoints[i].Name, err))
+			continue
+		}
+		votes[block.Hash]++
+		results[block.Hash] = res.raw
+	}
+	for hash, count := range votes {
+		if count >= p.quorum {
+			if len(votes) > 1 {
+				p.log.Warn("RPC endpoints disagree on block", "method", method, "label", args[0], "hash", hash, "votes", votes)
+			}
+			return json.Unmarshal(results[hash], result)
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	errs = append(errs, fmt.Errorf("%w on %v block, required %d votes: %v", ErrNoQuorum, args[0], p.quorum, votes))
+	return errors.Join(errs...)
+}
+
+// poolSubscription is a subscription of a PoolRPC, which follows the active endpoint of the pool.
+type poolSubscription struct {
+	pool    *PoolRPC
+	channel any
+	args    []any
+
+	// endpoint, active and sub are only accessed by the subscribe and loop functions.
+	endpoint int
+	active   int
+	sub      ethereum.Subscription
+
+	err chan error
+	// ctx is cancelled on unsubscribe, and limits the requests to move the subscription.
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+	once   sync.Once
+}
+
+// subscribe subscribes with the endpoint that serves the request.
+func (s *poolSubscription) subscribe(ctx context.Context) error {
+	p := s.pool
+	p.mu.Lock()
+	active := p.active
+	p.mu.Unlock()
+	var sub ethereum.Subscription
+	i, err := p.do(ctx, 1, func(ctx context.Context, e RPC) error {
+		var err error
+		sub, err = e.EthSubscribe(ctx, s.channel, s.args...)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	p.mu.Lock()
+	// Serving the request may have made the endpoint the active endpoint.
+	if p.active == i {
+		active = i
+	}
+	p.mu.Unlock()
+	s.endpoint, s.active, s.sub = i, active, sub
+	return nil
+}
+
+func (s *poolSubscription) loop() {
+	defer close(s.done)
+	for {
+		move, changed := s.pool.subscriptionState(s.endpoint, s.active)
+		if move {
+			s.sub.Unsubscribe()
+			if err := s.subscribe(s.ctx); err != nil {
+				if s.ctx.Err() != nil {
+					return
+				}
+				s.err <- fmt.Errorf("failed to move subscription to another endpoint: %w", err)
+				return
+			}
+			s.pool.log.Info("Moved subscription to another RPC endpoint", "endpoint", s.pool.endpoints[s.endpoint].Name)
+			continue
+		}
+		select {
+		case err := <-s.sub.Err():
+			s.err <- err
+			return
+		case <-changed:
+		case <-s.ctx.Done():
+			s.sub.Unsubscribe()
+			return
+		}
+	}
+}
+
+func (s *poolSubscription) Unsubscribe() {
+	s.once.Do(func() {
+		s.cancel()
+		<-s.done
+		close(s.err)
+	})
+}
+
+func (s *poolSubscription) Err() <-chan error {
+	return s.err
+}
@@ -0,0 +1,286 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type poolTestRPC struct {
+	calls int
+	err   error
+	hash  common.Hash
+	// hang makes requests hang until their context is done
+	hang bool
+	// subs, if not nil, receives the subscriptions made with the endpoint
+	subs chan *poolTestSub
+}
+
+func (m *poolTestRPC) Close() {}
+
+func (m *poolTestRPC) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	m.calls++
+	if m.hang {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	if m.err != nil {
+		return m.err
+	}
+	data, err := json.Marshal(map[string]any{"hash": m.hash})
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, result)
+}
+
+func (m *poolTestRPC) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	m.calls++
+	return m.err
+}
+
+func (m *poolTestRPC) EthSubscribe(ctx context.Context, channel any, args ...any) (ethereum.Subscription, error) {
+	m.calls++
+	if m.err != nil {
+		return nil, m.err
+	}
+	sub := &poolTestSub{err: make(chan error, 1), unsubscribed: make(chan struct{})}
+	if m.subs != nil {
+		m.subs <- sub
+	}
+	return sub, nil
+}
+
+type poolTestSub struct {
+	err          chan error
+	unsubscribed chan struct{}
+}
+
+func (s *poolTestSub) Unsubscribe() {
+	close(s.unsubscribed)
+}
+
+func (s *poolTestSub) Err() <-chan error {
+	return s.err
+}
+
+type poolTestRPCError struct {
+	code int
+}
+
+func (e *poolTestRPCError) Error() string  { return "rpc error" }
+func (e *poolTestRPCError) ErrorCode() int { return e.code }
+
+func newTestPool(t *testing.T, quorum int, rpcs ...*poolTestRPC) *PoolRPC {
+	endpoints := make([]PoolEndpoint, len(rpcs))
+	for i, r := range rpcs {
+		endpoints[i] = PoolEndpoint{Name: string(rune('a' + i)), RPC: r}
+	}
+	pool, err := NewPoolRPC(testlog.Logger(t, log.LevelInfo), endpoints, quorum, time.Hour, 50*time.Millisecond)
+	require.NoError(t, err)
+	return pool
+}
+
+type poolTestBlock struct {
+	Hash common.Hash `json:"hash"`
+}
+
+func TestPoolRPC_Failover(t *testing.T) {
+	a := &poolTestRPC{err: errors.New("connection refused")}
+	b := &poolTestRPC{hash: common.Hash{0xbb}}
+	pool := newTestPool(t, 0, a, b)
+
+	var block poolTestBlock
+	require.NoError(t, pool.CallContext(context.Background(), &block, "eth_getBlockByNumber", "latest", false))
+	require.Equal(t, common.Hash{0xbb}, block.Hash)
+	require.Equal(t, 1, a.calls)
+	require.Equal(t, 1, b.calls)
+
+	// The failed endpoint is avoided while it is unhealthy.
+	a.err = nil
+	require.NoError(t, pool.BatchCallContext(context.Background(), nil))
+	require.Equal(t, 1, a.calls)
+	require.Equal(t, 2, b.calls)
+
+	// All endpoints are still tried if all are unhealthy.
+	b.err = errors.New("timeout")
+	require.NoError(t, pool.BatchCallContext(context.Background(), nil))
+	require.Equal(t, 2, a.calls)
+	require.Equal(t, 3, b.calls)
+}
+
+func TestPoolRPC_AttemptTimeout(t *testing.T) {
+	a := &poolTestRPC{hang: true}
+	b := &poolTestRPC{hash: common.Hash{0xbb}}
+	pool := newTestPool(t, 0, a, b)
+
+	// The hanging endpoint times out, and the request fails over within the deadline of the caller.
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var block poolTestBlock
+	require.NoError(t, pool.CallContext(ctx, &block, "eth_getBlockByNumber", "latest", false))
+	require.Equal(t, common.Hash{0xbb}, block.Hash)
+
+	// The timed out endpoint is unhealthy.
+	require.NoError(t, pool.CallContext(ctx, &block, "eth_getBlockByNumber", "latest", false))
+	require.Equal(t, 1, a.calls)
+	require.Equal(t, 2, b.calls)
+
+	t.Run("CallerDeadline", func(t *testing.T) {
+		a := &poolTestRPC{hang: true}
+		b := &poolTestRPC{}
+		pool := newTestPool(t, 0, a, b)
+		pool.attemptTimeout = 0
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		require.ErrorIs(t, pool.CallContext(ctx, nil, "eth_chainId"), context.DeadlineExceeded)
+		require.Equal(t, 0, b.calls, "no time is left to try other endpoints")
+		order, _ := pool.order(1)
+		require.Equal(t, []int{1, 0}, order, "an endpoint that exceeds the deadline of the caller failed")
+	})
+
+	t.Run("CallerCancelled", func(t *testing.T) {
+		a := &poolTestRPC{hang: true}
+		pool := newTestPool(t, 0, a, &poolTestRPC{})
+		pool.attemptTimeout = 0
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(10*time.Millisecond, cancel)
+		require.ErrorIs(t, pool.CallContext(ctx, nil, "eth_chainId"), context.Canceled)
+		order, _ := pool.order(1)
+		require.Equal(t, []int{0, 1}, order, "a cancelled request is not a failure of the endpoint")
+	})
+}
+
+func TestPoolRPC_Subscription(t *testing.T) {
+	a := &poolTestRPC{subs: make(chan *poolTestSub, 1)}
+	b := &poolTestRPC{subs: make(chan *poolTestSub, 1)}
+	pool := newTestPool(t, 0, a, b)
+
+	sub, err := pool.EthSubscribe(context.Background(), make(chan struct{}), "newHeads")
+	require.NoError(t, err)
+	subA := <-a.subs
+
+	// The subscription moves to the next endpoint when the pool fails over.
+	a.err = errors.New("connection refused")
+	require.NoError(t, pool.CallContext(context.Background(), &poolTestBlock{}, "eth_chainId"))
+	subB := <-b.subs
+	<-subA.unsubscribed
+
+	// Errors of the subscription are passed through.
+	subB.err <- errors.New("connection lost")
+	require.ErrorContains(t, <-sub.Err(), "connection lost")
+	sub.Unsubscribe()
+
+	t.Run("Unsubscribe", func(t *testing.T) {
+		a := &poolTestRPC{subs: make(chan *poolTestSub, 1)}
+		pool := newTestPool(t, 0, a, &poolTestRPC{})
+		sub, err := pool.EthSubscribe(context.Background(), make(chan struct{}), "newHeads")
+		require.NoError(t, err)
+		sub.Unsubscribe()
+		<-(<-a.subs).unsubscribed
+		_, ok := <-sub.Err()
+		require.False(t, ok, "error channel must be closed")
+		sub.Unsubscribe()
+	})
+}
+
+func TestPoolRPC_RequestErrors(t *testing.T) {
+	t.Run("NoFailoverOnRequestError", func(t *testing.T) {
+		a := &poolTestRPC{err: &poolTestRPCError{code: -32000}}
+		b := &poolTestRPC{}
+		pool := newTestPool(t, 0, a, b)
+		require.ErrorIs(t, pool.CallContext(context.Background(), nil, "eth_call"), a.err)
+		require.Equal(t, 0, b.calls)
+	})
+
+	t.Run("FailoverWhenRateLimited", func(t *testing.T) {
+		a := &poolTestRPC{err: &poolTestRPCError{code: limitExceededCode}}
+		b := &poolTestRPC{}
+		pool := newTestPool(t, 0, a, b)
+		require.NoError(t, pool.CallContext(context.Background(), &poolTestBlock{}, "eth_call"))
+		require.Equal(t, 1, b.calls)
+	})
+
+	t.Run("AllFailed", func(t *testing.T) {
+		a := &poolTestRPC{err: errors.New("a failed")}
+		b := &poolTestRPC{err: errors.New("b failed")}
+		pool := newTestPool(t, 0, a, b)
+		_, err := pool.EthSubscribe(context.Background(), nil, "newHeads")
+		require.ErrorIs(t, err, b.err)
+	})
+}
+
+func TestPoolRPC_RateLimitBudget(t *testing.T) {
+	a := &poolTestRPC{hash: common.Hash{0xaa}}
+	b := &poolTestRPC{hash: common.Hash{0xbb}}
+	limiterA := rate.NewLimiter(rate.Every(time.Hour), 2)
+	limiterB := rate.NewLimiter(rate.Every(time.Hour), 5)
+	pool, err := NewPoolRPC(testlog.Logger(t, log.LevelInfo), []PoolEndpoint{
+		{Name: "a", RPC: a, Limiter: limiterA},
+		{Name: "b", RPC: b, Limiter: limiterB},
+	}, 0, time.Hour, time.Minute)
+	require.NoError(t, err)
+
+	// A batch that exceeds the remaining budget of the active endpoint is spread to the endpoint with budget left.
+	require.NoError(t, pool.BatchCallContext(context.Background(), make([]rpc.BatchElem, 3)))
+	require.Equal(t, 0, a.calls)
+	require.Equal(t, 1, b.calls)
+
+	// Spreading a request does not change the active endpoint.
+	require.NoError(t, pool.CallContext(context.Background(), &poolTestBlock{}, "eth_chainId"))
+	require.Equal(t, 1, a.calls)
+
+	// Without budget left on any endpoint, the request waits for the active endpoint.
+	require.True(t, limiterA.AllowN(time.Now(), 2))
+	require.True(t, limiterB.AllowN(time.Now(), 5))
+	require.NoError(t, pool.CallContext(context.Background(), &poolTestBlock{}, "eth_chainId"))
+	require.Equal(t, 2, a.calls)
+	require.Equal(t, 1, b.calls)
+}
+
+func TestPoolRPC_Quorum(t *testing.T) {
+	good := common.Hash{0xaa}
+	bad := common.Hash{0xbb}
+
+	t.Run("Agreed", func(t *testing.T) {
+		pool := newTestPool(t, 2, &poolTestRPC{hash: good}, &poolTestRPC{hash: bad}, &poolTestRPC{hash: good})
+		var block poolTestBlock
+		require.NoError(t, pool.CallContext(context.Background(), &block, "eth_getBlockByNumber", "finalized", false))
+		require.Equal(t, good, block.Hash)
+	})
+
+	t.Run("NoQuorum", func(t *testing.T) {
+		pool := newTestPool(t, 2, &poolTestRPC{hash: bad}, &poolTestRPC{err: errors.New("offline")}, &poolTestRPC{hash: good})
+		var block poolTestBlock
+		err := pool.CallContext(context.Background(), &block, "eth_getBlockByNumber", "safe", false)
+		require.ErrorIs(t, err, ErrNoQuorum)
+	})
+
+	t.Run("OnlySafeAndFinalized", func(t *testing.T) {
+		a := &poolTestRPC{hash: bad}
+		b := &poolTestRPC{hash: good}
+		pool := newTestPool(t, 2, a, b)
+		var block poolTestBlock
+		require.NoError(t, pool.CallContext(context.Background(), &block, "eth_getBlockByNumber", "latest", false))
+		require.Equal(t, bad, block.Hash)
+		require.Equal(t, 0, b.calls)
+	})
+}
+
+func TestNewPoolRPC(t *testing.T) {
+	_, err := NewPoolRPC(testlog.Logger(t, log.LevelInfo), nil, 0, time.Hour, time.Minute)
+	require.Error(t, err)
+	_, err = NewPoolRPC(testlog.Logger(t, log.LevelInfo), []PoolEndpoint{{Name: "a", RPC: &poolTestRPC{}}}, 2, time.Hour, time.Minute)
+	require.Error(t, err)
+}
@@ -2,6 +2,9 @@ package client
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
@@ -9,6 +12,53 @@ import (
 	"golang.org/x/time/rate"
 )
 
+// RateBudget is a self-imposed request budget of an endpoint: a sustained rate in requests per second,
+// and the number of requests that may be made at once.
+type RateBudget struct {
+	Limit float64
+	Burst int
+}
+
+// ParseRateBudget parses a budget of the form <requests per second>[:<burst>].
+// The burst defaults to defaultBurst if it is omitted.
+func ParseRateBudget(s string, defaultBurst int) (RateBudget, error) {
+	limitStr, burstStr, hasBurst := strings.Cut(s, ":")
+	limit, err := strconv.ParseFloat(limitStr, 64)
+	if err != nil {
+		return RateBudget{}, fmt.Errorf("invalid rate limit %q: %w", limitStr, err)
+	}
+	b := RateBudget{Limit: limit, Burst: defaultBurst}
+	if hasBurst {
+		if b.Burst, err = strconv.Atoi(burstStr); err != nil {
+			return RateBudget{}, fmt.Errorf("invalid burst %q: %w", burstStr, err)
+		}
+	}
+	return b, b.Check()
+}
+
+// Enabled returns true if the budget limits the rate of requests. A zero limit is no limit.
+func (b RateBudget) Enabled() bool {
+	return b.Limit != 0
+}
+
+func (b RateBudget) Check() error {
+	if b.Limit < 0 {
+		return fmt.Errorf("rate limit cannot be negative, was %v", b.Limit)
+	}
+	if b.Enabled() && b.Burst < 1 {
+		return fmt.Errorf("rate limit burst must be at least 1, was %d", b.Burst)
+	}
+	return nil
+}
+
+// Limiter returns a new limiter that enforces the budget, or nil if the budget is not enabled.
+func (b RateBudget) Limiter() *rate.Limiter {
+	if !b.Enabled() {
+		return nil
+	}
+	return rate.NewLimiter(rate.Limit(b.Limit), b.Burst)
+}
+
 // RateLimitingClient is a wrapper around a pure RPC that implements a global rate-limit on requests.
 type RateLimitingClient struct {
 	c  RPC
@@ -19,7 +69,13 @@ type RateLimitingClient struct {
 // A limit of N will ensure that over a long enough time-frame the given number of tokens per second is targeted.
 // Burst limits how far off we can be from the target, by specifying how many requests are allowed at once.
 func NewRateLimitingClient(c RPC, limit rate.Limit, burst int) *RateLimitingClient {
-	return &RateLimitingClient{c: c, rl: rate.NewLimiter(limit, burst)}
+	return NewRateLimitingClientWithLimiter(c, rate.NewLimiter(limit, burst))
+}
+
+// NewRateLimitingClientWithLimiter rate-limits the RPC requests with the given limiter,
+// which may be shared to inspect the remaining budget, e.g. by a PoolRPC.
+func NewRateLimitingClientWithLimiter(c RPC, rl *rate.Limiter) *RateLimitingClient {
+	return &RateLimitingClient{c: c, rl: rl}
 }
 
 func (b *RateLimitingClient) Close() {
@@ -0,0 +1,29 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRateBudget(t *testing.T) {
+	budget, err := ParseRateBudget("12.5", 20)
+	require.NoError(t, err)
+	require.Equal(t, RateBudget{Limit: 12.5, Burst: 20}, budget)
+
+	budget, err = ParseRateBudget("10:50", 20)
+	require.NoError(t, err)
+	require.Equal(t, RateBudget{Limit: 10, Burst: 50}, budget)
+	require.True(t, budget.Enabled())
+	require.NotNil(t, budget.Limiter())
+
+	budget, err = ParseRateBudget("0", 20)
+	require.NoError(t, err)
+	require.False(t, budget.Enabled())
+	require.Nil(t, budget.Limiter())
+
+	for _, invalid := range []string{"", "fast", "10:", "10:many", "-1", "10:0"} {
+		_, err := ParseRateBudget(invalid, 20)
+		require.Error(t, err, invalid)
+	}
+}
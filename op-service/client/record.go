@@ -0,0 +1,249 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var ErrReplaySubscription = errors.New("subscriptions are not supported when replaying recorded calls")
+
+// RecordedCall is a single call and its response, as persisted by a CallRecorder.
+type RecordedCall struct {
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  *RecordedError  `json:"error,omitempty"`
+}
+
+// RecordedError is the error of a recorded call.
+// It implements rpc.Error so that replayed JSON-RPC errors retain their error code.
+type RecordedError struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message"`
+}
+
+var _ rpc.Error = (*RecordedError)(nil)
+
+func (e *RecordedError) Error() string {
+	return e.Message
+}
+
+func (e *RecordedError) ErrorCode() int {
+	return e.Code
+}
+
+// CallRecorder appends calls and their responses to a file, one JSON object per line.
+// It is safe for concurrent use.
+type CallRecorder struct {
+	mu  sync.Mutex
+	f   *os.File
+	enc *json.Encoder
+}
+
+// NewCallRecorder creates a recorder that appends to the file at the given path, creating it if it does not exist.
+func NewCallRecorder(path string) (*CallRecorder, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open recording %q: %w", path, err)
+	}
+	return &CallRecorder{f: f, enc: json.NewEncoder(f)}, nil
+}
+
+// Record persists a call with the given params, and either its JSON encoded result or its error.
+func (r *CallRecorder) Record(method string, params any, result json.RawMessage, callErr error) error {
+	encodedParams, err := json.Marshal(params)
+	if err != nil {
+		return fmt.Errorf("failed to encode params of %v: %w", method, err)
+	}
+	call := RecordedCall{Method: method, Params: encodedParams}
+	if callErr != nil {
+		call.Error = &RecordedError{Message: callErr.Error()}
+		var rpcErr rpc.Error
+		if errors.As(callErr, &rpcErr) {
+			call.Error.Code = rpcErr.ErrorCode()
+		}
+	} else {
+		call.Result = result
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.enc.Encode(&call); err != nil {
+		return fmt.Errorf("failed to record call to %v: %w", method, err)
+	}
+	return nil
+}
+
+func (r *CallRecorder) Close() error {
+	return r.f.Close()
+}
+
+// CallReplayer serves responses from a recording made by a CallRecorder.
+// Calls are matched by method and params. Repeated calls with the same method and params are served
+// the recorded responses in order, and the last response is served again once they are exhausted.
+// It is safe for concurrent use.
+type CallReplayer struct {
+	mu    sync.Mutex
+	calls map[string][]RecordedCall
+	next  map[string]int
+}
+
+// LoadCallReplayer reads the recording at the given path.
+func LoadCallReplayer(path string) (*CallReplayer, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open recording %q: %w", path, err)
+	}
+	defer f.Close()
+	replayer := &CallReplayer{
+		calls: make(map[string][]RecordedCall),
+		next:  make(map[string]int),
+	}
+	dec := json.NewDecoder(bufio.NewReader(f))
+	for {
+		var call RecordedCall
+		if err := dec.Decode(&call); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to decode recording %q: %w", path, err)
+		}
+		key := callKey(call.Method, call.Params)
+		replayer.calls[key] = append(replayer.calls[key], call)
+	}
+	return replayer, nil
+}
+
+// Replay decodes the next recorded response of the call into result, or returns the recorded error.
+func (r *CallReplayer) Replay(method string, params any, result any) error {
+	encodedParams, err := json.Marshal(params)
+	if err != nil {
+		return fmt.Errorf("failed to encode params of %v: %w", method, err)
+	}
+	key := callKey(method, encodedParams)
+	r.mu.Lock()
+	calls := r.calls[key]
+	if len(calls) == 0 {
+		r.mu.Unlock()
+		return fmt.Errorf("no recorded response for %v with params %s", method, encodedParams)
+	}
+	i := r.next[key]
+	if i < len(calls)-1 {
+		r.next[key] = i + 1
+	}
+	call := calls[i]
+	r.mu.Unlock()
+
+	if call.Error != nil {
+		return call.Error
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(call.Result, result)
+}
+
+func callKey(method string, params json.RawMessage) string {
+	return method + ":" + string(params)
+}
+
+// RecordingRPC is an RPC that records the responses of all calls made through it.
+// Subscriptions are passed through to the underlying RPC and not recorded.
+type RecordingRPC struct {
+	c   RPC
+	rec *CallRecorder
+}
+
+var _ RPC = (*RecordingRPC)(nil)
+
+// NewRecordingRPC records all calls to the given RPC to the file at the given path.
+func NewRecordingRPC(c RPC, path string) (*RecordingRPC, error) {
+	rec, err := NewCallRecorder(path)
+	if err != nil {
+		return nil, err
+	}
+	return &RecordingRPC{c: c, rec: rec}, nil
+}
+
+func (r *RecordingRPC) Close() {
+	r.c.Close()
+	_ = r.rec.Close()
+}
+
+func (r *RecordingRPC) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	var raw json.RawMessage
+	callErr := r.c.CallContext(ctx, &raw, method, args...)
+	if err := r.rec.Record(method, args, raw, callErr); err != nil {
+		return err
+	}
+	if callErr != nil || result == nil {
+		return callErr
+	}
+	return json.Unmarshal(raw, result)
+}
+
+func (r *RecordingRPC) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	raws := make([]json.RawMessage, len(b))
+	batch := make([]rpc.BatchElem, len(b))
+	for i, elem := range b {
+		batch[i] = rpc.BatchElem{Method: elem.Method, Args: elem.Args, Result: &raws[i]}
+	}
+	if err := r.c.BatchCallContext(ctx, batch); err != nil {
+		return err
+	}
+	for i, elem := range batch {
+		if err := r.rec.Record(elem.Method, elem.Args, raws[i], elem.Error); err != nil {
+			return err
+		}
+		b[i].Error = elem.Error
+		if elem.Error == nil && b[i].Result != nil {
+			b[i].Error = json.Unmarshal(raws[i], b[i].Result)
+		}
+	}
+	return nil
+}
+
+func (r *RecordingRPC) EthSubscribe(ctx context.Context, channel any, args ...any) (ethereum.Subscription, error) {
+	return r.c.EthSubscribe(ctx, channel, args...)
+}
+
+// ReplayRPC is an RPC that serves all calls from a recording made by a RecordingRPC.
+// It does not support subscriptions: wrap it with a PollingClient to follow the recorded head.
+type ReplayRPC struct {
+	replayer *CallReplayer
+}
+
+var _ RPC = (*ReplayRPC)(nil)
+
+// NewReplayRPC serves all calls from the recording at the given path.
+func NewReplayRPC(path string) (*ReplayRPC, error) {
+	replayer, err := LoadCallReplayer(path)
+	if err != nil {
+		return nil, err
+	}
+	return &ReplayRPC{replayer: replayer}, nil
+}
+
+func (r *ReplayRPC) Close() {}
+
+func (r *ReplayRPC) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	return r.replayer.Replay(method, args, result)
+}
+
+func (r *ReplayRPC) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	for i := range b {
+		b[i].Error = r.replayer.Replay(b[i].Method, b[i].Args, b[i].Result)
+	}
+	return nil
+}
+
+func (r *ReplayRPC) EthSubscribe(ctx context.Context, channel any, args ...any) (ethereum.Subscription, error) {
+	return nil, ErrReplaySubscription
+}
@@ -0,0 +1,140 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+// stubRPC serves a fixed result for each method, or an error if it has none.
+type stubRPC struct {
+	results map[string]any
+	calls   int
+}
+
+func (s *stubRPC) Close() {}
+
+func (s *stubRPC) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	s.calls++
+	res, ok := s.results[method]
+	if !ok {
+		return &RecordedError{Code: -32601, Message: "method not found"}
+	}
+	return copyJSON(res, result)
+}
+
+func (s *stubRPC) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	for i := range b {
+		b[i].Error = s.CallContext(ctx, b[i].Result, b[i].Method, b[i].Args...)
+	}
+	return nil
+}
+
+func (s *stubRPC) EthSubscribe(ctx context.Context, channel any, args ...any) (ethereum.Subscription, error) {
+	return nil, errors.New("not supported")
+}
+
+func copyJSON(src any, dest any) error {
+	data, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, dest)
+}
+
+func TestRecordReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "calls.jsonl")
+	stub := &stubRPC{results: map[string]any{
+		"eth_chainId":      hexutil.Uint64(10),
+		"eth_blockNumber":  hexutil.Uint64(100),
+		"eth_getBlockHash": "0x1234",
+	}}
+	recording, err := NewRecordingRPC(stub, path)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	var chainID hexutil.Uint64
+	require.NoError(t, recording.CallContext(ctx, &chainID, "eth_chainId"))
+	require.Equal(t, hexutil.Uint64(10), chainID)
+	var num hexutil.Uint64
+	require.NoError(t, recording.CallContext(ctx, &num, "eth_blockNumber"))
+	stub.results["eth_blockNumber"] = hexutil.Uint64(101)
+	require.NoError(t, recording.CallContext(ctx, &num, "eth_blockNumber"))
+	require.Equal(t, hexutil.Uint64(101), num)
+	err = recording.CallContext(ctx, &num, "eth_unknown", "a", 1)
+	var rpcErr rpc.Error
+	require.ErrorAs(t, err, &rpcErr)
+
+	var hashA, hashB string
+	batch := []rpc.BatchElem{
+		{Method: "eth_getBlockHash", Args: []any{"0x1"}, Result: &hashA},
+		{Method: "eth_missing", Args: []any{"0x2"}, Result: &hashB},
+	}
+	require.NoError(t, recording.BatchCallContext(ctx, batch))
+	require.Equal(t, "0x1234", hashA)
+	require.NoError(t, batch[0].Error)
+	require.Error(t, batch[1].Error)
+	recording.Close()
+
+	replay, err := NewReplayRPC(path)
+	require.NoError(t, err)
+	calls := stub.calls
+
+	t.Run("Call", func(t *testing.T) {
+		var chainID hexutil.Uint64
+		require.NoError(t, replay.CallContext(ctx, &chainID, "eth_chainId"))
+		require.Equal(t, hexutil.Uint64(10), chainID)
+	})
+
+	t.Run("RepeatedCallsInOrder", func(t *testing.T) {
+		var num hexutil.Uint64
+		require.NoError(t, replay.CallContext(ctx, &num, "eth_blockNumber"))
+		require.Equal(t, hexutil.Uint64(100), num)
+		require.NoError(t, replay.CallContext(ctx, &num, "eth_blockNumber"))
+		require.Equal(t, hexutil.Uint64(101), num)
+		// The last response is repeated once the recorded responses are exhausted
+		require.NoError(t, replay.CallContext(ctx, &num, "eth_blockNumber"))
+		require.Equal(t, hexutil.Uint64(101), num)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		var num hexutil.Uint64
+		err := replay.CallContext(ctx, &num, "eth_unknown", "a", 1)
+		var rpcErr rpc.Error
+		require.ErrorAs(t, err, &rpcErr)
+		require.Equal(t, -32601, rpcErr.ErrorCode())
+		require.Equal(t, "method not found", rpcErr.Error())
+	})
+
+	t.Run("UnrecordedParams", func(t *testing.T) {
+		var num hexutil.Uint64
+		err := replay.CallContext(ctx, &num, "eth_unknown", "b", 1)
+		require.ErrorContains(t, err, "no recorded response for eth_unknown")
+	})
+
+	t.Run("Batch", func(t *testing.T) {
+		var hashA, hashB string
+		batch := []rpc.BatchElem{
+			{Method: "eth_missing", Args: []any{"0x2"}, Result: &hashB},
+			{Method: "eth_getBlockHash", Args: []any{"0x1"}, Result: &hashA},
+		}
+		require.NoError(t, replay.BatchCallContext(ctx, batch))
+		require.Error(t, batch[0].Error)
+		require.NoError(t, batch[1].Error)
+		require.Equal(t, "0x1234", hashA)
+	})
+
+	t.Run("Subscribe", func(t *testing.T) {
+		_, err := replay.EthSubscribe(ctx, make(chan any))
+		require.ErrorIs(t, err, ErrReplaySubscription)
+	})
+
+	require.Equal(t, calls, stub.calls, "replay should not call the original RPC")
+}
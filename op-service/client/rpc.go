@@ -35,6 +35,10 @@ type rpcConfig struct {
 	backoffAttempts  int
 	limit            float64
 	burst            int
+	limiter          *rate.Limiter
+
+	circuitBreaker        CircuitBreakerConfig
+	circuitBreakerMetrics metrics.CircuitBreakerMetricer
 }
 
 type RPCOption func(cfg *rpcConfig) error
@@ -74,6 +78,29 @@ func WithRateLimit(rateLimit float64, burst int) RPCOption {
 	}
 }
 
+// WithRateLimiter configures the RPC to limit the rate of requests with the given limiter.
+// It takes precedence over WithRateLimit. The limiter can be shared with a PoolEndpoint,
+// so the pool can spread requests to endpoints with budget left.
+func WithRateLimiter(limiter *rate.Limiter) RPCOption {
+	return func(cfg *rpcConfig) error {
+		cfg.limiter = limiter
+		return nil
+	}
+}
+
+// WithCircuitBreaker configures the RPC to fail fast while the endpoint is failing.
+// See NewCircuitBreaker for more details. The circuit breaker state is recorded to the metrics, which may be nil.
+func WithCircuitBreaker(cfg CircuitBreakerConfig, m metrics.CircuitBreakerMetricer) RPCOption {
+	return func(rcfg *rpcConfig) error {
+		if err := cfg.Check(); err != nil {
+			return err
+		}
+		rcfg.circuitBreaker = cfg
+		rcfg.circuitBreakerMetrics = m
+		return nil
+	}
+}
+
 // NewRPC returns the correct client.RPC instance for a given RPC url.
 func NewRPC(ctx context.Context, lgr log.Logger, addr string, opts ...RPCOption) (RPC, error) {
 	var cfg rpcConfig
@@ -94,10 +121,18 @@ func NewRPC(ctx context.Context, lgr log.Logger, addr string, opts ...RPCOption)
 
 	var wrapped RPC = &BaseRPCClient{c: underlying}
 
-	if cfg.limit != 0 {
+	if cfg.limiter != nil {
+		wrapped = NewRateLimitingClientWithLimiter(wrapped, cfg.limiter)
+	} else if cfg.limit != 0 {
 		wrapped = NewRateLimitingClient(wrapped, rate.Limit(cfg.limit), cfg.burst)
 	}
 
+	// The circuit breaker wraps the rate limiter, so rejected requests don't consume the rate limit.
+	if cfg.circuitBreaker.Enabled() {
+		breaker := NewCircuitBreaker(lgr, EndpointName(addr), cfg.circuitBreaker, cfg.circuitBreakerMetrics)
+		wrapped = NewCircuitBreakerRPC(wrapped, breaker)
+	}
+
 	return NewRPCWithClient(ctx, lgr, addr, wrapped, cfg.httpPollInterval)
 }
 
@@ -7,11 +7,16 @@ import (
 )
 
 type action interface {
-	// Return true if the action is due to fire
-	isDue(time.Time) bool
+	// dueAt returns the time the action is next due to fire
+	dueAt() time.Time
 
-	// fire triggers the action. Returns true if the action needs to fire again in the future
-	fire(time.Time) bool
+	// fire triggers the action. Returns true if the action needs to fire again in the future, and the callback,
+	// if any, to run once the clock is unlocked.
+	fire(time.Time) (bool, func())
+}
+
+func isDue(a action, now time.Time) bool {
+	return !a.dueAt().After(now)
 }
 
 type task struct {
@@ -19,16 +24,17 @@ type task struct {
 	due time.Time
 }
 
-func (t task) isDue(now time.Time) bool {
-	return !t.due.After(now)
+func (t task) dueAt() time.Time {
+	return t.due
 }
 
-func (t task) fire(now time.Time) bool {
+func (t task) fire(now time.Time) (bool, func()) {
 	t.ch <- now
 	close(t.ch)
-	return false
+	return false, nil
 }
 
+// timer either publishes the time on ch, or calls f, when due.
 type timer struct {
 	f       func()
 	ch      chan time.Time
@@ -38,20 +44,23 @@ type timer struct {
 	sync.Mutex
 }
 
-func (t *timer) isDue(now time.Time) bool {
+func (t *timer) dueAt() time.Time {
 	t.Lock()
 	defer t.Unlock()
-	return !t.due.After(now)
+	return t.due
 }
 
-func (t *timer) fire(now time.Time) bool {
+func (t *timer) fire(now time.Time) (bool, func()) {
 	t.Lock()
 	defer t.Unlock()
-	if !t.stopped {
-		t.f()
-		t.run = true
+	if t.stopped {
+		return false, nil
 	}
-	return false
+	t.run = true
+	if t.ch != nil {
+		t.ch <- now
+	}
+	return false, t.f
 }
 
 func (t *timer) Ch() <-chan time.Time {
@@ -95,25 +104,25 @@ func (t *ticker) Reset(d time.Duration) {
 	t.nextDue = t.c.Now().Add(d)
 }
 
-func (t *ticker) isDue(now time.Time) bool {
+func (t *ticker) dueAt() time.Time {
 	t.Lock()
 	defer t.Unlock()
-	return !t.nextDue.After(now)
+	return t.nextDue
 }
 
-func (t *ticker) fire(now time.Time) bool {
+func (t *ticker) fire(now time.Time) (bool, func()) {
 	t.Lock()
 	defer t.Unlock()
 	if t.stopped {
-		return false
+		return false, nil
 	}
-	// Publish without blocking and only update due time if we publish successfully
+	// Publish without blocking, dropping the tick if the previous one wasn't received yet
 	select {
 	case t.ch <- now:
-		t.nextDue = now.Add(t.period)
 	default:
 	}
-	return true
+	t.nextDue = now.Add(t.period)
+	return true, nil
 }
 
 type DeterministicClock struct {
@@ -157,14 +166,19 @@ func (s *DeterministicClock) After(d time.Duration) <-chan time.Time {
 	return ch
 }
 
+// AfterFunc calls f once the duration has elapsed. Unlike the system clock, f is called synchronously by the
+// call that advances the time, after the clock is unlocked, so f may use the clock.
+// Functions due at the same time are called in the order they were scheduled.
 func (s *DeterministicClock) AfterFunc(d time.Duration, f func()) Timer {
 	s.lock.Lock()
-	defer s.lock.Unlock()
 	timer := &timer{f: f, due: s.now.Add(d)}
 	if d.Nanoseconds() == 0 {
-		timer.fire(s.now)
+		s.lock.Unlock()
+		_, f := timer.fire(timer.due)
+		f()
 	} else {
 		s.addPending(timer)
+		s.lock.Unlock()
 	}
 	return timer
 }
@@ -189,12 +203,8 @@ func (s *DeterministicClock) NewTicker(d time.Duration) Ticker {
 func (s *DeterministicClock) NewTimer(d time.Duration) Timer {
 	s.lock.Lock()
 	defer s.lock.Unlock()
-	ch := make(chan time.Time, 1)
 	t := &timer{
-		f: func() {
-			ch <- s.now
-		},
-		ch:  ch,
+		ch:  make(chan time.Time, 1),
 		due: s.now.Add(d),
 	}
 	s.addPending(t)
@@ -231,18 +241,105 @@ func (s *DeterministicClock) WaitForNewPendingTask(ctx context.Context) bool {
 	}
 }
 
-// AdvanceTime moves the time forward by the specific duration
-func (s *DeterministicClock) AdvanceTime(d time.Duration) {
+// PendingTasks returns the number of pending timers, tickers and After channels.
+func (s *DeterministicClock) PendingTasks() int {
 	s.lock.Lock()
 	defer s.lock.Unlock()
+	return len(s.pending)
+}
+
+// NextDue returns the earliest time a pending timer, ticker or After channel is due to fire.
+// false is returned if nothing is pending.
+func (s *DeterministicClock) NextDue() (time.Time, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.nextDue()
+}
+
+func (s *DeterministicClock) nextDue() (time.Time, bool) {
+	var next time.Time
+	found := false
+	for _, a := range s.pending {
+		if due := a.dueAt(); !found || due.Before(next) {
+			next = due
+			found = true
+		}
+	}
+	return next, found
+}
+
+// AdvanceTime moves the time forward by the specific duration.
+// Everything that is due by the new time fires once, with the new time.
+func (s *DeterministicClock) AdvanceTime(d time.Duration) {
+	s.lock.Lock()
 	s.now = s.now.Add(d)
+	callbacks := s.fireDue()
+	s.lock.Unlock()
+	for _, f := range callbacks {
+		f()
+	}
+}
+
+// AdvanceToNextDue moves the time forward to when the next pending timer, ticker or After channel is due,
+// and fires everything that is due at that time. Returns false, without moving the time, if nothing is pending.
+func (s *DeterministicClock) AdvanceToNextDue() bool {
+	s.lock.Lock()
+	next, ok := s.nextDue()
+	if !ok {
+		s.lock.Unlock()
+		return false
+	}
+	if next.After(s.now) {
+		s.now = next
+	}
+	callbacks := s.fireDue()
+	s.lock.Unlock()
+	for _, f := range callbacks {
+		f()
+	}
+	return true
+}
+
+// RunFor moves the time forward by the specific duration in steps, stopping each time something is due,
+// so that timers, tickers and After channels fire in the order they are due, with the time they are due at.
+// Unlike AdvanceTime, a ticker fires once for each elapsed period, though ticks are still dropped if the previous
+// tick hasn't been received. Timers scheduled by AfterFunc callbacks also fire if they are due within the duration.
+func (s *DeterministicClock) RunFor(d time.Duration) {
+	end := s.Now().Add(d)
+	for {
+		next, ok := s.NextDue()
+		if !ok || next.After(end) {
+			break
+		}
+		s.AdvanceToNextDue()
+	}
+	s.lock.Lock()
+	if end.After(s.now) {
+		s.now = end
+	}
+	s.lock.Unlock()
+}
+
+// fireDue fires everything that is due at the current time, in the order it was scheduled,
+// and returns the AfterFunc callbacks to call once the clock is unlocked.
+func (s *DeterministicClock) fireDue() []func() {
 	var remaining []action
+	var callbacks []func()
 	for _, a := range s.pending {
-		if !a.isDue(s.now) || a.fire(s.now) {
+		if !isDue(a, s.now) {
 			remaining = append(remaining, a)
+			continue
+		}
+		again, f := a.fire(s.now)
+		if again {
+			remaining = append(remaining, a)
+		}
+		if f != nil {
+			callbacks = append(callbacks, f)
 		}
 	}
 	s.pending = remaining
+	return callbacks
 }
 
 var _ Clock = (*DeterministicClock)(nil)
@@ -350,3 +350,94 @@ func TestSleepCtx(t *testing.T) {
 		require.Nil(t, result.Load())
 	})
 }
+
+func TestAfterFuncMayUseClock(t *testing.T) {
+	clock := NewDeterministicClock(time.UnixMilli(1000))
+	var calls []time.Time
+	var reschedule func()
+	reschedule = func() {
+		calls = append(calls, clock.Now())
+		if len(calls) < 3 {
+			clock.AfterFunc(time.Second, reschedule)
+		}
+	}
+	clock.AfterFunc(time.Second, reschedule)
+
+	clock.AdvanceTime(time.Second)
+	require.Equal(t, []time.Time{time.UnixMilli(2000)}, calls)
+
+	// Timers scheduled by callbacks fire at their due time
+	clock.RunFor(10 * time.Second)
+	require.Equal(t, []time.Time{time.UnixMilli(2000), time.UnixMilli(3000), time.UnixMilli(4000)}, calls)
+	require.Equal(t, time.UnixMilli(12000), clock.Now())
+	require.Zero(t, clock.PendingTasks())
+}
+
+func TestNextDue(t *testing.T) {
+	clock := NewDeterministicClock(time.UnixMilli(1000))
+	_, ok := clock.NextDue()
+	require.False(t, ok, "nothing pending")
+	require.False(t, clock.AdvanceToNextDue())
+	require.Equal(t, time.UnixMilli(1000), clock.Now(), "should not advance time when nothing is pending")
+
+	ticker := clock.NewTicker(5 * time.Second)
+	timer := clock.NewTimer(3 * time.Second)
+	after := clock.After(3 * time.Second)
+	require.Equal(t, 3, clock.PendingTasks())
+
+	next, ok := clock.NextDue()
+	require.True(t, ok)
+	require.Equal(t, time.UnixMilli(4000), next)
+
+	require.True(t, clock.AdvanceToNextDue())
+	require.Equal(t, time.UnixMilli(4000), clock.Now())
+	require.Equal(t, time.UnixMilli(4000), <-timer.Ch())
+	require.Equal(t, time.UnixMilli(4000), <-after)
+	require.Len(t, ticker.Ch(), 0, "should not fire before due")
+
+	require.True(t, clock.AdvanceToNextDue())
+	require.Equal(t, time.UnixMilli(6000), clock.Now())
+	require.Equal(t, time.UnixMilli(6000), <-ticker.Ch())
+	require.Equal(t, 1, clock.PendingTasks(), "only the ticker is still pending")
+}
+
+func TestRunFor(t *testing.T) {
+	t.Run("FiresInDueOrder", func(t *testing.T) {
+		clock := NewDeterministicClock(time.UnixMilli(1000))
+		var order []string
+		clock.AfterFunc(3*time.Second, func() { order = append(order, "3s") })
+		clock.AfterFunc(1*time.Second, func() { order = append(order, "1s") })
+		clock.AfterFunc(2*time.Second, func() { order = append(order, "2s") })
+		clock.AfterFunc(5*time.Second, func() { order = append(order, "5s") })
+
+		clock.RunFor(4 * time.Second)
+		require.Equal(t, []string{"1s", "2s", "3s"}, order)
+		require.Equal(t, time.UnixMilli(5000), clock.Now())
+	})
+
+	t.Run("TicksOncePerPeriod", func(t *testing.T) {
+		clock := NewDeterministicClock(time.UnixMilli(1000))
+		ticker := clock.NewTicker(time.Second)
+		var ticks []time.Time
+		// Receive each tick before the next one is due, like a consumer that keeps up.
+		for i := 0; i < 3; i++ {
+			clock.AfterFunc(time.Duration(i+1)*time.Second, func() {
+				ticks = append(ticks, <-ticker.Ch())
+			})
+		}
+		clock.RunFor(3500 * time.Millisecond)
+		require.Equal(t, []time.Time{time.UnixMilli(2000), time.UnixMilli(3000), time.UnixMilli(4000)}, ticks)
+		require.Equal(t, time.UnixMilli(4500), clock.Now())
+	})
+
+	t.Run("DropsTicksNotReceived", func(t *testing.T) {
+		clock := NewDeterministicClock(time.UnixMilli(1000))
+		ticker := clock.NewTicker(time.Second)
+		clock.RunFor(5 * time.Second)
+		require.Len(t, ticker.Ch(), 1)
+		require.Equal(t, time.UnixMilli(2000), <-ticker.Ch(), "should keep the first tick")
+
+		clock.RunFor(time.Second)
+		require.Equal(t, time.UnixMilli(7000), <-ticker.Ch(), "should continue ticking each period")
+	})
+}
@@ -43,12 +43,25 @@ type SignerFn func(context.Context, common.Address, *types.Transaction) (*types.
 type SignerFactory func(chainID *big.Int) SignerFn
 
 // SignerFactoryFromConfig considers three ways that signers are created & then creates single factory from those config options.
-// It can either take a remote signer (via opsigner.CLIConfig) or it can be provided either a mnemonic + derivation path or a private key.
-// It prefers the remote signer, then the mnemonic or private key (only one of which can be provided).
+// It can either take a signer backend (via opsigner.CLIConfig: a remote signer, AWS KMS or HashiCorp Vault)
+// or it can be provided either a mnemonic + derivation path or a private key.
+// It prefers the signer backend, then the mnemonic or private key (only one of which can be provided).
 func SignerFactoryFromConfig(l log.Logger, privateKey, mnemonic, hdPath string, signerConfig opsigner.CLIConfig) (SignerFactory, common.Address, error) {
 	var signer SignerFactory
 	var fromAddress common.Address
-	if signerConfig.Enabled() {
+	if signerConfig.Enabled() && (signerConfig.Backend == opsigner.BackendAWSKMS || signerConfig.Backend == opsigner.BackendVault) {
+		keySigner, err := opsigner.NewKeySignerFromConfig(l, signerConfig)
+		if err != nil {
+			l.Error("Unable to create key signer", "backend", signerConfig.Backend, "error", err)
+			return nil, common.Address{}, fmt.Errorf("failed to create the %s signer: %w", signerConfig.Backend, err)
+		}
+		fromAddress = keySigner.Address()
+		signer = func(chainID *big.Int) SignerFn {
+			return func(ctx context.Context, address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+				return keySigner.SignTransaction(ctx, chainID, address, tx)
+			}
+		}
+	} else if signerConfig.Enabled() {
 		signerClient, err := opsigner.NewSignerClientFromConfig(l, signerConfig)
 		if err != nil {
 			l.Error("Unable to create Signer Client", "error", err)
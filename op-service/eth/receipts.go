@@ -1,6 +1,7 @@
 package eth
 
 import (
+	"bytes"
 	"fmt"
 	"math/big"
 
@@ -30,7 +31,14 @@ func DecodeRawReceipts(block BlockID, rawReceipts []hexutil.Bytes, txHashes []co
 	prevCumulativeGasUsed := uint64(0)
 	for i, r := range rawReceipts {
 		var x types.Receipt
-		if err := x.UnmarshalBinary(r); err != nil {
+		if IsSetCodeTx(r) {
+			// Set-code receipts are encoded like dynamic-fee receipts, but go-ethereum does not decode them yet.
+			typed := append([]byte{types.DynamicFeeTxType}, r[1:]...)
+			if err := x.UnmarshalBinary(typed); err != nil {
+				return nil, fmt.Errorf("failed to decode set-code receipt %d: %w", i, err)
+			}
+			x.Type = SetCodeTxType
+		} else if err := x.UnmarshalBinary(r); err != nil {
 			return nil, fmt.Errorf("failed to decode receipt %d: %w", i, err)
 		}
 		x.TxHash = txHashes[i]
@@ -52,3 +60,21 @@ func DecodeRawReceipts(block BlockID, rawReceipts []hexutil.Bytes, txHashes []co
 	}
 	return result, nil
 }
+
+// ReceiptsList computes the receipts trie like types.Receipts, but also encodes set-code transaction receipts,
+// which go-ethereum does not include in the receipts trie yet.
+type ReceiptsList types.Receipts
+
+func (rs ReceiptsList) Len() int { return len(rs) }
+
+func (rs ReceiptsList) EncodeIndex(i int, w *bytes.Buffer) {
+	if rs[i].Type != SetCodeTxType {
+		types.Receipts(rs).EncodeIndex(i, w)
+		return
+	}
+	// The consensus encoding of typed receipts is the same as the receipts trie encoding.
+	// On error nothing is written, like go-ethereum does for unsupported types: the root will not match.
+	if data, err := rs[i].MarshalBinary(); err == nil {
+		w.Write(data)
+	}
+}
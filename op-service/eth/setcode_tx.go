@@ -0,0 +1,199 @@
+package eth
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// SetCodeTxType is the EIP-7702 set-code transaction type.
+// The go-ethereum transaction types used by the services do not support it yet,
+// so blocks and receipts with set-code transactions are handled with the types in this file instead.
+const SetCodeTxType = 0x04
+
+// SetCodeAuthorizationGas is the intrinsic gas cost per authorization of a set-code transaction,
+// the PER_EMPTY_ACCOUNT_COST of EIP-7702.
+const SetCodeAuthorizationGas = 25000
+
+var ErrNotSetCodeTx = errors.New("not a set-code transaction")
+
+// SetCodeAuthorization is an EIP-7702 authorization to set the code of the signing account.
+type SetCodeAuthorization struct {
+	ChainID *hexutil.Big   `json:"chainId"`
+	Address common.Address `json:"address"`
+	Nonce   hexutil.Uint64 `json:"nonce"`
+	YParity hexutil.Uint64 `json:"yParity"`
+	R       *hexutil.Big   `json:"r"`
+	S       *hexutil.Big   `json:"s"`
+}
+
+// SetCodeTx is an EIP-7702 set-code transaction, in the JSON format of the execution-layer RPC.
+type SetCodeTx struct {
+	ChainID           *hexutil.Big           `json:"chainId"`
+	Nonce             hexutil.Uint64         `json:"nonce"`
+	GasTipCap         *hexutil.Big           `json:"maxPriorityFeePerGas"`
+	GasFeeCap         *hexutil.Big           `json:"maxFeePerGas"`
+	Gas               hexutil.Uint64         `json:"gas"`
+	To                common.Address         `json:"to"`
+	Value             *hexutil.Big           `json:"value"`
+	Data              hexutil.Bytes          `json:"input"`
+	AccessList        types.AccessList       `json:"accessList"`
+	AuthorizationList []SetCodeAuthorization `json:"authorizationList"`
+	YParity           hexutil.Uint64         `json:"yParity"`
+	R                 *hexutil.Big           `json:"r"`
+	S                 *hexutil.Big           `json:"s"`
+}
+
+type setCodeAuthorizationRLP struct {
+	ChainID *big.Int
+	Address common.Address
+	Nonce   uint64
+	YParity uint8
+	R       *big.Int
+	S       *big.Int
+}
+
+type setCodeTxRLP struct {
+	ChainID           *big.Int
+	Nonce             uint64
+	GasTipCap         *big.Int
+	GasFeeCap         *big.Int
+	Gas               uint64
+	To                common.Address
+	Value             *big.Int
+	Data              []byte
+	AccessList        types.AccessList
+	AuthorizationList []setCodeAuthorizationRLP
+	YParity           uint8
+	R                 *big.Int
+	S                 *big.Int
+}
+
+func bigOrZero(v *hexutil.Big) *big.Int {
+	if v == nil {
+		return new(big.Int)
+	}
+	return (*big.Int)(v)
+}
+
+// MarshalBinary returns the canonical encoding of the transaction, as included in the transactions trie.
+func (tx *SetCodeTx) MarshalBinary() ([]byte, error) {
+	if tx.YParity > 1 {
+		return nil, fmt.Errorf("invalid y-parity %d", tx.YParity)
+	}
+	auths := make([]setCodeAuthorizationRLP, len(tx.AuthorizationList))
+	for i, a := range tx.AuthorizationList {
+		if a.YParity > 1 {
+			return nil, fmt.Errorf("invalid y-parity %d of authorization %d", a.YParity, i)
+		}
+		auths[i] = setCodeAuthorizationRLP{
+			ChainID: bigOrZero(a.ChainID),
+			Address: a.Address,
+			Nonce:   uint64(a.Nonce),
+			YParity: uint8(a.YParity),
+			R:       bigOrZero(a.R),
+			S:       bigOrZero(a.S),
+		}
+	}
+	accessList := tx.AccessList
+	if accessList == nil {
+		accessList = types.AccessList{}
+	}
+	var buf bytes.Buffer
+	buf.WriteByte(SetCodeTxType)
+	err := rlp.Encode(&buf, &setCodeTxRLP{
+		ChainID:           bigOrZero(tx.ChainID),
+		Nonce:             uint64(tx.Nonce),
+		GasTipCap:         bigOrZero(tx.GasTipCap),
+		GasFeeCap:         bigOrZero(tx.GasFeeCap),
+		Gas:               uint64(tx.Gas),
+		To:                tx.To,
+		Value:             bigOrZero(tx.Value),
+		Data:              tx.Data,
+		AccessList:        accessList,
+		AuthorizationList: auths,
+		YParity:           uint8(tx.YParity),
+		R:                 bigOrZero(tx.R),
+		S:                 bigOrZero(tx.S),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary decodes the canonical encoding of a set-code transaction.
+func (tx *SetCodeTx) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 || data[0] != SetCodeTxType {
+		return ErrNotSetCodeTx
+	}
+	var dec setCodeTxRLP
+	if err := rlp.DecodeBytes(data[1:], &dec); err != nil {
+		return fmt.Errorf("failed to decode set-code tx: %w", err)
+	}
+	auths := make([]SetCodeAuthorization, len(dec.AuthorizationList))
+	for i, a := range dec.AuthorizationList {
+		auths[i] = SetCodeAuthorization{
+			ChainID: (*hexutil.Big)(a.ChainID),
+			Address: a.Address,
+			Nonce:   hexutil.Uint64(a.Nonce),
+			YParity: hexutil.Uint64(a.YParity),
+			R:       (*hexutil.Big)(a.R),
+			S:       (*hexutil.Big)(a.S),
+		}
+	}
+	*tx = SetCodeTx{
+		ChainID:           (*hexutil.Big)(dec.ChainID),
+		Nonce:             hexutil.Uint64(dec.Nonce),
+		GasTipCap:         (*hexutil.Big)(dec.GasTipCap),
+		GasFeeCap:         (*hexutil.Big)(dec.GasFeeCap),
+		Gas:               hexutil.Uint64(dec.Gas),
+		To:                dec.To,
+		Value:             (*hexutil.Big)(dec.Value),
+		Data:              dec.Data,
+		AccessList:        dec.AccessList,
+		AuthorizationList: auths,
+		YParity:           hexutil.Uint64(dec.YParity),
+		R:                 (*hexutil.Big)(dec.R),
+		S:                 (*hexutil.Big)(dec.S),
+	}
+	return nil
+}
+
+// Hash returns the transaction hash.
+func (tx *SetCodeTx) Hash() (common.Hash, error) {
+	data, err := tx.MarshalBinary()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return crypto.Keccak256Hash(data), nil
+}
+
+// IntrinsicGas returns the gas that is charged for the transaction before execution.
+func (tx *SetCodeTx) IntrinsicGas() uint64 {
+	gas := params.TxGas
+	for _, b := range tx.Data {
+		if b == 0 {
+			gas += params.TxDataZeroGas
+		} else {
+			gas += params.TxDataNonZeroGasEIP2028
+		}
+	}
+	gas += uint64(len(tx.AccessList)) * params.TxAccessListAddressGas
+	gas += uint64(tx.AccessList.StorageKeys()) * params.TxAccessListStorageKeyGas
+	gas += uint64(len(tx.AuthorizationList)) * SetCodeAuthorizationGas
+	return gas
+}
+
+// IsSetCodeTx returns whether the opaque transaction is a set-code transaction.
+func IsSetCodeTx(opaqueTx []byte) bool {
+	return len(opaqueTx) > 0 && opaqueTx[0] == SetCodeTxType
+}
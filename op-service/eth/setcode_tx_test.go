@@ -0,0 +1,130 @@
+package eth
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/stretchr/testify/require"
+)
+
+func testSetCodeTx() *SetCodeTx {
+	return &SetCodeTx{
+		ChainID:    (*hexutil.Big)(big.NewInt(1)),
+		Nonce:      7,
+		GasTipCap:  (*hexutil.Big)(big.NewInt(1_000_000_000)),
+		GasFeeCap:  (*hexutil.Big)(big.NewInt(30_000_000_000)),
+		Gas:        100_000,
+		To:         common.Address{0xaa},
+		Value:      (*hexutil.Big)(big.NewInt(0)),
+		Data:       hexutil.Bytes{0x00, 0x01},
+		AccessList: types.AccessList{{Address: common.Address{0xbb}, StorageKeys: []common.Hash{{0x01}}}},
+		AuthorizationList: []SetCodeAuthorization{{
+			ChainID: (*hexutil.Big)(big.NewInt(1)),
+			Address: common.Address{0xcc},
+			Nonce:   3,
+			YParity: 1,
+			R:       (*hexutil.Big)(big.NewInt(123)),
+			S:       (*hexutil.Big)(big.NewInt(456)),
+		}},
+		YParity: 0,
+		R:       (*hexutil.Big)(big.NewInt(789)),
+		S:       (*hexutil.Big)(big.NewInt(1011)),
+	}
+}
+
+func TestSetCodeTx(t *testing.T) {
+	t.Run("BinaryRoundTrip", func(t *testing.T) {
+		tx := testSetCodeTx()
+		data, err := tx.MarshalBinary()
+		require.NoError(t, err)
+		require.True(t, IsSetCodeTx(data))
+
+		var decoded SetCodeTx
+		require.NoError(t, decoded.UnmarshalBinary(data))
+		require.Equal(t, tx, &decoded)
+
+		hash, err := tx.Hash()
+		require.NoError(t, err)
+		require.Equal(t, crypto.Keccak256Hash(data), hash)
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		tx := testSetCodeTx()
+		data, err := json.Marshal(tx)
+		require.NoError(t, err)
+		var decoded SetCodeTx
+		require.NoError(t, json.Unmarshal(data, &decoded))
+		expected, err := tx.MarshalBinary()
+		require.NoError(t, err)
+		actual, err := decoded.MarshalBinary()
+		require.NoError(t, err)
+		require.Equal(t, expected, actual)
+	})
+
+	t.Run("NotSetCodeTx", func(t *testing.T) {
+		var tx SetCodeTx
+		require.ErrorIs(t, tx.UnmarshalBinary([]byte{types.DynamicFeeTxType, 0xc0}), ErrNotSetCodeTx)
+		require.ErrorIs(t, tx.UnmarshalBinary(nil), ErrNotSetCodeTx)
+		require.False(t, IsSetCodeTx([]byte{types.BlobTxType}))
+	})
+
+	t.Run("InvalidYParity", func(t *testing.T) {
+		tx := testSetCodeTx()
+		tx.AuthorizationList[0].YParity = 2
+		_, err := tx.MarshalBinary()
+		require.ErrorContains(t, err, "y-parity")
+	})
+
+	t.Run("IntrinsicGas", func(t *testing.T) {
+		tx := testSetCodeTx()
+		expected := params.TxGas + params.TxDataZeroGas + params.TxDataNonZeroGasEIP2028 +
+			params.TxAccessListAddressGas + params.TxAccessListStorageKeyGas + SetCodeAuthorizationGas
+		require.Equal(t, expected, tx.IntrinsicGas())
+	})
+}
+
+func TestSetCodeReceipts(t *testing.T) {
+	receipt := &types.Receipt{
+		Type:              SetCodeTxType,
+		Status:            types.ReceiptStatusSuccessful,
+		CumulativeGasUsed: 50_000,
+		Logs:              []*types.Log{{Address: common.Address{0xaa}, Topics: []common.Hash{{0x01}}, Data: []byte{0x02}}},
+	}
+	receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+	raw, err := EncodeReceipts([]*types.Receipt{receipt})
+	require.NoError(t, err)
+	require.True(t, IsSetCodeTx(raw[0]))
+
+	block := BlockID{Hash: common.Hash{0x01}, Number: 10}
+	decoded, err := DecodeRawReceipts(block, raw, []common.Hash{{0x02}})
+	require.NoError(t, err)
+	require.Len(t, decoded, 1)
+	require.Equal(t, uint8(SetCodeTxType), decoded[0].Type)
+	require.Equal(t, receipt.CumulativeGasUsed, decoded[0].GasUsed)
+	require.Equal(t, common.Hash{0x02}, decoded[0].Logs[0].TxHash)
+
+	// The set-code receipt must be included in the receipts trie, with its typed encoding
+	expectedRoot := types.DeriveSha(rawList(raw), trie.NewStackTrie(nil))
+	require.NotEqual(t, types.EmptyReceiptsHash, expectedRoot)
+	require.Equal(t, expectedRoot, types.DeriveSha(ReceiptsList(decoded), trie.NewStackTrie(nil)))
+	// A dynamic-fee receipt with the same contents has the same encoding, apart from the type
+	asDynamicFee := *receipt
+	asDynamicFee.Type = types.DynamicFeeTxType
+	dynamicFeeRaw, err := asDynamicFee.MarshalBinary()
+	require.NoError(t, err)
+	require.Equal(t, []byte(raw[0][1:]), dynamicFeeRaw[1:])
+}
+
+type rawList []hexutil.Bytes
+
+func (l rawList) Len() int { return len(l) }
+
+func (l rawList) EncodeIndex(i int, w *bytes.Buffer) { w.Write(l[i]) }
@@ -0,0 +1,94 @@
+package eth
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	ErrInvalidSuperRoot        = errors.New("invalid super root")
+	ErrInvalidSuperRootVersion = errors.New("invalid super root version")
+)
+
+const (
+	SuperRootVersionV1 = byte(1)
+
+	superRootV1HeaderLen = 1 + 8
+	chainIDAndOutputLen  = 32 + 32
+)
+
+// ChainIDAndOutput is the output root of a single chain in a super root.
+type ChainIDAndOutput struct {
+	ChainID uint64
+	Output  Bytes32
+}
+
+// SuperV1 commits to the output roots of all chains in a dependency set at the same timestamp.
+// Chains must be sorted by chain ID.
+type SuperV1 struct {
+	Timestamp uint64
+	Chains    []ChainIDAndOutput
+}
+
+func (s *SuperV1) Version() byte {
+	return SuperRootVersionV1
+}
+
+// Marshal a super root into a byte slice for hashing.
+// The encoding is the version byte, the timestamp, and the 32 byte chain ID and output root of each chain.
+func (s *SuperV1) Marshal() []byte {
+	buf := make([]byte, 0, superRootV1HeaderLen+len(s.Chains)*chainIDAndOutputLen)
+	buf = append(buf, s.Version())
+	buf = binary.BigEndian.AppendUint64(buf, s.Timestamp)
+	for _, c := range s.Chains {
+		var chainID Bytes32
+		binary.BigEndian.PutUint64(chainID[24:], c.ChainID)
+		buf = append(buf, chainID[:]...)
+		buf = append(buf, c.Output[:]...)
+	}
+	return buf
+}
+
+// SuperRoot returns the keccak256 hash of the marshaled super root
+func SuperRoot(super *SuperV1) Bytes32 {
+	return Bytes32(crypto.Keccak256Hash(super.Marshal()))
+}
+
+func UnmarshalSuperRoot(data []byte) (*SuperV1, error) {
+	if len(data) < 1 {
+		return nil, ErrInvalidSuperRoot
+	}
+	switch data[0] {
+	case SuperRootVersionV1:
+		return unmarshalSuperRootV1(data)
+	default:
+		return nil, ErrInvalidSuperRootVersion
+	}
+}
+
+func unmarshalSuperRootV1(data []byte) (*SuperV1, error) {
+	if len(data) < superRootV1HeaderLen || (len(data)-superRootV1HeaderLen)%chainIDAndOutputLen != 0 {
+		return nil, ErrInvalidSuperRoot
+	}
+	// data[0] is the version
+	super := &SuperV1{Timestamp: binary.BigEndian.Uint64(data[1:9])}
+	for i := superRootV1HeaderLen; i < len(data); i += chainIDAndOutputLen {
+		chainID := data[i : i+32]
+		// Chain IDs are encoded as 32 bytes, but must fit a uint64
+		for _, b := range chainID[:24] {
+			if b != 0 {
+				return nil, ErrInvalidSuperRoot
+			}
+		}
+		var c ChainIDAndOutput
+		c.ChainID = binary.BigEndian.Uint64(chainID[24:])
+		copy(c.Output[:], data[i+32:i+chainIDAndOutputLen])
+		if len(super.Chains) > 0 && super.Chains[len(super.Chains)-1].ChainID >= c.ChainID {
+			return nil, ErrInvalidSuperRoot
+		}
+		super.Chains = append(super.Chains, c)
+	}
+	return super, nil
+}
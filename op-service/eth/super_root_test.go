@@ -0,0 +1,34 @@
+package eth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuperRootV1Codec(t *testing.T) {
+	super := &SuperV1{
+		Timestamp: 7000,
+		Chains: []ChainIDAndOutput{
+			{ChainID: 10, Output: Bytes32{1, 2, 3}},
+			{ChainID: 8453, Output: Bytes32{4, 5, 6}},
+		},
+	}
+	marshaled := super.Marshal()
+	require.Len(t, marshaled, 1+8+2*64)
+	unmarshaled, err := UnmarshalSuperRoot(marshaled)
+	require.NoError(t, err)
+	require.Equal(t, super, unmarshaled)
+	require.Equal(t, SuperRoot(super), SuperRoot(unmarshaled))
+
+	_, err = UnmarshalSuperRoot([]byte{0x2, 0})
+	require.ErrorIs(t, err, ErrInvalidSuperRootVersion)
+	_, err = UnmarshalSuperRoot(marshaled[:len(marshaled)-1])
+	require.ErrorIs(t, err, ErrInvalidSuperRoot)
+	_, err = UnmarshalSuperRoot(nil)
+	require.ErrorIs(t, err, ErrInvalidSuperRoot)
+
+	unsorted := &SuperV1{Timestamp: 7000, Chains: []ChainIDAndOutput{super.Chains[1], super.Chains[0]}}
+	_, err = UnmarshalSuperRoot(unsorted.Marshal())
+	require.ErrorIs(t, err, ErrInvalidSuperRoot)
+}
@@ -39,3 +39,24 @@ type SyncStatus struct {
 	// LocalSafeL2 is an L2 block derived from L1, not yet verified to have valid cross-L2 dependencies.
 	LocalSafeL2 L2BlockRef `json:"local_safe_l2"`
 }
+
+// HeadUpdateKind identifies which L2 head label a HeadUpdate applies to.
+type HeadUpdateKind string
+
+const (
+	HeadUpdateUnsafe    HeadUpdateKind = "unsafe"
+	HeadUpdateSafe      HeadUpdateKind = "safe"
+	HeadUpdateFinalized HeadUpdateKind = "finalized"
+)
+
+// HeadUpdate describes an advancement (or reorg) of one of the L2 head labels.
+type HeadUpdate struct {
+	Kind HeadUpdateKind `json:"kind"`
+	// Head is the new L2 block with this label.
+	Head L2BlockRef `json:"head"`
+	// L1 is the L1 block that caused the update:
+	// the L1 origin of the block for unsafe updates,
+	// the L1 block the derivation process was at for safe updates,
+	// and the finalized L1 block for finalized updates.
+	L1 BlockID `json:"l1"`
+}
@@ -0,0 +1,93 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	// FastLatencyBuckets are the latency buckets, in seconds, of operations that usually complete within a second,
+	// like RPC calls and derivation steps.
+	FastLatencyBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+	// SlowLatencyBuckets are the latency buckets, in seconds, of operations that may take minutes to complete,
+	// like the submission and confirmation of a transaction.
+	SlowLatencyBuckets = []float64{.5, 1, 2, 5, 10, 20, 30, 60, 120, 300, 600, 1200}
+)
+
+// TraceIDLabel is the exemplar label that latency observations are annotated with.
+const TraceIDLabel = "trace_id"
+
+type traceIDKey struct{}
+
+// ContextWithTraceID returns a copy of ctx that carries the given trace ID.
+// Latencies observed with the returned context are annotated with the trace ID as exemplar.
+func ContextWithTraceID(ctx context.Context, traceID string) context.Context {
+	return context.WithValue(ctx, traceIDKey{}, traceID)
+}
+
+// TraceIDFromContext returns the trace ID carried by ctx, or an empty string if there is none.
+func TraceIDFromContext(ctx context.Context) string {
+	traceID, _ := ctx.Value(traceIDKey{}).(string)
+	return traceID
+}
+
+// LatencyFactory creates latency histograms for a single module,
+// so all latencies of the module share the same namespace, subsystem and naming scheme.
+type LatencyFactory struct {
+	factory   Factory
+	ns        string
+	subsystem string
+}
+
+func NewLatencyFactory(factory Factory, ns string, subsystem string) *LatencyFactory {
+	return &LatencyFactory{
+		factory:   factory,
+		ns:        ns,
+		subsystem: subsystem,
+	}
+}
+
+// NewLatency creates a histogram named <name>_duration_seconds with the given buckets, which should usually be
+// FastLatencyBuckets or SlowLatencyBuckets.
+func (f *LatencyFactory) NewLatency(name string, displayName string, buckets []float64, labelNames ...string) *Latency {
+	return &Latency{
+		vec: f.factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: f.ns,
+			Subsystem: f.subsystem,
+			Name:      fmt.Sprintf("%s_duration_seconds", name),
+			Help:      fmt.Sprintf("Histogram of %s durations, in seconds", displayName),
+			Buckets:   buckets,
+		}, labelNames),
+	}
+}
+
+// Latency is a latency histogram that annotates observations with the trace ID of the context as exemplar.
+type Latency struct {
+	vec *prometheus.HistogramVec
+}
+
+// Observe records the latency of an operation.
+// The observation is annotated with the trace ID of ctx as exemplar, if ctx carries one.
+func (l *Latency) Observe(ctx context.Context, d time.Duration, lvs ...string) {
+	obs := l.vec.WithLabelValues(lvs...)
+	traceID := TraceIDFromContext(ctx)
+	if eo, ok := obs.(prometheus.ExemplarObserver); ok && traceID != "" &&
+		utf8.ValidString(traceID) && utf8.RuneCountInString(TraceIDLabel)+utf8.RuneCountInString(traceID) <= prometheus.ExemplarMaxRunes {
+		eo.ObserveWithExemplar(d.Seconds(), prometheus.Labels{TraceIDLabel: traceID})
+		return
+	}
+	obs.Observe(d.Seconds())
+}
+
+// Time starts timing an operation, and returns a function to call when the operation completes.
+// Calling the returned function records the latency of the operation.
+func (l *Latency) Time(ctx context.Context, lvs ...string) func() {
+	start := time.Now()
+	return func() {
+		l.Observe(ctx, time.Since(start), lvs...)
+	}
+}
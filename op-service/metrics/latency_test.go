@@ -0,0 +1,51 @@
+package metrics
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatency(t *testing.T) {
+	registry := NewRegistry()
+	factory := NewLatencyFactory(With(registry), "test", "module")
+	latency := factory.NewLatency("rpc_call", "RPC call", FastLatencyBuckets, "method")
+
+	latency.Observe(context.Background(), 20*time.Millisecond, "eth_call")
+	latency.Observe(ContextWithTraceID(context.Background(), "abc123"), 2*time.Second, "eth_call")
+	latency.Observe(ContextWithTraceID(context.Background(), strings.Repeat("x", 200)), 3*time.Millisecond, "eth_call")
+	done := latency.Time(ContextWithTraceID(context.Background(), "def456"), "eth_getLogs")
+	done()
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	var histograms map[string]*dto.Histogram
+	for _, family := range families {
+		if family.GetName() != "test_module_rpc_call_duration_seconds" {
+			continue
+		}
+		histograms = make(map[string]*dto.Histogram)
+		for _, m := range family.GetMetric() {
+			histograms[m.GetLabel()[0].GetValue()] = m.GetHistogram()
+		}
+	}
+	require.NotNil(t, histograms, "latency histogram must be registered")
+
+	call := histograms["eth_call"]
+	require.Equal(t, uint64(3), call.GetSampleCount())
+	exemplars := make(map[string]float64)
+	for _, bucket := range call.GetBucket() {
+		if ex := bucket.GetExemplar(); ex != nil {
+			exemplars[ex.GetLabel()[0].GetValue()] = ex.GetValue()
+		}
+	}
+	require.Equal(t, map[string]float64{"abc123": 2}, exemplars, "only valid trace IDs are recorded as exemplar")
+
+	getLogs := histograms["eth_getLogs"]
+	require.Equal(t, uint64(1), getLogs.GetSampleCount())
+	require.Equal(t, "def456", getLogs.GetBucket()[0].GetExemplar().GetLabel()[0].GetValue())
+}
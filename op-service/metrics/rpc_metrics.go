@@ -21,8 +21,18 @@ type RPCClientMetricer interface {
 	RecordRPCClientResponse(method string, err error)
 }
 
+// CircuitBreakerMetricer records the state of the circuit breakers of RPC client endpoints.
+type CircuitBreakerMetricer interface {
+	// RecordCircuitBreakerState records the state of the circuit breaker of the endpoint:
+	// 0 if closed, 1 if half-open and 2 if open.
+	RecordCircuitBreakerState(endpoint string, state int)
+	// RecordCircuitBreakerRejection records a request that was rejected because the circuit of the endpoint is open.
+	RecordCircuitBreakerRejection(endpoint string)
+}
+
 type RPCServerMetricer interface {
 	RecordRPCServerRequest(method string) func()
+	RecordRPCServerRejection(method string, reason string)
 }
 
 type RPCMetricer interface {
@@ -35,12 +45,21 @@ type RPCClientMetrics struct {
 	RPCClientRequestsTotal          *prometheus.CounterVec
 	RPCClientRequestDurationSeconds *prometheus.HistogramVec
 	RPCClientResponsesTotal         *prometheus.CounterVec
+
+	CircuitBreakerMetrics
+}
+
+// CircuitBreakerMetrics tracks the circuit breakers of RPC client endpoints
+type CircuitBreakerMetrics struct {
+	RPCClientCircuitBreakerState           *prometheus.GaugeVec
+	RPCClientCircuitBreakerRejectionsTotal *prometheus.CounterVec
 }
 
 // RPCMetrics tracks server-only RPC metrics
 type RPCServerMetrics struct {
 	RPCServerRequestsTotal          *prometheus.CounterVec
 	RPCServerRequestDurationSeconds *prometheus.HistogramVec
+	RPCServerRejectionsTotal        *prometheus.CounterVec
 }
 
 // RPCMetrics tracks all the RPC metrics, both client & server
@@ -72,7 +91,7 @@ func MakeRPCClientMetrics(ns string, factory Factory) RPCClientMetrics {
 			Namespace: ns,
 			Subsystem: RPCClientSubsystem,
 			Name:      "request_duration_seconds",
-			Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+			Buckets:   FastLatencyBuckets,
 			Help:      "Histogram of RPC client request durations",
 		}, []string{
 			"method",
@@ -86,9 +105,40 @@ func MakeRPCClientMetrics(ns string, factory Factory) RPCClientMetrics {
 			"method",
 			"error",
 		}),
+		CircuitBreakerMetrics: MakeCircuitBreakerMetrics(ns, factory),
 	}
 }
 
+// MakeCircuitBreakerMetrics creates a new CircuitBreakerMetrics instance with the given namespace
+func MakeCircuitBreakerMetrics(ns string, factory Factory) CircuitBreakerMetrics {
+	return CircuitBreakerMetrics{
+		RPCClientCircuitBreakerState: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Subsystem: RPCClientSubsystem,
+			Name:      "circuit_breaker_state",
+			Help:      "State of the circuit breaker of each RPC endpoint: 0 if closed, 1 if half-open, 2 if open",
+		}, []string{
+			"endpoint",
+		}),
+		RPCClientCircuitBreakerRejectionsTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Subsystem: RPCClientSubsystem,
+			Name:      "circuit_breaker_rejections_total",
+			Help:      "Total RPC requests rejected because the circuit breaker of the endpoint was open",
+		}, []string{
+			"endpoint",
+		}),
+	}
+}
+
+func (m *CircuitBreakerMetrics) RecordCircuitBreakerState(endpoint string, state int) {
+	m.RPCClientCircuitBreakerState.WithLabelValues(endpoint).Set(float64(state))
+}
+
+func (m *CircuitBreakerMetrics) RecordCircuitBreakerRejection(endpoint string) {
+	m.RPCClientCircuitBreakerRejectionsTotal.WithLabelValues(endpoint).Inc()
+}
+
 // RecordRPCClientRequest is a helper method to record an RPC client
 // request. It bumps the requests metric, tracks the response
 // duration, and records the response's error code.
@@ -140,11 +190,20 @@ func MakeRPCServerMetrics(ns string, factory Factory) RPCServerMetrics {
 			Namespace: ns,
 			Subsystem: RPCServerSubsystem,
 			Name:      "request_duration_seconds",
-			Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+			Buckets:   FastLatencyBuckets,
 			Help:      "Histogram of RPC server request durations",
 		}, []string{
 			"method",
 		}),
+		RPCServerRejectionsTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Subsystem: RPCServerSubsystem,
+			Name:      "rejections_total",
+			Help:      "Total requests rejected by the RPC server limits, by reason",
+		}, []string{
+			"method",
+			"reason",
+		}),
 	}
 }
 
@@ -159,12 +218,23 @@ func (m *RPCServerMetrics) RecordRPCServerRequest(method string) func() {
 	}
 }
 
-type NoopRPCMetrics struct{}
+// RecordRPCServerRejection records a request that was rejected by the limits of the RPC server.
+// The method is only set if the request was rejected by the rate limit of that method.
+func (m *RPCServerMetrics) RecordRPCServerRejection(method string, reason string) {
+	m.RPCServerRejectionsTotal.WithLabelValues(method, reason).Inc()
+}
+
+type NoopRPCMetrics struct {
+	NoopCircuitBreakerMetrics
+}
 
 func (n *NoopRPCMetrics) RecordRPCServerRequest(method string) func() {
 	return func() {}
 }
 
+func (n *NoopRPCMetrics) RecordRPCServerRejection(method string, reason string) {
+}
+
 func (n *NoopRPCMetrics) RecordRPCClientRequest(method string) func(err error) {
 	return func(err error) {}
 }
@@ -172,3 +242,11 @@ func (n *NoopRPCMetrics) RecordRPCClientResponse(method string, err error) {
 }
 
 var _ RPCMetricer = (*NoopRPCMetrics)(nil)
+
+type NoopCircuitBreakerMetrics struct{}
+
+func (n *NoopCircuitBreakerMetrics) RecordCircuitBreakerState(endpoint string, state int) {}
+
+func (n *NoopCircuitBreakerMetrics) RecordCircuitBreakerRejection(endpoint string) {}
+
+var _ CircuitBreakerMetricer = (*NoopCircuitBreakerMetrics)(nil)
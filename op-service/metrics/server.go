@@ -12,7 +12,10 @@ import (
 func StartServer(r *prometheus.Registry, hostname string, port int) (*httputil.HTTPServer, error) {
 	addr := net.JoinHostPort(hostname, strconv.Itoa(port))
 	h := promhttp.InstrumentMetricHandler(
-		r, promhttp.HandlerFor(r, promhttp.HandlerOpts{}),
+		r, promhttp.HandlerFor(r, promhttp.HandlerOpts{
+			// OpenMetrics is required to expose the exemplars of latency histograms
+			EnableOpenMetrics: true,
+		}),
 	)
 	return httputil.StartHTTPServer(addr, h)
 }
@@ -0,0 +1,103 @@
+package objectstore
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"sync"
+)
+
+// ErrUnavailable is returned by a MemStore while it is set to fail.
+var ErrUnavailable = errors.New("object store unavailable")
+
+// MemStore is an in-memory Store, with controls to simulate an unavailable or slow store in tests.
+type MemStore struct {
+	mu       sync.Mutex
+	objects  map[string][]byte
+	failures int
+	gets     int
+	// block, if not nil, blocks PutObject until it is closed.
+	block chan struct{}
+}
+
+func NewMemStore() *MemStore {
+	return &MemStore{objects: make(map[string][]byte)}
+}
+
+func (s *MemStore) GetObject(_ context.Context, name string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.gets++
+	if s.failures > 0 {
+		s.failures--
+		return nil, ErrUnavailable
+	}
+	v, ok := s.objects[name]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return v, nil
+}
+
+func (s *MemStore) PutObject(_ context.Context, name string, data []byte) error {
+	s.mu.Lock()
+	block := s.block
+	s.mu.Unlock()
+	if block != nil {
+		<-block
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failures > 0 {
+		s.failures--
+		return ErrUnavailable
+	}
+	s.objects[name] = data
+	return nil
+}
+
+// SetObject stores the object directly, bypassing any failures.
+func (s *MemStore) SetObject(name string, data []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.objects[name] = data
+}
+
+// Objects returns a copy of the stored objects by name.
+func (s *MemStore) Objects() map[string][]byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return maps.Clone(s.objects)
+}
+
+// Gets returns the number of GetObject calls.
+func (s *MemStore) Gets() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.gets
+}
+
+// FailNext makes the next n calls to GetObject or PutObject fail with ErrUnavailable.
+func (s *MemStore) FailNext(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = n
+}
+
+// BlockPuts blocks all calls to PutObject, until the returned function is called.
+func (s *MemStore) BlockPuts() (release func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	block := make(chan struct{})
+	s.block = block
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.block == block {
+			s.block = nil
+		}
+		close(block)
+	}
+}
+
+var _ Store = (*MemStore)(nil)
@@ -0,0 +1,19 @@
+package objectstore
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNotFound is returned by GetObject when the object does not exist.
+var ErrNotFound = errors.New("object not found")
+
+// Store is a minimal blob storage interface.
+type Store interface {
+	// GetObject returns the contents of the object with the given name.
+	// It returns ErrNotFound when the object does not exist.
+	GetObject(ctx context.Context, name string) ([]byte, error)
+
+	// PutObject stores data as the contents of the object with the given name.
+	PutObject(ctx context.Context, name string, data []byte) error
+}
@@ -0,0 +1,71 @@
+package objectstore
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// S3Config configures an S3 compatible object store.
+// GCS can be used through its S3 interoperability endpoint, storage.googleapis.com.
+type S3Config struct {
+	Endpoint        string
+	Bucket          string
+	AccessKeyID     string
+	AccessKeySecret string
+	// Insecure disables TLS, e.g. for local development.
+	Insecure bool
+	// ContentType is the content type that objects are stored with. Optional.
+	ContentType string
+}
+
+// S3Store is a Store backed by a bucket in an S3 compatible object store.
+type S3Store struct {
+	bucket      string
+	contentType string
+	client      *minio.Client
+}
+
+func NewS3Store(cfg S3Config) (*S3Store, error) {
+	client, err := minio.New(cfg.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(cfg.AccessKeyID, cfg.AccessKeySecret, ""),
+		Secure: !cfg.Insecure,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create s3 client: %w", err)
+	}
+	return &S3Store{
+		bucket:      cfg.Bucket,
+		contentType: cfg.ContentType,
+		client:      client,
+	}, nil
+}
+
+func (s *S3Store) GetObject(ctx context.Context, name string) ([]byte, error) {
+	obj, err := s.client.GetObject(ctx, s.bucket, name, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer obj.Close()
+	// GetObject is lazy, so errors such as missing keys are only returned once the object is read.
+	data, err := io.ReadAll(obj)
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return data, nil
+}
+
+func (s *S3Store) PutObject(ctx context.Context, name string, data []byte) error {
+	_, err := s.client.PutObject(ctx, s.bucket, name, bytes.NewReader(data), int64(len(data)),
+		minio.PutObjectOptions{ContentType: s.contentType})
+	return err
+}
+
+var _ Store = (*S3Store)(nil)
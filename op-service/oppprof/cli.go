@@ -4,7 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"slices"
 	"strings"
+	"time"
 
 	opservice "github.com/ethereum-optimism/optimism/op-service"
 	openum "github.com/ethereum-optimism/optimism/op-service/enum"
@@ -20,9 +22,24 @@ const (
 	ProfilePathFlagName = "pprof.path"
 	defaultListenAddr   = "0.0.0.0"
 	defaultListenPort   = 6060
+
+	UploadURLFlagName      = "pprof.upload.url"
+	UploadBackendFlagName  = "pprof.upload.backend"
+	UploadIntervalFlagName = "pprof.upload.interval"
+	UploadTypesFlagName    = "pprof.upload.types"
+	UploadServiceFlagName  = "pprof.upload.service"
+	UploadLabelsFlagName   = "pprof.upload.labels"
+	defaultUploadInterval  = 15 * time.Second
 )
 
-var ErrInvalidPort = errors.New("invalid pprof port")
+var (
+	ErrInvalidPort           = errors.New("invalid pprof port")
+	ErrInvalidUploadBackend  = errors.New("invalid pprof upload backend")
+	ErrInvalidUploadInterval = errors.New("pprof upload interval must be at least 1s")
+	ErrInvalidUploadType     = errors.New("invalid pprof upload profile type")
+	ErrInvalidUploadLabel    = errors.New("invalid pprof upload label, expected <key>=<value>")
+	ErrUploadCPUConflict     = errors.New("cpu profiles can't be uploaded while profiling cpu to a file")
+)
 var allowedProfileTypes = []profileType{"cpu", "heap", "goroutine", "threadcreate", "block", "mutex", "allocs"}
 
 type profileType string
@@ -53,11 +70,27 @@ func validProfileType(value profileType) bool {
 	return false
 }
 
+const (
+	UploadBackendPyroscope = "pyroscope"
+	UploadBackendParca     = "parca"
+)
+
+var uploadBackends = []string{UploadBackendPyroscope, UploadBackendParca}
+
+func defaultUploadTypes() []string {
+	return []string{"cpu", "heap", "goroutine"}
+}
+
 func DefaultCLIConfig() CLIConfig {
 	return CLIConfig{
 		ListenEnabled: false,
 		ListenAddr:    defaultListenAddr,
 		ListenPort:    defaultListenPort,
+		Upload: UploadConfig{
+			Backend:  UploadBackendPyroscope,
+			Interval: defaultUploadInterval,
+			Types:    defaultUploadTypes(),
+		},
 	}
 }
 
@@ -104,9 +137,95 @@ func CLIFlagsWithCategory(envPrefix string, category string) []cli.Flag {
 			EnvVars:  opservice.PrefixEnvVar(envPrefix, "PPROF_TYPE"),
 			Category: category,
 		},
+		&cli.StringFlag{
+			Name:     UploadURLFlagName,
+			Usage:    "URL of the continuous profiling backend to periodically upload profiles to. Uploads are disabled if empty",
+			EnvVars:  opservice.PrefixEnvVar(envPrefix, "PPROF_UPLOAD_URL"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:     UploadBackendFlagName,
+			Usage:    "Continuous profiling backend to upload profiles to. One of " + strings.Join(uploadBackends, ", "),
+			Value:    UploadBackendPyroscope,
+			EnvVars:  opservice.PrefixEnvVar(envPrefix, "PPROF_UPLOAD_BACKEND"),
+			Category: category,
+		},
+		&cli.DurationFlag{
+			Name:     UploadIntervalFlagName,
+			Usage:    "Interval to collect and upload profiles at. CPU profiles cover the whole interval",
+			Value:    defaultUploadInterval,
+			EnvVars:  opservice.PrefixEnvVar(envPrefix, "PPROF_UPLOAD_INTERVAL"),
+			Category: category,
+		},
+		&cli.StringSliceFlag{
+			Name:     UploadTypesFlagName,
+			Usage:    "Profile types to upload. Any of " + openum.EnumString(allowedProfileTypes),
+			Value:    cli.NewStringSlice(defaultUploadTypes()...),
+			EnvVars:  opservice.PrefixEnvVar(envPrefix, "PPROF_UPLOAD_TYPES"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:     UploadServiceFlagName,
+			Usage:    "Service name to upload profiles as. Defaults to the name of the executable",
+			EnvVars:  opservice.PrefixEnvVar(envPrefix, "PPROF_UPLOAD_SERVICE"),
+			Category: category,
+		},
+		&cli.StringSliceFlag{
+			Name:     UploadLabelsFlagName,
+			Usage:    "Additional labels to upload profiles with, as <key>=<value>. The service and hostname labels are always set",
+			EnvVars:  opservice.PrefixEnvVar(envPrefix, "PPROF_UPLOAD_LABELS"),
+			Category: category,
+		},
 	}
 }
 
+// UploadConfig configures the periodic upload of profiles to a continuous profiling backend.
+type UploadConfig struct {
+	URL      string // Upload URL of the backend. Uploads are disabled if empty
+	Backend  string // Type of the backend, one of UploadBackendPyroscope or UploadBackendParca
+	Interval time.Duration
+	Types    []string // Profile types to upload
+	Service  string   // Service name to upload profiles as. The executable name is used if empty
+	Labels   []string // Additional labels, as <key>=<value>
+}
+
+func (c UploadConfig) Enabled() bool {
+	return c.URL != ""
+}
+
+func (c UploadConfig) Check() error {
+	if !c.Enabled() {
+		return nil
+	}
+	if !slices.Contains(uploadBackends, c.Backend) {
+		return fmt.Errorf("%w: %q", ErrInvalidUploadBackend, c.Backend)
+	}
+	if c.Interval < time.Second {
+		return ErrInvalidUploadInterval
+	}
+	for _, t := range c.Types {
+		if !validProfileType(profileType(t)) {
+			return fmt.Errorf("%w: %q", ErrInvalidUploadType, t)
+		}
+	}
+	if _, err := c.parseLabels(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c UploadConfig) parseLabels() (map[string]string, error) {
+	labels := make(map[string]string, len(c.Labels))
+	for _, label := range c.Labels {
+		key, value, ok := strings.Cut(label, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidUploadLabel, label)
+		}
+		labels[key] = value
+	}
+	return labels, nil
+}
+
 type CLIConfig struct {
 	ListenEnabled bool
 	ListenAddr    string
@@ -115,9 +234,18 @@ type CLIConfig struct {
 	ProfileType     profileType
 	ProfileDir      string
 	ProfileFilename string
+
+	Upload UploadConfig
 }
 
 func (m CLIConfig) Check() error {
+	if err := m.Upload.Check(); err != nil {
+		return err
+	}
+	if m.Upload.Enabled() && m.ProfileType == "cpu" && slices.Contains(m.Upload.Types, "cpu") {
+		return ErrUploadCPUConflict
+	}
+
 	if !m.ListenEnabled {
 		return nil
 	}
@@ -138,5 +266,13 @@ func ReadCLIConfig(ctx *cli.Context) CLIConfig {
 		ProfileType:     profileType(strings.ToLower(ctx.String(ProfileTypeFlagName))),
 		ProfileDir:      profilePathFlag.Dir(),
 		ProfileFilename: profilePathFlag.Filename(),
+		Upload: UploadConfig{
+			URL:      ctx.String(UploadURLFlagName),
+			Backend:  strings.ToLower(ctx.String(UploadBackendFlagName)),
+			Interval: ctx.Duration(UploadIntervalFlagName),
+			Types:    ctx.StringSlice(UploadTypesFlagName),
+			Service:  ctx.String(UploadServiceFlagName),
+			Labels:   ctx.StringSlice(UploadLabelsFlagName),
+		},
 	}
 }
@@ -25,11 +25,14 @@ type Service struct {
 	profileDir      string
 	profileFilename string
 
+	upload UploadConfig
+
 	cpuFile    io.Closer
 	httpServer *httputil.HTTPServer
+	uploader   *uploader
 }
 
-func New(listenEnabled bool, listenAddr string, listenPort int, profType profileType, profileDir, profileFilename string) *Service {
+func New(listenEnabled bool, listenAddr string, listenPort int, profType profileType, profileDir, profileFilename string, upload UploadConfig) *Service {
 	return &Service{
 		listenEnabled:   listenEnabled,
 		listenAddr:      listenAddr,
@@ -37,6 +40,7 @@ func New(listenEnabled bool, listenAddr string, listenPort int, profType profile
 		profileType:     string(profType),
 		profileDir:      profileDir,
 		profileFilename: profileFilename,
+		upload:          upload,
 	}
 }
 
@@ -59,10 +63,18 @@ func (s *Service) Start() error {
 	if s.profileType != "" {
 		log.Info("start profiling to file", "profile_type", s.profileType, "profile_filepath", s.buildTargetFilePath())
 	}
+	if s.upload.Enabled() {
+		if err := s.startUploader(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
 func (s *Service) Stop(ctx context.Context) error {
+	if s.uploader != nil {
+		s.uploader.Stop()
+	}
 	switch s.profileType {
 	case "cpu":
 		pprof.StopCPUProfile()
@@ -119,6 +131,18 @@ func (s *Service) startServer() error {
 	return nil
 }
 
+func (s *Service) startUploader() error {
+	u, err := newUploader(log.Root(), s.upload)
+	if err != nil {
+		return err
+	}
+	if err := u.Start(); err != nil {
+		return err
+	}
+	s.uploader = u
+	return nil
+}
+
 func (s *Service) startCPUProfile() error {
 	f, err := os.Create(s.buildTargetFilePath())
 	if err != nil {
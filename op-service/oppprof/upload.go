@@ -0,0 +1,283 @@
+package oppprof
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const uploadTimeout = 30 * time.Second
+
+type collectedProfile struct {
+	profileType string
+	data        []byte
+	from        time.Time
+	until       time.Time
+}
+
+type profileBackend interface {
+	upload(ctx context.Context, p collectedProfile) error
+}
+
+// uploader periodically collects profiles and uploads them to a continuous profiling backend.
+type uploader struct {
+	log      log.Logger
+	interval time.Duration
+	types    []string
+	backend  profileBackend
+
+	cpu   bytes.Buffer
+	start time.Time
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newUploader(logger log.Logger, cfg UploadConfig) (*uploader, error) {
+	labels, err := cfg.parseLabels()
+	if err != nil {
+		return nil, err
+	}
+	service := cfg.Service
+	if service == "" {
+		service = filepath.Base(os.Args[0])
+	}
+	labels["service"] = service
+	if _, ok := labels["hostname"]; !ok {
+		if hostname, err := os.Hostname(); err == nil {
+			labels["hostname"] = hostname
+		}
+	}
+	client := &http.Client{Timeout: uploadTimeout}
+	var backend profileBackend
+	switch cfg.Backend {
+	case UploadBackendPyroscope:
+		backend = &pyroscopeBackend{client: client, url: strings.TrimSuffix(cfg.URL, "/"), service: service, labels: labels}
+	case UploadBackendParca:
+		backend = &parcaBackend{client: client, url: strings.TrimSuffix(cfg.URL, "/"), labels: labels}
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrInvalidUploadBackend, cfg.Backend)
+	}
+	return &uploader{
+		log:      logger.New("backend", cfg.Backend, "url", cfg.URL),
+		interval: cfg.Interval,
+		types:    cfg.Types,
+		backend:  backend,
+	}, nil
+}
+
+func (u *uploader) Start() error {
+	if slices.Contains(u.types, "cpu") {
+		if err := u.startCPUProfile(); err != nil {
+			return err
+		}
+	}
+	if slices.Contains(u.types, "block") {
+		runtime.SetBlockProfileRate(1)
+	}
+	if slices.Contains(u.types, "mutex") {
+		runtime.SetMutexProfileFraction(1)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	u.cancel = cancel
+	u.wg.Add(1)
+	go u.loop(ctx)
+	u.log.Info("Uploading profiles", "types", u.types, "interval", u.interval)
+	return nil
+}
+
+func (u *uploader) startCPUProfile() error {
+	u.cpu.Reset()
+	u.start = time.Now()
+	if err := pprof.StartCPUProfile(&u.cpu); err != nil {
+		return fmt.Errorf("failed to start cpu profile for upload: %w", err)
+	}
+	return nil
+}
+
+func (u *uploader) loop(ctx context.Context) {
+	defer u.wg.Done()
+	ticker := time.NewTicker(u.interval)
+	defer ticker.Stop()
+	from := time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			if slices.Contains(u.types, "cpu") {
+				pprof.StopCPUProfile()
+			}
+			return
+		case until := <-ticker.C:
+			u.uploadAll(ctx, u.collect(from, until))
+			from = until
+		}
+	}
+}
+
+func (u *uploader) collect(from, until time.Time) []collectedProfile {
+	var profiles []collectedProfile
+	for _, t := range u.types {
+		if t == "cpu" {
+			pprof.StopCPUProfile()
+			profiles = append(profiles, collectedProfile{profileType: t, data: bytes.Clone(u.cpu.Bytes()), from: u.start, until: until})
+			if err := u.startCPUProfile(); err != nil {
+				u.log.Warn("Failed to restart cpu profile", "err", err)
+			}
+			continue
+		}
+		profile := pprof.Lookup(t)
+		if profile == nil {
+			continue
+		}
+		var buf bytes.Buffer
+		if err := profile.WriteTo(&buf, 0); err != nil {
+			u.log.Warn("Failed to collect profile", "type", t, "err", err)
+			continue
+		}
+		profiles = append(profiles, collectedProfile{profileType: t, data: buf.Bytes(), from: from, until: until})
+	}
+	return profiles
+}
+
+func (u *uploader) uploadAll(ctx context.Context, profiles []collectedProfile) {
+	for _, p := range profiles {
+		if err := u.backend.upload(ctx, p); err != nil {
+			u.log.Warn("Failed to upload profile", "type", p.profileType, "err", err)
+			continue
+		}
+		u.log.Debug("Uploaded profile", "type", p.profileType, "size", len(p.data))
+	}
+}
+
+func (u *uploader) Stop() {
+	if u.cancel != nil {
+		u.cancel()
+	}
+	u.wg.Wait()
+}
+
+// pyroscopeBackend uploads profiles with the Pyroscope ingest API.
+type pyroscopeBackend struct {
+	client  *http.Client
+	url     string
+	service string
+	labels  map[string]string
+}
+
+func (b *pyroscopeBackend) upload(ctx context.Context, p collectedProfile) error {
+	var labels []string
+	for k, v := range b.labels {
+		labels = append(labels, k+"="+v)
+	}
+	sort.Strings(labels)
+	query := url.Values{}
+	query.Set("name", fmt.Sprintf("%s.%s{%s}", b.service, p.profileType, strings.Join(labels, ",")))
+	query.Set("from", strconv.FormatInt(p.from.Unix(), 10))
+	query.Set("until", strconv.FormatInt(p.until.Unix(), 10))
+	query.Set("format", "pprof")
+	query.Set("spyName", "gospy")
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile("profile", "profile.pprof")
+	if err != nil {
+		return err
+	}
+	if _, err := part.Write(p.data); err != nil {
+		return err
+	}
+	if err := form.Close(); err != nil {
+		return err
+	}
+	return post(ctx, b.client, b.url+"/ingest?"+query.Encode(), form.FormDataContentType(), &body)
+}
+
+// parcaBackend uploads profiles with the Parca profile store HTTP API.
+type parcaBackend struct {
+	client *http.Client
+	url    string
+	labels map[string]string
+}
+
+type parcaLabel struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type parcaWriteRawRequest struct {
+	Series []parcaSeries `json:"series"`
+}
+
+type parcaSeries struct {
+	Labels  parcaLabelSet `json:"labels"`
+	Samples []parcaSample `json:"samples"`
+}
+
+type parcaLabelSet struct {
+	Labels []parcaLabel `json:"labels"`
+}
+
+type parcaSample struct {
+	RawProfile []byte `json:"rawProfile"`
+}
+
+// parcaProfileNames maps the profile types to the names Parca uses for them.
+var parcaProfileNames = map[string]string{
+	"cpu":  "process_cpu",
+	"heap": "memory",
+}
+
+func (b *parcaBackend) upload(ctx context.Context, p collectedProfile) error {
+	name, ok := parcaProfileNames[p.profileType]
+	if !ok {
+		name = p.profileType
+	}
+	labels := []parcaLabel{{Name: "__name__", Value: name}}
+	for k, v := range b.labels {
+		labels = append(labels, parcaLabel{Name: k, Value: v})
+	}
+	sort.Slice(labels, func(i, j int) bool { return labels[i].Name < labels[j].Name })
+	body, err := json.Marshal(parcaWriteRawRequest{Series: []parcaSeries{{
+		Labels:  parcaLabelSet{Labels: labels},
+		Samples: []parcaSample{{RawProfile: p.data}},
+	}}})
+	if err != nil {
+		return err
+	}
+	return post(ctx, b.client, b.url+"/profiles/writeraw", "application/json", bytes.NewReader(body))
+}
+
+func post(ctx context.Context, client *http.Client, url string, contentType string, body io.Reader) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("upload failed with status %v: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package oppprof
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type uploadRecorder struct {
+	mu       sync.Mutex
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (r *uploadRecorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests = append(r.requests, req)
+	r.bodies = append(r.bodies, body)
+}
+
+func (r *uploadRecorder) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.requests)
+}
+
+func startUploader(t *testing.T, cfg UploadConfig) *uploadRecorder {
+	rec := &uploadRecorder{}
+	srv := httptest.NewServer(rec)
+	t.Cleanup(srv.Close)
+	cfg.URL = srv.URL
+	cfg.Interval = 50 * time.Millisecond
+	u, err := newUploader(testlog.Logger(t, log.LevelInfo), cfg)
+	require.NoError(t, err)
+	require.NoError(t, u.Start())
+	t.Cleanup(u.Stop)
+	require.Eventually(t, func() bool { return rec.Len() >= len(cfg.Types) }, 5*time.Second, 10*time.Millisecond)
+	return rec
+}
+
+func TestUploadPyroscope(t *testing.T) {
+	rec := startUploader(t, UploadConfig{
+		Backend: UploadBackendPyroscope,
+		Types:   []string{"cpu", "heap"},
+		Service: "op-test",
+		Labels:  []string{"env=test", "hostname=host"},
+	})
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	for i, typ := range []string{"cpu", "heap"} {
+		req := rec.requests[i]
+		require.Equal(t, "/ingest", req.URL.Path)
+		require.Equal(t, "op-test."+typ+"{env=test,hostname=host,service=op-test}", req.URL.Query().Get("name"))
+		require.Equal(t, "pprof", req.URL.Query().Get("format"))
+		require.NotEmpty(t, req.URL.Query().Get("from"))
+		require.NotEmpty(t, req.URL.Query().Get("until"))
+		require.Contains(t, req.Header.Get("Content-Type"), "multipart/form-data")
+		require.Contains(t, string(rec.bodies[i]), `name="profile"`)
+	}
+}
+
+func TestUploadParca(t *testing.T) {
+	rec := startUploader(t, UploadConfig{
+		Backend: UploadBackendParca,
+		Types:   []string{"heap", "goroutine"},
+		Service: "op-test",
+	})
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	for i, name := range []string{"memory", "goroutine"} {
+		require.Equal(t, "/profiles/writeraw", rec.requests[i].URL.Path)
+		var body parcaWriteRawRequest
+		require.NoError(t, json.Unmarshal(rec.bodies[i], &body))
+		require.Len(t, body.Series, 1)
+		require.Contains(t, body.Series[0].Labels.Labels, parcaLabel{Name: "__name__", Value: name})
+		require.Contains(t, body.Series[0].Labels.Labels, parcaLabel{Name: "service", Value: "op-test"})
+		require.Len(t, body.Series[0].Samples, 1)
+		require.NotEmpty(t, body.Series[0].Samples[0].RawProfile)
+	}
+}
+
+func TestUploadConfigCheck(t *testing.T) {
+	valid := func() CLIConfig {
+		cfg := DefaultCLIConfig()
+		cfg.Upload.URL = "http://localhost:4040"
+		return cfg
+	}
+	require.NoError(t, valid().Check())
+
+	t.Run("DisabledIsNotChecked", func(t *testing.T) {
+		cfg := valid()
+		cfg.Upload.URL = ""
+		cfg.Upload.Backend = "foo"
+		require.NoError(t, cfg.Check())
+	})
+
+	t.Run("InvalidBackend", func(t *testing.T) {
+		cfg := valid()
+		cfg.Upload.Backend = "foo"
+		require.ErrorIs(t, cfg.Check(), ErrInvalidUploadBackend)
+	})
+
+	t.Run("InvalidInterval", func(t *testing.T) {
+		cfg := valid()
+		cfg.Upload.Interval = time.Millisecond
+		require.ErrorIs(t, cfg.Check(), ErrInvalidUploadInterval)
+	})
+
+	t.Run("InvalidType", func(t *testing.T) {
+		cfg := valid()
+		cfg.Upload.Types = []string{"heap", "foo"}
+		require.ErrorIs(t, cfg.Check(), ErrInvalidUploadType)
+	})
+
+	t.Run("InvalidLabel", func(t *testing.T) {
+		cfg := valid()
+		cfg.Upload.Labels = []string{"foo"}
+		require.ErrorIs(t, cfg.Check(), ErrInvalidUploadLabel)
+	})
+
+	t.Run("CPUConflict", func(t *testing.T) {
+		cfg := valid()
+		cfg.ProfileType = "cpu"
+		require.ErrorIs(t, cfg.Check(), ErrUploadCPUConflict)
+		cfg.Upload.Types = []string{"heap"}
+		require.NoError(t, cfg.Check())
+	})
+}
@@ -0,0 +1,124 @@
+package optracing
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+)
+
+const (
+	EnabledFlagName    = "tracing.enabled"
+	EndpointFlagName   = "tracing.endpoint"
+	SampleRateFlagName = "tracing.sample-rate"
+	ServiceFlagName    = "tracing.service"
+	HeadersFlagName    = "tracing.headers"
+	defaultEndpoint    = "http://localhost:4318"
+	defaultSampleRate  = 1.0
+)
+
+var (
+	ErrInvalidEndpoint   = errors.New("invalid tracing endpoint, expected an http or https URL")
+	ErrInvalidSampleRate = errors.New("tracing sample rate must be between 0 and 1")
+	ErrInvalidHeader     = errors.New("invalid tracing header, expected <key>=<value>")
+)
+
+func DefaultCLIConfig() CLIConfig {
+	return CLIConfig{
+		Enabled:    false,
+		Endpoint:   defaultEndpoint,
+		SampleRate: defaultSampleRate,
+	}
+}
+
+func CLIFlags(envPrefix string) []cli.Flag {
+	return CLIFlagsWithCategory(envPrefix, "")
+}
+
+func CLIFlagsWithCategory(envPrefix string, category string) []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:     EnabledFlagName,
+			Usage:    "Enable exporting OpenTelemetry traces",
+			EnvVars:  opservice.PrefixEnvVar(envPrefix, "TRACING_ENABLED"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:     EndpointFlagName,
+			Usage:    "URL of the OTLP HTTP endpoint to export traces to. The /v1/traces path is used if the URL has no path",
+			Value:    defaultEndpoint,
+			EnvVars:  opservice.PrefixEnvVar(envPrefix, "TRACING_ENDPOINT"),
+			Category: category,
+		},
+		&cli.Float64Flag{
+			Name:     SampleRateFlagName,
+			Usage:    "Fraction of traces to sample, between 0 and 1. Spans follow the sampling decision of their parent",
+			Value:    defaultSampleRate,
+			EnvVars:  opservice.PrefixEnvVar(envPrefix, "TRACING_SAMPLE_RATE"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:     ServiceFlagName,
+			Usage:    "Service name to export traces as. Defaults to the name of the service",
+			EnvVars:  opservice.PrefixEnvVar(envPrefix, "TRACING_SERVICE"),
+			Category: category,
+		},
+		&cli.StringSliceFlag{
+			Name:     HeadersFlagName,
+			Usage:    "Additional headers to export traces with, as <key>=<value>, e.g. for authentication",
+			EnvVars:  opservice.PrefixEnvVar(envPrefix, "TRACING_HEADERS"),
+			Category: category,
+		},
+	}
+}
+
+type CLIConfig struct {
+	Enabled    bool
+	Endpoint   string  // URL of the OTLP HTTP endpoint
+	SampleRate float64 // Fraction of traces to sample
+	Service    string  // Service name to export traces as. The name of the service is used if empty
+	Headers    []string
+}
+
+func (c CLIConfig) Check() error {
+	if !c.Enabled {
+		return nil
+	}
+	u, err := url.Parse(c.Endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: %q", ErrInvalidEndpoint, c.Endpoint)
+	}
+	if c.SampleRate < 0 || c.SampleRate > 1 {
+		return ErrInvalidSampleRate
+	}
+	if _, err := c.parseHeaders(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c CLIConfig) parseHeaders() (map[string]string, error) {
+	headers := make(map[string]string, len(c.Headers))
+	for _, header := range c.Headers {
+		key, value, ok := strings.Cut(header, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidHeader, header)
+		}
+		headers[key] = value
+	}
+	return headers, nil
+}
+
+func ReadCLIConfig(ctx *cli.Context) CLIConfig {
+	return CLIConfig{
+		Enabled:    ctx.Bool(EnabledFlagName),
+		Endpoint:   ctx.String(EndpointFlagName),
+		SampleRate: ctx.Float64(SampleRateFlagName),
+		Service:    ctx.String(ServiceFlagName),
+		Headers:    ctx.StringSlice(HeadersFlagName),
+	}
+}
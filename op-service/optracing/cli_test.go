@@ -0,0 +1,57 @@
+package optracing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCLIConfig_Check(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *CLIConfig)
+		err    error
+	}{
+		{name: "Default"},
+		{name: "DisabledIgnoresInvalid", modify: func(cfg *CLIConfig) {
+			cfg.Endpoint = "localhost:4318"
+			cfg.SampleRate = 2
+		}},
+		{name: "Enabled", modify: func(cfg *CLIConfig) { cfg.Enabled = true }},
+		{name: "HTTPSWithPath", modify: func(cfg *CLIConfig) {
+			cfg.Enabled = true
+			cfg.Endpoint = "https://otlp.example.com/otlp/v1/traces"
+			cfg.Headers = []string{"authorization=Bearer abc", "x-empty="}
+		}},
+		{name: "NoScheme", err: ErrInvalidEndpoint, modify: func(cfg *CLIConfig) {
+			cfg.Enabled = true
+			cfg.Endpoint = "localhost:4318"
+		}},
+		{name: "UnsupportedScheme", err: ErrInvalidEndpoint, modify: func(cfg *CLIConfig) {
+			cfg.Enabled = true
+			cfg.Endpoint = "grpc://localhost:4317"
+		}},
+		{name: "NegativeSampleRate", err: ErrInvalidSampleRate, modify: func(cfg *CLIConfig) {
+			cfg.Enabled = true
+			cfg.SampleRate = -0.1
+		}},
+		{name: "SampleRateAboveOne", err: ErrInvalidSampleRate, modify: func(cfg *CLIConfig) {
+			cfg.Enabled = true
+			cfg.SampleRate = 1.1
+		}},
+		{name: "InvalidHeader", err: ErrInvalidHeader, modify: func(cfg *CLIConfig) {
+			cfg.Enabled = true
+			cfg.Headers = []string{"authorization"}
+		}},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			cfg := DefaultCLIConfig()
+			if test.modify != nil {
+				test.modify(&cfg)
+			}
+			require.ErrorIs(t, cfg.Check(), test.err)
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package optracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// defaultURLPath is the path of the OTLP HTTP traces endpoint, used if the endpoint URL has no path.
+const defaultURLPath = "/v1/traces"
+
+// Service exports the spans of the global tracer provider to an OTLP HTTP endpoint.
+// Spans are created with Tracer, and are discarded while no Service is started.
+type Service struct {
+	log     log.Logger
+	cfg     CLIConfig
+	service string
+	version string
+
+	provider *sdktrace.TracerProvider
+}
+
+// New creates a Service that exports traces as the given service, unless overridden by the config.
+func New(log log.Logger, cfg CLIConfig, service string, version string) *Service {
+	if cfg.Service != "" {
+		service = cfg.Service
+	}
+	return &Service{
+		log:     log,
+		cfg:     cfg,
+		service: service,
+		version: version,
+	}
+}
+
+// Start sets the global tracer provider to export spans, if tracing is enabled.
+func (s *Service) Start(ctx context.Context) error {
+	if !s.cfg.Enabled {
+		return nil
+	}
+	headers, err := s.cfg.parseHeaders()
+	if err != nil {
+		return err
+	}
+	opts := []otlptracehttp.Option{
+		otlptracehttp.WithEndpointURL(s.cfg.Endpoint),
+		otlptracehttp.WithHeaders(headers),
+	}
+	if u, err := url.Parse(s.cfg.Endpoint); err == nil && strings.Trim(u.Path, "/") == "" {
+		opts = append(opts, otlptracehttp.WithURLPath(defaultURLPath))
+	}
+	exporter, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+	res := resource.NewSchemaless(
+		attribute.String("service.name", s.service),
+		attribute.String("service.version", s.version))
+	s.provider = sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(s.cfg.SampleRate))))
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		s.log.Warn("Failed to export traces", "err", err)
+	}))
+	otel.SetTracerProvider(s.provider)
+	s.log.Info("Exporting traces", "endpoint", s.cfg.Endpoint, "service", s.service, "sampleRate", s.cfg.SampleRate)
+	return nil
+}
+
+// Stop flushes the spans that have not been exported yet, and stops exporting spans.
+func (s *Service) Stop(ctx context.Context) error {
+	if s.provider == nil {
+		return nil
+	}
+	return s.provider.Shutdown(ctx)
+}
+
+// Tracer returns the named tracer of the global tracer provider.
+// The tracer can be created before the Service is started, its spans are exported once it is.
+func Tracer(name string) trace.Tracer {
+	return otel.Tracer(name)
+}
+
+// End records err as the status of the span, unless it is nil or io.EOF, and ends the span.
+// io.EOF is used to signal that no more data is available, rather than a failure.
+func End(span trace.Span, err error) {
+	if err != nil && !errors.Is(err, io.EOF) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
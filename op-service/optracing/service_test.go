@@ -0,0 +1,84 @@
+package optracing
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type exportRecorder struct {
+	mu      sync.Mutex
+	paths   []string
+	headers []http.Header
+}
+
+func (r *exportRecorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	_, _ = io.ReadAll(req.Body)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.paths = append(r.paths, req.URL.Path)
+	r.headers = append(r.headers, req.Header)
+}
+
+func TestService_ExportsSpansOnStop(t *testing.T) {
+	prev := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	rec := &exportRecorder{}
+	srv := httptest.NewServer(rec)
+	t.Cleanup(srv.Close)
+
+	cfg := DefaultCLIConfig()
+	cfg.Enabled = true
+	cfg.Endpoint = srv.URL
+	cfg.Headers = []string{"authorization=secret"}
+	require.NoError(t, cfg.Check())
+	svc := New(testlog.Logger(t, log.LevelInfo), cfg, "op-test", "v0.0.0")
+	require.NoError(t, svc.Start(context.Background()))
+
+	_, span := Tracer("test").Start(context.Background(), "span")
+	span.End()
+	require.NoError(t, svc.Stop(context.Background()))
+
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	require.Equal(t, []string{defaultURLPath}, rec.paths)
+	require.Equal(t, "secret", rec.headers[0].Get("authorization"))
+}
+
+func TestService_Disabled(t *testing.T) {
+	svc := New(testlog.Logger(t, log.LevelInfo), DefaultCLIConfig(), "op-test", "v0.0.0")
+	require.NoError(t, svc.Start(context.Background()))
+	require.Nil(t, svc.provider)
+	require.NoError(t, svc.Stop(context.Background()))
+}
+
+func TestEnd(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	tracer := provider.Tracer("test")
+
+	for _, err := range []error{nil, io.EOF, errors.New("boom")} {
+		_, span := tracer.Start(context.Background(), "span")
+		End(span, err)
+	}
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 3)
+	require.Equal(t, codes.Unset, spans[0].Status.Code)
+	require.Equal(t, codes.Unset, spans[1].Status.Code)
+	require.Equal(t, codes.Error, spans[2].Status.Code)
+	require.Equal(t, "boom", spans[2].Status.Description)
+}
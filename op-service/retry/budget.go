@@ -0,0 +1,62 @@
+package retry
+
+import (
+	"sync"
+	"time"
+)
+
+// Budget limits the retries of an operation beyond its max attempts.
+// Do checks whether the Strategy it is given implements Budget.
+type Budget interface {
+	// AllowRetry reports whether the operation may be retried after a failed attempt.
+	// attempt is the number of the failed attempt, starting at 0,
+	// and elapsed is the time since the first attempt was started.
+	AllowRetry(attempt int, elapsed time.Duration) bool
+	// RecordSuccess records that an attempt of the operation succeeded.
+	RecordSuccess()
+}
+
+// TokenBucket throttles retries across all operations sharing it, so that retries stop
+// when most operations are failing, e.g. during an outage of the RPC they depend on,
+// instead of every operation retrying up to its max attempts at once.
+//
+// Every failed attempt takes a token from the bucket, and every successful attempt adds Ratio tokens back.
+// Retries are only allowed while more than half of the bucket's capacity is left.
+// It is safe for concurrent use.
+type TokenBucket struct {
+	mu       sync.Mutex
+	tokens   float64
+	capacity float64
+	ratio    float64
+}
+
+// NewTokenBucket creates a full token bucket, with the given capacity, that refills ratio tokens per success.
+func NewTokenBucket(capacity float64, ratio float64) *TokenBucket {
+	return &TokenBucket{
+		tokens:   capacity,
+		capacity: capacity,
+		ratio:    ratio,
+	}
+}
+
+// TakeRetry takes a token for a failed attempt, and reports whether it may be retried.
+func (b *TokenBucket) TakeRetry() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens = max(b.tokens-1, 0)
+	return b.tokens > b.capacity/2
+}
+
+// RecordSuccess adds tokens back to the bucket for a successful attempt.
+func (b *TokenBucket) RecordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens = min(b.tokens+b.ratio, b.capacity)
+}
+
+// Tokens returns the number of tokens left in the bucket.
+func (b *TokenBucket) Tokens() float64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.tokens
+}
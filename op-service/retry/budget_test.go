@@ -0,0 +1,71 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenBucket(t *testing.T) {
+	b := NewTokenBucket(4, 0.5)
+	require.True(t, b.TakeRetry())
+	require.False(t, b.TakeRetry(), "retries stop at half capacity")
+	require.False(t, b.TakeRetry())
+	require.Equal(t, 1.0, b.Tokens())
+
+	for i := 0; i < 4; i++ {
+		b.RecordSuccess()
+	}
+	require.Equal(t, 3.0, b.Tokens())
+	for i := 0; i < 10; i++ {
+		b.RecordSuccess()
+	}
+	require.Equal(t, 4.0, b.Tokens(), "bucket is capped at its capacity")
+
+	for i := 0; i < 10; i++ {
+		b.TakeRetry()
+	}
+	require.Zero(t, b.Tokens())
+}
+
+func TestDoBudget(t *testing.T) {
+	dummyErr := errors.New("explode")
+	bucket := NewTokenBucket(10, 1)
+	strategy := &AdaptiveStrategy{Bucket: bucket}
+
+	var calls int
+	_, err := Do(context.Background(), 100, strategy, func() (int, error) {
+		calls++
+		return 0, dummyErr
+	})
+	var failed *ErrFailedPermanently
+	require.ErrorAs(t, err, &failed)
+	require.Equal(t, dummyErr, failed.LastErr)
+	require.Equal(t, 5, calls, "retries must stop when the shared bucket is depleted")
+
+	// Other operations sharing the bucket are not retried either, until operations succeed again.
+	calls = 0
+	_, err = Do(context.Background(), 100, strategy, func() (int, error) {
+		calls++
+		return 0, dummyErr
+	})
+	require.ErrorAs(t, err, &failed)
+	require.Equal(t, 1, calls)
+
+	_, err = Do(context.Background(), 100, strategy, func() (int, error) {
+		return 0, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 5.0, bucket.Tokens())
+
+	calls = 0
+	_, err = Do(context.Background(), 100, &AdaptiveStrategy{MaxElapsed: 20 * time.Millisecond, Base: 5 * time.Millisecond, Max: 5 * time.Millisecond}, func() (int, error) {
+		calls++
+		return 0, dummyErr
+	})
+	require.ErrorAs(t, err, &failed)
+	require.Less(t, calls, 100, "retries must stop when the operation budget is used up")
+}
@@ -37,7 +37,9 @@ func Do2[T, U any](ctx context.Context, maxAttempts int, strategy Strategy, op f
 
 // Do performs the provided Operation up to maxAttempts times
 // with delays in between each retry according to the provided
-// Strategy.
+// Strategy. If the Strategy implements Budget, the operation is
+// only retried while the budget allows it. Delays are cut short
+// when the context is done.
 func Do[T any](ctx context.Context, maxAttempts int, strategy Strategy, op func() (T, error)) (T, error) {
 	var empty, ret T
 	var err error
@@ -45,17 +47,35 @@ func Do[T any](ctx context.Context, maxAttempts int, strategy Strategy, op func(
 		return empty, fmt.Errorf("need at least 1 attempt to run op, but have %d max attempts", maxAttempts)
 	}
 
+	budget, _ := strategy.(Budget)
+	start := time.Now()
 	for i := 0; i < maxAttempts; i++ {
 		if ctx.Err() != nil {
 			return empty, ctx.Err()
 		}
 		ret, err = op()
 		if err == nil {
+			if budget != nil {
+				budget.RecordSuccess()
+			}
 			return ret, nil
 		}
 		// Don't sleep when we are about to exit the loop & return ErrFailedPermanently
-		if i != maxAttempts-1 {
-			time.Sleep(strategy.Duration(i))
+		if i == maxAttempts-1 {
+			break
+		}
+		if budget != nil && !budget.AllowRetry(i, time.Since(start)) {
+			return empty, &ErrFailedPermanently{
+				attempts: i + 1,
+				LastErr:  err,
+			}
+		}
+		timer := time.NewTimer(strategy.Duration(i))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return empty, ctx.Err()
+		case <-timer.C:
 		}
 	}
 	return empty, &ErrFailedPermanently{
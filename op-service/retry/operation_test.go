@@ -32,3 +32,14 @@ func TestDo(t *testing.T) {
 	require.Equal(t, dummyErr, err.(*ErrFailedPermanently).LastErr)
 	require.True(t, time.Since(start) > 20*time.Millisecond)
 }
+
+func TestDoContextDuringDelay(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := Do(ctx, 2, Fixed(time.Minute), func() (int, error) {
+		return 0, errors.New("explode")
+	})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Less(t, time.Since(start), time.Minute)
+}
@@ -67,3 +67,63 @@ func Fixed(dur time.Duration) Strategy {
 		Dur: dur,
 	}
 }
+
+// AdaptiveStrategy performs exponential backoff with full jitter: the delay of an attempt is
+// randBetween(0, min(a.Base * 2^attempt, a.Max)), which spreads the retries of operations that failed at the same time.
+//
+// It also implements Budget: an operation is not retried once MaxElapsed has passed since its first attempt,
+// or when the shared Bucket is depleted by the failures of other operations.
+type AdaptiveStrategy struct {
+	// Base is the delay cap of the first retry.
+	Base time.Duration
+
+	// Max is the maximum amount of time to wait between attempts.
+	Max time.Duration
+
+	// MaxElapsed is the retry budget of a single operation. Zero means no limit.
+	MaxElapsed time.Duration
+
+	// Bucket is shared by all operations that throttle their retries together. Nil means no throttling.
+	Bucket *TokenBucket
+}
+
+func (a *AdaptiveStrategy) Duration(attempt int) time.Duration {
+	limit := a.Max
+	if attempt < 0 {
+		limit = min(a.Base, a.Max)
+	} else if attempt < 63 {
+		if exp := float64(a.Base) * math.Pow(2, float64(attempt)); exp < float64(a.Max) {
+			limit = time.Duration(exp)
+		}
+	}
+	if limit <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(limit)))
+}
+
+func (a *AdaptiveStrategy) AllowRetry(attempt int, elapsed time.Duration) bool {
+	if a.MaxElapsed > 0 && elapsed >= a.MaxElapsed {
+		return false
+	}
+	if a.Bucket != nil {
+		return a.Bucket.TakeRetry()
+	}
+	return true
+}
+
+func (a *AdaptiveStrategy) RecordSuccess() {
+	if a.Bucket != nil {
+		a.Bucket.RecordSuccess()
+	}
+}
+
+// Adaptive returns an AdaptiveStrategy that throttles its retries with the given bucket, which may be nil.
+func Adaptive(bucket *TokenBucket) Strategy {
+	return &AdaptiveStrategy{
+		Base:       250 * time.Millisecond,
+		Max:        10 * time.Second,
+		MaxElapsed: time.Minute,
+		Bucket:     bucket,
+	}
+}
@@ -24,3 +24,23 @@ func TestExponential(t *testing.T) {
 	require.Equal(t, 10*time.Second, strategy.Duration(16000))
 	require.Equal(t, 10*time.Second, strategy.Duration(math.MaxInt))
 }
+
+func TestAdaptive(t *testing.T) {
+	strategy := &AdaptiveStrategy{
+		Base: 100 * time.Millisecond,
+		Max:  time.Second,
+	}
+	for i := 0; i < 100; i++ {
+		require.Less(t, strategy.Duration(-1), 100*time.Millisecond)
+		require.Less(t, strategy.Duration(0), 100*time.Millisecond)
+		require.Less(t, strategy.Duration(2), 400*time.Millisecond)
+		require.Less(t, strategy.Duration(10), time.Second)
+		require.Less(t, strategy.Duration(math.MaxInt), time.Second)
+	}
+	require.Zero(t, (&AdaptiveStrategy{}).Duration(3))
+
+	require.True(t, strategy.AllowRetry(100, time.Hour), "no budget by default")
+	strategy.MaxElapsed = time.Minute
+	require.True(t, strategy.AllowRetry(0, 59*time.Second))
+	require.False(t, strategy.AllowRetry(0, time.Minute))
+}
@@ -3,6 +3,7 @@ package rpc
 import (
 	"errors"
 	"math"
+	"strconv"
 
 	opservice "github.com/ethereum-optimism/optimism/op-service"
 	"github.com/urfave/cli/v2"
@@ -12,12 +13,19 @@ const (
 	ListenAddrFlagName  = "rpc.addr"
 	PortFlagName        = "rpc.port"
 	EnableAdminFlagName = "rpc.enable-admin"
+
+	MaxRequestBodySizeFlagName = "rpc.max-request-body-size"
+	MaxBatchSizeFlagName       = "rpc.max-batch-size"
+	IPRateLimitFlagName        = "rpc.ip-rate-limit"
+	IPRateBurstFlagName        = "rpc.ip-rate-burst"
+	MethodRateLimitsFlagName   = "rpc.method-rate-limits"
+	TrustForwardedForFlagName  = "rpc.trust-forwarded-for"
 )
 
 var ErrInvalidPort = errors.New("invalid RPC port")
 
 func CLIFlags(envPrefix string) []cli.Flag {
-	return []cli.Flag{
+	return append([]cli.Flag{
 		&cli.StringFlag{
 			Name:    ListenAddrFlagName,
 			Usage:   "rpc listening address",
@@ -35,6 +43,49 @@ func CLIFlags(envPrefix string) []cli.Flag {
 			Usage:   "Enable the admin API",
 			EnvVars: opservice.PrefixEnvVar(envPrefix, "RPC_ENABLE_ADMIN"),
 		},
+	}, LimitsCLIFlags(envPrefix, "")...)
+}
+
+// LimitsCLIFlags returns the flags of the limits applied to RPC requests,
+// for services that define their other RPC flags themselves.
+func LimitsCLIFlags(envPrefix string, category string) []cli.Flag {
+	return []cli.Flag{
+		&cli.Int64Flag{
+			Name:     MaxRequestBodySizeFlagName,
+			Usage:    "Maximum size of an RPC request body in bytes. Defaults to " + strconv.Itoa(DefaultMaxRequestBodySize) + " bytes if 0.",
+			EnvVars:  opservice.PrefixEnvVar(envPrefix, "RPC_MAX_REQUEST_BODY_SIZE"),
+			Category: category,
+		},
+		&cli.IntFlag{
+			Name:     MaxBatchSizeFlagName,
+			Usage:    "Maximum number of calls in an RPC batch request. 0 disables the limit.",
+			EnvVars:  opservice.PrefixEnvVar(envPrefix, "RPC_MAX_BATCH_SIZE"),
+			Category: category,
+		},
+		&cli.Float64Flag{
+			Name:     IPRateLimitFlagName,
+			Usage:    "Maximum number of RPC calls per second per client IP. 0 disables the limit.",
+			EnvVars:  opservice.PrefixEnvVar(envPrefix, "RPC_IP_RATE_LIMIT"),
+			Category: category,
+		},
+		&cli.IntFlag{
+			Name:     IPRateBurstFlagName,
+			Usage:    "Maximum number of RPC calls per client IP at once. Defaults to the IP rate limit if 0.",
+			EnvVars:  opservice.PrefixEnvVar(envPrefix, "RPC_IP_RATE_BURST"),
+			Category: category,
+		},
+		&cli.StringSliceFlag{
+			Name:     MethodRateLimitsFlagName,
+			Usage:    "Maximum number of calls per second to an RPC method across all clients, as <method>=<calls per second>, e.g. admin_startBatcher=0.1",
+			EnvVars:  opservice.PrefixEnvVar(envPrefix, "RPC_METHOD_RATE_LIMITS"),
+			Category: category,
+		},
+		&cli.BoolFlag{
+			Name:     TrustForwardedForFlagName,
+			Usage:    "Identify RPC clients by the X-Forwarded-For header for IP rate limiting. Only enable this behind a proxy that sets the header.",
+			EnvVars:  opservice.PrefixEnvVar(envPrefix, "RPC_TRUST_FORWARDED_FOR"),
+			Category: category,
+		},
 	}
 }
 
@@ -42,6 +93,14 @@ type CLIConfig struct {
 	ListenAddr  string
 	ListenPort  int
 	EnableAdmin bool
+
+	MaxRequestBodySize int64
+	MaxBatchSize       int
+	IPRateLimit        float64
+	IPRateBurst        int
+	// MethodRateLimits are the rate limits of methods, as <method>=<calls per second>.
+	MethodRateLimits  []string
+	TrustForwardedFor bool
 }
 
 func DefaultCLIConfig() CLIConfig {
@@ -56,8 +115,37 @@ func (c CLIConfig) Check() error {
 	if c.ListenPort < 0 || c.ListenPort > math.MaxUint16 {
 		return ErrInvalidPort
 	}
+	limits, err := c.Limits()
+	if err != nil {
+		return err
+	}
+	return limits.Check()
+}
 
-	return nil
+// Limits returns the limits to apply to RPC requests.
+func (c CLIConfig) Limits() (LimitsConfig, error) {
+	methodRateLimits, err := ParseMethodRateLimits(c.MethodRateLimits)
+	if err != nil {
+		return LimitsConfig{}, err
+	}
+	return LimitsConfig{
+		MaxRequestBodySize: c.MaxRequestBodySize,
+		MaxBatchSize:       c.MaxBatchSize,
+		IPRateLimit:        c.IPRateLimit,
+		IPRateBurst:        c.IPRateBurst,
+		MethodRateLimits:   methodRateLimits,
+		TrustForwardedFor:  c.TrustForwardedFor,
+	}, nil
+}
+
+// ReadLimitsConfig reads the limits applied to RPC requests from the flags of LimitsCLIFlags.
+func ReadLimitsConfig(ctx *cli.Context) (LimitsConfig, error) {
+	cfg := ReadCLIConfig(ctx)
+	limits, err := cfg.Limits()
+	if err != nil {
+		return LimitsConfig{}, err
+	}
+	return limits, limits.Check()
 }
 
 func ReadCLIConfig(ctx *cli.Context) CLIConfig {
@@ -65,5 +153,12 @@ func ReadCLIConfig(ctx *cli.Context) CLIConfig {
 		ListenAddr:  ctx.String(ListenAddrFlagName),
 		ListenPort:  ctx.Int(PortFlagName),
 		EnableAdmin: ctx.Bool(EnableAdminFlagName),
+
+		MaxRequestBodySize: ctx.Int64(MaxRequestBodySizeFlagName),
+		MaxBatchSize:       ctx.Int(MaxBatchSizeFlagName),
+		IPRateLimit:        ctx.Float64(IPRateLimitFlagName),
+		IPRateBurst:        ctx.Int(IPRateBurstFlagName),
+		MethodRateLimits:   ctx.StringSlice(MethodRateLimitsFlagName),
+		TrustForwardedFor:  ctx.Bool(TrustForwardedForFlagName),
 	}
 }
@@ -0,0 +1,386 @@
+package rpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/gorilla/websocket"
+	lru "github.com/hashicorp/golang-lru/v2"
+	"golang.org/x/time/rate"
+
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+)
+
+const (
+	RejectReasonBodySize        = "body_size"
+	RejectReasonBatchSize       = "batch_size"
+	RejectReasonIPRateLimit     = "ip_rate_limit"
+	RejectReasonMethodRateLimit = "method_rate_limit"
+)
+
+// limitExceededCode is the JSON-RPC error code for requests that exceed a limit, as defined in EIP-1474.
+const limitExceededCode = -32005
+
+// maxRateLimitedIPs is the number of client IPs that rate limits are tracked for.
+// The least recently seen clients are evicted first.
+const maxRateLimitedIPs = 10_000
+
+// DefaultMaxRequestBodySize is the size that request bodies and websocket messages are limited to,
+// if no MaxRequestBodySize is configured. It matches the default of the geth RPC server.
+const DefaultMaxRequestBodySize = 5 * 1024 * 1024
+
+// LimitsConfig configures the limits that the RPC server applies to incoming requests,
+// to protect it against abusive clients. A zero value disables the limit, except for the request body size.
+type LimitsConfig struct {
+	// MaxRequestBodySize is the maximum size of a request body or websocket message, in bytes.
+	// It defaults to DefaultMaxRequestBodySize, as a request body is always limited.
+	MaxRequestBodySize int64
+	// MaxBatchSize is the maximum number of calls in a batch request.
+	MaxBatchSize int
+	// IPRateLimit is the number of calls per second that a single client IP may make.
+	IPRateLimit float64
+	// IPRateBurst is the maximum number of calls that a single client IP may make at once.
+	// It defaults to the rate limit, rounded up.
+	IPRateBurst int
+	// MethodRateLimits is the number of calls per second to a method, across all clients, by method name.
+	MethodRateLimits map[string]float64
+	// TrustForwardedFor identifies clients by the first IP in the X-Forwarded-For header, if present.
+	// This should only be enabled if the server runs behind a proxy that sets the header.
+	TrustForwardedFor bool
+}
+
+func (c *LimitsConfig) Check() error {
+	if c.MaxRequestBodySize < 0 {
+		return errors.New("max request body size must not be negative")
+	}
+	if c.MaxBatchSize < 0 {
+		return errors.New("max batch size must not be negative")
+	}
+	if c.IPRateLimit < 0 || c.IPRateBurst < 0 {
+		return errors.New("IP rate limit must not be negative")
+	}
+	for method, limit := range c.MethodRateLimits {
+		if limit <= 0 {
+			return fmt.Errorf("rate limit of method %q must be positive", method)
+		}
+	}
+	return nil
+}
+
+// ParseMethodRateLimits parses method rate limits of the form <method>=<calls per second>.
+func ParseMethodRateLimits(limits []string) (map[string]float64, error) {
+	if len(limits) == 0 {
+		return nil, nil
+	}
+	out := make(map[string]float64, len(limits))
+	for _, limit := range limits {
+		method, value, ok := strings.Cut(limit, "=")
+		if !ok || method == "" {
+			return nil, fmt.Errorf("invalid method rate limit %q, expected <method>=<calls per second>", limit)
+		}
+		perSecond, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid method rate limit %q: %w", limit, err)
+		}
+		out[method] = perSecond
+	}
+	return out, nil
+}
+
+// limits enforces a LimitsConfig on the requests to the RPC server.
+type limits struct {
+	cfg     LimitsConfig
+	log     log.Logger
+	metrics opmetrics.RPCServerMetricer
+
+	methods map[string]*rate.Limiter
+
+	ipsLock sync.Mutex
+	ips     *lru.Cache[string, *rate.Limiter]
+}
+
+func newLimits(cfg LimitsConfig, log log.Logger, m opmetrics.RPCServerMetricer) *limits {
+	l := &limits{
+		cfg:     cfg,
+		log:     log,
+		metrics: m,
+		methods: make(map[string]*rate.Limiter, len(cfg.MethodRateLimits)),
+	}
+	for method, perSecond := range cfg.MethodRateLimits {
+		l.methods[method] = rate.NewLimiter(rate.Limit(perSecond), burst(perSecond))
+	}
+	if cfg.IPRateLimit > 0 {
+		// Only errors if the size is not positive.
+		l.ips, _ = lru.New[string, *rate.Limiter](maxRateLimitedIPs)
+	}
+	return l
+}
+
+func burst(perSecond float64) int {
+	return max(1, int(math.Ceil(perSecond)))
+}
+
+// jsonrpcCall is the part of a JSON-RPC call that limits apply to.
+type jsonrpcCall struct {
+	ID     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+}
+
+type jsonrpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type jsonrpcErrorResponse struct {
+	Version string       `json:"jsonrpc"`
+	ID      any          `json:"id"`
+	Error   jsonrpcError `json:"error"`
+}
+
+// Middleware rejects the requests that exceed any of the limits, before they are passed to the next handler.
+func (l *limits) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			next.ServeHTTP(w, r)
+			return
+		}
+		data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, l.maxBodySize()))
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			l.reject(w, r, l.bodySizeRejection())
+			return
+		} else if err != nil {
+			http.Error(w, "failed to read request body", http.StatusBadRequest)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(data))
+
+		// Requests that cannot be parsed are left to the RPC server, to respond with the appropriate error.
+		calls, isBatch := parseCalls(data)
+		if rej := l.check(calls, isBatch, l.clientIP(r)); rej != nil {
+			l.reject(w, r, rej)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// WebsocketHandler serves websocket connections with the RPC server, and applies the limits to every message,
+// as a websocket connection is not limited by the limits of the HTTP request that opened it.
+func (l *limits) WebsocketHandler(srv *rpc.Server, allowedOrigins []string) http.Handler {
+	upgrader := websocket.Upgrader{
+		ReadBufferSize:  wsBufferSize,
+		WriteBufferSize: wsBufferSize,
+		CheckOrigin:     originChecker(allowedOrigins),
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			l.log.Debug("WebSocket upgrade failed", "err", err)
+			return
+		}
+		conn.SetReadLimit(l.maxBodySize())
+		c := &limitedWSConn{Conn: conn, limits: l, ip: l.clientIP(r)}
+		srv.ServeCodec(rpc.NewFuncCodec(c, c.writeJSON, c.readJSON), 0)
+	})
+}
+
+// wsBufferSize is the size of the read and write buffers of websocket connections, as used by the geth RPC server.
+const wsBufferSize = 1024
+
+// limitedWSConn reads the RPC messages of a websocket connection, and responds to the ones that exceed the
+// limits itself, instead of passing them to the RPC server.
+type limitedWSConn struct {
+	*websocket.Conn
+	limits *limits
+	ip     string
+
+	// writeLock serializes the writes of rejections with the writes of the RPC server.
+	writeLock sync.Mutex
+}
+
+func (c *limitedWSConn) readJSON(v any) error {
+	for {
+		_, data, err := c.ReadMessage()
+		if errors.Is(err, websocket.ErrReadLimit) {
+			c.limits.recordRejection(c.ip, c.limits.bodySizeRejection())
+			return err
+		} else if err != nil {
+			return err
+		}
+		calls, isBatch := parseCalls(data)
+		if rej := c.limits.check(calls, isBatch, c.ip); rej != nil {
+			c.limits.recordRejection(c.ip, rej)
+			if err := c.writeJSON(rejectionResponse(calls, isBatch, rej.msg), true); err != nil {
+				return err
+			}
+			continue
+		}
+		return json.Unmarshal(data, v)
+	}
+}
+
+func (c *limitedWSConn) writeJSON(v any, _ bool) error {
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+	return c.WriteJSON(v)
+}
+
+// originChecker allows websocket connections from the given origins, or from any origin with the "*" wildcard.
+// Connections without an origin, i.e. from clients other than browsers, are allowed as well.
+func originChecker(allowedOrigins []string) func(r *http.Request) bool {
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			return true
+		}
+		for _, allowed := range allowedOrigins {
+			if allowed == "*" || strings.EqualFold(allowed, origin) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func (l *limits) maxBodySize() int64 {
+	if l.cfg.MaxRequestBodySize > 0 {
+		return l.cfg.MaxRequestBodySize
+	}
+	return DefaultMaxRequestBodySize
+}
+
+// rejection describes a request that exceeds a limit.
+type rejection struct {
+	status int
+	method string
+	reason string
+	msg    string
+}
+
+func (l *limits) bodySizeRejection() *rejection {
+	return &rejection{
+		status: http.StatusRequestEntityTooLarge,
+		reason: RejectReasonBodySize,
+		msg:    fmt.Sprintf("request body exceeds %d bytes", l.maxBodySize()),
+	}
+}
+
+// check returns the rejection of the calls of a request from the given client IP, or nil if no limit is exceeded.
+func (l *limits) check(calls []jsonrpcCall, isBatch bool, ip string) *rejection {
+	if isBatch && l.cfg.MaxBatchSize > 0 && len(calls) > l.cfg.MaxBatchSize {
+		return &rejection{
+			status: http.StatusRequestEntityTooLarge,
+			reason: RejectReasonBatchSize,
+			msg:    fmt.Sprintf("batch of %d calls exceeds %d calls", len(calls), l.cfg.MaxBatchSize),
+		}
+	}
+	if len(calls) > 0 && l.ips != nil && !l.ipLimiter(ip).AllowN(time.Now(), len(calls)) {
+		return &rejection{
+			status: http.StatusTooManyRequests,
+			reason: RejectReasonIPRateLimit,
+			msg:    "rate limit exceeded",
+		}
+	}
+	for _, call := range calls {
+		if limiter, ok := l.methods[call.Method]; ok && !limiter.Allow() {
+			return &rejection{
+				status: http.StatusTooManyRequests,
+				method: call.Method,
+				reason: RejectReasonMethodRateLimit,
+				msg:    fmt.Sprintf("rate limit of method %s exceeded", call.Method),
+			}
+		}
+	}
+	return nil
+}
+
+// parseCalls returns the calls of the request, and whether it is a batch request.
+func parseCalls(data []byte) ([]jsonrpcCall, bool) {
+	data = bytes.TrimLeft(data, " \t\r\n")
+	if len(data) > 0 && data[0] == '[' {
+		var calls []jsonrpcCall
+		if err := json.Unmarshal(data, &calls); err != nil {
+			return nil, true
+		}
+		return calls, true
+	}
+	var call jsonrpcCall
+	if err := json.Unmarshal(data, &call); err != nil {
+		return nil, false
+	}
+	return []jsonrpcCall{call}, false
+}
+
+func (l *limits) ipLimiter(ip string) *rate.Limiter {
+	l.ipsLock.Lock()
+	defer l.ipsLock.Unlock()
+	limiter, ok := l.ips.Get(ip)
+	if !ok {
+		b := l.cfg.IPRateBurst
+		if b == 0 {
+			b = burst(l.cfg.IPRateLimit)
+		}
+		limiter = rate.NewLimiter(rate.Limit(l.cfg.IPRateLimit), b)
+		l.ips.Add(ip, limiter)
+	}
+	return limiter
+}
+
+func (l *limits) clientIP(r *http.Request) string {
+	if l.cfg.TrustForwardedFor {
+		if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+			first, _, _ := strings.Cut(forwarded, ",")
+			return strings.TrimSpace(first)
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func (l *limits) recordRejection(ip string, rej *rejection) {
+	l.log.Debug("Rejected RPC request", "reason", rej.reason, "method", rej.method, "client", ip, "msg", rej.msg)
+	l.metrics.RecordRPCServerRejection(rej.method, rej.reason)
+}
+
+func (l *limits) reject(w http.ResponseWriter, r *http.Request, rej *rejection) {
+	l.recordRejection(l.clientIP(r), rej)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(rej.status)
+	_ = json.NewEncoder(w).Encode(&jsonrpcErrorResponse{
+		Version: "2.0",
+		Error:   jsonrpcError{Code: limitExceededCode, Message: rej.msg},
+	})
+}
+
+// rejectionResponse returns the error responses to the rejected calls of a websocket message.
+// Unlike HTTP responses, these carry the IDs of the calls, so the client can match them to its pending calls.
+func rejectionResponse(calls []jsonrpcCall, isBatch bool, msg string) any {
+	responses := make([]*jsonrpcErrorResponse, len(calls))
+	for i, call := range calls {
+		responses[i] = &jsonrpcErrorResponse{
+			Version: "2.0",
+			ID:      call.ID,
+			Error:   jsonrpcError{Code: limitExceededCode, Message: msg},
+		}
+	}
+	if !isBatch {
+		return responses[0]
+	}
+	return responses
+}
@@ -0,0 +1,161 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+type rejectionMetrics struct {
+	rejections map[string]int
+}
+
+func (m *rejectionMetrics) RecordRPCServerRequest(method string) func() {
+	return func() {}
+}
+
+func (m *rejectionMetrics) RecordRPCServerRejection(method string, reason string) {
+	m.rejections[method+"/"+reason]++
+}
+
+func TestServerLimits(t *testing.T) {
+	startServer := func(t *testing.T, cfg LimitsConfig) (string, *rejectionMetrics) {
+		m := &rejectionMetrics{rejections: make(map[string]int)}
+		server := NewServer("127.0.0.1", 0, "test",
+			WithAPIs([]rpc.API{{Namespace: "test", Service: new(testAPI)}}),
+			WithLimits(cfg, m))
+		require.NoError(t, server.Start())
+		t.Cleanup(func() {
+			_ = server.Stop()
+		})
+		return fmt.Sprintf("http://%s", server.Endpoint()), m
+	}
+	post := func(t *testing.T, url string, body string, header ...string) int {
+		req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		for i := 0; i+1 < len(header); i += 2 {
+			req.Header.Set(header[i], header[i+1])
+		}
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+		return res.StatusCode
+	}
+	call := `{"jsonrpc":"2.0","id":1,"method":"test_frobnicate","params":[2]}`
+	batch := func(n int) string {
+		calls := make([]string, n)
+		for i := range calls {
+			calls[i] = call
+		}
+		return "[" + strings.Join(calls, ",") + "]"
+	}
+
+	t.Run("MaxRequestBodySize", func(t *testing.T) {
+		url, m := startServer(t, LimitsConfig{MaxRequestBodySize: int64(len(call))})
+		require.Equal(t, http.StatusOK, post(t, url, call))
+		require.Equal(t, http.StatusRequestEntityTooLarge, post(t, url, call+" "))
+		require.Equal(t, 1, m.rejections["/"+RejectReasonBodySize])
+	})
+
+	t.Run("DefaultMaxRequestBodySize", func(t *testing.T) {
+		url, m := startServer(t, LimitsConfig{})
+		padding := strings.Repeat(" ", DefaultMaxRequestBodySize-len(call))
+		require.Equal(t, http.StatusOK, post(t, url, call+padding))
+		require.Equal(t, http.StatusRequestEntityTooLarge, post(t, url, call+padding+" "))
+		require.Equal(t, 1, m.rejections["/"+RejectReasonBodySize])
+	})
+
+	t.Run("MaxBatchSize", func(t *testing.T) {
+		url, m := startServer(t, LimitsConfig{MaxBatchSize: 2})
+		require.Equal(t, http.StatusOK, post(t, url, batch(2)))
+		require.Equal(t, http.StatusRequestEntityTooLarge, post(t, url, batch(3)))
+		require.Equal(t, 1, m.rejections["/"+RejectReasonBatchSize])
+	})
+
+	t.Run("IPRateLimit", func(t *testing.T) {
+		url, m := startServer(t, LimitsConfig{IPRateLimit: 0.001, IPRateBurst: 2})
+		require.Equal(t, http.StatusOK, post(t, url, batch(2)))
+		require.Equal(t, http.StatusTooManyRequests, post(t, url, call))
+		require.Equal(t, 1, m.rejections["/"+RejectReasonIPRateLimit])
+	})
+
+	t.Run("TrustForwardedFor", func(t *testing.T) {
+		url, _ := startServer(t, LimitsConfig{IPRateLimit: 0.001, TrustForwardedFor: true})
+		require.Equal(t, http.StatusOK, post(t, url, call, "X-Forwarded-For", "10.0.0.1, 127.0.0.1"))
+		require.Equal(t, http.StatusTooManyRequests, post(t, url, call, "X-Forwarded-For", "10.0.0.1"))
+		require.Equal(t, http.StatusOK, post(t, url, call, "X-Forwarded-For", "10.0.0.2"))
+	})
+
+	t.Run("MethodRateLimits", func(t *testing.T) {
+		url, m := startServer(t, LimitsConfig{MethodRateLimits: map[string]float64{"test_frobnicate": 0.001}})
+		require.Equal(t, http.StatusOK, post(t, url, call))
+		require.Equal(t, http.StatusTooManyRequests, post(t, url, call))
+		require.Equal(t, http.StatusOK, post(t, url, `{"jsonrpc":"2.0","id":1,"method":"health_status","params":[]}`))
+		require.Equal(t, 1, m.rejections["test_frobnicate/"+RejectReasonMethodRateLimit])
+	})
+}
+
+func TestServerLimitsWebsocket(t *testing.T) {
+	m := &rejectionMetrics{rejections: make(map[string]int)}
+	server := NewServer("127.0.0.1", 0, "test",
+		WithAPIs([]rpc.API{{Namespace: "test", Service: new(testAPI)}}),
+		WithWebsocket(),
+		WithLimits(LimitsConfig{
+			MaxRequestBodySize: 1000,
+			MaxBatchSize:       2,
+			MethodRateLimits:   map[string]float64{"test_frobnicate": 0.001},
+		}, m))
+	require.NoError(t, server.Start())
+	t.Cleanup(func() {
+		_ = server.Stop()
+	})
+	ctx := context.Background()
+	client, err := rpc.DialContext(ctx, fmt.Sprintf("ws://%s", server.Endpoint()))
+	require.NoError(t, err)
+	defer client.Close()
+
+	var res int
+	require.NoError(t, client.CallContext(ctx, &res, "test_frobnicate", 2))
+	require.Equal(t, 4, res)
+	// The limits apply to every message, not just to the request that opened the connection.
+	err = client.CallContext(ctx, &res, "test_frobnicate", 2)
+	var rpcErr rpc.Error
+	require.ErrorAs(t, err, &rpcErr)
+	require.Equal(t, limitExceededCode, rpcErr.ErrorCode())
+	require.Equal(t, 1, m.rejections["test_frobnicate/"+RejectReasonMethodRateLimit])
+
+	var status string
+	batch := make([]rpc.BatchElem, 3)
+	for i := range batch {
+		batch[i] = rpc.BatchElem{Method: "health_status", Result: &status}
+	}
+	require.NoError(t, client.BatchCallContext(ctx, batch))
+	for _, elem := range batch {
+		require.ErrorAs(t, elem.Error, &rpcErr)
+		require.Equal(t, limitExceededCode, rpcErr.ErrorCode())
+	}
+	require.Equal(t, 1, m.rejections["/"+RejectReasonBatchSize])
+	require.NoError(t, client.CallContext(ctx, &status, "health_status"))
+	require.Equal(t, "test", status)
+
+	// Messages that exceed the size limit close the connection.
+	require.Error(t, client.CallContext(ctx, &status, "health_status", strings.Repeat("a", 1000)))
+	require.Equal(t, 1, m.rejections["/"+RejectReasonBodySize])
+}
+
+func TestParseMethodRateLimits(t *testing.T) {
+	limits, err := ParseMethodRateLimits([]string{"admin_startBatcher=0.5", "admin_stopBatcher=2"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]float64{"admin_startBatcher": 0.5, "admin_stopBatcher": 2}, limits)
+
+	_, err = ParseMethodRateLimits([]string{"admin_startBatcher"})
+	require.Error(t, err)
+	_, err = ParseMethodRateLimits([]string{"admin_startBatcher=fast"})
+	require.Error(t, err)
+}
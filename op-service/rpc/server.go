@@ -8,6 +8,7 @@ import (
 	"net"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/log"
@@ -31,12 +32,17 @@ type Server struct {
 	jwtSecret      []byte
 	rpcPath        string
 	healthzPath    string
+	httpHandlers   map[string]http.Handler
 	httpRecorder   opmetrics.HTTPRecorder
 	httpServer     *http.Server
 	listener       net.Listener
 	log            log.Logger
 	tls            *ServerTLSConfig
 	middlewares    []Middleware
+	websocket      bool
+	limits         LimitsConfig
+	rpcMetrics     opmetrics.RPCServerMetricer
+	rpcServer      *rpc.Server
 }
 
 type ServerTLSConfig struct {
@@ -90,6 +96,16 @@ func WithHealthzPath(path string) ServerOption {
 	}
 }
 
+// WithHTTPHandler serves the handler on the given path, next to the RPC and health endpoints.
+func WithHTTPHandler(path string, hdlr http.Handler) ServerOption {
+	return func(b *Server) {
+		if b.httpHandlers == nil {
+			b.httpHandlers = make(map[string]http.Handler)
+		}
+		b.httpHandlers[path] = hdlr
+	}
+}
+
 func WithHTTPRecorder(recorder opmetrics.HTTPRecorder) ServerOption {
 	return func(b *Server) {
 		b.httpRecorder = recorder
@@ -118,6 +134,22 @@ func WithMiddleware(middleware func(http.Handler) (hdlr http.Handler)) ServerOpt
 	}
 }
 
+// WithWebsocket serves websocket connections on the RPC path, e.g. for subscriptions.
+// The limits of the server apply to every message of a websocket connection.
+func WithWebsocket() ServerOption {
+	return func(b *Server) {
+		b.websocket = true
+	}
+}
+
+// WithLimits configures the limits applied to RPC requests, and the metrics to record rejected requests with.
+func WithLimits(cfg LimitsConfig, m opmetrics.RPCServerMetricer) ServerOption {
+	return func(b *Server) {
+		b.limits = cfg
+		b.rpcMetrics = m
+	}
+}
+
 func NewServer(host string, port int, appVersion string, opts ...ServerOption) *Server {
 	endpoint := net.JoinHostPort(host, strconv.Itoa(port))
 	bs := &Server{
@@ -129,6 +161,7 @@ func NewServer(host string, port int, appVersion string, opts ...ServerOption) *
 		rpcPath:        "/",
 		healthzPath:    "/healthz",
 		httpRecorder:   opmetrics.NoopHTTPRecorder,
+		rpcMetrics:     &opmetrics.NoopRPCMetrics{},
 		httpServer: &http.Server{
 			Addr: endpoint,
 		},
@@ -169,10 +202,21 @@ func (b *Server) Start() error {
 		nodeHdlr = middleware(nodeHdlr)
 	}
 	nodeHdlr = node.NewHTTPHandlerStack(nodeHdlr, b.corsHosts, b.vHosts, b.jwtSecret)
+	// Limits are applied before authentication, so unauthenticated clients are limited as well.
+	limits := newLimits(b.limits, b.log, b.rpcMetrics)
+	nodeHdlr = limits.Middleware(nodeHdlr)
+	if b.websocket {
+		wsHdlr := node.NewWSHandlerStack(limits.WebsocketHandler(srv, b.corsHosts), b.jwtSecret)
+		nodeHdlr = withWebsocket(nodeHdlr, wsHdlr)
+	}
+	b.rpcServer = srv
 
 	mux := http.NewServeMux()
 	mux.Handle(b.rpcPath, nodeHdlr)
 	mux.Handle(b.healthzPath, b.healthzHandler)
+	for path, hdlr := range b.httpHandlers {
+		mux.Handle(path, hdlr)
+	}
 
 	// http middleware
 	var handler http.Handler = mux
@@ -218,9 +262,24 @@ func (b *Server) Stop() error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 	_ = b.httpServer.Shutdown(ctx)
+	// Shutting down the HTTP server does not close the hijacked websocket connections.
+	if b.rpcServer != nil {
+		b.rpcServer.Stop()
+	}
 	return nil
 }
 
+// withWebsocket routes websocket upgrade requests to the ws handler, and all other requests to the http handler.
+func withWebsocket(httpHandler http.Handler, wsHandler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+			wsHandler.ServeHTTP(w, r)
+			return
+		}
+		httpHandler.ServeHTTP(w, r)
+	})
+}
+
 type HealthzResponse struct {
 	Version string `json:"version"`
 }
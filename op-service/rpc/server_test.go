@@ -30,6 +30,9 @@ func TestBaseServer(t *testing.T) {
 				Service:   new(testAPI),
 			},
 		}),
+		WithHTTPHandler("/status", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("ok"))
+		})),
 	)
 	require.NoError(t, server.Start())
 	defer func() {
@@ -48,6 +51,15 @@ func TestBaseServer(t *testing.T) {
 		require.EqualValues(t, fmt.Sprintf("{\"version\":\"%s\"}\n", appVersion), string(body))
 	})
 
+	t.Run("supports additional HTTP handlers", func(t *testing.T) {
+		res, err := http.Get(fmt.Sprintf("http://%s/status", server.endpoint))
+		require.NoError(t, err)
+		defer res.Body.Close()
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.Equal(t, "ok", string(body))
+	})
+
 	t.Run("supports health_status", func(t *testing.T) {
 		var res string
 		require.NoError(t, rpcClient.Call(&res, "health_status"))
@@ -0,0 +1,19 @@
+package signer
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// BlockPayloadArgs are the arguments of an opsigner_signBlockPayload request,
+// to sign an L2 block payload for p2p gossip.
+type BlockPayloadArgs struct {
+	// Domain is the signing domain of the payload.
+	Domain common.Hash `json:"domain"`
+	// ChainID is the L2 chain ID.
+	ChainID *hexutil.Big `json:"chainId"`
+	// PayloadHash is the keccak256 hash of the encoded payload.
+	PayloadHash common.Hash `json:"payloadHash"`
+	// SenderAddress is the address of the key the payload is to be signed with.
+	SenderAddress *common.Address `json:"senderAddress"`
+}
@@ -2,6 +2,8 @@ package signer
 
 import (
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/urfave/cli/v2"
 
@@ -10,13 +12,45 @@ import (
 )
 
 const (
-	EndpointFlagName = "signer.endpoint"
-	AddressFlagName  = "signer.address"
+	BackendFlagName            = "signer.backend"
+	EndpointFlagName           = "signer.endpoint"
+	AddressFlagName            = "signer.address"
+	KeyRefreshIntervalFlagName = "signer.key-refresh-interval"
+	KMSKeyIDFlagName           = "signer.kms.key-id"
+	KMSRegionFlagName          = "signer.kms.region"
+	KMSEndpointFlagName        = "signer.kms.endpoint"
+	VaultAddrFlagName          = "signer.vault.addr"
+	VaultMountFlagName         = "signer.vault.mount"
+	VaultKeyFlagName           = "signer.vault.key"
+	VaultTokenFileFlagName     = "signer.vault.token-file"
+)
+
+// Backend identifies the service that holds the signing key.
+type Backend string
+
+const (
+	// BackendRemote signs transactions with a remote signer, via eth_signTransaction.
+	BackendRemote Backend = "remote"
+	// BackendAWSKMS signs transaction digests with a secp256k1 key held in AWS KMS.
+	BackendAWSKMS Backend = "aws-kms"
+	// BackendVault signs transaction digests with a secp256k1 key held in the HashiCorp Vault transit engine.
+	BackendVault Backend = "vault"
+)
+
+var (
+	DefaultKeyRefreshInterval = 5 * time.Minute
+	DefaultVaultMount         = "transit"
 )
 
 func CLIFlags(envPrefix string) []cli.Flag {
 	envPrefix += "_SIGNER"
 	flags := []cli.Flag{
+		&cli.StringFlag{
+			Name:    BackendFlagName,
+			Usage:   fmt.Sprintf("Signer backend to use: %s, %s or %s", BackendRemote, BackendAWSKMS, BackendVault),
+			Value:   string(BackendRemote),
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "BACKEND"),
+		},
 		&cli.StringFlag{
 			Name:    EndpointFlagName,
 			Usage:   "Signer endpoint the client will connect to",
@@ -24,38 +58,131 @@ func CLIFlags(envPrefix string) []cli.Flag {
 		},
 		&cli.StringFlag{
 			Name:    AddressFlagName,
-			Usage:   "Address the signer is signing transactions for",
+			Usage:   "Address the signer is signing transactions for. Optional for the aws-kms and vault backends, which verify it against the key if set.",
 			EnvVars: opservice.PrefixEnvVar(envPrefix, "ADDRESS"),
 		},
+		&cli.DurationFlag{
+			Name: KeyRefreshIntervalFlagName,
+			Usage: "Interval at which the aws-kms and vault backends re-resolve the signing key, and the vault backend reloads its token, " +
+				"to pick up rotations without a restart. A rotation to a key with a different address is refused. 0 disables refreshing.",
+			Value:   DefaultKeyRefreshInterval,
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "KEY_REFRESH_INTERVAL"),
+		},
+		&cli.StringFlag{
+			Name: KMSKeyIDFlagName,
+			Usage: "ID, ARN or alias of the AWS KMS key to sign with. Credentials are loaded with the default AWS credential chain: " +
+				"environment variables, shared config files, web identity tokens (IRSA), or the ECS and EC2 instance metadata endpoints.",
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "KMS_KEY_ID"),
+		},
+		&cli.StringFlag{
+			Name:    KMSRegionFlagName,
+			Usage:   "AWS region of the KMS key",
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "KMS_REGION"),
+		},
+		&cli.StringFlag{
+			Name:    KMSEndpointFlagName,
+			Usage:   "Override of the AWS KMS endpoint, e.g. for a VPC endpoint. Defaults to the public endpoint of the region.",
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "KMS_ENDPOINT"),
+		},
+		&cli.StringFlag{
+			Name:    VaultAddrFlagName,
+			Usage:   "Address of the HashiCorp Vault server",
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "VAULT_ADDR"),
+		},
+		&cli.StringFlag{
+			Name:    VaultMountFlagName,
+			Usage:   "Mount path of the Vault transit secrets engine",
+			Value:   DefaultVaultMount,
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "VAULT_MOUNT"),
+		},
+		&cli.StringFlag{
+			Name:    VaultKeyFlagName,
+			Usage:   "Name of the Vault transit key to sign with. The latest version of the key is used.",
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "VAULT_KEY"),
+		},
+		&cli.StringFlag{
+			Name:    VaultTokenFileFlagName,
+			Usage:   "File containing the Vault token, re-read on every key refresh. Defaults to the VAULT_TOKEN environment variable if not set.",
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "VAULT_TOKEN_FILE"),
+		},
 	}
 	flags = append(flags, optls.CLIFlagsWithFlagPrefix(envPrefix, "signer")...)
 	return flags
 }
 
 type CLIConfig struct {
+	Backend   Backend
 	Endpoint  string
 	Address   string
 	TLSConfig optls.CLIConfig
+
+	KeyRefreshInterval time.Duration
+	KMS                KMSConfig
+	Vault              VaultConfig
+}
+
+type KMSConfig struct {
+	KeyID    string
+	Region   string
+	Endpoint string
+}
+
+type VaultConfig struct {
+	Addr      string
+	Mount     string
+	Key       string
+	TokenFile string
 }
 
 func NewCLIConfig() CLIConfig {
 	return CLIConfig{
-		TLSConfig: optls.NewCLIConfig(),
+		Backend:            BackendRemote,
+		TLSConfig:          optls.NewCLIConfig(),
+		KeyRefreshInterval: DefaultKeyRefreshInterval,
+		Vault:              VaultConfig{Mount: DefaultVaultMount},
 	}
 }
 
 func (c CLIConfig) Check() error {
-	if err := c.TLSConfig.Check(); err != nil {
-		return err
+	if c.KeyRefreshInterval < 0 {
+		return errors.New("signer key refresh interval must not be negative")
 	}
-	if !((c.Endpoint == "" && c.Address == "") || (c.Endpoint != "" && c.Address != "")) {
-		return errors.New("signer endpoint and address must both be set or not set")
+	switch c.Backend {
+	case "", BackendRemote:
+		if err := c.TLSConfig.Check(); err != nil {
+			return err
+		}
+		if !((c.Endpoint == "" && c.Address == "") || (c.Endpoint != "" && c.Address != "")) {
+			return errors.New("signer endpoint and address must both be set or not set")
+		}
+	case BackendAWSKMS:
+		if c.KMS.KeyID == "" {
+			return errors.New("signer KMS key ID must be set for the aws-kms backend")
+		}
+		if c.KMS.Region == "" {
+			return errors.New("signer KMS region must be set for the aws-kms backend")
+		}
+	case BackendVault:
+		if c.Vault.Addr == "" {
+			return errors.New("signer Vault address must be set for the vault backend")
+		}
+		if c.Vault.Key == "" {
+			return errors.New("signer Vault key must be set for the vault backend")
+		}
+		if c.Vault.Mount == "" {
+			return errors.New("signer Vault mount must be set for the vault backend")
+		}
+	default:
+		return fmt.Errorf("unknown signer backend: %q", c.Backend)
 	}
 	return nil
 }
 
 func (c CLIConfig) Enabled() bool {
-	if c.Endpoint != "" && c.Address != "" {
+	switch c.Backend {
+	case "", BackendRemote:
+		return c.Endpoint != "" && c.Address != ""
+	case BackendAWSKMS, BackendVault:
 		return true
 	}
 	return false
@@ -63,9 +190,22 @@ func (c CLIConfig) Enabled() bool {
 
 func ReadCLIConfig(ctx *cli.Context) CLIConfig {
 	cfg := CLIConfig{
-		Endpoint:  ctx.String(EndpointFlagName),
-		Address:   ctx.String(AddressFlagName),
-		TLSConfig: optls.ReadCLIConfigWithPrefix(ctx, "signer"),
+		Backend:            Backend(ctx.String(BackendFlagName)),
+		Endpoint:           ctx.String(EndpointFlagName),
+		Address:            ctx.String(AddressFlagName),
+		TLSConfig:          optls.ReadCLIConfigWithPrefix(ctx, "signer"),
+		KeyRefreshInterval: ctx.Duration(KeyRefreshIntervalFlagName),
+		KMS: KMSConfig{
+			KeyID:    ctx.String(KMSKeyIDFlagName),
+			Region:   ctx.String(KMSRegionFlagName),
+			Endpoint: ctx.String(KMSEndpointFlagName),
+		},
+		Vault: VaultConfig{
+			Addr:      ctx.String(VaultAddrFlagName),
+			Mount:     ctx.String(VaultMountFlagName),
+			Key:       ctx.String(VaultKeyFlagName),
+			TokenFile: ctx.String(VaultTokenFileFlagName),
+		},
 	}
 	return cfg
 }
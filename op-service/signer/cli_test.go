@@ -2,6 +2,7 @@ package signer
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/urfave/cli/v2"
@@ -38,6 +39,52 @@ func TestInvalidConfig(t *testing.T) {
 				config.Endpoint = "http://localhost"
 			},
 		},
+		{
+			name:     "UnknownBackend",
+			expected: "unknown signer backend",
+			configChange: func(config *CLIConfig) {
+				config.Backend = "hsm"
+			},
+		},
+		{
+			name:     "NegativeKeyRefreshInterval",
+			expected: "signer key refresh interval must not be negative",
+			configChange: func(config *CLIConfig) {
+				config.KeyRefreshInterval = -1
+			},
+		},
+		{
+			name:     "MissingKMSKeyID",
+			expected: "signer KMS key ID must be set",
+			configChange: func(config *CLIConfig) {
+				config.Backend = BackendAWSKMS
+				config.KMS.Region = "us-east-1"
+			},
+		},
+		{
+			name:     "MissingKMSRegion",
+			expected: "signer KMS region must be set",
+			configChange: func(config *CLIConfig) {
+				config.Backend = BackendAWSKMS
+				config.KMS.KeyID = "alias/batcher"
+			},
+		},
+		{
+			name:     "MissingVaultAddr",
+			expected: "signer Vault address must be set",
+			configChange: func(config *CLIConfig) {
+				config.Backend = BackendVault
+				config.Vault.Key = "batcher"
+			},
+		},
+		{
+			name:     "MissingVaultKey",
+			expected: "signer Vault key must be set",
+			configChange: func(config *CLIConfig) {
+				config.Backend = BackendVault
+				config.Vault.Addr = "http://localhost:8200"
+			},
+		},
 		{
 			name:     "InvalidTLSConfig",
 			expected: "all tls flags must be set if at least one is set",
@@ -56,6 +103,21 @@ func TestInvalidConfig(t *testing.T) {
 	}
 }
 
+func TestBackendConfig(t *testing.T) {
+	cfg := configForArgs("test", "--signer.backend=vault", "--signer.vault.addr=http://localhost:8200",
+		"--signer.vault.key=batcher", "--signer.vault.token-file=/run/vault/token", "--signer.key-refresh-interval=1m")
+	require.NoError(t, cfg.Check())
+	require.True(t, cfg.Enabled())
+	require.Equal(t, BackendVault, cfg.Backend)
+	require.Equal(t, VaultConfig{Addr: "http://localhost:8200", Mount: DefaultVaultMount, Key: "batcher", TokenFile: "/run/vault/token"}, cfg.Vault)
+	require.Equal(t, time.Minute, cfg.KeyRefreshInterval)
+
+	cfg = configForArgs("test", "--signer.backend=aws-kms", "--signer.kms.key-id=alias/batcher", "--signer.kms.region=eu-west-1")
+	require.NoError(t, cfg.Check())
+	require.True(t, cfg.Enabled())
+	require.Equal(t, KMSConfig{KeyID: "alias/batcher", Region: "eu-west-1"}, cfg.KMS)
+}
+
 func configForArgs(args ...string) CLIConfig {
 	app := cli.NewApp()
 	app.Flags = CLIFlags("TEST_")
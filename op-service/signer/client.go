@@ -82,6 +82,11 @@ func NewSignerClientFromConfig(logger log.Logger, config CLIConfig) (*SignerClie
 	return NewSignerClient(logger, config.Endpoint, config.TLSConfig)
 }
 
+// Close closes the connection to the remote signer.
+func (s *SignerClient) Close() {
+	s.client.Close()
+}
+
 func (s *SignerClient) pingVersion() (string, error) {
 	var v string
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
@@ -113,3 +118,15 @@ func (s *SignerClient) SignTransaction(ctx context.Context, chainId *big.Int, fr
 
 	return &signed, nil
 }
+
+// SignBlockPayload signs the L2 block payload with the remote signer, via opsigner_signBlockPayload.
+func (s *SignerClient) SignBlockPayload(ctx context.Context, args *BlockPayloadArgs) ([65]byte, error) {
+	var result hexutil.Bytes
+	if err := s.client.CallContext(ctx, &result, "opsigner_signBlockPayload", args); err != nil {
+		return [65]byte{}, fmt.Errorf("opsigner_signBlockPayload failed: %w", err)
+	}
+	if len(result) != 65 {
+		return [65]byte{}, fmt.Errorf("invalid signature length %d, expected 65", len(result))
+	}
+	return [65]byte(result), nil
+}
@@ -0,0 +1,220 @@
+package signer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var (
+	// oidPublicKeyECDSA is the object identifier of ECDSA public keys, see RFC 5480.
+	oidPublicKeyECDSA = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+	// oidSecp256k1 is the object identifier of the secp256k1 curve, see SEC 2.
+	oidSecp256k1 = asn1.ObjectIdentifier{1, 3, 132, 0, 10}
+
+	secp256k1N     = crypto.S256().Params().N
+	secp256k1HalfN = new(big.Int).Rsh(secp256k1N, 1)
+)
+
+// KeyBackend is a service that holds secp256k1 keys and signs digests with them, without exposing the private key.
+type KeyBackend interface {
+	// ResolveKey resolves the key that should currently be used for signing, e.g. the latest version of a key,
+	// reloading the credentials of the backend if they changed. The returned reference identifies the key for SignDigest.
+	ResolveKey(ctx context.Context) (ref string, pub *ecdsa.PublicKey, err error)
+	// SignDigest signs the digest with the referenced key, and returns the ASN.1 DER encoded signature.
+	SignDigest(ctx context.Context, ref string, digest []byte) ([]byte, error)
+}
+
+type signingKey struct {
+	ref  string
+	pub  *ecdsa.PublicKey
+	addr common.Address
+}
+
+// KeySigner signs transactions with a key held by a KeyBackend.
+// The key is re-resolved at the refresh interval, so rotations of the key or of the credentials of the backend
+// are picked up without a restart. The address of the signer is fixed: the transaction manager and the
+// configuration of the chain depend on it, so a rotation to a key with a different address is refused,
+// and the previous key continues to be used.
+type KeySigner struct {
+	logger          log.Logger
+	backend         KeyBackend
+	refreshInterval time.Duration
+
+	mu         sync.Mutex
+	key        signingKey
+	refreshed  time.Time
+	refreshing bool
+}
+
+// NewKeySigner resolves the signing key of the backend. Key refreshing is disabled if the refresh interval is 0.
+func NewKeySigner(ctx context.Context, logger log.Logger, backend KeyBackend, refreshInterval time.Duration) (*KeySigner, error) {
+	s := &KeySigner{
+		logger:          logger,
+		backend:         backend,
+		refreshInterval: refreshInterval,
+	}
+	key, err := s.resolve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.key = key
+	s.refreshed = time.Now()
+	return s, nil
+}
+
+// NewKeySignerFromConfig creates a KeySigner for the aws-kms or vault backend of the config.
+// If the config specifies an address, it must match the address of the key.
+func NewKeySignerFromConfig(logger log.Logger, config CLIConfig) (*KeySigner, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	var backend KeyBackend
+	switch config.Backend {
+	case BackendAWSKMS:
+		kmsBackend, err := NewKMSBackendFromConfig(ctx, config.KMS)
+		if err != nil {
+			return nil, err
+		}
+		backend = kmsBackend
+	case BackendVault:
+		backend = NewVaultBackend(config.Vault, &http.Client{Timeout: 10 * time.Second})
+	default:
+		return nil, fmt.Errorf("signer backend %q does not use a key backend", config.Backend)
+	}
+	s, err := NewKeySigner(ctx, logger, backend, config.KeyRefreshInterval)
+	if err != nil {
+		return nil, err
+	}
+	if config.Address != "" && common.HexToAddress(config.Address) != s.Address() {
+		return nil, fmt.Errorf("signer address %s does not match the address of the %s key %s", config.Address, config.Backend, s.Address())
+	}
+	logger.Info("Created key signer", "backend", config.Backend, "address", s.Address())
+	return s, nil
+}
+
+// Address returns the address of the signing key. It does not change after the KeySigner is created.
+func (s *KeySigner) Address() common.Address {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.key.addr
+}
+
+func (s *KeySigner) SignTransaction(ctx context.Context, chainId *big.Int, from common.Address, tx *types.Transaction) (*types.Transaction, error) {
+	key := s.currentKey(ctx)
+	if from != key.addr {
+		return nil, fmt.Errorf("attempting to sign for %s, but the signing key is for %s", from, key.addr)
+	}
+	signer := types.LatestSignerForChainID(chainId)
+	digest := signer.Hash(tx)
+	der, err := s.backend.SignDigest(ctx, key.ref, digest[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction digest: %w", err)
+	}
+	sig, err := recoverableSignature(digest[:], der, key.pub)
+	if err != nil {
+		return nil, err
+	}
+	return tx.WithSignature(signer, sig)
+}
+
+// currentKey returns the signing key, re-resolving it first if the refresh interval elapsed.
+// The lock is not held while resolving: concurrent signing requests continue with the previous key meanwhile.
+// The previous key is kept if it cannot be re-resolved, or if it resolves to a different address.
+func (s *KeySigner) currentKey(ctx context.Context) signingKey {
+	s.mu.Lock()
+	if s.refreshInterval == 0 || s.refreshing || time.Since(s.refreshed) < s.refreshInterval {
+		defer s.mu.Unlock()
+		return s.key
+	}
+	s.refreshing = true
+	s.mu.Unlock()
+
+	key, err := s.resolve(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.refreshing = false
+	s.refreshed = time.Now()
+	if err != nil {
+		s.logger.Warn("Failed to refresh signing key, continuing with previous key", "err", err)
+		return s.key
+	}
+	if key.addr != s.key.addr {
+		s.logger.Error("Refusing rotation of the signing key to a different address, continuing with previous key",
+			"address", s.key.addr, "new", key.addr, "ref", key.ref)
+		return s.key
+	}
+	if key.ref != s.key.ref {
+		s.logger.Info("Signing key rotated", "ref", key.ref)
+	}
+	s.key = key
+	return key
+}
+
+func (s *KeySigner) resolve(ctx context.Context) (signingKey, error) {
+	ref, pub, err := s.backend.ResolveKey(ctx)
+	if err != nil {
+		return signingKey{}, fmt.Errorf("failed to resolve signing key: %w", err)
+	}
+	return signingKey{ref: ref, pub: pub, addr: crypto.PubkeyToAddress(*pub)}, nil
+}
+
+// recoverableSignature converts an ASN.1 DER encoded signature to the [R || S || V] format used by Ethereum,
+// normalizing S to the lower half of the curve order and deriving V from the public key of the signer.
+func recoverableSignature(digest []byte, der []byte, pub *ecdsa.PublicKey) ([]byte, error) {
+	var parsed struct {
+		R, S *big.Int
+	}
+	if rest, err := asn1.Unmarshal(der, &parsed); err != nil {
+		return nil, fmt.Errorf("failed to decode signature: %w", err)
+	} else if len(rest) != 0 {
+		return nil, errors.New("trailing data after signature")
+	}
+	if parsed.S.Cmp(secp256k1HalfN) > 0 {
+		parsed.S = new(big.Int).Sub(secp256k1N, parsed.S)
+	}
+	sig := make([]byte, crypto.SignatureLength)
+	parsed.R.FillBytes(sig[0:32])
+	parsed.S.FillBytes(sig[32:64])
+	expected := crypto.FromECDSAPub(pub)
+	for v := byte(0); v < 2; v++ {
+		sig[crypto.RecoveryIDOffset] = v
+		recovered, err := crypto.Ecrecover(digest, sig)
+		if err == nil && string(recovered) == string(expected) {
+			return sig, nil
+		}
+	}
+	return nil, errors.New("signature does not match the public key of the signing key")
+}
+
+// parsePublicKey decodes an ASN.1 DER encoded SubjectPublicKeyInfo of a secp256k1 key.
+// The x509 package does not support the secp256k1 curve, so the structure is decoded directly.
+func parsePublicKey(der []byte) (*ecdsa.PublicKey, error) {
+	var info struct {
+		Algorithm struct {
+			Algorithm  asn1.ObjectIdentifier
+			Parameters asn1.ObjectIdentifier
+		}
+		PublicKey asn1.BitString
+	}
+	if rest, err := asn1.Unmarshal(der, &info); err != nil {
+		return nil, fmt.Errorf("failed to decode public key: %w", err)
+	} else if len(rest) != 0 {
+		return nil, errors.New("trailing data after public key")
+	}
+	if !info.Algorithm.Algorithm.Equal(oidPublicKeyECDSA) || !info.Algorithm.Parameters.Equal(oidSecp256k1) {
+		return nil, fmt.Errorf("public key is not a secp256k1 key: algorithm %v, curve %v", info.Algorithm.Algorithm, info.Algorithm.Parameters)
+	}
+	return crypto.UnmarshalPubkey(info.PublicKey.Bytes)
+}
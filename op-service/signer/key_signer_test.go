@@ -0,0 +1,244 @@
+package signer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func encodePublicKey(t *testing.T, key *ecdsa.PrivateKey) []byte {
+	pub := crypto.FromECDSAPub(&key.PublicKey)
+	der, err := asn1.Marshal(struct {
+		Algorithm struct {
+			Algorithm  asn1.ObjectIdentifier
+			Parameters asn1.ObjectIdentifier
+		}
+		PublicKey asn1.BitString
+	}{
+		Algorithm: struct {
+			Algorithm  asn1.ObjectIdentifier
+			Parameters asn1.ObjectIdentifier
+		}{oidPublicKeyECDSA, oidSecp256k1},
+		PublicKey: asn1.BitString{Bytes: pub, BitLength: 8 * len(pub)},
+	})
+	require.NoError(t, err)
+	return der
+}
+
+// signDER signs the digest and returns an ASN.1 DER encoded signature.
+// If highS is set, the S value is moved to the upper half of the curve order, as KMS and Vault signatures may be.
+func signDER(t *testing.T, key *ecdsa.PrivateKey, digest []byte, highS bool) []byte {
+	sig, err := crypto.Sign(digest, key)
+	require.NoError(t, err)
+	r := new(big.Int).SetBytes(sig[:32])
+	s := new(big.Int).SetBytes(sig[32:64])
+	if highS {
+		s = new(big.Int).Sub(secp256k1N, s)
+	}
+	der, err := asn1.Marshal(struct{ R, S *big.Int }{r, s})
+	require.NoError(t, err)
+	return der
+}
+
+func testTx(t *testing.T, signer *KeySigner, chainID *big.Int) {
+	tx := types.NewTx(&types.DynamicFeeTx{ChainID: chainID, Nonce: 3, Gas: 21000, To: &common.Address{0xaa}})
+	signed, err := signer.SignTransaction(context.Background(), chainID, signer.Address(), tx)
+	require.NoError(t, err)
+	sender, err := types.LatestSignerForChainID(chainID).Sender(signed)
+	require.NoError(t, err)
+	require.Equal(t, signer.Address(), sender)
+}
+
+func TestKMSBackend(t *testing.T) {
+	keys := map[string]*ecdsa.PrivateKey{}
+	for _, id := range []string{"key-1", "key-2"} {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		keys[id] = key
+	}
+	alias := "key-1"
+	var signCount int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/"))
+		var req map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		switch r.Header.Get("X-Amz-Target") {
+		case "TrentService.GetPublicKey":
+			require.Equal(t, "alias/batcher", req["KeyId"])
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"KeyId":     alias,
+				"KeySpec":   "ECC_SECG_P256K1",
+				"PublicKey": encodePublicKey(t, keys[alias]),
+			})
+		case "TrentService.Sign":
+			require.Equal(t, "DIGEST", req["MessageType"])
+			digest, err := base64.StdEncoding.DecodeString(req["Message"].(string))
+			require.NoError(t, err)
+			signCount++
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"Signature": signDER(t, keys[req["KeyId"].(string)], digest, signCount%2 == 0),
+			})
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client := kms.New(kms.Options{
+		Region:       "us-east-1",
+		BaseEndpoint: aws.String(server.URL),
+		Credentials:  credentials.NewStaticCredentialsProvider("AKID", "secret", ""),
+		HTTPClient:   server.Client(),
+	})
+	backend := NewKMSBackend("alias/batcher", client)
+	signer, err := NewKeySigner(context.Background(), testlog.Logger(t, log.LevelInfo), backend, time.Nanosecond)
+	require.NoError(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(keys["key-1"].PublicKey), signer.Address())
+	testTx(t, signer, big.NewInt(10))
+	testTx(t, signer, big.NewInt(10))
+
+	// Pointing the alias at a key with a different address is refused, and the previous key is still used
+	alias = "key-2"
+	testTx(t, signer, big.NewInt(10))
+	require.Equal(t, crypto.PubkeyToAddress(keys["key-1"].PublicKey), signer.Address())
+}
+
+// blockingKeyBackend is a KeyBackend that can block key resolution.
+type blockingKeyBackend struct {
+	key     *ecdsa.PrivateKey
+	ref     string
+	block   chan struct{}
+	started chan struct{}
+}
+
+func (b *blockingKeyBackend) ResolveKey(ctx context.Context) (string, *ecdsa.PublicKey, error) {
+	if b.block != nil {
+		close(b.started)
+		<-b.block
+	}
+	return b.ref, &b.key.PublicKey, nil
+}
+
+func (b *blockingKeyBackend) SignDigest(ctx context.Context, ref string, digest []byte) ([]byte, error) {
+	sig, err := crypto.Sign(digest, b.key)
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(struct{ R, S *big.Int }{new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:64])})
+}
+
+func TestKeySignerRefreshNotLocked(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	backend := &blockingKeyBackend{key: key, ref: "v1"}
+	signer, err := NewKeySigner(context.Background(), testlog.Logger(t, log.LevelInfo), backend, time.Nanosecond)
+	require.NoError(t, err)
+
+	backend.ref = "v2"
+	backend.block = make(chan struct{})
+	backend.started = make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		testTx(t, signer, big.NewInt(10))
+	}()
+	<-backend.started
+	// Signing continues with the previous key while the key is being refreshed
+	testTx(t, signer, big.NewInt(10))
+	require.Equal(t, "v1", signer.currentKey(context.Background()).ref)
+	close(backend.block)
+	<-done
+	signer.mu.Lock()
+	defer signer.mu.Unlock()
+	require.Equal(t, "v2", signer.key.ref)
+}
+
+func TestVaultBackend(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("token-1\n"), 0o600))
+	expectedToken := "token-1"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != expectedToken {
+			w.WriteHeader(http.StatusForbidden)
+			_ = json.NewEncoder(w).Encode(map[string]any{"errors": []string{"permission denied"}})
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/transit/keys/batcher":
+			pemKey := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: encodePublicKey(t, key)})
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{
+				"latest_version": 2,
+				"keys":           map[string]any{"2": map[string]any{"public_key": string(pemKey)}},
+			}})
+		case "/v1/transit/sign/batcher":
+			var req struct {
+				Input      string `json:"input"`
+				Prehashed  bool   `json:"prehashed"`
+				KeyVersion int    `json:"key_version"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.True(t, req.Prehashed)
+			require.Equal(t, 2, req.KeyVersion)
+			digest, err := base64.StdEncoding.DecodeString(req.Input)
+			require.NoError(t, err)
+			sig := base64.StdEncoding.EncodeToString(signDER(t, key, digest, true))
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"signature": fmt.Sprintf("vault:v%d:%s", req.KeyVersion, sig)}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	backend := NewVaultBackend(VaultConfig{Addr: server.URL, Mount: "transit", Key: "batcher", TokenFile: tokenFile}, server.Client())
+	signer, err := NewKeySigner(context.Background(), testlog.Logger(t, log.LevelInfo), backend, time.Nanosecond)
+	require.NoError(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(key.PublicKey), signer.Address())
+	testTx(t, signer, big.NewInt(900))
+
+	// A renewed token is picked up from the token file
+	expectedToken = "token-2"
+	require.NoError(t, os.WriteFile(tokenFile, []byte("token-2\n"), 0o600))
+	testTx(t, signer, big.NewInt(900))
+}
+
+func TestParsePublicKeyRejectsOtherCurves(t *testing.T) {
+	der, err := asn1.Marshal(struct {
+		Algorithm struct {
+			Algorithm  asn1.ObjectIdentifier
+			Parameters asn1.ObjectIdentifier
+		}
+		PublicKey asn1.BitString
+	}{
+		Algorithm: struct {
+			Algorithm  asn1.ObjectIdentifier
+			Parameters asn1.ObjectIdentifier
+		}{oidPublicKeyECDSA, asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7}},
+	})
+	require.NoError(t, err)
+	_, err = parsePublicKey(der)
+	require.ErrorContains(t, err, "not a secp256k1 key")
+}
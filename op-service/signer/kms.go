@@ -0,0 +1,80 @@
+package signer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/aws/aws-sdk-go-v2/service/kms/types"
+)
+
+// KMSClient is the subset of the AWS KMS client used by the KMSBackend.
+type KMSClient interface {
+	GetPublicKey(ctx context.Context, params *kms.GetPublicKeyInput, optFns ...func(*kms.Options)) (*kms.GetPublicKeyOutput, error)
+	Sign(ctx context.Context, params *kms.SignInput, optFns ...func(*kms.Options)) (*kms.SignOutput, error)
+}
+
+// KMSBackend signs with a secp256k1 (ECC_SECG_P256K1) key in AWS KMS.
+// The key ID may be an alias, which is re-resolved on every key refresh, so the alias can be pointed at a new key
+// to rotate it.
+type KMSBackend struct {
+	keyID  string
+	client KMSClient
+}
+
+var _ KeyBackend = (*KMSBackend)(nil)
+
+// NewKMSBackendFromConfig creates a KMSBackend with a KMS client that uses the default AWS credential chain:
+// environment variables, the shared config and credentials files, web identity tokens (IRSA),
+// and the ECS and EC2 instance metadata endpoints. Credentials are refreshed by the client as they expire.
+func NewKMSBackendFromConfig(ctx context.Context, cfg KMSConfig) (*KMSBackend, error) {
+	awsCfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(cfg.Region))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	client := kms.NewFromConfig(awsCfg, func(o *kms.Options) {
+		if cfg.Endpoint != "" {
+			o.BaseEndpoint = aws.String(cfg.Endpoint)
+		}
+	})
+	return NewKMSBackend(cfg.KeyID, client), nil
+}
+
+func NewKMSBackend(keyID string, client KMSClient) *KMSBackend {
+	return &KMSBackend{
+		keyID:  keyID,
+		client: client,
+	}
+}
+
+func (k *KMSBackend) ResolveKey(ctx context.Context) (string, *ecdsa.PublicKey, error) {
+	resp, err := k.client.GetPublicKey(ctx, &kms.GetPublicKeyInput{KeyId: aws.String(k.keyID)})
+	if err != nil {
+		return "", nil, fmt.Errorf("KMS GetPublicKey request failed: %w", err)
+	}
+	keyID := aws.ToString(resp.KeyId)
+	if resp.KeySpec != types.KeySpecEccSecgP256k1 {
+		return "", nil, fmt.Errorf("KMS key %s has unsupported key spec %q, expected %s", keyID, resp.KeySpec, types.KeySpecEccSecgP256k1)
+	}
+	pub, err := parsePublicKey(resp.PublicKey)
+	if err != nil {
+		return "", nil, err
+	}
+	return keyID, pub, nil
+}
+
+func (k *KMSBackend) SignDigest(ctx context.Context, ref string, digest []byte) ([]byte, error) {
+	resp, err := k.client.Sign(ctx, &kms.SignInput{
+		KeyId:            aws.String(ref),
+		Message:          digest,
+		MessageType:      types.MessageTypeDigest,
+		SigningAlgorithm: types.SigningAlgorithmSpecEcdsaSha256,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("KMS Sign request failed: %w", err)
+	}
+	return resp.Signature, nil
+}
@@ -0,0 +1,167 @@
+package signer
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// VaultBackend signs with a secp256k1 key in a HashiCorp Vault transit secrets engine.
+// The transit engine must support secp256k1 keys. The latest version of the key is re-resolved on every key refresh,
+// so the key can be rotated in Vault. The token is re-read from the token file on every refresh,
+// so it can be renewed externally, e.g. by a Vault agent.
+type VaultBackend struct {
+	addr      string
+	mount     string
+	key       string
+	tokenFile string
+	client    *http.Client
+
+	mu    sync.Mutex
+	token string
+}
+
+var _ KeyBackend = (*VaultBackend)(nil)
+
+func NewVaultBackend(cfg VaultConfig, client *http.Client) *VaultBackend {
+	return &VaultBackend{
+		addr:      strings.TrimSuffix(cfg.Addr, "/"),
+		mount:     strings.Trim(cfg.Mount, "/"),
+		key:       cfg.Key,
+		tokenFile: cfg.TokenFile,
+		client:    client,
+	}
+}
+
+func (v *VaultBackend) loadToken() (string, error) {
+	if v.tokenFile == "" {
+		token := os.Getenv("VAULT_TOKEN")
+		if token == "" {
+			return "", errors.New("no Vault token file configured and VAULT_TOKEN is not set")
+		}
+		return token, nil
+	}
+	data, err := os.ReadFile(v.tokenFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read Vault token file: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+func (v *VaultBackend) ResolveKey(ctx context.Context) (string, *ecdsa.PublicKey, error) {
+	token, err := v.loadToken()
+	if err != nil {
+		return "", nil, err
+	}
+	v.mu.Lock()
+	v.token = token
+	v.mu.Unlock()
+
+	var resp struct {
+		Data struct {
+			Type          string `json:"type"`
+			LatestVersion int    `json:"latest_version"`
+			Keys          map[string]struct {
+				PublicKey string `json:"public_key"`
+			} `json:"keys"`
+		} `json:"data"`
+	}
+	if err := v.call(ctx, http.MethodGet, "keys/"+v.key, nil, &resp); err != nil {
+		return "", nil, err
+	}
+	version := strconv.Itoa(resp.Data.LatestVersion)
+	key, ok := resp.Data.Keys[version]
+	if !ok {
+		return "", nil, fmt.Errorf("vault key %s has no public key for latest version %s", v.key, version)
+	}
+	block, _ := pem.Decode([]byte(key.PublicKey))
+	if block == nil {
+		return "", nil, fmt.Errorf("vault key %s version %s has no PEM encoded public key", v.key, version)
+	}
+	pub, err := parsePublicKey(block.Bytes)
+	if err != nil {
+		return "", nil, err
+	}
+	return version, pub, nil
+}
+
+func (v *VaultBackend) SignDigest(ctx context.Context, ref string, digest []byte) ([]byte, error) {
+	version, err := strconv.Atoi(ref)
+	if err != nil {
+		return nil, fmt.Errorf("invalid vault key version %q: %w", ref, err)
+	}
+	req := map[string]any{
+		"input":                base64.StdEncoding.EncodeToString(digest),
+		"prehashed":            true,
+		"key_version":          version,
+		"marshaling_algorithm": "asn1",
+	}
+	var resp struct {
+		Data struct {
+			Signature string `json:"signature"`
+		} `json:"data"`
+	}
+	if err := v.call(ctx, http.MethodPost, "sign/"+v.key, req, &resp); err != nil {
+		return nil, err
+	}
+	// Signatures are prefixed with the key version: vault:v<version>:<base64 signature>
+	parts := strings.Split(resp.Data.Signature, ":")
+	if len(parts) != 3 || parts[0] != "vault" {
+		return nil, fmt.Errorf("unexpected vault signature format: %q", resp.Data.Signature)
+	}
+	return base64.StdEncoding.DecodeString(parts[2])
+}
+
+func (v *VaultBackend) call(ctx context.Context, method string, path string, request any, result any) error {
+	var body io.Reader
+	if request != nil {
+		data, err := json.Marshal(request)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	url := fmt.Sprintf("%s/v1/%s/%s", v.addr, v.mount, path)
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return fmt.Errorf("failed to construct vault request: %w", err)
+	}
+	v.mu.Lock()
+	req.Header.Set("X-Vault-Token", v.token)
+	v.mu.Unlock()
+	if request != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("vault request to %s failed: %w", path, err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read vault response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Errors []string `json:"errors"`
+		}
+		_ = json.Unmarshal(respBody, &apiErr)
+		return fmt.Errorf("vault request to %s failed with status %d: %s", path, resp.StatusCode, strings.Join(apiErr.Errors, "; "))
+	}
+	if err := json.Unmarshal(respBody, result); err != nil {
+		return fmt.Errorf("failed to decode vault response: %w", err)
+	}
+	return nil
+}
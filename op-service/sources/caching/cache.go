@@ -41,3 +41,31 @@ func NewLRUCache[K comparable, V any](m Metrics, label string, maxSize int) *LRU
 		inner: cache,
 	}
 }
+
+// NewLRUCacheWithEvict creates a LRU cache like NewLRUCache,
+// but calls onEvict for every entry that is evicted or removed from the cache.
+func NewLRUCacheWithEvict[K comparable, V any](m Metrics, label string, maxSize int, onEvict func(key K, value V)) *LRUCache[K, V] {
+	// no errors if the size is positive
+	cache, _ := lru.NewWithEvict[K, V](maxSize, onEvict)
+	return &LRUCache[K, V]{
+		m:     m,
+		label: label,
+		inner: cache,
+	}
+}
+
+// Peek returns the value of the key, without updating its recency or tracking cache metrics.
+func (c *LRUCache[K, V]) Peek(key K) (value V, ok bool) {
+	return c.inner.Peek(key)
+}
+
+// RemoveOldest removes the least recently used entry from the cache, and returns whether any entry was removed.
+func (c *LRUCache[K, V]) RemoveOldest() (removed bool) {
+	_, _, removed = c.inner.RemoveOldest()
+	return removed
+}
+
+// Len returns the number of entries in the cache.
+func (c *LRUCache[K, V]) Len() int {
+	return c.inner.Len()
+}
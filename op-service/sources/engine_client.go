@@ -10,10 +10,13 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rpc"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/optracing"
 	"github.com/ethereum-optimism/optimism/op-service/sources/caching"
 )
 
@@ -28,6 +31,8 @@ func EngineClientDefaultConfig(config *rollup.Config) *EngineClientConfig {
 	}
 }
 
+var engineTracer = optracing.Tracer("op-service/sources/engine")
+
 // EngineClient extends L2Client with engine API bindings.
 type EngineClient struct {
 	*L2Client
@@ -80,7 +85,13 @@ func (s *EngineAPIClient) EngineVersionProvider() EngineVersionProvider { return
 // 1. Processing error: ForkchoiceUpdatedResult.PayloadStatusV1.ValidationError or other non-success PayloadStatusV1,
 // 2. `error` as eth.InputError: the forkchoice state or attributes are not valid.
 // 3. Other types of `error`: temporary RPC errors, like timeouts.
-func (s *EngineAPIClient) ForkchoiceUpdate(ctx context.Context, fc *eth.ForkchoiceState, attributes *eth.PayloadAttributes) (*eth.ForkchoiceUpdatedResult, error) {
+func (s *EngineAPIClient) ForkchoiceUpdate(ctx context.Context, fc *eth.ForkchoiceState, attributes *eth.PayloadAttributes) (_ *eth.ForkchoiceUpdatedResult, outErr error) {
+	ctx, span := engineTracer.Start(ctx, "engine.ForkchoiceUpdate", trace.WithAttributes(
+		attribute.String("head", fc.HeadBlockHash.String()),
+		attribute.String("safe", fc.SafeBlockHash.String()),
+		attribute.String("finalized", fc.FinalizedBlockHash.String()),
+		attribute.Bool("build", attributes != nil)))
+	defer func() { optracing.End(span, outErr) }()
 	llog := s.log.New("state", fc)       // local logger
 	tlog := llog.New("attr", attributes) // trace logger
 	tlog.Trace("Sharing forkchoice-updated signal")
@@ -90,6 +101,7 @@ func (s *EngineAPIClient) ForkchoiceUpdate(ctx context.Context, fc *eth.Forkchoi
 	method := s.evp.ForkchoiceUpdatedVersion(attributes)
 	err := s.RPC.CallContext(fcCtx, &result, string(method), fc, attributes)
 	if err == nil {
+		span.SetAttributes(attribute.String("status", string(result.PayloadStatus.Status)))
 		tlog.Trace("Shared forkchoice-updated signal")
 		if attributes != nil { // block building is optional, we only get a payload ID if we are building a block
 			tlog.Trace("Received payload id", "payloadId", result.PayloadID)
@@ -116,7 +128,12 @@ func (s *EngineAPIClient) ForkchoiceUpdate(ctx context.Context, fc *eth.Forkchoi
 // NewPayload executes a full block on the execution engine.
 // This returns a PayloadStatusV1 which encodes any validation/processing error,
 // and this type of error is kept separate from the returned `error` used for RPC errors, like timeouts.
-func (s *EngineAPIClient) NewPayload(ctx context.Context, payload *eth.ExecutionPayload, parentBeaconBlockRoot *common.Hash) (*eth.PayloadStatusV1, error) {
+func (s *EngineAPIClient) NewPayload(ctx context.Context, payload *eth.ExecutionPayload, parentBeaconBlockRoot *common.Hash) (_ *eth.PayloadStatusV1, outErr error) {
+	ctx, span := engineTracer.Start(ctx, "engine.NewPayload", trace.WithAttributes(
+		attribute.Int64("number", int64(payload.BlockNumber)),
+		attribute.String("hash", payload.BlockHash.String()),
+		attribute.Int("txs", len(payload.Transactions))))
+	defer func() { optracing.End(span, outErr) }()
 	e := s.log.New("block_hash", payload.BlockHash)
 	e.Trace("sending payload for execution")
 
@@ -139,6 +156,7 @@ func (s *EngineAPIClient) NewPayload(ctx context.Context, payload *eth.Execution
 		e.Error("Payload execution failed", "err", err)
 		return nil, fmt.Errorf("failed to execute payload: %w", err)
 	}
+	span.SetAttributes(attribute.String("status", string(result.Status)))
 	return &result, nil
 }
 
@@ -146,7 +164,10 @@ func (s *EngineAPIClient) NewPayload(ctx context.Context, payload *eth.Execution
 // There may be two types of error:
 // 1. `error` as eth.InputError: the payload ID may be unknown
 // 2. Other types of `error`: temporary RPC errors, like timeouts.
-func (s *EngineAPIClient) GetPayload(ctx context.Context, payloadInfo eth.PayloadInfo) (*eth.ExecutionPayloadEnvelope, error) {
+func (s *EngineAPIClient) GetPayload(ctx context.Context, payloadInfo eth.PayloadInfo) (_ *eth.ExecutionPayloadEnvelope, outErr error) {
+	ctx, span := engineTracer.Start(ctx, "engine.GetPayload", trace.WithAttributes(
+		attribute.String("payload_id", payloadInfo.ID.String())))
+	defer func() { optracing.End(span, outErr) }()
 	e := s.log.New("payload_id", payloadInfo.ID)
 	e.Trace("getting payload")
 	var result eth.ExecutionPayloadEnvelope
@@ -169,6 +190,11 @@ func (s *EngineAPIClient) GetPayload(ctx context.Context, payloadInfo eth.Payloa
 		return nil, err
 	}
 	e.Trace("Received payload")
+	if result.ExecutionPayload != nil {
+		span.SetAttributes(
+			attribute.Int64("number", int64(result.ExecutionPayload.BlockNumber)),
+			attribute.String("hash", result.ExecutionPayload.BlockHash.String()))
+	}
 	return &result, nil
 }
 
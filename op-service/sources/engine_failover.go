@@ -0,0 +1,235 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// DefaultEngineFailoverThreshold is the default number of consecutive failed requests
+// after which an engine fails over to its standby.
+const DefaultEngineFailoverThreshold = 5
+
+// engineVerifyTimeout is the time the standby engine has to prove it is consistent with the active engine.
+const engineVerifyTimeout = 10 * time.Second
+
+// EngineFailoverRPC is an RPC to an execution engine with a standby engine.
+// Requests are served by the active engine. After threshold consecutive failures of the active engine,
+// the standby engine becomes active, if it is consistent with the chain of the active engine:
+// it must have the last finalized and safe blocks that were read from the active engine.
+// The engines switch roles, so a failing standby engine fails back to the primary engine the same way.
+// The forkchoice state of the caller is of the previous engine, so the caller must resync it with the new engine
+// after a failover, see OnFailover.
+// Failed requests are not retried on the standby engine: the caller retries engine requests anyway,
+// and the standby engine must not receive requests before it is verified.
+type EngineFailoverRPC struct {
+	log       log.Logger
+	threshold int
+
+	mu        sync.Mutex
+	engines   [2]client.PoolEndpoint
+	active    int
+	failures  int
+	verifying bool
+	// heads are the last finalized and safe blocks that were read from the active engine
+	heads      map[eth.BlockLabel]eth.BlockID
+	onFailover func()
+}
+
+var _ client.RPC = (*EngineFailoverRPC)(nil)
+
+func NewEngineFailoverRPC(log log.Logger, primary, standby client.RPC, threshold int) *EngineFailoverRPC {
+	return &EngineFailoverRPC{
+		log:       log,
+		threshold: threshold,
+		engines: [2]client.PoolEndpoint{
+			{Name: "primary", RPC: primary},
+			{Name: "standby", RPC: standby},
+		},
+		heads: make(map[eth.BlockLabel]eth.BlockID),
+	}
+}
+
+// OnFailover sets the function that is called after the engines switched roles, which must not block.
+// The new engine may have different unsafe and safe heads, and has not received a forkchoice update yet,
+// so the caller should reset its forkchoice state from the heads of the new engine before using it.
+func (f *EngineFailoverRPC) OnFailover(fn func()) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.onFailover = fn
+}
+
+// Active returns the name of the active engine.
+func (f *EngineFailoverRPC) Active() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.engines[f.active].Name
+}
+
+func (f *EngineFailoverRPC) Close() {
+	for _, e := range f.engines {
+		e.RPC.Close()
+	}
+}
+
+func (f *EngineFailoverRPC) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	label, ok := trackedHeadCall(method, args)
+	if !ok {
+		return f.do(ctx, func(e client.RPC) error {
+			return e.CallContext(ctx, result, method, args...)
+		})
+	}
+	// Keep track of the finalized and safe blocks, to verify the standby engine against.
+	var raw json.RawMessage
+	err := f.do(ctx, func(e client.RPC) error {
+		return e.CallContext(ctx, &raw, method, args...)
+	})
+	if err != nil {
+		return err
+	}
+	var block struct {
+		Hash   common.Hash    `json:"hash"`
+		Number hexutil.Uint64 `json:"number"`
+	}
+	if err := json.Unmarshal(raw, &block); err == nil && block.Hash != (common.Hash{}) {
+		f.mu.Lock()
+		f.heads[label] = eth.BlockID{Hash: block.Hash, Number: uint64(block.Number)}
+		f.mu.Unlock()
+	}
+	return json.Unmarshal(raw, result)
+}
+
+func (f *EngineFailoverRPC) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	return f.do(ctx, func(e client.RPC) error {
+		return e.BatchCallContext(ctx, b)
+	})
+}
+
+func (f *EngineFailoverRPC) EthSubscribe(ctx context.Context, channel any, args ...any) (ethereum.Subscription, error) {
+	var sub ethereum.Subscription
+	err := f.do(ctx, func(e client.RPC) error {
+		var err error
+		sub, err = e.EthSubscribe(ctx, channel, args...)
+		return err
+	})
+	return sub, err
+}
+
+func (f *EngineFailoverRPC) do(ctx context.Context, fn func(e client.RPC) error) error {
+	f.mu.Lock()
+	i := f.active
+	e := f.engines[i].RPC
+	f.mu.Unlock()
+
+	err := fn(e)
+	switch {
+	case errors.Is(ctx.Err(), context.Canceled):
+		// The caller gave up on the request, so it says nothing about the health of the engine.
+	case err != nil && (errors.Is(ctx.Err(), context.DeadlineExceeded) || client.IsEndpointFailure(ctx, err)):
+		f.onFailure(i, err)
+	default:
+		f.onSuccess(i)
+	}
+	return err
+}
+
+func (f *EngineFailoverRPC) onSuccess(i int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.active == i {
+		f.failures = 0
+	}
+}
+
+func (f *EngineFailoverRPC) onFailure(i int, err error) {
+	f.mu.Lock()
+	if f.active != i || f.verifying {
+		// The engines switched roles already, or are about to.
+		f.mu.Unlock()
+		return
+	}
+	f.failures++
+	if f.failures < f.threshold {
+		f.mu.Unlock()
+		return
+	}
+	f.verifying = true
+	active, standby := f.engines[i], f.engines[1-i]
+	heads := maps.Clone(f.heads)
+	f.mu.Unlock()
+
+	f.log.Warn("Engine failed persistently, verifying standby engine", "active", active.Name, "standby", standby.Name, "failures", f.threshold, "err", err)
+	verifyErr := verifyStandbyEngine(standby.RPC, heads)
+
+	f.mu.Lock()
+	f.verifying = false
+	f.failures = 0
+	if verifyErr != nil {
+		f.mu.Unlock()
+		f.log.Error("Not failing over to standby engine", "active", active.Name, "standby", standby.Name, "err", verifyErr)
+		return
+	}
+	f.active = 1 - i
+	onFailover := f.onFailover
+	f.mu.Unlock()
+	f.log.Warn("Failed over to standby engine", "from", active.Name, "to", standby.Name,
+		"finalized", heads[eth.Finalized], "safe", heads[eth.Safe])
+	if onFailover != nil {
+		onFailover()
+	}
+}
+
+// verifyStandbyEngine checks that the standby engine is available and has the given finalized and safe blocks, if any.
+func verifyStandbyEngine(standby client.RPC, heads map[eth.BlockLabel]eth.BlockID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), engineVerifyTimeout)
+	defer cancel()
+	if len(heads) == 0 {
+		var chainID hexutil.Big
+		if err := standby.CallContext(ctx, &chainID, "eth_chainId"); err != nil {
+			return fmt.Errorf("standby engine is unavailable: %w", err)
+		}
+		return nil
+	}
+	for _, label := range []eth.BlockLabel{eth.Finalized, eth.Safe} {
+		id, ok := heads[label]
+		if !ok {
+			continue
+		}
+		var header *RPCHeader
+		if err := standby.CallContext(ctx, &header, "eth_getBlockByNumber", hexutil.EncodeUint64(id.Number), false); err != nil {
+			return fmt.Errorf("failed to get %s block %d from standby engine: %w", label, id.Number, err)
+		}
+		if header == nil {
+			return fmt.Errorf("standby engine does not have %s block %s", label, id)
+		}
+		if header.Hash != id.Hash {
+			return fmt.Errorf("standby engine has block %s at the height of %s block %s", header.Hash, label, id)
+		}
+	}
+	return nil
+}
+
+// trackedHeadCall returns the label of the block that is requested, if it is a finalized or safe block request.
+func trackedHeadCall(method string, args []any) (eth.BlockLabel, bool) {
+	if method != "eth_getBlockByNumber" || len(args) == 0 {
+		return "", false
+	}
+	label, ok := args[0].(string)
+	if !ok || (label != string(eth.Finalized) && label != string(eth.Safe)) {
+		return "", false
+	}
+	return eth.BlockLabel(label), true
+}
@@ -0,0 +1,177 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type engineTestRPC struct {
+	calls     int
+	err       error
+	finalized uint64
+	safe      uint64
+	blocks    map[uint64]common.Hash
+}
+
+func (m *engineTestRPC) Close() {}
+
+func (m *engineTestRPC) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	m.calls++
+	if m.err != nil {
+		return m.err
+	}
+	if result == nil {
+		return nil
+	}
+	var res any
+	switch method {
+	case "eth_chainId":
+		res = "0x1"
+	case "eth_getBlockByNumber":
+		var num uint64
+		switch args[0] {
+		case "finalized":
+			num = m.finalized
+		case "safe":
+			num = m.safe
+		default:
+			n, err := hexutil.DecodeUint64(args[0].(string))
+			if err != nil {
+				return err
+			}
+			num = n
+		}
+		if hash, ok := m.blocks[num]; ok {
+			res = map[string]any{"hash": hash, "number": hexutil.Uint64(num)}
+		}
+	}
+	data, err := json.Marshal(res)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, result)
+}
+
+func (m *engineTestRPC) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	m.calls++
+	return m.err
+}
+
+func (m *engineTestRPC) EthSubscribe(ctx context.Context, channel any, args ...any) (ethereum.Subscription, error) {
+	m.calls++
+	return nil, m.err
+}
+
+type engineTestRPCError struct {
+	code int
+}
+
+func (e *engineTestRPCError) Error() string  { return "rpc error" }
+func (e *engineTestRPCError) ErrorCode() int { return e.code }
+
+func TestEngineFailoverRPC(t *testing.T) {
+	ctx := context.Background()
+	blocks := map[uint64]common.Hash{10: {0x0a}, 11: {0x0b}}
+	primary := &engineTestRPC{finalized: 10, safe: 11, blocks: blocks}
+	standby := &engineTestRPC{blocks: blocks}
+	f := NewEngineFailoverRPC(testlog.Logger(t, log.LevelInfo), primary, standby, 3)
+	failovers := 0
+	f.OnFailover(func() { failovers++ })
+
+	var header *RPCHeader
+	require.NoError(t, f.CallContext(ctx, &header, "eth_getBlockByNumber", "finalized", false))
+	require.Equal(t, common.Hash{0x0a}, header.Hash)
+	require.NoError(t, f.CallContext(ctx, &header, "eth_getBlockByNumber", "safe", false))
+	require.Equal(t, common.Hash{0x0b}, header.Hash)
+
+	t.Run("JSON-RPC errors are not engine failures", func(t *testing.T) {
+		primary.err = &engineTestRPCError{code: -38003}
+		for i := 0; i < 5; i++ {
+			require.Error(t, f.CallContext(ctx, nil, "engine_forkchoiceUpdatedV3"))
+		}
+		require.Equal(t, "primary", f.Active())
+	})
+
+	t.Run("a success resets the failures", func(t *testing.T) {
+		primary.err = errors.New("connection refused")
+		require.Error(t, f.CallContext(ctx, nil, "engine_forkchoiceUpdatedV3"))
+		require.Error(t, f.CallContext(ctx, nil, "engine_forkchoiceUpdatedV3"))
+		primary.err = nil
+		require.NoError(t, f.CallContext(ctx, nil, "engine_forkchoiceUpdatedV3"))
+		primary.err = errors.New("connection refused")
+		require.Error(t, f.CallContext(ctx, nil, "engine_forkchoiceUpdatedV3"))
+		require.Error(t, f.CallContext(ctx, nil, "engine_forkchoiceUpdatedV3"))
+		require.Equal(t, "primary", f.Active())
+		primary.err = nil
+		require.NoError(t, f.CallContext(ctx, nil, "engine_forkchoiceUpdatedV3"))
+	})
+
+	t.Run("inconsistent standby", func(t *testing.T) {
+		standby.blocks = map[uint64]common.Hash{10: {0xff}}
+		standby.calls = 0
+		primary.err = errors.New("connection refused")
+		for i := 0; i < 3; i++ {
+			require.Error(t, f.CallContext(ctx, nil, "engine_forkchoiceUpdatedV3"))
+		}
+		require.Equal(t, "primary", f.Active(), "must not fail over to a standby engine on a different chain")
+		require.Equal(t, 1, standby.calls, "standby engine must only be verified")
+	})
+
+	t.Run("standby without safe block", func(t *testing.T) {
+		standby.blocks = map[uint64]common.Hash{10: {0x0a}}
+		standby.calls = 0
+		for i := 0; i < 3; i++ {
+			require.Error(t, f.CallContext(ctx, nil, "engine_forkchoiceUpdatedV3"))
+		}
+		require.Equal(t, "primary", f.Active(), "must not fail over to a standby engine that does not have the safe block")
+		require.Equal(t, 2, standby.calls, "standby engine must only be verified")
+		require.Zero(t, failovers)
+	})
+
+	t.Run("failover", func(t *testing.T) {
+		standby.blocks = blocks
+		standby.calls = 0
+		for i := 0; i < 3; i++ {
+			require.Error(t, f.CallContext(ctx, nil, "engine_forkchoiceUpdatedV3"))
+		}
+		require.Equal(t, "standby", f.Active())
+		require.Equal(t, 1, failovers, "the forkchoice state must be reset after a failover")
+		require.NoError(t, f.CallContext(ctx, nil, "engine_forkchoiceUpdatedV3"))
+		require.Equal(t, 3, standby.calls)
+	})
+
+	t.Run("failback", func(t *testing.T) {
+		primary.err = nil
+		standby.err = errors.New("connection refused")
+		for i := 0; i < 3; i++ {
+			require.Error(t, f.CallContext(ctx, nil, "engine_forkchoiceUpdatedV3"))
+		}
+		require.Equal(t, "primary", f.Active())
+		require.Equal(t, 2, failovers)
+	})
+}
+
+func TestEngineFailoverRPC_NoFinalizedBlock(t *testing.T) {
+	ctx := context.Background()
+	primary := &engineTestRPC{err: errors.New("connection refused")}
+	standby := &engineTestRPC{err: errors.New("connection refused")}
+	f := NewEngineFailoverRPC(testlog.Logger(t, log.LevelInfo), primary, standby, 1)
+
+	require.Error(t, f.CallContext(ctx, nil, "engine_forkchoiceUpdatedV3"))
+	require.Equal(t, "primary", f.Active(), "must not fail over to an unavailable standby engine")
+
+	standby.err = nil
+	require.Error(t, f.CallContext(ctx, nil, "engine_forkchoiceUpdatedV3"))
+	require.Equal(t, "standby", f.Active())
+}
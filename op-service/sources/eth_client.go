@@ -38,6 +38,8 @@ type EthClientConfig struct {
 
 	// Number of blocks worth of receipts to cache
 	ReceiptsCacheSize int
+	// Approximate maximum memory size in bytes of the cached receipts. 0 disables the memory bound.
+	ReceiptsCacheMaxBytes int
 	// Number of blocks worth of transactions to cache
 	TransactionsCacheSize int
 	// Number of block headers to cache
@@ -63,12 +65,20 @@ type EthClientConfig struct {
 	// till we re-attempt the user-preferred methods.
 	// If this is 0 then the client does not fall back to less optimal but available methods.
 	MethodResetDuration time.Duration
+
+	// DetectReceiptsMethods signals that the user of the client should detect the receipt fetching methods
+	// supported by the RPC at startup, with EthClient.DetectReceiptsMethods,
+	// so unsupported methods of the RPC provider kind are not tried on every method reset.
+	DetectReceiptsMethods bool
 }
 
 func (c *EthClientConfig) Check() error {
 	if c.ReceiptsCacheSize < 0 {
 		return fmt.Errorf("invalid receipts cache size: %d", c.ReceiptsCacheSize)
 	}
+	if c.ReceiptsCacheMaxBytes < 0 {
+		return fmt.Errorf("invalid receipts cache max bytes: %d", c.ReceiptsCacheMaxBytes)
+	}
 	if c.TransactionsCacheSize < 0 {
 		return fmt.Errorf("invalid transactions cache size: %d", c.TransactionsCacheSize)
 	}
@@ -106,6 +116,11 @@ type EthClient struct {
 	// common.Hash -> types.Transactions
 	transactionsCache *caching.LRUCache[common.Hash, types.Transactions]
 
+	// cache the hashes of all transactions per block hash, including transactions not in the transactionsCache,
+	// like set-code transactions, to match receipts against.
+	// common.Hash -> []common.Hash
+	txHashesCache *caching.LRUCache[common.Hash, []common.Hash]
+
 	// cache block headers of blocks by hash
 	// common.Hash -> *HeaderInfo
 	headersCache *caching.LRUCache[common.Hash, eth.BlockInfo]
@@ -134,6 +149,7 @@ func NewEthClient(client client.RPC, log log.Logger, metrics caching.Metrics, co
 		mustBePostMerge:   config.MustBePostMerge,
 		log:               log,
 		transactionsCache: caching.NewLRUCache[common.Hash, types.Transactions](metrics, "txs", config.TransactionsCacheSize),
+		txHashesCache:     caching.NewLRUCache[common.Hash, []common.Hash](metrics, "tx_hashes", config.TransactionsCacheSize),
 		headersCache:      caching.NewLRUCache[common.Hash, eth.BlockInfo](metrics, "headers", config.HeadersCacheSize),
 		payloadsCache:     caching.NewLRUCache[common.Hash, *eth.ExecutionPayloadEnvelope](metrics, "payloads", config.PayloadsCacheSize),
 	}, nil
@@ -197,24 +213,36 @@ func (s *EthClient) headerCall(ctx context.Context, method string, id rpcBlockID
 }
 
 func (s *EthClient) blockCall(ctx context.Context, method string, id rpcBlockID) (eth.BlockInfo, types.Transactions, error) {
+	info, txs, _, err := s.blockCallWithTxHashes(ctx, method, id)
+	return info, txs, err
+}
+
+// blockCallWithTxHashes is like blockCall, but also returns the hashes of all transactions in the block,
+// including those that are not supported by the go-ethereum transaction type, like set-code transactions.
+func (s *EthClient) blockCallWithTxHashes(ctx context.Context, method string, id rpcBlockID) (eth.BlockInfo, types.Transactions, []common.Hash, error) {
 	var block *RPCBlock
 	err := s.client.CallContext(ctx, &block, method, id.Arg(), true)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 	if block == nil {
-		return nil, nil, ethereum.NotFound
+		return nil, nil, nil, ethereum.NotFound
 	}
 	info, txs, err := block.Info(s.trustRPC, s.mustBePostMerge)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 	if err := id.CheckID(eth.ToBlockID(info)); err != nil {
-		return nil, nil, fmt.Errorf("fetched block data does not match requested ID: %w", err)
+		return nil, nil, nil, fmt.Errorf("fetched block data does not match requested ID: %w", err)
+	}
+	txHashes, err := block.TxHashes()
+	if err != nil {
+		return nil, nil, nil, err
 	}
 	s.headersCache.Add(info.Hash(), info)
 	s.transactionsCache.Add(info.Hash(), txs)
-	return info, txs, nil
+	s.txHashesCache.Add(info.Hash(), txHashes)
+	return info, txs, txHashes, nil
 }
 
 func (s *EthClient) payloadCall(ctx context.Context, method string, id rpcBlockID) (*eth.ExecutionPayloadEnvelope, error) {
@@ -273,6 +301,16 @@ func (s *EthClient) InfoAndTxsByHash(ctx context.Context, hash common.Hash) (eth
 	return s.blockCall(ctx, "eth_getBlockByHash", hashID(hash))
 }
 
+func (s *EthClient) infoAndTxHashesByHash(ctx context.Context, hash common.Hash) (eth.BlockInfo, []common.Hash, error) {
+	if header, ok := s.headersCache.Get(hash); ok {
+		if txHashes, ok := s.txHashesCache.Get(hash); ok {
+			return header, txHashes, nil
+		}
+	}
+	info, _, txHashes, err := s.blockCallWithTxHashes(ctx, "eth_getBlockByHash", hashID(hash))
+	return info, txHashes, err
+}
+
 func (s *EthClient) InfoAndTxsByNumber(ctx context.Context, number uint64) (eth.BlockInfo, types.Transactions, error) {
 	// can't hit the cache when querying by number due to reorgs.
 	return s.blockCall(ctx, "eth_getBlockByNumber", numberID(number))
@@ -302,12 +340,11 @@ func (s *EthClient) PayloadByLabel(ctx context.Context, label eth.BlockLabel) (*
 // It verifies the receipt hash in the block header against the receipt hash of the fetched receipts
 // to ensure that the execution engine did not fail to return any receipts.
 func (s *EthClient) FetchReceipts(ctx context.Context, blockHash common.Hash) (eth.BlockInfo, types.Receipts, error) {
-	info, txs, err := s.InfoAndTxsByHash(ctx, blockHash)
+	info, txHashes, err := s.infoAndTxHashesByHash(ctx, blockHash)
 	if err != nil {
 		return nil, nil, fmt.Errorf("querying block: %w", err)
 	}
 
-	txHashes, _ := eth.TransactionsToHashes(txs), eth.ToBlockID(info)
 	receipts, err := s.recProvider.FetchReceipts(ctx, info, txHashes)
 	if err != nil {
 		return nil, nil, err
@@ -315,6 +352,23 @@ func (s *EthClient) FetchReceipts(ctx context.Context, blockHash common.Hash) (e
 	return info, receipts, nil
 }
 
+// DetectReceiptsMethods detects the receipt fetching methods supported by the RPC, by probing them against
+// the latest block. Methods that are not supported are not used for receipt fetching anymore.
+func (s *EthClient) DetectReceiptsMethods(ctx context.Context) error {
+	p, ok := s.recProvider.(*CachingReceiptsProvider)
+	if !ok {
+		return errors.New("receipts provider does not support method detection")
+	}
+	head, err := s.InfoByLabel(ctx, eth.Unsafe)
+	if err != nil {
+		return fmt.Errorf("failed to fetch block to detect receipt methods with: %w", err)
+	}
+	if _, ok := p.DetectReceiptsMethods(ctx, head.Hash()); !ok {
+		return errors.New("receipts provider does not support method detection")
+	}
+	return nil
+}
+
 // GetProof returns an account proof result, with any optional requested storage proofs.
 // The retrieval does sanity-check that storage proofs for the expected keys are present in the response,
 // but does not verify the result. Call accountResult.Verify(stateRoot) to verify the result.
@@ -26,15 +26,38 @@ const (
 	sidecarsMethodPrefix = "eth/v1/beacon/blob_sidecars/"
 )
 
+// Names of the sources of blob sidecars, as recorded to the BeaconMetrics.
+const (
+	BeaconSourcePrimary  = "primary"
+	BeaconSourceFallback = "fallback_%d"
+	BeaconSourceArchive  = "archive"
+)
+
 type L1BeaconClientConfig struct {
 	FetchAllSidecars bool
+	// Archive is an optional blob archive, e.g. a blob-archiver service, that is only used
+	// when neither the beacon node nor any of the fallbacks can serve the blob sidecars.
+	Archive BlobSideCarsFetcher
+	// Metrics records which source served the blob sidecars. Optional.
+	Metrics BeaconMetrics
+}
+
+// BeaconMetrics records the outcome of blob sidecar requests per source.
+type BeaconMetrics interface {
+	RecordBlobSidecarsFetch(source string, hit bool)
 }
 
+type noopBeaconMetrics struct{}
+
+func (noopBeaconMetrics) RecordBlobSidecarsFetch(source string, hit bool) {}
+
 // L1BeaconClient is a high level golang client for the Beacon API.
 type L1BeaconClient struct {
-	cl   BeaconClient
-	pool *ClientPool[BlobSideCarsFetcher]
-	cfg  L1BeaconClientConfig
+	cl      BeaconClient
+	pool    *ClientPool[blobSource]
+	archive *blobSource
+	cfg     L1BeaconClientConfig
+	metrics BeaconMetrics
 
 	initLock     sync.Mutex
 	timeToSlotFn TimeToSlotFn
@@ -172,15 +195,35 @@ func (p *ClientPool[T]) MoveToNext() {
 	}
 }
 
+// blobSource is a fetcher of blob sidecars, named for metrics.
+type blobSource struct {
+	name string
+	BlobSideCarsFetcher
+}
+
 // NewL1BeaconClient returns a client for making requests to an L1 consensus layer node.
 // Fallbacks are optional clients that will be used for fetching blobs. L1BeaconClient will rotate between
 // the `cl` and the fallbacks whenever a client runs into an error while fetching blobs.
+// The archive of the config, if any, is only tried after all of these failed.
 func NewL1BeaconClient(cl BeaconClient, cfg L1BeaconClientConfig, fallbacks ...BlobSideCarsFetcher) *L1BeaconClient {
-	cs := append([]BlobSideCarsFetcher{cl}, fallbacks...)
+	cs := []blobSource{{name: BeaconSourcePrimary, BlobSideCarsFetcher: cl}}
+	for i, f := range fallbacks {
+		cs = append(cs, blobSource{name: fmt.Sprintf(BeaconSourceFallback, i), BlobSideCarsFetcher: f})
+	}
+	var archive *blobSource
+	if cfg.Archive != nil {
+		archive = &blobSource{name: BeaconSourceArchive, BlobSideCarsFetcher: cfg.Archive}
+	}
+	var m BeaconMetrics = noopBeaconMetrics{}
+	if cfg.Metrics != nil {
+		m = cfg.Metrics
+	}
 	return &L1BeaconClient{
-		cl:   cl,
-		pool: NewClientPool(cs...),
-		cfg:  cfg,
+		cl:      cl,
+		pool:    NewClientPool(cs...),
+		archive: archive,
+		cfg:     cfg,
+		metrics: m,
 	}
 }
 
@@ -223,13 +266,23 @@ func (cl *L1BeaconClient) fetchSidecars(ctx context.Context, slot uint64, hashes
 	for i := 0; i < cl.pool.Len(); i++ {
 		f := cl.pool.Get()
 		resp, err := f.BeaconBlobSideCars(ctx, cl.cfg.FetchAllSidecars, slot, hashes)
+		cl.metrics.RecordBlobSidecarsFetch(f.name, err == nil)
 		if err != nil {
 			cl.pool.MoveToNext()
-			errs = append(errs, err)
+			errs = append(errs, fmt.Errorf("%s: %w", f.name, err))
 		} else {
 			return resp, nil
 		}
 	}
+	// The archive is not part of the pool, so it never becomes the preferred source.
+	if cl.archive != nil {
+		resp, err := cl.archive.BeaconBlobSideCars(ctx, cl.cfg.FetchAllSidecars, slot, hashes)
+		cl.metrics.RecordBlobSidecarsFetch(cl.archive.name, err == nil)
+		if err == nil {
+			return resp, nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", cl.archive.name, err))
+	}
 	return eth.APIGetBlobSidecarsResponse{}, errors.Join(errs...)
 }
 
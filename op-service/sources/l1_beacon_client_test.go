@@ -183,6 +183,55 @@ func TestBeaconClientFallback(t *testing.T) {
 
 }
 
+type testBeaconMetrics map[string][]bool
+
+func (m testBeaconMetrics) RecordBlobSidecarsFetch(source string, hit bool) {
+	m[source] = append(m[source], hit)
+}
+
+func TestBeaconClientArchive(t *testing.T) {
+	index0, sidecar0 := makeTestBlobSidecar(1)
+	hashes := []eth.IndexedBlobHash{index0}
+	sidecars := []*eth.BlobSidecar{sidecar0}
+	apiSidecars := toAPISideCars(sidecars)
+
+	ctx := context.Background()
+	p := mocks.NewBeaconClient(t)
+	f := mocks.NewBlobSideCarsFetcher(t)
+	a := mocks.NewBlobSideCarsFetcher(t)
+	m := make(testBeaconMetrics)
+	c := NewL1BeaconClient(p, L1BeaconClientConfig{Archive: a, Metrics: m}, f)
+	p.EXPECT().BeaconGenesis(ctx).Return(eth.APIGenesisResponse{Data: eth.ReducedGenesisData{GenesisTime: 10}}, nil)
+	p.EXPECT().ConfigSpec(ctx).Return(eth.APIConfigResponse{Data: eth.ReducedConfigData{SecondsPerSlot: 2}}, nil)
+
+	// Timestamp 12 = Slot 1, pruned by the beacon node and the fallback
+	p.EXPECT().BeaconBlobSideCars(ctx, false, uint64(1), hashes).Return(eth.APIGetBlobSidecarsResponse{}, errors.New("404 not found")).Once()
+	f.EXPECT().BeaconBlobSideCars(ctx, false, uint64(1), hashes).Return(eth.APIGetBlobSidecarsResponse{}, errors.New("404 not found")).Once()
+	a.EXPECT().BeaconBlobSideCars(ctx, false, uint64(1), hashes).Return(eth.APIGetBlobSidecarsResponse{Data: apiSidecars}, nil).Once()
+	resp, err := c.GetBlobSidecars(ctx, eth.L1BlockRef{Time: 12}, hashes)
+	require.NoError(t, err)
+	require.Equal(t, sidecars, resp)
+
+	// Timestamp 14 = Slot 2, the archive must not become the preferred source
+	p.EXPECT().BeaconBlobSideCars(ctx, false, uint64(2), hashes).Return(eth.APIGetBlobSidecarsResponse{Data: apiSidecars}, nil).Once()
+	resp, err = c.GetBlobSidecars(ctx, eth.L1BlockRef{Time: 14}, hashes)
+	require.NoError(t, err)
+	require.Equal(t, sidecars, resp)
+
+	// Timestamp 16 = Slot 3, not available anywhere
+	p.EXPECT().BeaconBlobSideCars(ctx, false, uint64(3), hashes).Return(eth.APIGetBlobSidecarsResponse{}, errors.New("404 not found")).Once()
+	f.EXPECT().BeaconBlobSideCars(ctx, false, uint64(3), hashes).Return(eth.APIGetBlobSidecarsResponse{}, errors.New("404 not found")).Once()
+	a.EXPECT().BeaconBlobSideCars(ctx, false, uint64(3), hashes).Return(eth.APIGetBlobSidecarsResponse{}, errors.New("404 not found")).Once()
+	_, err = c.GetBlobSidecars(ctx, eth.L1BlockRef{Time: 16}, hashes)
+	require.ErrorContains(t, err, BeaconSourceArchive)
+
+	require.Equal(t, testBeaconMetrics{
+		BeaconSourcePrimary: {false, true, false},
+		"fallback_0":        {false, false},
+		BeaconSourceArchive: {true, false},
+	}, m)
+}
+
 func TestBeaconHTTPClient(t *testing.T) {
 	c := client_mocks.NewHTTP(t)
 	b := NewBeaconHTTPClient(c)
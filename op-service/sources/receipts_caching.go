@@ -3,6 +3,7 @@ package sources
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/sources/caching"
@@ -13,25 +14,65 @@ import (
 
 // A CachingReceiptsProvider caches successful receipt fetches from the inner
 // ReceiptsProvider. It also avoids duplicate in-flight requests per block hash.
+// The cache is bounded by the number of blocks, and optionally by the approximate memory size of the receipts.
 type CachingReceiptsProvider struct {
 	inner ReceiptsProvider
 	cache *caching.LRUCache[common.Hash, types.Receipts]
 
+	// maxBytes bounds the approximate memory size of the cached receipts. Zero disables the bound.
+	maxBytes int
+	// cacheBytes is the approximate memory size of the currently cached receipts.
+	cacheBytes atomic.Int64
+	// addMu serializes additions to the cache, so entries are evicted consistently when over the memory bound.
+	addMu sync.Mutex
+
 	// lock fetching process for each block hash to avoid duplicate requests
 	fetching   map[common.Hash]*sync.Mutex
 	fetchingMu sync.Mutex // only protects map
 }
 
-func NewCachingReceiptsProvider(inner ReceiptsProvider, m caching.Metrics, cacheSize int) *CachingReceiptsProvider {
-	return &CachingReceiptsProvider{
+// NewCachingReceiptsProvider creates a CachingReceiptsProvider that caches the receipts of up to cacheSize blocks.
+// If maxBytes is positive, least recently used receipts are evicted when their approximate memory size exceeds it.
+func NewCachingReceiptsProvider(inner ReceiptsProvider, m caching.Metrics, cacheSize int, maxBytes int) *CachingReceiptsProvider {
+	p := &CachingReceiptsProvider{
 		inner:    inner,
-		cache:    caching.NewLRUCache[common.Hash, types.Receipts](m, "receipts", cacheSize),
+		maxBytes: maxBytes,
 		fetching: make(map[common.Hash]*sync.Mutex),
 	}
+	p.cache = caching.NewLRUCacheWithEvict[common.Hash, types.Receipts](m, "receipts", cacheSize, p.onEvict)
+	return p
+}
+
+func NewCachingRPCReceiptsProvider(client rpcClient, log log.Logger, config RPCReceiptsConfig, m caching.Metrics, cacheSize int, maxBytes int) *CachingReceiptsProvider {
+	return NewCachingReceiptsProvider(NewRPCReceiptsFetcher(client, log, config), m, cacheSize, maxBytes)
 }
 
-func NewCachingRPCReceiptsProvider(client rpcClient, log log.Logger, config RPCReceiptsConfig, m caching.Metrics, cacheSize int) *CachingReceiptsProvider {
-	return NewCachingReceiptsProvider(NewRPCReceiptsFetcher(client, log, config), m, cacheSize)
+func (p *CachingReceiptsProvider) onEvict(_ common.Hash, r types.Receipts) {
+	p.cacheBytes.Add(-receiptsSize(r))
+}
+
+// add adds the receipts to the cache, and evicts the least recently used receipts while over the memory bound.
+// The most recently added receipts are always kept, even if they exceed the memory bound by themselves.
+func (p *CachingReceiptsProvider) add(blockHash common.Hash, r types.Receipts) {
+	p.addMu.Lock()
+	defer p.addMu.Unlock()
+	if _, ok := p.cache.Peek(blockHash); ok {
+		return
+	}
+	p.cacheBytes.Add(receiptsSize(r))
+	p.cache.Add(blockHash, r)
+	for p.maxBytes > 0 && p.cacheBytes.Load() > int64(p.maxBytes) && p.cache.Len() > 1 {
+		p.cache.RemoveOldest()
+	}
+}
+
+// receiptsSize returns the approximate memory size of the receipts.
+func receiptsSize(r types.Receipts) int64 {
+	var size int64
+	for _, rec := range r {
+		size += int64(rec.Size())
+	}
+	return size
 }
 
 func (p *CachingReceiptsProvider) getOrCreateFetchingLock(blockHash common.Hash) *sync.Mutex {
@@ -75,12 +116,24 @@ func (p *CachingReceiptsProvider) FetchReceipts(ctx context.Context, blockInfo e
 		return nil, err
 	}
 
-	p.cache.Add(block.Hash, r)
+	p.add(block.Hash, r)
 	// result now in cache, can delete fetching lock
 	p.deleteFetchingLock(block.Hash)
 	return r, nil
 }
 
+// DetectReceiptsMethods detects the receipt fetching methods supported by the inner provider, if it supports detection.
+// See RPCReceiptsFetcher.DetectReceiptsMethods.
+func (p *CachingReceiptsProvider) DetectReceiptsMethods(ctx context.Context, blockHash common.Hash) (ReceiptsFetchingMethod, bool) {
+	d, ok := p.inner.(interface {
+		DetectReceiptsMethods(ctx context.Context, blockHash common.Hash) ReceiptsFetchingMethod
+	})
+	if !ok {
+		return 0, false
+	}
+	return d.DetectReceiptsMethods(ctx, blockHash), true
+}
+
 func (p *CachingReceiptsProvider) isInnerNil() bool {
 	return p.inner == nil
 }
@@ -28,7 +28,7 @@ func TestCachingReceiptsProvider_Caching(t *testing.T) {
 	txHashes := receiptTxHashes(receipts)
 	blockid := block.BlockID()
 	mrp := new(mockReceiptsProvider)
-	rp := NewCachingReceiptsProvider(mrp, nil, 1)
+	rp := NewCachingReceiptsProvider(mrp, nil, 1, 0)
 	ctx, done := context.WithTimeout(context.Background(), 10*time.Second)
 	defer done()
 
@@ -52,7 +52,7 @@ func TestCachingReceiptsProvider_Concurrency(t *testing.T) {
 	txHashes := receiptTxHashes(receipts)
 	blockid := block.BlockID()
 	mrp := new(mockReceiptsProvider)
-	rp := NewCachingReceiptsProvider(mrp, nil, 1)
+	rp := NewCachingReceiptsProvider(mrp, nil, 1, 0)
 
 	mrp.On("FetchReceipts", mock.Anything, blockid, txHashes).
 		Return(types.Receipts(receipts), error(nil)).
@@ -62,3 +62,36 @@ func TestCachingReceiptsProvider_Concurrency(t *testing.T) {
 
 	mrp.AssertExpectations(t)
 }
+
+func TestCachingReceiptsProvider_MaxBytes(t *testing.T) {
+	rng := rand.New(rand.NewSource(69))
+	blockA, receiptsA := randomRpcBlockAndReceipts(rng, 4)
+	blockB, receiptsB := randomRpcBlockAndReceipts(rng, 4)
+	txHashesA, txHashesB := receiptTxHashes(receiptsA), receiptTxHashes(receiptsB)
+	mrp := new(mockReceiptsProvider)
+	// Enough memory for the receipts of one block only, even though there is room for more entries.
+	maxBytes := int(receiptsSize(receiptsA))
+	rp := NewCachingReceiptsProvider(mrp, nil, 10, maxBytes)
+	ctx := context.Background()
+
+	mrp.On("FetchReceipts", ctx, blockA.BlockID(), txHashesA).
+		Return(types.Receipts(receiptsA), error(nil)).
+		Twice() // evicted by the receipts of block B
+	mrp.On("FetchReceipts", ctx, blockB.BlockID(), txHashesB).
+		Return(types.Receipts(receiptsB), error(nil)).
+		Once()
+
+	infoA, _, _ := blockA.Info(true, true)
+	infoB, _, _ := blockB.Info(true, true)
+	_, err := rp.FetchReceipts(ctx, infoA, txHashesA)
+	require.NoError(t, err)
+	_, err = rp.FetchReceipts(ctx, infoB, txHashesB)
+	require.NoError(t, err)
+	_, err = rp.FetchReceipts(ctx, infoB, txHashesB)
+	require.NoError(t, err)
+	_, err = rp.FetchReceipts(ctx, infoA, txHashesA)
+	require.NoError(t, err)
+	require.Equal(t, 1, rp.cache.Len())
+	require.Equal(t, receiptsSize(receiptsA), rp.cacheBytes.Load())
+	mrp.AssertExpectations(t)
+}
@@ -2,6 +2,7 @@ package sources
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -22,7 +23,7 @@ func newRPCRecProviderFromConfig(client client.RPC, log log.Logger, metrics cach
 		ProviderKind:        config.RPCProviderKind,
 		MethodResetDuration: config.MethodResetDuration,
 	}
-	return NewCachingRPCReceiptsProvider(client, log, recCfg, metrics, config.ReceiptsCacheSize)
+	return NewCachingRPCReceiptsProvider(client, log, recCfg, metrics, config.ReceiptsCacheSize, config.ReceiptsCacheMaxBytes)
 }
 
 type rpcClient interface {
@@ -43,6 +44,10 @@ type RPCReceiptsFetcher struct {
 	// uint64 that's not critical (fine to miss or mix up a modification)
 	availableReceiptMethods ReceiptsFetchingMethod
 
+	// supportedReceiptMethods are the methods that availableReceiptMethods is periodically reset to.
+	// These are the methods of the provider kind, minus the methods that were detected to be unsupported.
+	supportedReceiptMethods ReceiptsFetchingMethod
+
 	// lastMethodsReset tracks when availableReceiptMethods was last reset.
 	// When receipt-fetching fails it falls back to available methods,
 	// but periodically it will try to reset to the preferred optimal methods.
@@ -65,6 +70,7 @@ func NewRPCReceiptsFetcher(client rpcClient, log log.Logger, config RPCReceiptsC
 		log:                     log,
 		provKind:                config.ProviderKind,
 		availableReceiptMethods: AvailableReceiptsFetchingMethods(config.ProviderKind),
+		supportedReceiptMethods: AvailableReceiptsFetchingMethods(config.ProviderKind),
 		lastMethodsReset:        time.Now(),
 		methodResetDuration:     config.MethodResetDuration,
 	}
@@ -120,7 +126,7 @@ type receiptsWrapper struct {
 func (f *RPCReceiptsFetcher) PickReceiptsMethod(txCount int) ReceiptsFetchingMethod {
 	txc := uint64(txCount)
 	if now := time.Now(); now.Sub(f.lastMethodsReset) > f.methodResetDuration {
-		m := AvailableReceiptsFetchingMethods(f.provKind)
+		m := f.supportedReceiptMethods
 		if f.availableReceiptMethods != m {
 			f.log.Warn("resetting back RPC preferences, please review RPC provider kind setting", "kind", f.provKind.String())
 		}
@@ -142,6 +148,50 @@ func (f *RPCReceiptsFetcher) OnReceiptsMethodErr(m ReceiptsFetchingMethod, err e
 	}
 }
 
+// DetectReceiptsMethods probes the receipt fetching methods of the provider kind against the given block,
+// and permanently disables the methods that the RPC does not support.
+// Per-tx receipt fetching is never disabled, since it is the standard fallback.
+// Methods that fail for other reasons, e.g. a timeout, are assumed to be supported.
+// It returns the remaining supported methods.
+func (f *RPCReceiptsFetcher) DetectReceiptsMethods(ctx context.Context, blockHash common.Hash) ReceiptsFetchingMethod {
+	supported := AvailableReceiptsFetchingMethods(f.provKind)
+	for m := ReceiptsFetchingMethod(1); m != 0 && m <= supported; m <<= 1 {
+		if supported&m == 0 || m == EthGetTransactionReceiptBatch {
+			continue
+		}
+		method, arg := receiptsMethodCall(m, blockHash)
+		var tmp json.RawMessage
+		if err := f.client.CallContext(ctx, &tmp, method, arg); err != nil && unusableMethod(err) {
+			f.log.Info("RPC does not support receipt fetching method", "method", m, "err", err)
+			supported &^= m
+		}
+	}
+	f.log.Info("Detected supported receipt fetching methods", "provider_kind", f.provKind, "methods", supported)
+	f.supportedReceiptMethods = supported
+	f.availableReceiptMethods = supported
+	f.lastMethodsReset = time.Now()
+	return supported
+}
+
+// receiptsMethodCall returns the RPC method name and argument to fetch all receipts of a block with the given method.
+// It is not applicable to EthGetTransactionReceiptBatch, which fetches receipts per tx.
+func receiptsMethodCall(m ReceiptsFetchingMethod, blockHash common.Hash) (string, any) {
+	switch m {
+	case AlchemyGetTransactionReceipts:
+		return "alchemy_getTransactionReceipts", blockHashParameter{BlockHash: blockHash}
+	case DebugGetRawReceipts:
+		return "debug_getRawReceipts", blockHash
+	case ParityGetBlockReceipts:
+		return "parity_getBlockReceipts", blockHash
+	case EthGetBlockReceipts:
+		return "eth_getBlockReceipts", blockHash
+	case ErigonGetBlockReceiptsByBlockHash:
+		return "erigon_getBlockReceiptsByBlockHash", blockHash
+	default:
+		return "", nil
+	}
+}
+
 // Cost break-down sources:
 // Alchemy: https://docs.alchemy.com/reference/compute-units
 // QuickNode: https://www.quicknode.com/docs/ethereum/api_credits
@@ -577,3 +577,43 @@ func requireEqualReceipt(t *testing.T, exp, act *types.Receipt, msgAndArgs ...an
 	require.NoError(t, err, msgAndArgs...)
 	require.Equal(t, string(expJson), string(actJson), msgAndArgs...)
 }
+
+type methodNotFoundErr struct{}
+
+func (methodNotFoundErr) Error() string  { return "the method does not exist/is not available" }
+func (methodNotFoundErr) ErrorCode() int { return -32601 }
+
+type detectRPC struct {
+	supported map[string]bool
+	called    []string
+}
+
+func (r *detectRPC) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	r.called = append(r.called, method)
+	if !r.supported[method] {
+		return methodNotFoundErr{}
+	}
+	return nil
+}
+
+func (r *detectRPC) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	return nil
+}
+
+func TestRPCReceiptsFetcher_DetectReceiptsMethods(t *testing.T) {
+	rpcCl := &detectRPC{supported: map[string]bool{"eth_getBlockReceipts": true}}
+	f := NewRPCReceiptsFetcher(rpcCl, testlog.Logger(t, log.LevelInfo), RPCReceiptsConfig{
+		MaxBatchSize:        10,
+		ProviderKind:        RPCKindAny,
+		MethodResetDuration: time.Nanosecond,
+	})
+	supported := f.DetectReceiptsMethods(context.Background(), common.Hash{0xaa})
+	require.Equal(t, EthGetBlockReceipts|EthGetTransactionReceiptBatch, supported)
+	require.NotContains(t, rpcCl.called, "eth_getTransactionReceipt", "per-tx fetching is always supported")
+	require.Len(t, rpcCl.called, 5)
+
+	// Resets go back to the detected methods, instead of all methods of the provider kind.
+	time.Sleep(time.Millisecond)
+	require.Equal(t, EthGetBlockReceipts, f.PickReceiptsMethod(100))
+	require.Equal(t, supported, f.availableReceiptMethods)
+}
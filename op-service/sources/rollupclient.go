@@ -8,6 +8,9 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/attributes"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
 	"github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
@@ -38,6 +41,36 @@ func (r *RollupClient) SyncStatus(ctx context.Context) (*eth.SyncStatus, error)
 	return output, err
 }
 
+func (r *RollupClient) BatchDrops(ctx context.Context) (*derive.BatchDropReport, error) {
+	var output *derive.BatchDropReport
+	err := r.rpc.CallContext(ctx, &output, "optimism_batchDrops")
+	return output, err
+}
+
+func (r *RollupClient) AttributesMismatches(ctx context.Context) ([]attributes.AttributesMismatch, error) {
+	var output []attributes.AttributesMismatch
+	err := r.rpc.CallContext(ctx, &output, "optimism_attributesMismatches")
+	return output, err
+}
+
+func (r *RollupClient) EventTrace(ctx context.Context) (*event.TraceDump, error) {
+	var output *event.TraceDump
+	err := r.rpc.CallContext(ctx, &output, "optimism_eventTrace")
+	return output, err
+}
+
+func (r *RollupClient) BatchPreview(ctx context.Context, args *derive.ChannelPreviewArgs) (*derive.ChannelPreview, error) {
+	var output *derive.ChannelPreview
+	err := r.rpc.CallContext(ctx, &output, "optimism_batchPreview", args)
+	return output, err
+}
+
+func (r *RollupClient) SafetyTrace(ctx context.Context, blockNum uint64) (*derive.SafetyTrace, error) {
+	var output *derive.SafetyTrace
+	err := r.rpc.CallContext(ctx, &output, "optimism_safetyTrace", hexutil.Uint64(blockNum))
+	return output, err
+}
+
 func (r *RollupClient) RollupConfig(ctx context.Context) (*rollup.Config, error) {
 	var output *rollup.Config
 	err := r.rpc.CallContext(ctx, &output, "optimism_rollupConfig")
@@ -66,10 +99,20 @@ func (r *RollupClient) SequencerActive(ctx context.Context) (bool, error) {
 	return result, err
 }
 
+// ResetDerivationPipelineTo rewinds the forkchoice state of the rollup node to the given L2 block,
+// and re-derives the chain from there.
+func (r *RollupClient) ResetDerivationPipelineTo(ctx context.Context, blockNum uint64) error {
+	return r.rpc.CallContext(ctx, nil, "admin_resetDerivationPipeline", hexutil.Uint64(blockNum))
+}
+
 func (r *RollupClient) PostUnsafePayload(ctx context.Context, payload *eth.ExecutionPayloadEnvelope) error {
 	return r.rpc.CallContext(ctx, nil, "admin_postUnsafePayload", payload)
 }
 
+func (r *RollupClient) SubmitBuilderPayload(ctx context.Context, payload *eth.ExecutionPayloadEnvelope) error {
+	return r.rpc.CallContext(ctx, nil, "admin_submitBuilderPayload", payload)
+}
+
 func (r *RollupClient) OverrideLeader(ctx context.Context) error {
 	return r.rpc.CallContext(ctx, nil, "admin_overrideLeader")
 }
@@ -1,6 +1,8 @@
 package sources
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"math/big"
 	"strings"
@@ -12,6 +14,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/ethereum/go-ethereum/trie"
 
@@ -213,18 +216,123 @@ type RPCBlock struct {
 	RPCHeader
 	Transactions []*types.Transaction `json:"transactions"`
 	Withdrawals  *types.Withdrawals   `json:"withdrawals,omitempty"`
+
+	// setCodeTxs are the EIP-7702 set-code transactions of the block, by index in the block.
+	// They are not included in Transactions, since the go-ethereum transaction type does not support them yet.
+	setCodeTxs map[int]*eth.SetCodeTx
+}
+
+func (block *RPCBlock) UnmarshalJSON(data []byte) error {
+	type rpcBlock RPCBlock
+	var dec struct {
+		*rpcBlock
+		Transactions []json.RawMessage `json:"transactions"`
+	}
+	dec.rpcBlock = (*rpcBlock)(block)
+	if err := json.Unmarshal(data, &dec); err != nil {
+		return err
+	}
+	block.Transactions = make([]*types.Transaction, 0, len(dec.Transactions))
+	block.setCodeTxs = nil
+	for i, raw := range dec.Transactions {
+		var txType struct {
+			Type hexutil.Uint64 `json:"type"`
+		}
+		if err := json.Unmarshal(raw, &txType); err != nil {
+			return fmt.Errorf("failed to decode type of tx %d: %w", i, err)
+		}
+		if txType.Type == eth.SetCodeTxType {
+			var tx eth.SetCodeTx
+			if err := json.Unmarshal(raw, &tx); err != nil {
+				return fmt.Errorf("failed to decode set-code tx %d: %w", i, err)
+			}
+			if block.setCodeTxs == nil {
+				block.setCodeTxs = make(map[int]*eth.SetCodeTx)
+			}
+			block.setCodeTxs[i] = &tx
+			continue
+		}
+		var tx *types.Transaction
+		if err := json.Unmarshal(raw, &tx); err != nil {
+			return fmt.Errorf("failed to decode tx %d: %w", i, err)
+		}
+		block.Transactions = append(block.Transactions, tx)
+	}
+	return nil
+}
+
+// opaqueTxs returns the encoding of all transactions of the block, including set-code transactions, in block order.
+func (block *RPCBlock) opaqueTxs() ([]hexutil.Bytes, error) {
+	out := make([]hexutil.Bytes, 0, len(block.Transactions)+len(block.setCodeTxs))
+	txs := block.Transactions
+	for i := 0; i < cap(out); i++ {
+		var data []byte
+		var err error
+		if tx, ok := block.setCodeTxs[i]; ok {
+			data, err = tx.MarshalBinary()
+		} else {
+			if txs[0] == nil {
+				return nil, fmt.Errorf("block tx %d is nil", i)
+			}
+			data, err = txs[0].MarshalBinary()
+			txs = txs[1:]
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode tx %d from RPC: %w", i, err)
+		}
+		out = append(out, data)
+	}
+	return out, nil
 }
 
+// TxHashes returns the hashes of all transactions of the block, including set-code transactions, in block order.
+func (block *RPCBlock) TxHashes() ([]common.Hash, error) {
+	if len(block.setCodeTxs) == 0 {
+		return eth.TransactionsToHashes(block.Transactions), nil
+	}
+	opaqueTxs, err := block.opaqueTxs()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]common.Hash, len(opaqueTxs))
+	for i, tx := range opaqueTxs {
+		out[i] = crypto.Keccak256Hash(tx)
+	}
+	return out, nil
+}
+
+// SetCodeTxs returns the EIP-7702 set-code transactions of the block, by index in the block.
+// They are not included in Transactions.
+func (block *RPCBlock) SetCodeTxs() map[int]*eth.SetCodeTx {
+	return block.setCodeTxs
+}
+
+// opaqueTxsList implements types.DerivableList, to compute the transactions trie of opaque transactions.
+type opaqueTxsList []hexutil.Bytes
+
+func (l opaqueTxsList) Len() int { return len(l) }
+
+func (l opaqueTxsList) EncodeIndex(i int, w *bytes.Buffer) { w.Write(l[i]) }
+
 func (block *RPCBlock) verify() error {
 	if computed := block.computeBlockHash(); computed != block.Hash {
 		return fmt.Errorf("failed to verify block hash: computed %s but RPC said %s", computed, block.Hash)
 	}
-	for i, tx := range block.Transactions {
-		if tx == nil {
-			return fmt.Errorf("block tx %d is nil", i)
+	var txs types.DerivableList = types.Transactions(block.Transactions)
+	if len(block.setCodeTxs) > 0 {
+		opaqueTxs, err := block.opaqueTxs()
+		if err != nil {
+			return err
+		}
+		txs = opaqueTxsList(opaqueTxs)
+	} else {
+		for i, tx := range block.Transactions {
+			if tx == nil {
+				return fmt.Errorf("block tx %d is nil", i)
+			}
 		}
 	}
-	if computed := types.DeriveSha(types.Transactions(block.Transactions), trie.NewStackTrie(nil)); block.TxHash != computed {
+	if computed := types.DeriveSha(txs, trie.NewStackTrie(nil)); block.TxHash != computed {
 		return fmt.Errorf("failed to verify transactions list: computed %s but RPC said %s", computed, block.TxHash)
 	}
 	if block.WithdrawalsRoot != nil {
@@ -282,13 +390,9 @@ func (block *RPCBlock) ExecutionPayloadEnvelope(trustCache bool) (*eth.Execution
 
 	// Unfortunately eth_getBlockByNumber either returns full transactions, or only tx-hashes.
 	// There is no option for encoded transactions.
-	opaqueTxs := make([]hexutil.Bytes, len(block.Transactions))
-	for i, tx := range block.Transactions {
-		data, err := tx.MarshalBinary()
-		if err != nil {
-			return nil, fmt.Errorf("failed to encode tx %d from RPC: %w", i, err)
-		}
-		opaqueTxs[i] = data
+	opaqueTxs, err := block.opaqueTxs()
+	if err != nil {
+		return nil, err
 	}
 
 	payload := &eth.ExecutionPayload{
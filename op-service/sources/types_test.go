@@ -11,6 +11,8 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/trie"
 	"github.com/stretchr/testify/require"
 )
 
@@ -146,3 +148,91 @@ func TestBlockToExecutionPayloadIncludesEcotoneProperties(t *testing.T) {
 	require.NotNil(t, envelope.ExecutionPayload.BlobGasUsed)
 	require.Equal(t, *envelope.ExecutionPayload.BlobGasUsed, *rhdr.BlobGasUsed)
 }
+
+func TestBlockJSONWithSetCodeTx(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signer := types.LatestSignerForChainID(big.NewInt(1))
+	newTx := func(nonce uint64) *types.Transaction {
+		return types.MustSignNewTx(key, signer, &types.DynamicFeeTx{
+			ChainID:   big.NewInt(1),
+			Nonce:     nonce,
+			GasTipCap: big.NewInt(1),
+			GasFeeCap: big.NewInt(10),
+			Gas:       21000,
+			To:        &common.Address{0xaa},
+			Value:     big.NewInt(1),
+		})
+	}
+	txA, txB := newTx(0), newTx(1)
+	setCodeTx := &eth.SetCodeTx{
+		ChainID:   (*hexutil.Big)(big.NewInt(1)),
+		Nonce:     2,
+		GasTipCap: (*hexutil.Big)(big.NewInt(1)),
+		GasFeeCap: (*hexutil.Big)(big.NewInt(10)),
+		Gas:       100_000,
+		To:        common.Address{0xbb},
+		Value:     (*hexutil.Big)(big.NewInt(0)),
+		AuthorizationList: []eth.SetCodeAuthorization{{
+			ChainID: (*hexutil.Big)(big.NewInt(1)),
+			Address: common.Address{0xcc},
+			R:       (*hexutil.Big)(big.NewInt(1)),
+			S:       (*hexutil.Big)(big.NewInt(2)),
+		}},
+		R: (*hexutil.Big)(big.NewInt(3)),
+		S: (*hexutil.Big)(big.NewInt(4)),
+	}
+	setCodeData, err := setCodeTx.MarshalBinary()
+	require.NoError(t, err)
+	opaqueTxs, err := eth.EncodeTransactions([]*types.Transaction{txA, txB})
+	require.NoError(t, err)
+	opaqueTxs = []hexutil.Bytes{opaqueTxs[0], setCodeData, opaqueTxs[1]}
+
+	header := RPCHeader{
+		UncleHash: types.EmptyUncleHash,
+		TxHash:    types.DeriveSha(opaqueTxsList(opaqueTxs), trie.NewStackTrie(nil)),
+		Number:    100,
+		BaseFee:   (*hexutil.Big)(big.NewInt(1)),
+	}
+	header.Hash = header.computeBlockHash()
+
+	// Encode the block like an RPC would, with the set-code tx in the middle of the transactions list.
+	var blockJSON map[string]any
+	data, err := json.Marshal(&header)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &blockJSON))
+	setCodeJSON := map[string]any{"type": hexutil.Uint64(eth.SetCodeTxType)}
+	data, err = json.Marshal(setCodeTx)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &setCodeJSON))
+	blockJSON["transactions"] = []any{txA, setCodeJSON, txB}
+	data, err = json.Marshal(blockJSON)
+	require.NoError(t, err)
+
+	var block RPCBlock
+	require.NoError(t, json.Unmarshal(data, &block))
+	require.NoError(t, block.verify())
+
+	_, txs, err := block.Info(false, true)
+	require.NoError(t, err)
+	require.Equal(t, []common.Hash{txA.Hash(), txB.Hash()}, eth.TransactionsToHashes(txs),
+		"set-code txs are not included in the geth txs")
+
+	setCodeHash, err := setCodeTx.Hash()
+	require.NoError(t, err)
+	txHashes, err := block.TxHashes()
+	require.NoError(t, err)
+	require.Equal(t, []common.Hash{txA.Hash(), setCodeHash, txB.Hash()}, txHashes)
+	require.Len(t, block.SetCodeTxs(), 1)
+	decodedHash, err := block.SetCodeTxs()[1].Hash()
+	require.NoError(t, err)
+	require.Equal(t, setCodeHash, decodedHash, "set-code txs are indexed by position in the block")
+
+	envelope, err := block.ExecutionPayloadEnvelope(false)
+	require.NoError(t, err)
+	require.Equal(t, opaqueTxs, envelope.ExecutionPayload.Transactions)
+
+	// A modified set-code tx must not verify against the tx root
+	block.setCodeTxs[1].Nonce++
+	require.ErrorContains(t, block.verify(), "failed to verify transactions list")
+}
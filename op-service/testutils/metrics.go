@@ -67,12 +67,29 @@ func (t *TestDerivationMetrics) RecordFrame() {
 func (n *TestDerivationMetrics) RecordDerivedBatches(batchType string) {
 }
 
+func (n *TestDerivationMetrics) RecordBatchDrop(reason string) {
+}
+
+func (n *TestDerivationMetrics) RecordDerivationStageItems(stage string, itemsIn uint64, itemsOut uint64) {
+}
+
+func (n *TestDerivationMetrics) RecordDerivationStageBufferedBytes(stage string, bytes uint64) {
+}
+
+func (n *TestDerivationMetrics) RecordDerivationStageAdvance(stage string, t time.Time) {
+}
+
+func (n *TestDerivationMetrics) RecordDerivationStageStall(stage string) {
+}
+
 type TestRPCMetrics struct{}
 
 func (n *TestRPCMetrics) RecordRPCServerRequest(method string) func() {
 	return func() {}
 }
 
+func (n *TestRPCMetrics) RecordRPCServerRejection(method string, reason string) {}
+
 func (n *TestRPCMetrics) RecordRPCClientRequest(method string) func(err error) {
 	return func(err error) {}
 }
@@ -3,9 +3,14 @@ package testutils
 import "github.com/ethereum/go-ethereum/common"
 
 type MockRuntimeConfig struct {
-	P2PSeqAddress common.Address
+	P2PSeqAddress         common.Address
+	PreviousP2PSeqAddress common.Address
 }
 
 func (m *MockRuntimeConfig) P2PSequencerAddress() common.Address {
 	return m.P2PSeqAddress
 }
+
+func (m *MockRuntimeConfig) PreviousP2PSequencerAddress() (common.Address, bool) {
+	return m.PreviousP2PSeqAddress, m.PreviousP2PSeqAddress != (common.Address{})
+}
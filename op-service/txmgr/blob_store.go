@@ -0,0 +1,173 @@
+package txmgr
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/ioutil"
+)
+
+const (
+	blobTxFilePrefix = "blobtx-"
+	blobTxFileSuffix = ".rlp"
+)
+
+// blobTxStore persists pending blob transactions including their sidecars, keyed by transaction hash.
+// The sidecar of a blob transaction is not part of its on-chain encoding, so without the store a restarted tx manager
+// cannot rebroadcast a pending blob transaction, or price a replacement for it.
+// Only the latest transaction per nonce is kept. A nil store is disabled and persists nothing.
+type blobTxStore struct {
+	dir string
+
+	mu      sync.Mutex
+	byNonce map[uint64]common.Hash
+}
+
+// openBlobTxStore opens the store in dir, and returns the persisted transactions that are signed by from.
+// Unreadable files and transactions of other senders are skipped.
+func openBlobTxStore(lgr log.Logger, dir string, chainID *big.Int, from common.Address) (*blobTxStore, []*types.Transaction, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, nil, fmt.Errorf("failed to create blob tx state dir: %w", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read blob tx state dir: %w", err)
+	}
+	s := &blobTxStore{dir: dir, byNonce: make(map[uint64]common.Hash)}
+	signer := types.LatestSignerForChainID(chainID)
+	txs := make(map[uint64]*types.Transaction)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, blobTxFilePrefix) || !strings.HasSuffix(name, blobTxFileSuffix) {
+			continue
+		}
+		tx, err := readBlobTx(filepath.Join(dir, name))
+		if err != nil {
+			lgr.Warn("Skipping unreadable persisted blob tx", "file", name, "err", err)
+			continue
+		}
+		if sender, err := types.Sender(signer, tx); err != nil || sender != from {
+			lgr.Warn("Skipping persisted blob tx of another sender", "tx", tx.Hash(), "sender", sender, "err", err)
+			continue
+		}
+		// A crash between persisting a replacement and removing the previous tx may leave both behind.
+		if prev, ok := txs[tx.Nonce()]; ok {
+			if prev.GasTipCap().Cmp(tx.GasTipCap()) >= 0 {
+				_ = s.remove(tx.Hash())
+				continue
+			}
+			_ = s.remove(prev.Hash())
+		}
+		txs[tx.Nonce()] = tx
+		s.byNonce[tx.Nonce()] = tx.Hash()
+	}
+	out := make([]*types.Transaction, 0, len(txs))
+	for _, tx := range txs {
+		out = append(out, tx)
+	}
+	return s, out, nil
+}
+
+func readBlobTx(path string) (*types.Transaction, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var tx types.Transaction
+	if err := tx.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	if tx.Type() != types.BlobTxType || tx.BlobTxSidecar() == nil {
+		return nil, errors.New("not a blob tx with sidecar")
+	}
+	return &tx, nil
+}
+
+func (s *blobTxStore) path(hash common.Hash) string {
+	return filepath.Join(s.dir, blobTxFilePrefix+hash.Hex()+blobTxFileSuffix)
+}
+
+func (s *blobTxStore) remove(hash common.Hash) error {
+	if err := os.Remove(s.path(hash)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// put persists the blob transaction, replacing any previously persisted transaction with the same nonce.
+// Transactions without a sidecar are ignored.
+func (s *blobTxStore) put(tx *types.Transaction) error {
+	if s == nil || tx.Type() != types.BlobTxType || tx.BlobTxSidecar() == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	prev, ok := s.byNonce[tx.Nonce()]
+	if ok && prev == tx.Hash() {
+		return nil
+	}
+	data, err := tx.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	w, err := ioutil.NewAtomicWriterCompressed(s.path(tx.Hash()), 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		_ = w.Abort()
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	s.byNonce[tx.Nonce()] = tx.Hash()
+	if ok {
+		return s.remove(prev)
+	}
+	return nil
+}
+
+// prune removes all persisted transactions with a nonce below next, the nonce of the next transaction to be mined.
+func (s *blobTxStore) prune(next uint64) error {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var errs []error
+	for nonce, hash := range s.byNonce {
+		if nonce >= next {
+			continue
+		}
+		if err := s.remove(hash); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		delete(s.byNonce, nonce)
+	}
+	return errors.Join(errs...)
+}
+
+// nextNonce returns the nonce after the highest persisted nonce, or 0 if no transactions are persisted.
+func (s *blobTxStore) nextNonce() uint64 {
+	if s == nil {
+		return 0
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var next uint64
+	for nonce := range s.byNonce {
+		next = max(next, nonce+1)
+	}
+	return next
+}
@@ -0,0 +1,143 @@
+package txmgr
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func signedBlobTx(t *testing.T, key *ecdsa.PrivateKey, chainID *big.Int, nonce uint64, tip uint64) *types.Transaction {
+	sidecar, blobHashes, err := MakeSidecar([]*eth.Blob{{}})
+	require.NoError(t, err)
+	tx, err := types.SignNewTx(key, types.LatestSignerForChainID(chainID), &types.BlobTx{
+		ChainID:    uint256.MustFromBig(chainID),
+		Nonce:      nonce,
+		GasTipCap:  uint256.NewInt(tip),
+		GasFeeCap:  uint256.NewInt(tip * 2),
+		Gas:        21_000,
+		BlobFeeCap: uint256.NewInt(1),
+		BlobHashes: blobHashes,
+		Sidecar:    sidecar,
+	})
+	require.NoError(t, err)
+	return tx
+}
+
+func TestBlobTxStore(t *testing.T) {
+	lgr := testlog.Logger(t, log.LevelInfo)
+	chainID := big.NewInt(1)
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	dir := t.TempDir()
+
+	store, txs, err := openBlobTxStore(lgr, dir, chainID, from)
+	require.NoError(t, err)
+	require.Empty(t, txs)
+
+	tx1 := signedBlobTx(t, key, chainID, 1, 10)
+	tx2 := signedBlobTx(t, key, chainID, 2, 10)
+	replacement := signedBlobTx(t, key, chainID, 2, 20)
+	require.NoError(t, store.put(tx1))
+	require.NoError(t, store.put(tx2))
+	require.NoError(t, store.put(replacement))
+	// Non-blob txs are not persisted
+	require.NoError(t, store.put(types.NewTx(&types.DynamicFeeTx{Nonce: 3})))
+	require.Equal(t, uint64(3), store.nextNonce())
+
+	_, txs, err = openBlobTxStore(lgr, dir, chainID, from)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []common.Hash{tx1.Hash(), replacement.Hash()}, []common.Hash{txs[0].Hash(), txs[1].Hash()})
+	for _, tx := range txs {
+		require.NotNil(t, tx.BlobTxSidecar(), "sidecar is restored")
+	}
+
+	require.NoError(t, store.prune(2))
+	_, txs, err = openBlobTxStore(lgr, dir, chainID, from)
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+	require.Equal(t, replacement.Hash(), txs[0].Hash())
+
+	// Txs of other senders are not restored
+	other, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	_, txs, err = openBlobTxStore(lgr, dir, chainID, crypto.PubkeyToAddress(other.PublicKey))
+	require.NoError(t, err)
+	require.Empty(t, txs)
+
+	var nilStore *blobTxStore
+	require.NoError(t, nilStore.put(tx1))
+	require.NoError(t, nilStore.prune(10))
+	require.Zero(t, nilStore.nextNonce())
+}
+
+func TestBlobTxStoreKeepsHighestFeeReplacement(t *testing.T) {
+	lgr := testlog.Logger(t, log.LevelInfo)
+	chainID := big.NewInt(1)
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	dir := t.TempDir()
+
+	store, _, err := openBlobTxStore(lgr, dir, chainID, from)
+	require.NoError(t, err)
+	tx := signedBlobTx(t, key, chainID, 1, 10)
+	replacement := signedBlobTx(t, key, chainID, 1, 20)
+	require.NoError(t, store.put(replacement))
+	// Simulate a crash before the replaced tx was removed
+	data, err := tx.MarshalBinary()
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(store.path(tx.Hash()), data, 0o644))
+
+	_, txs, err := openBlobTxStore(lgr, dir, chainID, from)
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+	require.Equal(t, replacement.Hash(), txs[0].Hash())
+	require.NoFileExists(t, store.path(tx.Hash()))
+}
+
+func TestSignWithNextNonceSkipsRestoredBlobTxs(t *testing.T) {
+	chainID := big.NewInt(1)
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	dir := t.TempDir()
+
+	store, _, err := openBlobTxStore(testlog.Logger(t, log.LevelInfo), dir, chainID, from)
+	require.NoError(t, err)
+	// One pending blob tx at the latest nonce, and one that was mined already
+	pending := signedBlobTx(t, key, chainID, startingNonce, 10)
+	require.NoError(t, store.put(signedBlobTx(t, key, chainID, startingNonce-1, 10)))
+	require.NoError(t, store.put(pending))
+
+	cfg := configWithNumConfs(1)
+	cfg.ChainID = chainID
+	cfg.From = from
+	h := newTestHarnessWithConfig(t, cfg)
+	var sent []common.Hash
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		sent = append(sent, tx.Hash())
+		return nil
+	})
+	h.mgr.blobTxs, h.mgr.restored, err = openBlobTxStore(h.mgr.l, dir, chainID, from)
+	require.NoError(t, err)
+
+	tx, err := h.mgr.signWithNextNonce(context.Background(), &types.DynamicFeeTx{})
+	require.NoError(t, err)
+	require.Equal(t, uint64(startingNonce+1), tx.Nonce(), "nonce of the pending blob tx is skipped")
+	require.Equal(t, []common.Hash{pending.Hash()}, sent, "pending blob tx is re-broadcast")
+	require.Empty(t, h.mgr.restored)
+	require.Equal(t, uint64(startingNonce+1), h.mgr.blobTxs.nextNonce())
+}
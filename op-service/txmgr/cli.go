@@ -33,11 +33,17 @@ const (
 	FeeLimitThresholdFlagName         = "txmgr.fee-limit-threshold"
 	MinBaseFeeFlagName                = "txmgr.min-basefee"
 	MinTipCapFlagName                 = "txmgr.min-tip-cap"
+	MaxBlobFeeFlagName                = "txmgr.max-blob-fee"
 	ResubmissionTimeoutFlagName       = "resubmission-timeout"
 	NetworkTimeoutFlagName            = "network-timeout"
 	TxSendTimeoutFlagName             = "txmgr.send-timeout"
 	TxNotInMempoolTimeoutFlagName     = "txmgr.not-in-mempool-timeout"
 	ReceiptQueryIntervalFlagName      = "txmgr.receipt-query-interval"
+	NonceGapTimeoutFlagName           = "txmgr.nonce-gap-timeout"
+	NonceGapMaxCancelsFlagName        = "txmgr.nonce-gap-max-cancels"
+	TxDeadlineFlagName                = "txmgr.deadline"
+	TipOracleProbabilityFlagName      = "txmgr.tip-oracle.inclusion-probability"
+	TipOracleBlocksFlagName           = "txmgr.tip-oracle.blocks"
 )
 
 var (
@@ -67,8 +73,13 @@ type DefaultFlagValues struct {
 	TxSendTimeout             time.Duration
 	TxNotInMempoolTimeout     time.Duration
 	ReceiptQueryInterval      time.Duration
+	NonceGapTimeout           time.Duration
+	NonceGapMaxCancels        uint64
 }
 
+// defaultTipOracleBlocks is the default number of recent blocks the tip oracle suggests tips from.
+const defaultTipOracleBlocks = 20
+
 var (
 	DefaultBatcherFlagValues = DefaultFlagValues{
 		NumConfirmations:          uint64(10),
@@ -82,6 +93,8 @@ var (
 		TxSendTimeout:             10 * time.Minute,
 		TxNotInMempoolTimeout:     2 * time.Minute,
 		ReceiptQueryInterval:      12 * time.Second,
+		NonceGapTimeout:           5 * time.Minute,
+		NonceGapMaxCancels:        uint64(10),
 	}
 	DefaultChallengerFlagValues = DefaultFlagValues{
 		NumConfirmations:          uint64(3),
@@ -95,6 +108,8 @@ var (
 		TxSendTimeout:             2 * time.Minute,
 		TxNotInMempoolTimeout:     1 * time.Minute,
 		ReceiptQueryInterval:      12 * time.Second,
+		NonceGapTimeout:           2 * time.Minute,
+		NonceGapMaxCancels:        uint64(10),
 	}
 
 	// geth enforces a 1 gwei minimum for blob tx fee
@@ -161,6 +176,12 @@ func CLIFlagsWithDefaults(envPrefix string, defaults DefaultFlagValues) []cli.Fl
 			Value:   defaults.MinBaseFeeGwei,
 			EnvVars: prefixEnvVars("TXMGR_MIN_BASEFEE"),
 		},
+		&cli.Float64Flag{
+			Name:    MaxBlobFeeFlagName,
+			Aliases: []string{"max-blob-fee"},
+			Usage:   "The maximum blob fee cap (in GWei) of blob txs. Blob fee bumps that exceed it fail, regardless of the fee limit threshold. Disabled if set to 0.",
+			EnvVars: prefixEnvVars("TXMGR_MAX_BLOB_FEE"),
+		},
 		&cli.DurationFlag{
 			Name:    ResubmissionTimeoutFlagName,
 			Usage:   "Duration we will wait before resubmitting a transaction to L1",
@@ -191,6 +212,34 @@ func CLIFlagsWithDefaults(envPrefix string, defaults DefaultFlagValues) []cli.Fl
 			Value:   defaults.ReceiptQueryInterval,
 			EnvVars: prefixEnvVars("TXMGR_RECEIPT_QUERY_INTERVAL"),
 		},
+		&cli.DurationFlag{
+			Name:    NonceGapTimeoutFlagName,
+			Usage:   "Duration a nonce left behind by an abandoned tx may block later txs before it is filled with a cancellation tx. If 0 it is disabled.",
+			Value:   defaults.NonceGapTimeout,
+			EnvVars: prefixEnvVars("TXMGR_NONCE_GAP_TIMEOUT"),
+		},
+		&cli.Uint64Flag{
+			Name:    NonceGapMaxCancelsFlagName,
+			Usage:   "Maximum number of fee bumped cancellation txs to send for a single nonce gap",
+			Value:   defaults.NonceGapMaxCancels,
+			EnvVars: prefixEnvVars("TXMGR_NONCE_GAP_MAX_CANCELS"),
+		},
+		&cli.DurationFlag{
+			Name:    TxDeadlineFlagName,
+			Usage:   "Duration after which a tx that hasn't been mined is replaced with a cancellation tx at the same nonce, instead of bumping its fees further. If 0 it is disabled.",
+			EnvVars: prefixEnvVars("TXMGR_DEADLINE"),
+		},
+		&cli.Float64Flag{
+			Name:    TipOracleProbabilityFlagName,
+			Usage:   "Target inclusion probability, in (0, 1], of the tip oracle. Suggests tips at this percentile of the tips paid by similar txs in recent blocks, instead of the tip suggested by the L1 node. Disabled if set to 0.",
+			EnvVars: prefixEnvVars("TXMGR_TIP_ORACLE_INCLUSION_PROBABILITY"),
+		},
+		&cli.Uint64Flag{
+			Name:    TipOracleBlocksFlagName,
+			Usage:   "Number of recent blocks the tip oracle suggests tips from",
+			Value:   defaultTipOracleBlocks,
+			EnvVars: prefixEnvVars("TXMGR_TIP_ORACLE_BLOCKS"),
+		},
 	}, opsigner.CLIFlags(envPrefix)...)
 }
 
@@ -208,11 +257,21 @@ type CLIConfig struct {
 	FeeLimitThresholdGwei     float64
 	MinBaseFeeGwei            float64
 	MinTipCapGwei             float64
+	MaxBlobFeeGwei            float64
 	ResubmissionTimeout       time.Duration
 	ReceiptQueryInterval      time.Duration
 	NetworkTimeout            time.Duration
 	TxSendTimeout             time.Duration
 	TxNotInMempoolTimeout     time.Duration
+	NonceGapTimeout           time.Duration
+	NonceGapMaxCancels        uint64
+	TxDeadline                time.Duration
+	// StateDir is the directory to persist pending blob txs in. It has no flag of its own,
+	// the service sets it to a subdirectory of its state directory. Disabled if empty.
+	StateDir string
+	// TipOracleProbability is the target inclusion probability of the tip oracle. Disabled if 0.
+	TipOracleProbability float64
+	TipOracleBlocks      uint64
 }
 
 func NewCLIConfig(l1RPCURL string, defaults DefaultFlagValues) CLIConfig {
@@ -229,6 +288,9 @@ func NewCLIConfig(l1RPCURL string, defaults DefaultFlagValues) CLIConfig {
 		TxSendTimeout:             defaults.TxSendTimeout,
 		TxNotInMempoolTimeout:     defaults.TxNotInMempoolTimeout,
 		ReceiptQueryInterval:      defaults.ReceiptQueryInterval,
+		NonceGapTimeout:           defaults.NonceGapTimeout,
+		NonceGapMaxCancels:        defaults.NonceGapMaxCancels,
+		TipOracleBlocks:           defaultTipOracleBlocks,
 		SignerCLIConfig:           opsigner.NewCLIConfig(),
 	}
 }
@@ -250,9 +312,15 @@ func (m CLIConfig) Check() error {
 		return fmt.Errorf("minBaseFee smaller than minTipCap, have %f < %f",
 			m.MinBaseFeeGwei, m.MinTipCapGwei)
 	}
+	if m.MaxBlobFeeGwei < 0 {
+		return fmt.Errorf("maxBlobFee must not be negative, have %f", m.MaxBlobFeeGwei)
+	}
 	if m.ResubmissionTimeout == 0 {
 		return errors.New("must provide ResubmissionTimeout")
 	}
+	if m.TxDeadline < 0 {
+		return fmt.Errorf("txDeadline must not be negative, have %v", m.TxDeadline)
+	}
 	if m.ReceiptQueryInterval == 0 {
 		return errors.New("must provide ReceiptQueryInterval")
 	}
@@ -262,6 +330,12 @@ func (m CLIConfig) Check() error {
 	if m.SafeAbortNonceTooLowCount == 0 {
 		return errors.New("SafeAbortNonceTooLowCount must not be 0")
 	}
+	if m.TipOracleProbability < 0 || m.TipOracleProbability > 1 {
+		return fmt.Errorf("tip oracle inclusion probability must be in [0, 1], have %f", m.TipOracleProbability)
+	}
+	if m.TipOracleProbability > 0 && m.TipOracleBlocks == 0 {
+		return errors.New("must provide TipOracleBlocks when the tip oracle is enabled")
+	}
 	if err := m.SignerCLIConfig.Check(); err != nil {
 		return err
 	}
@@ -283,11 +357,17 @@ func ReadCLIConfig(ctx *cli.Context) CLIConfig {
 		FeeLimitThresholdGwei:     ctx.Float64(FeeLimitThresholdFlagName),
 		MinBaseFeeGwei:            ctx.Float64(MinBaseFeeFlagName),
 		MinTipCapGwei:             ctx.Float64(MinTipCapFlagName),
+		MaxBlobFeeGwei:            ctx.Float64(MaxBlobFeeFlagName),
 		ResubmissionTimeout:       ctx.Duration(ResubmissionTimeoutFlagName),
 		ReceiptQueryInterval:      ctx.Duration(ReceiptQueryIntervalFlagName),
 		NetworkTimeout:            ctx.Duration(NetworkTimeoutFlagName),
 		TxSendTimeout:             ctx.Duration(TxSendTimeoutFlagName),
 		TxNotInMempoolTimeout:     ctx.Duration(TxNotInMempoolTimeoutFlagName),
+		NonceGapTimeout:           ctx.Duration(NonceGapTimeoutFlagName),
+		NonceGapMaxCancels:        ctx.Uint64(NonceGapMaxCancelsFlagName),
+		TxDeadline:                ctx.Duration(TxDeadlineFlagName),
+		TipOracleProbability:      ctx.Float64(TipOracleProbabilityFlagName),
+		TipOracleBlocks:           ctx.Uint64(TipOracleBlocksFlagName),
 	}
 }
 
@@ -338,11 +418,20 @@ func NewConfig(cfg CLIConfig, l log.Logger) (*Config, error) {
 		return nil, fmt.Errorf("invalid min tip cap: %w", err)
 	}
 
+	maxBlobFee, err := eth.GweiToWei(cfg.MaxBlobFeeGwei)
+	if err != nil {
+		return nil, fmt.Errorf("invalid max blob fee: %w", err)
+	}
+
 	res := Config{
 		Backend:                   l1,
 		ChainID:                   chainID,
 		TxSendTimeout:             cfg.TxSendTimeout,
 		TxNotInMempoolTimeout:     cfg.TxNotInMempoolTimeout,
+		NonceGapTimeout:           cfg.NonceGapTimeout,
+		NonceGapMaxCancels:        cfg.NonceGapMaxCancels,
+		TxDeadline:                cfg.TxDeadline,
+		StateDir:                  cfg.StateDir,
 		NetworkTimeout:            cfg.NetworkTimeout,
 		ReceiptQueryInterval:      cfg.ReceiptQueryInterval,
 		NumConfirmations:          cfg.NumConfirmations,
@@ -351,12 +440,17 @@ func NewConfig(cfg CLIConfig, l log.Logger) (*Config, error) {
 		From:                      from,
 	}
 
+	if cfg.TipOracleProbability > 0 {
+		res.TipOracle = NewInclusionTipOracle(l, l1, cfg.TipOracleBlocks, cfg.TipOracleProbability)
+	}
+
 	res.ResubmissionTimeout.Store(int64(cfg.ResubmissionTimeout))
 	res.FeeLimitThreshold.Store(feeLimitThreshold)
 	res.FeeLimitMultiplier.Store(cfg.FeeLimitMultiplier)
 	res.MinBaseFee.Store(minBaseFee)
 	res.MinTipCap.Store(minTipCap)
 	res.MinBlobTxFee.Store(defaultMinBlobTxFee)
+	res.MaxBlobFee.Store(maxBlobFee)
 
 	return &res, nil
 }
@@ -386,6 +480,13 @@ type Config struct {
 
 	MinBlobTxFee atomic.Pointer[big.Int]
 
+	// Maximum blob fee cap (in Wei) of blob txs. Unlike the FeeLimitMultiplier,
+	// it applies regardless of the FeeLimitThreshold. Nil or zero disables the limit.
+	MaxBlobFee atomic.Pointer[big.Int]
+
+	// TipOracle suggests the tip cap of transactions, instead of the Backend. Optional.
+	TipOracle TipOracle
+
 	// ChainID is the chain ID of the L1 chain.
 	ChainID *big.Int
 
@@ -397,6 +498,23 @@ type Config struct {
 	// make it to the mempool. If the tx is in the mempool, TxSendTimeout is used instead.
 	TxNotInMempoolTimeout time.Duration
 
+	// NonceGapTimeout is how long a nonce left behind by an abandoned transaction may block later transactions
+	// before it is filled with a cancellation transaction. Zero disables cancellation, so gaps are only
+	// filled when a new transaction is signed with the missing nonce.
+	NonceGapTimeout time.Duration
+
+	// NonceGapMaxCancels is the maximum number of fee bumped cancellation transactions sent for a single nonce gap.
+	// Once reached, the last cancellation transaction is re-broadcast without further fee bumps.
+	NonceGapMaxCancels uint64
+
+	// TxDeadline is how long after the send started a transaction that hasn't been mined is replaced with a
+	// cancellation transaction, unless the candidate sets its own deadline. Zero disables the default deadline.
+	TxDeadline time.Duration
+
+	// StateDir is the directory pending blob transactions are persisted in, including their sidecars, so that they
+	// can be re-broadcast, replaced or cancelled after a restart. Persistence is disabled if empty.
+	StateDir string
+
 	// NetworkTimeout is the allowed duration for a single network request.
 	// This is intended to be used for network requests that can be replayed.
 	NetworkTimeout time.Duration
@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"math/big"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -15,10 +16,12 @@ type TxMetricer interface {
 	RecordTxConfirmationLatency(int64)
 	RecordNonce(uint64)
 	RecordPendingTx(pending int64)
+	RecordStuckNonceDuration(time.Duration)
 	TxConfirmed(*types.Receipt)
 	TxPublished(string)
 	RecordBaseFee(*big.Int)
 	RecordBlobBaseFee(*big.Int)
+	RecordBlobFeeBump()
 	RecordTipCap(*big.Int)
 	RPCError()
 }
@@ -32,11 +35,13 @@ type TxMetrics struct {
 	latencyConfirmedTx prometheus.Gauge
 	currentNonce       prometheus.Gauge
 	pendingTxs         prometheus.Gauge
+	stuckNonceDuration prometheus.Gauge
 	txPublishError     *prometheus.CounterVec
 	publishEvent       *metrics.Event
 	confirmEvent       metrics.EventVec
 	baseFee            prometheus.Gauge
 	blobBaseFee        prometheus.Gauge
+	blobFeeBumps       prometheus.Counter
 	tipCap             prometheus.Gauge
 	rpcError           prometheus.Counter
 }
@@ -105,6 +110,12 @@ func MakeTxMetrics(ns string, factory metrics.Factory) TxMetrics {
 			Help:      "Number of transactions pending receipts",
 			Subsystem: "txmgr",
 		}),
+		stuckNonceDuration: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "stuck_nonce_duration_seconds",
+			Help:      "Duration that the lowest unconfirmed nonce has been blocking later transactions",
+			Subsystem: "txmgr",
+		}),
 		txPublishError: factory.NewCounterVec(prometheus.CounterOpts{
 			Namespace: ns,
 			Name:      "tx_publish_error_count",
@@ -125,6 +136,12 @@ func MakeTxMetrics(ns string, factory metrics.Factory) TxMetrics {
 			Help:      "Latest Blob base fee (in Wei)",
 			Subsystem: "txmgr",
 		}),
+		blobFeeBumps: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "blob_fee_bump_count",
+			Help:      "Count of blob fee cap bumps of blob transactions",
+			Subsystem: "txmgr",
+		}),
 		tipCap: factory.NewGauge(prometheus.GaugeOpts{
 			Namespace: ns,
 			Name:      "tipcap_wei",
@@ -148,6 +165,10 @@ func (t *TxMetrics) RecordPendingTx(pending int64) {
 	t.pendingTxs.Set(float64(pending))
 }
 
+func (t *TxMetrics) RecordStuckNonceDuration(duration time.Duration) {
+	t.stuckNonceDuration.Set(duration.Seconds())
+}
+
 // TxConfirmed records lots of information about the confirmed transaction
 func (t *TxMetrics) TxConfirmed(receipt *types.Receipt) {
 	fee := float64(receipt.EffectiveGasPrice.Uint64() * receipt.GasUsed / params.GWei)
@@ -184,6 +205,10 @@ func (t *TxMetrics) RecordBlobBaseFee(blobBaseFee *big.Int) {
 	t.blobBaseFee.Set(bff)
 }
 
+func (t *TxMetrics) RecordBlobFeeBump() {
+	t.blobFeeBumps.Inc()
+}
+
 func (t *TxMetrics) RecordTipCap(tipcap *big.Int) {
 	tcf, _ := tipcap.Float64()
 	t.tipCap.Set(tcf)
@@ -0,0 +1,145 @@
+package txmgr
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// multicall3ABI is the ABI of the aggregate3 method of Multicall3.
+const multicall3ABI = `[{"type":"function","name":"aggregate3","stateMutability":"payable",` +
+	`"inputs":[{"name":"calls","type":"tuple[]","internalType":"struct Multicall3.Call3[]","components":[` +
+	`{"name":"target","type":"address","internalType":"address"},` +
+	`{"name":"allowFailure","type":"bool","internalType":"bool"},` +
+	`{"name":"callData","type":"bytes","internalType":"bytes"}]}],` +
+	`"outputs":[{"name":"returnData","type":"tuple[]","internalType":"struct Multicall3.Result[]","components":[` +
+	`{"name":"success","type":"bool","internalType":"bool"},` +
+	`{"name":"returnData","type":"bytes","internalType":"bytes"}]}]}]`
+
+var multicall3 = mustParseABI(multicall3ABI)
+
+func mustParseABI(json string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(json))
+	if err != nil {
+		panic(fmt.Errorf("failed to parse ABI: %w", err))
+	}
+	return parsed
+}
+
+var ErrCannotBatch = errors.New("candidate cannot be batched")
+
+type call3 struct {
+	Target       common.Address
+	AllowFailure bool
+	CallData     []byte
+}
+
+// Multicall3Batcher aggregates independent tx candidates into transactions to a Multicall3 compatible contract,
+// reducing the number of transactions, and so the nonces, needed to send them.
+//
+// Only candidates that call a contract without value or blobs can be batched. The calls are made by the
+// Multicall3 contract, so batching is only suitable for calls that don't depend on msg.sender.
+type Multicall3Batcher struct {
+	multicall common.Address
+	maxCalls  int
+}
+
+// NewMulticall3Batcher creates a Multicall3Batcher that aggregates up to maxCalls candidates
+// into each transaction to the multicall contract.
+func NewMulticall3Batcher(multicall common.Address, maxCalls int) *Multicall3Batcher {
+	return &Multicall3Batcher{
+		multicall: multicall,
+		maxCalls:  max(maxCalls, 1),
+	}
+}
+
+// CanBatch reports whether the candidate can be aggregated into a multicall transaction.
+func CanBatch(candidate TxCandidate) bool {
+	return candidate.To != nil && len(candidate.Blobs) == 0 && (candidate.Value == nil || candidate.Value.Sign() == 0)
+}
+
+// Aggregate combines the candidates into a single transaction to the multicall contract.
+// None of the calls are allowed to fail, so either all or none of the calls are made.
+// The gas limit of the candidates is ignored, and the gas limit of the multicall transaction is estimated instead.
+func (b *Multicall3Batcher) Aggregate(candidates []TxCandidate) (TxCandidate, error) {
+	calls := make([]call3, 0, len(candidates))
+	for i, candidate := range candidates {
+		if !CanBatch(candidate) {
+			return TxCandidate{}, fmt.Errorf("%w: candidate %d", ErrCannotBatch, i)
+		}
+		calls = append(calls, call3{Target: *candidate.To, CallData: candidate.TxData})
+	}
+	data, err := multicall3.Pack("aggregate3", calls)
+	if err != nil {
+		return TxCandidate{}, fmt.Errorf("failed to pack multicall: %w", err)
+	}
+	return TxCandidate{
+		TxData: data,
+		To:     &b.multicall,
+	}, nil
+}
+
+// SendBatched sends the candidates through the queue, aggregating the candidates that can be batched into
+// multicall transactions. Candidates that can't be batched are sent individually.
+//
+// The receipt of each candidate is sent on receiptCh with its id, so receiptCh receives exactly one receipt per
+// candidate. Batched candidates share the receipt of their multicall transaction. If a multicall transaction
+// fails, its candidates are retried individually so a single failing call doesn't prevent the others being made.
+func SendBatched[T any](q *Queue[T], b *Multicall3Batcher, ids []T, candidates []TxCandidate, receiptCh chan TxReceipt[T]) {
+	var batchIDs []T
+	var batch []TxCandidate
+	flush := func() {
+		switch len(batch) {
+		case 0:
+		case 1:
+			q.Send(batchIDs[0], batch[0], receiptCh)
+		default:
+			sendMulticall(q, b, batchIDs, batch, receiptCh)
+		}
+		batchIDs, batch = nil, nil
+	}
+	for i, candidate := range candidates {
+		if !CanBatch(candidate) {
+			q.Send(ids[i], candidate, receiptCh)
+			continue
+		}
+		batchIDs = append(batchIDs, ids[i])
+		batch = append(batch, candidate)
+		if len(batch) == b.maxCalls {
+			flush()
+		}
+	}
+	flush()
+}
+
+// sendMulticall sends the candidates as a single multicall transaction, and sends its receipt on receiptCh
+// for each of the candidates once it completes. The candidates are sent individually instead if the
+// multicall transaction can't be created or fails.
+func sendMulticall[T any](q *Queue[T], b *Multicall3Batcher, ids []T, candidates []TxCandidate, receiptCh chan TxReceipt[T]) {
+	sendEach := func() {
+		for i, candidate := range candidates {
+			q.Send(ids[i], candidate, receiptCh)
+		}
+	}
+	multicall, err := b.Aggregate(candidates)
+	if err != nil {
+		sendEach()
+		return
+	}
+	multicallCh := make(chan TxReceipt[T], 1)
+	q.Send(ids[0], multicall, multicallCh)
+	go func() {
+		rcpt := <-multicallCh
+		if rcpt.Err != nil || rcpt.Receipt == nil || rcpt.Receipt.Status != types.ReceiptStatusSuccessful {
+			sendEach()
+			return
+		}
+		for _, id := range ids {
+			receiptCh <- TxReceipt[T]{ID: id, Receipt: rcpt.Receipt}
+		}
+	}()
+}
@@ -0,0 +1,55 @@
+package txmgr
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+func TestCanBatch(t *testing.T) {
+	to := common.Address{0xaa}
+	require.True(t, CanBatch(TxCandidate{To: &to, TxData: []byte{1}}))
+	require.True(t, CanBatch(TxCandidate{To: &to, Value: new(big.Int)}))
+	require.False(t, CanBatch(TxCandidate{TxData: []byte{1}}), "contract creation")
+	require.False(t, CanBatch(TxCandidate{To: &to, Value: big.NewInt(1)}), "value transfer")
+	require.False(t, CanBatch(TxCandidate{To: &to, Blobs: []*eth.Blob{{}}}), "blob tx")
+}
+
+func TestMulticall3Batcher_Aggregate(t *testing.T) {
+	multicall := common.Address{0xca, 0x11}
+	to1, to2 := common.Address{0xaa}, common.Address{0xbb}
+	batcher := NewMulticall3Batcher(multicall, 10)
+
+	candidate, err := batcher.Aggregate([]TxCandidate{
+		{To: &to1, TxData: []byte{1, 2}, GasLimit: 50_000},
+		{To: &to2, TxData: []byte{3}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, multicall, *candidate.To)
+	require.Zero(t, candidate.GasLimit, "should estimate gas of the multicall")
+	require.Nil(t, candidate.Value)
+
+	method, err := multicall3.MethodById(candidate.TxData[:4])
+	require.NoError(t, err)
+	require.Equal(t, "aggregate3", method.Name)
+	args, err := method.Inputs.Unpack(candidate.TxData[4:])
+	require.NoError(t, err)
+	calls := args[0].([]struct {
+		Target       common.Address `json:"target"`
+		AllowFailure bool           `json:"allowFailure"`
+		CallData     []byte         `json:"callData"`
+	})
+	require.Len(t, calls, 2)
+	require.Equal(t, to1, calls[0].Target)
+	require.Equal(t, []byte{1, 2}, calls[0].CallData)
+	require.False(t, calls[0].AllowFailure)
+	require.Equal(t, to2, calls[1].Target)
+	require.Equal(t, []byte{3}, calls[1].CallData)
+
+	_, err = batcher.Aggregate([]TxCandidate{{To: &to1}, {TxData: []byte{1}}})
+	require.ErrorIs(t, err, ErrCannotBatch)
+}
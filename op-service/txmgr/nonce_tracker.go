@@ -0,0 +1,327 @@
+package txmgr
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// nonceTracker keeps track of the transactions signed by the tx manager by nonce, so that nonce gaps can be detected.
+// A nonce gap occurs when the send of a transaction is abandoned, e.g. because it timed out, while transactions with
+// later nonces are still waiting to be mined. Those later transactions can never be mined until the gap is filled.
+// Only nonces reserved by the tx manager are tracked, so nonces used by other senders are never cancelled.
+// The zero value is ready to use.
+type nonceTracker struct {
+	mu        sync.Mutex
+	txs       map[uint64]*trackedNonce
+	lastOwner uint64
+}
+
+type trackedNonce struct {
+	// tx is the latest transaction sent for the nonce.
+	tx *types.Transaction
+	// owner identifies the send that reserved the nonce.
+	owner uint64
+	// since is when the nonce was first tracked.
+	since time.Time
+	// abandonedAt is when the send of tx was abandoned.
+	// It is the zero time while tx is still being sent.
+	abandonedAt time.Time
+	// cancels is the number of cancellation transactions sent for the nonce.
+	cancels uint64
+	// lastAction is when a cancellation transaction was last sent or re-broadcast.
+	lastAction time.Time
+}
+
+type gapAction int
+
+const (
+	gapActionNone gapAction = iota
+	gapActionCancel
+	gapActionRebroadcast
+)
+
+// gapPolicy configures how nonce gaps are resolved.
+type gapPolicy struct {
+	// timeout is how long a nonce must be abandoned before it is cancelled. Zero disables cancellation.
+	timeout time.Duration
+	// interval is the minimum time between cancellation attempts for the same nonce.
+	interval time.Duration
+	// maxCancels is the maximum number of cancellation transactions sent for the same nonce.
+	maxCancels uint64
+}
+
+// reserve records tx as the transaction being sent for its nonce by a new send, which owns the nonce from now on.
+// It returns the owner of the nonce, which identifies the send.
+func (n *nonceTracker) reserve(tx *types.Transaction, now time.Time) uint64 {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.txs == nil {
+		n.txs = make(map[uint64]*trackedNonce)
+	}
+	n.lastOwner++
+	entry, ok := n.txs[tx.Nonce()]
+	if !ok {
+		n.txs[tx.Nonce()] = &trackedNonce{tx: tx, owner: n.lastOwner, since: now}
+		return n.lastOwner
+	}
+	// A new transaction for an abandoned nonce fills the gap, so it no longer needs to be cancelled.
+	entry.tx = tx
+	entry.owner = n.lastOwner
+	entry.abandonedAt = time.Time{}
+	entry.cancels = 0
+	return n.lastOwner
+}
+
+// track records tx as the latest transaction sent for its nonce, if the nonce is still owned by owner.
+func (n *nonceTracker) track(tx *types.Transaction, owner uint64) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if entry, ok := n.txs[tx.Nonce()]; ok && entry.owner == owner {
+		entry.tx = tx
+	}
+}
+
+// owns returns true if the nonce is still owned by owner, i.e. it hasn't been reserved by another send since.
+func (n *nonceTracker) owns(nonce uint64, owner uint64) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	entry, ok := n.txs[nonce]
+	return ok && entry.owner == owner
+}
+
+// abandon marks the nonce as abandoned, if it is still owned by owner.
+func (n *nonceTracker) abandon(nonce uint64, owner uint64, now time.Time) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if entry, ok := n.txs[nonce]; ok && entry.owner == owner {
+		entry.abandonedAt = now
+	}
+}
+
+// confirm stops tracking the nonce, and returns the number of nonces still tracked.
+func (n *nonceTracker) confirm(nonce uint64) int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	delete(n.txs, nonce)
+	return len(n.txs)
+}
+
+// prune stops tracking all nonces below next, the nonce of the next transaction to be mined.
+func (n *nonceTracker) prune(next uint64) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for nonce := range n.txs {
+		if nonce < next {
+			delete(n.txs, nonce)
+		}
+	}
+}
+
+// stuckDuration returns how long the next nonce to be mined has been tracked without being mined.
+func (n *nonceTracker) stuckDuration(next uint64, now time.Time) time.Duration {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if entry, ok := n.txs[next]; ok {
+		return now.Sub(entry.since)
+	}
+	return 0
+}
+
+// highestSending returns the highest nonce of a transaction that is still being sent.
+// It returns false if no transaction is being sent.
+func (n *nonceTracker) highestSending() (uint64, bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	var highest uint64
+	found := false
+	for nonce, entry := range n.txs {
+		if entry.abandonedAt.IsZero() && (!found || nonce > highest) {
+			highest = nonce
+			found = true
+		}
+	}
+	return highest, found
+}
+
+// nextAction returns the action to take to resolve a gap at the given nonce, which is blocking later transactions.
+// Nonces that are not tracked weren't reserved by the tx manager, so no action is taken for them.
+// If an action is returned, the latest transaction for the nonce is returned with it.
+func (n *nonceTracker) nextAction(nonce uint64, now time.Time, policy gapPolicy) (gapAction, *types.Transaction) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	entry, ok := n.txs[nonce]
+	if !ok {
+		return gapActionNone, nil
+	}
+	if entry.abandonedAt.IsZero() || policy.timeout == 0 ||
+		now.Sub(entry.abandonedAt) < policy.timeout || now.Sub(entry.lastAction) < policy.interval {
+		return gapActionNone, nil
+	}
+	if entry.cancels < policy.maxCancels {
+		entry.lastAction = now
+		return gapActionCancel, entry.tx
+	}
+	entry.lastAction = now
+	return gapActionRebroadcast, entry.tx
+}
+
+// cancelled records tx as a cancellation transaction sent for its nonce.
+func (n *nonceTracker) cancelled(tx *types.Transaction) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if entry, ok := n.txs[tx.Nonce()]; ok {
+		entry.tx = tx
+		entry.cancels++
+	}
+}
+
+// resolveNonceGaps checks whether the transactions being sent are blocked by lower nonces that have not been mined,
+// and records how long the next nonce has been stuck. Nonces that were abandoned for longer than the NonceGapTimeout
+// are filled with cancellation transactions, with fees escalated on every attempt until NonceGapMaxCancels is reached.
+// All sends call it on every resubmission tick, but the check runs at most once per resubmission timeout.
+func (m *SimpleTxManager) resolveNonceGaps(ctx context.Context) {
+	if !m.gapCheckLock.TryLock() {
+		// Another send is already checking for nonce gaps.
+		return
+	}
+	defer m.gapCheckLock.Unlock()
+	interval := m.GetBumpFeeRetryTime()
+	if time.Since(m.lastGapCheck) < interval {
+		return
+	}
+	m.lastGapCheck = time.Now()
+
+	cCtx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
+	next, err := m.backend.NonceAt(cCtx, m.cfg.From, nil)
+	cancel()
+	if err != nil {
+		m.metr.RPCError()
+		m.l.Warn("Failed to fetch nonce to check for nonce gaps", "err", err)
+		return
+	}
+	now := time.Now()
+	m.nonces.prune(next)
+	if err := m.blobTxs.prune(next); err != nil {
+		m.l.Warn("Failed to prune persisted blob transactions", "err", err)
+	}
+	m.metr.RecordStuckNonceDuration(m.nonces.stuckDuration(next, now))
+
+	blocked, ok := m.nonces.highestSending()
+	if !ok {
+		return
+	}
+	policy := gapPolicy{
+		timeout:    m.cfg.NonceGapTimeout,
+		interval:   interval,
+		maxCancels: m.cfg.NonceGapMaxCancels,
+	}
+	for nonce := next; nonce < blocked; nonce++ {
+		action, prev := m.nonces.nextAction(nonce, now, policy)
+		switch action {
+		case gapActionCancel:
+			cancelTx, err := m.makeCancelTx(ctx, nonce, prev)
+			if err != nil {
+				m.l.Warn("Failed to create nonce gap cancellation transaction", "nonce", nonce, "err", err)
+				continue
+			}
+			l := m.txLogger(cancelTx, true)
+			if err := m.blobTxs.put(cancelTx); err != nil {
+				l.Warn("Failed to persist nonce gap cancellation transaction", "err", err)
+			}
+			if err := m.publishGapTx(ctx, cancelTx); err != nil {
+				l.Warn("Failed to publish nonce gap cancellation transaction", "err", err)
+				continue
+			}
+			l.Info("Published nonce gap cancellation transaction", "blocked_nonce", blocked)
+			m.nonces.cancelled(cancelTx)
+		case gapActionRebroadcast:
+			if err := m.publishGapTx(ctx, prev); err != nil {
+				m.txLogger(prev, false).Warn("Failed to re-broadcast nonce gap cancellation transaction", "err", err)
+			}
+		}
+	}
+}
+
+// publishGapTx publishes a transaction that fills a nonce gap.
+// It is not an error if the transaction is already known, or the nonce has been filled in the meantime.
+func (m *SimpleTxManager) publishGapTx(ctx context.Context, tx *types.Transaction) error {
+	cCtx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
+	defer cancel()
+	err := m.backend.SendTransaction(cCtx, tx)
+	if errStringMatch(err, txpool.ErrAlreadyKnown) || errStringMatch(err, core.ErrNonceTooLow) {
+		return nil
+	}
+	return err
+}
+
+// makeCancelTx creates a transaction that fills the given nonce with a zero value transfer to the sender.
+// If prev is not nil, the fees are bumped to be able to replace it in the mempool.
+func (m *SimpleTxManager) makeCancelTx(ctx context.Context, nonce uint64, prev *types.Transaction) (*types.Transaction, error) {
+	tip, baseFee, blobBaseFee, err := m.SuggestGasPriceCaps(ctx)
+	if err != nil {
+		return nil, err
+	}
+	gasTipCap, gasFeeCap := tip, calcGasFeeCap(baseFee, tip)
+	isBlobTx := prev != nil && prev.Type() == types.BlobTxType
+	if prev != nil {
+		gasTipCap, gasFeeCap = updateFees(prev.GasTipCap(), prev.GasFeeCap(), tip, baseFee, isBlobTx, m.l)
+		if err := m.checkLimits(tip, baseFee, gasTipCap, gasFeeCap); err != nil {
+			return nil, err
+		}
+	}
+
+	var txMessage types.TxData
+	if isBlobTx {
+		// Blob transactions can only be replaced by blob transactions, so the cancellation must carry a blob too.
+		if blobBaseFee == nil {
+			return nil, errors.New("expected non-nil blobBaseFee")
+		}
+		blobFeeCap := m.calcBlobFeeCap(blobBaseFee)
+		if bumped := calcThresholdValue(prev.BlobGasFeeCap(), true); bumped.Cmp(blobFeeCap) > 0 {
+			blobFeeCap = bumped
+		}
+		if err := m.checkBlobFeeLimits(blobBaseFee, blobFeeCap); err != nil {
+			return nil, err
+		}
+		sidecar, blobHashes, err := MakeSidecar([]*eth.Blob{{}})
+		if err != nil {
+			return nil, err
+		}
+		message := &types.BlobTx{
+			Nonce:      nonce,
+			To:         m.cfg.From,
+			Gas:        params.TxGas,
+			BlobHashes: blobHashes,
+			Sidecar:    sidecar,
+		}
+		if err := finishBlobTx(message, m.chainID, gasTipCap, gasFeeCap, blobFeeCap, common.Big0); err != nil {
+			return nil, err
+		}
+		txMessage = message
+	} else {
+		txMessage = &types.DynamicFeeTx{
+			ChainID:   m.chainID,
+			Nonce:     nonce,
+			To:        &m.cfg.From,
+			GasTipCap: gasTipCap,
+			GasFeeCap: gasFeeCap,
+			Value:     new(big.Int),
+			Gas:       params.TxGas,
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
+	defer cancel()
+	return m.cfg.Signer(ctx, m.cfg.From, types.NewTx(txMessage))
+}
@@ -0,0 +1,257 @@
+package txmgr
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+)
+
+func nonceTx(nonce uint64, tip int64) *types.Transaction {
+	return types.NewTx(&types.DynamicFeeTx{
+		Nonce:     nonce,
+		GasTipCap: big.NewInt(tip),
+		GasFeeCap: big.NewInt(tip * 10),
+	})
+}
+
+func TestNonceTracker(t *testing.T) {
+	start := time.Unix(1000, 0)
+	policy := gapPolicy{timeout: time.Minute, interval: 10 * time.Second, maxCancels: 2}
+
+	t.Run("ActiveTxNotCancelled", func(t *testing.T) {
+		var n nonceTracker
+		tx := nonceTx(1, 1)
+		n.reserve(tx, start)
+		action, _ := n.nextAction(1, start.Add(time.Hour), policy)
+		require.Equal(t, gapActionNone, action)
+	})
+
+	t.Run("CancelAbandonedAfterTimeout", func(t *testing.T) {
+		var n nonceTracker
+		tx := nonceTx(1, 1)
+		owner := n.reserve(tx, start)
+		n.abandon(tx.Nonce(), owner, start)
+
+		action, _ := n.nextAction(1, start.Add(time.Minute-1), policy)
+		require.Equal(t, gapActionNone, action, "not cancelled before timeout")
+
+		now := start.Add(time.Minute)
+		action, prev := n.nextAction(1, now, policy)
+		require.Equal(t, gapActionCancel, action)
+		require.Equal(t, tx, prev)
+		cancel1 := nonceTx(1, 2)
+		n.cancelled(cancel1)
+
+		action, _ = n.nextAction(1, now.Add(time.Second), policy)
+		require.Equal(t, gapActionNone, action, "not cancelled again before interval")
+
+		now = now.Add(policy.interval)
+		action, prev = n.nextAction(1, now, policy)
+		require.Equal(t, gapActionCancel, action, "escalate cancellation")
+		require.Equal(t, cancel1, prev)
+		cancel2 := nonceTx(1, 3)
+		n.cancelled(cancel2)
+
+		now = now.Add(policy.interval)
+		action, prev = n.nextAction(1, now, policy)
+		require.Equal(t, gapActionRebroadcast, action, "re-broadcast once max cancels is reached")
+		require.Equal(t, cancel2, prev)
+	})
+
+	t.Run("UnknownNonceNotCancelled", func(t *testing.T) {
+		var n nonceTracker
+		action, _ := n.nextAction(1, start, policy)
+		require.Equal(t, gapActionNone, action)
+		action, _ = n.nextAction(1, start.Add(time.Hour), policy)
+		require.Equal(t, gapActionNone, action, "nonces not reserved by the tx manager are never cancelled")
+	})
+
+	t.Run("NewTxFillsGap", func(t *testing.T) {
+		var n nonceTracker
+		tx := nonceTx(1, 1)
+		owner := n.reserve(tx, start)
+		n.abandon(tx.Nonce(), owner, start)
+		n.reserve(nonceTx(1, 5), start.Add(time.Second))
+		action, _ := n.nextAction(1, start.Add(time.Hour), policy)
+		require.Equal(t, gapActionNone, action)
+	})
+
+	t.Run("IgnoreAbandonOfReusedNonce", func(t *testing.T) {
+		var n nonceTracker
+		tx := nonceTx(1, 1)
+		owner := n.reserve(tx, start)
+		reused := nonceTx(1, 5)
+		newOwner := n.reserve(reused, start.Add(time.Second))
+		require.False(t, n.owns(1, owner))
+		require.True(t, n.owns(1, newOwner))
+
+		// The previous send can neither replace the tx nor abandon the nonce of the new send
+		n.track(nonceTx(1, 2), owner)
+		n.abandon(1, owner, start.Add(time.Second))
+		action, _ := n.nextAction(1, start.Add(time.Hour), policy)
+		require.Equal(t, gapActionNone, action)
+
+		n.abandon(1, newOwner, start.Add(time.Second))
+		action, prev := n.nextAction(1, start.Add(time.Hour), policy)
+		require.Equal(t, gapActionCancel, action)
+		require.Equal(t, reused, prev)
+	})
+
+	t.Run("DisabledCancellation", func(t *testing.T) {
+		var n nonceTracker
+		tx := nonceTx(1, 1)
+		owner := n.reserve(tx, start)
+		n.abandon(tx.Nonce(), owner, start)
+		action, _ := n.nextAction(1, start.Add(time.Hour), gapPolicy{interval: time.Second, maxCancels: 2})
+		require.Equal(t, gapActionNone, action)
+	})
+
+	t.Run("HighestSending", func(t *testing.T) {
+		var n nonceTracker
+		_, ok := n.highestSending()
+		require.False(t, ok)
+		n.reserve(nonceTx(1, 1), start)
+		owner := n.reserve(nonceTx(3, 1), start)
+		n.reserve(nonceTx(2, 1), start)
+		highest, ok := n.highestSending()
+		require.True(t, ok)
+		require.EqualValues(t, 3, highest)
+
+		n.abandon(3, owner, start)
+		highest, ok = n.highestSending()
+		require.True(t, ok)
+		require.EqualValues(t, 2, highest, "abandoned txs are not being sent")
+	})
+
+	t.Run("StuckDurationAndPrune", func(t *testing.T) {
+		var n nonceTracker
+		n.reserve(nonceTx(1, 1), start)
+		n.reserve(nonceTx(2, 1), start.Add(time.Second))
+		require.Equal(t, time.Minute, n.stuckDuration(1, start.Add(time.Minute)))
+		require.Zero(t, n.stuckDuration(3, start.Add(time.Minute)))
+
+		n.prune(2)
+		require.Zero(t, n.stuckDuration(1, start.Add(time.Minute)))
+		require.Equal(t, 0, n.confirm(2))
+	})
+}
+
+func TestResolveNonceGaps(t *testing.T) {
+	setup := func(t *testing.T) (*testHarness, func() []*types.Transaction) {
+		cfg := configWithNumConfs(1)
+		cfg.NonceGapTimeout = time.Minute
+		cfg.NonceGapMaxCancels = 2
+		cfg.ChainID = big.NewInt(1)
+		cfg.NetworkTimeout = time.Second
+		cfg.From = common.Address{0xaa}
+		h := newTestHarnessWithConfig(t, cfg)
+		var mu sync.Mutex
+		var sent []*types.Transaction
+		h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+			mu.Lock()
+			defer mu.Unlock()
+			sent = append(sent, tx)
+			return nil
+		})
+		return h, func() []*types.Transaction {
+			mu.Lock()
+			defer mu.Unlock()
+			return sent
+		}
+	}
+	// reserveBlocked starts tracking a tx that is blocked by the nonce before it.
+	reserveBlocked := func(h *testHarness) {
+		h.mgr.nonces.reserve(nonceTx(startingNonce+1, 1), time.Now())
+	}
+	abandon := func(h *testHarness, tx *types.Transaction) {
+		owner := h.mgr.nonces.reserve(tx, time.Now().Add(-time.Hour))
+		h.mgr.nonces.abandon(tx.Nonce(), owner, time.Now().Add(-time.Hour))
+	}
+
+	t.Run("CancelAbandonedTx", func(t *testing.T) {
+		h, sent := setup(t)
+		abandoned, err := h.mgr.craftTx(context.Background(), h.createTxCandidate())
+		require.NoError(t, err)
+		require.EqualValues(t, startingNonce, abandoned.Nonce())
+		abandon(h, abandoned)
+		reserveBlocked(h)
+
+		h.mgr.resolveNonceGaps(context.Background())
+		txs := sent()
+		require.Len(t, txs, 1)
+		cancelTx := txs[0]
+		require.Equal(t, abandoned.Nonce(), cancelTx.Nonce())
+		require.Equal(t, h.cfg.From, *cancelTx.To())
+		require.Zero(t, cancelTx.Value().Sign())
+		require.Equal(t, params.TxGas, cancelTx.Gas())
+		require.Equal(t, uint8(types.DynamicFeeTxType), cancelTx.Type())
+		require.True(t, cancelTx.GasTipCap().Cmp(abandoned.GasTipCap()) > 0, "tip must be bumped")
+		require.True(t, cancelTx.GasFeeCap().Cmp(abandoned.GasFeeCap()) > 0, "fee cap must be bumped")
+
+		// Not cancelled again before the resubmission timeout
+		h.mgr.lastGapCheck = time.Time{}
+		h.mgr.resolveNonceGaps(context.Background())
+		require.Len(t, sent(), 1)
+	})
+
+	t.Run("CancelAbandonedBlobTx", func(t *testing.T) {
+		h, sent := setup(t)
+		abandoned, err := h.mgr.craftTx(context.Background(), h.createBlobTxCandidate())
+		require.NoError(t, err)
+		abandon(h, abandoned)
+		reserveBlocked(h)
+
+		h.mgr.resolveNonceGaps(context.Background())
+		txs := sent()
+		require.Len(t, txs, 1)
+		cancelTx := txs[0]
+		require.Equal(t, uint8(types.BlobTxType), cancelTx.Type(), "blob txs can only be replaced by blob txs")
+		require.Equal(t, abandoned.Nonce(), cancelTx.Nonce())
+		require.Len(t, cancelTx.BlobHashes(), 1)
+		require.True(t, cancelTx.BlobGasFeeCap().Cmp(abandoned.BlobGasFeeCap()) > 0, "blob fee cap must be bumped")
+	})
+
+	t.Run("IgnoreActiveTx", func(t *testing.T) {
+		h, sent := setup(t)
+		active, err := h.mgr.craftTx(context.Background(), h.createTxCandidate())
+		require.NoError(t, err)
+		h.mgr.nonces.reserve(active, time.Now().Add(-time.Hour))
+		reserveBlocked(h)
+
+		h.mgr.resolveNonceGaps(context.Background())
+		require.Empty(t, sent())
+	})
+
+	t.Run("IgnoreNonceNotReserved", func(t *testing.T) {
+		h, sent := setup(t)
+		// The gap at startingNonce was not reserved by this tx manager, e.g. it is used by another sender.
+		reserveBlocked(h)
+		h.mgr.resolveNonceGaps(context.Background())
+		h.mgr.lastGapCheck = time.Now().Add(-time.Hour)
+		h.mgr.resolveNonceGaps(context.Background())
+		require.Empty(t, sent())
+	})
+
+	t.Run("NotBlocked", func(t *testing.T) {
+		h, sent := setup(t)
+		h.mgr.nonces.reserve(nonceTx(startingNonce, 1), time.Now())
+		h.mgr.resolveNonceGaps(context.Background())
+		require.Empty(t, sent())
+	})
+
+	t.Run("OneNonceFetchPerInterval", func(t *testing.T) {
+		h, _ := setup(t)
+		reserveBlocked(h)
+		h.mgr.resolveNonceGaps(context.Background())
+		h.mgr.resolveNonceGaps(context.Background())
+		h.mgr.resolveNonceGaps(context.Background())
+		require.Equal(t, 1, h.backend.nonceAtCalls())
+	})
+}
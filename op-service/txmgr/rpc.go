@@ -37,6 +37,14 @@ func (a *SimpleTxmgrAPI) SetMinBlobFee(_ context.Context, val *big.Int) {
 	a.mgr.SetMinBlobFee(val)
 }
 
+func (a *SimpleTxmgrAPI) GetMaxBlobFee(_ context.Context) *big.Int {
+	return a.mgr.GetMaxBlobFee()
+}
+
+func (a *SimpleTxmgrAPI) SetMaxBlobFee(_ context.Context, val *big.Int) {
+	a.mgr.SetMaxBlobFee(val)
+}
+
 func (a *SimpleTxmgrAPI) GetFeeThreshold(_ context.Context) *big.Int {
 	return a.mgr.GetFeeThreshold()
 }
@@ -14,6 +14,7 @@ func TestTxmgrRPC(t *testing.T) {
 	minBaseFeeInit := big.NewInt(1000)
 	minPriorityFeeInit := big.NewInt(2000)
 	minBlobFeeInit := big.NewInt(3000)
+	maxBlobFeeInit := big.NewInt(5000)
 	feeThresholdInit := big.NewInt(4000)
 	bumpFeeRetryTimeInit := int64(100)
 
@@ -21,6 +22,7 @@ func TestTxmgrRPC(t *testing.T) {
 	cfg.MinBaseFee.Store(minBaseFeeInit)
 	cfg.MinTipCap.Store(minPriorityFeeInit)
 	cfg.MinBlobTxFee.Store(minBlobFeeInit)
+	cfg.MaxBlobFee.Store(maxBlobFeeInit)
 	cfg.FeeLimitThreshold.Store(feeThresholdInit)
 	cfg.ResubmissionTimeout.Store(bumpFeeRetryTimeInit)
 
@@ -52,6 +54,7 @@ func TestTxmgrRPC(t *testing.T) {
 		{"MinBaseFee", minBaseFeeInit},
 		{"MinPriorityFee", minPriorityFeeInit},
 		{"MinBlobFee", minBlobFeeInit},
+		{"MaxBlobFee", maxBlobFeeInit},
 		{"FeeThreshold", feeThresholdInit},
 		{"BumpFeeRetryTime", big.NewInt(bumpFeeRetryTimeInit)},
 	}
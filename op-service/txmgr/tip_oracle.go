@@ -0,0 +1,192 @@
+package txmgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// minSimilarTxs is the minimum number of similar transactions to suggest a tip from. With fewer similar
+// transactions, the tips of all transactions of the same kind are used instead.
+const minSimilarTxs = 10
+
+var ErrNoInclusionData = errors.New("no transactions included in recent blocks")
+
+// TxShape describes the kind and size of the transaction that a tip is suggested for.
+// The zero TxShape matches transactions of any kind and size.
+type TxShape struct {
+	// Blobs is the number of blobs of a blob transaction.
+	Blobs int
+	// CalldataSize is the calldata size in bytes of a non-blob transaction.
+	CalldataSize int
+}
+
+func shapeOf(tx *types.Transaction) TxShape {
+	if blobs := len(tx.BlobHashes()); blobs > 0 {
+		return TxShape{Blobs: blobs}
+	}
+	return TxShape{CalldataSize: len(tx.Data())}
+}
+
+// TipOracle suggests the gas tip cap of transactions of the given shape.
+type TipOracle interface {
+	SuggestGasTipCap(ctx context.Context, shape TxShape) (*big.Int, error)
+}
+
+// BlockFetcher fetches L1 blocks with their transactions.
+type BlockFetcher interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+	BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error)
+}
+
+// includedTx is the effective tip paid by a transaction included in a recent block.
+type includedTx struct {
+	shape TxShape
+	tip   *big.Int
+}
+
+// InclusionTipOracle suggests the tip that similar transactions included in recent blocks paid, at the percentile
+// of the target inclusion probability. Transactions are similar if they are of the same kind, blob or calldata,
+// and their blob count or calldata size is within a factor of two.
+//
+// The tips of the included transactions are a proxy for the competition for inclusion: a lower target
+// probability cuts costs when blocks aren't full, while a higher one outbids more of the recent transactions.
+type InclusionTipOracle struct {
+	log         log.Logger
+	backend     BlockFetcher
+	blocks      uint64
+	probability float64
+
+	mu    sync.Mutex
+	cache map[uint64][]includedTx
+}
+
+// NewInclusionTipOracle creates an InclusionTipOracle that suggests tips from the given number of most recent blocks,
+// targeting the given inclusion probability, which must be in (0, 1].
+func NewInclusionTipOracle(log log.Logger, backend BlockFetcher, blocks uint64, probability float64) *InclusionTipOracle {
+	return &InclusionTipOracle{
+		log:         log,
+		backend:     backend,
+		blocks:      max(blocks, 1),
+		probability: probability,
+		cache:       make(map[uint64][]includedTx),
+	}
+}
+
+func (o *InclusionTipOracle) SuggestGasTipCap(ctx context.Context, shape TxShape) (*big.Int, error) {
+	txs, err := o.recentTxs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var similar, sameKind []*big.Int
+	for _, tx := range txs {
+		if !sameKindAs(shape, tx.shape) {
+			continue
+		}
+		sameKind = append(sameKind, tx.tip)
+		if similarSize(shape, tx.shape) {
+			similar = append(similar, tx.tip)
+		}
+	}
+	tips := similar
+	if len(tips) < minSimilarTxs {
+		tips = sameKind
+	}
+	if len(tips) == 0 {
+		return nil, fmt.Errorf("%w: no txs like %+v in the last %d blocks", ErrNoInclusionData, shape, o.blocks)
+	}
+	sort.Slice(tips, func(i, j int) bool { return tips[i].Cmp(tips[j]) < 0 })
+	tip := tips[percentileIndex(len(tips), o.probability)]
+	o.log.Debug("Suggested tip from recent inclusions", "tip", tip, "shape", shape, "samples", len(tips), "similar", len(similar))
+	return new(big.Int).Set(tip), nil
+}
+
+// recentTxs returns the transactions included in the recent blocks, fetching the blocks that aren't cached yet.
+func (o *InclusionTipOracle) recentTxs(ctx context.Context) ([]includedTx, error) {
+	head, err := o.backend.BlockNumber(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the latest block number: %w", err)
+	}
+	start := uint64(0)
+	if head >= o.blocks {
+		start = head - o.blocks + 1
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for number := range o.cache {
+		if number < start || number > head {
+			delete(o.cache, number)
+		}
+	}
+	var txs []includedTx
+	for number := start; number <= head; number++ {
+		blockTxs, ok := o.cache[number]
+		if !ok {
+			blockTxs, err = o.fetchBlock(ctx, number)
+			if err != nil {
+				return nil, err
+			}
+			o.cache[number] = blockTxs
+		}
+		txs = append(txs, blockTxs...)
+	}
+	return txs, nil
+}
+
+func (o *InclusionTipOracle) fetchBlock(ctx context.Context, number uint64) ([]includedTx, error) {
+	block, err := o.backend.BlockByNumber(ctx, new(big.Int).SetUint64(number))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch block %d: %w", number, err)
+	}
+	baseFee := block.BaseFee()
+	if baseFee == nil {
+		return nil, errors.New("txmgr does not support pre-london blocks that do not have a base fee")
+	}
+	txs := make([]includedTx, 0, len(block.Transactions()))
+	for _, tx := range block.Transactions() {
+		if tx.Type() == types.DepositTxType {
+			continue
+		}
+		tip, err := tx.EffectiveGasTip(baseFee)
+		if err != nil {
+			continue
+		}
+		txs = append(txs, includedTx{shape: shapeOf(tx), tip: tip})
+	}
+	return txs, nil
+}
+
+func sameKindAs(shape, other TxShape) bool {
+	if shape == (TxShape{}) {
+		return true
+	}
+	return (shape.Blobs > 0) == (other.Blobs > 0)
+}
+
+func similarSize(shape, other TxShape) bool {
+	if shape == (TxShape{}) {
+		return true
+	}
+	if shape.Blobs > 0 {
+		return withinFactorTwo(shape.Blobs, other.Blobs)
+	}
+	return withinFactorTwo(shape.CalldataSize, other.CalldataSize)
+}
+
+func withinFactorTwo(a, b int) bool {
+	return b >= a/2 && b <= a*2
+}
+
+// percentileIndex returns the index of the element at the given percentile, in (0, 1], of n sorted elements.
+func percentileIndex(n int, percentile float64) int {
+	i := int(math.Ceil(percentile*float64(n))) - 1
+	return min(max(i, 0), n-1)
+}
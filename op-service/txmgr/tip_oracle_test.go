@@ -0,0 +1,178 @@
+package txmgr
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type stubBlockFetcher struct {
+	blocks  map[uint64]*types.Block
+	head    uint64
+	fetches int
+}
+
+func (s *stubBlockFetcher) BlockNumber(_ context.Context) (uint64, error) {
+	return s.head, nil
+}
+
+func (s *stubBlockFetcher) BlockByNumber(_ context.Context, number *big.Int) (*types.Block, error) {
+	s.fetches++
+	block, ok := s.blocks[number.Uint64()]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return block, nil
+}
+
+func (s *stubBlockFetcher) addBlock(number uint64, baseFee int64, txs ...*types.Transaction) {
+	header := &types.Header{Number: new(big.Int).SetUint64(number), BaseFee: big.NewInt(baseFee)}
+	s.blocks[number] = types.NewBlockWithHeader(header).WithBody(types.Body{Transactions: txs})
+	s.head = max(s.head, number)
+}
+
+func calldataTx(tip int64, size int) *types.Transaction {
+	return types.NewTx(&types.DynamicFeeTx{
+		GasTipCap: big.NewInt(tip),
+		GasFeeCap: big.NewInt(1_000_000),
+		Data:      make([]byte, size),
+	})
+}
+
+func blobTx(tip int64, blobs int) *types.Transaction {
+	return types.NewTx(&types.BlobTx{
+		GasTipCap:  uint256.NewInt(uint64(tip)),
+		GasFeeCap:  uint256.NewInt(1_000_000),
+		BlobFeeCap: uint256.NewInt(1),
+		BlobHashes: make([]common.Hash, blobs),
+	})
+}
+
+func newTestTipOracle(t *testing.T, blocks uint64, probability float64) (*InclusionTipOracle, *stubBlockFetcher) {
+	fetcher := &stubBlockFetcher{blocks: make(map[uint64]*types.Block)}
+	return NewInclusionTipOracle(testlog.Logger(t, log.LevelInfo), fetcher, blocks, probability), fetcher
+}
+
+func TestInclusionTipOracle_Percentile(t *testing.T) {
+	oracle, fetcher := newTestTipOracle(t, 2, 0.9)
+	var txs []*types.Transaction
+	for i := int64(1); i <= 20; i++ {
+		txs = append(txs, calldataTx(i*10, 1000))
+	}
+	fetcher.addBlock(1, 100, txs[:10]...)
+	fetcher.addBlock(2, 100, txs[10:]...)
+
+	tip, err := oracle.SuggestGasTipCap(context.Background(), TxShape{CalldataSize: 1200})
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(180), tip)
+
+	oracle.probability = 0.5
+	tip, err = oracle.SuggestGasTipCap(context.Background(), TxShape{CalldataSize: 1200})
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(100), tip)
+}
+
+func TestInclusionTipOracle_EffectiveTip(t *testing.T) {
+	oracle, fetcher := newTestTipOracle(t, 1, 1)
+	// The fee cap limits the tip paid above the base fee.
+	fetcher.addBlock(1, 100, types.NewTx(&types.DynamicFeeTx{
+		GasTipCap: big.NewInt(500),
+		GasFeeCap: big.NewInt(150),
+	}))
+	tip, err := oracle.SuggestGasTipCap(context.Background(), TxShape{})
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(50), tip)
+}
+
+func TestInclusionTipOracle_SimilarTxs(t *testing.T) {
+	oracle, fetcher := newTestTipOracle(t, 1, 1)
+	var txs []*types.Transaction
+	for i := 0; i < minSimilarTxs; i++ {
+		txs = append(txs, calldataTx(10, 100_000), blobTx(30, 6))
+	}
+	txs = append(txs, calldataTx(20, 100), blobTx(40, 1))
+	fetcher.addBlock(1, 100, txs...)
+
+	tip, err := oracle.SuggestGasTipCap(context.Background(), TxShape{CalldataSize: 120_000})
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(10), tip, "large calldata txs")
+
+	tip, err = oracle.SuggestGasTipCap(context.Background(), TxShape{Blobs: 4})
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(30), tip, "blob txs with many blobs")
+
+	// Falls back to all txs of the same kind if there are too few similar txs.
+	tip, err = oracle.SuggestGasTipCap(context.Background(), TxShape{Blobs: 1})
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(40), tip, "blob txs")
+
+	tip, err = oracle.SuggestGasTipCap(context.Background(), TxShape{})
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(40), tip, "any txs")
+}
+
+func TestInclusionTipOracle_NoData(t *testing.T) {
+	oracle, fetcher := newTestTipOracle(t, 1, 1)
+	fetcher.addBlock(1, 100, calldataTx(10, 100))
+	_, err := oracle.SuggestGasTipCap(context.Background(), TxShape{Blobs: 1})
+	require.ErrorIs(t, err, ErrNoInclusionData)
+}
+
+func TestInclusionTipOracle_CachesBlocks(t *testing.T) {
+	oracle, fetcher := newTestTipOracle(t, 2, 1)
+	fetcher.addBlock(1, 100, calldataTx(10, 100))
+	fetcher.addBlock(2, 100, calldataTx(20, 100))
+	_, err := oracle.SuggestGasTipCap(context.Background(), TxShape{})
+	require.NoError(t, err)
+	require.Equal(t, 2, fetcher.fetches)
+
+	fetcher.addBlock(3, 100, calldataTx(5, 100))
+	tip, err := oracle.SuggestGasTipCap(context.Background(), TxShape{})
+	require.NoError(t, err)
+	require.Equal(t, 3, fetcher.fetches, "only the new block is fetched")
+	require.Equal(t, big.NewInt(20), tip)
+	require.Len(t, oracle.cache, 2, "blocks outside the window are evicted")
+}
+
+type stubTipOracle struct {
+	tip    *big.Int
+	err    error
+	shapes []TxShape
+}
+
+func (s *stubTipOracle) SuggestGasTipCap(_ context.Context, shape TxShape) (*big.Int, error) {
+	s.shapes = append(s.shapes, shape)
+	return s.tip, s.err
+}
+
+func TestTxMgr_CraftTxWithTipOracle(t *testing.T) {
+	cfg := configWithNumConfs(1)
+	oracle := &stubTipOracle{tip: big.NewInt(7)}
+	cfg.TipOracle = oracle
+	h := newTestHarnessWithConfig(t, cfg)
+
+	tx, err := h.mgr.craftTx(context.Background(), h.createTxCandidate())
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(7), tx.GasTipCap())
+	require.Equal(t, calcGasFeeCap(h.gasPricer.baseFee(), big.NewInt(7)), tx.GasFeeCap())
+
+	_, err = h.mgr.craftTx(context.Background(), h.createBlobTxCandidate())
+	require.NoError(t, err)
+	require.Equal(t, []TxShape{{CalldataSize: 3}, {Blobs: 2}}, oracle.shapes)
+
+	// Falls back to the tip suggested by the backend if the oracle fails.
+	oracle.err = errors.New("boom")
+	gasTipCap, _, _ := h.gasPricer.feesForEpoch(h.gasPricer.epoch + 1)
+	tx, err = h.mgr.craftTx(context.Background(), h.createTxCandidate())
+	require.NoError(t, err)
+	require.Equal(t, gasTipCap, tx.GasTipCap())
+}
@@ -44,6 +44,7 @@ var (
 
 	ErrBlobFeeLimit = errors.New("blob fee limit reached")
 	ErrClosed       = errors.New("transaction manager is closed")
+	ErrTxCancelled  = errors.New("transaction cancelled at its deadline")
 )
 
 // TxManager is an interface that allows callers to reliably publish txs,
@@ -126,6 +127,16 @@ type SimpleTxManager struct {
 	nonce     *uint64
 	nonceLock sync.RWMutex
 
+	nonces nonceTracker
+	// gapCheckLock is held while checking for nonce gaps, so that only one send checks at a time.
+	gapCheckLock sync.Mutex
+	lastGapCheck time.Time
+	// blobTxs persists pending blob transactions. It is nil if no StateDir is configured.
+	blobTxs *blobTxStore
+	// restored are the pending blob transactions restored from the StateDir,
+	// which are re-broadcast when the nonce is first fetched.
+	restored []*types.Transaction
+
 	pending atomic.Int64
 
 	closed atomic.Bool
@@ -145,14 +156,31 @@ func NewSimpleTxManagerFromConfig(name string, l log.Logger, m metrics.TxMetrice
 	if err := conf.Check(); err != nil {
 		return nil, fmt.Errorf("invalid config: %w", err)
 	}
-	return &SimpleTxManager{
+	mgr := &SimpleTxManager{
 		chainID: conf.ChainID,
 		name:    name,
 		cfg:     conf,
 		backend: conf.Backend,
 		l:       l.New("service", name),
 		metr:    m,
-	}, nil
+	}
+	if conf.StateDir != "" {
+		store, txs, err := openBlobTxStore(mgr.l, conf.StateDir, conf.ChainID, conf.From)
+		if err != nil {
+			return nil, err
+		}
+		now := time.Now()
+		for _, tx := range txs {
+			// The sends of restored txs are not resumed, so they are abandoned, and can be cancelled once they block
+			// later txs.
+			owner := mgr.nonces.reserve(tx, now)
+			mgr.nonces.abandon(tx.Nonce(), owner, now)
+			mgr.txLogger(tx, false).Info("Restored pending blob transaction")
+		}
+		mgr.blobTxs = store
+		mgr.restored = txs
+	}
+	return mgr, nil
 }
 
 func (m *SimpleTxManager) From() common.Address {
@@ -206,6 +234,12 @@ type TxCandidate struct {
 	GasLimit uint64
 	// Value is the value to be used in the constructed tx.
 	Value *big.Int
+	// Deadline is when to stop bumping the fees of the tx if it hasn't been mined yet, and replace it with
+	// a cancellation tx instead, to free its nonce for newer txs. Zero uses the TxDeadline of the config.
+	Deadline time.Time
+	// OnPublish is called with every version of the tx right before it is published, including fee bumps,
+	// so the caller can find the tx on L1 after a restart. It is not called for cancellation txs. Optional.
+	OnPublish func(tx *types.Transaction)
 }
 
 // Send is used to publish a transaction with incrementally higher gas prices
@@ -253,7 +287,11 @@ func (m *SimpleTxManager) send(ctx context.Context, candidate TxCandidate) (*typ
 	if err != nil {
 		return nil, fmt.Errorf("failed to create the tx: %w", err)
 	}
-	return m.sendTx(ctx, tx)
+	deadline := candidate.Deadline
+	if deadline.IsZero() && m.cfg.TxDeadline != 0 {
+		deadline = time.Now().Add(m.cfg.TxDeadline)
+	}
+	return m.sendTxUntil(ctx, tx, deadline, candidate.OnPublish)
 }
 
 // craftTx creates the signed transaction
@@ -263,7 +301,11 @@ func (m *SimpleTxManager) send(ctx context.Context, candidate TxCandidate) (*typ
 // NOTE: Otherwise, the [SimpleTxManager] will query the specified backend for an estimate.
 func (m *SimpleTxManager) craftTx(ctx context.Context, candidate TxCandidate) (*types.Transaction, error) {
 	m.l.Debug("crafting Transaction", "blobs", len(candidate.Blobs), "calldata_size", len(candidate.TxData))
-	gasTipCap, baseFee, blobBaseFee, err := m.SuggestGasPriceCaps(ctx)
+	shape := TxShape{Blobs: len(candidate.Blobs)}
+	if shape.Blobs == 0 {
+		shape.CalldataSize = len(candidate.TxData)
+	}
+	gasTipCap, baseFee, blobBaseFee, err := m.suggestGasPriceCaps(ctx, shape)
 	if err != nil {
 		m.metr.RPCError()
 		return nil, fmt.Errorf("failed to get gas price info: %w", err)
@@ -305,7 +347,10 @@ func (m *SimpleTxManager) craftTx(ctx context.Context, candidate TxCandidate) (*
 		if blobBaseFee == nil {
 			return nil, fmt.Errorf("expected non-nil blobBaseFee")
 		}
-		blobFeeCap := m.calcBlobFeeCap(blobBaseFee)
+		blobFeeCap, err := m.capBlobFee(blobBaseFee, m.calcBlobFeeCap(blobBaseFee))
+		if err != nil {
+			return nil, err
+		}
 		message := &types.BlobTx{
 			To:         *candidate.To,
 			Data:       candidate.TxData,
@@ -358,6 +403,15 @@ func (m *SimpleTxManager) SetMinBlobFee(val *big.Int) {
 	m.l.Info("txmgr config val changed: SetMinBlobFee", "newVal", val)
 }
 
+func (m *SimpleTxManager) GetMaxBlobFee() *big.Int {
+	return m.cfg.MaxBlobFee.Load()
+}
+
+func (m *SimpleTxManager) SetMaxBlobFee(val *big.Int) {
+	m.cfg.MaxBlobFee.Store(val)
+	m.l.Info("txmgr config val changed: SetMaxBlobFee", "newVal", val)
+}
+
 func (m *SimpleTxManager) GetFeeLimitMultiplier() uint64 {
 	return m.cfg.FeeLimitMultiplier.Load()
 }
@@ -426,6 +480,15 @@ func (m *SimpleTxManager) signWithNextNonce(ctx context.Context, txMessage types
 			m.metr.RPCError()
 			return nil, fmt.Errorf("failed to get nonce: %w", err)
 		}
+		if err := m.blobTxs.prune(nonce); err != nil {
+			m.l.Warn("Failed to prune persisted blob transactions", "err", err)
+		}
+		// Blob txs that were still pending before a restart reserve their nonces, as they can only be replaced by
+		// other blob txs.
+		m.rebroadcastRestored(ctx, nonce)
+		if next := m.blobTxs.nextNonce(); next > nonce {
+			nonce = next
+		}
 		m.nonce = &nonce
 	} else {
 		*m.nonce++
@@ -452,6 +515,21 @@ func (m *SimpleTxManager) signWithNextNonce(ctx context.Context, txMessage types
 	return tx, err
 }
 
+// rebroadcastRestored publishes the pending blob transactions restored after a restart once,
+// in case they were dropped from the mempool in the meantime. Restored transactions that were mined are skipped.
+func (m *SimpleTxManager) rebroadcastRestored(ctx context.Context, next uint64) {
+	restored := m.restored
+	m.restored = nil
+	for _, tx := range restored {
+		if tx.Nonce() < next {
+			continue
+		}
+		if err := m.publishGapTx(ctx, tx); err != nil {
+			m.txLogger(tx, false).Warn("Failed to re-broadcast restored blob transaction", "err", err)
+		}
+	}
+}
+
 // resetNonce resets the internal nonce tracking. This is called if any pending send
 // returns an error.
 func (m *SimpleTxManager) resetNonce() {
@@ -463,6 +541,27 @@ func (m *SimpleTxManager) resetNonce() {
 // send submits the same transaction several times with increasing gas prices as necessary.
 // It waits for the transaction to be confirmed on chain.
 func (m *SimpleTxManager) sendTx(ctx context.Context, tx *types.Transaction) (*types.Receipt, error) {
+	return m.sendTxUntil(ctx, tx, time.Time{}, nil)
+}
+
+// sendTxUntil is like sendTx, but replaces the transaction with a cancellation transaction at the same nonce
+// if it hasn't been mined by the deadline, unless the deadline is zero. Once cancelled, the fees of the
+// cancellation transaction are bumped instead, and ErrTxCancelled is returned if it is mined.
+// The original transaction may still be mined before the cancellation, in which case its receipt is returned.
+// If onPublish is not nil, it is called with every version of the transaction before it is published,
+// but not with cancellation transactions.
+func (m *SimpleTxManager) sendTxUntil(ctx context.Context, tx *types.Transaction, deadline time.Time, onPublish func(*types.Transaction)) (_ *types.Receipt, err error) {
+	if !deadline.IsZero() && !time.Now().Before(deadline) {
+		return nil, fmt.Errorf("%w: deadline passed before the tx was sent", ErrTxCancelled)
+	}
+	owner := m.nonces.reserve(tx, time.Now())
+	defer func() {
+		// The nonce of an abandoned tx leaves a gap that blocks all later nonces until it is filled.
+		if err != nil {
+			m.nonces.abandon(tx.Nonce(), owner, time.Now())
+		}
+	}()
+
 	var wg sync.WaitGroup
 	defer wg.Wait()
 	ctx, cancel := context.WithCancel(ctx)
@@ -474,6 +573,35 @@ func (m *SimpleTxManager) sendTx(ctx context.Context, tx *types.Transaction) (*t
 	ticker := time.NewTicker(resubmissionTimeout)
 	defer ticker.Stop()
 
+	var deadlineC <-chan time.Time
+	if !deadline.IsZero() {
+		timer := time.NewTimer(time.Until(deadline))
+		defer timer.Stop()
+		deadlineC = timer.C
+	}
+	// cancelTxs are the hashes of the published cancellation txs. Nil until the deadline passed.
+	var cancelTxs map[common.Hash]bool
+	deadlinePassed := false
+	replaceWithCancellation := func() {
+		if !m.nonces.owns(tx.Nonce(), owner) {
+			// The nonce was reserved again by a later send, e.g. after the nonce was reset, so cancelling it would
+			// replace the tx of that send instead.
+			m.txLogger(tx, false).Warn("Not cancelling tx after its deadline, its nonce is used by another tx")
+			deadlinePassed = false
+			return
+		}
+		cancelTx, err := m.makeCancelTx(ctx, tx.Nonce(), tx)
+		if err != nil {
+			m.txLogger(tx, false).Warn("Failed to create cancellation tx after the deadline, will retry", "err", err)
+			return
+		}
+		m.txLogger(cancelTx, true).Info("Cancelling tx after its deadline", "cancelled_tx", tx.Hash())
+		tx = cancelTx
+		cancelTxs = make(map[common.Hash]bool)
+		// The cancellation tx already has bumped fees to replace the tx, so it can be published right away.
+		sendState.bumpFees = false
+	}
+
 	for {
 		if !sendState.IsWaitingForConfirmation() {
 			if m.closed.Load() {
@@ -481,8 +609,16 @@ func (m *SimpleTxManager) sendTx(ctx context.Context, tx *types.Transaction) (*t
 				m.txLogger(tx, false).Warn("TxManager closed, aborting transaction submission")
 				return nil, ErrClosed
 			}
+			notify := onPublish
+			if cancelTxs != nil {
+				notify = nil
+			}
 			var published bool
-			if tx, published = m.publishTx(ctx, tx, sendState); published {
+			if tx, published = m.publishTx(ctx, tx, sendState, notify); published {
+				if cancelTxs != nil {
+					cancelTxs[tx.Hash()] = true
+				}
+				m.nonces.track(tx, owner)
 				wg.Add(1)
 				go func() {
 					defer wg.Done()
@@ -497,13 +633,33 @@ func (m *SimpleTxManager) sendTx(ctx context.Context, tx *types.Transaction) (*t
 
 		select {
 		case <-ticker.C:
+			if deadlinePassed && cancelTxs == nil && !sendState.IsWaitingForConfirmation() {
+				replaceWithCancellation()
+			}
+			m.resolveNonceGaps(ctx)
+
+		case <-deadlineC:
+			deadlineC = nil
+			deadlinePassed = true
+			if !sendState.IsWaitingForConfirmation() {
+				replaceWithCancellation()
+			}
 
 		case <-ctx.Done():
 			return nil, ctx.Err()
 
 		case receipt := <-receiptChan:
+			if m.nonces.confirm(tx.Nonce()) == 0 {
+				m.metr.RecordStuckNonceDuration(0)
+			}
+			if err := m.blobTxs.prune(tx.Nonce() + 1); err != nil {
+				m.l.Warn("Failed to prune persisted blob transactions", "err", err)
+			}
 			m.metr.RecordGasBumpCount(sendState.bumpCount)
 			m.metr.TxConfirmed(receipt)
+			if cancelTxs[receipt.TxHash] {
+				return nil, fmt.Errorf("%w: nonce %d filled by cancellation tx %s", ErrTxCancelled, tx.Nonce(), receipt.TxHash)
+			}
 			return receipt, nil
 		}
 	}
@@ -512,7 +668,7 @@ func (m *SimpleTxManager) sendTx(ctx context.Context, tx *types.Transaction) (*t
 // publishTx publishes the transaction to the transaction pool. If it receives any underpriced errors
 // it will bump the fees and retry.
 // Returns the latest fee bumped tx, and a boolean indicating whether the tx was sent or not
-func (m *SimpleTxManager) publishTx(ctx context.Context, tx *types.Transaction, sendState *SendState) (*types.Transaction, bool) {
+func (m *SimpleTxManager) publishTx(ctx context.Context, tx *types.Transaction, sendState *SendState, onPublish func(*types.Transaction)) (*types.Transaction, bool) {
 	l := m.txLogger(tx, true)
 
 	l.Info("Publishing transaction", "tx", tx.Hash())
@@ -542,6 +698,13 @@ func (m *SimpleTxManager) publishTx(ctx context.Context, tx *types.Transaction,
 			}
 		}
 
+		// Persist blob txs before publishing them, so they can still be replaced after a restart.
+		if err := m.blobTxs.put(tx); err != nil {
+			l.Warn("Failed to persist blob transaction", "err", err)
+		}
+		if onPublish != nil {
+			onPublish(tx)
+		}
 		cCtx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
 		err := m.backend.SendTransaction(cCtx, tx)
 		cancel()
@@ -700,7 +863,7 @@ func (m *SimpleTxManager) queryReceipt(ctx context.Context, txHash common.Hash,
 // multiple of the suggested values.
 func (m *SimpleTxManager) increaseGasPrice(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
 	m.txLogger(tx, true).Info("bumping gas price for transaction")
-	tip, baseFee, blobBaseFee, err := m.SuggestGasPriceCaps(ctx)
+	tip, baseFee, blobBaseFee, err := m.suggestGasPriceCaps(ctx, shapeOf(tx))
 	if err != nil {
 		m.txLogger(tx, false).Warn("failed to get suggested gas tip and base fee", "err", err)
 		return nil, err
@@ -748,6 +911,7 @@ func (m *SimpleTxManager) increaseGasPrice(ctx context.Context, tx *types.Transa
 		if err := m.checkBlobFeeLimits(blobBaseFee, bumpedBlobFee); err != nil {
 			return nil, err
 		}
+		m.metr.RecordBlobFeeBump()
 		message := &types.BlobTx{
 			Nonce:      tx.Nonce(),
 			To:         *tx.To(),
@@ -786,16 +950,16 @@ func (m *SimpleTxManager) increaseGasPrice(ctx context.Context, tx *types.Transa
 // SuggestGasPriceCaps suggests what the new tip, base fee, and blob base fee should be based on
 // the current L1 conditions. blobfee will be nil if 4844 is not yet active.
 func (m *SimpleTxManager) SuggestGasPriceCaps(ctx context.Context) (*big.Int, *big.Int, *big.Int, error) {
-	cCtx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
-	defer cancel()
-	tip, err := m.backend.SuggestGasTipCap(cCtx)
+	return m.suggestGasPriceCaps(ctx, TxShape{})
+}
+
+// suggestGasPriceCaps suggests the fee caps of a transaction of the given shape.
+func (m *SimpleTxManager) suggestGasPriceCaps(ctx context.Context, shape TxShape) (*big.Int, *big.Int, *big.Int, error) {
+	tip, err := m.suggestGasTipCap(ctx, shape)
 	if err != nil {
-		m.metr.RPCError()
-		return nil, nil, nil, fmt.Errorf("failed to fetch the suggested gas tip cap: %w", err)
-	} else if tip == nil {
-		return nil, nil, nil, errors.New("the suggested tip was nil")
+		return nil, nil, nil, err
 	}
-	cCtx, cancel = context.WithTimeout(ctx, m.cfg.NetworkTimeout)
+	cCtx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
 	defer cancel()
 	head, err := m.backend.HeaderByNumber(cCtx, nil)
 	if err != nil {
@@ -830,6 +994,30 @@ func (m *SimpleTxManager) SuggestGasPriceCaps(ctx context.Context) (*big.Int, *b
 	return tip, baseFee, blobFee, nil
 }
 
+// suggestGasTipCap suggests the tip of a transaction of the given shape with the TipOracle if configured,
+// falling back to the tip suggested by the backend if the oracle fails.
+func (m *SimpleTxManager) suggestGasTipCap(ctx context.Context, shape TxShape) (*big.Int, error) {
+	if m.cfg.TipOracle != nil {
+		cCtx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
+		defer cancel()
+		tip, err := m.cfg.TipOracle.SuggestGasTipCap(cCtx, shape)
+		if err == nil {
+			return tip, nil
+		}
+		m.l.Warn("Failed to suggest tip with the tip oracle, using the suggestion of the backend", "err", err)
+	}
+	cCtx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
+	defer cancel()
+	tip, err := m.backend.SuggestGasTipCap(cCtx)
+	if err != nil {
+		m.metr.RPCError()
+		return nil, fmt.Errorf("failed to fetch the suggested gas tip cap: %w", err)
+	} else if tip == nil {
+		return nil, errors.New("the suggested tip was nil")
+	}
+	return tip, nil
+}
+
 // checkLimits checks that the tip and baseFee have not increased by more than the configured multipliers
 // if FeeLimitThreshold is specified in config, any increase which stays under the threshold are allowed
 func (m *SimpleTxManager) checkLimits(tip, baseFee, bumpedTip, bumpedFee *big.Int) (errs error) {
@@ -863,6 +1051,11 @@ func (m *SimpleTxManager) checkBlobFeeLimits(blobBaseFee, bumpedBlobFee *big.Int
 	feeLimitThreshold := m.cfg.FeeLimitThreshold.Load()
 	feeLimitMultiplier := m.cfg.FeeLimitMultiplier.Load()
 
+	// The max blob fee applies regardless of the threshold. The blob fee cannot be capped when bumping,
+	// since replacement blob txs must at least double the blob fee cap of the tx they replace.
+	if maxBlobFee := m.GetMaxBlobFee(); maxBlobFee != nil && maxBlobFee.Sign() > 0 && bumpedBlobFee.Cmp(maxBlobFee) > 0 {
+		return fmt.Errorf("bumped blob fee %v is over the max blob fee %v: %w", bumpedBlobFee, maxBlobFee, ErrBlobFeeLimit)
+	}
 	if feeLimitThreshold != nil && feeLimitThreshold.Cmp(bumpedBlobFee) == 1 {
 		return nil
 	}
@@ -948,6 +1141,19 @@ func (m *SimpleTxManager) calcBlobFeeCap(blobBaseFee *big.Int) *big.Int {
 	return cap
 }
 
+// capBlobFee caps the blob fee cap of a new blob tx at the configured max blob fee, if set.
+// It errors if the blob base fee is over the max blob fee, since the tx could not be included.
+func (m *SimpleTxManager) capBlobFee(blobBaseFee, blobFeeCap *big.Int) (*big.Int, error) {
+	maxBlobFee := m.GetMaxBlobFee()
+	if maxBlobFee == nil || maxBlobFee.Sign() == 0 || blobFeeCap.Cmp(maxBlobFee) <= 0 {
+		return blobFeeCap, nil
+	}
+	if blobBaseFee.Cmp(maxBlobFee) > 0 {
+		return nil, fmt.Errorf("blob base fee %v is over the max blob fee %v: %w", blobBaseFee, maxBlobFee, ErrBlobFeeLimit)
+	}
+	return new(big.Int).Set(maxBlobFee), nil
+}
+
 // errStringMatch returns true if err.Error() is a substring in target.Error() or if both are nil.
 // It can accept nil errors without issue.
 func errStringMatch(err, target error) bool {
@@ -213,6 +213,9 @@ type mockBackend struct {
 
 	// minedTxs maps the hash of a mined transaction to its details.
 	minedTxs map[common.Hash]minedTxInfo
+
+	// nonceAts counts the NonceAt calls.
+	nonceAts int
 }
 
 // newMockBackend initializes a new mockBackend.
@@ -297,9 +300,18 @@ func (b *mockBackend) SendTransaction(ctx context.Context, tx *types.Transaction
 }
 
 func (b *mockBackend) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.nonceAts++
 	return startingNonce, nil
 }
 
+func (b *mockBackend) nonceAtCalls() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.nonceAts
+}
+
 func (b *mockBackend) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
 	return startingNonce, nil
 }
@@ -397,6 +409,127 @@ func TestTxMgrNeverConfirmCancel(t *testing.T) {
 	require.Nil(t, receipt)
 }
 
+// TestTxMgrCancelsAtDeadline asserts that a tx that isn't mined by its deadline is replaced with a cancellation
+// tx at the same nonce, and that ErrTxCancelled is returned once the cancellation is mined.
+func TestTxMgrCancelsAtDeadline(t *testing.T) {
+	t.Parallel()
+
+	h := newTestHarness(t)
+
+	inbox := common.HexToAddress("0x42000000000000000000000000000000000000ff")
+	gasTipCap, gasFeeCap, _ := h.gasPricer.sample()
+	tx := types.NewTx(&types.DynamicFeeTx{
+		Nonce:     startingNonce,
+		To:        &inbox,
+		GasTipCap: gasTipCap,
+		GasFeeCap: gasFeeCap,
+	})
+	var cancelTx *types.Transaction
+	sendTx := func(ctx context.Context, tx *types.Transaction) error {
+		// Only mine the cancellation tx, simulating the original tx never being mined.
+		if *tx.To() == h.cfg.From {
+			cancelTx = tx
+			txHash := tx.Hash()
+			h.backend.mine(&txHash, tx.GasFeeCap(), nil)
+		}
+		return nil
+	}
+	h.backend.setTxSender(sendTx)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var mu sync.Mutex
+	var published []*types.Transaction
+	onPublish := func(tx *types.Transaction) {
+		mu.Lock()
+		defer mu.Unlock()
+		published = append(published, tx)
+	}
+	receipt, err := h.mgr.sendTxUntil(ctx, tx, time.Now().Add(100*time.Millisecond), onPublish)
+	require.ErrorIs(t, err, ErrTxCancelled)
+	require.Nil(t, receipt)
+	require.NotNil(t, cancelTx)
+	mu.Lock()
+	defer mu.Unlock()
+	require.NotEmpty(t, published)
+	for _, p := range published {
+		require.NotEqual(t, cancelTx.Hash(), p.Hash(), "cancellation txs must not be reported as published")
+		require.Equal(t, tx.Nonce(), p.Nonce())
+	}
+	require.Equal(t, tx.Nonce(), cancelTx.Nonce())
+	require.Empty(t, cancelTx.Data())
+	require.Greater(t, cancelTx.GasFeeCap().Cmp(tx.GasFeeCap()), 0, "cancellation must replace the tx")
+}
+
+// TestTxMgrNoCancelOfReusedNonce asserts that a tx is not cancelled at its deadline if its nonce has been reserved
+// by another send since, as the cancellation would replace the tx of that send.
+func TestTxMgrNoCancelOfReusedNonce(t *testing.T) {
+	t.Parallel()
+
+	h := newTestHarness(t)
+
+	inbox := common.HexToAddress("0x42000000000000000000000000000000000000ff")
+	gasTipCap, gasFeeCap, _ := h.gasPricer.sample()
+	tx := types.NewTx(&types.DynamicFeeTx{
+		Nonce:     startingNonce,
+		To:        &inbox,
+		GasTipCap: gasTipCap,
+		GasFeeCap: gasFeeCap,
+	})
+	deadline := time.Now().Add(100 * time.Millisecond)
+	var mu sync.Mutex
+	var cancelled bool
+	sendTx := func(ctx context.Context, sent *types.Transaction) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if *sent.To() == h.cfg.From {
+			cancelled = true
+		}
+		if sent.Hash() == tx.Hash() {
+			// Another send reserves the same nonce, e.g. after the nonce was reset.
+			h.mgr.nonces.reserve(types.NewTx(&types.DynamicFeeTx{Nonce: startingNonce}), time.Now())
+		}
+		if time.Now().After(deadline.Add(100 * time.Millisecond)) {
+			txHash := sent.Hash()
+			h.backend.mine(&txHash, sent.GasFeeCap(), nil)
+		}
+		return nil
+	}
+	h.backend.setTxSender(sendTx)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	receipt, err := h.mgr.sendTxUntil(ctx, tx, deadline, nil)
+	require.NoError(t, err)
+	require.NotNil(t, receipt)
+	mu.Lock()
+	defer mu.Unlock()
+	require.False(t, cancelled, "must not cancel a nonce used by another send")
+}
+
+// TestTxMgrDeadlinePassedBeforeSend asserts that a tx is not published if its deadline already passed.
+func TestTxMgrDeadlinePassedBeforeSend(t *testing.T) {
+	t.Parallel()
+
+	h := newTestHarness(t)
+
+	gasTipCap, gasFeeCap, _ := h.gasPricer.sample()
+	tx := types.NewTx(&types.DynamicFeeTx{
+		GasTipCap: gasTipCap,
+		GasFeeCap: gasFeeCap,
+	})
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		t.Fatal("tx must not be published")
+		return nil
+	})
+
+	receipt, err := h.mgr.sendTxUntil(context.Background(), tx, time.Now().Add(-time.Second), nil)
+	require.ErrorIs(t, err, ErrTxCancelled)
+	require.Nil(t, receipt)
+}
+
 // TestTxMgrTxSendTimeout tests that the TxSendTimeout is respected when trying to send a
 // transaction, even if NetworkTimeout expires first.
 func TestTxMgrTxSendTimeout(t *testing.T) {
@@ -1303,6 +1436,67 @@ func testIncreaseGasPriceLimit(t *testing.T, lt gasPriceLimitTest) {
 	require.Equal(t, lt.expBlobFeeCap, lastGoodTx.BlobGasFeeCap().Int64())
 }
 
+func TestMaxBlobFee(t *testing.T) {
+	// simulate 100 excess blobs which yields a 50 wei blob base fee
+	excessBlobGas := uint64(100 * params.BlobTxBlobGasPerBlob)
+	backend := failingBackend{
+		gasTip:              big.NewInt(10),
+		baseFee:             big.NewInt(45),
+		excessBlobGas:       &excessBlobGas,
+		returnSuccessHeader: true,
+	}
+	cfg := Config{
+		Signer: func(ctx context.Context, from common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			return tx, nil
+		},
+	}
+	cfg.FeeLimitMultiplier.Store(5)
+	// A threshold above all fees, so only the max blob fee limits the blob fee.
+	cfg.FeeLimitThreshold.Store(big.NewInt(params.Ether))
+	cfg.MinBlobTxFee.Store(big.NewInt(1))
+	mgr := &SimpleTxManager{
+		cfg:     &cfg,
+		name:    "TEST",
+		backend: &backend,
+		l:       testlog.Logger(t, log.LevelCrit),
+		metr:    &metrics.NoopTxMetrics{},
+	}
+	blobBaseFee := big.NewInt(50)
+
+	t.Run("Disabled", func(t *testing.T) {
+		cap, err := mgr.capBlobFee(blobBaseFee, big.NewInt(100))
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(100), cap)
+	})
+
+	t.Run("CapsNewBlobTxs", func(t *testing.T) {
+		mgr.SetMaxBlobFee(big.NewInt(80))
+		cap, err := mgr.capBlobFee(blobBaseFee, big.NewInt(100))
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(80), cap)
+	})
+
+	t.Run("BlobBaseFeeOverMax", func(t *testing.T) {
+		mgr.SetMaxBlobFee(big.NewInt(40))
+		_, err := mgr.capBlobFee(blobBaseFee, big.NewInt(100))
+		require.ErrorIs(t, err, ErrBlobFeeLimit)
+	})
+
+	t.Run("LimitsBumps", func(t *testing.T) {
+		mgr.SetMaxBlobFee(big.NewInt(250))
+		blobTx := &types.BlobTx{}
+		blobTx.GasTipCap = uint256.NewInt(10)
+		blobTx.GasFeeCap = uint256.NewInt(100)
+		blobTx.BlobFeeCap = uint256.NewInt(100)
+		tx, err := mgr.increaseGasPrice(context.Background(), types.NewTx(blobTx))
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(200), tx.BlobGasFeeCap())
+
+		_, err = mgr.increaseGasPrice(context.Background(), tx)
+		require.ErrorIs(t, err, ErrBlobFeeLimit)
+	})
+}
+
 func TestErrStringMatch(t *testing.T) {
 	tests := []struct {
 		err    error
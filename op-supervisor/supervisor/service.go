@@ -100,6 +100,7 @@ func (su *SupervisorService) initPProf(cfg *config.Config) error {
 		cfg.PprofConfig.ProfileType,
 		cfg.PprofConfig.ProfileDir,
 		cfg.PprofConfig.ProfileFilename,
+		cfg.PprofConfig.Upload,
 	)
 
 	if err := su.pprofService.Start(); err != nil {
@@ -129,11 +130,16 @@ func (su *SupervisorService) initMetricsServer(cfg *config.Config) error {
 }
 
 func (su *SupervisorService) initRPCServer(cfg *config.Config) error {
+	limits, err := cfg.RPC.Limits()
+	if err != nil {
+		return fmt.Errorf("invalid RPC limits: %w", err)
+	}
 	server := oprpc.NewServer(
 		cfg.RPC.ListenAddr,
 		cfg.RPC.ListenPort,
 		cfg.Version,
 		oprpc.WithLogger(su.log),
+		oprpc.WithLimits(limits, su.metrics),
 		//oprpc.WithHTTPRecorder(su.metrics), // TODO(protocol-quest#286) hook up metrics to RPC server
 	)
 	if cfg.RPC.EnableAdmin {
@@ -28,6 +28,12 @@ var systemConfig []byte
 //go:embed abi/CrossL2Inbox.json
 var crossL2Inbox []byte
 
+//go:embed abi/SuperchainConfig.json
+var superchainConfig []byte
+
+//go:embed abi/OptimismPortal2.json
+var optimismPortal2 []byte
+
 func LoadDisputeGameFactoryABI() *abi.ABI {
 	return loadABI(disputeGameFactory)
 }
@@ -52,6 +58,14 @@ func LoadCrossL2InboxABI() *abi.ABI {
 	return loadABI(crossL2Inbox)
 }
 
+func LoadSuperchainConfigABI() *abi.ABI {
+	return loadABI(superchainConfig)
+}
+
+func LoadOptimismPortal2ABI() *abi.ABI {
+	return loadABI(optimismPortal2)
+}
+
 func loadABI(json []byte) *abi.ABI {
 	if parsed, err := abi.JSON(bytes.NewReader(json)); err != nil {
 		panic(err)